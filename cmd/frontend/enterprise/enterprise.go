@@ -13,6 +13,10 @@ type Services struct {
 	GitHubWebhook                    http.Handler
 	GitLabWebhook                    http.Handler
 	BitbucketServerWebhook           http.Handler
+	BitbucketCloudWebhook            http.Handler
+	CampaignChangesetsExport         http.Handler
+	CampaignPatch                    http.Handler
+	CampaignsREST                    http.Handler
 	NewCodeIntelUploadHandler        NewCodeIntelUploadHandler
 	NewCodeIntelInternalProxyHandler NewCodeIntelInternalProxyHandler
 	AuthzResolver                    graphqlbackend.AuthzResolver
@@ -34,6 +38,10 @@ func DefaultServices() Services {
 		GitHubWebhook:                    makeNotFoundHandler("github webhook"),
 		GitLabWebhook:                    makeNotFoundHandler("gitlab webhook"),
 		BitbucketServerWebhook:           makeNotFoundHandler("bitbucket server webhook"),
+		BitbucketCloudWebhook:            makeNotFoundHandler("bitbucket cloud webhook"),
+		CampaignChangesetsExport:         makeNotFoundHandler("campaign changesets export"),
+		CampaignPatch:                    makeNotFoundHandler("campaign patch"),
+		CampaignsREST:                    makeNotFoundHandler("campaigns REST API"),
 		NewCodeIntelUploadHandler:        func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		NewCodeIntelInternalProxyHandler: func() http.Handler { return makeNotFoundHandler("code intel internal proxy") },
 		AuthzResolver:                    graphqlbackend.DefaultAuthzResolver,
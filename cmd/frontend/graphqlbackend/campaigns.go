@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/externallink"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 )
 
 type CreateCampaignArgs struct {
@@ -15,8 +16,9 @@ type CreateCampaignArgs struct {
 }
 
 type ApplyCampaignArgs struct {
-	CampaignSpec   graphql.ID
-	EnsureCampaign *graphql.ID
+	CampaignSpec                graphql.ID
+	EnsureCampaign              *graphql.ID
+	ArchiveSupersededChangesets bool
 }
 
 type MoveCampaignArgs struct {
@@ -27,10 +29,22 @@ type MoveCampaignArgs struct {
 
 type ListCampaignArgs struct {
 	First               *int32
+	After               *string
 	State               *string
 	ViewerCanAdminister *bool
 
 	Namespace *graphql.ID
+	Query     *string
+
+	NamespaceType           *string
+	ViewerIsNamespaceMember *bool
+
+	Deleted bool
+
+	CreatedAfter  *DateTime
+	CreatedBefore *DateTime
+	ClosedAfter   *DateTime
+	ClosedBefore  *DateTime
 }
 
 type CloseCampaignArgs struct {
@@ -38,14 +52,130 @@ type CloseCampaignArgs struct {
 	CloseChangesets bool
 }
 
+type SetCampaignAutoMergeArgs struct {
+	Campaign graphql.ID
+	Enabled  bool
+}
+
+type SetCampaignReapplyScheduleArgs struct {
+	Campaign graphql.ID
+	Schedule *string
+}
+
+type SetCampaignVisibilityArgs struct {
+	Campaign   graphql.ID
+	Visibility string
+}
+
+type SetCampaignUpdatePropagationArgs struct {
+	Campaign          graphql.ID
+	UpdatePropagation string
+}
+
+type CreateCampaignCommentArgs struct {
+	Campaign graphql.ID
+	Body     string
+}
+
+type SetCampaignNotificationSettingsArgs struct {
+	Campaign    graphql.ID
+	Events      []string
+	Subscribers []graphql.ID
+}
+
+type ImportChangesetsArgs struct {
+	Campaign     graphql.ID
+	ExternalURLs []string
+}
+
+type TriggerCampaignArgs struct {
+	Campaign graphql.ID
+	Action   string
+	Source   string
+	Message  *string
+}
+
+type CheckChangesetURLsArgs struct {
+	Campaign graphql.ID
+}
+
+type DetachChangesetsArgs struct {
+	Campaign   graphql.ID
+	Changesets []graphql.ID
+}
+
+type CreateCampaignsCredentialArgs struct {
+	User                *graphql.ID
+	ExternalServiceKind string
+	ExternalServiceURL  string
+	Username            *string
+	Credential          string
+}
+
+type DeleteCampaignsCredentialArgs struct {
+	CampaignsCredential graphql.ID
+}
+
+type ListCampaignsCredentialsArgs struct {
+	User *graphql.ID
+}
+
+type SetCampaignAutoRebaseArgs struct {
+	Campaign graphql.ID
+	Enabled  bool
+}
+
+type PauseCampaignArgs struct {
+	Campaign graphql.ID
+}
+
+type ResumeCampaignArgs struct {
+	Campaign graphql.ID
+}
+
 type DeleteCampaignArgs struct {
 	Campaign graphql.ID
 }
 
+type RecordCampaignViewArgs struct {
+	Campaign graphql.ID
+}
+
+type RestoreCampaignArgs struct {
+	Campaign graphql.ID
+}
+
+type GrantCampaignPermissionArgs struct {
+	Campaign graphql.ID
+	Grantee  graphql.ID
+	Level    string
+}
+
+type RevokeCampaignPermissionArgs struct {
+	Grant graphql.ID
+}
+
 type SyncChangesetArgs struct {
 	Changeset graphql.ID
 }
 
+type RebaseChangesetArgs struct {
+	Changeset graphql.ID
+}
+
+type SetChangesetCustomMetadataArgs struct {
+	Changeset graphql.ID
+	Key       string
+	Value     *string
+}
+
+type CreateChangesetBulkOperationArgs struct {
+	Campaign   graphql.ID
+	Changesets []graphql.ID
+	Type       string
+	Message    *string
+}
+
 type CreateChangesetSpecArgs struct {
 	ChangesetSpec string
 }
@@ -57,6 +187,88 @@ type CreateCampaignSpecArgs struct {
 	ChangesetSpecs []graphql.ID
 }
 
+type SetCampaignSpecPinnedArgs struct {
+	CampaignSpec graphql.ID
+	Pinned       bool
+}
+
+type ValidateCampaignSpecArgs struct {
+	Namespace *graphql.ID
+	Spec      string
+}
+
+type LintCampaignSpecArgs struct {
+	Namespace      *graphql.ID
+	Spec           string
+	ChangesetSpecs *[]graphql.ID
+}
+
+type PreviewCampaignSpecRepositoriesArgs struct {
+	Spec string
+}
+
+type ExecuteCampaignSpecArgs struct {
+	Namespace    graphql.ID
+	CampaignSpec string
+}
+
+type CreateCampaignTemplateArgs struct {
+	Namespace graphql.ID
+
+	Name        string
+	Description *string
+	Template    string
+	Parameters  *[]CampaignTemplateParameterInput
+}
+
+type CampaignTemplateParameterInput struct {
+	Name        string
+	Description *string
+	Default     *string
+}
+
+type DeleteCampaignTemplateArgs struct {
+	CampaignTemplate graphql.ID
+}
+
+type CreateCampaignSpecFromTemplateArgs struct {
+	CampaignTemplate graphql.ID
+	Namespace        graphql.ID
+
+	Parameters     *[]CampaignTemplateParameterValueInput
+	ChangesetSpecs *[]graphql.ID
+}
+
+type CampaignTemplateParameterValueInput struct {
+	Name  string
+	Value string
+}
+
+type ListCampaignTemplatesArgs struct {
+	First *int32
+	After *string
+
+	Namespace *graphql.ID
+}
+
+type CampaignsStatisticsArgs struct {
+	Weeks     int32
+	CodeHosts int32
+}
+
+type CampaignNamespaceQuotaArgs struct {
+	Namespace graphql.ID
+}
+
+type BulkOperationArgs struct {
+	ID graphql.ID
+}
+
+type CampaignArgs struct {
+	Namespace graphql.ID
+	Name      string
+}
+
 type ChangesetSpecsConnectionArgs struct {
 	First *int32
 	After *string
@@ -68,18 +280,168 @@ type CampaignsResolver interface {
 	ApplyCampaign(ctx context.Context, args *ApplyCampaignArgs) (CampaignResolver, error)
 	MoveCampaign(ctx context.Context, args *MoveCampaignArgs) (CampaignResolver, error)
 	CloseCampaign(ctx context.Context, args *CloseCampaignArgs) (CampaignResolver, error)
+	SetCampaignAutoMerge(ctx context.Context, args *SetCampaignAutoMergeArgs) (CampaignResolver, error)
+	SetCampaignAutoRebase(ctx context.Context, args *SetCampaignAutoRebaseArgs) (CampaignResolver, error)
+	PauseCampaign(ctx context.Context, args *PauseCampaignArgs) (CampaignResolver, error)
+	ResumeCampaign(ctx context.Context, args *ResumeCampaignArgs) (CampaignResolver, error)
+	SetCampaignReapplySchedule(ctx context.Context, args *SetCampaignReapplyScheduleArgs) (CampaignResolver, error)
+	SetCampaignVisibility(ctx context.Context, args *SetCampaignVisibilityArgs) (CampaignResolver, error)
+	SetCampaignUpdatePropagation(ctx context.Context, args *SetCampaignUpdatePropagationArgs) (CampaignResolver, error)
+	SetCampaignNotificationSettings(ctx context.Context, args *SetCampaignNotificationSettingsArgs) (CampaignResolver, error)
+	ImportChangesets(ctx context.Context, args *ImportChangesetsArgs) ([]ChangesetResolver, error)
+	DetachChangesets(ctx context.Context, args *DetachChangesetsArgs) (CampaignResolver, error)
+	CheckChangesetURLs(ctx context.Context, args *CheckChangesetURLsArgs) (CampaignResolver, error)
+	TriggerCampaign(ctx context.Context, args *TriggerCampaignArgs) (*EmptyResponse, error)
+	CreateCampaignsCredential(ctx context.Context, args *CreateCampaignsCredentialArgs) (CampaignsCredentialResolver, error)
+	DeleteCampaignsCredential(ctx context.Context, args *DeleteCampaignsCredentialArgs) (*EmptyResponse, error)
+	CreateCampaignComment(ctx context.Context, args *CreateCampaignCommentArgs) (CampaignCommentResolver, error)
 	DeleteCampaign(ctx context.Context, args *DeleteCampaignArgs) (*EmptyResponse, error)
+	RecordCampaignView(ctx context.Context, args *RecordCampaignViewArgs) (*EmptyResponse, error)
+	RestoreCampaign(ctx context.Context, args *RestoreCampaignArgs) (CampaignResolver, error)
+	GrantCampaignPermission(ctx context.Context, args *GrantCampaignPermissionArgs) (CampaignPermissionGrantResolver, error)
+	RevokeCampaignPermission(ctx context.Context, args *RevokeCampaignPermissionArgs) (*EmptyResponse, error)
 	CreateChangesetSpec(ctx context.Context, args *CreateChangesetSpecArgs) (ChangesetSpecResolver, error)
 	CreateCampaignSpec(ctx context.Context, args *CreateCampaignSpecArgs) (CampaignSpecResolver, error)
-	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
+	SetCampaignSpecPinned(ctx context.Context, args *SetCampaignSpecPinnedArgs) (CampaignSpecResolver, error)
+	ValidateCampaignSpec(ctx context.Context, args *ValidateCampaignSpecArgs) (CampaignSpecValidationResolver, error)
+	LintCampaignSpec(ctx context.Context, args *LintCampaignSpecArgs) (CampaignSpecLintResolver, error)
+	PreviewCampaignSpecRepositories(ctx context.Context, args *PreviewCampaignSpecRepositoriesArgs) (CampaignSpecRepositoryPreviewResolver, error)
+	ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (CampaignSpecExecutionResolver, error)
+	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (ChangesetResolver, error)
+	RebaseChangeset(ctx context.Context, args *RebaseChangesetArgs) (ChangesetResolver, error)
+	SetChangesetCustomMetadata(ctx context.Context, args *SetChangesetCustomMetadataArgs) (ChangesetResolver, error)
+	CreateChangesetBulkOperation(ctx context.Context, args *CreateChangesetBulkOperationArgs) (BulkOperationResolver, error)
+	CreateCampaignTemplate(ctx context.Context, args *CreateCampaignTemplateArgs) (CampaignTemplateResolver, error)
+	DeleteCampaignTemplate(ctx context.Context, args *DeleteCampaignTemplateArgs) (*EmptyResponse, error)
+	CreateCampaignSpecFromTemplate(ctx context.Context, args *CreateCampaignSpecFromTemplateArgs) (CampaignSpecResolver, error)
 
 	// Queries
 	Campaigns(ctx context.Context, args *ListCampaignArgs) (CampaignsConnectionResolver, error)
 	CampaignByID(ctx context.Context, id graphql.ID) (CampaignResolver, error)
+	Campaign(ctx context.Context, args *CampaignArgs) (CampaignResolver, error)
 	ChangesetByID(ctx context.Context, id graphql.ID) (ChangesetResolver, error)
 
 	CampaignSpecByID(ctx context.Context, id graphql.ID) (CampaignSpecResolver, error)
 	ChangesetSpecByID(ctx context.Context, id graphql.ID) (ChangesetSpecResolver, error)
+	CampaignSpecExecutionByID(ctx context.Context, id graphql.ID) (CampaignSpecExecutionResolver, error)
+	BulkOperationByID(ctx context.Context, id graphql.ID) (BulkOperationResolver, error)
+
+	CampaignsAdvisoryLocks(ctx context.Context) ([]CampaignsAdvisoryLockResolver, error)
+	CampaignsPublicationBudgets(ctx context.Context) ([]CampaignsPublicationBudgetResolver, error)
+	ChangesetRetryPolicies(ctx context.Context) ([]ChangesetRetryPolicyResolver, error)
+	CampaignsStatistics(ctx context.Context, args *CampaignsStatisticsArgs) (CampaignsStatisticsResolver, error)
+	CampaignSpecExpiration(ctx context.Context) (CampaignSpecExpirationResolver, error)
+	CampaignNamespaceQuota(ctx context.Context, args *CampaignNamespaceQuotaArgs) (CampaignNamespaceQuotaResolver, error)
+	BulkOperation(ctx context.Context, args *BulkOperationArgs) (BulkOperationResolver, error)
+
+	CampaignTemplates(ctx context.Context, args *ListCampaignTemplatesArgs) (CampaignTemplateConnectionResolver, error)
+	CampaignTemplateByID(ctx context.Context, id graphql.ID) (CampaignTemplateResolver, error)
+
+	CampaignsCredentials(ctx context.Context, args *ListCampaignsCredentialsArgs) ([]CampaignsCredentialResolver, error)
+}
+
+type CampaignsCredentialResolver interface {
+	ID() graphql.ID
+	ExternalServiceKind() string
+	ExternalServiceURL() string
+	Username() *string
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
+
+type CampaignPermissionGrantResolver interface {
+	ID() graphql.ID
+	Grantee(ctx context.Context) (*NamespaceResolver, error)
+	Level() string
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
+
+type CampaignTemplateResolver interface {
+	ID() graphql.ID
+	Name() string
+	Description() string
+	Template() string
+	Parameters() []CampaignTemplateParameterResolver
+	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+	ViewerCanAdminister(ctx context.Context) (bool, error)
+}
+
+type CampaignTemplateParameterResolver interface {
+	Name() string
+	Description() string
+	Default() *string
+	Required() bool
+}
+
+type CampaignTemplateConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CampaignTemplateResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type CampaignsAdvisoryLockResolver interface {
+	Kind() string
+	Name() *string
+	Holder() string
+	Pid() int32
+	ConnectedAt() DateTime
+}
+
+type CampaignsPublicationBudgetResolver interface {
+	CodeHostURL() string
+	DisplayName() string
+	RequestsPerHour() *float64
+	RemainingRequests() *int32
+}
+
+type CampaignNamespaceQuotaResolver interface {
+	Enabled() bool
+	MaxChangesetsPerCampaign() *int32
+	MaxOpenCampaigns() *int32
+	OpenCampaigns() int32
+}
+
+type CampaignsStatisticsResolver interface {
+	TotalCampaigns() int32
+	OpenCampaigns() int32
+	Weeks() []CampaignsWeekStatisticsResolver
+	TopCodeHosts() []CampaignsCodeHostStatisticsResolver
+	ActiveAuthors() int32
+}
+
+type CampaignsWeekStatisticsResolver interface {
+	StartsAt() DateTime
+	ChangesetsPublished() int32
+	ChangesetsMerged() int32
+}
+
+type CampaignsCodeHostStatisticsResolver interface {
+	ExternalServiceKind() string
+	ExternalServiceURL() string
+	Changesets() int32
+	MergedChangesets() int32
+}
+
+type CampaignSpecExpirationResolver interface {
+	CampaignSpecHours() int32
+	ChangesetSpecHours() int32
+	IntervalMinutes() int32
+	DryRun() bool
+	LastRun(ctx context.Context) (CampaignSpecExpirationRunResolver, error)
+}
+
+type CampaignSpecExpirationRunResolver interface {
+	StartedAt() DateTime
+	FinishedAt() DateTime
+	DryRun() bool
+	ChangesetSpecsDeleted() int32
+	CampaignSpecsDeleted() int32
+	CampaignsDeleted() int32
+	Error() *string
 }
 
 type CampaignSpecResolver interface {
@@ -96,6 +458,7 @@ type CampaignSpecResolver interface {
 	Namespace(context.Context) (*NamespaceResolver, error)
 
 	ExpiresAt() *DateTime
+	PinnedAt() *DateTime
 
 	ApplyURL(ctx context.Context) (string, error)
 
@@ -104,6 +467,16 @@ type CampaignSpecResolver interface {
 	DiffStat(ctx context.Context) (*DiffStat, error)
 
 	AppliesToCampaign(ctx context.Context) (CampaignResolver, error)
+	DiffWithAppliedSpec(ctx context.Context) (CampaignSpecDiffResolver, error)
+}
+
+type CampaignSpecDiffResolver interface {
+	Campaign() CampaignResolver
+	Added() []VisibleChangesetSpecResolver
+	Updated() []ChangesetSpecDeltaResolver
+	Unchanged() []VisibleChangesetSpecResolver
+	Closed() []ExternalChangesetResolver
+	Detached() []ExternalChangesetResolver
 }
 
 type CampaignDescriptionResolver interface {
@@ -122,7 +495,7 @@ type ChangesetSpecResolver interface {
 
 	Type() campaigns.ChangesetSpecDescriptionType
 
-	ExpiresAt() *DateTime
+	ExpiresAt(ctx context.Context) (*DateTime, error)
 
 	ToHiddenChangesetSpec() (HiddenChangesetSpecResolver, bool)
 	ToVisibleChangesetSpec() (VisibleChangesetSpecResolver, bool)
@@ -136,6 +509,22 @@ type VisibleChangesetSpecResolver interface {
 	ChangesetSpecResolver
 
 	Description(ctx context.Context) (ChangesetDescription, error)
+	Delta(ctx context.Context) (ChangesetSpecDeltaResolver, error)
+}
+
+type ChangesetSpecDeltaResolver interface {
+	Changeset() ExternalChangesetResolver
+	ChangesetSpec() VisibleChangesetSpecResolver
+	TitleChanged() bool
+	BodyChanged() bool
+	BaseRefChanged() bool
+	DiffChanged() bool
+	CommitMessageChanged() bool
+	ReviewersChanged() bool
+	AssigneesChanged() bool
+	LabelsChanged() bool
+	MilestoneChanged() bool
+	DiffOfDiffs() (*string, error)
 }
 
 type ChangesetDescription interface {
@@ -164,6 +553,11 @@ type GitBranchChangesetDescriptionResolver interface {
 	Commits() []GitCommitDescriptionResolver
 
 	Published() bool
+
+	Reviewers() []string
+	Assignees() []string
+	Labels() []string
+	Milestone() *string
 }
 
 type GitCommitDescriptionResolver interface {
@@ -172,23 +566,41 @@ type GitCommitDescriptionResolver interface {
 }
 
 type ChangesetCountsArgs struct {
-	From *DateTime
-	To   *DateTime
+	From       *DateTime
+	To         *DateTime
+	GroupBy    *string
+	GroupLimit int32
 }
 
 type ListChangesetsArgs struct {
 	First            *int32
 	PublicationState *campaigns.ChangesetPublicationState
 	ReconcilerState  *campaigns.ReconcilerState
+	OnlyFailed       *bool
 	ExternalState    *campaigns.ChangesetExternalState
 	ReviewState      *campaigns.ChangesetReviewState
 	CheckState       *campaigns.ChangesetCheckState
+	CustomMetadata   *[]ChangesetCustomMetadataInput
+	UpdatedAfter     *DateTime
+	Archived         *bool
+}
+
+type ChangesetEventsConnectionArgs struct {
+	First *int32
+	After *string
+	Kinds *[]campaigns.ChangesetEventCategory
+}
+
+type ChangesetCustomMetadataInput struct {
+	Key   string
+	Value string
 }
 
 type CampaignResolver interface {
 	ID() graphql.ID
 	Name() string
 	Description() *string
+	DescriptionHTML() *string
 	InitialApplier(ctx context.Context) (*UserResolver, error)
 	LastApplier(ctx context.Context) (*UserResolver, error)
 	LastAppliedAt() DateTime
@@ -198,10 +610,223 @@ type CampaignResolver interface {
 	Namespace(ctx context.Context) (n NamespaceResolver, err error)
 	CreatedAt() DateTime
 	UpdatedAt() DateTime
+	ChangesetsUpdatedAt(ctx context.Context) (*DateTime, error)
 	Changesets(ctx context.Context, args *ListChangesetsArgs) (ChangesetsConnectionResolver, error)
+	ChangesetsByRepository(ctx context.Context, args *ChangesetRepositoryGroupsArgs) (ChangesetRepositoryGroupConnectionResolver, error)
+	SyncStatus(ctx context.Context) (CampaignSyncStatusResolver, error)
 	ChangesetCountsOverTime(ctx context.Context, args *ChangesetCountsArgs) ([]ChangesetCountsResolver, error)
 	ClosedAt() *DateTime
+	PausedAt() *DateTime
+	DeletedAt() *DateTime
+	PermissionGrants(ctx context.Context) ([]CampaignPermissionGrantResolver, error)
+	AutoMerge() bool
+	AutoRebase() bool
+	ReapplySchedule(ctx context.Context) (CampaignReapplyScheduleResolver, error)
+	Visibility() string
+	UpdatePropagation() string
+	NotificationSettings(ctx context.Context) (CampaignNotificationSettingsResolver, error)
+	DiffStat(ctx context.Context) (*DiffStat, error)
+	Analytics(ctx context.Context) (CampaignAnalyticsResolver, error)
+	Progress(ctx context.Context) (CampaignProgressResolver, error)
+	Stats(ctx context.Context) (CampaignStatsResolver, error)
+	Activity(ctx context.Context, args *CampaignActivityArgs) (CampaignActivitiesConnectionResolver, error)
+	Comments(ctx context.Context, args *struct{ graphqlutil.ConnectionArgs }) (CampaignCommentsConnectionResolver, error)
+	ChangesetsExportURL(args *ChangesetsExportURLArgs) string
+	PatchURL(args *CampaignPatchURLArgs) string
+}
+
+type CampaignActivityArgs struct {
+	First *int32
+	After *string
+}
+
+type ChangesetRepositoryGroupsArgs struct {
+	First *int32
+	After *string
+}
+
+type ChangesetRepositoryGroupConnectionResolver interface {
+	Nodes(ctx context.Context) ([]ChangesetRepositoryGroupResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type ChangesetRepositoryGroupResolver interface {
+	Repository() *RepositoryResolver
+	Total() int32
+	Unpublished() int32
+	Open() int32
+	Merged() int32
+	Closed() int32
+	DiffStat() *DiffStat
+}
+
+type CampaignSyncStatusResolver interface {
+	Changesets() []ChangesetSyncStatusResolver
+	OldestSyncAt() *DateTime
+	OverdueCount() int32
+	ErroredCount() int32
+}
+
+type ChangesetSyncStatusResolver interface {
+	Changeset() ChangesetResolver
+	LastSyncedAt() DateTime
+	NextSyncAt() DateTime
+	Overdue() bool
+	SyncError() *string
+}
+
+type ChangesetsExportURLArgs struct {
+	Format string
+}
+
+type CampaignPatchURLArgs struct {
+	Format string
+}
+
+type CampaignAnalyticsResolver interface {
+	MedianSecondsToFirstReview() *int32
+	MedianSecondsToMerge() *int32
+	MergedByWeek() []CampaignWeeklyMergeStatsResolver
+}
+
+type CampaignProgressResolver interface {
+	Total() int32
+	Published() int32
+	Merged() int32
+	PendingPublication() int32
+	PublishedPercentage() float64
+	MergedPercentage() float64
+	EstimatedPublicationCompletedAt() *DateTime
+}
+
+type CampaignStatsResolver interface {
+	Total() int32
+	Unpublished() int32
+	Open() int32
+	Merged() int32
+	Closed() int32
+	Archived() int32
 	DiffStat(ctx context.Context) (*DiffStat, error)
+	LastSyncedAt() *DateTime
+}
+
+type CampaignReapplyScheduleResolver interface {
+	Schedule() string
+	NextRunAt() DateTime
+	LastRunAt() *DateTime
+}
+
+type CampaignSpecValidationResolver interface {
+	Valid() bool
+	Errors() []CampaignSpecValidationErrorResolver
+}
+
+type CampaignSpecValidationErrorResolver interface {
+	Message() string
+	Line() *int32
+	Column() *int32
+}
+
+type CampaignSpecLintResolver interface {
+	Passed() bool
+	Problems() []CampaignSpecLintProblemResolver
+}
+
+type CampaignSpecLintProblemResolver interface {
+	Rule() string
+	Severity() string
+	Message() string
+	Line() *int32
+	Column() *int32
+}
+
+type CampaignSpecRepositoryPreviewResolver interface {
+	TotalCount() int32
+	SearchResultCount() int32
+	Nodes() []CampaignSpecPreviewedRepositoryResolver
+}
+
+type CampaignSpecPreviewedRepositoryResolver interface {
+	Repository() *RepositoryResolver
+	Branch() string
+	Commit() *string
+	FailureMessage() *string
+	SearchResultCount() int32
+}
+
+type CampaignSpecExecutionResolver interface {
+	ID() graphql.ID
+	State() string
+	FailureMessage() *string
+	RepositoriesTotal() int32
+	RepositoriesCompleted() int32
+	CampaignSpec(ctx context.Context) (CampaignSpecResolver, error)
+	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	StartedAt() *DateTime
+	FinishedAt() *DateTime
+}
+
+type BulkOperationResolver interface {
+	ID() graphql.ID
+	Type() string
+	State() string
+	Progress() float64
+	Campaign(ctx context.Context) (CampaignResolver, error)
+	Initiator(ctx context.Context) (*UserResolver, error)
+	ChangesetCount() int32
+	Items(ctx context.Context) ([]BulkOperationItemResolver, error)
+	CreatedAt() DateTime
+	FinishedAt() *DateTime
+}
+
+type BulkOperationItemResolver interface {
+	Changeset() ChangesetResolver
+	State() string
+	FailureMessage() *string
+	FinishedAt() *DateTime
+}
+
+type CampaignNotificationSettingsResolver interface {
+	Events() []string
+	Subscribers(ctx context.Context) ([]*UserResolver, error)
+}
+
+type CampaignWeeklyMergeStatsResolver interface {
+	Date() DateTime
+	Published() int32
+	Merged() int32
+	MergedPercentage() float64
+}
+
+type CampaignActivitiesConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CampaignActivityResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type CampaignActivityResolver interface {
+	ID() graphql.ID
+	Kind() campaigns.CampaignActivityKind
+	Actor(ctx context.Context) (*UserResolver, error)
+	Changeset(ctx context.Context) (ChangesetResolver, error)
+	CreatedAt() DateTime
+}
+
+type CampaignCommentsConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CampaignCommentResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type CampaignCommentResolver interface {
+	ID() graphql.ID
+	Author(ctx context.Context) (*UserResolver, error)
+	Body() string
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
 }
 
 type CampaignsConnectionResolver interface {
@@ -215,7 +840,13 @@ type ChangesetsConnectionStatsResolver interface {
 	Open() int32
 	Merged() int32
 	Closed() int32
+	Archived() int32
+	Conflicting() int32
+	Unreviewed() int32
+	Approved() int32
+	ChangesRequested() int32
 	Total() int32
+	Hidden() int32
 }
 
 type ChangesetsConnectionResolver interface {
@@ -223,6 +854,27 @@ type ChangesetsConnectionResolver interface {
 	TotalCount(ctx context.Context) (int32, error)
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 	Stats(ctx context.Context) (ChangesetsConnectionStatsResolver, error)
+	HiddenCount(ctx context.Context) (int32, error)
+}
+
+type ChangesetCheckResolver interface {
+	Name() string
+	State() campaigns.ChangesetCheckState
+	URL() *string
+}
+
+type ChangesetReviewThreadResolver interface {
+	Resolved() *bool
+	Path() string
+	Line() *int32
+	Comments() []ChangesetReviewCommentResolver
+}
+
+type ChangesetReviewCommentResolver interface {
+	Author() string
+	Body() string
+	URL() *string
+	CreatedAt() DateTime
 }
 
 type ChangesetLabelResolver interface {
@@ -267,18 +919,56 @@ type ExternalChangesetResolver interface {
 	Title(context.Context) (string, error)
 	Body(context.Context) (string, error)
 	ExternalURL() (*externallink.Resolver, error)
+	ExternalURLState() *campaigns.ChangesetURLState
+	ExternalURLCheckedAt() *DateTime
 	ReviewState(context.Context) *campaigns.ChangesetReviewState
 	CheckState() *campaigns.ChangesetCheckState
+	Checks(ctx context.Context) ([]ChangesetCheckResolver, error)
+	Mergeable() *campaigns.ChangesetMergeableState
 	Repository(ctx context.Context) *RepositoryResolver
 
-	Events(ctx context.Context, args *struct{ graphqlutil.ConnectionArgs }) (ChangesetEventsConnectionResolver, error)
+	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
 	Diff(ctx context.Context) (RepositoryComparisonInterface, error)
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	Head(ctx context.Context) (*GitRefResolver, error)
 	Base(ctx context.Context) (*GitRefResolver, error)
 	Labels(ctx context.Context) ([]ChangesetLabelResolver, error)
+	ReviewThreads(ctx context.Context) ([]ChangesetReviewThreadResolver, error)
 
+	WaitReason(ctx context.Context) (ChangesetWaitReasonResolver, error)
+	PlannedOperations(ctx context.Context) ([]ChangesetPlannedOperationResolver, error)
+	SkippedReason() *string
+	ArchivedAt() *DateTime
 	Error() *string
+	ErrorClass() *failure.Class
+	ErrorCode() *campaigns.ChangesetErrorCode
+	NumFailures() int32
+
+	CustomMetadata() []ChangesetCustomMetadataEntryResolver
+}
+
+type ChangesetRetryPolicyResolver interface {
+	ErrorCode() campaigns.ChangesetErrorCode
+	RetriedAutomatically() bool
+	BackoffSeconds() *int32
+	MaxAttempts() *int32
+}
+
+type ChangesetWaitReasonResolver interface {
+	Kind() campaigns.ChangesetWaitReason
+	Message() string
+	Until() *DateTime
+	QueuePosition() *int32
+}
+
+type ChangesetPlannedOperationResolver interface {
+	Operation() campaigns.ReconcilerOperation
+	Reason() string
+}
+
+type ChangesetCustomMetadataEntryResolver interface {
+	Key() string
+	Value() string
 }
 
 type ChangesetEventsConnectionResolver interface {
@@ -295,6 +985,7 @@ type ChangesetEventResolver interface {
 
 type ChangesetCountsResolver interface {
 	Date() DateTime
+	Repository() *RepositoryResolver
 	Total() int32
 	Merged() int32
 	Closed() int32
@@ -327,6 +1018,26 @@ func (defaultCampaignsResolver) CreateCampaignSpec(ctx context.Context, args *Cr
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) SetCampaignSpecPinned(ctx context.Context, args *SetCampaignSpecPinnedArgs) (CampaignSpecResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ValidateCampaignSpec(ctx context.Context, args *ValidateCampaignSpecArgs) (CampaignSpecValidationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) LintCampaignSpec(ctx context.Context, args *LintCampaignSpecArgs) (CampaignSpecLintResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) PreviewCampaignSpecRepositories(ctx context.Context, args *PreviewCampaignSpecRepositoriesArgs) (CampaignSpecRepositoryPreviewResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (CampaignSpecExecutionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) MoveCampaign(ctx context.Context, args *MoveCampaignArgs) (CampaignResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
@@ -335,7 +1046,83 @@ func (defaultCampaignsResolver) CloseCampaign(ctx context.Context, args *CloseCa
 	return nil, campaignsOnlyInEnterprise
 }
 
-func (defaultCampaignsResolver) SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error) {
+func (defaultCampaignsResolver) SetCampaignAutoMerge(ctx context.Context, args *SetCampaignAutoMergeArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) SetCampaignReapplySchedule(ctx context.Context, args *SetCampaignReapplyScheduleArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) SetCampaignVisibility(ctx context.Context, args *SetCampaignVisibilityArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) SetCampaignUpdatePropagation(ctx context.Context, args *SetCampaignUpdatePropagationArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) SetCampaignNotificationSettings(ctx context.Context, args *SetCampaignNotificationSettingsArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ImportChangesets(ctx context.Context, args *ImportChangesetsArgs) ([]ChangesetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) DetachChangesets(ctx context.Context, args *DetachChangesetsArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CheckChangesetURLs(ctx context.Context, args *CheckChangesetURLsArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) TriggerCampaign(ctx context.Context, args *TriggerCampaignArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CreateCampaignsCredential(ctx context.Context, args *CreateCampaignsCredentialArgs) (CampaignsCredentialResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) DeleteCampaignsCredential(ctx context.Context, args *DeleteCampaignsCredentialArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignsCredentials(ctx context.Context, args *ListCampaignsCredentialsArgs) ([]CampaignsCredentialResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CreateCampaignComment(ctx context.Context, args *CreateCampaignCommentArgs) (CampaignCommentResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) SetCampaignAutoRebase(ctx context.Context, args *SetCampaignAutoRebaseArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) PauseCampaign(ctx context.Context, args *PauseCampaignArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ResumeCampaign(ctx context.Context, args *ResumeCampaignArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (ChangesetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) RebaseChangeset(ctx context.Context, args *RebaseChangesetArgs) (ChangesetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) SetChangesetCustomMetadata(ctx context.Context, args *SetChangesetCustomMetadataArgs) (ChangesetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CreateChangesetBulkOperation(ctx context.Context, args *CreateChangesetBulkOperationArgs) (BulkOperationResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
 
@@ -343,11 +1130,43 @@ func (defaultCampaignsResolver) DeleteCampaign(ctx context.Context, args *Delete
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) RecordCampaignView(ctx context.Context, args *RecordCampaignViewArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) RestoreCampaign(ctx context.Context, args *RestoreCampaignArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) GrantCampaignPermission(ctx context.Context, args *GrantCampaignPermissionArgs) (CampaignPermissionGrantResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) RevokeCampaignPermission(ctx context.Context, args *RevokeCampaignPermissionArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CreateCampaignTemplate(ctx context.Context, args *CreateCampaignTemplateArgs) (CampaignTemplateResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) DeleteCampaignTemplate(ctx context.Context, args *DeleteCampaignTemplateArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CreateCampaignSpecFromTemplate(ctx context.Context, args *CreateCampaignSpecFromTemplateArgs) (CampaignSpecResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 // Queries
 func (defaultCampaignsResolver) CampaignByID(ctx context.Context, id graphql.ID) (CampaignResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) Campaign(ctx context.Context, args *CampaignArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) Campaigns(ctx context.Context, args *ListCampaignArgs) (CampaignsConnectionResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
@@ -363,3 +1182,47 @@ func (defaultCampaignsResolver) CampaignSpecByID(ctx context.Context, id graphql
 func (defaultCampaignsResolver) ChangesetSpecByID(ctx context.Context, id graphql.ID) (ChangesetSpecResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
+
+func (defaultCampaignsResolver) CampaignSpecExecutionByID(ctx context.Context, id graphql.ID) (CampaignSpecExecutionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) BulkOperationByID(ctx context.Context, id graphql.ID) (BulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignsAdvisoryLocks(ctx context.Context) ([]CampaignsAdvisoryLockResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignsPublicationBudgets(ctx context.Context) ([]CampaignsPublicationBudgetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ChangesetRetryPolicies(ctx context.Context) ([]ChangesetRetryPolicyResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignsStatistics(ctx context.Context, args *CampaignsStatisticsArgs) (CampaignsStatisticsResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignSpecExpiration(ctx context.Context) (CampaignSpecExpirationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignNamespaceQuota(ctx context.Context, args *CampaignNamespaceQuotaArgs) (CampaignNamespaceQuotaResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) BulkOperation(ctx context.Context, args *BulkOperationArgs) (BulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignTemplates(ctx context.Context, args *ListCampaignTemplatesArgs) (CampaignTemplateConnectionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignTemplateByID(ctx context.Context, id graphql.ID) (CampaignTemplateResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
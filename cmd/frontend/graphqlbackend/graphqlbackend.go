@@ -413,6 +413,21 @@ func (r *NodeResolver) ToCampaignSpec() (CampaignSpecResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToCampaignSpecExecution() (CampaignSpecExecutionResolver, bool) {
+	n, ok := r.Node.(CampaignSpecExecutionResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToBulkOperation() (BulkOperationResolver, bool) {
+	n, ok := r.Node.(BulkOperationResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToCampaignTemplate() (CampaignTemplateResolver, bool) {
+	n, ok := r.Node.(CampaignTemplateResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToHiddenChangesetSpec() (HiddenChangesetSpecResolver, bool) {
 	n, ok := r.Node.(ChangesetSpecResolver)
 	if !ok {
@@ -552,10 +567,16 @@ func (r *schemaResolver) nodeByID(ctx context.Context, id graphql.ID) (Node, err
 	switch relay.UnmarshalKind(id) {
 	case "AccessToken":
 		return accessTokenByID(ctx, id)
+	case "BulkOperation":
+		return r.BulkOperationByID(ctx, id)
 	case "Campaign":
 		return r.CampaignByID(ctx, id)
 	case "CampaignSpec":
 		return r.CampaignSpecByID(ctx, id)
+	case "CampaignSpecExecution":
+		return r.CampaignSpecExecutionByID(ctx, id)
+	case "CampaignTemplate":
+		return r.CampaignTemplateByID(ctx, id)
 	case "ChangesetSpec":
 		return r.ChangesetSpecByID(ctx, id)
 	case "Changeset":
@@ -386,11 +386,123 @@ type Mutation {
         # conflicts if the underlying campaign is moved to a different namespace, renamed, or
         # deleted).
         ensureCampaign: ID
+
+        # If true, published changesets that the new campaign spec no longer contains are archived
+        # in the campaign instead of being closed on the code host. Archived changesets stay in the
+        # campaign and are still synced, but aren't counted as open.
+        archiveSupersededChangesets: Boolean = false
     ): Campaign!
 
-    # Move a campaign to a different namespace, or rename it in the current namespace.
+    # Move a campaign to a different namespace, or rename it in the current namespace. The viewer
+    # needs access to both the current and the new namespace, and the new namespace can't already
+    # contain a campaign with the same name.
     moveCampaign(campaign: ID!, newName: String, newNamespace: ID): Campaign!
 
+    # Enable or disable auto-merge for a campaign. When enabled, the campaign's open changesets are
+    # merged on their code hosts as soon as their checks passed and they have been approved. Every
+    # automatic merge is recorded in the campaign's activity log.
+    setCampaignAutoMerge(campaign: ID!, enabled: Boolean!): Campaign!
+
+    # Enable or disable auto-rebase for a campaign. When enabled, the diffs of the campaign's open
+    # changesets are re-applied on top of their base branch and force-pushed whenever the base branch
+    # advances. Every rebase is recorded as an event of the changeset.
+    setCampaignAutoRebase(campaign: ID!, enabled: Boolean!): Campaign!
+
+    # Pause a campaign. While a campaign is paused, its changesets aren't published, updated or
+    # closed on their code hosts, and they aren't synced. Changes to the campaign, such as applying a
+    # new spec or closing it, are carried out once it's resumed.
+    pauseCampaign(campaign: ID!): Campaign!
+
+    # Resume a paused campaign.
+    resumeCampaign(campaign: ID!): Campaign!
+
+    # Set the cron schedule on which a campaign is re-applied: the repositories matched by its spec
+    # and the spec's steps are re-evaluated server-side and the resulting changeset specs are
+    # applied. The schedule is a cron expression with five fields (minute, hour, day of month,
+    # month, day of week) in UTC, or one of @hourly, @daily, @weekly and @monthly. If schedule is
+    # null or blank, the existing schedule is removed. Closed campaigns can't be scheduled.
+    setCampaignReapplySchedule(campaign: ID!, schedule: String): Campaign!
+
+    # Set the visibility of a campaign. Campaigns with the NAMESPACE_ONLY visibility are only
+    # visible to site admins, their creator and the users with access to their namespace.
+    setCampaignVisibility(campaign: ID!, visibility: CampaignVisibility!): Campaign!
+
+    # Set how re-applying a campaign changes the title and body of its published changesets that
+    # were edited on the code host since Sourcegraph last updated them.
+    setCampaignUpdatePropagation(campaign: ID!, updatePropagation: CampaignUpdatePropagation!): Campaign!
+
+    # Set which events of a campaign are emailed to its author and to the given subscribers. Email
+    # must be configured in the site configuration for notifications to be sent.
+    setCampaignNotificationSettings(
+        campaign: ID!
+        events: [CampaignNotificationEvent!]!
+        subscribers: [ID!]!
+    ): Campaign!
+
+    # Import existing changesets into a campaign by the URLs of their pull or merge requests on
+    # GitHub, GitLab, Bitbucket Server or Bitbucket Cloud. Changesets that aren't tracked by
+    # Sourcegraph yet are created and synced. Closed campaigns can't import changesets.
+    #
+    # Imported changesets are detached from the campaign the next time a campaign spec is applied
+    # to it, unless that spec imports them too.
+    importChangesets(campaign: ID!, externalURLs: [String!]!): [Changeset!]!
+
+    # Remove changesets from a campaign without closing them on the code host, e.g. to hand them
+    # off to the owners of the repositories. Changesets created by the campaign are no longer
+    # updated by it, changesets that aren't in any other campaign are no longer tracked, and
+    # unpublished changesets are deleted.
+    #
+    # Changesets created by the campaign are created again the next time a campaign spec is applied
+    # to it, unless the spec no longer contains them.
+    detachChangesets(campaign: ID!, changesets: [ID!]!): Campaign!
+
+    # Check right away whether the external URLs of the published changesets of a campaign still
+    # resolve on the code host, instead of waiting for the next periodic check. The results are
+    # available in the externalURLState field of the changesets. Only campaign admins can check the
+    # URLs.
+    checkChangesetURLs(campaign: ID!): Campaign!
+
+    # Trigger a campaign from an external system, such as a code monitor or a CI job, e.g. when
+    # new occurrences of what the campaign fixes appear. Every trigger is recorded in the activity
+    # log of the campaign. Only campaign admins can trigger a campaign, and closed campaigns can't
+    # be triggered.
+    triggerCampaign(
+        # The campaign to trigger.
+        campaign: ID!
+        # What to do.
+        action: CampaignTriggerAction!
+        # What fired the trigger, e.g. the name of the code monitor. It's shown in notifications.
+        source: String!
+        # Why the trigger fired, e.g. the new search results. It's shown in notifications.
+        message: String
+    ): EmptyResponse!
+
+    # Store a code host credential of a user for publishing changesets. The changesets of the
+    # campaigns the user applied last are published, and their commits pushed, with the credential
+    # instead of with the token of the code host's external service. The credential is validated
+    # against the code host before it's stored, and replaces the user's existing credential for the
+    # code host. Only the user and site admins can create the user's credentials.
+    createCampaignsCredential(
+        # The user the credential belongs to. Defaults to the viewer.
+        user: ID
+        # The kind of the code host. Only GITHUB, GITLAB and BITBUCKETSERVER are supported.
+        externalServiceKind: ExternalServiceKind!
+        # The URL of the code host, e.g. "https://github.com".
+        externalServiceURL: String!
+        # The username to authenticate as. Required for Bitbucket Server.
+        username: String
+        # The token to authenticate with. It can't be read back.
+        credential: String!
+    ): CampaignsCredential!
+
+    # Delete a code host credential of a user. Changesets are then published with the token of the
+    # code host's external service again. Only the user and site admins can delete the user's
+    # credentials.
+    deleteCampaignsCredential(campaignsCredential: ID!): EmptyResponse
+
+    # Comment on a campaign. Everyone who can see a campaign can comment on it.
+    createCampaignComment(campaign: ID!, body: String!): CampaignComment!
+
     # Close a campaign.
     closeCampaign(
         campaign: ID!
@@ -400,10 +512,37 @@ type Mutation {
         closeChangesets: Boolean = false
     ): Campaign!
 
-    # Delete a campaign. A deleted campaign is completely removed and can't be un-deleted. The
-    # campaign's changesets are kept as-is; to close them, use the closeCampaign mutation first.
+    # Delete a campaign. A deleted campaign can be restored with the restoreCampaign mutation for
+    # 30 days, after which it's completely removed. The campaign's changesets are kept as-is but no
+    # longer synced; to close them, use the closeCampaign mutation first.
     deleteCampaign(campaign: ID!): EmptyResponse
 
+    # Record that the current user viewed a campaign. The changesets of recently viewed campaigns are
+    # synced more often, and the open ones are synced right away when a new view is recorded. Views
+    # are recorded at most every 5 minutes per campaign, so clients can call this whenever the
+    # campaign page is opened.
+    recordCampaignView(campaign: ID!): EmptyResponse
+
+    # Restore a deleted campaign. Campaigns can be restored for 30 days after they were deleted,
+    # unless a campaign with the same name has been created in the namespace in the meantime.
+    restoreCampaign(campaign: ID!): Campaign!
+
+    # Grant a user, or all members of an organization, view or admin rights on a single campaign,
+    # in addition to the rights that follow from the campaign's author, namespace and visibility.
+    # Granting rights to a user or organization that already has a grant on the campaign replaces
+    # its level. Only campaign admins can grant rights.
+    grantCampaignPermission(
+        # The campaign to grant rights on.
+        campaign: ID!
+        # The user or organization to grant the rights to.
+        grantee: ID!
+        # The rights to grant.
+        level: CampaignPermissionLevel!
+    ): CampaignPermissionGrant!
+
+    # Revoke rights granted with grantCampaignPermission. Only campaign admins can revoke rights.
+    revokeCampaignPermission(grant: ID!): EmptyResponse
+
     # Upload a changeset spec that will be used in a future update to a campaign. The changeset spec
     # is stored and can be referenced by its ID in the applyCampaign mutation. Just uploading the
     # changeset spec does not result in changes to the campaign or any of its changesets; you need
@@ -439,8 +578,129 @@ type Mutation {
         changesetSpecs: [ID!]!
     ): CampaignSpec!
 
-    # Enqueue the given changeset for high-priority syncing.
-    syncChangeset(changeset: ID!): EmptyResponse!
+    # Pin or unpin a campaign spec. A pinned campaign spec, and the changeset specs attached to it,
+    # never expire, so that it can go through a review that takes longer than the expiration window
+    # configured in the site configuration before it's applied. Only site admins and the creator of
+    # the campaign spec can pin it.
+    setCampaignSpecPinned(campaignSpec: ID!, pinned: Boolean!): CampaignSpec!
+
+    # Validate a campaign spec without creating it. The spec is validated against the campaign spec
+    # schema and, if that succeeds, it's checked that the namespace (if given) exists and can be
+    # used by the viewer and that the repositories of the changesets to import exist.
+    validateCampaignSpec(
+        # The namespace (either a user or organization) in which the campaign spec would be created.
+        namespace: ID
+
+        # The campaign spec as YAML (or the equivalent JSON).
+        spec: String!
+    ): CampaignSpecValidation!
+
+    # Lint a campaign spec without creating it. The spec is validated like with
+    # validateCampaignSpec and, if it's valid, checked for likely mistakes. Each problem found has a
+    # machine-readable rule, so that tools like src-cli and CI pipelines can fail on specific
+    # problems.
+    lintCampaignSpec(
+        # The namespace (either a user or organization) in which the campaign spec would be created.
+        namespace: ID
+
+        # The campaign spec as YAML (or the equivalent JSON).
+        spec: String!
+
+        # Changeset specs that were locally computed and uploaded using createChangesetSpec, whose
+        # diffs are checked too.
+        changesetSpecs: [ID!]
+    ): CampaignSpecLintResult!
+
+    # Resolve the repositories that a campaign spec is on, without creating or executing it. The
+    # repositoriesMatchingQuery searches of the spec are run on the server, and the repositories and
+    # revisions that its steps would be run on are returned, so that the scope of the campaign can
+    # be checked before running the steps locally or server-side. Only repositories that the current
+    # user has access to are returned.
+    previewCampaignSpecRepositories(
+        # The campaign spec as YAML (or the equivalent JSON).
+        spec: String!
+    ): CampaignSpecRepositoryPreview!
+
+    # Execute a campaign spec server-side. The steps of the spec are run in every repository that
+    # the spec is on, and a campaign spec with the resulting changeset specs is created in the
+    # namespace, which can then be previewed and applied like one created with createCampaignSpec.
+    # The execution runs in the background; poll the returned CampaignSpecExecution for its
+    # progress. Requires campaigns.executor to be enabled in the site configuration.
+    executeCampaignSpec(
+        # The namespace (either a user or organization) in which the campaign spec is created.
+        namespace: ID!
+
+        # The campaign spec as YAML (or the equivalent JSON).
+        campaignSpec: String!
+    ): CampaignSpecExecution!
+
+    # Create a campaign template in the given namespace. A campaign template is a reusable campaign
+    # spec in which values are left open as parameters, referenced as ${{ parameters.NAME }}. Use
+    # createCampaignSpecFromTemplate to create a campaign spec from it.
+    createCampaignTemplate(
+        # The namespace (either a user or organization) of the template.
+        namespace: ID!
+        # The name of the template.
+        name: String!
+        # The description of the template.
+        description: String
+        # The campaign spec as YAML (or the equivalent JSON), referencing the parameters.
+        template: String!
+        # The parameters of the template.
+        parameters: [CampaignTemplateParameterInput!]
+    ): CampaignTemplate!
+
+    # Delete a campaign template. Campaign specs created from the template are not affected.
+    deleteCampaignTemplate(campaignTemplate: ID!): EmptyResponse
+
+    # Create a campaign spec from a campaign template by substituting the given values for the
+    # template's parameters. Parameters without a value use their default. The result is handled
+    # like the campaignSpec argument of createCampaignSpec.
+    createCampaignSpecFromTemplate(
+        # The campaign template.
+        campaignTemplate: ID!
+        # The namespace (either a user or organization) of the campaign spec.
+        namespace: ID!
+        # The values of the template's parameters.
+        parameters: [CampaignTemplateParameterValueInput!]
+        # Changeset specs that were locally computed and then uploaded using createChangesetSpec.
+        changesetSpecs: [ID!]
+    ): CampaignSpec!
+
+    # Sync the given changeset with the code host right away, instead of waiting for the next
+    # background sync, and return its refreshed state. Only published changesets can be synced.
+    syncChangeset(changeset: ID!): Changeset!
+
+    # Re-apply the diff of the given changeset's current changeset spec onto the current head of its
+    # base branch and force-push the changeset's branch. This resolves conflicts caused by changes to the
+    # base branch without re-running the whole campaign. Only open changesets published by a campaign
+    # can be rebased. If the diff doesn't apply cleanly on the new base, an error is returned and the
+    # branch is left unchanged. The changeset is returned.
+    rebaseChangeset(changeset: ID!): Changeset!
+
+    # Set a custom metadata entry on the given changeset. The entry is only stored on Sourcegraph
+    # and not sent to the code host. The changeset is returned.
+    setChangesetCustomMetadata(
+        changeset: ID!
+        # The key of the entry.
+        key: String!
+        # The value of the entry. If null, the entry is removed.
+        value: String
+    ): Changeset!
+
+    # Run an operation on many changesets of a campaign at once. A job is enqueued for every
+    # changeset and processed in the background; poll the returned BulkOperation for the progress
+    # and the result of every job. Only campaign admins can run bulk operations.
+    createChangesetBulkOperation(
+        # The campaign the changesets belong to.
+        campaign: ID!
+        # The changesets to run the operation on.
+        changesets: [ID!]!
+        # The operation to run.
+        type: ChangesetJobType!
+        # The Markdown body of the comment, for COMMENT operations.
+        message: String
+    ): BulkOperation!
 
     #
     # OBSERVABILITY
@@ -469,7 +729,8 @@ interface ChangesetSpec {
     type: ChangesetSpecType!
 
     # The date, if any, when this changeset spec expires and is automatically purged. A changeset
-    # spec never expires (and this field is null) if its campaign spec has been applied.
+    # spec never expires (and this field is null) if its campaign spec has been applied or is
+    # pinned.
     expiresAt: DateTime
 }
 
@@ -490,7 +751,8 @@ type HiddenChangesetSpec implements ChangesetSpec & Node {
     type: ChangesetSpecType!
 
     # The date, if any, when this changeset spec expires and is automatically purged. A changeset
-    # spec never expires (and this field is null) if its campaign spec has been applied.
+    # spec never expires (and this field is null) if its campaign spec has been applied or is
+    # pinned.
     expiresAt: DateTime
 }
 
@@ -513,11 +775,57 @@ type VisibleChangesetSpec implements ChangesetSpec & Node {
     # The description of the changeset.
     description: ChangesetDescription!
 
+    # How applying the campaign spec changes the changeset that already exists for this changeset
+    # spec in the campaign the campaign spec applies to, or null if applying it creates a new
+    # changeset.
+    delta: ChangesetSpecDelta
+
     # The date, if any, when this changeset spec expires and is automatically purged. A changeset
-    # spec never expires (and this field is null) if its campaign spec has been applied.
+    # spec never expires (and this field is null) if its campaign spec has been applied or is
+    # pinned.
     expiresAt: DateTime
 }
 
+# Describes which attributes of an existing changeset change when a changeset spec is applied to it.
+type ChangesetSpecDelta {
+    # The changeset that is updated.
+    changeset: ExternalChangeset!
+
+    # The changeset spec that is applied to the changeset.
+    changesetSpec: VisibleChangesetSpec!
+
+    # Whether the title of the changeset changes.
+    titleChanged: Boolean!
+
+    # Whether the body of the changeset changes.
+    bodyChanged: Boolean!
+
+    # Whether the base ref of the changeset changes.
+    baseRefChanged: Boolean!
+
+    # Whether the diff of the changeset changes.
+    diffChanged: Boolean!
+
+    # Whether the commit message of the changeset changes.
+    commitMessageChanged: Boolean!
+
+    # Whether the reviewers of the changeset change.
+    reviewersChanged: Boolean!
+
+    # Whether the assignees of the changeset change.
+    assigneesChanged: Boolean!
+
+    # Whether the labels of the changeset change.
+    labelsChanged: Boolean!
+
+    # Whether the milestone of the changeset changes.
+    milestoneChanged: Boolean!
+
+    # The diff between the current diff of the changeset and the diff of the changeset spec, in
+    # unified diff format, or null if the diff doesn't change.
+    diffOfDiffs: String
+}
+
 # All possible types of changesets that can be specified in a changeset spec.
 union ChangesetDescription = ExistingChangesetReference | GitBranchChangesetDescription
 
@@ -589,6 +897,19 @@ type GitBranchChangesetDescription {
     # Another ChangesetSpec with the same description, but "published: true",
     # can later be applied publish the changeset.
     published: Boolean!
+
+    # The usernames of the users (and, on GitHub, "org/team" teams) that reviews of the changeset
+    # are requested from.
+    reviewers: [String!]!
+
+    # The usernames of the users that the changeset is assigned to.
+    assignees: [String!]!
+
+    # The labels that are added to the changeset on the code host.
+    labels: [String!]!
+
+    # The title of the milestone of the changeset on the code host, if any.
+    milestone: String
 }
 
 # A description of a Git commit.
@@ -655,9 +976,13 @@ type CampaignSpec implements Node {
     namespace: Namespace
 
     # The date, if any, when this campaign spec expires and is automatically purged. A campaign spec
-    # never expires if it has been applied.
+    # never expires if it has been applied or is pinned.
     expiresAt: DateTime
 
+    # The date and time when the campaign spec was pinned, or null if it isn't pinned. See
+    # setCampaignSpecPinned.
+    pinnedAt: DateTime
+
     # The URL of a web page that allows applying this campaign spec and
     # displays a preview of which changesets will be created by applying it.
     applyURL: String!
@@ -671,6 +996,101 @@ type CampaignSpec implements Node {
     # The campaign this spec will update when applied. If it's null, the
     # campaign doesn't yet exist.
     appliesToCampaign: Campaign
+
+    # What applying this spec changes in the campaign it applies to, compared to the campaign spec
+    # that's currently applied to the campaign. Changeset specs and changesets in repositories
+    # that the viewer doesn't have access to are left out.
+    diffWithAppliedSpec: CampaignSpecDiff!
+}
+
+# Describes what applying a campaign spec changes in the campaign it applies to.
+type CampaignSpecDiff {
+    # The campaign the campaign spec applies to, or null if applying it creates a new campaign.
+    campaign: Campaign
+
+    # The changeset specs for which a new changeset is created or an existing changeset is
+    # imported into the campaign.
+    added: [VisibleChangesetSpec!]!
+
+    # How the changeset specs that change a changeset of the campaign change it.
+    updated: [ChangesetSpecDelta!]!
+
+    # The changeset specs whose changeset is already in the campaign and stays the same.
+    unchanged: [VisibleChangesetSpec!]!
+
+    # The changesets that the campaign created on their code host and that are closed, because the
+    # campaign spec doesn't contain them anymore.
+    closed: [ExternalChangeset!]!
+
+    # The changesets that are removed from the campaign without being closed, because the campaign
+    # spec doesn't contain them anymore.
+    detached: [ExternalChangeset!]!
+}
+
+# A parameter of a campaign template to create.
+input CampaignTemplateParameterInput {
+    # The name of the parameter, as referenced in the template.
+    name: String!
+    # The description of the parameter.
+    description: String
+    # The value used when no value is given for the parameter. Parameters without a default are
+    # required.
+    default: String
+}
+
+# The value of a campaign template parameter.
+input CampaignTemplateParameterValueInput {
+    # The name of the parameter.
+    name: String!
+    # The value substituted for the parameter.
+    value: String!
+}
+
+# A parameter of a campaign template.
+type CampaignTemplateParameter {
+    # The name of the parameter, as referenced in the template.
+    name: String!
+    # The description of the parameter.
+    description: String!
+    # The value used when no value is given for the parameter.
+    default: String
+    # Whether a value must be given for the parameter, because it has no default.
+    required: Boolean!
+}
+
+# A reusable campaign spec in which values are left open as parameters. To create a campaign spec
+# from it, use the createCampaignSpecFromTemplate mutation.
+type CampaignTemplate implements Node {
+    # The unique ID for the campaign template.
+    id: ID!
+    # The name of the template.
+    name: String!
+    # The description of the template.
+    description: String!
+    # The campaign spec as YAML (or the equivalent JSON), with references to the parameters.
+    template: String!
+    # The parameters of the template.
+    parameters: [CampaignTemplateParameter!]!
+    # The namespace (either a user or organization) of the template.
+    namespace: Namespace!
+    # The user who created the template (or null if the user no longer exists).
+    creator: User
+    # The date when the template was created.
+    createdAt: DateTime!
+    # The date when the template was last updated.
+    updatedAt: DateTime!
+    # Whether the viewer can delete the template.
+    viewerCanAdminister: Boolean!
+}
+
+# A list of campaign templates.
+type CampaignTemplateConnection {
+    # A list of campaign templates.
+    nodes: [CampaignTemplate!]!
+    # The total number of campaign templates in the connection.
+    totalCount: Int!
+    # Pagination information.
+    pageInfo: PageInfo!
 }
 
 # A user (identified either by username or email address) with its repository permission.
@@ -697,6 +1117,10 @@ type Campaign implements Node {
     # The description (as Markdown).
     description: String
 
+    # The description rendered to HTML. The returned HTML is already sanitized and escaped and
+    # thus is always safe to render.
+    descriptionHTML: String
+
     # The user that created the initial spec. In an org, this will be different from the namespace.
     specCreator: User!
 
@@ -726,11 +1150,50 @@ type Campaign implements Node {
     # The date and time when the campaign was closed. If set, applying a spec for this campaign will fail with an error.
     closedAt: DateTime
 
+    # The date and time when the campaign was paused, or null if it isn't paused. See pauseCampaign.
+    pausedAt: DateTime
+
+    # The date and time when the campaign was deleted, or null if it hasn't been deleted.
+    deletedAt: DateTime
+
+    # The rights that have been granted on the campaign with grantCampaignPermission. Only campaign
+    # admins can see them; for all other users the list is empty.
+    permissionGrants: [CampaignPermissionGrant!]!
+
+    # Whether the campaign's changesets are merged automatically once their checks passed and they
+    # have been approved.
+    autoMerge: Boolean!
+
+    # Whether the campaign's changesets are rebased automatically when their base branch advances.
+    autoRebase: Boolean!
+
+    # The schedule on which the campaign is re-applied, if any.
+    reapplySchedule: CampaignReapplySchedule
+
+    # Who can see the campaign.
+    visibility: CampaignVisibility!
+
+    # How re-applying the campaign changes the title and body of published changesets that were
+    # edited on the code host.
+    updatePropagation: CampaignUpdatePropagation!
+
+    # Which events of the campaign are emailed to whom.
+    notificationSettings: CampaignNotificationSettings!
+
+    # The date and time when a changeset in this campaign was last updated, for example by the
+    # reconciler or by a sync with the code host. Null if the campaign has no changesets.
+    # Clients can poll this field and pass its previous value as updatedAfter to changesets to
+    # only fetch the changesets that changed in the meantime.
+    changesetsUpdatedAt: DateTime
+
     # The changesets in this campaign that already exist on the code host.
     changesets(
         first: Int
         # Only include changesets with the given reconciler state.
         reconcilerState: ChangesetReconcilerState
+        # Only include changesets whose last reconciliation failed, that is the ones that are
+        # ERRORED and the ones that are QUEUED to be retried after an error.
+        onlyFailed: Boolean
         # Only include changesets with the given publication state.
         publicationState: ChangesetPublicationState
         # Only include changesets with the given external state.
@@ -739,8 +1202,28 @@ type Campaign implements Node {
         reviewState: ChangesetReviewState
         # Only include changesets with the given check state.
         checkState: ChangesetCheckState
+        # Only include changesets whose custom metadata contains all of the given entries.
+        customMetadata: [ChangesetCustomMetadataInput!]
+        # Only include changesets that have been updated after the given time. Changesets that
+        # were removed from the campaign in the meantime are not included.
+        updatedAfter: DateTime
+        # Only include archived changesets (true) or changesets that aren't archived (false).
+        archived: Boolean
     ): ChangesetConnection!
 
+    # The changesets in this campaign, grouped by the repository they're in and ordered by the
+    # ID of the repository.
+    changesetsByRepository(
+        # Returns the first n groups from the list.
+        first: Int
+        # Opaque pagination cursor.
+        after: String
+    ): ChangesetRepositoryGroupConnection!
+
+    # The sync status of the changesets in this campaign that are synced with the code host. Use
+    # it to tell whether the data shown for the campaign, like changesetCountsOverTime, is stale.
+    syncStatus: CampaignSyncStatus!
+
     # The changeset counts over time, in 1-day intervals backwards from the point in time given in
     # the "to" parameter.
     changesetCountsOverTime(
@@ -749,16 +1232,220 @@ type Campaign implements Node {
         # Only include changeset counts up to this point in time (inclusive). Defaults to the
         # current time.
         to: DateTime
+        # Return a separate series of changeset counts for each group instead of a single series
+        # for the whole campaign. The series are returned one after the other.
+        groupBy: ChangesetCountsGroupBy
+        # When grouping by REPOSITORY, the maximum number of repositories that get their own
+        # series. They are the repositories with the most open changesets at the end of the
+        # timeframe. The counts of all other repositories are added up into a last series without
+        # a repository.
+        groupLimit: Int = 10
     ): [ChangesetCounts!]!
 
     # The diff stat for all the changesets in the campaign.
     diffStat: DiffStat!
+
+    # Statistics about how fast the published changesets of the campaign are reviewed and merged.
+    analytics: CampaignAnalytics!
+
+    # How far along the campaign is in publishing and merging its changesets.
+    progress: CampaignProgress!
+
+    # The aggregated statistics of the changesets in the campaign. When requested for a list of
+    # campaigns, the statistics of all campaigns in the list are loaded at once.
+    stats: CampaignStats!
+
+    # The activity log of the campaign, oldest entries first.
+    activity(
+        # Returns the first n entries from the list.
+        first: Int
+        # Opaque pagination cursor.
+        after: String
+    ): CampaignActivityConnection!
+
+    # The comments on the campaign, oldest comments first.
+    comments(
+        # Returns the first n comments from the list.
+        first: Int
+    ): CampaignCommentConnection!
+
+    # The URL from which all changesets of the campaign that the viewer has access to can be
+    # downloaded in the given format. Requests to this URL must be authenticated.
+    changesetsExportURL(
+        # The format of the export.
+        format: ChangesetsExportFormat = CSV
+    ): String!
+
+    # The URL from which the diffs of all changeset specs of the campaign's current campaign spec
+    # can be downloaded in the given format. Changeset specs in repositories the viewer doesn't
+    # have access to are left out. Requests to this URL must be authenticated.
+    patchURL(
+        # The format of the patch.
+        format: CampaignPatchFormat = DIFF
+    ): String!
+}
+
+# The format of an export of a campaign's changesets.
+enum ChangesetsExportFormat {
+    # Comma-separated values with a header row.
+    CSV
+    # A JSON array of objects.
+    JSON
+}
+
+# The format of a campaign's combined patch.
+enum CampaignPatchFormat {
+    # One unified diff, with each changeset spec's diff preceded by comment lines naming its
+    # repository and branch.
+    DIFF
+    # A gzipped tarball with one patch per repository and branch.
+    TAR_GZ
+}
+
+# The kind of a campaign activity.
+enum CampaignActivityKind {
+    # A campaign spec was applied to the campaign.
+    APPLIED
+    # The campaign was closed.
+    CLOSED
+    # A changeset of the campaign was published on its code host.
+    CHANGESET_PUBLISHED
+    # Changesets that were detached from the campaign were closed on their code hosts.
+    CHANGESETS_CLOSED
+    # A changeset of the campaign was merged automatically on its code host.
+    CHANGESET_AUTO_MERGED
+    # Auto-merge was enabled for the campaign.
+    AUTO_MERGE_ENABLED
+    # Auto-merge was disabled for the campaign.
+    AUTO_MERGE_DISABLED
+    # Existing changesets were imported into the campaign by their URLs.
+    CHANGESETS_IMPORTED
+    # Changesets were detached from the campaign without being closed.
+    CHANGESETS_DETACHED
+    # The campaign was deleted.
+    DELETED
+    # The deleted campaign was restored.
+    RESTORED
+    # Auto-rebase was enabled for the campaign.
+    AUTO_REBASE_ENABLED
+    # Auto-rebase was disabled for the campaign.
+    AUTO_REBASE_DISABLED
+    # The campaign was triggered by an external system with the triggerCampaign mutation.
+    TRIGGERED
+    # The campaign was paused.
+    PAUSED
+    # The campaign was resumed.
+    RESUMED
+    # Changesets that a newer campaign spec no longer contained were archived in the campaign
+    # instead of being closed.
+    CHANGESETS_ARCHIVED
+}
+
+# What happens when a campaign is triggered with the triggerCampaign mutation.
+enum CampaignTriggerAction {
+    # Re-evaluate the current campaign spec of the campaign server-side and apply the result, so
+    # that changesets are created for new occurrences. Requires campaigns.executor to be enabled.
+    # A re-evaluation is only enqueued if none is queued or running for the campaign already.
+    REAPPLY
+    # Email the author and the subscribers of the campaign. Requires email to be configured.
+    NOTIFY
+}
+
+# A code host credential of a user for publishing changesets. The token is never exposed.
+type CampaignsCredential {
+    # The unique ID for the credential.
+    id: ID!
+
+    # The kind of the code host.
+    externalServiceKind: ExternalServiceKind!
+
+    # The URL of the code host.
+    externalServiceURL: String!
+
+    # The username to authenticate as, if the code host needs one in addition to the token.
+    username: String
+
+    # The date and time when the credential was created.
+    createdAt: DateTime!
+
+    # The date and time when the credential was last updated.
+    updatedAt: DateTime!
+}
+
+# An entry in the activity log of a campaign.
+type CampaignActivity {
+    # The unique ID for the campaign activity.
+    id: ID!
+
+    # The kind of the activity.
+    kind: CampaignActivityKind!
+
+    # The user that caused the activity. Null if the activity was caused by Sourcegraph itself or
+    # the user has been deleted.
+    actor: User
+
+    # The changeset the activity relates to, if any.
+    changeset: Changeset
+
+    # The date and time when the activity happened.
+    createdAt: DateTime!
+}
+
+# A list of campaign activities.
+type CampaignActivityConnection {
+    # A list of campaign activities.
+    nodes: [CampaignActivity!]!
+
+    # The total number of campaign activities in the connection.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# A comment on a campaign.
+type CampaignComment {
+    # The unique ID for the campaign comment.
+    id: ID!
+
+    # The user that wrote the comment. Null if the user has been deleted.
+    author: User
+
+    # The text of the comment.
+    body: String!
+
+    # The date and time when the comment was created.
+    createdAt: DateTime!
+
+    # The date and time when the comment was updated.
+    updatedAt: DateTime!
+}
+
+# A list of campaign comments.
+type CampaignCommentConnection {
+    # A list of campaign comments.
+    nodes: [CampaignComment!]!
+
+    # The total number of campaign comments in the connection.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# How changeset counts over time are grouped.
+enum ChangesetCountsGroupBy {
+    # One series of changeset counts per repository.
+    REPOSITORY
 }
 
 # The counts of changesets in certain states at a specific point in time.
 type ChangesetCounts {
     # The point in time these counts were recorded.
     date: DateTime!
+    # The repository whose changesets these counts are for, if the counts are grouped by
+    # repository. Null for the counts of the other repositories and if the counts aren't grouped.
+    repository: Repository
     # The total number of changesets.
     total: Int!
     # The number of merged changesets.
@@ -775,6 +1462,301 @@ type ChangesetCounts {
     openPending: Int!
 }
 
+# Statistics about how fast the published changesets of a campaign are reviewed and merged.
+type CampaignAnalytics {
+    # The median number of seconds between a changeset being published and its first review. Null
+    # if no changeset has been reviewed.
+    medianSecondsToFirstReview: Int
+    # The median number of seconds between a changeset being published and being merged. Null if
+    # no changeset has been merged.
+    medianSecondsToMerge: Int
+    # The number of published and merged changesets at the end of each week since the campaign
+    # was created, oldest first.
+    mergedByWeek: [CampaignWeeklyMergeStats!]!
+}
+
+# How far along a campaign is in publishing and merging its changesets.
+type CampaignProgress {
+    # The total number of changesets in the campaign.
+    total: Int!
+    # The number of published changesets.
+    published: Int!
+    # The number of merged changesets.
+    merged: Int!
+    # The number of changesets of the campaign that are yet to be published by Sourcegraph.
+    pendingPublication: Int!
+    # The percentage of changesets that are published.
+    publishedPercentage: Float!
+    # The percentage of changesets that are merged.
+    mergedPercentage: Float!
+    # When the changesets pending publication are expected to be published, based on how many
+    # changesets of the campaign were published in the last hour. Null if no changesets are pending
+    # publication or none were published in the last hour.
+    estimatedPublicationCompletedAt: DateTime
+}
+
+# The aggregated statistics of the changesets in a campaign. The counts include the changesets in
+# repositories the viewer doesn't have access to. Changesets in deleted repositories aren't
+# counted.
+type CampaignStats {
+    # The count of all changesets.
+    total: Int!
+    # The count of unpublished changesets.
+    unpublished: Int!
+    # The count of externalState: OPEN changesets that aren't archived.
+    open: Int!
+    # The count of externalState: MERGED changesets.
+    merged: Int!
+    # The count of externalState: CLOSED changesets.
+    closed: Int!
+    # The count of archived changesets. See ExternalChangeset.archivedAt.
+    archived: Int!
+    # The diff stat for all the changesets in the campaign. Like Campaign.diffStat, it only
+    # includes the changesets in repositories the viewer has access to.
+    diffStat: DiffStat!
+    # When a changeset of the campaign was last synced with the code host. Null if none of its
+    # changesets are synced.
+    lastSyncedAt: DateTime
+}
+
+# The cron schedule on which a campaign is re-applied.
+type CampaignReapplySchedule {
+    # The cron expression of the schedule.
+    schedule: String!
+    # The date and time when the campaign is re-applied next.
+    nextRunAt: DateTime!
+    # The date and time when the campaign was last re-applied on this schedule, if ever.
+    lastRunAt: DateTime
+}
+
+# An event of a campaign that users can be notified about by email.
+enum CampaignNotificationEvent {
+    # All changesets created by the campaign have been published.
+    ALL_PUBLISHED
+    # A changeset created by the campaign couldn't be published.
+    PUBLICATION_FAILED
+    # The last open changeset of the campaign has been merged.
+    LAST_MERGED
+}
+
+# The result of validating a campaign spec.
+type CampaignSpecValidation {
+    # Whether the campaign spec is valid.
+    valid: Boolean!
+    # The problems found in the campaign spec. Empty if it's valid.
+    errors: [CampaignSpecValidationError!]!
+}
+
+# A problem found in a campaign spec.
+type CampaignSpecValidationError {
+    # A description of the problem.
+    message: String!
+    # The 1-based line of the campaign spec at which the problem was found, if known.
+    line: Int
+    # The 1-based column of the campaign spec at which the problem was found, if known. Null if
+    # only the line is known.
+    column: Int
+}
+
+# The result of linting a campaign spec.
+type CampaignSpecLintResult {
+    # Whether no problems of severity ERROR were found, so that the campaign spec can be created.
+    passed: Boolean!
+    # The problems found in the campaign spec.
+    problems: [CampaignSpecLintProblem!]!
+}
+
+# The repositories that the steps of a campaign spec would be run in.
+type CampaignSpecRepositoryPreview {
+    # The number of repositories.
+    totalCount: Int!
+    # The total number of results of the repositoriesMatchingQuery searches of the spec.
+    searchResultCount: Int!
+    # The repositories, in the order in which they're matched by the spec.
+    nodes: [CampaignSpecPreviewedRepository!]!
+}
+
+# A repository that the steps of a campaign spec would be run in.
+type CampaignSpecPreviewedRepository {
+    # The repository.
+    repository: Repository!
+    # The name of the branch that the steps would be run on.
+    branch: String!
+    # The OID of the commit that the branch points to, or null if it couldn't be resolved, for
+    # example because the repository hasn't been cloned yet.
+    commit: String
+    # Why the branch couldn't be resolved, if commit is null.
+    failureMessage: String
+    # The number of results of the repositoriesMatchingQuery searches of the spec in the repository.
+    # Zero if the repository is listed explicitly.
+    searchResultCount: Int!
+}
+
+# A problem found by linting a campaign spec.
+type CampaignSpecLintProblem {
+    # The rule that the campaign spec violates.
+    rule: CampaignSpecLintRule!
+    # The severity of the problem.
+    severity: CampaignSpecLintSeverity!
+    # A description of the problem.
+    message: String!
+    # The 1-based line of the campaign spec at which the problem was found, if known.
+    line: Int
+    # The 1-based column of the campaign spec at which the problem was found, if known.
+    column: Int
+}
+
+# A rule checked by lintCampaignSpec.
+enum CampaignSpecLintRule {
+    # The campaign spec doesn't pass validation.
+    INVALID_SPEC
+    # The campaign spec has steps but no changesetTemplate, so the changes made by the steps are
+    # never published.
+    STEPS_NOT_PUBLISHED
+    # A repositoriesMatchingQuery doesn't match any repositories.
+    QUERY_MATCHES_NO_REPOSITORIES
+    # A changeset is imported more than once.
+    DUPLICATE_IMPORT_CHANGESETS
+    # The diff of a changeset spec is too large to be reviewed.
+    OVERSIZED_DIFF
+}
+
+# The severity of a problem found by linting a campaign spec.
+enum CampaignSpecLintSeverity {
+    # The campaign spec can't be created.
+    ERROR
+    # The campaign spec can be created, but likely has a mistake.
+    WARNING
+}
+
+# The state of a campaign spec execution.
+enum CampaignSpecExecutionState {
+    # The execution is waiting to be processed.
+    QUEUED
+    # The steps of the campaign spec are being run.
+    PROCESSING
+    # The execution failed. See failureMessage.
+    ERRORED
+    # The execution completed and campaignSpec is set.
+    COMPLETED
+}
+
+# A server-side execution of a campaign spec, started with executeCampaignSpec.
+type CampaignSpecExecution implements Node {
+    # The unique ID of the execution.
+    id: ID!
+    # The state of the execution.
+    state: CampaignSpecExecutionState!
+    # The reason the execution failed, if it's ERRORED.
+    failureMessage: String
+    # The number of repositories in which the steps are run. 0 until they have been resolved.
+    repositoriesTotal: Int!
+    # The number of repositories in which the steps have been run.
+    repositoriesCompleted: Int!
+    # The campaign spec created with the resulting changeset specs, once the execution completed.
+    campaignSpec: CampaignSpec
+    # The namespace in which the campaign spec is created.
+    namespace: Namespace!
+    # The user that started the execution.
+    creator: User
+    # The date when the execution was started.
+    createdAt: DateTime!
+    # The date when the steps started to run, if they did.
+    startedAt: DateTime
+    # The date when the execution completed or failed, if it did.
+    finishedAt: DateTime
+}
+
+# The operations that can be run on changesets with createChangesetBulkOperation.
+enum ChangesetJobType {
+    # Merge the changesets on the code host.
+    MERGE
+    # Close the changesets on the code host. Changesets that aren't open are left unchanged.
+    CLOSE
+    # Comment on the changesets on the code host.
+    COMMENT
+    # Retry reconciling the changesets whose reconciliation failed.
+    RETRY
+}
+
+# The state of a bulk operation.
+enum BulkOperationState {
+    # Some of the changesets haven't been processed yet.
+    PROCESSING
+    # All changesets have been processed and the operation failed on at least one of them.
+    FAILED
+    # The operation completed on all changesets.
+    COMPLETED
+}
+
+# The state of the job of a bulk operation on a single changeset.
+enum BulkOperationItemState {
+    # The job is waiting to be processed.
+    QUEUED
+    # The job is being processed.
+    PROCESSING
+    # The job failed. See failureMessage.
+    ERRORED
+    # The job completed.
+    COMPLETED
+}
+
+# An operation on many changesets of a campaign, started with createChangesetBulkOperation.
+type BulkOperation implements Node {
+    # The unique ID of the bulk operation.
+    id: ID!
+    # The operation run on the changesets.
+    type: ChangesetJobType!
+    # The state of the bulk operation.
+    state: BulkOperationState!
+    # The share of changesets that have been processed, between 0 and 1.
+    progress: Float!
+    # The campaign the changesets belong to.
+    campaign: Campaign
+    # The user that started the bulk operation.
+    initiator: User
+    # The number of changesets the operation is run on.
+    changesetCount: Int!
+    # The result of the operation on every changeset.
+    items: [BulkOperationItem!]!
+    # The date when the bulk operation was started.
+    createdAt: DateTime!
+    # The date when all changesets have been processed, if they have.
+    finishedAt: DateTime
+}
+
+# The result of a bulk operation on a single changeset.
+type BulkOperationItem {
+    # The changeset.
+    changeset: Changeset!
+    # The state of the operation on the changeset.
+    state: BulkOperationItemState!
+    # The reason the operation failed on the changeset, if it's ERRORED.
+    failureMessage: String
+    # The date when the operation completed or failed on the changeset, if it did.
+    finishedAt: DateTime
+}
+
+# Which events of a campaign are emailed to whom.
+type CampaignNotificationSettings {
+    # The events that are emailed.
+    events: [CampaignNotificationEvent!]!
+    # The users that are notified in addition to the campaign's author.
+    subscribers: [User!]!
+}
+
+# The number of published and merged changesets of a campaign at a point in time.
+type CampaignWeeklyMergeStats {
+    # The point in time these counts were recorded.
+    date: DateTime!
+    # The number of published changesets.
+    published: Int!
+    # The number of merged changesets.
+    merged: Int!
+    # The percentage of published changesets that were merged.
+    mergedPercentage: Float!
+}
+
 # A list of campaigns.
 type CampaignConnection {
     # A list of campaigns.
@@ -837,6 +1819,256 @@ enum ChangesetCheckState {
     FAILED
 }
 
+# The class of an error that caused a background job, such as publishing a changeset, to fail.
+enum FailureClass {
+    # A network error that is likely to go away when retried.
+    TRANSIENT_NETWORK
+    # Missing, invalid, or insufficient credentials.
+    AUTH
+    # The rate limit of an external service was exceeded.
+    RATE_LIMIT
+    # An error caused by user input or configuration.
+    USER_CONFIG
+    # Any other error.
+    INTERNAL
+}
+
+# The category of an error that caused Sourcegraph to fail publishing or updating a changeset. See
+# changesetRetryPolicies for which errors are retried automatically.
+enum ChangesetErrorCode {
+    # The credentials used to publish the changeset are missing, invalid, or lack the required
+    # permissions on the code host.
+    AUTH
+    # The rate limit of the code host was exceeded.
+    RATE_LIMIT
+    # A network error occurred that is likely to go away when retried.
+    TRANSIENT
+    # The diff of the changeset doesn't apply to its base branch, and the campaign spec needs to be
+    # updated.
+    MERGE_CONFLICT
+    # The repository of the changeset is archived and can't be changed.
+    REPO_ARCHIVED
+    # Any other error.
+    UNKNOWN
+}
+
+# How Sourcegraph retries changesets that failed with errors of a code.
+type ChangesetRetryPolicy {
+    # The error code that the policy applies to.
+    errorCode: ChangesetErrorCode!
+    # Whether changesets are retried automatically. Changesets that aren't are marked as errored
+    # until the error is resolved and the campaign spec is applied again.
+    retriedAutomatically: Boolean!
+    # How long Sourcegraph waits before retrying a changeset after its first failure, in seconds.
+    # The backoff doubles with every consecutive failure, up to 1 hour, unless maxAttempts is null.
+    # Null if changesets aren't retried automatically.
+    backoffSeconds: Int
+    # How many times Sourcegraph attempts to process a changeset before it's marked as errored,
+    # configured in the campaigns.reconcilerMaxAttempts site configuration setting. 1 if changesets
+    # aren't retried automatically, null if they're retried until they succeed, since the error
+    # resolves itself over time.
+    maxAttempts: Int
+}
+
+# Whether a changeset can be merged into its base branch without conflicts.
+enum ChangesetMergeableState {
+    # The changeset can be merged.
+    MERGEABLE
+    # The changeset has merge conflicts with its base branch.
+    CONFLICTING
+    # The code host has not (yet) determined whether the changeset can be merged.
+    UNKNOWN
+}
+
+# Whether the external URL of a changeset still resolves on the code host.
+enum ChangesetExternalURLState {
+    # The URL resolves.
+    OK
+    # The URL redirects elsewhere or belongs to a repository that has a different name now, for
+    # example because the repository was renamed.
+    MOVED
+    # The changeset doesn't exist anymore on the code host, for example because it was deleted.
+    DEAD
+}
+
+# The kind of reason for which a queued changeset hasn't been processed yet.
+enum ChangesetWaitReasonKind {
+    # The changeset waits for the changesets that were queued before it.
+    QUEUE
+    # The code host's rate limit was exceeded, or the publication budget of the code host configured
+    # in the campaigns.publicationBudget site configuration is used up. The changeset is processed
+    # again once the time in until has passed.
+    RATE_LIMIT
+    # The changeset waits for another operation to finish, such as the clone of its repository.
+    DEPENDENCY
+    # The publication of the changeset is delayed by the rollout windows configured by site admins
+    # in the campaigns.rolloutWindows site configuration. The changeset is published once the
+    # time in until has passed.
+    ROLLOUT_WINDOW
+    # Processing the changeset failed with an error that's retried automatically. It's retried once
+    # the time in until has passed. See numFailures for the number of failed attempts.
+    RETRY
+    # The campaign that owns the changeset is paused. The changeset is processed once the campaign
+    # is resumed.
+    PAUSED
+}
+
+# An operation that the reconciler performs on a changeset.
+enum ChangesetOperation {
+    # Create a commit from the diff of the changeset spec and push it to the changeset's branch.
+    PUSH
+    # Open the changeset on the code host.
+    PUBLISH
+    # Update the attributes of the changeset on the code host, such as its title, body or labels.
+    UPDATE
+}
+
+# The kind of a campaigns advisory lock.
+enum CampaignsAdvisoryLockKind {
+    # Held by the single replica that runs a background job.
+    LEADER
+    # Held by the replica that syncs the changesets of a code host.
+    SYNCER
+}
+
+# An advisory lock held by a replica running campaigns background work.
+type CampaignsAdvisoryLock {
+    # The kind of the lock.
+    kind: CampaignsAdvisoryLockKind!
+    # The name of the job for LEADER locks, the URL of the code host for SYNCER locks. Null if the
+    # code host is unknown, for example because its external service has been deleted.
+    name: String
+    # The process and host name of the replica holding the lock.
+    holder: String!
+    # The process ID of the database backend holding the lock.
+    pid: Int!
+    # When the holder's database connection was opened.
+    connectedAt: DateTime!
+}
+
+# The share of a code host's API rate limit that the publication of campaign changesets may use, so
+# that publishing large campaigns doesn't exhaust the rate limit.
+type CampaignsPublicationBudget {
+    # The base URL of the code host.
+    codeHostURL: String!
+    # The display name of a code host connection to the code host.
+    displayName: String!
+    # The number of API requests per hour that the publication of changesets may use. Null if the
+    # rate limit of the code host is disabled, in which case publications aren't limited either.
+    requestsPerHour: Float
+    # The number of API requests currently left in the budget. Null if the rate limit of the code
+    # host is disabled.
+    remainingRequests: Int
+}
+
+# The limits on the campaigns in a namespace, and the namespace's current usage.
+type CampaignNamespaceQuota {
+    # Whether campaigns can be created in the namespace.
+    enabled: Boolean!
+    # The maximum number of changesets a single campaign in the namespace may contain. Null if
+    # there is no limit.
+    maxChangesetsPerCampaign: Int
+    # The maximum number of open campaigns the namespace may contain. Null if there is no limit.
+    maxOpenCampaigns: Int
+    # The number of open campaigns in the namespace.
+    openCampaigns: Int!
+}
+
+# Site-wide statistics about the usage of campaigns.
+type CampaignsStatistics {
+    # The number of campaigns, not including deleted campaigns.
+    totalCampaigns: Int!
+    # The number of open campaigns, not including deleted campaigns.
+    openCampaigns: Int!
+    # The statistics of the changesets of all campaigns in each week, oldest first.
+    weeks: [CampaignsWeekStatistics!]!
+    # The code hosts with the most published changesets in campaigns, most first.
+    topCodeHosts: [CampaignsCodeHostStatistics!]!
+    # The number of distinct users that applied a campaign spec since the start of the first week.
+    activeAuthors: Int!
+}
+
+# The statistics of the changesets of all campaigns in a single week.
+type CampaignsWeekStatistics {
+    # The start of the week, on Monday.
+    startsAt: DateTime!
+    # The number of changesets that campaigns published in the week.
+    changesetsPublished: Int!
+    # The number of merged changesets in campaigns that were last updated on the code host in the
+    # week.
+    changesetsMerged: Int!
+}
+
+# The statistics of the published changesets of all campaigns on a single code host.
+type CampaignsCodeHostStatistics {
+    # The kind of the code host.
+    externalServiceKind: ExternalServiceKind!
+    # The URL of the code host.
+    externalServiceURL: String!
+    # The number of published changesets in campaigns on the code host.
+    changesets: Int!
+    # The number of those changesets that are merged.
+    mergedChangesets: Int!
+}
+
+# The job that deletes expired campaign specs and changeset specs, and deleted campaigns that can no
+# longer be restored.
+type CampaignSpecExpiration {
+    # The number of hours after which a campaign spec that hasn't been applied expires.
+    campaignSpecHours: Int!
+    # The number of hours after which a changeset spec whose campaign spec hasn't been applied
+    # expires.
+    changesetSpecHours: Int!
+    # The number of minutes between two runs of the job.
+    intervalMinutes: Int!
+    # Whether the job only reports what it would delete, without deleting anything.
+    dryRun: Boolean!
+    # The last run of the job, or null if it hasn't run in the last week.
+    lastRun: CampaignSpecExpirationRun
+}
+
+# A single run of the job that deletes expired campaign specs and changeset specs, and deleted
+# campaigns that can no longer be restored.
+type CampaignSpecExpirationRun {
+    # When the run started.
+    startedAt: DateTime!
+    # When the run finished.
+    finishedAt: DateTime!
+    # Whether the run only determined what it would delete. If true, the counts are what would
+    # have been deleted.
+    dryRun: Boolean!
+    # The number of expired changeset specs that were deleted.
+    changesetSpecsDeleted: Int!
+    # The number of expired campaign specs that were deleted.
+    campaignSpecsDeleted: Int!
+    # The number of deleted campaigns that were removed for good.
+    campaignsDeleted: Int!
+    # The error the run failed with, if any. Rows may have been deleted even if the run failed.
+    error: String
+}
+
+# The reason for which a queued changeset hasn't been processed yet.
+type ChangesetWaitReason {
+    # The kind of the reason.
+    kind: ChangesetWaitReasonKind!
+    # A human-readable description of the reason.
+    message: String!
+    # The time before which the changeset won't be processed, or null if it's processed as soon
+    # as it's its turn.
+    until: DateTime
+    # The 1-based position of the changeset in the queue of changesets ready to be processed.
+    # Only set when kind is QUEUE.
+    queuePosition: Int
+}
+
+# An operation that the reconciler intends to perform on a changeset.
+type ChangesetPlannedOperation {
+    # The operation.
+    operation: ChangesetOperation!
+    # A human-readable description of why the operation is needed.
+    reason: String!
+}
+
 # A label attached to a changeset on a code host.
 type ChangesetLabel {
     # The label's text.
@@ -847,6 +2079,42 @@ type ChangesetLabel {
     description: String
 }
 
+# An individual check of the latest commit of a changeset, such as a GitHub check run or commit
+# status, a Bitbucket Server build status or a GitLab pipeline.
+type ChangesetCheck {
+    # The name of the check.
+    name: String!
+    # The state of the check.
+    state: ChangesetCheckState!
+    # The URL of the check on the continuous integration system, or null if it's unknown.
+    url: String
+}
+
+# A thread of review comments on a line of a file of a changeset.
+type ChangesetReviewThread {
+    # Whether the thread is resolved, or null if the code host doesn't report it.
+    resolved: Boolean
+    # The path of the file the thread is on.
+    path: String!
+    # The line the thread is on, or null if it's unknown. For GitHub, this is the line in the diff
+    # of the file.
+    line: Int
+    # The comments in the thread, ordered by the time they were created.
+    comments: [ChangesetReviewComment!]!
+}
+
+# A comment in a changeset review thread.
+type ChangesetReviewComment {
+    # The username of the author on the code host.
+    author: String!
+    # The body of the comment.
+    body: String!
+    # The URL of the comment on the code host, or null if it's unknown.
+    url: String
+    # The date and time when the comment was created.
+    createdAt: DateTime!
+}
+
 # A changeset on a codehost.
 interface Changeset {
     # The unique ID for the changeset.
@@ -856,6 +2124,8 @@ interface Changeset {
     campaigns(
         # Returns the first n campaigns from the list.
         first: Int
+        # Opaque pagination cursor.
+        after: String
         # Only return campaigns in this state.
         state: CampaignState
     ): CampaignConnection!
@@ -888,6 +2158,8 @@ type HiddenExternalChangeset implements Node & Changeset {
     campaigns(
         # Returns the first n campaigns from the list.
         first: Int
+        # Opaque pagination cursor.
+        after: String
         # Only return campaigns in this state.
         state: CampaignState
     ): CampaignConnection!
@@ -927,14 +2199,23 @@ type ExternalChangeset implements Node & Changeset {
     campaigns(
         # Returns the first n campaigns from the list.
         first: Int
+        # Opaque pagination cursor.
+        after: String
         # Only return campaigns in this state.
         state: CampaignState
         # Only include campaigns that the viewer can administer.
         viewerCanAdminister: Boolean
     ): CampaignConnection!
 
-    # The events belonging to this changeset.
-    events(first: Int): ChangesetEventConnection!
+    # The events belonging to this changeset, in the order they were recorded.
+    events(
+        # Returns the first n events from the list.
+        first: Int
+        # Opaque pagination cursor.
+        after: String
+        # Only return events of these categories. All events are returned if this is null or empty.
+        kinds: [ChangesetEventCategory!]
+    ): ChangesetEventConnection!
 
     # The date and time when the changeset was created.
     createdAt: DateTime!
@@ -957,15 +2238,43 @@ type ExternalChangeset implements Node & Changeset {
     # The reconciler state of the changeset.
     reconcilerState: ChangesetReconcilerState!
 
+    # Why the reconciler hasn't processed the changeset yet. Null unless reconcilerState is QUEUED.
+    waitReason: ChangesetWaitReason
+
+    # The operations that the reconciler intends to perform on the changeset the next time it
+    # processes it, in order, and why. Empty if reconcilerState is COMPLETED or the reconciler has
+    # nothing to do.
+    plannedOperations: [ChangesetPlannedOperation!]!
+
+    # The date and time when the changeset was archived in the campaign that owns it, because a
+    # newer campaign spec no longer contained it. Null if the changeset isn't archived.
+    archivedAt: DateTime
+
+    # Why the publication of the changeset was skipped, because its repository didn't meet the publication preconditions of the campaign spec. Null unless the publication was skipped.
+    skippedReason: String
+
     # The external state of the changeset, or null when not yet published to the code host.
     externalState: ChangesetExternalState
 
     # The labels attached to the changeset on the code host.
     labels: [ChangesetLabel!]!
 
+    # The review threads on lines of files of the changeset, ordered by the time their first
+    # comment was created. Only supported for GitHub and Bitbucket Server.
+    reviewThreads: [ChangesetReviewThread!]!
+
     # The external URL of the changeset on the code host. Not set when changeset state is UNPUBLISHED, PUBLISHING or externalState is DELETED.
     externalURL: ExternalLink
 
+    # Whether the external URL still resolved on the code host when it was last checked, or null if
+    # it was never checked. The URLs of published changesets are checked periodically, and with the
+    # checkChangesetURLs mutation. The URLs of changesets in private repositories aren't requested,
+    # but changesets that were deleted or whose repository was renamed are still detected.
+    externalURLState: ChangesetExternalURLState
+
+    # The date and time when the external URL was last checked, or null if it was never checked.
+    externalURLCheckedAt: DateTime
+
     # The review state of this changeset. This is only set once the changeset is published on the code host.
     reviewState: ChangesetReviewState
 
@@ -990,22 +2299,139 @@ type ExternalChangeset implements Node & Changeset {
     # checks have been configured.
     checkState: ChangesetCheckState
 
+    # The individual checks of the latest commit of this changeset, ordered by name. Empty if no
+    # checks have been configured.
+    checks: [ChangesetCheck!]!
+
+    # Whether the changeset can be merged into its base branch without conflicts, as last reported
+    # by the code host. Null if the changeset is not open.
+    mergeable: ChangesetMergeableState
+
     # An error that has occurred when publishing or updating the changeset. This is only set when the changeset state is ERRORED and the viewer can administer this changeset.
     error: String
+
+    # The class of the error in the error field. This is only set when error is set.
+    errorClass: FailureClass
+
+    # The code of the error in the error field, telling what needs to be done to resolve it. This
+    # is only set when error is set.
+    errorCode: ChangesetErrorCode
+
+    # The number of consecutive times Sourcegraph failed to publish or update the changeset. It's
+    # reset once the changeset is processed successfully or retried manually. See
+    # changesetRetryPolicies for how many attempts are made before the changeset is marked as
+    # errored.
+    numFailures: Int!
+
+    # The custom metadata entries attached to this changeset on Sourcegraph, ordered by key.
+    customMetadata: [ChangesetCustomMetadataEntry!]!
+}
+
+# A custom metadata entry attached to a changeset on Sourcegraph.
+type ChangesetCustomMetadataEntry {
+    # The key of the entry.
+    key: String!
+    # The value of the entry.
+    value: String!
+}
+
+# A custom metadata entry to filter changesets by.
+input ChangesetCustomMetadataInput {
+    # The key of the entry.
+    key: String!
+    # The value of the entry.
+    value: String!
 }
 
 # Used in the campaign page for the overview component.
 type ChangesetConnectionStats {
     # The count of unpublished changesets.
     unpublished: Int!
-    # The count of externalState: OPEN changesets.
+    # The count of externalState: OPEN changesets that aren't archived.
     open: Int!
     # The count of externalState: MERGED changesets.
     merged: Int!
     # The count of externalState: CLOSED changesets.
     closed: Int!
+    # The count of archived changesets. See ExternalChangeset.archivedAt.
+    archived: Int!
+    # The count of externalState: OPEN changesets that aren't archived and have merge conflicts with
+    # their base branch.
+    conflicting: Int!
+    # The count of externalState: OPEN changesets that aren't archived with reviewState: PENDING.
+    unreviewed: Int!
+    # The count of externalState: OPEN changesets that aren't archived with reviewState: APPROVED.
+    approved: Int!
+    # The count of externalState: OPEN changesets that aren't archived with reviewState:
+    # CHANGES_REQUESTED.
+    changesRequested: Int!
     # The count of all changesets. Equal to totalCount of the connection.
     total: Int!
+    # The count of changesets included in total that are in repositories the viewer doesn't have
+    # access to. Always equal to hiddenCount of the connection: when the connection is filtered by
+    # review state, check state or custom metadata, the hidden changesets aren't included in total
+    # and this is the number of hidden changesets matched without those filters.
+    hidden: Int!
+}
+
+# The changesets of a campaign that are in the same repository.
+type ChangesetRepositoryGroup {
+    # The repository, or null if the viewer doesn't have access to it.
+    repository: Repository
+    # The count of all changesets in the repository.
+    total: Int!
+    # The count of unpublished changesets.
+    unpublished: Int!
+    # The count of externalState: OPEN changesets.
+    open: Int!
+    # The count of externalState: MERGED changesets.
+    merged: Int!
+    # The count of externalState: CLOSED changesets.
+    closed: Int!
+    # The sum of the diff stats of the changesets, or null if the viewer doesn't have access to
+    # the repository.
+    diffStat: DiffStat
+}
+
+# The sync status of the changesets of a campaign. Only published changesets of open campaigns are
+# synced with the code host.
+type CampaignSyncStatus {
+    # The sync status of each synced changeset, ordered by changeset ID.
+    changesets: [ChangesetSyncStatus!]!
+    # The date and time when the least recently synced changeset was last synced, or null if no
+    # changesets are synced.
+    oldestSyncAt: DateTime
+    # The count of changesets whose next sync is overdue.
+    overdueCount: Int!
+    # The count of changesets whose last sync failed.
+    erroredCount: Int!
+}
+
+# The sync status of a changeset.
+type ChangesetSyncStatus {
+    # The changeset.
+    changeset: Changeset!
+    # The date and time when the changeset was last synced.
+    lastSyncedAt: DateTime!
+    # The date and time when the next sync of the changeset is scheduled.
+    nextSyncAt: DateTime!
+    # Whether the next sync is scheduled in the past, because the syncer is behind schedule.
+    overdue: Boolean!
+    # The error of the last sync, or null if it succeeded or if the viewer doesn't have access to
+    # the repository of the changeset.
+    syncError: String
+}
+
+# A list of changesets grouped by repository.
+type ChangesetRepositoryGroupConnection {
+    # A list of groups.
+    nodes: [ChangesetRepositoryGroup!]!
+
+    # The total number of repositories with changesets in the campaign.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
 }
 
 # A list of changesets.
@@ -1020,7 +2446,16 @@ type ChangesetConnection {
     pageInfo: PageInfo!
 
     # Stats on all the changesets that are in this connection. Pagination has no effect on the stats.
+    # All counts are computed at once, so use them instead of querying totalCount once per filter.
     stats: ChangesetConnectionStats!
+
+    # The number of changesets matched by this connection that are in repositories the viewer
+    # doesn't have access to. Pagination has no effect on it.
+    #
+    # When the connection is filtered by review state, check state or custom metadata, hidden
+    # changesets are left out of the nodes, since filtering them would reveal those attributes.
+    # In that case, this is the number of hidden changesets matched without those filters.
+    hiddenCount: Int!
 }
 
 # A changeset event in a code host (e.g., a comment on a pull request on GitHub).
@@ -1035,6 +2470,18 @@ type ChangesetEvent implements Node {
     createdAt: DateTime!
 }
 
+# The categories by which changeset events can be filtered, independent of the code host.
+enum ChangesetEventCategory {
+    # Reviews, approvals and review requests, and their dismissals.
+    REVIEW
+    # Comments on the changeset and on its diff.
+    COMMENT
+    # Commits pushed to the changeset.
+    COMMIT
+    # Updates of commit statuses, check runs and pipelines.
+    CHECK_RUN
+}
+
 # A list of changeset events.
 type ChangesetEventConnection {
     # A list of changeset events.
@@ -1213,6 +2660,58 @@ enum CampaignState {
     CLOSED
 }
 
+# The type of the namespace of a campaign.
+enum CampaignNamespaceType {
+    # The campaign is in the namespace of a user.
+    USER
+    # The campaign is in the namespace of an organization.
+    ORG
+}
+
+# The visibility of a campaign.
+enum CampaignVisibility {
+    # The campaign is visible to everyone who can see campaigns.
+    PUBLIC
+    # The campaign is only visible to site admins, its creator, the users with access to its
+    # namespace and the users that have been granted rights on it.
+    NAMESPACE_ONLY
+}
+
+# How re-applying a campaign changes the title and body of its published changesets that were
+# edited on the code host since Sourcegraph last updated them. Changesets that weren't edited are
+# always updated.
+enum CampaignUpdatePropagation {
+    # Edited titles and bodies are replaced with the ones from the campaign spec.
+    OVERWRITE
+    # Edited titles are kept and the body from the campaign spec is appended to edited bodies.
+    APPEND
+    # Edited titles and bodies are left untouched.
+    PRESERVE
+}
+
+# The rights granted on a campaign by a CampaignPermissionGrant.
+enum CampaignPermissionLevel {
+    # The grantee can see the campaign, regardless of its visibility.
+    VIEW
+    # The grantee has admin rights for the campaign, like its author.
+    ADMIN
+}
+
+# Rights on a single campaign that have been granted to a user or to all members of an
+# organization.
+type CampaignPermissionGrant {
+    # The unique ID for the grant.
+    id: ID!
+    # The user or organization the rights have been granted to. Null if it has been deleted.
+    grantee: Namespace
+    # The rights that have been granted.
+    level: CampaignPermissionLevel!
+    # The date and time when the rights were granted.
+    createdAt: DateTime!
+    # The date and time when the grant was last updated.
+    updatedAt: DateTime!
+}
+
 # A query.
 type Query {
     # The root of the query.
@@ -1220,15 +2719,100 @@ type Query {
     # Looks up a node by ID.
     node(id: ID!): Node
 
+    # Looks up a campaign by its namespace and name, e.g. to resolve the URL of a campaign. Returns
+    # null if no such campaign exists or the viewer can't see it.
+    campaign(
+        # The user or organization namespace of the campaign.
+        namespace: ID!
+        # The name of the campaign.
+        name: String!
+    ): Campaign
+
     # A list of campaigns.
     campaigns(
         # Returns the first n campaigns from the list.
         first: Int
+        # Opaque pagination cursor.
+        after: String
         state: CampaignState
         # Only include campaigns that the viewer can administer.
         viewerCanAdminister: Boolean
+        # Only include campaigns whose name contains this query, ignoring case.
+        query: String
+        # Only include campaigns in namespaces of this type.
+        namespaceType: CampaignNamespaceType
+        # Only include campaigns in the viewer's own namespace and in the namespaces of the
+        # organizations the viewer is a member of. Combined with namespaceType, this lists only the
+        # viewer's personal campaigns or only the campaigns of the viewer's organizations.
+        viewerIsNamespaceMember: Boolean
+        # Only include deleted campaigns that can still be restored, instead of excluding them.
+        deleted: Boolean = false
+        # Only include campaigns created at or after this point in time.
+        createdAfter: DateTime
+        # Only include campaigns created before this point in time.
+        createdBefore: DateTime
+        # Only include campaigns closed at or after this point in time.
+        closedAfter: DateTime
+        # Only include campaigns closed before this point in time.
+        closedBefore: DateTime
     ): CampaignConnection!
 
+    # The advisory locks held by the replicas running campaigns background work. Used to diagnose
+    # which replica runs which job and syncs the changesets of which code host.
+    # Only site admins can access this field.
+    campaignsAdvisoryLocks: [CampaignsAdvisoryLock!]!
+
+    # The budgets that the publication of campaign changesets has left on each code host, as
+    # configured in the campaigns.publicationBudget site configuration.
+    # Only site admins can access this field.
+    campaignsPublicationBudgets: [CampaignsPublicationBudget!]!
+
+    # How Sourcegraph retries changesets that failed to be published or updated, for each error code.
+    changesetRetryPolicies: [ChangesetRetryPolicy!]!
+
+    # Site-wide statistics about the usage of campaigns, for tracking their rollout.
+    # Only site admins can access this field.
+    campaignsStatistics(
+        # The number of weeks to return weekly statistics for, including the current week.
+        weeks: Int = 12
+        # The maximum number of code hosts to return statistics for.
+        codeHosts: Int = 5
+    ): CampaignsStatistics!
+
+    # The job that periodically deletes expired campaign specs and changeset specs, and deleted
+    # campaigns that can no longer be restored, as configured in the campaigns.specExpiration site
+    # configuration. Only site admins can access this field.
+    campaignSpecExpiration: CampaignSpecExpiration!
+
+    # The limits that the campaigns.namespaces site configuration imposes on the campaigns in a
+    # namespace, and the namespace's current usage. Only site admins and users with access to the
+    # namespace can access this field.
+    campaignNamespaceQuota(
+        # The namespace (either a user or organization).
+        namespace: ID!
+    ): CampaignNamespaceQuota!
+
+    # Look up a bulk operation on the changesets of a campaign by its ID, to poll its progress.
+    # Only campaign admins can access bulk operations.
+    bulkOperation(id: ID!): BulkOperation
+
+    # The code host credentials of a user for publishing changesets. Only the user and site admins
+    # can list them.
+    campaignsCredentials(
+        # The user whose credentials to list. Defaults to the viewer.
+        user: ID
+    ): [CampaignsCredential!]!
+
+    # A list of campaign templates.
+    campaignTemplates(
+        # Returns the first n campaign templates from the list.
+        first: Int
+        # Opaque pagination cursor.
+        after: String
+        # Only include campaign templates in this namespace (either a user or organization).
+        namespace: ID
+    ): CampaignTemplateConnection!
+
     # Looks up a repository by either name or cloneURL.
     repository(
         # Query the repository by name, for example "github.com/gorilla/mux".
@@ -3339,9 +4923,13 @@ type User implements Node & SettingsSubject & Namespace {
     campaigns(
         # Returns the first n campaigns from the list.
         first: Int
+        # Opaque pagination cursor.
+        after: String
         state: CampaignState
         # Only include campaigns that the viewer can administer.
         viewerCanAdminister: Boolean
+        # Only include campaigns whose name contains this query, ignoring case.
+        query: String
     ): CampaignConnection!
 }
 
@@ -3540,9 +5128,13 @@ type Org implements Node & SettingsSubject & Namespace {
     campaigns(
         # Returns the first n campaigns from the list.
         first: Int
+        # Opaque pagination cursor.
+        after: String
         state: CampaignState
         # Only include campaigns that the viewer can administer.
         viewerCanAdminister: Boolean
+        # Only include campaigns whose name contains this query, ignoring case.
+        query: String
     ): CampaignConnection!
 }
 
@@ -21,6 +21,10 @@ func newTest() *httptestutil.Client {
 		enterpriseServices.GitHubWebhook,
 		enterpriseServices.GitLabWebhook,
 		enterpriseServices.BitbucketServerWebhook,
+		enterpriseServices.BitbucketCloudWebhook,
+		enterpriseServices.CampaignChangesetsExport,
+		enterpriseServices.CampaignPatch,
+		enterpriseServices.CampaignsREST,
 		enterpriseServices.NewCodeIntelUploadHandler,
 	))
 }
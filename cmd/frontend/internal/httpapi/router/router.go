@@ -22,6 +22,11 @@ const (
 	GitHubWebhooks          = "github.webhooks"
 	GitLabWebhooks          = "gitlab.webhooks"
 	BitbucketServerWebhooks = "bitbucketServer.webhooks"
+	BitbucketCloudWebhooks  = "bitbucketCloud.webhooks"
+
+	CampaignChangesetsExport = "campaigns.changesets.export"
+	CampaignPatch            = "campaigns.patch"
+	CampaignsREST            = "campaigns.rest"
 
 	SavedQueriesListAll    = "internal.saved-queries.list-all"
 	SavedQueriesGetInfo    = "internal.saved-queries.get-info"
@@ -68,7 +73,11 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/github-webhooks").Methods("POST").Name(GitHubWebhooks)
 	base.Path("/gitlab-webhooks").Methods("POST").Name(GitLabWebhooks)
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
+	base.Path("/bitbucket-cloud-webhooks").Methods("POST").Name(BitbucketCloudWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
+	base.Path("/campaigns/{id}/changesets.{format:csv|json}").Methods("GET").Name(CampaignChangesetsExport)
+	base.Path("/campaigns/{id}/patch.{format:diff|tar\\.gz}").Methods("GET").Name(CampaignPatch)
+	base.Path("/campaigns/v1/{path:.*}").Methods("GET", "POST").Name(CampaignsREST)
 	base.Path("/src-cli/version").Methods("GET").Name(SrcCliVersion)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCliDownload)
 
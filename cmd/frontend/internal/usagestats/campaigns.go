@@ -11,7 +11,7 @@ import (
 func GetCampaignsUsageStatistics(ctx context.Context) (*types.CampaignsUsageStatistics, error) {
 	const q = `
 SELECT
-    (SELECT COUNT(*) FROM campaigns) AS campaigns_count,
+    (SELECT COUNT(*) FROM campaigns WHERE deleted_at IS NULL) AS campaigns_count,
     COUNT(*) FILTER (WHERE created_by_campaign) AS action_changesets,
     COUNT(*) FILTER (WHERE created_by_campaign AND external_state = 'MERGED') AS action_changesets_merged,
     COUNT(*) FILTER (WHERE added_to_campaign) AS manual_changesets,
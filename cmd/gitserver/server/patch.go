@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -77,6 +78,27 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		}
 	}()
 
+	// Commits are pushed to the repository itself, unless the path of
+	// another repository on the same code host, e.g. a fork, is given.
+	pushURL := remoteURL
+	if req.PushRemotePath != "" {
+		pushURL, err = replaceRemoteURLPath(remoteURL, req.PushRemotePath)
+		if err != nil {
+			log15.Error("Failed to build push URL", "ref", ref, "err", err)
+			resp.SetError(repo, "", "", errors.Wrap(err, "replaceRemoteURLPath"))
+			return http.StatusInternalServerError, resp
+		}
+	}
+	if req.PushUsername != "" {
+		pushURL, err = replaceRemoteURLUser(pushURL, req.PushUsername, req.PushPassword)
+		if err != nil {
+			log15.Error("Failed to build push URL", "ref", ref, "err", err)
+			resp.SetError(repo, "", "", errors.Wrap(err, "replaceRemoteURLUser"))
+			return http.StatusInternalServerError, resp
+		}
+		redactor.sensitive = append(redactor.sensitive, newURLRedactor(pushURL).sensitive...)
+	}
+
 	// Ensure tmp directory exists
 	tmpRepoDir, err := s.tempDir("patch-repo-")
 	if err != nil {
@@ -104,7 +126,7 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	}
 
 	if req.UniqueRef {
-		refs, err := repoRemoteRefs(ctx, remoteURL, ref)
+		refs, err := repoRemoteRefs(ctx, pushURL, ref)
 		if err != nil {
 			log15.Error("Failed to get remote refs", "ref", ref, "err", err)
 			resp.SetError(repo, "", "", errors.Wrap(err, "repoRemoteRefs"))
@@ -242,7 +264,7 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	}
 
 	if req.Push {
-		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL, fmt.Sprintf("%s:%s", cmtHash, ref))
+		cmd = exec.CommandContext(ctx, "git", "push", "--force", pushURL, fmt.Sprintf("%s:%s", cmtHash, ref))
 		cmd.Dir = repoGitDir
 
 		if out, err = run(cmd, "pushing ref"); err != nil {
@@ -279,3 +301,38 @@ func cleanUpTmpRepo(path string) {
 func ensureRefPrefix(ref string) string {
 	return "refs/heads/" + strings.TrimPrefix(ref, "refs/heads/")
 }
+
+// replaceRemoteURLUser returns remoteURL with its user info replaced by the
+// given username and password. The password is omitted if it's empty.
+func replaceRemoteURLUser(remoteURL, username, password string) (string, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return "", err
+	}
+
+	if password == "" {
+		u.User = url.User(username)
+	} else {
+		u.User = url.UserPassword(username, password)
+	}
+	return u.String(), nil
+}
+
+// replaceRemoteURLPath returns remoteURL with its path replaced by the given
+// repository path, keeping the ".git" suffix if remoteURL has one.
+func replaceRemoteURLPath(remoteURL, repoPath string) (string, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", errors.New("remote URL has no host")
+	}
+
+	p := "/" + strings.Trim(repoPath, "/")
+	if strings.HasSuffix(u.Path, ".git") {
+		p += ".git"
+	}
+	u.Path, u.RawPath = p, ""
+	return u.String(), nil
+}
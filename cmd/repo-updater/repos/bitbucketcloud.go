@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"sync"
 
 	"github.com/inconshreveable/log15"
@@ -14,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -231,3 +233,157 @@ func (s *BitbucketCloudSource) listAllRepos(ctx context.Context, results chan So
 		}
 	}
 }
+
+var _ ChangesetSource = &BitbucketCloudSource{}
+
+// CreateChangeset creates a Bitbucket Cloud pull request. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s *BitbucketCloudSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	repo := c.Repo.Metadata.(*bitbucketcloud.Repo)
+	source := git.AbbreviateRef(c.HeadRef)
+	destination := git.AbbreviateRef(c.BaseRef)
+
+	// Bitbucket Cloud doesn't reject pull requests for branches that already
+	// have an open pull request, so we have to check for one first.
+	exists := true
+	pr, err := s.client.FindOpenPullRequest(ctx, repo, source, destination)
+	if err == bitbucketcloud.ErrPullRequestNotFound {
+		exists = false
+
+		in := bitbucketcloud.PullRequestInput{
+			Title:             c.Title,
+			Description:       c.Body,
+			SourceBranch:      source,
+			DestinationBranch: destination,
+		}
+		if c.ForkNamespace != "" {
+			in.SourceRepo = c.ForkNamespace + "/" + repo.Slug
+		}
+
+		pr, err = s.client.CreatePullRequest(ctx, repo, in)
+		if err != nil {
+			return exists, errors.Wrap(err, "creating the pull request")
+		}
+	} else if err != nil {
+		return exists, errors.Wrap(err, "retrieving an extant pull request")
+	}
+
+	if err := s.loadPullRequestData(ctx, repo, pr); err != nil {
+		return exists, errors.Wrap(err, "loading extra metadata")
+	}
+	if err := c.SetMetadata(pr); err != nil {
+		return exists, errors.Wrap(err, "setting changeset metadata")
+	}
+	return exists, nil
+}
+
+// CloseChangeset declines the pull request on Bitbucket Cloud.
+func (s *BitbucketCloudSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	declined, err := s.client.DeclinePullRequest(ctx, c.Repo.Metadata.(*bitbucketcloud.Repo), pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "declining Bitbucket Cloud pull request")
+	}
+
+	// The statuses of the pull request don't change when it's declined.
+	declined.Statuses = pr.Statuses
+	if err := c.SetMetadata(declined); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// MergeChangeset merges the pull request on Bitbucket Cloud.
+func (s *BitbucketCloudSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	merged, err := s.client.MergePullRequest(ctx, c.Repo.Metadata.(*bitbucketcloud.Repo), pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "merging Bitbucket Cloud pull request")
+	}
+
+	merged.Statuses = pr.Statuses
+	if err := c.SetMetadata(merged); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// LoadChangesets loads the given pull requests from Bitbucket Cloud and
+// updates them. Like on GitLab, this needs two requests per pull request.
+func (s *BitbucketCloudSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
+	var notFound []*Changeset
+
+	for _, c := range cs {
+		repo := c.Repo.Metadata.(*bitbucketcloud.Repo)
+
+		id, err := strconv.ParseInt(c.ExternalID, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parsing changeset external ID %s", c.ExternalID)
+		}
+
+		pr, err := s.client.GetPullRequest(ctx, repo, id)
+		if err != nil {
+			if bitbucketcloud.IsNotFound(err) {
+				notFound = append(notFound, c)
+				if c.Changeset.Metadata == nil {
+					c.Changeset.Metadata = &bitbucketcloud.PullRequest{ID: id}
+				}
+				continue
+			}
+			return errors.Wrapf(err, "retrieving pull request %d", id)
+		}
+
+		if err := s.loadPullRequestData(ctx, repo, pr); err != nil {
+			return errors.Wrapf(err, "retrieving additional data for pull request %d", id)
+		}
+
+		if err := c.SetMetadata(pr); err != nil {
+			return errors.Wrapf(err, "setting changeset metadata for pull request %d", id)
+		}
+	}
+
+	if len(notFound) > 0 {
+		return ChangesetsNotFoundError{Changesets: notFound}
+	}
+
+	return nil
+}
+
+func (s *BitbucketCloudSource) loadPullRequestData(ctx context.Context, repo *bitbucketcloud.Repo, pr *bitbucketcloud.PullRequest) error {
+	statuses, err := s.client.GetPullRequestStatuses(ctx, repo, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "loading pull request statuses")
+	}
+	pr.Statuses = statuses
+	return nil
+}
+
+// UpdateChangeset updates the pull request on Bitbucket Cloud to reflect the
+// local state of the Changeset.
+func (s *BitbucketCloudSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, c.Repo.Metadata.(*bitbucketcloud.Repo), pr.ID, bitbucketcloud.PullRequestInput{
+		Title:             c.Title,
+		Description:       c.Body,
+		DestinationBranch: git.AbbreviateRef(c.BaseRef),
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating Bitbucket Cloud pull request")
+	}
+
+	updated.Statuses = pr.Statuses
+	c.Changeset.Metadata = updated
+	return nil
+}
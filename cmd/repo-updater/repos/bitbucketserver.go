@@ -85,6 +85,13 @@ func (s BitbucketServerSource) ListRepos(ctx context.Context, results chan Sourc
 }
 
 var _ ChangesetSource = BitbucketServerSource{}
+var _ AuthenticatingSource = BitbucketServerSource{}
+
+// ValidateAuthentication returns an error if the username and token of the
+// external service don't authenticate that Bitbucket Server user.
+func (s BitbucketServerSource) ValidateAuthentication(ctx context.Context) error {
+	return s.client.LoadUser(ctx, &bitbucketserver.User{Slug: s.config.Username})
+}
 
 // CreateChangeset creates the given *Changeset in the code host.
 func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
@@ -144,6 +151,23 @@ func (s BitbucketServerSource) CloseChangeset(ctx context.Context, c *Changeset)
 	return nil
 }
 
+// MergeChangeset merges a Changeset on the code host.
+func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	err := s.client.MergePullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
 // LoadChangesets loads the latest state of the given Changesets from the codehost.
 func (s BitbucketServerSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
 	var notFound []*Changeset
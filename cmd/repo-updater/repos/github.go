@@ -12,6 +12,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -151,18 +152,38 @@ func (s GithubSource) ExternalServices() ExternalServices {
 	return ExternalServices{s.svc}
 }
 
-var _ ChangesetSource = GithubSource{}
+var _ ForkableChangesetSource = GithubSource{}
+var _ AuthenticatingSource = GithubSource{}
+var _ ReviewRequestingChangesetSource = GithubSource{}
+var _ AssigningChangesetSource = GithubSource{}
+var _ LabelingChangesetSource = GithubSource{}
+var _ CommentingChangesetSource = GithubSource{}
+var _ CommitStatusSource = GithubSource{}
+var _ ChangesetStateSource = GithubSource{}
+
+// ValidateAuthentication returns an error if the token of the external
+// service doesn't authenticate a GitHub user.
+func (s GithubSource) ValidateAuthentication(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUser(ctx)
+	return err
+}
 
 // CreateChangeset creates the given *Changeset in the code host.
 func (s GithubSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
 	var exists bool
 	repo := c.Repo.Metadata.(*github.Repository)
 
+	// Pull requests from a fork name the head ref as "{namespace}:{branch}".
+	headRefName := git.AbbreviateRef(c.HeadRef)
+	if c.ForkNamespace != "" {
+		headRefName = c.ForkNamespace + ":" + headRefName
+	}
+
 	pr, err := s.client.CreatePullRequest(ctx, &github.CreatePullRequestInput{
 		RepositoryID: repo.ID,
 		Title:        c.Title,
 		Body:         c.Body,
-		HeadRefName:  git.AbbreviateRef(c.HeadRef),
+		HeadRefName:  headRefName,
 		BaseRefName:  git.AbbreviateRef(c.BaseRef),
 	})
 
@@ -206,6 +227,23 @@ func (s GithubSource) CloseChangeset(ctx context.Context, c *Changeset) error {
 	return nil
 }
 
+// MergeChangeset merges a Changeset on the code host.
+func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	err := s.client.MergePullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
 // LoadChangesets loads the latest state of the given Changesets from the codehost.
 func (s GithubSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
 	prs := make([]*github.PullRequest, len(cs))
@@ -236,6 +274,39 @@ func (s GithubSource) LoadChangesets(ctx context.Context, cs ...*Changeset) erro
 	return nil
 }
 
+// LoadChangesetStates loads only the state of the given Changesets from the
+// codehost, using a batched GraphQL query.
+func (s GithubSource) LoadChangesetStates(ctx context.Context, cs ...*Changeset) (map[int64]campaigns.ChangesetExternalState, error) {
+	prs := make([]*github.PullRequest, len(cs))
+	for i := range cs {
+		repo := cs[i].Repo.Metadata.(*github.Repository)
+		number, err := strconv.ParseInt(cs[i].ExternalID, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing changeset external id")
+		}
+
+		prs[i] = &github.PullRequest{
+			RepoWithOwner: repo.NameWithOwner,
+			Number:        number,
+		}
+	}
+
+	if err := s.client.LoadPullRequestStates(ctx, prs...); err != nil {
+		return nil, err
+	}
+
+	states := make(map[int64]campaigns.ChangesetExternalState, len(cs))
+	for i := range cs {
+		if prs[i].State == "" {
+			states[cs[i].Changeset.ID] = campaigns.ChangesetExternalStateDeleted
+			continue
+		}
+		states[cs[i].Changeset.ID] = campaigns.ChangesetExternalState(prs[i].State)
+	}
+
+	return states, nil
+}
+
 // UpdateChangeset updates the given *Changeset in the code host.
 func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
@@ -259,6 +330,201 @@ func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 	return nil
 }
 
+// RequestReviews requests reviews of the given *Changeset from the given
+// users and teams. The author of the pull request is skipped, since GitHub
+// doesn't allow authors to review their own pull requests.
+func (s GithubSource) RequestReviews(ctx context.Context, c *Changeset, reviewers []string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+	repo := c.Repo.Metadata.(*github.Repository)
+
+	var users, teams []string
+	for _, r := range reviewers {
+		if i := strings.Index(r, "/"); i >= 0 {
+			teams = append(teams, r[i+1:])
+			continue
+		}
+		if !strings.EqualFold(r, pr.Author.Login) {
+			users = append(users, r)
+		}
+	}
+	if len(users) == 0 && len(teams) == 0 {
+		return nil
+	}
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	return s.client.RequestReviews(ctx, owner, name, pr.Number, users, teams)
+}
+
+// AssignChangeset adds the given users to the assignees of the given
+// *Changeset.
+func (s GithubSource) AssignChangeset(ctx context.Context, c *Changeset, assignees []string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+	repo := c.Repo.Metadata.(*github.Repository)
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	return s.client.AddAssignees(ctx, owner, name, pr.Number, assignees)
+}
+
+// CreateComment adds a comment with the given body to the given *Changeset.
+func (s GithubSource) CreateComment(ctx context.Context, c *Changeset, body string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+	repo := c.Repo.Metadata.(*github.Repository)
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	return s.client.CreateComment(ctx, owner, name, pr.Number, body)
+}
+
+// LabelChangeset adds the given labels to the given *Changeset and removes
+// the given removed labels from it.
+func (s GithubSource) LabelChangeset(ctx context.Context, c *Changeset, labels, removed []string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+	repo := c.Repo.Metadata.(*github.Repository)
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	for _, label := range removed {
+		if err := s.client.RemoveLabel(ctx, owner, name, pr.Number, label); err != nil {
+			return err
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return s.client.AddLabels(ctx, owner, name, pr.Number, labels)
+}
+
+// SetChangesetMilestone sets the milestone of the given *Changeset.
+func (s GithubSource) SetChangesetMilestone(ctx context.Context, c *Changeset, milestone string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+	repo := c.Repo.Metadata.(*github.Repository)
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	m, err := s.client.GetOrCreateMilestone(ctx, owner, name, milestone)
+	if err != nil {
+		return errors.Wrap(err, "getting milestone")
+	}
+	return s.client.SetMilestone(ctx, owner, name, pr.Number, m.Number)
+}
+
+// CommitCheckState returns the combined state of the commit statuses and
+// check runs of the commit that the given ref of the given repository points
+// to. Failed statuses or checks take precedence over pending ones, and those
+// over passed ones.
+func (s GithubSource) CommitCheckState(ctx context.Context, r *Repo, ref string) (campaigns.ChangesetCheckState, error) {
+	repo := r.Metadata.(*github.Repository)
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return "", errors.Wrap(err, "getting repo owner and name")
+	}
+
+	status, err := s.client.GetCombinedStatus(ctx, owner, name, ref)
+	if err != nil {
+		return "", errors.Wrap(err, "getting commit status")
+	}
+	runs, err := s.client.ListCheckRuns(ctx, owner, name, ref)
+	if err != nil {
+		return "", errors.Wrap(err, "listing check runs")
+	}
+
+	var states []campaigns.ChangesetCheckState
+	if status.TotalCount > 0 {
+		switch status.State {
+		case "success":
+			states = append(states, campaigns.ChangesetCheckStatePassed)
+		case "pending":
+			states = append(states, campaigns.ChangesetCheckStatePending)
+		default:
+			states = append(states, campaigns.ChangesetCheckStateFailed)
+		}
+	}
+	for _, run := range runs {
+		switch {
+		case run.Status != "COMPLETED":
+			states = append(states, campaigns.ChangesetCheckStatePending)
+		case run.Conclusion == "SUCCESS", run.Conclusion == "NEUTRAL", run.Conclusion == "SKIPPED":
+			states = append(states, campaigns.ChangesetCheckStatePassed)
+		default:
+			states = append(states, campaigns.ChangesetCheckStateFailed)
+		}
+	}
+
+	return combineCheckStates(states), nil
+}
+
+// combineCheckStates returns the combined state of the given check states:
+// failed if any of them failed, otherwise pending if any of them is pending,
+// passed if any passed, and unknown if there are none.
+func combineCheckStates(states []campaigns.ChangesetCheckState) campaigns.ChangesetCheckState {
+	combined := campaigns.ChangesetCheckStateUnknown
+	for _, s := range states {
+		switch s {
+		case campaigns.ChangesetCheckStateFailed:
+			return s
+		case campaigns.ChangesetCheckStatePending:
+			combined = s
+		case campaigns.ChangesetCheckStatePassed:
+			if combined == campaigns.ChangesetCheckStateUnknown {
+				combined = s
+			}
+		}
+	}
+	return combined
+}
+
+// EnsureFork forks the given repository into the namespace of the user
+// authenticated by the token of the external service. Forking a repository
+// that the user already forked returns the existing fork.
+func (s GithubSource) EnsureFork(ctx context.Context, r *Repo) (namespace, name string, err error) {
+	repo := r.Metadata.(*github.Repository)
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return "", "", errors.Wrap(err, "getting repo owner and name")
+	}
+
+	fork, err := s.client.Fork(ctx, owner, name)
+	if err != nil {
+		return "", "", errors.Wrap(err, "forking repository")
+	}
+
+	return github.SplitRepositoryNameWithOwner(fork.NameWithOwner)
+}
+
 // GetRepo returns the Github repository with the given name and owner
 // ("org/repo-name")
 func (s GithubSource) GetRepo(ctx context.Context, nameWithOwner string) (*Repo, error) {
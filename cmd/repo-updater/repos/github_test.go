@@ -710,3 +710,26 @@ func githubGraphQLFailureMiddleware(cli httpcli.Doer) httpcli.Doer {
 		return cli.Do(req)
 	})
 }
+
+func TestCombineCheckStates(t *testing.T) {
+	const (
+		unknown = campaigns.ChangesetCheckStateUnknown
+		pending = campaigns.ChangesetCheckStatePending
+		passed  = campaigns.ChangesetCheckStatePassed
+		failed  = campaigns.ChangesetCheckStateFailed
+	)
+
+	for _, tc := range []struct {
+		states []campaigns.ChangesetCheckState
+		want   campaigns.ChangesetCheckState
+	}{
+		{states: nil, want: unknown},
+		{states: []campaigns.ChangesetCheckState{passed, passed}, want: passed},
+		{states: []campaigns.ChangesetCheckState{passed, pending, passed}, want: pending},
+		{states: []campaigns.ChangesetCheckState{pending, failed, passed}, want: failed},
+	} {
+		if have := combineCheckStates(tc.states); have != tc.want {
+			t.Errorf("combineCheckStates(%v): want=%s, have=%s", tc.states, tc.want, have)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -302,6 +303,18 @@ func projectQueryToURL(projectQuery string, perPage int) (string, error) {
 	return u.String(), nil
 }
 
+var _ AuthenticatingSource = &GitLabSource{}
+var _ AssigningChangesetSource = &GitLabSource{}
+var _ LabelingChangesetSource = &GitLabSource{}
+var _ ChangesetStateSource = &GitLabSource{}
+
+// ValidateAuthentication returns an error if the token of the external
+// service doesn't authenticate a GitLab user.
+func (s *GitLabSource) ValidateAuthentication(ctx context.Context) error {
+	_, err := s.client.GetUser(ctx, "")
+	return err
+}
+
 // CreateChangeset creates a GitLab merge request. If it already exists,
 // *Changeset will be populated and the return value will be true.
 func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
@@ -359,6 +372,24 @@ func (s *GitLabSource) CloseChangeset(ctx context.Context, c *Changeset) error {
 	return nil
 }
 
+// MergeChangeset merges the merge request on GitLab.
+func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	merged, err := s.client.MergeMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr)
+	if err != nil {
+		return errors.Wrap(err, "merging GitLab merge request")
+	}
+
+	if err := c.SetMetadata(merged); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
 // LoadChangesets loads the given merge requests from GitLab and updates them.
 // Note that this is an O(n) operation due to limitations in the GitLab REST
 // API.
@@ -393,6 +424,71 @@ func (s *GitLabSource) LoadChangesets(ctx context.Context, cs ...*Changeset) err
 	return nil
 }
 
+// LoadChangesetStates loads only the state of the given Changesets from the
+// codehost, listing the merge requests of each project at once.
+func (s *GitLabSource) LoadChangesetStates(ctx context.Context, cs ...*Changeset) (map[int64]campaigns.ChangesetExternalState, error) {
+	type projectChangesets struct {
+		project *gitlab.Project
+		byIID   map[gitlab.ID]*Changeset
+		iids    []gitlab.ID
+	}
+
+	var projects []*projectChangesets
+	byProject := map[int]*projectChangesets{}
+	for _, c := range cs {
+		project := c.Repo.Metadata.(*gitlab.Project)
+
+		iid, err := strconv.ParseInt(c.ExternalID, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing changeset external ID %s", c.ExternalID)
+		}
+
+		p, ok := byProject[project.ID]
+		if !ok {
+			p = &projectChangesets{project: project, byIID: map[gitlab.ID]*Changeset{}}
+			byProject[project.ID] = p
+			projects = append(projects, p)
+		}
+		p.byIID[gitlab.ID(iid)] = c
+		p.iids = append(p.iids, gitlab.ID(iid))
+	}
+
+	states := make(map[int64]campaigns.ChangesetExternalState, len(cs))
+	for _, p := range projects {
+		mrs, err := s.client.ListMergeRequestsByIIDs(ctx, p.project, p.iids)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing merge requests of project %d", p.project.ID)
+		}
+
+		for _, mr := range mrs {
+			c, ok := p.byIID[mr.IID]
+			if !ok {
+				continue
+			}
+
+			switch mr.State {
+			case gitlab.MergeRequestStateClosed, gitlab.MergeRequestStateLocked:
+				states[c.Changeset.ID] = campaigns.ChangesetExternalStateClosed
+			case gitlab.MergeRequestStateMerged:
+				states[c.Changeset.ID] = campaigns.ChangesetExternalStateMerged
+			case gitlab.MergeRequestStateOpened:
+				states[c.Changeset.ID] = campaigns.ChangesetExternalStateOpen
+			default:
+				return nil, errors.Errorf("unknown GitLab merge request state: %s", mr.State)
+			}
+		}
+
+		// Merge requests that weren't returned don't exist anymore.
+		for _, c := range p.byIID {
+			if _, ok := states[c.Changeset.ID]; !ok {
+				states[c.Changeset.ID] = campaigns.ChangesetExternalStateDeleted
+			}
+		}
+	}
+
+	return states, nil
+}
+
 func (s *GitLabSource) decorateMergeRequestData(ctx context.Context, project *gitlab.Project, mr, old *gitlab.MergeRequest) error {
 	notes, err := s.getMergeRequestNotes(ctx, project, mr, old)
 	if err != nil {
@@ -555,3 +651,73 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	c.Changeset.Metadata = updated
 	return nil
 }
+
+// AssignChangeset sets the assignees of the GitLab merge request to the users
+// with the given usernames.
+func (s *GitLabSource) AssignChangeset(ctx context.Context, c *Changeset, assignees []string) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	// GitLab assigns merge requests by user ID, so we need to look up the
+	// users first.
+	ids := make([]int32, 0, len(assignees))
+	for _, username := range assignees {
+		users, _, err := s.client.ListUsers(ctx, "users?username="+url.QueryEscape(username))
+		if err != nil {
+			return errors.Wrapf(err, "looking up GitLab user %q", username)
+		}
+		if len(users) == 0 {
+			return errors.Errorf("GitLab user %q not found", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+
+	updated, err := s.client.SetMergeRequestAssignees(ctx, c.Repo.Metadata.(*gitlab.Project), mr, ids)
+	if err != nil {
+		return errors.Wrap(err, "assigning GitLab merge request")
+	}
+
+	c.Changeset.Metadata = updated
+	return nil
+}
+
+// LabelChangeset adds the given labels to the GitLab merge request and
+// removes the given removed labels from it.
+func (s *GitLabSource) LabelChangeset(ctx context.Context, c *Changeset, labels, removed []string) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	updated, err := s.client.SetMergeRequestLabels(ctx, c.Repo.Metadata.(*gitlab.Project), mr, labels, removed)
+	if err != nil {
+		return errors.Wrap(err, "labeling GitLab merge request")
+	}
+
+	c.Changeset.Metadata = updated
+	return nil
+}
+
+// SetChangesetMilestone sets the milestone of the GitLab merge request.
+func (s *GitLabSource) SetChangesetMilestone(ctx context.Context, c *Changeset, milestone string) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.Repo.Metadata.(*gitlab.Project)
+
+	m, err := s.client.GetOrCreateMilestone(ctx, project, milestone)
+	if err != nil {
+		return errors.Wrap(err, "getting GitLab milestone")
+	}
+
+	updated, err := s.client.SetMergeRequestMilestone(ctx, project, mr, m.ID)
+	if err != nil {
+		return errors.Wrap(err, "setting GitLab merge request milestone")
+	}
+
+	c.Changeset.Metadata = updated
+	return nil
+}
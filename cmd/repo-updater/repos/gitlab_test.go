@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -354,6 +355,47 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("MergeChangeset", func(t *testing.T) {
+		t.Run("invalid metadata", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = struct{}{}
+
+			if err := p.source.MergeChangeset(p.ctx, p.changeset); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+
+		t.Run("error from MergeMergeRequest", func(t *testing.T) {
+			inner := errors.New("foo")
+			mr := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = mr
+			p.mockMergeMergeRequest(mr, nil, inner)
+
+			have := p.source.MergeChangeset(p.ctx, p.changeset)
+			if !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			want := &gitlab.MergeRequest{State: gitlab.MergeRequestStateMerged}
+			mr := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = mr
+			p.mockMergeMergeRequest(mr, want, nil)
+
+			if err := p.source.MergeChangeset(p.ctx, p.changeset); err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+			if p.changeset.Changeset.Metadata != want {
+				t.Errorf("unexpected metadata: have %+v; want %+v", p.changeset.Changeset.Metadata, want)
+			}
+		})
+	})
+
 	t.Run("LoadChangesets", func(t *testing.T) {
 		t.Run("invalid metadata", func(t *testing.T) {
 			defer func() { _ = recover() }()
@@ -528,6 +570,65 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("LoadChangesetStates", func(t *testing.T) {
+		t.Run("error from ParseInt", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.ExternalID = "foo"
+
+			if _, err := p.source.LoadChangesetStates(p.ctx, p.changeset); err == nil {
+				t.Error("invalid ExternalID did not result in an error")
+			}
+		})
+
+		t.Run("error from ListMergeRequestsByIIDs", func(t *testing.T) {
+			inner := errors.New("foo")
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.ExternalID = "42"
+			p.mockListMergeRequestsByIIDs([]gitlab.ID{42}, nil, inner)
+
+			if _, have := p.source.LoadChangesetStates(p.ctx, p.changeset); !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			project := p.changeset.Repo.Metadata.(*gitlab.Project)
+
+			p.changeset.Changeset.ID = 1
+			p.changeset.Changeset.ExternalID = "42"
+			changesets := []*Changeset{p.changeset}
+			for i := int64(2); i <= 4; i++ {
+				changesets = append(changesets, &Changeset{
+					Changeset: &campaigns.Changeset{ID: i, ExternalID: strconv.FormatInt(i+41, 10)},
+					Repo:      &Repo{Metadata: project},
+				})
+			}
+
+			p.mockListMergeRequestsByIIDs([]gitlab.ID{42, 43, 44, 45}, []*gitlab.MergeRequest{
+				{IID: 42, State: gitlab.MergeRequestStateOpened},
+				{IID: 43, State: gitlab.MergeRequestStateLocked},
+				{IID: 44, State: gitlab.MergeRequestStateMerged},
+			}, nil)
+
+			have, err := p.source.LoadChangesetStates(p.ctx, changesets...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := map[int64]campaigns.ChangesetExternalState{
+				1: campaigns.ChangesetExternalStateOpen,
+				2: campaigns.ChangesetExternalStateClosed,
+				3: campaigns.ChangesetExternalStateMerged,
+				4: campaigns.ChangesetExternalStateDeleted,
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Errorf("unexpected states (-want +have):\n%s", diff)
+			}
+		})
+	})
+
 	t.Run("UpdateChangeset", func(t *testing.T) {
 		t.Run("invalid metadata", func(t *testing.T) {
 			p := newGitLabChangesetSourceTestProvider(t)
@@ -573,6 +674,102 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("AssignChangeset", func(t *testing.T) {
+		t.Run("invalid metadata", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+
+			err := p.source.AssignChangeset(p.ctx, &Changeset{
+				Changeset: &campaigns.Changeset{Metadata: struct{}{}},
+			}, []string{"alice"})
+			if err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+
+		t.Run("unknown user", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = p.mr
+			p.mockListUsers(map[string]int32{})
+
+			if err := p.source.AssignChangeset(p.ctx, p.changeset, []string{"alice"}); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			out := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = p.mr
+			p.mockListUsers(map[string]int32{"alice": 1, "bob": 2})
+			p.mockSetMergeRequestAssignees(p.mr, []int32{2, 1}, out, nil)
+
+			if err := p.source.AssignChangeset(p.ctx, p.changeset, []string{"bob", "alice"}); err != nil {
+				t.Errorf("unexpected non-nil error: %+v", err)
+			}
+			if p.changeset.Changeset.Metadata != out {
+				t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
+			}
+		})
+	})
+
+	t.Run("LabelChangeset", func(t *testing.T) {
+		t.Run("error from SetMergeRequestLabels", func(t *testing.T) {
+			inner := errors.New("foo")
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = p.mr
+			p.mockSetMergeRequestLabels(p.mr, []string{"a"}, nil, nil, inner)
+
+			have := p.source.LabelChangeset(p.ctx, p.changeset, []string{"a"}, nil)
+			if !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			out := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = p.mr
+			p.mockSetMergeRequestLabels(p.mr, []string{"a", "b"}, []string{"c"}, out, nil)
+
+			if err := p.source.LabelChangeset(p.ctx, p.changeset, []string{"a", "b"}, []string{"c"}); err != nil {
+				t.Errorf("unexpected non-nil error: %+v", err)
+			}
+			if p.changeset.Changeset.Metadata != out {
+				t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
+			}
+		})
+	})
+
+	t.Run("SetChangesetMilestone", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			out := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = p.mr
+			gitlab.MockGetOrCreateMilestone = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, title string) (*gitlab.Milestone, error) {
+				p.testCommonParams(ctx, client, project)
+				return &gitlab.Milestone{ID: 7, Title: title}, nil
+			}
+			gitlab.MockSetMergeRequestMilestone = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, milestoneID gitlab.ID) (*gitlab.MergeRequest, error) {
+				p.testCommonParams(ctx, client, project)
+				if milestoneID != 7 {
+					t.Errorf("unexpected milestone ID: have %d; want %d", milestoneID, 7)
+				}
+				return out, nil
+			}
+
+			if err := p.source.SetChangesetMilestone(p.ctx, p.changeset, "v1"); err != nil {
+				t.Errorf("unexpected non-nil error: %+v", err)
+			}
+			if p.changeset.Changeset.Metadata != out {
+				t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
+			}
+		})
+	})
 }
 
 func TestReadNotesUntilSeen(t *testing.T) {
@@ -857,6 +1054,16 @@ func (p *gitLabChangesetSourceTestProvider) mockGetOpenMergeRequestByRefs(mr *gi
 	}
 }
 
+func (p *gitLabChangesetSourceTestProvider) mockListMergeRequestsByIIDs(expected []gitlab.ID, mrs []*gitlab.MergeRequest, err error) {
+	gitlab.MockListMergeRequestsByIIDs = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, iids []gitlab.ID) ([]*gitlab.MergeRequest, error) {
+		p.testCommonParams(ctx, client, project)
+		if diff := cmp.Diff(expected, iids); diff != "" {
+			p.t.Errorf("unexpected IIDs: %s", diff)
+		}
+		return mrs, err
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) mockUpdateMergeRequest(expectedMR, updated *gitlab.MergeRequest, err error) {
 	gitlab.MockUpdateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
 		p.testCommonParams(ctx, client, project)
@@ -867,13 +1074,75 @@ func (p *gitLabChangesetSourceTestProvider) mockUpdateMergeRequest(expectedMR, u
 	}
 }
 
+func (p *gitLabChangesetSourceTestProvider) mockMergeMergeRequest(expectedMR, merged *gitlab.MergeRequest, err error) {
+	gitlab.MockMergeMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest) (*gitlab.MergeRequest, error) {
+		p.testCommonParams(ctx, client, project)
+		if expectedMR != mrIn {
+			p.t.Errorf("unexpected MergeRequest: have %+v; want %+v", mrIn, expectedMR)
+		}
+		return merged, err
+	}
+}
+
+// mockListUsers mocks gitlab.ListUsers calls that look up users by username,
+// returning the users with the given usernames and IDs.
+func (p *gitLabChangesetSourceTestProvider) mockListUsers(ids map[string]int32) {
+	gitlab.MockListUsers = func(client *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.User, *string, error) {
+		u, err := url.Parse(urlStr)
+		if err != nil {
+			p.t.Fatal(err)
+		}
+		username := u.Query().Get("username")
+		if id, ok := ids[username]; ok {
+			return []*gitlab.User{{ID: id, Username: username}}, nil, nil
+		}
+		return []*gitlab.User{}, nil, nil
+	}
+}
+
+func (p *gitLabChangesetSourceTestProvider) mockSetMergeRequestAssignees(expectedMR *gitlab.MergeRequest, expectedIDs []int32, updated *gitlab.MergeRequest, err error) {
+	gitlab.MockSetMergeRequestAssignees = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, assigneeIDs []int32) (*gitlab.MergeRequest, error) {
+		p.testCommonParams(ctx, client, project)
+		if expectedMR != mrIn {
+			p.t.Errorf("unexpected MergeRequest: have %+v; want %+v", mrIn, expectedMR)
+		}
+		if diff := cmp.Diff(expectedIDs, assigneeIDs); diff != "" {
+			p.t.Errorf("unexpected assignee IDs: %s", diff)
+		}
+		return updated, err
+	}
+}
+
+func (p *gitLabChangesetSourceTestProvider) mockSetMergeRequestLabels(expectedMR *gitlab.MergeRequest, expectedLabels, expectedRemoved []string, updated *gitlab.MergeRequest, err error) {
+	gitlab.MockSetMergeRequestLabels = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, labels, removed []string) (*gitlab.MergeRequest, error) {
+		p.testCommonParams(ctx, client, project)
+		if expectedMR != mrIn {
+			p.t.Errorf("unexpected MergeRequest: have %+v; want %+v", mrIn, expectedMR)
+		}
+		if diff := cmp.Diff(expectedLabels, labels); diff != "" {
+			p.t.Errorf("unexpected labels: %s", diff)
+		}
+		if diff := cmp.Diff(expectedRemoved, removed); diff != "" {
+			p.t.Errorf("unexpected removed labels: %s", diff)
+		}
+		return updated, err
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockCreateMergeRequest = nil
 	gitlab.MockGetMergeRequest = nil
 	gitlab.MockGetMergeRequestNotes = nil
 	gitlab.MockGetMergeRequestPipelines = nil
 	gitlab.MockGetOpenMergeRequestByRefs = nil
+	gitlab.MockListMergeRequestsByIIDs = nil
 	gitlab.MockUpdateMergeRequest = nil
+	gitlab.MockMergeMergeRequest = nil
+	gitlab.MockListUsers = nil
+	gitlab.MockSetMergeRequestAssignees = nil
+	gitlab.MockSetMergeRequestLabels = nil
+	gitlab.MockSetMergeRequestMilestone = nil
+	gitlab.MockGetOrCreateMilestone = nil
 }
 
 // paginatedNoteIterator essentially fakes the pagination behaviour implemented
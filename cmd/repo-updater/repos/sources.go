@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
@@ -114,6 +115,90 @@ type ChangesetSource interface {
 	CloseChangeset(context.Context, *Changeset) error
 	// UpdateChangeset can update Changesets.
 	UpdateChangeset(context.Context, *Changeset) error
+	// MergeChangeset will merge the Changeset on the source into its base
+	// branch.
+	MergeChangeset(context.Context, *Changeset) error
+}
+
+// An AuthenticatingSource can check whether the credentials in the
+// configuration of its external service authenticate a user on the code host.
+type AuthenticatingSource interface {
+	// ValidateAuthentication returns an error if the code host rejects the
+	// credentials.
+	ValidateAuthentication(context.Context) error
+}
+
+// A ForkableChangesetSource is a ChangesetSource that can create changesets
+// whose head ref is in a fork of the repository, for users that can't push to
+// the repository itself.
+type ForkableChangesetSource interface {
+	ChangesetSource
+	// EnsureFork returns the namespace and name of the fork of the given Repo
+	// that's owned by the authenticated user, creating it if it doesn't
+	// exist yet.
+	EnsureFork(context.Context, *Repo) (namespace, name string, err error)
+}
+
+// A ReviewRequestingChangesetSource is a ChangesetSource that can request
+// reviews of changesets from users and teams on the code host.
+type ReviewRequestingChangesetSource interface {
+	ChangesetSource
+	// RequestReviews requests reviews of the given Changeset from the given
+	// reviewers, which are usernames or, for teams, "{org}/{team}".
+	RequestReviews(ctx context.Context, c *Changeset, reviewers []string) error
+}
+
+// An AssigningChangesetSource is a ChangesetSource that can assign changesets
+// to users on the code host.
+type AssigningChangesetSource interface {
+	ChangesetSource
+	// AssignChangeset assigns the given Changeset to the users with the given
+	// usernames.
+	AssignChangeset(ctx context.Context, c *Changeset, assignees []string) error
+}
+
+// A LabelingChangesetSource is a ChangesetSource that can set the labels and
+// milestones of changesets on the code host.
+type LabelingChangesetSource interface {
+	ChangesetSource
+	// LabelChangeset adds the given labels to the given Changeset and removes
+	// the given removed labels from it. Labels that don't exist yet are
+	// created.
+	LabelChangeset(ctx context.Context, c *Changeset, labels, removed []string) error
+	// SetChangesetMilestone sets the milestone of the given Changeset to the
+	// milestone with the given title, which is created if it doesn't exist
+	// yet.
+	SetChangesetMilestone(ctx context.Context, c *Changeset, milestone string) error
+}
+
+// A CommentingChangesetSource is a ChangesetSource that can comment on
+// changesets on the code host.
+type CommentingChangesetSource interface {
+	ChangesetSource
+	// CreateComment adds a comment with the given Markdown body to the given
+	// Changeset.
+	CreateComment(ctx context.Context, c *Changeset, body string) error
+}
+
+// A CommitStatusSource is a ChangesetSource that can report the state of the
+// commit statuses and checks of commits on the code host.
+type CommitStatusSource interface {
+	ChangesetSource
+	// CommitCheckState returns the combined state of the commit statuses and
+	// checks of the commit that the given ref of the given repository points
+	// to. It's unknown if the commit has none.
+	CommitCheckState(ctx context.Context, r *Repo, ref string) (campaigns.ChangesetCheckState, error)
+}
+
+// A ChangesetStateSource is a ChangesetSource that can load just the
+// open/closed/merged state of many Changesets at once, which is much cheaper
+// than loading them fully with LoadChangesets.
+type ChangesetStateSource interface {
+	ChangesetSource
+	// LoadChangesetStates returns the external state of the given Changesets
+	// keyed by their ID. Changesets that could not be found on the code host
+	// are reported as deleted.
+	LoadChangesetStates(context.Context, ...*Changeset) (map[int64]campaigns.ChangesetExternalState, error)
 }
 
 // ChangesetsNotFoundError is returned by LoadChangesets if any of the passed
@@ -35,6 +35,10 @@ type Changeset struct {
 	HeadRef string
 	BaseRef string
 
+	// ForkNamespace is the namespace of the fork of Repo that HeadRef was
+	// pushed to. If empty, HeadRef is a ref in Repo itself.
+	ForkNamespace string
+
 	*campaigns.Changeset
 	*Repo
 }
@@ -50,6 +50,11 @@ type Server struct {
 		// the registry can start or stop the syncer associated with the service
 		HandleExternalServiceSync(es api.ExternalService)
 	}
+	CampaignsPublicationBudgets interface {
+		// ListPublicationBudgets returns the budgets that the publication of
+		// campaign changesets has left on each code host.
+		ListPublicationBudgets(ctx context.Context) ([]protocol.CampaignsPublicationBudget, error)
+	}
 	RateLimitSyncer interface {
 		// SyncRateLimiters should be called when an external service changes so that
 		// our internal rate limiters are kept in sync
@@ -78,6 +83,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/status-messages", s.handleStatusMessages)
 	mux.HandleFunc("/enqueue-changeset-sync", s.handleEnqueueChangesetSync)
 	mux.HandleFunc("/schedule-perms-sync", s.handleSchedulePermsSync)
+	mux.HandleFunc("/campaigns-publication-budgets", s.handleCampaignsPublicationBudgets)
 	return mux
 }
 
@@ -629,6 +635,21 @@ func (s *Server) handleEnqueueChangesetSync(w http.ResponseWriter, r *http.Reque
 	respond(w, http.StatusOK, nil)
 }
 
+func (s *Server) handleCampaignsPublicationBudgets(w http.ResponseWriter, r *http.Request) {
+	if s.CampaignsPublicationBudgets == nil {
+		respond(w, http.StatusForbidden, nil)
+		return
+	}
+
+	budgets, err := s.CampaignsPublicationBudgets.ListPublicationBudgets(r.Context())
+	if err != nil {
+		resp := protocol.CampaignsPublicationBudgetsResponse{Error: err.Error()}
+		respond(w, http.StatusInternalServerError, resp)
+		return
+	}
+	respond(w, http.StatusOK, protocol.CampaignsPublicationBudgetsResponse{Budgets: budgets})
+}
+
 func (s *Server) handleSchedulePermsSync(w http.ResponseWriter, r *http.Request) {
 	if s.PermsSyncer == nil {
 		respond(w, http.StatusForbidden, nil)
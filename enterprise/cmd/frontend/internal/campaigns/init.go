@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/globalstatedb"
 )
@@ -22,7 +24,18 @@ func Init(ctx context.Context, enterpriseServices *enterprise.Services) error {
 	campaignsStore := campaigns.NewStoreWithClock(dbconn.Global, msResolutionClock)
 	repositories := repos.NewDBStore(dbconn.Global, sql.TxOptions{})
 
-	enterpriseServices.CampaignsResolver = resolvers.NewResolver(dbconn.Global)
+	// Only the GraphQL API, which runs the heavy list, count and burndown
+	// queries, reads from the replica.
+	resolverStore := campaignsStore
+	if dataSource := conf.Get().CampaignsReadReplicaDataSource; dataSource != "" {
+		replica, err := dbconn.Open(dataSource)
+		if err != nil {
+			return errors.Wrap(err, "opening campaigns read replica")
+		}
+		resolverStore = campaignsStore.WithReadReplica(replica)
+	}
+
+	enterpriseServices.CampaignsResolver = resolvers.NewResolverWithStore(resolverStore)
 	enterpriseServices.GitHubWebhook = campaigns.NewGitHubWebhook(campaignsStore, repositories, msResolutionClock)
 	enterpriseServices.BitbucketServerWebhook = campaigns.NewBitbucketServerWebhook(
 		campaignsStore,
@@ -31,6 +44,10 @@ func Init(ctx context.Context, enterpriseServices *enterprise.Services) error {
 		"sourcegraph-"+globalState.SiteID,
 	)
 	enterpriseServices.GitLabWebhook = campaigns.NewGitLabWebhook(campaignsStore, repositories, msResolutionClock)
+	enterpriseServices.BitbucketCloudWebhook = campaigns.NewBitbucketCloudWebhook(campaignsStore, repositories, msResolutionClock)
+	enterpriseServices.CampaignChangesetsExport = campaigns.NewChangesetsExportHandler(campaignsStore)
+	enterpriseServices.CampaignPatch = campaigns.NewCampaignPatchHandler(campaignsStore)
+	enterpriseServices.CampaignsREST = campaigns.NewRESTHandler(campaignsStore)
 
 	return nil
 }
@@ -2,6 +2,7 @@ package codeintel
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,6 +15,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 )
 
@@ -61,6 +63,9 @@ func newInternalProxyHandler() (func() http.Handler, error) {
 		// Proxy only the known routes in the index queue API
 		base.Path("/index-queue/{rest:(?:dequeue|complete|heartbeat)}").Handler(reverseProxy(indexerOrigin))
 
+		// Serve the indexer configuration from the site configuration
+		base.Path("/indexer-config").Methods("GET").Handler(indexerConfigHandler())
+
 		return internalProxyAuthTokenMiddleware(base)
 	}
 
@@ -89,6 +94,24 @@ func internalProxyAuthTokenMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// indexerConfigHandler serves the `codeIntel.indexer` site configuration, which indexers poll and
+// apply between index jobs. If the site configuration does not set it, the handler responds with
+// 204 No Content and indexers keep using their local configuration.
+func indexerConfigHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		indexerConfig := conf.Get().CodeIntelIndexer
+		if indexerConfig == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(indexerConfig); err != nil {
+			log15.Error("Failed to write payload to client", "err", err)
+		}
+	})
+}
+
 // TODO(efritz) - add tracing, metrics
 var client = http.DefaultClient
 
@@ -7,7 +7,11 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func init() {
@@ -201,3 +205,41 @@ func TestReverseProxyRedirectWithPayload(t *testing.T) {
 		t.Errorf("unexpected header value. want=%s have=%s", "foobarbaz", value)
 	}
 }
+
+func TestIndexerConfigHandler(t *testing.T) {
+	ts := httptest.NewServer(indexerConfigHandler())
+	defer ts.Close()
+
+	conf.Mock(&conf.Unified{})
+	defer conf.Mock(nil)
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error performing request: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusNoContent, resp.StatusCode)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelIndexer: &schema.CodeIntelIndexer{MaxContainers: 4, Memory: "4g"},
+	}})
+
+	resp, err = http.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error performing request: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code. want=%d have=%d", http.StatusOK, resp.StatusCode)
+	}
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading body: %s", err)
+	}
+	if value := strings.TrimSpace(string(contents)); value != `{"maxContainers":4,"memory":"4g"}` {
+		t.Errorf("unexpected payload. want=%s have=%s", `{"maxContainers":4,"memory":"4g"}`, value)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -14,7 +15,20 @@ var (
 	rawInternalProxyAuthToken   = env.Get("PRECISE_CODE_INTEL_INTERNAL_PROXY_AUTH_TOKEN", "", "The auth token supplied to the frontend.")
 	rawIndexerPollInterval      = env.Get("PRECISE_CODE_INTEL_INDEXER_POLL_INTERVAL", "1s", "Interval between queries to the precise-code-intel-index-manager.")
 	rawIndexerHeartbeatInterval = env.Get("PRECISE_CODE_INTEL_INDEXER_HEARTBEAT_INTERVAL", "1s", "Interval between heartbeat requests.")
-	rawMaxContainers            = env.Get("PRECISE_CODE_INTEL_MAXIMUM_CONTAINERS", "1", "Number of index containers that can be running at once.")
+	rawMaxContainers            = env.Get("PRECISE_CODE_INTEL_MAXIMUM_CONTAINERS", "1", "Maximum number of index containers that can be running at once.")
+	rawMinContainers            = env.Get("PRECISE_CODE_INTEL_MINIMUM_CONTAINERS", "1", "Minimum number of index containers that can be running at once, regardless of host load.")
+	rawConcurrencyInterval      = env.Get("PRECISE_CODE_INTEL_CONCURRENCY_ADJUSTMENT_INTERVAL", "30s", "Interval between adjustments of the number of index containers that can be running at once.")
+	rawHighLoadThreshold        = env.Get("PRECISE_CODE_INTEL_HIGH_LOAD_THRESHOLD", "0.9", "CPU, memory, or disk pressure (between 0 and 1) at which fewer index containers are run.")
+	rawLowLoadThreshold         = env.Get("PRECISE_CODE_INTEL_LOW_LOAD_THRESHOLD", "0.7", "CPU, memory, and disk pressure (between 0 and 1) below which more index containers may be run.")
+	rawLatencyThreshold         = env.Get("PRECISE_CODE_INTEL_LATENCY_THRESHOLD", "2", "Factor by which recent index job latency may exceed the average before fewer index containers are run. Zero disables this check.")
+	rawContainerCeiling         = env.Get("PRECISE_CODE_INTEL_CONTAINER_CEILING", "0", "Upper bound on the maximum number of index containers that reloaded configuration may set. Zero means the value of PRECISE_CODE_INTEL_MAXIMUM_CONTAINERS.")
+	rawIndexerImage             = env.Get("PRECISE_CODE_INTEL_INDEXER_IMAGE", "sourcegraph/lsif-go:latest", "The Docker image used to index repositories.")
+	rawIndexerImageAllowlist    = env.Get("PRECISE_CODE_INTEL_INDEXER_IMAGE_ALLOWLIST", "", "Comma-separated list of Docker images that index jobs may use. Empty allows any image.")
+	rawIndexerCPUs              = env.Get("PRECISE_CODE_INTEL_INDEXER_CPUS", "0", "Number of CPUs available to each index container. Zero means no limit.")
+	rawIndexerMemory            = env.Get("PRECISE_CODE_INTEL_INDEXER_MEMORY", "", "Memory limit of each index container (e.g. 4g). Empty means no limit.")
+	rawConfigFile               = env.Get("PRECISE_CODE_INTEL_CONFIG_FILE", "", "Path to a JSON file whose values override the environment. The file is reloaded periodically.")
+	rawConfigFromFrontend       = env.Get("PRECISE_CODE_INTEL_CONFIG_FROM_FRONTEND", "true", "Whether to apply the codeIntel.indexer site configuration served by the frontend.")
+	rawConfigReloadInterval     = env.Get("PRECISE_CODE_INTEL_CONFIG_RELOAD_INTERVAL", "30s", "Interval between reloads of the configuration file and the frontend configuration.")
 )
 
 // mustGet returns the non-empty version of the given raw value fatally logs on failure.
@@ -36,6 +50,16 @@ func mustParseInt(rawValue, name string) int {
 	return int(i)
 }
 
+// mustParseFloat returns the float version of the given raw value fatally logs on failure.
+func mustParseFloat(rawValue, name string) float64 {
+	f, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		log.Fatalf("invalid float %q for %s: %s", rawValue, name, err)
+	}
+
+	return f
+}
+
 // mustParseInterval returns the interval version of the given raw value fatally logs on failure.
 func mustParseInterval(rawValue, name string) time.Duration {
 	d, err := time.ParseDuration(rawValue)
@@ -45,3 +69,24 @@ func mustParseInterval(rawValue, name string) time.Duration {
 
 	return d
 }
+
+// mustParseBool returns the boolean version of the given raw value fatally logs on failure.
+func mustParseBool(rawValue, name string) bool {
+	b, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		log.Fatalf("invalid bool %q for %s: %s", rawValue, name, err)
+	}
+
+	return b
+}
+
+// parseList returns the non-empty comma-separated values of the given raw value.
+func parseList(rawValue string) (values []string) {
+	for _, value := range strings.Split(rawValue, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,219 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/efritz/glock"
+	"github.com/inconshreveable/log15"
+)
+
+// Controller determines how many index jobs may run in parallel on this host. The limit starts
+// at the configured minimum and is periodically adjusted (one job at a time) between the minimum
+// and maximum bounds based on the observed resource pressure of the host and the latency of the
+// jobs that completed since the last adjustment.
+type Controller struct {
+	sampler  Sampler
+	options  ControllerOptions
+	clock    glock.Clock
+	ctx      context.Context
+	cancel   func()
+	finished chan struct{}
+
+	m               sync.Mutex
+	limit           int
+	started         map[int]time.Time // start times of running jobs
+	latencies       []time.Duration   // latencies of jobs completed since the last adjustment
+	baselineLatency time.Duration     // moving average of job latencies
+}
+
+type ControllerOptions struct {
+	// MinConcurrency and MaxConcurrency bound the number of jobs that may run in parallel.
+	MinConcurrency int
+	MaxConcurrency int
+
+	// Interval is the time between adjustments of the concurrency limit.
+	Interval time.Duration
+
+	// HighLoadThreshold is the pressure (in [0, 1]) of any resource at or above which the
+	// limit is decreased.
+	HighLoadThreshold float64
+
+	// LowLoadThreshold is the pressure (in [0, 1]) that all resources must be below for the
+	// limit to be increased.
+	LowLoadThreshold float64
+
+	// LatencyThreshold is the factor by which the average latency of recently completed jobs
+	// may exceed the moving average of all job latencies before the limit is decreased. A
+	// value of zero disables latency-based adjustments.
+	LatencyThreshold float64
+}
+
+// baselineLatencyWeight is the weight of a new latency sample in the moving average of job
+// latencies. A small value ensures that the baseline reflects the latency of jobs over many
+// adjustment intervals.
+const baselineLatencyWeight = 0.1
+
+func NewController(ctx context.Context, sampler Sampler, options ControllerOptions) *Controller {
+	return newController(ctx, sampler, options, glock.NewRealClock())
+}
+
+func newController(ctx context.Context, sampler Sampler, options ControllerOptions, clock glock.Clock) *Controller {
+	ctx, cancel := context.WithCancel(ctx)
+
+	if options.MinConcurrency < 1 {
+		options.MinConcurrency = 1
+	}
+	if options.MaxConcurrency < options.MinConcurrency {
+		options.MaxConcurrency = options.MinConcurrency
+	}
+
+	return &Controller{
+		sampler:  sampler,
+		options:  options,
+		clock:    clock,
+		ctx:      ctx,
+		cancel:   cancel,
+		finished: make(chan struct{}),
+		limit:    options.MinConcurrency,
+		started:  map[int]time.Time{},
+	}
+}
+
+// SetBounds replaces the minimum and maximum concurrency and moves the current limit into the
+// new bounds. Jobs that are already running are not affected; a lowered limit only prevents
+// new jobs from starting until enough running jobs have finished.
+func (c *Controller) SetBounds(minConcurrency, maxConcurrency int) {
+	if minConcurrency < 1 {
+		minConcurrency = 1
+	}
+	if maxConcurrency < minConcurrency {
+		maxConcurrency = minConcurrency
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.options.MinConcurrency = minConcurrency
+	c.options.MaxConcurrency = maxConcurrency
+
+	if c.limit < minConcurrency {
+		c.limit = minConcurrency
+	}
+	if c.limit > maxConcurrency {
+		c.limit = maxConcurrency
+	}
+}
+
+// Start periodically adjusts the concurrency limit until Stop is called.
+func (c *Controller) Start() {
+	defer close(c.finished)
+
+	for {
+		select {
+		case <-c.clock.After(c.options.Interval):
+		case <-c.ctx.Done():
+			return
+		}
+
+		c.adjust()
+	}
+}
+
+func (c *Controller) Stop() {
+	c.cancel()
+	<-c.finished
+}
+
+// Limit returns the current maximum number of jobs that may run in parallel.
+func (c *Controller) Limit() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.limit
+}
+
+// HasCapacity returns true if fewer jobs than the current limit are running.
+func (c *Controller) HasCapacity() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return len(c.started) < c.limit
+}
+
+// JobStarted records that the job with the given identifier has started.
+func (c *Controller) JobStarted(id int) {
+	c.m.Lock()
+	c.started[id] = c.clock.Now()
+	c.m.Unlock()
+}
+
+// JobFinished records that the job with the given identifier has finished.
+func (c *Controller) JobFinished(id int) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if started, ok := c.started[id]; ok {
+		delete(c.started, id)
+		c.latencies = append(c.latencies, c.clock.Now().Sub(started))
+	}
+}
+
+// adjust samples the host load and moves the concurrency limit one step up or down.
+func (c *Controller) adjust() {
+	load, err := c.sampler.Sample()
+	if err != nil {
+		// Without a load sample we cannot tell if the host is under pressure, so keep the
+		// current limit and try again at the next interval.
+		log15.Error("Failed to sample host load", "err", err)
+		return
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	latencyDegraded := c.observeLatencies()
+	pressure := load.Max()
+
+	switch {
+	case pressure >= c.options.HighLoadThreshold || latencyDegraded:
+		if c.limit > c.options.MinConcurrency {
+			c.limit--
+			log15.Info("Decreased index job concurrency", "limit", c.limit, "cpu", load.CPU, "memory", load.Memory, "disk", load.Disk, "latencyDegraded", latencyDegraded)
+		}
+
+	case pressure < c.options.LowLoadThreshold && len(c.started) >= c.limit:
+		// Only grow the limit when it is actually the bottleneck.
+		if c.limit < c.options.MaxConcurrency {
+			c.limit++
+			log15.Info("Increased index job concurrency", "limit", c.limit, "cpu", load.CPU, "memory", load.Memory, "disk", load.Disk)
+		}
+	}
+}
+
+// observeLatencies folds the latencies of the jobs completed since the last adjustment into the
+// baseline latency and returns true if their average exceeds the baseline by more than the
+// configured threshold. This method must be called while holding the lock.
+func (c *Controller) observeLatencies() (degraded bool) {
+	if len(c.latencies) == 0 {
+		return false
+	}
+
+	var sum time.Duration
+	for _, latency := range c.latencies {
+		sum += latency
+	}
+	average := sum / time.Duration(len(c.latencies))
+	c.latencies = c.latencies[:0]
+
+	if c.baselineLatency == 0 {
+		c.baselineLatency = average
+		return false
+	}
+
+	if c.options.LatencyThreshold > 0 {
+		degraded = float64(average) > float64(c.baselineLatency)*c.options.LatencyThreshold
+	}
+
+	c.baselineLatency = time.Duration((1-baselineLatencyWeight)*float64(c.baselineLatency) + baselineLatencyWeight*float64(average))
+	return degraded
+}
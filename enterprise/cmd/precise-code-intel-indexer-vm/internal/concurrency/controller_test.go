@@ -0,0 +1,163 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/efritz/glock"
+)
+
+type testSampler struct {
+	load Load
+	err  error
+}
+
+func (s *testSampler) Sample() (Load, error) { return s.load, s.err }
+
+var testOptions = ControllerOptions{
+	MinConcurrency:    1,
+	MaxConcurrency:    3,
+	Interval:          time.Second,
+	HighLoadThreshold: 0.9,
+	LowLoadThreshold:  0.7,
+	LatencyThreshold:  2,
+}
+
+func TestControllerIncreasesLimitWhenSaturated(t *testing.T) {
+	sampler := &testSampler{load: Load{CPU: 0.2, Memory: 0.3, Disk: 0.1}}
+	controller := newController(context.Background(), sampler, testOptions, glock.NewMockClock())
+
+	// Not saturated: the limit is not the bottleneck
+	controller.adjust()
+	if limit := controller.Limit(); limit != 1 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 1, limit)
+	}
+
+	for i := 1; i <= 5; i++ {
+		if controller.HasCapacity() {
+			controller.JobStarted(i)
+		}
+		controller.adjust()
+	}
+
+	if limit := controller.Limit(); limit != 3 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 3, limit)
+	}
+	if controller.HasCapacity() {
+		t.Fatalf("expected controller to be at capacity")
+	}
+}
+
+func TestControllerDecreasesLimitUnderPressure(t *testing.T) {
+	sampler := &testSampler{load: Load{CPU: 0.1}}
+	options := testOptions
+	options.MinConcurrency = 2
+	options.MaxConcurrency = 4
+	controller := newController(context.Background(), sampler, options, glock.NewMockClock())
+	controller.limit = 4
+
+	sampler.load = Load{CPU: 0.1, Memory: 0.95}
+	for i := 0; i < 5; i++ {
+		controller.adjust()
+	}
+
+	if limit := controller.Limit(); limit != 2 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 2, limit)
+	}
+}
+
+func TestControllerDecreasesLimitOnLatencyDegradation(t *testing.T) {
+	sampler := &testSampler{load: Load{CPU: 0.8}}
+	clock := glock.NewMockClock()
+	controller := newController(context.Background(), sampler, testOptions, clock)
+	controller.limit = 3
+
+	runJob := func(id int, d time.Duration) {
+		controller.JobStarted(id)
+		clock.Advance(d)
+		controller.JobFinished(id)
+	}
+
+	// Establish a baseline
+	runJob(1, time.Minute)
+	controller.adjust()
+	if limit := controller.Limit(); limit != 3 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 3, limit)
+	}
+
+	// Comparable latency
+	runJob(2, 90*time.Second)
+	controller.adjust()
+	if limit := controller.Limit(); limit != 3 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 3, limit)
+	}
+
+	// Degraded latency
+	runJob(3, 5*time.Minute)
+	controller.adjust()
+	if limit := controller.Limit(); limit != 2 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 2, limit)
+	}
+}
+
+func TestControllerKeepsLimitOnSampleError(t *testing.T) {
+	sampler := &testSampler{err: errors.New("oops")}
+	controller := newController(context.Background(), sampler, testOptions, glock.NewMockClock())
+	controller.limit = 2
+	controller.JobStarted(1)
+	controller.JobStarted(2)
+
+	controller.adjust()
+	if limit := controller.Limit(); limit != 2 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 2, limit)
+	}
+}
+
+func TestControllerStartAdjustsPeriodically(t *testing.T) {
+	sampler := &testSampler{load: Load{CPU: 0.1}}
+	clock := glock.NewMockClock()
+	controller := newController(context.Background(), sampler, testOptions, clock)
+	controller.JobStarted(1)
+
+	go func() { controller.Start() }()
+	clock.BlockingAdvance(time.Second)
+	clock.BlockingAdvance(time.Second)
+	controller.Stop()
+
+	if limit := controller.Limit(); limit != 2 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 2, limit)
+	}
+}
+
+func TestControllerSetBounds(t *testing.T) {
+	sampler := &testSampler{load: Load{CPU: 0.1}}
+	controller := newController(context.Background(), sampler, testOptions, glock.NewMockClock())
+	controller.limit = 3
+	controller.JobStarted(1)
+	controller.JobStarted(2)
+
+	// Lowered maximum clamps the limit
+	controller.SetBounds(1, 2)
+	if limit := controller.Limit(); limit != 2 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 2, limit)
+	}
+	if controller.HasCapacity() {
+		t.Fatalf("expected controller to be at capacity")
+	}
+
+	// Raised minimum raises the limit
+	controller.SetBounds(4, 6)
+	if limit := controller.Limit(); limit != 4 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 4, limit)
+	}
+
+	// Raised maximum allows further growth
+	controller.JobStarted(3)
+	controller.JobStarted(4)
+	controller.adjust()
+	if limit := controller.Limit(); limit != 5 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 5, limit)
+	}
+}
@@ -0,0 +1,138 @@
+package concurrency
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// Load is the pressure on the resources of the host. Each value is a fraction in [0, 1] where
+// higher values indicate less available capacity. CPU pressure may exceed 1 when the host is
+// overloaded.
+type Load struct {
+	CPU    float64
+	Memory float64
+	Disk   float64
+}
+
+// Max returns the highest pressure of all resources.
+func (l Load) Max() float64 {
+	max := l.CPU
+	if l.Memory > max {
+		max = l.Memory
+	}
+	if l.Disk > max {
+		max = l.Disk
+	}
+	return max
+}
+
+// Sampler measures the current load of the host.
+type Sampler interface {
+	Sample() (Load, error)
+}
+
+// ProcSampler samples the load of a Linux host from procfs and the file system that holds the
+// given disk path (where repositories are cloned for indexing).
+type ProcSampler struct {
+	DiskPath string
+}
+
+var _ Sampler = &ProcSampler{}
+
+func (s *ProcSampler) Sample() (load Load, err error) {
+	if load.CPU, err = cpuPressure(); err != nil {
+		return Load{}, errors.Wrap(err, "cpu")
+	}
+	if load.Memory, err = memoryPressure(); err != nil {
+		return Load{}, errors.Wrap(err, "memory")
+	}
+	if load.Disk, err = diskPressure(s.DiskPath); err != nil {
+		return Load{}, errors.Wrap(err, "disk")
+	}
+	return load, nil
+}
+
+// readFile is a wrapper around ioutil.ReadFile that can be replaced during unit tests.
+var readFile = ioutil.ReadFile
+
+// cpuPressure returns the one-minute load average normalized by the number of CPUs.
+func cpuPressure() (float64, error) {
+	contents, err := readFile("/proc/loadavg")
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(contents))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected contents of /proc/loadavg: %q", contents)
+	}
+
+	loadAvg, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return loadAvg / float64(runtime.NumCPU()), nil
+}
+
+// memoryPressure returns the fraction of memory that is not available for new processes.
+func memoryPressure() (float64, error) {
+	contents, err := readFile("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+
+	var total, available float64
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		var target *float64
+		switch fields[0] {
+		case "MemTotal:":
+			target = &total
+		case "MemAvailable:":
+			target = &available
+		default:
+			continue
+		}
+
+		if *target, err = strconv.ParseFloat(fields[1], 64); err != nil {
+			return 0, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if total == 0 {
+		return 0, errors.New("no MemTotal in /proc/meminfo")
+	}
+
+	return 1 - available/total, nil
+}
+
+// diskPressure returns the fraction of used blocks on the file system holding the given path.
+func diskPressure(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	if stat.Blocks == 0 {
+		return 0, nil
+	}
+
+	return 1 - float64(stat.Bavail)/float64(stat.Blocks), nil
+}
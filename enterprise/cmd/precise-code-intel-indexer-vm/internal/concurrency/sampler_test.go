@@ -0,0 +1,31 @@
+package concurrency
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestMemoryPressure(t *testing.T) {
+	readFile = func(filename string) ([]byte, error) {
+		if filename != "/proc/meminfo" {
+			t.Fatalf("unexpected filename %q", filename)
+		}
+
+		return []byte("MemTotal:       16000000 kB\nMemFree:         2000000 kB\nMemAvailable:    4000000 kB\nBuffers:          500000 kB\n"), nil
+	}
+	defer func() { readFile = ioutil.ReadFile }()
+
+	pressure, err := memoryPressure()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pressure != 0.75 {
+		t.Errorf("unexpected pressure. want=%f have=%f", 0.75, pressure)
+	}
+}
+
+func TestLoadMax(t *testing.T) {
+	if max := (Load{CPU: 0.2, Memory: 0.6, Disk: 0.4}).Max(); max != 0.6 {
+		t.Errorf("unexpected max. want=%f have=%f", 0.6, max)
+	}
+}
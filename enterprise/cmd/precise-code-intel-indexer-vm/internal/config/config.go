@@ -0,0 +1,80 @@
+package config
+
+import "reflect"
+
+// Config is the part of the indexer configuration that can be changed without restarting the
+// indexer. Changes are applied between index jobs: jobs that are already running keep using the
+// configuration with which they started.
+type Config struct {
+	// FrontendURL is the external URL of the Sourcegraph instance.
+	FrontendURL string `json:"frontendURL,omitempty"`
+
+	// FrontendURLFromDocker is the external URL of the Sourcegraph instance used from within
+	// an index container.
+	FrontendURLFromDocker string `json:"frontendURLFromDocker,omitempty"`
+
+	// MinContainers and MaxContainers bound the number of index jobs that may run in parallel.
+	MinContainers int `json:"minContainers,omitempty"`
+	MaxContainers int `json:"maxContainers,omitempty"`
+
+	// Image is the Docker image used to index repositories.
+	Image string `json:"image,omitempty"`
+
+	// ImageAllowlist, if non-empty, is the set of images that index jobs may use.
+	ImageAllowlist []string `json:"imageAllowlist,omitempty"`
+
+	// CPUs and Memory limit the resources of each index container. Zero values mean no limit.
+	CPUs   float64 `json:"cpus,omitempty"`
+	Memory string  `json:"memory,omitempty"`
+}
+
+// Merge returns a copy of c in which every field that is set in override replaces the
+// corresponding field of c.
+func (c Config) Merge(override Config) Config {
+	if override.FrontendURL != "" {
+		c.FrontendURL = override.FrontendURL
+	}
+	if override.FrontendURLFromDocker != "" {
+		c.FrontendURLFromDocker = override.FrontendURLFromDocker
+	}
+	if override.MinContainers != 0 {
+		c.MinContainers = override.MinContainers
+	}
+	if override.MaxContainers != 0 {
+		c.MaxContainers = override.MaxContainers
+	}
+	if override.Image != "" {
+		c.Image = override.Image
+	}
+	if len(override.ImageAllowlist) > 0 {
+		c.ImageAllowlist = override.ImageAllowlist
+	}
+	if override.CPUs != 0 {
+		c.CPUs = override.CPUs
+	}
+	if override.Memory != "" {
+		c.Memory = override.Memory
+	}
+
+	return c
+}
+
+// ImageAllowed returns true if the given image may be used to run index jobs.
+func (c Config) ImageAllowed(image string) bool {
+	if len(c.ImageAllowlist) == 0 {
+		return true
+	}
+
+	for _, allowed := range c.ImageAllowlist {
+		if allowed == image {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Equal returns true if both configurations are the same.
+func (c Config) Equal(other Config) bool {
+	return reflect.DeepEqual(c, other)
+}
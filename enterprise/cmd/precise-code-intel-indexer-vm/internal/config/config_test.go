@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMerge(t *testing.T) {
+	base := Config{
+		FrontendURL:   "https://sourcegraph.test",
+		MinContainers: 1,
+		MaxContainers: 2,
+		Image:         "sourcegraph/lsif-go:latest",
+		Memory:        "2g",
+	}
+
+	merged := base.Merge(Config{
+		MaxContainers:  4,
+		ImageAllowlist: []string{"sourcegraph/lsif-go:latest"},
+		CPUs:           1.5,
+	})
+
+	expected := Config{
+		FrontendURL:    "https://sourcegraph.test",
+		MinContainers:  1,
+		MaxContainers:  4,
+		Image:          "sourcegraph/lsif-go:latest",
+		ImageAllowlist: []string{"sourcegraph/lsif-go:latest"},
+		CPUs:           1.5,
+		Memory:         "2g",
+	}
+	if diff := cmp.Diff(expected, merged); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+}
+
+func TestImageAllowed(t *testing.T) {
+	if !(Config{}).ImageAllowed("sourcegraph/lsif-go:latest") {
+		t.Errorf("expected any image to be allowed without an allowlist")
+	}
+
+	config := Config{ImageAllowlist: []string{"sourcegraph/lsif-go:latest"}}
+	if !config.ImageAllowed("sourcegraph/lsif-go:latest") {
+		t.Errorf("expected allowlisted image to be allowed")
+	}
+	if config.ImageAllowed("sourcegraph/lsif-go:insiders") {
+		t.Errorf("expected image not in allowlist to be rejected")
+	}
+}
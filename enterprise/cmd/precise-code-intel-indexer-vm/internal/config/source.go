@@ -0,0 +1,110 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// Source loads configuration overrides. A source that has no configuration to offer returns
+// false and a nil error.
+type Source interface {
+	Load(ctx context.Context) (Config, bool, error)
+}
+
+// FileSource reads configuration overrides from a JSON file. A missing file is not an error.
+type FileSource struct {
+	Path string
+}
+
+var _ Source = &FileSource{}
+
+func (s *FileSource) Load(ctx context.Context) (Config, bool, error) {
+	content, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Config{}, false, nil
+		}
+		return Config{}, false, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(content, &config); err != nil {
+		return Config{}, false, errors.Wrap(err, fmt.Sprintf("failed to parse %s", s.Path))
+	}
+
+	return config, true, nil
+}
+
+// FrontendSource reads configuration overrides from the `codeIntel.indexer` site configuration
+// via the external frontend API.
+type FrontendSource struct {
+	// FrontendURL returns the current external URL of the Sourcegraph instance. This is a
+	// function so that the source follows changes of the frontend URL itself.
+	FrontendURL func() string
+	AuthToken   string
+	HTTPClient  *http.Client
+}
+
+var _ Source = &FrontendSource{}
+
+func (s *FrontendSource) Load(ctx context.Context) (Config, bool, error) {
+	url, err := makeConfigURL(s.FrontendURL(), s.AuthToken)
+	if err != nil {
+		return Config{}, false, err
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return Config{}, false, err
+	}
+
+	resp, err := ctxhttp.Do(ctx, httpClient, req)
+	if err != nil {
+		return Config{}, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return Config{}, false, nil
+	default:
+		return Config{}, false, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var config Config
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return Config{}, false, err
+	}
+
+	// The frontend URL is never taken from the frontend itself, as a bad value would leave the
+	// indexer unable to fetch a corrected configuration.
+	config.FrontendURL = ""
+	config.FrontendURLFromDocker = ""
+
+	return config, true, nil
+}
+
+func makeConfigURL(baseURL, authToken string) (*url.URL, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	base.User = url.UserPassword("indexer", authToken)
+
+	return base.ResolveReference(&url.URL{Path: path.Join(".internal-code-intel", "indexer-config")}), nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFileSource(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error creating temp directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	source := &FileSource{Path: filepath.Join(tempDir, "config.json")}
+
+	// Missing file
+	if _, ok, err := source.Load(context.Background()); err != nil {
+		t.Fatalf("unexpected error loading config: %s", err)
+	} else if ok {
+		t.Fatalf("expected no config")
+	}
+
+	if err := ioutil.WriteFile(source.Path, []byte(`{"maxContainers": 4, "memory": "4g"}`), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error writing config: %s", err)
+	}
+
+	config, ok, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected config")
+	}
+	if diff := cmp.Diff(Config{MaxContainers: 4, Memory: "4g"}, config); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+
+	if err := ioutil.WriteFile(source.Path, []byte(`{"maxContainers": `), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error writing config: %s", err)
+	}
+	if _, _, err := source.Load(context.Background()); err == nil {
+		t.Fatalf("expected an error loading malformed config")
+	}
+}
+
+func TestFrontendSource(t *testing.T) {
+	var payload string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.internal-code-intel/indexer-config" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, token, _ := r.BasicAuth(); token != "hunter2" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if payload == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer ts.Close()
+
+	source := &FrontendSource{
+		FrontendURL: func() string { return ts.URL },
+		AuthToken:   "hunter2",
+	}
+
+	// No site configuration
+	if _, ok, err := source.Load(context.Background()); err != nil {
+		t.Fatalf("unexpected error loading config: %s", err)
+	} else if ok {
+		t.Fatalf("expected no config")
+	}
+
+	payload = `{"maxContainers": 4, "image": "sourcegraph/lsif-go:insiders", "frontendURL": "https://unused.test"}`
+
+	config, ok, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected config")
+	}
+	if diff := cmp.Diff(Config{MaxContainers: 4, Image: "sourcegraph/lsif-go:insiders"}, config); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+
+	source.AuthToken = "hunter3"
+	if _, _, err := source.Load(context.Background()); err == nil {
+		t.Fatalf("expected an error loading config with a bad token")
+	}
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/efritz/glock"
+	"github.com/inconshreveable/log15"
+)
+
+// Watcher periodically reloads the configuration from a set of sources and notifies the
+// registered handlers when the effective configuration changes.
+type Watcher struct {
+	base     Config
+	sources  []Source
+	options  WatcherOptions
+	clock    glock.Clock
+	ctx      context.Context
+	cancel   func()
+	finished chan struct{}
+
+	m        sync.RWMutex
+	current  Config
+	handlers []func(Config)
+}
+
+type WatcherOptions struct {
+	// Interval is the time between reloads of the configuration.
+	Interval time.Duration
+}
+
+// NewWatcher creates a watcher whose effective configuration is the given base configuration
+// overridden by the configuration of each source, in order.
+func NewWatcher(ctx context.Context, base Config, sources []Source, options WatcherOptions) *Watcher {
+	return newWatcher(ctx, base, sources, options, glock.NewRealClock())
+}
+
+func newWatcher(ctx context.Context, base Config, sources []Source, options WatcherOptions, clock glock.Clock) *Watcher {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Watcher{
+		base:     base,
+		sources:  sources,
+		options:  options,
+		clock:    clock,
+		ctx:      ctx,
+		cancel:   cancel,
+		finished: make(chan struct{}),
+		current:  base,
+	}
+}
+
+// Current returns the effective configuration.
+func (w *Watcher) Current() Config {
+	w.m.RLock()
+	defer w.m.RUnlock()
+	return w.current
+}
+
+// OnChange registers a function that is called with the new configuration every time the
+// effective configuration changes.
+func (w *Watcher) OnChange(handler func(Config)) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+// Start periodically reloads the configuration until Stop is called. Callers that need the
+// configuration of the sources before Start is called should call Reload first.
+func (w *Watcher) Start() {
+	defer close(w.finished)
+
+	for {
+		select {
+		case <-w.clock.After(w.options.Interval):
+		case <-w.ctx.Done():
+			return
+		}
+
+		if err := w.Reload(w.ctx); err != nil && w.ctx.Err() == nil {
+			// Keep the last good configuration until the sources are fixed.
+			log15.Error("Failed to reload indexer configuration", "err", err)
+		}
+	}
+}
+
+func (w *Watcher) Stop() {
+	w.cancel()
+	<-w.finished
+}
+
+// Reload loads the configuration from all sources and, if the resulting configuration is valid
+// and differs from the current one, replaces it and notifies the registered handlers. If any
+// source fails, the current configuration is kept.
+func (w *Watcher) Reload(ctx context.Context) error {
+	config := w.base
+	for _, source := range w.sources {
+		override, ok, err := source.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			config = config.Merge(override)
+		}
+	}
+
+	if err := validate(config); err != nil {
+		return err
+	}
+
+	w.m.Lock()
+	if config.Equal(w.current) {
+		w.m.Unlock()
+		return nil
+	}
+	w.current = config
+	handlers := append([]func(Config){}, w.handlers...)
+	w.m.Unlock()
+
+	log15.Info("Reloaded indexer configuration", "minContainers", config.MinContainers, "maxContainers", config.MaxContainers, "image", config.Image, "cpus", config.CPUs, "memory", config.Memory)
+
+	for _, handler := range handlers {
+		handler(config)
+	}
+
+	return nil
+}
+
+func validate(config Config) error {
+	if config.FrontendURL == "" {
+		return fmt.Errorf("invalid configuration: no frontend URL")
+	}
+	if config.MinContainers < 1 || config.MaxContainers < config.MinContainers {
+		return fmt.Errorf("invalid configuration: container bounds [%d, %d]", config.MinContainers, config.MaxContainers)
+	}
+	if config.CPUs < 0 {
+		return fmt.Errorf("invalid configuration: negative cpus %v", config.CPUs)
+	}
+	if config.Image == "" {
+		return fmt.Errorf("invalid configuration: no image")
+	}
+
+	return nil
+}
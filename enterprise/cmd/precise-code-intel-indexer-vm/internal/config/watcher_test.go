@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/efritz/glock"
+	"github.com/google/go-cmp/cmp"
+)
+
+type testSource struct {
+	config Config
+	ok     bool
+	err    error
+}
+
+func (s *testSource) Load(ctx context.Context) (Config, bool, error) { return s.config, s.ok, s.err }
+
+var testBaseConfig = Config{
+	FrontendURL:   "https://sourcegraph.test",
+	MinContainers: 1,
+	MaxContainers: 2,
+	Image:         "sourcegraph/lsif-go:latest",
+}
+
+func TestWatcherReload(t *testing.T) {
+	fileSource := &testSource{}
+	frontendSource := &testSource{}
+	watcher := newWatcher(context.Background(), testBaseConfig, []Source{fileSource, frontendSource}, WatcherOptions{}, glock.NewMockClock())
+
+	var changes []Config
+	watcher.OnChange(func(config Config) { changes = append(changes, config) })
+
+	// No overrides
+	if err := watcher.Reload(context.Background()); err != nil {
+		t.Fatalf("unexpected error reloading config: %s", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("unexpected changes. want=%d have=%d", 0, len(changes))
+	}
+
+	// Later sources take precedence
+	fileSource.config, fileSource.ok = Config{MaxContainers: 3, Memory: "2g"}, true
+	frontendSource.config, frontendSource.ok = Config{MaxContainers: 4}, true
+	if err := watcher.Reload(context.Background()); err != nil {
+		t.Fatalf("unexpected error reloading config: %s", err)
+	}
+
+	expected := testBaseConfig
+	expected.MaxContainers = 4
+	expected.Memory = "2g"
+	if diff := cmp.Diff(expected, watcher.Current()); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]Config{expected}, changes); diff != "" {
+		t.Errorf("unexpected changes (-want +got):\n%s", diff)
+	}
+
+	// Unchanged configuration does not notify
+	if err := watcher.Reload(context.Background()); err != nil {
+		t.Fatalf("unexpected error reloading config: %s", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("unexpected changes. want=%d have=%d", 1, len(changes))
+	}
+}
+
+func TestWatcherReloadKeepsConfigOnError(t *testing.T) {
+	source := &testSource{config: Config{MaxContainers: 4}, ok: true}
+	watcher := newWatcher(context.Background(), testBaseConfig, []Source{source}, WatcherOptions{}, glock.NewMockClock())
+
+	if err := watcher.Reload(context.Background()); err != nil {
+		t.Fatalf("unexpected error reloading config: %s", err)
+	}
+
+	expected := testBaseConfig
+	expected.MaxContainers = 4
+
+	// Failing source
+	source.err = errors.New("oops")
+	if err := watcher.Reload(context.Background()); err == nil {
+		t.Fatalf("expected an error reloading config")
+	}
+	if diff := cmp.Diff(expected, watcher.Current()); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+
+	// Invalid configuration
+	source.err = nil
+	source.config = Config{MinContainers: 8, MaxContainers: 4}
+	if err := watcher.Reload(context.Background()); err == nil {
+		t.Fatalf("expected an error reloading config")
+	}
+	if diff := cmp.Diff(expected, watcher.Current()); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+}
+
+func TestWatcherStartReloadsPeriodically(t *testing.T) {
+	source := &testSource{}
+	clock := glock.NewMockClock()
+	watcher := newWatcher(context.Background(), testBaseConfig, []Source{source}, WatcherOptions{Interval: time.Second}, clock)
+
+	changes := make(chan Config, 1)
+	watcher.OnChange(func(config Config) { changes <- config })
+
+	go func() { watcher.Start() }()
+	source.config, source.ok = Config{MaxContainers: 4}, true
+	clock.BlockingAdvance(time.Second)
+
+	config := <-changes
+	watcher.Stop()
+
+	if config.MaxContainers != 4 {
+		t.Fatalf("unexpected max containers. want=%d have=%d", 4, config.MaxContainers)
+	}
+}
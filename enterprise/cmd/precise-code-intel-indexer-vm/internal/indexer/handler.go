@@ -7,12 +7,16 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/concurrency"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/config"
 	indexmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/index_manager"
 	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/queue/client"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
@@ -20,26 +24,36 @@ type Handler struct {
 	queueClient  queue.Client
 	indexManager *indexmanager.Manager
 	commander    Commander
+	concurrency  *concurrency.Controller
 	options      HandlerOptions
 }
 
 var _ workerutil.Handler = &Handler{}
+var _ workerutil.WithPreDequeue = &Handler{}
+var _ workerutil.WithHooks = &Handler{}
 
 type HandlerOptions struct {
-	FrontendURL           string
-	FrontendURLFromDocker string
-	AuthToken             string
+	AuthToken string
+
+	// Config returns the current indexer configuration. It is called once at the start of each
+	// index job so that configuration changes apply between jobs.
+	Config func() config.Config
 }
 
 // Handle clones the target code into a temporary directory, invokes the target indexer in a fresh
 // docker container, and uploads the results to the external frontend API.
 func (h *Handler) Handle(ctx context.Context, _ workerutil.Store, record workerutil.Record) error {
 	index := record.(store.Index)
+	cfg := h.options.Config()
+
+	if !cfg.ImageAllowed(cfg.Image) {
+		return failure.WithClass(fmt.Errorf("indexer image %q is not in the image allowlist", cfg.Image), failure.ClassUserConfig)
+	}
 
 	h.indexManager.AddID(index.ID)
 	defer h.indexManager.RemoveID(index.ID)
 
-	repoDir, err := h.fetchRepository(ctx, index.RepositoryName, index.Commit)
+	repoDir, err := h.fetchRepository(ctx, cfg.FrontendURL, index.RepositoryName, index.Commit)
 	if err != nil {
 		return err
 	}
@@ -50,23 +64,46 @@ func (h *Handler) Handle(ctx context.Context, _ workerutil.Store, record workeru
 	indexAndUploadCommand := []string{
 		"lsif-go",
 		"&&",
-		"src", "-endpoint", fmt.Sprintf(h.options.FrontendURLFromDocker), "lsif", "upload", "-repo", index.RepositoryName, "-commit", index.Commit,
+		"src", "-endpoint", cfg.FrontendURLFromDocker, "lsif", "upload", "-repo", index.RepositoryName, "-commit", index.Commit,
 	}
 
-	if err := h.commander.Run(
-		ctx,
-		"docker", "run", "--rm",
+	dockerArgs := []string{"run", "--rm"}
+	if cfg.CPUs > 0 {
+		dockerArgs = append(dockerArgs, "--cpus", strconv.FormatFloat(cfg.CPUs, 'f', -1, 64))
+	}
+	if cfg.Memory != "" {
+		dockerArgs = append(dockerArgs, "--memory", cfg.Memory)
+	}
+	dockerArgs = append(dockerArgs,
 		"-v", fmt.Sprintf("%s:/data", repoDir),
 		"-w", "/data",
-		"sourcegraph/lsif-go:latest",
+		cfg.Image,
 		"bash", "-c", strings.Join(indexAndUploadCommand, " "),
-	); err != nil {
+	)
+
+	if err := h.commander.Run(ctx, "docker", dockerArgs...); err != nil {
 		return errors.Wrap(err, "failed to index repository")
 	}
 
 	return nil
 }
 
+// PreDequeue declines to dequeue a record while the number of running index jobs is at the
+// limit currently chosen by the concurrency controller.
+func (h *Handler) PreDequeue(ctx context.Context) (bool, interface{}, error) {
+	return h.concurrency.HasCapacity(), nil, nil
+}
+
+// PreHandle registers the start of an index job with the concurrency controller.
+func (h *Handler) PreHandle(ctx context.Context, record workerutil.Record) {
+	h.concurrency.JobStarted(record.RecordID())
+}
+
+// PostHandle registers the end of an index job with the concurrency controller.
+func (h *Handler) PostHandle(ctx context.Context, record workerutil.Record) {
+	h.concurrency.JobFinished(record.RecordID())
+}
+
 // makeTempDir is a wrapper around ioutil.TempDir that can be replaced during unit tests.
 var makeTempDir = func() (string, error) {
 	// TMPDIR is set in the dev Procfile to avoid requiring developers to explicitly
@@ -83,7 +120,7 @@ var makeTempDir = func() (string, error) {
 
 // fetchRepository creates a temporary directory and performs a git checkout with the given repository
 // and commit. If there is an error, the temporary directory is removed.
-func (h *Handler) fetchRepository(ctx context.Context, repositoryName, commit string) (string, error) {
+func (h *Handler) fetchRepository(ctx context.Context, frontendURL, repositoryName, commit string) (string, error) {
 	tempDir, err := makeTempDir()
 	if err != nil {
 		return "", err
@@ -94,7 +131,7 @@ func (h *Handler) fetchRepository(ctx context.Context, repositoryName, commit st
 		}
 	}()
 
-	cloneURL, err := makeCloneURL(h.options.FrontendURL, h.options.AuthToken, repositoryName)
+	cloneURL, err := makeCloneURL(frontendURL, h.options.AuthToken, repositoryName)
 	if err != nil {
 		return "", err
 	}
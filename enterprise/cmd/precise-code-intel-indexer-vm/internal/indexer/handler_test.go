@@ -7,15 +7,24 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/config"
 	indexmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/index_manager"
 	queuemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/queue/client/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 )
 
-var testHandlerOptions = HandlerOptions{
+var testConfig = config.Config{
 	FrontendURL:           "https://sourcegraph.test:1234",
 	FrontendURLFromDocker: "https://sourcegraph.test:5432",
-	AuthToken:             "hunter2",
+	MinContainers:         1,
+	MaxContainers:         1,
+	Image:                 "sourcegraph/lsif-go:latest",
+}
+
+var testHandlerOptions = HandlerOptions{
+	AuthToken: "hunter2",
+	Config:    func() config.Config { return testConfig },
 }
 
 func init() {
@@ -63,3 +72,70 @@ func TestHandle(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleResourceLimits(t *testing.T) {
+	commander := NewMockCommander()
+
+	cfg := testConfig
+	cfg.Image = "sourcegraph/lsif-go:insiders"
+	cfg.CPUs = 1.5
+	cfg.Memory = "4g"
+
+	handler := &Handler{
+		queueClient:  queuemocks.NewMockClient(),
+		indexManager: indexmanager.New(),
+		commander:    commander,
+		options: HandlerOptions{
+			AuthToken: "hunter2",
+			Config:    func() config.Config { return cfg },
+		},
+	}
+
+	index := store.Index{
+		ID:             42,
+		RepositoryName: "github.com/sourcegraph/sourcegraph",
+		Commit:         "e2249f2173e8ca0c8c2541644847e7bf01aaef4a",
+	}
+
+	if err := handler.Handle(context.Background(), nil, index); err != nil {
+		t.Fatalf("unexpected error handling index: %s", err)
+	}
+
+	calls := commander.RunFunc.History()
+	if len(calls) != 4 {
+		t.Fatalf("unexpected run call count. want=%d have=%d", 4, len(calls))
+	}
+
+	expectedCall := "docker run --rm --cpus 1.5 --memory 4g -v /tmp/testing:/data -w /data sourcegraph/lsif-go:insiders bash -c lsif-go && src -endpoint https://sourcegraph.test:5432 lsif upload -repo github.com/sourcegraph/sourcegraph -commit e2249f2173e8ca0c8c2541644847e7bf01aaef4a"
+	if diff := cmp.Diff(expectedCall, fmt.Sprintf("%s %s", calls[3].Arg1, strings.Join(calls[3].Arg2, " "))); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleImageNotAllowed(t *testing.T) {
+	commander := NewMockCommander()
+
+	cfg := testConfig
+	cfg.ImageAllowlist = []string{"sourcegraph/lsif-go:3.0"}
+
+	handler := &Handler{
+		queueClient:  queuemocks.NewMockClient(),
+		indexManager: indexmanager.New(),
+		commander:    commander,
+		options: HandlerOptions{
+			AuthToken: "hunter2",
+			Config:    func() config.Config { return cfg },
+		},
+	}
+
+	err := handler.Handle(context.Background(), nil, store.Index{ID: 42})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if class := failure.Classify(err); class != failure.ClassUserConfig {
+		t.Errorf("unexpected failure class. want=%s have=%s", failure.ClassUserConfig, class)
+	}
+	if callCount := len(commander.RunFunc.History()); callCount != 0 {
+		t.Errorf("unexpected run call count. want=%d have=%d", 0, callCount)
+	}
+}
@@ -4,23 +4,27 @@ import (
 	"context"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/concurrency"
 	indexmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/index_manager"
 	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/queue/client"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
 type IndexerOptions struct {
+	// NumIndexers is the maximum number of index jobs that may run at once. The number of
+	// jobs actually running is further limited by the concurrency controller.
 	NumIndexers    int
 	Interval       time.Duration
 	Metrics        IndexerMetrics
 	HandlerOptions HandlerOptions
 }
 
-func NewIndexer(ctx context.Context, queueClient queue.Client, indexManager *indexmanager.Manager, options IndexerOptions) *workerutil.Worker {
+func NewIndexer(ctx context.Context, queueClient queue.Client, indexManager *indexmanager.Manager, concurrencyController *concurrency.Controller, options IndexerOptions) *workerutil.Worker {
 	handler := &Handler{
 		queueClient:  queueClient,
 		indexManager: indexManager,
 		commander:    DefaultCommander,
+		concurrency:  concurrencyController,
 		options:      options.HandlerOptions,
 	}
 
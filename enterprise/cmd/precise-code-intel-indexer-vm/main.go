@@ -10,11 +10,12 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/concurrency"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/config"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/heartbeat"
 	indexmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/index_manager"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/indexer"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer-vm/internal/server"
-	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/queue/client"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -33,12 +34,28 @@ func main() {
 		internalProxyAuthToken   = mustGet(rawInternalProxyAuthToken, "PRECISE_CODE_INTEL_INTERNAL_PROXY_AUTH_TOKEN")
 		indexerPollInterval      = mustParseInterval(rawIndexerPollInterval, "PRECISE_CODE_INTEL_INDEXER_POLL_INTERVAL")
 		indexerHeartbeatInterval = mustParseInterval(rawIndexerHeartbeatInterval, "PRECISE_CODE_INTEL_INDEXER_HEARTBEAT_INTERVAL")
-		numContainers            = mustParseInt(rawMaxContainers, "PRECISE_CODE_INTEL_MAXIMUM_CONTAINERS")
+		maxContainers            = mustParseInt(rawMaxContainers, "PRECISE_CODE_INTEL_MAXIMUM_CONTAINERS")
+		minContainers            = mustParseInt(rawMinContainers, "PRECISE_CODE_INTEL_MINIMUM_CONTAINERS")
+		concurrencyInterval      = mustParseInterval(rawConcurrencyInterval, "PRECISE_CODE_INTEL_CONCURRENCY_ADJUSTMENT_INTERVAL")
+		highLoadThreshold        = mustParseFloat(rawHighLoadThreshold, "PRECISE_CODE_INTEL_HIGH_LOAD_THRESHOLD")
+		lowLoadThreshold         = mustParseFloat(rawLowLoadThreshold, "PRECISE_CODE_INTEL_LOW_LOAD_THRESHOLD")
+		latencyThreshold         = mustParseFloat(rawLatencyThreshold, "PRECISE_CODE_INTEL_LATENCY_THRESHOLD")
+		containerCeiling         = mustParseInt(rawContainerCeiling, "PRECISE_CODE_INTEL_CONTAINER_CEILING")
+		indexerImage             = mustGet(rawIndexerImage, "PRECISE_CODE_INTEL_INDEXER_IMAGE")
+		indexerImageAllowlist    = parseList(rawIndexerImageAllowlist)
+		indexerCPUs              = mustParseFloat(rawIndexerCPUs, "PRECISE_CODE_INTEL_INDEXER_CPUS")
+		indexerMemory            = rawIndexerMemory
+		configFile               = rawConfigFile
+		configFromFrontend       = mustParseBool(rawConfigFromFrontend, "PRECISE_CODE_INTEL_CONFIG_FROM_FRONTEND")
+		configReloadInterval     = mustParseInterval(rawConfigReloadInterval, "PRECISE_CODE_INTEL_CONFIG_RELOAD_INTERVAL")
 	)
 
 	if frontendURLFromDocker == "" {
 		frontendURLFromDocker = frontendURL
 	}
+	if containerCeiling < maxContainers {
+		containerCeiling = maxContainers
+	}
 
 	observationContext := &observation.Context{
 		Logger:     log15.Root(),
@@ -48,32 +65,74 @@ func main() {
 
 	indexerName := uuid.New().String()
 
-	queueClient := queue.NewClient(
+	queueClient := newReloadingQueueClient(
 		indexerName,
 		frontendURL,
 		internalProxyAuthToken,
 	)
+
+	var configSources []config.Source
+	if configFile != "" {
+		configSources = append(configSources, &config.FileSource{Path: configFile})
+	}
+	if configFromFrontend {
+		configSources = append(configSources, &config.FrontendSource{
+			FrontendURL: queueClient.FrontendURL,
+			AuthToken:   internalProxyAuthToken,
+		})
+	}
+	configWatcher := config.NewWatcher(context.Background(), config.Config{
+		FrontendURL:           frontendURL,
+		FrontendURLFromDocker: frontendURLFromDocker,
+		MinContainers:         minContainers,
+		MaxContainers:         maxContainers,
+		Image:                 indexerImage,
+		ImageAllowlist:        indexerImageAllowlist,
+		CPUs:                  indexerCPUs,
+		Memory:                indexerMemory,
+	}, configSources, config.WatcherOptions{
+		Interval: configReloadInterval,
+	})
+	if err := configWatcher.Reload(context.Background()); err != nil {
+		log15.Error("Failed to load indexer configuration, using environment", "err", err)
+	}
+	initialConfig := configWatcher.Current()
+
 	indexManager := indexmanager.New()
 	server := server.New()
 	heartbeater := heartbeat.NewHeartbeater(context.Background(), queueClient, indexManager, heartbeat.HeartbeaterOptions{
 		Interval: indexerHeartbeatInterval,
 	})
+	concurrencyController := concurrency.NewController(context.Background(), &concurrency.ProcSampler{DiskPath: os.TempDir()}, concurrency.ControllerOptions{
+		MinConcurrency:    initialConfig.MinContainers,
+		MaxConcurrency:    min(initialConfig.MaxContainers, containerCeiling),
+		Interval:          concurrencyInterval,
+		HighLoadThreshold: highLoadThreshold,
+		LowLoadThreshold:  lowLoadThreshold,
+		LatencyThreshold:  latencyThreshold,
+	})
 	indexerMetrics := indexer.NewIndexerMetrics(observationContext)
-	indexer := indexer.NewIndexer(context.Background(), queueClient, indexManager, indexer.IndexerOptions{
-		NumIndexers: numContainers,
+	indexer := indexer.NewIndexer(context.Background(), queueClient, indexManager, concurrencyController, indexer.IndexerOptions{
+		NumIndexers: containerCeiling,
 		Interval:    indexerPollInterval,
 		Metrics:     indexerMetrics,
 		HandlerOptions: indexer.HandlerOptions{
-			FrontendURL:           frontendURL,
-			FrontendURLFromDocker: frontendURLFromDocker,
-			AuthToken:             internalProxyAuthToken,
+			AuthToken: internalProxyAuthToken,
+			Config:    configWatcher.Current,
 		},
 	})
 
+	configWatcher.OnChange(func(c config.Config) {
+		queueClient.SetFrontendURL(c.FrontendURL)
+		concurrencyController.SetBounds(c.MinContainers, min(c.MaxContainers, containerCeiling))
+	})
+
 	go server.Start()
 	go indexer.Start()
 	go debugserver.Start()
 	go heartbeater.Start()
+	go concurrencyController.Start()
+	go configWatcher.Start()
 
 	signals := make(chan os.Signal, 2)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGHUP)
@@ -88,4 +147,13 @@ func main() {
 	server.Stop()
 	indexer.Stop()
 	heartbeater.Stop()
+	concurrencyController.Stop()
+	configWatcher.Stop()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/queue/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// reloadingQueueClient is a queue client that targets the current frontend URL. The underlying
+// client is replaced when the frontend URL changes; requests that are already in flight finish
+// against the previous URL.
+type reloadingQueueClient struct {
+	indexerName string
+	authToken   string
+
+	m           sync.RWMutex
+	frontendURL string
+	client      queue.Client
+}
+
+var _ queue.Client = &reloadingQueueClient{}
+
+func newReloadingQueueClient(indexerName, frontendURL, authToken string) *reloadingQueueClient {
+	return &reloadingQueueClient{
+		indexerName: indexerName,
+		authToken:   authToken,
+		frontendURL: frontendURL,
+		client:      queue.NewClient(indexerName, frontendURL, authToken),
+	}
+}
+
+// FrontendURL returns the frontend URL targeted by the current client.
+func (c *reloadingQueueClient) FrontendURL() string {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.frontendURL
+}
+
+// SetFrontendURL replaces the underlying client if the given URL differs from the current one.
+func (c *reloadingQueueClient) SetFrontendURL(frontendURL string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if frontendURL != c.frontendURL {
+		c.frontendURL = frontendURL
+		c.client = queue.NewClient(c.indexerName, frontendURL, c.authToken)
+	}
+}
+
+func (c *reloadingQueueClient) current() queue.Client {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.client
+}
+
+func (c *reloadingQueueClient) Dequeue(ctx context.Context) (store.Index, bool, error) {
+	return c.current().Dequeue(ctx)
+}
+
+func (c *reloadingQueueClient) Complete(ctx context.Context, indexID int, indexErr error) error {
+	return c.current().Complete(ctx, indexID, indexErr)
+}
+
+func (c *reloadingQueueClient) Heartbeat(ctx context.Context, indexIDs []int) error {
+	return c.current().Heartbeat(ctx, indexIDs)
+}
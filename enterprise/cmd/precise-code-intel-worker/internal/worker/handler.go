@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -132,7 +133,12 @@ func (h *handler) handle(ctx context.Context, store store.Store, upload store.Up
 
 	groupedBundleData, err := correlation.Correlate(ctx, r, upload.ID, upload.Root, getChildren, h.metrics)
 	if err != nil {
-		return false, errors.Wrap(err, "correlation.Correlate")
+		err = errors.Wrap(err, "correlation.Correlate")
+		if failure.Classify(err) == failure.ClassInternal {
+			// Errors that are not caused by talking to gitserver are caused by malformed uploads
+			err = failure.WithClass(err, failure.ClassUserConfig)
+		}
+		return false, err
 	}
 
 	if err := h.write(ctx, tempDir, groupedBundleData); err != nil {
@@ -34,6 +34,7 @@ func NewWorker(
 	}
 
 	return dbworker.NewWorker(rootContext, store.WorkerutilUploadStore(s), dbworker.WorkerOptions{
+		Name:        "precise_code_intel_upload_worker",
 		Handler:     handler,
 		NumHandlers: numProcessorRoutines,
 		Interval:    pollInterval,
@@ -8,8 +8,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/inconshreveable/log15"
-
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repoupdater"
@@ -45,7 +43,10 @@ func enterpriseInit(
 	ctx := context.Background()
 	campaignsStore := campaigns.NewStore(db)
 
-	syncRegistry := campaigns.NewSyncRegistry(ctx, campaignsStore, repoStore, cf)
+	// Coordinates the campaigns background work between replicas.
+	locker := campaigns.NewLocker(db)
+
+	syncRegistry := campaigns.NewSyncRegistry(ctx, campaignsStore, repoStore, cf, locker)
 	if server != nil {
 		server.ChangesetSyncRegistry = syncRegistry
 	}
@@ -54,25 +55,25 @@ func enterpriseInit(
 		return time.Now().UTC().Truncate(time.Microsecond)
 	}
 
-	sourcer := repos.NewSourcer(cf)
-	go campaigns.RunWorkers(ctx, campaignsStore, gitserver.DefaultClient, sourcer)
-
-	// Set up expired spec deletion
-	go func() {
-		for {
-			// We first need to delete expired ChangesetSpecs...
-			if err := campaignsStore.DeleteExpiredChangesetSpecs(ctx); err != nil {
-				log15.Error("DeleteExpiredChangesetSpecs", "error", err)
-			}
-			// ... and then the CampaignSpecs, due to the campaign_spec_id
-			// foreign key on changeset_specs.
-			if err := campaignsStore.DeleteExpiredCampaignSpecs(ctx); err != nil {
-				log15.Error("DeleteExpiredCampaignSpecs", "error", err)
-			}
+	budgets := campaigns.NewPublicationBudgets(repoStore)
+	if server != nil {
+		server.CampaignsPublicationBudgets = budgets
+	}
 
-			time.Sleep(2 * time.Minute)
-		}
-	}()
+	sourcer := repos.NewSourcer(cf)
+	go campaigns.RunWorkers(ctx, campaignsStore, gitserver.DefaultClient, sourcer, budgets, syncRegistry, locker)
+	go campaigns.RunAutoMerger(ctx, campaignsStore, cf, sourcer, locker)
+	go campaigns.RunAutoRebaser(ctx, campaignsStore, gitserver.DefaultClient, sourcer, syncRegistry, locker)
+	go campaigns.RunDiffStatWorker(ctx, campaignsStore)
+	go campaigns.RunReapplyScheduler(ctx, campaignsStore, locker)
+	go campaigns.RunNotifier(ctx, campaignsStore, locker)
+	go campaigns.RunWebhookDeliverer(ctx, campaignsStore, cf, locker)
+	go campaigns.RunReapplyWorker(ctx, campaignsStore)
+	go campaigns.RunSpecExecutor(ctx, campaignsStore, cf, locker)
+	go campaigns.RunURLChecker(ctx, campaignsStore, cf, locker)
+	go campaigns.RunBulkOperationWorker(ctx, campaignsStore, cf, sourcer)
+	go campaigns.RunChangesetEventsCompactor(ctx, campaignsStore, locker)
+	go campaigns.RunSpecExpirer(ctx, campaignsStore, locker)
 
 	// TODO(jchen): This is an unfortunate compromise to not rewrite ossDB.ExternalServices for now.
 	dbconn.Global = db
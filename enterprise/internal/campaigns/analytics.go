@@ -0,0 +1,186 @@
+package campaigns
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// CampaignAnalytics are statistics about how fast the changesets of a
+// campaign are being reviewed and merged.
+type CampaignAnalytics struct {
+	// MedianTimeToFirstReview is the median duration between a changeset
+	// being published and its first review. It is nil if no changeset has
+	// been reviewed.
+	MedianTimeToFirstReview *time.Duration
+	// MedianTimeToMerge is the median duration between a changeset being
+	// published and being merged. It is nil if no changeset has been merged.
+	MedianTimeToMerge *time.Duration
+	// MergedByWeek contains the number of published and merged changesets at
+	// the end of each week, oldest first.
+	MergedByWeek []*WeeklyMergeCounts
+}
+
+// WeeklyMergeCounts are the number of changesets that were published and
+// merged at a given point in time.
+type WeeklyMergeCounts struct {
+	Time      time.Time
+	Published int32
+	Merged    int32
+}
+
+// MergedPercentage returns the percentage of published changesets that were
+// merged.
+func (c *WeeklyMergeCounts) MergedPercentage() float64 {
+	if c.Published == 0 {
+		return 0
+	}
+	return float64(c.Merged) / float64(c.Published) * 100
+}
+
+// CalcAnalytics calculates the CampaignAnalytics for the given published
+// Changesets and their ChangesetEvents. The weekly merge counts start at the
+// week in which start lies and end at end.
+func CalcAnalytics(start, end time.Time, cs []*campaigns.Changeset, es ...*campaigns.ChangesetEvent) (*CampaignAnalytics, error) {
+	c := NewAnalyticsCalculator(start, end)
+	if err := c.Add(cs, es...); err != nil {
+		return nil, err
+	}
+	return c.Analytics(), nil
+}
+
+// An AnalyticsCalculator calculates CampaignAnalytics like CalcAnalytics, but
+// from Changesets that are added in batches, so that they don't all have to
+// be loaded at once.
+type AnalyticsCalculator struct {
+	analytics                        *CampaignAnalytics
+	timesToFirstReview, timesToMerge []time.Duration
+}
+
+// NewAnalyticsCalculator returns an AnalyticsCalculator whose weekly merge
+// counts start at the week in which start lies and end at end.
+func NewAnalyticsCalculator(start, end time.Time) *AnalyticsCalculator {
+	ts := generateWeeklyTimestamps(start, end)
+	analytics := &CampaignAnalytics{MergedByWeek: make([]*WeeklyMergeCounts, len(ts))}
+	for i, t := range ts {
+		analytics.MergedByWeek[i] = &WeeklyMergeCounts{Time: t}
+	}
+	return &AnalyticsCalculator{analytics: analytics}
+}
+
+// Add adds the given published Changesets and their ChangesetEvents to the
+// CampaignAnalytics.
+func (a *AnalyticsCalculator) Add(cs []*campaigns.Changeset, es ...*campaigns.ChangesetEvent) error {
+	events := ChangesetEvents(es)
+	sort.Sort(events)
+
+	byChangesetID := make(map[int64]ChangesetEvents)
+	for _, e := range events {
+		id := e.Changeset()
+		byChangesetID[id] = append(byChangesetID[id], e)
+	}
+
+	for _, c := range cs {
+		csEvents := byChangesetID[c.ID]
+
+		history, err := computeHistory(c, csEvents)
+		if err != nil {
+			return err
+		}
+
+		publishedAt := c.ExternalCreatedAt()
+		if reviewedAt := firstReviewTime(csEvents); !reviewedAt.IsZero() {
+			a.timesToFirstReview = append(a.timesToFirstReview, reviewedAt.Sub(publishedAt))
+		}
+		if mergedAt := mergeTime(history); !mergedAt.IsZero() {
+			a.timesToMerge = append(a.timesToMerge, mergedAt.Sub(publishedAt))
+		}
+
+		for _, w := range a.analytics.MergedByWeek {
+			states, ok := history.StatesAtTime(w.Time)
+			if !ok {
+				// Changeset wasn't published yet
+				continue
+			}
+
+			w.Published++
+			if states.externalState == campaigns.ChangesetExternalStateMerged {
+				w.Merged++
+			}
+		}
+	}
+
+	return nil
+}
+
+// Analytics returns the CampaignAnalytics of the Changesets added so far.
+func (a *AnalyticsCalculator) Analytics() *CampaignAnalytics {
+	a.analytics.MedianTimeToFirstReview = medianDuration(a.timesToFirstReview)
+	a.analytics.MedianTimeToMerge = medianDuration(a.timesToMerge)
+	return a.analytics
+}
+
+// firstReviewTime returns the time of the first review event in the given
+// sorted ChangesetEvents or the zero time if there is none.
+func firstReviewTime(es ChangesetEvents) time.Time {
+	for _, e := range es {
+		switch e.Kind {
+		case campaigns.ChangesetEventKindGitHubReviewed,
+			campaigns.ChangesetEventKindBitbucketServerApproved,
+			campaigns.ChangesetEventKindBitbucketServerReviewed,
+			campaigns.ChangesetEventKindBitbucketServerParticipantApproved,
+			campaigns.ChangesetEventKindBitbucketServerParticipantReviewed,
+			campaigns.ChangesetEventKindGitLabApproved,
+			campaigns.ChangesetEventKindBitbucketCloudApproved,
+			campaigns.ChangesetEventKindBitbucketCloudChangesRequested:
+			if t := e.Timestamp(); !t.IsZero() {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// mergeTime returns the time at which the changeset with the given history
+// was merged or the zero time if it wasn't merged.
+func mergeTime(h changesetHistory) time.Time {
+	for _, s := range h {
+		if s.externalState == campaigns.ChangesetExternalStateMerged {
+			return s.t
+		}
+	}
+	return time.Time{}
+}
+
+func medianDuration(ds []time.Duration) *time.Duration {
+	if len(ds) == 0 {
+		return nil
+	}
+
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+
+	median := ds[len(ds)/2]
+	if len(ds)%2 == 0 {
+		median = (ds[len(ds)/2-1] + ds[len(ds)/2]) / 2
+	}
+	return &median
+}
+
+func generateWeeklyTimestamps(start, end time.Time) []time.Time {
+	// Walk backwards from `end` in 1 week intervals until the week ending at
+	// the current timestamp contains `start`. Backwards so we always end
+	// exactly on `end`.
+	ts := []time.Time{end}
+	for t := end.AddDate(0, 0, -7); !t.Before(start); t = t.AddDate(0, 0, -7) {
+		ts = append(ts, t)
+	}
+
+	// Now reverse so we go from oldest to newest in slice
+	for i := len(ts)/2 - 1; i >= 0; i-- {
+		opp := len(ts) - 1 - i
+		ts[i], ts[opp] = ts[opp], ts[i]
+	}
+
+	return ts
+}
@@ -0,0 +1,90 @@
+package campaigns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestCalcAnalytics(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	duration := func(d time.Duration) *time.Duration { return &d }
+
+	tests := []struct {
+		name       string
+		changesets []*campaigns.Changeset
+		start      time.Time
+		events     []*campaigns.ChangesetEvent
+		want       *CampaignAnalytics
+	}{
+		{
+			name:  "no changesets",
+			start: daysAgo(3),
+			want: &CampaignAnalytics{
+				MergedByWeek: []*WeeklyMergeCounts{
+					{Time: daysAgo(0)},
+				},
+			},
+		},
+		{
+			name: "reviewed and merged changesets",
+			changesets: []*campaigns.Changeset{
+				ghChangeset(1, daysAgo(20)),
+				ghChangeset(2, daysAgo(15)),
+				ghChangeset(3, daysAgo(10)),
+				bbsChangeset(4, daysAgo(3)),
+			},
+			start: daysAgo(20),
+			events: []*campaigns.ChangesetEvent{
+				ghReview(1, daysAgo(19), "user1", "COMMENTED"),
+				ghReview(1, daysAgo(18), "user2", "APPROVED"),
+				event(t, daysAgo(16), campaigns.ChangesetEventKindGitHubMerged, 1),
+				ghReview(2, daysAgo(12), "user1", "APPROVED"),
+				event(t, daysAgo(5), campaigns.ChangesetEventKindGitHubMerged, 2),
+				bbsActivity(4, daysAgo(2), "user1", campaigns.ChangesetEventKindBitbucketServerApproved),
+			},
+			want: &CampaignAnalytics{
+				// Time to first review: 1, 3, and 1 days.
+				MedianTimeToFirstReview: duration(24 * time.Hour),
+				// Time to merge: 4 and 10 days.
+				MedianTimeToMerge: duration(7 * 24 * time.Hour),
+				MergedByWeek: []*WeeklyMergeCounts{
+					{Time: daysAgo(14), Published: 2, Merged: 1},
+					{Time: daysAgo(7), Published: 3, Merged: 1},
+					{Time: daysAgo(0), Published: 4, Merged: 2},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := CalcAnalytics(tc.start, now, tc.changesets, tc.events...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong analytics (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWeeklyMergeCountsMergedPercentage(t *testing.T) {
+	for _, tc := range []struct {
+		counts WeeklyMergeCounts
+		want   float64
+	}{
+		{counts: WeeklyMergeCounts{}, want: 0},
+		{counts: WeeklyMergeCounts{Published: 4, Merged: 1}, want: 25},
+		{counts: WeeklyMergeCounts{Published: 3, Merged: 3}, want: 100},
+	} {
+		if have := tc.counts.MergedPercentage(); have != tc.want {
+			t.Errorf("wrong percentage for %+v. want=%f have=%f", tc.counts, tc.want, have)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// autoMergeInterval is the time between two passes of the auto-merger.
+const autoMergeInterval = 1 * time.Minute
+
+// RunAutoMerger periodically merges the changesets of campaigns that have
+// auto-merge enabled, once their checks passed and they have been approved.
+// It runs until the given context is canceled. If locker is not nil, merges
+// are only attempted by the replica that's the leader of the auto-merger job.
+func RunAutoMerger(ctx context.Context, s *Store, cf *httpcli.Factory, sourcer repos.Sourcer, locker *Locker) {
+	m := &autoMerger{store: s, cf: cf, sourcer: sourcer}
+	locker.DoAsLeader(ctx, LeaderJobAutoMerger, m.loop)
+}
+
+type autoMerger struct {
+	store   *Store
+	cf      *httpcli.Factory
+	sourcer repos.Sourcer
+}
+
+func (m *autoMerger) loop(ctx context.Context) {
+	for {
+		if err := m.run(ctx); err != nil {
+			log15.Error("Auto-merging changesets", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(autoMergeInterval):
+		}
+	}
+}
+
+// run merges all changesets that are ready to be merged automatically and
+// records the merges in the activity logs of their campaigns.
+func (m *autoMerger) run(ctx context.Context) error {
+	cs, err := m.store.ListAutoMergeableChangesets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing auto-mergeable changesets")
+	}
+
+	// The code host would reject the merge of a changeset with conflicts
+	// anyway, so we don't even try.
+	cs = cs.Filter(func(c *campaigns.Changeset) bool {
+		return c.MergeableState() != campaigns.ChangesetMergeableStateConflicting
+	})
+	if len(cs) == 0 {
+		return nil
+	}
+
+	reposStore := repos.NewDBStore(m.store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, m.cf, m.sourcer, cs...)
+	if err != nil {
+		return err
+	}
+
+	errs := &multierror.Error{}
+	var merged []*campaigns.Changeset
+	for _, group := range bySource {
+		for _, c := range group.Changesets {
+			if err := group.MergeChangeset(ctx, c); err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "merging changeset %d", c.Changeset.ID))
+				continue
+			}
+			merged = append(merged, c.Changeset)
+		}
+	}
+
+	if len(merged) > 0 {
+		if err := m.recordMerges(ctx, merged); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+
+		// Sync the changesets so that their state and events reflect the
+		// merge right away, instead of after the next run of the syncer.
+		if err := syncChangesetsWithSources(ctx, m.store, bySource); err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, "syncing merged changesets"))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// recordMerges adds an entry to the activity log of every campaign with
+// auto-merge enabled that contains one of the given changesets.
+func (m *autoMerger) recordMerges(ctx context.Context, merged []*campaigns.Changeset) (err error) {
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	byID := make(map[int64]*campaigns.Campaign)
+	for _, c := range merged {
+		for _, id := range c.CampaignIDs {
+			campaign, ok := byID[id]
+			if !ok {
+				if campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: id}); err != nil {
+					return err
+				}
+				byID[id] = campaign
+			}
+
+			if !campaign.AutoMerge || campaign.Closed() {
+				continue
+			}
+
+			err = tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+				CampaignID:  campaign.ID,
+				ChangesetID: c.ID,
+				Kind:        campaigns.CampaignActivityKindChangesetAutoMerged,
+				Metadata:    map[string]interface{}{"external_id": c.ExternalID},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+func TestAutoMergerRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	rs, extSvc := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	// After merging, the changesets are synced, so we need to mock that.
+	state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: api.RepoName(rs[0].Name),
+		VCS:  protocol.VCSInfo{URL: rs[0].URI},
+	})
+	defer state.Unmock()
+
+	autoMergeCampaign := testCampaign(admin.ID)
+	autoMergeCampaign.AutoMerge = true
+	manualCampaign := testCampaign(admin.ID)
+	for _, c := range []*campaigns.Campaign{autoMergeCampaign, manualCampaign} {
+		if err := store.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	createChangeset := func(campaign *campaigns.Campaign, checkState campaigns.ChangesetCheckState) *campaigns.Changeset {
+		t.Helper()
+
+		c := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		c.PublicationState = campaigns.ChangesetPublicationStatePublished
+		c.ReconcilerState = campaigns.ReconcilerStateCompleted
+		c.ExternalCheckState = checkState
+		c.ExternalReviewState = campaigns.ChangesetReviewStateApproved
+		if err := store.CreateChangeset(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	ready := createChangeset(autoMergeCampaign, campaigns.ChangesetCheckStatePassed)
+	createChangeset(autoMergeCampaign, campaigns.ChangesetCheckStateFailed)
+	createChangeset(manualCampaign, campaigns.ChangesetCheckStatePassed)
+
+	fakeSource := &ct.FakeChangesetSource{Svc: extSvc}
+	m := &autoMerger{store: store, sourcer: repos.NewFakeSourcer(nil, fakeSource)}
+
+	if err := m.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := len(fakeSource.MergedChangesets), 1; have != want {
+		t.Fatalf("wrong number of merged changesets. want=%d, have=%d", want, have)
+	}
+	if have, want := fakeSource.MergedChangesets[0].Changeset.ID, ready.ID; have != want {
+		t.Fatalf("wrong changeset merged. want=%d, have=%d", want, have)
+	}
+
+	activities, _, err := store.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{CampaignID: autoMergeCampaign.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activities) != 1 {
+		t.Fatalf("wrong number of activities. want=1, have=%d", len(activities))
+	}
+	if have, want := activities[0].Kind, campaigns.CampaignActivityKindChangesetAutoMerged; have != want {
+		t.Fatalf("wrong activity kind. want=%s, have=%s", want, have)
+	}
+	if have, want := activities[0].ChangesetID, ready.ID; have != want {
+		t.Fatalf("wrong activity changeset. want=%d, have=%d", want, have)
+	}
+
+	activities, _, err = store.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{CampaignID: manualCampaign.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activities) != 0 {
+		t.Fatalf("wrong number of activities. want=0, have=%d", len(activities))
+	}
+}
@@ -0,0 +1,196 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// autoRebaseInterval is the time between two passes of the auto-rebaser.
+const autoRebaseInterval = 5 * time.Minute
+
+// ChangesetSyncEnqueuer enqueues changesets to be synced as soon as possible.
+type ChangesetSyncEnqueuer interface {
+	EnqueueChangesetSyncs(ctx context.Context, ids []int64) error
+}
+
+// RunAutoRebaser periodically rebases the changesets of campaigns that have
+// auto-rebase enabled onto the current head of their base branch, whenever
+// the base branch advanced. It runs until the given context is canceled. If
+// locker is not nil, rebases are only done by the replica that's the leader
+// of the auto-rebaser job.
+func RunAutoRebaser(ctx context.Context, s *Store, gitClient GitserverClient, sourcer repos.Sourcer, syncs ChangesetSyncEnqueuer, locker *Locker) {
+	r := &autoRebaser{
+		store:      s,
+		reconciler: &reconciler{gitserverClient: gitClient, sourcer: sourcer, store: s},
+		syncs:      syncs,
+	}
+	locker.DoAsLeader(ctx, LeaderJobAutoRebaser, r.loop)
+}
+
+type autoRebaser struct {
+	store *Store
+	// reconciler is used to push the rebased branches, with the same
+	// credentials and to the same forks as when the changesets were
+	// published.
+	reconciler *reconciler
+	// syncs is notified of the rebased changesets. If nil, they're synced on
+	// the next regular sync.
+	syncs ChangesetSyncEnqueuer
+}
+
+func (r *autoRebaser) loop(ctx context.Context) {
+	for {
+		if err := r.run(ctx); err != nil {
+			log15.Error("Auto-rebasing changesets", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(autoRebaseInterval):
+		}
+	}
+}
+
+// run rebases all auto-rebaseable changesets whose base branch advanced and
+// records the rebases as changeset events.
+func (r *autoRebaser) run(ctx context.Context) error {
+	cs, err := r.store.ListAutoRebaseableChangesets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing auto-rebaseable changesets")
+	}
+
+	errs := &multierror.Error{}
+	var rebased []int64
+	for _, ch := range cs {
+		ok, err := r.rebase(ctx, ch)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "rebasing changeset %d", ch.ID))
+			continue
+		}
+		if ok {
+			rebased = append(rebased, ch.ID)
+		}
+	}
+
+	// Sync the changesets so that their diff and mergeability reflect the
+	// rebased branches right away.
+	if len(rebased) > 0 && r.syncs != nil {
+		if err := r.syncs.EnqueueChangesetSyncs(ctx, rebased); err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, "enqueueing sync of rebased changesets"))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// rebase re-applies the diff of the current spec of the given changeset on
+// top of the head of its base branch and force-pushes it, if the base branch
+// advanced since the branch was last pushed. It returns whether the changeset
+// was rebased.
+func (r *autoRebaser) rebase(ctx context.Context, ch *campaigns.Changeset) (bool, error) {
+	spec, err := r.store.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
+	if err != nil {
+		return false, err
+	}
+
+	repo, extSvc, err := loadAssociations(ctx, r.store, ch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load associations")
+	}
+
+	head, err := git.ResolveRevision(ctx, gitserver.Repo{Name: api.RepoName(repo.Name)}, nil, spec.Spec.BaseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "resolving base branch %q", spec.Spec.BaseRef)
+	}
+
+	base, err := changesetBaseRev(ctx, r.store, ch, spec)
+	if err != nil {
+		return false, err
+	}
+	if base == string(head) {
+		return false, nil
+	}
+
+	opts, err := buildCommitOpts(api.RepoName(repo.Name), spec)
+	if err != nil {
+		return false, err
+	}
+	opts.BaseCommit = head
+
+	cred, err := loadUserCredential(ctx, r.store, ch, repo)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load user credential")
+	}
+	if cred != nil {
+		opts.PushUsername, opts.PushPassword = userCredentialPushAuth(cred)
+	}
+
+	if ch.ExternalForkNamespace != "" {
+		ccs, err := r.reconciler.buildChangesetSource(repo, extSvc, cred)
+		if err != nil {
+			return false, err
+		}
+		if _, opts.PushRemotePath, err = ensureFork(ctx, ccs, repo); err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := pushCommit(ctx, r.reconciler.gitserverClient, opts); err != nil {
+		return false, err
+	}
+
+	return true, recordChangesetRebase(ctx, r.store, ch, &campaigns.ChangesetRebase{
+		ChangesetSpecID: spec.ID,
+		FromBaseRev:     base,
+		ToBaseRev:       string(head),
+		Automatic:       true,
+	})
+}
+
+// changesetBaseRev returns the commit that the branch of the given changeset
+// is based on: the commit it was last rebased onto with the diff of the given
+// spec, or else the base revision of the spec.
+func changesetBaseRev(ctx context.Context, s *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec) (string, error) {
+	events, _, err := s.ListChangesetEvents(ctx, ListChangesetEventsOpts{
+		ChangesetIDs: []int64{ch.ID},
+		Kinds:        []campaigns.ChangesetEventKind{campaigns.ChangesetEventKindSourcegraphRebased},
+		Limit:        -1,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	base := spec.Spec.BaseRev
+	for _, e := range events {
+		if rebase, ok := e.Metadata.(*campaigns.ChangesetRebase); ok && rebase.ChangesetSpecID == spec.ID {
+			base = rebase.ToBaseRev
+		}
+	}
+	return base, nil
+}
+
+// recordChangesetRebase records the given rebase of the changeset as a
+// changeset event.
+func recordChangesetRebase(ctx context.Context, s *Store, ch *campaigns.Changeset, rebase *campaigns.ChangesetRebase) error {
+	if rebase.CreatedAt.IsZero() {
+		rebase.CreatedAt = s.Clock()()
+	}
+
+	return s.UpsertChangesetEvents(ctx, &campaigns.ChangesetEvent{
+		ChangesetID: ch.ID,
+		Kind:        campaigns.ChangesetEventKindSourcegraphRebased,
+		Key:         fmt.Sprintf("%d:%s", rebase.ChangesetSpecID, rebase.ToBaseRev),
+		Metadata:    rebase,
+	})
+}
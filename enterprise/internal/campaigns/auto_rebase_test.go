@@ -0,0 +1,134 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestAutoRebaserRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	rs, extSvc := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	head := "d34db33f"
+	git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID(head), nil
+	}
+	defer git.ResetMocks()
+
+	autoRebaseCampaign := testCampaign(admin.ID)
+	autoRebaseCampaign.AutoRebase = true
+	manualCampaign := testCampaign(admin.ID)
+	for _, c := range []*campaigns.Campaign{autoRebaseCampaign, manualCampaign} {
+		if err := store.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	createChangeset := func(campaign *campaigns.Campaign) *campaigns.Changeset {
+		t.Helper()
+
+		spec := &campaigns.ChangesetSpec{
+			UserID: admin.ID,
+			RepoID: rs[0].ID,
+			Spec: &campaigns.ChangesetSpecDescription{
+				BaseRepository: graphqlbackend.MarshalRepositoryID(rs[0].ID),
+				BaseRev:        "b4s3r3v",
+				BaseRef:        "refs/heads/master",
+				HeadRef:        "refs/heads/my-branch",
+				Published:      true,
+				Title:          "Title",
+				Commits: []campaigns.GitCommitDescription{
+					{Message: "Message", Diff: "diff"},
+				},
+			},
+		}
+		if err := store.CreateChangesetSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		c := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		c.OwnedByCampaignID = campaign.ID
+		c.CurrentSpecID = spec.ID
+		c.PublicationState = campaigns.ChangesetPublicationStatePublished
+		c.ReconcilerState = campaigns.ReconcilerStateCompleted
+		if err := store.CreateChangeset(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	outdated := createChangeset(autoRebaseCampaign)
+	createChangeset(manualCampaign)
+
+	gitClient := &ct.FakeGitserverClient{}
+	fakeSource := &ct.FakeChangesetSource{Svc: extSvc}
+	r := &autoRebaser{
+		store: store,
+		reconciler: &reconciler{
+			gitserverClient: gitClient,
+			sourcer:         repos.NewFakeSourcer(nil, fakeSource),
+			store:           store,
+		},
+	}
+
+	if err := r.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if !gitClient.CreateCommitFromPatchCalled {
+		t.Fatal("rebased branch not pushed")
+	}
+	if have, want := gitClient.CreateCommitFromPatchReq.BaseCommit, api.CommitID(head); have != want {
+		t.Fatalf("wrong base commit. want=%s, have=%s", want, have)
+	}
+
+	events, _, err := store.ListChangesetEvents(ctx, ListChangesetEventsOpts{
+		Kinds: []campaigns.ChangesetEventKind{campaigns.ChangesetEventKindSourcegraphRebased},
+		Limit: -1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("wrong number of rebase events. want=1, have=%d", len(events))
+	}
+	if have, want := events[0].ChangesetID, outdated.ID; have != want {
+		t.Fatalf("wrong changeset rebased. want=%d, have=%d", want, have)
+	}
+	rebase := events[0].Metadata.(*campaigns.ChangesetRebase)
+	if rebase.FromBaseRev != "b4s3r3v" || rebase.ToBaseRev != head || !rebase.Automatic {
+		t.Fatalf("wrong rebase recorded: %+v", rebase)
+	}
+
+	// Running again doesn't rebase the changeset again, since its branch is
+	// now based on the head of the base branch.
+	gitClient.CreateCommitFromPatchCalled = false
+	if err := r.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if gitClient.CreateCommitFromPatchCalled {
+		t.Fatal("up-to-date branch pushed")
+	}
+}
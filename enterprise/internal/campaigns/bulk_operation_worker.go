@@ -0,0 +1,126 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// RunBulkOperationWorker starts a dbworker.NewWorker that processes the
+// ChangesetJobs of the bulk operations created with
+// Service.CreateBulkOperation. Every job runs its operation on a single
+// changeset, so that the jobs of a bulk operation fail independently of each
+// other and its progress can be polled.
+func RunBulkOperationWorker(ctx context.Context, s *Store, cf *httpcli.Factory, sourcer repos.Sourcer) {
+	w := &bulkOperationWorker{store: s, cf: cf, sourcer: sourcer}
+
+	options := dbworker.WorkerOptions{
+		Name:        "campaigns_bulk_operation_worker",
+		Handler:     w.HandlerFunc(),
+		NumHandlers: 5,
+		Interval:    5 * time.Second,
+		Metrics: workerutil.WorkerMetrics{
+			HandleOperation: newObservationOperation("campaigns_bulk_operation_worker", "BulkOperationWorker.Process"),
+		},
+	}
+
+	workerStore := dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
+		TableName:         "changeset_jobs",
+		ColumnExpressions: changesetJobColumns,
+		Scan:              scanFirstChangesetJobRecord,
+		OrderByExpression: sqlf.Sprintf("changeset_jobs.created_at, changeset_jobs.id"),
+		StalledMaxAge:     60 * time.Second,
+		MaxNumResets:      5,
+	})
+
+	dbworker.NewWorker(ctx, workerStore, options).Start()
+}
+
+func scanFirstChangesetJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return scanFirstChangesetJob(rows, err)
+}
+
+type bulkOperationWorker struct {
+	store   *Store
+	cf      *httpcli.Factory
+	sourcer repos.Sourcer
+}
+
+func (w *bulkOperationWorker) HandlerFunc() dbworker.HandlerFunc {
+	return func(ctx context.Context, tx dbworkerstore.Store, record workerutil.Record) error {
+		return w.process(ctx, w.store.With(tx), record.(*campaigns.ChangesetJob))
+	}
+}
+
+// process runs the operation of the given job on its changeset. A returned
+// error marks the job as errored.
+func (w *bulkOperationWorker) process(ctx context.Context, tx *Store, job *campaigns.ChangesetJob) error {
+	c, err := tx.GetChangeset(ctx, GetChangesetOpts{ID: job.ChangesetID})
+	if err != nil {
+		return errors.Wrap(err, "loading changeset")
+	}
+
+	// Retrying only changes the changeset in the database, the reconciler
+	// does the rest.
+	if job.JobType == campaigns.ChangesetJobTypeRetry {
+		if c.ReconcilerState != campaigns.ReconcilerStateErrored {
+			return ErrChangesetNotRetryable
+		}
+		requeueErroredChangeset(c)
+		return tx.UpdateChangeset(ctx, c)
+	}
+
+	// Closing a changeset that isn't open is a no-op.
+	if job.JobType == campaigns.ChangesetJobTypeClose && c.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return nil
+	}
+
+	reposStore := repos.NewDBStore(w.store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, w.cf, w.sourcer, c)
+	if err != nil {
+		return err
+	}
+	if len(bySource) == 0 || len(bySource[0].Changesets) == 0 {
+		return errors.New("no code host connection found for the changeset's repository")
+	}
+	group := bySource[0]
+	rc := group.Changesets[0]
+
+	switch job.JobType {
+	case campaigns.ChangesetJobTypeClose:
+		if err := group.CloseChangeset(ctx, rc); err != nil {
+			return errors.Wrap(err, "closing changeset")
+		}
+
+	case campaigns.ChangesetJobTypeMerge:
+		if err := group.MergeChangeset(ctx, rc); err != nil {
+			return errors.Wrap(err, "merging changeset")
+		}
+
+	case campaigns.ChangesetJobTypeComment:
+		cs, ok := group.ChangesetSource.(repos.CommentingChangesetSource)
+		if !ok {
+			return errors.Errorf("commenting on changesets is not supported on %s", c.ExternalServiceType)
+		}
+		if err := cs.CreateComment(ctx, rc, job.Payload.Message); err != nil {
+			return errors.Wrap(err, "commenting on changeset")
+		}
+
+	default:
+		return errors.Errorf("unknown changeset job type %q", job.JobType)
+	}
+
+	// Sync the changeset so that its state and events, including the event of
+	// a new comment, reflect the operation right away, instead of after the
+	// next run of the syncer.
+	return syncChangesetsWithSources(ctx, tx, bySource)
+}
@@ -0,0 +1,136 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+func TestBulkOperationWorkerProcess(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	rs, extSvc := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	// After closing and merging, the changesets are synced, so we need to
+	// mock that.
+	state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: api.RepoName(rs[0].Name),
+		VCS:  protocol.VCSInfo{URL: rs[0].URI},
+	})
+	defer state.Unmock()
+
+	campaign := testCampaign(admin.ID)
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	createChangeset := func(externalState campaigns.ChangesetExternalState, reconcilerState campaigns.ReconcilerState) *campaigns.Changeset {
+		t.Helper()
+
+		c := testChangeset(rs[0].ID, campaign.ID, externalState)
+		c.PublicationState = campaigns.ChangesetPublicationStatePublished
+		c.ReconcilerState = reconcilerState
+		if err := store.CreateChangeset(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	newJob := func(c *campaigns.Changeset, jobType campaigns.ChangesetJobType) *campaigns.ChangesetJob {
+		return &campaigns.ChangesetJob{
+			BulkGroup:   "bulk",
+			UserID:      admin.ID,
+			CampaignID:  campaign.ID,
+			ChangesetID: c.ID,
+			JobType:     jobType,
+			Payload:     campaigns.ChangesetJobPayload{Message: "Please review"},
+		}
+	}
+
+	t.Run("close", func(t *testing.T) {
+		fakeSource := &ct.FakeChangesetSource{Svc: extSvc}
+		w := &bulkOperationWorker{store: store, sourcer: repos.NewFakeSourcer(nil, fakeSource)}
+
+		open := createChangeset(campaigns.ChangesetExternalStateOpen, campaigns.ReconcilerStateCompleted)
+		if err := w.process(ctx, store, newJob(open, campaigns.ChangesetJobTypeClose)); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(fakeSource.ClosedChangesets), 1; have != want {
+			t.Fatalf("wrong number of closed changesets. want=%d, have=%d", want, have)
+		}
+
+		merged := createChangeset(campaigns.ChangesetExternalStateMerged, campaigns.ReconcilerStateCompleted)
+		if err := w.process(ctx, store, newJob(merged, campaigns.ChangesetJobTypeClose)); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(fakeSource.ClosedChangesets), 1; have != want {
+			t.Fatalf("merged changeset was closed. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		fakeSource := &ct.FakeChangesetSource{Svc: extSvc}
+		w := &bulkOperationWorker{store: store, sourcer: repos.NewFakeSourcer(nil, fakeSource)}
+
+		c := createChangeset(campaigns.ChangesetExternalStateOpen, campaigns.ReconcilerStateCompleted)
+		if err := w.process(ctx, store, newJob(c, campaigns.ChangesetJobTypeMerge)); err != nil {
+			t.Fatal(err)
+		}
+		if !fakeSource.MergeChangesetCalled {
+			t.Fatal("changeset not merged")
+		}
+	})
+
+	t.Run("comment", func(t *testing.T) {
+		fakeSource := &ct.FakeChangesetSource{Svc: extSvc}
+		w := &bulkOperationWorker{store: store, sourcer: repos.NewFakeSourcer(nil, fakeSource)}
+
+		c := createChangeset(campaigns.ChangesetExternalStateOpen, campaigns.ReconcilerStateCompleted)
+		if err := w.process(ctx, store, newJob(c, campaigns.ChangesetJobTypeComment)); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := fakeSource.Comments, []string{"Please review"}; len(have) != 1 || have[0] != want[0] {
+			t.Fatalf("wrong comments. want=%v, have=%v", want, have)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		w := &bulkOperationWorker{store: store}
+
+		errored := createChangeset(campaigns.ChangesetExternalStateOpen, campaigns.ReconcilerStateErrored)
+		if err := w.process(ctx, store, newJob(errored, campaigns.ChangesetJobTypeRetry)); err != nil {
+			t.Fatal(err)
+		}
+		reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: errored.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.ReconcilerState, campaigns.ReconcilerStateQueued; have != want {
+			t.Fatalf("wrong reconciler state. want=%s, have=%s", want, have)
+		}
+
+		completed := createChangeset(campaigns.ChangesetExternalStateOpen, campaigns.ReconcilerStateCompleted)
+		if err := w.process(ctx, store, newJob(completed, campaigns.ChangesetJobTypeRetry)); err != ErrChangesetNotRetryable {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrChangesetNotRetryable, err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ErrInvalidCampaignPermissionLevel is returned by GrantCampaignPermission if
+// the given level is not a valid CampaignPermissionLevel.
+var ErrInvalidCampaignPermissionLevel = errors.New("invalid campaign permission level")
+
+// GrantCampaignPermissionOpts are the options for GrantCampaignPermission.
+// Exactly one of UserID and OrgID needs to be set.
+type GrantCampaignPermissionOpts struct {
+	CampaignID int64
+
+	UserID int32
+	OrgID  int32
+
+	Level campaigns.CampaignPermissionLevel
+}
+
+// GrantCampaignPermission grants the given user or org the given level of
+// rights on the Campaign, replacing the level of an existing grant.
+func (s *Service) GrantCampaignPermission(ctx context.Context, opts GrantCampaignPermissionOpts) (grant *campaigns.CampaignPermissionGrant, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, user: %d, org: %d, level: %s", opts.CampaignID, opts.UserID, opts.OrgID, opts.Level)
+	tr, ctx := trace.New(ctx, "service.GrantCampaignPermission", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !opts.Level.Valid() {
+		return nil, ErrInvalidCampaignPermissionLevel
+	}
+	if (opts.UserID == 0) == (opts.OrgID == 0) {
+		return nil, ErrNoNamespace
+	}
+
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: opts.CampaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can grant rights on a campaign.
+	if err := CheckCampaignAdminRights(ctx, s.store, campaign); err != nil {
+		return nil, err
+	}
+
+	grant = &campaigns.CampaignPermissionGrant{
+		CampaignID: campaign.ID,
+		UserID:     opts.UserID,
+		OrgID:      opts.OrgID,
+		Level:      opts.Level,
+	}
+	if err := s.store.UpsertCampaignPermissionGrant(ctx, grant); err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// RevokeCampaignPermission deletes the CampaignPermissionGrant with the given
+// ID.
+func (s *Service) RevokeCampaignPermission(ctx context.Context, id int64) (err error) {
+	traceTitle := fmt.Sprintf("grant: %d", id)
+	tr, ctx := trace.New(ctx, "service.RevokeCampaignPermission", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	grant, err := s.store.GetCampaignPermissionGrant(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: grant.CampaignID})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can revoke rights on a campaign.
+	if err := CheckCampaignAdminRights(ctx, s.store, campaign); err != nil {
+		return err
+	}
+
+	return s.store.DeleteCampaignPermissionGrant(ctx, grant.ID)
+}
@@ -0,0 +1,231 @@
+package campaigns
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// eventsCompactorInterval is the time between two passes of the changeset
+// events compactor.
+const eventsCompactorInterval = time.Hour
+
+// eventsCompactorBatchSize is the maximum number of changesets whose events
+// are compacted in a single pass of the compactor.
+const eventsCompactorBatchSize = 500
+
+// changesetStateEventKinds are the kinds of ChangesetEvents that change the
+// external state of a changeset. They're kept when the events of a changeset
+// are compacted, so that its history can still be computed.
+var changesetStateEventKinds = []campaigns.ChangesetEventKind{
+	campaigns.ChangesetEventKindGitHubClosed,
+	campaigns.ChangesetEventKindGitHubMerged,
+	campaigns.ChangesetEventKindGitHubReopened,
+	campaigns.ChangesetEventKindBitbucketServerDeclined,
+	campaigns.ChangesetEventKindBitbucketServerMerged,
+	campaigns.ChangesetEventKindBitbucketServerReopened,
+	campaigns.ChangesetEventKindGitLabClosed,
+	campaigns.ChangesetEventKindGitLabMerged,
+	campaigns.ChangesetEventKindGitLabReopened,
+	campaigns.ChangesetEventKindBitbucketCloudDeclined,
+	campaigns.ChangesetEventKindBitbucketCloudMerged,
+}
+
+// changesetEventsRetentionMonths returns the number of months configured in
+// the campaigns.changesetEventsRetentionMonths site configuration setting.
+// It's a variable so that it can be mocked in tests.
+var changesetEventsRetentionMonths = func() int {
+	return conf.Get().CampaignsChangesetEventsRetentionMonths
+}
+
+// changesetEventsRetentionCutoff returns the time before which merged and
+// closed changesets must have been last updated on their code host for their
+// events to be compacted. It returns false if compaction is disabled.
+func changesetEventsRetentionCutoff(now time.Time) (time.Time, bool) {
+	months := changesetEventsRetentionMonths()
+	if months <= 0 {
+		return time.Time{}, false
+	}
+	return now.AddDate(0, -months, 0), true
+}
+
+// changesetEventsCompactable returns whether the events of the given
+// changeset are compacted with the given retention cutoff: it must be merged
+// or closed and not have been updated on its code host since the cutoff.
+func changesetEventsCompactable(c *campaigns.Changeset, cutoff time.Time) bool {
+	if c.ExternalState != campaigns.ChangesetExternalStateMerged && c.ExternalState != campaigns.ChangesetExternalStateClosed {
+		return false
+	}
+	return c.ExternalUpdatedAt.Before(cutoff)
+}
+
+// withoutCompactedEvents returns the given events of the changeset without
+// the ones that are deleted when its events are compacted, so that syncing
+// the changeset doesn't restore them.
+func withoutCompactedEvents(c *campaigns.Changeset, es []*campaigns.ChangesetEvent, now time.Time) []*campaigns.ChangesetEvent {
+	cutoff, ok := changesetEventsRetentionCutoff(now)
+	if !ok || !changesetEventsCompactable(c, cutoff) {
+		return es
+	}
+
+	kept := make([]*campaigns.ChangesetEvent, 0, len(es))
+	for _, e := range es {
+		for _, k := range changesetStateEventKinds {
+			if e.Kind == k {
+				kept = append(kept, e)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// RunChangesetEventsCompactor periodically compacts the events of merged and
+// closed changesets that haven't changed for longer than configured in the
+// campaigns.changesetEventsRetentionMonths site configuration setting. It
+// runs until the given context is canceled. If locker is not nil, events are
+// only compacted by the replica that's the leader of the compactor job.
+func RunChangesetEventsCompactor(ctx context.Context, s *Store, locker *Locker) {
+	c := &changesetEventsCompactor{store: s}
+	locker.DoAsLeader(ctx, LeaderJobEventsCompactor, c.loop)
+}
+
+// CompactChangesetEvents runs a single pass of the changeset events
+// compactor.
+func CompactChangesetEvents(ctx context.Context, s *Store) error {
+	c := &changesetEventsCompactor{store: s}
+	return c.run(ctx)
+}
+
+type changesetEventsCompactor struct {
+	store *Store
+}
+
+func (c *changesetEventsCompactor) loop(ctx context.Context) {
+	for {
+		if err := c.run(ctx); err != nil {
+			log15.Error("Compacting changeset events", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventsCompactorInterval):
+		}
+	}
+}
+
+// run compacts the events of a batch of changesets. Before that, the daily
+// ChangesetCounts of their campaigns up to the retention cutoff are stored,
+// since they can't be computed from the compacted events anymore.
+func (c *changesetEventsCompactor) run(ctx context.Context) error {
+	cutoff, ok := changesetEventsRetentionCutoff(c.store.now())
+	if !ok {
+		return nil
+	}
+
+	cs, err := c.store.ListChangesetsWithCompactableEvents(ctx, ListChangesetsWithCompactableEventsOpts{
+		UpdatedBefore: cutoff,
+		Limit:         eventsCompactorBatchSize,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets to compact")
+	}
+	if len(cs) == 0 {
+		return nil
+	}
+
+	campaignIDs := make(map[int64]struct{})
+	for _, ch := range cs {
+		for _, id := range ch.CampaignIDs {
+			campaignIDs[id] = struct{}{}
+		}
+	}
+	for id := range campaignIDs {
+		if err := storeCampaignChangesetCounts(ctx, c.store, id, cutoff); err != nil {
+			return errors.Wrapf(err, "storing changeset counts of campaign %d", id)
+		}
+	}
+
+	return c.store.DeleteCompactableChangesetEvents(ctx, cs.IDs(), cutoff)
+}
+
+// storeCampaignChangesetCounts stores the daily ChangesetCounts of the
+// campaign with the given ID up to the given time that aren't stored yet,
+// overall and per repository.
+func storeCampaignChangesetCounts(ctx context.Context, s *Store, campaignID int64, until time.Time) error {
+	campaign, err := s.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		if err == ErrNoResults {
+			return nil
+		}
+		return err
+	}
+
+	stored, err := s.ListCampaignChangesetCounts(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	storedByRepo, err := s.ListCampaignRepoChangesetCounts(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	// The counts are stored at midnight, continuing after the last stored
+	// ones. The counts per repository were added later, so they may not be
+	// stored as far as the overall counts.
+	start := campaign.CreatedAt.UTC()
+	if len(stored) > 0 {
+		start = stored[len(stored)-1].Time.Add(time.Nanosecond)
+	}
+	repoStart := campaign.CreatedAt.UTC()
+	for _, rc := range storedByRepo {
+		if t := rc.last().Time.Add(time.Nanosecond); t.After(repoStart) {
+			repoStart = t
+		}
+	}
+	end := until.UTC().Truncate(24 * time.Hour)
+	if end.Before(start) && end.Before(repoStart) {
+		return nil
+	}
+
+	publishedState := campaigns.ChangesetPublicationStatePublished
+	cs, _, err := s.ListChangesets(ctx, ListChangesetsOpts{CampaignID: campaignID, Limit: -1, PublicationState: &publishedState})
+	if err != nil {
+		return err
+	}
+
+	var es []*campaigns.ChangesetEvent
+	// An empty list of IDs would load the events of all changesets.
+	if len(cs) > 0 {
+		if es, _, err = s.ListChangesetEvents(ctx, ListChangesetEventsOpts{ChangesetIDs: cs.IDs(), Limit: -1}); err != nil {
+			return err
+		}
+	}
+
+	if !end.Before(start) {
+		counts, err := CalcCounts(start, end, cs, es...)
+		if err != nil {
+			return err
+		}
+		if err := s.CreateCampaignChangesetCounts(ctx, campaignID, counts...); err != nil {
+			return err
+		}
+	}
+
+	if !end.Before(repoStart) {
+		byRepo, err := CalcCountsByRepo(repoStart, end, cs, es...)
+		if err != nil {
+			return err
+		}
+		if err := s.CreateCampaignRepoChangesetCounts(ctx, campaignID, byRepo...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func mockChangesetEventsRetentionMonths(t *testing.T, months int) {
+	t.Helper()
+
+	orig := changesetEventsRetentionMonths
+	changesetEventsRetentionMonths = func() int { return months }
+	t.Cleanup(func() { changesetEventsRetentionMonths = orig })
+}
+
+func TestWithoutCompactedEvents(t *testing.T) {
+	now := time.Now().UTC()
+	events := []*campaigns.ChangesetEvent{
+		{Kind: campaigns.ChangesetEventKindGitHubCommented},
+		{Kind: campaigns.ChangesetEventKindGitHubReviewed},
+		{Kind: campaigns.ChangesetEventKindGitHubMerged},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		months    int
+		state     campaigns.ChangesetExternalState
+		updatedAt time.Time
+		want      int
+	}{
+		{name: "disabled", months: 0, state: campaigns.ChangesetExternalStateMerged, updatedAt: now.AddDate(-1, 0, 0), want: 3},
+		{name: "open", months: 1, state: campaigns.ChangesetExternalStateOpen, updatedAt: now.AddDate(-1, 0, 0), want: 3},
+		{name: "recently merged", months: 1, state: campaigns.ChangesetExternalStateMerged, updatedAt: now.AddDate(0, 0, -1), want: 3},
+		{name: "merged before cutoff", months: 1, state: campaigns.ChangesetExternalStateMerged, updatedAt: now.AddDate(-1, 0, 0), want: 1},
+		{name: "closed before cutoff", months: 1, state: campaigns.ChangesetExternalStateClosed, updatedAt: now.AddDate(-1, 0, 0), want: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockChangesetEventsRetentionMonths(t, tc.months)
+
+			c := &campaigns.Changeset{ExternalState: tc.state, ExternalUpdatedAt: tc.updatedAt}
+			if have := withoutCompactedEvents(c, events, now); len(have) != tc.want {
+				t.Fatalf("wrong number of events. want=%d, have=%d", tc.want, len(have))
+			}
+		})
+	}
+}
+
+func TestChangesetEventsCompactor(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	mockChangesetEventsRetentionMonths(t, 1)
+
+	admin := createTestUser(ctx, t)
+	rs, _ := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	campaign := testCampaign(admin.ID)
+	campaign.CreatedAt = now.AddDate(0, -3, 0)
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	openedAt := now.AddDate(0, -3, 0)
+	mergedAt := now.AddDate(0, -2, 0)
+
+	createChangeset := func(state campaigns.ChangesetExternalState, updatedAt time.Time) *campaigns.Changeset {
+		t.Helper()
+
+		c := testChangeset(rs[0].ID, campaign.ID, state)
+		c.PublicationState = campaigns.ChangesetPublicationStatePublished
+		c.Metadata = &github.PullRequest{State: string(state), CreatedAt: openedAt}
+		c.ExternalUpdatedAt = updatedAt
+		if err := store.CreateChangeset(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		events := []*campaigns.ChangesetEvent{
+			{
+				ChangesetID: c.ID,
+				Kind:        campaigns.ChangesetEventKindGitHubCommented,
+				Key:         "comment",
+				Metadata:    &github.IssueComment{CreatedAt: openedAt, UpdatedAt: openedAt},
+			},
+		}
+		if state == campaigns.ChangesetExternalStateMerged {
+			events = append(events, &campaigns.ChangesetEvent{
+				ChangesetID: c.ID,
+				Kind:        campaigns.ChangesetEventKindGitHubMerged,
+				Key:         "merged",
+				Metadata:    &github.MergedEvent{CreatedAt: mergedAt},
+			})
+		}
+		if err := store.UpsertChangesetEvents(ctx, events...); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	merged := createChangeset(campaigns.ChangesetExternalStateMerged, mergedAt)
+	open := createChangeset(campaigns.ChangesetExternalStateOpen, mergedAt)
+
+	c := &changesetEventsCompactor{store: store}
+	if err := c.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	countEvents := func(changesetID int64) int {
+		t.Helper()
+
+		count, err := store.CountChangesetEvents(ctx, CountChangesetEventsOpts{ChangesetID: changesetID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+	if have, want := countEvents(merged.ID), 1; have != want {
+		t.Fatalf("wrong number of events of merged changeset. want=%d, have=%d", want, have)
+	}
+	if have, want := countEvents(open.ID), 1; have != want {
+		t.Fatalf("wrong number of events of open changeset. want=%d, have=%d", want, have)
+	}
+
+	// The counts are stored daily up to the cutoff.
+	stored, err := store.ListCampaignChangesetCounts(ctx, campaign.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) == 0 {
+		t.Fatal("no changeset counts stored")
+	}
+	cutoff, _ := changesetEventsRetentionCutoff(now)
+	if have, want := stored[len(stored)-1].Time, cutoff.Truncate(24*time.Hour); !have.Equal(want) {
+		t.Fatalf("wrong time of last stored counts. want=%s, have=%s", want, have)
+	}
+	if last := stored[len(stored)-1]; last.Merged != 1 || last.Open != 1 {
+		t.Fatalf("wrong last stored counts: %s", last)
+	}
+
+	// The counts are stored per repository too.
+	storedByRepo, err := store.ListCampaignRepoChangesetCounts(ctx, campaign.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(storedByRepo) != 1 || storedByRepo[0].RepoID != rs[0].ID {
+		t.Fatalf("wrong repositories of stored counts: %v", storedByRepo)
+	}
+	if diff := cmp.Diff(stored, storedByRepo[0].Counts); diff != "" {
+		t.Fatalf("wrong counts stored for repository: %s", diff)
+	}
+
+	// Running again neither fails nor stores counts twice.
+	if err := c.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	again, err := store.ListCampaignChangesetCounts(ctx, campaign.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != len(stored) {
+		t.Fatalf("counts stored twice. want=%d, have=%d", len(stored), len(again))
+	}
+	againByRepo, err := store.ListCampaignRepoChangesetCounts(ctx, campaign.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(againByRepo) != 1 || len(againByRepo[0].Counts) != len(stored) {
+		t.Fatalf("counts per repository stored twice: %v", againByRepo)
+	}
+}
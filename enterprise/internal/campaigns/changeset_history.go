@@ -82,7 +82,8 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 		switch e.Kind {
 		case campaigns.ChangesetEventKindGitHubClosed,
 			campaigns.ChangesetEventKindBitbucketServerDeclined,
-			campaigns.ChangesetEventKindGitLabClosed:
+			campaigns.ChangesetEventKindGitLabClosed,
+			campaigns.ChangesetEventKindBitbucketCloudDeclined:
 			// Merged is a final state. We can ignore everything after.
 			if currentExtState != campaigns.ChangesetExternalStateMerged {
 				currentExtState = campaigns.ChangesetExternalStateClosed
@@ -91,7 +92,8 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 
 		case campaigns.ChangesetEventKindGitHubMerged,
 			campaigns.ChangesetEventKindBitbucketServerMerged,
-			campaigns.ChangesetEventKindGitLabMerged:
+			campaigns.ChangesetEventKindGitLabMerged,
+			campaigns.ChangesetEventKindBitbucketCloudMerged:
 			currentExtState = campaigns.ChangesetExternalStateMerged
 			pushStates(et)
 
@@ -107,7 +109,11 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 		case campaigns.ChangesetEventKindGitHubReviewed,
 			campaigns.ChangesetEventKindBitbucketServerApproved,
 			campaigns.ChangesetEventKindBitbucketServerReviewed,
-			campaigns.ChangesetEventKindGitLabApproved:
+			campaigns.ChangesetEventKindBitbucketServerParticipantApproved,
+			campaigns.ChangesetEventKindBitbucketServerParticipantReviewed,
+			campaigns.ChangesetEventKindGitLabApproved,
+			campaigns.ChangesetEventKindBitbucketCloudApproved,
+			campaigns.ChangesetEventKindBitbucketCloudChangesRequested:
 
 			s, err := e.ReviewState()
 			if err != nil {
@@ -159,7 +165,9 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 
 		case campaigns.ChangesetEventKindBitbucketServerUnapproved,
 			campaigns.ChangesetEventKindBitbucketServerDismissed,
-			campaigns.ChangesetEventKindGitLabUnapproved:
+			campaigns.ChangesetEventKindGitLabUnapproved,
+			campaigns.ChangesetEventKindBitbucketCloudUnapproved,
+			campaigns.ChangesetEventKindBitbucketCloudChangesRequestRemoved:
 			author, err := e.ReviewAuthor()
 			if err != nil {
 				return nil, err
@@ -179,10 +187,10 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 			}
 
 			if e.Type() == campaigns.ChangesetEventKindBitbucketServerDismissed {
-				// A BitbucketServer Dismissed event can only follow a previous "Changes Requested" review by
-				// the same author.
-				lastReview, ok := lastReviewByAuthor[author]
-				if !ok || lastReview != campaigns.ChangesetReviewStateChangesRequested {
+				// A BitbucketServer Dismissed event can only follow a previous review by the
+				// same author. The plugin only sends these after a "Changes Requested" review,
+				// but native webhooks also send them when an approval is withdrawn.
+				if _, ok := lastReviewByAuthor[author]; !ok {
 					log15.Warn("Bitbucket Server Dismissal not following a Review", "event", e)
 					continue
 				}
@@ -0,0 +1,212 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// urlCheckInterval is the time between two passes of the URL checker.
+const urlCheckInterval = 10 * time.Minute
+
+// urlRecheckAge is how old the result of the last check of a changeset URL
+// has to be before the URL is checked again.
+const urlRecheckAge = 24 * time.Hour
+
+// urlCheckBatchSize is the maximum number of changeset URLs that are checked
+// in a single pass of the URL checker.
+const urlCheckBatchSize = 500
+
+// RunURLChecker periodically checks whether the external URLs of published
+// changesets still resolve on the code host, and records the results on the
+// changesets. It runs until the given context is canceled. If locker is not
+// nil, URLs are only checked by the replica that's the leader of the URL
+// checker job.
+func RunURLChecker(ctx context.Context, s *Store, cf *httpcli.Factory, locker *Locker) {
+	c := &urlChecker{store: s, cf: cf}
+	locker.DoAsLeader(ctx, LeaderJobURLChecker, c.loop)
+}
+
+type urlChecker struct {
+	store *Store
+	cf    *httpcli.Factory
+}
+
+func (c *urlChecker) loop(ctx context.Context) {
+	for {
+		if err := c.run(ctx); err != nil {
+			log15.Error("Checking changeset URLs", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(urlCheckInterval):
+		}
+	}
+}
+
+// run checks the URLs of the published changesets that weren't checked
+// recently, starting with the ones checked least recently.
+func (c *urlChecker) run(ctx context.Context) error {
+	cs, err := c.store.ListChangesetsToCheckURL(ctx, ListChangesetsToCheckURLOpts{
+		CheckedBefore: c.store.now().Add(-urlRecheckAge),
+		Limit:         urlCheckBatchSize,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets to check")
+	}
+
+	return c.check(ctx, cs)
+}
+
+// check checks the URLs of the given changesets and records the results,
+// both in the database and on the changesets.
+func (c *urlChecker) check(ctx context.Context, cs campaigns.Changesets) error {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	cli, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	reposStore := repos.NewDBStore(c.store.DB(), sql.TxOptions{})
+	rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{IDs: cs.RepoIDs()})
+	if err != nil {
+		return errors.Wrap(err, "listing repositories")
+	}
+	reposByID := make(map[api.RepoID]*repos.Repo, len(rs))
+	for _, r := range rs {
+		reposByID[r.ID] = r
+	}
+
+	es, err := reposStore.ListExternalServices(ctx, repos.StoreListExternalServicesArgs{Kinds: changesetURLKinds})
+	if err != nil {
+		return errors.Wrap(err, "listing external services")
+	}
+
+	errs := &multierror.Error{}
+	for _, ch := range cs {
+		repo, ok := reposByID[ch.RepoID]
+		if !ok {
+			continue
+		}
+
+		state, err := checkChangesetURL(ctx, cli, repoExternalServices(es, repo), repo, ch)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "checking URL of changeset %d", ch.ID))
+			continue
+		}
+
+		checkedAt := c.store.now()
+		if err := c.store.SetChangesetURLState(ctx, ch.ID, state, checkedAt); err != nil {
+			return err
+		}
+		ch.ExternalURLState, ch.ExternalURLCheckedAt = state, checkedAt
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// client returns the HTTP client used to check URLs. It doesn't follow
+// redirects, since they mean that a changeset moved.
+func (c *urlChecker) client() (*http.Client, error) {
+	cf := c.cf
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Client(httpcli.NewTimeoutOpt(30 * time.Second))
+	if err != nil {
+		return nil, err
+	}
+	cli.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return cli, nil
+}
+
+// repoExternalServices returns the external services of the given ones that
+// the given repository belongs to.
+func repoExternalServices(es []*repos.ExternalService, repo *repos.Repo) []*repos.ExternalService {
+	ids := make(map[int64]bool, len(repo.Sources))
+	for _, id := range repo.ExternalServiceIDs() {
+		ids[id] = true
+	}
+
+	var filtered []*repos.ExternalService
+	for _, e := range es {
+		if ids[e.ID] {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// checkChangesetURL returns whether the external URL of the given changeset
+// in the given repository still resolves. The external services are used to
+// map the URL to a repository name.
+//
+// Changesets that the syncer found to be deleted on the code host are dead.
+// The URLs of changesets in public repositories are requested without
+// credentials, while those in private repositories can't be, so that they're
+// only checked against the repository name.
+func checkChangesetURL(ctx context.Context, cli *http.Client, es []*repos.ExternalService, repo *repos.Repo, ch *campaigns.Changeset) (campaigns.ChangesetURLState, error) {
+	if ch.IsDeleted() {
+		return campaigns.ChangesetURLStateDead, nil
+	}
+
+	rawURL, err := ch.URL()
+	if err != nil {
+		return "", err
+	}
+
+	// A URL that belongs to another repository means that the repository
+	// was renamed or transferred after the changeset was last synced.
+	if name, _, err := parseChangesetURL(es, rawURL); err == nil && !strings.EqualFold(string(name), repo.Name) {
+		return campaigns.ChangesetURLStateMoved, nil
+	}
+
+	if repo.Private {
+		return campaigns.ChangesetURLStateOK, nil
+	}
+
+	req, err := http.NewRequest("HEAD", rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	// Responses from the HTTP cache of external clients would hide changes.
+	req.Header.Set("Cache-Control", "no-cache")
+
+	resp, err := cli.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return campaigns.ChangesetURLStateDead, nil
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return campaigns.ChangesetURLStateMoved, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Other errors, such as rate limits, don't tell us anything about
+		// the changeset.
+		return "", errors.Errorf("unexpected status code %d from %s", resp.StatusCode, rawURL)
+	}
+	return campaigns.ChangesetURLStateOK, nil
+}
@@ -0,0 +1,130 @@
+package campaigns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestCheckChangesetURL(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "HEAD" {
+			t.Errorf("wrong method. want=HEAD, have=%s", r.Method)
+		}
+
+		switch r.URL.Path {
+		case "/sourcegraph/sourcegraph/pull/1":
+			w.WriteHeader(http.StatusOK)
+		case "/sourcegraph/sourcegraph/pull/2":
+			http.Redirect(w, r, "/sourcegraph/renamed/pull/2", http.StatusMovedPermanently)
+		case "/sourcegraph/sourcegraph/pull/3":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := &urlChecker{}
+	cli, err := c.client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	es := []*repos.ExternalService{{
+		Kind:   extsvc.KindGitHub,
+		Config: `{"url": "https://github.com", "token": "abc", "repos": []}`,
+	}}
+
+	publicRepo := &repos.Repo{Name: "github.com/sourcegraph/sourcegraph"}
+	privateRepo := &repos.Repo{Name: "github.com/sourcegraph/sourcegraph", Private: true}
+
+	changeset := func(url string) *campaigns.Changeset {
+		return &campaigns.Changeset{Metadata: &github.PullRequest{URL: url}}
+	}
+
+	deleted := changeset(srv.URL + "/sourcegraph/sourcegraph/pull/1")
+	deleted.ExternalDeletedAt = time.Now()
+
+	for _, tc := range []struct {
+		name      string
+		repo      *repos.Repo
+		changeset *campaigns.Changeset
+		want      campaigns.ChangesetURLState
+		wantErr   bool
+	}{
+		{
+			name:      "ok",
+			repo:      publicRepo,
+			changeset: changeset(srv.URL + "/sourcegraph/sourcegraph/pull/1"),
+			want:      campaigns.ChangesetURLStateOK,
+		},
+		{
+			name:      "redirect",
+			repo:      publicRepo,
+			changeset: changeset(srv.URL + "/sourcegraph/sourcegraph/pull/2"),
+			want:      campaigns.ChangesetURLStateMoved,
+		},
+		{
+			name:      "not found",
+			repo:      publicRepo,
+			changeset: changeset(srv.URL + "/sourcegraph/sourcegraph/pull/4"),
+			want:      campaigns.ChangesetURLStateDead,
+		},
+		{
+			name:      "rate limited",
+			repo:      publicRepo,
+			changeset: changeset(srv.URL + "/sourcegraph/sourcegraph/pull/3"),
+			wantErr:   true,
+		},
+		{
+			name:      "deleted on code host",
+			repo:      publicRepo,
+			changeset: deleted,
+			want:      campaigns.ChangesetURLStateDead,
+		},
+		{
+			name:      "private repository isn't requested",
+			repo:      privateRepo,
+			changeset: changeset(srv.URL + "/sourcegraph/sourcegraph/pull/4"),
+			want:      campaigns.ChangesetURLStateOK,
+		},
+		{
+			name:      "renamed repository",
+			repo:      privateRepo,
+			changeset: changeset("https://github.com/sourcegraph/old-name/pull/5"),
+			want:      campaigns.ChangesetURLStateMoved,
+		},
+		{
+			name:      "same repository with different case",
+			repo:      privateRepo,
+			changeset: changeset("https://github.com/SourceGraph/SourceGraph/pull/5"),
+			want:      campaigns.ChangesetURLStateOK,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := checkChangesetURL(ctx, cli, es, tc.repo, tc.changeset)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("want error, have nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Fatalf("wrong URL state. want=%s, have=%s", tc.want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package campaigns
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var (
+	gitHubPullRequestPath          = regexp.MustCompile(`^/([^/]+/[^/]+)/pull/(\d+)/?`)
+	gitLabMergeRequestPath         = regexp.MustCompile(`^/(.+?)(?:/-)?/merge_requests/(\d+)/?`)
+	bitbucketServerPullRequestPath = regexp.MustCompile(`^/projects/([^/]+)/repos/([^/]+)/pull-requests/(\d+)/?`)
+	bitbucketCloudPullRequestPath  = regexp.MustCompile(`^/([^/]+/[^/]+)/pull-requests/(\d+)/?`)
+)
+
+// changesetURLKinds are the kinds of external services whose pull and merge
+// request URLs can be parsed by parseChangesetURL.
+var changesetURLKinds = []string{
+	extsvc.KindGitHub,
+	extsvc.KindGitLab,
+	extsvc.KindBitbucketServer,
+	extsvc.KindBitbucketCloud,
+}
+
+// parseChangesetURL maps the URL of a pull or merge request on a code host to
+// the name of the repository it belongs to and its external ID. The
+// repository name is computed using the configuration of the first of the
+// given external services whose URL matches the host of the changeset URL.
+// It does not check whether the repository or changeset actually exist.
+func parseChangesetURL(es []*repos.ExternalService, rawURL string) (api.RepoName, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "parsing changeset URL %q", rawURL)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", "", errors.Errorf("changeset URL %q is not absolute", rawURL)
+	}
+
+	for _, e := range es {
+		cfg, err := e.Configuration()
+		if err != nil {
+			return "", "", err
+		}
+
+		var (
+			src      reposource.RepoSource
+			cloneURL string
+			id       string
+		)
+
+		switch c := cfg.(type) {
+		case *schema.GitHubConnection:
+			if m := gitHubPullRequestPath.FindStringSubmatch(u.Path); m != nil {
+				src, cloneURL, id = reposource.GitHub{GitHubConnection: c}, m[1], m[2]
+			}
+		case *schema.GitLabConnection:
+			if m := gitLabMergeRequestPath.FindStringSubmatch(u.Path); m != nil {
+				src, cloneURL, id = reposource.GitLab{GitLabConnection: c}, m[1], m[2]
+			}
+		case *schema.BitbucketServerConnection:
+			if m := bitbucketServerPullRequestPath.FindStringSubmatch(u.Path); m != nil {
+				src, cloneURL, id = reposource.BitbucketServer{BitbucketServerConnection: c}, "scm/"+m[1]+"/"+m[2], m[3]
+			}
+		case *schema.BitbucketCloudConnection:
+			if m := bitbucketCloudPullRequestPath.FindStringSubmatch(u.Path); m != nil {
+				src, cloneURL, id = reposource.BitbucketCloud{BitbucketCloudConnection: c}, m[1], m[2]
+			}
+		}
+
+		if src == nil {
+			continue
+		}
+
+		name, err := src.CloneURLToRepoName(u.Scheme + "://" + u.Host + "/" + cloneURL + ".git")
+		if err != nil {
+			return "", "", err
+		}
+		if name != "" {
+			return name, id, nil
+		}
+	}
+
+	return "", "", errors.Errorf("no code host configuration matches changeset URL %q", rawURL)
+}
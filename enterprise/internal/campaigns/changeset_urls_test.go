@@ -0,0 +1,93 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestParseChangesetURL(t *testing.T) {
+	es := []*repos.ExternalService{
+		{
+			Kind:   extsvc.KindGitHub,
+			Config: `{"url": "https://github.com", "token": "abc", "repos": []}`,
+		},
+		{
+			Kind:   extsvc.KindGitLab,
+			Config: `{"url": "https://gitlab.sgdev.org", "token": "abc", "projectQuery": ["none"]}`,
+		},
+		{
+			Kind:   extsvc.KindBitbucketServer,
+			Config: `{"url": "https://bitbucket.sgdev.org", "username": "u", "token": "abc", "repositoryPathPattern": "bbs/{projectKey}/{repositorySlug}", "repos": []}`,
+		},
+		{
+			Kind:   extsvc.KindBitbucketCloud,
+			Config: `{"url": "https://bitbucket.org", "username": "u", "appPassword": "abc"}`,
+		},
+	}
+
+	for _, tc := range []struct {
+		url        string
+		name       api.RepoName
+		externalID string
+		wantErr    bool
+	}{
+		{
+			url:        "https://github.com/sourcegraph/sourcegraph/pull/12345",
+			name:       "github.com/sourcegraph/sourcegraph",
+			externalID: "12345",
+		},
+		{
+			url:        "https://github.com/sourcegraph/sourcegraph/pull/12345/files",
+			name:       "github.com/sourcegraph/sourcegraph",
+			externalID: "12345",
+		},
+		{
+			url:        "https://gitlab.sgdev.org/group/subgroup/project/-/merge_requests/7",
+			name:       "gitlab.sgdev.org/group/subgroup/project",
+			externalID: "7",
+		},
+		{
+			url:        "https://gitlab.sgdev.org/group/project/merge_requests/7",
+			name:       "gitlab.sgdev.org/group/project",
+			externalID: "7",
+		},
+		{
+			url:        "https://bitbucket.sgdev.org/projects/SOUR/repos/vegeta/pull-requests/42/overview",
+			name:       "bbs/SOUR/vegeta",
+			externalID: "42",
+		},
+		{
+			url:        "https://bitbucket.org/sourcegraph/src-cli/pull-requests/3",
+			name:       "bitbucket.org/sourcegraph/src-cli",
+			externalID: "3",
+		},
+		// Unknown code host
+		{url: "https://example.com/sourcegraph/sourcegraph/pull/1", wantErr: true},
+		// Known code host, but not a changeset URL
+		{url: "https://github.com/sourcegraph/sourcegraph/issues/1", wantErr: true},
+		// Not an absolute URL
+		{url: "sourcegraph/sourcegraph/pull/1", wantErr: true},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			name, externalID, err := parseChangesetURL(es, tc.url)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got name %q and external ID %q", name, externalID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != tc.name {
+				t.Errorf("wrong repo name: have %q; want %q", name, tc.name)
+			}
+			if externalID != tc.externalID {
+				t.Errorf("wrong external ID: have %q; want %q", externalID, tc.externalID)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package campaigns
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	codeintelstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// Valid values of the campaigns.codeIntelIndexOnMerge site configuration
+// setting.
+const (
+	codeIntelIndexOnMergeNever               = "never"
+	codeIntelIndexOnMergePreciseRepositories = "preciseRepositories"
+	codeIntelIndexOnMergeAlways              = "always"
+)
+
+// codeIntelIndexOnMerge returns the policy that controls for which merged
+// changesets code intel index jobs are enqueued. It's a variable so that it
+// can be mocked in tests.
+var codeIntelIndexOnMerge = func() string {
+	return conf.Get().CampaignsCodeIntelIndexOnMerge
+}
+
+// mergedChangeset returns true if the changeset was merged on the code host
+// since its previous external state was computed. Changesets that are
+// imported in the merged state don't count, since they were merged before
+// they were added to a campaign.
+func mergedChangeset(previous campaigns.ChangesetExternalState, c *campaigns.Changeset) bool {
+	return previous != "" &&
+		previous != campaigns.ChangesetExternalStateMerged &&
+		c.ExternalState == campaigns.ChangesetExternalStateMerged
+}
+
+// enqueueMergeCommitIndex enqueues a precise code intel index job for the
+// merge commit of the given merged changeset, so that code navigation is
+// accurate right after the changes of a campaign land in the repository.
+// Whether a job is enqueued depends on the campaigns.codeIntelIndexOnMerge
+// policy. No job is enqueued if the code host doesn't report the merge commit
+// or if the commit is already indexed or queued.
+func enqueueMergeCommitIndex(ctx context.Context, tx *Store, c *campaigns.Changeset, events ChangesetEvents) error {
+	policy := codeIntelIndexOnMerge()
+	if policy != codeIntelIndexOnMergePreciseRepositories && policy != codeIntelIndexOnMergeAlways {
+		return nil
+	}
+
+	commit := events.FindMergeCommitID()
+	if commit == "" {
+		return nil
+	}
+
+	store := codeintelstore.NewWithHandle(tx.Handle())
+	repoID := int(c.RepoID)
+
+	if policy == codeIntelIndexOnMergePreciseRepositories {
+		hasData, err := store.HasRepository(ctx, repoID)
+		if err != nil {
+			return errors.Wrap(err, "checking for precise code intel data")
+		}
+		if !hasData {
+			return nil
+		}
+	}
+
+	queued, err := store.IsQueued(ctx, repoID, commit)
+	if err != nil {
+		return errors.Wrap(err, "checking for queued code intel index")
+	}
+	if queued {
+		return nil
+	}
+
+	id, err := store.InsertIndex(ctx, codeintelstore.Index{
+		Commit:       commit,
+		RepositoryID: repoID,
+		State:        "queued",
+	})
+	if err != nil {
+		return errors.Wrap(err, "enqueueing code intel index")
+	}
+
+	log15.Info("Enqueued code intel index for merged changeset", "id", id, "changeset", c.ID, "repository_id", repoID, "commit", commit)
+	return nil
+}
@@ -0,0 +1,159 @@
+package campaigns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	codeintelstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestEnqueueMergeCommitIndex(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbtesting.SetupGlobalTestDB(t)
+
+	rs, _ := createTestRepos(t, ctx, dbconn.Global, 2)
+	preciseRepo, otherRepo := rs[0], rs[1]
+
+	_, err := dbconn.Global.Exec(
+		`INSERT INTO lsif_uploads (commit, indexer, num_parts, uploaded_parts, repository_id) VALUES ($1, 'lsif-go', 1, '{}', $2)`,
+		strings.Repeat("a", 40), preciseRepo.ID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore(dbconn.Global)
+	codeintel := codeintelstore.NewWithHandle(store.Handle())
+
+	mergedEvents := func(commit string) ChangesetEvents {
+		return ChangesetEvents{{
+			Kind:     campaigns.ChangesetEventKindGitHubMerged,
+			Metadata: &github.MergedEvent{Commit: github.Commit{OID: commit}},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		policy    string
+		repo      *repos.Repo
+		events    ChangesetEvents
+		wantIndex bool
+	}{
+		{
+			name:   "policy not set",
+			repo:   preciseRepo,
+			events: mergedEvents(strings.Repeat("b", 40)),
+		},
+		{
+			name:   "policy never",
+			policy: codeIntelIndexOnMergeNever,
+			repo:   preciseRepo,
+			events: mergedEvents(strings.Repeat("c", 40)),
+		},
+		{
+			name:      "policy preciseRepositories with precise repository",
+			policy:    codeIntelIndexOnMergePreciseRepositories,
+			repo:      preciseRepo,
+			events:    mergedEvents(strings.Repeat("d", 40)),
+			wantIndex: true,
+		},
+		{
+			name:   "policy preciseRepositories with other repository",
+			policy: codeIntelIndexOnMergePreciseRepositories,
+			repo:   otherRepo,
+			events: mergedEvents(strings.Repeat("e", 40)),
+		},
+		{
+			name:      "policy always",
+			policy:    codeIntelIndexOnMergeAlways,
+			repo:      otherRepo,
+			events:    mergedEvents(strings.Repeat("f", 40)),
+			wantIndex: true,
+		},
+		{
+			name:   "no merge commit",
+			policy: codeIntelIndexOnMergeAlways,
+			repo:   otherRepo,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func() string) { codeIntelIndexOnMerge = f }(codeIntelIndexOnMerge)
+			codeIntelIndexOnMerge = func() string { return tc.policy }
+
+			opts := codeintelstore.GetIndexesOptions{RepositoryID: int(tc.repo.ID), Limit: 100}
+			_, before, err := codeintel.GetIndexes(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := &campaigns.Changeset{ID: 1, RepoID: tc.repo.ID}
+
+			// Enqueueing twice must not result in two jobs.
+			for i := 0; i < 2; i++ {
+				if err := enqueueMergeCommitIndex(ctx, store, c, tc.events); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			indexes, after, err := codeintel.GetIndexes(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tc.wantIndex {
+				if after != before {
+					t.Fatalf("unexpected index enqueued: %+v", indexes)
+				}
+				return
+			}
+
+			if have, want := after, before+1; have != want {
+				t.Fatalf("wrong number of indexes. want=%d, have=%d", want, have)
+			}
+
+			commit := tc.events.FindMergeCommitID()
+			for _, index := range indexes {
+				if index.Commit == commit {
+					if have, want := index.State, "queued"; have != want {
+						t.Fatalf("index has wrong state. want=%q, have=%q", want, have)
+					}
+					return
+				}
+			}
+			t.Fatalf("no index enqueued for commit %q", commit)
+		})
+	}
+}
+
+func TestMergedChangeset(t *testing.T) {
+	tests := []struct {
+		previous campaigns.ChangesetExternalState
+		current  campaigns.ChangesetExternalState
+		want     bool
+	}{
+		{previous: campaigns.ChangesetExternalStateOpen, current: campaigns.ChangesetExternalStateMerged, want: true},
+		{previous: campaigns.ChangesetExternalStateClosed, current: campaigns.ChangesetExternalStateMerged, want: true},
+		{previous: campaigns.ChangesetExternalStateMerged, current: campaigns.ChangesetExternalStateMerged, want: false},
+		{previous: "", current: campaigns.ChangesetExternalStateMerged, want: false},
+		{previous: campaigns.ChangesetExternalStateOpen, current: campaigns.ChangesetExternalStateClosed, want: false},
+	}
+
+	for _, tc := range tests {
+		c := &campaigns.Changeset{ExternalState: tc.current}
+		if have := mergedChangeset(tc.previous, c); have != tc.want {
+			t.Errorf("mergedChangeset(%q -> %q): want=%t, have=%t", tc.previous, tc.current, tc.want, have)
+		}
+	}
+}
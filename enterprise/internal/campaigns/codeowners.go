@@ -0,0 +1,225 @@
+package campaigns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// codeOwnersPaths are the paths at which code hosts look for the CODEOWNERS
+// file of a repository, in the order in which they're looked up.
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeOwnersMaxSize is the maximum size of a CODEOWNERS file that's read.
+const codeOwnersMaxSize = 3 * 1024 * 1024
+
+// codeOwners are the rules of a CODEOWNERS file, in the order in which they
+// appear in the file.
+type codeOwners []*codeOwnersRule
+
+// codeOwnersRule is a line of a CODEOWNERS file, assigning owners to the files
+// matched by a pattern.
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// parseCodeOwners parses the rules of the given CODEOWNERS file. Lines with
+// invalid patterns are skipped, like code hosts do.
+func parseCodeOwners(r io.Reader) (codeOwners, error) {
+	var rules codeOwners
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		var owners []string
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, "#") {
+				break
+			}
+			owners = append(owners, f)
+		}
+
+		pattern, err := codeOwnersPatternRegexp(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, &codeOwnersRule{pattern: pattern, owners: owners})
+	}
+
+	return rules, sc.Err()
+}
+
+// codeOwnersPatternRegexp converts the given CODEOWNERS pattern, which
+// follows the rules of .gitignore patterns, to a regular expression that
+// matches the paths of the files it applies to.
+func codeOwnersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	// Patterns that contain a slash anywhere but at their end are relative
+	// to the root of the repository, all others match at any depth.
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil, errors.New("empty pattern")
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	// A pattern that matches a directory applies to all files in it, except
+	// for patterns ending in a wildcard, which code hosts only apply to the
+	// files directly in the directory.
+	if !strings.HasSuffix(pattern, "*") {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// Owners returns the owners of the file at the given path. As on code hosts,
+// the last rule that matches the path takes precedence.
+func (o codeOwners) Owners(path string) []string {
+	for i := len(o) - 1; i >= 0; i-- {
+		if o[i].pattern.MatchString(path) {
+			return o[i].owners
+		}
+	}
+	return nil
+}
+
+// OwnersOfFiles returns the owners of all of the files at the given paths,
+// without duplicates, in the order in which they're first encountered.
+func (o codeOwners) OwnersOfFiles(paths []string) []string {
+	var owners []string
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		for _, owner := range o.Owners(p) {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// loadCodeOwners returns the rules of the CODEOWNERS file of the given
+// repository at the given commit. It returns nil if the repository doesn't
+// have a CODEOWNERS file.
+func loadCodeOwners(ctx context.Context, repo api.RepoName, commit api.CommitID) (codeOwners, error) {
+	for _, path := range codeOwnersPaths {
+		data, err := git.ReadFile(ctx, gitserver.Repo{Name: repo}, commit, path, codeOwnersMaxSize)
+		if err != nil {
+			if os.IsNotExist(errors.Cause(err)) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+		return parseCodeOwners(bytes.NewReader(data))
+	}
+	return nil, nil
+}
+
+// changedFiles returns the paths of the files changed by the diff of the
+// given changeset spec.
+func changedFiles(spec *campaigns.ChangesetSpec) ([]string, error) {
+	d, err := spec.Spec.Diff()
+	if err != nil {
+		return nil, err
+	}
+
+	fileDiffs, err := diff.ParseMultiFileDiff([]byte(d))
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, fd := range fileDiffs {
+		// Deleted files only have an original name.
+		name := fd.NewName
+		if name == "/dev/null" {
+			name = fd.OrigName
+		}
+		paths = append(paths, strings.TrimPrefix(strings.TrimPrefix(name, "b/"), "a/"))
+	}
+	return paths, nil
+}
+
+// codeOwnerReviewers returns the users and teams to request reviews from
+// for a changeset with the given changed files, according to the given
+// rules. Owners are given as @user or @org/team in CODEOWNERS files and
+// returned without the @. Owners given by email address are skipped, since
+// code hosts don't accept them as reviewers.
+func codeOwnerReviewers(owners codeOwners, paths []string) []string {
+	var reviewers []string
+	for _, owner := range owners.OwnersOfFiles(paths) {
+		if strings.HasPrefix(owner, "@") {
+			reviewers = append(reviewers, strings.TrimPrefix(owner, "@"))
+		}
+	}
+	return reviewers
+}
+
+// requestCodeOwnerReviews requests reviews of the given changeset, which
+// was created from the given spec, from the owners of the files it changes,
+// if the spec asks for it and the code host supports it.
+func requestCodeOwnerReviews(ctx context.Context, ccs repos.ChangesetSource, repo *repos.Repo, spec *campaigns.ChangesetSpec, cs *repos.Changeset) error {
+	if !spec.Spec.RequestCodeOwnerReviews {
+		return nil
+	}
+	rrs, ok := ccs.(repos.ReviewRequestingChangesetSource)
+	if !ok {
+		return nil
+	}
+
+	owners, err := loadCodeOwners(ctx, api.RepoName(repo.Name), api.CommitID(spec.Spec.BaseRev))
+	if err != nil || owners == nil {
+		return err
+	}
+
+	paths, err := changedFiles(spec)
+	if err != nil {
+		return err
+	}
+
+	reviewers := codeOwnerReviewers(owners, paths)
+	if len(reviewers) == 0 {
+		return nil
+	}
+	return rrs.RequestReviews(ctx, cs, reviewers)
+}
@@ -0,0 +1,108 @@
+package campaigns
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestCodeOwners(t *testing.T) {
+	owners, err := parseCodeOwners(strings.NewReader(`
+# Default owners.
+*                @global-owner
+
+*.js             @js-owner # Inline comments are ignored.
+/build/logs/     @doctocat
+docs/*           docs@example.com
+apps/            @octocat
+/scripts/**/*.sh @org/scripts
+**/testdata      @org/testers
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path string
+		want []string
+	}{
+		{path: "README.md", want: []string{"@global-owner"}},
+		{path: "web/index.js", want: []string{"@js-owner"}},
+		{path: "build/logs/out.txt", want: []string{"@doctocat"}},
+		{path: "build/logs/2020/out.txt", want: []string{"@doctocat"}},
+		{path: "src/build/logs/out.txt", want: []string{"@global-owner"}},
+		{path: "docs/getting-started.md", want: []string{"docs@example.com"}},
+		{path: "docs/build-app/troubleshooting.md", want: []string{"@global-owner"}},
+		{path: "apps/main.go", want: []string{"@octocat"}},
+		{path: "nested/apps/main.go", want: []string{"@octocat"}},
+		{path: "scripts/deploy.sh", want: []string{"@org/scripts"}},
+		{path: "scripts/ci/deploy.sh", want: []string{"@org/scripts"}},
+		{path: "cmd/testdata/golden.json", want: []string{"@org/testers"}},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, owners.Owners(tc.path)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	t.Run("codeOwnerReviewers", func(t *testing.T) {
+		have := codeOwnerReviewers(owners, []string{
+			"README.md",
+			"docs/index.md",
+			"scripts/deploy.sh",
+			"LICENSE",
+		})
+		want := []string{"global-owner", "org/scripts"}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestLoadCodeOwners(t *testing.T) {
+	defer git.ResetMocks()
+
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		var read []string
+		git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+			read = append(read, name)
+			if name == "CODEOWNERS" {
+				return []byte("* @owner\n"), nil
+			}
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+
+		owners, err := loadCodeOwners(ctx, "github.com/sourcegraph/sourcegraph", "deadbeef")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{".github/CODEOWNERS", "CODEOWNERS"}, read); diff != "" {
+			t.Fatalf("wrong files read: %s", diff)
+		}
+		if diff := cmp.Diff([]string{"@owner"}, owners.Owners("main.go")); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+
+		owners, err := loadCodeOwners(ctx, "github.com/sourcegraph/sourcegraph", "deadbeef")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if owners != nil {
+			t.Fatalf("unexpected owners: %+v", owners)
+		}
+	})
+}
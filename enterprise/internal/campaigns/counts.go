@@ -5,6 +5,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 )
 
@@ -104,6 +105,146 @@ func CalcCounts(start, end time.Time, cs []*campaigns.Changeset, es ...*campaign
 	return counts, nil
 }
 
+// ApplyStoredCounts replaces the given ChangesetCounts that fall into the
+// timeframe of the stored ChangesetCounts with the latest stored
+// ChangesetCounts at or before their time. Both must be sorted by time.
+//
+// Stored ChangesetCounts are computed before the events of the changesets
+// are compacted, so they're more accurate than ChangesetCounts computed from
+// the compacted events.
+func ApplyStoredCounts(counts, stored []*ChangesetCounts) {
+	if len(stored) == 0 {
+		return
+	}
+
+	first, last := stored[0].Time, stored[len(stored)-1].Time
+	i := 0
+	for _, c := range counts {
+		if c.Time.Before(first) || c.Time.After(last) {
+			continue
+		}
+		for i+1 < len(stored) && !stored[i+1].Time.After(c.Time) {
+			i++
+		}
+
+		t := c.Time
+		*c = *stored[i]
+		c.Time = t
+	}
+}
+
+// CalcCountsSince calculates the ChangesetCounts of the given Changesets in
+// the timeframe specified by the start and end parameters, like CalcCounts,
+// but resumes from the given stored ChangesetCounts, which must be sorted by
+// time.
+//
+// The ChangesetCounts in the timeframe of the stored ones are taken from
+// them, like with ApplyStoredCounts. The ones after the last stored
+// ChangesetCounts start from it and only fold in the changesets that changed
+// since, so only the Changesets returned by ChangesetsToCount need their
+// ChangesetEvents passed in. Like with ApplyStoredCounts, the given
+// Changesets are assumed to be the ones the stored ChangesetCounts were
+// computed from.
+func CalcCountsSince(start, end time.Time, stored []*ChangesetCounts, cs []*campaigns.Changeset, es ...*campaigns.ChangesetEvent) ([]*ChangesetCounts, error) {
+	if len(stored) == 0 {
+		return CalcCounts(start, end, cs, es...)
+	}
+	first, snapshot := stored[0], stored[len(stored)-1]
+
+	// Before the stored counts, only the changesets that existed back then
+	// are counted.
+	var early []*campaigns.Changeset
+	if start.Before(first.Time) {
+		for _, c := range cs {
+			if c.ExternalCreatedAt().Before(first.Time) {
+				early = append(early, c)
+			}
+		}
+	}
+	counts, err := CalcCounts(start, end, early, es...)
+	if err != nil {
+		return nil, err
+	}
+	ApplyStoredCounts(counts, stored)
+
+	if !end.After(snapshot.Time) {
+		return counts, nil
+	}
+
+	// After the snapshot, the counts are the snapshot plus the differences
+	// between the states of the changed changesets at each point in time and
+	// their states at the time of the snapshot.
+	var changed []*campaigns.Changeset
+	for _, c := range cs {
+		if changedSince(c, snapshot.Time) {
+			changed = append(changed, c)
+		}
+	}
+
+	afterStart := snapshot.Time.Add(time.Nanosecond)
+	if start.After(afterStart) {
+		afterStart = start
+	}
+	after, err := CalcCounts(afterStart, end, changed, es...)
+	if err != nil {
+		return nil, err
+	}
+	before, err := CalcCounts(snapshot.Time, snapshot.Time, changed, es...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The counts after the snapshot are the last ones, since the timestamps
+	// of both are generated back from end.
+	offset := len(counts) - len(after)
+	for i, a := range after {
+		counts[offset+i] = &ChangesetCounts{
+			Time:                 a.Time,
+			Total:                snapshot.Total + a.Total - before[0].Total,
+			Merged:               snapshot.Merged + a.Merged - before[0].Merged,
+			Closed:               snapshot.Closed + a.Closed - before[0].Closed,
+			Open:                 snapshot.Open + a.Open - before[0].Open,
+			OpenApproved:         snapshot.OpenApproved + a.OpenApproved - before[0].OpenApproved,
+			OpenChangesRequested: snapshot.OpenChangesRequested + a.OpenChangesRequested - before[0].OpenChangesRequested,
+			OpenPending:          snapshot.OpenPending + a.OpenPending - before[0].OpenPending,
+		}
+	}
+
+	return counts, nil
+}
+
+// ChangesetsToCount returns the Changesets of the given ones that
+// CalcCountsSince needs to compute the history of, when resuming from the
+// given stored ChangesetCounts in a timeframe beginning at start: the ones
+// that changed after the last stored ChangesetCounts and, if the timeframe
+// begins before the stored ones, the ones that existed before them.
+func ChangesetsToCount(start time.Time, stored []*ChangesetCounts, cs []*campaigns.Changeset) []*campaigns.Changeset {
+	if len(stored) == 0 {
+		return cs
+	}
+	first, snapshot := stored[0], stored[len(stored)-1]
+
+	var toCount []*campaigns.Changeset
+	for _, c := range cs {
+		early := start.Before(first.Time) && c.ExternalCreatedAt().Before(first.Time)
+		if early || changedSince(c, snapshot.Time) {
+			toCount = append(toCount, c)
+		}
+	}
+	return toCount
+}
+
+// changedSince returns whether the states of the given Changeset may have
+// changed after t. Every event on the code host bumps the changeset's
+// ExternalUpdatedAt, so a changeset that wasn't updated since can't have
+// events after t either.
+func changedSince(c *campaigns.Changeset, t time.Time) bool {
+	return c.ExternalUpdatedAt.IsZero() ||
+		c.ExternalUpdatedAt.After(t) ||
+		c.ExternalCreatedAt().After(t) ||
+		c.ExternalDeletedAt.After(t)
+}
+
 func generateTimestamps(start, end time.Time) []time.Time {
 	// Walk backwards from `end` to >= `start` in 1 day intervals
 	// Backwards so we always end exactly on `end`
@@ -120,3 +261,95 @@ func generateTimestamps(start, end time.Time) []time.Time {
 
 	return ts
 }
+
+// RepoChangesetCounts are the ChangesetCounts over time of the Changesets in
+// a single repository.
+type RepoChangesetCounts struct {
+	RepoID api.RepoID
+	Counts []*ChangesetCounts
+}
+
+// CalcCountsByRepo calculates the ChangesetCounts of the given Changesets in
+// the timeframe specified by the start and end parameters, like CalcCounts,
+// but separately for each repository.
+//
+// The returned RepoChangesetCounts are ordered so that the repositories that
+// lag behind the most come first: the ones with the most open changesets at
+// the end of the timeframe, then the ones with the most changesets overall.
+func CalcCountsByRepo(start, end time.Time, cs []*campaigns.Changeset, es ...*campaigns.ChangesetEvent) ([]*RepoChangesetCounts, error) {
+	repoByChangesetID := make(map[int64]api.RepoID, len(cs))
+	changesetsByRepo := make(map[api.RepoID][]*campaigns.Changeset)
+	for _, c := range cs {
+		repoByChangesetID[c.ID] = c.RepoID
+		changesetsByRepo[c.RepoID] = append(changesetsByRepo[c.RepoID], c)
+	}
+
+	eventsByRepo := make(map[api.RepoID][]*campaigns.ChangesetEvent)
+	for _, e := range es {
+		repoID, ok := repoByChangesetID[e.ChangesetID]
+		if !ok {
+			continue
+		}
+		eventsByRepo[repoID] = append(eventsByRepo[repoID], e)
+	}
+
+	byRepo := make([]*RepoChangesetCounts, 0, len(changesetsByRepo))
+	for repoID, repoChangesets := range changesetsByRepo {
+		counts, err := CalcCounts(start, end, repoChangesets, eventsByRepo[repoID]...)
+		if err != nil {
+			return nil, err
+		}
+		byRepo = append(byRepo, &RepoChangesetCounts{RepoID: repoID, Counts: counts})
+	}
+
+	sort.Slice(byRepo, func(i, j int) bool {
+		a, b := byRepo[i].last(), byRepo[j].last()
+		if a.Open != b.Open {
+			return a.Open > b.Open
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return byRepo[i].RepoID < byRepo[j].RepoID
+	})
+
+	return byRepo, nil
+}
+
+// last returns the ChangesetCounts at the end of the timeframe.
+func (rc *RepoChangesetCounts) last() *ChangesetCounts {
+	if len(rc.Counts) == 0 {
+		return &ChangesetCounts{}
+	}
+	return rc.Counts[len(rc.Counts)-1]
+}
+
+// SumCounts adds up the given series of ChangesetCounts, which all need to
+// span the same timeframe, into a single series.
+func SumCounts(series ...[]*ChangesetCounts) []*ChangesetCounts {
+	if len(series) == 0 {
+		return nil
+	}
+
+	sum := make([]*ChangesetCounts, len(series[0]))
+	for i, c := range series[0] {
+		sum[i] = &ChangesetCounts{Time: c.Time}
+	}
+
+	for _, counts := range series {
+		for i, c := range counts {
+			if i >= len(sum) {
+				break
+			}
+			sum[i].Total += c.Total
+			sum[i].Merged += c.Merged
+			sum[i].Closed += c.Closed
+			sum[i].Open += c.Open
+			sum[i].OpenApproved += c.OpenApproved
+			sum[i].OpenChangesRequested += c.OpenChangesRequested
+			sum[i].OpenPending += c.OpenPending
+		}
+	}
+
+	return sum
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
@@ -425,6 +426,25 @@ func TestCalcCounts(t *testing.T) {
 				{Time: daysAgo(0), Total: 1, Open: 1, OpenPending: 1},
 			},
 		},
+		{
+			codehosts: "bitbucketserver",
+			name:      "single changeset open, approved and unapproved via native webhooks",
+			changesets: []*campaigns.Changeset{
+				bbsChangeset(1, daysAgo(3)),
+			},
+			start: daysAgo(4),
+			events: []*campaigns.ChangesetEvent{
+				bbsParticipantEvent(1, daysAgo(2), "user1", campaigns.ChangesetEventKindBitbucketServerParticipantApproved),
+				bbsParticipantEvent(1, daysAgo(1), "user1", campaigns.ChangesetEventKindBitbucketServerDismissed),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(4), Total: 0, Open: 0},
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenApproved: 1},
+				{Time: daysAgo(1), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(0), Total: 1, Open: 1, OpenPending: 1},
+			},
+		},
 		{
 			codehosts: extsvc.TypeGitHub,
 			name:      "single changeset open, approved, closed, reopened",
@@ -1224,6 +1244,208 @@ func TestCalcCounts(t *testing.T) {
 	}
 }
 
+func TestCalcCountsByRepo(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	inRepo := func(c *campaigns.Changeset, repoID api.RepoID) *campaigns.Changeset {
+		c.RepoID = repoID
+		return c
+	}
+
+	changesets := []*campaigns.Changeset{
+		inRepo(ghChangeset(1, daysAgo(2)), 1),
+		inRepo(ghChangeset(2, daysAgo(2)), 2),
+		inRepo(ghChangeset(3, daysAgo(2)), 2),
+		inRepo(ghChangeset(4, daysAgo(2)), 3),
+		inRepo(ghChangeset(5, daysAgo(2)), 3),
+	}
+	events := []*campaigns.ChangesetEvent{
+		event(t, daysAgo(1), campaigns.ChangesetEventKindGitHubMerged, 1),
+		event(t, daysAgo(1), campaigns.ChangesetEventKindGitHubMerged, 4),
+	}
+
+	have, err := CalcCountsByRepo(daysAgo(1), now, changesets, events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Repo 2 has the most open changesets, followed by repo 3, which has
+	// more changesets overall than repo 1.
+	want := []*RepoChangesetCounts{
+		{RepoID: 2, Counts: []*ChangesetCounts{
+			{Time: daysAgo(1), Total: 2, Open: 2, OpenPending: 2},
+			{Time: daysAgo(0), Total: 2, Open: 2, OpenPending: 2},
+		}},
+		{RepoID: 3, Counts: []*ChangesetCounts{
+			{Time: daysAgo(1), Total: 2, Merged: 1, Open: 1, OpenPending: 1},
+			{Time: daysAgo(0), Total: 2, Merged: 1, Open: 1, OpenPending: 1},
+		}},
+		{RepoID: 1, Counts: []*ChangesetCounts{
+			{Time: daysAgo(1), Total: 1, Merged: 1},
+			{Time: daysAgo(0), Total: 1, Merged: 1},
+		}},
+	}
+	if diff := cmp.Diff(have, want); diff != "" {
+		t.Fatalf("wrong counts calculated. diff=%s", diff)
+	}
+
+	sum := SumCounts(have[1].Counts, have[2].Counts)
+	wantSum := []*ChangesetCounts{
+		{Time: daysAgo(1), Total: 3, Merged: 2, Open: 1, OpenPending: 1},
+		{Time: daysAgo(0), Total: 3, Merged: 2, Open: 1, OpenPending: 1},
+	}
+	if diff := cmp.Diff(sum, wantSum); diff != "" {
+		t.Fatalf("wrong counts summed. diff=%s", diff)
+	}
+}
+
+func TestApplyStoredCounts(t *testing.T) {
+	now := time.Now().UTC().Truncate(24 * time.Hour)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	counts := []*ChangesetCounts{
+		{Time: daysAgo(4).Add(time.Hour), Total: 1, Open: 1, OpenPending: 1},
+		{Time: daysAgo(3).Add(time.Hour), Total: 1, Open: 1, OpenPending: 1},
+		{Time: daysAgo(2).Add(time.Hour), Total: 1, Open: 1, OpenPending: 1},
+		{Time: daysAgo(1).Add(time.Hour), Total: 1, Merged: 1},
+		{Time: daysAgo(0).Add(time.Hour), Total: 1, Merged: 1},
+	}
+	stored := []*ChangesetCounts{
+		{Time: daysAgo(3), Total: 1, Open: 1, OpenApproved: 1},
+		{Time: daysAgo(2), Total: 1, Open: 1, OpenChangesRequested: 1},
+		{Time: daysAgo(1), Total: 1, Merged: 1},
+	}
+
+	ApplyStoredCounts(counts, stored)
+
+	want := []*ChangesetCounts{
+		// Before the stored counts.
+		{Time: daysAgo(4).Add(time.Hour), Total: 1, Open: 1, OpenPending: 1},
+		{Time: daysAgo(3).Add(time.Hour), Total: 1, Open: 1, OpenApproved: 1},
+		{Time: daysAgo(2).Add(time.Hour), Total: 1, Open: 1, OpenChangesRequested: 1},
+		// After the stored counts.
+		{Time: daysAgo(1).Add(time.Hour), Total: 1, Merged: 1},
+		{Time: daysAgo(0).Add(time.Hour), Total: 1, Merged: 1},
+	}
+	if diff := cmp.Diff(want, counts); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestCalcCountsSince(t *testing.T) {
+	now := time.Now().UTC().Truncate(24 * time.Hour)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	updatedAt := func(c *campaigns.Changeset, t time.Time) *campaigns.Changeset {
+		c.ExternalUpdatedAt = t
+		return c
+	}
+
+	changesets := []*campaigns.Changeset{
+		// Existed before the stored counts, unchanged since.
+		updatedAt(ghChangeset(1, daysAgo(5)), daysAgo(4)),
+		// Reviewed after the stored counts.
+		updatedAt(ghChangeset(2, daysAgo(3)), daysAgo(1)),
+		// Deleted after the stored counts.
+		setExternalDeletedAt(updatedAt(ghChangeset(3, daysAgo(3)), daysAgo(3)), daysAgo(1)),
+		// Merged before the stored counts ended, unchanged since.
+		updatedAt(ghChangeset(4, daysAgo(3)), daysAgo(3)),
+		// Opened after the stored counts.
+		updatedAt(ghChangeset(5, daysAgo(1)), daysAgo(1)),
+	}
+
+	events := []*campaigns.ChangesetEvent{
+		ghReview(1, daysAgo(4), "user1", "APPROVED"),
+		ghReview(2, daysAgo(3), "user1", "CHANGES_REQUESTED"),
+		ghReview(2, daysAgo(1), "user1", "APPROVED"),
+		event(t, daysAgo(3), campaigns.ChangesetEventKindGitHubMerged, 4),
+	}
+
+	want, err := CalcCounts(daysAgo(6), now, changesets, events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := CalcCounts(daysAgo(4), daysAgo(2), changesets, events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toCount := ChangesetsToCount(daysAgo(6), stored, changesets)
+	if diff := cmp.Diff([]int64{1, 2, 3, 5}, campaigns.Changesets(toCount).IDs()); diff != "" {
+		t.Fatalf("wrong changesets to count. diff=%s", diff)
+	}
+
+	// Only the events of the changesets to count are needed.
+	var es []*campaigns.ChangesetEvent
+	for _, e := range events {
+		if e.ChangesetID != 4 {
+			es = append(es, e)
+		}
+	}
+
+	t.Run("resumes from stored counts", func(t *testing.T) {
+		have, err := CalcCountsSince(daysAgo(6), now, stored, changesets, es...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong counts calculated. diff=%s", diff)
+		}
+	})
+
+	t.Run("stored counts take precedence", func(t *testing.T) {
+		// Counts stored before the events were compacted differ from the
+		// ones computed now, which carries over to later counts.
+		adjusted := make([]*ChangesetCounts, len(stored))
+		for i, c := range stored {
+			cc := *c
+			cc.Total++
+			cc.Closed++
+			adjusted[i] = &cc
+		}
+
+		have, err := CalcCountsSince(daysAgo(6), now, adjusted, changesets, es...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, c := range have {
+			w := *want[i]
+			if !c.Time.Before(daysAgo(4)) {
+				w.Total++
+				w.Closed++
+			}
+			if diff := cmp.Diff(&w, c); diff != "" {
+				t.Errorf("wrong counts at %s. diff=%s", c.Time, diff)
+			}
+		}
+	})
+
+	t.Run("timeframe after stored counts", func(t *testing.T) {
+		have, err := CalcCountsSince(daysAgo(1), now, stored, changesets, es...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want[5:], have); diff != "" {
+			t.Fatalf("wrong counts calculated. diff=%s", diff)
+		}
+	})
+
+	t.Run("no stored counts", func(t *testing.T) {
+		if have := ChangesetsToCount(daysAgo(6), nil, changesets); len(have) != len(changesets) {
+			t.Fatalf("wrong number of changesets to count. want=%d, have=%d", len(changesets), len(have))
+		}
+
+		have, err := CalcCountsSince(daysAgo(6), now, nil, changesets, events...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong counts calculated. diff=%s", diff)
+		}
+	})
+}
+
 func ghChangeset(id int64, t time.Time) *campaigns.Changeset {
 	return &campaigns.Changeset{ID: id, Metadata: &github.PullRequest{CreatedAt: t}}
 }
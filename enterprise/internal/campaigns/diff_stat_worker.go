@@ -0,0 +1,130 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+const (
+	// diffStatJobBackoff is how long the diff stat worker waits before
+	// retrying a job whose head revision isn't available in gitserver yet.
+	diffStatJobBackoff = 1 * time.Minute
+
+	// maxDiffStatJobAttempts is how often a job is retried before it's
+	// marked as errored.
+	maxDiffStatJobAttempts = 10
+)
+
+// RunDiffStatWorker starts a dbworker.NewWorker that processes the
+// ChangesetDiffStatJobs enqueued by the syncer when it notices that the head
+// of a changeset changed on the code host, for example after a force-push.
+//
+// SetDerivedState already tries to update the diff stat during the sync, but
+// that fails if gitserver hasn't fetched the new head yet, which is common
+// right after a push. The worker makes gitserver fetch it and retries until
+// the diff stat reflects the changeset's current head.
+func RunDiffStatWorker(ctx context.Context, s *Store) {
+	w := &diffStatWorker{store: s}
+
+	options := dbworker.WorkerOptions{
+		Name:        "campaigns_diff_stat_worker",
+		Handler:     w.HandlerFunc(),
+		NumHandlers: 5,
+		Interval:    5 * time.Second,
+		Metrics: workerutil.WorkerMetrics{
+			HandleOperation: newObservationOperation("campaigns_diff_stat_worker", "DiffStatWorker.Process"),
+		},
+	}
+
+	workerStore := dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
+		TableName:         "changeset_diff_stat_jobs",
+		ColumnExpressions: changesetDiffStatJobColumns,
+		Scan:              scanFirstChangesetDiffStatJobRecord,
+		OrderByExpression: sqlf.Sprintf("changeset_diff_stat_jobs.created_at"),
+		StalledMaxAge:     60 * time.Second,
+		MaxNumResets:      5,
+	})
+
+	dbworker.NewWorker(ctx, workerStore, options).Start()
+}
+
+func scanFirstChangesetDiffStatJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return scanFirstChangesetDiffStatJob(rows, err)
+}
+
+type diffStatWorker struct {
+	store *Store
+}
+
+func (w *diffStatWorker) HandlerFunc() dbworker.HandlerFunc {
+	return func(ctx context.Context, tx dbworkerstore.Store, record workerutil.Record) error {
+		store := w.store.With(tx)
+		job := record.(*campaigns.ChangesetDiffStatJob)
+
+		err := w.process(ctx, store, job)
+		if isRevisionNotAvailable(err) && job.NumAttempts < maxDiffStatJobAttempts {
+			log15.Info("Requeueing changeset diff stat job", "job", job.ID, "changeset", job.ChangesetID, "err", err)
+			return store.requeueChangesetDiffStatJob(ctx, job.ID, store.Clock()().Add(diffStatJobBackoff))
+		}
+		return err
+	}
+}
+
+// process recomputes the diff stat of the job's changeset between the job's
+// base and head revisions.
+func (w *diffStatWorker) process(ctx context.Context, tx *Store, job *campaigns.ChangesetDiffStatJob) error {
+	c, err := tx.GetChangeset(ctx, GetChangesetOpts{ID: job.ChangesetID})
+	if err != nil {
+		if err == ErrNoResults {
+			return nil
+		}
+		return err
+	}
+
+	// If the head moved again in the meantime, the diff stat is recomputed
+	// by the job enqueued for the new head.
+	if c.SyncState.HeadRefOid != job.HeadRefOid || c.SyncState.BaseRefOid != job.BaseRefOid {
+		return nil
+	}
+
+	repo, err := changesetGitserverRepo(ctx, c)
+	if err != nil {
+		return errors.Wrap(err, "retrieving gitserver repo")
+	}
+
+	// Resolving the revision makes gitserver fetch it from the code host if
+	// it doesn't have it yet.
+	if _, err := git.ResolveRevision(ctx, *repo, nil, job.HeadRefOid, git.ResolveRevisionOptions{}); err != nil {
+		return errors.Wrap(err, "resolving head revision")
+	}
+
+	stat, err := computeDiffStat(ctx, c, *repo)
+	if err != nil {
+		return errors.Wrap(err, "computing diff stat")
+	}
+
+	c.SetDiffStat(stat)
+	return tx.UpdateChangeset(ctx, c)
+}
+
+// isRevisionNotAvailable reports whether err is caused by gitserver not
+// having a revision (yet), which usually resolves itself after a while.
+func isRevisionNotAvailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	cause := errors.Cause(err)
+	return gitserver.IsRevisionNotFound(cause) || vcs.IsCloneInProgress(cause) || vcs.IsRepoNotExist(cause)
+}
@@ -0,0 +1,96 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+func TestDiffStatWorkerProcess(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	rs, _ := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: api.RepoName(rs[0].Name),
+		VCS:  protocol.VCSInfo{URL: rs[0].URI},
+	})
+	defer state.Unmock()
+
+	campaign := testCampaign(admin.ID)
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+	c.SyncState = campaigns.ChangesetSyncState{BaseRefOid: "base", HeadRefOid: "force-pushed"}
+	c.SetDiffStat(&diff.Stat{Added: 10})
+	if err := store.CreateChangeset(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &diffStatWorker{store: store}
+
+	// A job for a head the changeset no longer points to is ignored.
+	outdated := &campaigns.ChangesetDiffStatJob{ChangesetID: c.ID, BaseRefOid: "base", HeadRefOid: "outdated"}
+	if err := w.process(ctx, store, outdated); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := *reloaded.DiffStat(), (diff.Stat{Added: 10}); have != want {
+		t.Fatalf("diff stat changed by outdated job. want=%+v, have=%+v", want, have)
+	}
+
+	job := &campaigns.ChangesetDiffStatJob{ChangesetID: c.ID, BaseRefOid: "base", HeadRefOid: "force-pushed"}
+	if err := w.process(ctx, store, job); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err = store.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// These values come from the diff returned by ct.MockChangesetSyncState.
+	if have, want := *reloaded.DiffStat(), (diff.Stat{Added: 1, Changed: 1, Deleted: 3}); have != want {
+		t.Fatalf("wrong diff stat. want=%+v, have=%+v", want, have)
+	}
+}
+
+func TestIsRevisionNotAvailable(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: errors.New("boom"), want: false},
+		{err: &gitserver.RevisionNotFoundError{Repo: "github.com/sourcegraph/sourcegraph", Spec: "deadbeef"}, want: true},
+		{err: errors.Wrap(&gitserver.RevisionNotFoundError{}, "resolving head revision"), want: true},
+	} {
+		if have := isRevisionNotAvailable(tc.err); have != tc.want {
+			t.Errorf("isRevisionNotAvailable(%v): want=%t, have=%t", tc.err, tc.want, have)
+		}
+	}
+}
@@ -0,0 +1,666 @@
+package campaigns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// campaignsExecutor returns the campaigns.executor site configuration. It's
+// a variable so that it can be mocked in tests.
+var campaignsExecutor = func() *schema.CampaignsExecutor {
+	return conf.Get().CampaignsExecutor
+}
+
+// executorEnabled returns true if server-side execution of campaign specs is
+// enabled.
+func executorEnabled() bool {
+	cfg := campaignsExecutor()
+	return cfg != nil && cfg.Enabled
+}
+
+// ErrExecutorDisabled is returned when a campaign spec is to be executed
+// server-side, but campaigns.executor isn't enabled.
+var ErrExecutorDisabled = errors.New("server-side execution of campaign specs is not enabled")
+
+// Commander abstracts running processes on the host of the executor.
+type Commander interface {
+	// Run invokes the given command and returns what it wrote to stdout.
+	Run(ctx context.Context, command string, args ...string) ([]byte, error)
+}
+
+// CommanderFunc is a function version of the Commander interface.
+type CommanderFunc func(ctx context.Context, command string, args ...string) ([]byte, error)
+
+// Run invokes the given command. See the Commander interface for additional
+// details.
+func (f CommanderFunc) Run(ctx context.Context, command string, args ...string) ([]byte, error) {
+	return f(ctx, command, args...)
+}
+
+// DefaultCommander is a Commander that uses exec.Cmd to invoke commands on
+// the host.
+var DefaultCommander Commander = CommanderFunc(runCommand)
+
+// runCommand invokes the given command on the host. If the command fails,
+// the returned error contains what it wrote to stderr.
+func runCommand(ctx context.Context, command string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "running %s: %s", command, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// specExecutorInterval is the time the spec executor waits before it checks
+// for queued executions again, after it processed all of them.
+const specExecutorInterval = 5 * time.Second
+
+// RunSpecExecutor processes the CampaignSpecExecutions enqueued by the
+// executeCampaignSpec mutation and the reapply job worker: it runs the steps
+// of the campaign spec in Docker containers in every repository the spec is
+// on and creates a CampaignSpec with the resulting ChangesetSpecs. Nothing is
+// processed while campaigns.executor isn't enabled. It runs until the given
+// context is canceled. If locker is not nil, executions are only processed by
+// the replica that's the leader of the spec executor job.
+func RunSpecExecutor(ctx context.Context, s *Store, cf *httpcli.Factory, locker *Locker) {
+	e := &specExecutor{store: s, cf: cf, commander: DefaultCommander}
+	locker.DoAsLeader(ctx, LeaderJobSpecExecutor, e.loop)
+}
+
+type specExecutor struct {
+	store     *Store
+	cf        *httpcli.Factory
+	commander Commander
+}
+
+func (e *specExecutor) loop(ctx context.Context) {
+	// Executions that are still processing were interrupted when the
+	// previous leader went away.
+	if err := e.store.ResetProcessingCampaignSpecExecutions(ctx); err != nil {
+		log15.Error("Resetting interrupted campaign spec executions", "err", err)
+	}
+
+	for {
+		if err := e.run(ctx); err != nil {
+			log15.Error("Executing campaign specs", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(specExecutorInterval):
+		}
+	}
+}
+
+// run processes queued executions, one at a time, until none are left.
+func (e *specExecutor) run(ctx context.Context) error {
+	for executorEnabled() {
+		exec, err := e.store.DequeueCampaignSpecExecution(ctx)
+		if err != nil {
+			if err == ErrNoResults {
+				return nil
+			}
+			return errors.Wrap(err, "dequeueing campaign spec execution")
+		}
+
+		if err := e.process(ctx, exec); err != nil {
+			return errors.Wrapf(err, "processing campaign spec execution %d", exec.ID)
+		}
+	}
+	return nil
+}
+
+// process executes the given execution and records its outcome.
+func (e *specExecutor) process(ctx context.Context, exec *campaigns.CampaignSpecExecution) error {
+	err := e.execute(ctx, exec)
+	if ctx.Err() != nil {
+		// The execution stays in the processing state and is picked up
+		// again by the next leader.
+		return nil
+	}
+
+	exec.FinishedAt = e.store.now()
+	if err != nil {
+		log15.Warn("Campaign spec execution failed", "execution", exec.ID, "err", err)
+		msg := err.Error()
+		exec.State = campaigns.ReconcilerStateErrored
+		exec.FailureMessage = &msg
+	} else {
+		exec.State = campaigns.ReconcilerStateCompleted
+		exec.FailureMessage = nil
+	}
+
+	return e.store.UpdateCampaignSpecExecution(ctx, exec)
+}
+
+func (e *specExecutor) execute(ctx context.Context, exec *campaigns.CampaignSpecExecution) error {
+	// 🚨 SECURITY: The spec is executed with the permissions of the user that
+	// requested the execution, so that only the repositories they have access
+	// to are changed and so that the resulting specs belong to them.
+	ctx = actor.WithActor(ctx, actor.FromUser(exec.UserID))
+
+	spec, err := campaigns.NewCampaignSpecFromRaw(exec.RawSpec)
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolveExecutionTargets(ctx, spec.Spec.On)
+	if err != nil {
+		return err
+	}
+
+	exec.RepositoriesTotal = int32(len(targets))
+	if err := e.store.UpdateCampaignSpecExecution(ctx, exec); err != nil {
+		return err
+	}
+
+	svc := NewService(e.store, e.cf)
+	randIDs, err := e.executeTargets(ctx, svc, exec, &spec.Spec, targets)
+	if err != nil {
+		return err
+	}
+
+	campaignSpec, err := svc.CreateCampaignSpec(ctx, CreateCampaignSpecOpts{
+		RawSpec:              exec.RawSpec,
+		NamespaceUserID:      exec.NamespaceUserID,
+		NamespaceOrgID:       exec.NamespaceOrgID,
+		ChangesetSpecRandIDs: randIDs,
+	})
+	if err != nil {
+		return errors.Wrap(err, "creating campaign spec")
+	}
+	exec.CampaignSpecID = campaignSpec.ID
+
+	if exec.CampaignID != 0 {
+		_, err := svc.ApplyCampaign(ctx, ApplyCampaignOpts{
+			CampaignSpecRandID: campaignSpec.RandID,
+			EnsureCampaignID:   exec.CampaignID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "applying campaign spec")
+		}
+	}
+
+	return nil
+}
+
+// executeTargets executes the steps in all targets, with the parallelism
+// configured in campaigns.executor, and returns the RandIDs of the resulting
+// ChangesetSpecs. Targets in which the steps produce no changes don't get a
+// ChangesetSpec.
+func (e *specExecutor) executeTargets(ctx context.Context, svc *Service, exec *campaigns.CampaignSpecExecution, spec *campaigns.CampaignSpecFields, targets []*executionTarget) ([]string, error) {
+	parallelism := 1
+	if cfg := campaignsExecutor(); cfg != nil && cfg.Parallelism > 1 {
+		parallelism = cfg.Parallelism
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		randIDs  []string
+		firstErr error
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, parallelism)
+	)
+
+	for _, t := range targets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(t *executionTarget) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			randID, err := e.executeTarget(ctx, svc, exec, spec, t)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "executing steps in %s", t.repo.Name)
+					cancel()
+				}
+				return
+			}
+			if randID != "" {
+				randIDs = append(randIDs, randID)
+			}
+
+			exec.RepositoriesCompleted++
+			if err := e.store.UpdateCampaignSpecExecution(ctx, exec); err != nil && firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}(t)
+	}
+
+	wg.Wait()
+	return randIDs, firstErr
+}
+
+// executeTarget runs the steps in a fresh checkout of the target and creates
+// a ChangesetSpec with the resulting diff. It returns the RandID of the
+// ChangesetSpec, or an empty string if the steps produced no changes.
+func (e *specExecutor) executeTarget(ctx context.Context, svc *Service, exec *campaigns.CampaignSpecExecution, spec *campaigns.CampaignSpecFields, t *executionTarget) (string, error) {
+	baseRef, baseRev, err := resolveTargetRevision(ctx, t)
+	if err != nil {
+		return "", err
+	}
+
+	diff, outputs, err := e.runSteps(ctx, t.repo.Name, string(baseRev), spec.Steps)
+	if err != nil {
+		return "", err
+	}
+	if len(diff) == 0 {
+		return "", nil
+	}
+
+	tmpl := spec.ChangesetTemplate.Render(campaigns.ChangesetTemplateVars{
+		RepositoryName:    string(t.repo.Name),
+		SearchResultCount: &t.searchResultCount,
+		StepOutputs:       outputs,
+	})
+
+	rawSpec, err := executedChangesetSpec(t.repo, baseRef, string(baseRev), tmpl, string(diff))
+	if err != nil {
+		return "", err
+	}
+
+	changesetSpec, err := svc.CreateChangesetSpec(ctx, rawSpec, exec.UserID)
+	if err != nil {
+		return "", errors.Wrap(err, "creating changeset spec")
+	}
+	return changesetSpec.RandID, nil
+}
+
+// runSteps checks out the given revision of the repository into a temporary
+// directory, runs the steps in it and returns the diff of all changes they
+// made and what each step wrote to stdout.
+func (e *specExecutor) runSteps(ctx context.Context, repo api.RepoName, rev string, steps []campaigns.CampaignSpecStep) (diff []byte, outputs []string, err error) {
+	if len(steps) == 0 {
+		return nil, nil, nil
+	}
+
+	cfg := campaignsExecutor()
+	if cfg == nil {
+		cfg = &schema.CampaignsExecutor{}
+	}
+
+	for _, step := range steps {
+		if !executorImageAllowed(cfg, step.Container) {
+			return nil, nil, errors.Errorf("image %q is not in the image allowlist of campaigns.executor", step.Container)
+		}
+	}
+
+	dir, err := makeExecutorTempDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	fetch := [][]string{
+		{"-C", dir, "init"},
+		{"-C", dir, "-c", "protocol.version=2", "fetch", gitserverCloneURL(ctx, repo), rev},
+		{"-C", dir, "checkout", rev},
+	}
+	for _, args := range fetch {
+		if _, err := e.commander.Run(ctx, "git", args...); err != nil {
+			return nil, nil, errors.Wrap(err, "checking out repository")
+		}
+	}
+
+	for i, step := range steps {
+		out, err := e.commander.Run(ctx, "docker", stepDockerArgs(cfg, dir, step)...)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "running step %d", i+1)
+		}
+		outputs = append(outputs, string(out))
+	}
+
+	if _, err := e.commander.Run(ctx, "git", "-C", dir, "add", "--all"); err != nil {
+		return nil, nil, errors.Wrap(err, "staging changes")
+	}
+	diff, err = e.commander.Run(ctx, "git", "-C", dir, "diff", "--cached", "--no-prefix", "--binary")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "computing diff")
+	}
+	return diff, outputs, nil
+}
+
+// stepDockerArgs returns the arguments of the docker command that runs the
+// given step in a fresh container, with the checkout in dir as its working
+// directory.
+func stepDockerArgs(cfg *schema.CampaignsExecutor, dir string, step campaigns.CampaignSpecStep) []string {
+	args := []string{"run", "--rm"}
+	if cfg.Cpus > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(cfg.Cpus, 'f', -1, 64))
+	}
+	if cfg.Memory != "" {
+		args = append(args, "--memory", cfg.Memory)
+	}
+
+	keys := make([]string, 0, len(step.Env))
+	for k := range step.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+step.Env[k])
+	}
+
+	return append(args,
+		"-v", fmt.Sprintf("%s:/work", dir),
+		"-w", "/work",
+		"--entrypoint", "/bin/sh",
+		step.Container,
+		"-c", step.Run,
+	)
+}
+
+// executorImageAllowed returns true if steps may use the given image.
+func executorImageAllowed(cfg *schema.CampaignsExecutor, image string) bool {
+	if len(cfg.ImageAllowlist) == 0 {
+		return true
+	}
+	for _, allowed := range cfg.ImageAllowlist {
+		if allowed == image {
+			return true
+		}
+	}
+	return false
+}
+
+// gitserverCloneURL returns the URL from which the repository can be fetched
+// from gitserver. It's a variable so that it can be mocked in tests.
+var gitserverCloneURL = func(ctx context.Context, repo api.RepoName) string {
+	return (&url.URL{
+		Scheme: "http",
+		Host:   gitserver.DefaultClient.AddrForRepo(ctx, repo),
+		Path:   "/git/" + string(repo),
+	}).String()
+}
+
+// makeExecutorTempDir is a wrapper around ioutil.TempDir that can be
+// replaced in tests. The directory is bind-mounted into the step containers,
+// so TMPDIR must point to a directory that's shared with the Docker host.
+var makeExecutorTempDir = func() (string, error) {
+	return ioutil.TempDir("", "campaign-spec-execution")
+}
+
+// executedChangesetSpec returns the raw ChangesetSpec for the given diff in
+// the repository, filled in from the changeset template of the campaign
+// spec. It doesn't use ChangesetSpecDescription, since that omits the empty
+// fields that are required by the schema.
+func executedChangesetSpec(repo *types.Repo, baseRef, baseRev string, tmpl *campaigns.ChangesetTemplate, diff string) (string, error) {
+	repoID := graphqlbackend.MarshalRepositoryID(repo.ID)
+	spec := struct {
+		BaseRepository string                           `json:"baseRepository"`
+		BaseRef        string                           `json:"baseRef"`
+		BaseRev        string                           `json:"baseRev"`
+		HeadRepository string                           `json:"headRepository"`
+		HeadRef        string                           `json:"headRef"`
+		Title          string                           `json:"title"`
+		Body           string                           `json:"body"`
+		Commits        []campaigns.GitCommitDescription `json:"commits"`
+		Published      bool                             `json:"published"`
+		CustomMetadata map[string]string                `json:"customMetadata,omitempty"`
+
+		RequestCodeOwnerReviews bool     `json:"requestCodeOwnerReviews,omitempty"`
+		Reviewers               []string `json:"reviewers,omitempty"`
+		Assignees               []string `json:"assignees,omitempty"`
+		Labels                  []string `json:"labels,omitempty"`
+		Milestone               string   `json:"milestone,omitempty"`
+	}{
+		BaseRepository: string(repoID),
+		BaseRef:        baseRef,
+		BaseRev:        baseRev,
+		HeadRepository: string(repoID),
+		HeadRef:        "refs/heads/" + strings.TrimPrefix(tmpl.Branch, "refs/heads/"),
+		Title:          tmpl.Title,
+		Body:           tmpl.Body,
+		Commits:        []campaigns.GitCommitDescription{{Message: tmpl.Commit.Message, Diff: diff}},
+		Published:      tmpl.Published,
+		CustomMetadata: tmpl.CustomMetadata,
+
+		RequestCodeOwnerReviews: tmpl.RequestCodeOwnerReviews,
+		Reviewers:               tmpl.Reviewers,
+		Assignees:               tmpl.Assignees,
+		Labels:                  tmpl.Labels,
+		Milestone:               tmpl.Milestone,
+	}
+
+	raw, err := json.Marshal(spec)
+	return string(raw), err
+}
+
+// An executionTarget is a repository, and optionally a branch in it, in
+// which the steps of a campaign spec are executed.
+type executionTarget struct {
+	repo *types.Repo
+	// branch is empty if the default branch of the repository is used.
+	branch string
+	// searchResultCount is the number of results of the
+	// repositoriesMatchingQuery searches in the repository.
+	searchResultCount int
+}
+
+// resolveExecutionTargets returns the repositories matched by the on
+// property of a campaign spec that the current user has access to.
+// Repositories matched by a search query whose code host isn't supported by
+// campaigns are skipped; explicitly listed ones are an error.
+func resolveExecutionTargets(ctx context.Context, on []campaigns.CampaignSpecOn) ([]*executionTarget, error) {
+	var targets []*executionTarget
+	seen := make(map[api.RepoID]*executionTarget)
+	add := func(repo *types.Repo, branch string, searchResultCount int) {
+		if t, ok := seen[repo.ID]; ok {
+			t.searchResultCount += searchResultCount
+			return
+		}
+		t := &executionTarget{repo: repo, branch: branch, searchResultCount: searchResultCount}
+		seen[repo.ID] = t
+		targets = append(targets, t)
+	}
+
+	for _, o := range on {
+		if o.Repository != "" {
+			// 🚨 SECURITY: db.Repos.GetByName returns an error if the user
+			// doesn't have access to the repository.
+			repo, err := db.Repos.GetByName(ctx, api.RepoName(o.Repository))
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolving repository %q", o.Repository)
+			}
+			if err := checkRepoSupported(repo); err != nil {
+				return nil, err
+			}
+			add(repo, o.Branch, 0)
+			continue
+		}
+
+		names, counts, err := searchRepositoryNames(ctx, o.RepositoriesMatchingQuery)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving repositories matching %q", o.RepositoriesMatchingQuery)
+		}
+		for _, name := range names {
+			// 🚨 SECURITY: The search runs with internal permissions, so
+			// repositories the user doesn't have access to are filtered out
+			// here.
+			repo, err := db.Repos.GetByName(ctx, name)
+			if err != nil {
+				if errcode.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if checkRepoSupported(repo) != nil {
+				continue
+			}
+			add(repo, "", counts[name])
+		}
+	}
+
+	return targets, nil
+}
+
+// resolveTargetRevision returns the ref of the branch of the target and the
+// commit it points to.
+func resolveTargetRevision(ctx context.Context, t *executionTarget) (string, api.CommitID, error) {
+	repo := gitserver.Repo{Name: t.repo.Name}
+
+	ref := "refs/heads/" + t.branch
+	if t.branch == "" {
+		out, _, _, err := git.ExecSafe(ctx, repo, []string{"symbolic-ref", "HEAD"})
+		if err != nil {
+			return "", "", errors.Wrap(err, "resolving default branch")
+		}
+		ref = strings.TrimSpace(string(out))
+	}
+
+	rev, err := git.ResolveRevision(ctx, repo, nil, ref, git.ResolveRevisionOptions{})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "resolving %s", ref)
+	}
+	return ref, rev, nil
+}
+
+const searchRepositoriesQuery = `query SearchRepositories($query: String!) {
+	search(query: $query) {
+		results {
+			results {
+				__typename
+				... on Repository { name }
+				... on FileMatch { repository { name } }
+				... on CommitSearchResult { commit { repository { name } } }
+			}
+		}
+	}
+}`
+
+type searchRepositoriesResponse struct {
+	Data struct {
+		Search struct {
+			Results struct {
+				Results []struct {
+					Typename   string `json:"__typename"`
+					Name       string `json:"name"`
+					Repository struct {
+						Name string `json:"name"`
+					} `json:"repository"`
+					Commit struct {
+						Repository struct {
+							Name string `json:"name"`
+						} `json:"repository"`
+					} `json:"commit"`
+				} `json:"results"`
+			} `json:"results"`
+		} `json:"search"`
+	} `json:"data"`
+	Errors []interface{} `json:"errors"`
+}
+
+// searchRepositoryNames returns the names of the repositories with results
+// for the given search query, and the number of results in each of them,
+// using the internal GraphQL API of the frontend. It's a variable so that it
+// can be mocked in tests.
+var searchRepositoryNames = func(ctx context.Context, query string) ([]api.RepoName, map[api.RepoName]int, error) {
+	// Like src-cli, return all results instead of the default number.
+	if !strings.Contains(query, "count:") {
+		query += " count:999999"
+	}
+
+	var buf bytes.Buffer
+	err := json.NewEncoder(&buf).Encode(map[string]interface{}{
+		"query":     searchRepositoriesQuery,
+		"variables": map[string]string{"query": query},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u, err := url.Parse(api.InternalClient.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.Path = "/.internal/graphql"
+	u.RawQuery = "SearchRepositories"
+
+	resp, err := ctxhttp.Post(ctx, nil, u.String(), "application/json", &buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	var res searchRepositoriesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, nil, errors.Wrap(err, "decoding search response")
+	}
+	if len(res.Errors) > 0 {
+		return nil, nil, errors.Errorf("graphql: errors: %v", res.Errors)
+	}
+
+	var names []api.RepoName
+	counts := make(map[api.RepoName]int)
+	for _, r := range res.Data.Search.Results.Results {
+		var name string
+		switch r.Typename {
+		case "Repository":
+			name = r.Name
+		case "FileMatch":
+			name = r.Repository.Name
+		case "CommitSearchResult":
+			name = r.Commit.Repository.Name
+		}
+		if name == "" {
+			continue
+		}
+		if _, ok := counts[api.RepoName(name)]; !ok {
+			names = append(names, api.RepoName(name))
+		}
+		counts[api.RepoName(name)]++
+	}
+	return names, counts, nil
+}
@@ -0,0 +1,174 @@
+package campaigns
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestStepDockerArgs(t *testing.T) {
+	step := campaigns.CampaignSpecStep{
+		Run:       "sed -i s/foo/bar/ README.md",
+		Container: "alpine:3",
+		Env:       map[string]string{"B": "2", "A": "1"},
+	}
+
+	for _, tc := range []struct {
+		name string
+		cfg  *schema.CampaignsExecutor
+		want []string
+	}{
+		{
+			name: "no limits",
+			cfg:  &schema.CampaignsExecutor{},
+			want: []string{
+				"run", "--rm",
+				"-e", "A=1", "-e", "B=2",
+				"-v", "/tmp/x:/work", "-w", "/work", "--entrypoint", "/bin/sh",
+				"alpine:3", "-c", "sed -i s/foo/bar/ README.md",
+			},
+		},
+		{
+			name: "limits",
+			cfg:  &schema.CampaignsExecutor{Cpus: 1.5, Memory: "2g"},
+			want: []string{
+				"run", "--rm", "--cpus", "1.5", "--memory", "2g",
+				"-e", "A=1", "-e", "B=2",
+				"-v", "/tmp/x:/work", "-w", "/work", "--entrypoint", "/bin/sh",
+				"alpine:3", "-c", "sed -i s/foo/bar/ README.md",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have := stepDockerArgs(tc.cfg, "/tmp/x", step)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestExecutorImageAllowed(t *testing.T) {
+	if !executorImageAllowed(&schema.CampaignsExecutor{}, "alpine:3") {
+		t.Fatal("image not allowed without allowlist")
+	}
+
+	cfg := &schema.CampaignsExecutor{ImageAllowlist: []string{"alpine:3"}}
+	if !executorImageAllowed(cfg, "alpine:3") {
+		t.Fatal("allowlisted image not allowed")
+	}
+	if executorImageAllowed(cfg, "ubuntu:20.04") {
+		t.Fatal("image allowed that's not in allowlist")
+	}
+}
+
+func TestExecutedChangesetSpec(t *testing.T) {
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	tmpl := &campaigns.ChangesetTemplate{
+		Title:  "Replace foo with bar",
+		Branch: "replace-foo",
+		Commit: campaigns.CommitTemplate{Message: "Replace foo with bar"},
+	}
+	diff := "--- README.md\n+++ README.md\n@@ -1 +1 @@\n-foo\n+bar\n"
+
+	raw, err := executedChangesetSpec(repo, "refs/heads/main", "d34db33f", tmpl, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The empty body and the unpublished state must survive validation.
+	spec, err := campaigns.NewChangesetSpecFromRaw(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repoID := graphqlbackend.MarshalRepositoryID(repo.ID)
+	want := &campaigns.ChangesetSpecDescription{
+		BaseRepository: repoID,
+		BaseRef:        "refs/heads/main",
+		BaseRev:        "d34db33f",
+		HeadRepository: repoID,
+		HeadRef:        "refs/heads/replace-foo",
+		Title:          "Replace foo with bar",
+		Commits:        []campaigns.GitCommitDescription{{Message: "Replace foo with bar", Diff: diff}},
+	}
+	if diff := cmp.Diff(want, spec.Spec); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSpecExecutorRunSteps(t *testing.T) {
+	cfg := &schema.CampaignsExecutor{Enabled: true, ImageAllowlist: []string{"alpine:3"}}
+	defer func(f func() *schema.CampaignsExecutor) { campaignsExecutor = f }(campaignsExecutor)
+	campaignsExecutor = func() *schema.CampaignsExecutor { return cfg }
+
+	defer func(f func(context.Context, api.RepoName) string) { gitserverCloneURL = f }(gitserverCloneURL)
+	gitserverCloneURL = func(ctx context.Context, repo api.RepoName) string {
+		return "http://gitserver/git/" + string(repo)
+	}
+
+	dir, err := ioutil.TempDir("", "executor-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(f func() (string, error)) { makeExecutorTempDir = f }(makeExecutorTempDir)
+	makeExecutorTempDir = func() (string, error) { return dir, nil }
+
+	var commands []string
+	e := &specExecutor{commander: CommanderFunc(func(ctx context.Context, command string, args ...string) ([]byte, error) {
+		commands = append(commands, command+" "+strings.Join(args, " "))
+		if command == "git" && args[2] == "diff" {
+			return []byte("the diff"), nil
+		}
+		if command == "docker" {
+			return []byte("the output\n"), nil
+		}
+		return nil, nil
+	})}
+
+	steps := []campaigns.CampaignSpecStep{{Run: "echo bar > README.md", Container: "alpine:3"}}
+	diff, outputs, err := e.runSteps(context.Background(), "github.com/sourcegraph/sourcegraph", "d34db33f", steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := string(diff), "the diff"; have != want {
+		t.Fatalf("wrong diff. want=%q, have=%q", want, have)
+	}
+	if diff := cmp.Diff([]string{"the output\n"}, outputs); diff != "" {
+		t.Fatalf("wrong outputs: %s", diff)
+	}
+
+	wantCommands := []string{
+		"git -C " + dir + " init",
+		"git -C " + dir + " -c protocol.version=2 fetch http://gitserver/git/github.com/sourcegraph/sourcegraph d34db33f",
+		"git -C " + dir + " checkout d34db33f",
+		"docker run --rm -v " + dir + ":/work -w /work --entrypoint /bin/sh alpine:3 -c echo bar > README.md",
+		"git -C " + dir + " add --all",
+		"git -C " + dir + " diff --cached --no-prefix --binary",
+	}
+	if diff := cmp.Diff(wantCommands, commands); diff != "" {
+		t.Fatal(diff)
+	}
+
+	t.Run("image not allowed", func(t *testing.T) {
+		commands = nil
+		steps := []campaigns.CampaignSpecStep{{Run: "true", Container: "ubuntu:20.04"}}
+		if _, _, err := e.runSteps(context.Background(), "github.com/sourcegraph/sourcegraph", "d34db33f", steps); err == nil {
+			t.Fatal("no error for image that's not allowed")
+		}
+		if len(commands) != 0 {
+			t.Fatalf("commands run for image that's not allowed: %v", commands)
+		}
+	})
+}
@@ -0,0 +1,288 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// exportBatchSize is the number of changesets loaded from the database at a
+// time while streaming an export.
+const exportBatchSize = 500
+
+// ChangesetExportRow is a single changeset in an export of a campaign's
+// changesets.
+type ChangesetExportRow struct {
+	Repository  string `json:"repository"`
+	ExternalID  string `json:"externalID"`
+	State       string `json:"state"`
+	ReviewState string `json:"reviewState"`
+	CheckState  string `json:"checkState"`
+	URL         string `json:"url"`
+}
+
+var changesetExportCSVHeader = []string{"repository", "external_id", "state", "review_state", "check_state", "url"}
+
+func (r *ChangesetExportRow) csvRecord() []string {
+	return []string{r.Repository, r.ExternalID, r.State, r.ReviewState, r.CheckState, r.URL}
+}
+
+// newChangesetExportRow returns the export row of the given changeset in the
+// repository with the given name.
+func newChangesetExportRow(c *campaigns.Changeset, repoName string) (*ChangesetExportRow, error) {
+	row := &ChangesetExportRow{Repository: repoName}
+
+	if c.PublicationState.Unpublished() {
+		row.State = string(c.PublicationState)
+		return row, nil
+	}
+
+	url, err := c.URL()
+	if err != nil {
+		return nil, err
+	}
+
+	row.ExternalID = c.ExternalID
+	row.State = string(c.ExternalState)
+	row.ReviewState = string(c.ExternalReviewState)
+	if c.ExternalCheckState != campaigns.ChangesetCheckStateUnknown {
+		row.CheckState = string(c.ExternalCheckState)
+	}
+	row.URL = url
+
+	return row, nil
+}
+
+// changesetExportWriter writes ChangesetExportRows in a specific format.
+type changesetExportWriter interface {
+	Write(*ChangesetExportRow) error
+	Close() error
+}
+
+type csvChangesetExportWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (e *csvChangesetExportWriter) Write(row *ChangesetExportRow) error {
+	if !e.wroteHeader {
+		if err := e.w.Write(changesetExportCSVHeader); err != nil {
+			return err
+		}
+		e.wroteHeader = true
+	}
+	return e.w.Write(row.csvRecord())
+}
+
+func (e *csvChangesetExportWriter) Close() error {
+	if !e.wroteHeader {
+		if err := e.w.Write(changesetExportCSVHeader); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonChangesetExportWriter writes a JSON array of rows without buffering
+// the complete array in memory.
+type jsonChangesetExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonChangesetExportWriter) Write(row *ChangesetExportRow) error {
+	delim := ","
+	if e.count == 0 {
+		delim = "["
+	}
+
+	bs, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(e.w, delim); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(bs); err != nil {
+		return err
+	}
+
+	e.count++
+	return nil
+}
+
+func (e *jsonChangesetExportWriter) Close() error {
+	closing := "]\n"
+	if e.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
+
+// ChangesetsExportHandler is an http.Handler that streams all changesets of
+// a campaign as CSV or JSON.
+//
+// It expects to be registered on a route with the mux variables "id", the
+// GraphQL ID of the campaign, and "format", which is either "csv" or "json".
+type ChangesetsExportHandler struct {
+	Store *Store
+}
+
+// NewChangesetsExportHandler returns a new ChangesetsExportHandler that
+// reads changesets from the given Store.
+func NewChangesetsExportHandler(store *Store) *ChangesetsExportHandler {
+	return &ChangesetsExportHandler{Store: store}
+}
+
+func (h *ChangesetsExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	campaign, ok := exportedCampaign(w, r, h.Store)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	var ew changesetExportWriter
+	switch format := vars["format"]; format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		ew = &csvChangesetExportWriter{w: csv.NewWriter(w)}
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		ew = &jsonChangesetExportWriter{w: w}
+	default:
+		respond(w, http.StatusBadRequest, fmt.Errorf("unsupported export format %q", format))
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("campaign-%d-changesets.%s", campaign.ID, vars["format"])))
+	w.WriteHeader(http.StatusOK)
+
+	// Once the header has been written we can no longer report an error to
+	// the client with a status code, so we only log it.
+	if err := h.export(ctx, campaign.ID, ew); err != nil {
+		log15.Error("exporting campaign changesets", "campaign", campaign.ID, "error", err)
+		return
+	}
+
+	if err := ew.Close(); err != nil {
+		log15.Error("finishing campaign changesets export", "campaign", campaign.ID, "error", err)
+	}
+}
+
+// exportedCampaign returns the campaign identified by the "id" mux variable of
+// the request, after checking that the current user may export data of
+// campaigns and can see the campaign. If it returns false, an error has been
+// written to w.
+func exportedCampaign(w http.ResponseWriter, r *http.Request, store *Store) (*campaigns.Campaign, bool) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Exports are only available to authenticated users.
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		respond(w, http.StatusUnauthorized, errors.New("authentication required"))
+		return nil, false
+	}
+
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may
+	// access changesets.
+	if !conf.CampaignsReadAccessEnabled() {
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+			respond(w, http.StatusForbidden, err)
+			return nil, false
+		}
+	}
+
+	campaignID, err := campaigns.UnmarshalCampaignID(graphql.ID(mux.Vars(r)["id"]))
+	if err != nil || campaignID == 0 {
+		respond(w, http.StatusBadRequest, errors.New("invalid campaign ID"))
+		return nil, false
+	}
+
+	campaign, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		if err == ErrNoResults {
+			respond(w, http.StatusNotFound, errors.New("campaign not found"))
+			return nil, false
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	// 🚨 SECURITY: Campaigns that are only visible in their namespace are
+	// reported as not found to users who can't see them.
+	visible, err := CampaignVisible(ctx, store, campaign)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	if !visible {
+		respond(w, http.StatusNotFound, errors.New("campaign not found"))
+		return nil, false
+	}
+
+	return campaign, true
+}
+
+// export writes all changesets of the campaign with the given ID to ew in
+// batches of exportBatchSize.
+func (h *ChangesetsExportHandler) export(ctx context.Context, campaignID int64, ew changesetExportWriter) error {
+	opts := ListChangesetsOpts{
+		CampaignID:     campaignID,
+		WithoutDeleted: true,
+		Limit:          exportBatchSize,
+	}
+
+	for {
+		cs, next, err := h.Store.ListChangesets(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under
+		// the hood and filters out repositories that the user doesn't have
+		// access to. Changesets in those repositories are left out of the
+		// export.
+		accessibleRepos, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cs {
+			repo, ok := accessibleRepos[c.RepoID]
+			if !ok {
+				continue
+			}
+
+			row, err := newChangesetExportRow(c, string(repo.Name))
+			if err != nil {
+				return errors.Wrapf(err, "exporting changeset %d", c.ID)
+			}
+
+			if err := ew.Write(row); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
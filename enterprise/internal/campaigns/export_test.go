@@ -0,0 +1,227 @@
+package campaigns
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewChangesetExportRow(t *testing.T) {
+	tests := []struct {
+		name      string
+		changeset *campaigns.Changeset
+		want      *ChangesetExportRow
+	}{
+		{
+			name: "unpublished",
+			changeset: &campaigns.Changeset{
+				PublicationState: campaigns.ChangesetPublicationStateUnpublished,
+			},
+			want: &ChangesetExportRow{
+				Repository: "github.com/sourcegraph/sourcegraph",
+				State:      "UNPUBLISHED",
+			},
+		},
+		{
+			name: "published",
+			changeset: &campaigns.Changeset{
+				PublicationState:    campaigns.ChangesetPublicationStatePublished,
+				ExternalID:          "12345",
+				ExternalState:       campaigns.ChangesetExternalStateOpen,
+				ExternalReviewState: campaigns.ChangesetReviewStateApproved,
+				ExternalCheckState:  campaigns.ChangesetCheckStatePassed,
+				Metadata:            &github.PullRequest{URL: "https://github.com/sourcegraph/sourcegraph/pull/12345"},
+			},
+			want: &ChangesetExportRow{
+				Repository:  "github.com/sourcegraph/sourcegraph",
+				ExternalID:  "12345",
+				State:       "OPEN",
+				ReviewState: "APPROVED",
+				CheckState:  "PASSED",
+				URL:         "https://github.com/sourcegraph/sourcegraph/pull/12345",
+			},
+		},
+		{
+			name: "unknown check state",
+			changeset: &campaigns.Changeset{
+				PublicationState:    campaigns.ChangesetPublicationStatePublished,
+				ExternalID:          "1",
+				ExternalState:       campaigns.ChangesetExternalStateMerged,
+				ExternalReviewState: campaigns.ChangesetReviewStatePending,
+				ExternalCheckState:  campaigns.ChangesetCheckStateUnknown,
+				Metadata:            &github.PullRequest{URL: "https://github.com/sourcegraph/sourcegraph/pull/1"},
+			},
+			want: &ChangesetExportRow{
+				Repository:  "github.com/sourcegraph/sourcegraph",
+				ExternalID:  "1",
+				State:       "MERGED",
+				ReviewState: "PENDING",
+				URL:         "https://github.com/sourcegraph/sourcegraph/pull/1",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := newChangesetExportRow(tc.changeset, "github.com/sourcegraph/sourcegraph")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong row (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChangesetExportWriters(t *testing.T) {
+	rows := []*ChangesetExportRow{
+		{Repository: "a", State: "UNPUBLISHED"},
+		{Repository: "b", ExternalID: "2", State: "OPEN", ReviewState: "PENDING", URL: "https://example.com/b/2"},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := &csvChangesetExportWriter{w: csv.NewWriter(&buf)}
+		for _, r := range rows {
+			if err := w.Write(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "repository,external_id,state,review_state,check_state,url\n" +
+			"a,,UNPUBLISHED,,,\n" +
+			"b,2,OPEN,PENDING,,https://example.com/b/2\n"
+		if have := buf.String(); have != want {
+			t.Fatalf("wrong CSV (-want +have):\n%s", cmp.Diff(want, have))
+		}
+	})
+
+	t.Run("CSV without rows", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := &csvChangesetExportWriter{w: csv.NewWriter(&buf)}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "repository,external_id,state,review_state,check_state,url\n"
+		if have := buf.String(); have != want {
+			t.Fatalf("wrong CSV (-want +have):\n%s", cmp.Diff(want, have))
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := &jsonChangesetExportWriter{w: &buf}
+		for _, r := range rows {
+			if err := w.Write(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := `[{"repository":"a","externalID":"","state":"UNPUBLISHED","reviewState":"","checkState":"","url":""},` +
+			`{"repository":"b","externalID":"2","state":"OPEN","reviewState":"PENDING","checkState":"","url":"https://example.com/b/2"}]` + "\n"
+		if have := buf.String(); have != want {
+			t.Fatalf("wrong JSON (-want +have):\n%s", cmp.Diff(want, have))
+		}
+	})
+
+	t.Run("JSON without rows", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := &jsonChangesetExportWriter{w: &buf}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := buf.String(), "[]\n"; have != want {
+			t.Fatalf("wrong JSON: have %q, want %q", have, want)
+		}
+	})
+}
+
+func TestChangesetsExportHandlerVisibility(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	store := NewStore(dbconn.Global)
+
+	campaign, member, outsider := createNamespaceOnlyCampaign(t, store)
+
+	router := mux.NewRouter()
+	router.Path("/campaigns/{id}/changesets.{format}").Handler(NewChangesetsExportHandler(store))
+
+	for _, tc := range []struct {
+		name       string
+		userID     int32
+		wantStatus int
+	}{
+		{name: "namespace member", userID: member, wantStatus: http.StatusOK},
+		{name: "outsider", userID: outsider, wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serveCampaignRequest(router, tc.userID, "/campaigns/"+string(campaigns.MarshalCampaignID(campaign.ID))+"/changesets.csv")
+			if have, want := rec.Code, tc.wantStatus; have != want {
+				t.Fatalf("wrong status. want=%d, have=%d (body: %q)", want, have, rec.Body.String())
+			}
+		})
+	}
+}
+
+// createNamespaceOnlyCampaign creates a campaign that's only visible in the
+// namespace of its creator, with campaigns read access enabled. It returns
+// the campaign, the ID of its creator and the ID of a user who isn't a site
+// admin and can't see it.
+func createNamespaceOnlyCampaign(t *testing.T, store *Store) (campaign *campaigns.Campaign, member, outsider int32) {
+	t.Helper()
+
+	ctx := backend.WithAuthzBypass(context.Background())
+
+	readAccess := true
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{CampaignsReadAccessEnabled: &readAccess}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	// The first user is a site admin.
+	_ = createTestUser(ctx, t)
+	member = createTestUser(ctx, t).ID
+	outsider = createTestUser(ctx, t).ID
+
+	campaign = testCampaign(member)
+	campaign.Visibility = campaigns.CampaignVisibilityNamespaceOnly
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	return campaign, member, outsider
+}
+
+// serveCampaignRequest sends a GET request to the given path on the router as
+// the user with the given ID.
+func serveCampaignRequest(router http.Handler, userID int32, path string) *httptest.ResponseRecorder {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(userID))
+	req := httptest.NewRequest("GET", path, nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
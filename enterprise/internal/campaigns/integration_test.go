@@ -29,6 +29,21 @@ func TestIntegration(t *testing.T) {
 		t.Run("ListChangesetSyncData", storeTest(db, testStoreListChangesetSyncData))
 		t.Run("CampaignSpecs", storeTest(db, testStoreCampaignSpecs))
 		t.Run("ChangesetSpecs", storeTest(db, testStoreChangesetSpecs))
+		t.Run("CampaignActivities", storeTest(db, testStoreCampaignActivities))
+		t.Run("CampaignComments", storeTest(db, testStoreCampaignComments))
+		t.Run("ChangesetDiffStatJobs", storeTest(db, testStoreChangesetDiffStatJobs))
+		t.Run("ChangesetJobs", storeTest(db, testStoreChangesetJobs))
+		t.Run("CampaignTemplates", storeTest(db, testStoreCampaignTemplates))
+		t.Run("CampaignReapplySchedules", storeTest(db, testStoreCampaignReapplySchedules))
+		t.Run("CampaignNotifications", storeTest(db, testStoreCampaignNotifications))
+		t.Run("CampaignWebhookDeliveries", storeTest(db, testStoreCampaignWebhookDeliveries))
+		t.Run("CampaignSpecExecutions", storeTest(db, testStoreCampaignSpecExecutions))
+		t.Run("UserCredentials", storeTest(db, testStoreUserCredentials))
+		t.Run("CampaignPermissionGrants", storeTest(db, testStoreCampaignPermissionGrants))
+		t.Run("CampaignsStatistics", storeTest(db, testStoreCampaignsStatistics))
+		t.Run("CampaignProgress", storeTest(db, testStoreCampaignProgress))
+		t.Run("CampaignChangesetCounts", storeTest(db, testStoreCampaignChangesetCounts))
+		t.Run("SpecExpirationRuns", storeTest(db, testStoreSpecExpirationRuns))
 	})
 
 	t.Run("GitHubWebhook", testGitHubWebhook(db, userID))
@@ -0,0 +1,376 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/segmentio/fasthash/fnv1"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// Campaigns work that must not run on more than one replica at a time is
+// coordinated through Postgres advisory locks. Every lock is taken in one of
+// two namespaces: the leader namespace contains one key per background job
+// that only runs on a single replica, the syncer namespace contains one key
+// per code host, which partitions changeset syncing between the replicas.
+var (
+	leaderLockNamespace = lockKey("campaigns_leader")
+	syncerLockNamespace = lockKey("campaigns_syncer")
+)
+
+// Transactions that check the open campaigns quota of a user or organization
+// namespace serialize on a transaction-level advisory lock keyed by the
+// namespace's ID. Users and organizations have separate ID sequences, so each
+// has its own lock namespace.
+var (
+	userNamespaceLockNamespace = lockKey("campaigns_user_namespace")
+	orgNamespaceLockNamespace  = lockKey("campaigns_org_namespace")
+)
+
+// The background jobs of which only a single instance runs across all
+// replicas.
+const (
+	LeaderJobReconciler = "reconciler"
+	LeaderJobAutoMerger = "auto-merger"
+	LeaderJobSpecExpiry = "spec-expiry"
+
+	LeaderJobReapplyScheduler = "reapply-scheduler"
+	LeaderJobNotifier         = "notifier"
+	LeaderJobWebhookDeliverer = "webhook-deliverer"
+	LeaderJobSpecExecutor     = "spec-executor"
+	LeaderJobAutoRebaser      = "auto-rebaser"
+	LeaderJobURLChecker       = "url-checker"
+	LeaderJobEventsCompactor  = "events-compactor"
+)
+
+var leaderJobs = []string{
+	LeaderJobReconciler,
+	LeaderJobAutoMerger,
+	LeaderJobSpecExpiry,
+	LeaderJobReapplyScheduler,
+	LeaderJobNotifier,
+	LeaderJobWebhookDeliverer,
+	LeaderJobSpecExecutor,
+	LeaderJobAutoRebaser,
+	LeaderJobURLChecker,
+	LeaderJobEventsCompactor,
+}
+
+// lockCheckInterval is how often a replica checks whether it still holds a
+// lock while running the guarded work, and how often it tries to acquire a
+// lock that's held by another replica.
+var lockCheckInterval = 30 * time.Second
+
+// lockKey returns the key of the advisory lock for the given name. Keys are
+// non-negative, so that they're the same when read back from pg_locks, which
+// reports them as unsigned oids.
+func lockKey(name string) int32 {
+	return int32(fnv1.HashString32(name) & math.MaxInt32)
+}
+
+// A Locker takes session-level advisory locks on behalf of a replica. All
+// locks are taken on a single, dedicated database connection, so that they
+// are released by Postgres if the replica goes away. The connection's
+// application_name identifies the replica holding the locks.
+type Locker struct {
+	db     *sql.DB
+	holder string
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// NewLocker returns a Locker that takes advisory locks on connections to the
+// given database, identifying the replica by its process and host name.
+func NewLocker(db *sql.DB) *Locker {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return &Locker{db: db, holder: fmt.Sprintf("%s@%s", env.MyName, hostname)}
+}
+
+// DoAsLeader runs workFn while this replica holds the lock for the given
+// leader job. workFn is restarted whenever it returns while the lock is
+// still held, and the context passed to it is canceled as soon as the lock is
+// lost. If l is nil, workFn is run without any coordination.
+func (l *Locker) DoAsLeader(ctx context.Context, job string, workFn func(ctx context.Context)) {
+	l.doWhileLocked(ctx, leaderLockNamespace, lockKey(job), workFn)
+}
+
+// DoForCodeHost runs workFn while this replica holds the syncer shard of the
+// code host with the given normalised URL. See DoAsLeader for the semantics.
+func (l *Locker) DoForCodeHost(ctx context.Context, codeHostURL string, workFn func(ctx context.Context)) {
+	l.doWhileLocked(ctx, syncerLockNamespace, lockKey(codeHostURL), workFn)
+}
+
+func (l *Locker) doWhileLocked(parentCtx context.Context, namespace, key int32, workFn func(ctx context.Context)) {
+	if l == nil {
+		workFn(parentCtx)
+		return
+	}
+
+	for {
+		if parentCtx.Err() != nil {
+			return
+		}
+
+		ok, err := l.tryLock(parentCtx, namespace, key)
+		if err != nil {
+			log15.Error("Acquiring campaigns advisory lock", "namespace", namespace, "key", key, "err", err)
+		}
+		if !ok {
+			select {
+			case <-parentCtx.Done():
+				return
+			case <-time.After(jitter(lockCheckInterval)):
+			}
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(parentCtx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			workFn(ctx)
+		}()
+
+		l.waitUntilLost(ctx, namespace, key, done)
+		cancel()
+		<-done
+
+		if err := l.unlock(context.Background(), namespace, key); err != nil {
+			log15.Error("Releasing campaigns advisory lock", "namespace", namespace, "key", key, "err", err)
+		}
+	}
+}
+
+// waitUntilLost blocks until the lock is no longer held by this replica, the
+// context is canceled or done is closed.
+func (l *Locker) waitUntilLost(ctx context.Context, namespace, key int32, done <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-time.After(lockCheckInterval):
+		}
+
+		held, err := l.held(ctx, namespace, key)
+		if err != nil {
+			log15.Error("Checking campaigns advisory lock", "namespace", namespace, "key", key, "err", err)
+		}
+		if !held {
+			return
+		}
+	}
+}
+
+// tryLock attempts to take the advisory lock on the given key without
+// blocking. Advisory locks are reentrant, so callers must not call tryLock
+// again for a lock they already hold.
+func (l *Locker) tryLock(ctx context.Context, namespace, key int32) (locked bool, err error) {
+	err = l.withConn(ctx, func(conn *sql.Conn) error {
+		return conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1, $2)`, namespace, key).Scan(&locked)
+	})
+	return locked, err
+}
+
+// held reports whether the advisory lock on the given key is held by this
+// Locker's connection.
+func (l *Locker) held(ctx context.Context, namespace, key int32) (held bool, err error) {
+	err = l.withConn(ctx, func(conn *sql.Conn) error {
+		return conn.QueryRowContext(ctx, heldLockQuery, namespace, key).Scan(&held)
+	})
+	return held, err
+}
+
+const heldLockQuery = `
+-- source: enterprise/internal/campaigns/lock.go:held
+SELECT EXISTS (
+  SELECT 1 FROM pg_locks
+  WHERE locktype = 'advisory'
+  AND granted
+  AND pid = pg_backend_pid()
+  AND classid = $1
+  AND objid = $2
+  AND objsubid = 2
+)
+`
+
+// unlock releases the advisory lock on the given key.
+func (l *Locker) unlock(ctx context.Context, namespace, key int32) error {
+	return l.withConn(ctx, func(conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1, $2)`, namespace, key)
+		return err
+	})
+}
+
+// withConn calls fn with the Locker's dedicated connection, opening it if
+// necessary. If fn fails for any other reason than the context being
+// canceled, the connection is closed, which releases all locks taken on it.
+// Holders notice that on their next check and stop their work.
+func (l *Locker) withConn(ctx context.Context, fn func(*sql.Conn) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		conn, err := l.db.Conn(ctx)
+		if err != nil {
+			return errors.Wrap(err, "opening connection")
+		}
+
+		_, err = conn.ExecContext(ctx, `SELECT set_config('application_name', $1, false)`, l.holder)
+		if err != nil {
+			conn.Close()
+			return errors.Wrap(err, "setting application_name")
+		}
+
+		l.conn = conn
+	}
+
+	err := fn(l.conn)
+	if err != nil && ctx.Err() == nil {
+		l.conn.Close()
+		l.conn = nil
+	}
+	return err
+}
+
+// jitter returns the base duration increased by a random amount of up to 25%.
+func jitter(base time.Duration) time.Duration {
+	return base + time.Duration(rand.Int63n(int64(base/4)+1))
+}
+
+// LockCampaignNamespace takes the advisory lock of the given campaign
+// namespace, blocking until it's available. The lock is released when the
+// transaction ends, so that counting the campaigns in the namespace and
+// creating or reopening one can't interleave with another transaction doing
+// the same. It returns an error if s isn't in a transaction.
+func (s *Store) LockCampaignNamespace(ctx context.Context, namespaceUserID, namespaceOrgID int32) error {
+	if !s.InTransaction() {
+		return errors.New("locking campaign namespace outside of a transaction")
+	}
+
+	namespace, key := userNamespaceLockNamespace, namespaceUserID
+	if namespaceOrgID != 0 {
+		namespace, key = orgNamespaceLockNamespace, namespaceOrgID
+	}
+	return s.Exec(ctx, sqlf.Sprintf(lockCampaignNamespaceQueryFmtstr, namespace, key))
+}
+
+var lockCampaignNamespaceQueryFmtstr = `
+-- source: enterprise/internal/campaigns/lock.go:LockCampaignNamespace
+SELECT pg_advisory_xact_lock(%s, %s)
+`
+
+// AdvisoryLock is a campaigns advisory lock held by a replica.
+type AdvisoryLock struct {
+	// Kind is either "leader" or "syncer".
+	Kind string
+	// Name is the name of the leader job or the URL of the code host whose
+	// changesets are synced by the holder. It's empty if the code host
+	// couldn't be determined.
+	Name string
+	// Holder identifies the replica holding the lock.
+	Holder string
+	// PID is the process ID of the database backend holding the lock.
+	PID int32
+	// ConnectedAt is the time at which the holder's connection was opened.
+	ConnectedAt time.Time
+}
+
+// ListAdvisoryLocks lists the campaigns advisory locks that are currently
+// held, which tells which replica runs which leader job and syncs which code
+// host.
+func (s *Store) ListAdvisoryLocks(ctx context.Context) ([]*AdvisoryLock, error) {
+	names := make(map[int32]string)
+	for _, job := range leaderJobs {
+		names[lockKey(job)] = job
+	}
+
+	codeHosts, err := s.listCodeHostURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range codeHosts {
+		names[lockKey(u)] = u
+	}
+
+	q := sqlf.Sprintf(listAdvisoryLocksQueryFmtstr, leaderLockNamespace, syncerLockNamespace)
+
+	var locks []*AdvisoryLock
+	err = s.query(ctx, q, func(sc scanner) error {
+		var (
+			namespace, key int64
+			l              AdvisoryLock
+		)
+		if err := sc.Scan(&namespace, &key, &l.Holder, &l.PID, &l.ConnectedAt); err != nil {
+			return err
+		}
+
+		l.Kind = "syncer"
+		if int32(namespace) == leaderLockNamespace {
+			l.Kind = "leader"
+		}
+		l.Name = names[int32(key)]
+		l.ConnectedAt = l.ConnectedAt.UTC()
+
+		locks = append(locks, &l)
+		return nil
+	})
+	return locks, err
+}
+
+var listAdvisoryLocksQueryFmtstr = `
+-- source: enterprise/internal/campaigns/lock.go:ListAdvisoryLocks
+SELECT
+  l.classid::bigint,
+  l.objid::bigint,
+  a.application_name,
+  a.pid,
+  a.backend_start
+FROM pg_locks l
+JOIN pg_stat_activity a ON a.pid = l.pid
+WHERE
+  l.locktype = 'advisory'
+  AND l.granted
+  AND l.objsubid = 2
+  AND l.classid::bigint IN (%s, %s)
+ORDER BY l.classid, a.application_name, l.objid
+`
+
+// listCodeHostURLs returns the normalised URLs of the code hosts of all
+// external services supported by campaigns.
+func (s *Store) listCodeHostURLs(ctx context.Context) ([]string, error) {
+	q := sqlf.Sprintf("SELECT kind, config FROM external_services WHERE deleted_at IS NULL")
+
+	var urls []string
+	err := s.query(ctx, q, func(sc scanner) error {
+		var kind, config string
+		if err := sc.Scan(&kind, &config); err != nil {
+			return err
+		}
+
+		u, err := extsvc.ExtractBaseURL(kind, config)
+		if err != nil {
+			// Don't fail the whole listing because of one broken config.
+			log15.Warn("Extracting base URL of external service", "kind", kind, "err", err)
+			return nil
+		}
+		urls = append(urls, u.String())
+		return nil
+	})
+	return urls, err
+}
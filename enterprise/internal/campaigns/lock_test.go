@@ -0,0 +1,185 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestLockerDoAsLeader(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+
+	defer func(interval time.Duration) { lockCheckInterval = interval }(lockCheckInterval)
+	lockCheckInterval = 10 * time.Millisecond
+
+	ctx := context.Background()
+	store := NewStore(dbconn.Global)
+
+	first := &Locker{db: dbconn.Global, holder: "first"}
+	second := &Locker{db: dbconn.Global, holder: "second"}
+
+	firstCtx, cancelFirst := context.WithCancel(ctx)
+	firstStarted := make(chan struct{})
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		first.DoAsLeader(firstCtx, LeaderJobReconciler, func(ctx context.Context) {
+			close(firstStarted)
+			<-ctx.Done()
+		})
+	}()
+	<-firstStarted
+
+	locks, err := store.ListAdvisoryLocks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 {
+		t.Fatalf("wrong number of locks. want=1, have=%d", len(locks))
+	}
+	if have, want := *locks[0], (AdvisoryLock{Kind: "leader", Name: LeaderJobReconciler, Holder: "first", PID: locks[0].PID, ConnectedAt: locks[0].ConnectedAt}); have != want {
+		t.Fatalf("wrong lock. want=%+v, have=%+v", want, have)
+	}
+
+	secondCtx, cancelSecond := context.WithCancel(ctx)
+	defer cancelSecond()
+	secondStarted := make(chan struct{})
+	go second.DoAsLeader(secondCtx, LeaderJobReconciler, func(ctx context.Context) {
+		close(secondStarted)
+		<-ctx.Done()
+	})
+
+	select {
+	case <-secondStarted:
+		t.Fatal("second locker ran job while the first one is the leader")
+	case <-time.After(10 * lockCheckInterval):
+	}
+
+	// Once the leader stops, the other replica takes over.
+	cancelFirst()
+	<-firstDone
+
+	select {
+	case <-secondStarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("second locker didn't take over the job")
+	}
+}
+
+func TestLockerLosingLockCancelsWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+
+	defer func(interval time.Duration) { lockCheckInterval = interval }(lockCheckInterval)
+	lockCheckInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := &Locker{db: dbconn.Global, holder: "test"}
+
+	started := make(chan struct{}, 2)
+	canceled := make(chan struct{})
+	go l.DoForCodeHost(ctx, "https://github.com/", func(ctx context.Context) {
+		started <- struct{}{}
+		<-ctx.Done()
+		select {
+		case <-canceled:
+		default:
+			close(canceled)
+		}
+	})
+	<-started
+
+	// Closing the connection releases all locks held on it, as if the
+	// connection to the database was lost.
+	l.mu.Lock()
+	l.conn.Close()
+	l.mu.Unlock()
+
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("work wasn't canceled after the lock was lost")
+	}
+
+	// The lock is reacquired on a new connection and the work restarted.
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("work wasn't restarted after the lock was reacquired")
+	}
+}
+
+func TestStoreLockCampaignNamespace(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+
+	ctx := context.Background()
+	store := NewStore(dbconn.Global)
+
+	if err := store.LockCampaignNamespace(ctx, 1, 0); err == nil {
+		t.Fatal("locking outside of a transaction didn't fail")
+	}
+
+	first, err := store.Transact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := first.LockCampaignNamespace(ctx, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// An organization with the same ID is a different namespace.
+	other, err := store.Transact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.LockCampaignNamespace(ctx, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Done(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	locked := make(chan error, 1)
+	go func() {
+		second, err := store.Transact(ctx)
+		if err != nil {
+			locked <- err
+			return
+		}
+		err = second.LockCampaignNamespace(ctx, 1, 0)
+		locked <- second.Done(err)
+	}()
+
+	select {
+	case err := <-locked:
+		t.Fatalf("second transaction locked namespace while the first one holds it: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The lock is released when the transaction ends.
+	if err := first.Done(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-locked:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second transaction didn't lock namespace after the first one ended")
+	}
+}
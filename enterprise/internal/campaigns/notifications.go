@@ -0,0 +1,375 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+// notifierInterval is the time between two passes of the notifier.
+const notifierInterval = 1 * time.Minute
+
+// canSendNotificationEmails and sendNotificationEmail are variables so that
+// they can be mocked in tests.
+var (
+	canSendNotificationEmails = conf.CanSendEmail
+	sendNotificationEmail     = func(ctx context.Context, msg txtypes.Message) error {
+		return api.InternalClient.SendEmail(ctx, msg)
+	}
+)
+
+// RunNotifier periodically emails the authors and subscribers of open
+// campaigns about the events they're notified about. It runs until the given
+// context is canceled. If locker is not nil, notifications are only sent by
+// the replica that's the leader of the notifier job.
+func RunNotifier(ctx context.Context, s *Store, locker *Locker) {
+	n := &notifier{store: s}
+	locker.DoAsLeader(ctx, LeaderJobNotifier, n.loop)
+}
+
+type notifier struct {
+	store *Store
+}
+
+func (n *notifier) loop(ctx context.Context) {
+	for {
+		if err := n.run(ctx); err != nil {
+			log15.Error("Sending campaign notifications", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(notifierInterval):
+		}
+	}
+}
+
+// run sends the notifications that are due for all open campaigns. Nothing
+// is sent, or recorded as sent, while email isn't configured.
+func (n *notifier) run(ctx context.Context) error {
+	if !canSendNotificationEmails() {
+		return nil
+	}
+
+	errs := &multierror.Error{}
+	opts := ListCampaignsOpts{State: campaigns.CampaignStateOpen}
+	for {
+		cs, next, err := n.store.ListCampaigns(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "listing open campaigns")
+		}
+
+		for _, c := range cs {
+			if err := n.notifyCampaign(ctx, c); err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "notifying about campaign %d", c.ID))
+			}
+		}
+
+		if next == 0 {
+			return errs.ErrorOrNil()
+		}
+		opts.Cursor = next
+	}
+}
+
+// notifyCampaign sends the notifications that are due for the given
+// campaign. Every notification is recorded before it's sent, so that it's
+// sent at most once.
+func (n *notifier) notifyCampaign(ctx context.Context, c *campaigns.Campaign) error {
+	settings, err := n.store.GetCampaignNotificationSettings(ctx, c.ID)
+	if err != nil {
+		if err != ErrNoResults {
+			return err
+		}
+		settings = campaigns.DefaultCampaignNotificationSettings(c.ID)
+	}
+	if len(settings.Events) == 0 {
+		return nil
+	}
+
+	cs, _, err := n.store.ListChangesets(ctx, ListChangesetsOpts{CampaignID: c.ID, Limit: -1})
+	if err != nil {
+		return err
+	}
+
+	due := dueCampaignNotifications(c, cs, settings)
+	if len(due) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*campaigns.Changeset, len(cs))
+	for _, ch := range cs {
+		byID[ch.ID] = ch
+	}
+
+	errs := &multierror.Error{}
+	for _, notification := range due {
+		if err := n.store.CreateCampaignNotification(ctx, notification); err != nil {
+			return err
+		}
+		// The notification has already been sent.
+		if notification.ID == 0 {
+			continue
+		}
+
+		if err := n.send(ctx, c, settings, notification, byID[notification.ChangesetID]); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// dueCampaignNotifications returns the notifications about the current state
+// of the given campaign and its changesets that the settings ask for,
+// regardless of whether they have already been sent.
+func dueCampaignNotifications(c *campaigns.Campaign, cs campaigns.Changesets, settings *campaigns.CampaignNotificationSettings) (due []*campaigns.CampaignNotification) {
+	var owned, published, merged, finished int
+	for _, ch := range cs {
+		if ch.OwnedByCampaignID == c.ID {
+			owned++
+			if ch.PublicationState.Published() {
+				published++
+			} else if ch.ReconcilerState == campaigns.ReconcilerStateErrored && settings.Notifies(campaigns.CampaignNotificationEventPublicationFailed) {
+				due = append(due, &campaigns.CampaignNotification{
+					CampaignID:  c.ID,
+					ChangesetID: ch.ID,
+					Event:       campaigns.CampaignNotificationEventPublicationFailed,
+				})
+			}
+		}
+
+		switch ch.ExternalState {
+		case campaigns.ChangesetExternalStateMerged:
+			merged++
+			finished++
+		case campaigns.ChangesetExternalStateClosed, campaigns.ChangesetExternalStateDeleted:
+			finished++
+		}
+	}
+
+	if owned > 0 && published == owned && settings.Notifies(campaigns.CampaignNotificationEventAllPublished) {
+		due = append(due, &campaigns.CampaignNotification{
+			CampaignID: c.ID,
+			Event:      campaigns.CampaignNotificationEventAllPublished,
+		})
+	}
+
+	if merged > 0 && finished == len(cs) && settings.Notifies(campaigns.CampaignNotificationEventLastMerged) {
+		due = append(due, &campaigns.CampaignNotification{
+			CampaignID: c.ID,
+			Event:      campaigns.CampaignNotificationEventLastMerged,
+		})
+	}
+
+	return due
+}
+
+// send emails the given notification to the author and the subscribers of
+// the campaign. changeset is only set for notifications about a single
+// changeset.
+func (n *notifier) send(ctx context.Context, c *campaigns.Campaign, settings *campaigns.CampaignNotificationSettings, notification *campaigns.CampaignNotification, changeset *campaigns.Changeset) error {
+	url, err := campaignExternalURL(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		CampaignName   string
+		CampaignURL    string
+		RepoName       string
+		FailureMessage string
+	}{
+		CampaignName: c.Name,
+		CampaignURL:  url,
+	}
+
+	var template txtypes.Templates
+	switch notification.Event {
+	case campaigns.CampaignNotificationEventAllPublished:
+		template = allPublishedEmailTemplate
+	case campaigns.CampaignNotificationEventLastMerged:
+		template = lastMergedEmailTemplate
+	case campaigns.CampaignNotificationEventPublicationFailed:
+		template = publicationFailedEmailTemplate
+		if changeset != nil {
+			if changeset.FailureMessage != nil {
+				data.FailureMessage = *changeset.FailureMessage
+			}
+			if data.RepoName, err = n.repoName(ctx, changeset.RepoID); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("unknown campaign notification event %q", notification.Event)
+	}
+
+	return sendCampaignEmail(ctx, n.store, c, settings, template, data)
+}
+
+// sendCampaignEmail emails the given template, rendered with the given data,
+// to the author and the subscribers of the campaign that can see it.
+func sendCampaignEmail(ctx context.Context, s *Store, c *campaigns.Campaign, settings *campaigns.CampaignNotificationSettings, template txtypes.Templates, data interface{}) error {
+	errs := &multierror.Error{}
+	for _, userID := range notificationRecipients(c, settings) {
+		// 🚨 SECURITY: Subscribers that can't see the campaign (anymore)
+		// aren't notified about it.
+		visible, err := CampaignVisible(actor.WithActor(ctx, actor.FromUser(userID)), s, c)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if !visible {
+			continue
+		}
+
+		email, verified, err := db.UserEmails.GetPrimaryEmail(ctx, userID)
+		if err != nil {
+			if !errcode.IsNotFound(err) {
+				errs = multierror.Append(errs, err)
+			}
+			continue
+		}
+		// Users without a verified email address can't be notified.
+		if !verified {
+			continue
+		}
+
+		err = sendNotificationEmail(ctx, txtypes.Message{
+			To:       []string{email},
+			Template: template,
+			Data:     data,
+		})
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "sending email to user %d", userID))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (n *notifier) repoName(ctx context.Context, id api.RepoID) (string, error) {
+	reposStore := repos.NewDBStore(n.store.DB(), sql.TxOptions{})
+	rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{IDs: []api.RepoID{id}})
+	if err != nil {
+		return "", err
+	}
+	if len(rs) != 1 {
+		return "", errors.Errorf("repo not found: %d", id)
+	}
+	return rs[0].Name, nil
+}
+
+// notificationRecipients returns the IDs of the users that are notified about
+// the events of the given campaign: its author and its subscribers.
+func notificationRecipients(c *campaigns.Campaign, settings *campaigns.CampaignNotificationSettings) []int32 {
+	recipients := []int32{c.InitialApplierID}
+	seen := map[int32]bool{c.InitialApplierID: true}
+	for _, id := range settings.SubscriberIDs {
+		if !seen[id] {
+			seen[id] = true
+			recipients = append(recipients, id)
+		}
+	}
+	return recipients
+}
+
+// campaignExternalURL returns the absolute URL of the given campaign.
+func campaignExternalURL(ctx context.Context, c *campaigns.Campaign) (string, error) {
+	var namespaceURL string
+	if c.NamespaceUserID != 0 {
+		user, err := db.Users.GetByID(ctx, c.NamespaceUserID)
+		if err != nil {
+			return "", err
+		}
+		namespaceURL = "/users/" + user.Username
+	} else {
+		org, err := db.Orgs.GetByID(ctx, c.NamespaceOrgID)
+		if err != nil {
+			return "", err
+		}
+		namespaceURL = "/organizations/" + org.Name
+	}
+
+	externalURL := strings.TrimSuffix(conf.Get().ExternalURL, "/")
+	return externalURL + namespaceURL + "/campaigns/" + string(campaigns.MarshalCampaignID(c.ID)), nil
+}
+
+var allPublishedEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `[Campaign] All changesets of {{.CampaignName}} have been published`,
+	Text: `
+All changesets of the campaign "{{.CampaignName}}" have been published on their code hosts.
+
+View the campaign on Sourcegraph: {{.CampaignURL}}
+`,
+	HTML: `
+<p>All changesets of the campaign <strong>{{.CampaignName}}</strong> have been published on their code hosts.</p>
+
+<p><a href="{{.CampaignURL}}">View the campaign on Sourcegraph</a></p>
+`,
+})
+
+var publicationFailedEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `[Campaign] Publishing a changeset of {{.CampaignName}} failed`,
+	Text: `
+A changeset of the campaign "{{.CampaignName}}" in {{.RepoName}} could not be published:
+
+  {{.FailureMessage}}
+
+View the campaign on Sourcegraph: {{.CampaignURL}}
+`,
+	HTML: `
+<p>A changeset of the campaign <strong>{{.CampaignName}}</strong> in <code>{{.RepoName}}</code> could not be published:</p>
+
+<pre>{{.FailureMessage}}</pre>
+
+<p><a href="{{.CampaignURL}}">View the campaign on Sourcegraph</a></p>
+`,
+})
+
+var lastMergedEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `[Campaign] The last changeset of {{.CampaignName}} has been merged`,
+	Text: `
+The last open changeset of the campaign "{{.CampaignName}}" has been merged.
+
+View the campaign on Sourcegraph: {{.CampaignURL}}
+`,
+	HTML: `
+<p>The last open changeset of the campaign <strong>{{.CampaignName}}</strong> has been merged.</p>
+
+<p><a href="{{.CampaignURL}}">View the campaign on Sourcegraph</a></p>
+`,
+})
+
+var triggeredEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `[Campaign] {{.Source}} triggered {{.CampaignName}}`,
+	Text: `
+{{.Source}} triggered the campaign "{{.CampaignName}}".
+{{if .Message}}
+  {{.Message}}
+{{end}}
+View the campaign on Sourcegraph: {{.CampaignURL}}
+`,
+	HTML: `
+<p>{{.Source}} triggered the campaign <strong>{{.CampaignName}}</strong>.</p>
+{{if .Message}}
+<pre>{{.Message}}</pre>
+{{end}}
+<p><a href="{{.CampaignURL}}">View the campaign on Sourcegraph</a></p>
+`,
+})
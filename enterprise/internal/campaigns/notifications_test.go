@@ -0,0 +1,212 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+func TestDueCampaignNotifications(t *testing.T) {
+	campaign := &campaigns.Campaign{ID: 1}
+
+	owned := func(id int64, publication campaigns.ChangesetPublicationState, reconciler campaigns.ReconcilerState, external campaigns.ChangesetExternalState) *campaigns.Changeset {
+		return &campaigns.Changeset{
+			ID:                id,
+			OwnedByCampaignID: campaign.ID,
+			PublicationState:  publication,
+			ReconcilerState:   reconciler,
+			ExternalState:     external,
+		}
+	}
+	imported := func(id int64, external campaigns.ChangesetExternalState) *campaigns.Changeset {
+		return &campaigns.Changeset{
+			ID:               id,
+			PublicationState: campaigns.ChangesetPublicationStatePublished,
+			ReconcilerState:  campaigns.ReconcilerStateCompleted,
+			ExternalState:    external,
+		}
+	}
+
+	var (
+		unpublished = campaigns.ChangesetPublicationStateUnpublished
+		published   = campaigns.ChangesetPublicationStatePublished
+		queued      = campaigns.ReconcilerStateQueued
+		errored     = campaigns.ReconcilerStateErrored
+		completed   = campaigns.ReconcilerStateCompleted
+		open        = campaigns.ChangesetExternalStateOpen
+		merged      = campaigns.ChangesetExternalStateMerged
+		closed      = campaigns.ChangesetExternalStateClosed
+	)
+
+	allPublished := &campaigns.CampaignNotification{CampaignID: campaign.ID, Event: campaigns.CampaignNotificationEventAllPublished}
+	lastMerged := &campaigns.CampaignNotification{CampaignID: campaign.ID, Event: campaigns.CampaignNotificationEventLastMerged}
+	failed := func(id int64) *campaigns.CampaignNotification {
+		return &campaigns.CampaignNotification{CampaignID: campaign.ID, ChangesetID: id, Event: campaigns.CampaignNotificationEventPublicationFailed}
+	}
+
+	tests := []struct {
+		name       string
+		changesets campaigns.Changesets
+		events     []campaigns.CampaignNotificationEvent
+		want       []*campaigns.CampaignNotification
+	}{
+		{
+			name: "no changesets",
+		},
+		{
+			name: "publishing",
+			changesets: campaigns.Changesets{
+				owned(1, published, completed, open),
+				owned(2, unpublished, queued, ""),
+			},
+		},
+		{
+			name: "all published",
+			changesets: campaigns.Changesets{
+				owned(1, published, completed, open),
+				owned(2, published, completed, merged),
+			},
+			want: []*campaigns.CampaignNotification{allPublished},
+		},
+		{
+			name: "publication failed",
+			changesets: campaigns.Changesets{
+				owned(1, published, completed, open),
+				owned(2, unpublished, errored, ""),
+				owned(3, unpublished, errored, ""),
+			},
+			want: []*campaigns.CampaignNotification{failed(2), failed(3)},
+		},
+		{
+			name: "last merged",
+			changesets: campaigns.Changesets{
+				owned(1, published, completed, merged),
+				owned(2, published, completed, closed),
+				imported(3, merged),
+			},
+			want: []*campaigns.CampaignNotification{allPublished, lastMerged},
+		},
+		{
+			name: "imported changeset still open",
+			changesets: campaigns.Changesets{
+				owned(1, published, completed, merged),
+				imported(2, open),
+			},
+			want: []*campaigns.CampaignNotification{allPublished},
+		},
+		{
+			name: "only imported changesets",
+			changesets: campaigns.Changesets{
+				imported(1, merged),
+			},
+			want: []*campaigns.CampaignNotification{lastMerged},
+		},
+		{
+			name: "events not notified",
+			changesets: campaigns.Changesets{
+				owned(1, published, completed, merged),
+				owned(2, unpublished, errored, ""),
+			},
+			events: []campaigns.CampaignNotificationEvent{campaigns.CampaignNotificationEventAllPublished},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := campaigns.DefaultCampaignNotificationSettings(campaign.ID)
+			if tc.events != nil {
+				settings.Events = tc.events
+			}
+
+			have := dueCampaignNotifications(campaign, tc.changesets, settings)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong notifications (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotifierRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	subscriber := createTestUser(ctx, t)
+	rs, _ := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	db.Mocks.UserEmails.GetPrimaryEmail = func(ctx context.Context, id int32) (string, bool, error) {
+		return "user@example.com", true, nil
+	}
+	defer func() { db.Mocks.UserEmails.GetPrimaryEmail = nil }()
+
+	defer func(f func() bool) { canSendNotificationEmails = f }(canSendNotificationEmails)
+	canSendNotificationEmails = func() bool { return true }
+
+	var sent []txtypes.Message
+	defer func(f func(context.Context, txtypes.Message) error) { sendNotificationEmail = f }(sendNotificationEmail)
+	sendNotificationEmail = func(ctx context.Context, msg txtypes.Message) error {
+		sent = append(sent, msg)
+		return nil
+	}
+
+	campaign := testCampaign(admin.ID)
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	err := store.UpsertCampaignNotificationSettings(ctx, &campaigns.CampaignNotificationSettings{
+		CampaignID:    campaign.ID,
+		Events:        []campaigns.CampaignNotificationEvent{campaigns.CampaignNotificationEventLastMerged},
+		SubscriberIDs: []int32{subscriber.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateMerged)
+	c.OwnedByCampaignID = campaign.ID
+	c.PublicationState = campaigns.ChangesetPublicationStatePublished
+	c.ReconcilerState = campaigns.ReconcilerStateCompleted
+	if err := store.CreateChangeset(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	n := &notifier{store: store}
+	for i := 0; i < 2; i++ {
+		if err := n.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The author and the subscriber are notified once.
+	if len(sent) != 2 {
+		t.Fatalf("wrong number of emails sent. want=2, have=%d", len(sent))
+	}
+	if have, want := sent[0].Template, lastMergedEmailTemplate; have != want {
+		t.Fatalf("wrong email template. want=%+v, have=%+v", want, have)
+	}
+
+	notifications, err := store.ListCampaignNotifications(ctx, ListCampaignNotificationsOpts{CampaignID: campaign.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 1 || notifications[0].Event != campaigns.CampaignNotificationEventLastMerged {
+		t.Fatalf("wrong notifications recorded: %+v", notifications)
+	}
+}
@@ -0,0 +1,283 @@
+package campaigns
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// campaignsWebhooks returns the outgoing webhook endpoints configured in the
+// campaigns.webhooks site configuration setting. It's a variable so that it
+// can be mocked in tests.
+var campaignsWebhooks = func() []*schema.CampaignsWebhook {
+	return conf.Get().CampaignsWebhooks
+}
+
+// webhookEventPayload is the JSON body POSTed to outgoing webhook endpoints.
+// Campaign is set for all events, Changeset only for changeset events.
+type webhookEventPayload struct {
+	Event     campaigns.CampaignWebhookEvent `json:"event"`
+	Timestamp time.Time                      `json:"timestamp"`
+	Campaign  *webhookCampaign               `json:"campaign,omitempty"`
+	Changeset *webhookChangeset              `json:"changeset,omitempty"`
+}
+
+type webhookCampaign struct {
+	ID       graphql.ID `json:"id"`
+	Name     string     `json:"name"`
+	ClosedAt *time.Time `json:"closedAt"`
+}
+
+type webhookChangeset struct {
+	ID                    graphql.ID                       `json:"id"`
+	RepositoryID          graphql.ID                       `json:"repositoryID"`
+	ExternalID            string                           `json:"externalID"`
+	ExternalState         campaigns.ChangesetExternalState `json:"externalState"`
+	PreviousExternalState campaigns.ChangesetExternalState `json:"previousExternalState,omitempty"`
+	CampaignIDs           []graphql.ID                     `json:"campaignIDs"`
+}
+
+func newWebhookCampaign(c *campaigns.Campaign) *webhookCampaign {
+	wc := &webhookCampaign{ID: campaigns.MarshalCampaignID(c.ID), Name: c.Name}
+	if c.Closed() {
+		closedAt := c.ClosedAt
+		wc.ClosedAt = &closedAt
+	}
+	return wc
+}
+
+func newWebhookChangeset(c *campaigns.Changeset, previous campaigns.ChangesetExternalState) *webhookChangeset {
+	wc := &webhookChangeset{
+		ID:                    relay.MarshalID("Changeset", c.ID),
+		RepositoryID:          relay.MarshalID("Repository", c.RepoID),
+		ExternalID:            c.ExternalID,
+		ExternalState:         c.ExternalState,
+		PreviousExternalState: previous,
+		CampaignIDs:           make([]graphql.ID, 0, len(c.CampaignIDs)),
+	}
+	for _, id := range c.CampaignIDs {
+		wc.CampaignIDs = append(wc.CampaignIDs, campaigns.MarshalCampaignID(id))
+	}
+	return wc
+}
+
+// enqueueCampaignWebhookEvent enqueues a delivery of the given campaign event
+// to every configured endpoint that receives it.
+func enqueueCampaignWebhookEvent(ctx context.Context, tx *Store, event campaigns.CampaignWebhookEvent, c *campaigns.Campaign) error {
+	return enqueueWebhookEvent(ctx, tx, &webhookEventPayload{
+		Event:    event,
+		Campaign: newWebhookCampaign(c),
+	})
+}
+
+// enqueueChangesetWebhookEvent enqueues a delivery of the given changeset
+// event to every configured endpoint that receives it. previous is the
+// external state of the changeset before the event, if it had one.
+func enqueueChangesetWebhookEvent(ctx context.Context, tx *Store, event campaigns.CampaignWebhookEvent, c *campaigns.Changeset, previous campaigns.ChangesetExternalState) error {
+	return enqueueWebhookEvent(ctx, tx, &webhookEventPayload{
+		Event:     event,
+		Changeset: newWebhookChangeset(c, previous),
+	})
+}
+
+// changesetStateChanged returns true if the external state of the changeset
+// changed since its previous external state was computed. The first time the
+// state is computed, right after publishing or importing the changeset,
+// doesn't count as a change.
+func changesetStateChanged(previous campaigns.ChangesetExternalState, c *campaigns.Changeset) bool {
+	return previous != "" && previous != c.ExternalState
+}
+
+func enqueueWebhookEvent(ctx context.Context, tx *Store, payload *webhookEventPayload) error {
+	var endpoints []*schema.CampaignsWebhook
+	for _, w := range campaignsWebhooks() {
+		if webhookReceives(w, payload.Event) {
+			endpoints = append(endpoints, w)
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	payload.Timestamp = tx.now()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range endpoints {
+		err := tx.CreateCampaignWebhookDelivery(ctx, &campaigns.CampaignWebhookDelivery{
+			URL:     w.Url,
+			Event:   payload.Event,
+			Payload: body,
+		})
+		if err != nil {
+			return errors.Wrap(err, "enqueueing webhook delivery")
+		}
+	}
+
+	return nil
+}
+
+func webhookReceives(w *schema.CampaignsWebhook, event campaigns.CampaignWebhookEvent) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if campaigns.CampaignWebhookEvent(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// webhookDelivererInterval is the time between two passes of the webhook
+	// deliverer.
+	webhookDelivererInterval = 10 * time.Second
+
+	// webhookMaxAttempts is how often the delivery of a payload is attempted
+	// before it's given up.
+	webhookMaxAttempts = 5
+)
+
+// RunWebhookDeliverer periodically POSTs the enqueued webhook payloads to
+// their endpoints. Failed deliveries are retried with an exponential backoff.
+// It runs until the given context is canceled. If locker is not nil, payloads
+// are only delivered by the replica that's the leader of the webhook
+// deliverer job.
+func RunWebhookDeliverer(ctx context.Context, s *Store, cf *httpcli.Factory, locker *Locker) {
+	cli, err := cf.Doer()
+	if err != nil {
+		log15.Error("Creating HTTP client for campaign webhooks", "err", err)
+		return
+	}
+
+	d := &webhookDeliverer{store: s, cli: cli}
+	locker.DoAsLeader(ctx, LeaderJobWebhookDeliverer, d.loop)
+}
+
+type webhookDeliverer struct {
+	store *Store
+	cli   httpcli.Doer
+}
+
+func (d *webhookDeliverer) loop(ctx context.Context) {
+	for {
+		if err := d.run(ctx); err != nil {
+			log15.Error("Delivering campaign webhooks", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(webhookDelivererInterval):
+		}
+	}
+}
+
+// run attempts to deliver all due payloads once.
+func (d *webhookDeliverer) run(ctx context.Context) error {
+	deliveries, err := d.store.ListDueCampaignWebhookDeliveries(ctx, ListDueCampaignWebhookDeliveriesOpts{
+		MaxAttempts: webhookMaxAttempts,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing due webhook deliveries")
+	}
+
+	secrets := make(map[string]string)
+	for _, w := range campaignsWebhooks() {
+		secrets[w.Url] = w.Secret
+	}
+
+	errs := &multierror.Error{}
+	for _, delivery := range deliveries {
+		secret, ok := secrets[delivery.URL]
+		if !ok {
+			// The endpoint has been removed from the configuration since
+			// the payload was enqueued.
+			if err := d.store.DeleteCampaignWebhookDelivery(ctx, delivery.ID); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+			continue
+		}
+
+		delivery.Attempts++
+		if err := d.deliver(ctx, delivery, secret); err != nil {
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = d.store.now().Add(webhookRetryBackoff(delivery.Attempts))
+		} else {
+			delivery.LastError = ""
+			delivery.DeliveredAt = d.store.now()
+		}
+
+		if err := d.store.UpdateCampaignWebhookDelivery(ctx, delivery); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// webhookRetryBackoff returns how long to wait before the next attempt after
+// the given number of failed attempts: 1, 2, 4, 8... minutes.
+func webhookRetryBackoff(attempts int32) time.Duration {
+	return time.Minute << uint(attempts-1)
+}
+
+// deliver POSTs the payload of the given delivery, signed with the given
+// secret. Responses with a status other than 2xx are errors.
+func (d *webhookDeliverer) deliver(ctx context.Context, delivery *campaigns.CampaignWebhookDelivery, secret string) error {
+	req, err := http.NewRequest("POST", delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sourcegraph-Campaigns-Webhook")
+	req.Header.Set("X-Sourcegraph-Event", string(delivery.Event))
+	req.Header.Set("X-Sourcegraph-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Sourcegraph-Signature", signWebhookPayload(delivery.Payload, secret))
+
+	resp, err := d.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
+
+// signWebhookPayload returns the hex-encoded HMAC-SHA256 of the payload,
+// keyed with the secret of the endpoint.
+func signWebhookPayload(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
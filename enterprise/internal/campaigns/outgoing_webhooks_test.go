@@ -0,0 +1,195 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestWebhookReceives(t *testing.T) {
+	all := &schema.CampaignsWebhook{Url: "https://example.com"}
+	closed := &schema.CampaignsWebhook{Url: "https://example.com", Events: []string{"campaign.closed"}}
+
+	for _, tc := range []struct {
+		webhook *schema.CampaignsWebhook
+		event   campaigns.CampaignWebhookEvent
+		want    bool
+	}{
+		{all, campaigns.CampaignWebhookEventCampaignApplied, true},
+		{all, campaigns.CampaignWebhookEventChangesetStateChanged, true},
+		{closed, campaigns.CampaignWebhookEventCampaignClosed, true},
+		{closed, campaigns.CampaignWebhookEventCampaignApplied, false},
+	} {
+		if have := webhookReceives(tc.webhook, tc.event); have != tc.want {
+			t.Errorf("webhookReceives(%v, %q): want=%t, have=%t", tc.webhook.Events, tc.event, tc.want, have)
+		}
+	}
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	for attempts, want := range map[int32]time.Duration{
+		1: time.Minute,
+		2: 2 * time.Minute,
+		4: 8 * time.Minute,
+	} {
+		if have := webhookRetryBackoff(attempts); have != want {
+			t.Errorf("attempts %d: want=%s, have=%s", attempts, want, have)
+		}
+	}
+}
+
+func TestWebhookDelivererDeliver(t *testing.T) {
+	payload := []byte(`{"event": "campaign.closed"}`)
+
+	var (
+		status = http.StatusOK
+		header http.Header
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	d := &webhookDeliverer{cli: srv.Client()}
+	delivery := &campaigns.CampaignWebhookDelivery{
+		ID:      42,
+		URL:     srv.URL,
+		Event:   campaigns.CampaignWebhookEventCampaignClosed,
+		Payload: payload,
+	}
+
+	if err := d.deliver(context.Background(), delivery, "s3cr3t"); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != string(payload) {
+		t.Fatalf("wrong body. want=%s, have=%s", payload, body)
+	}
+	for name, want := range map[string]string{
+		"Content-Type":            "application/json",
+		"X-Sourcegraph-Event":     "campaign.closed",
+		"X-Sourcegraph-Delivery":  "42",
+		"X-Sourcegraph-Signature": signWebhookPayload(payload, "s3cr3t"),
+	} {
+		if have := header.Get(name); have != want {
+			t.Errorf("wrong %s header. want=%q, have=%q", name, want, have)
+		}
+	}
+
+	status = http.StatusInternalServerError
+	if err := d.deliver(context.Background(), delivery, "s3cr3t"); err == nil {
+		t.Fatal("no error for failed delivery")
+	}
+}
+
+func TestWebhookDelivererRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	var received []webhookEventPayload
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p webhookEventPayload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Error(err)
+		}
+		received = append(received, p)
+	}))
+	defer ok.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	defer func(f func() []*schema.CampaignsWebhook) { campaignsWebhooks = f }(campaignsWebhooks)
+	campaignsWebhooks = func() []*schema.CampaignsWebhook {
+		return []*schema.CampaignsWebhook{
+			{Url: ok.URL, Secret: "ok"},
+			{Url: failing.URL, Secret: "failing"},
+			{Url: "https://example.com", Secret: "other", Events: []string{"changeset.published"}},
+		}
+	}
+
+	campaign := &campaigns.Campaign{ID: 5, Name: "test-campaign"}
+	if err := enqueueCampaignWebhookEvent(ctx, store, campaigns.CampaignWebhookEventCampaignApplied, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the endpoints that receive the event get a delivery.
+	deliveries, err := store.ListCampaignWebhookDeliveries(ctx, ListCampaignWebhookDeliveriesOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("wrong number of deliveries. want=2, have=%d", len(deliveries))
+	}
+
+	d := &webhookDeliverer{store: store, cli: http.DefaultClient}
+	if err := d.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("wrong number of received payloads. want=1, have=%d", len(received))
+	}
+	if have, want := received[0].Campaign.ID, campaigns.MarshalCampaignID(campaign.ID); have != want {
+		t.Fatalf("wrong campaign ID. want=%q, have=%q", want, have)
+	}
+
+	deliveries, err = store.ListCampaignWebhookDeliveries(ctx, ListCampaignWebhookDeliveriesOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, delivery := range deliveries {
+		switch delivery.URL {
+		case ok.URL:
+			if delivery.DeliveredAt.IsZero() {
+				t.Fatal("delivery not marked as delivered")
+			}
+		case failing.URL:
+			if !delivery.DeliveredAt.IsZero() || delivery.LastError == "" {
+				t.Fatalf("failed delivery not recorded: %+v", delivery)
+			}
+			if want := now.Add(time.Minute); !delivery.NextAttemptAt.Equal(want) {
+				t.Fatalf("wrong next attempt. want=%s, have=%s", want, delivery.NextAttemptAt)
+			}
+		}
+	}
+
+	// Deliveries to endpoints that have been removed from the configuration
+	// are dropped.
+	campaignsWebhooks = func() []*schema.CampaignsWebhook { return nil }
+	now = now.Add(time.Minute)
+	if err := d.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, err = store.ListCampaignWebhookDeliveries(ctx, ListCampaignWebhookDeliveriesOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].URL != ok.URL {
+		t.Fatalf("wrong deliveries left: %+v", deliveries)
+	}
+}
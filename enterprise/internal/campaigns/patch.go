@@ -0,0 +1,191 @@
+package campaigns
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// campaignPatchWriter writes the diffs of the changeset specs of a campaign in
+// a specific format.
+type campaignPatchWriter interface {
+	Write(repo api.RepoName, spec *campaigns.ChangesetSpec, diff string) error
+	Close() error
+}
+
+// diffCampaignPatchWriter concatenates the diffs into one unified patch. Each
+// diff is preceded by comment lines naming its repository and branch, which
+// tools like git apply and patch ignore.
+type diffCampaignPatchWriter struct {
+	w io.Writer
+}
+
+func (p *diffCampaignPatchWriter) Write(repo api.RepoName, spec *campaigns.ChangesetSpec, diff string) error {
+	if _, err := fmt.Fprintf(p.w, "# Repository: %s\n# Branch: %s\n", repo, campaignPatchBranch(spec)); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(diff, "\n") {
+		diff += "\n"
+	}
+	_, err := io.WriteString(p.w, diff)
+	return err
+}
+
+func (p *diffCampaignPatchWriter) Close() error { return nil }
+
+// tarCampaignPatchWriter writes a gzipped tarball with one patch per
+// repository and branch, named "{repository}/{branch}.patch".
+type tarCampaignPatchWriter struct {
+	gz  *gzip.Writer
+	tw  *tar.Writer
+	now time.Time
+}
+
+func newTarCampaignPatchWriter(w io.Writer, now time.Time) *tarCampaignPatchWriter {
+	gz := gzip.NewWriter(w)
+	return &tarCampaignPatchWriter{gz: gz, tw: tar.NewWriter(gz), now: now}
+}
+
+func (p *tarCampaignPatchWriter) Write(repo api.RepoName, spec *campaigns.ChangesetSpec, diff string) error {
+	err := p.tw.WriteHeader(&tar.Header{
+		Name:    path.Join(string(repo), campaignPatchBranch(spec)) + ".patch",
+		Mode:    0644,
+		Size:    int64(len(diff)),
+		ModTime: p.now,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(p.tw, diff)
+	return err
+}
+
+func (p *tarCampaignPatchWriter) Close() error {
+	if err := p.tw.Close(); err != nil {
+		return err
+	}
+	return p.gz.Close()
+}
+
+func campaignPatchBranch(spec *campaigns.ChangesetSpec) string {
+	return strings.TrimPrefix(spec.Spec.HeadRef, "refs/heads/")
+}
+
+// CampaignPatchHandler is an http.Handler that streams the diffs of all
+// changeset specs of a campaign, either as one unified patch or as a gzipped
+// tarball of one patch per repository and branch.
+//
+// It expects to be registered on a route with the mux variables "id", the
+// GraphQL ID of the campaign, and "format", which is either "diff" or
+// "tar.gz".
+type CampaignPatchHandler struct {
+	Store *Store
+}
+
+// NewCampaignPatchHandler returns a new CampaignPatchHandler that reads
+// changeset specs from the given Store.
+func NewCampaignPatchHandler(store *Store) *CampaignPatchHandler {
+	return &CampaignPatchHandler{Store: store}
+}
+
+func (h *CampaignPatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	campaign, ok := exportedCampaign(w, r, h.Store)
+	if !ok {
+		return
+	}
+
+	var pw campaignPatchWriter
+	var filename string
+	switch format := mux.Vars(r)["format"]; format {
+	case "diff":
+		w.Header().Set("Content-Type", "text/x-diff; charset=utf-8")
+		pw = &diffCampaignPatchWriter{w: w}
+		filename = fmt.Sprintf("campaign-%d.patch", campaign.ID)
+	case "tar.gz":
+		w.Header().Set("Content-Type", "application/gzip")
+		pw = newTarCampaignPatchWriter(w, h.Store.Clock()())
+		filename = fmt.Sprintf("campaign-%d-patches.tar.gz", campaign.ID)
+	default:
+		respond(w, http.StatusBadRequest, fmt.Errorf("unsupported patch format %q", format))
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// Once the header has been written we can no longer report an error to
+	// the client with a status code, so we only log it.
+	if err := h.write(ctx, campaign.CampaignSpecID, pw); err != nil {
+		log15.Error("writing campaign patch", "campaign", campaign.ID, "error", err)
+		return
+	}
+
+	if err := pw.Close(); err != nil {
+		log15.Error("finishing campaign patch", "campaign", campaign.ID, "error", err)
+	}
+}
+
+// write writes the diffs of all changeset specs of the campaign spec with the
+// given ID to pw in batches of exportBatchSize.
+func (h *CampaignPatchHandler) write(ctx context.Context, campaignSpecID int64, pw campaignPatchWriter) error {
+	opts := ListChangesetSpecsOpts{
+		CampaignSpecID: campaignSpecID,
+		Limit:          exportBatchSize,
+	}
+
+	for {
+		specs, next, err := h.Store.ListChangesetSpecs(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under
+		// the hood and filters out repositories that the user doesn't have
+		// access to. Changeset specs in those repositories are left out of
+		// the patch.
+		accessibleRepos, err := db.Repos.GetReposSetByIDs(ctx, specs.RepoIDs()...)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range specs {
+			repo, ok := accessibleRepos[spec.RepoID]
+			if !ok {
+				continue
+			}
+
+			// Specs of changesets that are only imported have no diff.
+			if !spec.Spec.IsBranch() {
+				continue
+			}
+
+			diff, err := spec.Spec.Diff()
+			if err != nil {
+				return errors.Wrapf(err, "getting diff of changeset spec %d", spec.ID)
+			}
+
+			if err := pw.Write(repo.Name, spec, diff); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
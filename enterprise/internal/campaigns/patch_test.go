@@ -0,0 +1,125 @@
+package campaigns
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestCampaignPatchWriters(t *testing.T) {
+	specs := []*campaigns.ChangesetSpec{
+		{Spec: &campaigns.ChangesetSpecDescription{HeadRef: "refs/heads/replace-foo"}},
+		{Spec: &campaigns.ChangesetSpecDescription{HeadRef: "replace-foo"}},
+	}
+	repos := []string{"github.com/sourcegraph/sourcegraph", "github.com/sourcegraph/src-cli"}
+	diffs := []string{
+		"--- README.md\n+++ README.md\n@@ -1 +1 @@\n-foo\n+bar\n",
+		// No trailing newline.
+		"--- main.go\n+++ main.go\n@@ -1 +1 @@\n-foo\n+bar",
+	}
+
+	t.Run("diff", func(t *testing.T) {
+		var buf bytes.Buffer
+		pw := &diffCampaignPatchWriter{w: &buf}
+		for i, spec := range specs {
+			if err := pw.Write(api.RepoName(repos[i]), spec, diffs[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := pw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "# Repository: github.com/sourcegraph/sourcegraph\n# Branch: replace-foo\n" + diffs[0] +
+			"# Repository: github.com/sourcegraph/src-cli\n# Branch: replace-foo\n" + diffs[1] + "\n"
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("tar.gz", func(t *testing.T) {
+		now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+		var buf bytes.Buffer
+		pw := newTarCampaignPatchWriter(&buf, now)
+		for i, spec := range specs {
+			if err := pw.Write(api.RepoName(repos[i]), spec, diffs[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := pw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		gz, err := gzip.NewReader(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr := tar.NewReader(gz)
+
+		have := map[string]string{}
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				break
+			}
+			if !hdr.ModTime.Equal(now) {
+				t.Errorf("wrong mod time of %s. want=%s, have=%s", hdr.Name, now, hdr.ModTime)
+			}
+			content, err := ioutil.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have[hdr.Name] = string(content)
+		}
+
+		want := map[string]string{
+			"github.com/sourcegraph/sourcegraph/replace-foo.patch": diffs[0],
+			"github.com/sourcegraph/src-cli/replace-foo.patch":     diffs[1],
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestCampaignPatchHandlerVisibility(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	store := NewStore(dbconn.Global)
+
+	campaign, member, outsider := createNamespaceOnlyCampaign(t, store)
+
+	router := mux.NewRouter()
+	router.Path("/campaigns/{id}/patch.{format}").Handler(NewCampaignPatchHandler(store))
+
+	for _, tc := range []struct {
+		name       string
+		userID     int32
+		wantStatus int
+	}{
+		{name: "namespace member", userID: member, wantStatus: http.StatusOK},
+		{name: "outsider", userID: outsider, wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serveCampaignRequest(router, tc.userID, "/campaigns/"+string(campaigns.MarshalCampaignID(campaign.ID))+"/patch.diff")
+			if have, want := rec.Code, tc.wantStatus; have != want {
+				t.Fatalf("wrong status. want=%d, have=%d (body: %q)", want, have, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// ChangesetSpecPreview describes what applying the CampaignSpec of a
+// ChangesetSpec does to the changeset that already exists for the
+// ChangesetSpec in the campaign the CampaignSpec applies to.
+type ChangesetSpecPreview struct {
+	// Changeset is the changeset that's updated by the ChangesetSpec.
+	Changeset *campaigns.Changeset
+	// CurrentSpec is the ChangesetSpec the changeset currently has.
+	CurrentSpec *campaigns.ChangesetSpec
+	// Spec is the ChangesetSpec that's previewed.
+	Spec *campaigns.ChangesetSpec
+
+	Delta *ChangesetSpecDelta
+}
+
+// DiffOfDiffs returns the unified diff between the diff of the current spec
+// of the changeset and the diff of the previewed spec, or an empty string if
+// the diff doesn't change.
+func (p *ChangesetSpecPreview) DiffOfDiffs() (string, error) {
+	if !p.Delta.DiffChanged {
+		return "", nil
+	}
+
+	current, err := p.CurrentSpec.Spec.Diff()
+	if err != nil {
+		return "", err
+	}
+	previewed, err := p.Spec.Spec.Diff()
+	if err != nil {
+		return "", err
+	}
+	return diffOfDiffs(current, previewed), nil
+}
+
+// PreviewChangesetSpec returns the ChangesetSpecPreview of the given
+// ChangesetSpec. It returns nil if applying the spec creates a new changeset,
+// because the campaign the spec applies to doesn't exist yet, isn't visible
+// to the current user, or has no changeset with the same repository and head
+// ref.
+func (s *Service) PreviewChangesetSpec(ctx context.Context, spec *campaigns.ChangesetSpec) (preview *ChangesetSpecPreview, err error) {
+	tr, ctx := trace.New(ctx, "Service.PreviewChangesetSpec", fmt.Sprintf("ChangesetSpec %d", spec.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// Changesets that are imported are only tracked and never updated.
+	if !spec.Spec.IsBranch() {
+		return nil, nil
+	}
+
+	campaignSpec, err := s.store.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: spec.CampaignSpecID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting campaign spec")
+	}
+
+	campaign, err := s.GetCampaignMatchingCampaignSpec(ctx, s.store, campaignSpec)
+	if err != nil || campaign == nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: The changesets of campaigns that aren't visible to the
+	// current user must not be revealed.
+	visible, err := CampaignVisible(ctx, s.store, campaign)
+	if err != nil || !visible {
+		return nil, err
+	}
+
+	changesets, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID: campaign.ID,
+		Limit:      -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Like ApplyCampaign, match the changeset that has the same repository
+	// and head ref.
+	headRef := git.EnsureRefPrefix(spec.Spec.HeadRef)
+	for _, c := range changesets {
+		if c.RepoID != spec.RepoID || c.CurrentSpecID == 0 {
+			continue
+		}
+
+		current, err := s.store.GetChangesetSpecByID(ctx, c.CurrentSpecID)
+		if err != nil {
+			return nil, err
+		}
+
+		ref := c.ExternalBranch
+		if ref == "" {
+			ref = current.Spec.HeadRef
+		}
+		if git.EnsureRefPrefix(ref) != headRef {
+			continue
+		}
+
+		delta, err := CompareChangesetSpecs(current, spec)
+		if err != nil {
+			return nil, err
+		}
+		return &ChangesetSpecPreview{
+			Changeset:   c,
+			CurrentSpec: current,
+			Spec:        spec,
+			Delta:       delta,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// CampaignSpecDiff describes what applying a CampaignSpec changes in the
+// Campaign it applies to, compared to the CampaignSpec that's currently
+// applied to the Campaign.
+//
+// ChangesetSpecs and Changesets in repositories that the current user doesn't
+// have access to are left out.
+type CampaignSpecDiff struct {
+	// Campaign is the Campaign the CampaignSpec applies to. It's nil if
+	// applying the CampaignSpec creates a new Campaign.
+	Campaign *campaigns.Campaign
+
+	// Added are the ChangesetSpecs for which a new changeset is created or
+	// an existing changeset is imported into the Campaign.
+	Added []*campaigns.ChangesetSpec
+	// Updated are the previews of the ChangesetSpecs that change a
+	// changeset of the Campaign.
+	Updated []*ChangesetSpecPreview
+	// Unchanged are the ChangesetSpecs whose changeset is already in the
+	// Campaign and stays the same.
+	Unchanged []*campaigns.ChangesetSpec
+	// Closed are the changesets that the Campaign created on their code host
+	// and that are closed, because the CampaignSpec doesn't contain them
+	// anymore.
+	Closed campaigns.Changesets
+	// Detached are the changesets that are removed from the Campaign without
+	// being closed, because the CampaignSpec doesn't contain them anymore.
+	Detached campaigns.Changesets
+
+	// Repos are the repositories of the ChangesetSpecs and Changesets above.
+	Repos map[api.RepoID]*types.Repo
+}
+
+// DiffCampaignSpec returns the CampaignSpecDiff of the given CampaignSpec. It
+// matches the ChangesetSpecs of the CampaignSpec with the changesets of the
+// Campaign in the same way ApplyCampaign does, without changing anything.
+func (s *Service) DiffCampaignSpec(ctx context.Context, spec *campaigns.CampaignSpec) (diff *CampaignSpecDiff, err error) {
+	tr, ctx := trace.New(ctx, "Service.DiffCampaignSpec", fmt.Sprintf("CampaignSpec %d", spec.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaign, err := s.GetCampaignMatchingCampaignSpec(ctx, s.store, spec)
+	if err != nil {
+		return nil, err
+	}
+	if campaign != nil {
+		// 🚨 SECURITY: Campaigns that aren't visible to the current user are
+		// treated as if they didn't exist.
+		visible, err := CampaignVisible(ctx, s.store, campaign)
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			campaign = nil
+		}
+	}
+
+	specs, _, err := s.store.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{
+		CampaignSpecID: spec.ID,
+		Limit:          -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var changesets campaigns.Changesets
+	if campaign != nil {
+		changesets, _, err = s.store.ListChangesets(ctx, ListChangesetsOpts{
+			CampaignID: campaign.ID,
+			Limit:      -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	repos, err := db.Repos.GetReposSetByIDs(ctx, append(specs.RepoIDs(), changesets.RepoIDs()...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := indexCampaignChangesets(ctx, s.store, changesets)
+	if err != nil {
+		return nil, err
+	}
+
+	diff = &CampaignSpecDiff{Campaign: campaign, Repos: repos}
+	attached := map[int64]bool{}
+	for _, spec := range specs {
+		if _, ok := repos[spec.RepoID]; !ok {
+			continue
+		}
+
+		if spec.Spec.IsImportingExisting() {
+			k := repoExternalID{repo: spec.RepoID, externalID: spec.Spec.ExternalID}
+			if c, ok := index.byRepoExternalID[k]; ok {
+				attached[c.ID] = true
+				diff.Unchanged = append(diff.Unchanged, spec)
+			} else {
+				diff.Added = append(diff.Added, spec)
+			}
+			continue
+		}
+
+		k := repoHeadRef{repo: spec.RepoID, headRef: git.EnsureRefPrefix(spec.Spec.HeadRef)}
+		c, ok := index.byRepoHeadRef[k]
+		if !ok {
+			diff.Added = append(diff.Added, spec)
+			continue
+		}
+		attached[c.ID] = true
+
+		current := index.currentSpecs[c.ID]
+		delta, err := CompareChangesetSpecs(current, spec)
+		if err != nil {
+			return nil, err
+		}
+		if delta == nil || !delta.AttributesChanged() {
+			diff.Unchanged = append(diff.Unchanged, spec)
+			continue
+		}
+		diff.Updated = append(diff.Updated, &ChangesetSpecPreview{
+			Changeset:   c,
+			CurrentSpec: current,
+			Spec:        spec,
+			Delta:       delta,
+		})
+	}
+
+	for _, c := range changesets {
+		if _, ok := repos[c.RepoID]; !ok || attached[c.ID] {
+			continue
+		}
+
+		// Like ApplyCampaign, keep the changesets that are archived, close
+		// the changesets that this campaign published and detach all others.
+		owned := c.CurrentSpecID != 0 && c.OwnedByCampaignID == campaign.ID
+		if owned && c.Archived() {
+			continue
+		}
+		if owned && c.PublicationState.Published() {
+			diff.Closed = append(diff.Closed, c)
+		} else {
+			diff.Detached = append(diff.Detached, c)
+		}
+	}
+
+	return diff, nil
+}
+
+// diffOfDiffsContext is the number of unchanged lines around the changed
+// lines in the hunks returned by diffOfDiffs.
+const diffOfDiffsContext = 3
+
+// diffOfDiffs returns the line-based unified diff between the given diffs.
+func diffOfDiffs(a, b string) string {
+	dmp := diffmatchpatch.New()
+	ca, cb, lines := dmp.DiffLinesToChars(a, b)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(ca, cb, false), lines)
+
+	type line struct {
+		op   byte
+		text string
+	}
+	var ls []line
+	for _, d := range diffs {
+		op := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			op = '-'
+		case diffmatchpatch.DiffInsert:
+			op = '+'
+		}
+		for _, l := range strings.Split(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			ls = append(ls, line{op: op, text: l})
+		}
+	}
+
+	// aLines[i] and bLines[i] are the 1-based line numbers of ls[i] in a and
+	// b.
+	aLines := make([]int, len(ls)+1)
+	bLines := make([]int, len(ls)+1)
+	aLines[0], bLines[0] = 1, 1
+	for i, l := range ls {
+		aLines[i+1], bLines[i+1] = aLines[i], bLines[i]
+		if l.op != '+' {
+			aLines[i+1]++
+		}
+		if l.op != '-' {
+			bLines[i+1]++
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString("--- current\n+++ previewed\n")
+
+	for i := 0; i < len(ls); {
+		if ls[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk until the next changed line is further away than
+		// twice the context.
+		start := i - diffOfDiffsContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(ls) && j <= end+2*diffOfDiffsContext; j++ {
+			if ls[j].op != ' ' {
+				end = j
+			}
+		}
+		end += diffOfDiffsContext + 1
+		if end > len(ls) {
+			end = len(ls)
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(aLines[start], aLines[end]-aLines[start]),
+			hunkRange(bLines[start], bLines[end]-bLines[start]),
+		)
+		for _, l := range ls[start:end] {
+			out.WriteByte(l.op)
+			out.WriteString(l.text)
+			out.WriteByte('\n')
+		}
+
+		i = end
+	}
+
+	return out.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// An empty range starts at the line before it, by convention.
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,46 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffOfDiffs(t *testing.T) {
+	current := `diff README.md README.md
+--- README.md
++++ README.md
+@@ -1,3 +1,3 @@
+ # Title
+-foo
++bar
+ end
+`
+	previewed := `diff README.md README.md
+--- README.md
++++ README.md
+@@ -1,3 +1,3 @@
+ # Title
+-foo
++baz
+ end
+`
+
+	want := `--- current
++++ previewed
+@@ -4,5 +4,5 @@
+ @@ -1,3 +1,3 @@
+  # Title
+ -foo
+-+bar
+++baz
+  end
+`
+	if diff := cmp.Diff(want, diffOfDiffs(current, previewed)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if have, want := diffOfDiffs(current, current), "--- current\n+++ previewed\n"; have != want {
+		t.Fatalf("wrong diff of equal diffs. want=%q, have=%q", want, have)
+	}
+}
@@ -0,0 +1,62 @@
+package campaigns
+
+import (
+	"time"
+)
+
+// ProgressThroughputWindow is the timeframe over which the publication
+// throughput of a campaign is measured to estimate when its pending
+// changesets will be published.
+const ProgressThroughputWindow = time.Hour
+
+// CampaignProgress is how far along a campaign is in publishing and merging
+// its changesets.
+type CampaignProgress struct {
+	// Total is the number of changesets in the campaign.
+	Total int32
+	// Published is the number of published changesets.
+	Published int32
+	// Merged is the number of merged changesets.
+	Merged int32
+	// PendingPublication is the number of changesets owned by the campaign
+	// that the reconciler is yet to publish.
+	PendingPublication int32
+	// RecentlyPublished is the number of changesets of the campaign that the
+	// reconciler published within the throughput window ending at Time.
+	RecentlyPublished int32
+	// Time is when the progress was computed.
+	Time time.Time
+}
+
+// PublishedPercentage returns the percentage of changesets that are
+// published.
+func (p *CampaignProgress) PublishedPercentage() float64 {
+	return percentage(p.Published, p.Total)
+}
+
+// MergedPercentage returns the percentage of changesets that are merged.
+func (p *CampaignProgress) MergedPercentage() float64 {
+	return percentage(p.Merged, p.Total)
+}
+
+// EstimatedPublicationCompletedAt returns when the pending changesets are
+// expected to be published, assuming the reconciler keeps publishing them as
+// fast as within the throughput window. It returns nil if no changesets are
+// pending or none were published recently, in which case there's nothing to
+// base an estimate on.
+func (p *CampaignProgress) EstimatedPublicationCompletedAt() *time.Time {
+	if p.PendingPublication == 0 || p.RecentlyPublished == 0 {
+		return nil
+	}
+
+	perChangeset := ProgressThroughputWindow / time.Duration(p.RecentlyPublished)
+	eta := p.Time.Add(perChangeset * time.Duration(p.PendingPublication))
+	return &eta
+}
+
+func percentage(n, total int32) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
@@ -0,0 +1,59 @@
+package campaigns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCampaignProgress(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	eta := now.Add(90 * time.Minute)
+
+	for _, tc := range []struct {
+		name          string
+		progress      CampaignProgress
+		wantPublished float64
+		wantMerged    float64
+		wantETA       *time.Time
+	}{
+		{
+			name:     "no changesets",
+			progress: CampaignProgress{Time: now},
+		},
+		{
+			name:          "all published",
+			progress:      CampaignProgress{Total: 4, Published: 4, Merged: 1, RecentlyPublished: 2, Time: now},
+			wantPublished: 100,
+			wantMerged:    25,
+		},
+		{
+			name:          "pending without recent publications",
+			progress:      CampaignProgress{Total: 4, Published: 2, PendingPublication: 2, Time: now},
+			wantPublished: 50,
+		},
+		{
+			name:          "pending with recent publications",
+			progress:      CampaignProgress{Total: 10, Published: 4, Merged: 2, PendingPublication: 6, RecentlyPublished: 4, Time: now},
+			wantPublished: 40,
+			wantMerged:    20,
+			wantETA:       &eta,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := tc.progress.PublishedPercentage(); have != tc.wantPublished {
+				t.Errorf("wrong published percentage. want=%f, have=%f", tc.wantPublished, have)
+			}
+			if have := tc.progress.MergedPercentage(); have != tc.wantMerged {
+				t.Errorf("wrong merged percentage. want=%f, have=%f", tc.wantMerged, have)
+			}
+
+			have := tc.progress.EstimatedPublicationCompletedAt()
+			switch {
+			case tc.wantETA == nil && have != nil:
+				t.Errorf("wrong ETA. want=nil, have=%s", have)
+			case tc.wantETA != nil && (have == nil || !have.Equal(*tc.wantETA)):
+				t.Errorf("wrong ETA. want=%s, have=%v", tc.wantETA, have)
+			}
+		})
+	}
+}
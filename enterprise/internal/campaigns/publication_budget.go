@@ -0,0 +1,219 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"golang.org/x/time/rate"
+)
+
+// publicationCost is roughly the number of code host API requests it takes
+// to publish a changeset: looking up the repository, creating the changeset
+// and loading its metadata.
+const publicationCost = 5
+
+// defaultPublicationBudget is the percentage of a code host's rate limit that
+// the publication of changesets may use if `campaigns.publicationBudget` is
+// not set.
+const defaultPublicationBudget = 50
+
+// publicationBudgetErr is returned by the reconciler if a changeset can't be
+// published yet because the publication budget of its code host is used up.
+type publicationBudgetErr struct {
+	baseURL string
+	delay   time.Duration
+}
+
+func (e *publicationBudgetErr) Error() string {
+	return fmt.Sprintf("publication delayed by the publication budget of %s for %s", e.baseURL, e.delay)
+}
+
+// PublicationBudgets limits the rate at which changesets are published on
+// each code host to the share of the code host's rate limit that's
+// configured in the `campaigns.publicationBudget` site configuration, so that
+// publishing large campaigns leaves enough requests for syncing repositories.
+//
+// The rate limits are read from the ratelimit.Registry that repo-updater
+// keeps in sync with the code host connections. Since the reconciler only
+// runs on a single replica, the budgets are tracked in memory.
+type PublicationBudgets struct {
+	store    repos.Store
+	registry *ratelimit.Registry
+
+	// config returns the configured percentage. If nil, conf.Get is used.
+	config func() int
+
+	mu      sync.Mutex
+	budgets map[string]*publicationBudget
+}
+
+// publicationBudget is the token bucket of a single code host, in which each
+// token is an API request.
+type publicationBudget struct {
+	displayName string
+	// limiter is nil if the rate limit of the code host is disabled.
+	limiter *rate.Limiter
+}
+
+// NewPublicationBudgets returns the PublicationBudgets of the code hosts of
+// the external services in the given store, based on the rate limits in
+// ratelimit.DefaultRegistry.
+func NewPublicationBudgets(store repos.Store) *PublicationBudgets {
+	return &PublicationBudgets{store: store, registry: ratelimit.DefaultRegistry}
+}
+
+// reserve returns 0 if a changeset may be published on the code host of the
+// given external service at the given time, in which case the publication
+// counts against the budget of the code host. Otherwise it returns how long
+// the publication should be delayed.
+func (b *PublicationBudgets) reserve(svc *repos.ExternalService, now time.Time) (time.Duration, error) {
+	rlc, err := extsvc.ExtractRateLimitConfig(svc.Config, svc.Kind, svc.DisplayName)
+	if err != nil {
+		if _, ok := err.(extsvc.ErrRateLimitUnsupported); ok {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l := b.load(rlc, now).limiter
+	if l == nil {
+		return 0, nil
+	}
+	if l.Limit() == 0 {
+		// The code host doesn't allow any requests, so the budget is never
+		// replenished.
+		return rateLimitBackoff, nil
+	}
+
+	res := l.ReserveN(now, publicationCost)
+	delay := res.DelayFrom(now)
+	if delay > 0 {
+		res.CancelAt(now)
+	}
+	return delay, nil
+}
+
+// ListPublicationBudgets returns the current publication budgets of the code
+// hosts of all external services, ordered by base URL.
+func (b *PublicationBudgets) ListPublicationBudgets(ctx context.Context) ([]protocol.CampaignsPublicationBudget, error) {
+	svcs, err := b.store.ListExternalServices(ctx, repos.StoreListExternalServicesArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external services")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	seen := make(map[string]bool, len(svcs))
+	budgets := make([]protocol.CampaignsPublicationBudget, 0, len(svcs))
+	for _, svc := range svcs {
+		rlc, err := extsvc.ExtractRateLimitConfig(svc.Config, svc.Kind, svc.DisplayName)
+		if err != nil {
+			// Code hosts without rate limits have no budget, and invalid
+			// configurations are reported by the syncer.
+			continue
+		}
+		if seen[rlc.BaseURL] {
+			continue
+		}
+		seen[rlc.BaseURL] = true
+
+		budget := b.load(rlc, now)
+		pb := protocol.CampaignsPublicationBudget{
+			BaseURL:     rlc.BaseURL,
+			DisplayName: budget.displayName,
+			Unlimited:   budget.limiter == nil,
+		}
+		if budget.limiter != nil {
+			pb.RequestsPerHour = float64(budget.limiter.Limit()) * 3600
+			pb.Remaining = availableTokens(budget.limiter, now)
+		}
+		budgets = append(budgets, pb)
+	}
+
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].BaseURL < budgets[j].BaseURL })
+	return budgets, nil
+}
+
+// load returns the budget of the given code host, adjusted to the current
+// rate limit of the code host and the configured share of it. New budgets
+// start out full.
+func (b *PublicationBudgets) load(rlc extsvc.RateLimitConfig, now time.Time) *publicationBudget {
+	if b.budgets == nil {
+		b.budgets = make(map[string]*publicationBudget)
+	}
+
+	budget, ok := b.budgets[rlc.BaseURL]
+	if !ok {
+		budget = &publicationBudget{displayName: rlc.DisplayName}
+		b.budgets[rlc.BaseURL] = budget
+	}
+
+	shared := b.registry.Get(rlc.BaseURL).Limit()
+	if shared == rate.Inf {
+		budget.limiter = nil
+		return budget
+	}
+
+	limit := shared * rate.Limit(b.percentage()) / 100
+	// Allow bursts of up to a minute's worth of requests, but at least one
+	// publication.
+	burst := int(limit * 60)
+	if burst < publicationCost {
+		burst = publicationCost
+	}
+
+	switch {
+	case budget.limiter == nil:
+		budget.limiter = rate.NewLimiter(limit, burst)
+	case budget.limiter.Limit() != limit || budget.limiter.Burst() != burst:
+		budget.limiter.SetLimitAt(now, limit)
+		budget.limiter.SetBurstAt(now, burst)
+	}
+	return budget
+}
+
+func (b *PublicationBudgets) percentage() int {
+	var p int
+	if b.config != nil {
+		p = b.config()
+	} else {
+		p = conf.Get().CampaignsPublicationBudget
+	}
+
+	if p <= 0 || p > 100 {
+		return defaultPublicationBudget
+	}
+	return p
+}
+
+// availableTokens returns the number of tokens that are available in l at the
+// given time, without consuming them.
+func availableTokens(l *rate.Limiter, now time.Time) int {
+	if l.Limit() == 0 {
+		return 0
+	}
+
+	res := l.ReserveN(now, l.Burst())
+	defer res.CancelAt(now)
+
+	missing := math.Ceil(res.DelayFrom(now).Seconds() * float64(l.Limit()))
+	if available := l.Burst() - int(missing); available > 0 {
+		return available
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"golang.org/x/time/rate"
+)
+
+func TestPublicationBudgets(t *testing.T) {
+	now := time.Date(2020, 9, 7, 12, 0, 0, 0, time.UTC)
+
+	github := &repos.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GitHub",
+		Config:      `{"url": "https://github.com", "token": "secret"}`,
+	}
+	gitlab := &repos.ExternalService{
+		Kind:        extsvc.KindGitLab,
+		DisplayName: "GitLab",
+		Config:      `{"url": "https://gitlab.com", "token": "secret"}`,
+	}
+	gitolite := &repos.ExternalService{
+		Kind:        extsvc.KindGitolite,
+		DisplayName: "Gitolite",
+		Config:      `{"host": "git@gitolite.example.com", "prefix": "gitolite/"}`,
+	}
+
+	// GitHub allows 3600 requests per hour, of which publications may use
+	// 10%, in bursts of at most 6 requests. GitLab's rate limit is disabled.
+	registry := ratelimit.NewRegistry()
+	registry.Get("https://github.com/").SetLimit(rate.Limit(1))
+
+	newBudgets := func() *PublicationBudgets {
+		return &PublicationBudgets{registry: registry, config: func() int { return 10 }}
+	}
+
+	t.Run("reserve", func(t *testing.T) {
+		b := newBudgets()
+
+		for _, tc := range []struct {
+			svc       *repos.ExternalService
+			at        time.Time
+			wantDelay time.Duration
+		}{
+			// The budget starts out full.
+			{svc: github, at: now, wantDelay: 0},
+			// One request is left, so the next publication needs to wait
+			// for 4 more at 0.1 requests per second.
+			{svc: github, at: now, wantDelay: 40 * time.Second},
+			{svc: github, at: now.Add(30 * time.Second), wantDelay: 10 * time.Second},
+			{svc: github, at: now.Add(40 * time.Second), wantDelay: 0},
+			// Code hosts without rate limits aren't limited.
+			{svc: gitlab, at: now, wantDelay: 0},
+			{svc: gitlab, at: now, wantDelay: 0},
+			{svc: gitolite, at: now, wantDelay: 0},
+		} {
+			delay, err := b.reserve(tc.svc, tc.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if delay != tc.wantDelay {
+				t.Errorf("%s at %s: want delay %s, have %s", tc.svc.DisplayName, tc.at, tc.wantDelay, delay)
+			}
+		}
+	})
+
+	t.Run("ListPublicationBudgets", func(t *testing.T) {
+		ctx := context.Background()
+
+		store := new(repos.FakeStore)
+		if err := store.UpsertExternalServices(ctx, github.Clone(), gitlab.Clone(), gitolite.Clone()); err != nil {
+			t.Fatal(err)
+		}
+
+		b := newBudgets()
+		b.store = store
+
+		have, err := b.ListPublicationBudgets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []protocol.CampaignsPublicationBudget{
+			{BaseURL: "https://github.com/", DisplayName: "GitHub", RequestsPerHour: 360, Remaining: 6},
+			{BaseURL: "https://gitlab.com/", DisplayName: "GitLab", Unlimited: true},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
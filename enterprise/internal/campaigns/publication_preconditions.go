@@ -0,0 +1,83 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// loadPublicationPreconditions returns the PublicationPreconditions of the
+// current campaign spec of the campaign that owns the given changeset.
+func loadPublicationPreconditions(ctx context.Context, tx *Store, ch *campaigns.Changeset) (campaigns.PublicationPreconditions, error) {
+	var p campaigns.PublicationPreconditions
+	if ch.OwnedByCampaignID == 0 {
+		return p, nil
+	}
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: ch.OwnedByCampaignID, IncludeDeleted: true})
+	if err != nil {
+		return p, errors.Wrap(err, "failed to load owning campaign")
+	}
+	if campaign.CampaignSpecID == 0 {
+		return p, nil
+	}
+
+	campaignSpec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: campaign.CampaignSpecID})
+	if err != nil {
+		return p, errors.Wrap(err, "failed to load campaign spec")
+	}
+
+	return campaignSpec.Spec.PublicationPreconditions, nil
+}
+
+// checkPublicationPreconditions checks whether the given repository meets
+// the preconditions that can only be evaluated once a ChangesetSource has
+// been built. It returns a non-empty reason if the publication of the
+// changeset should be skipped.
+func checkPublicationPreconditions(ctx context.Context, ccs repos.ChangesetSource, p campaigns.PublicationPreconditions, repo *repos.Repo, spec *campaigns.ChangesetSpec) (string, error) {
+	if p.BaseBranchPassing {
+		css, ok := ccs.(repos.CommitStatusSource)
+		if !ok {
+			return fmt.Sprintf("The commit statuses of the base branch can't be checked on %s.", repo.ExternalRepo.ServiceType), nil
+		}
+
+		branch := git.AbbreviateRef(spec.Spec.BaseRef)
+		state, err := css.CommitCheckState(ctx, repo, branch)
+		if err != nil {
+			return "", errors.Wrap(err, "loading commit statuses of base branch")
+		}
+		if state != campaigns.ChangesetCheckStatePassed {
+			return fmt.Sprintf("The commit statuses of the base branch %q are %s instead of PASSED.", branch, state), nil
+		}
+	}
+
+	for _, query := range p.SearchMatches {
+		q := fmt.Sprintf("repo:^%s$@%s %s", regexp.QuoteMeta(repo.Name), spec.Spec.BaseRev, query)
+		names, _, err := searchRepositoryNames(ctx, q)
+		if err != nil {
+			return "", errors.Wrapf(err, "evaluating search precondition %q", query)
+		}
+		if len(names) == 0 {
+			return fmt.Sprintf("The search query %q has no results in the repository.", query), nil
+		}
+	}
+
+	return "", nil
+}
+
+// skipPublication records on the changeset that its publication was skipped
+// for the given reason. The changeset stays unpublished until its campaign
+// spec is applied again.
+func skipPublication(ctx context.Context, tx *Store, ch *campaigns.Changeset, reason string) error {
+	ch.SkippedReason = reason
+	ch.FailureMessage = nil
+	ch.FailureClass = ""
+	ch.FailureCode = ""
+	ch.WaitReason = ""
+	return tx.UpdateChangeset(ctx, ch)
+}
@@ -0,0 +1,85 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestCheckPublicationPreconditions(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &repos.Repo{Name: "github.com/sourcegraph/sourcegraph"}
+	spec := &campaigns.ChangesetSpec{Spec: &campaigns.ChangesetSpecDescription{
+		BaseRef: "refs/heads/master",
+		BaseRev: "d34db33f",
+	}}
+
+	defer func(f func(context.Context, string) ([]api.RepoName, map[api.RepoName]int, error)) {
+		searchRepositoryNames = f
+	}(searchRepositoryNames)
+	var queries []string
+	searchRepositoryNames = func(ctx context.Context, query string) ([]api.RepoName, map[api.RepoName]int, error) {
+		queries = append(queries, query)
+		if query == `repo:^github\.com/sourcegraph/sourcegraph$@d34db33f file:go.mod` {
+			return []api.RepoName{api.RepoName(repo.Name)}, nil, nil
+		}
+		return nil, nil, nil
+	}
+
+	tests := []struct {
+		name          string
+		preconditions campaigns.PublicationPreconditions
+		checkState    campaigns.ChangesetCheckState
+		wantReason    bool
+	}{
+		{
+			name: "no preconditions",
+		},
+		{
+			name:          "base branch passing",
+			preconditions: campaigns.PublicationPreconditions{BaseBranchPassing: true},
+			checkState:    campaigns.ChangesetCheckStatePassed,
+		},
+		{
+			name:          "base branch failing",
+			preconditions: campaigns.PublicationPreconditions{BaseBranchPassing: true},
+			checkState:    campaigns.ChangesetCheckStateFailed,
+			wantReason:    true,
+		},
+		{
+			name:          "search matches",
+			preconditions: campaigns.PublicationPreconditions{SearchMatches: []string{"file:go.mod"}},
+		},
+		{
+			name:          "search doesn't match",
+			preconditions: campaigns.PublicationPreconditions{SearchMatches: []string{"file:go.mod", "file:package.json"}},
+			wantReason:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			queries = nil
+			ccs := &ct.FakeChangesetSource{CheckState: tc.checkState}
+
+			reason, err := checkPublicationPreconditions(ctx, ccs, tc.preconditions, repo, spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := reason != "", tc.wantReason; have != want {
+				t.Fatalf("wrong reason. want skipped=%t, have %q", want, reason)
+			}
+			if have, want := ccs.CommitCheckStateCalled, tc.preconditions.BaseBranchPassing; have != want {
+				t.Fatalf("CommitCheckStateCalled=%t, want %t", have, want)
+			}
+			if have, want := len(queries), len(tc.preconditions.SearchMatches); have != want {
+				t.Fatalf("wrong number of search queries. want=%d, have=%d", want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// reapplySchedulerInterval is the time between two passes of the reapply
+// scheduler. It's the resolution of reapply schedules.
+const reapplySchedulerInterval = 1 * time.Minute
+
+// RunReapplyScheduler periodically enqueues CampaignReapplyJobs for the
+// campaigns whose CampaignReapplySchedule is due. The jobs are processed by
+// server-side campaign spec execution, which re-evaluates the campaign's
+// spec and applies the resulting changeset specs. It runs until the given
+// context is canceled. If locker is not nil, jobs are only enqueued by the
+// replica that's the leader of the reapply scheduler job.
+func RunReapplyScheduler(ctx context.Context, s *Store, locker *Locker) {
+	r := &reapplyScheduler{store: s}
+	locker.DoAsLeader(ctx, LeaderJobReapplyScheduler, r.loop)
+}
+
+type reapplyScheduler struct {
+	store *Store
+}
+
+func (r *reapplyScheduler) loop(ctx context.Context) {
+	for {
+		if err := r.run(ctx); err != nil {
+			log15.Error("Scheduling campaign reapply jobs", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reapplySchedulerInterval):
+		}
+	}
+}
+
+// run enqueues a CampaignReapplyJob for every due schedule and advances the
+// schedule to its next run.
+func (r *reapplyScheduler) run(ctx context.Context) error {
+	due, err := r.store.ListDueCampaignReapplySchedules(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing due reapply schedules")
+	}
+
+	for _, rs := range due {
+		if err := r.schedule(ctx, rs); err != nil {
+			log15.Error("Scheduling campaign reapply job", "campaign", rs.CampaignID, "err", err)
+		}
+	}
+	return nil
+}
+
+func (r *reapplyScheduler) schedule(ctx context.Context, rs *campaigns.CampaignReapplySchedule) (err error) {
+	tx, err := r.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: rs.CampaignID, IncludeDeleted: true})
+	if err != nil {
+		return errors.Wrap(err, "getting campaign")
+	}
+
+	// Closed and deleted campaigns can't be re-applied, so their schedule
+	// only advances.
+	if campaign.ClosedAt.IsZero() && !campaign.Deleted() {
+		job := &campaigns.CampaignReapplyJob{
+			CampaignID:     campaign.ID,
+			CampaignSpecID: campaign.CampaignSpecID,
+		}
+		if err := tx.EnqueueCampaignReapplyJob(ctx, job); err != nil {
+			return errors.Wrap(err, "enqueueing reapply job")
+		}
+	}
+
+	cron, err := parseCronSchedule(rs.Schedule)
+	if err != nil {
+		return err
+	}
+
+	now := tx.now()
+	rs.LastRunAt = now
+	rs.NextRunAt = cron.next(now)
+	return tx.UpsertCampaignReapplySchedule(ctx, rs)
+}
+
+// cronSchedule is a parsed cron expression. Each field is a bit set of the
+// values at which the schedule fires.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar are true if the day of month, respectively day of
+	// week, field is a wildcard. If neither is, a day matches if it matches
+	// either of the fields, like in cron(8).
+	domStar, dowStar bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// parseCronSchedule parses a cron expression with the five fields minute,
+// hour, day of month, month and day of week, each of which is a comma
+// separated list of `*`, values and ranges with an optional step, such as
+// `*/15` or `1-5`. Both 0 and 7 denote Sunday. The macros @hourly, @daily,
+// @weekly and @monthly are supported as well. All times are in UTC.
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[spec]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Errorf("invalid schedule %q: expected %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, f := range fields {
+		set, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid schedule %q", expr)
+		}
+		sets[i] = set
+	}
+
+	c := &cronSchedule{
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	// Sunday can be written as 7.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	// Schedules such as "0 0 30 2 *" are syntactically valid but never fire.
+	if c.next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, errors.Errorf("invalid schedule %q: never runs", expr)
+	}
+
+	return c, nil
+}
+
+func parseCronField(field string, f cronField) (set uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			rng = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, part)
+			}
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			if lo, err = parseCronValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range in %s field %q", f.name, part)
+			}
+		default:
+			if lo, err = parseCronValue(rng, f); err != nil {
+				return 0, err
+			}
+			// A single value with a step, such as 5/10, runs until the max.
+			if step == 1 {
+				hi = lo
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q: must be between %d and %d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// next returns the first time after t at which the schedule fires, or the
+// zero time if it doesn't fire within the next five years.
+func (c *cronSchedule) next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *cronSchedule) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
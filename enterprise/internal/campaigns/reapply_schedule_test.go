@@ -0,0 +1,146 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestReapplySchedulerRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+
+	spec := &campaigns.CampaignSpec{UserID: admin.ID, NamespaceUserID: admin.ID}
+	if err := store.CreateCampaignSpec(ctx, spec); err != nil {
+		t.Fatal(err)
+	}
+
+	openCampaign := testCampaign(admin.ID)
+	closedCampaign := testCampaign(admin.ID)
+	closedCampaign.ClosedAt = now
+	notDueCampaign := testCampaign(admin.ID)
+	for _, c := range []*campaigns.Campaign{openCampaign, closedCampaign, notDueCampaign} {
+		c.CampaignSpecID = spec.ID
+		if err := store.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		rs := &campaigns.CampaignReapplySchedule{
+			CampaignID: c.ID,
+			Schedule:   "@hourly",
+			NextRunAt:  now.Add(-time.Minute),
+		}
+		if c == notDueCampaign {
+			rs.NextRunAt = now.Add(time.Minute)
+		}
+		if err := store.UpsertCampaignReapplySchedule(ctx, rs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &reapplyScheduler{store: store}
+	if err := r.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := store.ListCampaignReapplyJobs(ctx, ListCampaignReapplyJobsOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].CampaignID != openCampaign.ID || jobs[0].CampaignSpecID != spec.ID {
+		t.Fatalf("wrong jobs enqueued: %+v", jobs)
+	}
+
+	// The schedules that were due are advanced, including the one of the
+	// closed campaign.
+	for _, c := range []*campaigns.Campaign{openCampaign, closedCampaign} {
+		rs, err := store.GetCampaignReapplySchedule(ctx, c.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !rs.LastRunAt.Equal(now) {
+			t.Fatalf("wrong last run. want=%s, have=%s", now, rs.LastRunAt)
+		}
+		if want := now.Truncate(time.Hour).Add(time.Hour); !rs.NextRunAt.Equal(want) {
+			t.Fatalf("wrong next run. want=%s, have=%s", want, rs.NextRunAt)
+		}
+	}
+}
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, tc := range []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "@daily"},
+		{expr: " @weekly "},
+		{expr: "*/15 * * * *"},
+		{expr: "0 9-17/2 * * 1-5"},
+		{expr: "0 0 1,15 * 7"},
+		{expr: "@yearly", wantErr: true},
+		{expr: "* * * *", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "0 17-9 * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "0 0 * * mon", wantErr: true},
+		{expr: "0 0 30 2 *", wantErr: true},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := parseCronSchedule(tc.expr)
+			if have, want := err != nil, tc.wantErr; have != want {
+				t.Fatalf("wrong error. want error=%t, have=%v", want, err)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2020-09-07 was a Monday.
+	now := time.Date(2020, 9, 7, 10, 30, 15, 0, time.UTC)
+
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2020, 9, 7, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/20 * * * *", want: time.Date(2020, 9, 7, 10, 40, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2020, 9, 7, 11, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2020, 9, 8, 0, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", want: time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "30 10 * * *", want: time.Date(2020, 9, 8, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 9 * * 5", want: time.Date(2020, 9, 11, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// If both day of month and day of week are restricted, either of
+		// them matching is enough.
+		{expr: "0 0 20 * 3", want: time.Date(2020, 9, 9, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 8 * 3", want: time.Date(2020, 9, 8, 0, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			c, err := parseCronSchedule(tc.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have := c.next(now); !have.Equal(tc.want) {
+				t.Fatalf("wrong next run. want=%s, have=%s", tc.want, have)
+			}
+		})
+	}
+}
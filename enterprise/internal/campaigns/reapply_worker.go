@@ -0,0 +1,107 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// RunReapplyWorker starts a dbworker.NewWorker that processes the
+// CampaignReapplyJobs enqueued by the reapply scheduler. Every job enqueues a
+// CampaignSpecExecution of the campaign's current spec, which is applied to
+// the campaign by the spec executor once it completes. Jobs stay queued while
+// campaigns.executor isn't enabled.
+func RunReapplyWorker(ctx context.Context, s *Store) {
+	w := &reapplyWorker{store: s}
+
+	options := dbworker.WorkerOptions{
+		Name:        "campaigns_reapply_worker",
+		Handler:     w,
+		NumHandlers: 1,
+		Interval:    5 * time.Second,
+		Metrics: workerutil.WorkerMetrics{
+			HandleOperation: newObservationOperation("campaigns_reapply_worker", "ReapplyWorker.Process"),
+		},
+	}
+
+	workerStore := dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
+		TableName:         "campaign_reapply_jobs",
+		ColumnExpressions: campaignReapplyJobColumns,
+		Scan:              scanFirstCampaignReapplyJobRecord,
+		OrderByExpression: sqlf.Sprintf("campaign_reapply_jobs.created_at"),
+		StalledMaxAge:     60 * time.Second,
+		MaxNumResets:      5,
+	})
+
+	dbworker.NewWorker(ctx, workerStore, options).Start()
+}
+
+func scanFirstCampaignReapplyJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var js []*campaigns.CampaignReapplyJob
+	err = scanAll(rows, func(sc scanner) error {
+		var j campaigns.CampaignReapplyJob
+		if err := scanCampaignReapplyJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+	if err != nil || len(js) == 0 {
+		return &campaigns.CampaignReapplyJob{}, false, err
+	}
+	return js[0], true, nil
+}
+
+type reapplyWorker struct {
+	store *Store
+}
+
+var _ dbworker.Handler = &reapplyWorker{}
+var _ workerutil.WithPreDequeue = &reapplyWorker{}
+
+// PreDequeue declines to dequeue jobs while campaigns.executor isn't
+// enabled, so that they're processed once it is.
+func (w *reapplyWorker) PreDequeue(ctx context.Context) (bool, interface{}, error) {
+	return executorEnabled(), nil, nil
+}
+
+// Handle enqueues a CampaignSpecExecution for the job.
+func (w *reapplyWorker) Handle(ctx context.Context, tx dbworkerstore.Store, record workerutil.Record) error {
+	store := w.store.With(tx)
+	job := record.(*campaigns.CampaignReapplyJob)
+
+	campaign, err := store.GetCampaign(ctx, GetCampaignOpts{ID: job.CampaignID, IncludeDeleted: true})
+	if err != nil {
+		return errors.Wrap(err, "getting campaign")
+	}
+	// The campaign has been closed or deleted since the job was enqueued.
+	if campaign.Closed() || campaign.Deleted() {
+		return nil
+	}
+
+	spec, err := store.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: job.CampaignSpecID})
+	if err != nil {
+		return errors.Wrap(err, "getting campaign spec")
+	}
+
+	// The spec is executed on behalf of the user that last applied the
+	// campaign, so that the execution sees the repositories they can see.
+	return store.CreateCampaignSpecExecution(ctx, &campaigns.CampaignSpecExecution{
+		RawSpec:         spec.RawSpec,
+		NamespaceUserID: campaign.NamespaceUserID,
+		NamespaceOrgID:  campaign.NamespaceOrgID,
+		UserID:          campaign.LastApplierID,
+		CampaignID:      campaign.ID,
+	})
+}
@@ -5,13 +5,19 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -30,16 +36,248 @@ type reconciler struct {
 	gitserverClient GitserverClient
 	sourcer         repos.Sourcer
 	store           *Store
+
+	// rollout delays the publication of changesets according to the
+	// rollout windows in the site configuration. If nil, changesets are
+	// published right away.
+	rollout *rolloutWindows
+
+	// budgets limits the publication of changesets to a share of the rate
+	// limit of their code host. If nil, publications aren't limited.
+	budgets *PublicationBudgets
+
+	// concurrency, if not nil, limits how many changesets are processed in
+	// parallel, in total and on each code host.
+	concurrency *reconcilerConcurrency
+
+	// syncs, if not nil, is used to sync newly published changesets right
+	// after their transaction is committed, so that their state and events
+	// show up within seconds instead of after the next regular sync.
+	syncs ChangesetSyncEnqueuer
+
+	mu sync.Mutex
+	// published contains the IDs of the changesets that were published by
+	// handlers whose PostHandle hook hasn't run yet.
+	published map[int64]struct{}
+}
+
+var _ dbworker.Handler = &reconciler{}
+var _ workerutil.WithPreDequeue = &reconciler{}
+var _ workerutil.WithHooks = &reconciler{}
+
+// Handle processes the given changeset. It implements dbworker.Handler.
+func (r *reconciler) Handle(ctx context.Context, tx dbworkerstore.Store, record workerutil.Record) error {
+	return r.HandlerFunc()(ctx, tx, record)
+}
+
+// PreDequeue skips dequeueing if the configured number of changesets is
+// already being processed, and otherwise excludes the changesets of code
+// hosts whose limit is reached. The changesets of deleted campaigns are
+// always excluded, so that they stay queued until the campaign is restored.
+// It implements workerutil.WithPreDequeue.
+func (r *reconciler) PreDequeue(ctx context.Context) (bool, interface{}, error) {
+	conditions := []*sqlf.Query{sqlf.Sprintf(deletedCampaignConditionFmtstr)}
+	if r.concurrency == nil {
+		return true, conditions, nil
+	}
+
+	ok, concurrencyConditions := r.concurrency.conditions()
+	return ok, append(conditions, concurrencyConditions...), nil
+}
+
+var deletedCampaignConditionFmtstr = `
+NOT EXISTS (
+  SELECT 1 FROM campaigns
+  WHERE campaigns.id = changesets.owned_by_campaign_id AND campaigns.deleted_at IS NOT NULL
+)
+`
+
+// PreHandle records that the given changeset is being processed. It
+// implements workerutil.WithHooks.
+func (r *reconciler) PreHandle(ctx context.Context, record workerutil.Record) {
+	if r.concurrency != nil {
+		r.concurrency.acquire(ctx, record.(*campaigns.Changeset))
+	}
+}
+
+// PostHandle records that the given changeset is no longer being processed
+// and enqueues a sync of it if it was published by the handler. The sync can't be enqueued by the handler itself, since the
+// syncer skips changesets that are still being processed and the published
+// changeset only becomes visible once the handler's transaction is
+// committed.
+func (r *reconciler) PostHandle(ctx context.Context, record workerutil.Record) {
+	id := int64(record.RecordID())
+	if r.concurrency != nil {
+		r.concurrency.release(id)
+	}
+
+	r.mu.Lock()
+	_, ok := r.published[id]
+	delete(r.published, id)
+	r.mu.Unlock()
+
+	if !ok || r.syncs == nil {
+		return
+	}
+	if err := r.syncs.EnqueueChangesetSyncs(ctx, []int64{id}); err != nil {
+		log15.Warn("Enqueueing sync of published changeset", "changeset", id, "err", err)
+	}
+}
+
+// markPublished records that the changeset with the given ID was published,
+// so that PostHandle enqueues a sync of it.
+func (r *reconciler) markPublished(id int64) {
+	if r.syncs == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.published == nil {
+		r.published = make(map[int64]struct{})
+	}
+	r.published[id] = struct{}{}
 }
 
 // HandlerFunc returns a dbworker.HandlerFunc that can be passed to a
 // workerutil.Worker to process queued changesets.
 func (r *reconciler) HandlerFunc() dbworker.HandlerFunc {
 	return func(ctx context.Context, tx dbworkerstore.Store, record workerutil.Record) error {
-		return r.process(ctx, r.store.With(tx), record.(*campaigns.Changeset))
+		store := r.store.With(tx)
+		ch := record.(*campaigns.Changeset)
+
+		err := r.process(ctx, store, ch)
+		if err == nil {
+			// A successful attempt ends the streak of failures.
+			if ch.NumFailures > 0 {
+				return store.SetChangesetNumFailures(ctx, ch.ID, 0)
+			}
+			return nil
+		}
+		if reason, backoff, ok := waitReasonForError(err); ok {
+			log15.Info("Requeueing changeset", "changeset", ch.ID, "reason", reason, "backoff", backoff, "err", err)
+			return requeueChangeset(ctx, store, ch.ID, reason, store.Clock()().Add(backoff), ch.NumFailures, nil)
+		}
+
+		numFailures := ch.NumFailures + 1
+		if reason, backoff, ok := retryReasonForError(err, numFailures); ok {
+			log15.Info("Retrying changeset", "changeset", ch.ID, "failures", numFailures, "backoff", backoff, "err", err)
+			return requeueChangeset(ctx, store, ch.ID, reason, store.Clock()().Add(backoff), numFailures, err)
+		}
+
+		// The worker marks the changeset as errored with the failure class of
+		// the error, but doesn't know about changeset error codes.
+		if codeErr := store.SetChangesetFailureCode(ctx, ch.ID, changesetErrorCode(err)); codeErr != nil {
+			log15.Error("Setting changeset failure code", "changeset", ch.ID, "err", codeErr)
+		}
+		if numErr := store.SetChangesetNumFailures(ctx, ch.ID, numFailures); numErr != nil {
+			log15.Error("Setting changeset number of failures", "changeset", ch.ID, "err", numErr)
+		}
+		return err
 	}
 }
 
+const (
+	// rateLimitBackoff is how long the reconciler waits before processing a
+	// changeset again after the code host's rate limit was exceeded.
+	rateLimitBackoff = 5 * time.Minute
+
+	// transientBackoff is how long the reconciler waits before processing a
+	// changeset again after a transient network error.
+	transientBackoff = time.Minute
+
+	// dependencyBackoff is how long the reconciler waits before processing a
+	// changeset again whose repository is still being cloned.
+	dependencyBackoff = 30 * time.Second
+
+	// pausedBackoff is how long the reconciler waits before checking again
+	// whether the campaign of a changeset is still paused. Resuming the
+	// campaign makes its changesets ready to be processed right away.
+	pausedBackoff = time.Hour
+)
+
+// campaignPausedErr is returned by process if the campaign that owns the
+// changeset is paused.
+type campaignPausedErr struct {
+	campaignID int64
+}
+
+func (e *campaignPausedErr) Error() string {
+	return fmt.Sprintf("campaign %d is paused", e.campaignID)
+}
+
+// waitReasonForError returns whether the given error returned by process is
+// one that resolves itself over time, and if so, why and how long the
+// changeset should wait before it's processed again.
+func waitReasonForError(err error) (campaigns.ChangesetWaitReason, time.Duration, bool) {
+	if err == nil {
+		return "", 0, false
+	}
+
+	if e, ok := errors.Cause(err).(*rolloutWindowErr); ok {
+		return campaigns.ChangesetWaitReasonRolloutWindow, e.delay, true
+	}
+	if e, ok := errors.Cause(err).(*publicationBudgetErr); ok {
+		return campaigns.ChangesetWaitReasonRateLimit, e.delay, true
+	}
+	if _, ok := errors.Cause(err).(*campaignPausedErr); ok {
+		return campaigns.ChangesetWaitReasonPaused, pausedBackoff, true
+	}
+	if vcs.IsCloneInProgress(errors.Cause(err)) {
+		return campaigns.ChangesetWaitReasonDependency, dependencyBackoff, true
+	}
+	if p, ok := changesetRetryPolicies[changesetErrorCode(err)]; ok && !p.exponential {
+		return p.reason, p.backoff, true
+	}
+
+	return "", 0, false
+}
+
+// retryReasonForError returns whether the changeset should be retried after
+// its numFailures-th consecutive failure with the given error returned by
+// process, and if so, why and how long it should wait before it's processed
+// again. Errors that require user action and changesets that have used up
+// their attempts aren't retried.
+func retryReasonForError(err error, numFailures int64) (campaigns.ChangesetWaitReason, time.Duration, bool) {
+	p, ok := changesetRetryPolicies[changesetErrorCode(err)]
+	if !ok || !p.exponential {
+		return "", 0, false
+	}
+
+	backoff, ok := p.retryBackoff(numFailures, reconcilerMaxAttempts())
+	if !ok {
+		return "", 0, false
+	}
+	return p.reason, backoff, true
+}
+
+// requeueChangeset puts the changeset with the given ID back into the queue,
+// so that it's not processed again before the given time. Since the
+// changeset is no longer in the processing state afterwards, the worker
+// doesn't mark it as completed. If failureErr is not nil, the changeset is
+// retried after it failed with that error, which is recorded on it so that
+// users can see why it's retried.
+func requeueChangeset(ctx context.Context, tx *Store, id int64, reason campaigns.ChangesetWaitReason, after time.Time, numFailures int64, failureErr error) error {
+	// Reload the changeset to discard any changes that process made before
+	// it failed.
+	ch, err := tx.GetChangeset(ctx, GetChangesetOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	ch.ReconcilerState = campaigns.ReconcilerStateQueued
+	ch.ProcessAfter = after
+	ch.WaitReason = reason
+	ch.NumFailures = numFailures
+	if failureErr != nil {
+		msg := failureErr.Error()
+		ch.FailureMessage = &msg
+		ch.FailureClass = failure.Classify(failureErr)
+		ch.FailureCode = changesetErrorCode(failureErr)
+	}
+	return tx.UpdateChangeset(ctx, ch)
+}
+
 // process is the main entry point of the reconciler and processes changesets
 // that were marked as queued in the database.
 //
@@ -53,10 +291,27 @@ func (r *reconciler) HandlerFunc() dbworker.HandlerFunc {
 //
 // If an error is returned, the workerutil.Worker that called this function
 // (through the HandlerFunc) will set the changeset's ReconcilerState to
-// errored and set its FailureMessage to the error.
+// errored and set its FailureMessage and FailureClass according to the error.
 func (r *reconciler) process(ctx context.Context, tx *Store, ch *campaigns.Changeset) error {
 	log15.Info("Processing changeset", "changeset", ch.ID)
 
+	// Changesets of paused campaigns wait until the campaign is resumed.
+	if ch.OwnedByCampaignID != 0 {
+		campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: ch.OwnedByCampaignID, IncludeDeleted: true})
+		if err != nil {
+			return errors.Wrap(err, "failed to load campaign")
+		}
+		// The changesets of deleted campaigns aren't dequeued, but the
+		// campaign may have been deleted since.
+		if campaign.Deleted() {
+			log15.Info("Skipping changeset of deleted campaign", "changeset", ch.ID, "campaign", campaign.ID)
+			return nil
+		}
+		if campaign.Paused() {
+			return &campaignPausedErr{campaignID: campaign.ID}
+		}
+	}
+
 	action, err := determineAction(ctx, tx, ch)
 	if err != nil {
 		return err
@@ -64,10 +319,20 @@ func (r *reconciler) process(ctx context.Context, tx *Store, ch *campaigns.Chang
 
 	switch action.actionType {
 	case actionPublish:
+		if r.rollout != nil {
+			if delay := r.rollout.reserve(tx.Clock()()); delay > 0 {
+				return &rolloutWindowErr{delay: delay}
+			}
+		}
+
 		log15.Info("Publishing", "changeset", ch.ID)
 		if err := r.publishChangeset(ctx, tx, ch, action.spec); err != nil {
 			return err
 		}
+		// Skipped publications leave the changeset unpublished.
+		if ch.PublicationState.Published() {
+			r.markPublished(ch.ID)
+		}
 
 		u, err := ch.URL()
 		if err != nil {
@@ -105,30 +370,81 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 		return errors.Wrap(err, "failed to load associations")
 	}
 
+	preconditions, err := loadPublicationPreconditions(ctx, tx, ch)
+	if err != nil {
+		return err
+	}
+
+	// Archived repositories are read-only on the code host.
+	if repo.Archived {
+		if preconditions.RepositoryNotArchived {
+			return skipPublication(ctx, tx, ch, "The repository is archived.")
+		}
+		return repoArchivedError(repo)
+	}
+
+	cred, err := loadUserCredential(ctx, tx, ch, repo)
+	if err != nil {
+		return errors.Wrap(err, "failed to load user credential")
+	}
+
 	// Set up a source with which we can create a changeset
-	ccs, err := r.buildChangesetSource(repo, extSvc)
+	ccs, err := r.buildChangesetSource(repo, extSvc, cred)
 	if err != nil {
 		return err
 	}
 
+	reason, err := checkPublicationPreconditions(ctx, ccs, preconditions, repo, spec)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return skipPublication(ctx, tx, ch, reason)
+	}
+
+	if r.budgets != nil {
+		delay, err := r.budgets.reserve(extSvc, tx.Clock()())
+		if err != nil {
+			return errors.Wrap(err, "failed to reserve publication budget")
+		}
+		if delay > 0 {
+			return &publicationBudgetErr{baseURL: repo.ExternalRepo.ServiceID, delay: delay}
+		}
+	}
+
 	// Create a commit and push it
-	opts, err := buildCommitOpts(repo, spec)
+	opts, err := buildCommitOpts(api.RepoName(repo.Name), spec)
 	if err != nil {
 		return err
 	}
-	ref, err := r.pushCommit(ctx, opts)
+	if cred != nil {
+		opts.PushUsername, opts.PushPassword = userCredentialPushAuth(cred)
+	}
+
+	// If configured, publish the changeset from a fork on code hosts that
+	// support it.
+	var forkNamespace string
+	if _, ok := ccs.(repos.ForkableChangesetSource); ok && conf.Get().CampaignsPushToFork {
+		forkNamespace, opts.PushRemotePath, err = ensureFork(ctx, ccs, repo)
+		if err != nil {
+			return err
+		}
+	}
+
+	ref, err := pushCommit(ctx, r.gitserverClient, opts)
 	if err != nil {
 		return err
 	}
 
 	// Now create the actual pull request on the code host
 	cs := &repos.Changeset{
-		Title:     spec.Spec.Title,
-		Body:      spec.Spec.Body,
-		BaseRef:   spec.Spec.BaseRef,
-		HeadRef:   git.EnsureRefPrefix(ref),
-		Repo:      repo,
-		Changeset: ch,
+		Title:         spec.Spec.Title,
+		Body:          spec.Spec.Body,
+		BaseRef:       spec.Spec.BaseRef,
+		HeadRef:       git.EnsureRefPrefix(ref),
+		ForkNamespace: forkNamespace,
+		Repo:          repo,
+		Changeset:     ch,
 	}
 
 	// If we're running this method a second time, because we failed due to an
@@ -151,6 +467,17 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 				return errors.Wrap(err, "updating changeset")
 			}
 		}
+	} else if err := requestCodeOwnerReviews(ctx, ccs, repo, spec, cs); err != nil {
+		// The changeset has been created, so failing to request reviews
+		// shouldn't fail its publication.
+		log15.Warn("Requesting code owner reviews", "changeset", ch.ID, "err", err)
+	}
+
+	if err := assignChangeset(ctx, ccs, cs, spec.Spec.Reviewers, spec.Spec.Assignees); err != nil {
+		return err
+	}
+	if err := labelChangeset(ctx, ccs, cs, spec.Spec.Labels, nil, spec.Spec.Milestone); err != nil {
+		return err
 	}
 
 	events := ch.Events()
@@ -163,8 +490,29 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 
 	ch.CreatedByCampaign = true
 	ch.PublicationState = campaigns.ChangesetPublicationStatePublished
+	ch.ExternalForkNamespace = forkNamespace
 	ch.FailureMessage = nil
-	return tx.UpdateChangeset(ctx, ch)
+	ch.FailureClass = ""
+	ch.FailureCode = ""
+	ch.WaitReason = ""
+	ch.SkippedReason = ""
+	if err := tx.UpdateChangeset(ctx, ch); err != nil {
+		return err
+	}
+
+	if err := enqueueChangesetWebhookEvent(ctx, tx, campaigns.CampaignWebhookEventChangesetPublished, ch, ""); err != nil {
+		return err
+	}
+
+	if ch.OwnedByCampaignID == 0 {
+		return nil
+	}
+
+	return tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID:  ch.OwnedByCampaignID,
+		ChangesetID: ch.ID,
+		Kind:        campaigns.CampaignActivityKindChangesetPublished,
+	})
 }
 
 // updateChangeset updates the given changeset's attribute on the code host
@@ -173,25 +521,46 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 // create and force push a new commit.
 // If the delta requires updates to the changeset on the code host, it will
 // update the changeset there.
-func (r *reconciler) updateChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec, delta *changesetSpecDelta) (err error) {
+func (r *reconciler) updateChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec, delta *ChangesetSpecDelta) (err error) {
 	repo, extSvc, err := loadAssociations(ctx, tx, ch)
 	if err != nil {
 		return errors.Wrap(err, "failed to load associations")
 	}
 
+	// Archived repositories are read-only on the code host.
+	if repo.Archived {
+		return repoArchivedError(repo)
+	}
+
+	cred, err := loadUserCredential(ctx, tx, ch, repo)
+	if err != nil {
+		return errors.Wrap(err, "failed to load user credential")
+	}
+
 	// Set up a source with which we can update the changeset on the code host.
-	ccs, err := r.buildChangesetSource(repo, extSvc)
+	ccs, err := r.buildChangesetSource(repo, extSvc, cred)
 	if err != nil {
 		return err
 	}
 
 	if delta.NeedCommitUpdate() {
-		opts, err := buildCommitOpts(repo, spec)
+		opts, err := buildCommitOpts(api.RepoName(repo.Name), spec)
 		if err != nil {
 			return err
 		}
+		if cred != nil {
+			opts.PushUsername, opts.PushPassword = userCredentialPushAuth(cred)
+		}
+
+		// Changesets published from a fork are updated there, regardless
+		// of the current site configuration.
+		if ch.ExternalForkNamespace != "" {
+			if _, opts.PushRemotePath, err = ensureFork(ctx, ccs, repo); err != nil {
+				return err
+			}
+		}
 
-		if _, err = r.pushCommit(ctx, opts); err != nil {
+		if _, err = pushCommit(ctx, r.gitserverClient, opts); err != nil {
 			return err
 		}
 	}
@@ -200,23 +569,61 @@ func (r *reconciler) updateChangeset(ctx context.Context, tx *Store, ch *campaig
 	// pushed the commit. We don't need to update anything on the codehost.
 	if !delta.NeedCodeHostUpdate() {
 		ch.FailureMessage = nil
+		ch.FailureClass = ""
+		ch.FailureCode = ""
+		ch.WaitReason = ""
 		return tx.UpdateChangeset(ctx, ch)
 	}
 
 	// Otherwise, we need to update the pull request on the code host.
+	title, body, err := propagatedTitleAndBody(ctx, tx, ch, spec)
+	if err != nil {
+		return err
+	}
+
 	cs := repos.Changeset{
-		Title:     spec.Spec.Title,
-		Body:      spec.Spec.Body,
-		BaseRef:   spec.Spec.BaseRef,
-		HeadRef:   git.EnsureRefPrefix(spec.Spec.HeadRef),
-		Repo:      repo,
-		Changeset: ch,
+		Title:         title,
+		Body:          body,
+		BaseRef:       spec.Spec.BaseRef,
+		HeadRef:       git.EnsureRefPrefix(spec.Spec.HeadRef),
+		ForkNamespace: ch.ExternalForkNamespace,
+		Repo:          repo,
+		Changeset:     ch,
 	}
 
 	if err := ccs.UpdateChangeset(ctx, &cs); err != nil {
 		return errors.Wrap(err, "updating changeset")
 	}
 
+	var reviewers, assignees []string
+	if delta.ReviewersChanged {
+		reviewers = spec.Spec.Reviewers
+	}
+	if delta.AssigneesChanged {
+		assignees = spec.Spec.Assignees
+	}
+	if err := assignChangeset(ctx, ccs, &cs, reviewers, assignees); err != nil {
+		return err
+	}
+
+	if delta.LabelsChanged || delta.MilestoneChanged {
+		var labels, removed []string
+		if delta.LabelsChanged {
+			prev, err := tx.GetChangesetSpecByID(ctx, ch.PreviousSpecID)
+			if err != nil {
+				return errors.Wrap(err, "failed to load previous changeset spec")
+			}
+			labels, removed = spec.Spec.Labels, subtractStrings(prev.Spec.Labels, spec.Spec.Labels)
+		}
+		var milestone string
+		if delta.MilestoneChanged {
+			milestone = spec.Spec.Milestone
+		}
+		if err := labelChangeset(ctx, ccs, &cs, labels, removed, milestone); err != nil {
+			return err
+		}
+	}
+
 	// We extract the events, compute derived state and upsert events because
 	// the update of the pull request might have changed the changeset on the
 	// code host.
@@ -228,20 +635,143 @@ func (r *reconciler) updateChangeset(ctx context.Context, tx *Store, ch *campaig
 	}
 
 	ch.FailureMessage = nil
+	ch.FailureClass = ""
+	ch.FailureCode = ""
+	ch.WaitReason = ""
 	return tx.UpdateChangeset(ctx, ch)
 }
 
-func (r *reconciler) pushCommit(ctx context.Context, opts protocol.CreateCommitFromPatchRequest) (string, error) {
-	ref, err := r.gitserverClient.CreateCommitFromPatch(ctx, opts)
+// propagatedTitleAndBody returns the title and body with which the given
+// changeset is updated on the code host to match the given spec, taking into
+// account the CampaignUpdatePropagation of the campaign that owns it.
+func propagatedTitleAndBody(ctx context.Context, tx *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec) (title, body string, err error) {
+	title, body = spec.Spec.Title, spec.Spec.Body
+	if ch.OwnedByCampaignID == 0 || ch.PreviousSpecID == 0 {
+		return title, body, nil
+	}
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: ch.OwnedByCampaignID, IncludeDeleted: true})
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to load campaign")
+	}
+	mode := campaign.UpdatePropagation
+	if mode == "" || mode == campaigns.CampaignUpdatePropagationOverwrite {
+		return title, body, nil
+	}
+
+	prev, err := tx.GetChangesetSpecByID(ctx, ch.PreviousSpecID)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to load previous changeset spec")
+	}
+
+	currentTitle, err := ch.Title()
+	if err != nil {
+		return "", "", err
+	}
+	currentBody, err := ch.Body()
+	if err != nil {
+		return "", "", err
+	}
+
+	title = propagateUpdate(mode, currentTitle, prev.Spec.Title, title, false)
+	body = propagateUpdate(mode, currentBody, prev.Spec.Body, body, true)
+	return title, body, nil
+}
+
+// propagateUpdate returns the value with which a title or body that's
+// currently set to current on the code host is replaced, when the spec
+// changes it from prev to next. Values that weren't edited on the code host
+// since they were set to prev are always replaced with next. Edited values
+// are handled according to the given mode; only appendable values get the
+// next value appended in CampaignUpdatePropagationAppend mode.
+func propagateUpdate(mode campaigns.CampaignUpdatePropagation, current, prev, next string, appendable bool) string {
+	if current == prev {
+		return next
+	}
+
+	switch mode {
+	case campaigns.CampaignUpdatePropagationPreserve:
+		return current
+
+	case campaigns.CampaignUpdatePropagationAppend:
+		if !appendable {
+			return current
+		}
+		// If the previous value was appended before, or maintainers only
+		// added text in front of it, we replace it instead of appending
+		// the next value again.
+		if prev != "" && strings.HasSuffix(current, prev) {
+			return strings.TrimSuffix(current, prev) + next
+		}
+		if current == "" {
+			return next
+		}
+		return current + "\n\n" + next
+
+	default:
+		return next
+	}
+}
+
+// assignChangeset requests reviews of the given changeset from the given
+// reviewers and assigns it to the given assignees, on code hosts that support
+// it. Nothing is removed from the changeset, so reviewers and assignees that
+// were removed from its spec have to be removed on the code host by hand.
+func assignChangeset(ctx context.Context, ccs repos.ChangesetSource, cs *repos.Changeset, reviewers, assignees []string) error {
+	if len(reviewers) > 0 {
+		if rrs, ok := ccs.(repos.ReviewRequestingChangesetSource); ok {
+			if err := rrs.RequestReviews(ctx, cs, reviewers); err != nil {
+				return errors.Wrap(err, "requesting reviews")
+			}
+		}
+	}
+	if len(assignees) > 0 {
+		if as, ok := ccs.(repos.AssigningChangesetSource); ok {
+			if err := as.AssignChangeset(ctx, cs, assignees); err != nil {
+				return errors.Wrap(err, "assigning changeset")
+			}
+		}
+	}
+	return nil
+}
+
+// labelChangeset adds the given labels to the given changeset, removes the
+// given removed labels from it and sets its milestone, on code hosts that
+// support it. An empty milestone leaves the milestone of the changeset
+// unchanged.
+func labelChangeset(ctx context.Context, ccs repos.ChangesetSource, cs *repos.Changeset, labels, removed []string, milestone string) error {
+	ls, ok := ccs.(repos.LabelingChangesetSource)
+	if !ok {
+		return nil
+	}
+	if len(labels) > 0 || len(removed) > 0 {
+		if err := ls.LabelChangeset(ctx, cs, labels, removed); err != nil {
+			return errors.Wrap(err, "labeling changeset")
+		}
+	}
+	if milestone != "" {
+		if err := ls.SetChangesetMilestone(ctx, cs, milestone); err != nil {
+			return errors.Wrap(err, "setting changeset milestone")
+		}
+	}
+	return nil
+}
+
+// pushCommit creates a commit from the patch in the given options and pushes
+// it to the code host.
+func pushCommit(ctx context.Context, gitserverClient GitserverClient, opts protocol.CreateCommitFromPatchRequest) (string, error) {
+	ref, err := gitserverClient.CreateCommitFromPatch(ctx, opts)
 	if err != nil {
 		if diffErr, ok := err.(*protocol.CreateCommitFromPatchError); ok {
-			return "", errors.Errorf(
+			// A patch that doesn't apply won't apply on retry either: the
+			// campaign spec needs to be updated.
+			return "", failure.WithClass(withErrorCode(errors.Errorf(
 				"creating commit from patch for repository %q: %s\n"+
 					"```\n"+
 					"$ %s\n"+
 					"%s\n"+
 					"```",
-				diffErr.RepositoryName, diffErr.InternalError, diffErr.Command, strings.TrimSpace(diffErr.CombinedOutput))
+				diffErr.RepositoryName, diffErr.InternalError, diffErr.Command, strings.TrimSpace(diffErr.CombinedOutput)), campaigns.ChangesetErrorCodeMergeConflict), failure.ClassUserConfig)
 		}
 		return "", err
 	}
@@ -249,7 +779,17 @@ func (r *reconciler) pushCommit(ctx context.Context, opts protocol.CreateCommitF
 	return ref, nil
 }
 
-func (r *reconciler) buildChangesetSource(repo *repos.Repo, extSvc *repos.ExternalService) (repos.ChangesetSource, error) {
+// buildChangesetSource returns a ChangesetSource for the given repository.
+// If cred is not nil, the source authenticates with it instead of with the
+// token of the external service.
+func (r *reconciler) buildChangesetSource(repo *repos.Repo, extSvc *repos.ExternalService, cred *campaigns.UserCredential) (repos.ChangesetSource, error) {
+	if cred != nil {
+		var err error
+		if extSvc, err = externalServiceWithUserCredential(extSvc, cred); err != nil {
+			return nil, err
+		}
+	}
+
 	sources, err := r.sourcer(extSvc)
 	if err != nil {
 		return nil, err
@@ -266,7 +806,24 @@ func (r *reconciler) buildChangesetSource(repo *repos.Repo, extSvc *repos.Extern
 	return ccs, nil
 }
 
-func buildCommitOpts(repo *repos.Repo, spec *campaigns.ChangesetSpec) (protocol.CreateCommitFromPatchRequest, error) {
+// ensureFork returns the namespace of the fork of the given repository that
+// changesets are published from, and the path of the fork to push their
+// branches to. The fork is created if it doesn't exist yet.
+func ensureFork(ctx context.Context, ccs repos.ChangesetSource, repo *repos.Repo) (namespace, path string, err error) {
+	fcs, ok := ccs.(repos.ForkableChangesetSource)
+	if !ok {
+		return "", "", errors.Errorf("publishing changesets from forks on the code host of repo %q is not implemented", repo.Name)
+	}
+
+	namespace, name, err := fcs.EnsureFork(ctx, repo)
+	if err != nil {
+		return "", "", errors.Wrap(err, "ensuring fork")
+	}
+
+	return namespace, namespace + "/" + name, nil
+}
+
+func buildCommitOpts(repoName api.RepoName, spec *campaigns.ChangesetSpec) (protocol.CreateCommitFromPatchRequest, error) {
 	var opts protocol.CreateCommitFromPatchRequest
 
 	desc := spec.Spec
@@ -282,7 +839,7 @@ func buildCommitOpts(repo *repos.Repo, spec *campaigns.ChangesetSpec) (protocol.
 	}
 
 	opts = protocol.CreateCommitFromPatchRequest{
-		Repo:       api.RepoName(repo.Name),
+		Repo:       repoName,
 		BaseCommit: api.CommitID(desc.BaseRev),
 		// IMPORTANT: We add a trailing newline here, otherwise `git apply`
 		// will fail with "corrupt patch at line <N>" where N is the last line.
@@ -331,7 +888,76 @@ type reconcilerAction struct {
 
 	// The delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
-	delta *changesetSpecDelta
+	delta *ChangesetSpecDelta
+}
+
+// PlannedOperation is an operation that the reconciler intends to perform on
+// a changeset the next time it processes it, and why.
+type PlannedOperation struct {
+	Operation campaigns.ReconcilerOperation
+	Reason    string
+}
+
+// PlanOperations returns the operations that the reconciler intends to
+// perform on the given changeset the next time it processes it, in order.
+// Changesets that the reconciler has completed have no planned operations.
+func PlanOperations(ctx context.Context, s *Store, ch *campaigns.Changeset) ([]PlannedOperation, error) {
+	if ch.ReconcilerState == campaigns.ReconcilerStateCompleted {
+		return nil, nil
+	}
+
+	action, err := determineAction(ctx, s, ch)
+	if err != nil {
+		return nil, err
+	}
+	return action.plannedOperations(), nil
+}
+
+// plannedOperations returns the operations the reconciler performs to take
+// the action.
+func (a reconcilerAction) plannedOperations() []PlannedOperation {
+	switch a.actionType {
+	case actionPublish:
+		return []PlannedOperation{
+			{
+				Operation: campaigns.ReconcilerOperationPush,
+				Reason:    "The branch of the changeset hasn't been pushed to the code host yet.",
+			},
+			{
+				Operation: campaigns.ReconcilerOperationPublish,
+				Reason:    "The changeset spec sets published to true, but the changeset hasn't been published yet.",
+			},
+		}
+
+	case actionUpdate:
+		commit, codeHost := a.delta.changedAttributes()
+
+		var ops []PlannedOperation
+		if len(commit) > 0 {
+			ops = append(ops, PlannedOperation{
+				Operation: campaigns.ReconcilerOperationPush,
+				Reason:    fmt.Sprintf("The %s changed in the current changeset spec.", joinWords(commit)),
+			})
+		}
+		if len(codeHost) > 0 {
+			ops = append(ops, PlannedOperation{
+				Operation: campaigns.ReconcilerOperationUpdate,
+				Reason:    fmt.Sprintf("The %s changed in the current changeset spec.", joinWords(codeHost)),
+			})
+		}
+		return ops
+
+	default:
+		return nil
+	}
+}
+
+// joinWords joins the given words into an enumeration such as "a, b and c".
+func joinWords(words []string) string {
+	if len(words) < 2 {
+		return strings.Join(words, "")
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
 }
 
 // determineAction looks at the given changeset to determine what action the
@@ -477,21 +1103,36 @@ func loadExternalService(ctx context.Context, reposStore repos.Store, repo *repo
 	return externalService, nil
 }
 
-func CompareChangesetSpecs(previous, current *campaigns.ChangesetSpec) (*changesetSpecDelta, error) {
-	delta := &changesetSpecDelta{}
+// CompareChangesetSpecs returns the ChangesetSpecDelta between the previous
+// and the current ChangesetSpec of a changeset. If there is no previous spec,
+// nothing changed.
+func CompareChangesetSpecs(previous, current *campaigns.ChangesetSpec) (*ChangesetSpecDelta, error) {
+	delta := &ChangesetSpecDelta{}
 
 	if previous == nil {
 		return delta, nil
 	}
 
 	if previous.Spec.Title != current.Spec.Title {
-		delta.titleChanged = true
+		delta.TitleChanged = true
 	}
 	if previous.Spec.Body != current.Spec.Body {
-		delta.bodyChanged = true
+		delta.BodyChanged = true
 	}
 	if previous.Spec.BaseRef != current.Spec.BaseRef {
-		delta.baseRefChanged = true
+		delta.BaseRefChanged = true
+	}
+	if !sameStrings(previous.Spec.Reviewers, current.Spec.Reviewers) {
+		delta.ReviewersChanged = true
+	}
+	if !sameStrings(previous.Spec.Assignees, current.Spec.Assignees) {
+		delta.AssigneesChanged = true
+	}
+	if !sameStrings(previous.Spec.Labels, current.Spec.Labels) {
+		delta.LabelsChanged = true
+	}
+	if previous.Spec.Milestone != current.Spec.Milestone {
+		delta.MilestoneChanged = true
 	}
 
 	// Diff
@@ -504,7 +1145,7 @@ func CompareChangesetSpecs(previous, current *campaigns.ChangesetSpec) (*changes
 		return nil, err
 	}
 	if previousDiff != currentDiff {
-		delta.diffChanged = true
+		delta.DiffChanged = true
 	}
 
 	// CommitMessage
@@ -517,30 +1158,101 @@ func CompareChangesetSpecs(previous, current *campaigns.ChangesetSpec) (*changes
 		return nil, err
 	}
 	if previousCommitMessage != currentCommitMessage {
-		delta.commitMessageChanged = true
+		delta.CommitMessageChanged = true
 	}
 
 	return delta, nil
 }
 
-type changesetSpecDelta struct {
-	titleChanged         bool
-	bodyChanged          bool
-	baseRefChanged       bool
-	diffChanged          bool
-	commitMessageChanged bool
+// ChangesetSpecDelta describes which attributes of a changeset change when
+// its ChangesetSpec is replaced by another one.
+type ChangesetSpecDelta struct {
+	TitleChanged         bool
+	BodyChanged          bool
+	BaseRefChanged       bool
+	DiffChanged          bool
+	CommitMessageChanged bool
+	ReviewersChanged     bool
+	AssigneesChanged     bool
+	LabelsChanged        bool
+	MilestoneChanged     bool
 }
 
-func (d *changesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
+func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
 
-func (d *changesetSpecDelta) NeedCommitUpdate() bool {
-	return d.diffChanged || d.commitMessageChanged
+func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
+	return d.DiffChanged || d.CommitMessageChanged
 }
 
-func (d *changesetSpecDelta) NeedCodeHostUpdate() bool {
-	return d.titleChanged || d.bodyChanged || d.baseRefChanged
+func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
+	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged ||
+		d.ReviewersChanged || d.AssigneesChanged || d.LabelsChanged || d.MilestoneChanged
 }
 
-func (d *changesetSpecDelta) AttributesChanged() bool {
+func (d *ChangesetSpecDelta) AttributesChanged() bool {
 	return d.NeedCommitUpdate() || d.NeedCodeHostUpdate()
 }
+
+// changedAttributes returns the human-readable names of the changed
+// attributes that require a new commit and of those that require an update
+// on the code host.
+func (d *ChangesetSpecDelta) changedAttributes() (commit, codeHost []string) {
+	for _, a := range []struct {
+		changed bool
+		name    string
+		commit  bool
+	}{
+		{d.DiffChanged, "diff", true},
+		{d.CommitMessageChanged, "commit message", true},
+		{d.TitleChanged, "title", false},
+		{d.BodyChanged, "body", false},
+		{d.BaseRefChanged, "base branch", false},
+		{d.ReviewersChanged, "reviewers", false},
+		{d.AssigneesChanged, "assignees", false},
+		{d.LabelsChanged, "labels", false},
+		{d.MilestoneChanged, "milestone", false},
+	} {
+		switch {
+		case !a.changed:
+		case a.commit:
+			commit = append(commit, a.name)
+		default:
+			codeHost = append(codeHost, a.name)
+		}
+	}
+	return commit, codeHost
+}
+
+// sameStrings returns whether a and b contain the same strings, regardless of
+// their order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+	return true
+}
+
+// subtractStrings returns the strings in a that aren't in b.
+func subtractStrings(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var diff []string
+	for _, s := range a {
+		if !in[s] {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
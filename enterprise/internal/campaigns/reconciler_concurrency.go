@@ -0,0 +1,150 @@
+package campaigns
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// defaultReconcilerConcurrency is the number of changesets the
+	// reconciler processes in parallel if campaigns.reconcilerConcurrency
+	// isn't set.
+	defaultReconcilerConcurrency = 5
+
+	// maxReconcilerConcurrency is the maximum number of changesets the
+	// reconciler processes in parallel, regardless of the configuration.
+	maxReconcilerConcurrency = 50
+)
+
+// reconcilerConcurrency limits how many changesets the reconciler processes
+// in parallel, in total and on each code host, to the limits configured in
+// the campaigns.reconcilerConcurrency site configuration.
+//
+// The worker is started with maxReconcilerConcurrency handlers, and the
+// limits are enforced before every dequeue, so that configuration changes
+// apply right away. Since the reconciler only runs on a single replica, the
+// changesets being processed are tracked in memory.
+type reconcilerConcurrency struct {
+	store *Store
+
+	// config returns the configured limits. If nil, conf.Get is used.
+	config func() *schema.CampaignsReconcilerConcurrency
+
+	mu sync.Mutex
+	// processing maps the IDs of the changesets being processed to the ID
+	// of their code host.
+	processing map[int64]string
+	// codeHosts maps the IDs of code hosts to the number of their
+	// changesets being processed.
+	codeHosts map[string]int
+}
+
+// limits returns the configured total and per code host limits.
+func (c *reconcilerConcurrency) limits() (total, perCodeHost int) {
+	var cfg *schema.CampaignsReconcilerConcurrency
+	if c.config != nil {
+		cfg = c.config()
+	} else {
+		cfg = conf.Get().CampaignsReconcilerConcurrency
+	}
+
+	total = defaultReconcilerConcurrency
+	if cfg != nil && cfg.Total > 0 {
+		total = cfg.Total
+	}
+	if total > maxReconcilerConcurrency {
+		total = maxReconcilerConcurrency
+	}
+
+	perCodeHost = total
+	if cfg != nil && cfg.PerCodeHost > 0 && cfg.PerCodeHost < total {
+		perCodeHost = cfg.PerCodeHost
+	}
+	return total, perCodeHost
+}
+
+// conditions returns whether another changeset can be processed, and if so,
+// the conditions that exclude the changesets of code hosts whose limit is
+// reached from being dequeued.
+func (c *reconcilerConcurrency) conditions() (bool, []*sqlf.Query) {
+	total, perCodeHost := c.limits()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.processing) >= total {
+		return false, nil
+	}
+
+	var saturated []string
+	for codeHost, n := range c.codeHosts {
+		if n >= perCodeHost {
+			saturated = append(saturated, codeHost)
+		}
+	}
+	if len(saturated) == 0 {
+		return true, nil
+	}
+	sort.Strings(saturated)
+
+	ids := make([]*sqlf.Query, 0, len(saturated))
+	for _, id := range saturated {
+		ids = append(ids, sqlf.Sprintf("%s", id))
+	}
+	return true, []*sqlf.Query{sqlf.Sprintf(saturatedCodeHostsConditionFmtstr, sqlf.Join(ids, ", "))}
+}
+
+var saturatedCodeHostsConditionFmtstr = `
+NOT EXISTS (
+  SELECT 1 FROM repo
+  WHERE repo.id = changesets.repo_id AND repo.external_service_id IN (%s)
+)
+`
+
+// acquire records that the given changeset is being processed.
+func (c *reconcilerConcurrency) acquire(ctx context.Context, ch *campaigns.Changeset) {
+	// Changesets whose code host can't be determined still count towards
+	// the total.
+	codeHost, err := c.store.GetRepoServiceID(ctx, ch.RepoID)
+	if err != nil {
+		log15.Warn("Loading code host of changeset", "changeset", ch.ID, "err", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.processing == nil {
+		c.processing = make(map[int64]string)
+		c.codeHosts = make(map[string]int)
+	}
+	c.processing[ch.ID] = codeHost
+	if codeHost != "" {
+		c.codeHosts[codeHost]++
+	}
+}
+
+// release records that the changeset with the given ID is no longer being
+// processed.
+func (c *reconcilerConcurrency) release(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	codeHost, ok := c.processing[id]
+	if !ok {
+		return
+	}
+	delete(c.processing, id)
+	if codeHost == "" {
+		return
+	}
+	if c.codeHosts[codeHost]--; c.codeHosts[codeHost] <= 0 {
+		delete(c.codeHosts, codeHost)
+	}
+}
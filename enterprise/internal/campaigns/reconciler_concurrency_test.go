@@ -0,0 +1,120 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestReconcilerConcurrencyLimits(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          *schema.CampaignsReconcilerConcurrency
+		wantTotal       int
+		wantPerCodeHost int
+	}{
+		{
+			name:            "not configured",
+			wantTotal:       defaultReconcilerConcurrency,
+			wantPerCodeHost: defaultReconcilerConcurrency,
+		},
+		{
+			name:            "total",
+			config:          &schema.CampaignsReconcilerConcurrency{Total: 20},
+			wantTotal:       20,
+			wantPerCodeHost: 20,
+		},
+		{
+			name:            "total and per code host",
+			config:          &schema.CampaignsReconcilerConcurrency{Total: 20, PerCodeHost: 4},
+			wantTotal:       20,
+			wantPerCodeHost: 4,
+		},
+		{
+			name:            "per code host above total",
+			config:          &schema.CampaignsReconcilerConcurrency{Total: 3, PerCodeHost: 4},
+			wantTotal:       3,
+			wantPerCodeHost: 3,
+		},
+		{
+			name:            "total above maximum",
+			config:          &schema.CampaignsReconcilerConcurrency{Total: 1000},
+			wantTotal:       maxReconcilerConcurrency,
+			wantPerCodeHost: maxReconcilerConcurrency,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &reconcilerConcurrency{config: func() *schema.CampaignsReconcilerConcurrency { return tc.config }}
+
+			total, perCodeHost := c.limits()
+			if total != tc.wantTotal || perCodeHost != tc.wantPerCodeHost {
+				t.Fatalf("wrong limits. want=(%d, %d), have=(%d, %d)", tc.wantTotal, tc.wantPerCodeHost, total, perCodeHost)
+			}
+		})
+	}
+}
+
+func TestReconcilerConcurrencyConditions(t *testing.T) {
+	c := &reconcilerConcurrency{
+		config: func() *schema.CampaignsReconcilerConcurrency {
+			return &schema.CampaignsReconcilerConcurrency{Total: 4, PerCodeHost: 2}
+		},
+	}
+
+	// Changesets are acquired in PreHandle, which needs the database to look
+	// up their code host, so they're recorded directly here.
+	process := func(id int64, codeHost string) {
+		if c.processing == nil {
+			c.processing = make(map[int64]string)
+			c.codeHosts = make(map[string]int)
+		}
+		c.processing[id] = codeHost
+		if codeHost != "" {
+			c.codeHosts[codeHost]++
+		}
+	}
+
+	assertConditions := func(t *testing.T, wantOk bool, wantCodeHosts ...interface{}) {
+		t.Helper()
+
+		ok, conditions := c.conditions()
+		if ok != wantOk {
+			t.Fatalf("wrong dequeueable. want=%t, have=%t", wantOk, ok)
+		}
+
+		var have []interface{}
+		for _, q := range conditions {
+			have = append(have, q.Args()...)
+		}
+		if diff := cmp.Diff(wantCodeHosts, have); diff != "" {
+			t.Fatalf("wrong excluded code hosts (-want +have):\n%s", diff)
+		}
+	}
+
+	assertConditions(t, true)
+
+	process(1, "https://github.com/")
+	process(2, "https://gitlab.com/")
+	assertConditions(t, true)
+
+	process(3, "https://github.com/")
+	assertConditions(t, true, "https://github.com/")
+
+	// Changesets without a code host only count towards the total.
+	process(4, "")
+	assertConditions(t, false)
+
+	c.release(4)
+	c.release(1)
+	assertConditions(t, true)
+
+	// Releasing a changeset that's not being processed is a no-op.
+	c.release(1)
+	assertConditions(t, true)
+	if len(c.processing) != 2 || c.codeHosts["https://github.com/"] != 1 {
+		t.Fatalf("wrong state after releasing: processing=%v, codeHosts=%v", c.processing, c.codeHosts)
+	}
+}
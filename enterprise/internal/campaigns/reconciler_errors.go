@@ -0,0 +1,177 @@
+package campaigns
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
+)
+
+// changesetRetryPolicies are how the reconciler retries changesets whose
+// processing failed with errors of the given codes. Errors of all other codes
+// need to be resolved by a user, so that the changesets are marked as
+// errored instead.
+var changesetRetryPolicies = map[campaigns.ChangesetErrorCode]changesetRetryPolicy{
+	campaigns.ChangesetErrorCodeRateLimit: {reason: campaigns.ChangesetWaitReasonRateLimit, backoff: rateLimitBackoff},
+	campaigns.ChangesetErrorCodeTransient: {reason: campaigns.ChangesetWaitReasonRetry, backoff: transientBackoff, exponential: true},
+	campaigns.ChangesetErrorCodeUnknown:   {reason: campaigns.ChangesetWaitReasonRetry, backoff: transientBackoff, exponential: true},
+}
+
+// changesetRetryPolicy is how the reconciler retries a changeset after an
+// error.
+type changesetRetryPolicy struct {
+	// reason is the reason that's recorded on the requeued changeset.
+	reason campaigns.ChangesetWaitReason
+	// backoff is how long the changeset is requeued for after its first
+	// failure.
+	backoff time.Duration
+	// exponential is whether the error counts as a failure of the changeset:
+	// the backoff doubles with every consecutive failure, up to
+	// maxRetryBackoff, and the changeset is marked as errored after
+	// reconcilerMaxAttempts attempts. Otherwise the error resolves itself
+	// over time, like an exceeded rate limit, and the changeset is retried
+	// with the same backoff until it succeeds.
+	exponential bool
+}
+
+// maxRetryBackoff is the longest the reconciler waits before retrying a
+// changeset whose processing failed.
+const maxRetryBackoff = time.Hour
+
+// defaultReconcilerMaxAttempts is the number of attempts after which the
+// reconciler gives up if campaigns.reconcilerMaxAttempts isn't set.
+const defaultReconcilerMaxAttempts = 5
+
+// reconcilerMaxAttempts returns the number of times the reconciler attempts
+// to process a changeset whose processing keeps failing, configured in the
+// campaigns.reconcilerMaxAttempts site configuration setting. It's a
+// variable so that it can be mocked in tests.
+var reconcilerMaxAttempts = func() int {
+	if n := conf.Get().CampaignsReconcilerMaxAttempts; n > 0 {
+		return n
+	}
+	return defaultReconcilerMaxAttempts
+}
+
+// retryBackoff returns how long the changeset waits before it's retried
+// after its numFailures-th consecutive failure, and whether it's retried at
+// all.
+func (p changesetRetryPolicy) retryBackoff(numFailures int64, maxAttempts int) (time.Duration, bool) {
+	if !p.exponential {
+		return p.backoff, true
+	}
+	if numFailures >= int64(maxAttempts) {
+		return 0, false
+	}
+
+	backoff := p.backoff
+	for i := int64(1); i < numFailures && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff, true
+}
+
+// ChangesetRetryBackoff returns how long the reconciler waits before
+// retrying a changeset whose processing failed with an error of the given
+// code for the first time, and whether such changesets are retried
+// automatically at all.
+func ChangesetRetryBackoff(code campaigns.ChangesetErrorCode) (time.Duration, bool) {
+	p, ok := changesetRetryPolicies[code]
+	return p.backoff, ok
+}
+
+// ChangesetRetryMaxAttempts returns the number of times the reconciler
+// attempts to process a changeset whose processing keeps failing with an
+// error of the given code, or 0 if the number isn't limited. Changesets
+// whose errors aren't retried automatically are attempted once.
+func ChangesetRetryMaxAttempts(code campaigns.ChangesetErrorCode) int {
+	p, ok := changesetRetryPolicies[code]
+	if !ok {
+		return 1
+	}
+	if !p.exponential {
+		return 0
+	}
+	return reconcilerMaxAttempts()
+}
+
+// changesetError is an error with an explicitly assigned ChangesetErrorCode.
+type changesetError struct {
+	error
+	code campaigns.ChangesetErrorCode
+}
+
+func (e *changesetError) Cause() error  { return e.error }
+func (e *changesetError) Unwrap() error { return e.error }
+
+// withErrorCode annotates err with the given ChangesetErrorCode, which takes
+// precedence over the code changesetErrorCode would otherwise infer.
+func withErrorCode(err error, code campaigns.ChangesetErrorCode) error {
+	if err == nil {
+		return nil
+	}
+	return &changesetError{error: err, code: code}
+}
+
+// repoArchivedError returns the error for changes to the given archived
+// repository, which the code host would reject.
+func repoArchivedError(repo *repos.Repo) error {
+	err := errors.Errorf("repository %q is archived and can't be changed", repo.Name)
+	return failure.WithClass(withErrorCode(err, campaigns.ChangesetErrorCodeRepoArchived), failure.ClassUserConfig)
+}
+
+// archivedMessages are parts of the error messages with which code hosts
+// reject changes to archived repositories.
+var archivedMessages = []string{
+	"was archived so",
+	"repository is archived",
+}
+
+// changesetErrorCode returns the ChangesetErrorCode of the given error
+// returned by the reconciler. Errors annotated with withErrorCode return
+// their code, all others are categorized by their failure.Class and message.
+// It returns the empty code for a nil error.
+func changesetErrorCode(err error) campaigns.ChangesetErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	for e := err; e != nil; {
+		if ce, ok := e.(*changesetError); ok {
+			return ce.code
+		}
+
+		c, ok := e.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		e = c.Cause()
+	}
+
+	// Code hosts reject changes to archived repositories with permission
+	// errors, so they need to be told apart before classifying the error.
+	msg := strings.ToLower(err.Error())
+	for _, m := range archivedMessages {
+		if strings.Contains(msg, m) {
+			return campaigns.ChangesetErrorCodeRepoArchived
+		}
+	}
+
+	switch failure.Classify(err) {
+	case failure.ClassAuth:
+		return campaigns.ChangesetErrorCodeAuth
+	case failure.ClassRateLimit:
+		return campaigns.ChangesetErrorCodeRateLimit
+	case failure.ClassTransientNetwork:
+		return campaigns.ChangesetErrorCodeTransient
+	}
+
+	return campaigns.ChangesetErrorCodeUnknown
+}
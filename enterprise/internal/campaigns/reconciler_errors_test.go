@@ -0,0 +1,174 @@
+package campaigns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestChangesetErrorCode(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want campaigns.ChangesetErrorCode
+	}{
+		{name: "nil", err: nil, want: ""},
+		{
+			name: "unknown",
+			err:  errors.New("boom"),
+			want: campaigns.ChangesetErrorCodeUnknown,
+		},
+		{
+			name: "auth",
+			err:  errors.Wrap(&errcode.HTTPErr{Status: http.StatusForbidden}, "creating changeset"),
+			want: campaigns.ChangesetErrorCodeAuth,
+		},
+		{
+			name: "rate limit",
+			err:  errors.Wrap(&errcode.HTTPErr{Status: http.StatusTooManyRequests}, "creating changeset"),
+			want: campaigns.ChangesetErrorCodeRateLimit,
+		},
+		{
+			name: "transient",
+			err:  errors.Wrap(context.DeadlineExceeded, "creating changeset"),
+			want: campaigns.ChangesetErrorCodeTransient,
+		},
+		{
+			name: "merge conflict",
+			err: func() error {
+				_, err := pushCommit(context.Background(), &ct.FakeGitserverClient{ResponseErr: &protocol.CreateCommitFromPatchError{
+					RepositoryName: "github.com/sourcegraph/sourcegraph",
+					InternalError:  "applying patch",
+					Command:        "git apply",
+					CombinedOutput: "error: patch failed",
+				}}, protocol.CreateCommitFromPatchRequest{})
+				return errors.Wrap(err, "publishing changeset")
+			}(),
+			want: campaigns.ChangesetErrorCodeMergeConflict,
+		},
+		{
+			name: "archived repository on Sourcegraph",
+			err:  errors.Wrap(repoArchivedError(&repos.Repo{Name: "github.com/sourcegraph/sourcegraph"}), "publishing changeset"),
+			want: campaigns.ChangesetErrorCodeRepoArchived,
+		},
+		{
+			name: "archived repository on code host",
+			err:  errors.Wrap(&errcode.HTTPErr{Status: http.StatusForbidden, Err: errors.New("Repository was archived so is read-only.")}, "creating changeset"),
+			want: campaigns.ChangesetErrorCodeRepoArchived,
+		},
+		{
+			name: "explicit code takes precedence over class",
+			err:  failure.WithClass(withErrorCode(errors.New("boom"), campaigns.ChangesetErrorCodeAuth), failure.ClassInternal),
+			want: campaigns.ChangesetErrorCodeAuth,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := changesetErrorCode(tc.err); have != tc.want {
+				t.Fatalf("wrong error code. want=%q, have=%q", tc.want, have)
+			}
+		})
+	}
+}
+
+func mockReconcilerMaxAttempts(t *testing.T, n int) {
+	t.Helper()
+
+	orig := reconcilerMaxAttempts
+	reconcilerMaxAttempts = func() int { return n }
+	t.Cleanup(func() { reconcilerMaxAttempts = orig })
+}
+
+func TestRetryReasonForError(t *testing.T) {
+	mockReconcilerMaxAttempts(t, 10)
+
+	transient := errors.Wrap(&errcode.HTTPErr{Status: http.StatusBadGateway}, "creating changeset")
+
+	for _, tc := range []struct {
+		name        string
+		err         error
+		numFailures int64
+		wantReason  campaigns.ChangesetWaitReason
+		wantBackoff time.Duration
+		wantOk      bool
+	}{
+		{
+			name:        "first transient failure",
+			err:         transient,
+			numFailures: 1,
+			wantReason:  campaigns.ChangesetWaitReasonRetry,
+			wantBackoff: transientBackoff,
+			wantOk:      true,
+		},
+		{
+			name:        "third transient failure",
+			err:         transient,
+			numFailures: 3,
+			wantReason:  campaigns.ChangesetWaitReasonRetry,
+			wantBackoff: 4 * transientBackoff,
+			wantOk:      true,
+		},
+		{
+			name:        "backoff is capped",
+			err:         transient,
+			numFailures: 9,
+			wantReason:  campaigns.ChangesetWaitReasonRetry,
+			wantBackoff: maxRetryBackoff,
+			wantOk:      true,
+		},
+		{
+			name:        "attempts used up",
+			err:         transient,
+			numFailures: 10,
+		},
+		{
+			name:        "unknown",
+			err:         errors.New("boom"),
+			numFailures: 2,
+			wantReason:  campaigns.ChangesetWaitReasonRetry,
+			wantBackoff: 2 * transientBackoff,
+			wantOk:      true,
+		},
+		{
+			name:        "auth",
+			err:         errors.Wrap(&errcode.HTTPErr{Status: http.StatusUnauthorized}, "creating changeset"),
+			numFailures: 1,
+		},
+		{
+			// Exceeded rate limits aren't failures, see TestWaitReasonForError.
+			name:        "rate limit",
+			err:         errors.Wrap(&errcode.HTTPErr{Status: http.StatusTooManyRequests}, "creating changeset"),
+			numFailures: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, backoff, ok := retryReasonForError(tc.err, tc.numFailures)
+			if reason != tc.wantReason || backoff != tc.wantBackoff || ok != tc.wantOk {
+				t.Errorf("got (%q, %s, %t), want (%q, %s, %t)", reason, backoff, ok, tc.wantReason, tc.wantBackoff, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestChangesetRetryMaxAttempts(t *testing.T) {
+	mockReconcilerMaxAttempts(t, 7)
+
+	for code, want := range map[campaigns.ChangesetErrorCode]int{
+		campaigns.ChangesetErrorCodeTransient: 7,
+		campaigns.ChangesetErrorCodeUnknown:   7,
+		campaigns.ChangesetErrorCodeRateLimit: 0,
+		campaigns.ChangesetErrorCodeAuth:      1,
+	} {
+		if have := ChangesetRetryMaxAttempts(code); have != want {
+			t.Errorf("wrong max attempts for %s. want=%d, have=%d", code, want, have)
+		}
+	}
+}
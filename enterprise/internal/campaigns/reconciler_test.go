@@ -2,20 +2,28 @@ package campaigns
 
 import (
 	"context"
+	"net/http"
+	"reflect"
 	"time"
 
 	"testing"
 
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestReconcilerProcess(t *testing.T) {
@@ -51,10 +59,20 @@ func TestReconcilerProcess(t *testing.T) {
 		sourcerMetadata interface{}
 		// Whether or not the source responds to CreateChangeset with "already exists"
 		alreadyExists bool
+		// Whether or not campaigns.pushToFork is enabled in the site config
+		pushToFork bool
+		// Whether or not the applier of the campaign has a credential for
+		// the code host
+		userCredential bool
+		// Whether or not the campaign owning the changeset has been deleted
+		campaignDeleted bool
 
 		wantCreateOnHostCode bool
 		wantUpdateOnCodeHost bool
 		wantGitserverCommit  bool
+		wantPushRemotePath   string
+		wantPushUsername     string
+		wantForkNamespace    string
 
 		wantChangeset changesetAssertions
 	}
@@ -123,6 +141,50 @@ func TestReconcilerProcess(t *testing.T) {
 				body:  "Remote body",
 			},
 		},
+		"publish changeset of deleted campaign": {
+			campaignDeleted: true,
+			currentSpec: &testSpecOpts{
+				headRef:   "refs/heads/head-ref-on-github",
+				published: true,
+			},
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStateUnpublished,
+			},
+			sourcerMetadata: githubPR,
+
+			wantCreateOnHostCode: false,
+			wantUpdateOnCodeHost: false,
+			wantGitserverCommit:  false,
+
+			wantChangeset: changesetAssertions{
+				publicationState: campaigns.ChangesetPublicationStateUnpublished,
+			},
+		},
+		"publish changeset with user credential": {
+			userCredential: true,
+			currentSpec: &testSpecOpts{
+				headRef:   "refs/heads/head-ref-on-github",
+				published: true,
+			},
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStateUnpublished,
+			},
+			sourcerMetadata: githubPR,
+
+			wantCreateOnHostCode: true,
+			wantUpdateOnCodeHost: false,
+			wantGitserverCommit:  true,
+			wantPushUsername:     "user-token",
+
+			wantChangeset: changesetAssertions{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalID:       "12345",
+				externalBranch:   "head-ref-on-github",
+
+				title: "Remote title",
+				body:  "Remote body",
+			},
+		},
 		"retry publish changeset": {
 			// This test case makes sure that everything works when the code host says
 			// that the changeset already exists.
@@ -300,6 +362,29 @@ func TestReconcilerProcess(t *testing.T) {
 				// failureMessage should be nil
 			},
 		},
+		"publish changeset to fork": {
+			currentSpec: &testSpecOpts{
+				headRef:   "refs/heads/head-ref-on-github",
+				published: true,
+			},
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStateUnpublished,
+			},
+			sourcerMetadata: githubPR,
+			pushToFork:      true,
+
+			wantCreateOnHostCode: true,
+			wantUpdateOnCodeHost: false,
+			wantGitserverCommit:  true,
+			wantPushRemotePath:   "alice/sourcegraph",
+			wantForkNamespace:    "alice",
+
+			wantChangeset: changesetAssertions{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalID:       "12345",
+				externalBranch:   "head-ref-on-github",
+			},
+		},
 		"reprocess published changeset without changes": {
 			// ChangesetSpec is already published and has no previous spec.
 			// Simply a reprocessing of the same changeset.
@@ -333,7 +418,12 @@ func TestReconcilerProcess(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// Clean up database.
-			truncateTables(t, dbconn.Global, "changeset_events", "changesets", "campaigns", "campaign_specs", "changeset_specs")
+			truncateTables(t, dbconn.Global, "changeset_events", "changesets", "campaigns", "campaign_specs", "changeset_specs", "user_credentials")
+
+			if tc.pushToFork {
+				conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{CampaignsPushToFork: true}})
+				defer conf.Mock(nil)
+			}
 
 			// Create necessary associations.
 			campaignSpec := createCampaignSpec(t, ctx, store, "reconciler-test-campaign", admin.ID)
@@ -370,6 +460,27 @@ func TestReconcilerProcess(t *testing.T) {
 			if previousSpec != nil {
 				changesetOpts.previousSpec = previousSpec.ID
 			}
+			if tc.userCredential {
+				changesetOpts.ownedByCampaign = campaign.ID
+
+				cred := &campaigns.UserCredential{
+					UserID:              admin.ID,
+					ExternalServiceType: rs[0].ExternalRepo.ServiceType,
+					ExternalServiceID:   rs[0].ExternalRepo.ServiceID,
+					Token:               "user-token",
+				}
+				if err := store.UpsertUserCredential(ctx, cred); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.campaignDeleted {
+				changesetOpts.ownedByCampaign = campaign.ID
+
+				deletedAt := clock()
+				if _, err := store.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, DeletedAt: &deletedAt}); err != nil {
+					t.Fatal(err)
+				}
+			}
 			changeset := createChangeset(t, ctx, store, changesetOpts)
 
 			// Setup the assertion: wantChangeset is what we want in the database
@@ -389,6 +500,8 @@ func TestReconcilerProcess(t *testing.T) {
 				Err:             nil,
 				ChangesetExists: tc.alreadyExists,
 				FakeMetadata:    metadata,
+				ForkNamespace:   "alice",
+				ForkName:        "sourcegraph",
 			}
 			if changesetSpec != nil {
 				fakeSource.WantHeadRef = changesetSpec.Spec.HeadRef
@@ -411,6 +524,7 @@ func TestReconcilerProcess(t *testing.T) {
 
 			assertions := tc.wantChangeset
 			assertions.repo = rs[0].ID
+			assertions.ownedByCampaign = changesetOpts.ownedByCampaign
 			if changesetSpec != nil {
 				assertions.currentSpec = changesetSpec.ID
 			}
@@ -424,6 +538,18 @@ func TestReconcilerProcess(t *testing.T) {
 				t.Fatalf("wrong CreateCommitFromPatch call. wantCalled=%t, wasCalled=%t", want, have)
 			}
 
+			if have, want := gitClient.CreateCommitFromPatchReq.PushRemotePath, tc.wantPushRemotePath; have != want {
+				t.Fatalf("wrong PushRemotePath. want=%q, have=%q", want, have)
+			}
+
+			if have, want := gitClient.CreateCommitFromPatchReq.PushUsername, tc.wantPushUsername; have != want {
+				t.Fatalf("wrong PushUsername. want=%q, have=%q", want, have)
+			}
+
+			if have, want := haveChangeset.ExternalForkNamespace, tc.wantForkNamespace; have != want {
+				t.Fatalf("wrong ExternalForkNamespace. want=%q, have=%q", want, have)
+			}
+
 			if have, want := fakeSource.CreateChangesetCalled, tc.wantCreateOnHostCode; have != want {
 				t.Fatalf("wrong CreateChangeset call. wantCalled=%t, wasCalled=%t", want, have)
 			}
@@ -435,6 +561,177 @@ func TestReconcilerProcess(t *testing.T) {
 	}
 }
 
+func TestWaitReasonForError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantReason  campaigns.ChangesetWaitReason
+		wantBackoff time.Duration
+		wantOk      bool
+	}{
+		{name: "nil", err: nil},
+		{name: "other", err: errors.New("boom")},
+		{
+			name:        "rate limit",
+			err:         errors.Wrap(&errcode.HTTPErr{Status: http.StatusTooManyRequests}, "creating changeset"),
+			wantReason:  campaigns.ChangesetWaitReasonRateLimit,
+			wantBackoff: rateLimitBackoff,
+			wantOk:      true,
+		},
+		{
+			name:        "clone in progress",
+			err:         errors.Wrap(&vcs.RepoNotExistError{Repo: "github.com/sourcegraph/sourcegraph", CloneInProgress: true}, "creating commit"),
+			wantReason:  campaigns.ChangesetWaitReasonDependency,
+			wantBackoff: dependencyBackoff,
+			wantOk:      true,
+		},
+		{
+			name: "repo not found",
+			err:  &vcs.RepoNotExistError{Repo: "github.com/sourcegraph/sourcegraph"},
+		},
+		{
+			name:        "campaign paused",
+			err:         &campaignPausedErr{campaignID: 1},
+			wantReason:  campaigns.ChangesetWaitReasonPaused,
+			wantBackoff: pausedBackoff,
+			wantOk:      true,
+		},
+		{
+			// Transient errors are failures, see TestRetryReasonForError.
+			name: "transient",
+			err:  errors.Wrap(&errcode.HTTPErr{Status: http.StatusBadGateway}, "creating changeset"),
+		},
+		{
+			name: "auth",
+			err:  errors.Wrap(&errcode.HTTPErr{Status: http.StatusUnauthorized}, "creating changeset"),
+		},
+		{
+			name: "repo archived",
+			err:  repoArchivedError(&repos.Repo{Name: "github.com/sourcegraph/sourcegraph"}),
+		},
+		{
+			name:        "rollout window",
+			err:         &rolloutWindowErr{delay: 72 * time.Second},
+			wantReason:  campaigns.ChangesetWaitReasonRolloutWindow,
+			wantBackoff: 72 * time.Second,
+			wantOk:      true,
+		},
+		{
+			name:        "publication budget",
+			err:         errors.Wrap(&publicationBudgetErr{baseURL: "https://github.com/", delay: 40 * time.Second}, "publishing changeset"),
+			wantReason:  campaigns.ChangesetWaitReasonRateLimit,
+			wantBackoff: 40 * time.Second,
+			wantOk:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, backoff, ok := waitReasonForError(tc.err)
+			if reason != tc.wantReason || backoff != tc.wantBackoff || ok != tc.wantOk {
+				t.Errorf("got (%q, %s, %t), want (%q, %s, %t)", reason, backoff, ok, tc.wantReason, tc.wantBackoff, tc.wantOk)
+			}
+		})
+	}
+}
+
+type fakeChangesetSyncEnqueuer struct {
+	ids []int64
+}
+
+func (f *fakeChangesetSyncEnqueuer) EnqueueChangesetSyncs(ctx context.Context, ids []int64) error {
+	f.ids = append(f.ids, ids...)
+	return nil
+}
+
+func TestReconcilerPostHandle(t *testing.T) {
+	ctx := context.Background()
+	syncs := &fakeChangesetSyncEnqueuer{}
+	r := &reconciler{syncs: syncs}
+
+	r.markPublished(1)
+
+	// Changesets that weren't published aren't synced.
+	r.PostHandle(ctx, &campaigns.Changeset{ID: 2})
+	if len(syncs.ids) != 0 {
+		t.Fatalf("unexpected syncs enqueued: %v", syncs.ids)
+	}
+
+	r.PostHandle(ctx, &campaigns.Changeset{ID: 1})
+	if want := []int64{1}; !reflect.DeepEqual(syncs.ids, want) {
+		t.Fatalf("wrong syncs enqueued. want=%v, have=%v", want, syncs.ids)
+	}
+
+	// The sync is only enqueued once per publication.
+	r.PostHandle(ctx, &campaigns.Changeset{ID: 1})
+	if want := []int64{1}; !reflect.DeepEqual(syncs.ids, want) {
+		t.Fatalf("wrong syncs enqueued. want=%v, have=%v", want, syncs.ids)
+	}
+}
+
+func TestReconcilerDequeueSkipsDeletedCampaigns(t *testing.T) {
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	store := NewStore(dbconn.Global)
+	admin := createTestUser(ctx, t)
+	rs, _ := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	campaignSpec := createCampaignSpec(t, ctx, store, "dequeue-deleted", admin.ID)
+	campaign := createCampaign(t, ctx, store, "dequeue-deleted", admin.ID, campaignSpec.ID)
+	changeset := createChangeset(t, ctx, store, testChangesetOpts{
+		repo:             rs[0].ID,
+		campaign:         campaign.ID,
+		ownedByCampaign:  campaign.ID,
+		publicationState: campaigns.ChangesetPublicationStateUnpublished,
+	})
+	changeset.ReconcilerState = campaigns.ReconcilerStateQueued
+	if err := store.UpdateChangeset(ctx, changeset); err != nil {
+		t.Fatal(err)
+	}
+
+	setDeletedAt := func(deletedAt time.Time) {
+		if _, err := store.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, DeletedAt: &deletedAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &reconciler{store: store}
+	workerStore := newReconcilerWorkerStore(store)
+	dequeue := func() (int, bool) {
+		_, conditions, err := r.PreDequeue(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record, tx, ok, err := workerStore.Dequeue(ctx, conditions.([]*sqlf.Query))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			return 0, false
+		}
+		if err := tx.Done(nil); err != nil {
+			t.Fatal(err)
+		}
+		return record.RecordID(), true
+	}
+
+	setDeletedAt(time.Now())
+	if id, ok := dequeue(); ok {
+		t.Fatalf("changeset %d of deleted campaign dequeued", id)
+	}
+
+	// Once the campaign is restored, its changesets are processed again.
+	setDeletedAt(time.Time{})
+	id, ok := dequeue()
+	if !ok {
+		t.Fatal("changeset of restored campaign not dequeued")
+	}
+	if have, want := int64(id), changeset.ID; have != want {
+		t.Fatalf("wrong changeset dequeued. want=%d, have=%d", want, have)
+	}
+}
+
 func buildGithubPR(now time.Time, externalID, title, body, headRef string) interface{} {
 	return &github.PullRequest{
 		ID:          externalID,
@@ -456,3 +753,264 @@ func buildGithubPR(now time.Time, externalID, title, body, headRef string) inter
 		UpdatedAt: now,
 	}
 }
+
+func TestReconcilerActionPlannedOperations(t *testing.T) {
+	tests := []struct {
+		name   string
+		action reconcilerAction
+		want   []PlannedOperation
+	}{
+		{
+			name:   "none",
+			action: reconcilerAction{actionType: actionNone},
+		},
+		{
+			name:   "publish",
+			action: reconcilerAction{actionType: actionPublish},
+			want: []PlannedOperation{
+				{
+					Operation: campaigns.ReconcilerOperationPush,
+					Reason:    "The branch of the changeset hasn't been pushed to the code host yet.",
+				},
+				{
+					Operation: campaigns.ReconcilerOperationPublish,
+					Reason:    "The changeset spec sets published to true, but the changeset hasn't been published yet.",
+				},
+			},
+		},
+		{
+			name: "update commit",
+			action: reconcilerAction{
+				actionType: actionUpdate,
+				delta:      &ChangesetSpecDelta{DiffChanged: true},
+			},
+			want: []PlannedOperation{
+				{
+					Operation: campaigns.ReconcilerOperationPush,
+					Reason:    "The diff changed in the current changeset spec.",
+				},
+			},
+		},
+		{
+			name: "update commit and code host",
+			action: reconcilerAction{
+				actionType: actionUpdate,
+				delta: &ChangesetSpecDelta{
+					CommitMessageChanged: true,
+					TitleChanged:         true,
+					BodyChanged:          true,
+					LabelsChanged:        true,
+				},
+			},
+			want: []PlannedOperation{
+				{
+					Operation: campaigns.ReconcilerOperationPush,
+					Reason:    "The commit message changed in the current changeset spec.",
+				},
+				{
+					Operation: campaigns.ReconcilerOperationUpdate,
+					Reason:    "The title, body and labels changed in the current changeset spec.",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := tc.action.plannedOperations(); !reflect.DeepEqual(have, tc.want) {
+				t.Fatalf("wrong planned operations.\nwant=%+v\nhave=%+v", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestCompareChangesetSpecsCodeHostMetadata(t *testing.T) {
+	spec := func(reviewers, assignees []string) *campaigns.ChangesetSpec {
+		return &campaigns.ChangesetSpec{Spec: &campaigns.ChangesetSpecDescription{
+			Reviewers: reviewers,
+			Assignees: assignees,
+			Commits:   []campaigns.GitCommitDescription{{Message: "msg", Diff: "diff"}},
+		}}
+	}
+	labeled := func(labels []string, milestone string) *campaigns.ChangesetSpec {
+		s := spec(nil, nil)
+		s.Spec.Labels = labels
+		s.Spec.Milestone = milestone
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		prev, curr *campaigns.ChangesetSpec
+		want       *ChangesetSpecDelta
+	}{
+		{
+			name: "unchanged",
+			prev: spec([]string{"alice", "bob"}, []string{"carol"}),
+			curr: spec([]string{"bob", "alice"}, []string{"carol"}),
+			want: &ChangesetSpecDelta{},
+		},
+		{
+			name: "reviewers changed",
+			prev: spec([]string{"alice"}, nil),
+			curr: spec([]string{"alice", "org/team"}, nil),
+			want: &ChangesetSpecDelta{ReviewersChanged: true},
+		},
+		{
+			name: "assignees changed",
+			prev: spec(nil, []string{"carol"}),
+			curr: spec(nil, []string{"dave"}),
+			want: &ChangesetSpecDelta{AssigneesChanged: true},
+		},
+		{
+			name: "labels reordered",
+			prev: labeled([]string{"a", "b"}, "v1"),
+			curr: labeled([]string{"b", "a"}, "v1"),
+			want: &ChangesetSpecDelta{},
+		},
+		{
+			name: "labels changed",
+			prev: labeled([]string{"a"}, "v1"),
+			curr: labeled([]string{"a", "b"}, "v1"),
+			want: &ChangesetSpecDelta{LabelsChanged: true},
+		},
+		{
+			name: "milestone changed",
+			prev: labeled(nil, "v1"),
+			curr: labeled(nil, "v2"),
+			want: &ChangesetSpecDelta{MilestoneChanged: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := CompareChangesetSpecs(tc.prev, tc.curr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *have != *tc.want {
+				t.Fatalf("wrong delta. want=%s, have=%s", tc.want, have)
+			}
+			if have.NeedCodeHostUpdate() != (*tc.want != ChangesetSpecDelta{}) {
+				t.Fatalf("wrong NeedCodeHostUpdate for delta %s", have)
+			}
+		})
+	}
+}
+
+func TestAssignChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		src := &ct.FakeChangesetSource{}
+		err := assignChangeset(ctx, src, &repos.Changeset{}, []string{"alice", "org/team"}, []string{"carol"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(src.Reviewers, []string{"alice", "org/team"}) {
+			t.Fatalf("wrong reviewers: %v", src.Reviewers)
+		}
+		if !reflect.DeepEqual(src.Assignees, []string{"carol"}) {
+			t.Fatalf("wrong assignees: %v", src.Assignees)
+		}
+	})
+
+	t.Run("nothing to assign", func(t *testing.T) {
+		src := &ct.FakeChangesetSource{}
+		if err := assignChangeset(ctx, src, &repos.Changeset{}, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		if src.RequestReviewsCalled || src.AssignChangesetCalled {
+			t.Fatal("code host called without reviewers or assignees")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		src := &ct.FakeChangesetSource{Err: errors.New("boom")}
+		if err := assignChangeset(ctx, src, &repos.Changeset{}, []string{"alice"}, nil); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+	})
+}
+
+func TestLabelChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		src := &ct.FakeChangesetSource{}
+		err := labelChangeset(ctx, src, &repos.Changeset{}, []string{"a", "b"}, []string{"c"}, "v1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(src.Labels, []string{"a", "b"}) {
+			t.Fatalf("wrong labels: %v", src.Labels)
+		}
+		if !reflect.DeepEqual(src.RemovedLabels, []string{"c"}) {
+			t.Fatalf("wrong removed labels: %v", src.RemovedLabels)
+		}
+		if src.Milestone != "v1" {
+			t.Fatalf("wrong milestone: %q", src.Milestone)
+		}
+	})
+
+	t.Run("nothing to label", func(t *testing.T) {
+		src := &ct.FakeChangesetSource{}
+		if err := labelChangeset(ctx, src, &repos.Changeset{}, nil, nil, ""); err != nil {
+			t.Fatal(err)
+		}
+		if src.LabelChangesetCalled {
+			t.Fatal("code host called without labels")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		src := &ct.FakeChangesetSource{Err: errors.New("boom")}
+		if err := labelChangeset(ctx, src, &repos.Changeset{}, nil, nil, "v1"); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+	})
+}
+
+func TestSubtractStrings(t *testing.T) {
+	have := subtractStrings([]string{"a", "b", "c"}, []string{"b", "d"})
+	if want := []string{"a", "c"}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("wrong result. want=%v, have=%v", want, have)
+	}
+}
+
+func TestPropagateUpdate(t *testing.T) {
+	const (
+		overwrite = campaigns.CampaignUpdatePropagationOverwrite
+		appnd     = campaigns.CampaignUpdatePropagationAppend
+		preserve  = campaigns.CampaignUpdatePropagationPreserve
+	)
+
+	tests := []struct {
+		name       string
+		mode       campaigns.CampaignUpdatePropagation
+		current    string
+		prev       string
+		next       string
+		appendable bool
+		want       string
+	}{
+		{name: "unedited overwrite", mode: overwrite, current: "old", prev: "old", next: "new", want: "new"},
+		{name: "unedited preserve", mode: preserve, current: "old", prev: "old", next: "new", want: "new"},
+		{name: "unedited append", mode: appnd, current: "old", prev: "old", next: "new", appendable: true, want: "new"},
+		{name: "edited overwrite", mode: overwrite, current: "edited", prev: "old", next: "new", want: "new"},
+		{name: "edited preserve", mode: preserve, current: "edited", prev: "old", next: "new", appendable: true, want: "edited"},
+		{name: "edited append not appendable", mode: appnd, current: "edited", prev: "old", next: "new", want: "edited"},
+		{name: "edited append", mode: appnd, current: "edited", prev: "old", next: "new", appendable: true, want: "edited\n\nnew"},
+		{name: "edited append replaces previous", mode: appnd, current: "edited\n\nold", prev: "old", next: "new", appendable: true, want: "edited\n\nnew"},
+		{name: "cleared append", mode: appnd, current: "", prev: "old", next: "new", appendable: true, want: "new"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := propagateUpdate(tc.mode, tc.current, tc.prev, tc.next, tc.appendable)
+			if have != tc.want {
+				t.Fatalf("wrong result. want=%q, have=%q", tc.want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package resolvers
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+)
+
+var _ graphqlbackend.CampaignsAdvisoryLockResolver = &advisoryLockResolver{}
+
+type advisoryLockResolver struct {
+	lock *ee.AdvisoryLock
+}
+
+func (r *advisoryLockResolver) Kind() string {
+	return strings.ToUpper(r.lock.Kind)
+}
+
+func (r *advisoryLockResolver) Name() *string {
+	if r.lock.Name == "" {
+		return nil
+	}
+	return &r.lock.Name
+}
+
+func (r *advisoryLockResolver) Holder() string {
+	return r.lock.Holder
+}
+
+func (r *advisoryLockResolver) Pid() int32 {
+	return r.lock.PID
+}
+
+func (r *advisoryLockResolver) ConnectedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.lock.ConnectedAt}
+}
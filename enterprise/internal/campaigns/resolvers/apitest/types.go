@@ -85,6 +85,7 @@ type Campaign struct {
 	ID                      string
 	Name                    string
 	Description             string
+	DescriptionHTML         string
 	InitialApplier          User
 	LastApplier             User
 	LastAppliedAt           string
@@ -96,6 +97,20 @@ type Campaign struct {
 	Changesets              ChangesetConnection
 	ChangesetCountsOverTime []ChangesetCounts
 	DiffStat                DiffStat
+	Stats                   CampaignStats
+	Activity                CampaignActivityConnection
+}
+
+type CampaignActivityConnection struct {
+	Nodes      []struct{ ID string }
+	TotalCount int
+	PageInfo   PageInfo
+}
+
+type CampaignStats struct {
+	Total, Unpublished, Open, Merged, Closed, Archived int32
+	DiffStat                                           DiffStat
+	LastSyncedAt                                       *string
 }
 
 type CampaignConnection struct {
@@ -143,6 +158,8 @@ type Changeset struct {
 	ExternalURL      ExternalURL
 	ReviewState      string
 	CheckState       string
+	Mergeable        string
+	WaitReason       *ChangesetWaitReason
 	Events           ChangesetEventConnection
 	Head             GitRef
 	Base             GitRef
@@ -152,6 +169,12 @@ type Changeset struct {
 	Labels []Label
 }
 
+type ChangesetWaitReason struct {
+	Kind          string
+	Until         string
+	QueuePosition int
+}
+
 type Comparison struct {
 	Typename  string `json:"__typename"`
 	FileDiffs FileDiffs
@@ -164,10 +187,11 @@ type Label struct {
 }
 
 type ChangesetConnection struct {
-	Nodes      []Changeset
-	TotalCount int
-	PageInfo   PageInfo
-	Stats      ChangesetConnectionStats
+	Nodes       []Changeset
+	TotalCount  int
+	HiddenCount int
+	PageInfo    PageInfo
+	Stats       ChangesetConnectionStats
 }
 
 type ChangesetConnectionStats struct {
@@ -175,7 +199,9 @@ type ChangesetConnectionStats struct {
 	Open        int
 	Merged      int
 	Closed      int
+	Conflicting int
 	Total       int
+	Hidden      int
 }
 
 type ChangesetCounts struct {
@@ -211,6 +237,7 @@ type CampaignSpec struct {
 
 	CreatedAt graphqlbackend.DateTime
 	ExpiresAt *graphqlbackend.DateTime
+	PinnedAt  *graphqlbackend.DateTime
 }
 
 type ChangesetSpec struct {
@@ -260,4 +287,5 @@ type GitCommitDescription struct {
 
 type PageInfo struct {
 	HasNextPage bool
+	EndCursor   *string
 }
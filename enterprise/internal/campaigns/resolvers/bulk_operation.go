@@ -0,0 +1,162 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const bulkOperationIDKind = "BulkOperation"
+
+func marshalBulkOperationID(id string) graphql.ID {
+	return relay.MarshalID(bulkOperationIDKind, id)
+}
+
+func unmarshalBulkOperationID(id graphql.ID) (bulkGroup string, err error) {
+	err = relay.UnmarshalSpec(id, &bulkGroup)
+	return
+}
+
+var _ graphqlbackend.BulkOperationResolver = &bulkOperationResolver{}
+
+type bulkOperationResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+	operation   *campaigns.BulkOperation
+}
+
+func (r *bulkOperationResolver) ID() graphql.ID {
+	return marshalBulkOperationID(r.operation.ID)
+}
+
+func (r *bulkOperationResolver) Type() string {
+	return string(r.operation.Type)
+}
+
+func (r *bulkOperationResolver) State() string {
+	return string(r.operation.State)
+}
+
+func (r *bulkOperationResolver) Progress() float64 {
+	return r.operation.Progress
+}
+
+func (r *bulkOperationResolver) Campaign(ctx context.Context) (graphqlbackend.CampaignResolver, error) {
+	campaign, err := r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: r.operation.CampaignID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *bulkOperationResolver) Initiator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.operation.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *bulkOperationResolver) ChangesetCount() int32 {
+	return r.operation.ChangesetCount
+}
+
+func (r *bulkOperationResolver) Items(ctx context.Context) ([]graphqlbackend.BulkOperationItemResolver, error) {
+	jobs, err := r.store.ListChangesetJobs(ctx, ee.ListChangesetJobsOpts{BulkGroup: r.operation.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.ChangesetID)
+	}
+	cs, _, err := r.store.ListChangesets(ctx, ee.ListChangesetsOpts{IDs: ids, Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+	changesetsByID := make(map[int64]*campaigns.Changeset, len(cs))
+	for _, c := range cs {
+		changesetsByID[c.ID] = c
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	reposByID, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]graphqlbackend.BulkOperationItemResolver, 0, len(jobs))
+	for _, j := range jobs {
+		c, ok := changesetsByID[j.ChangesetID]
+		if !ok {
+			continue
+		}
+
+		// If the repository is not in reposByID, it was filtered out by the
+		// authz-filter and a hidden changeset is returned.
+		repo := reposByID[c.RepoID]
+		item := &bulkOperationItemResolver{
+			changeset: NewChangesetResolver(r.store, r.httpFactory, c, repo),
+			job:       j,
+		}
+		// 🚨 SECURITY: Failure messages can contain the name of the
+		// repository, so they're not revealed for hidden changesets.
+		if repo != nil {
+			item.failureMessage = j.FailureMessage
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (r *bulkOperationResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.operation.CreatedAt}
+}
+
+func (r *bulkOperationResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.operation.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.operation.FinishedAt}
+}
+
+var _ graphqlbackend.BulkOperationItemResolver = &bulkOperationItemResolver{}
+
+type bulkOperationItemResolver struct {
+	changeset      graphqlbackend.ChangesetResolver
+	job            *campaigns.ChangesetJob
+	failureMessage *string
+}
+
+func (r *bulkOperationItemResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return r.changeset
+}
+
+func (r *bulkOperationItemResolver) State() string {
+	return string(r.job.State)
+}
+
+func (r *bulkOperationItemResolver) FailureMessage() *string {
+	return r.failureMessage
+}
+
+func (r *bulkOperationItemResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.job.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.job.FinishedAt}
+}
@@ -0,0 +1,86 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+var _ graphqlbackend.CampaignActivityResolver = &campaignActivityResolver{}
+
+type campaignActivityResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+	activity    *campaigns.CampaignActivity
+
+	// Cache the changeset on the resolver, since loading it requires a
+	// permission check on the repository.
+	changesetOnce sync.Once
+	changeset     graphqlbackend.ChangesetResolver
+	changesetErr  error
+}
+
+const campaignActivityIDKind = "CampaignActivity"
+
+func marshalCampaignActivityID(id int64) graphql.ID {
+	return relay.MarshalID(campaignActivityIDKind, id)
+}
+
+func (r *campaignActivityResolver) ID() graphql.ID {
+	return marshalCampaignActivityID(r.activity.ID)
+}
+
+func (r *campaignActivityResolver) Kind() campaigns.CampaignActivityKind {
+	return r.activity.Kind
+}
+
+func (r *campaignActivityResolver) Actor(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.activity.UserID == 0 {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.activity.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *campaignActivityResolver) Changeset(ctx context.Context) (graphqlbackend.ChangesetResolver, error) {
+	r.changesetOnce.Do(func() {
+		if r.activity.ChangesetID == 0 {
+			return
+		}
+
+		changeset, err := r.store.GetChangeset(ctx, ee.GetChangesetOpts{ID: r.activity.ChangesetID})
+		if err != nil {
+			if err != ee.ErrNoResults {
+				r.changesetErr = err
+			}
+			return
+		}
+
+		// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+		// filters out repositories that the user doesn't have access to.
+		repo, err := db.Repos.Get(ctx, changeset.RepoID)
+		if err != nil && !errcode.IsNotFound(err) {
+			r.changesetErr = err
+			return
+		}
+
+		r.changeset = NewChangesetResolver(r.store, r.httpFactory, changeset, repo)
+	})
+	return r.changeset, r.changesetErr
+}
+
+func (r *campaignActivityResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.activity.CreatedAt}
+}
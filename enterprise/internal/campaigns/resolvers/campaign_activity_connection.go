@@ -0,0 +1,67 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+var _ graphqlbackend.CampaignActivitiesConnectionResolver = &campaignActivitiesConnectionResolver{}
+
+type campaignActivitiesConnectionResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+	opts        ee.ListCampaignActivitiesOpts
+
+	// cache results because they are used by multiple fields
+	once       sync.Once
+	activities []*campaigns.CampaignActivity
+	next       int64
+	err        error
+}
+
+func (r *campaignActivitiesConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.CampaignActivityResolver, error) {
+	activities, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.CampaignActivityResolver, 0, len(activities))
+	for _, a := range activities {
+		resolvers = append(resolvers, &campaignActivityResolver{
+			store:       r.store,
+			httpFactory: r.httpFactory,
+			activity:    a,
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignActivitiesConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	opts := ee.CountCampaignActivitiesOpts{CampaignID: r.opts.CampaignID}
+	count, err := r.store.CountCampaignActivities(ctx, opts)
+	return int32(count), err
+}
+
+func (r *campaignActivitiesConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.FormatInt(next, 10)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *campaignActivitiesConnectionResolver) compute(ctx context.Context) ([]*campaigns.CampaignActivity, int64, error) {
+	r.once.Do(func() {
+		r.activities, r.next, r.err = r.store.ListCampaignActivities(ctx, r.opts)
+	})
+	return r.activities, r.next, r.err
+}
@@ -0,0 +1,102 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestCampaignActivityConnectionResolver(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	userID := insertTestUser(t, dbconn.Global, "campaign-activity-connection-resolver", true)
+
+	store := ee.NewStore(dbconn.Global)
+
+	campaign := &campaigns.Campaign{
+		Name:             "campaign-activity",
+		NamespaceUserID:  userID,
+		InitialApplierID: userID,
+	}
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	activities := make([]*campaigns.CampaignActivity, 0, 3)
+	for i := 0; i < cap(activities); i++ {
+		a := &campaigns.CampaignActivity{
+			CampaignID: campaign.ID,
+			UserID:     userID,
+			Kind:       campaigns.CampaignActivityKindApplied,
+		}
+		if err := store.CreateCampaignActivity(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+		activities = append(activities, a)
+	}
+
+	s, err := graphqlbackend.NewSchema(&Resolver{store: store}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	campaignAPIID := string(campaigns.MarshalCampaignID(campaign.ID))
+
+	var endCursor *string
+	for i, a := range activities {
+		input := map[string]interface{}{"campaign": campaignAPIID, "first": 1}
+		if endCursor != nil {
+			input["after"] = *endCursor
+		}
+		wantHasNextPage := i != len(activities)-1
+
+		var response struct{ Node apitest.Campaign }
+		apitest.MustExec(ctx, t, s, input, &response, queryCampaignActivityConnection)
+
+		have := response.Node.Activity
+		if diff := cmp.Diff(1, len(have.Nodes)); diff != "" {
+			t.Fatalf("unexpected number of nodes (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(string(marshalCampaignActivityID(a.ID)), have.Nodes[0].ID); diff != "" {
+			t.Fatalf("unexpected activity (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(len(activities), have.TotalCount); diff != "" {
+			t.Fatalf("unexpected total count (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(wantHasNextPage, have.PageInfo.HasNextPage); diff != "" {
+			t.Fatalf("unexpected hasNextPage (-want +got):\n%s", diff)
+		}
+
+		endCursor = have.PageInfo.EndCursor
+		if want, have := wantHasNextPage, endCursor != nil; have != want {
+			t.Fatalf("unexpected endCursor existence. want=%t, have=%t", want, have)
+		}
+	}
+}
+
+const queryCampaignActivityConnection = `
+query($campaign: ID!, $first: Int, $after: String){
+  node(id: $campaign) {
+    ... on Campaign {
+      activity(first: $first, after: $after) {
+        totalCount
+        pageInfo { hasNextPage, endCursor }
+        nodes { id }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+)
+
+type campaignAnalyticsResolver struct {
+	analytics *ee.CampaignAnalytics
+}
+
+var _ graphqlbackend.CampaignAnalyticsResolver = &campaignAnalyticsResolver{}
+
+func (r *campaignAnalyticsResolver) MedianSecondsToFirstReview() *int32 {
+	return durationSeconds(r.analytics.MedianTimeToFirstReview)
+}
+
+func (r *campaignAnalyticsResolver) MedianSecondsToMerge() *int32 {
+	return durationSeconds(r.analytics.MedianTimeToMerge)
+}
+
+func (r *campaignAnalyticsResolver) MergedByWeek() []graphqlbackend.CampaignWeeklyMergeStatsResolver {
+	resolvers := make([]graphqlbackend.CampaignWeeklyMergeStatsResolver, 0, len(r.analytics.MergedByWeek))
+	for _, c := range r.analytics.MergedByWeek {
+		resolvers = append(resolvers, &campaignWeeklyMergeStatsResolver{counts: c})
+	}
+	return resolvers
+}
+
+func durationSeconds(d *time.Duration) *int32 {
+	if d == nil {
+		return nil
+	}
+	seconds := int32(d.Seconds())
+	return &seconds
+}
+
+type campaignWeeklyMergeStatsResolver struct {
+	counts *ee.WeeklyMergeCounts
+}
+
+func (r *campaignWeeklyMergeStatsResolver) Date() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.counts.Time}
+}
+func (r *campaignWeeklyMergeStatsResolver) Published() int32 { return r.counts.Published }
+func (r *campaignWeeklyMergeStatsResolver) Merged() int32    { return r.counts.Merged }
+func (r *campaignWeeklyMergeStatsResolver) MergedPercentage() float64 {
+	return r.counts.MergedPercentage()
+}
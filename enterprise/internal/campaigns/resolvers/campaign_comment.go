@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+var _ graphqlbackend.CampaignCommentResolver = &campaignCommentResolver{}
+
+type campaignCommentResolver struct {
+	comment *campaigns.CampaignComment
+}
+
+const campaignCommentIDKind = "CampaignComment"
+
+func marshalCampaignCommentID(id int64) graphql.ID {
+	return relay.MarshalID(campaignCommentIDKind, id)
+}
+
+func (r *campaignCommentResolver) ID() graphql.ID {
+	return marshalCampaignCommentID(r.comment.ID)
+}
+
+func (r *campaignCommentResolver) Author(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.comment.AuthorID == 0 {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.comment.AuthorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *campaignCommentResolver) Body() string {
+	return r.comment.Body
+}
+
+func (r *campaignCommentResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.comment.CreatedAt}
+}
+
+func (r *campaignCommentResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.comment.UpdatedAt}
+}
@@ -0,0 +1,57 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+var _ graphqlbackend.CampaignCommentsConnectionResolver = &campaignCommentsConnectionResolver{}
+
+type campaignCommentsConnectionResolver struct {
+	store *ee.Store
+	opts  ee.ListCampaignCommentsOpts
+
+	// cache results because they are used by multiple fields
+	once     sync.Once
+	comments []*campaigns.CampaignComment
+	next     int64
+	err      error
+}
+
+func (r *campaignCommentsConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.CampaignCommentResolver, error) {
+	comments, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.CampaignCommentResolver, 0, len(comments))
+	for _, c := range comments {
+		resolvers = append(resolvers, &campaignCommentResolver{comment: c})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignCommentsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	opts := ee.CountCampaignCommentsOpts{CampaignID: r.opts.CampaignID}
+	count, err := r.store.CountCampaignComments(ctx, opts)
+	return int32(count), err
+}
+
+func (r *campaignCommentsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(next != 0), nil
+}
+
+func (r *campaignCommentsConnectionResolver) compute(ctx context.Context) ([]*campaigns.CampaignComment, int64, error) {
+	r.once.Do(func() {
+		r.comments, r.next, r.err = r.store.ListCampaignComments(ctx, r.opts)
+	})
+	return r.comments, r.next, r.err
+}
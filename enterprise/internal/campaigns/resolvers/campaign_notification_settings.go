@@ -0,0 +1,39 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+var _ graphqlbackend.CampaignNotificationSettingsResolver = &campaignNotificationSettingsResolver{}
+
+type campaignNotificationSettingsResolver struct {
+	settings *campaigns.CampaignNotificationSettings
+}
+
+func (r *campaignNotificationSettingsResolver) Events() []string {
+	events := make([]string, 0, len(r.settings.Events))
+	for _, e := range r.settings.Events {
+		events = append(events, string(e))
+	}
+	return events
+}
+
+func (r *campaignNotificationSettingsResolver) Subscribers(ctx context.Context) ([]*graphqlbackend.UserResolver, error) {
+	subscribers := make([]*graphqlbackend.UserResolver, 0, len(r.settings.SubscriberIDs))
+	for _, id := range r.settings.SubscriberIDs {
+		user, err := graphqlbackend.UserByIDInt32(ctx, id)
+		if err != nil {
+			// Deleted users aren't notified anymore.
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		subscribers = append(subscribers, user)
+	}
+	return subscribers, nil
+}
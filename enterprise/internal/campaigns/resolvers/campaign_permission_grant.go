@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const campaignPermissionGrantIDKind = "CampaignPermissionGrant"
+
+func marshalCampaignPermissionGrantID(id int64) graphql.ID {
+	return relay.MarshalID(campaignPermissionGrantIDKind, id)
+}
+
+func unmarshalCampaignPermissionGrantID(id graphql.ID) (grantID int64, err error) {
+	err = relay.UnmarshalSpec(id, &grantID)
+	return
+}
+
+var _ graphqlbackend.CampaignPermissionGrantResolver = &campaignPermissionGrantResolver{}
+
+type campaignPermissionGrantResolver struct {
+	grant *campaigns.CampaignPermissionGrant
+}
+
+func (r *campaignPermissionGrantResolver) ID() graphql.ID {
+	return marshalCampaignPermissionGrantID(r.grant.ID)
+}
+
+func (r *campaignPermissionGrantResolver) Grantee(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	var (
+		n   graphqlbackend.NamespaceResolver
+		err error
+	)
+	if r.grant.UserID != 0 {
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.grant.UserID)
+	} else {
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.grant.OrgID)
+	}
+
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (r *campaignPermissionGrantResolver) Level() string {
+	return string(r.grant.Level)
+}
+
+func (r *campaignPermissionGrantResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.grant.CreatedAt}
+}
+
+func (r *campaignPermissionGrantResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.grant.UpdatedAt}
+}
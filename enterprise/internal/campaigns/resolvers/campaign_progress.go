@@ -0,0 +1,29 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+)
+
+type campaignProgressResolver struct {
+	progress *ee.CampaignProgress
+}
+
+var _ graphqlbackend.CampaignProgressResolver = &campaignProgressResolver{}
+
+func (r *campaignProgressResolver) Total() int32              { return r.progress.Total }
+func (r *campaignProgressResolver) Published() int32          { return r.progress.Published }
+func (r *campaignProgressResolver) Merged() int32             { return r.progress.Merged }
+func (r *campaignProgressResolver) PendingPublication() int32 { return r.progress.PendingPublication }
+
+func (r *campaignProgressResolver) PublishedPercentage() float64 {
+	return r.progress.PublishedPercentage()
+}
+
+func (r *campaignProgressResolver) MergedPercentage() float64 {
+	return r.progress.MergedPercentage()
+}
+
+func (r *campaignProgressResolver) EstimatedPublicationCompletedAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.progress.EstimatedPublicationCompletedAt())
+}
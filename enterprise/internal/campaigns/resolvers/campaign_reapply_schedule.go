@@ -0,0 +1,27 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+var _ graphqlbackend.CampaignReapplyScheduleResolver = &campaignReapplyScheduleResolver{}
+
+type campaignReapplyScheduleResolver struct {
+	schedule *campaigns.CampaignReapplySchedule
+}
+
+func (r *campaignReapplyScheduleResolver) Schedule() string {
+	return r.schedule.Schedule
+}
+
+func (r *campaignReapplyScheduleResolver) NextRunAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.schedule.NextRunAt}
+}
+
+func (r *campaignReapplyScheduleResolver) LastRunAt() *graphqlbackend.DateTime {
+	if r.schedule.LastRunAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.schedule.LastRunAt}
+}
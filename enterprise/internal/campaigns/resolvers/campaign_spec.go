@@ -124,9 +124,19 @@ func (r *campaignSpecResolver) CreatedAt() graphqlbackend.DateTime {
 }
 
 func (r *campaignSpecResolver) ExpiresAt() *graphqlbackend.DateTime {
+	if r.campaignSpec.Pinned() {
+		return nil
+	}
 	return &graphqlbackend.DateTime{Time: r.campaignSpec.ExpiresAt()}
 }
 
+func (r *campaignSpecResolver) PinnedAt() *graphqlbackend.DateTime {
+	if !r.campaignSpec.Pinned() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.campaignSpec.PinnedAt}
+}
+
 func (r *campaignSpecResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
 	return checkSiteAdminOrSameUser(ctx, r.campaignSpec.UserID)
 }
@@ -189,9 +199,108 @@ func (r *campaignSpecResolver) AppliesToCampaign(ctx context.Context) (graphqlba
 		return nil, nil
 	}
 
+	// 🚨 SECURITY: Campaigns that aren't visible to the current user are
+	// treated as if they didn't exist.
+	visible, err := ee.CampaignVisible(ctx, r.store, campaign)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
 	return &campaignResolver{
 		store:       r.store,
 		httpFactory: r.httpFactory,
 		Campaign:    campaign,
 	}, nil
 }
+
+func (r *campaignSpecResolver) DiffWithAppliedSpec(ctx context.Context) (graphqlbackend.CampaignSpecDiffResolver, error) {
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: DiffCampaignSpec leaves out the changeset specs and
+	// changesets in repositories the user doesn't have access to.
+	diff, err := svc.DiffCampaignSpec(ctx, r.campaignSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecDiffResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		diff:        diff,
+		repoCtx:     ctx,
+	}, nil
+}
+
+var _ graphqlbackend.CampaignSpecDiffResolver = &campaignSpecDiffResolver{}
+
+type campaignSpecDiffResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+	diff        *ee.CampaignSpecDiff
+	repoCtx     context.Context
+}
+
+func (r *campaignSpecDiffResolver) Campaign() graphqlbackend.CampaignResolver {
+	if r.diff.Campaign == nil {
+		return nil
+	}
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: r.diff.Campaign}
+}
+
+func (r *campaignSpecDiffResolver) Added() []graphqlbackend.VisibleChangesetSpecResolver {
+	return r.changesetSpecs(r.diff.Added)
+}
+
+func (r *campaignSpecDiffResolver) Updated() []graphqlbackend.ChangesetSpecDeltaResolver {
+	resolvers := make([]graphqlbackend.ChangesetSpecDeltaResolver, 0, len(r.diff.Updated))
+	for _, p := range r.diff.Updated {
+		resolvers = append(resolvers, &changesetSpecDeltaResolver{
+			changeset:     NewChangesetResolver(r.store, r.httpFactory, p.Changeset, r.diff.Repos[p.Changeset.RepoID]),
+			changesetSpec: r.changesetSpec(p.Spec),
+			preview:       p,
+		})
+	}
+	return resolvers
+}
+
+func (r *campaignSpecDiffResolver) Unchanged() []graphqlbackend.VisibleChangesetSpecResolver {
+	return r.changesetSpecs(r.diff.Unchanged)
+}
+
+func (r *campaignSpecDiffResolver) Closed() []graphqlbackend.ExternalChangesetResolver {
+	return r.changesets(r.diff.Closed)
+}
+
+func (r *campaignSpecDiffResolver) Detached() []graphqlbackend.ExternalChangesetResolver {
+	return r.changesets(r.diff.Detached)
+}
+
+func (r *campaignSpecDiffResolver) changesetSpec(spec *campaigns.ChangesetSpec) *changesetSpecResolver {
+	return &changesetSpecResolver{
+		store:         r.store,
+		httpFactory:   r.httpFactory,
+		changesetSpec: spec,
+
+		preloadedRepo:        r.diff.Repos[spec.RepoID],
+		attemptedPreloadRepo: true,
+		repoCtx:              r.repoCtx,
+	}
+}
+
+func (r *campaignSpecDiffResolver) changesetSpecs(specs []*campaigns.ChangesetSpec) []graphqlbackend.VisibleChangesetSpecResolver {
+	resolvers := make([]graphqlbackend.VisibleChangesetSpecResolver, 0, len(specs))
+	for _, s := range specs {
+		resolvers = append(resolvers, r.changesetSpec(s))
+	}
+	return resolvers
+}
+
+func (r *campaignSpecDiffResolver) changesets(cs []*campaigns.Changeset) []graphqlbackend.ExternalChangesetResolver {
+	resolvers := make([]graphqlbackend.ExternalChangesetResolver, 0, len(cs))
+	for _, c := range cs {
+		resolvers = append(resolvers, NewChangesetResolver(r.store, r.httpFactory, c, r.diff.Repos[c.RepoID]))
+	}
+	return resolvers
+}
@@ -0,0 +1,109 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const campaignSpecExecutionIDKind = "CampaignSpecExecution"
+
+func marshalCampaignSpecExecutionID(id int64) graphql.ID {
+	return relay.MarshalID(campaignSpecExecutionIDKind, id)
+}
+
+func unmarshalCampaignSpecExecutionID(id graphql.ID) (executionID int64, err error) {
+	err = relay.UnmarshalSpec(id, &executionID)
+	return
+}
+
+var _ graphqlbackend.CampaignSpecExecutionResolver = &campaignSpecExecutionResolver{}
+
+type campaignSpecExecutionResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+	execution   *campaigns.CampaignSpecExecution
+}
+
+func (r *campaignSpecExecutionResolver) ID() graphql.ID {
+	return marshalCampaignSpecExecutionID(r.execution.ID)
+}
+
+func (r *campaignSpecExecutionResolver) State() string {
+	return string(r.execution.State)
+}
+
+func (r *campaignSpecExecutionResolver) FailureMessage() *string {
+	return r.execution.FailureMessage
+}
+
+func (r *campaignSpecExecutionResolver) RepositoriesTotal() int32 {
+	return r.execution.RepositoriesTotal
+}
+
+func (r *campaignSpecExecutionResolver) RepositoriesCompleted() int32 {
+	return r.execution.RepositoriesCompleted
+}
+
+func (r *campaignSpecExecutionResolver) CampaignSpec(ctx context.Context) (graphqlbackend.CampaignSpecResolver, error) {
+	if r.execution.CampaignSpecID == 0 {
+		return nil, nil
+	}
+
+	spec, err := r.store.GetCampaignSpec(ctx, ee.GetCampaignSpecOpts{ID: r.execution.CampaignSpecID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &campaignSpecResolver{store: r.store, httpFactory: r.httpFactory, campaignSpec: spec}, nil
+}
+
+func (r *campaignSpecExecutionResolver) Namespace(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	var (
+		err error
+		n   = &graphqlbackend.NamespaceResolver{}
+	)
+
+	if r.execution.NamespaceUserID != 0 {
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.execution.NamespaceUserID)
+	} else {
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.execution.NamespaceOrgID)
+	}
+
+	return n, err
+}
+
+func (r *campaignSpecExecutionResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.execution.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *campaignSpecExecutionResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.execution.CreatedAt}
+}
+
+func (r *campaignSpecExecutionResolver) StartedAt() *graphqlbackend.DateTime {
+	if r.execution.StartedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.execution.StartedAt}
+}
+
+func (r *campaignSpecExecutionResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.execution.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.execution.FinishedAt}
+}
@@ -0,0 +1,80 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+var _ graphqlbackend.CampaignSpecExpirationResolver = &campaignSpecExpirationResolver{}
+
+type campaignSpecExpirationResolver struct {
+	store *ee.Store
+}
+
+func (r *campaignSpecExpirationResolver) CampaignSpecHours() int32 {
+	return int32(campaigns.CampaignSpecTTL() / time.Hour)
+}
+
+func (r *campaignSpecExpirationResolver) ChangesetSpecHours() int32 {
+	return int32(campaigns.ChangesetSpecTTL() / time.Hour)
+}
+
+func (r *campaignSpecExpirationResolver) IntervalMinutes() int32 {
+	return int32(campaigns.SpecExpirationInterval() / time.Minute)
+}
+
+func (r *campaignSpecExpirationResolver) DryRun() bool {
+	return campaigns.SpecExpirationDryRun()
+}
+
+func (r *campaignSpecExpirationResolver) LastRun(ctx context.Context) (graphqlbackend.CampaignSpecExpirationRunResolver, error) {
+	run, err := r.store.GetLastSpecExpirationRun(ctx)
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &campaignSpecExpirationRunResolver{run: run}, nil
+}
+
+var _ graphqlbackend.CampaignSpecExpirationRunResolver = &campaignSpecExpirationRunResolver{}
+
+type campaignSpecExpirationRunResolver struct {
+	run *campaigns.SpecExpirationRun
+}
+
+func (r *campaignSpecExpirationRunResolver) StartedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.run.StartedAt}
+}
+
+func (r *campaignSpecExpirationRunResolver) FinishedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.run.FinishedAt}
+}
+
+func (r *campaignSpecExpirationRunResolver) DryRun() bool {
+	return r.run.DryRun
+}
+
+func (r *campaignSpecExpirationRunResolver) ChangesetSpecsDeleted() int32 {
+	return r.run.ChangesetSpecsDeleted
+}
+
+func (r *campaignSpecExpirationRunResolver) CampaignSpecsDeleted() int32 {
+	return r.run.CampaignSpecsDeleted
+}
+
+func (r *campaignSpecExpirationRunResolver) CampaignsDeleted() int32 {
+	return r.run.CampaignsDeleted
+}
+
+func (r *campaignSpecExpirationRunResolver) Error() *string {
+	if r.run.Error == "" {
+		return nil
+	}
+	return &r.run.Error
+}
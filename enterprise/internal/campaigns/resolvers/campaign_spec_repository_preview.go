@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+)
+
+var _ graphqlbackend.CampaignSpecRepositoryPreviewResolver = &campaignSpecRepositoryPreviewResolver{}
+
+type campaignSpecRepositoryPreviewResolver struct {
+	repos []*ee.CampaignSpecRepository
+}
+
+func (r *campaignSpecRepositoryPreviewResolver) TotalCount() int32 {
+	return int32(len(r.repos))
+}
+
+func (r *campaignSpecRepositoryPreviewResolver) SearchResultCount() int32 {
+	var count int32
+	for _, repo := range r.repos {
+		count += int32(repo.SearchResultCount)
+	}
+	return count
+}
+
+func (r *campaignSpecRepositoryPreviewResolver) Nodes() []graphqlbackend.CampaignSpecPreviewedRepositoryResolver {
+	resolvers := make([]graphqlbackend.CampaignSpecPreviewedRepositoryResolver, 0, len(r.repos))
+	for _, repo := range r.repos {
+		resolvers = append(resolvers, &campaignSpecPreviewedRepositoryResolver{repo: repo})
+	}
+	return resolvers
+}
+
+var _ graphqlbackend.CampaignSpecPreviewedRepositoryResolver = &campaignSpecPreviewedRepositoryResolver{}
+
+type campaignSpecPreviewedRepositoryResolver struct {
+	repo *ee.CampaignSpecRepository
+}
+
+func (r *campaignSpecPreviewedRepositoryResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return graphqlbackend.NewRepositoryResolver(r.repo.Repo)
+}
+
+func (r *campaignSpecPreviewedRepositoryResolver) Branch() string {
+	return r.repo.Branch
+}
+
+func (r *campaignSpecPreviewedRepositoryResolver) Commit() *string {
+	if r.repo.Commit == "" {
+		return nil
+	}
+	commit := string(r.repo.Commit)
+	return &commit
+}
+
+func (r *campaignSpecPreviewedRepositoryResolver) FailureMessage() *string {
+	if r.repo.Err == nil {
+		return nil
+	}
+	msg := r.repo.Err.Error()
+	return &msg
+}
+
+func (r *campaignSpecPreviewedRepositoryResolver) SearchResultCount() int32 {
+	return int32(r.repo.SearchResultCount)
+}
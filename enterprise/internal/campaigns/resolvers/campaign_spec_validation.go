@@ -0,0 +1,107 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+var _ graphqlbackend.CampaignSpecValidationResolver = &campaignSpecValidationResolver{}
+
+type campaignSpecValidationResolver struct {
+	problems []*campaigns.CampaignSpecValidationError
+}
+
+func (r *campaignSpecValidationResolver) Valid() bool {
+	return len(r.problems) == 0
+}
+
+func (r *campaignSpecValidationResolver) Errors() []graphqlbackend.CampaignSpecValidationErrorResolver {
+	resolvers := make([]graphqlbackend.CampaignSpecValidationErrorResolver, 0, len(r.problems))
+	for _, p := range r.problems {
+		resolvers = append(resolvers, &campaignSpecValidationErrorResolver{problem: p})
+	}
+	return resolvers
+}
+
+var _ graphqlbackend.CampaignSpecValidationErrorResolver = &campaignSpecValidationErrorResolver{}
+
+type campaignSpecValidationErrorResolver struct {
+	problem *campaigns.CampaignSpecValidationError
+}
+
+func (r *campaignSpecValidationErrorResolver) Message() string {
+	return r.problem.Message
+}
+
+func (r *campaignSpecValidationErrorResolver) Line() *int32 {
+	if r.problem.Line == 0 {
+		return nil
+	}
+	line := int32(r.problem.Line)
+	return &line
+}
+
+func (r *campaignSpecValidationErrorResolver) Column() *int32 {
+	if r.problem.Column == 0 {
+		return nil
+	}
+	column := int32(r.problem.Column)
+	return &column
+}
+
+var _ graphqlbackend.CampaignSpecLintResolver = &campaignSpecLintResolver{}
+
+type campaignSpecLintResolver struct {
+	problems []*campaigns.CampaignSpecLintProblem
+}
+
+func (r *campaignSpecLintResolver) Passed() bool {
+	for _, p := range r.problems {
+		if p.Severity == campaigns.CampaignSpecLintSeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *campaignSpecLintResolver) Problems() []graphqlbackend.CampaignSpecLintProblemResolver {
+	resolvers := make([]graphqlbackend.CampaignSpecLintProblemResolver, 0, len(r.problems))
+	for _, p := range r.problems {
+		resolvers = append(resolvers, &campaignSpecLintProblemResolver{problem: p})
+	}
+	return resolvers
+}
+
+var _ graphqlbackend.CampaignSpecLintProblemResolver = &campaignSpecLintProblemResolver{}
+
+type campaignSpecLintProblemResolver struct {
+	problem *campaigns.CampaignSpecLintProblem
+}
+
+func (r *campaignSpecLintProblemResolver) Rule() string {
+	return string(r.problem.Rule)
+}
+
+func (r *campaignSpecLintProblemResolver) Severity() string {
+	return string(r.problem.Severity)
+}
+
+func (r *campaignSpecLintProblemResolver) Message() string {
+	return r.problem.Message
+}
+
+func (r *campaignSpecLintProblemResolver) Line() *int32 {
+	if r.problem.Line == 0 {
+		return nil
+	}
+	line := int32(r.problem.Line)
+	return &line
+}
+
+func (r *campaignSpecLintProblemResolver) Column() *int32 {
+	if r.problem.Column == 0 {
+		return nil
+	}
+	column := int32(r.problem.Column)
+	return &column
+}
@@ -0,0 +1,96 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+)
+
+// campaignStatsLoader loads the stats of the campaigns of a list of
+// resolvers. The first load fetches the stats of all campaigns the loader was
+// created with in a single query.
+//
+// A nil *campaignStatsLoader loads the stats of every campaign separately.
+type campaignStatsLoader struct {
+	ids []int64
+
+	once  sync.Once
+	stats map[int64]*ee.CampaignStats
+	err   error
+}
+
+// newCampaignStatsLoader returns a campaignStatsLoader that batches the
+// loading of the stats of the campaigns with the given IDs.
+func newCampaignStatsLoader(ids ...int64) *campaignStatsLoader {
+	return &campaignStatsLoader{ids: ids}
+}
+
+// load returns the stats of the campaign with the given ID. The stats of
+// campaigns that the loader wasn't created with are loaded separately.
+func (l *campaignStatsLoader) load(ctx context.Context, store *ee.Store, id int64) (*ee.CampaignStats, error) {
+	if l == nil {
+		stats, err := store.GetCampaignStats(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return stats[id], nil
+	}
+
+	l.once.Do(func() {
+		l.stats, l.err = store.GetCampaignStats(ctx, l.ids...)
+	})
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	if stats, ok := l.stats[id]; ok {
+		return stats, nil
+	}
+	return (*campaignStatsLoader)(nil).load(ctx, store, id)
+}
+
+var _ graphqlbackend.CampaignStatsResolver = &campaignStatsResolver{}
+
+type campaignStatsResolver struct {
+	campaign *campaignResolver
+	stats    *ee.CampaignStats
+}
+
+func (r *campaignStatsResolver) Total() int32       { return r.stats.Total }
+func (r *campaignStatsResolver) Unpublished() int32 { return r.stats.Unpublished }
+func (r *campaignStatsResolver) Open() int32        { return r.stats.Open }
+func (r *campaignStatsResolver) Merged() int32      { return r.stats.Merged }
+func (r *campaignStatsResolver) Closed() int32      { return r.stats.Closed }
+func (r *campaignStatsResolver) Archived() int32    { return r.stats.Archived }
+
+func (r *campaignStatsResolver) DiffStat(ctx context.Context) (*graphqlbackend.DiffStat, error) {
+	err := backend.CheckCurrentUserIsSiteAdmin(ctx)
+	if err == nil {
+		// Site admins can see all changesets, so the aggregated total is
+		// theirs.
+		return graphqlbackend.NewDiffStat(diff.Stat{
+			Added:   r.stats.DiffStatAdded,
+			Changed: r.stats.DiffStatChanged,
+			Deleted: r.stats.DiffStatDeleted,
+		}), nil
+	}
+	if err != backend.ErrMustBeSiteAdmin {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: The aggregated total includes the changesets in
+	// repositories the user doesn't have access to, so it's computed per
+	// repository instead.
+	return r.campaign.DiffStat(ctx)
+}
+
+func (r *campaignStatsResolver) LastSyncedAt() *graphqlbackend.DateTime {
+	if r.stats.LastSyncedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.stats.LastSyncedAt}
+}
@@ -0,0 +1,141 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func (r *campaignResolver) SyncStatus(ctx context.Context) (graphqlbackend.CampaignSyncStatusResolver, error) {
+	syncData, err := r.store.ListChangesetSyncData(ctx, ee.ListChangesetSyncDataOpts{CampaignID: r.Campaign.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &campaignSyncStatusResolver{}
+	if len(syncData) == 0 {
+		return res, nil
+	}
+
+	ids := make([]int64, 0, len(syncData))
+	for _, d := range syncData {
+		ids = append(ids, d.ChangesetID)
+	}
+	changesetsByID := make(map[int64]*campaigns.Changeset, len(ids))
+	var cs campaigns.Changesets
+	err = r.store.ForEachChangeset(ctx, ee.ListChangesetsOpts{IDs: ids}, func(c *campaigns.Changeset) error {
+		changesetsByID[c.ID] = c
+		cs = append(cs, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.GetRepoIDsSet uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, d := range syncData {
+		c, ok := changesetsByID[d.ChangesetID]
+		if !ok {
+			continue
+		}
+
+		// If the repository is not in reposByID, it was filtered out by the
+		// authz-filter and a hidden changeset is returned.
+		repo := reposByID[c.RepoID]
+		nextSyncAt := ee.NextSync(func() time.Time { return now }, d)
+		status := &changesetSyncStatusResolver{
+			changeset:    NewChangesetResolverWithNextSync(r.store, r.httpFactory, c, repo, &nextSyncAt),
+			lastSyncedAt: d.UpdatedAt,
+			nextSyncAt:   nextSyncAt,
+			overdue:      nextSyncAt.Before(now),
+		}
+		// 🚨 SECURITY: Sync errors can contain the name of the repository, so
+		// they're not revealed for hidden changesets.
+		if repo != nil {
+			status.syncError = d.SyncErrorMessage
+		}
+
+		if status.overdue {
+			res.overdueCount++
+		}
+		if d.SyncErrorMessage != nil {
+			res.erroredCount++
+		}
+		if res.oldestSyncAt == nil || d.UpdatedAt.Before(*res.oldestSyncAt) {
+			t := d.UpdatedAt
+			res.oldestSyncAt = &t
+		}
+		res.changesets = append(res.changesets, status)
+	}
+
+	return res, nil
+}
+
+var _ graphqlbackend.CampaignSyncStatusResolver = &campaignSyncStatusResolver{}
+
+type campaignSyncStatusResolver struct {
+	changesets   []graphqlbackend.ChangesetSyncStatusResolver
+	oldestSyncAt *time.Time
+	overdueCount int32
+	erroredCount int32
+}
+
+func (r *campaignSyncStatusResolver) Changesets() []graphqlbackend.ChangesetSyncStatusResolver {
+	return r.changesets
+}
+
+func (r *campaignSyncStatusResolver) OldestSyncAt() *graphqlbackend.DateTime {
+	if r.oldestSyncAt == nil {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: *r.oldestSyncAt}
+}
+
+func (r *campaignSyncStatusResolver) OverdueCount() int32 {
+	return r.overdueCount
+}
+
+func (r *campaignSyncStatusResolver) ErroredCount() int32 {
+	return r.erroredCount
+}
+
+var _ graphqlbackend.ChangesetSyncStatusResolver = &changesetSyncStatusResolver{}
+
+type changesetSyncStatusResolver struct {
+	changeset    graphqlbackend.ChangesetResolver
+	lastSyncedAt time.Time
+	nextSyncAt   time.Time
+	overdue      bool
+	syncError    *string
+}
+
+func (r *changesetSyncStatusResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return r.changeset
+}
+
+func (r *changesetSyncStatusResolver) LastSyncedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.lastSyncedAt}
+}
+
+func (r *changesetSyncStatusResolver) NextSyncAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.nextSyncAt}
+}
+
+func (r *changesetSyncStatusResolver) Overdue() bool {
+	return r.overdue
+}
+
+func (r *changesetSyncStatusResolver) SyncError() *string {
+	return r.syncError
+}
@@ -0,0 +1,184 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const campaignTemplateIDKind = "CampaignTemplate"
+
+func marshalCampaignTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(campaignTemplateIDKind, id)
+}
+
+func unmarshalCampaignTemplateID(id graphql.ID) (campaignTemplateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &campaignTemplateID)
+	return
+}
+
+var _ graphqlbackend.CampaignTemplateResolver = &campaignTemplateResolver{}
+
+type campaignTemplateResolver struct {
+	store    *ee.Store
+	template *campaigns.CampaignTemplate
+}
+
+func (r *campaignTemplateResolver) ID() graphql.ID {
+	return marshalCampaignTemplateID(r.template.ID)
+}
+
+func (r *campaignTemplateResolver) Name() string {
+	return r.template.Name
+}
+
+func (r *campaignTemplateResolver) Description() string {
+	return r.template.Description
+}
+
+func (r *campaignTemplateResolver) Template() string {
+	return r.template.Template
+}
+
+func (r *campaignTemplateResolver) Parameters() []graphqlbackend.CampaignTemplateParameterResolver {
+	resolvers := make([]graphqlbackend.CampaignTemplateParameterResolver, 0, len(r.template.Parameters))
+	for _, p := range r.template.Parameters {
+		resolvers = append(resolvers, &campaignTemplateParameterResolver{parameter: p})
+	}
+	return resolvers
+}
+
+func (r *campaignTemplateResolver) Namespace(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	var (
+		err error
+		n   = &graphqlbackend.NamespaceResolver{}
+	)
+
+	if r.template.NamespaceUserID != 0 {
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.template.NamespaceUserID)
+	} else {
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.template.NamespaceOrgID)
+	}
+
+	return n, err
+}
+
+func (r *campaignTemplateResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.template.CreatorID == 0 {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.template.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *campaignTemplateResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.CreatedAt}
+}
+
+func (r *campaignTemplateResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.UpdatedAt}
+}
+
+func (r *campaignTemplateResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
+	if r.template.NamespaceUserID != 0 {
+		return checkSiteAdminOrSameUser(ctx, r.template.NamespaceUserID)
+	}
+
+	// 🚨 SECURITY: Only site admins and members of the org can administer
+	// the org's templates.
+	if err := backend.CheckOrgAccess(ctx, r.template.NamespaceOrgID); err != nil {
+		if err == backend.ErrNotAnOrgMember {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+var _ graphqlbackend.CampaignTemplateParameterResolver = &campaignTemplateParameterResolver{}
+
+type campaignTemplateParameterResolver struct {
+	parameter campaigns.CampaignTemplateParameter
+}
+
+func (r *campaignTemplateParameterResolver) Name() string {
+	return r.parameter.Name
+}
+
+func (r *campaignTemplateParameterResolver) Description() string {
+	return r.parameter.Description
+}
+
+func (r *campaignTemplateParameterResolver) Default() *string {
+	return r.parameter.Default
+}
+
+func (r *campaignTemplateParameterResolver) Required() bool {
+	return r.parameter.Required()
+}
+
+var _ graphqlbackend.CampaignTemplateConnectionResolver = &campaignTemplateConnectionResolver{}
+
+type campaignTemplateConnectionResolver struct {
+	store *ee.Store
+	opts  ee.ListCampaignTemplatesOpts
+
+	// Cache results because they are used by multiple fields
+	once      sync.Once
+	templates []*campaigns.CampaignTemplate
+	next      int64
+	err       error
+}
+
+func (r *campaignTemplateConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.CampaignTemplateResolver, error) {
+	templates, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CampaignTemplateResolver, 0, len(templates))
+	for _, t := range templates {
+		resolvers = append(resolvers, &campaignTemplateResolver{store: r.store, template: t})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignTemplateConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountCampaignTemplates(ctx, ee.CountCampaignTemplatesOpts{
+		NamespaceUserID: r.opts.NamespaceUserID,
+		NamespaceOrgID:  r.opts.NamespaceOrgID,
+	})
+	return int32(count), err
+}
+
+func (r *campaignTemplateConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.FormatInt(next, 10)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *campaignTemplateConnectionResolver) compute(ctx context.Context) ([]*campaigns.CampaignTemplate, int64, error) {
+	r.once.Do(func() {
+		r.templates, r.next, r.err = r.store.ListCampaignTemplates(ctx, r.opts)
+	})
+	return r.templates, r.next, r.err
+}
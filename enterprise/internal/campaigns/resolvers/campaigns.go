@@ -2,16 +2,26 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/markdown"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 var _ graphqlbackend.CampaignsConnectionResolver = &campaignsConnectionResolver{}
@@ -33,20 +43,46 @@ func (r *campaignsConnectionResolver) Nodes(ctx context.Context) ([]graphqlbacke
 	if err != nil {
 		return nil, err
 	}
+
+	// Share a userLoader between the campaigns, so that their appliers,
+	// spec creators and namespaces are loaded in a single query.
+	ids := make([]int32, 0, 4*len(nodes))
+	for _, c := range nodes {
+		ids = append(ids, c.InitialApplierID, c.LastApplierID, c.CampaignSpecUserID, c.NamespaceUserID)
+	}
+	users := newUserLoader(ids...)
+
+	// Likewise, their stats are loaded in a single query.
+	campaignIDs := make([]int64, 0, len(nodes))
+	for _, c := range nodes {
+		campaignIDs = append(campaignIDs, c.ID)
+	}
+	stats := newCampaignStatsLoader(campaignIDs...)
+
 	resolvers := make([]graphqlbackend.CampaignResolver, 0, len(nodes))
 	for _, c := range nodes {
-		resolvers = append(resolvers, &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: c})
+		resolvers = append(resolvers, &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: c, users: users, stats: stats})
 	}
 	return resolvers, nil
 }
 
 func (r *campaignsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
 	opts := ee.CountCampaignsOpts{
-		ChangesetID:      r.opts.ChangesetID,
-		State:            r.opts.State,
-		InitialApplierID: r.opts.InitialApplierID,
-		NamespaceUserID:  r.opts.NamespaceUserID,
-		NamespaceOrgID:   r.opts.NamespaceOrgID,
+		ChangesetID:       r.opts.ChangesetID,
+		State:             r.opts.State,
+		InitialApplierID:  r.opts.InitialApplierID,
+		AdministeredBy:    r.opts.AdministeredBy,
+		NamespaceUserID:   r.opts.NamespaceUserID,
+		NamespaceOrgID:    r.opts.NamespaceOrgID,
+		NamespaceType:     r.opts.NamespaceType,
+		Query:             r.opts.Query,
+		NamespaceMemberID: r.opts.NamespaceMemberID,
+		VisibleTo:         r.opts.VisibleTo,
+		OnlyDeleted:       r.opts.OnlyDeleted,
+		CreatedAfter:      r.opts.CreatedAfter,
+		CreatedBefore:     r.opts.CreatedBefore,
+		ClosedAfter:       r.opts.ClosedAfter,
+		ClosedBefore:      r.opts.ClosedBefore,
 	}
 	count, err := r.store.CountCampaigns(ctx, opts)
 	return int32(count), err
@@ -57,7 +93,10 @@ func (r *campaignsConnectionResolver) PageInfo(ctx context.Context) (*graphqluti
 	if err != nil {
 		return nil, err
 	}
-	return graphqlutil.HasNextPage(next != 0), nil
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.FormatInt(next, 10)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
 }
 
 func (r *campaignsConnectionResolver) compute(ctx context.Context) ([]*campaigns.Campaign, int64, error) {
@@ -74,6 +113,13 @@ type campaignResolver struct {
 	httpFactory *httpcli.Factory
 	*campaigns.Campaign
 
+	// users, if set, is shared with the resolvers of the other campaigns in
+	// the same list.
+	users *userLoader
+	// stats, if set, is shared with the resolvers of the other campaigns in
+	// the same list.
+	stats *campaignStatsLoader
+
 	// Cache the namespace on the resolver, since it's accessed more than once.
 	namespaceOnce sync.Once
 	namespace     graphqlbackend.NamespaceResolver
@@ -95,12 +141,20 @@ func (r *campaignResolver) Description() *string {
 	return &r.Campaign.Description
 }
 
+func (r *campaignResolver) DescriptionHTML() *string {
+	if r.Campaign.Description == "" {
+		return nil
+	}
+	html := markdown.Render(r.Campaign.Description)
+	return &html
+}
+
 func (r *campaignResolver) InitialApplier(ctx context.Context) (*graphqlbackend.UserResolver, error) {
-	return graphqlbackend.UserByIDInt32(ctx, r.Campaign.InitialApplierID)
+	return r.users.load(ctx, r.Campaign.InitialApplierID)
 }
 
 func (r *campaignResolver) LastApplier(ctx context.Context) (*graphqlbackend.UserResolver, error) {
-	return graphqlbackend.UserByIDInt32(ctx, r.Campaign.LastApplierID)
+	return r.users.load(ctx, r.Campaign.LastApplierID)
 }
 
 func (r *campaignResolver) LastAppliedAt() graphqlbackend.DateTime {
@@ -108,17 +162,29 @@ func (r *campaignResolver) LastAppliedAt() graphqlbackend.DateTime {
 }
 
 func (r *campaignResolver) SpecCreator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.Campaign.CampaignSpecUserID != 0 {
+		return r.users.load(ctx, r.Campaign.CampaignSpecUserID)
+	}
+
 	spec, err := r.store.GetCampaignSpec(ctx, ee.GetCampaignSpecOpts{
 		ID: r.Campaign.CampaignSpecID,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return graphqlbackend.UserByIDInt32(ctx, spec.UserID)
+	return r.users.load(ctx, spec.UserID)
 }
 
 func (r *campaignResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
-	return checkSiteAdminOrSameUser(ctx, r.Campaign.InitialApplierID)
+	// 🚨 SECURITY: Only site admins, the authors of a campaign and, if
+	// enabled, the members of the campaign's org have campaign admin rights.
+	if err := ee.CheckCampaignAdminRights(ctx, r.store, r.Campaign); err != nil {
+		if _, ok := err.(*backend.InsufficientAuthorizationError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
 
 func (r *campaignResolver) URL(ctx context.Context) (string, error) {
@@ -136,7 +202,7 @@ func (r *campaignResolver) Namespace(ctx context.Context) (graphqlbackend.Namesp
 func (r *campaignResolver) computeNamespace(ctx context.Context) (graphqlbackend.NamespaceResolver, error) {
 	r.namespaceOnce.Do(func() {
 		if r.Campaign.NamespaceUserID != 0 {
-			r.namespace.Namespace, r.namespaceErr = graphqlbackend.UserByIDInt32(
+			r.namespace.Namespace, r.namespaceErr = r.users.load(
 				ctx,
 				r.Campaign.NamespaceUserID,
 			)
@@ -163,6 +229,17 @@ func (r *campaignResolver) UpdatedAt() graphqlbackend.DateTime {
 	return graphqlbackend.DateTime{Time: r.Campaign.UpdatedAt}
 }
 
+func (r *campaignResolver) ChangesetsUpdatedAt(ctx context.Context) (*graphqlbackend.DateTime, error) {
+	updatedAt, err := r.store.GetCampaignChangesetsUpdatedAt(ctx, r.Campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	if updatedAt.IsZero() {
+		return nil, nil
+	}
+	return &graphqlbackend.DateTime{Time: updatedAt}, nil
+}
+
 func (r *campaignResolver) ClosedAt() *graphqlbackend.DateTime {
 	if !r.Campaign.Closed() {
 		return nil
@@ -170,6 +247,81 @@ func (r *campaignResolver) ClosedAt() *graphqlbackend.DateTime {
 	return &graphqlbackend.DateTime{Time: r.Campaign.ClosedAt}
 }
 
+func (r *campaignResolver) PausedAt() *graphqlbackend.DateTime {
+	if !r.Campaign.Paused() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.Campaign.PausedAt}
+}
+
+func (r *campaignResolver) DeletedAt() *graphqlbackend.DateTime {
+	if !r.Campaign.Deleted() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.Campaign.DeletedAt}
+}
+
+func (r *campaignResolver) PermissionGrants(ctx context.Context) ([]graphqlbackend.CampaignPermissionGrantResolver, error) {
+	resolvers := []graphqlbackend.CampaignPermissionGrantResolver{}
+
+	// 🚨 SECURITY: Only campaign admins can see who has been granted rights
+	// on the campaign.
+	if err := ee.CheckCampaignAdminRights(ctx, r.store, r.Campaign); err != nil {
+		if _, ok := err.(*backend.InsufficientAuthorizationError); ok {
+			return resolvers, nil
+		}
+		return nil, err
+	}
+
+	grants, err := r.store.ListCampaignPermissionGrants(ctx, r.Campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range grants {
+		resolvers = append(resolvers, &campaignPermissionGrantResolver{grant: g})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignResolver) AutoMerge() bool {
+	return r.Campaign.AutoMerge
+}
+
+func (r *campaignResolver) AutoRebase() bool {
+	return r.Campaign.AutoRebase
+}
+
+func (r *campaignResolver) Visibility() string {
+	return string(r.Campaign.Visibility)
+}
+
+func (r *campaignResolver) UpdatePropagation() string {
+	return string(r.Campaign.UpdatePropagation)
+}
+
+func (r *campaignResolver) NotificationSettings(ctx context.Context) (graphqlbackend.CampaignNotificationSettingsResolver, error) {
+	settings, err := r.store.GetCampaignNotificationSettings(ctx, r.Campaign.ID)
+	if err != nil {
+		if err != ee.ErrNoResults {
+			return nil, err
+		}
+		settings = campaigns.DefaultCampaignNotificationSettings(r.Campaign.ID)
+	}
+	return &campaignNotificationSettingsResolver{settings: settings}, nil
+}
+
+func (r *campaignResolver) ReapplySchedule(ctx context.Context) (graphqlbackend.CampaignReapplyScheduleResolver, error) {
+	rs, err := r.store.GetCampaignReapplySchedule(ctx, r.Campaign.ID)
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &campaignReapplyScheduleResolver{schedule: rs}, nil
+}
+
 func (r *campaignResolver) Changesets(
 	ctx context.Context,
 	args *graphqlbackend.ListChangesetsArgs,
@@ -186,10 +338,35 @@ func (r *campaignResolver) Changesets(
 	}, nil
 }
 
+func (r *campaignResolver) ChangesetsByRepository(
+	ctx context.Context,
+	args *graphqlbackend.ChangesetRepositoryGroupsArgs,
+) (graphqlbackend.ChangesetRepositoryGroupConnectionResolver, error) {
+	opts := ee.ListChangesetRepoGroupsOpts{CampaignID: r.Campaign.ID}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing after cursor")
+		}
+		opts.Cursor = cursor
+	}
+
+	return &changesetRepositoryGroupConnectionResolver{store: r.store, opts: opts}, nil
+}
+
 func (r *campaignResolver) ChangesetCountsOverTime(
 	ctx context.Context,
 	args *graphqlbackend.ChangesetCountsArgs,
-) ([]graphqlbackend.ChangesetCountsResolver, error) {
+) (_ []graphqlbackend.ChangesetCountsResolver, err error) {
+	tr, ctx := trace.New(ctx, "campaignResolver.ChangesetCountsOverTime", fmt.Sprintf("Campaign %d", r.Campaign.ID), campaignIDTag(r.Campaign.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
 	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access changesets.
 	if err := allowReadAccess(ctx); err != nil {
 		return nil, err
@@ -197,13 +374,6 @@ func (r *campaignResolver) ChangesetCountsOverTime(
 
 	resolvers := []graphqlbackend.ChangesetCountsResolver{}
 
-	publishedState := campaigns.ChangesetPublicationStatePublished
-	opts := ee.ListChangesetsOpts{CampaignID: r.Campaign.ID, Limit: -1, PublicationState: &publishedState}
-	cs, _, err := r.store.ListChangesets(ctx, opts)
-	if err != nil {
-		return resolvers, err
-	}
-
 	now := r.store.Clock()()
 
 	weekAgo := now.Add(-7 * 24 * time.Hour)
@@ -220,13 +390,40 @@ func (r *campaignResolver) ChangesetCountsOverTime(
 		end = args.To.Time.UTC()
 	}
 
-	eventsOpts := ee.ListChangesetEventsOpts{ChangesetIDs: cs.IDs(), Limit: -1}
-	es, _, err := r.store.ListChangesetEvents(ctx, eventsOpts)
+	if args.GroupBy != nil {
+		if *args.GroupBy != changesetCountsGroupByRepository {
+			return resolvers, errors.Errorf("invalid groupBy %q", *args.GroupBy)
+		}
+
+		cs, es, err := r.loadPublishedChangesets(ctx, tr, func(cs campaigns.Changesets) campaigns.Changesets { return cs })
+		if err != nil {
+			return resolvers, err
+		}
+
+		// Counts computed from compacted events are replaced with the ones
+		// stored before the compaction.
+		stored, err := r.store.ListCampaignRepoChangesetCounts(ctx, r.Campaign.ID)
+		if err != nil {
+			return resolvers, err
+		}
+		return changesetCountsByRepo(ctx, start, end, args.GroupLimit, cs, es, stored)
+	}
+
+	// The counts resume from the ones stored by the events compactor, so
+	// only the events of the changesets that changed since are needed.
+	stored, err := r.store.ListCampaignChangesetCounts(ctx, r.Campaign.ID)
+	if err != nil {
+		return resolvers, err
+	}
+
+	cs, es, err := r.loadPublishedChangesets(ctx, tr, func(cs campaigns.Changesets) campaigns.Changesets {
+		return ee.ChangesetsToCount(start, stored, cs)
+	})
 	if err != nil {
 		return resolvers, err
 	}
 
-	counts, err := ee.CalcCounts(start, end, cs, es...)
+	counts, err := ee.CalcCountsSince(start, end, stored, cs, es...)
 	if err != nil {
 		return resolvers, err
 	}
@@ -238,35 +435,244 @@ func (r *campaignResolver) ChangesetCountsOverTime(
 	return resolvers, nil
 }
 
+// loadPublishedChangesets loads the published changesets of the campaign in
+// batches and returns the ones of each batch that keep returns, together with
+// their events.
+func (r *campaignResolver) loadPublishedChangesets(
+	ctx context.Context,
+	tr *trace.Trace,
+	keep func(campaigns.Changesets) campaigns.Changesets,
+) (cs campaigns.Changesets, es []*campaigns.ChangesetEvent, err error) {
+	publishedState := campaigns.ChangesetPublicationStatePublished
+	opts := ee.ListChangesetsOpts{CampaignID: r.Campaign.ID, PublicationState: &publishedState}
+	err = r.store.ForEachChangesetBatch(ctx, opts, func(batch campaigns.Changesets) error {
+		kept := keep(batch)
+		// An empty list of IDs would load the events of all changesets.
+		if len(kept) == 0 {
+			return nil
+		}
+
+		batchEvents, _, err := r.store.ListChangesetEvents(ctx, ee.ListChangesetEventsOpts{ChangesetIDs: kept.IDs(), Limit: -1})
+		if err != nil {
+			return err
+		}
+		cs = append(cs, kept...)
+		es = append(es, batchEvents...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tr.LogFields(
+		otlog.Int("changesets", len(cs)),
+		otlog.Int("events", len(es)),
+		trace.Printf("changeset_ids", "%v", cs.IDs()),
+	)
+	return cs, es, nil
+}
+
+const changesetCountsGroupByRepository = "REPOSITORY"
+
+// changesetCountsByRepo returns a series of changeset counts for each of the
+// limit repositories lagging behind the most, followed by a series for all
+// other repositories. The stored counts of each repository replace the ones
+// computed in their timeframe.
+func changesetCountsByRepo(
+	ctx context.Context,
+	start, end time.Time,
+	limit int32,
+	cs campaigns.Changesets,
+	es []*campaigns.ChangesetEvent,
+	stored []*ee.RepoChangesetCounts,
+) ([]graphqlbackend.ChangesetCountsResolver, error) {
+	byRepo, err := ee.CalcCountsByRepo(start, end, cs, es...)
+	if err != nil {
+		return nil, err
+	}
+
+	storedByRepo := make(map[api.RepoID][]*ee.ChangesetCounts, len(stored))
+	for _, rc := range stored {
+		storedByRepo[rc.RepoID] = rc.Counts
+	}
+	for _, rc := range byRepo {
+		ee.ApplyStoredCounts(rc.Counts, storedByRepo[rc.RepoID])
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(byRepo))
+	for _, rc := range byRepo {
+		repoIDs = append(repoIDs, rc.RepoID)
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := db.Repos.GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := []graphqlbackend.ChangesetCountsResolver{}
+	var (
+		grouped int32
+		other   [][]*ee.ChangesetCounts
+	)
+	for _, rc := range byRepo {
+		// 🚨 SECURITY: The counts of repositories the user doesn't have
+		// access to are only included in the series of the other repositories.
+		repo, ok := reposByID[rc.RepoID]
+		if !ok || grouped >= limit {
+			other = append(other, rc.Counts)
+			continue
+		}
+		grouped++
+		for _, c := range rc.Counts {
+			resolvers = append(resolvers, &changesetCountsResolver{counts: c, repo: repo})
+		}
+	}
+
+	for _, c := range ee.SumCounts(other...) {
+		resolvers = append(resolvers, &changesetCountsResolver{counts: c})
+	}
+
+	return resolvers, nil
+}
+
+func (r *campaignResolver) Analytics(ctx context.Context) (graphqlbackend.CampaignAnalyticsResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access changesets.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	now := r.store.Clock()()
+	calc := ee.NewAnalyticsCalculator(r.Campaign.CreatedAt.UTC(), now.UTC())
+
+	// The changesets are added in batches, so that large campaigns don't
+	// have to be loaded all at once.
+	publishedState := campaigns.ChangesetPublicationStatePublished
+	opts := ee.ListChangesetsOpts{CampaignID: r.Campaign.ID, PublicationState: &publishedState}
+	err := r.store.ForEachChangesetBatch(ctx, opts, func(cs campaigns.Changesets) error {
+		es, _, err := r.store.ListChangesetEvents(ctx, ee.ListChangesetEventsOpts{ChangesetIDs: cs.IDs(), Limit: -1})
+		if err != nil {
+			return err
+		}
+		return calc.Add(cs, es...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignAnalyticsResolver{analytics: calc.Analytics()}, nil
+}
+
+func (r *campaignResolver) Progress(ctx context.Context) (graphqlbackend.CampaignProgressResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access changesets.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	progress, err := r.store.GetCampaignProgress(ctx, r.Campaign.ID, r.store.Clock()().Add(-ee.ProgressThroughputWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignProgressResolver{progress: progress}, nil
+}
+
+func (r *campaignResolver) Stats(ctx context.Context) (graphqlbackend.CampaignStatsResolver, error) {
+	stats, err := r.stats.load(ctx, r.store, r.Campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &campaignStatsResolver{campaign: r, stats: stats}, nil
+}
+
 func (r *campaignResolver) DiffStat(ctx context.Context) (*graphqlbackend.DiffStat, error) {
-	changesetsConnection := &changesetsConnectionResolver{
-		store: r.store,
-		opts: ee.ListChangesetsOpts{
-			CampaignID: r.Campaign.ID,
-			Limit:      -1, // Get all changesets
-		},
-		optsSafe: true,
+	err := backend.CheckCurrentUserIsSiteAdmin(ctx)
+	if err == nil {
+		// Site admins can see all changesets, so the total that's cached on
+		// the campaign is theirs.
+		return graphqlbackend.NewDiffStat(r.Campaign.DiffStat()), nil
+	}
+	if err != backend.ErrMustBeSiteAdmin {
+		return nil, err
+	}
+
+	groups, _, err := r.store.ListChangesetRepoGroups(ctx, ee.ListChangesetRepoGroupsOpts{
+		CampaignID: r.Campaign.ID,
+		Limit:      -1,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	changesets, err := changesetsConnection.Nodes(ctx)
+	repoIDs := make([]api.RepoID, 0, len(groups))
+	for _, g := range groups {
+		repoIDs = append(repoIDs, g.RepoID)
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to. The
+	// cached total includes the changesets in those repositories, so we only
+	// sum up the diff stats of the accessible ones.
+	accessibleRepos, err := db.Repos.GetReposSetByIDs(ctx, repoIDs...)
 	if err != nil {
 		return nil, err
 	}
 
-	totalStat := &graphqlbackend.DiffStat{}
-	for _, cs := range changesets {
-		// Not being able to convert is OK; it just means there's a hidden
-		// changeset that we can't use the stats from.
-		if external, ok := cs.ToExternalChangeset(); ok && external != nil {
-			stat, err := external.DiffStat(ctx)
-			if err != nil {
-				return nil, err
-			}
-			if stat != nil {
-				totalStat.AddDiffStat(stat)
-			}
+	var total diff.Stat
+	for _, g := range groups {
+		if _, ok := accessibleRepos[g.RepoID]; !ok {
+			continue
+		}
+		stat := g.DiffStat()
+		total.Added += stat.Added
+		total.Changed += stat.Changed
+		total.Deleted += stat.Deleted
+	}
+
+	return graphqlbackend.NewDiffStat(total), nil
+}
+
+func (r *campaignResolver) Activity(
+	ctx context.Context,
+	args *graphqlbackend.CampaignActivityArgs,
+) (graphqlbackend.CampaignActivitiesConnectionResolver, error) {
+	opts := ee.ListCampaignActivitiesOpts{CampaignID: r.Campaign.ID}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing after cursor")
 		}
+		opts.Cursor = cursor
 	}
 
-	return totalStat, nil
+	return &campaignActivitiesConnectionResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		opts:        opts,
+	}, nil
+}
+
+func (r *campaignResolver) Comments(
+	ctx context.Context,
+	args *struct{ graphqlutil.ConnectionArgs },
+) (graphqlbackend.CampaignCommentsConnectionResolver, error) {
+	return &campaignCommentsConnectionResolver{
+		store: r.store,
+		opts: ee.ListCampaignCommentsOpts{
+			CampaignID: r.Campaign.ID,
+			Limit:      int(args.GetFirst()),
+		},
+	}, nil
+}
+
+func (r *campaignResolver) ChangesetsExportURL(args *graphqlbackend.ChangesetsExportURLArgs) string {
+	return campaignChangesetsExportURL(r, args.Format)
+}
+
+func (r *campaignResolver) PatchURL(args *graphqlbackend.CampaignPatchURLArgs) string {
+	return campaignPatchURL(r, args.Format)
 }
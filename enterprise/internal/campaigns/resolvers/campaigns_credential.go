@@ -0,0 +1,62 @@
+package resolvers
+
+import (
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+const campaignsCredentialIDKind = "CampaignsCredential"
+
+func marshalCampaignsCredentialID(id int64) graphql.ID {
+	return relay.MarshalID(campaignsCredentialIDKind, id)
+}
+
+func unmarshalCampaignsCredentialID(id graphql.ID) (credentialID int64, err error) {
+	err = relay.UnmarshalSpec(id, &credentialID)
+	return
+}
+
+var _ graphqlbackend.CampaignsCredentialResolver = &campaignsCredentialResolver{}
+
+type campaignsCredentialResolver struct {
+	credential *campaigns.UserCredential
+}
+
+func (r *campaignsCredentialResolver) ID() graphql.ID {
+	return marshalCampaignsCredentialID(r.credential.ID)
+}
+
+func (r *campaignsCredentialResolver) ExternalServiceKind() string {
+	switch r.credential.ExternalServiceType {
+	case extsvc.TypeGitHub:
+		return extsvc.KindGitHub
+	case extsvc.TypeGitLab:
+		return extsvc.KindGitLab
+	case extsvc.TypeBitbucketServer:
+		return extsvc.KindBitbucketServer
+	default:
+		return r.credential.ExternalServiceType
+	}
+}
+
+func (r *campaignsCredentialResolver) ExternalServiceURL() string {
+	return r.credential.ExternalServiceID
+}
+
+func (r *campaignsCredentialResolver) Username() *string {
+	if r.credential.Username == "" {
+		return nil
+	}
+	return &r.credential.Username
+}
+
+func (r *campaignsCredentialResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.credential.CreatedAt}
+}
+
+func (r *campaignsCredentialResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.credential.UpdatedAt}
+}
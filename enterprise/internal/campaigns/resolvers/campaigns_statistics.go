@@ -0,0 +1,88 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+var _ graphqlbackend.CampaignsStatisticsResolver = &campaignsStatisticsResolver{}
+
+type campaignsStatisticsResolver struct {
+	stats *campaigns.CampaignsStatistics
+}
+
+func (r *campaignsStatisticsResolver) TotalCampaigns() int32 {
+	return r.stats.CampaignsCount
+}
+
+func (r *campaignsStatisticsResolver) OpenCampaigns() int32 {
+	return r.stats.OpenCampaignsCount
+}
+
+func (r *campaignsStatisticsResolver) Weeks() []graphqlbackend.CampaignsWeekStatisticsResolver {
+	resolvers := make([]graphqlbackend.CampaignsWeekStatisticsResolver, 0, len(r.stats.Weeks))
+	for _, w := range r.stats.Weeks {
+		resolvers = append(resolvers, &campaignsWeekStatisticsResolver{week: w})
+	}
+	return resolvers
+}
+
+func (r *campaignsStatisticsResolver) TopCodeHosts() []graphqlbackend.CampaignsCodeHostStatisticsResolver {
+	resolvers := make([]graphqlbackend.CampaignsCodeHostStatisticsResolver, 0, len(r.stats.CodeHosts))
+	for _, h := range r.stats.CodeHosts {
+		resolvers = append(resolvers, &campaignsCodeHostStatisticsResolver{codeHost: h})
+	}
+	return resolvers
+}
+
+func (r *campaignsStatisticsResolver) ActiveAuthors() int32 {
+	return r.stats.ActiveAuthorsCount
+}
+
+type campaignsWeekStatisticsResolver struct {
+	week *campaigns.CampaignsWeekStatistics
+}
+
+func (r *campaignsWeekStatisticsResolver) StartsAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.week.StartsAt}
+}
+
+func (r *campaignsWeekStatisticsResolver) ChangesetsPublished() int32 {
+	return r.week.ChangesetsPublished
+}
+
+func (r *campaignsWeekStatisticsResolver) ChangesetsMerged() int32 {
+	return r.week.ChangesetsMerged
+}
+
+type campaignsCodeHostStatisticsResolver struct {
+	codeHost *campaigns.CampaignsCodeHostStatistics
+}
+
+func (r *campaignsCodeHostStatisticsResolver) ExternalServiceKind() string {
+	switch r.codeHost.ExternalServiceType {
+	case extsvc.TypeGitHub:
+		return extsvc.KindGitHub
+	case extsvc.TypeGitLab:
+		return extsvc.KindGitLab
+	case extsvc.TypeBitbucketServer:
+		return extsvc.KindBitbucketServer
+	case extsvc.TypeBitbucketCloud:
+		return extsvc.KindBitbucketCloud
+	default:
+		return r.codeHost.ExternalServiceType
+	}
+}
+
+func (r *campaignsCodeHostStatisticsResolver) ExternalServiceURL() string {
+	return r.codeHost.ExternalServiceID
+}
+
+func (r *campaignsCodeHostStatisticsResolver) Changesets() int32 {
+	return r.codeHost.ChangesetsCount
+}
+
+func (r *campaignsCodeHostStatisticsResolver) MergedChangesets() int32 {
+	return r.codeHost.MergedChangesetsCount
+}
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/resolvers/apitest"
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
@@ -43,7 +45,7 @@ func TestCampaignResolver(t *testing.T) {
 
 	campaign := &campaigns.Campaign{
 		Name:             "my-unique-name",
-		Description:      "The campaign description",
+		Description:      "The *campaign* description",
 		NamespaceUserID:  userID,
 		InitialApplierID: userID,
 		LastApplierID:    userID,
@@ -67,20 +69,188 @@ func TestCampaignResolver(t *testing.T) {
 	apitest.MustExec(ctx, t, s, input, &response, queryCampaign)
 
 	wantCampaign := apitest.Campaign{
-		ID:             campaignAPIID,
-		Name:           campaign.Name,
-		Description:    campaign.Description,
-		Namespace:      apitest.UserOrg{DatabaseID: userID, SiteAdmin: true},
-		InitialApplier: apitest.User{DatabaseID: userID, SiteAdmin: true},
-		LastApplier:    apitest.User{DatabaseID: userID, SiteAdmin: true},
-		LastAppliedAt:  marshalDateTime(t, now),
-		URL:            fmt.Sprintf("/users/%s/campaigns/%s", username, campaignAPIID),
+		ID:              campaignAPIID,
+		Name:            campaign.Name,
+		Description:     campaign.Description,
+		DescriptionHTML: "<p>The <em>campaign</em> description</p>\n",
+		Namespace:       apitest.UserOrg{DatabaseID: userID, SiteAdmin: true},
+		InitialApplier:  apitest.User{DatabaseID: userID, SiteAdmin: true},
+		LastApplier:     apitest.User{DatabaseID: userID, SiteAdmin: true},
+		LastAppliedAt:   marshalDateTime(t, now),
+		URL:             fmt.Sprintf("/users/%s/campaigns/%s", username, campaignAPIID),
 	}
 	if diff := cmp.Diff(wantCampaign, response.Node); diff != "" {
 		t.Fatalf("wrong campaign response (-want +got):\n%s", diff)
 	}
+
+	input = map[string]interface{}{
+		"namespace": string(graphqlbackend.MarshalUserID(userID)),
+		"name":      campaign.Name,
+	}
+	var byNameResponse struct{ Campaign apitest.Campaign }
+	apitest.MustExec(ctx, t, s, input, &byNameResponse, queryCampaignByName)
+
+	if diff := cmp.Diff(wantCampaign, byNameResponse.Campaign); diff != "" {
+		t.Fatalf("wrong campaign response by name (-want +got):\n%s", diff)
+	}
+}
+
+func TestCampaignConnectionResolver(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	userID := insertTestUser(t, dbconn.Global, "campaign-connection-resolver", true)
+
+	store := ee.NewStore(dbconn.Global)
+
+	cs := make([]*campaigns.Campaign, 0, 3)
+	for i := 0; i < cap(cs); i++ {
+		c := &campaigns.Campaign{
+			Name:             fmt.Sprintf("campaign-%d", i),
+			NamespaceUserID:  userID,
+			InitialApplierID: userID,
+		}
+		if err := store.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		cs = append(cs, c)
+	}
+
+	s, err := graphqlbackend.NewSchema(&Resolver{store: store}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var endCursor *string
+	for i, c := range cs {
+		input := map[string]interface{}{"first": 1}
+		if endCursor != nil {
+			input["after"] = *endCursor
+		}
+		wantHasNextPage := i != len(cs)-1
+
+		var response struct{ Campaigns apitest.CampaignConnection }
+		apitest.MustExec(ctx, t, s, input, &response, queryCampaignConnection)
+
+		have := response.Campaigns
+		if diff := cmp.Diff(1, len(have.Nodes)); diff != "" {
+			t.Fatalf("unexpected number of nodes (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(string(campaigns.MarshalCampaignID(c.ID)), have.Nodes[0].ID); diff != "" {
+			t.Fatalf("unexpected campaign (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(len(cs), have.TotalCount); diff != "" {
+			t.Fatalf("unexpected total count (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(wantHasNextPage, have.PageInfo.HasNextPage); diff != "" {
+			t.Fatalf("unexpected hasNextPage (-want +got):\n%s", diff)
+		}
+
+		endCursor = have.PageInfo.EndCursor
+		if want, have := wantHasNextPage, endCursor != nil; have != want {
+			t.Fatalf("unexpected endCursor existence. want=%t, have=%t", want, have)
+		}
+	}
+}
+
+func TestCampaignConnectionResolverStats(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	userID := insertTestUser(t, dbconn.Global, "campaign-connection-stats", true)
+
+	store := ee.NewStore(dbconn.Global)
+	rstore := repos.NewDBStore(dbconn.Global, sql.TxOptions{})
+
+	repo := newGitHubTestRepo("github.com/sourcegraph/campaign-stats", 1)
+	if err := rstore.UpsertRepos(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	synced := &campaigns.Campaign{Name: "synced", NamespaceUserID: userID, InitialApplierID: userID}
+	unsynced := &campaigns.Campaign{Name: "unsynced", NamespaceUserID: userID, InitialApplierID: userID}
+	for _, c := range []*campaigns.Campaign{synced, unsynced} {
+		if err := store.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, state := range []campaigns.ChangesetExternalState{campaigns.ChangesetExternalStateOpen, campaigns.ChangesetExternalStateMerged} {
+		createChangeset(t, ctx, store, testChangesetOpts{
+			repo:                repo.ID,
+			externalServiceType: "github",
+			externalID:          fmt.Sprintf("stats-%d", i),
+			externalState:       state,
+			publicationState:    campaigns.ChangesetPublicationStatePublished,
+			reconcilerState:     campaigns.ReconcilerStateCompleted,
+			campaign:            synced.ID,
+		})
+	}
+	createChangeset(t, ctx, store, testChangesetOpts{
+		repo:                repo.ID,
+		externalServiceType: "github",
+		publicationState:    campaigns.ChangesetPublicationStateUnpublished,
+		reconcilerState:     campaigns.ReconcilerStateCompleted,
+		campaign:            unsynced.ID,
+	})
+
+	s, err := graphqlbackend.NewSchema(&Resolver{store: store}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var response struct{ Campaigns apitest.CampaignConnection }
+	apitest.MustExec(ctx, t, s, nil, &response, queryCampaignConnectionStats)
+
+	have := make(map[string]apitest.CampaignStats, len(response.Campaigns.Nodes))
+	for _, c := range response.Campaigns.Nodes {
+		have[c.Name] = c.Stats
+	}
+	if have["synced"].LastSyncedAt == nil {
+		t.Fatal("lastSyncedAt of synced campaign is null")
+	}
+	want := map[string]apitest.CampaignStats{
+		"synced":   {Total: 2, Open: 1, Merged: 1, LastSyncedAt: have["synced"].LastSyncedAt},
+		"unsynced": {Total: 1, Unpublished: 1},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+	}
 }
 
+const queryCampaignConnectionStats = `
+query {
+  campaigns {
+    nodes {
+      name
+      stats {
+        total, unpublished, open, merged, closed, archived
+        diffStat { added, changed, deleted }
+        lastSyncedAt
+      }
+    }
+  }
+}
+`
+
+const queryCampaignConnection = `
+query($first: Int!, $after: String) {
+  campaigns(first: $first, after: $after) {
+    totalCount
+    pageInfo { hasNextPage, endCursor }
+    nodes { id }
+  }
+}
+`
+
 const queryCampaign = `
 fragment u on User { databaseID, siteAdmin }
 fragment o on Org  { name }
@@ -88,7 +258,7 @@ fragment o on Org  { name }
 query($campaign: ID!){
   node(id: $campaign) {
     ... on Campaign {
-      id, name, description
+      id, name, description, descriptionHTML
       initialApplier { ...u }
       lastApplier    { ...u }
       lastAppliedAt
@@ -101,3 +271,22 @@ query($campaign: ID!){
   }
 }
 `
+
+const queryCampaignByName = `
+fragment u on User { databaseID, siteAdmin }
+fragment o on Org  { name }
+
+query($namespace: ID!, $name: String!){
+  campaign(namespace: $namespace, name: $name) {
+    id, name, description, descriptionHTML
+    initialApplier { ...u }
+    lastApplier    { ...u }
+    lastAppliedAt
+    namespace {
+      ... on User { ...u }
+      ... on Org  { ...o }
+    }
+    url
+  }
+}
+`
@@ -2,7 +2,9 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,14 +15,15 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/externallink"
-	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -103,6 +106,12 @@ func (r *changesetResolver) repoAccessible() bool {
 
 func (r *changesetResolver) computeSpec(ctx context.Context) (*campaigns.ChangesetSpec, error) {
 	r.specOnce.Do(func() {
+		tr, ctx := r.trace(ctx, "changesetResolver.computeSpec")
+		defer func() {
+			tr.SetError(r.specErr)
+			tr.Finish()
+		}()
+
 		if r.changeset.CurrentSpecID == 0 {
 			r.specErr = errors.New("Changeset has no ChangesetSpec")
 			return
@@ -115,6 +124,12 @@ func (r *changesetResolver) computeSpec(ctx context.Context) (*campaigns.Changes
 
 func (r *changesetResolver) computeEvents(ctx context.Context) ([]*campaigns.ChangesetEvent, error) {
 	r.eventsOnce.Do(func() {
+		tr, ctx := r.trace(ctx, "changesetResolver.computeEvents")
+		defer func() {
+			tr.SetError(r.eventsErr)
+			tr.Finish()
+		}()
+
 		opts := ee.ListChangesetEventsOpts{
 			ChangesetIDs: []int64{r.changeset.ID},
 			Limit:        -1,
@@ -137,6 +152,13 @@ func (r *changesetResolver) computeNextSyncAt(ctx context.Context) (time.Time, e
 			}
 			return
 		}
+
+		tr, ctx := r.trace(ctx, "changesetResolver.computeNextSyncAt")
+		defer func() {
+			tr.SetError(r.nextSyncAtErr)
+			tr.Finish()
+		}()
+
 		syncData, err := r.store.ListChangesetSyncData(ctx, ee.ListChangesetSyncDataOpts{ChangesetIDs: []int64{r.changeset.ID}})
 		if err != nil {
 			r.nextSyncAtErr = err
@@ -152,6 +174,12 @@ func (r *changesetResolver) computeNextSyncAt(ctx context.Context) (time.Time, e
 	return r.nextSyncAt, r.nextSyncAtErr
 }
 
+// trace starts a trace span for the given compute path of the resolver,
+// tagged with the ID of the changeset.
+func (r *changesetResolver) trace(ctx context.Context, family string) (*trace.Trace, context.Context) {
+	return trace.New(ctx, family, fmt.Sprintf("Changeset %d", r.changeset.ID), changesetIDTag(r.changeset.ID))
+}
+
 func (r *changesetResolver) ID() graphql.ID {
 	return marshalChangesetID(r.changeset.ID)
 }
@@ -180,6 +208,12 @@ func (r *changesetResolver) Campaigns(ctx context.Context, args *graphqlbackend.
 	if args.First != nil {
 		opts.Limit = int(*args.First)
 	}
+	if args.After != nil {
+		opts.Cursor, err = strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing after cursor")
+		}
+	}
 
 	authErr := backend.CheckCurrentUserIsSiteAdmin(ctx)
 	if authErr != nil && authErr != backend.ErrMustBeSiteAdmin {
@@ -187,10 +221,13 @@ func (r *changesetResolver) Campaigns(ctx context.Context, args *graphqlbackend.
 	}
 	isSiteAdmin := authErr != backend.ErrMustBeSiteAdmin
 	if !isSiteAdmin {
+		actor := actor.FromContext(ctx)
 		if args.ViewerCanAdminister != nil && *args.ViewerCanAdminister {
-			actor := actor.FromContext(ctx)
-			opts.InitialApplierID = actor.UID
+			opts.AdministeredBy = ee.NewCampaignAdmin(actor.UID)
 		}
+		// 🚨 SECURITY: Non-site-admins only see the campaigns whose
+		// visibility allows them to.
+		opts.VisibleTo = &ee.CampaignViewer{UserID: actor.UID}
 	}
 
 	return &campaignsConnectionResolver{store: r.store, httpFactory: r.httpFactory, opts: opts}, nil
@@ -280,6 +317,20 @@ func (r *changesetResolver) ExternalURL() (*externallink.Resolver, error) {
 	return externallink.NewResolver(url, r.changeset.ExternalServiceType), nil
 }
 
+func (r *changesetResolver) ExternalURLState() *campaigns.ChangesetURLState {
+	if r.changeset.ExternalURLState == "" {
+		return nil
+	}
+	return &r.changeset.ExternalURLState
+}
+
+func (r *changesetResolver) ExternalURLCheckedAt() *graphqlbackend.DateTime {
+	if r.changeset.ExternalURLCheckedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.changeset.ExternalURLCheckedAt}
+}
+
 func (r *changesetResolver) ReviewState(ctx context.Context) *campaigns.ChangesetReviewState {
 	if r.changeset.PublicationState.Unpublished() {
 		return nil
@@ -300,8 +351,120 @@ func (r *changesetResolver) CheckState() *campaigns.ChangesetCheckState {
 	return &state
 }
 
+func (r *changesetResolver) Mergeable() *campaigns.ChangesetMergeableState {
+	if r.changeset.PublicationState.Unpublished() || r.changeset.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return nil
+	}
+
+	state := r.changeset.MergeableState()
+	return &state
+}
+
+func (r *changesetResolver) ArchivedAt() *graphqlbackend.DateTime {
+	if !r.changeset.Archived() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.changeset.ArchivedAt}
+}
+
+func (r *changesetResolver) SkippedReason() *string {
+	if r.changeset.SkippedReason == "" {
+		return nil
+	}
+	return &r.changeset.SkippedReason
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
+func (r *changesetResolver) ErrorClass() *failure.Class {
+	if r.changeset.FailureMessage == nil || r.changeset.FailureClass == "" {
+		return nil
+	}
+
+	class := r.changeset.FailureClass
+	return &class
+}
+
+func (r *changesetResolver) ErrorCode() *campaigns.ChangesetErrorCode {
+	if r.changeset.FailureMessage == nil || r.changeset.FailureCode == "" {
+		return nil
+	}
+
+	code := r.changeset.FailureCode
+	return &code
+}
+
+func (r *changesetResolver) NumFailures() int32 { return int32(r.changeset.NumFailures) }
+
+func (r *changesetResolver) WaitReason(ctx context.Context) (graphqlbackend.ChangesetWaitReasonResolver, error) {
+	if r.changeset.ReconcilerState != campaigns.ReconcilerStateQueued {
+		return nil, nil
+	}
+
+	// A changeset that was put back into the queue by the reconciler isn't
+	// processed before ProcessAfter, regardless of its position in the queue.
+	if r.changeset.ProcessAfter.After(r.store.Clock()()) {
+		kind := r.changeset.WaitReason
+		if kind == "" || kind == campaigns.ChangesetWaitReasonQueue {
+			kind = campaigns.ChangesetWaitReasonRateLimit
+		}
+		until := r.changeset.ProcessAfter
+		return &changesetWaitReasonResolver{kind: kind, until: &until}, nil
+	}
+
+	position, err := r.store.GetChangesetQueuePosition(ctx, r.changeset)
+	if err != nil {
+		return nil, err
+	}
+	return &changesetWaitReasonResolver{kind: campaigns.ChangesetWaitReasonQueue, queuePosition: &position}, nil
+}
+
+func (r *changesetResolver) PlannedOperations(ctx context.Context) ([]graphqlbackend.ChangesetPlannedOperationResolver, error) {
+	ops, err := ee.PlanOperations(ctx, r.store, r.changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetPlannedOperationResolver, 0, len(ops))
+	for _, op := range ops {
+		resolvers = append(resolvers, &changesetPlannedOperationResolver{op: op})
+	}
+	return resolvers, nil
+}
+
+func (r *changesetResolver) CustomMetadata() []graphqlbackend.ChangesetCustomMetadataEntryResolver {
+	keys := make([]string, 0, len(r.changeset.CustomMetadata))
+	for k := range r.changeset.CustomMetadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	resolvers := make([]graphqlbackend.ChangesetCustomMetadataEntryResolver, 0, len(keys))
+	for _, k := range keys {
+		resolvers = append(resolvers, &changesetCustomMetadataEntryResolver{
+			key:   k,
+			value: r.changeset.CustomMetadata[k],
+		})
+	}
+	return resolvers
+}
+
+func (r *changesetResolver) Checks(ctx context.Context) ([]graphqlbackend.ChangesetCheckResolver, error) {
+	es, err := r.computeEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like Labels, the checks of the last sync are combined with the
+	// changeset events that came in via webhooks since then.
+	checks := ee.ComputeChecks(r.changeset, es)
+	resolvers := make([]graphqlbackend.ChangesetCheckResolver, 0, len(checks))
+	for _, c := range checks {
+		resolvers = append(resolvers, &changesetCheckResolver{check: c})
+	}
+	return resolvers, nil
+}
+
 func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.ChangesetLabelResolver, error) {
 	// Not every code host supports labels on changesets so don't make a DB call unless we need to.
 	if ok := r.changeset.SupportsLabels(); !ok {
@@ -325,15 +488,48 @@ func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.Change
 	return resolvers, nil
 }
 
-func (r *changesetResolver) Events(ctx context.Context, args *struct {
-	graphqlutil.ConnectionArgs
-}) (graphqlbackend.ChangesetEventsConnectionResolver, error) {
+func (r *changesetResolver) ReviewThreads(ctx context.Context) ([]graphqlbackend.ChangesetReviewThreadResolver, error) {
+	es, err := r.computeEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := ee.ComputeReviewThreads(es)
+	resolvers := make([]graphqlbackend.ChangesetReviewThreadResolver, 0, len(threads))
+	for _, t := range threads {
+		resolvers = append(resolvers, &changesetReviewThreadResolver{thread: t})
+	}
+	return resolvers, nil
+}
+
+func (r *changesetResolver) Events(ctx context.Context, args *graphqlbackend.ChangesetEventsConnectionArgs) (graphqlbackend.ChangesetEventsConnectionResolver, error) {
 	// TODO: We already need to fetch all events for ReviewState and Labels
 	// perhaps we can use the cached data here
+	opts := ee.ListChangesetEventsOpts{ChangesetIDs: []int64{r.changeset.ID}}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing after cursor")
+		}
+		opts.Cursor = cursor
+	}
+	if args.Kinds != nil {
+		for _, c := range *args.Kinds {
+			if !c.Valid() {
+				return nil, errors.Errorf("changeset event category not valid: %q", c)
+			}
+			opts.Kinds = append(opts.Kinds, c.Kinds()...)
+		}
+	}
+
 	return &changesetEventsConnectionResolver{
 		store:             r.store,
+		httpFactory:       r.httpFactory,
 		changesetResolver: r,
-		first:             int(args.GetFirst()),
+		opts:              opts,
 	}, nil
 }
 
@@ -494,6 +690,76 @@ func (r *changesetResolver) commitID(ctx context.Context, repo *graphqlbackend.R
 	return git.ResolveRevision(ctx, *grepo, nil, refName, git.ResolveRevisionOptions{})
 }
 
+type changesetCheckResolver struct {
+	check campaigns.ChangesetCheck
+}
+
+func (r *changesetCheckResolver) Name() string {
+	return r.check.Name
+}
+
+func (r *changesetCheckResolver) State() campaigns.ChangesetCheckState {
+	return r.check.State
+}
+
+func (r *changesetCheckResolver) URL() *string {
+	if r.check.URL == "" {
+		return nil
+	}
+	return &r.check.URL
+}
+
+type changesetReviewThreadResolver struct {
+	thread campaigns.ChangesetReviewThread
+}
+
+func (r *changesetReviewThreadResolver) Resolved() *bool {
+	return r.thread.Resolved
+}
+
+func (r *changesetReviewThreadResolver) Path() string {
+	return r.thread.Path
+}
+
+func (r *changesetReviewThreadResolver) Line() *int32 {
+	if r.thread.Line == 0 {
+		return nil
+	}
+	line := int32(r.thread.Line)
+	return &line
+}
+
+func (r *changesetReviewThreadResolver) Comments() []graphqlbackend.ChangesetReviewCommentResolver {
+	resolvers := make([]graphqlbackend.ChangesetReviewCommentResolver, 0, len(r.thread.Comments))
+	for _, c := range r.thread.Comments {
+		resolvers = append(resolvers, &changesetReviewCommentResolver{comment: c})
+	}
+	return resolvers
+}
+
+type changesetReviewCommentResolver struct {
+	comment campaigns.ChangesetReviewComment
+}
+
+func (r *changesetReviewCommentResolver) Author() string {
+	return r.comment.Author
+}
+
+func (r *changesetReviewCommentResolver) Body() string {
+	return r.comment.Body
+}
+
+func (r *changesetReviewCommentResolver) URL() *string {
+	if r.comment.URL == "" {
+		return nil
+	}
+	return &r.comment.URL
+}
+
+func (r *changesetReviewCommentResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.comment.CreatedAt}
+}
+
 type changesetLabelResolver struct {
 	label campaigns.ChangesetLabel
 }
@@ -512,3 +778,72 @@ func (r *changesetLabelResolver) Description() *string {
 	}
 	return &r.label.Description
 }
+
+type changesetPlannedOperationResolver struct {
+	op ee.PlannedOperation
+}
+
+func (r *changesetPlannedOperationResolver) Operation() campaigns.ReconcilerOperation {
+	return r.op.Operation
+}
+
+func (r *changesetPlannedOperationResolver) Reason() string {
+	return r.op.Reason
+}
+
+type changesetCustomMetadataEntryResolver struct {
+	key, value string
+}
+
+func (r *changesetCustomMetadataEntryResolver) Key() string {
+	return r.key
+}
+
+func (r *changesetCustomMetadataEntryResolver) Value() string {
+	return r.value
+}
+
+type changesetWaitReasonResolver struct {
+	kind          campaigns.ChangesetWaitReason
+	until         *time.Time
+	queuePosition *int
+}
+
+func (r *changesetWaitReasonResolver) Kind() campaigns.ChangesetWaitReason {
+	return r.kind
+}
+
+func (r *changesetWaitReasonResolver) Message() string {
+	switch r.kind {
+	case campaigns.ChangesetWaitReasonRateLimit:
+		return fmt.Sprintf("The code host's rate limit was exceeded. Retrying at %s.", r.until.UTC().Format(time.RFC3339))
+	case campaigns.ChangesetWaitReasonDependency:
+		return fmt.Sprintf("Waiting for the repository to be cloned. Retrying at %s.", r.until.UTC().Format(time.RFC3339))
+	case campaigns.ChangesetWaitReasonRolloutWindow:
+		return fmt.Sprintf("Publication is delayed by the rollout windows configured by site admins. Retrying at %s.", r.until.UTC().Format(time.RFC3339))
+	case campaigns.ChangesetWaitReasonRetry:
+		return fmt.Sprintf("Processing failed. Retrying at %s.", r.until.UTC().Format(time.RFC3339))
+	case campaigns.ChangesetWaitReasonPaused:
+		return "The campaign is paused. Waiting for it to be resumed."
+	default:
+		if r.queuePosition != nil && *r.queuePosition > 1 {
+			return fmt.Sprintf("Waiting for %d changesets queued before this one to be processed.", *r.queuePosition-1)
+		}
+		return "Waiting to be processed."
+	}
+}
+
+func (r *changesetWaitReasonResolver) Until() *graphqlbackend.DateTime {
+	if r.until == nil {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: *r.until}
+}
+
+func (r *changesetWaitReasonResolver) QueuePosition() *int32 {
+	if r.queuePosition == nil {
+		return nil
+	}
+	position := int32(*r.queuePosition)
+	return &position
+}
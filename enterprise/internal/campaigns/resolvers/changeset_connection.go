@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 type changesetsConnectionResolver struct {
@@ -31,16 +33,27 @@ type changesetsConnectionResolver struct {
 	reposByID  map[api.RepoID]*types.Repo
 	err        error
 
-	// allAccessibleChangesets contains all changesets in this connection,
-	// without any pagination.
-	// We need them for TotalCount and Stats and we need to load all, without a
-	// limit, because some might be filtered out by the authzFilter.
-	//
-	// NOTE: In the future, as an optimization, we can combine this with
-	// `changesets`, since changesets is a subset of `allAccessibleChangesets`.
-	allAccessibleChangesetsOnce sync.Once
-	allAccessibleChangesets     campaigns.Changesets
-	allAccessibleChangesetsErr  error
+	// accessibleCount is the number of changesets in this connection, without
+	// any pagination. We need it for TotalCount and PageInfo and we need to
+	// count all, without a limit, because some might be filtered out by the
+	// authzFilter.
+	accessibleCountOnce sync.Once
+	accessibleCount     int32
+	accessibleCountErr  error
+
+	// statsByRepo are the stats of the changesets in this connection, without
+	// any pagination, in each repository. statsAccessibleRepos are the
+	// repositories among them that the user has access to.
+	statsOnce            sync.Once
+	statsByRepo          map[api.RepoID]*campaigns.ChangesetsStats
+	statsAccessibleRepos map[api.RepoID]*types.Repo
+	statsErr             error
+
+	// hiddenCount is the number of changesets in this connection, without any
+	// pagination, that are in repositories the user doesn't have access to.
+	hiddenCountOnce sync.Once
+	hiddenCount     int32
+	hiddenCountErr  error
 }
 
 func (r *changesetsConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.ChangesetResolver, error) {
@@ -83,33 +96,133 @@ func (r *changesetsConnectionResolver) Nodes(ctx context.Context) ([]graphqlback
 }
 
 func (r *changesetsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
-	cs, err := r.computeAllAccessibleChangesets(ctx)
-	if err != nil {
-		return 0, err
-	}
-	return int32(len(cs)), nil
+	return r.computeAccessibleCount(ctx)
 }
 
 func (r *changesetsConnectionResolver) Stats(ctx context.Context) (graphqlbackend.ChangesetsConnectionStatsResolver, error) {
-	cs, err := r.computeAllAccessibleChangesets(ctx)
+	byRepo, accessibleRepos, err := r.computeStatsByRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hidden, err := r.HiddenCount(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return newChangesetConnectionStats(cs), nil
+
+	stats := &changesetsConnectionStatsResolver{hidden: hidden}
+	for repoID, repoStats := range byRepo {
+		// 🚨 SECURITY: Hidden changesets are only included in the stats if
+		// the opts don't leak information about them.
+		if _, ok := accessibleRepos[repoID]; !ok && !r.optsSafe {
+			continue
+		}
+		stats.stats.Add(repoStats)
+	}
+	return stats, nil
+}
+
+// computeStatsByRepo returns the stats of the changesets matched by r.opts,
+// ignoring pagination, in each repository, and the repositories among them
+// that the user has access to.
+func (r *changesetsConnectionResolver) computeStatsByRepo(ctx context.Context) (map[api.RepoID]*campaigns.ChangesetsStats, map[api.RepoID]*types.Repo, error) {
+	r.statsOnce.Do(func() {
+		tr, ctx := r.trace(ctx, "changesetsConnectionResolver.computeStatsByRepo")
+		defer func() {
+			tr.SetError(r.statsErr)
+			tr.Finish()
+		}()
+
+		r.statsByRepo, r.statsAccessibleRepos, r.statsErr = listChangesetsStatsByRepo(ctx, r.store, r.opts)
+	})
+
+	return r.statsByRepo, r.statsAccessibleRepos, r.statsErr
+}
+
+// listChangesetsStatsByRepo returns the stats of the changesets matched by
+// the given opts in each repository, and the repositories among them that the
+// user has access to.
+func listChangesetsStatsByRepo(ctx context.Context, store *ee.Store, opts ee.ListChangesetsOpts) (map[api.RepoID]*campaigns.ChangesetsStats, map[api.RepoID]*types.Repo, error) {
+	byRepo, err := store.ListChangesetsStatsByRepo(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(byRepo))
+	for repoID := range byRepo {
+		repoIDs = append(repoIDs, repoID)
+	}
+
+	// 🚨 SECURITY: db.Repos.GetRepoIDsSet uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	accessibleRepos, err := db.Repos.GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return byRepo, accessibleRepos, nil
 }
 
-// computeAllChangesets loads all changesets matched by r.opts, but without a
-// limit.
-// If r.optsSafe is true, it returns all of them. If not, it filters out the
-// ones to which the user doesn't have access.
-func (r *changesetsConnectionResolver) computeAllAccessibleChangesets(ctx context.Context) (campaigns.Changesets, error) {
-	r.allAccessibleChangesetsOnce.Do(func() {
+// HiddenCount returns the number of changesets matched by r.opts that are in
+// repositories the user doesn't have access to. Stats reports the same
+// number, and if the opts are safe, both are computed from the same stats.
+func (r *changesetsConnectionResolver) HiddenCount(ctx context.Context) (int32, error) {
+	r.hiddenCountOnce.Do(func() {
+		tr, ctx := r.trace(ctx, "changesetsConnectionResolver.HiddenCount")
+		defer func() {
+			tr.SetError(r.hiddenCountErr)
+			tr.Finish()
+		}()
+
+		var (
+			byRepo          map[api.RepoID]*campaigns.ChangesetsStats
+			accessibleRepos map[api.RepoID]*types.Repo
+			err             error
+		)
+		if r.optsSafe {
+			byRepo, accessibleRepos, err = r.computeStatsByRepo(ctx)
+		} else {
+			// 🚨 SECURITY: The opts would leak information about the hidden
+			// changesets, so we count them without the filters that leak
+			// information. Only the number is returned, never the
+			// changesets or their repositories.
+			opts := r.opts
+			opts.ExternalReviewState = nil
+			opts.ExternalCheckState = nil
+			opts.CustomMetadata = nil
+			byRepo, accessibleRepos, err = listChangesetsStatsByRepo(ctx, r.store, opts)
+		}
+		if err != nil {
+			r.hiddenCountErr = err
+			return
+		}
+
+		for repoID, repoStats := range byRepo {
+			if _, ok := accessibleRepos[repoID]; !ok {
+				r.hiddenCount += repoStats.Total
+			}
+		}
+	})
+
+	return r.hiddenCount, r.hiddenCountErr
+}
+
+// computeAccessibleCount counts all changesets matched by r.opts, but without
+// a limit.
+// If r.optsSafe is true, it counts all of them. If not, it leaves out the ones
+// to which the user doesn't have access.
+func (r *changesetsConnectionResolver) computeAccessibleCount(ctx context.Context) (int32, error) {
+	r.accessibleCountOnce.Do(func() {
+		tr, ctx := r.trace(ctx, "changesetsConnectionResolver.computeAccessibleCount")
+		defer func() {
+			tr.SetError(r.accessibleCountErr)
+			tr.Finish()
+		}()
+
 		opts := r.opts
-		opts.Limit = -1
+		opts.Limit = 0
 
-		cs, _, err := r.store.ListChangesets(ctx, opts)
+		byRepo, err := countChangesetsByRepo(ctx, r.store, opts)
 		if err != nil {
-			r.allAccessibleChangesetsErr = err
+			r.accessibleCountErr = err
 			return
 		}
 
@@ -117,30 +230,55 @@ func (r *changesetsConnectionResolver) computeAllAccessibleChangesets(ctx contex
 		// number of changesets. Otherwise we have to filter the changesets by
 		// accessible repos.
 		if r.optsSafe {
-			r.allAccessibleChangesets = cs
+			for _, count := range byRepo {
+				r.accessibleCount += count
+			}
 			return
 		}
 
 		// 🚨 SECURITY: db.Repos.GetRepoIDsSet uses the authzFilter under the hood and
 		// filters out repositories that the user doesn't have access to.
-		accessibleRepos, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+		accessibleRepos, err := db.Repos.GetReposSetByIDs(ctx, byRepo.repoIDs()...)
 		if err != nil {
-			r.allAccessibleChangesetsErr = err
+			r.accessibleCountErr = err
 			return
 		}
 
-		var accessibleChangesets []*campaigns.Changeset
-		for _, c := range cs {
-			if _, ok := accessibleRepos[c.RepoID]; !ok {
-				continue
+		for repoID, count := range byRepo {
+			if _, ok := accessibleRepos[repoID]; ok {
+				r.accessibleCount += count
 			}
-			accessibleChangesets = append(accessibleChangesets, c)
 		}
-
-		r.allAccessibleChangesets = accessibleChangesets
 	})
 
-	return r.allAccessibleChangesets, r.allAccessibleChangesetsErr
+	return r.accessibleCount, r.accessibleCountErr
+}
+
+// trace starts a trace span for the given compute path of the resolver,
+// tagged with the ID of the campaign the changesets are listed for, if any.
+func (r *changesetsConnectionResolver) trace(ctx context.Context, family string) (*trace.Trace, context.Context) {
+	if r.opts.CampaignID == 0 {
+		return trace.New(ctx, family, "")
+	}
+	return trace.New(ctx, family, fmt.Sprintf("Campaign %d", r.opts.CampaignID), campaignIDTag(r.opts.CampaignID))
+}
+
+// repoChangesetCounts is the number of changesets in each repository.
+type repoChangesetCounts map[api.RepoID]int32
+
+func (c repoChangesetCounts) repoIDs() []api.RepoID {
+	ids := make([]api.RepoID, 0, len(c))
+	for id := range c {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// countChangesetsByRepo counts the changesets matched by the given opts in
+// each repository, starting at opts.Cursor, in the database.
+func countChangesetsByRepo(ctx context.Context, store *ee.Store, opts ee.ListChangesetsOpts) (repoChangesetCounts, error) {
+	counts, err := store.CountChangesetsByRepo(ctx, opts)
+	return repoChangesetCounts(counts), err
 }
 
 func (r *changesetsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
@@ -148,16 +286,22 @@ func (r *changesetsConnectionResolver) PageInfo(ctx context.Context) (*graphqlut
 	if err != nil {
 		return nil, err
 	}
-	all, err := r.computeAllAccessibleChangesets(ctx)
+	total, err := r.computeAccessibleCount(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return graphqlutil.HasNextPage(len(page) < len(all)), nil
+	return graphqlutil.HasNextPage(int32(len(page)) < total), nil
 }
 
 func (r *changesetsConnectionResolver) compute(ctx context.Context) (campaigns.Changesets, map[api.RepoID]*types.Repo, error) {
 	r.once.Do(func() {
+		tr, ctx := r.trace(ctx, "changesetsConnectionResolver.compute")
+		defer func() {
+			tr.SetError(r.err)
+			tr.Finish()
+		}()
+
 		r.changesets, _, r.err = r.store.ListChangesets(ctx, r.opts)
 		if r.err != nil {
 			return
@@ -171,46 +315,41 @@ func (r *changesetsConnectionResolver) compute(ctx context.Context) (campaigns.C
 	return r.changesets, r.reposByID, r.err
 }
 
-func newChangesetConnectionStats(cs []*campaigns.Changeset) *changesetsConnectionStatsResolver {
-	stats := &changesetsConnectionStatsResolver{
-		total: int32(len(cs)),
-	}
-
-	for _, c := range cs {
-		if c.PublicationState.Unpublished() {
-			stats.unpublished++
-			continue
-		}
-
-		switch c.ExternalState {
-		case campaigns.ChangesetExternalStateClosed:
-			stats.closed++
-		case campaigns.ChangesetExternalStateMerged:
-			stats.merged++
-		case campaigns.ChangesetExternalStateOpen:
-			stats.open++
-		}
-	}
-
-	return stats
-}
-
 type changesetsConnectionStatsResolver struct {
-	unpublished, open, merged, closed, total int32
+	stats  campaigns.ChangesetsStats
+	hidden int32
 }
 
 func (r *changesetsConnectionStatsResolver) Unpublished() int32 {
-	return r.unpublished
+	return r.stats.Unpublished
 }
 func (r *changesetsConnectionStatsResolver) Open() int32 {
-	return r.open
+	return r.stats.Open
 }
 func (r *changesetsConnectionStatsResolver) Merged() int32 {
-	return r.merged
+	return r.stats.Merged
 }
 func (r *changesetsConnectionStatsResolver) Closed() int32 {
-	return r.closed
+	return r.stats.Closed
+}
+func (r *changesetsConnectionStatsResolver) Archived() int32 {
+	return r.stats.Archived
+}
+func (r *changesetsConnectionStatsResolver) Conflicting() int32 {
+	return r.stats.Conflicting
+}
+func (r *changesetsConnectionStatsResolver) Unreviewed() int32 {
+	return r.stats.Unreviewed
+}
+func (r *changesetsConnectionStatsResolver) Approved() int32 {
+	return r.stats.Approved
+}
+func (r *changesetsConnectionStatsResolver) ChangesRequested() int32 {
+	return r.stats.ChangesRequested
 }
 func (r *changesetsConnectionStatsResolver) Total() int32 {
-	return r.total
+	return r.stats.Total
+}
+func (r *changesetsConnectionStatsResolver) Hidden() int32 {
+	return r.hidden
 }
@@ -131,16 +131,19 @@ func TestChangesetConnectionResolver(t *testing.T) {
 		wantHasNextPage bool
 		wantTotalCount  int
 		wantOpen        int
+		wantHidden      int
 		wantNodes       []apitest.Changeset
 	}{
-		{firstParam: 1, wantHasNextPage: true, wantTotalCount: 4, wantOpen: 2, wantNodes: nodes[:1]},
-		{firstParam: 2, wantHasNextPage: true, wantTotalCount: 4, wantOpen: 2, wantNodes: nodes[:2]},
-		{firstParam: 3, wantHasNextPage: true, wantTotalCount: 4, wantOpen: 2, wantNodes: nodes[:3]},
-		{firstParam: 4, wantHasNextPage: false, wantTotalCount: 4, wantOpen: 2, wantNodes: nodes[:4]},
-		// Expect only 3 changesets to be returned when an unsafe filter is applied.
-		{firstParam: 1, useUnsafeOpts: true, wantHasNextPage: true, wantTotalCount: 3, wantOpen: 1, wantNodes: nodes[:1]},
-		{firstParam: 2, useUnsafeOpts: true, wantHasNextPage: true, wantTotalCount: 3, wantOpen: 1, wantNodes: nodes[:2]},
-		{firstParam: 3, useUnsafeOpts: true, wantHasNextPage: false, wantTotalCount: 3, wantOpen: 1, wantNodes: nodes[:3]},
+		{firstParam: 1, wantHasNextPage: true, wantTotalCount: 4, wantOpen: 2, wantHidden: 1, wantNodes: nodes[:1]},
+		{firstParam: 2, wantHasNextPage: true, wantTotalCount: 4, wantOpen: 2, wantHidden: 1, wantNodes: nodes[:2]},
+		{firstParam: 3, wantHasNextPage: true, wantTotalCount: 4, wantOpen: 2, wantHidden: 1, wantNodes: nodes[:3]},
+		{firstParam: 4, wantHasNextPage: false, wantTotalCount: 4, wantOpen: 2, wantHidden: 1, wantNodes: nodes[:4]},
+		// Expect only 3 changesets to be returned when an unsafe filter is applied,
+		// but the hidden changeset to still be counted, both in the stats and
+		// in hiddenCount.
+		{firstParam: 1, useUnsafeOpts: true, wantHasNextPage: true, wantTotalCount: 3, wantOpen: 1, wantHidden: 1, wantNodes: nodes[:1]},
+		{firstParam: 2, useUnsafeOpts: true, wantHasNextPage: true, wantTotalCount: 3, wantOpen: 1, wantHidden: 1, wantNodes: nodes[:2]},
+		{firstParam: 3, useUnsafeOpts: true, wantHasNextPage: false, wantTotalCount: 3, wantOpen: 1, wantHidden: 1, wantNodes: nodes[:3]},
 	}
 
 	for _, tc := range tests {
@@ -159,8 +162,10 @@ func TestChangesetConnectionResolver(t *testing.T) {
 					Merged:      1,
 					Closed:      0,
 					Total:       tc.wantTotalCount,
+					Hidden:      tc.wantHidden,
 				},
-				TotalCount: tc.wantTotalCount,
+				TotalCount:  tc.wantTotalCount,
+				HiddenCount: 1,
 				PageInfo: apitest.PageInfo{
 					HasNextPage: tc.wantHasNextPage,
 				},
@@ -180,7 +185,8 @@ query($campaign: ID!, $first: Int, $reviewState: ChangesetReviewState){
     ... on Campaign {
       changesets(first: $first, reviewState: $reviewState) {
         totalCount
-        stats { unpublished, open, merged, closed, total }
+        hiddenCount
+        stats { unpublished, open, merged, closed, conflicting, total, hidden }
         nodes {
           __typename
 
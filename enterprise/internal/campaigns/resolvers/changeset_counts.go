@@ -2,16 +2,27 @@ package resolvers
 
 import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
 )
 
 type changesetCountsResolver struct {
 	counts *ee.ChangesetCounts
+	// repo is only set if the counts are grouped by repository.
+	repo *types.Repo
 }
 
 func (r *changesetCountsResolver) Date() graphqlbackend.DateTime {
 	return graphqlbackend.DateTime{Time: r.counts.Time}
 }
+
+func (r *changesetCountsResolver) Repository() *graphqlbackend.RepositoryResolver {
+	if r.repo == nil {
+		return nil
+	}
+	return graphqlbackend.NewRepositoryResolver(r.repo)
+}
+
 func (r *changesetCountsResolver) Total() int32                { return r.counts.Total }
 func (r *changesetCountsResolver) Merged() int32               { return r.counts.Merged }
 func (r *changesetCountsResolver) Closed() int32               { return r.counts.Closed }
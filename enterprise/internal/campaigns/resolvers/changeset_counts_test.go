@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -113,6 +114,136 @@ func TestChangesetCountsOverTimeResolver(t *testing.T) {
 	}
 }
 
+func TestChangesetCountsOverTimeGroupByRepositoryCompacted(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CampaignsChangesetEventsRetentionMonths: 1,
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	userID := insertTestUser(t, dbconn.Global, "changeset-counts-compacted", true)
+	userCtx := actor.WithActor(ctx, actor.FromUser(userID))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now }
+	store := ee.NewStoreWithClock(dbconn.Global, clock)
+	rstore := repos.NewDBStore(dbconn.Global, sql.TxOptions{})
+
+	repo := newGitHubTestRepo("github.com/sourcegraph/compacted", 1)
+	if err := rstore.UpsertRepos(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	openedAt := now.AddDate(0, 0, -90)
+	approvedAt := now.AddDate(0, 0, -80)
+	mergedAt := now.AddDate(0, 0, -60)
+
+	campaign := &campaigns.Campaign{
+		Name:             "compacted",
+		NamespaceUserID:  userID,
+		InitialApplierID: userID,
+		CreatedAt:        openedAt,
+	}
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	changeset := createChangeset(t, ctx, store, testChangesetOpts{
+		repo:             repo.ID,
+		externalState:    campaigns.ChangesetExternalStateMerged,
+		publicationState: campaigns.ChangesetPublicationStatePublished,
+		campaign:         campaign.ID,
+		metadata: &github.PullRequest{
+			CreatedAt: openedAt,
+			TimelineItems: []github.TimelineItem{
+				{Type: "PullRequestReview", Item: &github.PullRequestReview{
+					DatabaseID: 1,
+					Author:     github.Actor{Login: "reviewer"},
+					State:      "APPROVED",
+					CreatedAt:  approvedAt,
+					UpdatedAt:  approvedAt,
+				}},
+				{Type: "MergedEvent", Item: &github.MergedEvent{
+					CreatedAt: mergedAt,
+				}},
+			},
+		},
+	})
+	changeset.ExternalUpdatedAt = mergedAt
+	if err := store.UpdateChangeset(ctx, changeset); err != nil {
+		t.Fatal(err)
+	}
+	addChangeset(t, ctx, store, campaign, changeset.ID)
+
+	// Compaction deletes the review, so the approval can only be shown from
+	// the stored counts.
+	if err := ee.CompactChangesetEvents(ctx, store); err != nil {
+		t.Fatal(err)
+	}
+	events, err := store.CountChangesetEvents(ctx, ee.CountChangesetEventsOpts{ChangesetID: changeset.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events != 1 {
+		t.Fatalf("events not compacted. want=%d, have=%d", 1, events)
+	}
+
+	start := approvedAt.AddDate(0, 0, 1)
+	end := approvedAt.AddDate(0, 0, 3)
+	groupBy := "REPOSITORY"
+
+	r := &campaignResolver{store: store, Campaign: campaign}
+	rs, err := r.ChangesetCountsOverTime(userCtx, &graphqlbackend.ChangesetCountsArgs{
+		From:       &graphqlbackend.DateTime{Time: start},
+		To:         &graphqlbackend.DateTime{Time: end},
+		GroupBy:    &groupBy,
+		GroupLimit: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have := make([]*ee.ChangesetCounts, 0, len(rs))
+	for _, cr := range rs {
+		r := cr.(*changesetCountsResolver)
+		if r.repo == nil || r.repo.ID != repo.ID {
+			t.Fatalf("counts not grouped by repository: %+v", r)
+		}
+		have = append(have, r.counts)
+	}
+
+	want := []*ee.ChangesetCounts{
+		{Time: start, Total: 1, Open: 1, OpenApproved: 1},
+		{Time: start.AddDate(0, 0, 1), Total: 1, Open: 1, OpenApproved: 1},
+		{Time: end, Total: 1, Open: 1, OpenApproved: 1},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong counts (-want +got):\n%s", diff)
+	}
+
+	// The counts of the whole campaign resume from the stored counts, up
+	// to now.
+	rs, err = r.ChangesetCountsOverTime(userCtx, &graphqlbackend.ChangesetCountsArgs{
+		From: &graphqlbackend.DateTime{Time: start},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, last := rs[0].(*changesetCountsResolver).counts, rs[len(rs)-1].(*changesetCountsResolver).counts
+	if diff := cmp.Diff(want[0], first); diff != "" {
+		t.Fatalf("wrong first counts (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&ee.ChangesetCounts{Time: now, Total: 1, Merged: 1}, last); diff != "" {
+		t.Fatalf("wrong last counts (-want +got):\n%s", diff)
+	}
+}
+
 const queryChangesetCountsConnection = `
 query($campaign: ID!) {
   node(id: $campaign) {
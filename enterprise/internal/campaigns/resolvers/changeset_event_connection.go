@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"strconv"
 	"sync"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -15,7 +16,7 @@ type changesetEventsConnectionResolver struct {
 	store             *ee.Store
 	httpFactory       *httpcli.Factory
 	changesetResolver *changesetResolver
-	first             int
+	opts              ee.ListChangesetEventsOpts
 
 	// cache results because they are used by multiple fields
 	once            sync.Once
@@ -42,7 +43,10 @@ func (r *changesetEventsConnectionResolver) Nodes(ctx context.Context) ([]graphq
 }
 
 func (r *changesetEventsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
-	opts := ee.CountChangesetEventsOpts{ChangesetID: r.changesetResolver.changeset.ID}
+	opts := ee.CountChangesetEventsOpts{
+		ChangesetID: r.changesetResolver.changeset.ID,
+		Kinds:       r.opts.Kinds,
+	}
 	count, err := r.store.CountChangesetEvents(ctx, opts)
 	return int32(count), err
 }
@@ -52,16 +56,15 @@ func (r *changesetEventsConnectionResolver) PageInfo(ctx context.Context) (*grap
 	if err != nil {
 		return nil, err
 	}
-	return graphqlutil.HasNextPage(next != 0), nil
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.FormatInt(next, 10)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
 }
 
 func (r *changesetEventsConnectionResolver) compute(ctx context.Context) ([]*campaigns.ChangesetEvent, int64, error) {
 	r.once.Do(func() {
-		opts := ee.ListChangesetEventsOpts{
-			ChangesetIDs: []int64{r.changesetResolver.changeset.ID},
-			Limit:        r.first,
-		}
-		r.changesetEvents, r.next, r.err = r.store.ListChangesetEvents(ctx, opts)
+		r.changesetEvents, r.next, r.err = r.store.ListChangesetEvents(ctx, r.opts)
 	})
 	return r.changesetEvents, r.next, r.err
 }
@@ -3,7 +3,7 @@ package resolvers
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -97,20 +97,38 @@ func TestChangesetEventConnectionResolver(t *testing.T) {
 		},
 	}
 
+	cursor := func(id int64) *string {
+		s := strconv.FormatInt(id, 10)
+		return &s
+	}
+
 	tests := []struct {
+		name            string
 		firstParam      int
+		after           *string
+		kinds           []string
 		wantHasNextPage bool
+		wantEndCursor   *string
 		wantTotalCount  int
 		wantNodes       []apitest.ChangesetEvent
 	}{
-		{firstParam: 1, wantHasNextPage: true, wantTotalCount: 2, wantNodes: nodes[:1]},
-		{firstParam: 2, wantHasNextPage: false, wantTotalCount: 2, wantNodes: nodes},
-		{firstParam: 3, wantHasNextPage: false, wantTotalCount: 2, wantNodes: nodes},
+		{name: "first=1", firstParam: 1, wantHasNextPage: true, wantEndCursor: cursor(2), wantTotalCount: 2, wantNodes: nodes[:1]},
+		{name: "first=2", firstParam: 2, wantHasNextPage: false, wantTotalCount: 2, wantNodes: nodes},
+		{name: "first=3", firstParam: 3, wantHasNextPage: false, wantTotalCount: 2, wantNodes: nodes},
+		{name: "first=1 after", firstParam: 1, after: cursor(2), wantHasNextPage: false, wantTotalCount: 2, wantNodes: nodes[1:]},
+		{name: "kinds=COMMIT", firstParam: 2, kinds: []string{"COMMIT"}, wantHasNextPage: false, wantTotalCount: 1, wantNodes: nodes[:1]},
+		{name: "kinds=REVIEW", firstParam: 2, kinds: []string{"REVIEW", "CHECK_RUN"}, wantHasNextPage: false, wantTotalCount: 0, wantNodes: []apitest.ChangesetEvent{}},
 	}
 
 	for _, tc := range tests {
-		t.Run(fmt.Sprintf("first=%d", tc.firstParam), func(t *testing.T) {
+		t.Run(tc.name, func(t *testing.T) {
 			input := map[string]interface{}{"changeset": changesetAPIID, "first": int64(tc.firstParam)}
+			if tc.after != nil {
+				input["after"] = *tc.after
+			}
+			if tc.kinds != nil {
+				input["kinds"] = tc.kinds
+			}
 			var response struct{ Node apitest.Changeset }
 			apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(userID)), t, s, input, &response, queryChangesetEventConnection)
 
@@ -118,6 +136,7 @@ func TestChangesetEventConnectionResolver(t *testing.T) {
 				TotalCount: tc.wantTotalCount,
 				PageInfo: apitest.PageInfo{
 					HasNextPage: tc.wantHasNextPage,
+					EndCursor:   tc.wantEndCursor,
 				},
 				Nodes: tc.wantNodes,
 			}
@@ -130,13 +149,14 @@ func TestChangesetEventConnectionResolver(t *testing.T) {
 }
 
 const queryChangesetEventConnection = `
-query($changeset: ID!, $first: Int){
+query($changeset: ID!, $first: Int, $after: String, $kinds: [ChangesetEventCategory!]){
   node(id: $changeset) {
     ... on ExternalChangeset {
-      events(first: $first) {
+      events(first: $first, after: $after, kinds: $kinds) {
         totalCount
         pageInfo {
           hasNextPage
+          endCursor
         }
         nodes {
          id
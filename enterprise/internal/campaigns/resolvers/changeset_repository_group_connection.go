@@ -0,0 +1,125 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+var _ graphqlbackend.ChangesetRepositoryGroupConnectionResolver = &changesetRepositoryGroupConnectionResolver{}
+
+type changesetRepositoryGroupConnectionResolver struct {
+	store *ee.Store
+	opts  ee.ListChangesetRepoGroupsOpts
+
+	// cache results because they are used by multiple fields
+	once      sync.Once
+	groups    []*campaigns.ChangesetRepoGroup
+	reposByID map[api.RepoID]*types.Repo
+	next      int64
+	err       error
+}
+
+func (r *changesetRepositoryGroupConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.ChangesetRepositoryGroupResolver, error) {
+	groups, reposByID, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetRepositoryGroupResolver, 0, len(groups))
+	for _, g := range groups {
+		// If the repository is not in reposByID it was filtered out by the
+		// authz-filter and the group is returned without it.
+		resolvers = append(resolvers, &changesetRepositoryGroupResolver{
+			group: g,
+			repo:  reposByID[g.RepoID],
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *changesetRepositoryGroupConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountChangesetRepoGroups(ctx, r.opts.CampaignID)
+	return int32(count), err
+}
+
+func (r *changesetRepositoryGroupConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, _, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.FormatInt(next, 10)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *changesetRepositoryGroupConnectionResolver) compute(ctx context.Context) ([]*campaigns.ChangesetRepoGroup, map[api.RepoID]*types.Repo, int64, error) {
+	r.once.Do(func() {
+		r.groups, r.next, r.err = r.store.ListChangesetRepoGroups(ctx, r.opts)
+		if r.err != nil {
+			return
+		}
+
+		repoIDs := make([]api.RepoID, 0, len(r.groups))
+		for _, g := range r.groups {
+			repoIDs = append(repoIDs, g.RepoID)
+		}
+
+		// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+		// filters out repositories that the user doesn't have access to.
+		r.reposByID, r.err = db.Repos.GetReposSetByIDs(ctx, repoIDs...)
+	})
+	return r.groups, r.reposByID, r.next, r.err
+}
+
+var _ graphqlbackend.ChangesetRepositoryGroupResolver = &changesetRepositoryGroupResolver{}
+
+type changesetRepositoryGroupResolver struct {
+	group *campaigns.ChangesetRepoGroup
+	// repo is nil if the viewer doesn't have access to the repository.
+	repo *types.Repo
+}
+
+func (r *changesetRepositoryGroupResolver) Repository() *graphqlbackend.RepositoryResolver {
+	if r.repo == nil {
+		return nil
+	}
+	return graphqlbackend.NewRepositoryResolver(r.repo)
+}
+
+func (r *changesetRepositoryGroupResolver) Total() int32 {
+	return r.group.Total
+}
+
+func (r *changesetRepositoryGroupResolver) Unpublished() int32 {
+	return r.group.Unpublished
+}
+
+func (r *changesetRepositoryGroupResolver) Open() int32 {
+	return r.group.Open
+}
+
+func (r *changesetRepositoryGroupResolver) Merged() int32 {
+	return r.group.Merged
+}
+
+func (r *changesetRepositoryGroupResolver) Closed() int32 {
+	return r.group.Closed
+}
+
+func (r *changesetRepositoryGroupResolver) DiffStat() *graphqlbackend.DiffStat {
+	// 🚨 SECURITY: The diff stat of hidden changesets isn't revealed.
+	if r.repo == nil {
+		return nil
+	}
+	return graphqlbackend.NewDiffStat(r.group.DiffStat())
+}
@@ -0,0 +1,53 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// changesetErrorCodes are the error codes that retry policies are returned
+// for, in the order of the ChangesetErrorCode GraphQL enum.
+var changesetErrorCodes = []campaigns.ChangesetErrorCode{
+	campaigns.ChangesetErrorCodeAuth,
+	campaigns.ChangesetErrorCodeRateLimit,
+	campaigns.ChangesetErrorCodeTransient,
+	campaigns.ChangesetErrorCodeMergeConflict,
+	campaigns.ChangesetErrorCodeRepoArchived,
+	campaigns.ChangesetErrorCodeUnknown,
+}
+
+type changesetRetryPolicyResolver struct {
+	code        campaigns.ChangesetErrorCode
+	backoff     time.Duration
+	retried     bool
+	maxAttempts int
+}
+
+var _ graphqlbackend.ChangesetRetryPolicyResolver = &changesetRetryPolicyResolver{}
+
+func newChangesetRetryPolicyResolver(code campaigns.ChangesetErrorCode) *changesetRetryPolicyResolver {
+	backoff, retried := ee.ChangesetRetryBackoff(code)
+	maxAttempts := ee.ChangesetRetryMaxAttempts(code)
+	return &changesetRetryPolicyResolver{code: code, backoff: backoff, retried: retried, maxAttempts: maxAttempts}
+}
+
+func (r *changesetRetryPolicyResolver) ErrorCode() campaigns.ChangesetErrorCode { return r.code }
+func (r *changesetRetryPolicyResolver) RetriedAutomatically() bool              { return r.retried }
+
+func (r *changesetRetryPolicyResolver) BackoffSeconds() *int32 {
+	if !r.retried {
+		return nil
+	}
+	return durationSeconds(&r.backoff)
+}
+
+func (r *changesetRetryPolicyResolver) MaxAttempts() *int32 {
+	if r.maxAttempts == 0 {
+		return nil
+	}
+	n := int32(r.maxAttempts)
+	return &n
+}
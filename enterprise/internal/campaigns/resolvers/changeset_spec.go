@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
@@ -97,8 +98,42 @@ func (r *changesetSpecResolver) Description(ctx context.Context) (graphqlbackend
 	return descriptionResolver, nil
 }
 
-func (r *changesetSpecResolver) ExpiresAt() *graphqlbackend.DateTime {
-	return &graphqlbackend.DateTime{Time: r.changesetSpec.ExpiresAt()}
+func (r *changesetSpecResolver) Delta(ctx context.Context) (graphqlbackend.ChangesetSpecDeltaResolver, error) {
+	svc := ee.NewService(r.store, r.httpFactory)
+	preview, err := svc.PreviewChangesetSpec(ctx, r.changesetSpec)
+	if err != nil || preview == nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := db.Repos.Get(ctx, preview.Changeset.RepoID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &changesetSpecDeltaResolver{
+		changeset:     NewChangesetResolver(r.store, r.httpFactory, preview.Changeset, repo),
+		changesetSpec: r,
+		preview:       preview,
+	}, nil
+}
+
+func (r *changesetSpecResolver) ExpiresAt(ctx context.Context) (*graphqlbackend.DateTime, error) {
+	if r.changesetSpec.CampaignSpecID != 0 {
+		// Changeset specs attached to a pinned campaign spec don't expire.
+		campaignSpec, err := r.store.GetCampaignSpec(ctx, ee.GetCampaignSpecOpts{ID: r.changesetSpec.CampaignSpecID})
+		if err != nil {
+			return nil, err
+		}
+		if campaignSpec.Pinned() {
+			return nil, nil
+		}
+	}
+	return &graphqlbackend.DateTime{Time: r.changesetSpec.ExpiresAt()}, nil
 }
 
 func (r *changesetSpecResolver) repoAccessible() (bool, error) {
@@ -176,6 +211,34 @@ func (r *changesetDescriptionResolver) Title() string   { return r.desc.Title }
 func (r *changesetDescriptionResolver) Body() string    { return r.desc.Body }
 func (r *changesetDescriptionResolver) Published() bool { return r.desc.Published }
 
+func (r *changesetDescriptionResolver) Reviewers() []string {
+	if r.desc.Reviewers == nil {
+		return []string{}
+	}
+	return r.desc.Reviewers
+}
+
+func (r *changesetDescriptionResolver) Assignees() []string {
+	if r.desc.Assignees == nil {
+		return []string{}
+	}
+	return r.desc.Assignees
+}
+
+func (r *changesetDescriptionResolver) Labels() []string {
+	if r.desc.Labels == nil {
+		return []string{}
+	}
+	return r.desc.Labels
+}
+
+func (r *changesetDescriptionResolver) Milestone() *string {
+	if r.desc.Milestone == "" {
+		return nil
+	}
+	return &r.desc.Milestone
+}
+
 func (r *changesetDescriptionResolver) Diff(ctx context.Context) (graphqlbackend.PreviewRepositoryComparisonResolver, error) {
 	diff, err := r.desc.Diff()
 	if err != nil {
@@ -195,6 +258,49 @@ func (r *changesetDescriptionResolver) Commits() []graphqlbackend.GitCommitDescr
 	return resolvers
 }
 
+var _ graphqlbackend.ChangesetSpecDeltaResolver = &changesetSpecDeltaResolver{}
+
+type changesetSpecDeltaResolver struct {
+	changeset     *changesetResolver
+	changesetSpec *changesetSpecResolver
+	preview       *ee.ChangesetSpecPreview
+}
+
+func (r *changesetSpecDeltaResolver) Changeset() graphqlbackend.ExternalChangesetResolver {
+	return r.changeset
+}
+
+func (r *changesetSpecDeltaResolver) ChangesetSpec() graphqlbackend.VisibleChangesetSpecResolver {
+	return r.changesetSpec
+}
+func (r *changesetSpecDeltaResolver) TitleChanged() bool   { return r.preview.Delta.TitleChanged }
+func (r *changesetSpecDeltaResolver) BodyChanged() bool    { return r.preview.Delta.BodyChanged }
+func (r *changesetSpecDeltaResolver) BaseRefChanged() bool { return r.preview.Delta.BaseRefChanged }
+func (r *changesetSpecDeltaResolver) DiffChanged() bool    { return r.preview.Delta.DiffChanged }
+func (r *changesetSpecDeltaResolver) CommitMessageChanged() bool {
+	return r.preview.Delta.CommitMessageChanged
+}
+func (r *changesetSpecDeltaResolver) ReviewersChanged() bool {
+	return r.preview.Delta.ReviewersChanged
+}
+func (r *changesetSpecDeltaResolver) AssigneesChanged() bool {
+	return r.preview.Delta.AssigneesChanged
+}
+func (r *changesetSpecDeltaResolver) LabelsChanged() bool {
+	return r.preview.Delta.LabelsChanged
+}
+func (r *changesetSpecDeltaResolver) MilestoneChanged() bool {
+	return r.preview.Delta.MilestoneChanged
+}
+
+func (r *changesetSpecDeltaResolver) DiffOfDiffs() (*string, error) {
+	diff, err := r.preview.DiffOfDiffs()
+	if err != nil || diff == "" {
+		return nil, err
+	}
+	return &diff, nil
+}
+
 var _ graphqlbackend.GitCommitDescriptionResolver = &gitCommitDescriptionResolver{}
 
 type gitCommitDescriptionResolver struct {
@@ -70,6 +70,7 @@ func TestChangesetResolver(t *testing.T) {
 		currentSpec:         unpublishedSpec.ID,
 		externalServiceType: "github",
 		publicationState:    campaigns.ChangesetPublicationStateUnpublished,
+		reconcilerState:     campaigns.ReconcilerStateQueued,
 		createdByCampaign:   false,
 	})
 
@@ -97,6 +98,7 @@ func TestChangesetResolver(t *testing.T) {
 			HeadRefOid:  headRev,
 			BaseRefOid:  baseRev,
 			BaseRefName: "master",
+			Mergeable:   "CONFLICTING",
 			TimelineItems: []github.TimelineItem{
 				{Type: "PullRequestCommit", Item: &github.PullRequestCommit{
 					Commit: github.Commit{
@@ -158,6 +160,10 @@ func TestChangesetResolver(t *testing.T) {
 				// Not scheduled for sync, because it's not published.
 				NextSyncAt: "",
 				Labels:     []apitest.Label{},
+				WaitReason: &apitest.ChangesetWaitReason{
+					Kind:          "QUEUE",
+					QueuePosition: 1,
+				},
 				Diff: apitest.Comparison{
 					Typename:  "PreviewRepositoryComparison",
 					FileDiffs: testDiffGraphQL,
@@ -173,6 +179,7 @@ func TestChangesetResolver(t *testing.T) {
 				ExternalState: "OPEN",
 				ExternalID:    "12345",
 				CheckState:    "PENDING",
+				Mergeable:     "CONFLICTING",
 				ReviewState:   "CHANGES_REQUESTED",
 				NextSyncAt:    marshalDateTime(t, now.Add(8*time.Hour)),
 				Repository:    apitest.Repository{Name: repo.Name},
@@ -279,6 +286,8 @@ query($changeset: ID!) {
       externalState
       reviewState
       checkState
+      mergeable
+      waitReason { kind, until, queuePosition }
       externalURL { url, serviceType }
       nextSyncAt
 
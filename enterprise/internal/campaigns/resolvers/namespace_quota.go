@@ -0,0 +1,37 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+)
+
+var _ graphqlbackend.CampaignNamespaceQuotaResolver = &namespaceQuotaResolver{}
+
+type namespaceQuotaResolver struct {
+	quota *ee.NamespaceQuota
+}
+
+func (r *namespaceQuotaResolver) Enabled() bool {
+	return r.quota.Enabled
+}
+
+func (r *namespaceQuotaResolver) MaxChangesetsPerCampaign() *int32 {
+	return limitOrNil(r.quota.MaxChangesets)
+}
+
+func (r *namespaceQuotaResolver) MaxOpenCampaigns() *int32 {
+	return limitOrNil(r.quota.MaxOpenCampaigns)
+}
+
+func (r *namespaceQuotaResolver) OpenCampaigns() int32 {
+	return int32(r.quota.OpenCampaigns)
+}
+
+// limitOrNil returns nil for a limit of 0, which means no limit.
+func limitOrNil(limit int) *int32 {
+	if limit == 0 {
+		return nil
+	}
+	l := int32(limit)
+	return &l
+}
@@ -22,7 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
-	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -40,11 +40,11 @@ func TestPermissionLevels(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// SyncChangeset uses EnqueueChangesetSync and tries to talk to repo-updater, hence we need to mock it.
-	repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+	// SyncChangeset syncs the changeset with the code host, hence we need to mock it.
+	ee.MockSyncChangesets = func(_ context.Context, _ ee.RepoStore, _ ee.SyncStore, _ *httpcli.Factory, _ ...*campaigns.Changeset) error {
 		return nil
 	}
-	t.Cleanup(func() { repoupdater.MockEnqueueChangesetSync = nil })
+	t.Cleanup(func() { ee.MockSyncChangesets = nil })
 
 	ctx := context.Background()
 
@@ -328,6 +328,58 @@ func TestPermissionLevels(t *testing.T) {
 		})
 	})
 
+	t.Run("CampaignsStatistics", func(t *testing.T) {
+		query := `query { campaignsStatistics(weeks: 2) { totalCampaigns, weeks { changesetsPublished } } }`
+
+		for _, tc := range []struct {
+			name        string
+			currentUser int32
+			wantErr     bool
+		}{
+			{name: "site-admin", currentUser: adminID, wantErr: false},
+			{name: "non site-admin", currentUser: userID, wantErr: true},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				actorCtx := actor.WithActor(context.Background(), actor.FromUser(tc.currentUser))
+
+				var response struct{}
+				errs := apitest.Exec(actorCtx, t, s, nil, &response, query)
+				if tc.wantErr && len(errs) == 0 {
+					t.Fatal("no error returned")
+				}
+				if !tc.wantErr && len(errs) != 0 {
+					t.Fatalf("unexpected errors: %+v", errs)
+				}
+			})
+		}
+	})
+
+	t.Run("CampaignSpecExpiration", func(t *testing.T) {
+		query := `query { campaignSpecExpiration { intervalMinutes, dryRun, lastRun { campaignSpecsDeleted } } }`
+
+		for _, tc := range []struct {
+			name        string
+			currentUser int32
+			wantErr     bool
+		}{
+			{name: "site-admin", currentUser: adminID, wantErr: false},
+			{name: "non site-admin", currentUser: userID, wantErr: true},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				actorCtx := actor.WithActor(context.Background(), actor.FromUser(tc.currentUser))
+
+				var response struct{}
+				errs := apitest.Exec(actorCtx, t, s, nil, &response, query)
+				if tc.wantErr && len(errs) == 0 {
+					t.Fatal("no error returned")
+				}
+				if !tc.wantErr && len(errs) != 0 {
+					t.Fatalf("unexpected errors: %+v", errs)
+				}
+			})
+		}
+	})
+
 	t.Run("mutations", func(t *testing.T) {
 		mutations := []struct {
 			name         string
@@ -348,7 +400,7 @@ func TestPermissionLevels(t *testing.T) {
 			{
 				name: "syncChangeset",
 				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
-					return fmt.Sprintf(`mutation { syncChangeset(changeset: %q) { alwaysNil } }`, changesetID)
+					return fmt.Sprintf(`mutation { syncChangeset(changeset: %q) { id } }`, changesetID)
 				},
 			},
 			{
@@ -357,6 +409,54 @@ func TestPermissionLevels(t *testing.T) {
 					return fmt.Sprintf(`mutation { applyCampaign(campaignSpec: %q) { id } }`, campaignSpecID)
 				},
 			},
+			{
+				name: "setCampaignAutoMerge",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { setCampaignAutoMerge(campaign: %q, enabled: true) { id } }`, campaignID)
+				},
+			},
+			{
+				name: "setCampaignAutoRebase",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { setCampaignAutoRebase(campaign: %q, enabled: true) { id } }`, campaignID)
+				},
+			},
+			{
+				name: "setCampaignReapplySchedule",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { setCampaignReapplySchedule(campaign: %q, schedule: "@daily") { id } }`, campaignID)
+				},
+			},
+			{
+				name: "setCampaignVisibility",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { setCampaignVisibility(campaign: %q, visibility: NAMESPACE_ONLY) { id } }`, campaignID)
+				},
+			},
+			{
+				name: "pauseCampaign",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { pauseCampaign(campaign: %q) { id } }`, campaignID)
+				},
+			},
+			{
+				name: "resumeCampaign",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { resumeCampaign(campaign: %q) { id } }`, campaignID)
+				},
+			},
+			{
+				name: "setCampaignUpdatePropagation",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { setCampaignUpdatePropagation(campaign: %q, updatePropagation: PRESERVE) { id } }`, campaignID)
+				},
+			},
+			{
+				name: "setCampaignNotificationSettings",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { setCampaignNotificationSettings(campaign: %q, events: [ALL_PUBLISHED], subscribers: []) { id } }`, campaignID)
+				},
+			},
 			{
 				name: "moveCampaign",
 				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
@@ -439,6 +539,65 @@ func TestPermissionLevels(t *testing.T) {
 				}
 			})
 		}
+
+		t.Run("deleteCampaignTemplate", func(t *testing.T) {
+			tests := []struct {
+				name              string
+				currentUser       int32
+				templateNamespace int32
+				wantAuthErr       bool
+			}{
+				{
+					name:              "unauthorized",
+					currentUser:       userID,
+					templateNamespace: adminID,
+					wantAuthErr:       true,
+				},
+				{
+					name:              "authorized namespace user",
+					currentUser:       userID,
+					templateNamespace: userID,
+					wantAuthErr:       false,
+				},
+				{
+					name:              "authorized site-admin",
+					currentUser:       adminID,
+					templateNamespace: userID,
+					wantAuthErr:       false,
+				},
+			}
+
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					template := &campaigns.CampaignTemplate{
+						Name:            "test-template",
+						Template:        "name: test",
+						NamespaceUserID: tc.templateNamespace,
+						CreatorID:       tc.templateNamespace,
+					}
+					if err := store.CreateCampaignTemplate(ctx, template); err != nil {
+						t.Fatal(err)
+					}
+
+					mutation := fmt.Sprintf(`mutation { deleteCampaignTemplate(campaignTemplate: %q) { alwaysNil } }`, marshalCampaignTemplateID(template.ID))
+					actorCtx := actor.WithActor(ctx, actor.FromUser(tc.currentUser))
+
+					var response struct{}
+					errs := apitest.Exec(actorCtx, t, s, nil, &response, mutation)
+
+					if tc.wantAuthErr {
+						if len(errs) != 1 {
+							t.Fatalf("expected 1 error, but got %d: %s", len(errs), errs)
+						}
+						if !strings.Contains(errs[0].Error(), "must be authenticated") {
+							t.Fatalf("wrong error: %s %T", errs[0], errs[0])
+						}
+					} else if len(errs) != 0 {
+						t.Fatalf("unexpected errors: %s", errs)
+					}
+				})
+			}
+		})
 	})
 }
 
@@ -0,0 +1,35 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+var _ graphqlbackend.CampaignsPublicationBudgetResolver = &publicationBudgetResolver{}
+
+type publicationBudgetResolver struct {
+	budget protocol.CampaignsPublicationBudget
+}
+
+func (r *publicationBudgetResolver) CodeHostURL() string {
+	return r.budget.BaseURL
+}
+
+func (r *publicationBudgetResolver) DisplayName() string {
+	return r.budget.DisplayName
+}
+
+func (r *publicationBudgetResolver) RequestsPerHour() *float64 {
+	if r.budget.Unlimited {
+		return nil
+	}
+	return &r.budget.RequestsPerHour
+}
+
+func (r *publicationBudgetResolver) RemainingRequests() *int32 {
+	if r.budget.Unlimited {
+		return nil
+	}
+	remaining := int32(r.budget.Remaining)
+	return &remaining
+}
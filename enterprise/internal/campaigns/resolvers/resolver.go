@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -17,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
@@ -33,6 +35,12 @@ func NewResolver(db *sql.DB) graphqlbackend.CampaignsResolver {
 	return &Resolver{store: ee.NewStore(db)}
 }
 
+// NewResolverWithStore returns a new Resolver that uses the given store, e.g.
+// one that reads from a read replica.
+func NewResolverWithStore(store *ee.Store) graphqlbackend.CampaignsResolver {
+	return &Resolver{store: store}
+}
+
 func allowReadAccess(ctx context.Context) error {
 	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access changesets.
 	if readAccess := conf.CampaignsReadAccessEnabled(); readAccess {
@@ -94,7 +102,34 @@ func (r *Resolver) CampaignByID(ctx context.Context, id graphql.ID) (graphqlback
 		return nil, nil
 	}
 
-	campaign, err := r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: campaignID})
+	return r.campaignByOpts(ctx, ee.GetCampaignOpts{ID: campaignID})
+}
+
+func (r *Resolver) Campaign(ctx context.Context, args *graphqlbackend.CampaignArgs) (graphqlbackend.CampaignResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	opts := ee.GetCampaignOpts{Name: args.Name}
+	var err error
+	opts.NamespaceUserID, opts.NamespaceOrgID, err = unmarshalNamespaceID(args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a namespace, campaigns of any namespace would match.
+	if opts.NamespaceUserID == 0 && opts.NamespaceOrgID == 0 {
+		return nil, nil
+	}
+
+	return r.campaignByOpts(ctx, opts)
+}
+
+// campaignByOpts returns the resolver of the campaign matching the given
+// options, or nil if there is none or the current user can't see it.
+func (r *Resolver) campaignByOpts(ctx context.Context, opts ee.GetCampaignOpts) (graphqlbackend.CampaignResolver, error) {
+	campaign, err := r.store.GetCampaign(ctx, opts)
 	if err != nil {
 		if err == ee.ErrNoResults {
 			return nil, nil
@@ -102,6 +137,16 @@ func (r *Resolver) CampaignByID(ctx context.Context, id graphql.ID) (graphqlback
 		return nil, err
 	}
 
+	// 🚨 SECURITY: Campaigns that aren't visible to the current user are
+	// treated as if they didn't exist.
+	visible, err := ee.CampaignVisible(ctx, r.store, campaign)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
 	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
 }
 
@@ -164,6 +209,231 @@ func (r *Resolver) ChangesetSpecByID(ctx context.Context, id graphql.ID) (graphq
 	}, nil
 }
 
+func (r *Resolver) CampaignsAdvisoryLocks(ctx context.Context) ([]graphqlbackend.CampaignsAdvisoryLockResolver, error) {
+	// 🚨 SECURITY: Only site admins may see which replicas hold which locks.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	locks, err := r.store.ListAdvisoryLocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CampaignsAdvisoryLockResolver, 0, len(locks))
+	for _, l := range locks {
+		resolvers = append(resolvers, &advisoryLockResolver{lock: l})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CampaignsPublicationBudgets(ctx context.Context) ([]graphqlbackend.CampaignsPublicationBudgetResolver, error) {
+	// 🚨 SECURITY: Only site admins may see the rate limits of code hosts.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	budgets, err := repoupdater.DefaultClient.CampaignsPublicationBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CampaignsPublicationBudgetResolver, 0, len(budgets))
+	for _, b := range budgets {
+		resolvers = append(resolvers, &publicationBudgetResolver{budget: b})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) ChangesetRetryPolicies(ctx context.Context) ([]graphqlbackend.ChangesetRetryPolicyResolver, error) {
+	resolvers := make([]graphqlbackend.ChangesetRetryPolicyResolver, 0, len(changesetErrorCodes))
+	for _, code := range changesetErrorCodes {
+		resolvers = append(resolvers, newChangesetRetryPolicyResolver(code))
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CampaignsStatistics(ctx context.Context, args *graphqlbackend.CampaignsStatisticsArgs) (graphqlbackend.CampaignsStatisticsResolver, error) {
+	// 🚨 SECURITY: Only site admins may see the site-wide usage of campaigns.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if args.Weeks < 1 {
+		return nil, errors.New("weeks must be at least 1")
+	}
+	if args.CodeHosts < 1 {
+		return nil, errors.New("codeHosts must be at least 1")
+	}
+
+	since := r.store.Clock()().AddDate(0, 0, -7*int(args.Weeks-1))
+	stats, err := r.store.GetCampaignsStatistics(ctx, ee.GetCampaignsStatisticsOpts{
+		Since:         since,
+		CodeHostLimit: int(args.CodeHosts),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &campaignsStatisticsResolver{stats: stats}, nil
+}
+
+func (r *Resolver) CampaignSpecExpiration(ctx context.Context) (graphqlbackend.CampaignSpecExpirationResolver, error) {
+	// 🚨 SECURITY: Only site admins may see what the spec expiration deletes.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return &campaignSpecExpirationResolver{store: r.store}, nil
+}
+
+func (r *Resolver) CampaignNamespaceQuota(ctx context.Context, args *graphqlbackend.CampaignNamespaceQuotaArgs) (graphqlbackend.CampaignNamespaceQuotaResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaigns.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	userID, orgID, err := unmarshalNamespaceID(args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: GetNamespaceQuota checks whether the user has access to
+	// the namespace.
+	svc := ee.NewService(r.store, r.httpFactory)
+	quota, err := svc.GetNamespaceQuota(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &namespaceQuotaResolver{quota: quota}, nil
+}
+
+func (r *Resolver) CampaignTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.CampaignTemplateResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign templates.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalCampaignTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, nil
+	}
+
+	template, err := r.store.GetCampaignTemplate(ctx, ee.GetCampaignTemplateOpts{ID: templateID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &campaignTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) CampaignSpecExecutionByID(ctx context.Context, id graphql.ID) (graphqlbackend.CampaignSpecExecutionResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	executionID, err := unmarshalCampaignSpecExecutionID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if executionID == 0 {
+		return nil, nil
+	}
+
+	execution, err := r.store.GetCampaignSpecExecution(ctx, ee.GetCampaignSpecExecutionOpts{ID: executionID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Executions contain the raw spec, so they're only visible
+	// to site admins and the user that started them.
+	if ok, err := checkSiteAdminOrSameUser(ctx, execution.UserID); err != nil || !ok {
+		return nil, err
+	}
+
+	return &campaignSpecExecutionResolver{store: r.store, httpFactory: r.httpFactory, execution: execution}, nil
+}
+
+func (r *Resolver) BulkOperationByID(ctx context.Context, id graphql.ID) (graphqlbackend.BulkOperationResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	bulkGroup, err := unmarshalBulkOperationID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if bulkGroup == "" {
+		return nil, nil
+	}
+
+	operation, err := r.store.GetBulkOperation(ctx, ee.GetBulkOperationOpts{ID: bulkGroup})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	campaign, err := r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: operation.CampaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can run bulk operations, so only
+	// they can see them.
+	if err := ee.CheckCampaignAdminRights(ctx, r.store, campaign); err != nil {
+		return nil, err
+	}
+
+	return &bulkOperationResolver{store: r.store, httpFactory: r.httpFactory, operation: operation}, nil
+}
+
+func (r *Resolver) BulkOperation(ctx context.Context, args *graphqlbackend.BulkOperationArgs) (graphqlbackend.BulkOperationResolver, error) {
+	return r.BulkOperationByID(ctx, args.ID)
+}
+
+func (r *Resolver) CampaignTemplates(ctx context.Context, args *graphqlbackend.ListCampaignTemplatesArgs) (graphqlbackend.CampaignTemplateConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign templates.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	var opts ee.ListCampaignTemplatesOpts
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		id, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = id
+	}
+
+	if args.Namespace != nil {
+		var err error
+		opts.NamespaceUserID, opts.NamespaceOrgID, err = unmarshalNamespaceID(*args.Namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &campaignTemplateConnectionResolver{store: r.store, opts: opts}, nil
+}
+
 func (r *Resolver) CreateCampaign(ctx context.Context, args *graphqlbackend.CreateCampaignArgs) (graphqlbackend.CampaignResolver, error) {
 	var err error
 	tr, _ := trace.New(ctx, "Resolver.CreateCampaign", fmt.Sprintf("CampaignSpec %s", args.CampaignSpec))
@@ -203,7 +473,7 @@ func (r *Resolver) ApplyCampaign(ctx context.Context, args *graphqlbackend.Apply
 		tr.Finish()
 	}()
 
-	opts := ee.ApplyCampaignOpts{}
+	opts := ee.ApplyCampaignOpts{ArchiveSuperseded: args.ArchiveSupersededChangesets}
 
 	opts.CampaignSpecRandID, err = unmarshalCampaignSpecID(args.CampaignSpec)
 	if err != nil {
@@ -285,224 +555,1297 @@ func (r *Resolver) CreateCampaignSpec(ctx context.Context, args *graphqlbackend.
 	return specResolver, nil
 }
 
-func (r *Resolver) CreateChangesetSpec(ctx context.Context, args *graphqlbackend.CreateChangesetSpecArgs) (graphqlbackend.ChangesetSpecResolver, error) {
-	var err error
-	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetSpec", "")
+func (r *Resolver) CreateCampaignTemplate(ctx context.Context, args *graphqlbackend.CreateCampaignTemplateArgs) (_ graphqlbackend.CampaignTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateCampaignTemplate", fmt.Sprintf("Namespace %s, Name %q", args.Namespace, args.Name))
 	defer func() {
 		tr.SetError(err)
 		tr.Finish()
 	}()
 
-	user, err := db.Users.GetByCurrentAuthUser(ctx)
-	if err != nil {
-		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	// 🚨 SECURITY: Only site admins may create campaign templates for now.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
 	}
 
-	// 🚨 SECURITY: Only site admins may create campaign specs for now.
-	if !user.SiteAdmin {
-		return nil, backend.ErrMustBeSiteAdmin
+	template := &campaigns.CampaignTemplate{
+		Name:     args.Name,
+		Template: args.Template,
+	}
+	if args.Description != nil {
+		template.Description = *args.Description
+	}
+	if args.Parameters != nil {
+		for _, p := range *args.Parameters {
+			param := campaigns.CampaignTemplateParameter{Name: p.Name, Default: p.Default}
+			if p.Description != nil {
+				param.Description = *p.Description
+			}
+			template.Parameters = append(template.Parameters, param)
+		}
 	}
 
-	svc := ee.NewService(r.store, r.httpFactory)
-	spec, err := svc.CreateChangesetSpec(ctx, args.ChangesetSpec, user.ID)
+	template.NamespaceUserID, template.NamespaceOrgID, err = unmarshalNamespaceID(args.Namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	resolver := &changesetSpecResolver{
-		store:         r.store,
-		httpFactory:   r.httpFactory,
-		changesetSpec: spec,
-		repoCtx:       ctx,
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: CreateCampaignTemplate checks whether the current user has
+	// access to the namespace.
+	if err := svc.CreateCampaignTemplate(ctx, template); err != nil {
+		return nil, err
 	}
-	return resolver, nil
+
+	return &campaignTemplateResolver{store: r.store, template: template}, nil
 }
 
-func (r *Resolver) MoveCampaign(ctx context.Context, args *graphqlbackend.MoveCampaignArgs) (graphqlbackend.CampaignResolver, error) {
-	var err error
-	tr, ctx := trace.New(ctx, "Resolver.MoveCampaign", fmt.Sprintf("Campaign %s", args.Campaign))
+func (r *Resolver) DeleteCampaignTemplate(ctx context.Context, args *graphqlbackend.DeleteCampaignTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteCampaignTemplate", fmt.Sprintf("CampaignTemplate: %q", args.CampaignTemplate))
 	defer func() {
 		tr.SetError(err)
 		tr.Finish()
 	}()
 
-	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	templateID, err := unmarshalCampaignTemplateID(args.CampaignTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	if campaignID == 0 {
+	if templateID == 0 {
 		return nil, ErrIDIsZero
 	}
 
-	var opts ee.MoveCampaignOpts
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: DeleteCampaignTemplate checks whether current user is authorized.
+	err = svc.DeleteCampaignTemplate(ctx, templateID)
+	return &graphqlbackend.EmptyResponse{}, err
+}
 
-	if args.NewName != nil {
-		opts.NewName = *args.NewName
-	}
+func (r *Resolver) CreateCampaignsCredential(ctx context.Context, args *graphqlbackend.CreateCampaignsCredentialArgs) (_ graphqlbackend.CampaignsCredentialResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateCampaignsCredential", fmt.Sprintf("Kind %s, URL %q", args.ExternalServiceKind, args.ExternalServiceURL))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
 
-	if args.NewNamespace != nil {
-		newNamespace := *args.NewNamespace
-		switch relay.UnmarshalKind(newNamespace) {
-		case "User":
-			err = relay.UnmarshalSpec(newNamespace, &opts.NewNamespaceUserID)
-		case "Org":
-			err = relay.UnmarshalSpec(newNamespace, &opts.NewNamespaceOrgID)
-		default:
-			err = errors.Errorf("Invalid namespace %q", newNamespace)
-		}
+	userID, err := campaignsCredentialUserID(ctx, args.User)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	opts := ee.CreateUserCredentialOpts{
+		UserID:              userID,
+		ExternalServiceKind: args.ExternalServiceKind,
+		ExternalServiceURL:  args.ExternalServiceURL,
+		Token:               args.Credential,
+	}
+	if args.Username != nil {
+		opts.Username = *args.Username
 	}
 
 	svc := ee.NewService(r.store, r.httpFactory)
-	// 🚨 SECURITY: MoveCampaign checks whether the current user is authorized.
-	campaign, err := svc.MoveCampaign(ctx, opts)
+	// 🚨 SECURITY: CreateUserCredential checks whether the current user is
+	// the user or a site admin.
+	cred, err := svc.CreateUserCredential(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+	return &campaignsCredentialResolver{credential: cred}, nil
 }
 
-func (r *Resolver) DeleteCampaign(ctx context.Context, args *graphqlbackend.DeleteCampaignArgs) (_ *graphqlbackend.EmptyResponse, err error) {
-	tr, ctx := trace.New(ctx, "Resolver.DeleteCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
+func (r *Resolver) DeleteCampaignsCredential(ctx context.Context, args *graphqlbackend.DeleteCampaignsCredentialArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteCampaignsCredential", fmt.Sprintf("CampaignsCredential: %q", args.CampaignsCredential))
 	defer func() {
 		tr.SetError(err)
 		tr.Finish()
 	}()
 
-	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	credentialID, err := unmarshalCampaignsCredentialID(args.CampaignsCredential)
 	if err != nil {
 		return nil, err
 	}
 
-	if campaignID == 0 {
+	if credentialID == 0 {
 		return nil, ErrIDIsZero
 	}
 
 	svc := ee.NewService(r.store, r.httpFactory)
-	// 🚨 SECURITY: DeleteCampaign checks whether current user is authorized.
-	err = svc.DeleteCampaign(ctx, campaignID)
+	// 🚨 SECURITY: DeleteUserCredential checks whether current user is authorized.
+	err = svc.DeleteUserCredential(ctx, credentialID)
 	return &graphqlbackend.EmptyResponse{}, err
 }
 
-func (r *Resolver) Campaigns(ctx context.Context, args *graphqlbackend.ListCampaignArgs) (graphqlbackend.CampaignsConnectionResolver, error) {
-	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
-	if err := allowReadAccess(ctx); err != nil {
+func (r *Resolver) CampaignsCredentials(ctx context.Context, args *graphqlbackend.ListCampaignsCredentialsArgs) ([]graphqlbackend.CampaignsCredentialResolver, error) {
+	userID, err := campaignsCredentialUserID(ctx, args.User)
+	if err != nil {
 		return nil, err
 	}
-	opts := ee.ListCampaignsOpts{}
 
-	state, err := parseCampaignState(args.State)
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: ListUserCredentials checks whether the current user is
+	// the user or a site admin.
+	creds, err := svc.ListUserCredentials(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	opts.State = state
-	if args.First != nil {
-		opts.Limit = int(*args.First)
-	}
 
-	authErr := backend.CheckCurrentUserIsSiteAdmin(ctx)
-	if authErr != nil && authErr != backend.ErrMustBeSiteAdmin {
-		return nil, authErr
+	resolvers := make([]graphqlbackend.CampaignsCredentialResolver, 0, len(creds))
+	for _, c := range creds {
+		resolvers = append(resolvers, &campaignsCredentialResolver{credential: c})
+	}
+	return resolvers, nil
+}
+
+// campaignsCredentialUserID returns the ID of the user whose credentials are
+// managed: the given user or, if nil, the current user.
+func campaignsCredentialUserID(ctx context.Context, user *graphql.ID) (int32, error) {
+	if user == nil {
+		a := actor.FromContext(ctx)
+		if !a.IsAuthenticated() {
+			return 0, backend.ErrNotAuthenticated
+		}
+		return a.UID, nil
+	}
+
+	userID, err := graphqlbackend.UnmarshalUserID(*user)
+	if err != nil {
+		return 0, err
+	}
+	if userID == 0 {
+		return 0, ErrIDIsZero
+	}
+	return userID, nil
+}
+
+func (r *Resolver) ValidateCampaignSpec(ctx context.Context, args *graphqlbackend.ValidateCampaignSpecArgs) (_ graphqlbackend.CampaignSpecValidationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ValidateCampaignSpec", fmt.Sprintf("Namespace %v", args.Namespace))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	opts := ee.ValidateCampaignSpecOpts{RawSpec: args.Spec}
+	if args.Namespace != nil {
+		opts.NamespaceUserID, opts.NamespaceOrgID, err = unmarshalNamespaceID(*args.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if opts.NamespaceUserID == 0 && opts.NamespaceOrgID == 0 {
+			return nil, ErrIDIsZero
+		}
+	}
+
+	// 🚨 SECURITY: Only signed-in users may validate campaign specs, since
+	// validation checks whether namespaces and repositories exist.
+	if _, err := db.Users.GetByCurrentAuthUser(ctx); err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	problems, err := svc.ValidateCampaignSpec(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecValidationResolver{problems: problems}, nil
+}
+
+func (r *Resolver) LintCampaignSpec(ctx context.Context, args *graphqlbackend.LintCampaignSpecArgs) (_ graphqlbackend.CampaignSpecLintResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.LintCampaignSpec", fmt.Sprintf("Namespace %v", args.Namespace))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	opts := ee.LintCampaignSpecOpts{RawSpec: args.Spec}
+	if args.Namespace != nil {
+		opts.NamespaceUserID, opts.NamespaceOrgID, err = unmarshalNamespaceID(*args.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if opts.NamespaceUserID == 0 && opts.NamespaceOrgID == 0 {
+			return nil, ErrIDIsZero
+		}
+	}
+
+	if args.ChangesetSpecs != nil {
+		for _, graphqlID := range *args.ChangesetSpecs {
+			randID, err := unmarshalChangesetSpecID(graphqlID)
+			if err != nil {
+				return nil, err
+			}
+			opts.ChangesetSpecRandIDs = append(opts.ChangesetSpecRandIDs, randID)
+		}
+	}
+
+	// 🚨 SECURITY: Only signed-in users may lint campaign specs, since
+	// linting checks whether namespaces and repositories exist.
+	if _, err := db.Users.GetByCurrentAuthUser(ctx); err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	problems, err := svc.LintCampaignSpec(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecLintResolver{problems: problems}, nil
+}
+
+func (r *Resolver) PreviewCampaignSpecRepositories(ctx context.Context, args *graphqlbackend.PreviewCampaignSpecRepositoriesArgs) (_ graphqlbackend.CampaignSpecRepositoryPreviewResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.PreviewCampaignSpecRepositories", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only signed-in users may preview the repositories of
+	// campaign specs. The repositories are resolved with the permissions of
+	// the current user.
+	if _, err := db.Users.GetByCurrentAuthUser(ctx); err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	repos, err := svc.ResolveCampaignSpecRepositories(ctx, args.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecRepositoryPreviewResolver{repos: repos}, nil
+}
+
+func (r *Resolver) ExecuteCampaignSpec(ctx context.Context, args *graphqlbackend.ExecuteCampaignSpecArgs) (_ graphqlbackend.CampaignSpecExecutionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ExecuteCampaignSpec", fmt.Sprintf("Namespace %s", args.Namespace))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	opts := ee.ExecuteCampaignSpecOpts{RawSpec: args.CampaignSpec}
+	opts.NamespaceUserID, opts.NamespaceOrgID, err = unmarshalNamespaceID(args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if opts.NamespaceUserID == 0 && opts.NamespaceOrgID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: Only site admins may create campaign specs for now.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: ExecuteCampaignSpec checks whether the current user has
+	// access to the namespace.
+	execution, err := svc.ExecuteCampaignSpec(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecExecutionResolver{store: r.store, httpFactory: r.httpFactory, execution: execution}, nil
+}
+
+func (r *Resolver) CreateCampaignSpecFromTemplate(ctx context.Context, args *graphqlbackend.CreateCampaignSpecFromTemplateArgs) (_ graphqlbackend.CampaignSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateCampaignSpecFromTemplate", fmt.Sprintf("CampaignTemplate %s, Namespace %s", args.CampaignTemplate, args.Namespace))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may create campaign specs for now.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var opts ee.CreateCampaignSpecFromTemplateOpts
+
+	opts.CampaignTemplateID, err = unmarshalCampaignTemplateID(args.CampaignTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.CampaignTemplateID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	opts.NamespaceUserID, opts.NamespaceOrgID, err = unmarshalNamespaceID(args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Parameters != nil {
+		opts.Parameters = make(map[string]string, len(*args.Parameters))
+		for _, p := range *args.Parameters {
+			opts.Parameters[p.Name] = p.Value
+		}
+	}
+
+	if args.ChangesetSpecs != nil {
+		for _, graphqlID := range *args.ChangesetSpecs {
+			randID, err := unmarshalChangesetSpecID(graphqlID)
+			if err != nil {
+				return nil, err
+			}
+			opts.ChangesetSpecRandIDs = append(opts.ChangesetSpecRandIDs, randID)
+		}
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	campaignSpec, err := svc.CreateCampaignSpecFromTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecResolver{store: r.store, httpFactory: r.httpFactory, campaignSpec: campaignSpec}, nil
+}
+
+func (r *Resolver) CreateChangesetSpec(ctx context.Context, args *graphqlbackend.CreateChangesetSpecArgs) (graphqlbackend.ChangesetSpecResolver, error) {
+	var err error
+	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetSpec", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	user, err := db.Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+
+	// 🚨 SECURITY: Only site admins may create campaign specs for now.
+	if !user.SiteAdmin {
+		return nil, backend.ErrMustBeSiteAdmin
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	spec, err := svc.CreateChangesetSpec(ctx, args.ChangesetSpec, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &changesetSpecResolver{
+		store:         r.store,
+		httpFactory:   r.httpFactory,
+		changesetSpec: spec,
+		repoCtx:       ctx,
+	}
+	return resolver, nil
+}
+
+func (r *Resolver) MoveCampaign(ctx context.Context, args *graphqlbackend.MoveCampaignArgs) (graphqlbackend.CampaignResolver, error) {
+	var err error
+	tr, ctx := trace.New(ctx, "Resolver.MoveCampaign", fmt.Sprintf("Campaign %s", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	var opts ee.MoveCampaignOpts
+
+	if args.NewName != nil {
+		opts.NewName = *args.NewName
+	}
+
+	if args.NewNamespace != nil {
+		newNamespace := *args.NewNamespace
+		switch relay.UnmarshalKind(newNamespace) {
+		case "User":
+			err = relay.UnmarshalSpec(newNamespace, &opts.NewNamespaceUserID)
+		case "Org":
+			err = relay.UnmarshalSpec(newNamespace, &opts.NewNamespaceOrgID)
+		default:
+			err = errors.Errorf("Invalid namespace %q", newNamespace)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: MoveCampaign checks whether the current user is authorized.
+	campaign, err := svc.MoveCampaign(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) DeleteCampaign(ctx context.Context, args *graphqlbackend.DeleteCampaignArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: DeleteCampaign checks whether current user is authorized.
+	err = svc.DeleteCampaign(ctx, campaignID)
+	return &graphqlbackend.EmptyResponse{}, err
+}
+
+func (r *Resolver) RecordCampaignView(ctx context.Context, args *graphqlbackend.RecordCampaignViewArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RecordCampaignView", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: RecordCampaignView checks whether the campaign is visible
+	// to the current user.
+	if err := svc.RecordCampaignView(ctx, campaignID); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) RestoreCampaign(ctx context.Context, args *graphqlbackend.RestoreCampaignArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RestoreCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: RestoreCampaign checks whether current user is authorized.
+	campaign, err := svc.RestoreCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) GrantCampaignPermission(ctx context.Context, args *graphqlbackend.GrantCampaignPermissionArgs) (_ graphqlbackend.CampaignPermissionGrantResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.GrantCampaignPermission", fmt.Sprintf("Campaign: %q, Grantee: %q, Level: %s", args.Campaign, args.Grantee, args.Level))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	opts := ee.GrantCampaignPermissionOpts{
+		CampaignID: campaignID,
+		Level:      campaigns.CampaignPermissionLevel(args.Level),
+	}
+	opts.UserID, opts.OrgID, err = unmarshalNamespaceID(args.Grantee)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: GrantCampaignPermission checks whether current user is authorized.
+	grant, err := svc.GrantCampaignPermission(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignPermissionGrantResolver{grant: grant}, nil
+}
+
+func (r *Resolver) RevokeCampaignPermission(ctx context.Context, args *graphqlbackend.RevokeCampaignPermissionArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RevokeCampaignPermission", fmt.Sprintf("Grant: %q", args.Grant))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	grantID, err := unmarshalCampaignPermissionGrantID(args.Grant)
+	if err != nil {
+		return nil, err
+	}
+
+	if grantID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: RevokeCampaignPermission checks whether current user is authorized.
+	err = svc.RevokeCampaignPermission(ctx, grantID)
+	return &graphqlbackend.EmptyResponse{}, err
+}
+
+func (r *Resolver) Campaigns(ctx context.Context, args *graphqlbackend.ListCampaignArgs) (graphqlbackend.CampaignsConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+	opts := ee.ListCampaignsOpts{OnlyDeleted: args.Deleted}
+
+	state, err := parseCampaignState(args.State)
+	if err != nil {
+		return nil, err
+	}
+	opts.State = state
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		opts.Cursor, err = strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing after cursor")
+		}
+	}
+
+	namespaceType, err := parseCampaignNamespaceType(args.NamespaceType)
+	if err != nil {
+		return nil, err
+	}
+	opts.NamespaceType = namespaceType
+
+	if args.Query != nil {
+		opts.Query = *args.Query
+	}
+
+	if args.CreatedAfter != nil {
+		opts.CreatedAfter = args.CreatedAfter.Time
+	}
+	if args.CreatedBefore != nil {
+		opts.CreatedBefore = args.CreatedBefore.Time
+	}
+	if args.ClosedAfter != nil {
+		opts.ClosedAfter = args.ClosedAfter.Time
+	}
+	if args.ClosedBefore != nil {
+		opts.ClosedBefore = args.ClosedBefore.Time
+	}
+
+	if args.ViewerIsNamespaceMember != nil && *args.ViewerIsNamespaceMember {
+		actor := actor.FromContext(ctx)
+		if !actor.IsAuthenticated() {
+			return nil, backend.ErrNotAuthenticated
+		}
+		opts.NamespaceMemberID = actor.UID
+	}
+
+	authErr := backend.CheckCurrentUserIsSiteAdmin(ctx)
+	if authErr != nil && authErr != backend.ErrMustBeSiteAdmin {
+		return nil, authErr
 	}
 	isSiteAdmin := authErr != backend.ErrMustBeSiteAdmin
 	if !isSiteAdmin {
+		actor := actor.FromContext(ctx)
 		if args.ViewerCanAdminister != nil && *args.ViewerCanAdminister {
-			actor := actor.FromContext(ctx)
-			opts.InitialApplierID = actor.UID
+			opts.AdministeredBy = ee.NewCampaignAdmin(actor.UID)
+		}
+		// 🚨 SECURITY: Non-site-admins only see the campaigns whose
+		// visibility allows them to.
+		opts.VisibleTo = &ee.CampaignViewer{UserID: actor.UID}
+	}
+
+	if args.Namespace != nil {
+		switch relay.UnmarshalKind(*args.Namespace) {
+		case "User":
+			err = relay.UnmarshalSpec(*args.Namespace, &opts.NamespaceUserID)
+		case "Org":
+			err = relay.UnmarshalSpec(*args.Namespace, &opts.NamespaceOrgID)
+		default:
+			err = errors.Errorf("Invalid namespace %q", *args.Namespace)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &campaignsConnectionResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		opts:        opts,
+	}, nil
+}
+
+// listChangesetOptsFromArgs turns the graphqlbackend.ListChangesetsArgs into
+// ListChangesetsOpts.
+// If the args do not include a filter that would reveal sensitive information
+// about a changeset the user doesn't have access to, the second return value
+// is false.
+func listChangesetOptsFromArgs(args *graphqlbackend.ListChangesetsArgs) (opts ee.ListChangesetsOpts, optsSafe bool, err error) {
+	if args == nil {
+		return opts, true, nil
+	}
+
+	safe := true
+
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+
+	if args.PublicationState != nil {
+		publicationState := *args.PublicationState
+		if !publicationState.Valid() {
+			return opts, false, errors.New("changeset publication state not valid")
+		}
+		opts.PublicationState = &publicationState
+	}
+
+	if args.ReconcilerState != nil {
+		reconcilerState := *args.ReconcilerState
+		if !reconcilerState.Valid() {
+			return opts, false, errors.New("changeset reconciler state not valid")
+		}
+		opts.ReconcilerState = &reconcilerState
+	}
+	if args.OnlyFailed != nil {
+		// Hidden changesets expose their reconciler state, so filtering by
+		// whether they failed doesn't leak information.
+		opts.OnlyFailed = *args.OnlyFailed
+	}
+
+	if args.ExternalState != nil {
+		externalState := *args.ExternalState
+		if !externalState.Valid() {
+			return opts, false, errors.New("changeset external state not valid")
+		}
+		opts.ExternalState = &externalState
+	}
+	if args.ReviewState != nil {
+		state := *args.ReviewState
+		if !state.Valid() {
+			return opts, false, errors.New("changeset review state not valid")
+		}
+		opts.ExternalReviewState = &state
+		// If the user filters by ReviewState we cannot include hidden
+		// changesets, since that would leak information.
+		safe = false
+	}
+	if args.CheckState != nil {
+		state := *args.CheckState
+		if !state.Valid() {
+			return opts, false, errors.New("changeset check state not valid")
+		}
+		opts.ExternalCheckState = &state
+		// If the user filters by CheckState we cannot include hidden
+		// changesets, since that would leak information.
+		safe = false
+	}
+	if args.CustomMetadata != nil && len(*args.CustomMetadata) > 0 {
+		opts.CustomMetadata = make(map[string]string, len(*args.CustomMetadata))
+		for _, entry := range *args.CustomMetadata {
+			if entry.Key == "" {
+				return opts, false, errors.New("changeset custom metadata key cannot be blank")
+			}
+			opts.CustomMetadata[entry.Key] = entry.Value
+		}
+		// If the user filters by CustomMetadata we cannot include hidden
+		// changesets, since that would leak information.
+		safe = false
+	}
+	if args.UpdatedAfter != nil {
+		// Hidden changesets expose when they were updated, so filtering by
+		// it doesn't leak information.
+		opts.UpdatedAfter = &args.UpdatedAfter.Time
+	}
+	if args.Archived != nil {
+		// Whether a changeset is archived doesn't leak information about
+		// it.
+		opts.Archived = args.Archived
+	}
+
+	return opts, safe, nil
+}
+
+func (r *Resolver) CloseCampaign(ctx context.Context, args *graphqlbackend.CloseCampaignArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CloseCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: CloseCampaign checks whether current user is authorized.
+	campaign, err := svc.CloseCampaign(ctx, campaignID, args.CloseChangesets, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "closing campaign")
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) SetCampaignSpecPinned(ctx context.Context, args *graphqlbackend.SetCampaignSpecPinnedArgs) (_ graphqlbackend.CampaignSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetCampaignSpecPinned", fmt.Sprintf("CampaignSpec: %q, Pinned: %t", args.CampaignSpec, args.Pinned))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignSpecRandID, err := unmarshalCampaignSpecID(args.CampaignSpec)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign spec id")
+	}
+
+	if campaignSpecRandID == "" {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: SetCampaignSpecPinned checks whether current user is authorized.
+	campaignSpec, err := svc.SetCampaignSpecPinned(ctx, campaignSpecRandID, args.Pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecResolver{store: r.store, httpFactory: r.httpFactory, campaignSpec: campaignSpec}, nil
+}
+
+func (r *Resolver) SetCampaignAutoMerge(ctx context.Context, args *graphqlbackend.SetCampaignAutoMergeArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetCampaignAutoMerge", fmt.Sprintf("Campaign: %q, Enabled: %t", args.Campaign, args.Enabled))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: SetCampaignAutoMerge checks whether current user is authorized.
+	campaign, err := svc.SetCampaignAutoMerge(ctx, campaignID, args.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) SetCampaignAutoRebase(ctx context.Context, args *graphqlbackend.SetCampaignAutoRebaseArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetCampaignAutoRebase", fmt.Sprintf("Campaign: %q, Enabled: %t", args.Campaign, args.Enabled))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: SetCampaignAutoRebase checks whether current user is authorized.
+	campaign, err := svc.SetCampaignAutoRebase(ctx, campaignID, args.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) PauseCampaign(ctx context.Context, args *graphqlbackend.PauseCampaignArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.PauseCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: PauseCampaign checks whether current user is authorized.
+	campaign, err := svc.PauseCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) ResumeCampaign(ctx context.Context, args *graphqlbackend.ResumeCampaignArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ResumeCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: ResumeCampaign checks whether current user is authorized.
+	campaign, err := svc.ResumeCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) SetCampaignReapplySchedule(ctx context.Context, args *graphqlbackend.SetCampaignReapplyScheduleArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetCampaignReapplySchedule", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: SetCampaignReapplySchedule checks whether current user is authorized.
+	if _, err := svc.SetCampaignReapplySchedule(ctx, campaignID, args.Schedule); err != nil {
+		return nil, err
+	}
+
+	campaign, err := r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) SetCampaignVisibility(ctx context.Context, args *graphqlbackend.SetCampaignVisibilityArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetCampaignVisibility", fmt.Sprintf("Campaign: %q, Visibility: %s", args.Campaign, args.Visibility))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: SetCampaignVisibility checks whether current user is authorized.
+	campaign, err := svc.SetCampaignVisibility(ctx, campaignID, campaigns.CampaignVisibility(args.Visibility))
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) SetCampaignUpdatePropagation(ctx context.Context, args *graphqlbackend.SetCampaignUpdatePropagationArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetCampaignUpdatePropagation", fmt.Sprintf("Campaign: %q, UpdatePropagation: %s", args.Campaign, args.UpdatePropagation))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: SetCampaignUpdatePropagation checks whether current user is authorized.
+	campaign, err := svc.SetCampaignUpdatePropagation(ctx, campaignID, campaigns.CampaignUpdatePropagation(args.UpdatePropagation))
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) SetCampaignNotificationSettings(ctx context.Context, args *graphqlbackend.SetCampaignNotificationSettingsArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetCampaignNotificationSettings", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	events := make([]campaigns.CampaignNotificationEvent, 0, len(args.Events))
+	for _, e := range args.Events {
+		events = append(events, campaigns.CampaignNotificationEvent(e))
+	}
+
+	subscriberIDs := make([]int32, 0, len(args.Subscribers))
+	for _, id := range args.Subscribers {
+		userID, err := graphqlbackend.UnmarshalUserID(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshaling subscriber id")
 		}
+		subscriberIDs = append(subscriberIDs, userID)
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: SetCampaignNotificationSettings checks whether current user is authorized.
+	if _, err := svc.SetCampaignNotificationSettings(ctx, campaignID, events, subscriberIDs); err != nil {
+		return nil, err
+	}
+
+	campaign, err := r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) CreateCampaignComment(ctx context.Context, args *graphqlbackend.CreateCampaignCommentArgs) (_ graphqlbackend.CampaignCommentResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateCampaignComment", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may
+	// comment on campaigns.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
 	}
 
-	if args.Namespace != nil {
-		switch relay.UnmarshalKind(*args.Namespace) {
-		case "User":
-			err = relay.UnmarshalSpec(*args.Namespace, &opts.NamespaceUserID)
-		case "Org":
-			err = relay.UnmarshalSpec(*args.Namespace, &opts.NamespaceOrgID)
-		default:
-			err = errors.Errorf("Invalid namespace %q", *args.Namespace)
-		}
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: CreateCampaignComment checks whether the campaign is
+	// visible to the current user.
+	comment, err := svc.CreateCampaignComment(ctx, campaignID, args.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignCommentResolver{comment: comment}, nil
+}
+
+func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncChangesetArgs) (_ graphqlbackend.ChangesetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SyncChangeset", fmt.Sprintf("Changeset: %q", args.Changeset))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changesetID, err := unmarshalChangesetID(args.Changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if changesetID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: SyncChangeset checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	changeset, err := svc.SyncChangeset(ctx, changesetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := db.Repos.Get(ctx, changeset.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChangesetResolver(r.store, r.httpFactory, changeset, repo), nil
+}
+
+func (r *Resolver) RebaseChangeset(ctx context.Context, args *graphqlbackend.RebaseChangesetArgs) (_ graphqlbackend.ChangesetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RebaseChangeset", fmt.Sprintf("Changeset: %q", args.Changeset))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changesetID, err := unmarshalChangesetID(args.Changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if changesetID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: RebaseChangeset checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	changeset, err := svc.RebaseChangeset(ctx, changesetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := db.Repos.Get(ctx, changeset.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChangesetResolver(r.store, r.httpFactory, changeset, repo), nil
+}
+
+func (r *Resolver) SetChangesetCustomMetadata(ctx context.Context, args *graphqlbackend.SetChangesetCustomMetadataArgs) (_ graphqlbackend.ChangesetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetChangesetCustomMetadata", fmt.Sprintf("Changeset: %q, Key: %q", args.Changeset, args.Key))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changesetID, err := unmarshalChangesetID(args.Changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if changesetID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: SetChangesetCustomMetadata checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	changeset, err := svc.SetChangesetCustomMetadata(ctx, changesetID, args.Key, args.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := db.Repos.Get(ctx, changeset.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChangesetResolver(r.store, r.httpFactory, changeset, repo), nil
+}
+
+func (r *Resolver) CreateChangesetBulkOperation(ctx context.Context, args *graphqlbackend.CreateChangesetBulkOperationArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetBulkOperation", fmt.Sprintf("Campaign: %q, Type: %q, Changesets: %d", args.Campaign, args.Type, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may
+	// run bulk operations.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	opts := ee.CreateBulkOperationOpts{
+		CampaignID: campaignID,
+		Type:       campaigns.ChangesetJobType(args.Type),
+	}
+	for _, id := range args.Changesets {
+		changesetID, err := unmarshalChangesetID(id)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "unmarshaling changeset id")
 		}
+		if changesetID == 0 {
+			return nil, ErrIDIsZero
+		}
+		opts.ChangesetIDs = append(opts.ChangesetIDs, changesetID)
+	}
+	if args.Message != nil {
+		opts.Payload.Message = *args.Message
 	}
 
-	return &campaignsConnectionResolver{
-		store:       r.store,
-		httpFactory: r.httpFactory,
-		opts:        opts,
-	}, nil
+	// 🚨 SECURITY: CreateBulkOperation checks whether the current user has
+	// admin rights for the campaign and access to the changesets.
+	svc := ee.NewService(r.store, r.httpFactory)
+	operation, err := svc.CreateBulkOperation(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bulkOperationResolver{store: r.store, httpFactory: r.httpFactory, operation: operation}, nil
 }
 
-// listChangesetOptsFromArgs turns the graphqlbackend.ListChangesetsArgs into
-// ListChangesetsOpts.
-// If the args do not include a filter that would reveal sensitive information
-// about a changeset the user doesn't have access to, the second return value
-// is false.
-func listChangesetOptsFromArgs(args *graphqlbackend.ListChangesetsArgs) (opts ee.ListChangesetsOpts, optsSafe bool, err error) {
-	if args == nil {
-		return opts, true, nil
+func (r *Resolver) ImportChangesets(ctx context.Context, args *graphqlbackend.ImportChangesetsArgs) (_ []graphqlbackend.ChangesetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ImportChangesets", fmt.Sprintf("Campaign: %q, URLs: %d", args.Campaign, len(args.ExternalURLs)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
 	}
 
-	safe := true
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
 
-	if args.First != nil {
-		opts.Limit = int(*args.First)
+	// 🚨 SECURITY: ImportChangesets checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	changesets, err := svc.ImportChangesets(ctx, campaignID, args.ExternalURLs)
+	if err != nil {
+		return nil, err
 	}
 
-	if args.PublicationState != nil {
-		publicationState := *args.PublicationState
-		if !publicationState.Valid() {
-			return opts, false, errors.New("changeset publication state not valid")
-		}
-		opts.PublicationState = &publicationState
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := db.Repos.GetReposSetByIDs(ctx, changesets.RepoIDs()...)
+	if err != nil {
+		return nil, err
 	}
 
-	if args.ReconcilerState != nil {
-		reconcilerState := *args.ReconcilerState
-		if !reconcilerState.Valid() {
-			return opts, false, errors.New("changeset reconciler state not valid")
+	resolvers := make([]graphqlbackend.ChangesetResolver, 0, len(changesets))
+	for _, c := range changesets {
+		repo, ok := reposByID[c.RepoID]
+		if !ok {
+			continue
 		}
-		opts.ReconcilerState = &reconcilerState
+		resolvers = append(resolvers, NewChangesetResolver(r.store, r.httpFactory, c, repo))
 	}
 
-	if args.ExternalState != nil {
-		externalState := *args.ExternalState
-		if !externalState.Valid() {
-			return opts, false, errors.New("changeset external state not valid")
-		}
-		opts.ExternalState = &externalState
+	return resolvers, nil
+}
+
+func (r *Resolver) DetachChangesets(ctx context.Context, args *graphqlbackend.DetachChangesetsArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DetachChangesets", fmt.Sprintf("Campaign: %q, Changesets: %d", args.Campaign, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
 	}
-	if args.ReviewState != nil {
-		state := *args.ReviewState
-		if !state.Valid() {
-			return opts, false, errors.New("changeset review state not valid")
-		}
-		opts.ExternalReviewState = &state
-		// If the user filters by ReviewState we cannot include hidden
-		// changesets, since that would leak information.
-		safe = false
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
 	}
-	if args.CheckState != nil {
-		state := *args.CheckState
-		if !state.Valid() {
-			return opts, false, errors.New("changeset check state not valid")
+
+	changesetIDs := make([]int64, 0, len(args.Changesets))
+	for _, id := range args.Changesets {
+		changesetID, err := unmarshalChangesetID(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshaling changeset id")
 		}
-		opts.ExternalCheckState = &state
-		// If the user filters by CheckState we cannot include hidden
-		// changesets, since that would leak information.
-		safe = false
+		if changesetID == 0 {
+			return nil, ErrIDIsZero
+		}
+		changesetIDs = append(changesetIDs, changesetID)
 	}
 
-	return opts, safe, nil
+	// 🚨 SECURITY: DetachChangesets checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	campaign, err := svc.DetachChangesets(ctx, campaignID, changesetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
 }
 
-func (r *Resolver) CloseCampaign(ctx context.Context, args *graphqlbackend.CloseCampaignArgs) (_ graphqlbackend.CampaignResolver, err error) {
-	tr, ctx := trace.New(ctx, "Resolver.CloseCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
+func (r *Resolver) CheckChangesetURLs(ctx context.Context, args *graphqlbackend.CheckChangesetURLsArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CheckChangesetURLs", fmt.Sprintf("Campaign: %q", args.Campaign))
 	defer func() {
 		tr.SetError(err)
 		tr.Finish()
@@ -517,35 +1860,44 @@ func (r *Resolver) CloseCampaign(ctx context.Context, args *graphqlbackend.Close
 		return nil, ErrIDIsZero
 	}
 
+	// 🚨 SECURITY: CheckChangesetURLs checks whether current user is authorized.
 	svc := ee.NewService(r.store, r.httpFactory)
-	// 🚨 SECURITY: CloseCampaign checks whether current user is authorized.
-	campaign, err := svc.CloseCampaign(ctx, campaignID, args.CloseChangesets, true)
+	campaign, err := svc.CheckChangesetURLs(ctx, campaignID)
 	if err != nil {
-		return nil, errors.Wrap(err, "closing campaign")
+		return nil, err
 	}
 
 	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
 }
 
-func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncChangesetArgs) (_ *graphqlbackend.EmptyResponse, err error) {
-	tr, ctx := trace.New(ctx, "Resolver.SyncChangeset", fmt.Sprintf("Changeset: %q", args.Changeset))
+func (r *Resolver) TriggerCampaign(ctx context.Context, args *graphqlbackend.TriggerCampaignArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.TriggerCampaign", fmt.Sprintf("Campaign: %q, Action: %s", args.Campaign, args.Action))
 	defer func() {
 		tr.SetError(err)
 		tr.Finish()
 	}()
 
-	changesetID, err := unmarshalChangesetID(args.Changeset)
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
 	}
 
-	if changesetID == 0 {
+	if campaignID == 0 {
 		return nil, ErrIDIsZero
 	}
 
-	// 🚨 SECURITY: EnqueueChangesetSync checks whether current user is authorized.
+	opts := ee.TriggerCampaignOpts{
+		CampaignID: campaignID,
+		Action:     campaigns.CampaignTriggerAction(args.Action),
+		Source:     args.Source,
+	}
+	if args.Message != nil {
+		opts.Message = *args.Message
+	}
+
+	// 🚨 SECURITY: TriggerCampaign checks whether current user is authorized.
 	svc := ee.NewService(r.store, r.httpFactory)
-	if err = svc.EnqueueChangesetSync(ctx, changesetID); err != nil {
+	if err := svc.TriggerCampaign(ctx, opts); err != nil {
 		return nil, err
 	}
 
@@ -566,6 +1918,43 @@ func parseCampaignState(s *string) (campaigns.CampaignState, error) {
 	}
 }
 
+func parseCampaignNamespaceType(s *string) (campaigns.CampaignNamespaceType, error) {
+	if s == nil {
+		return campaigns.CampaignNamespaceTypeAny, nil
+	}
+	switch *s {
+	case "USER":
+		return campaigns.CampaignNamespaceTypeUser, nil
+	case "ORG":
+		return campaigns.CampaignNamespaceTypeOrg, nil
+	default:
+		return campaigns.CampaignNamespaceTypeAny, fmt.Errorf("unknown namespace type %q", *s)
+	}
+}
+
+// unmarshalNamespaceID returns the user or org ID of the given namespace.
+// campaignIDTag returns a trace.Tag that carries the given campaign ID.
+func campaignIDTag(id int64) trace.Tag {
+	return trace.Tag{Key: "campaign_id", Value: strconv.FormatInt(id, 10)}
+}
+
+// changesetIDTag returns a trace.Tag that carries the given changeset ID.
+func changesetIDTag(id int64) trace.Tag {
+	return trace.Tag{Key: "changeset_id", Value: strconv.FormatInt(id, 10)}
+}
+
+func unmarshalNamespaceID(id graphql.ID) (userID, orgID int32, err error) {
+	switch relay.UnmarshalKind(id) {
+	case "User":
+		err = relay.UnmarshalSpec(id, &userID)
+	case "Org":
+		err = relay.UnmarshalSpec(id, &orgID)
+	default:
+		err = errors.Errorf("Invalid namespace %q", id)
+	}
+	return userID, orgID, err
+}
+
 func checkSiteAdminOrSameUser(ctx context.Context, userID int32) (bool, error) {
 	// 🚨 SECURITY: Only site admins or the authors of a campaign have campaign
 	// admin rights.
@@ -38,6 +38,7 @@ func TestNullIDResilience(t *testing.T) {
 		marshalChangesetID(0),
 		marshalCampaignSpecRandID(""),
 		marshalChangesetSpecRandID(""),
+		marshalCampaignTemplateID(0),
 	}
 
 	for _, id := range ids {
@@ -54,10 +55,24 @@ func TestNullIDResilience(t *testing.T) {
 	mutations := []string{
 		fmt.Sprintf(`mutation { closeCampaign(campaign: %q) { id } }`, campaigns.MarshalCampaignID(0)),
 		fmt.Sprintf(`mutation { deleteCampaign(campaign: %q) { alwaysNil } }`, campaigns.MarshalCampaignID(0)),
-		fmt.Sprintf(`mutation { syncChangeset(changeset: %q) { alwaysNil } }`, marshalChangesetID(0)),
+		fmt.Sprintf(`mutation { recordCampaignView(campaign: %q) { alwaysNil } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { syncChangeset(changeset: %q) { id } }`, marshalChangesetID(0)),
 		fmt.Sprintf(`mutation { applyCampaign(campaignSpec: %q) { id } }`, marshalCampaignSpecRandID("")),
 		fmt.Sprintf(`mutation { createCampaign(campaignSpec: %q) { id } }`, marshalCampaignSpecRandID("")),
+		fmt.Sprintf(`mutation { setCampaignSpecPinned(campaignSpec: %q, pinned: true) { id } }`, marshalCampaignSpecRandID("")),
 		fmt.Sprintf(`mutation { moveCampaign(campaign: %q, newName: "foobar") { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { setCampaignAutoMerge(campaign: %q, enabled: true) { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { setCampaignAutoRebase(campaign: %q, enabled: true) { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { setCampaignReapplySchedule(campaign: %q, schedule: "@daily") { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { setCampaignVisibility(campaign: %q, visibility: NAMESPACE_ONLY) { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { setCampaignUpdatePropagation(campaign: %q, updatePropagation: PRESERVE) { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { pauseCampaign(campaign: %q) { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { resumeCampaign(campaign: %q) { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { setCampaignNotificationSettings(campaign: %q, events: [ALL_PUBLISHED], subscribers: []) { id } }`, campaigns.MarshalCampaignID(0)),
+		fmt.Sprintf(`mutation { validateCampaignSpec(namespace: %q, spec: "name: foobar") { valid } }`, graphqlbackend.MarshalUserID(0)),
+		fmt.Sprintf(`mutation { executeCampaignSpec(namespace: %q, campaignSpec: "name: foobar") { id } }`, graphqlbackend.MarshalUserID(0)),
+		fmt.Sprintf(`mutation { deleteCampaignTemplate(campaignTemplate: %q) { alwaysNil } }`, marshalCampaignTemplateID(0)),
+		fmt.Sprintf(`mutation { createCampaignSpecFromTemplate(campaignTemplate: %q, namespace: %q) { id } }`, marshalCampaignTemplateID(0), graphqlbackend.MarshalUserID(1)),
 	}
 
 	for _, m := range mutations {
@@ -587,6 +602,70 @@ mutation($campaign: ID!, $newName: String, $newNamespace: ID){
 }
 `
 
+func TestSetCampaignSpecPinned(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbtesting.SetupGlobalTestDB(t)
+
+	userID := insertTestUser(t, dbconn.Global, "set-campaign-spec-pinned", false)
+
+	store := ee.NewStore(dbconn.Global)
+
+	campaignSpec := &campaigns.CampaignSpec{
+		RawSpec:         ct.TestRawCampaignSpec,
+		UserID:          userID,
+		NamespaceUserID: userID,
+	}
+	if err := store.CreateCampaignSpec(ctx, campaignSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Resolver{store: store}
+	s, err := graphqlbackend.NewSchema(r, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := map[string]interface{}{
+		"campaignSpec": string(marshalCampaignSpecRandID(campaignSpec.RandID)),
+		"pinned":       true,
+	}
+
+	var response struct{ SetCampaignSpecPinned apitest.CampaignSpec }
+	actorCtx := actor.WithActor(ctx, actor.FromUser(userID))
+	apitest.MustExec(actorCtx, t, s, input, &response, mutationSetCampaignSpecPinned)
+
+	have := response.SetCampaignSpecPinned
+	if have.PinnedAt == nil {
+		t.Fatal("campaign spec not pinned")
+	}
+	if have.ExpiresAt != nil {
+		t.Fatalf("pinned campaign spec has expiresAt: %s", have.ExpiresAt.Time)
+	}
+
+	input["pinned"] = false
+	apitest.MustExec(actorCtx, t, s, input, &response, mutationSetCampaignSpecPinned)
+
+	have = response.SetCampaignSpecPinned
+	if have.PinnedAt != nil {
+		t.Fatalf("campaign spec still pinned at %s", have.PinnedAt.Time)
+	}
+	if have.ExpiresAt == nil {
+		t.Fatal("unpinned campaign spec has no expiresAt")
+	}
+}
+
+const mutationSetCampaignSpecPinned = `
+mutation($campaignSpec: ID!, $pinned: Boolean!){
+  setCampaignSpecPinned(campaignSpec: $campaignSpec, pinned: $pinned) {
+	id, pinnedAt, expiresAt
+  }
+}
+`
+
 func TestListChangesetOptsFromArgs(t *testing.T) {
 	var wantFirst int32 = 10
 	wantPublicationStates := []campaigns.ChangesetPublicationState{
@@ -598,6 +677,7 @@ func TestListChangesetOptsFromArgs(t *testing.T) {
 		campaigns.ReconcilerStateProcessing,
 		"INVALID",
 	}
+	onlyFailed := true
 	wantExternalStates := []campaigns.ChangesetExternalState{"OPEN", "INVALID"}
 	wantReviewStates := []campaigns.ChangesetReviewState{"APPROVED", "INVALID"}
 	wantCheckStates := []campaigns.ChangesetCheckState{"PENDING", "INVALID"}
@@ -656,6 +736,14 @@ func TestListChangesetOptsFromArgs(t *testing.T) {
 			},
 			wantErr: "changeset reconciler state not valid",
 		},
+		// Filtering by failed changesets is safe and transferred to opts.
+		{
+			args: &graphqlbackend.ListChangesetsArgs{
+				OnlyFailed: &onlyFailed,
+			},
+			wantSafe:   true,
+			wantParsed: ee.ListChangesetsOpts{OnlyFailed: true},
+		},
 		// Setting external state is safe and transferred to opts.
 		{
 			args: &graphqlbackend.ListChangesetsArgs{
@@ -1,6 +1,8 @@
 package resolvers
 
 import (
+	"strings"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 )
 
@@ -11,3 +13,11 @@ func campaignsApplyURL(n graphqlbackend.Namespace, c graphqlbackend.CampaignSpec
 func campaignURL(n graphqlbackend.Namespace, c graphqlbackend.CampaignResolver) string {
 	return n.URL() + "/campaigns/" + string(c.ID())
 }
+
+func campaignChangesetsExportURL(c graphqlbackend.CampaignResolver, format string) string {
+	return "/.api/campaigns/" + string(c.ID()) + "/changesets." + strings.ToLower(format)
+}
+
+func campaignPatchURL(c graphqlbackend.CampaignResolver, format string) string {
+	return "/.api/campaigns/" + string(c.ID()) + "/patch." + strings.ToLower(strings.Replace(format, "_", ".", 1))
+}
@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// userLoader resolves users by ID for a list of resolvers. The first load
+// fetches all users the loader was created with in a single query, instead of
+// each resolver querying its users one by one.
+//
+// A nil *userLoader loads every user separately.
+type userLoader struct {
+	ids []int32
+
+	once  sync.Once
+	users map[int32]*types.User
+	err   error
+}
+
+// newUserLoader returns a userLoader that batches the loading of the users
+// with the given IDs. Zero IDs are ignored.
+func newUserLoader(ids ...int32) *userLoader {
+	seen := make(map[int32]bool, len(ids))
+	l := &userLoader{ids: make([]int32, 0, len(ids))}
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			l.ids = append(l.ids, id)
+		}
+	}
+	return l
+}
+
+// load returns the user with the given ID. Users that the loader wasn't
+// created with or that weren't found are loaded separately, so that the same
+// errors are returned as by graphqlbackend.UserByIDInt32.
+func (l *userLoader) load(ctx context.Context, id int32) (*graphqlbackend.UserResolver, error) {
+	if l == nil {
+		return graphqlbackend.UserByIDInt32(ctx, id)
+	}
+
+	l.once.Do(func() {
+		var users []*types.User
+		users, l.err = db.Users.List(ctx, &db.UsersListOptions{UserIDs: l.ids})
+		l.users = make(map[int32]*types.User, len(users))
+		for _, u := range users {
+			l.users[u.ID] = u
+		}
+	})
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	if u, ok := l.users[id]; ok {
+		return graphqlbackend.NewUserResolver(u), nil
+	}
+	return graphqlbackend.UserByIDInt32(ctx, id)
+}
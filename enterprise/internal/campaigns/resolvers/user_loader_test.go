@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func TestUserLoader(t *testing.T) {
+	ctx := context.Background()
+
+	var listed [][]int32
+	db.Mocks.Users.List = func(ctx context.Context, opt *db.UsersListOptions) ([]*types.User, error) {
+		listed = append(listed, opt.UserIDs)
+		var users []*types.User
+		for _, id := range opt.UserIDs {
+			// User 3 has been deleted.
+			if id != 3 {
+				users = append(users, &types.User{ID: id})
+			}
+		}
+		return users, nil
+	}
+	var fetched []int32
+	db.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		fetched = append(fetched, id)
+		if id == 3 {
+			return nil, errors.New("user not found")
+		}
+		return &types.User{ID: id}, nil
+	}
+	defer func() { db.Mocks.Users = db.MockUsers{} }()
+
+	l := newUserLoader(1, 0, 2, 1, 3)
+	for _, id := range []int32{1, 2, 1} {
+		u, err := l.load(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := u.DatabaseID(); have != id {
+			t.Fatalf("wrong user loaded. want=%d, have=%d", id, have)
+		}
+	}
+
+	// Users that don't exist anymore or that the loader doesn't know about
+	// are loaded separately.
+	if _, err := l.load(ctx, 3); err == nil {
+		t.Fatal("no error for deleted user")
+	}
+	if _, err := l.load(ctx, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([][]int32{{1, 2, 3}}, listed); diff != "" {
+		t.Fatalf("wrong users listed: %s", diff)
+	}
+	if diff := cmp.Diff([]int32{3, 4}, fetched); diff != "" {
+		t.Fatalf("wrong users fetched: %s", diff)
+	}
+}
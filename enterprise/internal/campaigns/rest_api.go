@@ -0,0 +1,419 @@
+package campaigns
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const (
+	// restDefaultPageSize is the number of campaigns or changesets returned
+	// by the REST API if the request doesn't specify "first".
+	restDefaultPageSize = 50
+	// restMaxPageSize is the maximum number of campaigns or changesets
+	// returned by the REST API in one response.
+	restMaxPageSize = 1000
+)
+
+// RESTCampaign is a campaign as returned by the campaigns REST API.
+type RESTCampaign struct {
+	ID          graphql.ID `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	ClosedAt    *time.Time `json:"closedAt,omitempty"`
+	PausedAt    *time.Time `json:"pausedAt,omitempty"`
+}
+
+func newRESTCampaign(c *campaigns.Campaign) *RESTCampaign {
+	rc := &RESTCampaign{
+		ID:          campaigns.MarshalCampaignID(c.ID),
+		Name:        c.Name,
+		Description: c.Description,
+		State:       string(campaigns.CampaignStateOpen),
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+	if c.Closed() {
+		rc.State = string(campaigns.CampaignStateClosed)
+		rc.ClosedAt = &c.ClosedAt
+	}
+	if c.Paused() {
+		rc.PausedAt = &c.PausedAt
+	}
+	return rc
+}
+
+// RESTChangeset is a changeset as returned by the campaigns REST API.
+type RESTChangeset struct {
+	ID               graphql.ID `json:"id"`
+	Repository       string     `json:"repository"`
+	PublicationState string     `json:"publicationState"`
+	ReconcilerState  string     `json:"reconcilerState"`
+	ExternalID       string     `json:"externalID,omitempty"`
+	ExternalState    string     `json:"externalState,omitempty"`
+	ReviewState      string     `json:"reviewState,omitempty"`
+	CheckState       string     `json:"checkState,omitempty"`
+	URL              string     `json:"url,omitempty"`
+	Error            string     `json:"error,omitempty"`
+	SkippedReason    string     `json:"skippedReason,omitempty"`
+	ArchivedAt       *time.Time `json:"archivedAt,omitempty"`
+}
+
+// restChangesetIDKind is the kind of the GraphQL IDs of changesets, so that
+// IDs returned by the REST API can be used with the GraphQL API.
+const restChangesetIDKind = "ExternalChangeset"
+
+func newRESTChangeset(c *campaigns.Changeset, repoName string) (*RESTChangeset, error) {
+	rc := &RESTChangeset{
+		ID:               relay.MarshalID(restChangesetIDKind, c.ID),
+		Repository:       repoName,
+		PublicationState: string(c.PublicationState),
+		ReconcilerState:  string(c.ReconcilerState),
+		SkippedReason:    c.SkippedReason,
+	}
+	if c.FailureMessage != nil {
+		rc.Error = *c.FailureMessage
+	}
+	if c.Archived() {
+		rc.ArchivedAt = &c.ArchivedAt
+	}
+
+	if c.PublicationState.Unpublished() {
+		return rc, nil
+	}
+
+	url, err := c.URL()
+	if err != nil {
+		return nil, err
+	}
+
+	rc.ExternalID = c.ExternalID
+	rc.ExternalState = string(c.ExternalState)
+	rc.ReviewState = string(c.ExternalReviewState)
+	if c.ExternalCheckState != campaigns.ChangesetCheckStateUnknown {
+		rc.CheckState = string(c.ExternalCheckState)
+	}
+	rc.URL = url
+
+	return rc, nil
+}
+
+// RESTHandler is an http.Handler that serves a minimal REST API for scripts
+// and dashboards that can't use the GraphQL API:
+//
+//	GET  /campaigns                     lists campaigns
+//	GET  /campaigns/{id}/changesets     lists the changesets of a campaign
+//	POST /campaigns/{id}/close          closes a campaign
+//	POST /changesets/{id}/retry         retries an errored changeset
+//
+// Campaigns and changesets are identified by their GraphQL IDs. Lists are
+// paginated with the "first" and "after" query parameters.
+//
+// It expects to be registered on a route with the mux variable "path", the
+// path of the request relative to the root of the API.
+type RESTHandler struct {
+	Store *Store
+
+	router *mux.Router
+}
+
+// NewRESTHandler returns a new RESTHandler that reads and writes campaigns
+// and changesets with the given Store.
+func NewRESTHandler(store *Store) *RESTHandler {
+	h := &RESTHandler{Store: store, router: mux.NewRouter()}
+
+	h.router.Path("/campaigns").Methods("GET").HandlerFunc(h.listCampaigns)
+	h.router.Path("/campaigns/{id}/changesets").Methods("GET").HandlerFunc(h.listChangesets)
+	h.router.Path("/campaigns/{id}/close").Methods("POST").HandlerFunc(h.closeCampaign)
+	h.router.Path("/changesets/{id}/retry").Methods("POST").HandlerFunc(h.retryChangeset)
+	h.router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusNotFound, errors.New("no route"))
+	})
+
+	return h
+}
+
+func (h *RESTHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// 🚨 SECURITY: The REST API is only available to authenticated users.
+	if !actor.FromContext(r.Context()).IsAuthenticated() {
+		respond(w, http.StatusUnauthorized, errors.New("authentication required"))
+		return
+	}
+
+	r = r.Clone(r.Context())
+	r.URL.Path = "/" + strings.TrimPrefix(mux.Vars(r)["path"], "/")
+	h.router.ServeHTTP(w, r)
+}
+
+// restPage returns the limit and cursor requested with the "first" and
+// "after" query parameters.
+func restPage(r *http.Request) (limit int, cursor int64, err error) {
+	limit = restDefaultPageSize
+	if first := r.URL.Query().Get("first"); first != "" {
+		limit, err = strconv.Atoi(first)
+		if err != nil || limit < 1 || limit > restMaxPageSize {
+			return 0, 0, errors.Errorf("first must be between 1 and %d", restMaxPageSize)
+		}
+	}
+	if after := r.URL.Query().Get("after"); after != "" {
+		cursor, err = strconv.ParseInt(after, 10, 64)
+		if err != nil || cursor < 1 {
+			return 0, 0, errors.New("invalid cursor")
+		}
+	}
+	return limit, cursor, nil
+}
+
+// restNextCursor returns the cursor of the next page, or an empty string if
+// there is none.
+func restNextCursor(next int64) string {
+	if next == 0 {
+		return ""
+	}
+	return strconv.FormatInt(next, 10)
+}
+
+func (h *RESTHandler) listCampaigns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may
+	// access campaigns.
+	if err := checkRESTReadAccess(ctx); err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	limit, cursor, err := restPage(r)
+	if err != nil {
+		respond(w, http.StatusBadRequest, err)
+		return
+	}
+	opts := ListCampaignsOpts{Limit: limit, Cursor: cursor, Query: r.URL.Query().Get("query")}
+
+	switch state := campaigns.CampaignState(strings.ToUpper(r.URL.Query().Get("state"))); state {
+	case "", campaigns.CampaignStateAny:
+	case campaigns.CampaignStateOpen, campaigns.CampaignStateClosed:
+		opts.State = state
+	default:
+		respond(w, http.StatusBadRequest, errors.Errorf("unknown campaign state %q", state))
+		return
+	}
+
+	// 🚨 SECURITY: Non-site-admins only see the campaigns whose visibility
+	// allows them to.
+	switch err := backend.CheckCurrentUserIsSiteAdmin(ctx); err {
+	case nil:
+	case backend.ErrMustBeSiteAdmin:
+		opts.VisibleTo = &CampaignViewer{UserID: actor.FromContext(ctx).UID}
+	default:
+		respondRESTError(w, err)
+		return
+	}
+
+	cs, next, err := h.Store.ListCampaigns(ctx, opts)
+	if err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	res := struct {
+		Campaigns []*RESTCampaign `json:"campaigns"`
+		Next      string          `json:"next,omitempty"`
+	}{
+		Campaigns: make([]*RESTCampaign, 0, len(cs)),
+		Next:      restNextCursor(next),
+	}
+	for _, c := range cs {
+		res.Campaigns = append(res.Campaigns, newRESTCampaign(c))
+	}
+
+	respond(w, http.StatusOK, res)
+}
+
+func (h *RESTHandler) listChangesets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	campaign, ok := h.campaign(w, r)
+	if !ok {
+		return
+	}
+
+	limit, cursor, err := restPage(r)
+	if err != nil {
+		respond(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cs, next, err := h.Store.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID:     campaign.ID,
+		WithoutDeleted: true,
+		Limit:          limit,
+		Cursor:         cursor,
+	})
+	if err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access
+	// to. Changesets in those repositories are left out of the response.
+	accessibleRepos, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	res := struct {
+		Changesets []*RESTChangeset `json:"changesets"`
+		Next       string           `json:"next,omitempty"`
+	}{
+		Changesets: make([]*RESTChangeset, 0, len(cs)),
+		Next:       restNextCursor(next),
+	}
+	for _, c := range cs {
+		repo, ok := accessibleRepos[c.RepoID]
+		if !ok {
+			continue
+		}
+
+		rc, err := newRESTChangeset(c, string(repo.Name))
+		if err != nil {
+			respondRESTError(w, errors.Wrapf(err, "changeset %d", c.ID))
+			return
+		}
+		res.Changesets = append(res.Changesets, rc)
+	}
+
+	respond(w, http.StatusOK, res)
+}
+
+func (h *RESTHandler) closeCampaign(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := h.campaign(w, r)
+	if !ok {
+		return
+	}
+
+	closeChangesets, _ := strconv.ParseBool(r.URL.Query().Get("closeChangesets"))
+
+	// 🚨 SECURITY: CloseCampaign checks whether the current user has admin
+	// rights for the campaign.
+	svc := NewService(h.Store, nil)
+	campaign, err := svc.CloseCampaign(r.Context(), campaign.ID, closeChangesets, true)
+	if err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	respond(w, http.StatusOK, newRESTCampaign(campaign))
+}
+
+func (h *RESTHandler) retryChangeset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var changesetID int64
+	if err := relay.UnmarshalSpec(graphql.ID(mux.Vars(r)["id"]), &changesetID); err != nil || changesetID == 0 {
+		respond(w, http.StatusBadRequest, errors.New("invalid changeset ID"))
+		return
+	}
+
+	// 🚨 SECURITY: RetryChangeset checks whether the current user has admin
+	// rights for one of the changeset's campaigns and access to its
+	// repository.
+	svc := NewService(h.Store, nil)
+	changeset, err := svc.RetryChangeset(ctx, changesetID)
+	if err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	repo, err := db.Repos.Get(ctx, changeset.RepoID)
+	if err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	rc, err := newRESTChangeset(changeset, string(repo.Name))
+	if err != nil {
+		respondRESTError(w, err)
+		return
+	}
+
+	respond(w, http.StatusOK, rc)
+}
+
+// campaign returns the campaign identified by the "id" mux variable of the
+// request, if the current user can see it. If it returns false, an error
+// has been written to w.
+func (h *RESTHandler) campaign(w http.ResponseWriter, r *http.Request) (*campaigns.Campaign, bool) {
+	campaign, ok := exportedCampaign(w, r, h.Store)
+	if !ok {
+		return nil, false
+	}
+
+	// 🚨 SECURITY: Campaigns that the user can't see are reported as not
+	// found, so that their existence isn't revealed.
+	visible, err := CampaignVisible(r.Context(), h.Store, campaign)
+	if err != nil {
+		respondRESTError(w, err)
+		return nil, false
+	}
+	if !visible {
+		respond(w, http.StatusNotFound, errors.New("campaign not found"))
+		return nil, false
+	}
+
+	return campaign, true
+}
+
+// checkRESTReadAccess returns an error if campaigns read access is disabled
+// and the current user isn't a site admin.
+func checkRESTReadAccess(ctx context.Context) error {
+	if conf.CampaignsReadAccessEnabled() {
+		return nil
+	}
+	return backend.CheckCurrentUserIsSiteAdmin(ctx)
+}
+
+// restErrorStatus returns the HTTP status code with which the REST API
+// responds to the given error.
+func restErrorStatus(err error) int {
+	switch errors.Cause(err) {
+	case ErrNoResults:
+		return http.StatusNotFound
+	case backend.ErrNotAuthenticated:
+		return http.StatusUnauthorized
+	case backend.ErrMustBeSiteAdmin:
+		return http.StatusForbidden
+	case ErrChangesetNotRetryable, ErrCloseProcessingCampaign, ErrClosePausedCampaignChangesets:
+		return http.StatusConflict
+	}
+
+	if _, ok := errors.Cause(err).(*backend.InsufficientAuthorizationError); ok {
+		return http.StatusForbidden
+	}
+	if errcode.IsNotFound(err) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func respondRESTError(w http.ResponseWriter, err error) {
+	respond(w, restErrorStatus(err), err)
+}
@@ -0,0 +1,183 @@
+package campaigns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestNewRESTChangeset(t *testing.T) {
+	failureMessage := "failed to push"
+
+	tests := []struct {
+		name      string
+		changeset *campaigns.Changeset
+		want      *RESTChangeset
+	}{
+		{
+			name: "unpublished",
+			changeset: &campaigns.Changeset{
+				ID:               1,
+				PublicationState: campaigns.ChangesetPublicationStateUnpublished,
+				ReconcilerState:  campaigns.ReconcilerStateErrored,
+				FailureMessage:   &failureMessage,
+			},
+			want: &RESTChangeset{
+				ID:               "RXh0ZXJuYWxDaGFuZ2VzZXQ6MQ==",
+				Repository:       "github.com/sourcegraph/sourcegraph",
+				PublicationState: "UNPUBLISHED",
+				ReconcilerState:  "ERRORED",
+				Error:            failureMessage,
+			},
+		},
+		{
+			name: "published",
+			changeset: &campaigns.Changeset{
+				ID:                  2,
+				PublicationState:    campaigns.ChangesetPublicationStatePublished,
+				ReconcilerState:     campaigns.ReconcilerStateCompleted,
+				ExternalID:          "12345",
+				ExternalState:       campaigns.ChangesetExternalStateOpen,
+				ExternalReviewState: campaigns.ChangesetReviewStateApproved,
+				ExternalCheckState:  campaigns.ChangesetCheckStateUnknown,
+				Metadata:            &github.PullRequest{URL: "https://github.com/sourcegraph/sourcegraph/pull/12345"},
+			},
+			want: &RESTChangeset{
+				ID:               "RXh0ZXJuYWxDaGFuZ2VzZXQ6Mg==",
+				Repository:       "github.com/sourcegraph/sourcegraph",
+				PublicationState: "PUBLISHED",
+				ReconcilerState:  "COMPLETED",
+				ExternalID:       "12345",
+				ExternalState:    "OPEN",
+				ReviewState:      "APPROVED",
+				URL:              "https://github.com/sourcegraph/sourcegraph/pull/12345",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := newRESTChangeset(tc.changeset, "github.com/sourcegraph/sourcegraph")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong changeset (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRESTErrorStatus(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{err: ErrNoResults, want: http.StatusNotFound},
+		{err: errors.Wrap(ErrNoResults, "getting campaign"), want: http.StatusNotFound},
+		{err: backend.ErrNotAuthenticated, want: http.StatusUnauthorized},
+		{err: backend.ErrMustBeSiteAdmin, want: http.StatusForbidden},
+		{err: &backend.InsufficientAuthorizationError{Message: "no"}, want: http.StatusForbidden},
+		{err: ErrChangesetNotRetryable, want: http.StatusConflict},
+		{err: ErrClosePausedCampaignChangesets, want: http.StatusConflict},
+		{err: errors.New("boom"), want: http.StatusInternalServerError},
+	} {
+		if have := restErrorStatus(tc.err); have != tc.want {
+			t.Errorf("restErrorStatus(%q): want=%d, have=%d", tc.err, tc.want, have)
+		}
+	}
+}
+
+func TestRESTHandler(t *testing.T) {
+	h := NewRESTHandler(nil)
+
+	router := mux.NewRouter()
+	router.Path("/.api/campaigns/v1/{path:.*}").Handler(h)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		userID     int32
+		wantStatus int
+	}{
+		{
+			name:       "unauthenticated",
+			method:     "GET",
+			path:       "/.api/campaigns/v1/campaigns",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown route",
+			method:     "GET",
+			path:       "/.api/campaigns/v1/foobar",
+			userID:     1,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "wrong method",
+			method:     "GET",
+			path:       "/.api/campaigns/v1/changesets/RXh0ZXJuYWxDaGFuZ2VzZXQ6MQ==/retry",
+			userID:     1,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid changeset ID",
+			method:     "POST",
+			path:       "/.api/campaigns/v1/changesets/invalid/retry",
+			userID:     1,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.userID != 0 {
+				ctx = actor.WithActor(ctx, &actor.Actor{UID: tc.userID})
+			}
+
+			req := httptest.NewRequest(tc.method, tc.path, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if have, want := rec.Code, tc.wantStatus; have != want {
+				t.Fatalf("wrong status. want=%d, have=%d (body: %q)", want, have, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRESTPage(t *testing.T) {
+	for _, tc := range []struct {
+		query      string
+		wantLimit  int
+		wantCursor int64
+		wantErr    bool
+	}{
+		{query: "", wantLimit: restDefaultPageSize},
+		{query: "first=10&after=42", wantLimit: 10, wantCursor: 42},
+		{query: "first=0", wantErr: true},
+		{query: "first=100000", wantErr: true},
+		{query: "after=foo", wantErr: true},
+	} {
+		req := httptest.NewRequest("GET", "/campaigns?"+tc.query, nil)
+		limit, cursor, err := restPage(req)
+		if have, want := err != nil, tc.wantErr; have != want {
+			t.Errorf("query %q: wrong error %v", tc.query, err)
+			continue
+		}
+		if limit != tc.wantLimit || cursor != tc.wantCursor {
+			t.Errorf("query %q: want limit=%d cursor=%d, have limit=%d cursor=%d", tc.query, tc.wantLimit, tc.wantCursor, limit, cursor)
+		}
+	}
+}
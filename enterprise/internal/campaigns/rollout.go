@@ -0,0 +1,226 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// campaignNamespace is the user or organization namespace of a campaign,
+// identified by its name as used in the site configuration.
+type campaignNamespace struct {
+	user string
+	org  string
+}
+
+func (n campaignNamespace) String() string {
+	if n.org != "" {
+		return n.org
+	}
+	return n.user
+}
+
+func (n campaignNamespace) matches(rule *schema.CampaignsNamespaceRule) bool {
+	if rule == nil {
+		return false
+	}
+	if n.org != "" {
+		return rule.Org != "" && strings.EqualFold(rule.Org, n.org)
+	}
+	return rule.User != "" && strings.EqualFold(rule.User, n.user)
+}
+
+// namespaceNotEnabledErr is returned by CreateCampaignSpec and ApplyCampaign
+// if campaigns have not been enabled for the campaign's namespace in the
+// `campaigns.namespaces` site configuration.
+type namespaceNotEnabledErr struct {
+	Namespace string
+}
+
+func (e *namespaceNotEnabledErr) Error() string {
+	return fmt.Sprintf("campaigns are not enabled for namespace %q", e.Namespace)
+}
+
+// changesetLimitExceededErr is returned by CreateCampaignSpec and
+// ApplyCampaign if a campaign would contain more changesets than allowed for
+// its namespace in the `campaigns.namespaces` site configuration.
+type changesetLimitExceededErr struct {
+	Namespace string
+	Limit     int
+	Count     int
+}
+
+func (e *changesetLimitExceededErr) Error() string {
+	return fmt.Sprintf("campaigns in namespace %q can contain at most %d changesets, but this campaign contains %d", e.Namespace, e.Limit, e.Count)
+}
+
+func (e *changesetLimitExceededErr) BadRequest() bool { return true }
+
+// openCampaignLimitExceededErr is returned by ApplyCampaign and MoveCampaign
+// if a namespace would contain more open campaigns than allowed in the
+// `campaigns.namespaces` site configuration.
+type openCampaignLimitExceededErr struct {
+	Namespace string
+	Limit     int
+}
+
+func (e *openCampaignLimitExceededErr) Error() string {
+	return fmt.Sprintf("namespace %q can contain at most %d open campaigns", e.Namespace, e.Limit)
+}
+
+func (e *openCampaignLimitExceededErr) BadRequest() bool { return true }
+
+// NamespaceQuota describes the limits that the `campaigns.namespaces` site
+// configuration imposes on a namespace, and its current usage.
+type NamespaceQuota struct {
+	// Enabled is false if campaigns can't be created in the namespace.
+	Enabled bool
+	// MaxChangesets is the maximum number of changesets per campaign. 0
+	// means no limit.
+	MaxChangesets int
+	// MaxOpenCampaigns is the maximum number of open campaigns in the
+	// namespace. 0 means no limit.
+	MaxOpenCampaigns int
+	// OpenCampaigns is the number of open campaigns in the namespace.
+	OpenCampaigns int
+}
+
+// namespaceLimits are the limits that apply to a namespace.
+type namespaceLimits struct {
+	maxChangesets    int
+	maxOpenCampaigns int
+}
+
+// loadCampaignNamespace looks up the name of the given user or organization
+// namespace.
+func loadCampaignNamespace(ctx context.Context, namespaceUserID, namespaceOrgID int32) (campaignNamespace, error) {
+	var ns campaignNamespace
+	if namespaceOrgID != 0 {
+		org, err := db.Orgs.GetByID(ctx, namespaceOrgID)
+		if err != nil {
+			return ns, err
+		}
+		ns.org = org.Name
+	} else {
+		user, err := db.Users.GetByID(ctx, namespaceUserID)
+		if err != nil {
+			return ns, err
+		}
+		ns.user = user.Username
+	}
+	return ns, nil
+}
+
+// checkNamespaceRollout returns an error if the site configuration doesn't
+// allow a campaign with the given number of changesets in the given
+// namespace.
+func checkNamespaceRollout(ctx context.Context, namespaceUserID, namespaceOrgID int32, changesetCount int) error {
+	cfg := conf.Get().CampaignsNamespaces
+	if cfg == nil {
+		return nil
+	}
+
+	ns, err := loadCampaignNamespace(ctx, namespaceUserID, namespaceOrgID)
+	if err != nil {
+		return err
+	}
+
+	return checkNamespaceRolloutConfig(cfg, ns, changesetCount)
+}
+
+// checkNamespaceRolloutConfig checks the given namespace and number of
+// changesets against the given configuration.
+func checkNamespaceRolloutConfig(cfg *schema.CampaignsNamespaces, ns campaignNamespace, changesetCount int) error {
+	limits, err := namespaceLimitsConfig(cfg, ns)
+	if err != nil {
+		return err
+	}
+
+	if limits.maxChangesets > 0 && changesetCount > limits.maxChangesets {
+		return &changesetLimitExceededErr{Namespace: ns.String(), Limit: limits.maxChangesets, Count: changesetCount}
+	}
+	return nil
+}
+
+// namespaceLimitsConfig returns the limits that the given configuration
+// imposes on the given namespace, or a namespaceNotEnabledErr if campaigns
+// are not enabled for it.
+func namespaceLimitsConfig(cfg *schema.CampaignsNamespaces, ns campaignNamespace) (namespaceLimits, error) {
+	limits := namespaceLimits{
+		maxChangesets:    cfg.MaxChangesets,
+		maxOpenCampaigns: cfg.MaxOpenCampaigns,
+	}
+
+	for _, rule := range cfg.Denylist {
+		if ns.matches(rule) {
+			return limits, &namespaceNotEnabledErr{Namespace: ns.String()}
+		}
+	}
+
+	if len(cfg.Allowlist) > 0 {
+		var allowed bool
+		for _, rule := range cfg.Allowlist {
+			if ns.matches(rule) {
+				allowed = true
+				if rule.MaxChangesets != nil {
+					limits.maxChangesets = *rule.MaxChangesets
+				}
+				if rule.MaxOpenCampaigns != nil {
+					limits.maxOpenCampaigns = *rule.MaxOpenCampaigns
+				}
+				break
+			}
+		}
+		if !allowed {
+			return limits, &namespaceNotEnabledErr{Namespace: ns.String()}
+		}
+	}
+
+	return limits, nil
+}
+
+// checkOpenCampaignsQuota returns an error if the given namespace can't
+// contain another open campaign.
+func checkOpenCampaignsQuota(ctx context.Context, tx *Store, namespaceUserID, namespaceOrgID int32) error {
+	cfg := conf.Get().CampaignsNamespaces
+	if cfg == nil {
+		return nil
+	}
+
+	ns, err := loadCampaignNamespace(ctx, namespaceUserID, namespaceOrgID)
+	if err != nil {
+		return err
+	}
+
+	limits, err := namespaceLimitsConfig(cfg, ns)
+	if err != nil {
+		return err
+	}
+	if limits.maxOpenCampaigns == 0 {
+		return nil
+	}
+
+	// Hold the namespace's lock until the transaction ends, so that concurrent
+	// transactions can't both see room for one more open campaign.
+	if err := tx.LockCampaignNamespace(ctx, namespaceUserID, namespaceOrgID); err != nil {
+		return err
+	}
+
+	open, err := tx.CountCampaigns(ctx, CountCampaignsOpts{
+		State:           campaigns.CampaignStateOpen,
+		NamespaceUserID: namespaceUserID,
+		NamespaceOrgID:  namespaceOrgID,
+	})
+	if err != nil {
+		return err
+	}
+	if open >= limits.maxOpenCampaigns {
+		return &openCampaignLimitExceededErr{Namespace: ns.String(), Limit: limits.maxOpenCampaigns}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCheckNamespaceRolloutConfig(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	alice := campaignNamespace{user: "alice"}
+	bob := campaignNamespace{user: "bob"}
+	pilot := campaignNamespace{org: "pilot"}
+
+	for _, tc := range []struct {
+		name    string
+		cfg     *schema.CampaignsNamespaces
+		ns      campaignNamespace
+		count   int
+		wantErr error
+	}{
+		{
+			name: "empty config",
+			cfg:  &schema.CampaignsNamespaces{},
+			ns:   alice,
+		},
+		{
+			name:    "denied",
+			cfg:     &schema.CampaignsNamespaces{Denylist: []*schema.CampaignsNamespaceRule{{User: "ALICE"}}},
+			ns:      alice,
+			wantErr: &namespaceNotEnabledErr{Namespace: "alice"},
+		},
+		{
+			name: "org rule doesn't match user with same name",
+			cfg:  &schema.CampaignsNamespaces{Denylist: []*schema.CampaignsNamespaceRule{{Org: "alice"}}},
+			ns:   alice,
+		},
+		{
+			name: "allowed",
+			cfg:  &schema.CampaignsNamespaces{Allowlist: []*schema.CampaignsNamespaceRule{{Org: "pilot"}}},
+			ns:   pilot,
+		},
+		{
+			name:    "not in allowlist",
+			cfg:     &schema.CampaignsNamespaces{Allowlist: []*schema.CampaignsNamespaceRule{{Org: "pilot"}}},
+			ns:      bob,
+			wantErr: &namespaceNotEnabledErr{Namespace: "bob"},
+		},
+		{
+			name: "denylist takes precedence",
+			cfg: &schema.CampaignsNamespaces{
+				Allowlist: []*schema.CampaignsNamespaceRule{{Org: "pilot"}},
+				Denylist:  []*schema.CampaignsNamespaceRule{{Org: "pilot"}},
+			},
+			ns:      pilot,
+			wantErr: &namespaceNotEnabledErr{Namespace: "pilot"},
+		},
+		{
+			name:    "default limit exceeded",
+			cfg:     &schema.CampaignsNamespaces{MaxChangesets: 10},
+			ns:      alice,
+			count:   11,
+			wantErr: &changesetLimitExceededErr{Namespace: "alice", Limit: 10, Count: 11},
+		},
+		{
+			name: "allowlist limit overrides default",
+			cfg: &schema.CampaignsNamespaces{
+				Allowlist:     []*schema.CampaignsNamespaceRule{{Org: "pilot", MaxChangesets: intPtr(100)}},
+				MaxChangesets: 10,
+			},
+			ns:    pilot,
+			count: 100,
+		},
+		{
+			name: "allowlist removes default limit",
+			cfg: &schema.CampaignsNamespaces{
+				Allowlist:     []*schema.CampaignsNamespaceRule{{Org: "pilot", MaxChangesets: intPtr(0)}},
+				MaxChangesets: 10,
+			},
+			ns:    pilot,
+			count: 1000,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkNamespaceRolloutConfig(tc.cfg, tc.ns, tc.count)
+			if diff := cmp.Diff(tc.wantErr, err); diff != "" {
+				t.Fatalf("wrong error (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNamespaceLimitsConfig(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	alice := campaignNamespace{user: "alice"}
+	pilot := campaignNamespace{org: "pilot"}
+
+	for _, tc := range []struct {
+		name       string
+		cfg        *schema.CampaignsNamespaces
+		ns         campaignNamespace
+		wantLimits namespaceLimits
+		wantErr    error
+	}{
+		{
+			name:       "defaults",
+			cfg:        &schema.CampaignsNamespaces{MaxChangesets: 10, MaxOpenCampaigns: 2},
+			ns:         alice,
+			wantLimits: namespaceLimits{maxChangesets: 10, maxOpenCampaigns: 2},
+		},
+		{
+			name: "allowlist overrides defaults",
+			cfg: &schema.CampaignsNamespaces{
+				Allowlist:        []*schema.CampaignsNamespaceRule{{Org: "pilot", MaxOpenCampaigns: intPtr(20)}},
+				MaxChangesets:    10,
+				MaxOpenCampaigns: 2,
+			},
+			ns:         pilot,
+			wantLimits: namespaceLimits{maxChangesets: 10, maxOpenCampaigns: 20},
+		},
+		{
+			name: "allowlist removes default limit",
+			cfg: &schema.CampaignsNamespaces{
+				Allowlist:        []*schema.CampaignsNamespaceRule{{Org: "pilot", MaxOpenCampaigns: intPtr(0)}},
+				MaxOpenCampaigns: 2,
+			},
+			ns: pilot,
+		},
+		{
+			name:       "denied",
+			cfg:        &schema.CampaignsNamespaces{Denylist: []*schema.CampaignsNamespaceRule{{User: "alice"}}, MaxOpenCampaigns: 2},
+			ns:         alice,
+			wantLimits: namespaceLimits{maxOpenCampaigns: 2},
+			wantErr:    &namespaceNotEnabledErr{Namespace: "alice"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			limits, err := namespaceLimitsConfig(tc.cfg, tc.ns)
+			if diff := cmp.Diff(tc.wantErr, err); diff != "" {
+				t.Fatalf("wrong error (-want +have):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantLimits, limits, cmp.AllowUnexported(namespaceLimits{})); diff != "" {
+				t.Fatalf("wrong limits (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckOpenCampaignsQuotaConcurrent(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	user := createTestUser(ctx, t)
+	store := NewStore(dbconn.Global)
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CampaignsNamespaces: &schema.CampaignsNamespaces{MaxOpenCampaigns: 1},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	first, err := store.Transact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkOpenCampaignsQuota(ctx, first, user.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	checked := make(chan error, 1)
+	go func() {
+		second, err := store.Transact(ctx)
+		if err != nil {
+			checked <- err
+			return
+		}
+		err = checkOpenCampaignsQuota(ctx, second, user.ID, 0)
+		second.Done(nil)
+		checked <- err
+	}()
+
+	// The second check waits for the first transaction, which is still about
+	// to create the namespace's only allowed open campaign.
+	select {
+	case err := <-checked:
+		t.Fatalf("second quota check didn't wait for the first transaction: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := first.CreateCampaign(ctx, testCampaign(user.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Done(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-checked:
+		if _, ok := err.(*openCampaignLimitExceededErr); !ok {
+			t.Fatalf("expected openCampaignLimitExceededErr but got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second quota check didn't finish after the first transaction ended")
+	}
+}
@@ -0,0 +1,242 @@
+package campaigns
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+	"golang.org/x/time/rate"
+)
+
+// rolloutWindowErr is returned by the reconciler if a changeset can't be
+// published yet because of the `campaigns.rolloutWindows` site
+// configuration.
+type rolloutWindowErr struct {
+	delay time.Duration
+}
+
+func (e *rolloutWindowErr) Error() string {
+	return fmt.Sprintf("publication delayed by rollout windows for %s", e.delay)
+}
+
+// rolloutWindow is a parsed schema.CampaignsRolloutWindow.
+type rolloutWindow struct {
+	// days is nil if the window applies on every day.
+	days map[time.Weekday]bool
+	// start and end are the minutes since midnight UTC between which the
+	// window applies. end is exclusive.
+	start, end int
+
+	limit rate.Limit
+	burst int
+}
+
+// covers returns whether the window applies at the given time.
+func (w *rolloutWindow) covers(t time.Time) bool {
+	t = t.UTC()
+	if w.days != nil && !w.days[t.Weekday()] {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	return minute >= w.start && minute < w.end
+}
+
+// nextStart returns the next time after t at which the window starts.
+func (w *rolloutWindow) nextStart(t time.Time) time.Time {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	for d := 0; d <= 7; d++ {
+		day := midnight.AddDate(0, 0, d)
+		start := day.Add(time.Duration(w.start) * time.Minute)
+		if start.After(t) && (w.days == nil || w.days[day.Weekday()]) {
+			return start
+		}
+	}
+	// Not reachable for valid windows, which apply on at least one day.
+	return midnight.AddDate(0, 0, 8)
+}
+
+// endOn returns the time at which the window ends on the day of t.
+func (w *rolloutWindow) endOn(t time.Time) time.Time {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return midnight.Add(time.Duration(w.end) * time.Minute)
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func parseRolloutWindow(raw *schema.CampaignsRolloutWindow) (*rolloutWindow, error) {
+	w := &rolloutWindow{start: 0, end: 24 * 60}
+
+	if len(raw.Days) > 0 {
+		w.days = make(map[time.Weekday]bool, len(raw.Days))
+		for _, d := range raw.Days {
+			day, ok := weekdays[strings.ToLower(d)]
+			if !ok {
+				return nil, errors.Errorf("invalid day %q", d)
+			}
+			w.days[day] = true
+		}
+	}
+
+	var err error
+	if raw.Start != "" {
+		if w.start, err = parseTimeOfDay(raw.Start); err != nil {
+			return nil, err
+		}
+	}
+	if raw.End != "" {
+		if w.end, err = parseTimeOfDay(raw.End); err != nil {
+			return nil, err
+		}
+	}
+	if w.end <= w.start {
+		return nil, errors.Errorf("end %q must be after start %q", raw.End, raw.Start)
+	}
+
+	if w.limit, w.burst, err = parseRolloutRate(raw.Rate); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// parseTimeOfDay parses a time of day in HH:MM format into minutes since
+// midnight.
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.Errorf("invalid time of day %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseRolloutRate parses a rate like "50/hour" into a rate.Limit that
+// spreads publications evenly over the given unit.
+func parseRolloutRate(s string) (rate.Limit, int, error) {
+	if s == "unlimited" {
+		return rate.Inf, 1, nil
+	}
+
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("invalid rate %q", s)
+	}
+	n, err := strconv.Atoi(parts[0])
+	if err != nil || n < 0 {
+		return 0, 0, errors.Errorf("invalid rate %q", s)
+	}
+	if n == 0 {
+		return 0, 0, nil
+	}
+
+	var unit time.Duration
+	switch parts[1] {
+	case "second":
+		unit = time.Second
+	case "minute":
+		unit = time.Minute
+	case "hour":
+		unit = time.Hour
+	case "day":
+		unit = 24 * time.Hour
+	default:
+		return 0, 0, errors.Errorf("invalid rate unit %q", parts[1])
+	}
+
+	return rate.Every(unit / time.Duration(n)), 1, nil
+}
+
+// rolloutWindows decides when changesets may be published, based on the
+// `campaigns.rolloutWindows` site configuration. Since the reconciler only
+// runs on a single replica, the rate limits are tracked in memory.
+type rolloutWindows struct {
+	// config returns the current configuration. If nil, conf.Get is used.
+	config func() []*schema.CampaignsRolloutWindow
+
+	mu       sync.Mutex
+	raw      []*schema.CampaignsRolloutWindow
+	windows  []*rolloutWindow
+	limiters []*rate.Limiter
+}
+
+// reserve returns 0 if a changeset may be published at the given time, in
+// which case the publication counts against the rate of the current window.
+// Otherwise it returns how long the publication should be delayed.
+func (r *rolloutWindows) reserve(now time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.load()
+	if len(r.windows) == 0 {
+		return 0
+	}
+
+	for i, w := range r.windows {
+		if !w.covers(now) {
+			continue
+		}
+
+		if w.burst == 0 {
+			// Publication is paused for the rest of the window.
+			return w.endOn(now).Sub(now)
+		}
+
+		res := r.limiters[i].ReserveN(now, 1)
+		delay := res.DelayFrom(now)
+		if delay > 0 {
+			res.CancelAt(now)
+		}
+		return delay
+	}
+
+	next := r.windows[0].nextStart(now)
+	for _, w := range r.windows[1:] {
+		if s := w.nextStart(now); s.Before(next) {
+			next = s
+		}
+	}
+	return next.Sub(now)
+}
+
+// load parses the configuration if it changed since the last call. Invalid
+// windows are logged and ignored.
+func (r *rolloutWindows) load() {
+	var raw []*schema.CampaignsRolloutWindow
+	if r.config != nil {
+		raw = r.config()
+	} else {
+		raw = conf.Get().CampaignsRolloutWindows
+	}
+
+	if r.windows != nil && reflect.DeepEqual(raw, r.raw) {
+		return
+	}
+
+	r.raw = raw
+	r.windows = make([]*rolloutWindow, 0, len(raw))
+	r.limiters = make([]*rate.Limiter, 0, len(raw))
+	for _, rw := range raw {
+		w, err := parseRolloutWindow(rw)
+		if err != nil {
+			log15.Error("Ignoring invalid campaigns rollout window", "err", err)
+			continue
+		}
+		r.windows = append(r.windows, w)
+		r.limiters = append(r.limiters, rate.NewLimiter(w.limit, w.burst))
+	}
+}
@@ -0,0 +1,74 @@
+package campaigns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseRolloutWindow(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		raw     schema.CampaignsRolloutWindow
+		wantErr bool
+	}{
+		{name: "unlimited", raw: schema.CampaignsRolloutWindow{Rate: "unlimited"}},
+		{name: "rate", raw: schema.CampaignsRolloutWindow{Rate: "50/hour", Days: []string{"monday"}, Start: "09:00", End: "17:00"}},
+		{name: "paused", raw: schema.CampaignsRolloutWindow{Rate: "0/hour"}},
+		{name: "invalid rate", raw: schema.CampaignsRolloutWindow{Rate: "50/fortnight"}, wantErr: true},
+		{name: "invalid day", raw: schema.CampaignsRolloutWindow{Rate: "unlimited", Days: []string{"someday"}}, wantErr: true},
+		{name: "invalid time", raw: schema.CampaignsRolloutWindow{Rate: "unlimited", Start: "9am"}, wantErr: true},
+		{name: "end before start", raw: schema.CampaignsRolloutWindow{Rate: "unlimited", Start: "17:00", End: "09:00"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseRolloutWindow(&tc.raw)
+			if have, want := err != nil, tc.wantErr; have != want {
+				t.Fatalf("wrong error. want error=%t, have=%v", want, err)
+			}
+		})
+	}
+}
+
+func TestRolloutWindowsReserve(t *testing.T) {
+	// 2020-09-07 was a Monday.
+	monday := func(hour, min int) time.Time {
+		return time.Date(2020, 9, 7, hour, min, 0, 0, time.UTC)
+	}
+
+	t.Run("no windows", func(t *testing.T) {
+		r := &rolloutWindows{config: func() []*schema.CampaignsRolloutWindow { return nil }}
+		for i := 0; i < 3; i++ {
+			if delay := r.reserve(monday(3, 0)); delay != 0 {
+				t.Fatalf("publication delayed by %s", delay)
+			}
+		}
+	})
+
+	r := &rolloutWindows{config: func() []*schema.CampaignsRolloutWindow {
+		return []*schema.CampaignsRolloutWindow{
+			{Rate: "2/hour", Days: []string{"monday", "tuesday"}, Start: "09:00", End: "17:00"},
+			{Rate: "0/hour", Days: []string{"monday"}, Start: "17:00", End: "18:00"},
+		}
+	}}
+
+	for _, tc := range []struct {
+		name string
+		now  time.Time
+		want time.Duration
+	}{
+		{name: "before window", now: monday(8, 0), want: time.Hour},
+		{name: "first in window", now: monday(9, 0), want: 0},
+		{name: "rate exceeded", now: monday(9, 0), want: 30 * time.Minute},
+		{name: "rate recovered", now: monday(9, 30), want: 0},
+		{name: "paused window", now: monday(17, 15), want: 45 * time.Minute},
+		{name: "after windows", now: monday(18, 0), want: 15 * time.Hour},
+		{name: "not on weekend", now: time.Date(2020, 9, 12, 12, 0, 0, 0, time.UTC), want: 45 * time.Hour},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := r.reserve(tc.now); have != tc.want {
+				t.Fatalf("wrong delay. want=%s, have=%s", tc.want, have)
+			}
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dineshappavoo/basex"
 	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
@@ -16,7 +19,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -31,7 +37,7 @@ func NewService(store *Store, cf *httpcli.Factory) *Service {
 // NewServiceWithClock returns a Service the given clock used
 // to generate timestamps.
 func NewServiceWithClock(store *Store, cf *httpcli.Factory, clock func() time.Time) *Service {
-	svc := &Service{store: store, cf: cf, clock: clock}
+	svc := &Service{store: store, cf: cf, clock: clock, gitserverClient: gitserver.DefaultClient}
 
 	return svc
 }
@@ -40,7 +46,8 @@ type Service struct {
 	store *Store
 	cf    *httpcli.Factory
 
-	sourcer repos.Sourcer
+	sourcer         repos.Sourcer
+	gitserverClient GitserverClient
 
 	clock func() time.Time
 }
@@ -73,6 +80,12 @@ func (s *Service) CreateCampaignSpec(ctx context.Context, opts CreateCampaignSpe
 	if err != nil {
 		return nil, err
 	}
+
+	// Check whether campaigns have been rolled out to the namespace.
+	err = checkNamespaceRollout(ctx, opts.NamespaceUserID, opts.NamespaceOrgID, len(opts.ChangesetSpecRandIDs))
+	if err != nil {
+		return nil, err
+	}
 	spec.NamespaceOrgID = opts.NamespaceOrgID
 	spec.NamespaceUserID = opts.NamespaceUserID
 	spec.UserID = actor.UID
@@ -152,10 +165,15 @@ func (s *Service) CreateChangesetSpec(ctx context.Context, rawSpec string, userI
 
 	// 🚨 SECURITY: We use db.Repos.Get to check whether the user has access to
 	// the repository or not.
-	if _, err = db.Repos.Get(ctx, spec.RepoID); err != nil {
+	repo, err := db.Repos.Get(ctx, spec.RepoID)
+	if err != nil {
 		return nil, err
 	}
 
+	// Only the repository is known for specs that were executed elsewhere, so
+	// references to other variables are left as they are.
+	spec.Spec.RenderTemplateVars(campaigns.ChangesetTemplateVars{RepositoryName: string(repo.Name)})
+
 	return spec, s.store.CreateChangesetSpec(ctx, spec)
 }
 
@@ -175,6 +193,457 @@ func (e *changesetSpecNotFoundErr) Error() string {
 
 func (e *changesetSpecNotFoundErr) NotFound() bool { return true }
 
+// CreateCampaignTemplate validates the given CampaignTemplate and creates it
+// in its namespace.
+func (s *Service) CreateCampaignTemplate(ctx context.Context, template *campaigns.CampaignTemplate) (err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.CreateCampaignTemplate", fmt.Sprintf("Actor %s", actor))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace can create
+	// templates in it.
+	if err := checkNamespaceAccess(ctx, template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return err
+	}
+	template.CreatorID = actor.UID
+
+	return s.store.CreateCampaignTemplate(ctx, template)
+}
+
+// DeleteCampaignTemplate deletes the CampaignTemplate with the given ID.
+func (s *Service) DeleteCampaignTemplate(ctx context.Context, id int64) (err error) {
+	tr, ctx := trace.New(ctx, "Service.DeleteCampaignTemplate", fmt.Sprintf("CampaignTemplate %d", id))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	template, err := s.store.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace can delete
+	// templates in it.
+	if err := checkNamespaceAccess(ctx, template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteCampaignTemplate(ctx, id)
+}
+
+type CreateCampaignSpecFromTemplateOpts struct {
+	CampaignTemplateID int64
+
+	// Parameters are the values substituted for the template's parameters.
+	Parameters map[string]string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	ChangesetSpecRandIDs []string
+}
+
+// CreateCampaignSpecFromTemplate instantiates the CampaignTemplate with the
+// given parameter values and creates a CampaignSpec from the result, as if it
+// was passed to CreateCampaignSpec.
+func (s *Service) CreateCampaignSpecFromTemplate(ctx context.Context, opts CreateCampaignSpecFromTemplateOpts) (spec *campaigns.CampaignSpec, err error) {
+	tr, ctx := trace.New(ctx, "Service.CreateCampaignSpecFromTemplate", fmt.Sprintf("CampaignTemplate %d", opts.CampaignTemplateID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	template, err := s.store.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: opts.CampaignTemplateID})
+	if err != nil {
+		return nil, err
+	}
+
+	rawSpec, err := template.Instantiate(opts.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateCampaignSpec(ctx, CreateCampaignSpecOpts{
+		RawSpec:              rawSpec,
+		NamespaceUserID:      opts.NamespaceUserID,
+		NamespaceOrgID:       opts.NamespaceOrgID,
+		ChangesetSpecRandIDs: opts.ChangesetSpecRandIDs,
+	})
+}
+
+// SetCampaignSpecPinned pins or unpins the CampaignSpec with the given RandID.
+// Pinned CampaignSpecs, and the ChangesetSpecs attached to them, never expire,
+// so that they can go through a review that takes longer than their TTL
+// before being applied.
+func (s *Service) SetCampaignSpecPinned(ctx context.Context, randID string, pinned bool) (spec *campaigns.CampaignSpec, err error) {
+	traceTitle := fmt.Sprintf("campaignSpec: %q, pinned: %t", randID, pinned)
+	tr, ctx := trace.New(ctx, "service.SetCampaignSpecPinned", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	spec, err = s.store.GetCampaignSpec(ctx, GetCampaignSpecOpts{RandID: randID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site-admins or the creator of the campaign spec can
+	// pin it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, spec.UserID); err != nil {
+		return nil, err
+	}
+
+	if spec.Pinned() == pinned {
+		return spec, nil
+	}
+
+	if pinned {
+		spec.PinnedAt = s.clock()
+	} else {
+		spec.PinnedAt = time.Time{}
+	}
+
+	return spec, s.store.UpdateCampaignSpec(ctx, spec)
+}
+
+// ValidateCampaignSpecOpts are the options for ValidateCampaignSpec. The
+// namespace is optional.
+type ValidateCampaignSpecOpts struct {
+	RawSpec string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ValidateCampaignSpec validates the raw campaign spec against the
+// CampaignSpec schema and, if that succeeds, checks that the namespace exists
+// and can be accessed by the current user and that the repositories of the
+// changesets to import exist. It returns all problems found, or nil if the
+// spec is valid. The returned error is only set if the validation itself
+// failed.
+func (s *Service) ValidateCampaignSpec(ctx context.Context, opts ValidateCampaignSpecOpts) (problems []*campaigns.CampaignSpecValidationError, err error) {
+	tr, ctx := trace.New(ctx, "Service.ValidateCampaignSpec", fmt.Sprintf("User %d, Org %d", opts.NamespaceUserID, opts.NamespaceOrgID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	spec, problems := campaigns.ValidateCampaignSpec(opts.RawSpec)
+	if len(problems) > 0 {
+		return problems, nil
+	}
+
+	if opts.NamespaceUserID != 0 || opts.NamespaceOrgID != 0 {
+		problem, err := validateNamespace(ctx, opts.NamespaceUserID, opts.NamespaceOrgID)
+		if err != nil {
+			return nil, err
+		}
+		if problem != nil {
+			problems = append(problems, problem)
+		}
+	}
+
+	for i, importChangeset := range spec.ImportChangesets {
+		// 🚨 SECURITY: db.Repos.GetByName uses the authzFilter under the hood
+		// and doesn't return repositories that the user doesn't have access
+		// to.
+		repo, err := db.Repos.GetByName(ctx, api.RepoName(importChangeset.Repository))
+		var message string
+		if err != nil {
+			if !errcode.IsNotFound(err) {
+				return nil, err
+			}
+			message = fmt.Sprintf("repository %q not found", importChangeset.Repository)
+		} else if err := checkRepoSupported(repo); err != nil {
+			message = err.Error()
+		} else {
+			continue
+		}
+
+		line, column := campaigns.CampaignSpecPosition(opts.RawSpec, []string{"importChangesets", strconv.Itoa(i), "repository"})
+		problems = append(problems, &campaigns.CampaignSpecValidationError{
+			Message: message,
+			Line:    line,
+			Column:  column,
+		})
+	}
+
+	return problems, nil
+}
+
+// maxLintDiffLines is the number of changed lines above which
+// LintCampaignSpec reports the diff of a changeset spec as too large to be
+// reviewed.
+const maxLintDiffLines = 5000
+
+// LintCampaignSpecOpts are the options for LintCampaignSpec. The namespace and
+// the changeset specs are optional.
+type LintCampaignSpecOpts struct {
+	RawSpec string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	ChangesetSpecRandIDs []string
+}
+
+// LintCampaignSpec validates the raw campaign spec like ValidateCampaignSpec
+// and, if it's valid, checks it and the given changeset specs for likely
+// mistakes: steps whose changes are never published, repository queries that
+// match no repositories, changesets that are imported more than once and
+// oversized diffs. It returns all problems found. The returned error is only
+// set if linting itself failed.
+func (s *Service) LintCampaignSpec(ctx context.Context, opts LintCampaignSpecOpts) (problems []*campaigns.CampaignSpecLintProblem, err error) {
+	tr, ctx := trace.New(ctx, "Service.LintCampaignSpec", fmt.Sprintf("User %d, Org %d", opts.NamespaceUserID, opts.NamespaceOrgID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	invalid, err := s.ValidateCampaignSpec(ctx, ValidateCampaignSpecOpts{
+		RawSpec:         opts.RawSpec,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(invalid) > 0 {
+		for _, p := range invalid {
+			problems = append(problems, campaigns.NewInvalidSpecLintProblem(p))
+		}
+		return problems, nil
+	}
+
+	spec, problems := campaigns.LintCampaignSpec(opts.RawSpec)
+	if spec == nil {
+		return problems, nil
+	}
+
+	for i, on := range spec.On {
+		if on.RepositoriesMatchingQuery == "" {
+			continue
+		}
+
+		matches, err := matchesAccessibleRepository(ctx, on.RepositoriesMatchingQuery)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			continue
+		}
+
+		line, column := campaigns.CampaignSpecPosition(opts.RawSpec, []string{"on", strconv.Itoa(i), "repositoriesMatchingQuery"})
+		problems = append(problems, &campaigns.CampaignSpecLintProblem{
+			Rule:     campaigns.CampaignSpecLintRuleQueryMatchesNoRepositories,
+			Severity: campaigns.CampaignSpecLintSeverityWarning,
+			Message:  fmt.Sprintf("query %q doesn't match any repositories", on.RepositoriesMatchingQuery),
+			Line:     line,
+			Column:   column,
+		})
+	}
+
+	if len(opts.ChangesetSpecRandIDs) == 0 {
+		return problems, nil
+	}
+
+	cs, _, err := s.store.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{Limit: -1, RandIDs: opts.ChangesetSpecRandIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	byRandID := make(map[string]*campaigns.ChangesetSpec, len(cs))
+	for _, changesetSpec := range cs {
+		// 🚨 SECURITY: We return an error if the user doesn't have access to
+		// one of the repositories associated with a ChangesetSpec.
+		repo, ok := accessibleReposByID[changesetSpec.RepoID]
+		if !ok {
+			return nil, &db.RepoNotFoundErr{ID: changesetSpec.RepoID}
+		}
+		byRandID[changesetSpec.RandID] = changesetSpec
+
+		lines := changesetSpec.DiffStatAdded + changesetSpec.DiffStatChanged + changesetSpec.DiffStatDeleted
+		if lines > maxLintDiffLines {
+			problems = append(problems, &campaigns.CampaignSpecLintProblem{
+				Rule:     campaigns.CampaignSpecLintRuleOversizedDiff,
+				Severity: campaigns.CampaignSpecLintSeverityWarning,
+				Message:  fmt.Sprintf("the diff for repository %q changes %d lines, which is more than %d and hard to review", repo.Name, lines, maxLintDiffLines),
+			})
+		}
+	}
+
+	for _, randID := range opts.ChangesetSpecRandIDs {
+		if _, ok := byRandID[randID]; !ok {
+			return nil, &changesetSpecNotFoundErr{RandID: randID}
+		}
+	}
+
+	return problems, nil
+}
+
+// matchesAccessibleRepository returns whether the given search query has
+// results in any repository that the current user has access to.
+func matchesAccessibleRepository(ctx context.Context, query string) (bool, error) {
+	names, _, err := searchRepositoryNames(ctx, query)
+	if err != nil {
+		return false, errors.Wrapf(err, "resolving repositories matching %q", query)
+	}
+
+	for _, name := range names {
+		// 🚨 SECURITY: The search runs with internal permissions, so
+		// repositories the user doesn't have access to are filtered out here.
+		_, err := db.Repos.GetByName(ctx, name)
+		if err == nil {
+			return true, nil
+		}
+		if !errcode.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// ExecuteCampaignSpecOpts are the options for ExecuteCampaignSpec.
+type ExecuteCampaignSpecOpts struct {
+	RawSpec string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ExecuteCampaignSpec enqueues a server-side execution of the raw campaign
+// spec on behalf of the current user. The execution runs the steps of the
+// spec and creates a CampaignSpec in the namespace, which can then be
+// applied.
+func (s *Service) ExecuteCampaignSpec(ctx context.Context, opts ExecuteCampaignSpecOpts) (exec *campaigns.CampaignSpecExecution, err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.ExecuteCampaignSpec", fmt.Sprintf("Actor %s", actor))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !executorEnabled() {
+		return nil, ErrExecutorDisabled
+	}
+
+	// The spec is validated now, so that users don't have to wait for the
+	// execution to find out it's invalid.
+	if _, err := campaigns.NewCampaignSpecFromRaw(opts.RawSpec); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace can create
+	// campaign specs in it.
+	if err := checkNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	if err := checkNamespaceRollout(ctx, opts.NamespaceUserID, opts.NamespaceOrgID, 0); err != nil {
+		return nil, err
+	}
+
+	exec = &campaigns.CampaignSpecExecution{
+		RawSpec:         opts.RawSpec,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+		UserID:          actor.UID,
+	}
+	return exec, s.store.CreateCampaignSpecExecution(ctx, exec)
+}
+
+// CampaignSpecRepository is a repository matched by the on property of a
+// campaign spec, together with the revision that the spec's steps would be
+// run on.
+type CampaignSpecRepository struct {
+	Repo *types.Repo
+	// Branch is the name of the branch the steps would be run on.
+	Branch string
+	// Commit is the commit that Branch points to. It's empty if the branch
+	// couldn't be resolved, for example because the repository isn't cloned
+	// yet, in which case Err is set.
+	Commit api.CommitID
+	Err    error
+	// SearchResultCount is the number of results of the
+	// repositoriesMatchingQuery searches of the spec in the repository.
+	SearchResultCount int
+}
+
+// ResolveCampaignSpecRepositories resolves the on property of the given raw
+// campaign spec, without creating or executing it, and returns the
+// repositories and revisions its steps would be run on, in the same way
+// server-side execution resolves them. Only repositories the current user
+// has access to are returned.
+func (s *Service) ResolveCampaignSpecRepositories(ctx context.Context, rawSpec string) (matched []*CampaignSpecRepository, err error) {
+	tr, ctx := trace.New(ctx, "Service.ResolveCampaignSpecRepositories", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	spec, err := campaigns.NewCampaignSpecFromRaw(rawSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := resolveExecutionTargets(ctx, spec.Spec.On)
+	if err != nil {
+		return nil, err
+	}
+
+	matched = make([]*CampaignSpecRepository, 0, len(targets))
+	for _, t := range targets {
+		r := &CampaignSpecRepository{Repo: t.repo, SearchResultCount: t.searchResultCount}
+		ref, rev, err := resolveTargetRevision(ctx, t)
+		if err != nil {
+			r.Branch, r.Err = t.branch, err
+		} else {
+			r.Branch, r.Commit = git.AbbreviateRef(ref), rev
+		}
+		matched = append(matched, r)
+	}
+	return matched, nil
+}
+
+// validateNamespace returns a validation error if the given namespace doesn't
+// exist or can't be accessed by the current user.
+func validateNamespace(ctx context.Context, namespaceUserID, namespaceOrgID int32) (*campaigns.CampaignSpecValidationError, error) {
+	var err error
+	if namespaceOrgID != 0 {
+		_, err = db.Orgs.GetByID(ctx, namespaceOrgID)
+	} else {
+		_, err = db.Users.GetByID(ctx, namespaceUserID)
+	}
+	if err != nil {
+		if !errcode.IsNotFound(err) {
+			return nil, err
+		}
+		return &campaigns.CampaignSpecValidationError{Message: "namespace not found"}, nil
+	}
+
+	if err := checkNamespaceAccess(ctx, namespaceUserID, namespaceOrgID); err != nil {
+		return &campaigns.CampaignSpecValidationError{Message: "no access to namespace: " + err.Error()}, nil
+	}
+
+	return nil, nil
+}
+
 // ErrApplyClosedCampaign is returned by ApplyCampaign when the campaign
 // matched by the campaign spec is already closed.
 var ErrApplyClosedCampaign = errors.New("existing campaign matched by campaign spec is closed")
@@ -190,6 +659,11 @@ type ApplyCampaignOpts struct {
 	// When FailIfCampaignExists is true, ApplyCampaign will fail if a Campaign
 	// matching the given CampaignSpec already exists.
 	FailIfCampaignExists bool
+
+	// When ArchiveSuperseded is true, published changesets that the
+	// CampaignSpec no longer contains are archived in the Campaign instead
+	// of being detached and closed.
+	ArchiveSuperseded bool
 }
 
 func (o ApplyCampaignOpts) String() string {
@@ -214,8 +688,9 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		tr.Finish()
 	}()
 
-	// Setup a defer func that gets executed _after_ the `tx.Done(err)` below.
-	toClose := campaigns.Changesets{}
+	// Setup a defer func that gets executed _after_ the transaction below is
+	// done.
+	var toClose campaigns.Changesets
 	defer func() {
 		if mockApplyCampaignCloseChangesets != nil {
 			mockApplyCampaignCloseChangesets(toClose)
@@ -239,47 +714,127 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		}()
 	}()
 
-	tx, err := s.store.Transact(ctx)
+	// db.Repos doesn't run in the transaction below, which might be retried,
+	// so the repositories are loaded before it.
+	accessibleReposByID, err := s.applyCampaignRepos(ctx, opts.CampaignSpecRandID)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { err = tx.Done(err) }()
-
-	rstore := repos.NewDBStore(tx.DB(), sql.TxOptions{})
 
-	campaignSpec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{
-		RandID: opts.CampaignSpecRandID,
+	// The reconciler might update the same changesets concurrently, in which
+	// case the transaction is retried.
+	var toSync campaigns.Changesets
+	err = s.store.WithTransact(ctx, func(tx *Store) (err error) {
+		campaign, toClose, toSync, err = s.applyCampaign(ctx, tx, opts, accessibleReposByID)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 🚨 SECURITY: Only site-admins or the creator of campaignSpec can apply
-	// campaignSpec.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, campaignSpec.UserID); err != nil {
-		return nil, err
+	// Changesets that the campaign started to track are synced right away,
+	// so that they show up with their state on the code host. Syncing calls
+	// the code host, so it happens only once the transaction is committed. If
+	// it fails, the changesets are synced by the syncer later on.
+	if len(toSync) > 0 {
+		rstore := repos.NewDBStore(s.store.DB(), sql.TxOptions{})
+		if err := SyncChangesets(ctx, rstore, s.store, s.cf, toSync...); err != nil {
+			log15.Error("Syncing changesets tracked by ApplyCampaign", "campaign", campaign.ID, "err", err)
+		}
 	}
 
-	campaign, err = s.GetCampaignMatchingCampaignSpec(ctx, tx, campaignSpec)
+	return campaign, nil
+}
+
+// applyCampaignRepos returns the repositories of the changeset specs of the
+// campaign spec with the given rand ID and of the changesets of the campaign
+// it's applied to that the current user has access to.
+func (s *Service) applyCampaignRepos(ctx context.Context, campaignSpecRandID string) (map[api.RepoID]*types.Repo, error) {
+	campaignSpec, err := s.store.GetCampaignSpec(ctx, GetCampaignSpecOpts{RandID: campaignSpecRandID})
 	if err != nil {
 		return nil, err
 	}
+
+	specs, _, err := s.store.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{
+		Limit:          -1,
+		CampaignSpecID: campaignSpec.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	repoIDs := specs.RepoIDs()
+
+	campaign, err := s.GetCampaignMatchingCampaignSpec(ctx, s.store, campaignSpec)
+	if err != nil {
+		return nil, err
+	}
+	if campaign != nil {
+		changesets, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{
+			Limit:      -1,
+			CampaignID: campaign.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		repoIDs = append(repoIDs, changesets.RepoIDs()...)
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	return db.Repos.GetReposSetByIDs(ctx, repoIDs...)
+}
+
+// applyCampaign is ApplyCampaign inside of the transaction tx, with the
+// repositories returned by applyCampaignRepos. It only touches tx, since the
+// transaction might be retried. Besides the campaign, it returns the
+// changesets that need to be closed on the code host and the changesets it
+// started to track, which need to be synced.
+func (s *Service) applyCampaign(ctx context.Context, tx *Store, opts ApplyCampaignOpts, accessibleReposByID map[api.RepoID]*types.Repo) (campaign *campaigns.Campaign, toClose, toSync campaigns.Changesets, err error) {
+	var archived campaigns.Changesets
+
+	campaignSpec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{
+		RandID: opts.CampaignSpecRandID,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 🚨 SECURITY: Only site-admins or the creator of campaignSpec can apply
+	// campaignSpec.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, campaignSpec.UserID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The rollout configuration might have changed since the campaignSpec was
+	// created, so we check it again.
+	changesetSpecCount, err := tx.CountChangesetSpecs(ctx, CountChangesetSpecsOpts{CampaignSpecID: campaignSpec.ID})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkNamespaceRollout(ctx, campaignSpec.NamespaceUserID, campaignSpec.NamespaceOrgID, changesetSpecCount); err != nil {
+		return nil, nil, nil, err
+	}
+
+	campaign, err = s.GetCampaignMatchingCampaignSpec(ctx, tx, campaignSpec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	if campaign == nil {
 		campaign = &campaigns.Campaign{}
 	} else if opts.FailIfCampaignExists {
-		return nil, ErrMatchingCampaignExists
+		return nil, nil, nil, ErrMatchingCampaignExists
 	}
 
 	if opts.EnsureCampaignID != 0 && campaign.ID != opts.EnsureCampaignID {
-		return nil, ErrEnsureCampaignFailed
+		return nil, nil, nil, ErrEnsureCampaignFailed
 	}
 
 	if campaign.Closed() {
-		return nil, ErrApplyClosedCampaign
+		return nil, nil, nil, ErrApplyClosedCampaign
 	}
 
 	if campaign.CampaignSpecID == campaignSpec.ID {
-		return campaign, nil
+		return campaign, nil, nil, nil
 	}
 
 	campaign.CampaignSpecID = campaignSpec.ID
@@ -297,9 +852,13 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 	campaign.Description = campaignSpec.Spec.Description
 
 	if campaign.ID == 0 {
+		if err := checkOpenCampaignsQuota(ctx, tx, campaign.NamespaceUserID, campaign.NamespaceOrgID); err != nil {
+			return nil, nil, nil, err
+		}
+
 		err := tx.CreateCampaign(ctx, campaign)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 	}
 
@@ -313,7 +872,7 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		CampaignSpecID: campaign.CampaignSpecID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// Load all Changesets attached to this Campaign.
@@ -322,17 +881,7 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		CampaignID: campaign.ID,
 	})
 	if err != nil {
-		return nil, err
-	}
-
-	// We load all the repositories involved, checking for repository permissions
-	// under the hood.
-	repoIDs := append(newChangesetSpecs.RepoIDs(), changesets.RepoIDs()...)
-	// 🚨 SECURITY: db.Repos.GetRepoIDsSet uses the authzFilter under the hood and
-	// filters out repositories that the user doesn't have access to.
-	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, repoIDs...)
-	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// Now we have two lists:
@@ -384,57 +933,9 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 	// Spec 4 should be attached to Changeset 4, since it tracks PR #333 in Repo C.
 	// Changeset 3 doesn't have a matching spec and should be detached from the campaign (and closed).
 
-	type repoHeadRef struct {
-		repo    api.RepoID
-		headRef string
-	}
-	changesetsByRepoHeadRef := map[repoHeadRef]*campaigns.Changeset{}
-
-	type repoExternalID struct {
-		repo       api.RepoID
-		externalID string
-	}
-	changesetsByRepoExternalID := map[repoExternalID]*campaigns.Changeset{}
-
-	currentSpecsByChangeset := map[int64]*campaigns.ChangesetSpec{}
-
-	for _, c := range changesets {
-		// This is an n+1
-		s, err := tx.GetChangesetSpecByID(ctx, c.CurrentSpecID)
-		if err != nil {
-			return nil, err
-		}
-		currentSpecsByChangeset[c.ID] = s
-
-		if c.ExternalID != "" {
-			k := repoExternalID{repo: c.RepoID, externalID: c.ExternalID}
-			changesetsByRepoExternalID[k] = c
-
-			// If it has an externalID but no CurrentSpecID, it is a tracked
-			// changeset, and we're done and don't need to match it by HeadRef
-			if c.CurrentSpecID == 0 {
-				continue
-			}
-		}
-
-		k := repoHeadRef{repo: c.RepoID}
-		if c.ExternalBranch != "" {
-			k.headRef = git.EnsureRefPrefix(c.ExternalBranch)
-			changesetsByRepoHeadRef[k] = c
-			continue
-		}
-
-		// If we don't have an ExternalBranch, the changeset hasn't been
-		// published yet (or hasn't been synced yet).
-		if c.CurrentSpecID != 0 {
-			// If we're here, the changeset doesn't have an external branch
-			//
-			// So we load the spec to get the branch where we _would_ push
-			// the commit.
-
-			k.headRef = git.EnsureRefPrefix(s.Spec.HeadRef)
-			changesetsByRepoHeadRef[k] = c
-		}
+	index, err := indexCampaignChangesets(ctx, tx, changesets)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	attachedChangesets := map[int64]bool{}
@@ -445,18 +946,18 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		// would require a new spec.
 		repo, ok := accessibleReposByID[spec.RepoID]
 		if !ok {
-			return nil, &db.RepoNotFoundErr{ID: spec.RepoID}
+			return nil, nil, nil, &db.RepoNotFoundErr{ID: spec.RepoID}
 		}
 
 		if err := checkRepoSupported(repo); err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 		// If we need to track a changeset, we need to find it.
 		if spec.Spec.IsImportingExisting() {
 			k := repoExternalID{repo: spec.RepoID, externalID: spec.Spec.ExternalID}
 
-			c, ok := changesetsByRepoExternalID[k]
+			c, ok := index.byRepoExternalID[k]
 			if ok {
 				// If we have the changeset, it's already attached to the campaign
 				// but we need to keep track of all changesets in campaign
@@ -469,7 +970,7 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 					ExternalServiceType: repo.ExternalRepo.ServiceType,
 				})
 				if err != nil && err != ErrNoResults {
-					return nil, err
+					return nil, nil, nil, err
 				}
 				if existing != nil {
 					// We already have a changeset with the given repoID and
@@ -477,7 +978,7 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 					existing.AddedToCampaign = true
 					existing.CampaignIDs = append(existing.CampaignIDs, campaign.ID)
 					if err = tx.UpdateChangeset(ctx, existing); err != nil {
-						return nil, err
+						return nil, nil, nil, err
 					}
 					attachedChangesets[existing.ID] = true
 				} else {
@@ -495,16 +996,9 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 					}
 
 					if err = tx.CreateChangeset(ctx, newChangeset); err != nil {
-						return nil, err
-					}
-
-					// TODO: Now we're syncing in the request path to ensure
-					// that the remote changeset exists and also to remove the possibility
-					// of an unsynced changeset entering our database
-					// IMPORTANT: We need to move that to the reconciler/syncer/background.
-					if err = SyncChangesets(ctx, rstore, tx, s.cf, newChangeset); err != nil {
-						return nil, errors.Wrapf(err, "syncing changeset failed. repo=%q, externalID=%q", repo.Name, k.externalID)
+						return nil, nil, nil, err
 					}
+					toSync = append(toSync, newChangeset)
 
 					attachedChangesets[newChangeset.ID] = true
 				}
@@ -524,7 +1018,7 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		// So, let's check:
 		// Do we already have a changeset on this branch in this repo?
 		k := repoHeadRef{repo: spec.RepoID, headRef: git.EnsureRefPrefix(spec.Spec.HeadRef)}
-		c, ok := changesetsByRepoHeadRef[k]
+		c, ok := index.byRepoHeadRef[k]
 		if !ok {
 			// No, we don't have a changeset on that branch in this repo.
 			// We're going to create one so the changeset reconciler picks it up,
@@ -541,9 +1035,10 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 				PublicationState: campaigns.ChangesetPublicationStateUnpublished,
 				ReconcilerState:  campaigns.ReconcilerStateQueued,
 			}
+			newChangeset.SetCustomMetadata(spec.Spec.CustomMetadata)
 
 			if err = tx.CreateChangeset(ctx, newChangeset); err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 			attachedChangesets[newChangeset.ID] = true
 		} else {
@@ -556,6 +1051,11 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 			// And we need to update it to have the new spec
 			c.PreviousSpecID = c.CurrentSpecID
 			c.CurrentSpecID = spec.ID
+			c.SetCustomMetadata(spec.Spec.CustomMetadata)
+
+			// If it was archived because a previous spec no longer
+			// contained it, it's back in the campaign now.
+			c.ArchivedAt = time.Time{}
 
 			// And we need to enqueue it for the changeset reconciler, so the
 			// reconciler wakes up, compares old and new spec and, if
@@ -563,7 +1063,7 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 			c.ReconcilerState = campaigns.ReconcilerStateQueued
 
 			if err = tx.UpdateChangeset(ctx, c); err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 		}
 	}
@@ -593,6 +1093,22 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		if c.CurrentSpecID != 0 && c.OwnedByCampaignID == campaign.ID {
 			// If we have a current spec ID and the changeset was created by
 			// _this_ campaign that means we should detach and close it.
+			//
+			// Unless it was archived by a previous apply, or it should be
+			// archived now: then it stays in the campaign and is left open.
+			if c.Archived() {
+				campaign.ChangesetIDs = append(campaign.ChangesetIDs, c.ID)
+				continue
+			}
+			if opts.ArchiveSuperseded && c.PublicationState.Published() {
+				c.ArchivedAt = s.clock()
+				if err = tx.UpdateChangeset(ctx, c); err != nil {
+					return nil, nil, nil, err
+				}
+				campaign.ChangesetIDs = append(campaign.ChangesetIDs, c.ID)
+				archived = append(archived, c)
+				continue
+			}
 
 			// But only if it was created on the code host:
 			if c.PublicationState.Published() {
@@ -600,7 +1116,7 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 			} else {
 				// otherwise we simply delete it.
 				if err = tx.DeleteChangeset(ctx, c.ID); err != nil {
-					return nil, err
+					return nil, nil, nil, err
 				}
 				continue
 			}
@@ -608,11 +1124,122 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 
 		c.RemoveCampaignID(campaign.ID)
 		if err = tx.UpdateChangeset(ctx, c); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if err := tx.UpdateCampaign(ctx, campaign); err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.UID,
+		Kind:       campaigns.CampaignActivityKindApplied,
+		Metadata:   map[string]interface{}{"campaign_spec_id": campaignSpec.ID},
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := enqueueCampaignWebhookEvent(ctx, tx, campaigns.CampaignWebhookEventCampaignApplied, campaign); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(toClose) > 0 {
+		err = tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+			CampaignID: campaign.ID,
+			UserID:     actor.UID,
+			Kind:       campaigns.CampaignActivityKindChangesetsClosed,
+			Metadata:   map[string]interface{}{"changeset_ids": toClose.IDs()},
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if len(archived) > 0 {
+		err = tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+			CampaignID: campaign.ID,
+			UserID:     actor.UID,
+			Kind:       campaigns.CampaignActivityKindChangesetsArchived,
+			Metadata:   map[string]interface{}{"changeset_ids": archived.IDs()},
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return campaign, toClose, toSync, nil
+}
+
+type repoHeadRef struct {
+	repo    api.RepoID
+	headRef string
+}
+
+type repoExternalID struct {
+	repo       api.RepoID
+	externalID string
+}
+
+// campaignChangesetsIndex indexes the Changesets of a Campaign so that the
+// ChangesetSpecs of a new CampaignSpec can be matched with them.
+type campaignChangesetsIndex struct {
+	// byRepoExternalID contains the published changesets, which are matched
+	// with the specs that import existing changesets.
+	byRepoExternalID map[repoExternalID]*campaigns.Changeset
+	// byRepoHeadRef contains the changesets created by a campaign, keyed by
+	// the branch they have been, or will be, pushed to.
+	byRepoHeadRef map[repoHeadRef]*campaigns.Changeset
+	// currentSpecs contains the current specs of the changesets in
+	// byRepoHeadRef.
+	currentSpecs map[int64]*campaigns.ChangesetSpec
+}
+
+func indexCampaignChangesets(ctx context.Context, tx *Store, changesets campaigns.Changesets) (*campaignChangesetsIndex, error) {
+	index := &campaignChangesetsIndex{
+		byRepoExternalID: map[repoExternalID]*campaigns.Changeset{},
+		byRepoHeadRef:    map[repoHeadRef]*campaigns.Changeset{},
+		currentSpecs:     map[int64]*campaigns.ChangesetSpec{},
+	}
+
+	for _, c := range changesets {
+		if c.ExternalID != "" {
+			k := repoExternalID{repo: c.RepoID, externalID: c.ExternalID}
+			index.byRepoExternalID[k] = c
+
+			// If it has an externalID but no CurrentSpecID, it is a tracked
+			// changeset, and we're done and don't need to match it by HeadRef
+			if c.CurrentSpecID == 0 {
+				continue
+			}
+		}
+
+		if c.CurrentSpecID == 0 {
+			continue
+		}
+
+		// This is an n+1
+		s, err := tx.GetChangesetSpecByID(ctx, c.CurrentSpecID)
+		if err != nil {
 			return nil, err
 		}
+		index.currentSpecs[c.ID] = s
+
+		k := repoHeadRef{repo: c.RepoID}
+		if c.ExternalBranch != "" {
+			k.headRef = git.EnsureRefPrefix(c.ExternalBranch)
+		} else {
+			// If we don't have an ExternalBranch, the changeset hasn't been
+			// published yet (or hasn't been synced yet), so we use the branch
+			// where we _would_ push the commit.
+			k.headRef = git.EnsureRefPrefix(s.Spec.HeadRef)
+		}
+		index.byRepoHeadRef[k] = c
 	}
 
-	return campaign, tx.UpdateCampaign(ctx, campaign)
+	return index, nil
 }
 
 // GetCampaignMatchingCampaignSpec returns the Campaign that the CampaignSpec
@@ -675,8 +1302,13 @@ func (s *Service) MoveCampaign(ctx context.Context, opts MoveCampaignOpts) (camp
 		return nil, err
 	}
 
-	// 🚨 SECURITY: Only the Author of the campaign can move it.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, campaign.InitialApplierID); err != nil {
+	// 🚨 SECURITY: Only campaign admins can move the campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Moving a campaign out of a namespace requires access to
+	// it, so that authors who left an org can't take its campaigns along.
+	if err := checkNamespaceAccess(ctx, campaign.NamespaceUserID, campaign.NamespaceOrgID); err != nil {
 		return nil, err
 	}
 	// Check if current user has access to target namespace if set.
@@ -685,6 +1317,22 @@ func (s *Service) MoveCampaign(ctx context.Context, opts MoveCampaignOpts) (camp
 		if err != nil {
 			return nil, err
 		}
+
+		// Check whether campaigns have been rolled out to the target namespace.
+		err = checkNamespaceRollout(ctx, opts.NewNamespaceUserID, opts.NewNamespaceOrgID, len(campaign.ChangesetIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		// An open campaign counts against the open campaigns quota of the
+		// target namespace, unless it's already in it.
+		sameNamespace := opts.NewNamespaceOrgID == campaign.NamespaceOrgID && opts.NewNamespaceUserID == campaign.NamespaceUserID
+		if !campaign.Closed() && !sameNamespace {
+			err = checkOpenCampaignsQuota(ctx, tx, opts.NewNamespaceUserID, opts.NewNamespaceOrgID)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if opts.NewNamespaceOrgID != 0 {
@@ -699,125 +1347,1145 @@ func (s *Service) MoveCampaign(ctx context.Context, opts MoveCampaignOpts) (camp
 		campaign.Name = opts.NewName
 	}
 
+	// Campaign specs are matched to campaigns by namespace and name, so
+	// they must stay unique.
+	existing, err := tx.GetCampaign(ctx, GetCampaignOpts{
+		Name:            campaign.Name,
+		NamespaceUserID: campaign.NamespaceUserID,
+		NamespaceOrgID:  campaign.NamespaceOrgID,
+	})
+	if err != nil && err != ErrNoResults {
+		return nil, err
+	}
+	if existing != nil && existing.ID != campaign.ID {
+		return nil, ErrMoveCampaignNameTaken
+	}
+
 	return campaign, tx.UpdateCampaign(ctx, campaign)
 }
 
-// ErrEnsureCampaignFailed is returned by ApplyCampaign when a ensureCampaignID
-// is provided but a campaign with the name specified the campaignSpec exists
-// in the given namespace but has a different ID.
-var ErrEnsureCampaignFailed = errors.New("a campaign in the given namespace and with the given name exists but does not match the given ID")
-
-// ErrCloseProcessingCampaign is returned by CloseCampaign if the Campaign has
-// been published at the time of closing but its Changesets are still being
-// processed by the reconciler.
-var ErrCloseProcessingCampaign = errors.New("cannot close a campaign while changesets are being processed")
+// ErrMoveCampaignNameTaken is returned by MoveCampaign if the target
+// namespace already contains a campaign with the target name.
+var ErrMoveCampaignNameTaken = errors.New("a campaign with the given name already exists in the target namespace")
 
-// CloseCampaign closes the Campaign with the given ID if it has not been closed yet.
-func (s *Service) CloseCampaign(ctx context.Context, id int64, closeChangesets, closeAsync bool) (campaign *campaigns.Campaign, err error) {
-	traceTitle := fmt.Sprintf("campaign: %d, closeChangesets: %t", id, closeChangesets)
-	tr, ctx := trace.New(ctx, "service.CloseCampaign", traceTitle)
+// GetNamespaceQuota returns the limits that the `campaigns.namespaces` site
+// configuration imposes on the given namespace, and its current usage.
+func (s *Service) GetNamespaceQuota(ctx context.Context, namespaceUserID, namespaceOrgID int32) (quota *NamespaceQuota, err error) {
+	tr, ctx := trace.New(ctx, "Service.GetNamespaceQuota", fmt.Sprintf("user: %d, org: %d", namespaceUserID, namespaceOrgID))
 	defer func() {
 		tr.SetError(err)
 		tr.Finish()
 	}()
 
-	transaction := func() (err error) {
-		tx, err := s.store.Transact(ctx)
-		if err != nil {
-			return err
-		}
-		defer func() { err = tx.Done(err) }()
-
-		campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
-		if err != nil {
-			return errors.Wrap(err, "getting campaign")
-		}
-
-		if campaign.Closed() {
-			return nil
-		}
-
-		if err := backend.CheckSiteAdminOrSameUser(ctx, campaign.InitialApplierID); err != nil {
-			return err
-		}
-
-		if closeChangesets {
-			processingState := campaigns.ReconcilerStateProcessing
-			countOpts := CountChangesetsOpts{
-				CampaignID:      campaign.ID,
-				ReconcilerState: &processingState,
-			}
-			processingCount, err := tx.CountChangesets(ctx, countOpts)
-			if err != nil {
-				return errors.Wrap(err, "checking for processing changesets")
-			}
-			if processingCount != 0 {
-				err = ErrCloseProcessingCampaign
-				return err
-			}
-		}
-
-		campaign.ClosedAt = time.Now().UTC()
-
-		return tx.UpdateCampaign(ctx, campaign)
+	// 🚨 SECURITY: Only site admins and users with access to the namespace
+	// can see its quota.
+	if err := checkNamespaceAccess(ctx, namespaceUserID, namespaceOrgID); err != nil {
+		return nil, err
 	}
 
-	err = transaction()
+	open, err := s.store.CountCampaigns(ctx, CountCampaignsOpts{
+		State:           campaigns.CampaignStateOpen,
+		NamespaceUserID: namespaceUserID,
+		NamespaceOrgID:  namespaceOrgID,
+	})
 	if err != nil {
 		return nil, err
 	}
+	quota = &NamespaceQuota{Enabled: true, OpenCampaigns: open}
 
-	if closeChangesets {
-		closer := func() {
-			ctx := trace.ContextWithTrace(context.Background(), tr)
-
-			open := campaigns.ChangesetExternalStateOpen
-			cs, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{
-				CampaignID:    campaign.ID,
-				ExternalState: &open,
-				Limit:         -1,
-			})
-			if err != nil {
-				log15.Error("ListChangesets", "err", err)
-				return
-			}
+	cfg := conf.Get().CampaignsNamespaces
+	if cfg == nil {
+		return quota, nil
+	}
 
-			// Close only the changesets that are open
-			err = s.CloseOpenChangesets(ctx, cs)
-			if err != nil {
-				log15.Error("CloseCampaignChangesets", "err", err)
-			}
-		}
-		if closeAsync {
-			go closer()
-		} else {
-			closer()
+	ns, err := loadCampaignNamespace(ctx, namespaceUserID, namespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := namespaceLimitsConfig(cfg, ns)
+	if err != nil {
+		if _, ok := err.(*namespaceNotEnabledErr); !ok {
+			return nil, err
 		}
+		quota.Enabled = false
 	}
+	quota.MaxChangesets = limits.maxChangesets
+	quota.MaxOpenCampaigns = limits.maxOpenCampaigns
 
-	return campaign, nil
+	return quota, nil
 }
 
-// DeleteCampaign deletes the Campaign with the given ID if it hasn't been
-// deleted yet.
-func (s *Service) DeleteCampaign(ctx context.Context, id int64) (err error) {
-	traceTitle := fmt.Sprintf("campaign: %d", id)
-	tr, ctx := trace.New(ctx, "service.DeleteCampaign", traceTitle)
+// SetCampaignAutoMerge enables or disables the automatic merging of the
+// changesets of the Campaign with the given ID.
+func (s *Service) SetCampaignAutoMerge(ctx context.Context, id int64, enabled bool) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, enabled: %t", id, enabled)
+	tr, ctx := trace.New(ctx, "service.SetCampaignAutoMerge", traceTitle)
 	defer func() {
 		tr.SetError(err)
 		tr.Finish()
 	}()
 
-	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	tx, err := s.store.Transact(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can enable auto-merge.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	if campaign.AutoMerge == enabled {
+		return campaign, nil
+	}
+
+	campaign, err = tx.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, AutoMerge: &enabled})
+	if err != nil {
+		return nil, err
+	}
+
+	kind := campaigns.CampaignActivityKindAutoMergeDisabled
+	if enabled {
+		kind = campaigns.CampaignActivityKindAutoMergeEnabled
+	}
+
+	return campaign, tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       kind,
+	})
+}
+
+// SetCampaignAutoRebase enables or disables the automatic rebasing of the
+// changesets of the Campaign with the given ID when their base branch
+// advances.
+func (s *Service) SetCampaignAutoRebase(ctx context.Context, id int64, enabled bool) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, enabled: %t", id, enabled)
+	tr, ctx := trace.New(ctx, "service.SetCampaignAutoRebase", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can enable auto-rebase.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	if campaign.AutoRebase == enabled {
+		return campaign, nil
+	}
+
+	campaign, err = tx.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, AutoRebase: &enabled})
+	if err != nil {
+		return nil, err
+	}
+
+	kind := campaigns.CampaignActivityKindAutoRebaseDisabled
+	if enabled {
+		kind = campaigns.CampaignActivityKindAutoRebaseEnabled
+	}
+
+	return campaign, tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       kind,
+	})
+}
+
+// PauseCampaign pauses the Campaign with the given ID. While it's paused,
+// the reconciler doesn't publish, update or close its changesets and the
+// syncer doesn't sync them.
+func (s *Service) PauseCampaign(ctx context.Context, id int64) (*campaigns.Campaign, error) {
+	return s.setCampaignPaused(ctx, id, true)
+}
+
+// ResumeCampaign resumes the paused Campaign with the given ID and makes the
+// changesets that waited for it ready to be processed again.
+func (s *Service) ResumeCampaign(ctx context.Context, id int64) (*campaigns.Campaign, error) {
+	return s.setCampaignPaused(ctx, id, false)
+}
+
+func (s *Service) setCampaignPaused(ctx context.Context, id int64, paused bool) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, paused: %t", id, paused)
+	tr, ctx := trace.New(ctx, "service.setCampaignPaused", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can pause and resume a campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	if campaign.Paused() == paused {
+		return campaign, nil
+	}
+
+	kind := campaigns.CampaignActivityKindResumed
+	var pausedAt time.Time
+	if paused {
+		pausedAt = s.clock()
+		kind = campaigns.CampaignActivityKindPaused
+	} else {
+		if err := tx.ResumePausedChangesets(ctx, campaign.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	campaign, err = tx.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, PausedAt: &pausedAt})
+	if err != nil {
+		return nil, err
+	}
+
+	return campaign, tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       kind,
+	})
+}
+
+// ErrImportClosedCampaign is returned by ImportChangesets if the campaign has
+// been closed.
+var ErrImportClosedCampaign = errors.New("cannot import changesets into a closed campaign")
+
+// ImportChangesets resolves the given pull and merge request URLs to
+// changesets on the code hosts and adds them to the Campaign with the given
+// ID. Changesets that aren't tracked yet are created and synced. It returns
+// the imported changesets.
+//
+// Note that, just like changesets attached to the campaign by other means,
+// the imported changesets are detached by the next ApplyCampaign if its
+// campaign spec doesn't import them.
+func (s *Service) ImportChangesets(ctx context.Context, campaignID int64, externalURLs []string) (imported campaigns.Changesets, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, urls: %d", campaignID, len(externalURLs))
+	tr, ctx := trace.New(ctx, "service.ImportChangesets", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can add changesets to a campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	if campaign.Closed() {
+		return nil, ErrImportClosedCampaign
+	}
+
+	rstore := repos.NewDBStore(tx.DB(), sql.TxOptions{})
+	es, err := rstore.ListExternalServices(ctx, repos.StoreListExternalServicesArgs{Kinds: changesetURLKinds})
+	if err != nil {
+		return nil, err
+	}
+
+	attached := make(map[int64]bool, len(campaign.ChangesetIDs))
+	for _, id := range campaign.ChangesetIDs {
+		attached[id] = true
+	}
+
+	for _, externalURL := range externalURLs {
+		name, externalID, err := parseChangesetURL(es, externalURL)
+		if err != nil {
+			return nil, err
+		}
+
+		// 🚨 SECURITY: db.Repos.GetByName uses the authzFilter under the hood
+		// and returns an error if the user doesn't have access to the repo.
+		repo, err := db.Repos.GetByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkRepoSupported(repo); err != nil {
+			return nil, err
+		}
+
+		c, err := tx.GetChangeset(ctx, GetChangesetOpts{
+			RepoID:              repo.ID,
+			ExternalID:          externalID,
+			ExternalServiceType: repo.ExternalRepo.ServiceType,
+		})
+		if err != nil && err != ErrNoResults {
+			return nil, err
+		}
+
+		if c == nil {
+			c = &campaigns.Changeset{
+				RepoID:              repo.ID,
+				ExternalServiceType: repo.ExternalRepo.ServiceType,
+
+				CampaignIDs:     []int64{campaign.ID},
+				ExternalID:      externalID,
+				AddedToCampaign: true,
+
+				PublicationState: campaigns.ChangesetPublicationStatePublished,
+				ReconcilerState:  campaigns.ReconcilerStateCompleted,
+			}
+
+			if err = tx.CreateChangeset(ctx, c); err != nil {
+				return nil, err
+			}
+
+			// Just like in ApplyCampaign, we sync in the request path to
+			// ensure that the changeset exists on the code host.
+			if err = SyncChangesets(ctx, rstore, tx, s.cf, c); err != nil {
+				return nil, errors.Wrapf(err, "syncing changeset failed. repo=%q, externalID=%q", repo.Name, externalID)
+			}
+		} else if !attached[c.ID] {
+			c.AddedToCampaign = true
+			c.CampaignIDs = append(c.CampaignIDs, campaign.ID)
+			if err = tx.UpdateChangeset(ctx, c); err != nil {
+				return nil, err
+			}
+		}
+
+		if !attached[c.ID] {
+			attached[c.ID] = true
+			campaign.ChangesetIDs = append(campaign.ChangesetIDs, c.ID)
+		}
+		imported = append(imported, c)
+	}
+
+	if err := tx.UpdateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	return imported, tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       campaigns.CampaignActivityKindChangesetsImported,
+		Metadata:   map[string]interface{}{"changeset_ids": imported.IDs()},
+	})
+}
+
+// changesetNotAttachedErr is returned by DetachChangesets if a Changeset
+// with the given ID isn't attached to the campaign or its repository isn't
+// accessible to the user.
+// It fulfills the interface required by errcode.IsNotFound.
+type changesetNotAttachedErr struct {
+	ID int64
+}
+
+func (e *changesetNotAttachedErr) Error() string {
+	return fmt.Sprintf("changeset not attached to campaign: id=%d", e.ID)
+}
+
+func (e *changesetNotAttachedErr) NotFound() bool { return true }
+
+// DetachChangesets removes the Changesets with the given IDs from the
+// Campaign with the given ID without closing them on the code host. The
+// changesets are no longer updated by the campaign: changesets it created are
+// handed over as if they had been imported, and changesets that aren't
+// attached to any other campaign are no longer tracked. Changesets that
+// haven't been published yet are deleted.
+//
+// Note that a changeset created by the campaign is created again by the next
+// ApplyCampaign if its campaign spec still contains a changeset spec for it.
+func (s *Service) DetachChangesets(ctx context.Context, campaignID int64, changesetIDs []int64) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, changesets: %d", campaignID, len(changesetIDs))
+	tr, ctx := trace.New(ctx, "service.DetachChangesets", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can remove changesets from a campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	if len(changesetIDs) == 0 {
+		return campaign, nil
+	}
+
+	cs, _, err := tx.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID: campaign.ID,
+		IDs:        changesetIDs,
+		Limit:      -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the hood
+	// and filters out repositories that the user doesn't have access to.
+	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*campaigns.Changeset, len(cs))
+	for _, c := range cs {
+		if _, ok := accessibleReposByID[c.RepoID]; ok {
+			byID[c.ID] = c
+		}
+	}
+
+	var detached campaigns.Changesets
+	for _, id := range changesetIDs {
+		c, ok := byID[id]
+		if !ok {
+			return nil, &changesetNotAttachedErr{ID: id}
+		}
+		delete(byID, id)
+
+		c.RemoveCampaignID(campaign.ID)
+		campaign.RemoveChangesetID(c.ID)
+		detached = append(detached, c)
+
+		if c.OwnedByCampaignID == campaign.ID {
+			if !c.PublicationState.Published() {
+				if err := tx.DeleteChangeset(ctx, c.ID); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			// The changeset is now tracked like an imported one, so that
+			// the reconciler no longer updates it.
+			c.OwnedByCampaignID = 0
+			c.CurrentSpecID = 0
+			c.PreviousSpecID = 0
+			c.ReconcilerState = campaigns.ReconcilerStateCompleted
+		}
+
+		if len(c.CampaignIDs) == 0 {
+			if err := tx.DeleteChangeset(ctx, c.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := tx.UpdateChangeset(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.UpdateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       campaigns.CampaignActivityKindChangesetsDetached,
+		Metadata:   map[string]interface{}{"changeset_ids": detached.IDs()},
+	})
+}
+
+// CheckChangesetURLs checks right away whether the external URLs of the
+// published changesets of the given campaign still resolve on the code host,
+// and records the results on the changesets.
+func (s *Service) CheckChangesetURLs(ctx context.Context, campaignID int64) (campaign *campaigns.Campaign, err error) {
+	tr, ctx := trace.New(ctx, "service.CheckChangesetURLs", fmt.Sprintf("campaign: %d", campaignID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaign, err = s.store.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can check the URLs, since it makes
+	// requests to the code hosts.
+	if err := CheckCampaignAdminRights(ctx, s.store, campaign); err != nil {
+		return nil, err
+	}
+
+	cs, err := s.store.ListChangesetsToCheckURL(ctx, ListChangesetsToCheckURLOpts{CampaignID: campaign.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	c := &urlChecker{store: s.store, cf: s.cf}
+	if err := c.check(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// ErrTriggerClosedCampaign is returned by TriggerCampaign if the campaign has
+// been closed.
+var ErrTriggerClosedCampaign = errors.New("cannot trigger a closed campaign")
+
+// ErrEmailDisabled is returned by TriggerCampaign if a campaign is to be
+// triggered with CampaignTriggerActionNotify, but email isn't configured.
+var ErrEmailDisabled = errors.New("sending emails is not configured")
+
+// TriggerCampaignOpts are the options for TriggerCampaign.
+type TriggerCampaignOpts struct {
+	CampaignID int64
+	Action     campaigns.CampaignTriggerAction
+
+	// Source names what fired the trigger, e.g. the description of a code
+	// monitor. It's required.
+	Source string
+	// Message optionally describes why the trigger fired, e.g. the new
+	// search results of a code monitor.
+	Message string
+}
+
+// TriggerCampaign is the integration point for external triggers, such as
+// code monitors, that fire when new occurrences of what a campaign fixes
+// appear. Depending on the action, it enqueues the re-evaluation of the
+// current campaign spec of the campaign, which creates changesets for the new
+// occurrences, or emails the author and the subscribers of the campaign.
+// Re-evaluations are enqueued at most once at a time per campaign, so that
+// triggers that fire repeatedly don't pile up. Every trigger is recorded in
+// the activity log of the campaign.
+func (s *Service) TriggerCampaign(ctx context.Context, opts TriggerCampaignOpts) (err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, action: %s", opts.CampaignID, opts.Action)
+	tr, ctx := trace.New(ctx, "service.TriggerCampaign", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !opts.Action.Valid() {
+		return errors.Errorf("invalid campaign trigger action %q", opts.Action)
+	}
+	if strings.TrimSpace(opts.Source) == "" {
+		return errors.New("the source of a campaign trigger must not be empty")
+	}
+
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: opts.CampaignID})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can trigger a campaign.
+	if err := CheckCampaignAdminRights(ctx, s.store, campaign); err != nil {
+		return err
+	}
+
+	if campaign.Closed() {
+		return ErrTriggerClosedCampaign
+	}
+
+	switch opts.Action {
+	case campaigns.CampaignTriggerActionReapply:
+		if !executorEnabled() {
+			return ErrExecutorDisabled
+		}
+
+		job := &campaigns.CampaignReapplyJob{
+			CampaignID:     campaign.ID,
+			CampaignSpecID: campaign.CampaignSpecID,
+		}
+		if err := s.store.EnqueueCampaignReapplyJob(ctx, job); err != nil {
+			return errors.Wrap(err, "enqueueing reapply job")
+		}
+
+	case campaigns.CampaignTriggerActionNotify:
+		if !canSendNotificationEmails() {
+			return ErrEmailDisabled
+		}
+
+		settings, err := s.store.GetCampaignNotificationSettings(ctx, campaign.ID)
+		if err != nil {
+			if err != ErrNoResults {
+				return err
+			}
+			settings = campaigns.DefaultCampaignNotificationSettings(campaign.ID)
+		}
+
+		url, err := campaignExternalURL(ctx, campaign)
+		if err != nil {
+			return err
+		}
+
+		data := struct {
+			CampaignName string
+			CampaignURL  string
+			Source       string
+			Message      string
+		}{
+			CampaignName: campaign.Name,
+			CampaignURL:  url,
+			Source:       opts.Source,
+			Message:      opts.Message,
+		}
+		if err := sendCampaignEmail(ctx, s.store, campaign, settings, triggeredEmailTemplate, data); err != nil {
+			return err
+		}
+	}
+
+	return s.store.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       campaigns.CampaignActivityKindTriggered,
+		Metadata: map[string]interface{}{
+			"action":  opts.Action,
+			"source":  opts.Source,
+			"message": opts.Message,
+		},
+	})
+}
+
+// ErrReapplyClosedCampaign is returned by SetCampaignReapplySchedule if the
+// campaign has been closed.
+var ErrReapplyClosedCampaign = errors.New("cannot schedule re-applying a closed campaign")
+
+// SetCampaignReapplySchedule sets the cron schedule on which the Campaign
+// with the given ID is re-applied. If schedule is nil or blank, the existing
+// schedule is removed. It returns the resulting schedule, which is nil if
+// it was removed.
+func (s *Service) SetCampaignReapplySchedule(ctx context.Context, id int64, schedule *string) (rs *campaigns.CampaignReapplySchedule, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d", id)
+	tr, ctx := trace.New(ctx, "service.SetCampaignReapplySchedule", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can schedule re-applying the
+	// campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	if schedule == nil || strings.TrimSpace(*schedule) == "" {
+		return nil, tx.DeleteCampaignReapplySchedule(ctx, campaign.ID)
+	}
+
+	if !campaign.ClosedAt.IsZero() {
+		return nil, ErrReapplyClosedCampaign
+	}
+
+	cron, err := parseCronSchedule(*schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err = tx.GetCampaignReapplySchedule(ctx, campaign.ID)
+	if err != nil && err != ErrNoResults {
+		return nil, err
+	}
+	if rs == nil {
+		rs = &campaigns.CampaignReapplySchedule{CampaignID: campaign.ID}
+	}
+
+	rs.Schedule = strings.TrimSpace(*schedule)
+	rs.NextRunAt = cron.next(s.clock())
+	return rs, tx.UpsertCampaignReapplySchedule(ctx, rs)
+}
+
+// ErrInvalidCampaignVisibility is returned by SetCampaignVisibility if the
+// given visibility is unknown.
+var ErrInvalidCampaignVisibility = errors.New("invalid campaign visibility")
+
+// SetCampaignVisibility sets the visibility of the Campaign with the given ID.
+func (s *Service) SetCampaignVisibility(ctx context.Context, id int64, visibility campaigns.CampaignVisibility) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, visibility: %s", id, visibility)
+	tr, ctx := trace.New(ctx, "service.SetCampaignVisibility", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !visibility.Valid() {
+		return nil, ErrInvalidCampaignVisibility
+	}
+
+	campaign, err = s.store.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can change the visibility.
+	if err := CheckCampaignAdminRights(ctx, s.store, campaign); err != nil {
+		return nil, err
+	}
+
+	if campaign.Visibility == visibility {
+		return campaign, nil
+	}
+
+	return s.store.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, Visibility: &visibility})
+}
+
+// ErrInvalidCampaignUpdatePropagation is returned by
+// SetCampaignUpdatePropagation if the given mode is unknown.
+var ErrInvalidCampaignUpdatePropagation = errors.New("invalid campaign update propagation")
+
+// SetCampaignUpdatePropagation sets how re-applying the Campaign with the
+// given ID changes the title and body of published changesets that were
+// edited on the code host.
+func (s *Service) SetCampaignUpdatePropagation(ctx context.Context, id int64, propagation campaigns.CampaignUpdatePropagation) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, propagation: %s", id, propagation)
+	tr, ctx := trace.New(ctx, "service.SetCampaignUpdatePropagation", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !propagation.Valid() {
+		return nil, ErrInvalidCampaignUpdatePropagation
+	}
+
+	campaign, err = s.store.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can change how updates propagate.
+	if err := CheckCampaignAdminRights(ctx, s.store, campaign); err != nil {
+		return nil, err
+	}
+
+	if campaign.UpdatePropagation == propagation {
+		return campaign, nil
+	}
+
+	return s.store.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, UpdatePropagation: &propagation})
+}
+
+// CampaignVisible returns whether the current user in the ctx can see the
+// given Campaign. Public campaigns are visible to everyone, namespace-only
+// campaigns only to site admins, their creator, the users that have access
+// to their namespace and the users that have been granted rights on them.
+// The grants are read from s, so callers in a transaction should pass it.
+func CampaignVisible(ctx context.Context, s *Store, c *campaigns.Campaign) (bool, error) {
+	if c.Visibility != campaigns.CampaignVisibilityNamespaceOnly {
+		return true, nil
+	}
+
+	err := backend.CheckSiteAdminOrSameUser(ctx, c.InitialApplierID)
+	if err == nil {
+		return true, nil
+	}
+	if _, ok := err.(*backend.InsufficientAuthorizationError); !ok {
+		return false, err
+	}
+
+	switch err := checkNamespaceAccess(ctx, c.NamespaceUserID, c.NamespaceOrgID); err.(type) {
+	case nil:
+		return true, nil
+	case *backend.InsufficientAuthorizationError:
+	default:
+		if err != backend.ErrNotAnOrgMember && err != backend.ErrNotAuthenticated {
+			return false, err
+		}
+	}
+
+	return hasCampaignPermissionGrant(ctx, s, c, campaigns.CampaignPermissionLevelView)
+}
+
+// ErrEmptyCampaignComment is returned by CreateCampaignComment if the body of
+// the comment is blank.
+var ErrEmptyCampaignComment = errors.New("campaign comment must not be blank")
+
+// CreateCampaignComment creates a comment with the given body on the Campaign
+// with the given ID, authored by the current user.
+func (s *Service) CreateCampaignComment(ctx context.Context, id int64, body string) (comment *campaigns.CampaignComment, err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.CreateCampaignComment", fmt.Sprintf("Actor %s, campaign: %d", actor, id))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !actor.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return nil, ErrEmptyCampaignComment
+	}
+
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Everyone who can see a campaign can comment on it, but
+	// campaigns that aren't visible to the current user are treated as if
+	// they didn't exist.
+	visible, err := CampaignVisible(ctx, s.store, campaign)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrNoResults
+	}
+
+	comment = &campaigns.CampaignComment{
+		CampaignID: campaign.ID,
+		AuthorID:   actor.UID,
+		Body:       body,
+	}
+	return comment, s.store.CreateCampaignComment(ctx, comment)
+}
+
+// ErrInvalidCampaignNotificationEvent is returned by
+// SetCampaignNotificationSettings if one of the given events is unknown.
+var ErrInvalidCampaignNotificationEvent = errors.New("invalid campaign notification event")
+
+// SetCampaignNotificationSettings sets which events of the Campaign with the
+// given ID are emailed to its author and to the users with the given
+// subscriberIDs.
+func (s *Service) SetCampaignNotificationSettings(ctx context.Context, id int64, events []campaigns.CampaignNotificationEvent, subscriberIDs []int32) (settings *campaigns.CampaignNotificationSettings, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, events: %v", id, events)
+	tr, ctx := trace.New(ctx, "service.SetCampaignNotificationSettings", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can change who is notified about
+	// the campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	settings, err = tx.GetCampaignNotificationSettings(ctx, campaign.ID)
+	if err != nil && err != ErrNoResults {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &campaigns.CampaignNotificationSettings{CampaignID: campaign.ID}
+	}
+
+	settings.Events = settings.Events[:0]
+	seenEvents := make(map[campaigns.CampaignNotificationEvent]bool, len(events))
+	for _, e := range events {
+		if !e.Valid() {
+			return nil, ErrInvalidCampaignNotificationEvent
+		}
+		if !seenEvents[e] {
+			seenEvents[e] = true
+			settings.Events = append(settings.Events, e)
+		}
+	}
+
+	settings.SubscriberIDs = settings.SubscriberIDs[:0]
+	seenSubscribers := make(map[int32]bool, len(subscriberIDs))
+	for _, userID := range subscriberIDs {
+		if seenSubscribers[userID] {
+			continue
+		}
+		seenSubscribers[userID] = true
+
+		if _, err := db.Users.GetByID(ctx, userID); err != nil {
+			return nil, err
+		}
+		settings.SubscriberIDs = append(settings.SubscriberIDs, userID)
+	}
+
+	return settings, tx.UpsertCampaignNotificationSettings(ctx, settings)
+}
+
+// ErrEnsureCampaignFailed is returned by ApplyCampaign when a ensureCampaignID
+// is provided but a campaign with the name specified the campaignSpec exists
+// in the given namespace but has a different ID.
+var ErrEnsureCampaignFailed = errors.New("a campaign in the given namespace and with the given name exists but does not match the given ID")
+
+// ErrCloseProcessingCampaign is returned by CloseCampaign if the Campaign has
+// been published at the time of closing but its Changesets are still being
+// processed by the reconciler.
+var ErrCloseProcessingCampaign = errors.New("cannot close a campaign while changesets are being processed")
+
+// ErrClosePausedCampaignChangesets is returned by CloseCampaign if it's asked
+// to close the changesets of a paused Campaign.
+var ErrClosePausedCampaignChangesets = errors.New("cannot close the changesets of a paused campaign")
+
+// CloseCampaign closes the Campaign with the given ID if it has not been closed yet.
+func (s *Service) CloseCampaign(ctx context.Context, id int64, closeChangesets, closeAsync bool) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, closeChangesets: %t", id, closeChangesets)
+	tr, ctx := trace.New(ctx, "service.CloseCampaign", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	transaction := func() (err error) {
+		tx, err := s.store.Transact(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { err = tx.Done(err) }()
+
+		campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+		if err != nil {
+			return errors.Wrap(err, "getting campaign")
+		}
+
+		if campaign.Closed() {
+			return nil
+		}
+
+		if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+			return err
+		}
+
+		if closeChangesets && campaign.Paused() {
+			return ErrClosePausedCampaignChangesets
+		}
+
+		if closeChangesets {
+			processingState := campaigns.ReconcilerStateProcessing
+			countOpts := CountChangesetsOpts{
+				CampaignID:      campaign.ID,
+				ReconcilerState: &processingState,
+			}
+			processingCount, err := tx.CountChangesets(ctx, countOpts)
+			if err != nil {
+				return errors.Wrap(err, "checking for processing changesets")
+			}
+			if processingCount != 0 {
+				err = ErrCloseProcessingCampaign
+				return err
+			}
+		}
+
+		closedAt := time.Now().UTC()
+		campaign, err = tx.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, ClosedAt: &closedAt})
+		if err != nil {
+			return err
+		}
+
+		err = tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+			CampaignID: campaign.ID,
+			UserID:     actor.FromContext(ctx).UID,
+			Kind:       campaigns.CampaignActivityKindClosed,
+			Metadata:   map[string]interface{}{"close_changesets": closeChangesets},
+		})
+		if err != nil {
+			return err
+		}
+
+		return enqueueCampaignWebhookEvent(ctx, tx, campaigns.CampaignWebhookEventCampaignClosed, campaign)
+	}
+
+	err = transaction()
+	if err != nil {
+		return nil, err
+	}
+
+	if closeChangesets {
+		closer := func() {
+			ctx := trace.ContextWithTrace(context.Background(), tr)
+
+			open := campaigns.ChangesetExternalStateOpen
+			cs, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{
+				CampaignID:    campaign.ID,
+				ExternalState: &open,
+				Limit:         -1,
+			})
+			if err != nil {
+				log15.Error("ListChangesets", "err", err)
+				return
+			}
+
+			// Close only the changesets that are open
+			err = s.CloseOpenChangesets(ctx, cs)
+			if err != nil {
+				log15.Error("CloseCampaignChangesets", "err", err)
+			}
+		}
+		if closeAsync {
+			go closer()
+		} else {
+			closer()
+		}
+	}
+
+	return campaign, nil
+}
+
+// DeleteCampaign marks the Campaign with the given ID as deleted if it hasn't
+// been deleted yet. Deleted campaigns can be restored with RestoreCampaign
+// until campaigns.CampaignDeletionRetention has passed. Their changesets are
+// left untouched but no longer synced.
+func (s *Service) DeleteCampaign(ctx context.Context, id int64) (err error) {
+	traceTitle := fmt.Sprintf("campaign: %d", id)
+	tr, ctx := trace.New(ctx, "service.DeleteCampaign", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can delete a campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return err
+	}
+
+	deletedAt := tx.now()
+	if _, err := tx.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, DeletedAt: &deletedAt}); err != nil {
+		return err
+	}
+
+	return tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       campaigns.CampaignActivityKindDeleted,
+	})
+}
+
+// ErrCampaignNotDeleted is returned by RestoreCampaign if the campaign hasn't
+// been deleted.
+var ErrCampaignNotDeleted = errors.New("campaign has not been deleted")
+
+// ErrCampaignRestoreExpired is returned by RestoreCampaign if the campaign
+// was deleted longer than campaigns.CampaignDeletionRetention ago.
+var ErrCampaignRestoreExpired = errors.New("campaign was deleted too long ago to be restored")
+
+// ErrRestoreCampaignNameTaken is returned by RestoreCampaign if a campaign
+// with the same name has been created in the namespace since the campaign was
+// deleted.
+var ErrRestoreCampaignNameTaken = errors.New("a campaign with the same name has been created in the namespace since the campaign was deleted")
+
+// RestoreCampaign restores the deleted Campaign with the given ID.
+func (s *Service) RestoreCampaign(ctx context.Context, id int64) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d", id)
+	tr, ctx := trace.New(ctx, "service.RestoreCampaign", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err = tx.GetCampaign(ctx, GetCampaignOpts{ID: id, IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can restore a campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	if !campaign.Deleted() {
+		return nil, ErrCampaignNotDeleted
+	}
+	if tx.now().After(campaign.RestorableUntil()) {
+		return nil, ErrCampaignRestoreExpired
+	}
+
+	// Campaign specs are matched to campaigns by namespace and name, so
+	// they must stay unique.
+	existing, err := tx.GetCampaign(ctx, GetCampaignOpts{
+		Name:            campaign.Name,
+		NamespaceUserID: campaign.NamespaceUserID,
+		NamespaceOrgID:  campaign.NamespaceOrgID,
+	})
+	if err != nil && err != ErrNoResults {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrRestoreCampaignNameTaken
+	}
+
+	if !campaign.Closed() {
+		if err := checkOpenCampaignsQuota(ctx, tx, campaign.NamespaceUserID, campaign.NamespaceOrgID); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := backend.CheckSiteAdminOrSameUser(ctx, campaign.InitialApplierID); err != nil {
-		return err
+	campaign, err = tx.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: campaign.ID, DeletedAt: &time.Time{}})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.store.DeleteCampaign(ctx, id)
+	return campaign, tx.CreateCampaignActivity(ctx, &campaigns.CampaignActivity{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Kind:       campaigns.CampaignActivityKindRestored,
+	})
 }
 
 // CloseOpenChangesets closes the given Changesets on their respective codehosts and syncs them.
@@ -886,42 +2554,507 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 		return err
 	}
 
+	// 🚨 SECURITY: Only users with admin rights for one of the changeset's
+	// campaigns may enqueue a sync.
+	if err := s.checkChangesetAdminRights(ctx, changeset); err != nil {
+		return err
+	}
+
+	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{id}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// burstSyncMaxChangesets is the maximum number of changesets that
+// EnqueueCampaignChangesetSyncs enqueues for a single campaign, so that
+// viewing a huge campaign doesn't crowd out the syncs of all other campaigns.
+const burstSyncMaxChangesets = 100
+
+// EnqueueCampaignChangesetSyncs enqueues a high-priority sync of the open,
+// published changesets of the given campaign, so that the campaign page
+// reflects their state on the code host within seconds instead of after the
+// next regular sync. It doesn't check permissions, since it only triggers
+// syncs and doesn't return or change anything.
+func (s *Service) EnqueueCampaignChangesetSyncs(ctx context.Context, campaignID int64) (err error) {
+	traceTitle := fmt.Sprintf("campaign: %d", campaignID)
+	tr, ctx := trace.New(ctx, "service.EnqueueCampaignChangesetSyncs", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	published := campaigns.ChangesetPublicationStatePublished
+	open := campaigns.ChangesetExternalStateOpen
+	cs, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID:       campaignID,
+		PublicationState: &published,
+		ExternalState:    &open,
+		WithoutDeleted:   true,
+		Limit:            burstSyncMaxChangesets,
+	})
+	if err != nil {
+		return err
+	}
+	if len(cs) == 0 {
+		return nil
+	}
+
+	return repoupdater.DefaultClient.EnqueueChangesetSync(ctx, cs.IDs())
+}
+
+// RecordCampaignView records that the current user viewed the Campaign with
+// the given ID, so that its changesets are synced more often. When the view
+// starts a new viewing session, the open changesets are also synced right
+// away, so the campaign page catches up with the code host within seconds.
+func (s *Service) RecordCampaignView(ctx context.Context, id int64) (err error) {
+	traceTitle := fmt.Sprintf("campaign: %d", id)
+	tr, ctx := trace.New(ctx, "service.RecordCampaignView", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Campaigns that aren't visible to the current user are
+	// treated as if they didn't exist.
+	visible, err := CampaignVisible(ctx, s.store, campaign)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrNoResults
+	}
+
+	recorded, err := s.store.RecordCampaignView(ctx, campaign.ID)
+	if err != nil || !recorded {
+		return err
+	}
+	return s.EnqueueCampaignChangesetSyncs(ctx, campaign.ID)
+}
+
+// ErrChangesetNotSyncable is returned by SyncChangeset if the changeset
+// hasn't been published to the code host yet.
+var ErrChangesetNotSyncable = errors.New("only published changesets can be synced")
+
+// SyncChangeset loads the given changeset from the database, checks whether
+// the actor in the context has permission to sync it and then syncs it with
+// the code host right away, instead of enqueueing a sync like
+// EnqueueChangesetSync does. It returns the refreshed changeset.
+func (s *Service) SyncChangeset(ctx context.Context, id int64) (changeset *campaigns.Changeset, err error) {
+	traceTitle := fmt.Sprintf("changeset: %d", id)
+	tr, ctx := trace.New(ctx, "service.SyncChangeset", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changeset, err = s.store.GetChangeset(ctx, GetChangesetOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users with admin rights for one of the changeset's
+	// campaigns may sync it.
+	if err := s.checkChangesetAdminRights(ctx, changeset); err != nil {
+		return nil, err
+	}
+
+	if changeset.PublicationState.Unpublished() {
+		return nil, ErrChangesetNotSyncable
+	}
+
+	reposStore := repos.NewDBStore(s.store.DB(), sql.TxOptions{})
+	if err := SyncChangesets(ctx, reposStore, s.store, s.cf, changeset); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetChangeset(ctx, GetChangesetOpts{ID: id})
+}
+
+// ErrChangesetNotRebasable is returned by RebaseChangeset if the changeset
+// isn't an open changeset that was published from a changeset spec.
+var ErrChangesetNotRebasable = errors.New("only open changesets published by a campaign can be rebased")
+
+// RebaseChangeset re-applies the diff of the changeset's current
+// ChangesetSpec onto the current head of its base branch and force-pushes
+// the result to the changeset's branch. If the diff doesn't apply cleanly on
+// the new base, the branch is left untouched and an error is returned. The
+// rebase is recorded as a changeset event.
+func (s *Service) RebaseChangeset(ctx context.Context, id int64) (changeset *campaigns.Changeset, err error) {
+	traceTitle := fmt.Sprintf("changeset: %d", id)
+	tr, ctx := trace.New(ctx, "service.RebaseChangeset", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changeset, err = s.store.GetChangeset(ctx, GetChangesetOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users with admin rights for one of the changeset's
+	// campaigns may rebase it.
+	if err := s.checkChangesetAdminRights(ctx, changeset); err != nil {
+		return nil, err
+	}
+
+	if !changeset.PublicationState.Published() || changeset.ExternalState != campaigns.ChangesetExternalStateOpen || changeset.CurrentSpecID == 0 {
+		return nil, ErrChangesetNotRebasable
+	}
+
+	spec, err := s.store.GetChangesetSpecByID(ctx, changeset.CurrentSpecID)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := db.Repos.Get(ctx, changeset.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseRev, err := backend.Repos.ResolveRev(ctx, repo, spec.Spec.BaseRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving base branch %q", spec.Spec.BaseRef)
+	}
+
+	opts, err := buildCommitOpts(repo.Name, spec)
+	if err != nil {
+		return nil, err
+	}
+	opts.BaseCommit = baseRev
+
+	fromBaseRev, err := changesetBaseRev(ctx, s.store, changeset, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := pushCommit(ctx, s.gitserverClient, opts); err != nil {
+		return nil, err
+	}
+
+	err = recordChangesetRebase(ctx, s.store, changeset, &campaigns.ChangesetRebase{
+		ChangesetSpecID: spec.ID,
+		FromBaseRev:     fromBaseRev,
+		ToBaseRev:       string(baseRev),
+		UserID:          actor.FromContext(ctx).UID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync the changeset so that its diff and mergeability reflect the
+	// rebased branch.
+	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{id}); err != nil {
+		log15.Warn("Failed to enqueue sync of rebased changeset", "changeset", id, "err", err)
+	}
+
+	return changeset, nil
+}
+
+// ErrChangesetNotRetryable is returned by RetryChangeset if the reconciler
+// didn't give up on the changeset.
+var ErrChangesetNotRetryable = errors.New("only errored changesets can be retried")
+
+// RetryChangeset enqueues a changeset that the reconciler failed to process
+// and gave up on, so that the reconciler processes it again right away.
+func (s *Service) RetryChangeset(ctx context.Context, id int64) (changeset *campaigns.Changeset, err error) {
+	traceTitle := fmt.Sprintf("changeset: %d", id)
+	tr, ctx := trace.New(ctx, "service.RetryChangeset", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	changeset, err = tx.GetChangeset(ctx, GetChangesetOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users with admin rights for one of the changeset's
+	// campaigns may retry it.
+	if err := s.checkChangesetAdminRights(ctx, changeset); err != nil {
+		return nil, err
+	}
+
+	if changeset.ReconcilerState != campaigns.ReconcilerStateErrored {
+		return nil, ErrChangesetNotRetryable
+	}
+
+	requeueErroredChangeset(changeset)
+
+	return changeset, tx.UpdateChangeset(ctx, changeset)
+}
+
+// requeueErroredChangeset puts a changeset that the reconciler gave up on back
+// into the reconciler's queue, so that it's processed again right away.
+func requeueErroredChangeset(c *campaigns.Changeset) {
+	c.ReconcilerState = campaigns.ReconcilerStateQueued
+	c.NumResets = 0
+	c.NumFailures = 0
+	c.ProcessAfter = time.Time{}
+	c.WaitReason = ""
+}
+
+// ErrInvalidChangesetJobType is returned by CreateBulkOperation if the type
+// of the operation is unknown.
+var ErrInvalidChangesetJobType = errors.New("invalid bulk operation type")
+
+// ErrNoBulkOperationChangesets is returned by CreateBulkOperation if no
+// changesets are given.
+var ErrNoBulkOperationChangesets = errors.New("no changesets given for the bulk operation")
+
+// ErrEmptyChangesetComment is returned by CreateBulkOperation if the message
+// of a comment operation is blank.
+var ErrEmptyChangesetComment = errors.New("changeset comment must not be blank")
+
+// CreateBulkOperationOpts are the options for CreateBulkOperation.
+type CreateBulkOperationOpts struct {
+	CampaignID   int64
+	ChangesetIDs []int64
+	Type         campaigns.ChangesetJobType
+	Payload      campaigns.ChangesetJobPayload
+}
+
+// CreateBulkOperation enqueues a ChangesetJob of the given type for every
+// given changeset of the campaign, which are processed in the background by
+// the bulk operation worker. It returns the BulkOperation that groups the
+// jobs, which can be polled for their progress.
+func (s *Service) CreateBulkOperation(ctx context.Context, opts CreateBulkOperationOpts) (op *campaigns.BulkOperation, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, type: %s, changesets: %d", opts.CampaignID, opts.Type, len(opts.ChangesetIDs))
+	tr, ctx := trace.New(ctx, "service.CreateBulkOperation", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	if !opts.Type.Valid() {
+		return nil, ErrInvalidChangesetJobType
+	}
+	if len(opts.ChangesetIDs) == 0 {
+		return nil, ErrNoBulkOperationChangesets
+	}
+	if opts.Type == campaigns.ChangesetJobTypeComment && strings.TrimSpace(opts.Payload.Message) == "" {
+		return nil, ErrEmptyChangesetComment
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: opts.CampaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only campaign admins can run operations on the changesets
+	// of a campaign.
+	if err := CheckCampaignAdminRights(ctx, tx, campaign); err != nil {
+		return nil, err
+	}
+
+	cs, _, err := tx.ListChangesets(ctx, ListChangesetsOpts{CampaignID: campaign.ID, IDs: opts.ChangesetIDs, Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*campaigns.Changeset, len(cs))
+	for _, c := range cs {
+		if _, ok := accessibleReposByID[c.RepoID]; ok {
+			byID[c.ID] = c
+		}
+	}
+
+	bulkGroup, err := basex.Encode(strconv.Itoa(seededRand.Int()))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating bulk group ID failed")
+	}
+
+	jobs := make([]*campaigns.ChangesetJob, 0, len(opts.ChangesetIDs))
+	seen := make(map[int64]bool, len(opts.ChangesetIDs))
+	for _, id := range opts.ChangesetIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		// Changesets that don't belong to the campaign or whose repository
+		// isn't accessible are treated as if they didn't exist.
+		if _, ok := byID[id]; !ok {
+			return nil, errors.Errorf("changeset %d not found in campaign %d", id, campaign.ID)
+		}
+
+		jobs = append(jobs, &campaigns.ChangesetJob{
+			BulkGroup:   bulkGroup,
+			UserID:      a.UID,
+			CampaignID:  campaign.ID,
+			ChangesetID: id,
+			JobType:     opts.Type,
+			Payload:     opts.Payload,
+		})
+	}
+
+	if err := tx.CreateChangesetJobs(ctx, jobs...); err != nil {
+		return nil, err
+	}
+
+	return tx.GetBulkOperation(ctx, GetBulkOperationOpts{ID: bulkGroup})
+}
+
+// SetChangesetCustomMetadata sets the custom metadata of the given changeset
+// under the given key to value. If value is nil, the key is removed.
+func (s *Service) SetChangesetCustomMetadata(ctx context.Context, id int64, key string, value *string) (changeset *campaigns.Changeset, err error) {
+	traceTitle := fmt.Sprintf("changeset: %d, key: %q", id, key)
+	tr, ctx := trace.New(ctx, "service.SetChangesetCustomMetadata", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if key == "" {
+		return nil, ErrCustomMetadataKeyBlank
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	changeset, err = tx.GetChangeset(ctx, GetChangesetOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users with admin rights for one of the changeset's
+	// campaigns may change its metadata.
+	if err := s.checkChangesetAdminRights(ctx, changeset); err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		changeset.RemoveCustomMetadata(key)
+	} else {
+		changeset.SetCustomMetadata(map[string]string{key: *value})
+	}
+
+	return changeset, tx.UpdateChangeset(ctx, changeset)
+}
+
+// ErrCustomMetadataKeyBlank is returned by SetChangesetCustomMetadata if the
+// given key is blank.
+var ErrCustomMetadataKeyBlank = errors.New("custom metadata key cannot be blank")
+
+// checkChangesetAdminRights checks whether the actor in the context has
+// access to the changeset's repository and admin rights for at least one of
+// the campaigns the changeset belongs to.
+func (s *Service) checkChangesetAdminRights(ctx context.Context, changeset *campaigns.Changeset) error {
 	// 🚨 SECURITY: We use db.Repos.Get to check whether the user has access to
 	// the repository or not.
-	if _, err = db.Repos.Get(ctx, changeset.RepoID); err != nil {
+	if _, err := db.Repos.Get(ctx, changeset.RepoID); err != nil {
 		return err
 	}
 
-	campaigns, _, err := s.store.ListCampaigns(ctx, ListCampaignsOpts{ChangesetID: id})
+	cs, _, err := s.store.ListCampaigns(ctx, ListCampaignsOpts{ChangesetID: changeset.ID})
 	if err != nil {
 		return err
 	}
 
+	// Site admins have admin rights for changesets that don't belong to any
+	// campaign.
+	if len(cs) == 0 {
+		return backend.CheckCurrentUserIsSiteAdmin(ctx)
+	}
+
 	// Check whether the user has admin rights for one of the campaigns.
-	var (
-		authErr        error
-		hasAdminRights bool
-	)
+	var authErr error
+	for _, c := range cs {
+		err := CheckCampaignAdminRights(ctx, s.store, c)
+		if err == nil {
+			return nil
+		}
+		authErr = err
+	}
 
-	for _, c := range campaigns {
-		err := backend.CheckSiteAdminOrSameUser(ctx, c.InitialApplierID)
-		if err != nil {
-			authErr = err
-		} else {
-			hasAdminRights = true
-			break
+	return authErr
+}
+
+// orgMembersCanAdminister returns whether the members of an org have admin
+// rights for all campaigns in the org's namespace. It's a variable so that it
+// can be mocked in tests.
+var orgMembersCanAdminister = func() bool {
+	return conf.Get().CampaignsOrgMembersCanAdminister
+}
+
+// hasCampaignPermissionGrant returns whether the actor in the context has
+// been granted at least the given level of rights on the given campaign,
+// according to the grants in s. It's a variable so that it can be mocked in
+// tests.
+var hasCampaignPermissionGrant = func(ctx context.Context, s *Store, c *campaigns.Campaign, level campaigns.CampaignPermissionLevel) (bool, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || c.ID == 0 {
+		return false, nil
+	}
+	return s.HasCampaignPermissionGrant(ctx, c.ID, a.UID, level)
+}
+
+// CheckCampaignAdminRights checks whether the actor in the context has admin
+// rights for the given campaign. Site admins and the author of the campaign
+// always have them. If the campaign is in an org namespace and the
+// campaigns.orgMembersCanAdminister site configuration is enabled, all
+// members of the org have them too. So do the users that have been granted
+// admin rights on the campaign, directly or through one of their orgs, which
+// are read from s.
+func CheckCampaignAdminRights(ctx context.Context, s *Store, c *campaigns.Campaign) error {
+	if c.NamespaceOrgID != 0 && orgMembersCanAdminister() {
+		err := backend.CheckOrgAccess(ctx, c.NamespaceOrgID)
+		if err == nil {
+			return nil
+		}
+		if err != backend.ErrNotAnOrgMember && err != backend.ErrNotAuthenticated {
+			return err
 		}
 	}
 
-	if !hasAdminRights {
+	authErr := backend.CheckSiteAdminOrSameUser(ctx, c.InitialApplierID)
+	if _, ok := authErr.(*backend.InsufficientAuthorizationError); !ok {
 		return authErr
 	}
 
-	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{id}); err != nil {
+	granted, err := hasCampaignPermissionGrant(ctx, s, c, campaigns.CampaignPermissionLevelAdmin)
+	if err != nil {
 		return err
 	}
-
-	return nil
+	if granted {
+		return nil
+	}
+	return authErr
 }
 
 // ErrCampaignNameBlank is returned by CreateCampaign or UpdateCampaign if the
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,15 +19,19 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -154,6 +159,44 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("SyncChangeset", func(t *testing.T) {
+				// The cases that don't result in auth errors will fall through
+				// to sync the changeset with the code host, so we need to
+				// ensure we mock that call to avoid unexpected network calls.
+				MockSyncChangesets = func(_ context.Context, _ RepoStore, _ SyncStore, _ *httpcli.Factory, _ ...*campaigns.Changeset) error {
+					return nil
+				}
+				t.Cleanup(func() { MockSyncChangesets = nil })
+
+				_, err := svc.SyncChangeset(currentUserCtx, changeset.ID)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("RebaseChangeset", func(t *testing.T) {
+				_, err := svc.RebaseChangeset(currentUserCtx, changeset.ID)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("SetChangesetCustomMetadata", func(t *testing.T) {
+				value := "ENG-1234"
+				_, err := svc.SetChangesetCustomMetadata(currentUserCtx, changeset.ID, "ticket", &value)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("RetryChangeset", func(t *testing.T) {
+				_, err := svc.RetryChangeset(currentUserCtx, changeset.ID)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("GrantCampaignPermission", func(t *testing.T) {
+				_, err := svc.GrantCampaignPermission(currentUserCtx, GrantCampaignPermissionOpts{
+					CampaignID: campaign.ID,
+					UserID:     otherUser.ID,
+					Level:      campaigns.CampaignPermissionLevelView,
+				})
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("CloseCampaign", func(t *testing.T) {
 				_, err := svc.CloseCampaign(currentUserCtx, campaign.ID, false, false)
 				tc.assertFunc(t, err)
@@ -164,6 +207,11 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("RestoreCampaign", func(t *testing.T) {
+				_, err := svc.RestoreCampaign(currentUserCtx, campaign.ID)
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("MoveCampaign", func(t *testing.T) {
 				_, err := svc.MoveCampaign(currentUserCtx, MoveCampaignOpts{
 					CampaignID: campaign.ID,
@@ -172,12 +220,68 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("SetCampaignAutoMerge", func(t *testing.T) {
+				_, err := svc.SetCampaignAutoMerge(currentUserCtx, campaign.ID, true)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("SetCampaignAutoRebase", func(t *testing.T) {
+				_, err := svc.SetCampaignAutoRebase(currentUserCtx, campaign.ID, true)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("PauseCampaign", func(t *testing.T) {
+				_, err := svc.PauseCampaign(currentUserCtx, campaign.ID)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("ResumeCampaign", func(t *testing.T) {
+				_, err := svc.ResumeCampaign(currentUserCtx, campaign.ID)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("ImportChangesets", func(t *testing.T) {
+				_, err := svc.ImportChangesets(currentUserCtx, campaign.ID, nil)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("DetachChangesets", func(t *testing.T) {
+				_, err := svc.DetachChangesets(currentUserCtx, campaign.ID, nil)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("SetCampaignReapplySchedule", func(t *testing.T) {
+				schedule := "@daily"
+				_, err := svc.SetCampaignReapplySchedule(currentUserCtx, campaign.ID, &schedule)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("SetCampaignVisibility", func(t *testing.T) {
+				_, err := svc.SetCampaignVisibility(currentUserCtx, campaign.ID, campaigns.CampaignVisibilityNamespaceOnly)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("SetCampaignUpdatePropagation", func(t *testing.T) {
+				_, err := svc.SetCampaignUpdatePropagation(currentUserCtx, campaign.ID, campaigns.CampaignUpdatePropagationPreserve)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("SetCampaignNotificationSettings", func(t *testing.T) {
+				_, err := svc.SetCampaignNotificationSettings(currentUserCtx, campaign.ID, nil, nil)
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("ApplyCampaign", func(t *testing.T) {
 				_, err := svc.ApplyCampaign(currentUserCtx, ApplyCampaignOpts{
 					CampaignSpecRandID: campaignSpec.RandID,
 				})
 				tc.assertFunc(t, err)
 			})
+
+			t.Run("SetCampaignSpecPinned", func(t *testing.T) {
+				_, err := svc.SetCampaignSpecPinned(currentUserCtx, campaignSpec.RandID, true)
+				tc.assertFunc(t, err)
+			})
 		})
 	}
 }
@@ -222,184 +326,1076 @@ func TestService(t *testing.T) {
 		if err != nil && err != ErrNoResults {
 			t.Fatalf("want campaign to be deleted, but was not: %e", err)
 		}
+
+		deleted, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID, IncludeDeleted: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !deleted.Deleted() {
+			t.Fatalf("campaign DeletedAt not set")
+		}
 	})
 
-	t.Run("CloseCampaign", func(t *testing.T) {
-		// After close, the changesets will be synced, so we need to mock that operation.
-		state := ct.MockChangesetSyncState(&protocol.RepoInfo{
-			Name: api.RepoName(rs[0].Name),
-			VCS:  protocol.VCSInfo{URL: rs[0].URI},
-		})
-		defer state.Unmock()
-		createCampaign := func(t *testing.T) *campaigns.Campaign {
-			t.Helper()
-			campaign := testCampaign(admin.ID)
-			if err := store.CreateCampaign(ctx, campaign); err != nil {
-				t.Fatal(err)
-			}
-			return campaign
+	t.Run("GrantCampaignPermission", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		campaign.Visibility = campaigns.CampaignVisibilityNamespaceOnly
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
 		}
 
-		closeConfirm := func(t *testing.T, c *campaigns.Campaign, closeChangesets bool) {
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+		assertRights := func(t *testing.T, wantVisible, wantAdmin bool) {
 			t.Helper()
 
-			closedCampaign, err := svc.CloseCampaign(ctx, c.ID, closeChangesets, false)
+			visible, err := CampaignVisible(userCtx, store, campaign)
 			if err != nil {
-				t.Fatalf("campaign not closed: %s", err)
+				t.Fatal(err)
 			}
-			if closedCampaign.ClosedAt.IsZero() {
-				t.Fatalf("campaign ClosedAt is zero")
+			if visible != wantVisible {
+				t.Fatalf("wrong visibility. want=%t, have=%t", wantVisible, visible)
 			}
-		}
-
-		t.Run("no changesets", func(t *testing.T) {
-			campaign := createCampaign(t)
-			closeConfirm(t, campaign, false)
-		})
 
-		t.Run("processing changesets", func(t *testing.T) {
-			campaign := createCampaign(t)
-
-			changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
-			changeset.ReconcilerState = campaigns.ReconcilerStateProcessing
-			if err := store.CreateChangeset(ctx, changeset); err != nil {
+			err = CheckCampaignAdminRights(userCtx, store, campaign)
+			if _, ok := err.(*backend.InsufficientAuthorizationError); err != nil && !ok {
 				t.Fatal(err)
 			}
-
-			// should fail
-			_, err := svc.CloseCampaign(ctx, campaign.ID, true, false)
-			if err != ErrCloseProcessingCampaign {
-				t.Fatalf("CloseCampaign returned unexpected error: %s", err)
+			if isAdmin := err == nil; isAdmin != wantAdmin {
+				t.Fatalf("wrong admin rights. want=%t, have=%t", wantAdmin, isAdmin)
 			}
+		}
 
-			// without trying to close changesets, it should succeed:
-			closeConfirm(t, campaign, false)
-		})
+		assertRights(t, false, false)
 
-		t.Run("non-processing changesets", func(t *testing.T) {
-			campaign := createCampaign(t)
+		opts := GrantCampaignPermissionOpts{
+			CampaignID: campaign.ID,
+			UserID:     user.ID,
+			Level:      campaigns.CampaignPermissionLevelView,
+		}
+		if _, err := svc.GrantCampaignPermission(ctx, opts); err != nil {
+			t.Fatal(err)
+		}
+		assertRights(t, true, false)
 
-			changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
-			changeset.ReconcilerState = campaigns.ReconcilerStateCompleted
-			if err := store.CreateChangeset(ctx, changeset); err != nil {
-				t.Fatal(err)
-			}
+		opts.Level = campaigns.CampaignPermissionLevelAdmin
+		grant, err := svc.GrantCampaignPermission(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRights(t, true, true)
 
-			closeConfirm(t, campaign, true)
-		})
+		if err := svc.RevokeCampaignPermission(ctx, grant.ID); err != nil {
+			t.Fatal(err)
+		}
+		assertRights(t, false, false)
+
+		opts.Level = "OWNER"
+		if _, err := svc.GrantCampaignPermission(ctx, opts); err != ErrInvalidCampaignPermissionLevel {
+			t.Fatalf("wrong error. want=%s, have=%s", ErrInvalidCampaignPermissionLevel, err)
+		}
 	})
 
-	t.Run("EnqueueChangesetSync", func(t *testing.T) {
+	t.Run("RestoreCampaign", func(t *testing.T) {
 		campaign := testCampaign(admin.ID)
 		if err := store.CreateCampaign(ctx, campaign); err != nil {
 			t.Fatal(err)
 		}
 
-		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
-		if err := store.CreateChangeset(ctx, changeset); err != nil {
+		if _, err := svc.RestoreCampaign(ctx, campaign.ID); err != ErrCampaignNotDeleted {
+			t.Fatalf("restoring campaign that's not deleted returned wrong error. want=%s, have=%s", ErrCampaignNotDeleted, err)
+		}
+
+		if err := svc.DeleteCampaign(ctx, campaign.ID); err != nil {
+			t.Fatalf("campaign not deleted: %s", err)
+		}
+
+		restored, err := svc.RestoreCampaign(ctx, campaign.ID)
+		if err != nil {
+			t.Fatalf("campaign not restored: %s", err)
+		}
+		if restored.Deleted() {
+			t.Fatalf("restored campaign still has DeletedAt set")
+		}
+
+		if _, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID}); err != nil {
+			t.Fatalf("restored campaign not found: %s", err)
+		}
+
+		// Campaigns deleted longer ago than the retention period can't be
+		// restored anymore.
+		restored.DeletedAt = time.Now().Add(-campaigns.CampaignDeletionRetention - time.Hour)
+		if err := store.UpdateCampaign(ctx, restored); err != nil {
 			t.Fatal(err)
 		}
+		if _, err := svc.RestoreCampaign(ctx, campaign.ID); err != ErrCampaignRestoreExpired {
+			t.Fatalf("restoring expired campaign returned wrong error. want=%s, have=%s", ErrCampaignRestoreExpired, err)
+		}
+	})
 
-		campaign.ChangesetIDs = []int64{changeset.ID}
-		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+	t.Run("SetCampaignAutoMerge", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
 			t.Fatal(err)
 		}
 
-		called := false
-		repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
-			if len(ids) != 1 && ids[0] != changeset.ID {
-				t.Fatalf("MockEnqueueChangesetSync received wrong ids: %+v", ids)
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		for _, enabled := range []bool{true, true, false} {
+			updated, err := svc.SetCampaignAutoMerge(adminCtx, campaign.ID, enabled)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.AutoMerge != enabled {
+				t.Fatalf("wrong AutoMerge. want=%t, have=%t", enabled, updated.AutoMerge)
 			}
-			called = true
-			return nil
 		}
-		t.Cleanup(func() { repoupdater.MockEnqueueChangesetSync = nil })
 
-		if err := svc.EnqueueChangesetSync(ctx, changeset.ID); err != nil {
+		// Enabling auto-merge twice is recorded only once.
+		activities, _, err := store.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{CampaignID: campaign.ID})
+		if err != nil {
 			t.Fatal(err)
 		}
-
-		if !called {
-			t.Fatal("MockEnqueueChangesetSync not called")
+		var kinds []campaigns.CampaignActivityKind
+		for _, a := range activities {
+			if a.UserID != admin.ID {
+				t.Fatalf("wrong user. want=%d, have=%d", admin.ID, a.UserID)
+			}
+			kinds = append(kinds, a.Kind)
+		}
+		wantKinds := []campaigns.CampaignActivityKind{
+			campaigns.CampaignActivityKindAutoMergeEnabled,
+			campaigns.CampaignActivityKindAutoMergeDisabled,
 		}
+		if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+			t.Fatalf("wrong activities (-want +got):\n%s", diff)
+		}
+	})
 
-		// Repo filtered out by authzFilter
-		ct.AuthzFilterRepos(t, rs[0].ID)
+	t.Run("SetCampaignSpecPinned", func(t *testing.T) {
+		spec := createCampaignSpec(t, ctx, store, "pinned-campaign-spec", admin.ID)
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
 
-		// should result in a not found error
-		if err := svc.EnqueueChangesetSync(ctx, changeset.ID); !errcode.IsNotFound(err) {
-			t.Fatalf("expected not-found error but got %s", err)
+		pinned, err := svc.SetCampaignSpecPinned(adminCtx, spec.RandID, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pinned.Pinned() {
+			t.Fatal("campaign spec not pinned")
+		}
+		if !pinned.ExpiresAt().IsZero() {
+			t.Fatalf("pinned campaign spec expires at %s", pinned.ExpiresAt())
 		}
-	})
 
-	t.Run("CloseOpenChangesets", func(t *testing.T) {
-		// After close, the changesets will be synced, so we need to mock that operation.
-		state := ct.MockChangesetSyncState(&protocol.RepoInfo{
-			Name: api.RepoName(rs[0].Name),
-			VCS:  protocol.VCSInfo{URL: rs[0].URI},
-		})
-		defer state.Unmock()
+		// Pinning it again keeps the time when it was pinned first.
+		again, err := svc.SetCampaignSpecPinned(adminCtx, spec.RandID, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !again.PinnedAt.Equal(pinned.PinnedAt) {
+			t.Fatalf("wrong PinnedAt. want=%s, have=%s", pinned.PinnedAt, again.PinnedAt)
+		}
 
-		changeset1 := testChangeset(rs[0].ID, 0, campaigns.ChangesetExternalStateOpen)
-		if err := store.CreateChangeset(ctx, changeset1); err != nil {
+		unpinned, err := svc.SetCampaignSpecPinned(adminCtx, spec.RandID, false)
+		if err != nil {
 			t.Fatal(err)
 		}
-		changeset2 := testChangeset(rs[1].ID, 0, campaigns.ChangesetExternalStateOpen)
-		if err := store.CreateChangeset(ctx, changeset2); err != nil {
+		reloaded, err := store.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: spec.ID})
+		if err != nil {
 			t.Fatal(err)
 		}
+		if unpinned.Pinned() || reloaded.Pinned() {
+			t.Fatal("campaign spec still pinned")
+		}
+	})
 
-		// Repo of changeset2 filtered out by authzFilter
-		ct.AuthzFilterRepos(t, changeset2.RepoID)
+	t.Run("SetCampaignAutoRebase", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
 
-		fakeSource := &ct.FakeChangesetSource{Err: nil}
-		sourcer := repos.NewFakeSourcer(nil, fakeSource)
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
 
-		svc := NewService(store, nil)
-		svc.sourcer = sourcer
+		for _, enabled := range []bool{true, false, false} {
+			updated, err := svc.SetCampaignAutoRebase(adminCtx, campaign.ID, enabled)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.AutoRebase != enabled {
+				t.Fatalf("wrong AutoRebase. want=%t, have=%t", enabled, updated.AutoRebase)
+			}
+		}
 
-		// Try to close open changesets
-		err := svc.CloseOpenChangesets(ctx, []*campaigns.Changeset{changeset1, changeset2})
+		activities, _, err := store.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{CampaignID: campaign.ID})
 		if err != nil {
 			t.Fatal(err)
 		}
-
-		// Only changeset1 should be closed
-		if have, want := len(fakeSource.ClosedChangesets), 1; have != want {
-			t.Fatalf("ClosedChangesets has wrong length. want=%d, have=%d", want, have)
+		var kinds []campaigns.CampaignActivityKind
+		for _, a := range activities {
+			kinds = append(kinds, a.Kind)
 		}
-
-		if have, want := fakeSource.ClosedChangesets[0].RepoID, changeset1.RepoID; have != want {
-			t.Fatalf("wrong changesets closed. want=%d, have=%d", want, have)
+		wantKinds := []campaigns.CampaignActivityKind{
+			campaigns.CampaignActivityKindAutoRebaseEnabled,
+			campaigns.CampaignActivityKindAutoRebaseDisabled,
+		}
+		if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+			t.Fatalf("wrong activities (-want +got):\n%s", diff)
 		}
 	})
 
-	t.Run("CreateCampaignSpec", func(t *testing.T) {
-		changesetSpecs := make([]*campaigns.ChangesetSpec, 0, len(rs))
-		changesetSpecRandIDs := make([]string, 0, len(rs))
-		for _, r := range rs {
-			cs := &campaigns.ChangesetSpec{RepoID: r.ID, UserID: admin.ID}
-			if err := store.CreateChangesetSpec(ctx, cs); err != nil {
-				t.Fatal(err)
-			}
-			changesetSpecs = append(changesetSpecs, cs)
-			changesetSpecRandIDs = append(changesetSpecRandIDs, cs.RandID)
+	t.Run("PauseCampaign", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
 		}
 
 		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
 
-		t.Run("success", func(t *testing.T) {
-			opts := CreateCampaignSpecOpts{
-				NamespaceUserID:      admin.ID,
-				RawSpec:              ct.TestRawCampaignSpec,
-				ChangesetSpecRandIDs: changesetSpecRandIDs,
-			}
+		paused, err := svc.PauseCampaign(adminCtx, campaign.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !paused.Paused() {
+			t.Fatal("campaign not paused")
+		}
 
-			spec, err := svc.CreateCampaignSpec(adminCtx, opts)
+		// A changeset that the reconciler requeued while the campaign was
+		// paused is ready to be processed once it's resumed.
+		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		changeset.OwnedByCampaignID = campaign.ID
+		changeset.ReconcilerState = campaigns.ReconcilerStateQueued
+		changeset.ProcessAfter = time.Now().Add(pausedBackoff)
+		changeset.WaitReason = campaigns.ChangesetWaitReasonPaused
+		if err := store.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := svc.CloseCampaign(adminCtx, campaign.ID, true, false); err != ErrClosePausedCampaignChangesets {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrClosePausedCampaignChangesets, err)
+		}
+
+		for i := 0; i < 2; i++ {
+			resumed, err := svc.ResumeCampaign(adminCtx, campaign.ID)
 			if err != nil {
 				t.Fatal(err)
 			}
-
+			if resumed.Paused() {
+				t.Fatal("campaign still paused")
+			}
+		}
+
+		reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reloaded.ProcessAfter.IsZero() || reloaded.WaitReason != "" {
+			t.Fatalf("changeset still waiting. process after=%s, wait reason=%s", reloaded.ProcessAfter, reloaded.WaitReason)
+		}
+
+		activities, _, err := store.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{CampaignID: campaign.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var kinds []campaigns.CampaignActivityKind
+		for _, a := range activities {
+			kinds = append(kinds, a.Kind)
+		}
+		wantKinds := []campaigns.CampaignActivityKind{
+			campaigns.CampaignActivityKindPaused,
+			campaigns.CampaignActivityKindResumed,
+		}
+		if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+			t.Fatalf("wrong activities (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("TriggerCampaign", func(t *testing.T) {
+		spec := &campaigns.CampaignSpec{UserID: admin.ID, NamespaceUserID: admin.ID}
+		if err := store.CreateCampaignSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+		campaign := testCampaign(admin.ID)
+		campaign.CampaignSpecID = spec.ID
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+
+		reapply := TriggerCampaignOpts{
+			CampaignID: campaign.ID,
+			Action:     campaigns.CampaignTriggerActionReapply,
+			Source:     "Code monitor: new uses of deprecated API",
+		}
+		notify := reapply
+		notify.Action = campaigns.CampaignTriggerActionNotify
+		notify.Message = "3 new results"
+
+		defer func(f func() *schema.CampaignsExecutor) { campaignsExecutor = f }(campaignsExecutor)
+		campaignsExecutor = func() *schema.CampaignsExecutor { return nil }
+		if err := svc.TriggerCampaign(adminCtx, reapply); err != ErrExecutorDisabled {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrExecutorDisabled, err)
+		}
+
+		defer func(f func() bool) { canSendNotificationEmails = f }(canSendNotificationEmails)
+		canSendNotificationEmails = func() bool { return false }
+		if err := svc.TriggerCampaign(adminCtx, notify); err != ErrEmailDisabled {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrEmailDisabled, err)
+		}
+
+		campaignsExecutor = func() *schema.CampaignsExecutor {
+			return &schema.CampaignsExecutor{Enabled: true}
+		}
+		canSendNotificationEmails = func() bool { return true }
+		defer func(f func(context.Context, txtypes.Message) error) { sendNotificationEmail = f }(sendNotificationEmail)
+		sendNotificationEmail = func(ctx context.Context, msg txtypes.Message) error { return nil }
+
+		if err := svc.TriggerCampaign(userCtx, reapply); !errcode.IsUnauthorized(err) {
+			t.Fatalf("expected unauthorized error, got %+v", err)
+		}
+
+		invalid := reapply
+		invalid.Source = ""
+		if err := svc.TriggerCampaign(adminCtx, invalid); err == nil {
+			t.Fatal("campaign triggered without source")
+		}
+
+		// Triggers that fire repeatedly only enqueue a single job.
+		for i := 0; i < 2; i++ {
+			if err := svc.TriggerCampaign(adminCtx, reapply); err != nil {
+				t.Fatal(err)
+			}
+		}
+		jobs, err := store.ListCampaignReapplyJobs(ctx, ListCampaignReapplyJobsOpts{CampaignID: campaign.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 1 || jobs[0].CampaignSpecID != spec.ID {
+			t.Fatalf("wrong reapply jobs: %+v", jobs)
+		}
+
+		if err := svc.TriggerCampaign(adminCtx, notify); err != nil {
+			t.Fatal(err)
+		}
+
+		activities, _, err := store.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{CampaignID: campaign.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(activities) != 3 {
+			t.Fatalf("wrong number of activities. want=3, have=%d", len(activities))
+		}
+		for _, a := range activities {
+			if a.Kind != campaigns.CampaignActivityKindTriggered || a.Metadata["source"] != reapply.Source {
+				t.Fatalf("wrong activity: %+v", a)
+			}
+		}
+
+		campaign.ClosedAt = time.Now()
+		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.TriggerCampaign(adminCtx, reapply); err != ErrTriggerClosedCampaign {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrTriggerClosedCampaign, err)
+		}
+	})
+
+	t.Run("ImportChangesets", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		repo := testRepo(100, extsvc.TypeGitHub)
+		repo.Name = "github.com/sourcegraph/import-me"
+		if err := repos.NewDBStore(dbconn.Global, sql.TxOptions{}).UpsertRepos(ctx, repo); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := createChangeset(t, ctx, store, testChangesetOpts{
+			repo:             repo.ID,
+			externalID:       "1234",
+			publicationState: campaigns.ChangesetPublicationStatePublished,
+		})
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+		urls := []string{
+			"https://github.com/sourcegraph/import-me/pull/1234",
+			"https://github.com/sourcegraph/import-me/pull/1234/files",
+		}
+
+		// Importing the same changeset multiple times attaches it only once.
+		for i := 0; i < 2; i++ {
+			imported, err := svc.ImportChangesets(adminCtx, campaign.ID, urls)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff([]int64{changeset.ID, changeset.ID}, imported.IDs()); diff != "" {
+				t.Fatalf("wrong imported changesets (-want +got):\n%s", diff)
+			}
+		}
+
+		reloaded, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{changeset.ID}, reloaded.ChangesetIDs); diff != "" {
+			t.Fatalf("wrong campaign changesets (-want +got):\n%s", diff)
+		}
+
+		reloadedChangeset, err := store.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{campaign.ID}, reloadedChangeset.CampaignIDs); diff != "" {
+			t.Fatalf("wrong changeset campaigns (-want +got):\n%s", diff)
+		}
+		if !reloadedChangeset.AddedToCampaign {
+			t.Fatal("changeset not marked as added to campaign")
+		}
+
+		if _, err := svc.ImportChangesets(adminCtx, campaign.ID, []string{"https://example.com/foo/bar/pull/1"}); err == nil {
+			t.Fatal("expected error for unknown code host, got none")
+		}
+	})
+
+	t.Run("DetachChangesets", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+		otherCampaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, otherCampaign); err != nil {
+			t.Fatal(err)
+		}
+
+		owned := createChangeset(t, ctx, store, testChangesetOpts{
+			repo:             rs[0].ID,
+			campaign:         campaign.ID,
+			currentSpec:      1,
+			ownedByCampaign:  campaign.ID,
+			externalID:       "1",
+			publicationState: campaigns.ChangesetPublicationStatePublished,
+		})
+		unpublished := createChangeset(t, ctx, store, testChangesetOpts{
+			repo:             rs[1].ID,
+			campaign:         campaign.ID,
+			currentSpec:      2,
+			ownedByCampaign:  campaign.ID,
+			publicationState: campaigns.ChangesetPublicationStateUnpublished,
+		})
+		shared := createChangeset(t, ctx, store, testChangesetOpts{
+			repo:             rs[2].ID,
+			campaign:         campaign.ID,
+			externalID:       "3",
+			publicationState: campaigns.ChangesetPublicationStatePublished,
+		})
+		shared.CampaignIDs = append(shared.CampaignIDs, otherCampaign.ID)
+		if err := store.UpdateChangeset(ctx, shared); err != nil {
+			t.Fatal(err)
+		}
+		kept := createChangeset(t, ctx, store, testChangesetOpts{
+			repo:             rs[3].ID,
+			campaign:         campaign.ID,
+			externalID:       "4",
+			publicationState: campaigns.ChangesetPublicationStatePublished,
+		})
+
+		campaign.ChangesetIDs = []int64{owned.ID, unpublished.ID, shared.ID, kept.ID}
+		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		// Changesets that aren't attached to the campaign can't be detached.
+		if _, err := svc.DetachChangesets(adminCtx, otherCampaign.ID, []int64{owned.ID}); !errcode.IsNotFound(err) {
+			t.Fatalf("want not found error, got %v", err)
+		}
+
+		detachIDs := []int64{owned.ID, unpublished.ID, shared.ID}
+		updated, err := svc.DetachChangesets(adminCtx, campaign.ID, detachIDs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{kept.ID}, updated.ChangesetIDs); diff != "" {
+			t.Fatalf("wrong campaign changesets (-want +got):\n%s", diff)
+		}
+
+		// Changesets that aren't in any campaign anymore, or that haven't
+		// been published, are deleted.
+		for _, id := range []int64{owned.ID, unpublished.ID} {
+			if _, err := store.GetChangeset(ctx, GetChangesetOpts{ID: id}); err != ErrNoResults {
+				t.Fatalf("changeset %d not deleted: %v", id, err)
+			}
+		}
+
+		reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: shared.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{otherCampaign.ID}, reloaded.CampaignIDs); diff != "" {
+			t.Fatalf("wrong changeset campaigns (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("SetCampaignReapplySchedule", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		invalid := "every day"
+		if _, err := svc.SetCampaignReapplySchedule(adminCtx, campaign.ID, &invalid); err == nil {
+			t.Fatal("invalid schedule accepted")
+		}
+
+		schedule := "@daily"
+		rs, err := svc.SetCampaignReapplySchedule(adminCtx, campaign.ID, &schedule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rs.Schedule != schedule {
+			t.Fatalf("wrong schedule. want=%q, have=%q", schedule, rs.Schedule)
+		}
+		if !rs.NextRunAt.After(store.Clock()()) {
+			t.Fatalf("next run not in the future: %s", rs.NextRunAt)
+		}
+
+		rs, err = svc.SetCampaignReapplySchedule(adminCtx, campaign.ID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rs != nil {
+			t.Fatalf("schedule not removed: %+v", rs)
+		}
+		if _, err := store.GetCampaignReapplySchedule(ctx, campaign.ID); err != ErrNoResults {
+			t.Fatalf("schedule not deleted: %v", err)
+		}
+	})
+
+	t.Run("CloseCampaign", func(t *testing.T) {
+		// After close, the changesets will be synced, so we need to mock that operation.
+		state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+			Name: api.RepoName(rs[0].Name),
+			VCS:  protocol.VCSInfo{URL: rs[0].URI},
+		})
+		defer state.Unmock()
+		createCampaign := func(t *testing.T) *campaigns.Campaign {
+			t.Helper()
+			campaign := testCampaign(admin.ID)
+			if err := store.CreateCampaign(ctx, campaign); err != nil {
+				t.Fatal(err)
+			}
+			return campaign
+		}
+
+		closeConfirm := func(t *testing.T, c *campaigns.Campaign, closeChangesets bool) {
+			t.Helper()
+
+			closedCampaign, err := svc.CloseCampaign(ctx, c.ID, closeChangesets, false)
+			if err != nil {
+				t.Fatalf("campaign not closed: %s", err)
+			}
+			if closedCampaign.ClosedAt.IsZero() {
+				t.Fatalf("campaign ClosedAt is zero")
+			}
+		}
+
+		t.Run("no changesets", func(t *testing.T) {
+			campaign := createCampaign(t)
+			closeConfirm(t, campaign, false)
+		})
+
+		t.Run("processing changesets", func(t *testing.T) {
+			campaign := createCampaign(t)
+
+			changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+			changeset.ReconcilerState = campaigns.ReconcilerStateProcessing
+			if err := store.CreateChangeset(ctx, changeset); err != nil {
+				t.Fatal(err)
+			}
+
+			// should fail
+			_, err := svc.CloseCampaign(ctx, campaign.ID, true, false)
+			if err != ErrCloseProcessingCampaign {
+				t.Fatalf("CloseCampaign returned unexpected error: %s", err)
+			}
+
+			// without trying to close changesets, it should succeed:
+			closeConfirm(t, campaign, false)
+		})
+
+		t.Run("non-processing changesets", func(t *testing.T) {
+			campaign := createCampaign(t)
+
+			changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+			changeset.ReconcilerState = campaigns.ReconcilerStateCompleted
+			if err := store.CreateChangeset(ctx, changeset); err != nil {
+				t.Fatal(err)
+			}
+
+			closeConfirm(t, campaign, true)
+		})
+	})
+
+	t.Run("EnqueueChangesetSync", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{changeset.ID}
+		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		called := false
+		repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+			if len(ids) != 1 && ids[0] != changeset.ID {
+				t.Fatalf("MockEnqueueChangesetSync received wrong ids: %+v", ids)
+			}
+			called = true
+			return nil
+		}
+		t.Cleanup(func() { repoupdater.MockEnqueueChangesetSync = nil })
+
+		if err := svc.EnqueueChangesetSync(ctx, changeset.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if !called {
+			t.Fatal("MockEnqueueChangesetSync not called")
+		}
+
+		// Repo filtered out by authzFilter
+		ct.AuthzFilterRepos(t, rs[0].ID)
+
+		// should result in a not found error
+		if err := svc.EnqueueChangesetSync(ctx, changeset.ID); !errcode.IsNotFound(err) {
+			t.Fatalf("expected not-found error but got %s", err)
+		}
+	})
+
+	t.Run("EnqueueCampaignChangesetSyncs", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		open := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		open.PublicationState = campaigns.ChangesetPublicationStatePublished
+		merged := testChangeset(rs[1].ID, campaign.ID, campaigns.ChangesetExternalStateMerged)
+		merged.PublicationState = campaigns.ChangesetPublicationStatePublished
+		unpublished := testChangeset(rs[2].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		for _, c := range []*campaigns.Changeset{open, merged, unpublished} {
+			if err := store.CreateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var have []int64
+		repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+			have = append(have, ids...)
+			return nil
+		}
+		t.Cleanup(func() { repoupdater.MockEnqueueChangesetSync = nil })
+
+		if err := svc.EnqueueCampaignChangesetSyncs(ctx, campaign.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]int64{open.ID}, have); diff != "" {
+			t.Fatalf("wrong changesets enqueued (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("RecordCampaignView", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		open := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		open.PublicationState = campaigns.ChangesetPublicationStatePublished
+		if err := store.CreateChangeset(ctx, open); err != nil {
+			t.Fatal(err)
+		}
+
+		var enqueued []int64
+		repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+			enqueued = append(enqueued, ids...)
+			return nil
+		}
+		t.Cleanup(func() { repoupdater.MockEnqueueChangesetSync = nil })
+
+		userCtx := actor.WithActor(ctx, actor.FromUser(user.ID))
+		if err := svc.RecordCampaignView(userCtx, campaign.ID); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{open.ID}, enqueued); diff != "" {
+			t.Fatalf("wrong changesets enqueued (-want +have):\n%s", diff)
+		}
+
+		// Views within the record interval don't enqueue syncs again.
+		if err := svc.RecordCampaignView(userCtx, campaign.ID); err != nil {
+			t.Fatal(err)
+		}
+		if len(enqueued) != 1 {
+			t.Fatalf("syncs enqueued again: %v", enqueued)
+		}
+
+		// Views of campaigns that aren't visible aren't recorded.
+		hidden := testCampaign(admin.ID)
+		hidden.Visibility = campaigns.CampaignVisibilityNamespaceOnly
+		if err := store.CreateCampaign(ctx, hidden); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.RecordCampaignView(userCtx, hidden.ID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("SyncChangeset", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		changeset.PublicationState = campaigns.ChangesetPublicationStatePublished
+		if err := store.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{changeset.ID}
+		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		MockSyncChangesets = func(_ context.Context, _ RepoStore, tx SyncStore, _ *httpcli.Factory, cs ...*campaigns.Changeset) error {
+			if len(cs) != 1 || cs[0].ID != changeset.ID {
+				t.Fatalf("MockSyncChangesets received wrong changesets: %+v", cs)
+			}
+			cs[0].ExternalState = campaigns.ChangesetExternalStateMerged
+			return tx.UpdateChangeset(ctx, cs[0])
+		}
+		t.Cleanup(func() { MockSyncChangesets = nil })
+
+		synced, err := svc.SyncChangeset(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := synced.ExternalState, campaigns.ChangesetExternalStateMerged; have != want {
+			t.Fatalf("wrong external state. want=%s, have=%s", want, have)
+		}
+
+		unpublished := testChangeset(rs[0].ID, campaign.ID, "")
+		unpublished.PublicationState = campaigns.ChangesetPublicationStateUnpublished
+		if err := store.CreateChangeset(ctx, unpublished); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.SyncChangeset(ctx, unpublished.ID); err != ErrChangesetNotSyncable {
+			t.Fatalf("wrong error. want=%s, have=%s", ErrChangesetNotSyncable, err)
+		}
+
+		// Repo filtered out by authzFilter
+		ct.AuthzFilterRepos(t, rs[0].ID)
+
+		// should result in a not found error
+		if _, err := svc.SyncChangeset(ctx, changeset.ID); !errcode.IsNotFound(err) {
+			t.Fatalf("expected not-found error but got %s", err)
+		}
+	})
+
+	t.Run("SetChangesetCustomMetadata", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{changeset.ID}
+		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		value := "ENG-1234"
+		updated, err := svc.SetChangesetCustomMetadata(ctx, changeset.ID, "ticket", &value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(map[string]string{"ticket": value}, updated.CustomMetadata); diff != "" {
+			t.Fatalf("wrong custom metadata (-want +got):\n%s", diff)
+		}
+
+		updated, err = svc.SetChangesetCustomMetadata(ctx, changeset.ID, "ticket", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.CustomMetadata != nil {
+			t.Fatalf("custom metadata not removed: %+v", updated.CustomMetadata)
+		}
+
+		if _, err := svc.SetChangesetCustomMetadata(ctx, changeset.ID, "", &value); err != ErrCustomMetadataKeyBlank {
+			t.Fatalf("wrong error. want=%s, have=%s", ErrCustomMetadataKeyBlank, err)
+		}
+	})
+
+	t.Run("RetryChangeset", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{changeset.ID}
+		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := svc.RetryChangeset(ctx, changeset.ID); err != ErrChangesetNotRetryable {
+			t.Fatalf("wrong error. want=%s, have=%s", ErrChangesetNotRetryable, err)
+		}
+
+		failureMessage := "failed to push"
+		changeset.ReconcilerState = campaigns.ReconcilerStateErrored
+		changeset.FailureMessage = &failureMessage
+		changeset.NumResets = 5
+		if err := store.UpdateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		retried, err := svc.RetryChangeset(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := retried.ReconcilerState, campaigns.ReconcilerStateQueued; have != want {
+			t.Fatalf("wrong reconciler state. want=%s, have=%s", want, have)
+		}
+		if retried.NumResets != 0 {
+			t.Fatalf("NumResets not reset: %d", retried.NumResets)
+		}
+	})
+
+	t.Run("CreateBulkOperation", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+		other := testChangeset(rs[1].ID, 0, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, other); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+
+		opts := CreateBulkOperationOpts{
+			CampaignID:   campaign.ID,
+			ChangesetIDs: []int64{changeset.ID},
+			Type:         campaigns.ChangesetJobTypeComment,
+		}
+		if _, err := svc.CreateBulkOperation(adminCtx, opts); err != ErrEmptyChangesetComment {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrEmptyChangesetComment, err)
+		}
+
+		opts.Payload.Message = "Please review"
+		if _, err := svc.CreateBulkOperation(userCtx, opts); !errcode.IsUnauthorized(err) {
+			t.Fatalf("expected unauthorized error, got %+v", err)
+		}
+
+		opts.ChangesetIDs = []int64{changeset.ID, other.ID}
+		if _, err := svc.CreateBulkOperation(adminCtx, opts); err == nil {
+			t.Fatal("no error returned for changeset of another campaign")
+		}
+
+		opts.ChangesetIDs = []int64{changeset.ID, changeset.ID}
+		op, err := svc.CreateBulkOperation(adminCtx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op.State != campaigns.BulkOperationStateProcessing || op.ChangesetCount != 1 || op.UserID != admin.ID {
+			t.Fatalf("wrong bulk operation: %+v", op)
+		}
+
+		jobs, err := store.ListChangesetJobs(ctx, ListChangesetJobsOpts{BulkGroup: op.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 1 || jobs[0].ChangesetID != changeset.ID || jobs[0].Payload.Message != "Please review" {
+			t.Fatalf("wrong changeset jobs: %+v", jobs)
+		}
+	})
+
+	t.Run("RebaseChangeset", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		spec := createChangesetSpec(t, ctx, store, testSpecOpts{
+			user:          admin.ID,
+			repo:          rs[0].ID,
+			headRef:       "refs/heads/my-branch",
+			published:     true,
+			commitMessage: "commit message",
+			commitDiff:    "diff",
+		})
+
+		changeset := createChangeset(t, ctx, store, testChangesetOpts{
+			repo:             rs[0].ID,
+			campaign:         campaign.ID,
+			currentSpec:      spec.ID,
+			publicationState: campaigns.ChangesetPublicationStatePublished,
+			externalID:       "12345",
+			externalBranch:   "my-branch",
+		})
+
+		campaign.ChangesetIDs = []int64{changeset.ID}
+		if err := store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		// Closed changesets can't be rebased
+		changeset.ExternalState = campaigns.ChangesetExternalStateClosed
+		if err := store.UpdateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.RebaseChangeset(ctx, changeset.ID); err != ErrChangesetNotRebasable {
+			t.Fatalf("wrong error. want=%s, have=%s", ErrChangesetNotRebasable, err)
+		}
+
+		changeset.ExternalState = campaigns.ChangesetExternalStateOpen
+		if err := store.UpdateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		baseRev := api.CommitID("2cd6afe6b4a1a16fd1f3f8ad11c6e2ea8a0c8c6d")
+		backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+			return baseRev, nil
+		}
+		t.Cleanup(func() { backend.Mocks.Repos.ResolveRev = nil })
+
+		synced := false
+		repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+			synced = true
+			return nil
+		}
+		t.Cleanup(func() { repoupdater.MockEnqueueChangesetSync = nil })
+
+		gitClient := &ct.FakeGitserverClient{}
+		svc := NewService(store, nil)
+		svc.gitserverClient = gitClient
+
+		if _, err := svc.RebaseChangeset(ctx, changeset.ID); err != nil {
+			t.Fatal(err)
+		}
+		if !gitClient.CreateCommitFromPatchCalled {
+			t.Fatal("CreateCommitFromPatch not called")
+		}
+		if have, want := gitClient.CreateCommitFromPatchReq.BaseCommit, baseRev; have != want {
+			t.Fatalf("wrong base commit. want=%s, have=%s", want, have)
+		}
+		if !synced {
+			t.Fatal("MockEnqueueChangesetSync not called")
+		}
+
+		// Diff doesn't apply on the new base
+		gitClient.ResponseErr = &gitserverprotocol.CreateCommitFromPatchError{RepositoryName: rs[0].Name}
+		if _, err := svc.RebaseChangeset(ctx, changeset.ID); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("CloseOpenChangesets", func(t *testing.T) {
+		// After close, the changesets will be synced, so we need to mock that operation.
+		state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+			Name: api.RepoName(rs[0].Name),
+			VCS:  protocol.VCSInfo{URL: rs[0].URI},
+		})
+		defer state.Unmock()
+
+		changeset1 := testChangeset(rs[0].ID, 0, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, changeset1); err != nil {
+			t.Fatal(err)
+		}
+		changeset2 := testChangeset(rs[1].ID, 0, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, changeset2); err != nil {
+			t.Fatal(err)
+		}
+
+		// Repo of changeset2 filtered out by authzFilter
+		ct.AuthzFilterRepos(t, changeset2.RepoID)
+
+		fakeSource := &ct.FakeChangesetSource{Err: nil}
+		sourcer := repos.NewFakeSourcer(nil, fakeSource)
+
+		svc := NewService(store, nil)
+		svc.sourcer = sourcer
+
+		// Try to close open changesets
+		err := svc.CloseOpenChangesets(ctx, []*campaigns.Changeset{changeset1, changeset2})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Only changeset1 should be closed
+		if have, want := len(fakeSource.ClosedChangesets), 1; have != want {
+			t.Fatalf("ClosedChangesets has wrong length. want=%d, have=%d", want, have)
+		}
+
+		if have, want := fakeSource.ClosedChangesets[0].RepoID, changeset1.RepoID; have != want {
+			t.Fatalf("wrong changesets closed. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("CreateCampaignSpec", func(t *testing.T) {
+		changesetSpecs := make([]*campaigns.ChangesetSpec, 0, len(rs))
+		changesetSpecRandIDs := make([]string, 0, len(rs))
+		for _, r := range rs {
+			cs := &campaigns.ChangesetSpec{RepoID: r.ID, UserID: admin.ID}
+			if err := store.CreateChangesetSpec(ctx, cs); err != nil {
+				t.Fatal(err)
+			}
+			changesetSpecs = append(changesetSpecs, cs)
+			changesetSpecRandIDs = append(changesetSpecRandIDs, cs.RandID)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		t.Run("success", func(t *testing.T) {
+			opts := CreateCampaignSpecOpts{
+				NamespaceUserID:      admin.ID,
+				RawSpec:              ct.TestRawCampaignSpec,
+				ChangesetSpecRandIDs: changesetSpecRandIDs,
+			}
+
+			spec, err := svc.CreateCampaignSpec(adminCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			if spec.ID == 0 {
 				t.Fatalf("CampaignSpec ID is 0")
 			}
@@ -546,18 +1542,96 @@ func TestService(t *testing.T) {
 				ChangesetSpecRandIDs: []string{},
 			}
 
-			spec, err := svc.CreateCampaignSpec(adminCtx, opts)
-			if err != nil {
+			spec, err := svc.CreateCampaignSpec(adminCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			countOpts := CountChangesetSpecsOpts{CampaignSpecID: spec.ID}
+			count, err := store.CountChangesetSpecs(adminCtx, countOpts)
+			if err != nil {
+				return
+			}
+			if count != 0 {
+				t.Fatalf("want no changeset specs attached to campaign spec, but have %d", count)
+			}
+		})
+	})
+
+	t.Run("CampaignTemplates", func(t *testing.T) {
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+
+		newTemplate := func() *campaigns.CampaignTemplate {
+			return &campaigns.CampaignTemplate{
+				Name:            "template",
+				Template:        strings.Replace(ct.TestRawCampaignSpecYAML, "my-unique-name", "${{ parameters.name }}", 1),
+				Parameters:      []campaigns.CampaignTemplateParameter{{Name: "name"}},
+				NamespaceUserID: admin.ID,
+			}
+		}
+
+		t.Run("create in other namespace", func(t *testing.T) {
+			if err := svc.CreateCampaignTemplate(userCtx, newTemplate()); !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+		})
+
+		t.Run("create invalid template", func(t *testing.T) {
+			template := newTemplate()
+			template.Parameters = nil
+			if err := svc.CreateCampaignTemplate(adminCtx, template); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+
+		template := newTemplate()
+		if err := svc.CreateCampaignTemplate(adminCtx, template); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := template.CreatorID, admin.ID; have != want {
+			t.Fatalf("CreatorID is %d, want %d", have, want)
+		}
+
+		t.Run("create campaign spec", func(t *testing.T) {
+			opts := CreateCampaignSpecFromTemplateOpts{
+				CampaignTemplateID: template.ID,
+				Parameters:         map[string]string{"name": "from-template"},
+				NamespaceUserID:    admin.ID,
+			}
+
+			spec, err := svc.CreateCampaignSpecFromTemplate(adminCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := spec.Spec.Name, "from-template"; have != want {
+				t.Fatalf("campaign spec has wrong name. want=%q, have=%q", want, have)
+			}
+		})
+
+		t.Run("create campaign spec with missing parameter", func(t *testing.T) {
+			opts := CreateCampaignSpecFromTemplateOpts{
+				CampaignTemplateID: template.ID,
+				NamespaceUserID:    admin.ID,
+			}
+
+			if _, err := svc.CreateCampaignSpecFromTemplate(adminCtx, opts); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			if err := svc.DeleteCampaignTemplate(userCtx, template.ID); !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+
+			if err := svc.DeleteCampaignTemplate(adminCtx, template.ID); err != nil {
 				t.Fatal(err)
 			}
 
-			countOpts := CountChangesetSpecsOpts{CampaignSpecID: spec.ID}
-			count, err := store.CountChangesetSpecs(adminCtx, countOpts)
-			if err != nil {
-				return
-			}
-			if count != 0 {
-				t.Fatalf("want no changeset specs attached to campaign spec, but have %d", count)
+			if _, err := store.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: template.ID}); err != ErrNoResults {
+				t.Fatalf("want template to be deleted, but got err %v", err)
 			}
 		})
 	})
@@ -624,111 +1698,664 @@ func TestService(t *testing.T) {
 		// See TestServiceApplyCampaign
 	})
 
-	t.Run("MoveCampaign", func(t *testing.T) {
-		createCampaign := func(t *testing.T, name string, authorID, userID, orgID int32) *campaigns.Campaign {
-			t.Helper()
+	t.Run("MoveCampaign", func(t *testing.T) {
+		createCampaign := func(t *testing.T, name string, authorID, userID, orgID int32) *campaigns.Campaign {
+			t.Helper()
+
+			c := &campaigns.Campaign{
+				InitialApplierID: authorID,
+				NamespaceUserID:  userID,
+				NamespaceOrgID:   orgID,
+				Name:             name,
+			}
+
+			if err := store.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			return c
+		}
+
+		t.Run("new name", func(t *testing.T) {
+			campaign := createCampaign(t, "old-name", admin.ID, admin.ID, 0)
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewName: "new-name"}
+			moved, err := svc.MoveCampaign(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := moved.Name, opts.NewName; have != want {
+				t.Fatalf("wrong name. want=%q, have=%q", want, have)
+			}
+		})
+
+		t.Run("new user namespace", func(t *testing.T) {
+			campaign := createCampaign(t, "old-name", admin.ID, admin.ID, 0)
+
+			user2 := createTestUser(ctx, t)
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceUserID: user2.ID}
+			moved, err := svc.MoveCampaign(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := moved.NamespaceUserID, opts.NewNamespaceUserID; have != want {
+				t.Fatalf("wrong NamespaceUserID. want=%d, have=%d", want, have)
+			}
+
+			if have, want := moved.NamespaceOrgID, opts.NewNamespaceOrgID; have != want {
+				t.Fatalf("wrong NamespaceOrgID. want=%d, have=%d", want, have)
+			}
+		})
+
+		t.Run("new user namespace but current user is not admin", func(t *testing.T) {
+			campaign := createCampaign(t, "old-name", user.ID, user.ID, 0)
+
+			user2 := createTestUser(ctx, t)
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceUserID: user2.ID}
+
+			userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+			_, err := svc.MoveCampaign(userCtx, opts)
+			if !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+		})
+
+		t.Run("new org namespace", func(t *testing.T) {
+			campaign := createCampaign(t, "old-name", admin.ID, admin.ID, 0)
+
+			org, err := db.Orgs.Create(ctx, "org", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceOrgID: org.ID}
+			moved, err := svc.MoveCampaign(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := moved.NamespaceUserID, opts.NewNamespaceUserID; have != want {
+				t.Fatalf("wrong NamespaceUserID. want=%d, have=%d", want, have)
+			}
+
+			if have, want := moved.NamespaceOrgID, opts.NewNamespaceOrgID; have != want {
+				t.Fatalf("wrong NamespaceOrgID. want=%d, have=%d", want, have)
+			}
+		})
+
+		t.Run("new org namespace but current user is missing access", func(t *testing.T) {
+			campaign := createCampaign(t, "old-name", user.ID, user.ID, 0)
+
+			org, err := db.Orgs.Create(ctx, "org-no-access", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceOrgID: org.ID}
+
+			userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+			_, err = svc.MoveCampaign(userCtx, opts)
+			if have, want := err, backend.ErrNotAnOrgMember; have != want {
+				t.Fatalf("expected %s error but got %s", want, have)
+			}
+		})
+
+		t.Run("old org namespace but current user is missing access", func(t *testing.T) {
+			org, err := db.Orgs.Create(ctx, "org-left", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			campaign := createCampaign(t, "old-name", user.ID, 0, org.ID)
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceUserID: user.ID}
+
+			userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+			_, err = svc.MoveCampaign(userCtx, opts)
+			if have, want := err, backend.ErrNotAnOrgMember; have != want {
+				t.Fatalf("expected %s error but got %s", want, have)
+			}
+		})
+
+		t.Run("name taken in new namespace", func(t *testing.T) {
+			user2 := createTestUser(ctx, t)
+			createCampaign(t, "taken-name", admin.ID, user2.ID, 0)
+			campaign := createCampaign(t, "taken-name", admin.ID, admin.ID, 0)
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceUserID: user2.ID}
+			_, err := svc.MoveCampaign(ctx, opts)
+			if have, want := err, ErrMoveCampaignNameTaken; have != want {
+				t.Fatalf("expected %s error but got %s", want, have)
+			}
+		})
+
+		t.Run("open campaigns quota exceeded in new namespace", func(t *testing.T) {
+			user2 := createTestUser(ctx, t)
+			createCampaign(t, "existing", admin.ID, user2.ID, 0)
+			campaign := createCampaign(t, "quota", admin.ID, admin.ID, 0)
+
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				CampaignsNamespaces: &schema.CampaignsNamespaces{MaxOpenCampaigns: 1},
+			}})
+			defer conf.Mock(nil)
+
+			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceUserID: user2.ID}
+			_, err := svc.MoveCampaign(ctx, opts)
+			if _, ok := err.(*openCampaignLimitExceededErr); !ok {
+				t.Fatalf("expected openCampaignLimitExceededErr but got %v", err)
+			}
+
+			// Renaming within the same namespace isn't affected by the quota.
+			opts = MoveCampaignOpts{CampaignID: campaign.ID, NewName: "quota-renamed"}
+			if _, err := svc.MoveCampaign(ctx, opts); err != nil {
+				t.Fatal(err)
+			}
+		})
+	})
+
+	t.Run("GetNamespaceQuota", func(t *testing.T) {
+		user2 := createTestUser(ctx, t)
+		campaign := &campaigns.Campaign{InitialApplierID: user2.ID, NamespaceUserID: user2.ID, Name: "quota-usage"}
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			CampaignsNamespaces: &schema.CampaignsNamespaces{MaxChangesets: 20, MaxOpenCampaigns: 3},
+		}})
+		defer conf.Mock(nil)
+
+		user2Ctx := actor.WithActor(context.Background(), actor.FromUser(user2.ID))
+		quota, err := svc.GetNamespaceQuota(user2Ctx, user2.ID, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &NamespaceQuota{Enabled: true, MaxChangesets: 20, MaxOpenCampaigns: 3, OpenCampaigns: 1}
+		if diff := cmp.Diff(want, quota); diff != "" {
+			t.Fatalf("wrong quota (-want +got):\n%s", diff)
+		}
+
+		// 🚨 SECURITY: Other users can't see the quota of a user namespace.
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+		if _, err := svc.GetNamespaceQuota(userCtx, user2.ID, 0); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("CheckCampaignAdminRights", func(t *testing.T) {
+		org, err := db.Orgs.Create(ctx, "org-campaign-admins", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.OrgMembers.Create(ctx, org.ID, user.ID); err != nil {
+			t.Fatal(err)
+		}
+		nonMember := createTestUser(ctx, t)
+
+		orgCampaign := &campaigns.Campaign{Name: "org-admin-rights", InitialApplierID: admin.ID, NamespaceOrgID: org.ID}
+		userCampaign := &campaigns.Campaign{Name: "user-admin-rights", InitialApplierID: admin.ID, NamespaceUserID: admin.ID}
+		for _, c := range []*campaigns.Campaign{orgCampaign, userCampaign} {
+			if err := store.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		tests := []struct {
+			name           string
+			campaign       *campaigns.Campaign
+			user           int32
+			orgMembersCan  bool
+			wantAuthorized bool
+		}{
+			{name: "author", campaign: orgCampaign, user: admin.ID, wantAuthorized: true},
+			{name: "org member, disabled", campaign: orgCampaign, user: user.ID},
+			{name: "org member, enabled", campaign: orgCampaign, user: user.ID, orgMembersCan: true, wantAuthorized: true},
+			{name: "non-member, enabled", campaign: orgCampaign, user: nonMember.ID, orgMembersCan: true},
+			{name: "user namespace, enabled", campaign: userCampaign, user: user.ID, orgMembersCan: true},
+			{name: "anonymous, enabled", campaign: orgCampaign, user: 0, orgMembersCan: true},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				defer func(f func() bool) { orgMembersCanAdminister = f }(orgMembersCanAdminister)
+				orgMembersCanAdminister = func() bool { return tc.orgMembersCan }
+
+				userCtx := actor.WithActor(context.Background(), actor.FromUser(tc.user))
+				err := CheckCampaignAdminRights(userCtx, store, tc.campaign)
+				if have, want := err == nil, tc.wantAuthorized; have != want {
+					t.Fatalf("wrong authorization. want authorized=%t, have err=%v", want, err)
+				}
+				if _, ok := err.(*backend.InsufficientAuthorizationError); err != nil && !ok {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				// Listing the campaigns the user can administer agrees with
+				// the rights check.
+				listed, _, err := store.ListCampaigns(ctx, ListCampaignsOpts{
+					AdministeredBy:  NewCampaignAdmin(tc.user),
+					NamespaceUserID: tc.campaign.NamespaceUserID,
+					NamespaceOrgID:  tc.campaign.NamespaceOrgID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				found := false
+				for _, c := range listed {
+					found = found || c.ID == tc.campaign.ID
+				}
+				if have, want := found, tc.wantAuthorized; have != want {
+					t.Fatalf("wrong campaigns administered by user. want listed=%t, have=%t", want, have)
+				}
+			})
+		}
+	})
+
+	t.Run("SetCampaignUpdatePropagation", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		if _, err := svc.SetCampaignUpdatePropagation(adminCtx, campaign.ID, "MERGE"); err != ErrInvalidCampaignUpdatePropagation {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrInvalidCampaignUpdatePropagation, err)
+		}
+
+		updated, err := svc.SetCampaignUpdatePropagation(adminCtx, campaign.ID, campaigns.CampaignUpdatePropagationAppend)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := updated.UpdatePropagation, campaigns.CampaignUpdatePropagationAppend; have != want {
+			t.Fatalf("wrong update propagation. want=%s, have=%s", want, have)
+		}
+
+		reloaded, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.UpdatePropagation, campaigns.CampaignUpdatePropagationAppend; have != want {
+			t.Fatalf("wrong update propagation persisted. want=%s, have=%s", want, have)
+		}
+	})
+
+	t.Run("SetCampaignVisibility", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		if _, err := svc.SetCampaignVisibility(adminCtx, campaign.ID, "SECRET"); err != ErrInvalidCampaignVisibility {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrInvalidCampaignVisibility, err)
+		}
+
+		updated, err := svc.SetCampaignVisibility(adminCtx, campaign.ID, campaigns.CampaignVisibilityNamespaceOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := updated.Visibility, campaigns.CampaignVisibilityNamespaceOnly; have != want {
+			t.Fatalf("wrong visibility. want=%s, have=%s", want, have)
+		}
+
+		reloaded, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.Visibility, campaigns.CampaignVisibilityNamespaceOnly; have != want {
+			t.Fatalf("wrong visibility persisted. want=%s, have=%s", want, have)
+		}
+	})
+
+	t.Run("SetCampaignNotificationSettings", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		invalid := []campaigns.CampaignNotificationEvent{"SOMETHING_HAPPENED"}
+		if _, err := svc.SetCampaignNotificationSettings(adminCtx, campaign.ID, invalid, nil); err != ErrInvalidCampaignNotificationEvent {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrInvalidCampaignNotificationEvent, err)
+		}
+
+		if _, err := svc.SetCampaignNotificationSettings(adminCtx, campaign.ID, nil, []int32{1234567}); err == nil {
+			t.Fatal("expected error for unknown subscriber, got none")
+		}
+
+		events := []campaigns.CampaignNotificationEvent{
+			campaigns.CampaignNotificationEventLastMerged,
+			campaigns.CampaignNotificationEventLastMerged,
+		}
+		if _, err := svc.SetCampaignNotificationSettings(adminCtx, campaign.ID, events, []int32{user.ID, user.ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := store.GetCampaignNotificationSettings(ctx, campaign.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]campaigns.CampaignNotificationEvent{campaigns.CampaignNotificationEventLastMerged}, have.Events); diff != "" {
+			t.Fatalf("wrong events (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]int32{user.ID}, have.SubscriberIDs); diff != "" {
+			t.Fatalf("wrong subscribers (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("CreateCampaignComment", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
 
-			c := &campaigns.Campaign{
-				InitialApplierID: authorID,
-				NamespaceUserID:  userID,
-				NamespaceOrgID:   orgID,
-				Name:             name,
-			}
+		if _, err := svc.CreateCampaignComment(context.Background(), campaign.ID, "hello"); err != backend.ErrNotAuthenticated {
+			t.Fatalf("wrong error. want=%s, have=%v", backend.ErrNotAuthenticated, err)
+		}
 
-			if err := store.CreateCampaign(ctx, c); err != nil {
-				t.Fatal(err)
-			}
+		if _, err := svc.CreateCampaignComment(userCtx, campaign.ID, " \n "); err != ErrEmptyCampaignComment {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrEmptyCampaignComment, err)
+		}
 
-			return c
+		comment, err := svc.CreateCampaignComment(userCtx, campaign.ID, "LGTM")
+		if err != nil {
+			t.Fatal(err)
 		}
 
-		t.Run("new name", func(t *testing.T) {
-			campaign := createCampaign(t, "old-name", admin.ID, admin.ID, 0)
+		have, _, err := store.ListCampaignComments(ctx, ListCampaignCommentsOpts{CampaignID: campaign.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*campaigns.CampaignComment{{
+			ID:         comment.ID,
+			CampaignID: campaign.ID,
+			AuthorID:   user.ID,
+			Body:       "LGTM",
+			CreatedAt:  comment.CreatedAt,
+			UpdatedAt:  comment.UpdatedAt,
+		}}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
 
-			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewName: "new-name"}
-			moved, err := svc.MoveCampaign(ctx, opts)
-			if err != nil {
+		t.Run("campaign not visible", func(t *testing.T) {
+			campaign.Visibility = campaigns.CampaignVisibilityNamespaceOnly
+			if err := store.UpdateCampaign(ctx, campaign); err != nil {
 				t.Fatal(err)
 			}
 
-			if have, want := moved.Name, opts.NewName; have != want {
-				t.Fatalf("wrong name. want=%q, have=%q", want, have)
+			if _, err := svc.CreateCampaignComment(userCtx, campaign.ID, "LGTM"); err != ErrNoResults {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
 			}
 		})
+	})
 
-		t.Run("new user namespace", func(t *testing.T) {
-			campaign := createCampaign(t, "old-name", admin.ID, admin.ID, 0)
+	t.Run("ValidateCampaignSpec", func(t *testing.T) {
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+
+		rawSpec := fmt.Sprintf(`
+name: validated
+importChangesets:
+  - repository: %s
+    externalIDs: [1]
+  - repository: github.com/sourcegraph/does-not-exist
+    externalIDs: [2]
+`, rs[0].Name)
+
+		tests := []struct {
+			name string
+			opts ValidateCampaignSpecOpts
+			want []*campaigns.CampaignSpecValidationError
+		}{
+			{
+				name: "invalid schema",
+				opts: ValidateCampaignSpecOpts{RawSpec: "description: no name", NamespaceUserID: user.ID},
+				want: []*campaigns.CampaignSpecValidationError{
+					{Message: "name is required", Line: 1, Column: 1},
+				},
+			},
+			{
+				name: "unknown repository",
+				opts: ValidateCampaignSpecOpts{RawSpec: rawSpec, NamespaceUserID: user.ID},
+				want: []*campaigns.CampaignSpecValidationError{
+					{Message: `repository "github.com/sourcegraph/does-not-exist" not found`, Line: 6, Column: 17},
+				},
+			},
+			{
+				name: "unknown namespace",
+				opts: ValidateCampaignSpecOpts{RawSpec: "name: validated", NamespaceUserID: 1234567},
+				want: []*campaigns.CampaignSpecValidationError{
+					{Message: "namespace not found"},
+				},
+			},
+			{
+				name: "valid",
+				opts: ValidateCampaignSpecOpts{RawSpec: "name: validated", NamespaceUserID: user.ID},
+			},
+		}
 
-			user2 := createTestUser(ctx, t)
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				have, err := svc.ValidateCampaignSpec(userCtx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatalf("wrong problems (-want +got):\n%s", diff)
+				}
+			})
+		}
 
-			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceUserID: user2.ID}
-			moved, err := svc.MoveCampaign(ctx, opts)
-			if err != nil {
-				t.Fatal(err)
-			}
+		// Namespaces the user can't access are reported too.
+		have, err := svc.ValidateCampaignSpec(userCtx, ValidateCampaignSpecOpts{RawSpec: "name: validated", NamespaceUserID: admin.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || !strings.HasPrefix(have[0].Message, "no access to namespace") {
+			t.Fatalf("wrong problems: %+v", have)
+		}
+	})
 
-			if have, want := moved.NamespaceUserID, opts.NewNamespaceUserID; have != want {
-				t.Fatalf("wrong NamespaceUserID. want=%d, have=%d", want, have)
-			}
+	t.Run("LintCampaignSpec", func(t *testing.T) {
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
 
-			if have, want := moved.NamespaceOrgID, opts.NewNamespaceOrgID; have != want {
-				t.Fatalf("wrong NamespaceOrgID. want=%d, have=%d", want, have)
+		defer func(f func(context.Context, string) ([]api.RepoName, map[api.RepoName]int, error)) {
+			searchRepositoryNames = f
+		}(searchRepositoryNames)
+		searchRepositoryNames = func(ctx context.Context, query string) ([]api.RepoName, map[api.RepoName]int, error) {
+			if query == "repo:matches" {
+				return []api.RepoName{api.RepoName(rs[0].Name)}, nil, nil
 			}
-		})
+			return nil, nil, nil
+		}
 
-		t.Run("new user namespace but current user is not admin", func(t *testing.T) {
-			campaign := createCampaign(t, "old-name", user.ID, user.ID, 0)
+		rawSpec := `
+name: linted
+on:
+  - repositoriesMatchingQuery: repo:matches
+  - repositoriesMatchingQuery: repo:nothing
+`
+		have, err := svc.LintCampaignSpec(userCtx, LintCampaignSpecOpts{RawSpec: rawSpec, NamespaceUserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*campaigns.CampaignSpecLintProblem{
+			{
+				Rule:     campaigns.CampaignSpecLintRuleQueryMatchesNoRepositories,
+				Severity: campaigns.CampaignSpecLintSeverityWarning,
+				Message:  `query "repo:nothing" doesn't match any repositories`,
+				Line:     5,
+				Column:   32,
+			},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong problems (-want +got):\n%s", diff)
+		}
 
-			user2 := createTestUser(ctx, t)
+		// Invalid specs are reported as such, without running the other rules.
+		have, err = svc.LintCampaignSpec(userCtx, LintCampaignSpecOpts{RawSpec: "description: no name", NamespaceUserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].Rule != campaigns.CampaignSpecLintRuleInvalidSpec {
+			t.Fatalf("wrong problems: %+v", have)
+		}
+	})
 
-			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceUserID: user2.ID}
+	t.Run("ResolveCampaignSpecRepositories", func(t *testing.T) {
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
 
-			userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
-			_, err := svc.MoveCampaign(userCtx, opts)
-			if !errcode.IsUnauthorized(err) {
-				t.Fatalf("expected unauthorized error but got %s", err)
+		defer func(f func(context.Context, string) ([]api.RepoName, map[api.RepoName]int, error)) {
+			searchRepositoryNames = f
+		}(searchRepositoryNames)
+		searchRepositoryNames = func(ctx context.Context, query string) ([]api.RepoName, map[api.RepoName]int, error) {
+			names := []api.RepoName{api.RepoName(rs[0].Name), api.RepoName(rs[1].Name)}
+			return names, map[api.RepoName]int{names[0]: 3, names[1]: 1}, nil
+		}
+
+		defer git.ResetMocks()
+		git.Mocks.ExecSafe = func(params []string) ([]byte, []byte, int, error) {
+			return []byte("refs/heads/master\n"), nil, 0, nil
+		}
+		git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+			if spec == "refs/heads/missing" {
+				return "", fmt.Errorf("revision %s not found", spec)
 			}
-		})
+			return "deadbeef", nil
+		}
 
-		t.Run("new org namespace", func(t *testing.T) {
-			campaign := createCampaign(t, "old-name", admin.ID, admin.ID, 0)
+		rawSpec := fmt.Sprintf(`
+name: previewed
+on:
+  - repositoriesMatchingQuery: lang:go
+  - repository: %s
+    branch: missing
+`, rs[2].Name)
 
-			org, err := db.Orgs.Create(ctx, "org", nil)
-			if err != nil {
-				t.Fatal(err)
-			}
+		have, err := svc.ResolveCampaignSpecRepositories(userCtx, rawSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceOrgID: org.ID}
-			moved, err := svc.MoveCampaign(ctx, opts)
-			if err != nil {
-				t.Fatal(err)
-			}
+		type preview struct {
+			Repo        string
+			Branch      string
+			Commit      api.CommitID
+			Failed      bool
+			ResultCount int
+		}
+		var previews []preview
+		for _, r := range have {
+			previews = append(previews, preview{
+				Repo:        string(r.Repo.Name),
+				Branch:      r.Branch,
+				Commit:      r.Commit,
+				Failed:      r.Err != nil,
+				ResultCount: r.SearchResultCount,
+			})
+		}
+		want := []preview{
+			{Repo: rs[0].Name, Branch: "master", Commit: "deadbeef", ResultCount: 3},
+			{Repo: rs[1].Name, Branch: "master", Commit: "deadbeef", ResultCount: 1},
+			{Repo: rs[2].Name, Branch: "missing", Failed: true},
+		}
+		if diff := cmp.Diff(want, previews); diff != "" {
+			t.Fatalf("wrong repositories (-want +got):\n%s", diff)
+		}
 
-			if have, want := moved.NamespaceUserID, opts.NewNamespaceUserID; have != want {
-				t.Fatalf("wrong NamespaceUserID. want=%d, have=%d", want, have)
-			}
+		if _, err := svc.ResolveCampaignSpecRepositories(userCtx, "on: []"); err == nil {
+			t.Fatal("no error for invalid spec")
+		}
+	})
 
-			if have, want := moved.NamespaceOrgID, opts.NewNamespaceOrgID; have != want {
-				t.Fatalf("wrong NamespaceOrgID. want=%d, have=%d", want, have)
-			}
-		})
+	t.Run("ExecuteCampaignSpec", func(t *testing.T) {
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+		opts := ExecuteCampaignSpecOpts{RawSpec: "name: executed", NamespaceUserID: user.ID}
 
-		t.Run("new org namespace but current user is missing access", func(t *testing.T) {
-			campaign := createCampaign(t, "old-name", user.ID, user.ID, 0)
+		defer func(f func() *schema.CampaignsExecutor) { campaignsExecutor = f }(campaignsExecutor)
+		campaignsExecutor = func() *schema.CampaignsExecutor { return nil }
 
-			org, err := db.Orgs.Create(ctx, "org-no-access", nil)
-			if err != nil {
-				t.Fatal(err)
-			}
+		if _, err := svc.ExecuteCampaignSpec(userCtx, opts); err != ErrExecutorDisabled {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrExecutorDisabled, err)
+		}
 
-			opts := MoveCampaignOpts{CampaignID: campaign.ID, NewNamespaceOrgID: org.ID}
+		campaignsExecutor = func() *schema.CampaignsExecutor {
+			return &schema.CampaignsExecutor{Enabled: true}
+		}
 
-			userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
-			_, err = svc.MoveCampaign(userCtx, opts)
-			if have, want := err, backend.ErrNotAnOrgMember; have != want {
-				t.Fatalf("expected %s error but got %s", want, have)
-			}
-		})
+		if _, err := svc.ExecuteCampaignSpec(userCtx, ExecuteCampaignSpecOpts{RawSpec: "description: no name", NamespaceUserID: user.ID}); err == nil {
+			t.Fatal("invalid spec executed")
+		}
+
+		if _, err := svc.ExecuteCampaignSpec(userCtx, ExecuteCampaignSpecOpts{RawSpec: opts.RawSpec, NamespaceUserID: admin.ID}); !errcode.IsUnauthorized(err) {
+			t.Fatalf("expected unauthorized error, got %+v", err)
+		}
+
+		exec, err := svc.ExecuteCampaignSpec(userCtx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exec.UserID != user.ID {
+			t.Fatalf("wrong user. want=%d, have=%d", user.ID, exec.UserID)
+		}
+		if exec.State != campaigns.ReconcilerStateQueued {
+			t.Fatalf("wrong state. want=%s, have=%s", campaigns.ReconcilerStateQueued, exec.State)
+		}
+	})
+
+	t.Run("CampaignVisible", func(t *testing.T) {
+		org, err := db.Orgs.Create(ctx, "org-campaign-visibility", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.OrgMembers.Create(ctx, org.ID, user.ID); err != nil {
+			t.Fatal(err)
+		}
+		nonMember := createTestUser(ctx, t)
+
+		publicCampaign := &campaigns.Campaign{InitialApplierID: admin.ID, NamespaceOrgID: org.ID, Visibility: campaigns.CampaignVisibilityPublic}
+		orgCampaign := &campaigns.Campaign{InitialApplierID: admin.ID, NamespaceOrgID: org.ID, Visibility: campaigns.CampaignVisibilityNamespaceOnly}
+		userCampaign := &campaigns.Campaign{InitialApplierID: nonMember.ID, NamespaceUserID: nonMember.ID, Visibility: campaigns.CampaignVisibilityNamespaceOnly}
+
+		tests := []struct {
+			name        string
+			campaign    *campaigns.Campaign
+			user        int32
+			wantVisible bool
+		}{
+			{name: "public, anonymous", campaign: publicCampaign, wantVisible: true},
+			{name: "namespace-only, anonymous", campaign: orgCampaign},
+			{name: "namespace-only, org member", campaign: orgCampaign, user: user.ID, wantVisible: true},
+			{name: "namespace-only, non-member", campaign: orgCampaign, user: nonMember.ID},
+			{name: "namespace-only, namespace user", campaign: userCampaign, user: nonMember.ID, wantVisible: true},
+			{name: "namespace-only, other user", campaign: userCampaign, user: user.ID},
+			{name: "namespace-only, site admin", campaign: userCampaign, user: admin.ID, wantVisible: true},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				userCtx := context.Background()
+				if tc.user != 0 {
+					userCtx = actor.WithActor(userCtx, actor.FromUser(tc.user))
+				}
+
+				visible, err := CampaignVisible(userCtx, store, tc.campaign)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if visible != tc.wantVisible {
+					t.Fatalf("wrong visibility. want=%t, have=%t", tc.wantVisible, visible)
+				}
+			})
+		}
 	})
 
 	t.Run("GetCampaignMatchingCampaignSpec", func(t *testing.T) {
@@ -924,6 +2551,30 @@ func TestServiceApplyCampaign(t *testing.T) {
 				}
 			})
 
+			t.Run("apply new campaign spec exceeding open campaigns quota", func(t *testing.T) {
+				user2 := createTestUser(ctx, t)
+				campaignSpec := createCampaignSpec(t, ctx, store, "quota-1", user2.ID)
+				createCampaign(t, ctx, store, "quota-1", user2.ID, campaignSpec.ID)
+
+				conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+					CampaignsNamespaces: &schema.CampaignsNamespaces{MaxOpenCampaigns: 1},
+				}})
+				defer conf.Mock(nil)
+
+				// Applying to the existing campaign is still possible.
+				campaignSpec2 := createCampaignSpec(t, ctx, store, "quota-1", user2.ID)
+				if _, err := svc.ApplyCampaign(adminCtx, ApplyCampaignOpts{CampaignSpecRandID: campaignSpec2.RandID}); err != nil {
+					t.Fatal(err)
+				}
+
+				campaignSpec3 := createCampaignSpec(t, ctx, store, "quota-2", user2.ID)
+				_, err := svc.ApplyCampaign(adminCtx, ApplyCampaignOpts{CampaignSpecRandID: campaignSpec3.RandID})
+				want := &openCampaignLimitExceededErr{Namespace: user2.Username, Limit: 1}
+				if diff := cmp.Diff(want, err); diff != "" {
+					t.Fatalf("wrong error (-want +got):\n%s", diff)
+				}
+			})
+
 			t.Run("campaign spec with same name and same ensureCampaignID", func(t *testing.T) {
 				campaignSpec2 := createCampaignSpec(t, ctx, store, "campaign2", admin.ID)
 
@@ -962,6 +2613,11 @@ func TestServiceApplyCampaign(t *testing.T) {
 		// mock.
 		syncedBranchName := "refs/heads/synced-branch-name"
 		MockSyncChangesets = func(_ context.Context, _ RepoStore, tx SyncStore, _ *httpcli.Factory, cs ...*campaigns.Changeset) error {
+			// Syncing calls the code host, so it must not happen in the
+			// transaction of ApplyCampaign, which might be retried.
+			if s, ok := tx.(*Store); ok && s.InTransaction() {
+				t.Fatal("changesets synced in the ApplyCampaign transaction")
+			}
 			for _, c := range cs {
 				c.ExternalBranch = syncedBranchName
 				if err := tx.UpdateChangeset(ctx, c); err != nil {
@@ -1240,6 +2896,65 @@ func TestServiceApplyCampaign(t *testing.T) {
 			}
 		})
 
+		t.Run("campaign archiving superseded changesets", func(t *testing.T) {
+			campaignSpec1 := createCampaignSpec(t, ctx, store, "archive-superseded", admin.ID)
+
+			spec1 := createChangesetSpec(t, ctx, store, testSpecOpts{
+				user:         admin.ID,
+				repo:         repos[3].ID,
+				campaignSpec: campaignSpec1.ID,
+				headRef:      "refs/heads/archive-me",
+			})
+
+			_, changesets := applyAndListChangesets(adminCtx, t, svc, campaignSpec1.RandID, 1)
+			c := changesets[0]
+			setChangesetPublished(t, ctx, store, c, "9876", spec1.Spec.HeadRef)
+
+			// Applying a spec that no longer contains the changeset with
+			// ArchiveSuperseded keeps it in the campaign instead of closing
+			// it.
+			campaignSpec2 := createCampaignSpec(t, ctx, store, "archive-superseded", admin.ID)
+			verifyClosed := assertChangesetsClose(t)
+			campaign, err := svc.ApplyCampaign(adminCtx, ApplyCampaignOpts{
+				CampaignSpecRandID: campaignSpec2.RandID,
+				ArchiveSuperseded:  true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			verifyClosed()
+
+			if diff := cmp.Diff([]int64{c.ID}, campaign.ChangesetIDs); diff != "" {
+				t.Fatalf("wrong changeset IDs (-want +got):\n%s", diff)
+			}
+			archived, err := store.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !archived.Archived() {
+				t.Fatal("changeset not archived")
+			}
+
+			// Applying again without the option leaves it archived.
+			campaignSpec3 := createCampaignSpec(t, ctx, store, "archive-superseded", admin.ID)
+			verifyClosed = assertChangesetsClose(t)
+			applyAndListChangesets(adminCtx, t, svc, campaignSpec3.RandID, 1)
+			verifyClosed()
+
+			// And a spec that contains it again unarchives it.
+			campaignSpec4 := createCampaignSpec(t, ctx, store, "archive-superseded", admin.ID)
+			createChangesetSpec(t, ctx, store, testSpecOpts{
+				user:         admin.ID,
+				repo:         repos[3].ID,
+				campaignSpec: campaignSpec4.ID,
+				headRef:      "refs/heads/archive-me",
+			})
+			_, changesets = applyAndListChangesets(adminCtx, t, svc, campaignSpec4.RandID, 1)
+			if changesets[0].Archived() {
+				t.Fatal("changeset still archived")
+			}
+		})
+
 		t.Run("missing repository permissions", func(t *testing.T) {
 			// Single repository filtered out by authzFilter
 			ct.AuthzFilterRepos(t, repos[1].ID)
@@ -1276,6 +2991,157 @@ func TestServiceApplyCampaign(t *testing.T) {
 		})
 	})
 
+	t.Run("PreviewChangesetSpec", func(t *testing.T) {
+		campaignSpec1 := createCampaignSpec(t, ctx, store, "preview-campaign", admin.ID)
+		createChangesetSpec(t, ctx, store, testSpecOpts{
+			user:          admin.ID,
+			repo:          repos[0].ID,
+			campaignSpec:  campaignSpec1.ID,
+			headRef:       "refs/heads/preview",
+			title:         "Title",
+			body:          "Body",
+			commitMessage: "Message",
+			commitDiff:    "-foo\n+bar\n",
+		})
+		_, cs := applyAndListChangesets(adminCtx, t, svc, campaignSpec1.RandID, 1)
+
+		campaignSpec2 := createCampaignSpec(t, ctx, store, "preview-campaign", admin.ID)
+		updated := createChangesetSpec(t, ctx, store, testSpecOpts{
+			user:          admin.ID,
+			repo:          repos[0].ID,
+			campaignSpec:  campaignSpec2.ID,
+			headRef:       "refs/heads/preview",
+			title:         "New title",
+			body:          "Body",
+			commitMessage: "Message",
+			commitDiff:    "-foo\n+baz\n",
+		})
+		created := createChangesetSpec(t, ctx, store, testSpecOpts{
+			user:         admin.ID,
+			repo:         repos[0].ID,
+			campaignSpec: campaignSpec2.ID,
+			headRef:      "refs/heads/other-branch",
+		})
+
+		preview, err := svc.PreviewChangesetSpec(adminCtx, updated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if preview == nil {
+			t.Fatal("no preview for spec of existing changeset")
+		}
+		if have, want := preview.Changeset.ID, cs[0].ID; have != want {
+			t.Fatalf("wrong changeset. want=%d, have=%d", want, have)
+		}
+		wantDelta := &ChangesetSpecDelta{TitleChanged: true, DiffChanged: true}
+		if diff := cmp.Diff(wantDelta, preview.Delta); diff != "" {
+			t.Fatal(diff)
+		}
+		diffOfDiffs, err := preview.DiffOfDiffs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "--- current\n+++ previewed\n@@ -1,2 +1,2 @@\n -foo\n-+bar\n++baz\n"; diffOfDiffs != want {
+			t.Fatalf("wrong diff of diffs. want=%q, have=%q", want, diffOfDiffs)
+		}
+
+		preview, err = svc.PreviewChangesetSpec(adminCtx, created)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if preview != nil {
+			t.Fatalf("preview for spec of new changeset: %+v", preview)
+		}
+	})
+
+	t.Run("DiffCampaignSpec", func(t *testing.T) {
+		specOpts := func(campaignSpecID int64, headRef, title string) testSpecOpts {
+			return testSpecOpts{
+				user:          admin.ID,
+				repo:          repos[0].ID,
+				campaignSpec:  campaignSpecID,
+				headRef:       headRef,
+				title:         title,
+				commitMessage: "Message",
+				commitDiff:    "-foo\n+bar\n",
+			}
+		}
+
+		campaignSpec1 := createCampaignSpec(t, ctx, store, "diff-campaign", admin.ID)
+		for _, headRef := range []string{"refs/heads/unchanged", "refs/heads/updated", "refs/heads/closed", "refs/heads/detached"} {
+			createChangesetSpec(t, ctx, store, specOpts(campaignSpec1.ID, headRef, "Title"))
+		}
+		campaign, cs := applyAndListChangesets(adminCtx, t, svc, campaignSpec1.RandID, 4)
+
+		changesetsByHeadRef := map[string]*campaigns.Changeset{}
+		for _, c := range cs {
+			spec, err := store.GetChangesetSpecByID(ctx, c.CurrentSpecID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			changesetsByHeadRef[spec.Spec.HeadRef] = c
+		}
+		closed := changesetsByHeadRef["refs/heads/closed"]
+		closed.PublicationState = campaigns.ChangesetPublicationStatePublished
+		if err := store.UpdateChangeset(ctx, closed); err != nil {
+			t.Fatal(err)
+		}
+
+		campaignSpec2 := createCampaignSpec(t, ctx, store, "diff-campaign", admin.ID)
+		unchanged := createChangesetSpec(t, ctx, store, specOpts(campaignSpec2.ID, "refs/heads/unchanged", "Title"))
+		updated := createChangesetSpec(t, ctx, store, specOpts(campaignSpec2.ID, "refs/heads/updated", "New title"))
+		added := createChangesetSpec(t, ctx, store, specOpts(campaignSpec2.ID, "refs/heads/added", "Title"))
+
+		diff, err := svc.DiffCampaignSpec(adminCtx, campaignSpec2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff.Campaign == nil || diff.Campaign.ID != campaign.ID {
+			t.Fatalf("wrong campaign. want=%d, have=%+v", campaign.ID, diff.Campaign)
+		}
+		if diff := cmp.Diff([]*campaigns.ChangesetSpec{added}, diff.Added); diff != "" {
+			t.Fatalf("wrong added specs: %s", diff)
+		}
+		if diff := cmp.Diff([]*campaigns.ChangesetSpec{unchanged}, diff.Unchanged); diff != "" {
+			t.Fatalf("wrong unchanged specs: %s", diff)
+		}
+		if len(diff.Updated) != 1 {
+			t.Fatalf("wrong number of updated specs. want=1, have=%d", len(diff.Updated))
+		}
+		if have, want := diff.Updated[0].Spec.ID, updated.ID; have != want {
+			t.Fatalf("wrong updated spec. want=%d, have=%d", want, have)
+		}
+		if have, want := diff.Updated[0].Changeset.ID, changesetsByHeadRef["refs/heads/updated"].ID; have != want {
+			t.Fatalf("wrong updated changeset. want=%d, have=%d", want, have)
+		}
+		if diff := cmp.Diff(&ChangesetSpecDelta{TitleChanged: true}, diff.Updated[0].Delta); diff != "" {
+			t.Fatal(diff)
+		}
+		if diff := cmp.Diff([]int64{closed.ID}, diff.Closed.IDs()); diff != "" {
+			t.Fatalf("wrong closed changesets: %s", diff)
+		}
+		if diff := cmp.Diff([]int64{changesetsByHeadRef["refs/heads/detached"].ID}, diff.Detached.IDs()); diff != "" {
+			t.Fatalf("wrong detached changesets: %s", diff)
+		}
+
+		t.Run("new campaign", func(t *testing.T) {
+			campaignSpec := createCampaignSpec(t, ctx, store, "diff-new-campaign", admin.ID)
+			spec := createChangesetSpec(t, ctx, store, specOpts(campaignSpec.ID, "refs/heads/added", "Title"))
+
+			diff, err := svc.DiffCampaignSpec(adminCtx, campaignSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.Campaign != nil {
+				t.Fatalf("campaign returned for new campaign: %+v", diff.Campaign)
+			}
+			if diff := cmp.Diff([]*campaigns.ChangesetSpec{spec}, diff.Added); diff != "" {
+				t.Fatalf("wrong added specs: %s", diff)
+			}
+		})
+	})
+
 	t.Run("applying to closed campaign", func(t *testing.T) {
 		campaignSpec := createCampaignSpec(t, ctx, store, "closed-campaign", admin.ID)
 		campaign := createCampaign(t, ctx, store, "closed-campaign", admin.ID, campaignSpec.ID)
@@ -0,0 +1,175 @@
+package campaigns
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// specExpirationRunRetention is how long the SpecExpirationRuns of the spec
+// expirer are kept.
+const specExpirationRunRetention = 7 * 24 * time.Hour
+
+// specExpirationInterval and specExpirationDryRun return the
+// campaigns.specExpiration site configuration settings of the spec expirer.
+// They're variables so that they can be mocked in tests.
+var (
+	specExpirationInterval = campaigns.SpecExpirationInterval
+	specExpirationDryRun   = campaigns.SpecExpirationDryRun
+)
+
+// errSpecExpirationDryRun rolls back the transaction in which a dry run of
+// the spec expirer deletes the expired specs.
+var errSpecExpirationDryRun = errors.New("spec expiration dry run")
+
+var specExpirerMetrics = struct {
+	runs     *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	deleted  *prometheus.CounterVec
+}{}
+
+func init() {
+	specExpirerMetrics.runs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_repoupdater_campaigns_spec_expirer_runs",
+		Help: "Total number of runs of the campaigns spec expirer",
+	}, []string{"dry_run", "success"})
+	specExpirerMetrics.duration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_repoupdater_campaigns_spec_expirer_duration_seconds",
+		Help:    "Time spent deleting expired campaign specs, changeset specs and campaigns",
+		Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60},
+	}, []string{"dry_run", "success"})
+	specExpirerMetrics.deleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_repoupdater_campaigns_spec_expirer_deleted",
+		Help: "Total number of rows deleted by the campaigns spec expirer, or that would have been deleted by dry runs",
+	}, []string{"kind", "dry_run"})
+}
+
+// RunSpecExpirer periodically deletes the ChangesetSpecs and CampaignSpecs
+// that expired, and the deleted Campaigns that can no longer be restored, as
+// configured in the campaigns.specExpiration site configuration setting. Each
+// run is recorded as a SpecExpirationRun. It runs until the given context is
+// canceled. If locker is not nil, specs are only deleted by the replica
+// that's the leader of the spec expiry job.
+func RunSpecExpirer(ctx context.Context, s *Store, locker *Locker) {
+	e := &specExpirer{store: s}
+	locker.DoAsLeader(ctx, LeaderJobSpecExpiry, e.loop)
+}
+
+type specExpirer struct {
+	store *Store
+}
+
+func (e *specExpirer) loop(ctx context.Context) {
+	for {
+		if _, err := e.run(ctx); err != nil {
+			log15.Error("Deleting expired campaign specs", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(specExpirationInterval()):
+		}
+	}
+}
+
+// expiredIDs are the IDs of the rows deleted by a run of the spec expirer.
+type expiredIDs struct {
+	changesetSpecs []int64
+	campaignSpecs  []int64
+	campaigns      []int64
+}
+
+// run deletes the expired specs and records the run. In a dry run, nothing
+// is deleted and the IDs of what would have been deleted are logged instead.
+func (e *specExpirer) run(ctx context.Context) (*campaigns.SpecExpirationRun, error) {
+	r := &campaigns.SpecExpirationRun{
+		StartedAt: e.store.now(),
+		DryRun:    specExpirationDryRun(),
+	}
+
+	start := time.Now()
+	ids, err := e.deleteExpired(ctx, r.DryRun)
+	r.FinishedAt = e.store.now()
+	r.ChangesetSpecsDeleted = int32(len(ids.changesetSpecs))
+	r.CampaignSpecsDeleted = int32(len(ids.campaignSpecs))
+	r.CampaignsDeleted = int32(len(ids.campaigns))
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	dryRun := strconv.FormatBool(r.DryRun)
+	success := strconv.FormatBool(err == nil)
+	specExpirerMetrics.runs.WithLabelValues(dryRun, success).Inc()
+	specExpirerMetrics.duration.WithLabelValues(dryRun, success).Observe(time.Since(start).Seconds())
+	specExpirerMetrics.deleted.WithLabelValues("changeset_spec", dryRun).Add(float64(r.ChangesetSpecsDeleted))
+	specExpirerMetrics.deleted.WithLabelValues("campaign_spec", dryRun).Add(float64(r.CampaignSpecsDeleted))
+	specExpirerMetrics.deleted.WithLabelValues("campaign", dryRun).Add(float64(r.CampaignsDeleted))
+
+	if r.DryRun {
+		log15.Info("Dry run of campaigns spec expiration",
+			"changesetSpecs", ids.changesetSpecs,
+			"campaignSpecs", ids.campaignSpecs,
+			"campaigns", ids.campaigns,
+		)
+	}
+
+	if createErr := e.store.CreateSpecExpirationRun(ctx, r); createErr != nil {
+		err = multierror.Append(err, errors.Wrap(createErr, "recording spec expiration run"))
+	}
+	if deleteErr := e.store.DeleteSpecExpirationRuns(ctx, r.StartedAt.Add(-specExpirationRunRetention)); deleteErr != nil {
+		err = multierror.Append(err, errors.Wrap(deleteErr, "deleting old spec expiration runs"))
+	}
+
+	return r, err
+}
+
+// deleteExpired deletes the expired specs and returns their IDs. In a dry
+// run, they're deleted in a transaction that's rolled back afterwards, so
+// that the returned IDs are exactly what would have been deleted.
+func (e *specExpirer) deleteExpired(ctx context.Context, dryRun bool) (ids expiredIDs, err error) {
+	if !dryRun {
+		return deleteExpired(ctx, e.store)
+	}
+
+	err = e.store.WithTransact(ctx, func(tx *Store) error {
+		if ids, err = deleteExpired(ctx, tx); err != nil {
+			return err
+		}
+		return errSpecExpirationDryRun
+	})
+	if err == errSpecExpirationDryRun {
+		err = nil
+	}
+	return ids, err
+}
+
+func deleteExpired(ctx context.Context, s *Store) (ids expiredIDs, err error) {
+	var errs *multierror.Error
+
+	// We first need to delete expired ChangesetSpecs...
+	ids.changesetSpecs, err = s.DeleteExpiredChangesetSpecs(ctx)
+	if err != nil {
+		errs = multierror.Append(errs, errors.Wrap(err, "deleting expired changeset specs"))
+	}
+	// ... and then the CampaignSpecs, due to the campaign_spec_id foreign key
+	// on changeset_specs.
+	ids.campaignSpecs, err = s.DeleteExpiredCampaignSpecs(ctx)
+	if err != nil {
+		errs = multierror.Append(errs, errors.Wrap(err, "deleting expired campaign specs"))
+	}
+	// Deleted campaigns can only be restored for a while.
+	ids.campaigns, err = s.DeleteExpiredCampaigns(ctx)
+	if err != nil {
+		errs = multierror.Append(errs, errors.Wrap(err, "deleting expired campaigns"))
+	}
+
+	return ids, errs.ErrorOrNil()
+}
@@ -0,0 +1,92 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func mockSpecExpirationDryRun(t *testing.T, dryRun bool) {
+	t.Helper()
+
+	orig := specExpirationDryRun
+	specExpirationDryRun = func() bool { return dryRun }
+	t.Cleanup(func() { specExpirationDryRun = orig })
+}
+
+func TestSpecExpirer(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now }
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+
+	expired := &campaigns.CampaignSpec{
+		UserID:          admin.ID,
+		NamespaceUserID: admin.ID,
+		CreatedAt:       now.Add(-campaigns.CampaignSpecTTL() - time.Minute),
+	}
+	if err := store.CreateCampaignSpec(ctx, expired); err != nil {
+		t.Fatal(err)
+	}
+	fresh := &campaigns.CampaignSpec{
+		UserID:          admin.ID,
+		NamespaceUserID: admin.ID,
+		CreatedAt:       now,
+	}
+	if err := store.CreateCampaignSpec(ctx, fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	e := &specExpirer{store: store}
+
+	assertRun := func(t *testing.T, dryRun bool, wantExists bool) {
+		t.Helper()
+
+		mockSpecExpirationDryRun(t, dryRun)
+
+		r, err := e.run(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.DryRun != dryRun || r.CampaignSpecsDeleted != 1 || r.ChangesetSpecsDeleted != 0 || r.CampaignsDeleted != 0 {
+			t.Fatalf("unexpected run: %+v", r)
+		}
+
+		last, err := store.GetLastSpecExpirationRun(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last.ID != r.ID {
+			t.Fatalf("wrong last run. want=%d, have=%d", r.ID, last.ID)
+		}
+
+		_, err = store.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: expired.ID})
+		if have := err != ErrNoResults; have != wantExists {
+			t.Fatalf("expired campaign spec exists: want=%t, have=%t (err: %v)", wantExists, have, err)
+		}
+		if _, err := store.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: fresh.ID}); err != nil {
+			t.Fatalf("fresh campaign spec not found: %v", err)
+		}
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		assertRun(t, true, true)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assertRun(t, false, false)
+	})
+}
@@ -2,6 +2,7 @@ package campaigns
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"sort"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -104,6 +106,9 @@ func computeCheckState(c *campaigns.Changeset, events ChangesetEvents) campaigns
 
 	case *gitlab.MergeRequest:
 		return computeGitLabCheckState(c.UpdatedAt, m, events)
+
+	case *bitbucketcloud.PullRequest:
+		return computeBitbucketCloudBuildStatus(c.UpdatedAt, m, events)
 	}
 
 	return campaigns.ChangesetCheckStateUnknown
@@ -183,6 +188,17 @@ func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullR
 	return combineCheckStates(states)
 }
 
+func computeBitbucketCloudBuildStatus(lastSynced time.Time, pr *bitbucketcloud.PullRequest, events []*campaigns.ChangesetEvent) campaigns.ChangesetCheckState {
+	checks := computeBitbucketCloudBuildStatuses(lastSynced, pr, events)
+
+	states := make([]campaigns.ChangesetCheckState, 0, len(checks))
+	for _, c := range checks {
+		states = append(states, c.State)
+	}
+
+	return combineCheckStates(states)
+}
+
 func parseBitbucketBuildState(s string) campaigns.ChangesetCheckState {
 	switch s {
 	case "FAILED":
@@ -353,6 +369,16 @@ func parseGithubCheckSuiteState(status, conclusion string) campaigns.ChangesetCh
 }
 
 func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, events []*campaigns.ChangesetEvent) campaigns.ChangesetCheckState {
+	p := latestGitLabPipeline(lastSynced, mr, events)
+	if p == nil {
+		return campaigns.ChangesetCheckStateUnknown
+	}
+	return parseGitLabPipelineStatus(p.Status)
+}
+
+// latestGitLabPipeline returns the most recent pipeline of the merge request,
+// or nil if it has none.
+func latestGitLabPipeline(lastSynced time.Time, mr *gitlab.MergeRequest, events []*campaigns.ChangesetEvent) *gitlab.Pipeline {
 	// GitLab pipelines aren't tied to commits in the same way that GitHub
 	// checks are. We're simply looking for the most recent pipeline run that
 	// was associated with the merge request, which may live in a changeset
@@ -383,10 +409,7 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 		// HeadPipeline. If that's empty, then we'll shrug and say we don't
 		// know.
 		if len(mr.Pipelines) == 0 {
-			if mr.HeadPipeline != nil {
-				return parseGitLabPipelineStatus(mr.HeadPipeline.Status)
-			}
-			return campaigns.ChangesetCheckStateUnknown
+			return mr.HeadPipeline
 		}
 
 		// Sort into descending order so that the pipeline at index 0 is the latest.
@@ -395,10 +418,10 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 			return pipelines[i].CreatedAt.After(pipelines[j].CreatedAt.Time)
 		})
 
-		return parseGitLabPipelineStatus(pipelines[0].Status)
+		return pipelines[0]
 	}
 
-	return parseGitLabPipelineStatus(lastPipelineEvent.Status)
+	return lastPipelineEvent
 }
 
 func parseGitLabPipelineStatus(status gitlab.PipelineStatus) campaigns.ChangesetCheckState {
@@ -441,6 +464,17 @@ func computeSingleChangesetExternalState(c *campaigns.Changeset) (s campaigns.Ch
 		default:
 			return "", errors.Errorf("unknown GitLab merge request state: %s", m.State)
 		}
+	case *bitbucketcloud.PullRequest:
+		switch m.State {
+		case bitbucketcloud.PullRequestStateDeclined, bitbucketcloud.PullRequestStateSuperseded:
+			s = campaigns.ChangesetExternalStateClosed
+		case bitbucketcloud.PullRequestStateMerged:
+			s = campaigns.ChangesetExternalStateMerged
+		case bitbucketcloud.PullRequestStateOpen:
+			s = campaigns.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -498,6 +532,20 @@ func computeSingleChangesetReviewState(c *campaigns.Changeset) (s campaigns.Chan
 		}
 		return campaigns.ChangesetReviewStatePending, nil
 
+	case *bitbucketcloud.PullRequest:
+		for _, p := range m.Participants {
+			switch p.State {
+			case bitbucketcloud.ParticipantStateApproved:
+				states[campaigns.ChangesetReviewStateApproved] = true
+			case bitbucketcloud.ParticipantStateChangesRequested:
+				states[campaigns.ChangesetReviewStateChangesRequested] = true
+			default:
+				if p.Role == "REVIEWER" {
+					states[campaigns.ChangesetReviewStatePending] = true
+				}
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -663,3 +711,285 @@ func ComputeLabels(c *campaigns.Changeset, events ChangesetEvents) []campaigns.C
 
 	return labels
 }
+
+// ComputeChecks returns the individual checks of the latest commit of the
+// Changeset, sorted by name, based on the checks found in the Changeset and
+// the ChangesetEvents that have occurred after the Changeset.UpdatedAt.
+// The events should be presorted.
+func ComputeChecks(c *campaigns.Changeset, events ChangesetEvents) []campaigns.ChangesetCheck {
+	var checks []campaigns.ChangesetCheck
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		checks = computeGitHubChecks(c.UpdatedAt, m, events)
+
+	case *bitbucketserver.PullRequest:
+		checks = computeBitbucketBuildStatuses(c.UpdatedAt, m, events)
+
+	case *gitlab.MergeRequest:
+		if p := latestGitLabPipeline(c.UpdatedAt, m, events); p != nil {
+			checks = []campaigns.ChangesetCheck{{
+				Name:  fmt.Sprintf("Pipeline #%d", p.ID),
+				State: parseGitLabPipelineStatus(p.Status),
+				URL:   p.WebURL,
+			}}
+		}
+
+	case *bitbucketcloud.PullRequest:
+		checks = computeBitbucketCloudBuildStatuses(c.UpdatedAt, m, events)
+	}
+
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+
+	return checks
+}
+
+func computeGitHubChecks(lastSynced time.Time, pr *github.PullRequest, events []*campaigns.ChangesetEvent) []campaigns.ChangesetCheck {
+	// Like computeGitHubCheckState, we only consider the latest commit.
+	var latestCommitTime time.Time
+	var latestOID string
+	contexts := make(map[string]campaigns.ChangesetCheck)
+	checkRuns := make(map[string]campaigns.ChangesetCheck)
+
+	if len(pr.Commits.Nodes) > 0 {
+		commit := pr.Commits.Nodes[0]
+		latestCommitTime = commit.Commit.CommittedDate
+		latestOID = commit.Commit.OID
+		for _, c := range commit.Commit.Status.Contexts {
+			contexts[c.Context] = campaigns.ChangesetCheck{
+				Name:  c.Context,
+				State: parseGithubCheckState(c.State),
+				URL:   c.TargetURL,
+			}
+		}
+		for _, s := range commit.Commit.CheckSuites.Nodes {
+			for _, r := range s.CheckRuns.Nodes {
+				checkRuns[r.ID] = campaigns.ChangesetCheck{
+					Name:  r.Name,
+					State: parseGithubCheckSuiteState(r.Status, r.Conclusion),
+					URL:   r.DetailsURL,
+				}
+			}
+		}
+	}
+
+	var statuses []*github.CommitStatus
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *github.CommitStatus:
+			if m.ReceivedAt.After(lastSynced) {
+				statuses = append(statuses, m)
+			}
+		case *github.PullRequestCommit:
+			if m.Commit.CommittedDate.After(latestCommitTime) {
+				latestCommitTime = m.Commit.CommittedDate
+				latestOID = m.Commit.OID
+				// The contexts are now out of date, reset them
+				for k := range contexts {
+					delete(contexts, k)
+				}
+			}
+		case *github.CheckRun:
+			if m.ReceivedAt.After(lastSynced) {
+				check := checkRuns[m.ID]
+				if m.Name != "" {
+					check.Name = m.Name
+				}
+				if m.DetailsURL != "" {
+					check.URL = m.DetailsURL
+				}
+				check.State = parseGithubCheckSuiteState(m.Status, m.Conclusion)
+				checkRuns[m.ID] = check
+			}
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ReceivedAt.Before(statuses[j].ReceivedAt)
+	})
+	for _, s := range statuses {
+		if s.SHA != latestOID {
+			continue
+		}
+		contexts[s.Context] = campaigns.ChangesetCheck{
+			Name:  s.Context,
+			State: parseGithubCheckState(s.State),
+			URL:   s.TargetURL,
+		}
+	}
+
+	checks := make([]campaigns.ChangesetCheck, 0, len(contexts)+len(checkRuns))
+	for _, c := range contexts {
+		checks = append(checks, c)
+	}
+	for _, c := range checkRuns {
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func computeBitbucketBuildStatuses(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*campaigns.ChangesetEvent) []campaigns.ChangesetCheck {
+	var latestCommit bitbucketserver.Commit
+	for _, c := range pr.Commits {
+		if latestCommit.CommitterTimestamp <= c.CommitterTimestamp {
+			latestCommit = *c
+		}
+	}
+
+	toCheck := func(s bitbucketserver.BuildStatus) campaigns.ChangesetCheck {
+		name := s.Name
+		if name == "" {
+			name = s.Key
+		}
+		return campaigns.ChangesetCheck{
+			Name:  name,
+			State: parseBitbucketBuildState(s.State),
+			URL:   s.Url,
+		}
+	}
+
+	checks := make(map[string]campaigns.ChangesetCheck)
+	for _, status := range pr.CommitStatus {
+		checks[status.Key()] = toCheck(status.Status)
+	}
+
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *bitbucketserver.CommitStatus:
+			if m.Commit != latestCommit.ID {
+				continue
+			}
+			if unixMilliToTime(m.Status.DateAdded).Before(lastSynced) {
+				continue
+			}
+			checks[m.Key()] = toCheck(m.Status)
+		}
+	}
+
+	result := make([]campaigns.ChangesetCheck, 0, len(checks))
+	for _, c := range checks {
+		result = append(result, c)
+	}
+	return result
+}
+
+// computeBitbucketCloudBuildStatuses returns the checks of the source commit
+// of the pull request, based on the synced statuses and the commit status
+// events that arrived after the last sync.
+func computeBitbucketCloudBuildStatuses(lastSynced time.Time, pr *bitbucketcloud.PullRequest, events []*campaigns.ChangesetEvent) []campaigns.ChangesetCheck {
+	toCheck := func(s *bitbucketcloud.PullRequestStatus) campaigns.ChangesetCheck {
+		name := s.Name
+		if name == "" {
+			name = s.Key()
+		}
+		return campaigns.ChangesetCheck{
+			Name:  name,
+			State: parseBitbucketBuildState(s.State),
+			URL:   s.URL,
+		}
+	}
+
+	checks := make(map[string]campaigns.ChangesetCheck)
+	for _, status := range pr.Statuses {
+		checks[status.Key()] = toCheck(status)
+	}
+
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *bitbucketcloud.PullRequestStatus:
+			if m.Commit.Hash != pr.Source.Commit.Hash {
+				continue
+			}
+			if m.UpdatedOn.Before(lastSynced) {
+				continue
+			}
+			checks[m.Key()] = toCheck(m)
+		}
+	}
+
+	result := make([]campaigns.ChangesetCheck, 0, len(checks))
+	for _, c := range checks {
+		result = append(result, c)
+	}
+	return result
+}
+
+// ComputeReviewThreads returns the review threads on lines of files of a
+// changeset, ordered by the time their first comment was created, based on
+// the given ChangesetEvents. Only GitHub and Bitbucket Server are supported.
+func ComputeReviewThreads(events ChangesetEvents) []campaigns.ChangesetReviewThread {
+	var threads []campaigns.ChangesetReviewThread
+
+	// GitHub review comments are stored as separate events, so we group them
+	// by the first comment of their thread.
+	githubThreads := make(map[int64]int)
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *github.PullRequestReviewComment:
+			root := m.ReplyTo.DatabaseID
+			if root == 0 {
+				root = m.DatabaseID
+			}
+
+			i, ok := githubThreads[root]
+			if !ok {
+				i = len(threads)
+				githubThreads[root] = i
+				threads = append(threads, campaigns.ChangesetReviewThread{
+					Resolved: new(bool),
+					Path:     m.Path,
+					Line:     m.Position,
+				})
+			}
+
+			t := &threads[i]
+			*t.Resolved = *t.Resolved || m.ThreadResolved
+			if m.DatabaseID == root {
+				t.Path, t.Line = m.Path, m.Position
+			}
+			t.Comments = append(t.Comments, campaigns.ChangesetReviewComment{
+				Author:    m.Author.Login,
+				Body:      m.Body,
+				URL:       m.URL,
+				CreatedAt: m.CreatedAt,
+			})
+
+		case *bitbucketserver.Activity:
+			// Only comments on lines of files that are added are the start of
+			// a thread. Their replies are nested in them.
+			if m.CommentAction != "ADDED" || m.Comment == nil || m.CommentAnchor == nil {
+				continue
+			}
+
+			t := campaigns.ChangesetReviewThread{
+				Path: m.CommentAnchor.Path,
+				Line: m.CommentAnchor.Line,
+			}
+			var add func(c *bitbucketserver.Comment)
+			add = func(c *bitbucketserver.Comment) {
+				t.Comments = append(t.Comments, campaigns.ChangesetReviewComment{
+					Author:    c.Author.Name,
+					Body:      c.Text,
+					CreatedAt: unixMilliToTime(int64(c.CreatedDate)),
+				})
+				for i := range c.Comments {
+					add(&c.Comments[i])
+				}
+			}
+			add(m.Comment)
+			threads = append(threads, t)
+		}
+	}
+
+	for _, t := range threads {
+		sort.SliceStable(t.Comments, func(i, j int) bool {
+			return t.Comments[i].CreatedAt.Before(t.Comments[j].CreatedAt)
+		})
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].Comments[0].CreatedAt.Before(threads[j].Comments[0].CreatedAt)
+	})
+
+	return threads
+}
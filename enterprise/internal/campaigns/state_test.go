@@ -1,6 +1,7 @@
 package campaigns
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -263,6 +265,77 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeBitbucketCloudBuildStatus(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	lastSynced := now.Add(-1 * time.Minute)
+
+	status := func(hash, key, state string, updatedOn time.Time) *bitbucketcloud.PullRequestStatus {
+		s := &bitbucketcloud.PullRequestStatus{CommitStatus: bitbucketcloud.CommitStatus{
+			Key:       key,
+			State:     state,
+			UpdatedOn: updatedOn,
+		}}
+		s.Commit.Hash = hash
+		return s
+	}
+	statusEvent := func(hash, key, state string) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			Kind:     cmpgn.ChangesetEventKindBitbucketCloudCommitStatus,
+			Metadata: status(hash, key, state, now),
+		}
+	}
+
+	pr := &bitbucketcloud.PullRequest{
+		Statuses: []*bitbucketcloud.PullRequestStatus{
+			status("abcdef", "ctx1", "INPROGRESS", lastSynced),
+		},
+	}
+	pr.Source.Commit.Hash = "abcdef"
+
+	tests := []struct {
+		name   string
+		events []*cmpgn.ChangesetEvent
+		want   cmpgn.ChangesetCheckState
+	}{
+		{
+			name:   "synced statuses only",
+			events: nil,
+			want:   cmpgn.ChangesetCheckStatePending,
+		},
+		{
+			name: "event updates synced status",
+			events: []*cmpgn.ChangesetEvent{
+				statusEvent("abcdef", "ctx1", "SUCCESSFUL"),
+			},
+			want: cmpgn.ChangesetCheckStatePassed,
+		},
+		{
+			name: "new failed status",
+			events: []*cmpgn.ChangesetEvent{
+				statusEvent("abcdef", "ctx1", "SUCCESSFUL"),
+				statusEvent("abcdef", "ctx2", "FAILED"),
+			},
+			want: cmpgn.ChangesetCheckStateFailed,
+		},
+		{
+			name: "status of other commit is ignored",
+			events: []*cmpgn.ChangesetEvent{
+				statusEvent("123456", "ctx1", "SUCCESSFUL"),
+			},
+			want: cmpgn.ChangesetCheckStatePending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := computeBitbucketCloudBuildStatus(lastSynced, pr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Run("no events", func(t *testing.T) {
 		for name, tc := range map[string]struct {
@@ -762,6 +835,192 @@ func TestComputeLabels(t *testing.T) {
 	}
 }
 
+func TestComputeChecks(t *testing.T) {
+	now := time.Now()
+
+	githubPR := &github.PullRequest{}
+	commit := github.CommitWithChecks{}
+	commit.Commit.OID = "deadbeef"
+	commit.Commit.CommittedDate = now.Add(-time.Hour)
+	commit.Commit.Status.Contexts = []github.Context{
+		{Context: "buildkite/sourcegraph", State: "PENDING", TargetURL: "https://buildkite.com/1"},
+	}
+	commit.Commit.CheckSuites.Nodes = []github.CheckSuite{{
+		ID:     "suite",
+		Status: "COMPLETED",
+		CheckRuns: struct{ Nodes []github.CheckRun }{Nodes: []github.CheckRun{
+			{ID: "run", Name: "lint", Status: "COMPLETED", Conclusion: "FAILURE", DetailsURL: "https://github.com/runs/1"},
+		}},
+	}}
+	githubPR.Commits.Nodes = []github.CommitWithChecks{commit}
+
+	tests := []struct {
+		name      string
+		changeset *cmpgn.Changeset
+		events    ChangesetEvents
+		want      []cmpgn.ChangesetCheck
+	}{
+		{
+			name:      "github",
+			changeset: &cmpgn.Changeset{UpdatedAt: now, Metadata: githubPR},
+			want: []cmpgn.ChangesetCheck{
+				{Name: "buildkite/sourcegraph", State: cmpgn.ChangesetCheckStatePending, URL: "https://buildkite.com/1"},
+				{Name: "lint", State: cmpgn.ChangesetCheckStateFailed, URL: "https://github.com/runs/1"},
+			},
+		},
+		{
+			name:      "github with newer events",
+			changeset: &cmpgn.Changeset{UpdatedAt: now, Metadata: githubPR},
+			events: ChangesetEvents{
+				{Metadata: &github.CommitStatus{
+					SHA:        "deadbeef",
+					Context:    "buildkite/sourcegraph",
+					State:      "SUCCESS",
+					TargetURL:  "https://buildkite.com/2",
+					ReceivedAt: now.Add(time.Minute),
+				}},
+				{Metadata: &github.CheckRun{
+					ID:         "run",
+					Status:     "COMPLETED",
+					Conclusion: "SUCCESS",
+					ReceivedAt: now.Add(time.Minute),
+				}},
+				{Metadata: &github.CheckRun{
+					ID:         "old-run",
+					Name:       "old",
+					Status:     "COMPLETED",
+					Conclusion: "SUCCESS",
+					ReceivedAt: now.Add(-time.Minute),
+				}},
+			},
+			want: []cmpgn.ChangesetCheck{
+				{Name: "buildkite/sourcegraph", State: cmpgn.ChangesetCheckStatePassed, URL: "https://buildkite.com/2"},
+				{Name: "lint", State: cmpgn.ChangesetCheckStatePassed, URL: "https://github.com/runs/1"},
+			},
+		},
+		{
+			name: "bitbucket server",
+			changeset: &cmpgn.Changeset{UpdatedAt: now, Metadata: &bitbucketserver.PullRequest{
+				Commits: []*bitbucketserver.Commit{{ID: "deadbeef"}},
+				CommitStatus: []*bitbucketserver.CommitStatus{
+					{Commit: "deadbeef", Status: bitbucketserver.BuildStatus{Key: "build", State: "FAILED", Url: "https://ci.example.com/1"}},
+				},
+			}},
+			want: []cmpgn.ChangesetCheck{
+				{Name: "build", State: cmpgn.ChangesetCheckStateFailed, URL: "https://ci.example.com/1"},
+			},
+		},
+		{
+			name: "gitlab",
+			changeset: &cmpgn.Changeset{UpdatedAt: now, Metadata: &gitlab.MergeRequest{
+				HeadPipeline: &gitlab.Pipeline{ID: 42, Status: gitlab.PipelineStatusSuccess, WebURL: "https://gitlab.com/pipelines/42"},
+			}},
+			want: []cmpgn.ChangesetCheck{
+				{Name: "Pipeline #42", State: cmpgn.ChangesetCheckStatePassed, URL: "https://gitlab.com/pipelines/42"},
+			},
+		},
+		{
+			name:      "gitlab without pipelines",
+			changeset: &cmpgn.Changeset{UpdatedAt: now, Metadata: &gitlab.MergeRequest{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := ComputeChecks(tc.changeset, tc.events)
+			if diff := cmp.Diff(tc.want, have, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestComputeReviewThreads(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+
+	githubComment := func(id, replyTo int64, resolved bool, created time.Time) *cmpgn.ChangesetEvent {
+		c := &github.PullRequestReviewComment{
+			DatabaseID:     id,
+			Author:         github.Actor{Login: "alice"},
+			Body:           fmt.Sprintf("comment %d", id),
+			URL:            fmt.Sprintf("https://github.com/comments/%d", id),
+			CreatedAt:      created,
+			Path:           "README.md",
+			Position:       3,
+			ThreadResolved: resolved,
+		}
+		c.ReplyTo.DatabaseID = replyTo
+		return &cmpgn.ChangesetEvent{Metadata: c}
+	}
+	githubThreadComment := func(id int64, created time.Time) cmpgn.ChangesetReviewComment {
+		return cmpgn.ChangesetReviewComment{
+			Author:    "alice",
+			Body:      fmt.Sprintf("comment %d", id),
+			URL:       fmt.Sprintf("https://github.com/comments/%d", id),
+			CreatedAt: created,
+		}
+	}
+	resolved, unresolved := true, false
+
+	events := ChangesetEvents{
+		githubComment(1, 0, false, now),
+		githubComment(2, 1, true, now.Add(time.Minute)),
+		githubComment(3, 0, false, now.Add(2*time.Minute)),
+		{Metadata: &bitbucketserver.Activity{
+			CommentAction: "ADDED",
+			Comment: &bitbucketserver.Comment{
+				Text:        "Please fix",
+				Author:      bitbucketserver.User{Name: "bob"},
+				CreatedDate: int(now.Add(-time.Minute).UnixNano() / int64(time.Millisecond)),
+				Comments: []bitbucketserver.Comment{{
+					Text:        "Done",
+					Author:      bitbucketserver.User{Name: "alice"},
+					CreatedDate: int(now.UnixNano() / int64(time.Millisecond)),
+				}},
+			},
+			CommentAnchor: &bitbucketserver.CommentAnchor{Path: "main.go", Line: 42},
+		}},
+		// Comments that aren't on a line of a file are no review threads.
+		{Metadata: &bitbucketserver.Activity{
+			CommentAction: "ADDED",
+			Comment:       &bitbucketserver.Comment{Text: "LGTM"},
+		}},
+	}
+
+	want := []cmpgn.ChangesetReviewThread{
+		{
+			Path: "main.go",
+			Line: 42,
+			Comments: []cmpgn.ChangesetReviewComment{
+				{Author: "bob", Body: "Please fix", CreatedAt: now.Add(-time.Minute)},
+				{Author: "alice", Body: "Done", CreatedAt: now},
+			},
+		},
+		{
+			Resolved: &resolved,
+			Path:     "README.md",
+			Line:     3,
+			Comments: []cmpgn.ChangesetReviewComment{
+				githubThreadComment(1, now),
+				githubThreadComment(2, now.Add(time.Minute)),
+			},
+		},
+		{
+			Resolved: &unresolved,
+			Path:     "README.md",
+			Line:     3,
+			Comments: []cmpgn.ChangesetReviewComment{
+				githubThreadComment(3, now.Add(2*time.Minute)),
+			},
+		},
+	}
+
+	have := ComputeReviewThreads(events)
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *campaigns.Changeset {
 	return &campaigns.Changeset{
 		ExternalServiceType: extsvc.TypeBitbucketServer,
@@ -7,14 +7,20 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
+	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 // seededRand is used to populate the RandID fields on CampaignSpec and
-// ChangesetSpec when creating them.
+// ChangesetSpec when creating them, and the bulk groups of ChangesetJobs.
 var seededRand *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
 
 // ErrNoResults is returned by Store method calls that found no results.
@@ -24,7 +30,11 @@ var ErrNoResults = errors.New("no results")
 // from persistent storage.
 type Store struct {
 	*basestore.Store
-	now func() time.Time
+	// replica, if set, is the store that the read-only list, count and
+	// statistics queries run against outside of transactions. See
+	// WithReadReplica.
+	replica *basestore.Store
+	now     func() time.Time
 }
 
 // NewStore returns a new Store backed by the given db.
@@ -41,6 +51,33 @@ func NewStoreWithClock(db dbutil.DB, clock func() time.Time) *Store {
 	return &Store{Store: basestore.NewWithHandle(handle), now: clock}
 }
 
+// WithReadReplica returns a copy of the Store that runs the read-only queries
+// listing and counting campaigns, changesets and changeset events, and
+// computing campaign statistics, against the given read replica of the
+// database, unless the Store is in a transaction. All other queries still run
+// against the primary.
+//
+// Results read from the replica can lag behind writes made through the
+// Store, so callers that read their own writes need to do so in a
+// transaction.
+func (s *Store) WithReadReplica(replica dbutil.DB) *Store {
+	return &Store{
+		Store:   s.Store,
+		replica: basestore.NewWithHandle(basestore.NewHandleWithDB(replica)),
+		now:     s.now,
+	}
+}
+
+// reader returns the basestore.Store that read-only queries run against: the
+// read replica, if one is set and the Store isn't in a transaction, and the
+// primary otherwise.
+func (s *Store) reader() *basestore.Store {
+	if s.replica == nil || s.InTransaction() {
+		return s.Store
+	}
+	return s.replica
+}
+
 // Clock returns the clock used by the Store.
 func (s *Store) Clock() func() time.Time { return s.now }
 
@@ -60,7 +97,7 @@ func (s *Store) Handle() *basestore.TransactableHandle { return s.Store.Handle()
 // underlying basestore.Store.
 // Needed to implement the basestore.Store interface
 func (s *Store) With(other basestore.ShareableStore) *Store {
-	return &Store{Store: s.Store.With(other), now: s.now}
+	return &Store{Store: s.Store.With(other), replica: s.replica, now: s.now}
 }
 
 // Transact creates a new transaction.
@@ -74,12 +111,109 @@ func (s *Store) Transact(ctx context.Context) (*Store, error) {
 	return &Store{Store: txBase, now: s.now}, nil
 }
 
-func (s *Store) query(ctx context.Context, q *sqlf.Query, sc scanFunc) error {
-	rows, err := s.Store.Query(ctx, q)
+// maxTransactAttempts is how often WithTransact attempts to run a
+// transaction that fails due to a serialization failure or a deadlock.
+const maxTransactAttempts = 3
+
+// transactRetryBackoff is how long WithTransact waits before it retries a
+// transaction for the first time. It doubles with each further retry.
+var transactRetryBackoff = 50 * time.Millisecond
+
+// WithTransact runs fn in a new transaction, which is committed if fn
+// returns nil and rolled back otherwise.
+//
+// If Postgres aborts the transaction due to a serialization failure or a
+// deadlock, which happens when the reconciler and ApplyCampaign update the
+// same changesets at the same time, fn is run again in a new transaction, up
+// to maxTransactAttempts times. fn must not have side effects outside of the
+// transaction that can't be repeated.
+//
+// If the Store is already in a transaction, fn runs in a savepoint and isn't
+// retried, since the failure aborts the outer transaction too.
+//
+// Only ApplyCampaign, which races with the reconciler, and the dry runs of
+// the spec expirer use WithTransact. The other Service methods make a single
+// attempt with Transact.
+func (s *Store) WithTransact(ctx context.Context, fn func(tx *Store) error) (err error) {
+	retry := !s.InTransaction()
+	backoff := transactRetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		err = s.transact(ctx, fn)
+		if !retry || attempt >= maxTransactAttempts || !isRetryableTransactionErr(err) {
+			return err
+		}
+
+		log15.Warn("Retrying campaigns transaction", "attempt", attempt, "err", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+func (s *Store) transact(ctx context.Context, fn func(tx *Store) error) (err error) {
+	tx, err := s.Transact(ctx)
 	if err != nil {
 		return err
 	}
-	return scanAll(rows, sc)
+	defer func() { err = tx.Done(err) }()
+
+	return fn(tx)
+}
+
+// isRetryableTransactionErr returns whether the given error means that
+// Postgres aborted the transaction because it conflicted with a concurrent
+// one, so that it can be retried.
+func isRetryableTransactionErr(err error) bool {
+	return dbutil.IsPostgresError(err, "serialization_failure") ||
+		dbutil.IsPostgresError(err, "deadlock_detected")
+}
+
+// Query runs the given query and returns its rows. It shadows the Query
+// method of the underlying basestore.Store, so that the query is observed.
+func (s *Store) Query(ctx context.Context, q *sqlf.Query) (_ *sql.Rows, err error) {
+	ctx, done := observeStoreQuery(ctx, q)
+	defer func() { done(0, &err) }()
+
+	return s.Store.Query(ctx, q)
+}
+
+// Exec runs the given query and discards its result. It shadows the Exec
+// method of the underlying basestore.Store, so that the query is observed.
+func (s *Store) Exec(ctx context.Context, q *sqlf.Query) (err error) {
+	ctx, done := observeStoreQuery(ctx, q)
+	defer func() { done(0, &err) }()
+
+	return s.Store.Exec(ctx, q)
+}
+
+func (s *Store) query(ctx context.Context, q *sqlf.Query, scan scanFunc) error {
+	return queryWith(ctx, s.Store, q, scan)
+}
+
+// readQuery is like query, but runs the read-only query against the read
+// replica if one is set. See reader.
+func (s *Store) readQuery(ctx context.Context, q *sqlf.Query, scan scanFunc) error {
+	return queryWith(ctx, s.reader(), q, scan)
+}
+
+func queryWith(ctx context.Context, db *basestore.Store, q *sqlf.Query, scan scanFunc) (err error) {
+	var count float64
+	ctx, done := observeStoreQuery(ctx, q)
+	defer func() { done(count, &err) }()
+
+	rows, err := db.Query(ctx, q)
+	if err != nil {
+		return err
+	}
+	return scanAll(rows, func(sc scanner) error {
+		count++
+		return scan(sc)
+	})
 }
 
 func (s *Store) queryCount(ctx context.Context, q *sqlf.Query) (int, error) {
@@ -90,6 +224,27 @@ func (s *Store) queryCount(ctx context.Context, q *sqlf.Query) (int, error) {
 	return count, nil
 }
 
+// queryIDs runs the given query and returns the int64 IDs in the first column
+// of its rows.
+func (s *Store) queryIDs(ctx context.Context, q *sqlf.Query) (ids []int64, err error) {
+	err = s.query(ctx, q, func(sc scanner) error {
+		var id int64
+		if err := sc.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+	return ids, err
+}
+
+// readQueryCount is like queryCount, but runs the query against the read
+// replica if one is set. See reader.
+func (s *Store) readQueryCount(ctx context.Context, q *sqlf.Query) (count int, err error) {
+	err = s.readQuery(ctx, q, func(sc scanner) error { return sc.Scan(&count) })
+	return count, err
+}
+
 // scanner captures the Scan method of sql.Rows and sql.Row
 type scanner interface {
 	Scan(dst ...interface{}) error
@@ -111,6 +266,56 @@ func scanAll(rows *sql.Rows, scan scanFunc) (err error) {
 	return rows.Err()
 }
 
+// storeMetrics ensures that the operation metrics of the Store are
+// constructed only once, since many Stores are instantiated in a single
+// process.
+var storeMetrics = &metrics.SingletonOperationMetrics{}
+
+func storeOperationMetrics() *metrics.OperationMetrics {
+	return storeMetrics.Get(func() *metrics.OperationMetrics {
+		return metrics.NewOperationMetrics(
+			prometheus.DefaultRegisterer,
+			"campaigns_store",
+			metrics.WithLabels("op"),
+			metrics.WithCountHelp("Total number of rows returned"),
+		)
+	})
+}
+
+// storeQuerySourcePattern matches the "-- source:" comment that every query
+// of the Store starts with and captures the name of the Store method.
+var storeQuerySourcePattern = lazyregexp.New(`-- source: \S+\.go:(\w+)`)
+
+// storeQueryOp returns the name of the Store method that built the given
+// query, which is used as the "op" label of the store metrics.
+func storeQueryOp(q *sqlf.Query) string {
+	m := storeQuerySourcePattern.FindStringSubmatch(q.Query(sqlf.PostgresBindVar))
+	if m == nil {
+		return "unknown"
+	}
+	return m[1]
+}
+
+// observeStoreQuery starts a trace span for the given query, logging the query
+// and its arguments, and starts observing its duration. The returned func
+// finishes the span and records the duration, the number of returned rows
+// and whether the query failed.
+func observeStoreQuery(ctx context.Context, q *sqlf.Query) (context.Context, func(count float64, err *error)) {
+	op := storeQueryOp(q)
+
+	tr, ctx := trace.New(ctx, "campaigns.Store."+op, "")
+	tr.LogFields(trace.SQL(q))
+
+	start := time.Now()
+	return ctx, func(count float64, err *error) {
+		storeOperationMetrics().Observe(time.Since(start).Seconds(), count, err, op)
+
+		tr.LogFields(otlog.Int("rows", int(count)))
+		tr.SetError(*err)
+		tr.Finish()
+	}
+}
+
 func jsonbColumn(metadata interface{}) (msg json.RawMessage, err error) {
 	switch m := metadata.(type) {
 	case nil:
@@ -135,6 +340,13 @@ func jsonSetColumn(ids []int64) ([]byte, error) {
 	return json.Marshal(set)
 }
 
+func jsonStringMapColumn(m map[string]string) ([]byte, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
 func nullInt32Column(n int32) *int32 {
 	if n == 0 {
 		return nil
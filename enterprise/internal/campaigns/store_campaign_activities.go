@@ -0,0 +1,198 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignActivityColumns are used by the campaign activity related Store
+// methods to insert and query campaign activities.
+var campaignActivityColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_activities.id"),
+	sqlf.Sprintf("campaign_activities.campaign_id"),
+	sqlf.Sprintf("campaign_activities.user_id"),
+	sqlf.Sprintf("campaign_activities.changeset_id"),
+	sqlf.Sprintf("campaign_activities.kind"),
+	sqlf.Sprintf("campaign_activities.metadata"),
+	sqlf.Sprintf("campaign_activities.created_at"),
+}
+
+// campaignActivityInsertColumns is the list of campaign_activities columns
+// that are modified when inserting campaign activities.
+var campaignActivityInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("changeset_id"),
+	sqlf.Sprintf("kind"),
+	sqlf.Sprintf("metadata"),
+	sqlf.Sprintf("created_at"),
+}
+
+// CreateCampaignActivity creates the given CampaignActivity.
+func (s *Store) CreateCampaignActivity(ctx context.Context, a *campaigns.CampaignActivity) error {
+	q, err := s.createCampaignActivityQuery(a)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignActivity(a, sc) })
+}
+
+var createCampaignActivityQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_activities.go:CreateCampaignActivity
+INSERT INTO campaign_activities (%s)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *Store) createCampaignActivityQuery(a *campaigns.CampaignActivity) (*sqlf.Query, error) {
+	var metadata interface{}
+	if a.Metadata != nil {
+		metadata = a.Metadata
+	}
+
+	meta, err := jsonbColumn(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = s.now()
+	}
+
+	return sqlf.Sprintf(
+		createCampaignActivityQueryFmtstr,
+		sqlf.Join(campaignActivityInsertColumns, ", "),
+		a.CampaignID,
+		nullInt32Column(a.UserID),
+		nullInt64Column(a.ChangesetID),
+		a.Kind,
+		meta,
+		a.CreatedAt,
+		sqlf.Join(campaignActivityColumns, ", "),
+	), nil
+}
+
+// CountCampaignActivitiesOpts captures the query options needed for
+// counting campaign activities.
+type CountCampaignActivitiesOpts struct {
+	CampaignID int64
+}
+
+// CountCampaignActivities returns the number of campaign activities in the
+// database.
+func (s *Store) CountCampaignActivities(ctx context.Context, opts CountCampaignActivitiesOpts) (int, error) {
+	return s.queryCount(ctx, countCampaignActivitiesQuery(&opts))
+}
+
+var countCampaignActivitiesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_activities.go:CountCampaignActivities
+SELECT COUNT(campaign_activities.id)
+FROM campaign_activities
+WHERE %s
+`
+
+func countCampaignActivitiesQuery(opts *CountCampaignActivitiesOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_activities.campaign_id = %s", opts.CampaignID))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(countCampaignActivitiesQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
+// ListCampaignActivitiesOpts captures the query options needed for
+// listing campaign activities.
+type ListCampaignActivitiesOpts struct {
+	CampaignID int64
+	Cursor     int64
+	Limit      int
+}
+
+// ListCampaignActivities lists CampaignActivities with the given filters.
+func (s *Store) ListCampaignActivities(ctx context.Context, opts ListCampaignActivitiesOpts) (as []*campaigns.CampaignActivity, next int64, err error) {
+	q := listCampaignActivitiesQuery(&opts)
+
+	as = make([]*campaigns.CampaignActivity, 0, opts.Limit)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var a campaigns.CampaignActivity
+		if err := scanCampaignActivity(&a, sc); err != nil {
+			return err
+		}
+		as = append(as, &a)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(as) == opts.Limit {
+		next = as[len(as)-1].ID
+		as = as[:len(as)-1]
+	}
+
+	return as, next, err
+}
+
+var listCampaignActivitiesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_activities.go:ListCampaignActivities
+SELECT %s FROM campaign_activities
+WHERE %s
+ORDER BY id ASC
+`
+
+func listCampaignActivitiesQuery(opts *ListCampaignActivitiesOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("campaign_activities.id >= %s", opts.Cursor),
+	}
+
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_activities.campaign_id = %s", opts.CampaignID))
+	}
+
+	return sqlf.Sprintf(
+		listCampaignActivitiesQueryFmtstr+limitClause,
+		sqlf.Join(campaignActivityColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanCampaignActivity(a *campaigns.CampaignActivity, s scanner) error {
+	var metadata json.RawMessage
+
+	err := s.Scan(
+		&a.ID,
+		&a.CampaignID,
+		&dbutil.NullInt32{N: &a.UserID},
+		&dbutil.NullInt64{N: &a.ChangesetID},
+		&a.Kind,
+		&metadata,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign activity")
+	}
+
+	if err = json.Unmarshal(metadata, &a.Metadata); err != nil {
+		return errors.Wrap(err, "scanCampaignActivity: failed to unmarshal metadata")
+	}
+
+	return nil
+}
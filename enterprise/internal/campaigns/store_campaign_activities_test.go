@@ -0,0 +1,138 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignActivities(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	activities := make([]*cmpgn.CampaignActivity, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(activities); i++ {
+			a := &cmpgn.CampaignActivity{
+				CampaignID: int64(i%2) + 1,
+				UserID:     int32(i) + 50,
+				Kind:       cmpgn.CampaignActivityKindApplied,
+				Metadata:   map[string]interface{}{"some": "metadata"},
+			}
+
+			if i == 0 {
+				// Check for nullability of fields by not setting them
+				a.UserID = 0
+				a.ChangesetID = 0
+			} else {
+				a.ChangesetID = int64(i) + 100
+				a.Kind = cmpgn.CampaignActivityKindChangesetPublished
+			}
+
+			want := a.Clone()
+			have := a
+
+			err := s.CreateCampaignActivity(ctx, have)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.CreatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			activities = append(activities, a)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountCampaignActivities(ctx, CountCampaignActivitiesOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, len(activities); have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		count, err = s.CountCampaignActivities(ctx, CountCampaignActivitiesOpts{CampaignID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, 2; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("ByCampaignID", func(t *testing.T) {
+			have, _, err := s.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{CampaignID: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := activities[1:2]
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			for i := 1; i <= len(activities); i++ {
+				ts, next, err := s.ListCampaignActivities(ctx, ListCampaignActivitiesOpts{Limit: i})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				{
+					have, want := next, int64(0)
+					if i < len(activities) {
+						want = activities[i].ID
+					}
+
+					if have != want {
+						t.Fatalf("limit: %v: have next %v, want %v", i, have, want)
+					}
+				}
+
+				{
+					have, want := ts, activities[:i]
+					if len(have) != len(want) {
+						t.Fatalf("listed %d activities, want: %d", len(have), len(want))
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatalf("opts: %+v, diff: %s", i, diff)
+					}
+				}
+			}
+		})
+
+		t.Run("WithCursor", func(t *testing.T) {
+			var cursor int64
+			for i := 1; i <= len(activities); i++ {
+				opts := ListCampaignActivitiesOpts{Cursor: cursor, Limit: 1}
+				have, next, err := s.ListCampaignActivities(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := activities[i-1 : i]
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+
+				cursor = next
+			}
+		})
+	})
+}
@@ -0,0 +1,227 @@
+package campaigns
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// campaignChangesetCountsColumns are used by the campaign changeset counts
+// related Store methods to query stored ChangesetCounts.
+var campaignChangesetCountsColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_changeset_counts.time"),
+	sqlf.Sprintf("campaign_changeset_counts.total"),
+	sqlf.Sprintf("campaign_changeset_counts.merged"),
+	sqlf.Sprintf("campaign_changeset_counts.closed"),
+	sqlf.Sprintf("campaign_changeset_counts.open"),
+	sqlf.Sprintf("campaign_changeset_counts.open_approved"),
+	sqlf.Sprintf("campaign_changeset_counts.open_changes_requested"),
+	sqlf.Sprintf("campaign_changeset_counts.open_pending"),
+}
+
+// campaignRepoChangesetCountsColumns are used by the campaign repo changeset
+// counts related Store methods to query stored ChangesetCounts per
+// repository.
+var campaignRepoChangesetCountsColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_repo_changeset_counts.time"),
+	sqlf.Sprintf("campaign_repo_changeset_counts.total"),
+	sqlf.Sprintf("campaign_repo_changeset_counts.merged"),
+	sqlf.Sprintf("campaign_repo_changeset_counts.closed"),
+	sqlf.Sprintf("campaign_repo_changeset_counts.open"),
+	sqlf.Sprintf("campaign_repo_changeset_counts.open_approved"),
+	sqlf.Sprintf("campaign_repo_changeset_counts.open_changes_requested"),
+	sqlf.Sprintf("campaign_repo_changeset_counts.open_pending"),
+}
+
+// CreateCampaignChangesetCounts stores the given ChangesetCounts of the
+// campaign with the given ID. Counts at times for which the campaign already
+// has stored counts are ignored.
+func (s *Store) CreateCampaignChangesetCounts(ctx context.Context, campaignID int64, cs ...*ChangesetCounts) error {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	values := make([]*sqlf.Query, 0, len(cs))
+	for _, c := range cs {
+		values = append(values, sqlf.Sprintf(
+			"(%s, %s, %s, %s, %s, %s, %s, %s, %s)",
+			campaignID,
+			c.Time,
+			c.Total,
+			c.Merged,
+			c.Closed,
+			c.Open,
+			c.OpenApproved,
+			c.OpenChangesRequested,
+			c.OpenPending,
+		))
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(createCampaignChangesetCountsQueryFmtstr, sqlf.Join(values, ",\n")))
+}
+
+var createCampaignChangesetCountsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_changeset_counts.go:CreateCampaignChangesetCounts
+INSERT INTO campaign_changeset_counts (
+  campaign_id,
+  time,
+  total,
+  merged,
+  closed,
+  open,
+  open_approved,
+  open_changes_requested,
+  open_pending
+)
+VALUES %s
+ON CONFLICT (campaign_id, time) DO NOTHING
+`
+
+// ListCampaignChangesetCounts lists the stored ChangesetCounts of the
+// campaign with the given ID, oldest first.
+func (s *Store) ListCampaignChangesetCounts(ctx context.Context, campaignID int64) (cs []*ChangesetCounts, err error) {
+	q := sqlf.Sprintf(
+		listCampaignChangesetCountsQueryFmtstr,
+		sqlf.Join(campaignChangesetCountsColumns, ", "),
+		campaignID,
+	)
+
+	err = s.readQuery(ctx, q, func(sc scanner) error {
+		var c ChangesetCounts
+		if err := scanCampaignChangesetCounts(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var listCampaignChangesetCountsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_changeset_counts.go:ListCampaignChangesetCounts
+SELECT %s FROM campaign_changeset_counts
+WHERE campaign_id = %s
+ORDER BY time ASC
+`
+
+// CreateCampaignRepoChangesetCounts stores the given ChangesetCounts of the
+// campaign with the given ID per repository. Counts at times for which the
+// campaign already has stored counts in the repository are ignored.
+func (s *Store) CreateCampaignRepoChangesetCounts(ctx context.Context, campaignID int64, rcs ...*RepoChangesetCounts) error {
+	var values []*sqlf.Query
+	for _, rc := range rcs {
+		for _, c := range rc.Counts {
+			values = append(values, sqlf.Sprintf(
+				"(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)",
+				campaignID,
+				rc.RepoID,
+				c.Time,
+				c.Total,
+				c.Merged,
+				c.Closed,
+				c.Open,
+				c.OpenApproved,
+				c.OpenChangesRequested,
+				c.OpenPending,
+			))
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(createCampaignRepoChangesetCountsQueryFmtstr, sqlf.Join(values, ",\n")))
+}
+
+var createCampaignRepoChangesetCountsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_changeset_counts.go:CreateCampaignRepoChangesetCounts
+INSERT INTO campaign_repo_changeset_counts (
+  campaign_id,
+  repo_id,
+  time,
+  total,
+  merged,
+  closed,
+  open,
+  open_approved,
+  open_changes_requested,
+  open_pending
+)
+VALUES %s
+ON CONFLICT (campaign_id, repo_id, time) DO NOTHING
+`
+
+// ListCampaignRepoChangesetCounts lists the stored ChangesetCounts of the
+// campaign with the given ID per repository, ordered by repository ID. The
+// counts of each repository are sorted oldest first.
+func (s *Store) ListCampaignRepoChangesetCounts(ctx context.Context, campaignID int64) (rcs []*RepoChangesetCounts, err error) {
+	q := sqlf.Sprintf(
+		listCampaignRepoChangesetCountsQueryFmtstr,
+		sqlf.Join(campaignRepoChangesetCountsColumns, ", "),
+		campaignID,
+	)
+
+	err = s.readQuery(ctx, q, func(sc scanner) error {
+		var (
+			repoID api.RepoID
+			c      ChangesetCounts
+		)
+		if err := scanCampaignRepoChangesetCounts(&repoID, &c, sc); err != nil {
+			return err
+		}
+		if len(rcs) == 0 || rcs[len(rcs)-1].RepoID != repoID {
+			rcs = append(rcs, &RepoChangesetCounts{RepoID: repoID})
+		}
+		last := rcs[len(rcs)-1]
+		last.Counts = append(last.Counts, &c)
+		return nil
+	})
+	return rcs, err
+}
+
+var listCampaignRepoChangesetCountsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_changeset_counts.go:ListCampaignRepoChangesetCounts
+SELECT campaign_repo_changeset_counts.repo_id, %s
+FROM campaign_repo_changeset_counts
+WHERE campaign_id = %s
+ORDER BY repo_id ASC, time ASC
+`
+
+func scanCampaignChangesetCounts(c *ChangesetCounts, sc scanner) error {
+	err := sc.Scan(
+		&c.Time,
+		&c.Total,
+		&c.Merged,
+		&c.Closed,
+		&c.Open,
+		&c.OpenApproved,
+		&c.OpenChangesRequested,
+		&c.OpenPending,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign changeset counts")
+	}
+	c.Time = c.Time.UTC()
+	return nil
+}
+
+func scanCampaignRepoChangesetCounts(repoID *api.RepoID, c *ChangesetCounts, sc scanner) error {
+	err := sc.Scan(
+		repoID,
+		&c.Time,
+		&c.Total,
+		&c.Merged,
+		&c.Closed,
+		&c.Open,
+		&c.OpenApproved,
+		&c.OpenChangesRequested,
+		&c.OpenPending,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign repo changeset counts")
+	}
+	c.Time = c.Time.UTC()
+	return nil
+}
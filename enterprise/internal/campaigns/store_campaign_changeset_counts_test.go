@@ -0,0 +1,90 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+)
+
+func testStoreCampaignChangesetCounts(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	// Foreign key constraints are deferred, so the campaign doesn't need to
+	// exist.
+	const campaignID = int64(4321)
+
+	day := clock.now().UTC().Truncate(24 * time.Hour)
+	counts := []*ChangesetCounts{
+		{Time: day.AddDate(0, 0, -1), Total: 2, Open: 2, OpenApproved: 1, OpenPending: 1},
+		{Time: day, Total: 2, Merged: 1, Open: 1, OpenChangesRequested: 1},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateCampaignChangesetCounts(ctx, campaignID, counts...); err != nil {
+			t.Fatal(err)
+		}
+
+		// Counts at the same time are ignored.
+		dup := &ChangesetCounts{Time: day, Total: 5, Closed: 5}
+		if err := s.CreateCampaignChangesetCounts(ctx, campaignID, dup); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListCampaignChangesetCounts(ctx, campaignID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(counts, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListCampaignChangesetCounts(ctx, campaignID+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("counts of other campaign listed: %v", have)
+		}
+	})
+
+	t.Run("CreateByRepo", func(t *testing.T) {
+		byRepo := []*RepoChangesetCounts{
+			{RepoID: 2, Counts: counts[1:]},
+			{RepoID: 1, Counts: counts},
+		}
+		if err := s.CreateCampaignRepoChangesetCounts(ctx, campaignID, byRepo...); err != nil {
+			t.Fatal(err)
+		}
+
+		// Counts at the same time in the same repository are ignored.
+		dup := &RepoChangesetCounts{RepoID: 1, Counts: []*ChangesetCounts{{Time: day, Total: 5, Closed: 5}}}
+		if err := s.CreateCampaignRepoChangesetCounts(ctx, campaignID, dup); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("ListByRepo", func(t *testing.T) {
+		have, err := s.ListCampaignRepoChangesetCounts(ctx, campaignID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*RepoChangesetCounts{
+			{RepoID: 1, Counts: counts},
+			{RepoID: 2, Counts: counts[1:]},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListCampaignRepoChangesetCounts(ctx, campaignID+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("counts of other campaign listed: %v", have)
+		}
+	})
+}
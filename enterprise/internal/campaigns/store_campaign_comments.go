@@ -0,0 +1,175 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignCommentColumns are used by the campaign comment related Store
+// methods to insert and query campaign comments.
+var campaignCommentColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_comments.id"),
+	sqlf.Sprintf("campaign_comments.campaign_id"),
+	sqlf.Sprintf("campaign_comments.author_id"),
+	sqlf.Sprintf("campaign_comments.body"),
+	sqlf.Sprintf("campaign_comments.created_at"),
+	sqlf.Sprintf("campaign_comments.updated_at"),
+}
+
+// campaignCommentInsertColumns is the list of campaign_comments columns that
+// are modified when inserting campaign comments.
+var campaignCommentInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_id"),
+	sqlf.Sprintf("author_id"),
+	sqlf.Sprintf("body"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// CreateCampaignComment creates the given CampaignComment.
+func (s *Store) CreateCampaignComment(ctx context.Context, c *campaigns.CampaignComment) error {
+	q := s.createCampaignCommentQuery(c)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignComment(c, sc) })
+}
+
+var createCampaignCommentQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_comments.go:CreateCampaignComment
+INSERT INTO campaign_comments (%s)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *Store) createCampaignCommentQuery(c *campaigns.CampaignComment) *sqlf.Query {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = s.now()
+	}
+
+	if c.UpdatedAt.IsZero() {
+		c.UpdatedAt = c.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createCampaignCommentQueryFmtstr,
+		sqlf.Join(campaignCommentInsertColumns, ", "),
+		c.CampaignID,
+		nullInt32Column(c.AuthorID),
+		c.Body,
+		c.CreatedAt,
+		c.UpdatedAt,
+		sqlf.Join(campaignCommentColumns, ", "),
+	)
+}
+
+// CountCampaignCommentsOpts captures the query options needed for counting
+// campaign comments.
+type CountCampaignCommentsOpts struct {
+	CampaignID int64
+}
+
+// CountCampaignComments returns the number of campaign comments in the
+// database.
+func (s *Store) CountCampaignComments(ctx context.Context, opts CountCampaignCommentsOpts) (int, error) {
+	return s.queryCount(ctx, countCampaignCommentsQuery(&opts))
+}
+
+var countCampaignCommentsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_comments.go:CountCampaignComments
+SELECT COUNT(campaign_comments.id)
+FROM campaign_comments
+WHERE %s
+`
+
+func countCampaignCommentsQuery(opts *CountCampaignCommentsOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_comments.campaign_id = %s", opts.CampaignID))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(countCampaignCommentsQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
+// ListCampaignCommentsOpts captures the query options needed for listing
+// campaign comments.
+type ListCampaignCommentsOpts struct {
+	CampaignID int64
+	Cursor     int64
+	Limit      int
+}
+
+// ListCampaignComments lists CampaignComments with the given filters, oldest
+// comments first.
+func (s *Store) ListCampaignComments(ctx context.Context, opts ListCampaignCommentsOpts) (cs []*campaigns.CampaignComment, next int64, err error) {
+	q := listCampaignCommentsQuery(&opts)
+
+	cs = make([]*campaigns.CampaignComment, 0, opts.Limit)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var c campaigns.CampaignComment
+		if err := scanCampaignComment(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(cs) == opts.Limit {
+		next = cs[len(cs)-1].ID
+		cs = cs[:len(cs)-1]
+	}
+
+	return cs, next, err
+}
+
+var listCampaignCommentsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_comments.go:ListCampaignComments
+SELECT %s FROM campaign_comments
+WHERE %s
+ORDER BY id ASC
+`
+
+func listCampaignCommentsQuery(opts *ListCampaignCommentsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("campaign_comments.id >= %s", opts.Cursor),
+	}
+
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_comments.campaign_id = %s", opts.CampaignID))
+	}
+
+	return sqlf.Sprintf(
+		listCampaignCommentsQueryFmtstr+limitClause,
+		sqlf.Join(campaignCommentColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanCampaignComment(c *campaigns.CampaignComment, s scanner) error {
+	err := s.Scan(
+		&c.ID,
+		&c.CampaignID,
+		&dbutil.NullInt32{N: &c.AuthorID},
+		&c.Body,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	return errors.Wrap(err, "scanning campaign comment")
+}
@@ -0,0 +1,135 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignComments(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	comments := make([]*cmpgn.CampaignComment, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(comments); i++ {
+			c := &cmpgn.CampaignComment{
+				CampaignID: int64(i%2) + 1,
+				AuthorID:   int32(i) + 50,
+				Body:       fmt.Sprintf("comment %d", i),
+			}
+
+			if i == 0 {
+				// Check for nullability of fields by not setting them
+				c.AuthorID = 0
+			}
+
+			want := c.Clone()
+			have := c
+
+			err := s.CreateCampaignComment(ctx, have)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			comments = append(comments, c)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountCampaignComments(ctx, CountCampaignCommentsOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, len(comments); have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		count, err = s.CountCampaignComments(ctx, CountCampaignCommentsOpts{CampaignID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, 2; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("ByCampaignID", func(t *testing.T) {
+			have, _, err := s.ListCampaignComments(ctx, ListCampaignCommentsOpts{CampaignID: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := comments[1:2]
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			for i := 1; i <= len(comments); i++ {
+				ts, next, err := s.ListCampaignComments(ctx, ListCampaignCommentsOpts{Limit: i})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				{
+					have, want := next, int64(0)
+					if i < len(comments) {
+						want = comments[i].ID
+					}
+
+					if have != want {
+						t.Fatalf("limit: %v: have next %v, want %v", i, have, want)
+					}
+				}
+
+				{
+					have, want := ts, comments[:i]
+					if len(have) != len(want) {
+						t.Fatalf("listed %d comments, want: %d", len(have), len(want))
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatalf("opts: %+v, diff: %s", i, diff)
+					}
+				}
+			}
+		})
+
+		t.Run("WithCursor", func(t *testing.T) {
+			var cursor int64
+			for i := 1; i <= len(comments); i++ {
+				opts := ListCampaignCommentsOpts{Cursor: cursor, Limit: 1}
+				have, next, err := s.ListCampaignComments(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := comments[i-1 : i]
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+
+				cursor = next
+			}
+		})
+	})
+}
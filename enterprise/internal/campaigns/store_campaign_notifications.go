@@ -0,0 +1,219 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// campaignNotificationSettingsColumns are used by the campaign notification
+// settings related Store methods to query settings.
+var campaignNotificationSettingsColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_notification_settings.campaign_id"),
+	sqlf.Sprintf("campaign_notification_settings.events"),
+	sqlf.Sprintf("campaign_notification_settings.subscriber_ids"),
+	sqlf.Sprintf("campaign_notification_settings.created_at"),
+	sqlf.Sprintf("campaign_notification_settings.updated_at"),
+}
+
+// UpsertCampaignNotificationSettings creates the given
+// CampaignNotificationSettings or replaces the existing settings of their
+// campaign.
+func (s *Store) UpsertCampaignNotificationSettings(ctx context.Context, ns *campaigns.CampaignNotificationSettings) error {
+	if ns.CreatedAt.IsZero() {
+		ns.CreatedAt = s.now()
+	}
+	ns.UpdatedAt = s.now()
+
+	events, err := json.Marshal(notificationEventsColumn(ns.Events))
+	if err != nil {
+		return err
+	}
+
+	subscriberIDs, err := json.Marshal(subscriberIDsColumn(ns.SubscriberIDs))
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		upsertCampaignNotificationSettingsQueryFmtstr,
+		ns.CampaignID,
+		events,
+		subscriberIDs,
+		ns.CreatedAt,
+		ns.UpdatedAt,
+		sqlf.Join(campaignNotificationSettingsColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignNotificationSettings(ns, sc) })
+}
+
+var upsertCampaignNotificationSettingsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_notifications.go:UpsertCampaignNotificationSettings
+INSERT INTO campaign_notification_settings (campaign_id, events, subscriber_ids, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s)
+ON CONFLICT (campaign_id) DO UPDATE SET
+  events = excluded.events,
+  subscriber_ids = excluded.subscriber_ids,
+  updated_at = excluded.updated_at
+RETURNING %s
+`
+
+// GetCampaignNotificationSettings gets the CampaignNotificationSettings of
+// the campaign with the given ID. ErrNoResults is returned if the campaign
+// has no settings.
+func (s *Store) GetCampaignNotificationSettings(ctx context.Context, campaignID int64) (*campaigns.CampaignNotificationSettings, error) {
+	q := sqlf.Sprintf(
+		getCampaignNotificationSettingsQueryFmtstr,
+		sqlf.Join(campaignNotificationSettingsColumns, ", "),
+		campaignID,
+	)
+
+	var ns campaigns.CampaignNotificationSettings
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignNotificationSettings(&ns, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ns.CampaignID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &ns, nil
+}
+
+var getCampaignNotificationSettingsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_notifications.go:GetCampaignNotificationSettings
+SELECT %s FROM campaign_notification_settings
+WHERE campaign_id = %s
+LIMIT 1
+`
+
+func scanCampaignNotificationSettings(ns *campaigns.CampaignNotificationSettings, sc scanner) error {
+	var events, subscriberIDs json.RawMessage
+
+	err := sc.Scan(
+		&ns.CampaignID,
+		&events,
+		&subscriberIDs,
+		&ns.CreatedAt,
+		&ns.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign notification settings")
+	}
+
+	if err := json.Unmarshal(events, &ns.Events); err != nil {
+		return errors.Wrap(err, "unmarshaling campaign notification events")
+	}
+
+	return errors.Wrap(json.Unmarshal(subscriberIDs, &ns.SubscriberIDs), "unmarshaling campaign notification subscribers")
+}
+
+// notificationEventsColumn and subscriberIDsColumn make sure that nil slices
+// are stored as empty JSON arrays instead of null.
+func notificationEventsColumn(events []campaigns.CampaignNotificationEvent) []campaigns.CampaignNotificationEvent {
+	if events == nil {
+		return []campaigns.CampaignNotificationEvent{}
+	}
+	return events
+}
+
+func subscriberIDsColumn(ids []int32) []int32 {
+	if ids == nil {
+		return []int32{}
+	}
+	return ids
+}
+
+// campaignNotificationColumns are used by the campaign notification related
+// Store methods to query notifications.
+var campaignNotificationColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_notifications.id"),
+	sqlf.Sprintf("campaign_notifications.campaign_id"),
+	sqlf.Sprintf("campaign_notifications.changeset_id"),
+	sqlf.Sprintf("campaign_notifications.event"),
+	sqlf.Sprintf("campaign_notifications.created_at"),
+}
+
+// CreateCampaignNotification records the given CampaignNotification, unless
+// a notification about the same event has already been recorded. In that
+// case the ID of n stays zero.
+func (s *Store) CreateCampaignNotification(ctx context.Context, n *campaigns.CampaignNotification) error {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createCampaignNotificationQueryFmtstr,
+		n.CampaignID,
+		n.ChangesetID,
+		n.Event,
+		n.CreatedAt,
+		sqlf.Join(campaignNotificationColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignNotification(n, sc) })
+}
+
+var createCampaignNotificationQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_notifications.go:CreateCampaignNotification
+INSERT INTO campaign_notifications (campaign_id, changeset_id, event, created_at)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT DO NOTHING
+RETURNING %s
+`
+
+// ListCampaignNotificationsOpts captures the query options needed for
+// listing campaign notifications.
+type ListCampaignNotificationsOpts struct {
+	CampaignID int64
+}
+
+// ListCampaignNotifications lists the recorded CampaignNotifications with the
+// given filters, oldest first.
+func (s *Store) ListCampaignNotifications(ctx context.Context, opts ListCampaignNotificationsOpts) (ns []*campaigns.CampaignNotification, err error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_id = %s", opts.CampaignID))
+	}
+
+	q := sqlf.Sprintf(
+		listCampaignNotificationsQueryFmtstr,
+		sqlf.Join(campaignNotificationColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var n campaigns.CampaignNotification
+		if err := scanCampaignNotification(&n, sc); err != nil {
+			return err
+		}
+		ns = append(ns, &n)
+		return nil
+	})
+	return ns, err
+}
+
+var listCampaignNotificationsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_notifications.go:ListCampaignNotifications
+SELECT %s FROM campaign_notifications
+WHERE %s
+ORDER BY id ASC
+`
+
+func scanCampaignNotification(n *campaigns.CampaignNotification, sc scanner) error {
+	err := sc.Scan(
+		&n.ID,
+		&n.CampaignID,
+		&n.ChangesetID,
+		&n.Event,
+		&n.CreatedAt,
+	)
+	return errors.Wrap(err, "scanning campaign notification")
+}
@@ -0,0 +1,93 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignNotifications(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	// Foreign key constraints are deferred, so the campaigns don't need to
+	// exist.
+	campaignID := int64(4343)
+
+	t.Run("Settings", func(t *testing.T) {
+		if _, err := s.GetCampaignNotificationSettings(ctx, campaignID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+
+		ns := &cmpgn.CampaignNotificationSettings{
+			CampaignID:    campaignID,
+			Events:        []cmpgn.CampaignNotificationEvent{cmpgn.CampaignNotificationEventAllPublished},
+			SubscriberIDs: []int32{12, 13},
+		}
+		if err := s.UpsertCampaignNotificationSettings(ctx, ns); err != nil {
+			t.Fatal(err)
+		}
+
+		// Upserting settings for the same campaign replaces the existing
+		// ones.
+		clock.add(time.Minute)
+		updated := &cmpgn.CampaignNotificationSettings{CampaignID: campaignID}
+		if err := s.UpsertCampaignNotificationSettings(ctx, updated); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetCampaignNotificationSettings(ctx, campaignID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &cmpgn.CampaignNotificationSettings{
+			CampaignID:    campaignID,
+			Events:        []cmpgn.CampaignNotificationEvent{},
+			SubscriberIDs: []int32{},
+			CreatedAt:     clock.now().Add(-time.Minute),
+			UpdatedAt:     clock.now(),
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Notifications", func(t *testing.T) {
+		for _, n := range []*cmpgn.CampaignNotification{
+			{CampaignID: campaignID, Event: cmpgn.CampaignNotificationEventAllPublished},
+			{CampaignID: campaignID, ChangesetID: 5, Event: cmpgn.CampaignNotificationEventPublicationFailed},
+			{CampaignID: campaignID, ChangesetID: 6, Event: cmpgn.CampaignNotificationEventPublicationFailed},
+		} {
+			if err := s.CreateCampaignNotification(ctx, n); err != nil {
+				t.Fatal(err)
+			}
+			if n.ID == 0 {
+				t.Fatal("notification not recorded")
+			}
+		}
+
+		// Notifications about the same event are only recorded once.
+		dup := &cmpgn.CampaignNotification{CampaignID: campaignID, ChangesetID: 5, Event: cmpgn.CampaignNotificationEventPublicationFailed}
+		if err := s.CreateCampaignNotification(ctx, dup); err != nil {
+			t.Fatal(err)
+		}
+		if dup.ID != 0 {
+			t.Fatalf("duplicate notification recorded: %+v", dup)
+		}
+
+		have, err := s.ListCampaignNotifications(ctx, ListCampaignNotificationsOpts{CampaignID: campaignID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 3 {
+			t.Fatalf("wrong number of notifications. want=3, have=%d", len(have))
+		}
+		for _, n := range have {
+			if !n.CreatedAt.Equal(clock.now()) {
+				t.Fatalf("wrong created at. want=%s, have=%s", clock.now(), n.CreatedAt)
+			}
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package campaigns
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignPermissionGrantColumns are used by the campaign permission grant
+// related Store methods to query campaign permission grants.
+var campaignPermissionGrantColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_permission_grants.id"),
+	sqlf.Sprintf("campaign_permission_grants.campaign_id"),
+	sqlf.Sprintf("campaign_permission_grants.user_id"),
+	sqlf.Sprintf("campaign_permission_grants.org_id"),
+	sqlf.Sprintf("campaign_permission_grants.level"),
+	sqlf.Sprintf("campaign_permission_grants.created_at"),
+	sqlf.Sprintf("campaign_permission_grants.updated_at"),
+}
+
+// UpsertCampaignPermissionGrant creates the given CampaignPermissionGrant or
+// replaces the level of the existing grant of its campaign to its user or
+// org.
+func (s *Store) UpsertCampaignPermissionGrant(ctx context.Context, g *campaigns.CampaignPermissionGrant) error {
+	if g.CreatedAt.IsZero() {
+		g.CreatedAt = s.now()
+	}
+	g.UpdatedAt = s.now()
+
+	conflictTarget := sqlf.Sprintf("(campaign_id, user_id) WHERE user_id IS NOT NULL")
+	if g.OrgID != 0 {
+		conflictTarget = sqlf.Sprintf("(campaign_id, org_id) WHERE org_id IS NOT NULL")
+	}
+
+	q := sqlf.Sprintf(
+		upsertCampaignPermissionGrantQueryFmtstr,
+		g.CampaignID,
+		nullInt32Column(g.UserID),
+		nullInt32Column(g.OrgID),
+		g.Level,
+		g.CreatedAt,
+		g.UpdatedAt,
+		conflictTarget,
+		sqlf.Join(campaignPermissionGrantColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignPermissionGrant(g, sc) })
+}
+
+var upsertCampaignPermissionGrantQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_permission_grants.go:UpsertCampaignPermissionGrant
+INSERT INTO campaign_permission_grants (campaign_id, user_id, org_id, level, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s)
+ON CONFLICT %s DO UPDATE SET
+  level = excluded.level,
+  updated_at = excluded.updated_at
+RETURNING %s
+`
+
+// DeleteCampaignPermissionGrant deletes the CampaignPermissionGrant with the
+// given ID.
+func (s *Store) DeleteCampaignPermissionGrant(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteCampaignPermissionGrantQueryFmtstr, id))
+}
+
+var deleteCampaignPermissionGrantQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_permission_grants.go:DeleteCampaignPermissionGrant
+DELETE FROM campaign_permission_grants WHERE id = %s
+`
+
+// GetCampaignPermissionGrant gets the CampaignPermissionGrant with the given
+// ID.
+func (s *Store) GetCampaignPermissionGrant(ctx context.Context, id int64) (*campaigns.CampaignPermissionGrant, error) {
+	q := sqlf.Sprintf(
+		getCampaignPermissionGrantQueryFmtstr,
+		sqlf.Join(campaignPermissionGrantColumns, ", "),
+		id,
+	)
+
+	var g campaigns.CampaignPermissionGrant
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignPermissionGrant(&g, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if g.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &g, nil
+}
+
+var getCampaignPermissionGrantQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_permission_grants.go:GetCampaignPermissionGrant
+SELECT %s FROM campaign_permission_grants
+WHERE campaign_permission_grants.id = %s
+LIMIT 1
+`
+
+// ListCampaignPermissionGrants lists the CampaignPermissionGrants of the
+// Campaign with the given ID, ordered by ID.
+func (s *Store) ListCampaignPermissionGrants(ctx context.Context, campaignID int64) (gs []*campaigns.CampaignPermissionGrant, err error) {
+	q := sqlf.Sprintf(
+		listCampaignPermissionGrantsQueryFmtstr,
+		sqlf.Join(campaignPermissionGrantColumns, ", "),
+		campaignID,
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var g campaigns.CampaignPermissionGrant
+		if err := scanCampaignPermissionGrant(&g, sc); err != nil {
+			return err
+		}
+		gs = append(gs, &g)
+		return nil
+	})
+	return gs, err
+}
+
+var listCampaignPermissionGrantsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_permission_grants.go:ListCampaignPermissionGrants
+SELECT %s FROM campaign_permission_grants
+WHERE campaign_permission_grants.campaign_id = %s
+ORDER BY campaign_permission_grants.id ASC
+`
+
+// HasCampaignPermissionGrant returns whether the user with the given ID has
+// been granted at least the given level of rights on the Campaign with the
+// given ID, either directly or through one of their orgs.
+func (s *Store) HasCampaignPermissionGrant(ctx context.Context, campaignID int64, userID int32, level campaigns.CampaignPermissionLevel) (bool, error) {
+	levels := []*sqlf.Query{sqlf.Sprintf("%s", campaigns.CampaignPermissionLevelAdmin)}
+	if level == campaigns.CampaignPermissionLevelView {
+		levels = append(levels, sqlf.Sprintf("%s", campaigns.CampaignPermissionLevelView))
+	}
+
+	q := sqlf.Sprintf(
+		hasCampaignPermissionGrantQueryFmtstr,
+		campaignID,
+		sqlf.Join(levels, ", "),
+		userID,
+		userID,
+	)
+
+	ok, _, err := basestore.ScanFirstBool(s.Query(ctx, q))
+	return ok, err
+}
+
+var hasCampaignPermissionGrantQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_permission_grants.go:HasCampaignPermissionGrant
+SELECT EXISTS (
+  SELECT 1 FROM campaign_permission_grants
+  WHERE
+    campaign_id = %s AND
+    level IN (%s) AND
+    (
+      user_id = %s OR
+      org_id IN (SELECT org_id FROM org_members WHERE user_id = %s)
+    )
+)
+`
+
+func scanCampaignPermissionGrant(g *campaigns.CampaignPermissionGrant, sc scanner) error {
+	err := sc.Scan(
+		&g.ID,
+		&g.CampaignID,
+		&dbutil.NullInt32{N: &g.UserID},
+		&dbutil.NullInt32{N: &g.OrgID},
+		&g.Level,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+	)
+	return errors.Wrap(err, "scanning campaign permission grant")
+}
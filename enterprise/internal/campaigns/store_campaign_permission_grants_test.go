@@ -0,0 +1,124 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignPermissionGrants(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	// Foreign key constraints are deferred, so the campaign, users and org
+	// don't need to exist.
+	campaignID := int64(1337)
+	userID := int32(4242)
+
+	grants := []*cmpgn.CampaignPermissionGrant{
+		{CampaignID: campaignID, UserID: userID, Level: cmpgn.CampaignPermissionLevelView},
+		{CampaignID: campaignID, OrgID: 23, Level: cmpgn.CampaignPermissionLevelAdmin},
+		{CampaignID: campaignID + 1, UserID: userID, Level: cmpgn.CampaignPermissionLevelAdmin},
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		for _, g := range grants {
+			if err := s.UpsertCampaignPermissionGrant(ctx, g); err != nil {
+				t.Fatal(err)
+			}
+			if g.ID == 0 {
+				t.Fatal("grant ID should not be zero")
+			}
+			if have, want := g.CreatedAt, clock.now(); !have.Equal(want) {
+				t.Fatalf("wrong CreatedAt. want=%s, have=%s", want, have)
+			}
+		}
+
+		// Upserting a grant for the same campaign and user replaces the
+		// level of the existing one.
+		clock.add(time.Minute)
+		updated := &cmpgn.CampaignPermissionGrant{
+			CampaignID: campaignID,
+			UserID:     userID,
+			Level:      cmpgn.CampaignPermissionLevelAdmin,
+		}
+		if err := s.UpsertCampaignPermissionGrant(ctx, updated); err != nil {
+			t.Fatal(err)
+		}
+
+		want := grants[0].Clone()
+		want.Level = cmpgn.CampaignPermissionLevelAdmin
+		want.UpdatedAt = clock.now()
+		if diff := cmp.Diff(want, updated); diff != "" {
+			t.Fatal(diff)
+		}
+		grants[0] = updated
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, g := range grants {
+			have, err := s.GetCampaignPermissionGrant(ctx, g.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(g, have); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		if _, err := s.GetCampaignPermissionGrant(ctx, 0xdeadbeef); err != ErrNoResults {
+			t.Fatalf("unexpected error: want=%q, have=%q", ErrNoResults, err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListCampaignPermissionGrants(ctx, campaignID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(grants[:2], have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		tests := []struct {
+			campaignID int64
+			userID     int32
+			level      cmpgn.CampaignPermissionLevel
+			want       bool
+		}{
+			{campaignID: campaignID, userID: userID, level: cmpgn.CampaignPermissionLevelAdmin, want: true},
+			{campaignID: campaignID, userID: userID, level: cmpgn.CampaignPermissionLevelView, want: true},
+			{campaignID: campaignID, userID: userID + 1, level: cmpgn.CampaignPermissionLevelView, want: false},
+			{campaignID: campaignID + 2, userID: userID, level: cmpgn.CampaignPermissionLevelView, want: false},
+		}
+
+		for _, tc := range tests {
+			have, err := s.HasCampaignPermissionGrant(ctx, tc.campaignID, tc.userID, tc.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("campaign %d, user %d, level %s: want=%t, have=%t", tc.campaignID, tc.userID, tc.level, tc.want, have)
+			}
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, g := range grants {
+			if err := s.DeleteCampaignPermissionGrant(ctx, g.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListCampaignPermissionGrants(ctx, campaignID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("grants not deleted: %+v", have)
+		}
+	})
+}
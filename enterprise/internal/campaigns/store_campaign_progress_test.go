@@ -0,0 +1,132 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignProgress(t *testing.T, ctx context.Context, s *Store, reposStore repos.Store, clock clock) {
+	repo := testRepo(1, extsvc.TypeGitHub)
+	if err := reposStore.UpsertRepos(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	campaign := &cmpgn.Campaign{Name: "progress", InitialApplierID: 1, NamespaceUserID: 1}
+	if err := s.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	createSpec := func(published bool) *cmpgn.ChangesetSpec {
+		t.Helper()
+
+		spec := &cmpgn.ChangesetSpec{
+			RepoID: repo.ID,
+			UserID: 1,
+			Spec:   &cmpgn.ChangesetSpecDescription{Published: published},
+		}
+		if err := s.CreateChangesetSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+		return spec
+	}
+
+	createChangeset := func(i int, c *cmpgn.Changeset) {
+		t.Helper()
+
+		c.RepoID = repo.ID
+		c.CampaignIDs = []int64{campaign.ID}
+		c.ExternalServiceType = repo.ExternalRepo.ServiceType
+		if c.PublicationState == cmpgn.ChangesetPublicationStatePublished {
+			c.ExternalID = fmt.Sprintf("progress-%d", i)
+		}
+		if err := s.CreateChangeset(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Merged and open changesets that were published by the campaign.
+	createChangeset(1, &cmpgn.Changeset{
+		OwnedByCampaignID: campaign.ID,
+		PublicationState:  cmpgn.ChangesetPublicationStatePublished,
+		ExternalState:     cmpgn.ChangesetExternalStateMerged,
+		ReconcilerState:   cmpgn.ReconcilerStateCompleted,
+	})
+	createChangeset(2, &cmpgn.Changeset{
+		OwnedByCampaignID: campaign.ID,
+		PublicationState:  cmpgn.ChangesetPublicationStatePublished,
+		ExternalState:     cmpgn.ChangesetExternalStateOpen,
+		ReconcilerState:   cmpgn.ReconcilerStateCompleted,
+	})
+	// An imported changeset.
+	createChangeset(3, &cmpgn.Changeset{
+		PublicationState: cmpgn.ChangesetPublicationStatePublished,
+		ExternalState:    cmpgn.ChangesetExternalStateMerged,
+		ReconcilerState:  cmpgn.ReconcilerStateCompleted,
+	})
+	// Changesets that are yet to be published.
+	createChangeset(4, &cmpgn.Changeset{
+		OwnedByCampaignID: campaign.ID,
+		CurrentSpecID:     createSpec(true).ID,
+		PublicationState:  cmpgn.ChangesetPublicationStateUnpublished,
+		ReconcilerState:   cmpgn.ReconcilerStateQueued,
+	})
+	createChangeset(5, &cmpgn.Changeset{
+		OwnedByCampaignID: campaign.ID,
+		CurrentSpecID:     createSpec(true).ID,
+		PublicationState:  cmpgn.ChangesetPublicationStateUnpublished,
+		ReconcilerState:   cmpgn.ReconcilerStateProcessing,
+	})
+	// A changeset that isn't published, since its spec says so.
+	createChangeset(6, &cmpgn.Changeset{
+		OwnedByCampaignID: campaign.ID,
+		CurrentSpecID:     createSpec(false).ID,
+		PublicationState:  cmpgn.ChangesetPublicationStateUnpublished,
+		ReconcilerState:   cmpgn.ReconcilerStateCompleted,
+	})
+
+	for _, a := range []*cmpgn.CampaignActivity{
+		{CampaignID: campaign.ID, Kind: cmpgn.CampaignActivityKindChangesetPublished},
+		{CampaignID: campaign.ID, Kind: cmpgn.CampaignActivityKindApplied},
+	} {
+		if err := s.CreateCampaignActivity(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("RecentlyPublished", func(t *testing.T) {
+		have, err := s.GetCampaignProgress(ctx, campaign.ID, clock.now().Add(-ProgressThroughputWindow))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &CampaignProgress{
+			Total:              6,
+			Published:          3,
+			Merged:             2,
+			PendingPublication: 2,
+			RecentlyPublished:  1,
+			Time:               clock.now(),
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("NothingPublishedSince", func(t *testing.T) {
+		have, err := s.GetCampaignProgress(ctx, campaign.ID, clock.now().Add(ProgressThroughputWindow))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have.RecentlyPublished != 0 {
+			t.Fatalf("wrong number of recently published changesets. want=0, have=%d", have.RecentlyPublished)
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package campaigns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignReapplyScheduleColumns are used by the campaign reapply schedule
+// related Store methods to query schedules.
+var campaignReapplyScheduleColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_reapply_schedules.campaign_id"),
+	sqlf.Sprintf("campaign_reapply_schedules.schedule"),
+	sqlf.Sprintf("campaign_reapply_schedules.next_run_at"),
+	sqlf.Sprintf("campaign_reapply_schedules.last_run_at"),
+	sqlf.Sprintf("campaign_reapply_schedules.created_at"),
+	sqlf.Sprintf("campaign_reapply_schedules.updated_at"),
+}
+
+// UpsertCampaignReapplySchedule creates the given CampaignReapplySchedule or
+// replaces the existing schedule of its campaign.
+func (s *Store) UpsertCampaignReapplySchedule(ctx context.Context, rs *campaigns.CampaignReapplySchedule) error {
+	if rs.CreatedAt.IsZero() {
+		rs.CreatedAt = s.now()
+	}
+	rs.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		upsertCampaignReapplyScheduleQueryFmtstr,
+		rs.CampaignID,
+		rs.Schedule,
+		rs.NextRunAt,
+		nullTimeColumn(rs.LastRunAt),
+		rs.CreatedAt,
+		rs.UpdatedAt,
+		sqlf.Join(campaignReapplyScheduleColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignReapplySchedule(rs, sc) })
+}
+
+var upsertCampaignReapplyScheduleQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_reapply_schedules.go:UpsertCampaignReapplySchedule
+INSERT INTO campaign_reapply_schedules (campaign_id, schedule, next_run_at, last_run_at, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s)
+ON CONFLICT (campaign_id) DO UPDATE SET
+  schedule = excluded.schedule,
+  next_run_at = excluded.next_run_at,
+  last_run_at = excluded.last_run_at,
+  updated_at = excluded.updated_at
+RETURNING %s
+`
+
+// DeleteCampaignReapplySchedule deletes the CampaignReapplySchedule of the
+// campaign with the given ID.
+func (s *Store) DeleteCampaignReapplySchedule(ctx context.Context, campaignID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteCampaignReapplyScheduleQueryFmtstr, campaignID))
+}
+
+var deleteCampaignReapplyScheduleQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_reapply_schedules.go:DeleteCampaignReapplySchedule
+DELETE FROM campaign_reapply_schedules WHERE campaign_id = %s
+`
+
+// GetCampaignReapplySchedule gets the CampaignReapplySchedule of the campaign
+// with the given ID. ErrNoResults is returned if the campaign has no
+// schedule.
+func (s *Store) GetCampaignReapplySchedule(ctx context.Context, campaignID int64) (*campaigns.CampaignReapplySchedule, error) {
+	q := sqlf.Sprintf(
+		getCampaignReapplyScheduleQueryFmtstr,
+		sqlf.Join(campaignReapplyScheduleColumns, ", "),
+		campaignID,
+	)
+
+	var rs campaigns.CampaignReapplySchedule
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignReapplySchedule(&rs, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if rs.CampaignID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &rs, nil
+}
+
+var getCampaignReapplyScheduleQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_reapply_schedules.go:GetCampaignReapplySchedule
+SELECT %s FROM campaign_reapply_schedules
+WHERE campaign_id = %s
+LIMIT 1
+`
+
+// ListDueCampaignReapplySchedules lists the CampaignReapplySchedules whose
+// next run is due, ordered by the time they were due.
+func (s *Store) ListDueCampaignReapplySchedules(ctx context.Context) (rss []*campaigns.CampaignReapplySchedule, err error) {
+	q := sqlf.Sprintf(
+		listDueCampaignReapplySchedulesQueryFmtstr,
+		sqlf.Join(campaignReapplyScheduleColumns, ", "),
+		s.now(),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var rs campaigns.CampaignReapplySchedule
+		if err := scanCampaignReapplySchedule(&rs, sc); err != nil {
+			return err
+		}
+		rss = append(rss, &rs)
+		return nil
+	})
+	return rss, err
+}
+
+var listDueCampaignReapplySchedulesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_reapply_schedules.go:ListDueCampaignReapplySchedules
+SELECT %s FROM campaign_reapply_schedules
+WHERE next_run_at <= %s
+ORDER BY next_run_at ASC
+`
+
+func scanCampaignReapplySchedule(rs *campaigns.CampaignReapplySchedule, sc scanner) error {
+	err := sc.Scan(
+		&rs.CampaignID,
+		&rs.Schedule,
+		&rs.NextRunAt,
+		&dbutil.NullTime{Time: &rs.LastRunAt},
+		&rs.CreatedAt,
+		&rs.UpdatedAt,
+	)
+	return errors.Wrap(err, "scanning campaign reapply schedule")
+}
+
+// campaignReapplyJobColumns are used by the campaign reapply job related
+// Store methods to query jobs.
+var campaignReapplyJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_reapply_jobs.id"),
+	sqlf.Sprintf("campaign_reapply_jobs.campaign_id"),
+	sqlf.Sprintf("campaign_reapply_jobs.campaign_spec_id"),
+	sqlf.Sprintf("campaign_reapply_jobs.state"),
+	sqlf.Sprintf("campaign_reapply_jobs.failure_message"),
+	sqlf.Sprintf("campaign_reapply_jobs.started_at"),
+	sqlf.Sprintf("campaign_reapply_jobs.finished_at"),
+	sqlf.Sprintf("campaign_reapply_jobs.process_after"),
+	sqlf.Sprintf("campaign_reapply_jobs.num_resets"),
+	sqlf.Sprintf("campaign_reapply_jobs.created_at"),
+}
+
+// EnqueueCampaignReapplyJob creates the given CampaignReapplyJob in the
+// queued state, unless a job of the same campaign is already queued or being
+// processed. In that case the ID of j stays zero.
+func (s *Store) EnqueueCampaignReapplyJob(ctx context.Context, j *campaigns.CampaignReapplyJob) error {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		enqueueCampaignReapplyJobQueryFmtstr,
+		j.CampaignID,
+		j.CampaignSpecID,
+		j.CreatedAt,
+		j.CampaignID,
+		sqlf.Join(campaignReapplyJobColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignReapplyJob(j, sc) })
+}
+
+var enqueueCampaignReapplyJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_reapply_schedules.go:EnqueueCampaignReapplyJob
+INSERT INTO campaign_reapply_jobs (campaign_id, campaign_spec_id, state, created_at)
+SELECT %s, %s, 'queued', %s
+WHERE NOT EXISTS (
+  SELECT 1 FROM campaign_reapply_jobs
+  WHERE campaign_id = %s AND state IN ('queued', 'processing')
+)
+RETURNING %s
+`
+
+// ListCampaignReapplyJobsOpts captures the query options needed for listing
+// campaign reapply jobs.
+type ListCampaignReapplyJobsOpts struct {
+	CampaignID int64
+}
+
+// ListCampaignReapplyJobs lists the CampaignReapplyJobs with the given
+// filters, oldest first.
+func (s *Store) ListCampaignReapplyJobs(ctx context.Context, opts ListCampaignReapplyJobsOpts) (js []*campaigns.CampaignReapplyJob, err error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_reapply_jobs.campaign_id = %s", opts.CampaignID))
+	}
+
+	q := sqlf.Sprintf(
+		listCampaignReapplyJobsQueryFmtstr,
+		sqlf.Join(campaignReapplyJobColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var j campaigns.CampaignReapplyJob
+		if err := scanCampaignReapplyJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+	return js, err
+}
+
+var listCampaignReapplyJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_reapply_schedules.go:ListCampaignReapplyJobs
+SELECT %s FROM campaign_reapply_jobs
+WHERE %s
+ORDER BY campaign_reapply_jobs.id ASC
+`
+
+func scanCampaignReapplyJob(j *campaigns.CampaignReapplyJob, sc scanner) error {
+	var (
+		state          string
+		failureMessage string
+	)
+	err := sc.Scan(
+		&j.ID,
+		&j.CampaignID,
+		&j.CampaignSpecID,
+		&state,
+		&dbutil.NullString{S: &failureMessage},
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&dbutil.NullTime{Time: &j.ProcessAfter},
+		&j.NumResets,
+		&j.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign reapply job")
+	}
+
+	j.State = campaigns.ReconcilerState(strings.ToUpper(state))
+	if failureMessage != "" {
+		j.FailureMessage = &failureMessage
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignReapplySchedules(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	// Foreign key constraints are deferred, so the campaigns and campaign
+	// specs don't need to exist.
+	campaignID := int64(4242)
+	campaignSpecID := int64(2424)
+
+	t.Run("Upsert", func(t *testing.T) {
+		rs := &cmpgn.CampaignReapplySchedule{
+			CampaignID: campaignID,
+			Schedule:   "@daily",
+			NextRunAt:  clock.now().Add(time.Hour),
+		}
+		if err := s.UpsertCampaignReapplySchedule(ctx, rs); err != nil {
+			t.Fatal(err)
+		}
+
+		want := &cmpgn.CampaignReapplySchedule{
+			CampaignID: campaignID,
+			Schedule:   "@daily",
+			NextRunAt:  clock.now().Add(time.Hour),
+			CreatedAt:  clock.now(),
+			UpdatedAt:  clock.now(),
+		}
+		if diff := cmp.Diff(want, rs); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// Upserting a new schedule for the same campaign replaces the
+		// existing one.
+		clock.add(time.Minute)
+		updated := &cmpgn.CampaignReapplySchedule{
+			CampaignID: campaignID,
+			Schedule:   "@hourly",
+			NextRunAt:  clock.now().Add(-time.Minute),
+			LastRunAt:  clock.now(),
+		}
+		if err := s.UpsertCampaignReapplySchedule(ctx, updated); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetCampaignReapplySchedule(ctx, campaignID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want = &cmpgn.CampaignReapplySchedule{
+			CampaignID: campaignID,
+			Schedule:   "@hourly",
+			NextRunAt:  clock.now().Add(-time.Minute),
+			LastRunAt:  clock.now(),
+			CreatedAt:  rs.CreatedAt,
+			UpdatedAt:  clock.now(),
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("ListDue", func(t *testing.T) {
+		notDue := &cmpgn.CampaignReapplySchedule{
+			CampaignID: campaignID + 1,
+			Schedule:   "@daily",
+			NextRunAt:  clock.now().Add(time.Hour),
+		}
+		if err := s.UpsertCampaignReapplySchedule(ctx, notDue); err != nil {
+			t.Fatal(err)
+		}
+
+		due, err := s.ListDueCampaignReapplySchedules(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(due) != 1 || due[0].CampaignID != campaignID {
+			t.Fatalf("wrong due schedules: %+v", due)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteCampaignReapplySchedule(ctx, campaignID); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := s.GetCampaignReapplySchedule(ctx, campaignID)
+		if have, want := err, ErrNoResults; have != want {
+			t.Fatalf("have err %v, want %v", have, want)
+		}
+	})
+
+	t.Run("EnqueueJob", func(t *testing.T) {
+		j := &cmpgn.CampaignReapplyJob{CampaignID: campaignID, CampaignSpecID: campaignSpecID}
+		if err := s.EnqueueCampaignReapplyJob(ctx, j); err != nil {
+			t.Fatal(err)
+		}
+
+		want := &cmpgn.CampaignReapplyJob{
+			ID:             j.ID,
+			CampaignID:     campaignID,
+			CampaignSpecID: campaignSpecID,
+			State:          cmpgn.ReconcilerStateQueued,
+			CreatedAt:      clock.now(),
+		}
+		if diff := cmp.Diff(want, j); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// No second job is enqueued while the first one is queued.
+		second := &cmpgn.CampaignReapplyJob{CampaignID: campaignID, CampaignSpecID: campaignSpecID}
+		if err := s.EnqueueCampaignReapplyJob(ctx, second); err != nil {
+			t.Fatal(err)
+		}
+		if second.ID != 0 {
+			t.Fatalf("job enqueued twice: %+v", second)
+		}
+
+		other := &cmpgn.CampaignReapplyJob{CampaignID: campaignID + 1, CampaignSpecID: campaignSpecID}
+		if err := s.EnqueueCampaignReapplyJob(ctx, other); err != nil {
+			t.Fatal(err)
+		}
+
+		jobs, err := s.ListCampaignReapplyJobs(ctx, ListCampaignReapplyJobsOpts{CampaignID: campaignID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*cmpgn.CampaignReapplyJob{want}, jobs); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package campaigns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignSpecExecutionColumns are used by the campaign spec execution
+// related Store methods to query executions.
+var campaignSpecExecutionColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_spec_executions.id"),
+	sqlf.Sprintf("campaign_spec_executions.raw_spec"),
+	sqlf.Sprintf("campaign_spec_executions.namespace_user_id"),
+	sqlf.Sprintf("campaign_spec_executions.namespace_org_id"),
+	sqlf.Sprintf("campaign_spec_executions.user_id"),
+	sqlf.Sprintf("campaign_spec_executions.campaign_id"),
+	sqlf.Sprintf("campaign_spec_executions.campaign_spec_id"),
+	sqlf.Sprintf("campaign_spec_executions.state"),
+	sqlf.Sprintf("campaign_spec_executions.failure_message"),
+	sqlf.Sprintf("campaign_spec_executions.repositories_total"),
+	sqlf.Sprintf("campaign_spec_executions.repositories_completed"),
+	sqlf.Sprintf("campaign_spec_executions.started_at"),
+	sqlf.Sprintf("campaign_spec_executions.finished_at"),
+	sqlf.Sprintf("campaign_spec_executions.created_at"),
+	sqlf.Sprintf("campaign_spec_executions.updated_at"),
+}
+
+// CreateCampaignSpecExecution creates the given CampaignSpecExecution in the
+// queued state.
+func (s *Store) CreateCampaignSpecExecution(ctx context.Context, e *campaigns.CampaignSpecExecution) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
+	}
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = e.CreatedAt
+	}
+	e.State = campaigns.ReconcilerStateQueued
+
+	q := sqlf.Sprintf(
+		createCampaignSpecExecutionQueryFmtstr,
+		e.RawSpec,
+		nullInt32Column(e.NamespaceUserID),
+		nullInt32Column(e.NamespaceOrgID),
+		e.UserID,
+		nullInt64Column(e.CampaignID),
+		e.State.ToDB(),
+		e.CreatedAt,
+		e.UpdatedAt,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignSpecExecution(e, sc) })
+}
+
+var createCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:CreateCampaignSpecExecution
+INSERT INTO campaign_spec_executions (raw_spec, namespace_user_id, namespace_org_id, user_id, campaign_id, state, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateCampaignSpecExecution updates the result, the state and the progress
+// of the given CampaignSpecExecution.
+func (s *Store) UpdateCampaignSpecExecution(ctx context.Context, e *campaigns.CampaignSpecExecution) error {
+	e.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateCampaignSpecExecutionQueryFmtstr,
+		nullInt64Column(e.CampaignSpecID),
+		e.State.ToDB(),
+		e.FailureMessage,
+		e.RepositoriesTotal,
+		e.RepositoriesCompleted,
+		nullTimeColumn(e.StartedAt),
+		nullTimeColumn(e.FinishedAt),
+		e.UpdatedAt,
+		e.ID,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignSpecExecution(e, sc) })
+}
+
+var updateCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:UpdateCampaignSpecExecution
+UPDATE campaign_spec_executions
+SET
+  campaign_spec_id = %s,
+  state = %s,
+  failure_message = %s,
+  repositories_total = %s,
+  repositories_completed = %s,
+  started_at = %s,
+  finished_at = %s,
+  updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// GetCampaignSpecExecutionOpts captures the query options needed for getting
+// a CampaignSpecExecution.
+type GetCampaignSpecExecutionOpts struct {
+	ID int64
+}
+
+// GetCampaignSpecExecution gets the CampaignSpecExecution matching the given
+// options.
+func (s *Store) GetCampaignSpecExecution(ctx context.Context, opts GetCampaignSpecExecutionOpts) (*campaigns.CampaignSpecExecution, error) {
+	q := sqlf.Sprintf(
+		getCampaignSpecExecutionQueryFmtstr,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+		opts.ID,
+	)
+
+	var e campaigns.CampaignSpecExecution
+	err := s.query(ctx, q, func(sc scanner) error { return scanCampaignSpecExecution(&e, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if e.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &e, nil
+}
+
+var getCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:GetCampaignSpecExecution
+SELECT %s FROM campaign_spec_executions
+WHERE id = %s
+LIMIT 1
+`
+
+// DequeueCampaignSpecExecution moves the oldest queued CampaignSpecExecution
+// into the processing state and returns it. Unlike the dbworker stores, it
+// doesn't keep a transaction open while the execution is processed, so that
+// its progress can be reported while it runs. It returns ErrNoResults if no
+// execution is queued.
+func (s *Store) DequeueCampaignSpecExecution(ctx context.Context) (*campaigns.CampaignSpecExecution, error) {
+	now := s.now()
+	q := sqlf.Sprintf(
+		dequeueCampaignSpecExecutionQueryFmtstr,
+		campaigns.ReconcilerStateProcessing.ToDB(),
+		now,
+		now,
+		campaigns.ReconcilerStateQueued.ToDB(),
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+	)
+
+	var e campaigns.CampaignSpecExecution
+	err := s.query(ctx, q, func(sc scanner) error { return scanCampaignSpecExecution(&e, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if e.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &e, nil
+}
+
+var dequeueCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:DequeueCampaignSpecExecution
+UPDATE campaign_spec_executions
+SET state = %s, started_at = %s, updated_at = %s
+WHERE id = (
+  SELECT id FROM campaign_spec_executions
+  WHERE state = %s
+  ORDER BY id ASC
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING %s
+`
+
+// ResetProcessingCampaignSpecExecutions moves all CampaignSpecExecutions in
+// the processing state back into the queued state and resets their progress.
+// It's used to pick up executions that were interrupted, because the replica
+// processing them went away.
+func (s *Store) ResetProcessingCampaignSpecExecutions(ctx context.Context) error {
+	q := sqlf.Sprintf(
+		resetProcessingCampaignSpecExecutionsQueryFmtstr,
+		campaigns.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		campaigns.ReconcilerStateProcessing.ToDB(),
+	)
+	return s.Exec(ctx, q)
+}
+
+var resetProcessingCampaignSpecExecutionsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:ResetProcessingCampaignSpecExecutions
+UPDATE campaign_spec_executions
+SET
+  state = %s,
+  repositories_total = 0,
+  repositories_completed = 0,
+  started_at = NULL,
+  updated_at = %s
+WHERE state = %s
+`
+
+func scanCampaignSpecExecution(e *campaigns.CampaignSpecExecution, sc scanner) error {
+	var (
+		state          string
+		failureMessage string
+	)
+	err := sc.Scan(
+		&e.ID,
+		&e.RawSpec,
+		&dbutil.NullInt32{N: &e.NamespaceUserID},
+		&dbutil.NullInt32{N: &e.NamespaceOrgID},
+		&e.UserID,
+		&dbutil.NullInt64{N: &e.CampaignID},
+		&dbutil.NullInt64{N: &e.CampaignSpecID},
+		&state,
+		&dbutil.NullString{S: &failureMessage},
+		&e.RepositoriesTotal,
+		&e.RepositoriesCompleted,
+		&dbutil.NullTime{Time: &e.StartedAt},
+		&dbutil.NullTime{Time: &e.FinishedAt},
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign spec execution")
+	}
+
+	e.State = campaigns.ReconcilerState(strings.ToUpper(state))
+	e.FailureMessage = nil
+	if failureMessage != "" {
+		e.FailureMessage = &failureMessage
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignSpecExecutions(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	executions := []*cmpgn.CampaignSpecExecution{
+		{RawSpec: "name: a", NamespaceUserID: 1234, UserID: 1234},
+		{RawSpec: "name: b", NamespaceOrgID: 23, UserID: 1234, CampaignID: 42},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, e := range executions {
+			if err := s.CreateCampaignSpecExecution(ctx, e); err != nil {
+				t.Fatal(err)
+			}
+			if e.ID == 0 {
+				t.Fatal("execution has no ID")
+			}
+			if e.State != cmpgn.ReconcilerStateQueued {
+				t.Fatalf("wrong state. want=%s, have=%s", cmpgn.ReconcilerStateQueued, e.State)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range executions {
+			have, err := s.GetCampaignSpecExecution(ctx, GetCampaignSpecExecutionOpts{ID: want.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		_, err := s.GetCampaignSpecExecution(ctx, GetCampaignSpecExecutionOpts{ID: 0xdeadbeef})
+		if err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("Dequeue", func(t *testing.T) {
+		clock.add(time.Minute)
+		for _, want := range executions {
+			have, err := s.DequeueCampaignSpecExecution(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.ID != want.ID {
+				t.Fatalf("wrong execution dequeued. want=%d, have=%d", want.ID, have.ID)
+			}
+			if have.State != cmpgn.ReconcilerStateProcessing {
+				t.Fatalf("wrong state. want=%s, have=%s", cmpgn.ReconcilerStateProcessing, have.State)
+			}
+			if !have.StartedAt.Equal(clock.now()) {
+				t.Fatalf("wrong start time. want=%s, have=%s", clock.now(), have.StartedAt)
+			}
+			*want = *have
+		}
+
+		if _, err := s.DequeueCampaignSpecExecution(ctx); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		e := executions[0]
+		e.RepositoriesTotal = 3
+		e.RepositoriesCompleted = 3
+		e.State = cmpgn.ReconcilerStateErrored
+		msg := "running step 1: exit status 1"
+		e.FailureMessage = &msg
+		e.FinishedAt = clock.now()
+
+		want := *e
+		want.UpdatedAt = clock.now()
+		if err := s.UpdateCampaignSpecExecution(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&want, e); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("ResetProcessing", func(t *testing.T) {
+		if err := s.ResetProcessingCampaignSpecExecutions(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		// Only the second execution was still processing.
+		have, err := s.GetCampaignSpecExecution(ctx, GetCampaignSpecExecutionOpts{ID: executions[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.State != cmpgn.ReconcilerStateErrored {
+			t.Fatalf("wrong state. want=%s, have=%s", cmpgn.ReconcilerStateErrored, have.State)
+		}
+
+		have, err = s.DequeueCampaignSpecExecution(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ID != executions[1].ID {
+			t.Fatalf("wrong execution dequeued. want=%d, have=%d", executions[1].ID, have.ID)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package campaigns
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// specExpirationRunColumns are used by the spec expiration run related Store
+// methods to insert, update and query SpecExpirationRuns.
+var specExpirationRunColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_spec_expiration_runs.id"),
+	sqlf.Sprintf("campaign_spec_expiration_runs.started_at"),
+	sqlf.Sprintf("campaign_spec_expiration_runs.finished_at"),
+	sqlf.Sprintf("campaign_spec_expiration_runs.dry_run"),
+	sqlf.Sprintf("campaign_spec_expiration_runs.changeset_specs_deleted"),
+	sqlf.Sprintf("campaign_spec_expiration_runs.campaign_specs_deleted"),
+	sqlf.Sprintf("campaign_spec_expiration_runs.campaigns_deleted"),
+	sqlf.Sprintf("campaign_spec_expiration_runs.error"),
+}
+
+// CreateSpecExpirationRun records the given SpecExpirationRun and sets its
+// ID.
+func (s *Store) CreateSpecExpirationRun(ctx context.Context, r *campaigns.SpecExpirationRun) error {
+	q := sqlf.Sprintf(
+		createSpecExpirationRunQueryFmtstr,
+		r.StartedAt,
+		r.FinishedAt,
+		r.DryRun,
+		r.ChangesetSpecsDeleted,
+		r.CampaignSpecsDeleted,
+		r.CampaignsDeleted,
+		nullStringColumn(r.Error),
+		sqlf.Join(specExpirationRunColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanSpecExpirationRun(r, sc)
+	})
+}
+
+var createSpecExpirationRunQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_expiration_runs.go:CreateSpecExpirationRun
+INSERT INTO campaign_spec_expiration_runs (
+  started_at,
+  finished_at,
+  dry_run,
+  changeset_specs_deleted,
+  campaign_specs_deleted,
+  campaigns_deleted,
+  error
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// GetLastSpecExpirationRun returns the SpecExpirationRun that started last.
+// It returns ErrNoResults if no run has been recorded yet.
+func (s *Store) GetLastSpecExpirationRun(ctx context.Context) (*campaigns.SpecExpirationRun, error) {
+	q := sqlf.Sprintf(
+		getLastSpecExpirationRunQueryFmtstr,
+		sqlf.Join(specExpirationRunColumns, ", "),
+	)
+
+	var r campaigns.SpecExpirationRun
+	err := s.readQuery(ctx, q, func(sc scanner) error {
+		return scanSpecExpirationRun(&r, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &r, nil
+}
+
+var getLastSpecExpirationRunQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_expiration_runs.go:GetLastSpecExpirationRun
+SELECT %s FROM campaign_spec_expiration_runs
+ORDER BY started_at DESC, id DESC
+LIMIT 1
+`
+
+// DeleteSpecExpirationRuns deletes the SpecExpirationRuns that started before
+// the given time.
+func (s *Store) DeleteSpecExpirationRuns(ctx context.Context, before time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteSpecExpirationRunsQueryFmtstr, before))
+}
+
+var deleteSpecExpirationRunsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_expiration_runs.go:DeleteSpecExpirationRuns
+DELETE FROM campaign_spec_expiration_runs WHERE started_at < %s
+`
+
+func scanSpecExpirationRun(r *campaigns.SpecExpirationRun, sc scanner) error {
+	err := sc.Scan(
+		&r.ID,
+		&r.StartedAt,
+		&r.FinishedAt,
+		&r.DryRun,
+		&r.ChangesetSpecsDeleted,
+		&r.CampaignSpecsDeleted,
+		&r.CampaignsDeleted,
+		&dbutil.NullString{S: &r.Error},
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning spec expiration run")
+	}
+	return nil
+}
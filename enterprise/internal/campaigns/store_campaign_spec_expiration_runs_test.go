@@ -0,0 +1,70 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreSpecExpirationRuns(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	t.Run("GetLast without runs", func(t *testing.T) {
+		if _, err := s.GetLastSpecExpirationRun(ctx); err != ErrNoResults {
+			t.Fatalf("unexpected error: have %v, want %v", err, ErrNoResults)
+		}
+	})
+
+	now := clock.now()
+	runs := []*campaigns.SpecExpirationRun{
+		{
+			StartedAt:             now.Add(-2 * time.Hour),
+			FinishedAt:            now.Add(-2 * time.Hour).Add(time.Second),
+			ChangesetSpecsDeleted: 3,
+			CampaignSpecsDeleted:  1,
+		},
+		{
+			StartedAt:  now.Add(-time.Hour),
+			FinishedAt: now.Add(-time.Hour).Add(time.Second),
+			DryRun:     true,
+			Error:      "boom",
+		},
+		{
+			StartedAt:        now,
+			FinishedAt:       now.Add(time.Second),
+			CampaignsDeleted: 2,
+		},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, r := range runs {
+			if err := s.CreateSpecExpirationRun(ctx, r); err != nil {
+				t.Fatal(err)
+			}
+			if r.ID == 0 {
+				t.Fatal("run ID not set")
+			}
+		}
+	})
+
+	t.Run("GetLast", func(t *testing.T) {
+		have, err := s.GetLastSpecExpirationRun(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(runs[2], have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteSpecExpirationRuns(ctx, now.Add(time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetLastSpecExpirationRun(ctx); err != ErrNoResults {
+			t.Fatalf("unexpected error: have %v, want %v", err, ErrNoResults)
+		}
+	})
+}
@@ -25,6 +25,7 @@ var campaignSpecColumns = []*sqlf.Query{
 	sqlf.Sprintf("campaign_specs.user_id"),
 	sqlf.Sprintf("campaign_specs.created_at"),
 	sqlf.Sprintf("campaign_specs.updated_at"),
+	sqlf.Sprintf("campaign_specs.pinned_at"),
 }
 
 // campaignSpecInsertColumns is the list of campaign_specs columns that are
@@ -38,9 +39,10 @@ var campaignSpecInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("user_id"),
 	sqlf.Sprintf("created_at"),
 	sqlf.Sprintf("updated_at"),
+	sqlf.Sprintf("pinned_at"),
 }
 
-const campaignSpecInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s)`
+const campaignSpecInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s, %s)`
 
 // CreateCampaignSpec creates the given CampaignSpec.
 func (s *Store) CreateCampaignSpec(ctx context.Context, c *campaigns.CampaignSpec) error {
@@ -88,6 +90,7 @@ func (s *Store) createCampaignSpecQuery(c *campaigns.CampaignSpec) (*sqlf.Query,
 		c.UserID,
 		c.CreatedAt,
 		c.UpdatedAt,
+		nullTimeColumn(c.PinnedAt),
 		sqlf.Join(campaignSpecColumns, ", "),
 	), nil
 }
@@ -130,6 +133,7 @@ func (s *Store) updateCampaignSpecQuery(c *campaigns.CampaignSpec) (*sqlf.Query,
 		c.UserID,
 		c.CreatedAt,
 		c.UpdatedAt,
+		nullTimeColumn(c.PinnedAt),
 		c.ID,
 		sqlf.Join(campaignSpecColumns, ", "),
 	), nil
@@ -137,7 +141,7 @@ func (s *Store) updateCampaignSpecQuery(c *campaigns.CampaignSpec) (*sqlf.Query,
 
 // DeleteCampaignSpec deletes the CampaignSpec with the given ID.
 func (s *Store) DeleteCampaignSpec(ctx context.Context, id int64) error {
-	return s.Store.Exec(ctx, sqlf.Sprintf(deleteCampaignSpecQueryFmtstr, id))
+	return s.Exec(ctx, sqlf.Sprintf(deleteCampaignSpecQueryFmtstr, id))
 }
 
 var deleteCampaignSpecQueryFmtstr = `
@@ -268,12 +272,13 @@ func listCampaignSpecsQuery(opts *ListCampaignSpecsOpts) *sqlf.Query {
 }
 
 // DeleteExpiredCampaignSpecs deletes CampaignSpecs that have not been attached
-// to a Campaign within CampaignSpecTTL.
-func (s *Store) DeleteExpiredCampaignSpecs(ctx context.Context) error {
-	expirationTime := s.now().Add(-campaigns.CampaignSpecTTL)
+// to a Campaign within CampaignSpecTTL, unless they are pinned. It returns the
+// IDs of the deleted CampaignSpecs.
+func (s *Store) DeleteExpiredCampaignSpecs(ctx context.Context) ([]int64, error) {
+	expirationTime := s.now().Add(-campaigns.CampaignSpecTTL())
 	q := sqlf.Sprintf(deleteExpiredCampaignSpecsQueryFmtstr, expirationTime)
 
-	return s.Store.Exec(ctx, q)
+	return s.queryIDs(ctx, q)
 }
 
 var deleteExpiredCampaignSpecsQueryFmtstr = `
@@ -282,13 +287,16 @@ DELETE FROM
   campaign_specs
 WHERE
   created_at < %s
+AND
+  pinned_at IS NULL
 AND
 NOT EXISTS (
   SELECT 1 FROM campaigns WHERE campaign_spec_id = campaign_specs.id
 )
 AND NOT EXISTS (
   SELECT 1 FROM changeset_specs WHERE campaign_spec_id = campaign_specs.id
-);
+)
+RETURNING id;
 `
 
 func scanCampaignSpec(c *campaigns.CampaignSpec, s scanner) error {
@@ -304,6 +312,7 @@ func scanCampaignSpec(c *campaigns.CampaignSpec, s scanner) error {
 		&c.UserID,
 		&c.CreatedAt,
 		&c.UpdatedAt,
+		&dbutil.NullTime{Time: &c.PinnedAt},
 	)
 
 	if err != nil {
@@ -167,6 +167,7 @@ func testStoreCampaignSpecs(t *testing.T, ctx context.Context, s *Store, _ repos
 	t.Run("Update", func(t *testing.T) {
 		for _, c := range campaignSpecs {
 			c.UserID += 1234
+			c.PinnedAt = clock.now()
 
 			clock.add(1 * time.Second)
 
@@ -236,13 +237,14 @@ func testStoreCampaignSpecs(t *testing.T, ctx context.Context, s *Store, _ repos
 	})
 
 	t.Run("DeleteExpiredCampaignSpecs", func(t *testing.T) {
-		underTTL := clock.now().Add(-cmpgn.CampaignSpecTTL + 1*time.Minute)
-		overTTL := clock.now().Add(-cmpgn.CampaignSpecTTL - 1*time.Minute)
+		underTTL := clock.now().Add(-cmpgn.CampaignSpecTTL() + 1*time.Minute)
+		overTTL := clock.now().Add(-cmpgn.CampaignSpecTTL() - 1*time.Minute)
 
 		tests := []struct {
 			createdAt         time.Time
 			hasCampaign       bool
 			hasChangesetSpecs bool
+			pinned            bool
 			wantDeleted       bool
 		}{
 			{hasCampaign: false, hasChangesetSpecs: false, createdAt: underTTL, wantDeleted: false},
@@ -253,6 +255,8 @@ func testStoreCampaignSpecs(t *testing.T, ctx context.Context, s *Store, _ repos
 
 			{hasCampaign: true, hasChangesetSpecs: true, createdAt: underTTL, wantDeleted: false},
 			{hasCampaign: true, hasChangesetSpecs: true, createdAt: overTTL, wantDeleted: false},
+
+			{hasCampaign: false, hasChangesetSpecs: false, pinned: true, createdAt: overTTL, wantDeleted: false},
 		}
 
 		for _, tc := range tests {
@@ -261,6 +265,9 @@ func testStoreCampaignSpecs(t *testing.T, ctx context.Context, s *Store, _ repos
 				NamespaceUserID: 1,
 				CreatedAt:       tc.createdAt,
 			}
+			if tc.pinned {
+				campaignSpec.PinnedAt = clock.now()
+			}
 
 			if err := s.CreateCampaignSpec(ctx, campaignSpec); err != nil {
 				t.Fatal(err)
@@ -288,9 +295,13 @@ func testStoreCampaignSpecs(t *testing.T, ctx context.Context, s *Store, _ repos
 				}
 			}
 
-			if err := s.DeleteExpiredCampaignSpecs(ctx); err != nil {
+			deleted, err := s.DeleteExpiredCampaignSpecs(ctx)
+			if err != nil {
 				t.Fatal(err)
 			}
+			if have, want := containsID(deleted, campaignSpec.ID), tc.wantDeleted; have != want {
+				t.Fatalf("tc=%+v\n\t campaign spec in returned IDs: have %t, want %t", tc, have, want)
+			}
 
 			haveCampaignSpec, err := s.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: campaignSpec.ID})
 			if err != nil && err != ErrNoResults {
@@ -0,0 +1,257 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignTemplateColumns are used by the campaign template related Store
+// methods to insert and query campaign templates.
+var campaignTemplateColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_templates.id"),
+	sqlf.Sprintf("campaign_templates.name"),
+	sqlf.Sprintf("campaign_templates.description"),
+	sqlf.Sprintf("campaign_templates.template"),
+	sqlf.Sprintf("campaign_templates.parameters"),
+	sqlf.Sprintf("campaign_templates.namespace_user_id"),
+	sqlf.Sprintf("campaign_templates.namespace_org_id"),
+	sqlf.Sprintf("campaign_templates.creator_id"),
+	sqlf.Sprintf("campaign_templates.created_at"),
+	sqlf.Sprintf("campaign_templates.updated_at"),
+}
+
+// campaignTemplateInsertColumns is the list of campaign_templates columns
+// that are modified when inserting campaign templates.
+var campaignTemplateInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("name"),
+	sqlf.Sprintf("description"),
+	sqlf.Sprintf("template"),
+	sqlf.Sprintf("parameters"),
+	sqlf.Sprintf("namespace_user_id"),
+	sqlf.Sprintf("namespace_org_id"),
+	sqlf.Sprintf("creator_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// CreateCampaignTemplate creates the given CampaignTemplate.
+func (s *Store) CreateCampaignTemplate(ctx context.Context, t *campaigns.CampaignTemplate) error {
+	q, err := s.createCampaignTemplateQuery(t)
+	if err != nil {
+		return err
+	}
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignTemplate(t, sc) })
+}
+
+var createCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_templates.go:CreateCampaignTemplate
+INSERT INTO campaign_templates (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s`
+
+func (s *Store) createCampaignTemplateQuery(t *campaigns.CampaignTemplate) (*sqlf.Query, error) {
+	parameters := t.Parameters
+	if parameters == nil {
+		parameters = []campaigns.CampaignTemplateParameter{}
+	}
+	params, err := jsonbColumn(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createCampaignTemplateQueryFmtstr,
+		sqlf.Join(campaignTemplateInsertColumns, ", "),
+		t.Name,
+		t.Description,
+		t.Template,
+		params,
+		nullInt32Column(t.NamespaceUserID),
+		nullInt32Column(t.NamespaceOrgID),
+		nullInt32Column(t.CreatorID),
+		t.CreatedAt,
+		t.UpdatedAt,
+		sqlf.Join(campaignTemplateColumns, ", "),
+	), nil
+}
+
+// DeleteCampaignTemplate deletes the CampaignTemplate with the given ID.
+func (s *Store) DeleteCampaignTemplate(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteCampaignTemplateQueryFmtstr, id))
+}
+
+var deleteCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_templates.go:DeleteCampaignTemplate
+DELETE FROM campaign_templates WHERE id = %s
+`
+
+// CountCampaignTemplatesOpts captures the query options needed for
+// counting campaign templates.
+type CountCampaignTemplatesOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CountCampaignTemplates returns the number of campaign templates in the
+// database.
+func (s *Store) CountCampaignTemplates(ctx context.Context, opts CountCampaignTemplatesOpts) (int, error) {
+	preds := campaignTemplateNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	return s.queryCount(ctx, sqlf.Sprintf(countCampaignTemplatesQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+}
+
+var countCampaignTemplatesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_templates.go:CountCampaignTemplates
+SELECT COUNT(id)
+FROM campaign_templates
+WHERE %s
+`
+
+// GetCampaignTemplateOpts captures the query options needed for getting a
+// CampaignTemplate.
+type GetCampaignTemplateOpts struct {
+	ID int64
+}
+
+// GetCampaignTemplate gets a campaign template matching the given options.
+func (s *Store) GetCampaignTemplate(ctx context.Context, opts GetCampaignTemplateOpts) (*campaigns.CampaignTemplate, error) {
+	q := sqlf.Sprintf(
+		getCampaignTemplateQueryFmtstr,
+		sqlf.Join(campaignTemplateColumns, ", "),
+		opts.ID,
+	)
+
+	var t campaigns.CampaignTemplate
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignTemplate(&t, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &t, nil
+}
+
+var getCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_templates.go:GetCampaignTemplate
+SELECT %s FROM campaign_templates
+WHERE id = %s
+LIMIT 1
+`
+
+// ListCampaignTemplatesOpts captures the query options needed for listing
+// campaign templates.
+type ListCampaignTemplatesOpts struct {
+	Cursor int64
+	Limit  int
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ListCampaignTemplates lists CampaignTemplates with the given filters.
+func (s *Store) ListCampaignTemplates(ctx context.Context, opts ListCampaignTemplatesOpts) (ts []*campaigns.CampaignTemplate, next int64, err error) {
+	q := listCampaignTemplatesQuery(&opts)
+
+	ts = make([]*campaigns.CampaignTemplate, 0, opts.Limit)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var t campaigns.CampaignTemplate
+		if err := scanCampaignTemplate(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.Limit {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listCampaignTemplatesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_templates.go:ListCampaignTemplates
+SELECT %s FROM campaign_templates
+WHERE %s
+ORDER BY id ASC
+`
+
+func listCampaignTemplatesQuery(opts *ListCampaignTemplatesOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := campaignTemplateNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	preds = append(preds, sqlf.Sprintf("id >= %s", opts.Cursor))
+
+	return sqlf.Sprintf(
+		listCampaignTemplatesQueryFmtstr+limitClause,
+		sqlf.Join(campaignTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func campaignTemplateNamespacePreds(namespaceUserID, namespaceOrgID int32) []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+
+	if namespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_templates.namespace_user_id = %s", namespaceUserID))
+	}
+
+	if namespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_templates.namespace_org_id = %s", namespaceOrgID))
+	}
+
+	return preds
+}
+
+func scanCampaignTemplate(t *campaigns.CampaignTemplate, s scanner) error {
+	var params json.RawMessage
+
+	err := s.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&t.Template,
+		&params,
+		&dbutil.NullInt32{N: &t.NamespaceUserID},
+		&dbutil.NullInt32{N: &t.NamespaceOrgID},
+		&dbutil.NullInt32{N: &t.CreatorID},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign template")
+	}
+
+	if err = json.Unmarshal(params, &t.Parameters); err != nil {
+		return errors.Wrap(err, "scanCampaignTemplate: failed to unmarshal parameters")
+	}
+
+	return nil
+}
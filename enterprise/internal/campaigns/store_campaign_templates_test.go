@@ -0,0 +1,171 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignTemplates(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	templates := make([]*cmpgn.CampaignTemplate, 0, 3)
+	defaultBranch := "main"
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(templates); i++ {
+			tmpl := &cmpgn.CampaignTemplate{
+				Name:        "bump-dependency",
+				Description: "Bumps a dependency",
+				Template:    "name: bump-${{ parameters.dependency }}",
+				CreatorID:   int32(i + 1234),
+			}
+
+			if i%2 == 0 {
+				tmpl.NamespaceOrgID = 23
+				tmpl.Parameters = []cmpgn.CampaignTemplateParameter{
+					{Name: "dependency", Description: "The dependency"},
+					{Name: "branch", Default: &defaultBranch},
+				}
+			} else {
+				tmpl.NamespaceUserID = tmpl.CreatorID
+			}
+
+			want := tmpl.Clone()
+			have := tmpl
+
+			if err := s.CreateCampaignTemplate(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+			if want.Parameters == nil {
+				want.Parameters = []cmpgn.CampaignTemplateParameter{}
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			templates = append(templates, tmpl)
+		}
+	})
+
+	if len(templates) != cap(templates) {
+		t.Fatalf("templates is empty. creation failed")
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		tests := map[string]struct {
+			opts CountCampaignTemplatesOpts
+			want int
+		}{
+			"All":             {opts: CountCampaignTemplatesOpts{}, want: len(templates)},
+			"NamespaceOrgID":  {opts: CountCampaignTemplatesOpts{NamespaceOrgID: 23}, want: 2},
+			"NamespaceUserID": {opts: CountCampaignTemplatesOpts{NamespaceUserID: templates[1].CreatorID}, want: 1},
+		}
+
+		for name, tc := range tests {
+			t.Run(name, func(t *testing.T) {
+				count, err := s.CountCampaignTemplates(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if have, want := count, tc.want; have != want {
+					t.Fatalf("have count: %d, want: %d", have, want)
+				}
+			})
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("NoLimit", func(t *testing.T) {
+			have, next, err := s.ListCampaignTemplates(ctx, ListCampaignTemplatesOpts{Limit: -1})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := next, int64(0); have != want {
+				t.Fatalf("have next %v, want %v", have, want)
+			}
+
+			if diff := cmp.Diff(have, templates); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimitAndCursor", func(t *testing.T) {
+			var cursor int64
+			for i := 1; i <= len(templates); i++ {
+				opts := ListCampaignTemplatesOpts{Cursor: cursor, Limit: 1}
+				have, next, err := s.ListCampaignTemplates(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := templates[i-1 : i]
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+
+				cursor = next
+			}
+		})
+
+		t.Run("ByNamespace", func(t *testing.T) {
+			opts := ListCampaignTemplatesOpts{NamespaceUserID: templates[1].CreatorID}
+			have, _, err := s.ListCampaignTemplates(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := templates[1:2]
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		want := templates[0]
+
+		have, err := s.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: want.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: 0xdeadbeef})
+		if have, want := err, ErrNoResults; have != want {
+			t.Fatalf("have err %v, want %v", have, want)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for i := range templates {
+			if err := s.DeleteCampaignTemplate(ctx, templates[i].ID); err != nil {
+				t.Fatal(err)
+			}
+
+			count, err := s.CountCampaignTemplates(ctx, CountCampaignTemplatesOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := count, len(templates)-(i+1); have != want {
+				t.Fatalf("have count: %d, want: %d", have, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package campaigns
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignWebhookDeliveryColumns are used by the campaign webhook delivery
+// related Store methods to query deliveries.
+var campaignWebhookDeliveryColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_webhook_deliveries.id"),
+	sqlf.Sprintf("campaign_webhook_deliveries.url"),
+	sqlf.Sprintf("campaign_webhook_deliveries.event"),
+	sqlf.Sprintf("campaign_webhook_deliveries.payload"),
+	sqlf.Sprintf("campaign_webhook_deliveries.attempts"),
+	sqlf.Sprintf("campaign_webhook_deliveries.next_attempt_at"),
+	sqlf.Sprintf("campaign_webhook_deliveries.last_error"),
+	sqlf.Sprintf("campaign_webhook_deliveries.delivered_at"),
+	sqlf.Sprintf("campaign_webhook_deliveries.created_at"),
+}
+
+// CreateCampaignWebhookDelivery creates the given CampaignWebhookDelivery. It
+// is due right away unless NextAttemptAt is set.
+func (s *Store) CreateCampaignWebhookDelivery(ctx context.Context, d *campaigns.CampaignWebhookDelivery) error {
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = s.now()
+	}
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = d.CreatedAt
+	}
+
+	payload, err := jsonbColumn(d.Payload)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		createCampaignWebhookDeliveryQueryFmtstr,
+		d.URL,
+		d.Event,
+		payload,
+		d.Attempts,
+		d.NextAttemptAt,
+		nullStringColumn(d.LastError),
+		nullTimeColumn(d.DeliveredAt),
+		d.CreatedAt,
+		sqlf.Join(campaignWebhookDeliveryColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignWebhookDelivery(d, sc) })
+}
+
+var createCampaignWebhookDeliveryQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_webhook_deliveries.go:CreateCampaignWebhookDelivery
+INSERT INTO campaign_webhook_deliveries (url, event, payload, attempts, next_attempt_at, last_error, delivered_at, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateCampaignWebhookDelivery updates the attempts, the next attempt, the
+// last error and the delivery time of the given CampaignWebhookDelivery.
+func (s *Store) UpdateCampaignWebhookDelivery(ctx context.Context, d *campaigns.CampaignWebhookDelivery) error {
+	q := sqlf.Sprintf(
+		updateCampaignWebhookDeliveryQueryFmtstr,
+		d.Attempts,
+		d.NextAttemptAt,
+		nullStringColumn(d.LastError),
+		nullTimeColumn(d.DeliveredAt),
+		d.ID,
+		sqlf.Join(campaignWebhookDeliveryColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignWebhookDelivery(d, sc) })
+}
+
+var updateCampaignWebhookDeliveryQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_webhook_deliveries.go:UpdateCampaignWebhookDelivery
+UPDATE campaign_webhook_deliveries
+SET
+  attempts = %s,
+  next_attempt_at = %s,
+  last_error = %s,
+  delivered_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// ListDueCampaignWebhookDeliveriesOpts captures the query options needed for
+// listing due campaign webhook deliveries.
+type ListDueCampaignWebhookDeliveriesOpts struct {
+	// MaxAttempts excludes the deliveries that have been attempted this many
+	// times.
+	MaxAttempts int32
+	Limit       int
+}
+
+// ListDueCampaignWebhookDeliveries lists the undelivered
+// CampaignWebhookDeliveries whose next attempt is due, oldest first.
+func (s *Store) ListDueCampaignWebhookDeliveries(ctx context.Context, opts ListDueCampaignWebhookDeliveriesOpts) (ds []*campaigns.CampaignWebhookDelivery, err error) {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("delivered_at IS NULL"),
+		sqlf.Sprintf("next_attempt_at <= %s", s.now()),
+	}
+	if opts.MaxAttempts != 0 {
+		preds = append(preds, sqlf.Sprintf("attempts < %s", opts.MaxAttempts))
+	}
+
+	q := sqlf.Sprintf(
+		listDueCampaignWebhookDeliveriesQueryFmtstr,
+		sqlf.Join(campaignWebhookDeliveryColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+		opts.Limit,
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var d campaigns.CampaignWebhookDelivery
+		if err := scanCampaignWebhookDelivery(&d, sc); err != nil {
+			return err
+		}
+		ds = append(ds, &d)
+		return nil
+	})
+	return ds, err
+}
+
+var listDueCampaignWebhookDeliveriesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_webhook_deliveries.go:ListDueCampaignWebhookDeliveries
+SELECT %s FROM campaign_webhook_deliveries
+WHERE %s
+ORDER BY id ASC
+LIMIT %s
+`
+
+// ListCampaignWebhookDeliveriesOpts captures the query options needed for
+// listing campaign webhook deliveries.
+type ListCampaignWebhookDeliveriesOpts struct {
+	Event campaigns.CampaignWebhookEvent
+}
+
+// ListCampaignWebhookDeliveries lists the CampaignWebhookDeliveries with the
+// given filters, oldest first.
+func (s *Store) ListCampaignWebhookDeliveries(ctx context.Context, opts ListCampaignWebhookDeliveriesOpts) (ds []*campaigns.CampaignWebhookDelivery, err error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.Event != "" {
+		preds = append(preds, sqlf.Sprintf("event = %s", opts.Event))
+	}
+
+	q := sqlf.Sprintf(
+		listCampaignWebhookDeliveriesQueryFmtstr,
+		sqlf.Join(campaignWebhookDeliveryColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var d campaigns.CampaignWebhookDelivery
+		if err := scanCampaignWebhookDelivery(&d, sc); err != nil {
+			return err
+		}
+		ds = append(ds, &d)
+		return nil
+	})
+	return ds, err
+}
+
+var listCampaignWebhookDeliveriesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_webhook_deliveries.go:ListCampaignWebhookDeliveries
+SELECT %s FROM campaign_webhook_deliveries
+WHERE %s
+ORDER BY id ASC
+`
+
+// DeleteCampaignWebhookDelivery deletes the CampaignWebhookDelivery with the
+// given ID.
+func (s *Store) DeleteCampaignWebhookDelivery(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteCampaignWebhookDeliveryQueryFmtstr, id))
+}
+
+var deleteCampaignWebhookDeliveryQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_webhook_deliveries.go:DeleteCampaignWebhookDelivery
+DELETE FROM campaign_webhook_deliveries WHERE id = %s
+`
+
+func scanCampaignWebhookDelivery(d *campaigns.CampaignWebhookDelivery, sc scanner) error {
+	err := sc.Scan(
+		&d.ID,
+		&d.URL,
+		&d.Event,
+		&d.Payload,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&dbutil.NullString{S: &d.LastError},
+		&dbutil.NullTime{Time: &d.DeliveredAt},
+		&d.CreatedAt,
+	)
+	return errors.Wrap(err, "scanning campaign webhook delivery")
+}
@@ -0,0 +1,108 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignWebhookDeliveries(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	deliveries := []*cmpgn.CampaignWebhookDelivery{
+		{URL: "https://example.com/a", Event: cmpgn.CampaignWebhookEventCampaignApplied, Payload: []byte(`{"event":"campaign.applied"}`)},
+		{URL: "https://example.com/b", Event: cmpgn.CampaignWebhookEventCampaignApplied, Payload: []byte(`{"event":"campaign.applied"}`)},
+		{URL: "https://example.com/a", Event: cmpgn.CampaignWebhookEventChangesetPublished, Payload: []byte(`{"event":"changeset.published"}`)},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, d := range deliveries {
+			if err := s.CreateCampaignWebhookDelivery(ctx, d); err != nil {
+				t.Fatal(err)
+			}
+			if d.ID == 0 {
+				t.Fatal("delivery has no ID")
+			}
+			if !d.NextAttemptAt.Equal(clock.now()) {
+				t.Fatalf("wrong next attempt. want=%s, have=%s", clock.now(), d.NextAttemptAt)
+			}
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListCampaignWebhookDeliveries(ctx, ListCampaignWebhookDeliveriesOpts{
+			Event: cmpgn.CampaignWebhookEventCampaignApplied,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(deliveries[:2], have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		// The first delivery failed and is retried later, the second one
+		// succeeded and the third one has been attempted too often.
+		failed := deliveries[0]
+		failed.Attempts = 1
+		failed.LastError = "unexpected response status: 500 Internal Server Error"
+		failed.NextAttemptAt = clock.now().Add(time.Minute)
+
+		delivered := deliveries[1]
+		delivered.Attempts = 1
+		delivered.DeliveredAt = clock.now()
+
+		exhausted := deliveries[2]
+		exhausted.Attempts = 5
+
+		for _, d := range deliveries {
+			want := *d
+			if err := s.UpdateCampaignWebhookDelivery(ctx, d); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(&want, d); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("ListDue", func(t *testing.T) {
+		opts := ListDueCampaignWebhookDeliveriesOpts{MaxAttempts: 5}
+
+		have, err := s.ListDueCampaignWebhookDeliveries(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("deliveries are due: %+v", have)
+		}
+
+		clock.add(time.Minute)
+		have, err = s.ListDueCampaignWebhookDeliveries(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(deliveries[:1], have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, d := range deliveries {
+			if err := s.DeleteCampaignWebhookDelivery(ctx, d.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListCampaignWebhookDeliveries(ctx, ListCampaignWebhookDeliveriesOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("deliveries not deleted: %+v", have)
+		}
+	})
+}
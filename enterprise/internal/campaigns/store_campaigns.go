@@ -2,6 +2,8 @@ package campaigns
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
@@ -24,6 +26,15 @@ var campaignColumns = []*sqlf.Query{
 	sqlf.Sprintf("campaigns.changeset_ids"),
 	sqlf.Sprintf("campaigns.closed_at"),
 	sqlf.Sprintf("campaigns.campaign_spec_id"),
+	sqlf.Sprintf("campaigns.auto_merge"),
+	sqlf.Sprintf("campaigns.visibility"),
+	sqlf.Sprintf("campaigns.diff_stat_added"),
+	sqlf.Sprintf("campaigns.diff_stat_changed"),
+	sqlf.Sprintf("campaigns.diff_stat_deleted"),
+	sqlf.Sprintf("campaigns.deleted_at"),
+	sqlf.Sprintf("campaigns.auto_rebase"),
+	sqlf.Sprintf("campaigns.update_propagation"),
+	sqlf.Sprintf("campaigns.paused_at"),
 }
 
 // campaignInsertColumns is the list of campaign columns that are modified in
@@ -42,6 +53,12 @@ var campaignInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("changeset_ids"),
 	sqlf.Sprintf("closed_at"),
 	sqlf.Sprintf("campaign_spec_id"),
+	sqlf.Sprintf("auto_merge"),
+	sqlf.Sprintf("visibility"),
+	sqlf.Sprintf("deleted_at"),
+	sqlf.Sprintf("auto_rebase"),
+	sqlf.Sprintf("update_propagation"),
+	sqlf.Sprintf("paused_at"),
 }
 
 // CreateCampaign creates the given Campaign.
@@ -59,7 +76,7 @@ func (s *Store) CreateCampaign(ctx context.Context, c *campaigns.Campaign) error
 var createCampaignQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store.go:CreateCampaign
 INSERT INTO campaigns (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -77,6 +94,14 @@ func (s *Store) createCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		c.UpdatedAt = c.CreatedAt
 	}
 
+	if c.Visibility == "" {
+		c.Visibility = campaigns.CampaignVisibilityPublic
+	}
+
+	if c.UpdatePropagation == "" {
+		c.UpdatePropagation = campaigns.CampaignUpdatePropagationOverwrite
+	}
+
 	return sqlf.Sprintf(
 		createCampaignQueryFmtstr,
 		sqlf.Join(campaignInsertColumns, ", "),
@@ -92,6 +117,12 @@ func (s *Store) createCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		changesetIDs,
 		nullTimeColumn(c.ClosedAt),
 		nullInt64Column(c.CampaignSpecID),
+		c.AutoMerge,
+		c.Visibility,
+		nullTimeColumn(c.DeletedAt),
+		c.AutoRebase,
+		c.UpdatePropagation,
+		nullTimeColumn(c.PausedAt),
 		sqlf.Join(campaignColumns, ", "),
 	), nil
 }
@@ -109,7 +140,7 @@ func (s *Store) UpdateCampaign(ctx context.Context, c *campaigns.Campaign) error
 var updateCampaignQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store.go:UpdateCampaign
 UPDATE campaigns
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING %s
 `
@@ -137,14 +168,99 @@ func (s *Store) updateCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		changesetIDs,
 		nullTimeColumn(c.ClosedAt),
 		nullInt64Column(c.CampaignSpecID),
+		c.AutoMerge,
+		c.Visibility,
+		nullTimeColumn(c.DeletedAt),
+		c.AutoRebase,
+		c.UpdatePropagation,
+		nullTimeColumn(c.PausedAt),
 		c.ID,
 		sqlf.Join(campaignColumns, ", "),
 	), nil
 }
 
-// DeleteCampaign deletes the Campaign with the given ID.
+// UpdateCampaignColumnsOpts captures the columns that UpdateCampaignColumns
+// writes. Nil fields are left unchanged. A pointer to the zero time.Time sets
+// the column to NULL.
+type UpdateCampaignColumnsOpts struct {
+	ID int64
+
+	ClosedAt          *time.Time
+	DeletedAt         *time.Time
+	PausedAt          *time.Time
+	AutoMerge         *bool
+	AutoRebase        *bool
+	Visibility        *campaigns.CampaignVisibility
+	UpdatePropagation *campaigns.CampaignUpdatePropagation
+}
+
+// UpdateCampaignColumns updates only the given columns of the Campaign with
+// the given ID and returns the updated Campaign. Unlike UpdateCampaign it
+// doesn't rewrite the whole row, so it doesn't overwrite concurrent changes
+// to other columns made by the reconciler, the syncer or other mutations.
+func (s *Store) UpdateCampaignColumns(ctx context.Context, opts UpdateCampaignColumnsOpts) (*campaigns.Campaign, error) {
+	q := s.updateCampaignColumnsQuery(&opts)
+
+	var c campaigns.Campaign
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaign(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var updateCampaignColumnsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaigns.go:UpdateCampaignColumns
+UPDATE campaigns
+SET %s
+WHERE id = %s
+RETURNING %s
+`
+
+func (s *Store) updateCampaignColumnsQuery(opts *UpdateCampaignColumnsOpts) *sqlf.Query {
+	sets := []*sqlf.Query{sqlf.Sprintf("updated_at = %s", s.now())}
+
+	if opts.ClosedAt != nil {
+		sets = append(sets, sqlf.Sprintf("closed_at = %s", nullTimeColumn(*opts.ClosedAt)))
+	}
+	if opts.DeletedAt != nil {
+		sets = append(sets, sqlf.Sprintf("deleted_at = %s", nullTimeColumn(*opts.DeletedAt)))
+	}
+	if opts.PausedAt != nil {
+		sets = append(sets, sqlf.Sprintf("paused_at = %s", nullTimeColumn(*opts.PausedAt)))
+	}
+	if opts.AutoMerge != nil {
+		sets = append(sets, sqlf.Sprintf("auto_merge = %s", *opts.AutoMerge))
+	}
+	if opts.AutoRebase != nil {
+		sets = append(sets, sqlf.Sprintf("auto_rebase = %s", *opts.AutoRebase))
+	}
+	if opts.Visibility != nil {
+		sets = append(sets, sqlf.Sprintf("visibility = %s", *opts.Visibility))
+	}
+	if opts.UpdatePropagation != nil {
+		sets = append(sets, sqlf.Sprintf("update_propagation = %s", *opts.UpdatePropagation))
+	}
+
+	return sqlf.Sprintf(
+		updateCampaignColumnsQueryFmtstr,
+		sqlf.Join(sets, ", "),
+		opts.ID,
+		sqlf.Join(campaignColumns, ", "),
+	)
+}
+
+// DeleteCampaign deletes the Campaign with the given ID from the database.
+// Campaigns deleted by users are only marked as deleted, see DeletedAt.
 func (s *Store) DeleteCampaign(ctx context.Context, id int64) error {
-	return s.Store.Exec(ctx, sqlf.Sprintf(deleteCampaignQueryFmtstr, id))
+	return s.Exec(ctx, sqlf.Sprintf(deleteCampaignQueryFmtstr, id))
 }
 
 var deleteCampaignQueryFmtstr = `
@@ -152,6 +268,65 @@ var deleteCampaignQueryFmtstr = `
 DELETE FROM campaigns WHERE id = %s
 `
 
+// campaignViewRecordInterval is how often RecordCampaignView updates the
+// time a campaign was last viewed at most, to avoid writing on every read.
+const campaignViewRecordInterval = 5 * time.Minute
+
+// RecordCampaignView records that the Campaign with the given ID was viewed,
+// unless that was already recorded in the last campaignViewRecordInterval,
+// and returns whether it was recorded. The changeset syncer syncs the
+// changesets of recently viewed campaigns more often.
+func (s *Store) RecordCampaignView(ctx context.Context, id int64) (recorded bool, err error) {
+	now := s.now()
+	q := sqlf.Sprintf(recordCampaignViewQueryFmtstr, now, id, now.Add(-campaignViewRecordInterval))
+	err = s.query(ctx, q, func(sc scanner) error {
+		recorded = true
+		var id int64
+		return sc.Scan(&id)
+	})
+	return recorded, err
+}
+
+var recordCampaignViewQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaigns.go:RecordCampaignView
+UPDATE campaigns SET last_viewed_at = %s
+WHERE id = %s AND (last_viewed_at IS NULL OR last_viewed_at < %s)
+RETURNING id
+`
+
+// DeleteExpiredCampaigns removes the Campaigns that were deleted more than
+// CampaignDeletionRetention ago, so that they can no longer be restored. The
+// changesets they created are kept, but no longer owned by them. It returns
+// the IDs of the removed Campaigns.
+func (s *Store) DeleteExpiredCampaigns(ctx context.Context) (ids []int64, err error) {
+	expirationTime := s.now().Add(-campaigns.CampaignDeletionRetention)
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(disownExpiredCampaignsChangesetsQueryFmtstr, expirationTime)); err != nil {
+		return nil, err
+	}
+	return tx.queryIDs(ctx, sqlf.Sprintf(deleteExpiredCampaignsQueryFmtstr, expirationTime))
+}
+
+var disownExpiredCampaignsChangesetsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:DeleteExpiredCampaigns
+UPDATE changesets SET owned_by_campaign_id = NULL
+WHERE owned_by_campaign_id IN (
+  SELECT id FROM campaigns WHERE deleted_at IS NOT NULL AND deleted_at < %s
+)
+`
+
+var deleteExpiredCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:DeleteExpiredCampaigns
+DELETE FROM campaigns WHERE deleted_at IS NOT NULL AND deleted_at < %s
+RETURNING id
+`
+
 // CountCampaignsOpts captures the query options needed for
 // counting campaigns.
 type CountCampaignsOpts struct {
@@ -159,14 +334,45 @@ type CountCampaignsOpts struct {
 	State       campaigns.CampaignState
 
 	InitialApplierID int32
+	// AdministeredBy, if set, only includes the campaigns that the given
+	// user has admin rights for.
+	AdministeredBy *CampaignAdmin
 
 	NamespaceUserID int32
 	NamespaceOrgID  int32
+
+	// NamespaceType, if set, only includes the campaigns in namespaces of
+	// the given type.
+	NamespaceType campaigns.CampaignNamespaceType
+	// Query, if set, only includes the campaigns whose name contains it,
+	// ignoring case.
+	Query string
+	// NamespaceMemberID, if set, only includes the campaigns in the
+	// namespace of the user with the given ID and in the namespaces of the
+	// orgs the user is a member of.
+	NamespaceMemberID int32
+
+	// VisibleTo, if set, excludes the campaigns the viewer can't see
+	// because of their visibility.
+	VisibleTo *CampaignViewer
+
+	// OnlyDeleted, if set, only includes the deleted campaigns instead of
+	// excluding them.
+	OnlyDeleted bool
+
+	// CreatedAfter and CreatedBefore, if set, only include the campaigns
+	// created at or after and before the given times.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// ClosedAfter and ClosedBefore, if set, only include the campaigns
+	// closed at or after and before the given times.
+	ClosedAfter  time.Time
+	ClosedBefore time.Time
 }
 
 // CountCampaigns returns the number of campaigns in the database.
 func (s *Store) CountCampaigns(ctx context.Context, opts CountCampaignsOpts) (int, error) {
-	return s.queryCount(ctx, countCampaignsQuery(&opts))
+	return s.readQueryCount(ctx, countCampaignsQuery(&opts))
 }
 
 var countCampaignsQueryFmtstr = `
@@ -193,6 +399,10 @@ func countCampaignsQuery(opts *CountCampaignsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("initial_applier_id = %d", opts.InitialApplierID))
 	}
 
+	if opts.AdministeredBy != nil {
+		preds = append(preds, campaignAdministeredByPred(opts.AdministeredBy))
+	}
+
 	if opts.NamespaceUserID != 0 {
 		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", opts.NamespaceUserID))
 	}
@@ -201,10 +411,20 @@ func countCampaignsQuery(opts *CountCampaignsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", opts.NamespaceOrgID))
 	}
 
-	if len(preds) == 0 {
-		preds = append(preds, sqlf.Sprintf("TRUE"))
+	preds = append(preds, campaignNamespacePreds(opts.NamespaceType, opts.NamespaceMemberID)...)
+
+	if opts.Query != "" {
+		preds = append(preds, campaignNameQueryPred(opts.Query))
+	}
+
+	if opts.VisibleTo != nil {
+		preds = append(preds, campaignVisibilityPred(opts.VisibleTo))
 	}
 
+	preds = append(preds, campaignDeletedPred(opts.OnlyDeleted))
+
+	preds = append(preds, campaignDateRangePreds(opts.CreatedAfter, opts.CreatedBefore, opts.ClosedAfter, opts.ClosedBefore)...)
+
 	return sqlf.Sprintf(countCampaignsQueryFmtstr, sqlf.Join(preds, "\n AND "))
 }
 
@@ -216,7 +436,13 @@ type GetCampaignOpts struct {
 	NamespaceOrgID  int32
 
 	CampaignSpecID int64
-	Name           string
+	// Name, together with NamespaceUserID or NamespaceOrgID, looks up a
+	// campaign by its namespace and name, which are unique among the
+	// campaigns that aren't deleted.
+	Name string
+
+	// IncludeDeleted, if set, also matches deleted campaigns.
+	IncludeDeleted bool
 }
 
 // GetCampaign gets a campaign matching the given options.
@@ -268,6 +494,10 @@ func getCampaignQuery(opts *GetCampaignOpts) *sqlf.Query {
 
 	}
 
+	if !opts.IncludeDeleted {
+		preds = append(preds, campaignDeletedPred(false))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -283,14 +513,49 @@ func getCampaignQuery(opts *GetCampaignOpts) *sqlf.Query {
 // listing campaigns.
 type ListCampaignsOpts struct {
 	ChangesetID int64
-	Cursor      int64
-	Limit       int
-	State       campaigns.CampaignState
+	// Cursor is the ID of the first campaign to list, as returned in next by
+	// the previous call to ListCampaigns. Campaigns are listed in ID order,
+	// so that pages are read from an index instead of skipping over the
+	// campaigns on earlier pages.
+	Cursor int64
+	Limit  int
+	State  campaigns.CampaignState
 
 	InitialApplierID int32
+	// AdministeredBy, if set, only includes the campaigns that the given
+	// user has admin rights for.
+	AdministeredBy *CampaignAdmin
 
 	NamespaceUserID int32
 	NamespaceOrgID  int32
+
+	// NamespaceType, if set, only includes the campaigns in namespaces of
+	// the given type.
+	NamespaceType campaigns.CampaignNamespaceType
+	// Query, if set, only includes the campaigns whose name contains it,
+	// ignoring case.
+	Query string
+	// NamespaceMemberID, if set, only includes the campaigns in the
+	// namespace of the user with the given ID and in the namespaces of the
+	// orgs the user is a member of.
+	NamespaceMemberID int32
+
+	// VisibleTo, if set, excludes the campaigns the viewer can't see
+	// because of their visibility.
+	VisibleTo *CampaignViewer
+
+	// OnlyDeleted, if set, only includes the deleted campaigns instead of
+	// excluding them.
+	OnlyDeleted bool
+
+	// CreatedAfter and CreatedBefore, if set, only include the campaigns
+	// created at or after and before the given times.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// ClosedAfter and ClosedBefore, if set, only include the campaigns
+	// closed at or after and before the given times.
+	ClosedAfter  time.Time
+	ClosedBefore time.Time
 }
 
 // ListCampaigns lists Campaigns with the given filters.
@@ -298,9 +563,9 @@ func (s *Store) ListCampaigns(ctx context.Context, opts ListCampaignsOpts) (cs [
 	q := listCampaignsQuery(&opts)
 
 	cs = make([]*campaigns.Campaign, 0, opts.Limit)
-	err = s.query(ctx, q, func(sc scanner) error {
+	err = s.readQuery(ctx, q, func(sc scanner) error {
 		var c campaigns.Campaign
-		if err := scanCampaign(&c, sc); err != nil {
+		if err := scanCampaign(&c, sc, &dbutil.NullInt32{N: &c.CampaignSpecUserID}); err != nil {
 			return err
 		}
 		cs = append(cs, &c)
@@ -317,9 +582,12 @@ func (s *Store) ListCampaigns(ctx context.Context, opts ListCampaignsOpts) (cs [
 
 var listCampaignsQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store.go:ListCampaigns
-SELECT %s FROM campaigns
+SELECT
+  %s,
+  (SELECT user_id FROM campaign_specs WHERE campaign_specs.id = campaigns.campaign_spec_id)
+FROM campaigns
 WHERE %s
-ORDER BY id ASC
+ORDER BY campaigns.id ASC
 LIMIT %s
 `
 
@@ -329,8 +597,9 @@ func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
 	}
 	opts.Limit++
 
-	preds := []*sqlf.Query{
-		sqlf.Sprintf("id >= %s", opts.Cursor),
+	var preds []*sqlf.Query
+	if opts.Cursor > 0 {
+		preds = append(preds, sqlf.Sprintf("campaigns.id >= %s", opts.Cursor))
 	}
 
 	if opts.ChangesetID != 0 {
@@ -348,6 +617,10 @@ func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("initial_applier_id = %d", opts.InitialApplierID))
 	}
 
+	if opts.AdministeredBy != nil {
+		preds = append(preds, campaignAdministeredByPred(opts.AdministeredBy))
+	}
+
 	if opts.NamespaceUserID != 0 {
 		preds = append(preds, sqlf.Sprintf("campaigns.namespace_user_id = %s", opts.NamespaceUserID))
 	}
@@ -356,6 +629,20 @@ func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("campaigns.namespace_org_id = %s", opts.NamespaceOrgID))
 	}
 
+	preds = append(preds, campaignNamespacePreds(opts.NamespaceType, opts.NamespaceMemberID)...)
+
+	if opts.Query != "" {
+		preds = append(preds, campaignNameQueryPred(opts.Query))
+	}
+
+	if opts.VisibleTo != nil {
+		preds = append(preds, campaignVisibilityPred(opts.VisibleTo))
+	}
+
+	preds = append(preds, campaignDeletedPred(opts.OnlyDeleted))
+
+	preds = append(preds, campaignDateRangePreds(opts.CreatedAfter, opts.CreatedBefore, opts.ClosedAfter, opts.ClosedBefore)...)
+
 	return sqlf.Sprintf(
 		listCampaignsQueryFmtstr,
 		sqlf.Join(campaignColumns, ", "),
@@ -364,8 +651,172 @@ func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
 	)
 }
 
-func scanCampaign(c *campaigns.Campaign, s scanner) error {
-	return s.Scan(
+// campaignDateRangePreds returns the predicates that match the campaigns
+// created and closed in the given time ranges. The start of each range is
+// inclusive, the end exclusive, and zero times are ignored.
+func campaignDateRangePreds(createdAfter, createdBefore, closedAfter, closedBefore time.Time) []*sqlf.Query {
+	var preds []*sqlf.Query
+	if !createdAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("campaigns.created_at >= %s", createdAfter))
+	}
+	if !createdBefore.IsZero() {
+		preds = append(preds, sqlf.Sprintf("campaigns.created_at < %s", createdBefore))
+	}
+	if !closedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("campaigns.closed_at >= %s", closedAfter))
+	}
+	if !closedBefore.IsZero() {
+		preds = append(preds, sqlf.Sprintf("campaigns.closed_at < %s", closedBefore))
+	}
+	return preds
+}
+
+// campaignNamespacePreds returns the predicates that match the campaigns in
+// namespaces of the given type and, if memberID is set, in the namespace of
+// that user and the namespaces of their orgs.
+func campaignNamespacePreds(typ campaigns.CampaignNamespaceType, memberID int32) []*sqlf.Query {
+	var preds []*sqlf.Query
+
+	switch typ {
+	case campaigns.CampaignNamespaceTypeUser:
+		preds = append(preds, sqlf.Sprintf("campaigns.namespace_user_id IS NOT NULL"))
+	case campaigns.CampaignNamespaceTypeOrg:
+		preds = append(preds, sqlf.Sprintf("campaigns.namespace_org_id IS NOT NULL"))
+	}
+
+	if memberID != 0 {
+		preds = append(preds, sqlf.Sprintf(campaignNamespaceMemberPredFmtstr, memberID, memberID))
+	}
+
+	return preds
+}
+
+var campaignNamespaceMemberPredFmtstr = `
+(
+  campaigns.namespace_user_id = %s
+  OR EXISTS (
+    SELECT 1 FROM org_members
+    WHERE org_members.org_id = campaigns.namespace_org_id AND org_members.user_id = %s
+  )
+)
+`
+
+// campaignNameQueryPred returns the predicate that matches the campaigns whose
+// name contains the given query, ignoring case. Wildcards in the query are
+// matched literally. It can use the campaigns_name_trgm index.
+func campaignNameQueryPred(query string) *sqlf.Query {
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(query)) + "%"
+	return sqlf.Sprintf(`lower(campaigns.name) LIKE %s ESCAPE '\'`, pattern)
+}
+
+// likeEscaper escapes the characters that have a special meaning in a LIKE
+// pattern with the escape character '\'.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// campaignDeletedPred returns a predicate that matches the deleted campaigns
+// if deleted is true and the campaigns that haven't been deleted otherwise.
+func campaignDeletedPred(deleted bool) *sqlf.Query {
+	if deleted {
+		return sqlf.Sprintf("campaigns.deleted_at IS NOT NULL")
+	}
+	return sqlf.Sprintf("campaigns.deleted_at IS NULL")
+}
+
+// CampaignViewer is the user for whom campaigns are filtered by their
+// visibility. UserID is zero for anonymous viewers. Site admins can see all
+// campaigns and shouldn't be passed as viewers.
+type CampaignViewer struct {
+	UserID int32
+}
+
+// campaignVisibilityPred returns a predicate that matches the campaigns the
+// given viewer can see: public campaigns, the campaigns they created, the
+// campaigns in their own namespace or in the namespace of one of their orgs
+// and the campaigns they or one of their orgs have been granted rights on.
+func campaignVisibilityPred(v *CampaignViewer) *sqlf.Query {
+	return sqlf.Sprintf(
+		campaignVisibilityPredFmtstr,
+		campaigns.CampaignVisibilityPublic,
+		v.UserID,
+		v.UserID,
+		v.UserID,
+		v.UserID,
+		v.UserID,
+	)
+}
+
+var campaignVisibilityPredFmtstr = `
+(
+  campaigns.visibility = %s
+  OR campaigns.initial_applier_id = %s
+  OR campaigns.namespace_user_id = %s
+  OR EXISTS (
+    SELECT 1 FROM org_members
+    WHERE org_members.org_id = campaigns.namespace_org_id AND org_members.user_id = %s
+  )
+  OR EXISTS (
+    SELECT 1 FROM campaign_permission_grants
+    WHERE
+      campaign_permission_grants.campaign_id = campaigns.id AND
+      (
+        campaign_permission_grants.user_id = %s OR
+        campaign_permission_grants.org_id IN (SELECT org_id FROM org_members WHERE user_id = %s)
+      )
+  )
+)
+`
+
+// CampaignAdmin is the user for whom campaigns are filtered by their admin
+// rights. OrgMembersCanAdminister is whether the members of an org have admin
+// rights for all campaigns in the org's namespace.
+type CampaignAdmin struct {
+	UserID                  int32
+	OrgMembersCanAdminister bool
+}
+
+// NewCampaignAdmin returns the CampaignAdmin for the user with the given ID,
+// according to the site configuration.
+func NewCampaignAdmin(userID int32) *CampaignAdmin {
+	return &CampaignAdmin{UserID: userID, OrgMembersCanAdminister: orgMembersCanAdminister()}
+}
+
+// campaignAdministeredByPred returns a predicate that matches the campaigns
+// the given user has admin rights for, like CheckCampaignAdminRights: the
+// campaigns they created, the ones they or one of their orgs have been granted
+// admin rights on and, if enabled, the ones in the namespace of one of their
+// orgs.
+func campaignAdministeredByPred(a *CampaignAdmin) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("campaigns.initial_applier_id = %s", a.UserID),
+		sqlf.Sprintf(campaignAdminGrantPredFmtstr, campaigns.CampaignPermissionLevelAdmin, a.UserID, a.UserID),
+	}
+	if a.OrgMembersCanAdminister {
+		preds = append(preds, sqlf.Sprintf(campaignOrgMemberPredFmtstr, a.UserID))
+	}
+	return sqlf.Sprintf("(%s)", sqlf.Join(preds, "\n  OR "))
+}
+
+var campaignAdminGrantPredFmtstr = `
+EXISTS (
+  SELECT 1 FROM campaign_permission_grants
+  WHERE
+    campaign_permission_grants.campaign_id = campaigns.id AND
+    campaign_permission_grants.level = %s AND
+    (
+      campaign_permission_grants.user_id = %s OR
+      campaign_permission_grants.org_id IN (SELECT org_id FROM org_members WHERE user_id = %s)
+    )
+)
+`
+
+var campaignOrgMemberPredFmtstr = `
+campaigns.namespace_org_id IN (SELECT org_id FROM org_members WHERE user_id = %s)
+`
+
+// scanCampaign scans the campaignColumns into c, followed by the given extra
+// columns.
+func scanCampaign(c *campaigns.Campaign, s scanner, extra ...interface{}) error {
+	dest := []interface{}{
 		&c.ID,
 		&c.Name,
 		&dbutil.NullString{S: &c.Description},
@@ -379,5 +830,15 @@ func scanCampaign(c *campaigns.Campaign, s scanner) error {
 		&dbutil.JSONInt64Set{Set: &c.ChangesetIDs},
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&dbutil.NullInt64{N: &c.CampaignSpecID},
-	)
+		&c.AutoMerge,
+		&c.Visibility,
+		&c.DiffStatAdded,
+		&c.DiffStatChanged,
+		&c.DiffStatDeleted,
+		&dbutil.NullTime{Time: &c.DeletedAt},
+		&c.AutoRebase,
+		&c.UpdatePropagation,
+		&dbutil.NullTime{Time: &c.PausedAt},
+	}
+	return s.Scan(append(dest, extra...)...)
 }
@@ -0,0 +1,131 @@
+package campaigns
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// defaultCampaignsStatisticsCodeHostLimit is the number of code hosts
+// returned by GetCampaignsStatistics if no limit is given.
+const defaultCampaignsStatisticsCodeHostLimit = 5
+
+// GetCampaignsStatisticsOpts captures the query options needed for getting
+// the site-wide CampaignsStatistics.
+type GetCampaignsStatisticsOpts struct {
+	// Since is a point in time in the oldest week to return statistics for.
+	// Weeks start on Monday.
+	Since time.Time
+	// CodeHostLimit is the maximum number of code hosts to return.
+	CodeHostLimit int
+}
+
+// GetCampaignsStatistics computes the site-wide CampaignsStatistics for the
+// weeks from opts.Since up to the current one.
+func (s *Store) GetCampaignsStatistics(ctx context.Context, opts GetCampaignsStatisticsOpts) (*campaigns.CampaignsStatistics, error) {
+	if opts.CodeHostLimit == 0 {
+		opts.CodeHostLimit = defaultCampaignsStatisticsCodeHostLimit
+	}
+
+	var stats campaigns.CampaignsStatistics
+
+	q := sqlf.Sprintf(getCampaignsStatisticsCountsQueryFmtstr, opts.Since)
+	err := s.query(ctx, q, func(sc scanner) error {
+		return sc.Scan(&stats.CampaignsCount, &stats.OpenCampaignsCount, &stats.ActiveAuthorsCount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q = sqlf.Sprintf(getCampaignsStatisticsWeeksQueryFmtstr, opts.Since, s.now())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var w campaigns.CampaignsWeekStatistics
+		if err := sc.Scan(&w.StartsAt, &w.ChangesetsPublished, &w.ChangesetsMerged); err != nil {
+			return err
+		}
+		stats.Weeks = append(stats.Weeks, &w)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q = sqlf.Sprintf(getCampaignsStatisticsCodeHostsQueryFmtstr, opts.CodeHostLimit)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var h campaigns.CampaignsCodeHostStatistics
+		if err := sc.Scan(&h.ExternalServiceType, &h.ExternalServiceID, &h.ChangesetsCount, &h.MergedChangesetsCount); err != nil {
+			return err
+		}
+		stats.CodeHosts = append(stats.CodeHosts, &h)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+var getCampaignsStatisticsCountsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaigns_statistics.go:GetCampaignsStatistics
+SELECT
+  COUNT(*),
+  COUNT(*) FILTER (WHERE closed_at IS NULL),
+  (
+    SELECT COUNT(DISTINCT user_id) FROM campaign_activities
+    WHERE kind = 'APPLIED' AND created_at >= date_trunc('week', %s::timestamptz)
+  )
+FROM campaigns
+WHERE deleted_at IS NULL
+`
+
+var getCampaignsStatisticsWeeksQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaigns_statistics.go:GetCampaignsStatistics
+WITH weeks AS (
+  SELECT generate_series(
+    date_trunc('week', %s::timestamptz),
+    date_trunc('week', %s::timestamptz),
+    '1 week'
+  ) AS starts_at
+),
+published AS (
+  SELECT date_trunc('week', created_at) AS starts_at, COUNT(*) AS count
+  FROM campaign_activities
+  WHERE kind = 'CHANGESET_PUBLISHED'
+  GROUP BY 1
+),
+merged AS (
+  SELECT date_trunc('week', external_updated_at) AS starts_at, COUNT(*) AS count
+  FROM changesets
+  WHERE campaign_ids != '{}'::jsonb AND external_state = 'MERGED'
+  GROUP BY 1
+)
+SELECT
+  weeks.starts_at,
+  COALESCE(published.count, 0),
+  COALESCE(merged.count, 0)
+FROM weeks
+LEFT JOIN published ON published.starts_at = weeks.starts_at
+LEFT JOIN merged ON merged.starts_at = weeks.starts_at
+ORDER BY weeks.starts_at ASC
+`
+
+var getCampaignsStatisticsCodeHostsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaigns_statistics.go:GetCampaignsStatistics
+SELECT
+  repo.external_service_type,
+  repo.external_service_id,
+  COUNT(*),
+  COUNT(*) FILTER (WHERE changesets.external_state = 'MERGED')
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+  changesets.campaign_ids != '{}'::jsonb
+  AND changesets.publication_state = 'PUBLISHED'
+  AND repo.deleted_at IS NULL
+GROUP BY repo.external_service_type, repo.external_service_id
+ORDER BY COUNT(*) DESC, repo.external_service_id ASC
+LIMIT %s
+`
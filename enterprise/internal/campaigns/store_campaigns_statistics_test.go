@@ -0,0 +1,93 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignsStatistics(t *testing.T, ctx context.Context, s *Store, reposStore repos.Store, clock clock) {
+	githubRepo := testRepo(1, extsvc.TypeGitHub)
+	gitlabRepo := testRepo(2, extsvc.TypeGitLab)
+	gitlabRepo.ExternalRepo.ServiceID = "https://gitlab.example.com/"
+	if err := reposStore.UpsertRepos(ctx, githubRepo, gitlabRepo); err != nil {
+		t.Fatal(err)
+	}
+
+	open := &cmpgn.Campaign{Name: "open", InitialApplierID: 1, NamespaceUserID: 1}
+	closed := &cmpgn.Campaign{Name: "closed", InitialApplierID: 2, NamespaceUserID: 2, ClosedAt: clock.now()}
+	deleted := &cmpgn.Campaign{Name: "deleted", InitialApplierID: 3, NamespaceUserID: 3, DeletedAt: clock.now()}
+	for _, c := range []*cmpgn.Campaign{open, closed, deleted} {
+		if err := s.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	createChangeset := func(i int, repo *repos.Repo, state cmpgn.ChangesetExternalState) *cmpgn.Changeset {
+		t.Helper()
+
+		c := &cmpgn.Changeset{
+			RepoID:              repo.ID,
+			CampaignIDs:         []int64{open.ID},
+			ExternalID:          fmt.Sprintf("statistics-%d", i),
+			ExternalServiceType: repo.ExternalRepo.ServiceType,
+			ExternalState:       state,
+			ExternalUpdatedAt:   clock.now(),
+			PublicationState:    cmpgn.ChangesetPublicationStatePublished,
+		}
+		if err := s.CreateChangeset(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	createChangeset(1, githubRepo, cmpgn.ChangesetExternalStateMerged)
+	createChangeset(2, githubRepo, cmpgn.ChangesetExternalStateOpen)
+	createChangeset(3, gitlabRepo, cmpgn.ChangesetExternalStateOpen)
+
+	for _, a := range []*cmpgn.CampaignActivity{
+		{CampaignID: open.ID, UserID: 1, Kind: cmpgn.CampaignActivityKindApplied},
+		{CampaignID: open.ID, UserID: 1, Kind: cmpgn.CampaignActivityKindApplied},
+		{CampaignID: closed.ID, UserID: 2, Kind: cmpgn.CampaignActivityKindApplied},
+		{CampaignID: open.ID, Kind: cmpgn.CampaignActivityKindChangesetPublished},
+		{CampaignID: open.ID, Kind: cmpgn.CampaignActivityKindChangesetPublished},
+	} {
+		if err := s.CreateCampaignActivity(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	have, err := s.GetCampaignsStatistics(ctx, GetCampaignsStatisticsOpts{
+		Since: clock.now().AddDate(0, 0, -7),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have.CampaignsCount != 2 || have.OpenCampaignsCount != 1 || have.ActiveAuthorsCount != 2 {
+		t.Fatalf("wrong counts: %+v", have)
+	}
+
+	if len(have.Weeks) != 2 {
+		t.Fatalf("wrong number of weeks. want=2, have=%d", len(have.Weeks))
+	}
+	if w := have.Weeks[0]; w.ChangesetsPublished != 0 || w.ChangesetsMerged != 0 {
+		t.Fatalf("wrong statistics of last week: %+v", w)
+	}
+	if w := have.Weeks[1]; w.ChangesetsPublished != 2 || w.ChangesetsMerged != 1 {
+		t.Fatalf("wrong statistics of this week: %+v", w)
+	}
+
+	wantCodeHosts := []*cmpgn.CampaignsCodeHostStatistics{
+		{ExternalServiceType: extsvc.TypeGitHub, ExternalServiceID: "https://example.com/", ChangesetsCount: 2, MergedChangesetsCount: 1},
+		{ExternalServiceType: extsvc.TypeGitLab, ExternalServiceID: "https://gitlab.example.com/", ChangesetsCount: 1},
+	}
+	if diff := cmp.Diff(wantCodeHosts, have.CodeHosts); diff != "" {
+		t.Fatal(diff)
+	}
+}
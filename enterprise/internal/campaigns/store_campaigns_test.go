@@ -28,6 +28,11 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 				ChangesetIDs:   []int64{int64(i) + 1},
 				CampaignSpecID: 1742 + int64(i),
 				ClosedAt:       clock.now(),
+				AutoMerge:      true,
+				AutoRebase:     true,
+				Visibility:     cmpgn.CampaignVisibilityPublic,
+
+				UpdatePropagation: cmpgn.CampaignUpdatePropagationOverwrite,
 			}
 
 			if i == 0 {
@@ -36,6 +41,8 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 				c.ClosedAt = time.Time{}
 				c.LastAppliedAt = time.Time{}
 				c.LastApplierID = 0
+				c.AutoMerge = false
+				c.AutoRebase = false
 			}
 
 			if i%2 == 0 {
@@ -44,6 +51,12 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 				c.NamespaceUserID = c.InitialApplierID
 			}
 
+			if i == 1 {
+				c.Visibility = cmpgn.CampaignVisibilityNamespaceOnly
+				c.UpdatePropagation = cmpgn.CampaignUpdatePropagationPreserve
+				c.PausedAt = clock.now()
+			}
+
 			want := c.Clone()
 			have := c
 
@@ -123,6 +136,76 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 			}
 		})
 
+		t.Run("VisibleTo", func(t *testing.T) {
+			// campaigns[1] is only visible in its user namespace.
+			for _, tc := range []struct {
+				viewer CampaignViewer
+				want   int
+			}{
+				{viewer: CampaignViewer{UserID: 0}, want: len(campaigns) - 1},
+				{viewer: CampaignViewer{UserID: campaigns[0].InitialApplierID}, want: len(campaigns) - 1},
+				{viewer: CampaignViewer{UserID: campaigns[1].NamespaceUserID}, want: len(campaigns)},
+			} {
+				viewer := tc.viewer
+				have, err := s.CountCampaigns(ctx, CountCampaignsOpts{VisibleTo: &viewer})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have != tc.want {
+					t.Fatalf("wrong count for viewer %d. want=%d, have=%d", viewer.UserID, tc.want, have)
+				}
+
+				listed, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{VisibleTo: &viewer})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(listed) != tc.want {
+					t.Fatalf("wrong number of campaigns listed for viewer %d. want=%d, have=%d", viewer.UserID, tc.want, len(listed))
+				}
+			}
+		})
+
+		t.Run("NamespaceType and NamespaceMemberID", func(t *testing.T) {
+			// campaigns[0] and campaigns[2] are in org namespaces, campaigns[1]
+			// is in a user namespace.
+			for _, tc := range []struct {
+				name string
+				opts CountCampaignsOpts
+				want int
+			}{
+				{name: "user", opts: CountCampaignsOpts{NamespaceType: cmpgn.CampaignNamespaceTypeUser}, want: 1},
+				{name: "org", opts: CountCampaignsOpts{NamespaceType: cmpgn.CampaignNamespaceTypeOrg}, want: 2},
+				{name: "any", opts: CountCampaignsOpts{NamespaceType: cmpgn.CampaignNamespaceTypeAny}, want: len(campaigns)},
+				{name: "member", opts: CountCampaignsOpts{NamespaceMemberID: campaigns[1].NamespaceUserID}, want: 1},
+				{
+					name: "member of org namespaces",
+					opts: CountCampaignsOpts{NamespaceType: cmpgn.CampaignNamespaceTypeOrg, NamespaceMemberID: campaigns[1].NamespaceUserID},
+					want: 0,
+				},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					have, err := s.CountCampaigns(ctx, tc.opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if have != tc.want {
+						t.Fatalf("wrong count. want=%d, have=%d", tc.want, have)
+					}
+
+					listed, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{
+						NamespaceType:     tc.opts.NamespaceType,
+						NamespaceMemberID: tc.opts.NamespaceMemberID,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(listed) != tc.want {
+						t.Fatalf("wrong number of campaigns listed. want=%d, have=%d", tc.want, len(listed))
+					}
+				})
+			}
+		})
+
 		t.Run("NamespaceOrgID", func(t *testing.T) {
 			wantCounts := map[int32]int{}
 			for _, c := range campaigns {
@@ -307,6 +390,103 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 				}
 			}
 		})
+
+		t.Run("ListCampaigns by Query", func(t *testing.T) {
+			for _, tc := range []struct {
+				query string
+				want  []*cmpgn.Campaign
+			}{
+				{query: "CAMPAIGN-1", want: campaigns[1:2]},
+				{query: "test-campaign", want: campaigns},
+				{query: "no-match", want: []*cmpgn.Campaign{}},
+				// Wildcards are matched literally.
+				{query: "test_campaign", want: []*cmpgn.Campaign{}},
+				{query: "test%campaign", want: []*cmpgn.Campaign{}},
+				{query: `test\-campaign`, want: []*cmpgn.Campaign{}},
+			} {
+				have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: tc.query})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, tc.want); diff != "" {
+					t.Fatalf("query %q: %s", tc.query, diff)
+				}
+
+				count, err := s.CountCampaigns(ctx, CountCampaignsOpts{Query: tc.query})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have, want := count, len(tc.want); have != want {
+					t.Fatalf("query %q: wrong count. want=%d, have=%d", tc.query, want, have)
+				}
+			}
+		})
+
+		t.Run("ListCampaigns by Query with literal underscore", func(t *testing.T) {
+			underscore := &cmpgn.Campaign{
+				Name:             "test_campaign",
+				InitialApplierID: 1,
+				NamespaceUserID:  1,
+				Visibility:       cmpgn.CampaignVisibilityPublic,
+			}
+			if err := s.CreateCampaign(ctx, underscore); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := s.DeleteCampaign(ctx, underscore.ID); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: "t_c"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff([]*cmpgn.Campaign{underscore}, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		dateRangeTests := []struct {
+			name string
+			opts ListCampaignsOpts
+			want []*cmpgn.Campaign
+		}{
+			{name: "CreatedAfter", opts: ListCampaignsOpts{CreatedAfter: clock.now()}, want: campaigns},
+			{name: "CreatedBefore", opts: ListCampaignsOpts{CreatedBefore: clock.now()}, want: []*cmpgn.Campaign{}},
+			{name: "ClosedAfter", opts: ListCampaignsOpts{ClosedAfter: clock.now()}, want: campaigns[1:]},
+			{name: "ClosedBefore", opts: ListCampaignsOpts{ClosedBefore: clock.now().Add(time.Second)}, want: campaigns[1:]},
+			{
+				name: "ClosedAfter and ClosedBefore",
+				opts: ListCampaignsOpts{ClosedAfter: clock.now().Add(-time.Hour), ClosedBefore: clock.now()},
+				want: []*cmpgn.Campaign{},
+			},
+		}
+
+		for _, tc := range dateRangeTests {
+			t.Run("ListCampaigns "+tc.name, func(t *testing.T) {
+				have, _, err := s.ListCampaigns(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, tc.want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				count, err := s.CountCampaigns(ctx, CountCampaignsOpts{
+					CreatedAfter:  tc.opts.CreatedAfter,
+					CreatedBefore: tc.opts.CreatedBefore,
+					ClosedAfter:   tc.opts.ClosedAfter,
+					ClosedBefore:  tc.opts.ClosedBefore,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have, want := count, len(tc.want); have != want {
+					t.Fatalf("wrong count. want=%d, have=%d", want, have)
+				}
+			})
+		}
 	})
 
 	t.Run("Update", func(t *testing.T) {
@@ -378,6 +558,43 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 		}
 	})
 
+	t.Run("UpdateColumns", func(t *testing.T) {
+		for i, c := range campaigns {
+			clock.add(1 * time.Second)
+
+			autoMerge := !c.AutoMerge
+			pausedAt := time.Time{}
+			if !c.Paused() {
+				pausedAt = clock.now()
+			}
+
+			want := c.Clone()
+			want.AutoMerge = autoMerge
+			want.PausedAt = pausedAt
+			want.UpdatedAt = clock.now()
+
+			have, err := s.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{
+				ID:        c.ID,
+				AutoMerge: &autoMerge,
+				PausedAt:  &pausedAt,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			campaigns[i] = have
+		}
+
+		_, err := s.UpdateCampaignColumns(ctx, UpdateCampaignColumnsOpts{ID: 0xdeadbeef})
+		if have, want := err, ErrNoResults; have != want {
+			t.Fatalf("have err %v, want %v", have, want)
+		}
+	})
+
 	t.Run("Get", func(t *testing.T) {
 		t.Run("ByID", func(t *testing.T) {
 			want := campaigns[0]
@@ -460,6 +677,31 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 			}
 		})
 
+		t.Run("ByNamespaceAndName", func(t *testing.T) {
+			for _, c := range campaigns {
+				want := c
+				opts := GetCampaignOpts{
+					Name:            c.Name,
+					NamespaceUserID: c.NamespaceUserID,
+					NamespaceOrgID:  c.NamespaceOrgID,
+				}
+
+				have, err := s.GetCampaign(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+
+			opts := GetCampaignOpts{Name: campaigns[0].Name, NamespaceUserID: 0xbeef}
+			if _, have := s.GetCampaign(ctx, opts); have != ErrNoResults {
+				t.Fatalf("have err %v, want %v", have, ErrNoResults)
+			}
+		})
+
 		t.Run("NoResults", func(t *testing.T) {
 			opts := GetCampaignOpts{ID: 0xdeadbeef}
 
@@ -0,0 +1,168 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// changesetDiffStatJobColumns are used by the changeset diff stat job related
+// Store methods and by the diff stat worker to query jobs.
+var changesetDiffStatJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_diff_stat_jobs.id"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.changeset_id"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.base_ref_oid"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.head_ref_oid"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.state"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.failure_message"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.started_at"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.finished_at"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.process_after"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.num_resets"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.num_attempts"),
+	sqlf.Sprintf("changeset_diff_stat_jobs.created_at"),
+}
+
+// EnqueueChangesetDiffStatJob creates the given ChangesetDiffStatJob in the
+// queued state. Jobs of the same changeset that aren't being processed are
+// deleted, since they're superseded by the new one.
+func (s *Store) EnqueueChangesetDiffStatJob(ctx context.Context, j *campaigns.ChangesetDiffStatJob) error {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		enqueueChangesetDiffStatJobQueryFmtstr,
+		j.ChangesetID,
+		j.ChangesetID,
+		j.BaseRefOid,
+		j.HeadRefOid,
+		j.CreatedAt,
+		sqlf.Join(changesetDiffStatJobColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanChangesetDiffStatJob(j, sc) })
+}
+
+var enqueueChangesetDiffStatJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_diff_stat_jobs.go:EnqueueChangesetDiffStatJob
+WITH superseded AS (
+  DELETE FROM changeset_diff_stat_jobs
+  WHERE changeset_id = %s AND state != 'processing'
+)
+INSERT INTO changeset_diff_stat_jobs (changeset_id, base_ref_oid, head_ref_oid, state, created_at)
+VALUES (%s, %s, %s, 'queued', %s)
+RETURNING %s
+`
+
+// ListChangesetDiffStatJobsOpts captures the query options needed for
+// listing changeset diff stat jobs.
+type ListChangesetDiffStatJobsOpts struct {
+	ChangesetID int64
+}
+
+// ListChangesetDiffStatJobs lists the ChangesetDiffStatJobs with the given
+// filters, oldest first.
+func (s *Store) ListChangesetDiffStatJobs(ctx context.Context, opts ListChangesetDiffStatJobsOpts) (js []*campaigns.ChangesetDiffStatJob, err error) {
+	var preds []*sqlf.Query
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_diff_stat_jobs.changeset_id = %s", opts.ChangesetID))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	q := sqlf.Sprintf(
+		listChangesetDiffStatJobsQueryFmtstr,
+		sqlf.Join(changesetDiffStatJobColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var j campaigns.ChangesetDiffStatJob
+		if err := scanChangesetDiffStatJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+	return js, err
+}
+
+var listChangesetDiffStatJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_diff_stat_jobs.go:ListChangesetDiffStatJobs
+SELECT %s FROM changeset_diff_stat_jobs
+WHERE %s
+ORDER BY changeset_diff_stat_jobs.id ASC
+`
+
+// requeueChangesetDiffStatJob puts the job with the given ID back into the
+// queue, so that it's not processed again before the given time, and counts
+// the attempt.
+func (s *Store) requeueChangesetDiffStatJob(ctx context.Context, id int64, after time.Time) error {
+	q := sqlf.Sprintf(requeueChangesetDiffStatJobQueryFmtstr, after, id)
+	return s.Exec(ctx, q)
+}
+
+var requeueChangesetDiffStatJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_diff_stat_jobs.go:requeueChangesetDiffStatJob
+UPDATE changeset_diff_stat_jobs
+SET state = 'queued', process_after = %s, num_attempts = num_attempts + 1
+WHERE id = %s
+`
+
+func scanFirstChangesetDiffStatJob(rows *sql.Rows, err error) (*campaigns.ChangesetDiffStatJob, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var js []*campaigns.ChangesetDiffStatJob
+	err = scanAll(rows, func(sc scanner) error {
+		var j campaigns.ChangesetDiffStatJob
+		if err := scanChangesetDiffStatJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+	if err != nil || len(js) == 0 {
+		return &campaigns.ChangesetDiffStatJob{}, false, err
+	}
+	return js[0], true, nil
+}
+
+func scanChangesetDiffStatJob(j *campaigns.ChangesetDiffStatJob, sc scanner) error {
+	var (
+		state          string
+		failureMessage string
+	)
+	err := sc.Scan(
+		&j.ID,
+		&j.ChangesetID,
+		&j.BaseRefOid,
+		&j.HeadRefOid,
+		&state,
+		&dbutil.NullString{S: &failureMessage},
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&dbutil.NullTime{Time: &j.ProcessAfter},
+		&j.NumResets,
+		&j.NumAttempts,
+		&j.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning changeset diff stat job")
+	}
+
+	j.State = campaigns.ReconcilerState(strings.ToUpper(state))
+	if failureMessage != "" {
+		j.FailureMessage = &failureMessage
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreChangesetDiffStatJobs(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	// Foreign key constraints are deferred, so the changesets don't need to
+	// exist.
+	changesetID := int64(4242)
+
+	var first *cmpgn.ChangesetDiffStatJob
+
+	t.Run("Enqueue", func(t *testing.T) {
+		j := &cmpgn.ChangesetDiffStatJob{
+			ChangesetID: changesetID,
+			BaseRefOid:  "base",
+			HeadRefOid:  "head-1",
+		}
+		if err := s.EnqueueChangesetDiffStatJob(ctx, j); err != nil {
+			t.Fatal(err)
+		}
+
+		want := &cmpgn.ChangesetDiffStatJob{
+			ID:          j.ID,
+			ChangesetID: changesetID,
+			BaseRefOid:  "base",
+			HeadRefOid:  "head-1",
+			State:       cmpgn.ReconcilerStateQueued,
+			CreatedAt:   clock.now(),
+		}
+		if diff := cmp.Diff(want, j); diff != "" {
+			t.Fatal(diff)
+		}
+
+		first = j
+	})
+
+	t.Run("Enqueue supersedes queued jobs", func(t *testing.T) {
+		j := &cmpgn.ChangesetDiffStatJob{
+			ChangesetID: changesetID,
+			BaseRefOid:  "base",
+			HeadRefOid:  "head-2",
+		}
+		if err := s.EnqueueChangesetDiffStatJob(ctx, j); err != nil {
+			t.Fatal(err)
+		}
+
+		other := &cmpgn.ChangesetDiffStatJob{
+			ChangesetID: changesetID + 1,
+			BaseRefOid:  "base",
+			HeadRefOid:  "head-1",
+		}
+		if err := s.EnqueueChangesetDiffStatJob(ctx, other); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetDiffStatJobs(ctx, ListChangesetDiffStatJobsOpts{ChangesetID: changesetID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*cmpgn.ChangesetDiffStatJob{j}, have); diff != "" {
+			t.Fatal(diff)
+		}
+		if have[0].ID == first.ID {
+			t.Fatal("superseded job wasn't replaced")
+		}
+	})
+
+	t.Run("Requeue", func(t *testing.T) {
+		jobs, err := s.ListChangesetDiffStatJobs(ctx, ListChangesetDiffStatJobsOpts{ChangesetID: changesetID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		after := clock.now().Add(diffStatJobBackoff)
+		if err := s.requeueChangesetDiffStatJob(ctx, jobs[0].ID, after); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetDiffStatJobs(ctx, ListChangesetDiffStatJobsOpts{ChangesetID: changesetID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have[0].NumAttempts != 1 {
+			t.Fatalf("wrong number of attempts. want=1, have=%d", have[0].NumAttempts)
+		}
+		if !have[0].ProcessAfter.Equal(after) {
+			t.Fatalf("wrong process_after. want=%s, have=%s", after, have[0].ProcessAfter)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
@@ -78,6 +79,7 @@ func getChangesetEventQuery(opts *GetChangesetEventOpts) *sqlf.Query {
 // listing changeset events.
 type ListChangesetEventsOpts struct {
 	ChangesetIDs []int64
+	Kinds        []campaigns.ChangesetEventKind
 	Cursor       int64
 	Limit        int
 }
@@ -87,7 +89,7 @@ func (s *Store) ListChangesetEvents(ctx context.Context, opts ListChangesetEvent
 	q := listChangesetEventsQuery(&opts)
 
 	cs = make([]*campaigns.ChangesetEvent, 0, opts.Limit)
-	err = s.query(ctx, q, func(sc scanner) (err error) {
+	err = s.readQuery(ctx, q, func(sc scanner) (err error) {
 		var c campaigns.ChangesetEvent
 		if err = scanChangesetEvent(&c, sc); err != nil {
 			return err
@@ -145,6 +147,10 @@ func listChangesetEventsQuery(opts *ListChangesetEventsOpts) *sqlf.Query {
 			sqlf.Sprintf("changeset_id IN (%s)", sqlf.Join(ids, ",")))
 	}
 
+	if len(opts.Kinds) != 0 {
+		preds = append(preds, changesetEventKindsPredicate(opts.Kinds))
+	}
+
 	return sqlf.Sprintf(
 		listChangesetEventsQueryFmtstr+limitClause,
 		sqlf.Join(preds, "\n AND "),
@@ -155,11 +161,12 @@ func listChangesetEventsQuery(opts *ListChangesetEventsOpts) *sqlf.Query {
 // counting changeset events.
 type CountChangesetEventsOpts struct {
 	ChangesetID int64
+	Kinds       []campaigns.ChangesetEventKind
 }
 
 // CountChangesetEvents returns the number of changeset events in the database.
 func (s *Store) CountChangesetEvents(ctx context.Context, opts CountChangesetEventsOpts) (int, error) {
-	return s.queryCount(ctx, countChangesetEventsQuery(&opts))
+	return s.readQueryCount(ctx, countChangesetEventsQuery(&opts))
 }
 
 var countChangesetEventsQueryFmtstr = `
@@ -175,6 +182,10 @@ func countChangesetEventsQuery(opts *CountChangesetEventsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changeset_id = %s", opts.ChangesetID))
 	}
 
+	if len(opts.Kinds) != 0 {
+		preds = append(preds, changesetEventKindsPredicate(opts.Kinds))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -182,18 +193,179 @@ func countChangesetEventsQuery(opts *CountChangesetEventsOpts) *sqlf.Query {
 	return sqlf.Sprintf(countChangesetEventsQueryFmtstr, sqlf.Join(preds, "\n AND "))
 }
 
-// UpsertChangesetEvents creates or updates the given ChangesetEvents.
+func changesetEventKindsPredicate(kinds []campaigns.ChangesetEventKind) *sqlf.Query {
+	ks := make([]*sqlf.Query, 0, len(kinds))
+	for _, k := range kinds {
+		ks = append(ks, sqlf.Sprintf("%s", k))
+	}
+	return sqlf.Sprintf("kind IN (%s)", sqlf.Join(ks, ","))
+}
+
+// ListChangesetsWithCompactableEventsOpts captures the query options needed
+// for listing the changesets whose events can be compacted.
+type ListChangesetsWithCompactableEventsOpts struct {
+	// UpdatedBefore is the retention cutoff: only merged and closed
+	// changesets that weren't updated on their code host since are listed.
+	UpdatedBefore time.Time
+	Limit         int
+}
+
+// ListChangesetsWithCompactableEvents lists the merged and closed changesets
+// that were last updated on their code host before opts.UpdatedBefore and
+// still have events that DeleteCompactableChangesetEvents would delete.
+func (s *Store) ListChangesetsWithCompactableEvents(ctx context.Context, opts ListChangesetsWithCompactableEventsOpts) (cs campaigns.Changesets, err error) {
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	q := sqlf.Sprintf(
+		listChangesetsWithCompactableEventsQueryFmtstr+limitClause,
+		sqlf.Join(changesetColumns, ", "),
+		compactableChangesetsPred(opts.UpdatedBefore),
+		compactableChangesetEventsPred(),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) (err error) {
+		var c campaigns.Changeset
+		if err = scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listChangesetsWithCompactableEventsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_events.go:ListChangesetsWithCompactableEvents
+SELECT %s FROM changesets
+WHERE %s
+AND EXISTS (
+  SELECT 1 FROM changeset_events
+  WHERE changeset_events.changeset_id = changesets.id
+  AND %s
+)
+ORDER BY changesets.id ASC
+`
+
+// DeleteCompactableChangesetEvents compacts the events of the changesets
+// with the given IDs, by deleting all of their events that don't change
+// their external state. Changesets that aren't merged or closed, or that
+// were updated on their code host since updatedBefore, are skipped, in case
+// they changed since they were listed.
+func (s *Store) DeleteCompactableChangesetEvents(ctx context.Context, changesetIDs []int64, updatedBefore time.Time) error {
+	if len(changesetIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]*sqlf.Query, 0, len(changesetIDs))
+	for _, id := range changesetIDs {
+		ids = append(ids, sqlf.Sprintf("%s", id))
+	}
+
+	q := sqlf.Sprintf(
+		deleteCompactableChangesetEventsQueryFmtstr,
+		sqlf.Join(ids, ","),
+		compactableChangesetsPred(updatedBefore),
+		compactableChangesetEventsPred(),
+	)
+	return s.Exec(ctx, q)
+}
+
+var deleteCompactableChangesetEventsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_events.go:DeleteCompactableChangesetEvents
+DELETE FROM changeset_events
+USING changesets
+WHERE changesets.id = changeset_events.changeset_id
+AND changesets.id IN (%s)
+AND %s
+AND %s
+`
+
+// compactableChangesetsPred matches the changesets whose events are compacted
+// with the given retention cutoff. See changesetEventsCompactable.
+func compactableChangesetsPred(updatedBefore time.Time) *sqlf.Query {
+	return sqlf.Sprintf(
+		"changesets.external_state IN (%s, %s) AND changesets.external_updated_at < %s",
+		campaigns.ChangesetExternalStateMerged,
+		campaigns.ChangesetExternalStateClosed,
+		updatedBefore,
+	)
+}
+
+// compactableChangesetEventsPred matches the changeset events that are
+// deleted when the events of a changeset are compacted.
+func compactableChangesetEventsPred() *sqlf.Query {
+	ks := make([]*sqlf.Query, 0, len(changesetStateEventKinds))
+	for _, k := range changesetStateEventKinds {
+		ks = append(ks, sqlf.Sprintf("%s", k))
+	}
+	return sqlf.Sprintf("changeset_events.kind NOT IN (%s)", sqlf.Join(ks, ","))
+}
+
+// UpsertChangesetEvents creates or updates the given ChangesetEvents with a
+// single query, no matter how many there are.
+//
+// A single upsert can't update the same row twice, so events with the same
+// changeset, kind and key as a previous event are not upserted themselves.
+// They're set to the upserted event instead.
 func (s *Store) UpsertChangesetEvents(ctx context.Context, cs ...*campaigns.ChangesetEvent) (err error) {
-	q, err := s.upsertChangesetEventsQuery(cs)
+	if len(cs) == 0 {
+		return nil
+	}
+
+	unique, duplicates := dedupeChangesetEvents(cs)
+
+	q, err := s.upsertChangesetEventsQuery(unique)
 	if err != nil {
 		return err
 	}
 
 	i := -1
-	return s.query(ctx, q, func(sc scanner) (err error) {
+	err = s.query(ctx, q, func(sc scanner) (err error) {
 		i++
-		return scanChangesetEvent(cs[i], sc)
+		return scanChangesetEvent(unique[i], sc)
 	})
+	if err != nil {
+		return err
+	}
+
+	for dup, e := range duplicates {
+		*dup = *e
+	}
+	return nil
+}
+
+type changesetEventKey struct {
+	changesetID int64
+	kind        campaigns.ChangesetEventKind
+	key         string
+}
+
+// dedupeChangesetEvents returns the given events without the ones that have
+// the same changeset, kind and key as a previous one. The dropped duplicates
+// are returned mapped to the event they duplicate.
+func dedupeChangesetEvents(es []*campaigns.ChangesetEvent) (unique []*campaigns.ChangesetEvent, duplicates map[*campaigns.ChangesetEvent]*campaigns.ChangesetEvent) {
+	unique = make([]*campaigns.ChangesetEvent, 0, len(es))
+	byKey := make(map[changesetEventKey]*campaigns.ChangesetEvent, len(es))
+
+	for _, e := range es {
+		k := changesetEventKey{changesetID: e.ChangesetID, kind: e.Kind, key: e.Key}
+		if first, ok := byKey[k]; ok {
+			log15.Info("dropping duplicate changeset event", "changeset_id", e.ChangesetID, "kind", e.Kind, "key", e.Key)
+			if duplicates == nil {
+				duplicates = make(map[*campaigns.ChangesetEvent]*campaigns.ChangesetEvent)
+			}
+			duplicates[e] = first
+			continue
+		}
+		byKey[k] = e
+		unique = append(unique, e)
+	}
+
+	return unique, duplicates
 }
 
 const changesetEventsBatchQueryPrefix = `
@@ -68,6 +68,29 @@ func testStoreChangesetEvents(t *testing.T, ctx context.Context, s *Store, _ rep
 		}
 	})
 
+	t.Run("Upsert duplicates", func(t *testing.T) {
+		if err := s.UpsertChangesetEvents(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		// Events with the same changeset, kind and key as the ones created
+		// above, which would make a single upsert fail.
+		dups := make([]*cmpgn.ChangesetEvent, 0, 2*len(events))
+		for _, e := range events {
+			dups = append(dups, e.Clone(), e.Clone())
+		}
+
+		if err := s.UpsertChangesetEvents(ctx, dups...); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, have := range dups {
+			if diff := cmp.Diff(have, events[i/2]); diff != "" {
+				t.Fatalf("event %d: %s", i, diff)
+			}
+		}
+	})
+
 	t.Run("Count", func(t *testing.T) {
 		count, err := s.CountChangesetEvents(ctx, CountChangesetEventsOpts{})
 		if err != nil {
@@ -86,6 +109,18 @@ func testStoreChangesetEvents(t *testing.T, ctx context.Context, s *Store, _ rep
 		if have, want := count, 1; have != want {
 			t.Fatalf("have count: %d, want: %d", have, want)
 		}
+
+		count, err = s.CountChangesetEvents(ctx, CountChangesetEventsOpts{
+			ChangesetID: 1,
+			Kinds:       []cmpgn.ChangesetEventKind{cmpgn.ChangesetEventKindGitHubReviewed},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, 0; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
 	})
 
 	t.Run("Get", func(t *testing.T) {
@@ -229,6 +264,26 @@ func testStoreChangesetEvents(t *testing.T, ctx context.Context, s *Store, _ rep
 			}
 		})
 
+		t.Run("ByKinds", func(t *testing.T) {
+			opts := ListChangesetEventsOpts{Kinds: cmpgn.ChangesetEventCategoryComment.Kinds()}
+			have, _, err := s.ListChangesetEvents(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, events); diff != "" {
+				t.Fatalf("opts: %+v, diff: %s", opts, diff)
+			}
+
+			opts = ListChangesetEventsOpts{Kinds: cmpgn.ChangesetEventCategoryCommit.Kinds()}
+			have, _, err = s.ListChangesetEvents(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 0 {
+				t.Fatalf("opts: %+v: listed %d events, want: %d", opts, len(have), 0)
+			}
+		})
+
 		t.Run("EmptyResultListingAll", func(t *testing.T) {
 			opts := ListChangesetEventsOpts{ChangesetIDs: []int64{99999}, Limit: -1}
 
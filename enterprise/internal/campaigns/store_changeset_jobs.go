@@ -0,0 +1,302 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// changesetJobColumns are used by the changeset job related Store methods and
+// by the bulk operation worker to query jobs.
+var changesetJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_jobs.id"),
+	sqlf.Sprintf("changeset_jobs.bulk_group"),
+	sqlf.Sprintf("changeset_jobs.user_id"),
+	sqlf.Sprintf("changeset_jobs.campaign_id"),
+	sqlf.Sprintf("changeset_jobs.changeset_id"),
+	sqlf.Sprintf("changeset_jobs.job_type"),
+	sqlf.Sprintf("changeset_jobs.payload"),
+	sqlf.Sprintf("changeset_jobs.state"),
+	sqlf.Sprintf("changeset_jobs.failure_message"),
+	sqlf.Sprintf("changeset_jobs.started_at"),
+	sqlf.Sprintf("changeset_jobs.finished_at"),
+	sqlf.Sprintf("changeset_jobs.process_after"),
+	sqlf.Sprintf("changeset_jobs.num_resets"),
+	sqlf.Sprintf("changeset_jobs.created_at"),
+	sqlf.Sprintf("changeset_jobs.updated_at"),
+}
+
+// CreateChangesetJobs creates the given ChangesetJobs in the queued state with
+// a single query.
+func (s *Store) CreateChangesetJobs(ctx context.Context, js ...*campaigns.ChangesetJob) error {
+	if len(js) == 0 {
+		return nil
+	}
+
+	now := s.now()
+	values := make([]*sqlf.Query, 0, len(js))
+	for _, j := range js {
+		if j.CreatedAt.IsZero() {
+			j.CreatedAt = now
+		}
+		if j.UpdatedAt.IsZero() {
+			j.UpdatedAt = j.CreatedAt
+		}
+
+		payload, err := jsonbColumn(j.Payload)
+		if err != nil {
+			return err
+		}
+
+		values = append(values, sqlf.Sprintf(
+			"(%s, %s, %s, %s, %s, %s, 'queued', %s, %s)",
+			j.BulkGroup,
+			j.UserID,
+			j.CampaignID,
+			j.ChangesetID,
+			string(j.JobType),
+			payload,
+			j.CreatedAt,
+			j.UpdatedAt,
+		))
+	}
+
+	q := sqlf.Sprintf(
+		createChangesetJobsQueryFmtstr,
+		sqlf.Join(values, ",\n"),
+		sqlf.Join(changesetJobColumns, ", "),
+	)
+
+	// Rows are returned in the order of the VALUES list.
+	i := 0
+	return s.query(ctx, q, func(sc scanner) error {
+		if i >= len(js) {
+			return errors.New("more changeset jobs returned than created")
+		}
+		err := scanChangesetJob(js[i], sc)
+		i++
+		return err
+	})
+}
+
+var createChangesetJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:CreateChangesetJobs
+INSERT INTO changeset_jobs (bulk_group, user_id, campaign_id, changeset_id, job_type, payload, state, created_at, updated_at)
+VALUES %s
+RETURNING %s
+`
+
+// GetChangesetJobOpts captures the query options needed for getting a
+// ChangesetJob.
+type GetChangesetJobOpts struct {
+	ID int64
+}
+
+// GetChangesetJob gets a ChangesetJob matching the given options.
+func (s *Store) GetChangesetJob(ctx context.Context, opts GetChangesetJobOpts) (*campaigns.ChangesetJob, error) {
+	q := sqlf.Sprintf(
+		getChangesetJobQueryFmtstr,
+		sqlf.Join(changesetJobColumns, ", "),
+		opts.ID,
+	)
+
+	var j campaigns.ChangesetJob
+	err := s.query(ctx, q, func(sc scanner) error { return scanChangesetJob(&j, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if j.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &j, nil
+}
+
+var getChangesetJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:GetChangesetJob
+SELECT %s FROM changeset_jobs
+WHERE id = %s
+LIMIT 1
+`
+
+// ListChangesetJobsOpts captures the query options needed for listing
+// changeset jobs.
+type ListChangesetJobsOpts struct {
+	BulkGroup   string
+	ChangesetID int64
+}
+
+// ListChangesetJobs lists the ChangesetJobs with the given filters, oldest
+// first.
+func (s *Store) ListChangesetJobs(ctx context.Context, opts ListChangesetJobsOpts) (js []*campaigns.ChangesetJob, err error) {
+	var preds []*sqlf.Query
+	if opts.BulkGroup != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.bulk_group = %s", opts.BulkGroup))
+	}
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.changeset_id = %s", opts.ChangesetID))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	q := sqlf.Sprintf(
+		listChangesetJobsQueryFmtstr,
+		sqlf.Join(changesetJobColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var j campaigns.ChangesetJob
+		if err := scanChangesetJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+	return js, err
+}
+
+var listChangesetJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:ListChangesetJobs
+SELECT %s FROM changeset_jobs
+WHERE %s
+ORDER BY changeset_jobs.id ASC
+`
+
+// GetBulkOperationOpts captures the query options needed for getting a
+// BulkOperation.
+type GetBulkOperationOpts struct {
+	ID string
+}
+
+// GetBulkOperation aggregates the ChangesetJobs of the bulk group with the
+// given ID into a BulkOperation.
+func (s *Store) GetBulkOperation(ctx context.Context, opts GetBulkOperationOpts) (*campaigns.BulkOperation, error) {
+	q := sqlf.Sprintf(getBulkOperationQueryFmtstr, opts.ID)
+
+	var op campaigns.BulkOperation
+	err := s.query(ctx, q, func(sc scanner) error { return scanBulkOperation(&op, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if op.ID == "" {
+		return nil, ErrNoResults
+	}
+
+	return &op, nil
+}
+
+var getBulkOperationQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:GetBulkOperation
+SELECT
+  bulk_group,
+  MIN(job_type),
+  CASE
+    WHEN COUNT(*) FILTER (WHERE state IN ('queued', 'processing')) > 0 THEN 'PROCESSING'
+    WHEN COUNT(*) FILTER (WHERE state = 'errored') > 0 THEN 'FAILED'
+    ELSE 'COMPLETED'
+  END,
+  MIN(user_id),
+  MIN(campaign_id),
+  CAST(COUNT(*) FILTER (WHERE state NOT IN ('queued', 'processing')) AS float) / COUNT(*),
+  COUNT(*),
+  MIN(created_at),
+  CASE
+    WHEN COUNT(*) FILTER (WHERE state IN ('queued', 'processing')) > 0 THEN NULL
+    ELSE MAX(finished_at)
+  END
+FROM changeset_jobs
+WHERE bulk_group = %s
+GROUP BY bulk_group
+`
+
+func scanFirstChangesetJob(rows *sql.Rows, err error) (*campaigns.ChangesetJob, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var js []*campaigns.ChangesetJob
+	err = scanAll(rows, func(sc scanner) error {
+		var j campaigns.ChangesetJob
+		if err := scanChangesetJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+	if err != nil || len(js) == 0 {
+		return &campaigns.ChangesetJob{}, false, err
+	}
+	return js[0], true, nil
+}
+
+func scanChangesetJob(j *campaigns.ChangesetJob, sc scanner) error {
+	var (
+		jobType        string
+		payload        json.RawMessage
+		state          string
+		failureMessage string
+	)
+	err := sc.Scan(
+		&j.ID,
+		&j.BulkGroup,
+		&j.UserID,
+		&j.CampaignID,
+		&j.ChangesetID,
+		&jobType,
+		&payload,
+		&state,
+		&dbutil.NullString{S: &failureMessage},
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&dbutil.NullTime{Time: &j.ProcessAfter},
+		&j.NumResets,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning changeset job")
+	}
+
+	j.JobType = campaigns.ChangesetJobType(jobType)
+	if err := json.Unmarshal(payload, &j.Payload); err != nil {
+		return errors.Wrapf(err, "unmarshaling payload of changeset job %d", j.ID)
+	}
+
+	j.State = campaigns.ReconcilerState(strings.ToUpper(state))
+	if failureMessage != "" {
+		j.FailureMessage = &failureMessage
+	}
+	return nil
+}
+
+func scanBulkOperation(op *campaigns.BulkOperation, sc scanner) error {
+	var jobType, state string
+	err := sc.Scan(
+		&op.ID,
+		&jobType,
+		&state,
+		&op.UserID,
+		&op.CampaignID,
+		&op.Progress,
+		&op.ChangesetCount,
+		&op.CreatedAt,
+		&dbutil.NullTime{Time: &op.FinishedAt},
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning bulk operation")
+	}
+
+	op.Type = campaigns.ChangesetJobType(jobType)
+	op.State = campaigns.BulkOperationState(state)
+	return nil
+}
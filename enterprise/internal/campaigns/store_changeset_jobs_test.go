@@ -0,0 +1,161 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	// Foreign key constraints are deferred, so the users, campaigns and
+	// changesets don't need to exist.
+	const (
+		bulkGroup  = "bulk-1"
+		userID     = int32(1234)
+		campaignID = int64(4321)
+	)
+
+	jobs := []*cmpgn.ChangesetJob{
+		{
+			BulkGroup:   bulkGroup,
+			UserID:      userID,
+			CampaignID:  campaignID,
+			ChangesetID: 1,
+			JobType:     cmpgn.ChangesetJobTypeComment,
+			Payload:     cmpgn.ChangesetJobPayload{Message: "Please review"},
+		},
+		{
+			BulkGroup:   bulkGroup,
+			UserID:      userID,
+			CampaignID:  campaignID,
+			ChangesetID: 2,
+			JobType:     cmpgn.ChangesetJobTypeComment,
+			Payload:     cmpgn.ChangesetJobPayload{Message: "Please review"},
+		},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateChangesetJobs(ctx, jobs...); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, j := range jobs {
+			want := &cmpgn.ChangesetJob{
+				ID:          j.ID,
+				BulkGroup:   bulkGroup,
+				UserID:      userID,
+				CampaignID:  campaignID,
+				ChangesetID: int64(i + 1),
+				JobType:     cmpgn.ChangesetJobTypeComment,
+				Payload:     cmpgn.ChangesetJobPayload{Message: "Please review"},
+				State:       cmpgn.ReconcilerStateQueued,
+				CreatedAt:   clock.now(),
+				UpdatedAt:   clock.now(),
+			}
+			if j.ID == 0 {
+				t.Fatalf("job %d has no ID", i)
+			}
+			if diff := cmp.Diff(want, j); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		other := &cmpgn.ChangesetJob{
+			BulkGroup:   "bulk-2",
+			UserID:      userID,
+			CampaignID:  campaignID,
+			ChangesetID: 1,
+			JobType:     cmpgn.ChangesetJobTypeClose,
+		}
+		if err := s.CreateChangesetJobs(ctx, other); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetChangesetJob(ctx, GetChangesetJobOpts{ID: jobs[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(jobs[0], have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetChangesetJob(ctx, GetChangesetJobOpts{ID: 0xdeadbeef})
+		if err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListChangesetJobs(ctx, ListChangesetJobsOpts{BulkGroup: bulkGroup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(jobs, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListChangesetJobs(ctx, ListChangesetJobsOpts{ChangesetID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 2 {
+			t.Fatalf("wrong number of jobs. want=2, have=%d", len(have))
+		}
+	})
+
+	t.Run("GetBulkOperation", func(t *testing.T) {
+		have, err := s.GetBulkOperation(ctx, GetBulkOperationOpts{ID: bulkGroup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &cmpgn.BulkOperation{
+			ID:             bulkGroup,
+			Type:           cmpgn.ChangesetJobTypeComment,
+			State:          cmpgn.BulkOperationStateProcessing,
+			UserID:         userID,
+			CampaignID:     campaignID,
+			Progress:       0,
+			ChangesetCount: 2,
+			CreatedAt:      clock.now(),
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		setState := func(j *cmpgn.ChangesetJob, state string) {
+			q := sqlf.Sprintf("UPDATE changeset_jobs SET state = %s, finished_at = %s WHERE id = %s", state, clock.now(), j.ID)
+			if err := s.Exec(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		setState(jobs[0], "completed")
+		have, err = s.GetBulkOperation(ctx, GetBulkOperationOpts{ID: bulkGroup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.State != cmpgn.BulkOperationStateProcessing || have.Progress != 0.5 || !have.FinishedAt.IsZero() {
+			t.Fatalf("wrong bulk operation: %+v", have)
+		}
+
+		setState(jobs[1], "errored")
+		have, err = s.GetBulkOperation(ctx, GetBulkOperationOpts{ID: bulkGroup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.State != cmpgn.BulkOperationStateFailed || have.Progress != 1 || !have.FinishedAt.Equal(clock.now()) {
+			t.Fatalf("wrong bulk operation: %+v", have)
+		}
+
+		_, err = s.GetBulkOperation(ctx, GetBulkOperationOpts{ID: "unknown"})
+		if err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+}
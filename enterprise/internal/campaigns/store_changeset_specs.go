@@ -148,7 +148,7 @@ func (s *Store) updateChangesetSpecQuery(c *campaigns.ChangesetSpec) (*sqlf.Quer
 
 // DeleteChangesetSpec deletes the ChangesetSpec with the given ID.
 func (s *Store) DeleteChangesetSpec(ctx context.Context, id int64) error {
-	return s.Store.Exec(ctx, sqlf.Sprintf(deleteChangesetSpecQueryFmtstr, id))
+	return s.Exec(ctx, sqlf.Sprintf(deleteChangesetSpecQueryFmtstr, id))
 }
 
 var deleteChangesetSpecQueryFmtstr = `
@@ -332,11 +332,13 @@ func listChangesetSpecsQuery(opts *ListChangesetSpecsOpts) *sqlf.Query {
 }
 
 // DeleteExpiredChangesetSpecs deletes ChangesetSpecs that have not been
-// attached to a CampaignSpec within ChangesetSpecTTL.
-func (s *Store) DeleteExpiredChangesetSpecs(ctx context.Context) error {
-	expirationTime := s.now().Add(-campaigns.ChangesetSpecTTL)
+// attached to a CampaignSpec within ChangesetSpecTTL. ChangesetSpecs attached
+// to a pinned CampaignSpec are kept. It returns the IDs of the deleted
+// ChangesetSpecs.
+func (s *Store) DeleteExpiredChangesetSpecs(ctx context.Context) ([]int64, error) {
+	expirationTime := s.now().Add(-campaigns.ChangesetSpecTTL())
 	q := sqlf.Sprintf(deleteExpiredChangesetSpecsQueryFmtstr, expirationTime)
-	return s.Store.Exec(ctx, q)
+	return s.queryIDs(ctx, q)
 }
 
 var deleteExpiredChangesetSpecsQueryFmtstr = `
@@ -356,10 +358,14 @@ AND
     -- The campaign_spec is not applied to a campaign
     NOT EXISTS(SELECT 1 FROM campaigns WHERE campaign_spec_id = cspecs.campaign_spec_id)
     AND
+    -- and it's not pinned
+    NOT EXISTS(SELECT 1 FROM campaign_specs WHERE id = cspecs.campaign_spec_id AND pinned_at IS NOT NULL)
+    AND
     -- and the changeset_spec is not attached to a changeset
     NOT EXISTS(SELECT 1 FROM changesets WHERE current_spec_id = cspecs.id OR previous_spec_id = cspecs.id)
   )
-);
+)
+RETURNING id;
 `
 
 func scanChangesetSpec(c *campaigns.ChangesetSpec, s scanner) error {
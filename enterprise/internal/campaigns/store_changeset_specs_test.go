@@ -323,14 +323,15 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, rs rep
 	})
 
 	t.Run("DeleteExpiredChangesetSpecs", func(t *testing.T) {
-		underTTL := clock.now().Add(-cmpgn.ChangesetSpecTTL + 24*time.Hour)
-		overTTL := clock.now().Add(-cmpgn.ChangesetSpecTTL - 24*time.Hour)
+		underTTL := clock.now().Add(-cmpgn.ChangesetSpecTTL() + 24*time.Hour)
+		overTTL := clock.now().Add(-cmpgn.ChangesetSpecTTL() - 24*time.Hour)
 
 		type testCase struct {
 			createdAt time.Time
 
 			hasCampaignSpec     bool
 			campaignSpecApplied bool
+			campaignSpecPinned  bool
 
 			isCurrentSpec  bool
 			isPreviousSpec bool
@@ -345,8 +346,8 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, rs rep
 			}
 
 			return fmt.Sprintf(
-				"[tooOld=%t, hasCampaignSpec=%t, campaignSpecApplied=%t, isCurrentSpec=%t, isPreviousSpec=%t]",
-				tooOld, tc.hasCampaignSpec, tc.campaignSpecApplied, tc.isCurrentSpec, tc.isPreviousSpec,
+				"[tooOld=%t, hasCampaignSpec=%t, campaignSpecApplied=%t, campaignSpecPinned=%t, isCurrentSpec=%t, isPreviousSpec=%t]",
+				tooOld, tc.hasCampaignSpec, tc.campaignSpecApplied, tc.campaignSpecPinned, tc.isCurrentSpec, tc.isPreviousSpec,
 			)
 		}
 
@@ -369,10 +370,16 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, rs rep
 			// previous spec.
 			{hasCampaignSpec: true, createdAt: underTTL, wantDeleted: false},
 			{hasCampaignSpec: true, createdAt: overTTL, wantDeleted: true},
+
+			// Has a CampaignSpec that's not applied, but pinned.
+			{hasCampaignSpec: true, campaignSpecPinned: true, createdAt: overTTL, wantDeleted: false},
 		}
 
 		for _, tc := range tests {
 			campaignSpec := &cmpgn.CampaignSpec{UserID: 4567, NamespaceUserID: 4567}
+			if tc.campaignSpecPinned {
+				campaignSpec.PinnedAt = clock.now()
+			}
 
 			if tc.hasCampaignSpec {
 				if err := s.CreateCampaignSpec(ctx, campaignSpec); err != nil {
@@ -425,11 +432,15 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, rs rep
 				}
 			}
 
-			if err := s.DeleteExpiredChangesetSpecs(ctx); err != nil {
+			deleted, err := s.DeleteExpiredChangesetSpecs(ctx)
+			if err != nil {
 				t.Fatal(err)
 			}
+			if have, want := containsID(deleted, changesetSpec.ID), tc.wantDeleted; have != want {
+				t.Fatalf("tc=%s\n\t changeset spec in returned IDs: have %t, want %t", printTestCase(tc), have, want)
+			}
 
-			_, err := s.GetChangesetSpec(ctx, GetChangesetSpecOpts{ID: changesetSpec.ID})
+			_, err = s.GetChangesetSpec(ctx, GetChangesetSpecOpts{ID: changesetSpec.ID})
 			if err != nil && err != ErrNoResults {
 				t.Fatal(err)
 			}
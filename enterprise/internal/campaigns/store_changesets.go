@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -14,9 +16,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 )
 
 // changesetColumns are used by by the changeset related Store methods and by
@@ -49,14 +53,25 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.publication_state"),
 	sqlf.Sprintf("changesets.reconciler_state"),
 	sqlf.Sprintf("changesets.failure_message"),
+	sqlf.Sprintf("changesets.failure_class"),
 	sqlf.Sprintf("changesets.started_at"),
 	sqlf.Sprintf("changesets.finished_at"),
 	sqlf.Sprintf("changesets.process_after"),
 	sqlf.Sprintf("changesets.num_resets"),
+	sqlf.Sprintf("changesets.custom_metadata"),
+	sqlf.Sprintf("changesets.wait_reason"),
+	sqlf.Sprintf("changesets.external_fork_namespace"),
+	sqlf.Sprintf("changesets.external_url_state"),
+	sqlf.Sprintf("changesets.external_url_checked_at"),
+	sqlf.Sprintf("changesets.failure_code"),
+	sqlf.Sprintf("changesets.skipped_reason"),
+	sqlf.Sprintf("changesets.archived_at"),
+	sqlf.Sprintf("changesets.num_failures"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
-// CreateChangeset and UpdateChangeset.
+// CreateChangeset and UpdateChangeset. The results of URL checks are only
+// modified by SetChangesetURLState.
 var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("repo_id"),
 	sqlf.Sprintf("created_at"),
@@ -83,10 +98,18 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("publication_state"),
 	sqlf.Sprintf("reconciler_state"),
 	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("failure_class"),
 	sqlf.Sprintf("started_at"),
 	sqlf.Sprintf("finished_at"),
 	sqlf.Sprintf("process_after"),
 	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("custom_metadata"),
+	sqlf.Sprintf("wait_reason"),
+	sqlf.Sprintf("external_fork_namespace"),
+	sqlf.Sprintf("failure_code"),
+	sqlf.Sprintf("skipped_reason"),
+	sqlf.Sprintf("archived_at"),
+	sqlf.Sprintf("num_failures"),
 }
 
 // CreateChangeset creates the given Changeset.
@@ -102,7 +125,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *campaigns.Changeset) err
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 ON CONFLICT ON CONSTRAINT
 changesets_repo_external_id_unique
 DO NOTHING
@@ -125,6 +148,11 @@ func (s *Store) createChangesetQuery(c *campaigns.Changeset) (*sqlf.Query, error
 		return nil, err
 	}
 
+	customMetadata, err := jsonStringMapColumn(c.CustomMetadata)
+	if err != nil {
+		return nil, err
+	}
+
 	if c.CreatedAt.IsZero() {
 		c.CreatedAt = s.now()
 	}
@@ -161,20 +189,29 @@ func (s *Store) createChangesetQuery(c *campaigns.Changeset) (*sqlf.Query, error
 		c.PublicationState,
 		c.ReconcilerState.ToDB(),
 		c.FailureMessage,
+		nullStringColumn(string(c.FailureClass)),
 		nullTimeColumn(c.StartedAt),
 		nullTimeColumn(c.FinishedAt),
 		nullTimeColumn(c.ProcessAfter),
 		c.NumResets,
+		customMetadata,
+		nullStringColumn(string(c.WaitReason)),
+		nullStringColumn(c.ExternalForkNamespace),
+		nullStringColumn(string(c.FailureCode)),
+		nullStringColumn(c.SkippedReason),
+		nullTimeColumn(c.ArchivedAt),
+		c.NumFailures,
 		sqlf.Join(changesetColumns, ", "),
 	), nil
 }
 
 // DeleteChangeset deletes the Changeset with the given ID.
 func (s *Store) DeleteChangeset(ctx context.Context, id int64) error {
-	return s.Store.Exec(ctx, sqlf.Sprintf(deleteChangesetQueryFmtstr, id))
+	return s.Exec(ctx, sqlf.Sprintf(deleteChangesetQueryFmtstr, id))
 }
 
 var deleteChangesetQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:DeleteChangeset
 DELETE FROM changesets WHERE id = %s
 `
 
@@ -186,11 +223,14 @@ type CountChangesetsOpts struct {
 	ExternalReviewState *campaigns.ChangesetReviewState
 	ExternalCheckState  *campaigns.ChangesetCheckState
 	ReconcilerState     *campaigns.ReconcilerState
+	// OnlyFailed, if set, only counts changesets whose reconciliation failed.
+	// See ListChangesetsOpts.OnlyFailed.
+	OnlyFailed bool
 }
 
 // CountChangesets returns the number of changesets in the database.
 func (s *Store) CountChangesets(ctx context.Context, opts CountChangesetsOpts) (int, error) {
-	return s.queryCount(ctx, countChangesetsQuery(&opts))
+	return s.readQueryCount(ctx, countChangesetsQuery(&opts))
 }
 
 var countChangesetsQueryFmtstr = `
@@ -223,16 +263,141 @@ func countChangesetsQuery(opts *CountChangesetsOpts) *sqlf.Query {
 		state := (*opts.ReconcilerState).ToDB()
 		preds = append(preds, sqlf.Sprintf("changesets.reconciler_state = %s", state))
 	}
+	if opts.OnlyFailed {
+		preds = append(preds, changesetFailedPred)
+	}
 
 	return sqlf.Sprintf(countChangesetsQueryFmtstr, sqlf.Join(preds, "\n AND "))
 }
 
+// GetChangesetQueuePosition returns the 1-based position of the given queued
+// changeset in the reconciler queue. The reconciler dequeues changesets that
+// are ready to be processed in the order of their last update.
+func (s *Store) GetChangesetQueuePosition(ctx context.Context, c *campaigns.Changeset) (int, error) {
+	q := sqlf.Sprintf(getChangesetQueuePositionQueryFmtstr, c.UpdatedAt, c.UpdatedAt, c.ID)
+
+	ahead, err := s.queryCount(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+	return ahead + 1, nil
+}
+
+var getChangesetQueuePositionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:GetChangesetQueuePosition
+SELECT COUNT(changesets.id)
+FROM changesets
+WHERE
+  changesets.reconciler_state = 'queued' AND
+  (changesets.process_after IS NULL OR changesets.process_after <= NOW()) AND
+  (changesets.updated_at < %s OR (changesets.updated_at = %s AND changesets.id < %s))
+`
+
+// ListAutoMergeableChangesets lists the open changesets that belong to an open,
+// unpaused campaign with auto-merge enabled, whose checks passed and that have been
+// approved. Changesets that are still being reconciled are excluded.
+func (s *Store) ListAutoMergeableChangesets(ctx context.Context) (cs campaigns.Changesets, err error) {
+	q := sqlf.Sprintf(
+		listAutoMergeableChangesetsQueryFmtstr,
+		sqlf.Join(changesetColumns, ", "),
+		campaigns.ChangesetPublicationStatePublished,
+		campaigns.ReconcilerStateCompleted.ToDB(),
+		campaigns.ChangesetExternalStateOpen,
+		campaigns.ChangesetCheckStatePassed,
+		campaigns.ChangesetReviewStateApproved,
+	)
+
+	err = s.query(ctx, q, func(sc scanner) (err error) {
+		var c campaigns.Changeset
+		if err = scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listAutoMergeableChangesetsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:ListAutoMergeableChangesets
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+  repo.deleted_at IS NULL AND
+  changesets.publication_state = %s AND
+  changesets.reconciler_state = %s AND
+  changesets.external_state = %s AND
+  changesets.external_check_state = %s AND
+  changesets.external_review_state = %s AND
+  EXISTS (
+    SELECT 1 FROM campaigns
+    WHERE
+      campaigns.auto_merge AND
+      campaigns.closed_at IS NULL AND
+      campaigns.paused_at IS NULL AND
+      campaigns.deleted_at IS NULL AND
+      changesets.campaign_ids ? campaigns.id::text
+  )
+ORDER BY changesets.id ASC
+`
+
+// ListAutoRebaseableChangesets lists the open changesets that belong to an
+// open, unpaused campaign with auto-rebase enabled and that have a current changeset
+// spec whose diff can be re-applied. Changesets that are still being
+// reconciled are excluded.
+func (s *Store) ListAutoRebaseableChangesets(ctx context.Context) (cs campaigns.Changesets, err error) {
+	q := sqlf.Sprintf(
+		listAutoRebaseableChangesetsQueryFmtstr,
+		sqlf.Join(changesetColumns, ", "),
+		campaigns.ChangesetPublicationStatePublished,
+		campaigns.ReconcilerStateCompleted.ToDB(),
+		campaigns.ChangesetExternalStateOpen,
+	)
+
+	err = s.query(ctx, q, func(sc scanner) (err error) {
+		var c campaigns.Changeset
+		if err = scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listAutoRebaseableChangesetsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:ListAutoRebaseableChangesets
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+  repo.deleted_at IS NULL AND
+  changesets.publication_state = %s AND
+  changesets.reconciler_state = %s AND
+  changesets.external_state = %s AND
+  changesets.current_spec_id IS NOT NULL AND
+  EXISTS (
+    SELECT 1 FROM campaigns
+    WHERE
+      campaigns.auto_rebase AND
+      campaigns.closed_at IS NULL AND
+      campaigns.paused_at IS NULL AND
+      campaigns.deleted_at IS NULL AND
+      changesets.campaign_ids ? campaigns.id::text
+  )
+ORDER BY changesets.id ASC
+`
+
 // GetChangesetOpts captures the query options needed for getting a Changeset
 type GetChangesetOpts struct {
 	ID                  int64
 	RepoID              api.RepoID
 	ExternalID          string
 	ExternalServiceType string
+	// ExternalBranch, if set together with ExternalServiceType, matches the
+	// changeset whose head ref is the given branch.
+	ExternalBranch string
 }
 
 // GetChangeset gets a changeset matching the given options.
@@ -279,6 +444,13 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 		)
 	}
 
+	if opts.ExternalBranch != "" && opts.ExternalServiceType != "" {
+		preds = append(preds,
+			sqlf.Sprintf("changesets.external_branch = %s", opts.ExternalBranch),
+			sqlf.Sprintf("changesets.external_service_type = %s", opts.ExternalServiceType),
+		)
+	}
+
 	return sqlf.Sprintf(
 		getChangesetsQueryFmtstr,
 		sqlf.Join(changesetColumns, ", "),
@@ -289,12 +461,18 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 type ListChangesetSyncDataOpts struct {
 	// Return only the supplied changesets. If empty, all changesets are returned
 	ChangesetIDs []int64
+	// Return only the changesets of the given campaign. If 0, the changesets of
+	// all campaigns are returned
+	CampaignID int64
+	// Return only the changesets with the given external state. If nil,
+	// changesets in all states are returned
+	ExternalState *campaigns.ChangesetExternalState
 }
 
 // ListChangesetSyncData returns sync data on all non-externally-deleted changesets
 // that are part of at least one open campaign.
 func (s *Store) ListChangesetSyncData(ctx context.Context, opts ListChangesetSyncDataOpts) ([]campaigns.ChangesetSyncData, error) {
-	q := listChangesetSyncData(opts)
+	q := listChangesetSyncData(opts, s.now())
 	results := make([]campaigns.ChangesetSyncData, 0)
 	err := s.query(ctx, q, func(sc scanner) (err error) {
 		var h campaigns.ChangesetSyncData
@@ -307,9 +485,76 @@ func (s *Store) ListChangesetSyncData(ctx context.Context, opts ListChangesetSyn
 	if err != nil {
 		return nil, err
 	}
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	settings, err := s.listCodeHostSyncSettings(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing code host sync settings")
+	}
+	for i := range results {
+		cs := settings[results[i].RepoExternalServiceID]
+		results[i].WebhooksConfigured = cs.webhooksConfigured
+		results[i].MinSyncInterval = cs.minSyncInterval
+		results[i].MaxSyncInterval = cs.maxSyncInterval
+	}
 	return results, nil
 }
 
+// codeHostSyncSettings are the settings of a code host's external services
+// that determine how often its changesets are synced.
+type codeHostSyncSettings struct {
+	webhooksConfigured bool
+	minSyncInterval    time.Duration
+	maxSyncInterval    time.Duration
+}
+
+// listCodeHostSyncSettings returns the codeHostSyncSettings of all code
+// hosts, keyed by their normalised URL. If a code host has multiple external
+// services, it has webhooks configured if any of them has and the longest
+// configured sync intervals apply.
+func (s *Store) listCodeHostSyncSettings(ctx context.Context) (map[string]codeHostSyncSettings, error) {
+	q := sqlf.Sprintf(listCodeHostSyncSettingsQueryFmtstr)
+
+	settings := make(map[string]codeHostSyncSettings)
+	err := s.query(ctx, q, func(sc scanner) error {
+		var kind, config string
+		if err := sc.Scan(&kind, &config); err != nil {
+			return err
+		}
+
+		// Don't fail the whole listing because of one broken config.
+		cfg, err := extsvc.ParseConfig(kind, config)
+		if err != nil {
+			log15.Warn("Parsing config of external service", "kind", kind, "err", err)
+			return nil
+		}
+		u, err := extsvc.ExtractBaseURL(kind, config)
+		if err != nil {
+			log15.Warn("Extracting base URL of external service", "kind", kind, "err", err)
+			return nil
+		}
+		min, max, err := syncIntervals(cfg)
+		if err != nil {
+			log15.Warn("Parsing campaigns sync intervals of external service", "kind", kind, "err", err)
+		}
+
+		cs := settings[u.String()]
+		cs.webhooksConfigured = cs.webhooksConfigured || webhooksConfigured(cfg)
+		cs.minSyncInterval = maxDuration(cs.minSyncInterval, min)
+		cs.maxSyncInterval = maxDuration(cs.maxSyncInterval, max)
+		settings[u.String()] = cs
+		return nil
+	})
+	return settings, err
+}
+
+var listCodeHostSyncSettingsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:listCodeHostSyncSettings
+SELECT kind, config FROM external_services WHERE deleted_at IS NULL
+`
+
 func scanChangesetSyncData(h *campaigns.ChangesetSyncData, s scanner) error {
 	return s.Scan(
 		&h.ChangesetID,
@@ -317,16 +562,28 @@ func scanChangesetSyncData(h *campaigns.ChangesetSyncData, s scanner) error {
 		&dbutil.NullTime{Time: &h.LatestEvent},
 		&dbutil.NullTime{Time: &h.ExternalUpdatedAt},
 		&h.RepoExternalServiceID,
+		&h.SyncErrorMessage,
+		&h.SyncPriority,
 	)
 }
 
-func listChangesetSyncData(opts ListChangesetSyncDataOpts) *sqlf.Query {
+// listChangesetSyncData returns the query for ListChangesetSyncData. The sync
+// priority of the changesets is computed relative to now.
+func listChangesetSyncData(opts ListChangesetSyncDataOpts, now time.Time) *sqlf.Query {
 	fmtString := `
+-- source: enterprise/internal/campaigns/store_changesets.go:ListChangesetSyncData
  SELECT changesets.id,
         changesets.updated_at,
         max(ce.updated_at) AS latest_event,
         changesets.external_updated_at,
-        r.external_service_id
+        r.external_service_id,
+        changesets.sync_error_message,
+        CASE
+          WHEN max(GREATEST(campaigns.last_viewed_at, campaigns.last_applied_at)) >= %s THEN %s
+          WHEN changesets.external_state IN (%s, %s)
+           AND COALESCE(max(GREATEST(campaigns.last_viewed_at, campaigns.last_applied_at)), '-infinity') < %s THEN %s
+          ELSE %s
+        END AS sync_priority
  FROM changesets
  LEFT JOIN changeset_events ce ON changesets.id = ce.changeset_id
  JOIN campaigns ON campaigns.changeset_ids ? changesets.id::TEXT
@@ -338,6 +595,8 @@ func listChangesetSyncData(opts ListChangesetSyncDataOpts) *sqlf.Query {
 
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("campaigns.closed_at IS NULL"),
+		sqlf.Sprintf("campaigns.paused_at IS NULL"),
+		sqlf.Sprintf("campaigns.deleted_at IS NULL"),
 		sqlf.Sprintf("r.deleted_at IS NULL"),
 		sqlf.Sprintf("changesets.publication_state = %s", campaigns.ChangesetPublicationStatePublished),
 		sqlf.Sprintf("changesets.reconciler_state = %s", campaigns.ReconcilerStateCompleted.ToDB()),
@@ -351,10 +610,178 @@ func listChangesetSyncData(opts ListChangesetSyncDataOpts) *sqlf.Query {
 		}
 		preds = append(preds, sqlf.Sprintf("changesets.id IN (%s)", sqlf.Join(ids, ",")))
 	}
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaigns.id = %s", opts.CampaignID))
+	}
+	if opts.ExternalState != nil {
+		preds = append(preds, sqlf.Sprintf("changesets.external_state = %s", *opts.ExternalState))
+	}
+
+	return sqlf.Sprintf(
+		fmtString,
+		now.Add(-activeCampaignWindow),
+		campaigns.ChangesetSyncPriorityActive,
+		campaigns.ChangesetExternalStateClosed,
+		campaigns.ChangesetExternalStateMerged,
+		now.Add(-dormantCampaignWindow),
+		campaigns.ChangesetSyncPriorityDormant,
+		campaigns.ChangesetSyncPriorityNormal,
+		sqlf.Join(preds, "\n AND"),
+	)
+}
+
+// SetChangesetSyncErrorMessage records the error of the last sync of the
+// Changeset with the given ID. A nil message clears it.
+//
+// It doesn't touch updated_at, since that's used to schedule the next sync.
+func (s *Store) SetChangesetSyncErrorMessage(ctx context.Context, id int64, msg *string) error {
+	return s.Exec(ctx, sqlf.Sprintf(setChangesetSyncErrorMessageQueryFmtstr, msg, id))
+}
+
+var setChangesetSyncErrorMessageQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:SetChangesetSyncErrorMessage
+UPDATE changesets SET sync_error_message = %s WHERE id = %s
+`
+
+// SetChangesetFailureCode records the ChangesetErrorCode of the error that
+// made the reconciler fail to process the Changeset with the given ID.
+func (s *Store) SetChangesetFailureCode(ctx context.Context, id int64, code campaigns.ChangesetErrorCode) error {
+	return s.Exec(ctx, sqlf.Sprintf(setChangesetFailureCodeQueryFmtstr, nullStringColumn(string(code)), id))
+}
+
+var setChangesetFailureCodeQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:SetChangesetFailureCode
+UPDATE changesets SET failure_code = %s WHERE id = %s
+`
+
+// SetChangesetNumFailures records the number of consecutive times the
+// reconciler failed to process the Changeset with the given ID.
+//
+// It doesn't touch updated_at, since that determines the position of the
+// changeset in the reconciler queue.
+func (s *Store) SetChangesetNumFailures(ctx context.Context, id int64, numFailures int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(setChangesetNumFailuresQueryFmtstr, numFailures, id))
+}
+
+var setChangesetNumFailuresQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:SetChangesetNumFailures
+UPDATE changesets SET num_failures = %s WHERE id = %s
+`
+
+// GetRepoServiceID returns the ID of the code host of the repository with the
+// given ID, which is the ServiceID of its api.ExternalRepoSpec, such as
+// "https://github.com/". It returns ErrNoResults if there's no such
+// repository.
+func (s *Store) GetRepoServiceID(ctx context.Context, repoID api.RepoID) (serviceID string, err error) {
+	found := false
+	err = s.query(ctx, sqlf.Sprintf(getRepoServiceIDQueryFmtstr, repoID), func(sc scanner) error {
+		found = true
+		return sc.Scan(&dbutil.NullString{S: &serviceID})
+	})
+	if err == nil && !found {
+		err = ErrNoResults
+	}
+	return serviceID, err
+}
+
+var getRepoServiceIDQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:GetRepoServiceID
+SELECT external_service_id FROM repo WHERE id = %s
+`
+
+// ResumePausedChangesets makes the queued changesets owned by the campaign
+// with the given ID that were waiting for it to be resumed ready to be
+// processed by the reconciler again.
+func (s *Store) ResumePausedChangesets(ctx context.Context, campaignID int64) error {
+	q := sqlf.Sprintf(
+		resumePausedChangesetsQueryFmtstr,
+		campaignID,
+		campaigns.ReconcilerStateQueued.ToDB(),
+		campaigns.ChangesetWaitReasonPaused,
+	)
+	return s.Exec(ctx, q)
+}
+
+var resumePausedChangesetsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:ResumePausedChangesets
+UPDATE changesets
+SET process_after = NULL, wait_reason = NULL
+WHERE
+  owned_by_campaign_id = %s AND
+  reconciler_state = %s AND
+  wait_reason = %s
+`
+
+// SetChangesetURLState records the result of a check of the external URL of
+// the changeset with the given ID.
+//
+// It doesn't touch updated_at, since that's used to schedule the next sync.
+func (s *Store) SetChangesetURLState(ctx context.Context, id int64, state campaigns.ChangesetURLState, checkedAt time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(setChangesetURLStateQueryFmtstr, string(state), checkedAt, id))
+}
+
+var setChangesetURLStateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:SetChangesetURLState
+UPDATE changesets SET external_url_state = %s, external_url_checked_at = %s WHERE id = %s
+`
 
-	return sqlf.Sprintf(fmtString, sqlf.Join(preds, "\n AND"))
+// ListChangesetsToCheckURLOpts captures the query options needed for
+// listing the changesets whose external URL should be checked.
+type ListChangesetsToCheckURLOpts struct {
+	// CheckedBefore limits the changesets to those whose URL was never
+	// checked or last checked before the given time.
+	CheckedBefore time.Time
+	// CampaignID limits the changesets to those attached to the given
+	// campaign, if set.
+	CampaignID int64
+	Limit      int
 }
 
+// ListChangesetsToCheckURL lists the published changesets whose external URL
+// is due for a check, starting with the ones checked least recently.
+func (s *Store) ListChangesetsToCheckURL(ctx context.Context, opts ListChangesetsToCheckURLOpts) (cs campaigns.Changesets, err error) {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("repo.deleted_at IS NULL"),
+		sqlf.Sprintf("changesets.publication_state = %s", campaigns.ChangesetPublicationStatePublished),
+	}
+	if !opts.CheckedBefore.IsZero() {
+		preds = append(preds, sqlf.Sprintf("(changesets.external_url_checked_at IS NULL OR changesets.external_url_checked_at < %s)", opts.CheckedBefore))
+	}
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("changesets.campaign_ids ? %s", opts.CampaignID))
+	}
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	q := sqlf.Sprintf(
+		listChangesetsToCheckURLQueryFmtstr+limitClause,
+		sqlf.Join(changesetColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) (err error) {
+		var c campaigns.Changeset
+		if err = scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listChangesetsToCheckURLQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:ListChangesetsToCheckURL
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+ORDER BY changesets.external_url_checked_at ASC NULLS FIRST, changesets.id ASC
+`
+
 // ListChangesetsOpts captures the query options needed for
 // listing changesets.
 type ListChangesetsOpts struct {
@@ -369,6 +796,19 @@ type ListChangesetsOpts struct {
 	ExternalReviewState  *campaigns.ChangesetReviewState
 	ExternalCheckState   *campaigns.ChangesetCheckState
 	OnlyWithoutDiffStats bool
+	// OnlyFailed, if set, only matches changesets whose last reconciliation
+	// failed: the errored ones, and the ones that are queued to be retried
+	// after a failure.
+	OnlyFailed bool
+	// Archived, if set, only matches changesets that are archived (true)
+	// or not archived (false).
+	Archived *bool
+	// CustomMetadata, if set, only matches changesets whose custom metadata
+	// contains all of the given key/value pairs.
+	CustomMetadata map[string]string
+	// UpdatedAfter, if set, only matches changesets that have been updated
+	// after the given time.
+	UpdatedAfter *time.Time
 }
 
 // ListChangesets lists Changesets with the given filters.
@@ -376,7 +816,7 @@ func (s *Store) ListChangesets(ctx context.Context, opts ListChangesetsOpts) (cs
 	q := listChangesetsQuery(&opts)
 
 	cs = make([]*campaigns.Changeset, 0, opts.Limit)
-	err = s.query(ctx, q, func(sc scanner) (err error) {
+	err = s.readQuery(ctx, q, func(sc scanner) (err error) {
 		var c campaigns.Changeset
 		if err = scanChangeset(&c, sc); err != nil {
 			return err
@@ -393,6 +833,95 @@ func (s *Store) ListChangesets(ctx context.Context, opts ListChangesetsOpts) (cs
 	return cs, next, err
 }
 
+// forEachChangesetBatchSize is the number of changesets that
+// ForEachChangeset and ForEachChangesetBatch load at a time if no limit is
+// given.
+const forEachChangesetBatchSize = 500
+
+// ForEachChangeset calls fn with each Changeset matched by the given filters,
+// in ID order, starting at opts.Cursor. The changesets are loaded in batches
+// of opts.Limit, so that large campaigns never have to be held in memory all
+// at once. Iteration stops at the first error returned by fn, which is
+// returned.
+//
+// Changesets aren't loaded in a transaction unless the Store is one, so
+// concurrent changes to changesets that haven't been passed to fn yet are
+// visible.
+func (s *Store) ForEachChangeset(ctx context.Context, opts ListChangesetsOpts, fn func(*campaigns.Changeset) error) error {
+	return s.ForEachChangesetBatch(ctx, opts, func(cs campaigns.Changesets) error {
+		for _, c := range cs {
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ForEachChangesetBatch is like ForEachChangeset, but calls fn with each
+// batch of Changesets, so that data related to the changesets can be loaded
+// for a whole batch at once.
+func (s *Store) ForEachChangesetBatch(ctx context.Context, opts ListChangesetsOpts, fn func(campaigns.Changesets) error) error {
+	if opts.Limit <= 0 {
+		opts.Limit = forEachChangesetBatchSize
+	}
+
+	for {
+		cs, next, err := s.ListChangesets(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		if len(cs) > 0 {
+			if err := fn(cs); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
+
+// CountChangesetsByRepo counts the Changesets matched by the given filters,
+// starting at opts.Cursor and ignoring opts.Limit, in each repository.
+func (s *Store) CountChangesetsByRepo(ctx context.Context, opts ListChangesetsOpts) (map[api.RepoID]int32, error) {
+	q := countChangesetsByRepoQuery(&opts)
+
+	counts := make(map[api.RepoID]int32)
+	err := s.readQuery(ctx, q, func(sc scanner) error {
+		var (
+			repoID api.RepoID
+			count  int32
+		)
+		if err := sc.Scan(&repoID, &count); err != nil {
+			return err
+		}
+		counts[repoID] = count
+		return nil
+	})
+	return counts, err
+}
+
+var countChangesetsByRepoQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:CountChangesetsByRepo
+SELECT changesets.repo_id, COUNT(changesets.id) FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+GROUP BY changesets.repo_id
+`
+
+func countChangesetsByRepoQuery(opts *ListChangesetsOpts) *sqlf.Query {
+	preds := append(
+		[]*sqlf.Query{sqlf.Sprintf("changesets.id >= %s", opts.Cursor)},
+		listChangesetsPreds(opts)...,
+	)
+
+	return sqlf.Sprintf(countChangesetsByRepoQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
 var listChangesetsQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store.go:ListChangesets
 SELECT %s FROM changesets
@@ -414,8 +943,31 @@ func listChangesetsQuery(opts *ListChangesetsOpts) *sqlf.Query {
 		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
 	}
 
+	preds := append(
+		[]*sqlf.Query{sqlf.Sprintf("changesets.id >= %s", opts.Cursor)},
+		listChangesetsPreds(opts)...,
+	)
+
+	return sqlf.Sprintf(
+		listChangesetsQueryFmtstr+limitClause,
+		sqlf.Join(changesetColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// changesetFailedPred matches the changesets whose last reconciliation
+// failed. Completed changesets can still have the failure message of an
+// earlier attempt, so only errored and requeued changesets are matched.
+var changesetFailedPred = sqlf.Sprintf(
+	"changesets.failure_message IS NOT NULL AND changesets.reconciler_state IN (%s, %s)",
+	campaigns.ReconcilerStateErrored.ToDB(),
+	campaigns.ReconcilerStateQueued.ToDB(),
+)
+
+// listChangesetsPreds returns the predicates that match the changesets with
+// the filters of the given options, ignoring the cursor and limit.
+func listChangesetsPreds(opts *ListChangesetsOpts) []*sqlf.Query {
 	preds := []*sqlf.Query{
-		sqlf.Sprintf("changesets.id >= %s", opts.Cursor),
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
 	}
 
@@ -443,6 +995,9 @@ func listChangesetsQuery(opts *ListChangesetsOpts) *sqlf.Query {
 	if opts.ReconcilerState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.reconciler_state = %s", (*opts.ReconcilerState).ToDB()))
 	}
+	if opts.OnlyFailed {
+		preds = append(preds, changesetFailedPred)
+	}
 	if opts.ExternalState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.external_state = %s", *opts.ExternalState))
 	}
@@ -453,17 +1008,402 @@ func listChangesetsQuery(opts *ListChangesetsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changesets.external_check_state = %s", *opts.ExternalCheckState))
 	}
 
+	if opts.Archived != nil {
+		if *opts.Archived {
+			preds = append(preds, sqlf.Sprintf("changesets.archived_at IS NOT NULL"))
+		} else {
+			preds = append(preds, sqlf.Sprintf("changesets.archived_at IS NULL"))
+		}
+	}
+
 	if opts.OnlyWithoutDiffStats {
 		preds = append(preds, sqlf.Sprintf("(changesets.diff_stat_added IS NULL OR changesets.diff_stat_changed IS NULL OR changesets.diff_stat_deleted IS NULL)"))
 	}
 
+	if len(opts.CustomMetadata) > 0 {
+		// The error can be ignored, since a map[string]string can always be
+		// marshaled.
+		customMetadata, _ := jsonStringMapColumn(opts.CustomMetadata)
+		preds = append(preds, sqlf.Sprintf("changesets.custom_metadata @> %s", customMetadata))
+	}
+
+	if opts.UpdatedAfter != nil {
+		preds = append(preds, sqlf.Sprintf("changesets.updated_at > %s", *opts.UpdatedAfter))
+	}
+
+	return preds
+}
+
+// ListChangesetsStatsByRepo returns the ChangesetsStats of the changesets
+// matched by the given options, ignoring the cursor and limit, for each
+// repository they're in. All stats are computed with a single query.
+func (s *Store) ListChangesetsStatsByRepo(ctx context.Context, opts ListChangesetsOpts) (map[api.RepoID]*campaigns.ChangesetsStats, error) {
+	q := listChangesetsStatsByRepoQuery(&opts)
+
+	stats := make(map[api.RepoID]*campaigns.ChangesetsStats)
+	err := s.readQuery(ctx, q, func(sc scanner) error {
+		var (
+			repoID api.RepoID
+			st     campaigns.ChangesetsStats
+		)
+		dest := append([]interface{}{&repoID}, changesetsStatsDest(&st)...)
+		if err := sc.Scan(dest...); err != nil {
+			return err
+		}
+		stats[repoID] = &st
+		return nil
+	})
+	return stats, err
+}
+
+var listChangesetsStatsByRepoQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:ListChangesetsStatsByRepo
+SELECT
+  changesets.repo_id,
+  %s
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+GROUP BY changesets.repo_id
+`
+
+// changesetConflictingPred matches the changesets whose metadata says that
+// they have merge conflicts, like Changeset.MergeableState.
+var changesetConflictingPred = sqlf.Sprintf(`
+changesets.metadata->>'Mergeable' = 'CONFLICTING' OR
+changesets.metadata->'properties'->'mergeResult'->>'outcome' = 'CONFLICTED' OR
+changesets.metadata->>'merge_status' = 'cannot_be_merged'
+`)
+
+var changesetsStatsColumnsFmtstr = `
+COUNT(changesets.id),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s AND changesets.archived_at IS NULL),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s),
+COUNT(changesets.id) FILTER (WHERE changesets.archived_at IS NOT NULL),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s AND changesets.archived_at IS NULL AND (%s)),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s AND changesets.archived_at IS NULL AND changesets.external_review_state = %s),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s AND changesets.archived_at IS NULL AND changesets.external_review_state = %s),
+COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s AND changesets.archived_at IS NULL AND changesets.external_review_state = %s)
+`
+
+// changesetsStatsColumns returns the aggregate columns that compute the
+// ChangesetsStats of the changesets in a group. They're scanned into the
+// destinations returned by changesetsStatsDest.
+func changesetsStatsColumns() *sqlf.Query {
+	published := campaigns.ChangesetPublicationStatePublished
+	open := campaigns.ChangesetExternalStateOpen
 	return sqlf.Sprintf(
-		listChangesetsQueryFmtstr+limitClause,
-		sqlf.Join(changesetColumns, ", "),
-		sqlf.Join(preds, "\n AND "),
+		changesetsStatsColumnsFmtstr,
+		campaigns.ChangesetPublicationStateUnpublished,
+		published, open,
+		published, campaigns.ChangesetExternalStateMerged,
+		published, campaigns.ChangesetExternalStateClosed,
+		published, open, changesetConflictingPred,
+		published, open, campaigns.ChangesetReviewStatePending,
+		published, open, campaigns.ChangesetReviewStateApproved,
+		published, open, campaigns.ChangesetReviewStateChangesRequested,
 	)
 }
 
+// changesetsStatsDest returns the scan destinations for the columns returned
+// by changesetsStatsColumns.
+func changesetsStatsDest(st *campaigns.ChangesetsStats) []interface{} {
+	return []interface{}{
+		&st.Total,
+		&st.Unpublished,
+		&st.Open,
+		&st.Merged,
+		&st.Closed,
+		&st.Archived,
+		&st.Conflicting,
+		&st.Unreviewed,
+		&st.Approved,
+		&st.ChangesRequested,
+	}
+}
+
+func listChangesetsStatsByRepoQuery(opts *ListChangesetsOpts) *sqlf.Query {
+	return sqlf.Sprintf(
+		listChangesetsStatsByRepoQueryFmtstr,
+		changesetsStatsColumns(),
+		sqlf.Join(listChangesetsPreds(opts), "\n AND "),
+	)
+}
+
+// CampaignStats are the aggregated statistics of the changesets of a
+// campaign.
+type CampaignStats struct {
+	campaigns.ChangesetsStats
+
+	// DiffStatAdded, DiffStatChanged and DiffStatDeleted are the sums of the
+	// diff stats of the changesets.
+	DiffStatAdded   int32
+	DiffStatChanged int32
+	DiffStatDeleted int32
+
+	// LastSyncedAt is when a changeset of the campaign was last synced with
+	// the code host. Like in the sync data returned by ListChangesetSyncData,
+	// only published changesets that the reconciler completed are synced. It's
+	// zero if the campaign has no such changesets.
+	LastSyncedAt time.Time
+}
+
+// GetCampaignStats returns the CampaignStats of each of the campaigns with the
+// given IDs, computed with a single query. Campaigns without changesets get
+// empty stats. Changesets in deleted repositories aren't counted. The stats
+// aren't filtered by repository permissions.
+func (s *Store) GetCampaignStats(ctx context.Context, campaignIDs ...int64) (map[int64]*CampaignStats, error) {
+	stats := make(map[int64]*CampaignStats, len(campaignIDs))
+	if len(campaignIDs) == 0 {
+		return stats, nil
+	}
+	for _, id := range campaignIDs {
+		stats[id] = &CampaignStats{}
+	}
+
+	q := getCampaignStatsQuery(campaignIDs)
+	err := s.readQuery(ctx, q, func(sc scanner) error {
+		var (
+			campaignID int64
+			st         CampaignStats
+		)
+		dest := []interface{}{&campaignID}
+		dest = append(dest, changesetsStatsDest(&st.ChangesetsStats)...)
+		dest = append(dest,
+			&st.DiffStatAdded,
+			&st.DiffStatChanged,
+			&st.DiffStatDeleted,
+			&dbutil.NullTime{Time: &st.LastSyncedAt},
+		)
+		if err := sc.Scan(dest...); err != nil {
+			return err
+		}
+		stats[campaignID] = &st
+		return nil
+	})
+	return stats, err
+}
+
+var getCampaignStatsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:GetCampaignStats
+SELECT
+  campaigns.id,
+  %s,
+  COALESCE(SUM(changesets.diff_stat_added), 0),
+  COALESCE(SUM(changesets.diff_stat_changed), 0),
+  COALESCE(SUM(changesets.diff_stat_deleted), 0),
+  MAX(changesets.updated_at) FILTER (WHERE changesets.publication_state = %s AND changesets.reconciler_state = %s)
+FROM campaigns
+INNER JOIN changesets ON changesets.campaign_ids ? campaigns.id::text
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE campaigns.id IN (%s)
+AND repo.deleted_at IS NULL
+GROUP BY campaigns.id
+`
+
+func getCampaignStatsQuery(campaignIDs []int64) *sqlf.Query {
+	ids := make([]*sqlf.Query, 0, len(campaignIDs))
+	for _, id := range campaignIDs {
+		ids = append(ids, sqlf.Sprintf("%s", id))
+	}
+
+	return sqlf.Sprintf(
+		getCampaignStatsQueryFmtstr,
+		changesetsStatsColumns(),
+		campaigns.ChangesetPublicationStatePublished,
+		campaigns.ReconcilerStateCompleted.ToDB(),
+		sqlf.Join(ids, ","),
+	)
+}
+
+// GetCampaignChangesetsUpdatedAt returns the time at which the most recently
+// updated changeset of the given campaign was updated. The zero time is
+// returned if the campaign has no changesets.
+func (s *Store) GetCampaignChangesetsUpdatedAt(ctx context.Context, campaignID int64) (time.Time, error) {
+	q := sqlf.Sprintf(getCampaignChangesetsUpdatedAtQueryFmtstr, campaignID)
+
+	var updatedAt time.Time
+	err := s.query(ctx, q, func(sc scanner) error {
+		return sc.Scan(&dbutil.NullTime{Time: &updatedAt})
+	})
+	return updatedAt, err
+}
+
+var getCampaignChangesetsUpdatedAtQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:GetCampaignChangesetsUpdatedAt
+SELECT MAX(changesets.updated_at)
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE changesets.campaign_ids ? %s
+AND repo.deleted_at IS NULL
+`
+
+// GetCampaignProgress returns the CampaignProgress of the given campaign.
+// The changesets that the reconciler published since the given time are
+// counted as recently published.
+func (s *Store) GetCampaignProgress(ctx context.Context, campaignID int64, since time.Time) (*CampaignProgress, error) {
+	q := getCampaignProgressQuery(campaignID, since)
+
+	p := &CampaignProgress{Time: s.now()}
+	err := s.query(ctx, q, func(sc scanner) error {
+		return sc.Scan(
+			&p.Total,
+			&p.Published,
+			&p.Merged,
+			&p.PendingPublication,
+			&p.RecentlyPublished,
+		)
+	})
+	return p, err
+}
+
+var getCampaignProgressQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:GetCampaignProgress
+SELECT
+  COUNT(changesets.id),
+  COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s),
+  COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s),
+  COUNT(changesets.id) FILTER (WHERE
+    changesets.owned_by_campaign_id = %s AND
+    changesets.publication_state = %s AND
+    changesets.reconciler_state IN (%s, %s) AND
+    changeset_specs.spec->>'published' = 'true'
+  ),
+  (
+    SELECT COUNT(campaign_activities.id)
+    FROM campaign_activities
+    WHERE campaign_activities.campaign_id = %s
+    AND campaign_activities.kind = %s
+    AND campaign_activities.created_at >= %s
+  )
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+LEFT JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+WHERE changesets.campaign_ids ? %s
+AND repo.deleted_at IS NULL
+`
+
+func getCampaignProgressQuery(campaignID int64, since time.Time) *sqlf.Query {
+	published := campaigns.ChangesetPublicationStatePublished
+	return sqlf.Sprintf(
+		getCampaignProgressQueryFmtstr,
+		published,
+		published, campaigns.ChangesetExternalStateMerged,
+		campaignID,
+		campaigns.ChangesetPublicationStateUnpublished,
+		campaigns.ReconcilerStateQueued.ToDB(), campaigns.ReconcilerStateProcessing.ToDB(),
+		campaignID,
+		campaigns.CampaignActivityKindChangesetPublished,
+		since,
+		campaignID,
+	)
+}
+
+// ListChangesetRepoGroupsOpts captures the query options needed for
+// listing the changesets of a campaign grouped by repository.
+type ListChangesetRepoGroupsOpts struct {
+	CampaignID int64
+	// Cursor is the ID of the repository of the first group returned.
+	Cursor int64
+	Limit  int
+}
+
+// ListChangesetRepoGroups lists the changesets of a campaign grouped by their
+// repository, ordered by the ID of the repository. The returned cursor is the
+// ID of the repository of the next group.
+func (s *Store) ListChangesetRepoGroups(ctx context.Context, opts ListChangesetRepoGroupsOpts) (gs []*campaigns.ChangesetRepoGroup, next int64, err error) {
+	q := listChangesetRepoGroupsQuery(&opts)
+
+	gs = make([]*campaigns.ChangesetRepoGroup, 0, opts.Limit)
+	err = s.readQuery(ctx, q, func(sc scanner) error {
+		var g campaigns.ChangesetRepoGroup
+		if err := sc.Scan(
+			&g.RepoID,
+			&g.Total,
+			&g.Unpublished,
+			&g.Open,
+			&g.Merged,
+			&g.Closed,
+			&g.DiffStatAdded,
+			&g.DiffStatChanged,
+			&g.DiffStatDeleted,
+		); err != nil {
+			return err
+		}
+		gs = append(gs, &g)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(gs) == opts.Limit {
+		next = int64(gs[len(gs)-1].RepoID)
+		gs = gs[:len(gs)-1]
+	}
+
+	return gs, next, err
+}
+
+var listChangesetRepoGroupsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:ListChangesetRepoGroups
+SELECT
+  changesets.repo_id,
+  COUNT(changesets.id),
+  COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s),
+  COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s),
+  COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s),
+  COUNT(changesets.id) FILTER (WHERE changesets.publication_state = %s AND changesets.external_state = %s),
+  COALESCE(SUM(changesets.diff_stat_added), 0),
+  COALESCE(SUM(changesets.diff_stat_changed), 0),
+  COALESCE(SUM(changesets.diff_stat_deleted), 0)
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+  repo.deleted_at IS NULL AND
+  changesets.campaign_ids ? %s AND
+  changesets.repo_id >= %s
+GROUP BY changesets.repo_id
+ORDER BY changesets.repo_id ASC
+`
+
+func listChangesetRepoGroupsQuery(opts *ListChangesetRepoGroupsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	published := campaigns.ChangesetPublicationStatePublished
+	return sqlf.Sprintf(
+		listChangesetRepoGroupsQueryFmtstr+limitClause,
+		campaigns.ChangesetPublicationStateUnpublished,
+		published, campaigns.ChangesetExternalStateOpen,
+		published, campaigns.ChangesetExternalStateMerged,
+		published, campaigns.ChangesetExternalStateClosed,
+		opts.CampaignID,
+		opts.Cursor,
+	)
+}
+
+// CountChangesetRepoGroups returns the number of repositories the changesets
+// of the given campaign are in.
+func (s *Store) CountChangesetRepoGroups(ctx context.Context, campaignID int64) (int, error) {
+	return s.readQueryCount(ctx, sqlf.Sprintf(countChangesetRepoGroupsQueryFmtstr, campaignID))
+}
+
+var countChangesetRepoGroupsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:CountChangesetRepoGroups
+SELECT COUNT(DISTINCT changesets.repo_id)
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE changesets.campaign_ids ? %s
+AND repo.deleted_at IS NULL
+`
+
 // UpdateChangeset updates the given Changeset.
 func (s *Store) UpdateChangeset(ctx context.Context, cs *campaigns.Changeset) error {
 	q, err := s.updateChangesetQuery(cs)
@@ -479,7 +1419,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *campaigns.Changeset) er
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store_changeset_specs.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -501,6 +1441,11 @@ func (s *Store) updateChangesetQuery(c *campaigns.Changeset) (*sqlf.Query, error
 		return nil, err
 	}
 
+	customMetadata, err := jsonStringMapColumn(c.CustomMetadata)
+	if err != nil {
+		return nil, err
+	}
+
 	c.UpdatedAt = s.now()
 
 	return sqlf.Sprintf(
@@ -531,16 +1476,106 @@ func (s *Store) updateChangesetQuery(c *campaigns.Changeset) (*sqlf.Query, error
 		c.PublicationState,
 		c.ReconcilerState.ToDB(),
 		c.FailureMessage,
+		nullStringColumn(string(c.FailureClass)),
 		nullTimeColumn(c.StartedAt),
 		nullTimeColumn(c.FinishedAt),
 		nullTimeColumn(c.ProcessAfter),
 		c.NumResets,
+		customMetadata,
+		nullStringColumn(string(c.WaitReason)),
+		nullStringColumn(c.ExternalForkNamespace),
+		nullStringColumn(string(c.FailureCode)),
+		nullStringColumn(c.SkippedReason),
+		nullTimeColumn(c.ArchivedAt),
+		c.NumFailures,
 		// ID
 		c.ID,
 		sqlf.Join(changesetColumns, ", "),
 	), nil
 }
 
+// UpdateChangesetsBulkOpts captures the changes that UpdateChangesetsBulk
+// applies to a set of changesets. Nil fields are left unchanged.
+type UpdateChangesetsBulkOpts struct {
+	IDs []int64
+
+	PublicationState *campaigns.ChangesetPublicationState
+	ExternalState    *campaigns.ChangesetExternalState
+	ReconcilerState  *campaigns.ReconcilerState
+
+	// ResetWorkerFields clears the failure, timing and retry bookkeeping of
+	// the reconciler, so that the changesets are processed as if they were
+	// enqueued for the first time.
+	ResetWorkerFields bool
+}
+
+// UpdateChangesetsBulk applies the given changes to all changesets with the
+// given IDs in a single statement and returns the updated changesets.
+func (s *Store) UpdateChangesetsBulk(ctx context.Context, opts UpdateChangesetsBulkOpts) (cs campaigns.Changesets, err error) {
+	if len(opts.IDs) == 0 {
+		return nil, nil
+	}
+
+	q := s.updateChangesetsBulkQuery(&opts)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var c campaigns.Changeset
+		if err := scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var updateChangesetsBulkQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:UpdateChangesetsBulk
+UPDATE changesets
+SET %s
+WHERE id IN (%s)
+RETURNING
+  %s
+`
+
+func (s *Store) updateChangesetsBulkQuery(opts *UpdateChangesetsBulkOpts) *sqlf.Query {
+	sets := []*sqlf.Query{sqlf.Sprintf("updated_at = %s", s.now())}
+
+	if opts.PublicationState != nil {
+		sets = append(sets, sqlf.Sprintf("publication_state = %s", string(*opts.PublicationState)))
+	}
+	if opts.ExternalState != nil {
+		sets = append(sets, sqlf.Sprintf("external_state = %s", nullStringColumn(string(*opts.ExternalState))))
+	}
+	if opts.ReconcilerState != nil {
+		sets = append(sets, sqlf.Sprintf("reconciler_state = %s", opts.ReconcilerState.ToDB()))
+	}
+	if opts.ResetWorkerFields {
+		sets = append(sets,
+			sqlf.Sprintf("failure_message = NULL"),
+			sqlf.Sprintf("failure_class = NULL"),
+			sqlf.Sprintf("failure_code = NULL"),
+			sqlf.Sprintf("started_at = NULL"),
+			sqlf.Sprintf("finished_at = NULL"),
+			sqlf.Sprintf("process_after = NULL"),
+			sqlf.Sprintf("num_resets = 0"),
+			sqlf.Sprintf("num_failures = 0"),
+			sqlf.Sprintf("wait_reason = NULL"),
+		)
+	}
+
+	ids := make([]*sqlf.Query, 0, len(opts.IDs))
+	for _, id := range opts.IDs {
+		ids = append(ids, sqlf.Sprintf("%s", id))
+	}
+
+	return sqlf.Sprintf(
+		updateChangesetsBulkQueryFmtstr,
+		sqlf.Join(sets, ", "),
+		sqlf.Join(ids, ","),
+		sqlf.Join(changesetColumns, ", "),
+	)
+}
+
 // GetChangesetExternalIDs allows us to find the external ids for pull requests based on
 // a slice of head refs. We need this in order to match incoming webhooks to pull requests as
 // the only information they provide is the remote branch
@@ -566,7 +1601,7 @@ func (s *Store) GetChangesetExternalIDs(ctx context.Context, spec api.ExternalRe
 	}
 
 	q := sqlf.Sprintf(queryFmtString, spec.ServiceType, sqlf.Join(inClause, ","), spec.ID, spec.ServiceType, spec.ServiceID)
-	return basestore.ScanStrings(s.Store.Query(ctx, q))
+	return basestore.ScanStrings(s.Query(ctx, q))
 }
 
 func scanFirstChangeset(rows *sql.Rows, err error) (*campaigns.Changeset, bool, error) {
@@ -595,14 +1630,18 @@ func scanChangesets(rows *sql.Rows, queryErr error) ([]*campaigns.Changeset, err
 }
 
 func scanChangeset(t *campaigns.Changeset, s scanner) error {
-	var metadata, syncState json.RawMessage
+	var metadata, syncState, customMetadata json.RawMessage
 
 	var (
 		externalState       string
 		externalReviewState string
 		externalCheckState  string
 		failureMessage      string
+		failureClass        string
+		failureCode         string
 		reconcilerState     string
+		waitReason          string
+		externalURLState    string
 	)
 	err := s.Scan(
 		&t.ID,
@@ -631,10 +1670,20 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		&t.PublicationState,
 		&reconcilerState,
 		&dbutil.NullString{S: &failureMessage},
+		&dbutil.NullString{S: &failureClass},
 		&dbutil.NullTime{Time: &t.StartedAt},
 		&dbutil.NullTime{Time: &t.FinishedAt},
 		&dbutil.NullTime{Time: &t.ProcessAfter},
 		&t.NumResets,
+		&customMetadata,
+		&dbutil.NullString{S: &waitReason},
+		&dbutil.NullString{S: &t.ExternalForkNamespace},
+		&dbutil.NullString{S: &externalURLState},
+		&dbutil.NullTime{Time: &t.ExternalURLCheckedAt},
+		&dbutil.NullString{S: &failureCode},
+		&dbutil.NullString{S: &t.SkippedReason},
+		&dbutil.NullTime{Time: &t.ArchivedAt},
+		&t.NumFailures,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -646,7 +1695,11 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 	if failureMessage != "" {
 		t.FailureMessage = &failureMessage
 	}
+	t.FailureClass = failure.Class(failureClass)
+	t.FailureCode = campaigns.ChangesetErrorCode(failureCode)
 	t.ReconcilerState = campaigns.ReconcilerState(strings.ToUpper(reconcilerState))
+	t.WaitReason = campaigns.ChangesetWaitReason(waitReason)
+	t.ExternalURLState = campaigns.ChangesetURLState(externalURLState)
 
 	switch t.ExternalServiceType {
 	case extsvc.TypeGitHub:
@@ -655,6 +1708,8 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		t.Metadata = new(bitbucketserver.PullRequest)
 	case extsvc.TypeGitLab:
 		t.Metadata = new(gitlab.MergeRequest)
+	case extsvc.TypeBitbucketCloud:
+		t.Metadata = new(bitbucketcloud.PullRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -666,5 +1721,13 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
 	}
 
+	t.CustomMetadata = nil
+	if err = json.Unmarshal(customMetadata, &t.CustomMetadata); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal custom metadata: %s", customMetadata)
+	}
+	if len(t.CustomMetadata) == 0 {
+		t.CustomMetadata = nil
+	}
+
 	return nil
 }
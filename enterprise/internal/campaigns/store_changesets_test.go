@@ -10,6 +10,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
@@ -100,6 +102,9 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 				th.StartedAt = clock.now()
 				th.FinishedAt = clock.now()
 				th.ProcessAfter = clock.now()
+				th.WaitReason = cmpgn.ChangesetWaitReasonRateLimit
+
+				th.CustomMetadata = map[string]string{"ticket": fmt.Sprintf("ENG-%d", i), "risk": "low"}
 			}
 
 			if err := s.CreateChangeset(ctx, th); err != nil {
@@ -281,6 +286,52 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 				t.Fatalf("have countProcessing: %d, want: %d", have, want)
 			}
 		})
+
+		t.Run("OnlyFailed", func(t *testing.T) {
+			failureMessage := "failed"
+			c := changesets[0].Clone()
+			c.ReconcilerState = campaigns.ReconcilerStateErrored
+			c.FailureMessage = &failureMessage
+			if err := s.UpdateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := s.UpdateChangeset(ctx, changesets[0]); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			count, err := s.CountChangesets(ctx, CountChangesetsOpts{OnlyFailed: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := count, 1; have != want {
+				t.Fatalf("have count: %d, want: %d", have, want)
+			}
+
+			have, _, err := s.ListChangesets(ctx, ListChangesetsOpts{OnlyFailed: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 1 || have[0].ID != c.ID {
+				t.Fatalf("wrong changesets listed. want=%d, have=%v", c.ID, have.IDs())
+			}
+
+			// A completed changeset with the failure message of an earlier
+			// attempt didn't fail.
+			c.ReconcilerState = campaigns.ReconcilerStateCompleted
+			if err := s.UpdateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			count, err = s.CountChangesets(ctx, CountChangesetsOpts{OnlyFailed: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := count, 0; have != want {
+				t.Fatalf("have count: %d, want: %d", have, want)
+			}
+		})
 	})
 
 	t.Run("List", func(t *testing.T) {
@@ -432,6 +483,8 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 		stateChangesRequested := cmpgn.ChangesetReviewStateChangesRequested
 		statePassed := cmpgn.ChangesetCheckStatePassed
 		stateFailed := cmpgn.ChangesetCheckStateFailed
+		beforeCreation := clock.now().Add(-time.Minute)
+		afterCreation := clock.now()
 
 		filterCases := []struct {
 			opts      ListChangesetsOpts
@@ -511,6 +564,36 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 				},
 				wantCount: 0,
 			},
+			{
+				opts: ListChangesetsOpts{
+					CustomMetadata: map[string]string{"risk": "low"},
+				},
+				wantCount: 2,
+			},
+			{
+				opts: ListChangesetsOpts{
+					CustomMetadata: map[string]string{"risk": "low", "ticket": "ENG-1"},
+				},
+				wantCount: 1,
+			},
+			{
+				opts: ListChangesetsOpts{
+					CustomMetadata: map[string]string{"risk": "high"},
+				},
+				wantCount: 0,
+			},
+			{
+				opts: ListChangesetsOpts{
+					UpdatedAfter: &beforeCreation,
+				},
+				wantCount: 3,
+			},
+			{
+				opts: ListChangesetsOpts{
+					UpdatedAfter: &afterCreation,
+				},
+				wantCount: 0,
+			},
 		}
 
 		for _, tc := range filterCases {
@@ -526,6 +609,422 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 		}
 	})
 
+	t.Run("ForEachChangeset", func(t *testing.T) {
+		for i := 1; i <= len(changesets)+1; i++ {
+			var have cmpgn.Changesets
+			err := s.ForEachChangeset(ctx, ListChangesetsOpts{Limit: i}, func(c *cmpgn.Changeset) error {
+				have = append(have, c)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, changesets); diff != "" {
+				t.Fatalf("limit: %v, diff: %s", i, diff)
+			}
+		}
+
+		t.Run("Cursor", func(t *testing.T) {
+			var have cmpgn.Changesets
+			opts := ListChangesetsOpts{Cursor: changesets[1].ID, Limit: 1}
+			err := s.ForEachChangeset(ctx, opts, func(c *cmpgn.Changeset) error {
+				have = append(have, c)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, changesets[1:]); diff != "" {
+				t.Fatalf("diff: %s", diff)
+			}
+		})
+
+		t.Run("Error", func(t *testing.T) {
+			stop := errors.New("stop")
+
+			var calls int
+			err := s.ForEachChangeset(ctx, ListChangesetsOpts{Limit: 1}, func(c *cmpgn.Changeset) error {
+				calls++
+				return stop
+			})
+			if err != stop {
+				t.Fatalf("wrong error. want=%v, have=%v", stop, err)
+			}
+			if calls != 1 {
+				t.Fatalf("fn called %d times, want 1", calls)
+			}
+		})
+	})
+
+	t.Run("ForEachChangesetBatch", func(t *testing.T) {
+		var (
+			have  cmpgn.Changesets
+			sizes []int
+		)
+		err := s.ForEachChangesetBatch(ctx, ListChangesetsOpts{Limit: 2}, func(cs cmpgn.Changesets) error {
+			have = append(have, cs...)
+			sizes = append(sizes, len(cs))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(have, changesets); diff != "" {
+			t.Fatalf("diff: %s", diff)
+		}
+		for i, size := range sizes {
+			if size > 2 || size == 0 || (i < len(sizes)-1 && size != 2) {
+				t.Fatalf("wrong batch sizes: %v", sizes)
+			}
+		}
+	})
+
+	t.Run("CountChangesetsByRepo", func(t *testing.T) {
+		countByRepo := func(cs cmpgn.Changesets) map[api.RepoID]int32 {
+			counts := make(map[api.RepoID]int32)
+			for _, c := range cs {
+				counts[c.RepoID]++
+			}
+			return counts
+		}
+
+		// The limit is ignored.
+		have, err := s.CountChangesetsByRepo(ctx, ListChangesetsOpts{Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(countByRepo(changesets), have); diff != "" {
+			t.Fatalf("diff: %s", diff)
+		}
+
+		t.Run("Cursor", func(t *testing.T) {
+			have, err := s.CountChangesetsByRepo(ctx, ListChangesetsOpts{Cursor: changesets[1].ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(countByRepo(changesets[1:]), have); diff != "" {
+				t.Fatalf("diff: %s", diff)
+			}
+		})
+
+		t.Run("Filters", func(t *testing.T) {
+			opts := ListChangesetsOpts{IDs: []int64{changesets[0].ID}}
+			have, err := s.CountChangesetsByRepo(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(countByRepo(changesets[:1]), have); diff != "" {
+				t.Fatalf("diff: %s", diff)
+			}
+		})
+	})
+
+	t.Run("GetCampaignChangesetsUpdatedAt", func(t *testing.T) {
+		have, err := s.GetCampaignChangesetsUpdatedAt(ctx, changesets[0].CampaignIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := changesets[0].UpdatedAt; !have.Equal(want) {
+			t.Fatalf("wrong updated at. want=%s, have=%s", want, have)
+		}
+
+		have, err = s.GetCampaignChangesetsUpdatedAt(ctx, 999)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !have.IsZero() {
+			t.Fatalf("campaign without changesets has updated at %s", have)
+		}
+	})
+
+	t.Run("ListChangesetRepoGroups", func(t *testing.T) {
+		const campaignID = 4242
+
+		otherRepo := testRepo(3, extsvc.TypeGitHub)
+		if err := reposStore.UpsertRepos(ctx, otherRepo); err != nil {
+			t.Fatal(err)
+		}
+
+		var (
+			one int32 = 1
+			two int32 = 2
+		)
+		grouped := []*cmpgn.Changeset{
+			{RepoID: repo.ID, PublicationState: cmpgn.ChangesetPublicationStatePublished, ExternalState: cmpgn.ChangesetExternalStateOpen, DiffStatAdded: &one, DiffStatChanged: &one, DiffStatDeleted: &one},
+			{RepoID: repo.ID, PublicationState: cmpgn.ChangesetPublicationStatePublished, ExternalState: cmpgn.ChangesetExternalStateMerged, DiffStatAdded: &two, DiffStatChanged: &two, DiffStatDeleted: &two},
+			{RepoID: otherRepo.ID, PublicationState: cmpgn.ChangesetPublicationStateUnpublished},
+			{RepoID: deletedRepo.ID, PublicationState: cmpgn.ChangesetPublicationStatePublished, ExternalState: cmpgn.ChangesetExternalStateClosed},
+		}
+		for i, c := range grouped {
+			c.CampaignIDs = []int64{campaignID}
+			c.ExternalID = fmt.Sprintf("grouped-%d", i)
+			c.ExternalServiceType = extsvc.TypeGitHub
+			if err := s.CreateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		defer func() {
+			for _, c := range grouped {
+				if err := s.DeleteChangeset(ctx, c.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}()
+
+		want := []*cmpgn.ChangesetRepoGroup{
+			{RepoID: repo.ID, Total: 2, Open: 1, Merged: 1, DiffStatAdded: 3, DiffStatChanged: 3, DiffStatDeleted: 3},
+			{RepoID: otherRepo.ID, Total: 1, Unpublished: 1},
+		}
+
+		have, next, err := s.ListChangesetRepoGroups(ctx, ListChangesetRepoGroupsOpts{CampaignID: campaignID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+		if next != 0 {
+			t.Fatalf("wrong next. want=0, have=%d", next)
+		}
+
+		var cursor int64
+		for i := range want {
+			have, next, err := s.ListChangesetRepoGroups(ctx, ListChangesetRepoGroupsOpts{CampaignID: campaignID, Cursor: cursor, Limit: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want[i:i+1], have); diff != "" {
+				t.Fatalf("cursor: %d, diff: %s", cursor, diff)
+			}
+			cursor = next
+		}
+		if cursor != 0 {
+			t.Fatalf("wrong next after last page. want=0, have=%d", cursor)
+		}
+
+		count, err := s.CountChangesetRepoGroups(ctx, campaignID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, len(want); have != want {
+			t.Fatalf("wrong count. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("ListChangesetsStatsByRepo", func(t *testing.T) {
+		const campaignID = 4343
+
+		otherRepo := testRepo(3, extsvc.TypeGitHub)
+		if err := reposStore.UpsertRepos(ctx, otherRepo); err != nil {
+			t.Fatal(err)
+		}
+
+		published := cmpgn.ChangesetPublicationStatePublished
+		open := cmpgn.ChangesetExternalStateOpen
+		counted := []*cmpgn.Changeset{
+			{RepoID: repo.ID, PublicationState: published, ExternalState: open, ExternalReviewState: cmpgn.ChangesetReviewStatePending},
+			{RepoID: repo.ID, PublicationState: published, ExternalState: open, ExternalReviewState: cmpgn.ChangesetReviewStateApproved, Metadata: &github.PullRequest{Mergeable: "CONFLICTING"}},
+			{RepoID: repo.ID, PublicationState: published, ExternalState: open, ExternalReviewState: cmpgn.ChangesetReviewStateChangesRequested, Metadata: &gitlab.MergeRequest{MergeStatus: "cannot_be_merged"}},
+			{RepoID: repo.ID, PublicationState: published, ExternalState: cmpgn.ChangesetExternalStateMerged},
+			{RepoID: otherRepo.ID, PublicationState: published, ExternalState: cmpgn.ChangesetExternalStateClosed},
+			{RepoID: otherRepo.ID, PublicationState: cmpgn.ChangesetPublicationStateUnpublished},
+			{RepoID: otherRepo.ID, PublicationState: published, ExternalState: open, ExternalReviewState: cmpgn.ChangesetReviewStatePending, ArchivedAt: clock.now()},
+			{RepoID: deletedRepo.ID, PublicationState: published, ExternalState: open},
+		}
+		for i, c := range counted {
+			c.CampaignIDs = []int64{campaignID}
+			c.ExternalID = fmt.Sprintf("counted-%d", i)
+			c.ExternalServiceType = extsvc.TypeGitHub
+			if err := s.CreateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		defer func() {
+			for _, c := range counted {
+				if err := s.DeleteChangeset(ctx, c.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}()
+
+		want := map[api.RepoID]*cmpgn.ChangesetsStats{
+			repo.ID: {
+				Total:            4,
+				Open:             3,
+				Merged:           1,
+				Conflicting:      2,
+				Unreviewed:       1,
+				Approved:         1,
+				ChangesRequested: 1,
+			},
+			otherRepo.ID: {Total: 3, Unpublished: 1, Closed: 1, Archived: 1},
+		}
+
+		have, err := s.ListChangesetsStatsByRepo(ctx, ListChangesetsOpts{CampaignID: campaignID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// The stats are filtered like the changesets.
+		have, err = s.ListChangesetsStatsByRepo(ctx, ListChangesetsOpts{CampaignID: campaignID, ExternalState: &open})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = map[api.RepoID]*cmpgn.ChangesetsStats{
+			repo.ID:      {Total: 3, Open: 3, Conflicting: 2, Unreviewed: 1, Approved: 1, ChangesRequested: 1},
+			otherRepo.ID: {Total: 1, Archived: 1},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("GetCampaignStats", func(t *testing.T) {
+		campaign := &cmpgn.Campaign{
+			Name:             "stats-campaign",
+			InitialApplierID: 1,
+			NamespaceUserID:  1,
+			Visibility:       cmpgn.CampaignVisibilityPublic,
+		}
+		empty := &cmpgn.Campaign{
+			Name:             "empty-stats-campaign",
+			InitialApplierID: 1,
+			NamespaceUserID:  1,
+			Visibility:       cmpgn.CampaignVisibilityPublic,
+		}
+		for _, c := range []*cmpgn.Campaign{campaign, empty} {
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		defer func() {
+			for _, c := range []*cmpgn.Campaign{campaign, empty} {
+				if err := s.DeleteCampaign(ctx, c.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}()
+
+		one, two := int32(1), int32(2)
+		published := cmpgn.ChangesetPublicationStatePublished
+		completed := cmpgn.ReconcilerStateCompleted
+		counted := []*cmpgn.Changeset{
+			{RepoID: repo.ID, PublicationState: published, ReconcilerState: completed, ExternalState: cmpgn.ChangesetExternalStateOpen, DiffStatAdded: &one, DiffStatChanged: &two, DiffStatDeleted: &one},
+			{RepoID: repo.ID, PublicationState: published, ReconcilerState: completed, ExternalState: cmpgn.ChangesetExternalStateMerged, DiffStatAdded: &two},
+			// Neither the unpublished changeset nor the one that's queued
+			// are synced, so their later updates don't count as syncs.
+			{RepoID: repo.ID, PublicationState: cmpgn.ChangesetPublicationStateUnpublished, ReconcilerState: completed},
+			{RepoID: repo.ID, PublicationState: published, ReconcilerState: cmpgn.ReconcilerStateQueued, ExternalState: cmpgn.ChangesetExternalStateOpen},
+			{RepoID: deletedRepo.ID, PublicationState: published, ReconcilerState: completed, ExternalState: cmpgn.ChangesetExternalStateOpen, DiffStatAdded: &two},
+		}
+		lastSyncedAt := clock.now()
+		for i, c := range counted {
+			if i == 2 {
+				clock.add(1 * time.Second)
+			}
+			c.CampaignIDs = []int64{campaign.ID}
+			c.ExternalID = fmt.Sprintf("stats-%d", i)
+			c.ExternalServiceType = extsvc.TypeGitHub
+			if err := s.CreateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		defer func() {
+			for _, c := range counted {
+				if err := s.DeleteChangeset(ctx, c.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}()
+
+		have, err := s.GetCampaignStats(ctx, campaign.ID, empty.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[int64]*CampaignStats{
+			campaign.ID: {
+				ChangesetsStats: cmpgn.ChangesetsStats{Total: 4, Unpublished: 1, Open: 2, Merged: 1},
+				DiffStatAdded:   3,
+				DiffStatChanged: 2,
+				DiffStatDeleted: 1,
+				LastSyncedAt:    lastSyncedAt,
+			},
+			empty.ID: {},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Campaign diff stat", func(t *testing.T) {
+		campaign := &cmpgn.Campaign{
+			Name:             "diff-stat-campaign",
+			InitialApplierID: 1,
+			NamespaceUserID:  1,
+			Visibility:       cmpgn.CampaignVisibilityPublic,
+		}
+		if err := s.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err := s.DeleteCampaign(ctx, campaign.ID); err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		assertDiffStat := func(t *testing.T, want diff.Stat) {
+			t.Helper()
+			have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have.DiffStat()); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		var (
+			one int32 = 1
+			two int32 = 2
+		)
+		withStat := &cmpgn.Changeset{RepoID: repo.ID, DiffStatAdded: &one, DiffStatChanged: &one, DiffStatDeleted: &one}
+		withoutStat := &cmpgn.Changeset{RepoID: repo.ID}
+		for i, c := range []*cmpgn.Changeset{withStat, withoutStat} {
+			c.CampaignIDs = []int64{campaign.ID}
+			c.ExternalID = fmt.Sprintf("diff-stat-%d", i)
+			c.ExternalServiceType = extsvc.TypeGitHub
+			if err := s.CreateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		assertDiffStat(t, diff.Stat{Added: 1, Changed: 1, Deleted: 1})
+
+		withoutStat.DiffStatAdded, withoutStat.DiffStatChanged, withoutStat.DiffStatDeleted = &two, &two, &two
+		if err := s.UpdateChangeset(ctx, withoutStat); err != nil {
+			t.Fatal(err)
+		}
+		assertDiffStat(t, diff.Stat{Added: 3, Changed: 3, Deleted: 3})
+
+		withStat.CampaignIDs = []int64{}
+		if err := s.UpdateChangeset(ctx, withStat); err != nil {
+			t.Fatal(err)
+		}
+		assertDiffStat(t, diff.Stat{Added: 2, Changed: 2, Deleted: 2})
+
+		for _, c := range []*cmpgn.Changeset{withStat, withoutStat} {
+			if err := s.DeleteChangeset(ctx, c.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		assertDiffStat(t, diff.Stat{})
+	})
+
 	t.Run("Null changeset external state", func(t *testing.T) {
 		cs := &cmpgn.Changeset{
 			RepoID:              repo.ID,
@@ -748,15 +1247,403 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 			t.Fatal(diff)
 		}
 	})
-}
 
-func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store, reposStore repos.Store, clock clock) {
-	githubActor := github.Actor{
-		AvatarURL: "https://avatars2.githubusercontent.com/u/1185253",
-		Login:     "mrnugget",
-		URL:       "https://github.com/mrnugget",
-	}
-	githubPR := &github.PullRequest{
+	t.Run("GetChangesetQueuePosition", func(t *testing.T) {
+		for _, c := range changesets {
+			clock.add(1 * time.Second)
+
+			c.ReconcilerState = cmpgn.ReconcilerStateQueued
+			c.ProcessAfter = time.Time{}
+			if err := s.UpdateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for i, c := range changesets {
+			have, err := s.GetChangesetQueuePosition(ctx, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := i + 1; have != want {
+				t.Fatalf("wrong queue position. want=%d, have=%d", want, have)
+			}
+		}
+
+		// Changesets that wait until later aren't ahead in the queue.
+		changesets[0].ProcessAfter = time.Now().Add(1 * time.Hour)
+		changesets[0].WaitReason = cmpgn.ChangesetWaitReasonRateLimit
+		if err := s.UpdateChangeset(ctx, changesets[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		last := changesets[len(changesets)-1]
+		have, err := s.GetChangesetQueuePosition(ctx, last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := len(changesets) - 1; have != want {
+			t.Fatalf("wrong queue position. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("ListAutoMergeableChangesets", func(t *testing.T) {
+		autoMerge := &cmpgn.Campaign{
+			Name:             "auto-merge",
+			InitialApplierID: 1,
+			NamespaceUserID:  1,
+			AutoMerge:        true,
+		}
+		manual := &cmpgn.Campaign{
+			Name:             "manual",
+			InitialApplierID: 1,
+			NamespaceUserID:  1,
+		}
+		for _, c := range []*cmpgn.Campaign{autoMerge, manual} {
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for i, c := range changesets {
+			c.ReconcilerState = cmpgn.ReconcilerStateCompleted
+			c.ExternalState = cmpgn.ChangesetExternalStateOpen
+			c.ExternalCheckState = cmpgn.ChangesetCheckStatePassed
+			c.ExternalReviewState = cmpgn.ChangesetReviewStateApproved
+
+			switch i {
+			case 0:
+				c.CampaignIDs = []int64{manual.ID, autoMerge.ID}
+			case 1:
+				// Checks are still running.
+				c.CampaignIDs = []int64{autoMerge.ID}
+				c.ExternalCheckState = cmpgn.ChangesetCheckStatePending
+			default:
+				c.CampaignIDs = []int64{manual.ID}
+			}
+
+			if err := s.UpdateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListAutoMergeableChangesets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(have) != 1 || have[0].ID != changesets[0].ID {
+			t.Fatalf("wrong changesets. want=%d, have=%+v", changesets[0].ID, have)
+		}
+
+		// Changesets of closed campaigns are never merged automatically.
+		autoMerge.ClosedAt = clock.now()
+		if err := s.UpdateCampaign(ctx, autoMerge); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err = s.ListAutoMergeableChangesets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("wrong number of changesets. want=0, have=%d", len(have))
+		}
+	})
+
+	t.Run("ListAutoRebaseableChangesets", func(t *testing.T) {
+		autoRebase := &cmpgn.Campaign{
+			Name:             "auto-rebase",
+			InitialApplierID: 1,
+			NamespaceUserID:  1,
+			AutoRebase:       true,
+		}
+		manual := &cmpgn.Campaign{
+			Name:             "manual-rebase",
+			InitialApplierID: 1,
+			NamespaceUserID:  1,
+		}
+		for _, c := range []*cmpgn.Campaign{autoRebase, manual} {
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for i, c := range changesets {
+			c.ReconcilerState = cmpgn.ReconcilerStateCompleted
+			c.ExternalState = cmpgn.ChangesetExternalStateOpen
+			c.CurrentSpecID = int64(i) + 1
+
+			switch i {
+			case 0:
+				c.CampaignIDs = []int64{manual.ID, autoRebase.ID}
+			case 1:
+				// Imported changesets have no diff that could be re-applied.
+				c.CampaignIDs = []int64{autoRebase.ID}
+				c.CurrentSpecID = 0
+			default:
+				c.CampaignIDs = []int64{manual.ID}
+			}
+
+			if err := s.UpdateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListAutoRebaseableChangesets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != changesets[0].ID {
+			t.Fatalf("wrong changesets. want=%d, have=%+v", changesets[0].ID, have)
+		}
+
+		// Changesets of closed campaigns are never rebased automatically.
+		autoRebase.ClosedAt = clock.now()
+		if err := s.UpdateCampaign(ctx, autoRebase); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err = s.ListAutoRebaseableChangesets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("wrong number of changesets. want=0, have=%d", len(have))
+		}
+	})
+
+	t.Run("ListChangesetsToCheckURL", func(t *testing.T) {
+		checkedAt := clock.now()
+		if err := s.SetChangesetURLState(ctx, changesets[0].ID, cmpgn.ChangesetURLStateDead, checkedAt); err != nil {
+			t.Fatal(err)
+		}
+
+		// Updating the changeset doesn't overwrite the result of the check.
+		if err := s.UpdateChangeset(ctx, changesets[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangeset(ctx, GetChangesetOpts{ID: changesets[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ExternalURLState != cmpgn.ChangesetURLStateDead {
+			t.Fatalf("wrong URL state. want=%s, have=%s", cmpgn.ChangesetURLStateDead, have.ExternalURLState)
+		}
+		if !have.ExternalURLCheckedAt.Equal(checkedAt) {
+			t.Fatalf("wrong URL checked at. want=%s, have=%s", checkedAt, have.ExternalURLCheckedAt)
+		}
+
+		for _, tc := range []struct {
+			opts ListChangesetsToCheckURLOpts
+			want []int64
+		}{
+			{
+				opts: ListChangesetsToCheckURLOpts{CheckedBefore: checkedAt},
+				want: []int64{changesets[1].ID, changesets[2].ID},
+			},
+			{
+				opts: ListChangesetsToCheckURLOpts{CheckedBefore: checkedAt.Add(time.Second)},
+				want: []int64{changesets[1].ID, changesets[2].ID, changesets[0].ID},
+			},
+			{
+				opts: ListChangesetsToCheckURLOpts{CheckedBefore: checkedAt.Add(time.Second), Limit: 1},
+				want: []int64{changesets[1].ID},
+			},
+			{
+				// Only changesets[0] and changesets[1] are attached to the
+				// auto-rebase campaign created above.
+				opts: ListChangesetsToCheckURLOpts{CampaignID: changesets[1].CampaignIDs[0]},
+				want: []int64{changesets[1].ID, changesets[0].ID},
+			},
+		} {
+			have, err := s.ListChangesetsToCheckURL(ctx, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			haveIDs := make([]int64, 0, len(have))
+			for _, c := range have {
+				haveIDs = append(haveIDs, c.ID)
+			}
+			if diff := cmp.Diff(tc.want, haveIDs); diff != "" {
+				t.Fatalf("opts: %+v, diff: %s", tc.opts, diff)
+			}
+		}
+	})
+
+	t.Run("SetChangesetFailureCode", func(t *testing.T) {
+		ch := changesets[0]
+		if err := s.SetChangesetFailureCode(ctx, ch.ID, cmpgn.ChangesetErrorCodeMergeConflict); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangeset(ctx, GetChangesetOpts{ID: ch.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.FailureCode != cmpgn.ChangesetErrorCodeMergeConflict {
+			t.Fatalf("wrong failure code. want=%s, have=%s", cmpgn.ChangesetErrorCodeMergeConflict, have.FailureCode)
+		}
+
+		// Clearing the failure code in an update clears it in the database.
+		have.FailureCode = ""
+		if err := s.UpdateChangeset(ctx, have); err != nil {
+			t.Fatal(err)
+		}
+		if have.FailureCode != "" {
+			t.Fatalf("wrong failure code. want=\"\", have=%s", have.FailureCode)
+		}
+	})
+
+	t.Run("GetRepoServiceID", func(t *testing.T) {
+		have, err := s.GetRepoServiceID(ctx, repo.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := repo.ExternalRepo.ServiceID; have != want {
+			t.Fatalf("wrong service ID. want=%q, have=%q", want, have)
+		}
+
+		if _, err := s.GetRepoServiceID(ctx, repo.ID+9999); err != ErrNoResults {
+			t.Fatalf("wrong error for missing repo. want=%v, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("SetChangesetNumFailures", func(t *testing.T) {
+		ch, err := s.GetChangeset(ctx, GetChangesetOpts{ID: changesets[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SetChangesetNumFailures(ctx, ch.ID, 3); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangeset(ctx, GetChangesetOpts{ID: ch.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.NumFailures != 3 {
+			t.Fatalf("wrong number of failures. want=%d, have=%d", 3, have.NumFailures)
+		}
+		if !have.UpdatedAt.Equal(ch.UpdatedAt) {
+			t.Fatalf("updated_at changed. want=%s, have=%s", ch.UpdatedAt, have.UpdatedAt)
+		}
+
+		// Resetting the number of failures in an update resets it in the
+		// database.
+		have.NumFailures = 0
+		if err := s.UpdateChangeset(ctx, have); err != nil {
+			t.Fatal(err)
+		}
+		if have.NumFailures != 0 {
+			t.Fatalf("wrong number of failures. want=0, have=%d", have.NumFailures)
+		}
+	})
+
+	t.Run("ResumePausedChangesets", func(t *testing.T) {
+		resumed := &cmpgn.Campaign{Name: "resumed", InitialApplierID: 1, NamespaceUserID: 1}
+		other := &cmpgn.Campaign{Name: "other", InitialApplierID: 1, NamespaceUserID: 1}
+		for _, c := range []*cmpgn.Campaign{resumed, other} {
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		paused := clock.now().Add(time.Hour)
+		for i, c := range changesets {
+			c.OwnedByCampaignID = resumed.ID
+			c.ReconcilerState = cmpgn.ReconcilerStateQueued
+			c.ProcessAfter = paused
+			c.WaitReason = cmpgn.ChangesetWaitReasonPaused
+			switch i {
+			case 1:
+				c.OwnedByCampaignID = other.ID
+			case 2:
+				c.WaitReason = cmpgn.ChangesetWaitReasonRateLimit
+			}
+			if err := s.UpdateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := s.ResumePausedChangesets(ctx, resumed.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, c := range changesets {
+			have, err := s.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := i == 0; want != have.ProcessAfter.IsZero() {
+				t.Fatalf("changeset %d: wrong process after. resumed=%t, have=%s", i, want, have.ProcessAfter)
+			}
+		}
+	})
+
+	t.Run("UpdateChangesetsBulk", func(t *testing.T) {
+		failureMessage := "failed"
+		for _, c := range changesets {
+			c.PublicationState = cmpgn.ChangesetPublicationStateUnpublished
+			c.ReconcilerState = cmpgn.ReconcilerStateErrored
+			c.NumResets = 5
+			c.NumFailures = 3
+			c.FailureMessage = &failureMessage
+			c.ProcessAfter = clock.now().Add(time.Hour)
+			if err := s.UpdateChangeset(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		published := cmpgn.ChangesetPublicationStatePublished
+		queued := cmpgn.ReconcilerStateQueued
+		updated, err := s.UpdateChangesetsBulk(ctx, UpdateChangesetsBulkOpts{
+			IDs:               []int64{changesets[0].ID, changesets[1].ID},
+			PublicationState:  &published,
+			ReconcilerState:   &queued,
+			ResetWorkerFields: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(updated), 2; have != want {
+			t.Fatalf("wrong number of updated changesets. want=%d, have=%d", want, have)
+		}
+
+		for i, c := range changesets {
+			have, err := s.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := c.Clone()
+			if i < 2 {
+				want.PublicationState = published
+				want.ReconcilerState = queued
+				want.NumResets = 0
+				want.NumFailures = 0
+				want.FailureMessage = nil
+				want.ProcessAfter = time.Time{}
+				want.UpdatedAt = clock.now()
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatalf("changeset %d: wrong changeset (-want +have):\n%s", i, diff)
+			}
+		}
+
+		if cs, err := s.UpdateChangesetsBulk(ctx, UpdateChangesetsBulkOpts{}); err != nil || cs != nil {
+			t.Fatalf("expected no-op for empty IDs. have=%v, err=%v", cs, err)
+		}
+	})
+}
+
+func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store, reposStore repos.Store, clock clock) {
+	githubActor := github.Actor{
+		AvatarURL: "https://avatars2.githubusercontent.com/u/1185253",
+		Login:     "mrnugget",
+		URL:       "https://github.com/mrnugget",
+	}
+	githubPR := &github.PullRequest{
 		ID:           "FOOBARID",
 		Title:        "Fix a bunch of bugs",
 		Body:         "This fixes a bunch of bugs",
@@ -897,6 +1784,113 @@ func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store,
 		}
 	})
 
+	t.Run("by campaign", func(t *testing.T) {
+		hs, err := s.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{CampaignID: changesets[1].CampaignIDs[0]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkChangesetIDs(t, hs, changesets[1:2].IDs())
+	})
+
+	t.Run("by external state", func(t *testing.T) {
+		open := cmpgn.ChangesetExternalStateOpen
+		hs, err := s.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{ExternalState: &open})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkChangesetIDs(t, hs, changesets.IDs())
+
+		merged := cmpgn.ChangesetExternalStateMerged
+		hs, err = s.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{ExternalState: &merged})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkChangesetIDs(t, hs, []int64{})
+	})
+
+	t.Run("sync error message", func(t *testing.T) {
+		ch := changesets[2]
+		msg := "rate limit exceeded"
+		if err := s.SetChangesetSyncErrorMessage(ctx, ch.ID, &msg); err != nil {
+			t.Fatal(err)
+		}
+
+		hs, err := s.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{ChangesetIDs: []int64{ch.ID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []cmpgn.ChangesetSyncData{
+			{
+				ChangesetID:           ch.ID,
+				UpdatedAt:             clock.now(),
+				ExternalUpdatedAt:     clock.now(),
+				RepoExternalServiceID: "https://example.com/",
+				SyncErrorMessage:      &msg,
+			},
+		}
+		if diff := cmp.Diff(want, hs); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if err := s.SetChangesetSyncErrorMessage(ctx, ch.ID, nil); err != nil {
+			t.Fatal(err)
+		}
+		hs, err = s.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{ChangesetIDs: []int64{ch.ID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hs[0].SyncErrorMessage != nil {
+			t.Fatalf("sync error message not cleared: %q", *hs[0].SyncErrorMessage)
+		}
+	})
+
+	t.Run("sync priority", func(t *testing.T) {
+		recorded, err := s.RecordCampaignView(ctx, changesets[1].CampaignIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !recorded {
+			t.Fatal("campaign view not recorded")
+		}
+
+		// Views within campaignViewRecordInterval aren't recorded again.
+		recorded, err = s.RecordCampaignView(ctx, changesets[1].CampaignIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if recorded {
+			t.Fatal("campaign view recorded twice")
+		}
+
+		merged := changesets[2]
+		merged.ExternalState = cmpgn.ChangesetExternalStateMerged
+		if err := s.UpdateChangeset(ctx, merged); err != nil {
+			t.Fatal(err)
+		}
+
+		hs, err := s.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		have := make([]cmpgn.ChangesetSyncPriority, 0, len(hs))
+		for _, h := range hs {
+			have = append(have, h.SyncPriority)
+		}
+		want := []cmpgn.ChangesetSyncPriority{
+			cmpgn.ChangesetSyncPriorityNormal,
+			cmpgn.ChangesetSyncPriorityActive,
+			cmpgn.ChangesetSyncPriorityDormant,
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		merged.ExternalState = cmpgn.ChangesetExternalStateOpen
+		if err := s.UpdateChangeset(ctx, merged); err != nil {
+			t.Fatal(err)
+		}
+	})
+
 	t.Run("ignore closed campaign", func(t *testing.T) {
 		closedCampaignID := changesets[0].CampaignIDs[0]
 		c, err := s.GetCampaign(ctx, GetCampaignOpts{ID: closedCampaignID})
@@ -970,4 +1964,29 @@ func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store,
 		}
 		checkChangesetIDs(t, hs, changesets[1:].IDs())
 	})
+
+	t.Run("ignore paused campaign", func(t *testing.T) {
+		ch := changesets[0]
+		ch.PublicationState = cmpgn.ChangesetPublicationStatePublished
+		if err := s.UpdateChangeset(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+
+		// changesets[0] is only attached to a closed campaign and the
+		// campaign of changesets[1] at this point.
+		c, err := s.GetCampaign(ctx, GetCampaignOpts{ID: changesets[1].CampaignIDs[0]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.PausedAt = clock.now()
+		if err := s.UpdateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		hs, err := s.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkChangesetIDs(t, hs, changesets[2:].IDs())
+	})
 }
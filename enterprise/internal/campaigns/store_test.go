@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtest"
 )
@@ -42,3 +45,64 @@ func storeTest(db *sql.DB, f storeTestFunc) func(*testing.T) {
 		f(t, context.Background(), s, rs, c)
 	}
 }
+
+// containsID returns whether ids contains id.
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStoreQueryOp(t *testing.T) {
+	for _, tc := range []struct {
+		q    *sqlf.Query
+		want string
+	}{
+		{q: listChangesetsQuery(&ListChangesetsOpts{}), want: "ListChangesets"},
+		{q: sqlf.Sprintf(deleteChangesetQueryFmtstr, 1), want: "DeleteChangeset"},
+		{q: sqlf.Sprintf("SELECT 1"), want: "unknown"},
+	} {
+		if have := storeQueryOp(tc.q); have != tc.want {
+			t.Errorf("wrong op for %q. want=%q, have=%q", tc.q.Query(sqlf.PostgresBindVar), tc.want, have)
+		}
+	}
+}
+
+func TestIsRetryableTransactionErr(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: errors.New("boom"), want: false},
+		{err: &pq.Error{Code: "23505"}, want: false},
+		{err: &pq.Error{Code: "40001"}, want: true},
+		{err: &pq.Error{Code: "40P01"}, want: true},
+		{err: errors.Wrap(&pq.Error{Code: "40001"}, "updating changeset"), want: true},
+	} {
+		if have := isRetryableTransactionErr(tc.err); have != tc.want {
+			t.Errorf("wrong result for %v. want=%t, have=%t", tc.err, tc.want, have)
+		}
+	}
+}
+
+func TestStoreReader(t *testing.T) {
+	primary, replica := &sql.DB{}, &sql.DB{}
+
+	if have := NewStore(primary).reader().Handle().DB(); have != primary {
+		t.Error("store without replica doesn't read from the primary")
+	}
+
+	s := NewStore(primary).WithReadReplica(replica)
+	if have := s.reader().Handle().DB(); have != replica {
+		t.Error("store with replica doesn't read from the replica")
+	}
+
+	tx := &sql.Tx{}
+	if have := s.With(NewStore(tx)).reader().Handle().DB(); have != tx {
+		t.Error("store in transaction doesn't read from the transaction")
+	}
+}
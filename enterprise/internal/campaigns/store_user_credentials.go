@@ -0,0 +1,185 @@
+package campaigns
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// userCredentialColumns are used by the user credential related Store methods
+// to query user credentials.
+var userCredentialColumns = []*sqlf.Query{
+	sqlf.Sprintf("user_credentials.id"),
+	sqlf.Sprintf("user_credentials.user_id"),
+	sqlf.Sprintf("user_credentials.external_service_type"),
+	sqlf.Sprintf("user_credentials.external_service_id"),
+	sqlf.Sprintf("user_credentials.username"),
+	sqlf.Sprintf("user_credentials.token"),
+	sqlf.Sprintf("user_credentials.created_at"),
+	sqlf.Sprintf("user_credentials.updated_at"),
+}
+
+// UpsertUserCredential creates the given UserCredential or replaces the
+// username and token of the existing credential of its user for its code
+// host.
+func (s *Store) UpsertUserCredential(ctx context.Context, c *campaigns.UserCredential) error {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = s.now()
+	}
+	c.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		upsertUserCredentialQueryFmtstr,
+		c.UserID,
+		c.ExternalServiceType,
+		c.ExternalServiceID,
+		nullStringColumn(c.Username),
+		c.Token,
+		c.CreatedAt,
+		c.UpdatedAt,
+		sqlf.Join(userCredentialColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanUserCredential(c, sc) })
+}
+
+var upsertUserCredentialQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_user_credentials.go:UpsertUserCredential
+INSERT INTO user_credentials (user_id, external_service_type, external_service_id, username, token, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (user_id, external_service_type, external_service_id) DO UPDATE SET
+  username = excluded.username,
+  token = excluded.token,
+  updated_at = excluded.updated_at
+RETURNING %s
+`
+
+// DeleteUserCredential deletes the UserCredential with the given ID.
+func (s *Store) DeleteUserCredential(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteUserCredentialQueryFmtstr, id))
+}
+
+var deleteUserCredentialQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_user_credentials.go:DeleteUserCredential
+DELETE FROM user_credentials WHERE id = %s
+`
+
+// GetUserCredentialOpts captures the query options needed for getting a
+// UserCredential. Either the ID or the user and code host need to be set.
+type GetUserCredentialOpts struct {
+	ID int64
+
+	UserID              int32
+	ExternalServiceType string
+	ExternalServiceID   string
+}
+
+// GetUserCredential gets a user credential matching the given options.
+func (s *Store) GetUserCredential(ctx context.Context, opts GetUserCredentialOpts) (*campaigns.UserCredential, error) {
+	q := getUserCredentialQuery(&opts)
+
+	var c campaigns.UserCredential
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanUserCredential(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getUserCredentialQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_user_credentials.go:GetUserCredential
+SELECT %s FROM user_credentials
+WHERE %s
+LIMIT 1
+`
+
+func getUserCredentialQuery(opts *GetUserCredentialOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("user_credentials.id = %s", opts.ID))
+	}
+
+	if opts.UserID != 0 {
+		preds = append(preds, sqlf.Sprintf("user_credentials.user_id = %s", opts.UserID))
+	}
+
+	if opts.ExternalServiceType != "" {
+		preds = append(preds, sqlf.Sprintf("user_credentials.external_service_type = %s", opts.ExternalServiceType))
+	}
+
+	if opts.ExternalServiceID != "" {
+		preds = append(preds, sqlf.Sprintf("user_credentials.external_service_id = %s", opts.ExternalServiceID))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		getUserCredentialQueryFmtstr,
+		sqlf.Join(userCredentialColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListUserCredentialsOpts captures the query options needed for listing user
+// credentials.
+type ListUserCredentialsOpts struct {
+	UserID int32
+}
+
+// ListUserCredentials lists the UserCredentials with the given filters,
+// ordered by ID.
+func (s *Store) ListUserCredentials(ctx context.Context, opts ListUserCredentialsOpts) (cs []*campaigns.UserCredential, err error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.UserID != 0 {
+		preds = append(preds, sqlf.Sprintf("user_credentials.user_id = %s", opts.UserID))
+	}
+
+	q := sqlf.Sprintf(
+		listUserCredentialsQueryFmtstr,
+		sqlf.Join(userCredentialColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var c campaigns.UserCredential
+		if err := scanUserCredential(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var listUserCredentialsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_user_credentials.go:ListUserCredentials
+SELECT %s FROM user_credentials
+WHERE %s
+ORDER BY user_credentials.id ASC
+`
+
+func scanUserCredential(c *campaigns.UserCredential, sc scanner) error {
+	err := sc.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.ExternalServiceType,
+		&c.ExternalServiceID,
+		&dbutil.NullString{S: &c.Username},
+		&c.Token,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	return errors.Wrap(err, "scanning user credential")
+}
@@ -0,0 +1,141 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreUserCredentials(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	// Foreign key constraints are deferred, so the users don't need to exist.
+	userID := int32(4242)
+
+	credentials := []*cmpgn.UserCredential{
+		{
+			UserID:              userID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalServiceID:   "https://github.com/",
+			Token:               "github-token",
+		},
+		{
+			UserID:              userID,
+			ExternalServiceType: extsvc.TypeBitbucketServer,
+			ExternalServiceID:   "https://bitbucket.sgdev.org/",
+			Username:            "alice",
+			Token:               "bbs-token",
+		},
+		{
+			UserID:              userID + 1,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalServiceID:   "https://github.com/",
+			Token:               "other-token",
+		},
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		for _, c := range credentials {
+			if err := s.UpsertUserCredential(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			if c.ID == 0 {
+				t.Fatal("credential ID should not be zero")
+			}
+			if have, want := c.CreatedAt, clock.now(); !have.Equal(want) {
+				t.Fatalf("wrong CreatedAt. want=%s, have=%s", want, have)
+			}
+		}
+
+		// Upserting a credential for the same user and code host replaces
+		// the existing one.
+		clock.add(time.Minute)
+		updated := &cmpgn.UserCredential{
+			UserID:              userID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalServiceID:   "https://github.com/",
+			Token:               "new-github-token",
+		}
+		if err := s.UpsertUserCredential(ctx, updated); err != nil {
+			t.Fatal(err)
+		}
+
+		want := credentials[0].Clone()
+		want.Token = "new-github-token"
+		want.UpdatedAt = clock.now()
+		if diff := cmp.Diff(want, updated); diff != "" {
+			t.Fatal(diff)
+		}
+		credentials[0] = updated
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ByID", func(t *testing.T) {
+			for _, c := range credentials {
+				have, err := s.GetUserCredential(ctx, GetUserCredentialOpts{ID: c.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(c, have); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+
+		t.Run("ByUserAndCodeHost", func(t *testing.T) {
+			for _, c := range credentials {
+				have, err := s.GetUserCredential(ctx, GetUserCredentialOpts{
+					UserID:              c.UserID,
+					ExternalServiceType: c.ExternalServiceType,
+					ExternalServiceID:   c.ExternalServiceID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(c, have); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetUserCredential(ctx, GetUserCredentialOpts{
+				UserID:              userID,
+				ExternalServiceType: extsvc.TypeGitLab,
+				ExternalServiceID:   "https://gitlab.com/",
+			})
+			if err != ErrNoResults {
+				t.Fatalf("unexpected error: want=%q, have=%q", ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListUserCredentials(ctx, ListUserCredentialsOpts{UserID: userID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(credentials[:2], have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, c := range credentials {
+			if err := s.DeleteUserCredential(ctx, c.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListUserCredentials(ctx, ListUserCredentialsOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("credentials not deleted: %+v", have)
+		}
+	})
+}
@@ -4,10 +4,12 @@ import (
 	"container/heap"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 // SyncRegistry manages a ChangesetSyncer per code host
@@ -26,6 +29,10 @@ type SyncRegistry struct {
 	RepoStore   RepoStore
 	HTTPFactory *httpcli.Factory
 
+	// Locker is used to make sure that every code host is only synced by a
+	// single replica. If nil, all code hosts are synced.
+	Locker *Locker
+
 	// Used to receive high priority sync requests
 	priorityNotify chan []int64
 
@@ -41,12 +48,14 @@ type RepoStore interface {
 
 // NewSyncRegistry creates a new sync registry which starts a syncer for each code host and will update them
 // when external services are changed, added or removed.
-func NewSyncRegistry(ctx context.Context, store SyncStore, repoStore RepoStore, cf *httpcli.Factory) *SyncRegistry {
+// If locker is not nil, a syncer only runs while its replica holds the lock of the code host.
+func NewSyncRegistry(ctx context.Context, store SyncStore, repoStore RepoStore, cf *httpcli.Factory, locker *Locker) *SyncRegistry {
 	r := &SyncRegistry{
 		Ctx:            ctx,
 		SyncStore:      store,
 		RepoStore:      repoStore,
 		HTTPFactory:    cf,
+		Locker:         locker,
 		priorityNotify: make(chan []int64, 500),
 		syncers:        make(map[string]*ChangesetSyncer),
 	}
@@ -118,7 +127,10 @@ func (s *SyncRegistry) Add(extServiceID int64) {
 
 	s.syncers[normalised] = syncer
 
-	go syncer.Run(ctx)
+	// Changesets of the code host are only synced while this replica holds
+	// the code host's shard. Priority syncs requested in the meantime stay
+	// buffered until it does.
+	go s.Locker.DoForCodeHost(ctx, normalised, syncer.Run)
 }
 
 // handlePriorityItems fetches changesets in the priority queue from the db and passes them
@@ -224,12 +236,18 @@ type ChangesetSyncer struct {
 	// NOTE: It involves a DB query but no communication with code hosts.
 	scheduleInterval time.Duration
 
+	// stateSyncInterval determines how often the state of all open
+	// changesets is refreshed by a state-only sync. Defaults to
+	// stateSyncInterval.
+	stateSyncInterval time.Duration
+
 	queue          *changesetPriorityQueue
 	priorityNotify chan []int64
 
 	// Replaceable for testing
-	syncFunc func(ctx context.Context, id int64) error
-	clock    func() time.Time
+	syncFunc      func(ctx context.Context, id int64) error
+	stateSyncFunc func(ctx context.Context) ([]int64, error)
+	clock         func() time.Time
 
 	// cancel should be called to stop this syncer
 	cancel context.CancelFunc
@@ -242,6 +260,8 @@ var syncerMetrics = struct {
 	computeScheduleDuration *prometheus.HistogramVec
 	scheduleSize            *prometheus.GaugeVec
 	behindSchedule          *prometheus.GaugeVec
+	stateSyncDuration       *prometheus.HistogramVec
+	stateChanges            *prometheus.CounterVec
 }{}
 
 func init() {
@@ -271,6 +291,15 @@ func init() {
 		Name: "src_repoupdater_changeset_syncer_behind_schedule",
 		Help: "The number of changesets behind schedule",
 	}, []string{"codehost"})
+	syncerMetrics.stateSyncDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_repoupdater_changeset_syncer_state_sync_duration_seconds",
+		Help:    "Time spent syncing the state of open changesets",
+		Buckets: []float64{1, 2, 5, 10, 30, 60, 120},
+	}, []string{"codehost", "success"})
+	syncerMetrics.stateChanges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_repoupdater_changeset_syncer_state_changes",
+		Help: "Total number of changeset state changes detected by state syncs",
+	}, []string{"codehost"})
 }
 
 type SyncStore interface {
@@ -278,26 +307,39 @@ type SyncStore interface {
 	GetChangeset(context.Context, GetChangesetOpts) (*campaigns.Changeset, error)
 	ListChangesets(context.Context, ListChangesetsOpts) (campaigns.Changesets, int64, error)
 	UpdateChangeset(ctx context.Context, cs *campaigns.Changeset) error
+	SetChangesetSyncErrorMessage(ctx context.Context, id int64, msg *string) error
 	UpsertChangesetEvents(ctx context.Context, cs ...*campaigns.ChangesetEvent) error
 	Transact(context.Context) (*Store, error)
 }
 
 // Run will start the process of changeset syncing. It is long running
-// and is expected to be launched once at startup.
+// and runs until the given context is canceled. It may be started again
+// afterwards, for example when the replica reacquires the code host's lock.
 func (s *ChangesetSyncer) Run(ctx context.Context) {
 	scheduleInterval := s.scheduleInterval
 	if scheduleInterval == 0 {
 		scheduleInterval = 2 * time.Minute
 	}
+	stateSyncInterval := s.stateSyncInterval
+	if stateSyncInterval == 0 {
+		stateSyncInterval = defaultStateSyncInterval
+	}
 	if s.syncFunc == nil {
 		s.syncFunc = s.SyncChangeset
 	}
+	if s.stateSyncFunc == nil {
+		s.stateSyncFunc = s.SyncChangesetStates
+	}
 	if s.clock == nil {
 		s.clock = time.Now
 	}
 	s.queue = newChangesetPriorityQueue()
 	// How often to refresh the schedule
 	scheduleTicker := time.NewTicker(scheduleInterval)
+	defer scheduleTicker.Stop()
+	// How often to refresh the state of open changesets
+	stateSyncTicker := time.NewTicker(stateSyncInterval)
+	defer stateSyncTicker.Stop()
 
 	// Get initial schedule
 	if sched, err := s.computeSchedule(ctx); err != nil {
@@ -359,6 +401,21 @@ func (s *ChangesetSyncer) Run(ctx context.Context) {
 				}
 			}
 			syncerMetrics.behindSchedule.WithLabelValues(s.codeHostURL).Set(float64(behindSchedule))
+		case <-stateSyncTicker.C:
+			if timer != nil {
+				timer.Stop()
+			}
+			start := time.Now()
+			changed, err := s.stateSyncFunc(ctx)
+			labelValues := []string{s.codeHostURL, strconv.FormatBool(err == nil)}
+			syncerMetrics.stateSyncDuration.WithLabelValues(labelValues...).Observe(time.Since(start).Seconds())
+			if err != nil {
+				log15.Error("Syncing changeset states", "err", err)
+			}
+			// Changesets whose state changed are fully synced right away,
+			// even if the state of others couldn't be loaded.
+			syncerMetrics.stateChanges.WithLabelValues(s.codeHostURL).Add(float64(len(changed)))
+			s.prioritize(changed)
 		case <-timerChan:
 			start := time.Now()
 			err := s.syncFunc(ctx, next.changesetID)
@@ -378,32 +435,134 @@ func (s *ChangesetSyncer) Run(ctx context.Context) {
 			if timer != nil {
 				timer.Stop()
 			}
-			for _, id := range ids {
-				item, ok := s.queue.Get(id)
-				if !ok {
-					// Item has been recently synced and removed or we have an invalid id
-					// We have no way of telling the difference without making a DB call so
-					// add a new item anyway which will just lead to a harmless error later
-					item = scheduledSync{
-						changesetID: id,
-						nextSync:    time.Time{},
-					}
-				}
-				item.priority = priorityHigh
-				s.queue.Upsert(item)
-				syncerMetrics.scheduleSize.WithLabelValues(s.codeHostURL).Inc()
+			s.prioritize(ids)
+		}
+	}
+}
+
+// prioritize adds the changesets with the given IDs to the front of the
+// queue, so that they are synced as soon as possible. It must only be called
+// from the loop in Run.
+func (s *ChangesetSyncer) prioritize(ids []int64) {
+	for _, id := range ids {
+		item, ok := s.queue.Get(id)
+		if !ok {
+			// Item has been recently synced and removed or we have an invalid id
+			// We have no way of telling the difference without making a DB call so
+			// add a new item anyway which will just lead to a harmless error later
+			item = scheduledSync{
+				changesetID: id,
+				nextSync:    time.Time{},
 			}
-			syncerMetrics.priorityQueued.WithLabelValues(s.codeHostURL).Add(float64(len(ids)))
 		}
+		item.priority = priorityHigh
+		s.queue.Upsert(item)
+		syncerMetrics.scheduleSize.WithLabelValues(s.codeHostURL).Inc()
 	}
+	syncerMetrics.priorityQueued.WithLabelValues(s.codeHostURL).Add(float64(len(ids)))
 }
 
+// defaultStateSyncInterval is how often the state of all open changesets is
+// refreshed by a state-only sync, independent of their full syncs.
+const defaultStateSyncInterval = 5 * time.Minute
+
 var (
 	minSyncDelay = 2 * time.Minute
 	maxSyncDelay = 8 * time.Hour
+
+	// Changesets with ChangesetSyncPriorityActive are synced at least every
+	// activeMaxSyncDelay.
+	activeMaxSyncDelay = 1 * time.Hour
+	// Changesets with ChangesetSyncPriorityDormant are synced at most every
+	// dormantMinSyncDelay and at least every dormantMaxSyncDelay.
+	dormantMinSyncDelay = 1 * time.Hour
+	dormantMaxSyncDelay = 3 * 24 * time.Hour
+
+	// webhookSafetyNetSyncDelay is the delay between two syncs of changesets
+	// on code hosts with webhooks configured, unless their priority allows
+	// an even longer delay. Those changesets are kept up to date by webhooks
+	// and only polled in case webhooks were missed.
+	webhookSafetyNetSyncDelay = 24 * time.Hour
 )
 
-// NextSync computes the time we want the next sync to happen.
+var (
+	// activeCampaignWindow is how long after a campaign was last viewed or
+	// applied its changesets get ChangesetSyncPriorityActive.
+	activeCampaignWindow = 24 * time.Hour
+	// dormantCampaignWindow is how long after the campaigns of a closed or
+	// merged changeset were last viewed or applied it gets
+	// ChangesetSyncPriorityDormant.
+	dormantCampaignWindow = 14 * 24 * time.Hour
+)
+
+// syncDelayBounds returns the minimum and maximum delay between two syncs of
+// a changeset with the given sync data. The sync intervals configured for the
+// code host replace the default bounds, which are narrowed for active and
+// widened for dormant changesets.
+func syncDelayBounds(h campaigns.ChangesetSyncData) (min, max time.Duration) {
+	min, max = minSyncDelay, maxSyncDelay
+	if h.MinSyncInterval > 0 {
+		min = h.MinSyncInterval
+	}
+	if h.MaxSyncInterval > 0 {
+		max = h.MaxSyncInterval
+	}
+
+	switch h.SyncPriority {
+	case campaigns.ChangesetSyncPriorityActive:
+		if max > activeMaxSyncDelay {
+			max = activeMaxSyncDelay
+		}
+	case campaigns.ChangesetSyncPriorityDormant:
+		min = maxDuration(min, dormantMinSyncDelay)
+		max = maxDuration(max, dormantMaxSyncDelay)
+	}
+
+	return min, maxDuration(min, max)
+}
+
+// syncIntervals returns the campaigns sync intervals configured in the given
+// external service config, or zero durations if none are configured.
+func syncIntervals(cfg interface{}) (min, max time.Duration, err error) {
+	var rawMin, rawMax string
+	switch c := cfg.(type) {
+	case *schema.GitHubConnection:
+		if c.CampaignsSyncIntervals != nil {
+			rawMin, rawMax = c.CampaignsSyncIntervals.Min, c.CampaignsSyncIntervals.Max
+		}
+	case *schema.GitLabConnection:
+		if c.CampaignsSyncIntervals != nil {
+			rawMin, rawMax = c.CampaignsSyncIntervals.Min, c.CampaignsSyncIntervals.Max
+		}
+	case *schema.BitbucketServerConnection:
+		if c.CampaignsSyncIntervals != nil {
+			rawMin, rawMax = c.CampaignsSyncIntervals.Min, c.CampaignsSyncIntervals.Max
+		}
+	case *schema.BitbucketCloudConnection:
+		if c.CampaignsSyncIntervals != nil {
+			rawMin, rawMax = c.CampaignsSyncIntervals.Min, c.CampaignsSyncIntervals.Max
+		}
+	}
+
+	if rawMin != "" {
+		if min, err = time.ParseDuration(rawMin); err != nil {
+			return 0, 0, errors.Wrap(err, "parsing min")
+		}
+	}
+	if rawMax != "" {
+		if max, err = time.ParseDuration(rawMax); err != nil {
+			return 0, 0, errors.Wrap(err, "parsing max")
+		}
+	}
+	return min, max, nil
+}
+
+// NextSync computes the time we want the next sync to happen. The delay since
+// the last sync grows with the time since the changeset last changed, within
+// the bounds given by its sync priority and the sync intervals configured for
+// its code host. Changesets on code hosts with
+// webhooks configured are only synced shortly after a webhook arrived and
+// otherwise by a slow safety-net pass.
 func NextSync(clock func() time.Time, h campaigns.ChangesetSyncData) time.Time {
 	lastSync := h.UpdatedAt
 
@@ -434,11 +593,15 @@ func NextSync(clock func() time.Time, h campaigns.ChangesetSyncData) time.Time {
 		return lastChange.Add(minSyncDelay)
 	}
 
-	if diff > maxSyncDelay {
-		diff = maxSyncDelay
+	minDelay, maxDelay := syncDelayBounds(h)
+	if h.WebhooksConfigured {
+		return lastSync.Add(maxDuration(maxDelay, webhookSafetyNetSyncDelay))
 	}
-	if diff < minSyncDelay {
-		diff = minSyncDelay
+	if diff > maxDelay {
+		diff = maxDelay
+	}
+	if diff < minDelay {
+		diff = minDelay
 	}
 	return lastSync.Add(diff)
 }
@@ -450,6 +613,13 @@ func maxTime(a, b time.Time) time.Time {
 	return b
 }
 
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func absDuration(d time.Duration) time.Duration {
 	if d >= 0 {
 		return d
@@ -511,7 +681,87 @@ func (s *ChangesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	return syncChangesets(ctx, s.ReposStore, s.SyncStore, s.HTTPFactory, cs)
+
+	syncErr := syncChangesets(ctx, s.ReposStore, s.SyncStore, s.HTTPFactory, cs)
+
+	// Record the outcome of the sync, so that it can be shown to users.
+	var msg *string
+	if syncErr != nil {
+		m := syncErr.Error()
+		msg = &m
+	}
+	if err := s.SyncStore.SetChangesetSyncErrorMessage(ctx, id, msg); err != nil {
+		log15.Error("Recording changeset sync error", "id", id, "err", err)
+	}
+
+	return syncErr
+}
+
+// SyncChangesetStates loads just the external state of all open changesets of
+// the code host, which is much cheaper than fully syncing them, and returns
+// the IDs of the changesets whose state changed on the code host, for example
+// because they were merged, closed or deleted there. Those need to be fully
+// synced to record the change.
+//
+// Only the changesets of code hosts whose ChangesetSource implements
+// repos.ChangesetStateSource are checked; the others are only refreshed by
+// full syncs.
+func (s *ChangesetSyncer) SyncChangesetStates(ctx context.Context) ([]int64, error) {
+	open := campaigns.ChangesetExternalStateOpen
+	allSyncData, err := s.SyncStore.ListChangesetSyncData(ctx, ListChangesetSyncDataOpts{ExternalState: &open})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changeset sync data")
+	}
+
+	syncData := filterSyncData(s.codeHostURL, allSyncData)
+	if len(syncData) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(syncData))
+	for i, d := range syncData {
+		ids[i] = d.ChangesetID
+	}
+	cs, _, err := s.SyncStore.ListChangesets(ctx, ListChangesetsOpts{IDs: ids, Limit: -1})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	bySource, err := groupChangesetsBySource(ctx, s.ReposStore, s.HTTPFactory, nil, cs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return changedChangesetStates(ctx, bySource)
+}
+
+// changedChangesetStates loads the external state of the given changesets
+// with the given ChangesetSources, if they implement
+// repos.ChangesetStateSource, and returns the IDs of the changesets whose
+// state differs from the one in the database. The IDs of changed changesets
+// are also returned if the states of other sources couldn't be loaded.
+func changedChangesetStates(ctx context.Context, bySource []*SourceChangesets) (changed []int64, err error) {
+	for _, s := range bySource {
+		source, ok := s.ChangesetSource.(repos.ChangesetStateSource)
+		if !ok || len(s.Changesets) == 0 {
+			continue
+		}
+
+		states, loadErr := source.LoadChangesetStates(ctx, s.Changesets...)
+		if loadErr != nil {
+			err = multierror.Append(err, errors.Wrap(loadErr, "loading changeset states"))
+			continue
+		}
+
+		for _, c := range s.Changesets {
+			if state, ok := states[c.Changeset.ID]; ok && state != c.Changeset.ExternalState {
+				changed = append(changed, c.Changeset.ID)
+			}
+		}
+	}
+
+	sort.Slice(changed, func(i, j int) bool { return changed[i] < changed[j] })
+	return changed, err
 }
 
 // MockSyncChangesets can be set to mock SyncChangesets.
@@ -549,6 +799,14 @@ func syncChangesetsWithSources(ctx context.Context, store SyncStore, bySource []
 	var (
 		events []*campaigns.ChangesetEvent
 		cs     []*campaigns.Changeset
+		// Changesets whose head changed on the code host since the last sync.
+		headChanged []*campaigns.Changeset
+		// Changesets that were merged since the last sync, and their events.
+		merged       []*campaigns.Changeset
+		mergedEvents = map[int64]ChangesetEvents{}
+		// The previous external state of the changesets whose state changed
+		// since the last sync.
+		stateChanged = map[int64]campaigns.ChangesetExternalState{}
 	)
 
 	for _, s := range bySource {
@@ -574,22 +832,30 @@ func syncChangesetsWithSources(ctx context.Context, store SyncStore, bySource []
 				c.Changeset.SetDeleted()
 			}
 
+			oldHead := c.Changeset.SyncState.HeadRefOid
+			oldState := c.Changeset.ExternalState
+
 			csEvents := c.Events()
 			SetDerivedState(ctx, c.Changeset, csEvents)
 
-			// Deduplicate events per changeset based on their Kind+Key to avoid
-			// conflicts when inserting into database.
-			uniqueEvents := make(map[string]struct{}, len(csEvents))
-			for _, e := range csEvents {
-				k := string(e.Kind) + e.Key
-				if _, ok := uniqueEvents[k]; ok {
-					log15.Info("dropping duplicate changeset event", "changeset_id", e.ChangesetID, "kind", e.Kind, "key", e.Key)
-					continue
-				}
-				uniqueEvents[k] = struct{}{}
-				events = append(events, e)
+			if newHead := c.Changeset.SyncState.HeadRefOid; oldHead != "" && newHead != oldHead {
+				headChanged = append(headChanged, c.Changeset)
 			}
 
+			if mergedChangeset(oldState, c.Changeset) {
+				merged = append(merged, c.Changeset)
+				mergedEvents[c.Changeset.ID] = csEvents
+			}
+
+			if changesetStateChanged(oldState, c.Changeset) {
+				stateChanged[c.Changeset.ID] = oldState
+			}
+
+			// The events of all changesets are upserted at once, with
+			// duplicates being dropped by the Store. Compacted events
+			// aren't restored.
+			events = append(events, withoutCompactedEvents(c.Changeset, csEvents, time.Now())...)
+
 			cs = append(cs, c.Changeset)
 		}
 	}
@@ -604,6 +870,32 @@ func syncChangesetsWithSources(ctx context.Context, store SyncStore, bySource []
 		if err = tx.UpdateChangeset(ctx, c); err != nil {
 			return err
 		}
+
+		if oldState, ok := stateChanged[c.ID]; ok {
+			err = enqueueChangesetWebhookEvent(ctx, tx, campaigns.CampaignWebhookEventChangesetStateChanged, c, oldState)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The diff stat of changesets that were force-pushed or received new
+	// commits is recomputed by the diff stat worker.
+	for _, c := range headChanged {
+		err = tx.EnqueueChangesetDiffStatJob(ctx, &campaigns.ChangesetDiffStatJob{
+			ChangesetID: c.ID,
+			BaseRefOid:  c.SyncState.BaseRefOid,
+			HeadRefOid:  c.SyncState.HeadRefOid,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range merged {
+		if err = enqueueMergeCommitIndex(ctx, tx, c, mergedEvents[c.ID]); err != nil {
+			return err
+		}
 	}
 
 	return tx.UpsertChangesetEvents(ctx, events...)
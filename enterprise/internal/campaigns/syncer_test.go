@@ -3,6 +3,7 @@ package campaigns
 import (
 	"container/heap"
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -10,9 +11,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestNextSync(t *testing.T) {
@@ -77,6 +80,111 @@ func TestNextSync(t *testing.T) {
 			h:    campaigns.ChangesetSyncData{},
 			want: clock(),
 		},
+		{
+			name: "Active diff max is capped",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-2 * time.Hour),
+				SyncPriority:      campaigns.ChangesetSyncPriorityActive,
+			},
+			want: clock().Add(activeMaxSyncDelay),
+		},
+		{
+			name: "Dormant diff min is capped",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-10 * time.Minute),
+				SyncPriority:      campaigns.ChangesetSyncPriorityDormant,
+			},
+			want: clock().Add(dormantMinSyncDelay),
+		},
+		{
+			name: "Dormant diff max is capped",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-2 * dormantMaxSyncDelay),
+				SyncPriority:      campaigns.ChangesetSyncPriorityDormant,
+			},
+			want: clock().Add(dormantMaxSyncDelay),
+		},
+		{
+			name: "Dormant event arrives after sync",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-1 * maxSyncDelay / 2),
+				LatestEvent:       clock().Add(10 * time.Minute),
+				SyncPriority:      campaigns.ChangesetSyncPriorityDormant,
+			},
+			want: clock().Add(10 * time.Minute).Add(minSyncDelay),
+		},
+		{
+			name: "Configured min interval",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-1 * minSyncDelay / 2),
+				MinSyncInterval:   30 * time.Minute,
+			},
+			want: clock().Add(30 * time.Minute),
+		},
+		{
+			name: "Configured max interval",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-2 * maxSyncDelay),
+				MaxSyncInterval:   24 * time.Hour,
+			},
+			want: clock().Add(16 * time.Hour),
+		},
+		{
+			name: "Configured max interval active",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-2 * maxSyncDelay),
+				SyncPriority:      campaigns.ChangesetSyncPriorityActive,
+				MaxSyncInterval:   24 * time.Hour,
+			},
+			want: clock().Add(activeMaxSyncDelay),
+		},
+		{
+			name: "Configured min interval above active max",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-2 * maxSyncDelay),
+				SyncPriority:      campaigns.ChangesetSyncPriorityActive,
+				MinSyncInterval:   2 * time.Hour,
+			},
+			want: clock().Add(2 * time.Hour),
+		},
+		{
+			name: "Webhooks configured",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:          clock(),
+				ExternalUpdatedAt:  clock().Add(-10 * time.Minute),
+				SyncPriority:       campaigns.ChangesetSyncPriorityActive,
+				WebhooksConfigured: true,
+			},
+			want: clock().Add(webhookSafetyNetSyncDelay),
+		},
+		{
+			name: "Webhooks configured dormant",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:          clock(),
+				ExternalUpdatedAt:  clock().Add(-10 * time.Minute),
+				SyncPriority:       campaigns.ChangesetSyncPriorityDormant,
+				WebhooksConfigured: true,
+			},
+			want: clock().Add(dormantMaxSyncDelay),
+		},
+		{
+			name: "Webhooks configured event arrives after sync",
+			h: campaigns.ChangesetSyncData{
+				UpdatedAt:          clock(),
+				ExternalUpdatedAt:  clock().Add(-1 * maxSyncDelay / 2),
+				LatestEvent:        clock().Add(10 * time.Minute),
+				WebhooksConfigured: true,
+			},
+			want: clock().Add(10 * time.Minute).Add(minSyncDelay),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -88,6 +196,45 @@ func TestNextSync(t *testing.T) {
 	}
 }
 
+func TestSyncIntervals(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cfg     interface{}
+		wantMin time.Duration
+		wantMax time.Duration
+		wantErr bool
+	}{
+		{name: "not configured", cfg: &schema.GitHubConnection{}},
+		{
+			name:    "GitHub",
+			cfg:     &schema.GitHubConnection{CampaignsSyncIntervals: &schema.GitHubCampaignsSyncIntervals{Min: "10m", Max: "24h"}},
+			wantMin: 10 * time.Minute,
+			wantMax: 24 * time.Hour,
+		},
+		{
+			name:    "GitLab only max",
+			cfg:     &schema.GitLabConnection{CampaignsSyncIntervals: &schema.GitLabCampaignsSyncIntervals{Max: "12h"}},
+			wantMax: 12 * time.Hour,
+		},
+		{
+			name:    "invalid duration",
+			cfg:     &schema.BitbucketServerConnection{CampaignsSyncIntervals: &schema.BitbucketServerCampaignsSyncIntervals{Min: "often"}},
+			wantErr: true,
+		},
+		{name: "unsupported code host", cfg: &schema.GitoliteConnection{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			min, max, err := syncIntervals(tc.cfg)
+			if have, want := err != nil, tc.wantErr; have != want {
+				t.Fatalf("wrong error. want error=%t, have=%v", want, err)
+			}
+			if min != tc.wantMin || max != tc.wantMax {
+				t.Fatalf("wrong intervals. want=(%s, %s), have=(%s, %s)", tc.wantMin, tc.wantMax, min, max)
+			}
+		})
+	}
+}
+
 func TestChangesetPriorityQueue(t *testing.T) {
 	assertOrder := func(t *testing.T, q *changesetPriorityQueue, expected []int64) {
 		t.Helper()
@@ -385,6 +532,128 @@ func TestSyncerRun(t *testing.T) {
 		}
 	})
 
+	t.Run("State changed", func(t *testing.T) {
+		// Empty schedule but then the state sync detects a change
+		ctx, cancel := context.WithCancel(context.Background())
+		store := MockSyncStore{
+			listChangesets: mockListChangesets,
+			listChangesetSyncData: func(ctx context.Context, opts ListChangesetSyncDataOpts) ([]campaigns.ChangesetSyncData, error) {
+				return []campaigns.ChangesetSyncData{}, nil
+			},
+		}
+		var synced int64
+		syncFunc := func(ctx context.Context, id int64) error {
+			synced = id
+			cancel()
+			return nil
+		}
+		stateSyncFunc := func(ctx context.Context) ([]int64, error) {
+			return []int64{42}, nil
+		}
+		syncer := &ChangesetSyncer{
+			SyncStore:         store,
+			scheduleInterval:  10 * time.Minute,
+			stateSyncInterval: time.Millisecond,
+			syncFunc:          syncFunc,
+			stateSyncFunc:     stateSyncFunc,
+			priorityNotify:    make(chan []int64, 1),
+		}
+		go syncer.Run(ctx)
+		select {
+		case <-ctx.Done():
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Sync not called")
+		}
+		if synced != 42 {
+			t.Fatalf("wrong changeset synced. want=%d, have=%d", 42, synced)
+		}
+	})
+}
+
+func TestChangedChangesetStates(t *testing.T) {
+	ctx := context.Background()
+
+	changeset := func(id int64, state campaigns.ChangesetExternalState) *repos.Changeset {
+		return &repos.Changeset{Changeset: &campaigns.Changeset{ID: id, ExternalState: state}}
+	}
+
+	stateSource := &ct.FakeChangesetSource{
+		States: map[int64]campaigns.ChangesetExternalState{
+			1: campaigns.ChangesetExternalStateOpen,
+			2: campaigns.ChangesetExternalStateMerged,
+			3: campaigns.ChangesetExternalStateDeleted,
+		},
+	}
+	loadErr := errors.New("loading states failed")
+	failingSource := &ct.FakeChangesetSource{Err: loadErr}
+	// A source that can't load changeset states in bulk.
+	otherSource := &ct.FakeChangesetSource{}
+
+	bySource := []*SourceChangesets{
+		{
+			ChangesetSource: stateSource,
+			Changesets: []*repos.Changeset{
+				changeset(3, campaigns.ChangesetExternalStateOpen),
+				changeset(2, campaigns.ChangesetExternalStateOpen),
+				changeset(1, campaigns.ChangesetExternalStateOpen),
+				// Not returned by the source.
+				changeset(4, campaigns.ChangesetExternalStateOpen),
+			},
+		},
+		{
+			ChangesetSource: failingSource,
+			Changesets:      []*repos.Changeset{changeset(5, campaigns.ChangesetExternalStateOpen)},
+		},
+		{
+			ChangesetSource: struct{ repos.ChangesetSource }{otherSource},
+			Changesets:      []*repos.Changeset{changeset(6, campaigns.ChangesetExternalStateOpen)},
+		},
+	}
+
+	changed, err := changedChangesetStates(ctx, bySource)
+	if err == nil || !strings.Contains(err.Error(), loadErr.Error()) {
+		t.Fatalf("wrong error. want=%s, have=%v", loadErr, err)
+	}
+
+	if diff := cmp.Diff([]int64{2, 3}, changed); diff != "" {
+		t.Fatalf("wrong changed changesets (-want +have):\n%s", diff)
+	}
+
+	if otherSource.LoadChangesetStatesCalled {
+		t.Fatal("states loaded from source that doesn't support it")
+	}
+}
+
+func TestSyncChangesetRecordsSyncError(t *testing.T) {
+	var recorded []*string
+	syncStore := MockSyncStore{
+		getChangeset: func(ctx context.Context, opts GetChangesetOpts) (*campaigns.Changeset, error) {
+			return &campaigns.Changeset{ID: opts.ID, RepoID: 1}, nil
+		},
+		setSyncErrorMessage: func(ctx context.Context, id int64, msg *string) error {
+			recorded = append(recorded, msg)
+			return nil
+		},
+	}
+
+	listReposErr := errors.New("listing repos failed")
+	repoStore := MockRepoStore{
+		listRepos: func(ctx context.Context, args repos.StoreListReposArgs) ([]*repos.Repo, error) {
+			return nil, listReposErr
+		},
+	}
+
+	syncer := &ChangesetSyncer{SyncStore: syncStore, ReposStore: repoStore}
+	if err := syncer.SyncChangeset(context.Background(), 1); errors.Cause(err) != listReposErr {
+		t.Fatalf("wrong error. want=%s, have=%v", listReposErr, err)
+	}
+
+	if len(recorded) != 1 || recorded[0] == nil {
+		t.Fatalf("sync error not recorded: %v", recorded)
+	}
+	if !strings.Contains(*recorded[0], listReposErr.Error()) {
+		t.Fatalf("wrong sync error recorded: %q", *recorded[0])
+	}
 }
 
 func TestFilterSyncData(t *testing.T) {
@@ -473,7 +742,7 @@ func TestSyncRegistry(t *testing.T) {
 		},
 	}
 
-	r := NewSyncRegistry(ctx, syncStore, repoStore, nil)
+	r := NewSyncRegistry(ctx, syncStore, repoStore, nil, nil)
 
 	assertSyncerCount := func(want int) {
 		r.mu.Lock()
@@ -549,6 +818,7 @@ type MockSyncStore struct {
 	getChangeset          func(context.Context, GetChangesetOpts) (*campaigns.Changeset, error)
 	listChangesets        func(context.Context, ListChangesetsOpts) (campaigns.Changesets, int64, error)
 	updateChangeset       func(context.Context, *campaigns.Changeset) error
+	setSyncErrorMessage   func(context.Context, int64, *string) error
 	upsertChangesetEvents func(context.Context, ...*campaigns.ChangesetEvent) error
 	transact              func(context.Context) (*Store, error)
 }
@@ -569,6 +839,10 @@ func (m MockSyncStore) UpdateChangeset(ctx context.Context, c *campaigns.Changes
 	return m.updateChangeset(ctx, c)
 }
 
+func (m MockSyncStore) SetChangesetSyncErrorMessage(ctx context.Context, id int64, msg *string) error {
+	return m.setSyncErrorMessage(ctx, id, msg)
+}
+
 func (m MockSyncStore) UpsertChangesetEvents(ctx context.Context, cs ...*campaigns.ChangesetEvent) error {
 	return m.upsertChangesetEvents(ctx, cs...)
 }
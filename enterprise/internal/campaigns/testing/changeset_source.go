@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 )
 
@@ -20,6 +21,15 @@ type FakeChangesetSource struct {
 	ExternalServicesCalled bool
 	LoadChangesetsCalled   bool
 	CloseChangesetCalled   bool
+	MergeChangesetCalled   bool
+	EnsureForkCalled       bool
+	RequestReviewsCalled   bool
+	AssignChangesetCalled  bool
+	LabelChangesetCalled   bool
+	CreateCommentCalled    bool
+	CommitCheckStateCalled bool
+
+	LoadChangesetStatesCalled bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -34,6 +44,16 @@ type FakeChangesetSource struct {
 	// when CreateChangeset is called.
 	ChangesetExists bool
 
+	// The namespace and name of the fork returned by EnsureFork.
+	ForkNamespace string
+	ForkName      string
+
+	// The state returned by CommitCheckState.
+	CheckState campaigns.ChangesetCheckState
+
+	// The states returned by LoadChangesetStates, keyed by changeset ID.
+	States map[int64]campaigns.ChangesetExternalState
+
 	// error to be returned from every method
 	Err error
 
@@ -42,6 +62,26 @@ type FakeChangesetSource struct {
 
 	// LoadedChangesets contains the changesets that were passed to LoadChangesets
 	LoadedChangesets []*repos.Changeset
+
+	// MergedChangesets contains the changesets that were passed to MergeChangeset
+	MergedChangesets []*repos.Changeset
+
+	// Reviewers contains the reviewers that were passed to RequestReviews
+	Reviewers []string
+
+	// Assignees contains the assignees that were passed to AssignChangeset
+	Assignees []string
+
+	// Labels and RemovedLabels contain the labels that were passed to
+	// LabelChangeset
+	Labels        []string
+	RemovedLabels []string
+
+	// Milestone is the milestone that was passed to SetChangesetMilestone
+	Milestone string
+
+	// Comments contains the comment bodies that were passed to CreateComment
+	Comments []string
 }
 
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
@@ -112,6 +152,92 @@ func (s *FakeChangesetSource) CloseChangeset(ctx context.Context, c *repos.Chang
 	return nil
 }
 
+func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *repos.Changeset) error {
+	s.MergeChangesetCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+	s.MergedChangesets = append(s.MergedChangesets, c)
+	return nil
+}
+
+func (s *FakeChangesetSource) EnsureFork(ctx context.Context, r *repos.Repo) (string, string, error) {
+	s.EnsureForkCalled = true
+
+	if s.Err != nil {
+		return "", "", s.Err
+	}
+	return s.ForkNamespace, s.ForkName, nil
+}
+
+func (s *FakeChangesetSource) RequestReviews(ctx context.Context, c *repos.Changeset, reviewers []string) error {
+	s.RequestReviewsCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+	s.Reviewers = append(s.Reviewers, reviewers...)
+	return nil
+}
+
+func (s *FakeChangesetSource) AssignChangeset(ctx context.Context, c *repos.Changeset, assignees []string) error {
+	s.AssignChangesetCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+	s.Assignees = append(s.Assignees, assignees...)
+	return nil
+}
+
+func (s *FakeChangesetSource) LabelChangeset(ctx context.Context, c *repos.Changeset, labels, removed []string) error {
+	s.LabelChangesetCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+	s.Labels = append(s.Labels, labels...)
+	s.RemovedLabels = append(s.RemovedLabels, removed...)
+	return nil
+}
+
+func (s *FakeChangesetSource) SetChangesetMilestone(ctx context.Context, c *repos.Changeset, milestone string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.Milestone = milestone
+	return nil
+}
+
+func (s *FakeChangesetSource) CreateComment(ctx context.Context, c *repos.Changeset, body string) error {
+	s.CreateCommentCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+	s.Comments = append(s.Comments, body)
+	return nil
+}
+
+func (s *FakeChangesetSource) CommitCheckState(ctx context.Context, r *repos.Repo, ref string) (campaigns.ChangesetCheckState, error) {
+	s.CommitCheckStateCalled = true
+
+	if s.Err != nil {
+		return "", s.Err
+	}
+	return s.CheckState, nil
+}
+
+func (s *FakeChangesetSource) LoadChangesetStates(ctx context.Context, cs ...*repos.Changeset) (map[int64]campaigns.ChangesetExternalState, error) {
+	s.LoadChangesetStatesCalled = true
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	return s.States, nil
+}
+
 // FakeGitserverClient is a test implementation of the GitserverClient
 // interface required by ExecChangesetJob.
 type FakeGitserverClient struct {
@@ -119,9 +245,11 @@ type FakeGitserverClient struct {
 	ResponseErr error
 
 	CreateCommitFromPatchCalled bool
+	CreateCommitFromPatchReq    protocol.CreateCommitFromPatchRequest
 }
 
 func (f *FakeGitserverClient) CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
 	f.CreateCommitFromPatchCalled = true
+	f.CreateCommitFromPatchReq = req
 	return f.Response, f.ResponseErr
 }
@@ -0,0 +1,277 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// userCredentialKinds are the kinds of external services whose changesets
+// can be published with a UserCredential.
+var userCredentialKinds = []string{
+	extsvc.KindGitHub,
+	extsvc.KindGitLab,
+	extsvc.KindBitbucketServer,
+}
+
+// ErrUserCredentialKindNotSupported is returned by CreateUserCredential if
+// changesets on the given kind of code host can't be published with user
+// credentials.
+var ErrUserCredentialKindNotSupported = errors.New("credentials are only supported for GitHub, GitLab and Bitbucket Server")
+
+// ErrUserCredentialUsernameMissing is returned by CreateUserCredential if the
+// code host needs a username in addition to the token.
+var ErrUserCredentialUsernameMissing = errors.New("a username is required for Bitbucket Server credentials")
+
+// CreateUserCredentialOpts are the options for CreateUserCredential.
+type CreateUserCredentialOpts struct {
+	UserID int32
+
+	ExternalServiceKind string
+	ExternalServiceURL  string
+
+	Username string
+	Token    string
+}
+
+// CreateUserCredential validates the given token against the code host and
+// stores it as the credential of the user for the code host, replacing the
+// existing one.
+func (s *Service) CreateUserCredential(ctx context.Context, opts CreateUserCredentialOpts) (cred *campaigns.UserCredential, err error) {
+	traceTitle := fmt.Sprintf("user: %d, kind: %s, url: %s", opts.UserID, opts.ExternalServiceKind, opts.ExternalServiceURL)
+	tr, ctx := trace.New(ctx, "service.CreateUserCredential", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins and the user itself can create the
+	// credentials of a user.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, opts.UserID); err != nil {
+		return nil, err
+	}
+
+	if !userCredentialKindSupported(opts.ExternalServiceKind) {
+		return nil, ErrUserCredentialKindNotSupported
+	}
+	if opts.ExternalServiceKind == extsvc.KindBitbucketServer && opts.Username == "" {
+		return nil, ErrUserCredentialUsernameMissing
+	}
+
+	u, err := url.Parse(opts.ExternalServiceURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing code host URL")
+	}
+
+	cred = &campaigns.UserCredential{
+		UserID:              opts.UserID,
+		ExternalServiceType: extsvc.KindToType(opts.ExternalServiceKind),
+		ExternalServiceID:   extsvc.NormalizeBaseURL(u).String(),
+		Username:            opts.Username,
+		Token:               opts.Token,
+	}
+
+	rstore := repos.NewDBStore(s.store.DB(), sql.TxOptions{})
+	es, err := loadUserCredentialExternalService(ctx, rstore, cred.ExternalServiceType, cred.ExternalServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err = externalServiceWithUserCredential(es, cred)
+	if err != nil {
+		return nil, err
+	}
+
+	sourcer := s.sourcer
+	if sourcer == nil {
+		sourcer = repos.NewSourcer(s.cf)
+	}
+
+	sources, err := sourcer(es)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) != 1 {
+		return nil, errors.New("invalid number of sources for external service")
+	}
+	as, ok := sources[0].(repos.AuthenticatingSource)
+	if !ok {
+		return nil, ErrUserCredentialKindNotSupported
+	}
+	if err := as.ValidateAuthentication(ctx); err != nil {
+		return nil, errors.Wrap(err, "validating credential")
+	}
+
+	if err := s.store.UpsertUserCredential(ctx, cred); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
+
+// DeleteUserCredential deletes the UserCredential with the given ID.
+func (s *Service) DeleteUserCredential(ctx context.Context, id int64) (err error) {
+	traceTitle := fmt.Sprintf("credential: %d", id)
+	tr, ctx := trace.New(ctx, "service.DeleteUserCredential", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	cred, err := s.store.GetUserCredential(ctx, GetUserCredentialOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only site admins and the user itself can delete the
+	// credentials of a user.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, cred.UserID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteUserCredential(ctx, cred.ID)
+}
+
+// ListUserCredentials lists the UserCredentials of the user with the given ID.
+func (s *Service) ListUserCredentials(ctx context.Context, userID int32) (cs []*campaigns.UserCredential, err error) {
+	traceTitle := fmt.Sprintf("user: %d", userID)
+	tr, ctx := trace.New(ctx, "service.ListUserCredentials", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins and the user itself can list the
+	// credentials of a user.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.store.ListUserCredentials(ctx, ListUserCredentialsOpts{UserID: userID})
+}
+
+func userCredentialKindSupported(kind string) bool {
+	for _, k := range userCredentialKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// loadUserCredentialExternalService returns the first external service of
+// the code host with the given type and ID.
+func loadUserCredentialExternalService(ctx context.Context, rstore repos.Store, externalServiceType, externalServiceID string) (*repos.ExternalService, error) {
+	es, err := rstore.ListExternalServices(ctx, repos.StoreListExternalServicesArgs{
+		Kinds: userCredentialKinds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range es {
+		if extsvc.KindToType(e.Kind) != externalServiceType {
+			continue
+		}
+
+		cfg, err := e.Configuration()
+		if err != nil {
+			return nil, err
+		}
+
+		var rawURL string
+		switch c := cfg.(type) {
+		case *schema.GitHubConnection:
+			rawURL = c.Url
+		case *schema.GitLabConnection:
+			rawURL = c.Url
+		case *schema.BitbucketServerConnection:
+			rawURL = c.Url
+		}
+
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		if extsvc.NormalizeBaseURL(u).String() == externalServiceID {
+			return e, nil
+		}
+	}
+
+	return nil, errors.Errorf("no external service found for code host %q", externalServiceID)
+}
+
+// loadUserCredential returns the UserCredential that the given changeset is
+// published with: the credential of the user that last applied the campaign
+// owning the changeset, for the code host of the given repository. If the
+// changeset isn't owned by a campaign or the user has no credential for the
+// code host, it returns nil and the token of the external service is used.
+func loadUserCredential(ctx context.Context, tx *Store, ch *campaigns.Changeset, repo *repos.Repo) (*campaigns.UserCredential, error) {
+	if ch.OwnedByCampaignID == 0 {
+		return nil, nil
+	}
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: ch.OwnedByCampaignID, IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	// Without a user, GetUserCredential would return the credential of any
+	// user for the code host.
+	if campaign.LastApplierID == 0 {
+		return nil, nil
+	}
+
+	cred, err := tx.GetUserCredential(ctx, GetUserCredentialOpts{
+		UserID:              campaign.LastApplierID,
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalServiceID:   repo.ExternalRepo.ServiceID,
+	})
+	if err == ErrNoResults {
+		return nil, nil
+	}
+	return cred, err
+}
+
+// externalServiceWithUserCredential returns a copy of the given external
+// service whose configuration authenticates with the given UserCredential
+// instead of with its own token.
+func externalServiceWithUserCredential(es *repos.ExternalService, cred *campaigns.UserCredential) (*repos.ExternalService, error) {
+	config, err := jsonc.Edit(es.Config, cred.Token, "token")
+	if err != nil {
+		return nil, errors.Wrap(err, "setting token")
+	}
+
+	if cred.Username != "" {
+		config, err = jsonc.Edit(config, cred.Username, "username")
+		if err != nil {
+			return nil, errors.Wrap(err, "setting username")
+		}
+	}
+
+	clone := es.Clone()
+	clone.Config = config
+	return clone, nil
+}
+
+// userCredentialPushAuth returns the username and password with which
+// commits are pushed to the code host with the given UserCredential.
+func userCredentialPushAuth(cred *campaigns.UserCredential) (username, password string) {
+	switch cred.ExternalServiceType {
+	case extsvc.TypeGitHub:
+		return cred.Token, ""
+	case extsvc.TypeGitLab:
+		return "git", cred.Token
+	default:
+		return cred.Username, cred.Token
+	}
+}
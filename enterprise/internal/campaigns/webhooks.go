@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -80,6 +81,8 @@ func extractExternalServiceID(extSvc *repos.ExternalService) (string, error) {
 		serviceID = c.Url
 	case *schema.GitLabConnection:
 		serviceID = c.Url
+	case *schema.BitbucketCloudConnection:
+		serviceID = c.Url
 	}
 	if serviceID == "" {
 		return "", errors.New("could not determine service id")
@@ -93,6 +96,26 @@ func extractExternalServiceID(extSvc *repos.ExternalService) (string, error) {
 	return extsvc.NormalizeBaseURL(u).String(), nil
 }
 
+// webhooksConfigured returns whether the given external service config has
+// webhooks configured whose events campaigns receive.
+func webhooksConfigured(cfg interface{}) bool {
+	switch c := cfg.(type) {
+	case *schema.GitHubConnection:
+		for _, hook := range c.Webhooks {
+			if hook.Secret != "" {
+				return true
+			}
+		}
+	case *schema.BitbucketServerConnection:
+		return len(c.WebhookSecrets()) > 0
+	case *schema.GitLabConnection:
+		return len(c.Webhooks) > 0
+	case *schema.BitbucketCloudConnection:
+		return len(c.Webhooks) > 0
+	}
+	return false
+}
+
 type keyer interface {
 	Key() string
 }
@@ -171,11 +194,50 @@ func (h Webhook) upsertChangesetEvent(
 		ChangesetIDs: []int64{cs.ID},
 		Limit:        -1,
 	})
+	oldState := cs.ExternalState
 	SetDerivedState(ctx, cs, events)
 	if err := tx.UpdateChangeset(ctx, cs); err != nil {
 		return err
 	}
 
+	if changesetStateChanged(oldState, cs) {
+		err := enqueueChangesetWebhookEvent(ctx, tx, campaigns.CampaignWebhookEventChangesetStateChanged, cs, oldState)
+		if err != nil {
+			return err
+		}
+	}
+
+	if mergedChangeset(oldState, cs) {
+		return enqueueMergeCommitIndex(ctx, tx, cs, events)
+	}
+
+	return nil
+}
+
+// enqueueChangesetSync asks repo-updater to sync the changeset of the given PR
+// as soon as possible. It's used for webhook events that don't contain enough
+// information to create changeset events from.
+func (h Webhook) enqueueChangesetSync(ctx context.Context, externalServiceID string, pr PR) error {
+	repo, err := h.getRepoForPR(ctx, h.Store, pr, externalServiceID)
+	if err != nil {
+		log15.Debug("Webhook event could not be matched to repo", "err", err)
+		return nil
+	}
+
+	c, err := h.Store.GetChangeset(ctx, GetChangesetOpts{
+		RepoID:              repo.ID,
+		ExternalID:          strconv.FormatInt(pr.ID, 10),
+		ExternalServiceType: h.ServiceType,
+	})
+	if err == ErrNoResults {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "getting changeset")
+	}
+
+	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{c.ID}); err != nil {
+		return errors.Wrap(err, "enqueuing changeset sync")
+	}
 	return nil
 }
 
@@ -734,6 +796,9 @@ func (*GitHubWebhook) pullRequestReviewCommentEvent(e *gh.PullRequestReviewComme
 		comment.URL = c.GetURL()
 		comment.CreatedAt = c.GetCreatedAt()
 		comment.UpdatedAt = c.GetUpdatedAt()
+		comment.Path = c.GetPath()
+		comment.Position = c.GetPosition()
+		comment.ReplyTo.DatabaseID = c.GetInReplyTo()
 
 		if u := c.GetUser(); u != nil {
 			user.AvatarURL = u.GetAvatarURL()
@@ -758,6 +823,7 @@ func (*GitHubWebhook) commitStatusEvent(e *gh.StatusEvent) *github.CommitStatus
 		SHA:        e.GetSHA(),
 		State:      e.GetState(),
 		Context:    e.GetContext(),
+		TargetURL:  e.GetTargetURL(),
 		ReceivedAt: time.Now(),
 	}
 }
@@ -774,8 +840,10 @@ func (*GitHubWebhook) checkSuiteEvent(cs *gh.CheckSuite) *github.CheckSuite {
 func (*GitHubWebhook) checkRunEvent(cr *gh.CheckRun) *github.CheckRun {
 	return &github.CheckRun{
 		ID:         cr.GetNodeID(),
+		Name:       cr.GetName(),
 		Status:     cr.GetStatus(),
 		Conclusion: cr.GetConclusion(),
+		DetailsURL: cr.GetDetailsURL(),
 		ReceivedAt: time.Now(),
 	}
 }
@@ -810,7 +878,14 @@ func (h *BitbucketServerWebhook) ServeHTTP(w http.ResponseWriter, r *http.Reques
 			continue
 		}
 
-		err := h.upsertChangesetEvent(r.Context(), externalServiceID, pr, ev)
+		var err error
+		if ev == nil {
+			// Native webhook events that we can't create a changeset event
+			// from only tell us that the pull request changed, so we sync it.
+			err = h.enqueueChangesetSync(r.Context(), externalServiceID, pr)
+		} else {
+			err = h.upsertChangesetEvent(r.Context(), externalServiceID, pr, ev)
+		}
 		if err != nil {
 			m = multierror.Append(m, err)
 		}
@@ -859,12 +934,17 @@ func (h *BitbucketServerWebhook) parseEvent(r *http.Request) (interface{}, *repo
 			continue
 		}
 
-		if secret := con.WebhookSecret(); secret != "" {
+		// Both the plugin and the native webhooks sign their payloads the
+		// same way as GitHub, but with different secrets.
+		for _, secret := range con.WebhookSecrets() {
 			if err = gh.ValidateSignature(sig, payload, []byte(secret)); err == nil {
 				extSvc = e
 				break
 			}
 		}
+		if extSvc != nil {
+			break
+		}
 	}
 
 	if extSvc == nil || err != nil {
@@ -902,6 +982,20 @@ func (h *BitbucketServerWebhook) convertEvent(theirs interface{}) (prs []PR, our
 			Commit: e.Commit,
 			Status: e.Status,
 		}
+
+	// Unlike the plugin events above, native events are matched to changesets
+	// by the target repository of the pull request, which is also the
+	// repository of the changeset when it's opened from a fork.
+	case *bitbucketserver.NativePullRequestReviewerEvent:
+		repoID := strconv.Itoa(e.PullRequest.ToRef.Repository.ID)
+		pr := PR{ID: int64(e.PullRequest.ID), RepoExternalID: repoID}
+		prs = append(prs, pr)
+		return prs, e.ParticipantStatusEvent()
+	case *bitbucketserver.NativePullRequestEvent:
+		repoID := strconv.Itoa(e.PullRequest.ToRef.Repository.ID)
+		pr := PR{ID: int64(e.PullRequest.ID), RepoExternalID: repoID}
+		prs = append(prs, pr)
+		return prs, nil
 	}
 
 	return
@@ -0,0 +1,221 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// bitbucketCloudSecretParam is the query parameter that contains the webhook
+// secret, since Bitbucket Cloud doesn't sign webhook payloads.
+const bitbucketCloudSecretParam = "secret"
+
+// BitbucketCloudWebhook receives Bitbucket Cloud webhook events that are
+// relevant to campaigns, normalizes those events into ChangesetEvents and
+// upserts them to the database.
+type BitbucketCloudWebhook struct{ *Webhook }
+
+func NewBitbucketCloudWebhook(store *Store, repos repos.Store, now func() time.Time) *BitbucketCloudWebhook {
+	return &BitbucketCloudWebhook{&Webhook{store, repos, now, extsvc.TypeBitbucketCloud}}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	extSvc, err := h.getExternalServiceFromRawID(r.Context(), r.FormValue(extsvc.IDParam))
+	if err == errExternalServiceNotFound {
+		respond(w, http.StatusUnauthorized, err)
+		return
+	} else if err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "getting external service"))
+		return
+	}
+
+	// 🚨 SECURITY: Verify the shared secret against the Bitbucket Cloud
+	// external service configuration. If there isn't a webhook defined in the
+	// service with this secret, or the parameter is empty, then we return a
+	// 401 to the client.
+	if ok, err := validateBitbucketCloudSecret(extSvc, r.URL.Query().Get(bitbucketCloudSecretParam)); err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "validating the shared secret"))
+		return
+	} else if !ok {
+		respond(w, http.StatusUnauthorized, "shared secret is incorrect")
+		return
+	}
+
+	if r.Body == nil {
+		respond(w, http.StatusBadRequest, "missing request body")
+		return
+	}
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "reading payload"))
+		return
+	}
+
+	event, err := bitbucketcloud.ParseWebhookEvent(bitbucketcloud.WebhookEventKey(r), payload)
+	if err != nil {
+		// Like on GitLab, we don't want Bitbucket Cloud to retry events that
+		// we don't know how to handle.
+		log15.Debug("unknown Bitbucket Cloud webhook event", "err", err)
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusNoContent)
+		fmt.Fprintf(w, "%v", err)
+		return
+	}
+
+	if err := h.handleEvent(r.Context(), extSvc, event); err != nil {
+		respond(w, err.code, err)
+	} else {
+		respond(w, http.StatusNoContent, nil)
+	}
+}
+
+// getExternalServiceFromRawID retrieves the Bitbucket Cloud external service
+// matching the given raw ID. errExternalServiceNotFound is returned if there
+// is none.
+func (h *BitbucketCloudWebhook) getExternalServiceFromRawID(ctx context.Context, raw string) (*repos.ExternalService, error) {
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing the raw external service ID")
+	}
+
+	es, err := h.Repos.ListExternalServices(ctx, repos.StoreListExternalServicesArgs{
+		IDs:   []int64{id},
+		Kinds: []string{extsvc.KindBitbucketCloud},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external services")
+	}
+
+	if len(es) == 0 {
+		return nil, errExternalServiceNotFound
+	} else if len(es) > 1 {
+		return nil, errors.New("too many external services found")
+	}
+
+	return es[0], nil
+}
+
+// handleEvent dispatches based on the event type to perform whatever
+// changeset action is appropriate for that event.
+func (h *BitbucketCloudWebhook) handleEvent(ctx context.Context, extSvc *repos.ExternalService, event interface{}) *httpError {
+	log15.Debug("Bitbucket Cloud webhook received", "type", fmt.Sprintf("%T", event))
+
+	esID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		return &httpError{code: http.StatusInternalServerError, err: err}
+	}
+
+	switch e := event.(type) {
+	// Reviews are translated into the same events that we derive from the
+	// participants of a synced pull request.
+	case *bitbucketcloud.PullRequestApprovedEvent:
+		err = h.upsertChangesetEvent(ctx, esID, bitbucketCloudToPR(&e.PullRequestEvent), bitbucketcloud.ToParticipantStatusEvent(e))
+	case *bitbucketcloud.PullRequestUnapprovedEvent:
+		err = h.upsertChangesetEvent(ctx, esID, bitbucketCloudToPR(&e.PullRequestEvent), bitbucketcloud.ToParticipantStatusEvent(e))
+	case *bitbucketcloud.PullRequestChangesRequestCreatedEvent:
+		err = h.upsertChangesetEvent(ctx, esID, bitbucketCloudToPR(&e.PullRequestEvent), bitbucketcloud.ToParticipantStatusEvent(e))
+	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
+		err = h.upsertChangesetEvent(ctx, esID, bitbucketCloudToPR(&e.PullRequestEvent), bitbucketcloud.ToParticipantStatusEvent(e))
+
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		err = h.upsertChangesetEvent(ctx, esID, bitbucketCloudToPR(&e.PullRequestEvent), e)
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		err = h.upsertChangesetEvent(ctx, esID, bitbucketCloudToPR(&e.PullRequestEvent), e)
+
+	// Updates can change anything about the pull request, including its
+	// source commit, so we let repo-updater sync the changeset.
+	case *bitbucketcloud.PullRequestUpdatedEvent:
+		err = h.enqueueChangesetSync(ctx, esID, bitbucketCloudToPR(&e.PullRequestEvent))
+
+	case *bitbucketcloud.RepoCommitStatusEvent:
+		err = h.handleCommitStatusEvent(ctx, esID, e)
+	}
+
+	if err != nil {
+		return &httpError{code: http.StatusInternalServerError, err: err}
+	}
+	return nil
+}
+
+func (h *BitbucketCloudWebhook) handleCommitStatusEvent(ctx context.Context, esID string, event *bitbucketcloud.RepoCommitStatusEvent) error {
+	// Commit status payloads don't include the pull requests of the commit,
+	// so we match the changeset by the branch of the status.
+	if event.CommitStatus.RefName == "" {
+		log15.Debug("ignoring commit status event without a branch", "payload", event)
+		return nil
+	}
+
+	repo, err := h.getRepoForPR(ctx, h.Store, PR{RepoExternalID: event.Repository.UUID}, esID)
+	if err != nil {
+		log15.Debug("Webhook event could not be matched to repo", "err", err)
+		return nil
+	}
+
+	c, err := h.Store.GetChangeset(ctx, GetChangesetOpts{
+		RepoID:              repo.ID,
+		ExternalBranch:      event.CommitStatus.RefName,
+		ExternalServiceType: h.ServiceType,
+	})
+	if err == ErrNoResults {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "getting changeset")
+	}
+
+	id, err := strconv.ParseInt(c.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "parsing changeset external ID %s", c.ExternalID)
+	}
+
+	pr := PR{ID: id, RepoExternalID: event.Repository.UUID}
+	if err := h.upsertChangesetEvent(ctx, esID, pr, &event.CommitStatus); err != nil {
+		return errors.Wrap(err, "upserting changeset event")
+	}
+	return nil
+}
+
+// bitbucketCloudToPR instantiates a new PR instance from the common fields of
+// Bitbucket Cloud pull request webhook payloads.
+func bitbucketCloudToPR(e *bitbucketcloud.PullRequestEvent) PR {
+	return PR{
+		ID:             e.PullRequest.ID,
+		RepoExternalID: e.Repository.UUID,
+	}
+}
+
+// validateBitbucketCloudSecret validates that the given secret matches one of
+// the webhooks in the external service.
+func validateBitbucketCloudSecret(extSvc *repos.ExternalService, secret string) (bool, error) {
+	// An empty secret never succeeds.
+	if secret == "" {
+		return false, nil
+	}
+
+	c, err := extSvc.Configuration()
+	if err != nil {
+		return false, errors.Wrap(err, "getting external service configuration")
+	}
+
+	config, ok := c.(*schema.BitbucketCloudConnection)
+	if !ok {
+		return false, errExternalServiceWrongKind
+	}
+
+	for _, webhook := range config.Webhooks {
+		if webhook.Secret == secret {
+			return true, nil
+		}
+	}
+	return false, nil
+}
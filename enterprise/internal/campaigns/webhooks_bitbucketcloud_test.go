@@ -0,0 +1,60 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateBitbucketCloudSecret(t *testing.T) {
+	t.Run("empty secret", func(t *testing.T) {
+		ok, err := validateBitbucketCloudSecret(nil, "")
+		if ok {
+			t.Errorf("unexpected ok: %v", ok)
+		}
+		if err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+
+	t.Run("not a Bitbucket Cloud connection", func(t *testing.T) {
+		es := &repos.ExternalService{Kind: extsvc.KindGitHub}
+		ok, err := validateBitbucketCloudSecret(es, "secret")
+		if ok {
+			t.Errorf("unexpected ok: %v", ok)
+		}
+		if err != errExternalServiceWrongKind {
+			t.Errorf("unexpected error: have %+v; want %+v", err, errExternalServiceWrongKind)
+		}
+	})
+
+	t.Run("valid webhooks", func(t *testing.T) {
+		for secret, want := range map[string]bool{
+			"not secret": false,
+			"secret":     true,
+			"super":      true,
+		} {
+			t.Run(secret, func(t *testing.T) {
+				es := &repos.ExternalService{
+					Kind: extsvc.KindBitbucketCloud,
+					Config: marshalJSON(t, &schema.BitbucketCloudConnection{
+						Webhooks: []*schema.BitbucketCloudWebhook{
+							{Secret: "super"},
+							{Secret: "secret"},
+						},
+					}),
+				}
+
+				ok, err := validateBitbucketCloudSecret(es, secret)
+				if ok != want {
+					t.Errorf("unexpected ok: have %v; want %v", ok, want)
+				}
+				if err != nil {
+					t.Errorf("unexpected non-nil error: %+v", err)
+				}
+			})
+		}
+	})
+}
@@ -180,6 +180,18 @@ func (h *GitLabWebhook) handleEvent(ctx context.Context, extSvc *repos.ExternalS
 		}
 		return nil
 
+	// Notes on merge requests can be reviews, in which case we can create a
+	// changeset event straight away. Any other note still means that the merge
+	// request has changed, so we'll ask repo-updater to sync it.
+	case *webhooks.MergeRequestNoteEvent:
+		if err := h.handleNoteEvent(ctx, esID, e); err != nil {
+			return &httpError{
+				code: http.StatusInternalServerError,
+				err:  err,
+			}
+		}
+		return nil
+
 	// All other merge request events are state events.
 	case stateMergeRequestEvent:
 		if err := h.handleMergeRequestStateEvent(ctx, esID, e); err != nil {
@@ -237,6 +249,23 @@ func (h *GitLabWebhook) handleMergeRequestStateEvent(ctx context.Context, esID s
 	return nil
 }
 
+func (h *GitLabWebhook) handleNoteEvent(ctx context.Context, esID string, event *webhooks.MergeRequestNoteEvent) error {
+	if review := event.Note.ToReview(); review != nil {
+		pr := gitlabToPR(&event.Project, event.MergeRequest)
+		if err := h.upsertChangesetEvent(ctx, esID, pr, review.(keyer)); err != nil {
+			return errors.Wrap(err, "upserting changeset event")
+		}
+		return nil
+	}
+
+	// Notes are left on merge requests that aren't changesets all the time, so
+	// not finding a changeset isn't an error here.
+	if err := h.enqueueChangesetSyncFromEvent(ctx, esID, event.ToEvent()); err != nil && errors.Cause(err) != ErrNoResults {
+		return err
+	}
+	return nil
+}
+
 func (h *GitLabWebhook) handlePipelineEvent(ctx context.Context, esID string, event *webhooks.PipelineEvent) error {
 	// Pipeline webhook payloads don't include the merge request very reliably:
 	// for example, re-running a pipeline from the GitLab UI will result in no
@@ -379,6 +379,69 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 				assertChangesetEventForChangeset(t, ctx, store, changeset, campaigns.ChangesetEventKindGitLabPipeline)
 			})
+
+			t.Run("valid merge request note events", func(t *testing.T) {
+				store, rstore, clock := gitLabTestSetup(t, db)
+				h := NewGitLabWebhook(store, rstore, clock.now)
+				es := createGitLabExternalService(t, ctx, rstore)
+				repo := createGitLabRepo(t, ctx, rstore, es)
+				changeset := createGitLabChangeset(t, ctx, store, repo)
+				body := createNotePayload(t, repo, changeset, "looks good", false)
+
+				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
+				req, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Add(webhooks.TokenHeaderName, "secret")
+
+				changesetEnqueued := false
+				repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+					changesetEnqueued = true
+					if diff := cmp.Diff(ids, []int64{changeset.ID}); diff != "" {
+						t.Errorf("unexpected changeset ID: %s", diff)
+					}
+					return nil
+				}
+				defer func() { repoupdater.MockEnqueueChangesetSync = nil }()
+
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				resp := rec.Result()
+				if have, want := resp.StatusCode, http.StatusNoContent; have != want {
+					t.Errorf("unexpected status code: have %d; want %d", have, want)
+				}
+				if !changesetEnqueued {
+					t.Error("changeset was not enqueued")
+				}
+			})
+
+			t.Run("valid merge request review note events", func(t *testing.T) {
+				store, rstore, clock := gitLabTestSetup(t, db)
+				h := NewGitLabWebhook(store, rstore, clock.now)
+				es := createGitLabExternalService(t, ctx, rstore)
+				repo := createGitLabRepo(t, ctx, rstore, es)
+				changeset := createGitLabChangeset(t, ctx, store, repo)
+				body := createNotePayload(t, repo, changeset, "approved this merge request", true)
+
+				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
+				req, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Add(webhooks.TokenHeaderName, "secret")
+
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				resp := rec.Result()
+				if have, want := resp.StatusCode, http.StatusNoContent; have != want {
+					t.Errorf("unexpected status code: have %d; want %d", have, want)
+				}
+
+				assertChangesetEventForChangeset(t, ctx, store, changeset, campaigns.ChangesetEventKindGitLabApproved)
+			})
 		})
 
 		t.Run("getExternalServiceFromRawID", func(t *testing.T) {
@@ -675,6 +738,35 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			}
 		})
 
+		t.Run("handleNoteEvent missing changeset", func(t *testing.T) {
+			store, rstore, clock := gitLabTestSetup(t, db)
+			h := NewGitLabWebhook(store, rstore, clock.now)
+			es := createGitLabExternalService(t, ctx, rstore)
+			repo := createGitLabRepo(t, ctx, rstore, es)
+
+			pid, err := strconv.Atoi(repo.ExternalRepo.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			esid, err := extractExternalServiceID(es)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event := &webhooks.MergeRequestNoteEvent{
+				EventCommon: webhooks.EventCommon{
+					Project: gitlab.ProjectCommon{ID: pid},
+				},
+				MergeRequest: &gitlab.MergeRequest{IID: 12345},
+				Note:         &gitlab.Note{Body: "not a changeset"},
+			}
+
+			if err := h.handleNoteEvent(ctx, esid, event); err != nil {
+				t.Errorf("unexpected non-nil error: %+v", err)
+			}
+		})
+
 		t.Run("handlePipelineEvent", func(t *testing.T) {
 			// As with the handleMergeRequestStateEvent test above, we don't
 			// really need to test the success path here. However, there's one
@@ -1001,3 +1093,38 @@ func createPipelinePayload(t *testing.T, repo *repos.Repo, changeset *campaigns.
 
 	return marshalJSON(t, payload)
 }
+
+// createNotePayload creates a mock GitLab webhook payload of the note object
+// kind on the merge request of the given changeset.
+func createNotePayload(t *testing.T, repo *repos.Repo, changeset *campaigns.Changeset, note string, system bool) string {
+	cid, err := strconv.Atoi(changeset.ExternalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pid, err := strconv.Atoi(repo.ExternalRepo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return marshalJSON(t, map[string]interface{}{
+		"object_kind": "note",
+		"project": map[string]interface{}{
+			"id": pid,
+		},
+		"user": map[string]interface{}{
+			"id":       1,
+			"username": "alice",
+		},
+		"merge_request": map[string]interface{}{
+			"iid": cid,
+		},
+		"object_attributes": map[string]interface{}{
+			"id":            123,
+			"note":          note,
+			"noteable_type": "MergeRequest",
+			"system":        system,
+			"created_at":    "2020-06-26 23:11:17 UTC",
+		},
+	})
+}
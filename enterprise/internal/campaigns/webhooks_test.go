@@ -446,3 +446,46 @@ func marshalJSON(t testing.TB, v interface{}) string {
 
 	return string(bs)
 }
+
+func TestWebhooksConfigured(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  interface{}
+		want bool
+	}{
+		{name: "GitHub without webhooks", cfg: &schema.GitHubConnection{}, want: false},
+		{
+			name: "GitHub with webhook without secret",
+			cfg:  &schema.GitHubConnection{Webhooks: []*schema.GitHubWebhook{{Org: "sourcegraph"}}},
+			want: false,
+		},
+		{
+			name: "GitHub with webhook",
+			cfg:  &schema.GitHubConnection{Webhooks: []*schema.GitHubWebhook{{Org: "sourcegraph", Secret: "secret"}}},
+			want: true,
+		},
+		{name: "Bitbucket Server without webhooks", cfg: &schema.BitbucketServerConnection{}, want: false},
+		{
+			name: "Bitbucket Server with native webhooks",
+			cfg:  &schema.BitbucketServerConnection{NativeWebhooks: &schema.BitbucketServerNativeWebhooks{Secret: "secret"}},
+			want: true,
+		},
+		{
+			name: "GitLab with webhook",
+			cfg:  &schema.GitLabConnection{Webhooks: []*schema.GitLabWebhook{{Secret: "secret"}}},
+			want: true,
+		},
+		{
+			name: "Bitbucket Cloud with webhook",
+			cfg:  &schema.BitbucketCloudConnection{Webhooks: []*schema.BitbucketCloudWebhook{{Secret: "secret"}}},
+			want: true,
+		},
+		{name: "unsupported code host", cfg: &schema.GitoliteConnection{}, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := webhooksConfigured(tc.cfg); have != tc.want {
+				t.Fatalf("wrong result. want=%t, have=%t", tc.want, have)
+			}
+		})
+	}
+}
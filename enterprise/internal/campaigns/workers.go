@@ -21,24 +21,62 @@ import (
 // RunWorkers starts a dbworker.NewWorker that fetches enqueued changesets
 // from the database and passes them to the changeset reconciler for
 // processing.
+//
+// If budgets is not nil, the publication of changesets is limited to a share
+// of the rate limit of their code host.
+//
+// If syncs is not nil, newly published changesets are synced right away.
+//
+// The number of changesets processed in parallel, in total and on each code
+// host, is limited by the campaigns.reconcilerConcurrency site configuration.
+//
+// If locker is not nil, the workers only run on the replica that's the
+// leader of the reconciler job. Dequeueing already hands a changeset to a
+// single worker, but a changeset whose processing is considered stalled is
+// reset and could be published a second time by another replica.
 func RunWorkers(
 	ctx context.Context,
 	s *Store,
 	gitClient GitserverClient,
 	sourcer repos.Sourcer,
+	budgets *PublicationBudgets,
+	syncs ChangesetSyncEnqueuer,
+	locker *Locker,
 ) {
-	r := &reconciler{gitserverClient: gitClient, sourcer: sourcer, store: s}
+	r := &reconciler{
+		gitserverClient: gitClient,
+		sourcer:         sourcer,
+		store:           s,
+		rollout:         &rolloutWindows{},
+		budgets:         budgets,
+		concurrency:     &reconcilerConcurrency{store: s},
+		syncs:           syncs,
+	}
 
 	options := dbworker.WorkerOptions{
-		Handler:     r.HandlerFunc(),
-		NumHandlers: 5,
+		Name:    "campaigns_reconciler",
+		Handler: r,
+		// The number of changesets processed in parallel is limited by
+		// r.concurrency.
+		NumHandlers: maxReconcilerConcurrency,
 		Interval:    5 * time.Second,
 		Metrics: workerutil.WorkerMetrics{
-			HandleOperation: newObservationOperation(),
+			HandleOperation: newObservationOperation("campaigns_reconciler", "Reconciler.Process"),
 		},
 	}
 
-	workerStore := dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
+	workerStore := newReconcilerWorkerStore(s)
+
+	locker.DoAsLeader(ctx, LeaderJobReconciler, func(ctx context.Context) {
+		// The worker stops once ctx is canceled.
+		dbworker.NewWorker(ctx, workerStore, options).Start()
+	})
+}
+
+// newReconcilerWorkerStore returns the dbworkerstore.Store from which the
+// reconciler dequeues changesets.
+func newReconcilerWorkerStore(s *Store) dbworkerstore.Store {
+	return dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
 		TableName:            "changesets",
 		AlternateColumnNames: map[string]string{"state": "reconciler_state"},
 		ColumnExpressions:    changesetColumns,
@@ -47,16 +85,13 @@ func RunWorkers(
 		StalledMaxAge:        60 * time.Second,
 		MaxNumResets:         5,
 	})
-
-	worker := dbworker.NewWorker(ctx, workerStore, options)
-	worker.Start()
 }
 
 func scanFirstChangesetRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
 	return scanFirstChangeset(rows, err)
 }
 
-func newObservationOperation() *observation.Operation {
+func newObservationOperation(metricPrefix, opName string) *observation.Operation {
 	observationContext := &observation.Context{
 		Logger:     log15.Root(),
 		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
@@ -65,13 +100,13 @@ func newObservationOperation() *observation.Operation {
 
 	metrics := metrics.NewOperationMetrics(
 		observationContext.Registerer,
-		"campaigns_reconciler",
+		metricPrefix,
 		metrics.WithLabels("op"),
 		metrics.WithCountHelp("Total number of results returned"),
 	)
 
 	return observationContext.Operation(observation.Op{
-		Name:         "Reconciler.Process",
+		Name:         opName,
 		MetricLabels: []string{"process"},
 		Metrics:      metrics,
 	})
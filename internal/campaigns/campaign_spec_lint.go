@@ -0,0 +1,128 @@
+package campaigns
+
+import (
+	"fmt"
+	"strconv"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// CampaignSpecLintRule is the machine-readable ID of the rule that a
+// CampaignSpecLintProblem violates.
+type CampaignSpecLintRule string
+
+const (
+	// CampaignSpecLintRuleInvalidSpec is violated by campaign specs that
+	// don't pass validation.
+	CampaignSpecLintRuleInvalidSpec CampaignSpecLintRule = "INVALID_SPEC"
+	// CampaignSpecLintRuleStepsNotPublished is violated by campaign specs
+	// with steps but no changesetTemplate, so that the changes made by the
+	// steps never end up in changesets.
+	CampaignSpecLintRuleStepsNotPublished CampaignSpecLintRule = "STEPS_NOT_PUBLISHED"
+	// CampaignSpecLintRuleQueryMatchesNoRepositories is violated by
+	// repositoriesMatchingQuery entries that don't match any repository.
+	CampaignSpecLintRuleQueryMatchesNoRepositories CampaignSpecLintRule = "QUERY_MATCHES_NO_REPOSITORIES"
+	// CampaignSpecLintRuleDuplicateImportChangesets is violated by changesets
+	// that are imported more than once.
+	CampaignSpecLintRuleDuplicateImportChangesets CampaignSpecLintRule = "DUPLICATE_IMPORT_CHANGESETS"
+	// CampaignSpecLintRuleOversizedDiff is violated by changeset specs whose
+	// diffs are too large to be reviewed.
+	CampaignSpecLintRuleOversizedDiff CampaignSpecLintRule = "OVERSIZED_DIFF"
+)
+
+// CampaignSpecLintSeverity is the severity of a CampaignSpecLintProblem.
+type CampaignSpecLintSeverity string
+
+const (
+	// CampaignSpecLintSeverityError is the severity of problems that keep
+	// the campaign spec from being created.
+	CampaignSpecLintSeverityError CampaignSpecLintSeverity = "ERROR"
+	// CampaignSpecLintSeverityWarning is the severity of likely mistakes
+	// that don't keep the campaign spec from being created.
+	CampaignSpecLintSeverityWarning CampaignSpecLintSeverity = "WARNING"
+)
+
+// CampaignSpecLintProblem is a problem found by linting a campaign spec.
+// Line and Column are 1-based and 0 if the problem can't be attributed to a
+// node of the raw spec.
+type CampaignSpecLintProblem struct {
+	Rule     CampaignSpecLintRule
+	Severity CampaignSpecLintSeverity
+	Message  string
+	Line     int
+	Column   int
+}
+
+// NewInvalidSpecLintProblem returns the CampaignSpecLintProblem for the given
+// validation problem.
+func NewInvalidSpecLintProblem(e *CampaignSpecValidationError) *CampaignSpecLintProblem {
+	return &CampaignSpecLintProblem{
+		Rule:     CampaignSpecLintRuleInvalidSpec,
+		Severity: CampaignSpecLintSeverityError,
+		Message:  e.Message,
+		Line:     e.Line,
+		Column:   e.Column,
+	}
+}
+
+// LintCampaignSpec validates the given raw campaign spec like
+// ValidateCampaignSpec and, if it's valid, checks it for likely mistakes that
+// can be found in the spec itself. It returns the unmarshaled spec if it's
+// valid, and all problems found.
+func LintCampaignSpec(rawSpec string) (*CampaignSpecFields, []*CampaignSpecLintProblem) {
+	spec, errs := ValidateCampaignSpec(rawSpec)
+	if len(errs) > 0 {
+		problems := make([]*CampaignSpecLintProblem, 0, len(errs))
+		for _, e := range errs {
+			problems = append(problems, NewInvalidSpecLintProblem(e))
+		}
+		return nil, problems
+	}
+
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(rawSpec), &root); err != nil {
+		// ValidateCampaignSpec already parsed the spec successfully.
+		return nil, []*CampaignSpecLintProblem{{
+			Rule:     CampaignSpecLintRuleInvalidSpec,
+			Severity: CampaignSpecLintSeverityError,
+			Message:  err.Error(),
+		}}
+	}
+
+	var problems []*CampaignSpecLintProblem
+	warn := func(rule CampaignSpecLintRule, path []string, format string, args ...interface{}) {
+		line, column := nodePosition(&root, path)
+		problems = append(problems, &CampaignSpecLintProblem{
+			Rule:     rule,
+			Severity: CampaignSpecLintSeverityWarning,
+			Message:  fmt.Sprintf(format, args...),
+			Line:     line,
+			Column:   column,
+		})
+	}
+
+	if len(spec.Steps) > 0 {
+		if _, ok := findNode(&root, []string{"changesetTemplate"}); !ok {
+			warn(CampaignSpecLintRuleStepsNotPublished, []string{"steps"},
+				"the changes made by the steps are never published, since there's no changesetTemplate")
+		}
+	}
+
+	type importedChangeset struct{ repo, externalID string }
+	imported := make(map[importedChangeset]bool)
+	for i, ic := range spec.ImportChangesets {
+		for j, id := range ic.ExternalIDs {
+			// External IDs can be given as strings or numbers.
+			c := importedChangeset{repo: ic.Repository, externalID: fmt.Sprint(id)}
+			if imported[c] {
+				warn(CampaignSpecLintRuleDuplicateImportChangesets,
+					[]string{"importChangesets", strconv.Itoa(i), "externalIDs", strconv.Itoa(j)},
+					"changeset %s of repository %q is imported more than once", c.externalID, c.repo)
+				continue
+			}
+			imported[c] = true
+		}
+	}
+
+	return spec, problems
+}
@@ -0,0 +1,97 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLintCampaignSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawSpec string
+		want    []*CampaignSpecLintProblem
+	}{
+		{
+			name: "no problems",
+			rawSpec: `
+name: hello-world
+on:
+  - repository: github.com/sourcegraph/sourcegraph
+steps:
+  - run: echo hello >> README.md
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: false
+`,
+		},
+		{
+			name: "invalid spec",
+			rawSpec: `
+description: no name
+`,
+			want: []*CampaignSpecLintProblem{
+				{
+					Rule:     CampaignSpecLintRuleInvalidSpec,
+					Severity: CampaignSpecLintSeverityError,
+					Message:  "name is required",
+					Line:     2,
+					Column:   1,
+				},
+			},
+		},
+		{
+			name: "steps without changesetTemplate",
+			rawSpec: `
+name: hello-world
+steps:
+  - run: echo hello >> README.md
+    container: alpine:3
+`,
+			want: []*CampaignSpecLintProblem{
+				{
+					Rule:     CampaignSpecLintRuleStepsNotPublished,
+					Severity: CampaignSpecLintSeverityWarning,
+					Message:  "the changes made by the steps are never published, since there's no changesetTemplate",
+					Line:     4,
+					Column:   3,
+				},
+			},
+		},
+		{
+			name: "duplicate importChangesets",
+			rawSpec: `
+name: hello-world
+importChangesets:
+  - repository: github.com/sourcegraph/sourcegraph
+    externalIDs: [1, 2]
+  - repository: github.com/sourcegraph/sourcegraph
+    externalIDs: ["2", 3]
+  - repository: github.com/sourcegraph/src-cli
+    externalIDs: [1]
+`,
+			want: []*CampaignSpecLintProblem{
+				{
+					Rule:     CampaignSpecLintRuleDuplicateImportChangesets,
+					Severity: CampaignSpecLintSeverityWarning,
+					Message:  `changeset 2 of repository "github.com/sourcegraph/sourcegraph" is imported more than once`,
+					Line:     7,
+					Column:   19,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, have := LintCampaignSpec(tc.rawSpec)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong problems (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
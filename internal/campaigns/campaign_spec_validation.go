@@ -0,0 +1,169 @@
+package campaigns
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/sourcegraph/sourcegraph/schema"
+	"github.com/xeipuuv/gojsonschema"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// CampaignSpecValidationError is a problem found in a raw campaign spec. Line
+// and Column are 1-based and point at the offending YAML node. Both are 0 if
+// the problem can't be attributed to a node.
+type CampaignSpecValidationError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+func (e *CampaignSpecValidationError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// ValidateCampaignSpec validates the given raw campaign spec, which can be
+// YAML or JSON, against the CampaignSpec schema. It returns the unmarshaled
+// spec if it's valid and otherwise all problems found, located in the raw
+// spec where possible.
+func ValidateCampaignSpec(rawSpec string) (*CampaignSpecFields, []*CampaignSpecValidationError) {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(rawSpec), &root); err != nil {
+		return nil, yamlSyntaxErrors(err)
+	}
+
+	sl := gojsonschema.NewSchemaLoader()
+	sc, err := sl.Compile(gojsonschema.NewStringLoader(schema.CampaignSpecSchemaJSON))
+	if err != nil {
+		return nil, []*CampaignSpecValidationError{{Message: "failed to compile JSON schema: " + err.Error()}}
+	}
+
+	normalized, err := yaml.YAMLToJSONCustom([]byte(rawSpec), yamlv3.Unmarshal)
+	if err != nil {
+		return nil, []*CampaignSpecValidationError{{Message: "failed to normalize JSON: " + err.Error()}}
+	}
+
+	res, err := sc.Validate(gojsonschema.NewBytesLoader(normalized))
+	if err != nil {
+		return nil, []*CampaignSpecValidationError{{Message: "failed to validate input against schema: " + err.Error()}}
+	}
+
+	var errs []*CampaignSpecValidationError
+	for _, resErr := range res.Errors() {
+		e := &CampaignSpecValidationError{
+			// Remove `(root): ` from error formatting since these errors are
+			// presented to users.
+			Message: strings.TrimPrefix(resErr.String(), "(root): "),
+		}
+		e.Line, e.Column = nodePosition(&root, schemaErrorPath(resErr))
+		errs = append(errs, e)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	var spec CampaignSpecFields
+	if err := json.Unmarshal(normalized, &spec); err != nil {
+		return nil, []*CampaignSpecValidationError{{Message: err.Error()}}
+	}
+
+	return &spec, nil
+}
+
+// CampaignSpecPosition returns the line and column of the node at the given
+// path in the raw campaign spec. The path consists of mapping keys and
+// sequence indexes, e.g. []string{"importChangesets", "0", "repository"}. If
+// the node doesn't exist, the position of its closest existing ancestor is
+// returned.
+func CampaignSpecPosition(rawSpec string, path []string) (line, column int) {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(rawSpec), &root); err != nil {
+		return 0, 0
+	}
+	return nodePosition(&root, path)
+}
+
+// schemaErrorPath returns the path to the value that caused the given schema
+// validation error.
+func schemaErrorPath(err gojsonschema.ResultError) []string {
+	if err.Context() == nil {
+		return nil
+	}
+	// The context is rendered as "(root).steps.0.run".
+	path := strings.Split(err.Context().String(), ".")
+	return path[1:]
+}
+
+func nodePosition(root *yamlv3.Node, path []string) (line, column int) {
+	n, _ := findNode(root, path)
+	if n == nil {
+		return 0, 0
+	}
+	return n.Line, n.Column
+}
+
+// findNode returns the node at the given path and true if it exists, and
+// otherwise its closest existing ancestor and false.
+func findNode(root *yamlv3.Node, path []string) (*yamlv3.Node, bool) {
+	n := root
+	if n.Kind == yamlv3.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil, false
+		}
+		n = n.Content[0]
+	}
+
+	for _, p := range path {
+		var next *yamlv3.Node
+		switch n.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == p {
+					next = n.Content[i+1]
+					break
+				}
+			}
+		case yamlv3.SequenceNode:
+			if i, err := strconv.Atoi(p); err == nil && i >= 0 && i < len(n.Content) {
+				next = n.Content[i]
+			}
+		}
+		if next == nil {
+			return n, false
+		}
+		n = next
+	}
+
+	return n, true
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+): (.*)$`)
+
+// yamlSyntaxErrors converts an error returned by the YAML parser into
+// validation errors. The parser only reports the line of a problem.
+func yamlSyntaxErrors(err error) (errs []*CampaignSpecValidationError) {
+	for _, l := range strings.Split(err.Error(), "\n") {
+		l = strings.TrimSpace(strings.TrimPrefix(l, "yaml: "))
+		if l == "" || l == "unmarshal errors:" {
+			continue
+		}
+
+		e := &CampaignSpecValidationError{Message: l}
+		if m := yamlErrorLine.FindStringSubmatch(l); m != nil {
+			e.Line, _ = strconv.Atoi(m[1])
+			e.Message = m[2]
+		}
+		errs = append(errs, e)
+	}
+	if len(errs) == 0 {
+		errs = append(errs, &CampaignSpecValidationError{Message: err.Error()})
+	}
+	return errs
+}
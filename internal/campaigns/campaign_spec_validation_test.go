@@ -0,0 +1,111 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateCampaignSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawSpec  string
+		wantErrs []*CampaignSpecValidationError
+	}{
+		{
+			name: "valid",
+			rawSpec: `
+name: hello-world
+on:
+  - repository: github.com/sourcegraph/sourcegraph
+`,
+		},
+		{
+			name: "syntax error",
+			rawSpec: `
+name: hello-world
+on: [
+`,
+			wantErrs: []*CampaignSpecValidationError{
+				{Message: "did not find expected node content", Line: 3},
+			},
+		},
+		{
+			name: "missing property",
+			rawSpec: `
+description: no name
+`,
+			wantErrs: []*CampaignSpecValidationError{
+				{Message: "name is required", Line: 2, Column: 1},
+			},
+		},
+		{
+			name: "invalid nested value",
+			rawSpec: `
+name: hello-world
+steps:
+  - run: echo hello
+    container: alpine:3
+  - run: 42
+    container: alpine:3
+`,
+			wantErrs: []*CampaignSpecValidationError{
+				{Message: "steps.1.run: Invalid type. Expected: string, given: integer", Line: 6, Column: 10},
+			},
+		},
+		{
+			name: "unknown property",
+			rawSpec: `
+name: hello-world
+changesetTemplate:
+  title: Hello World
+  body: My first campaign!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: false
+  colors: [blue]
+`,
+			wantErrs: []*CampaignSpecValidationError{
+				{Message: "changesetTemplate: Additional property colors is not allowed", Line: 4, Column: 3},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, errs := ValidateCampaignSpec(tc.rawSpec)
+			if diff := cmp.Diff(tc.wantErrs, errs); diff != "" {
+				t.Fatal(diff)
+			}
+			if len(tc.wantErrs) == 0 && spec.Name != "hello-world" {
+				t.Fatalf("wrong spec: %+v", spec)
+			}
+		})
+	}
+}
+
+func TestCampaignSpecPosition(t *testing.T) {
+	rawSpec := `
+name: hello-world
+importChangesets:
+  - repository: github.com/sourcegraph/sourcegraph
+    externalIDs: [1, 2]
+`
+
+	for _, tc := range []struct {
+		path         []string
+		line, column int
+	}{
+		{[]string{"name"}, 2, 7},
+		{[]string{"importChangesets", "0", "repository"}, 4, 17},
+		{[]string{"importChangesets", "0", "externalIDs", "1"}, 5, 22},
+		// Missing nodes are attributed to their closest ancestor.
+		{[]string{"importChangesets", "1", "repository"}, 4, 3},
+	} {
+		line, column := CampaignSpecPosition(rawSpec, tc.path)
+		if line != tc.line || column != tc.column {
+			t.Errorf("%v: want=%d:%d, have=%d:%d", tc.path, tc.line, tc.column, line, column)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package campaigns
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ChangesetTemplateVars are the values of the variables that can be
+// referenced in the title, body and commit message of a changeset as
+// ${{ NAME }}:
+//
+//   - repository.name: the name of the repository
+//   - repository.search_result_count: the number of results of the
+//     repositoriesMatchingQuery search in the repository
+//   - steps.N.output: the trimmed standard output of the Nth step, starting at 1
+//
+// Variables without a value aren't substituted.
+type ChangesetTemplateVars struct {
+	RepositoryName    string
+	SearchResultCount *int
+	StepOutputs       []string
+}
+
+var changesetTemplateVarRefRegex = regexp.MustCompile(`\$\{\{\s*([A-Za-z0-9_.]+)\s*\}\}`)
+
+// lookup returns the value of the variable with the given name and whether
+// it's known.
+func (v ChangesetTemplateVars) lookup(name string) (string, bool) {
+	switch name {
+	case "repository.name":
+		return v.RepositoryName, v.RepositoryName != ""
+	case "repository.search_result_count":
+		if v.SearchResultCount == nil {
+			return "", false
+		}
+		return strconv.Itoa(*v.SearchResultCount), true
+	}
+
+	parts := strings.Split(name, ".")
+	if len(parts) == 3 && parts[0] == "steps" && parts[2] == "output" {
+		n, err := strconv.Atoi(parts[1])
+		if err == nil && n >= 1 && n <= len(v.StepOutputs) {
+			return strings.TrimSpace(v.StepOutputs[n-1]), true
+		}
+	}
+
+	return "", false
+}
+
+// Render substitutes the values of the variables referenced in s. References
+// to unknown variables, and to variables without a value, are left as they
+// are.
+func (v ChangesetTemplateVars) Render(s string) string {
+	return changesetTemplateVarRefRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		name := changesetTemplateVarRefRegex.FindStringSubmatch(ref)[1]
+		if value, ok := v.lookup(name); ok {
+			return value
+		}
+		return ref
+	})
+}
+
+// Render returns a copy of the ChangesetTemplate in which the variables
+// referenced in the title, body and commit message are substituted.
+func (t *ChangesetTemplate) Render(vars ChangesetTemplateVars) *ChangesetTemplate {
+	rendered := *t
+	rendered.Title = vars.Render(t.Title)
+	rendered.Body = vars.Render(t.Body)
+	rendered.Commit.Message = vars.Render(t.Commit.Message)
+	return &rendered
+}
+
+// RenderTemplateVars substitutes the given variables in the title, body and
+// commit messages of the ChangesetSpecDescription.
+func (d *ChangesetSpecDescription) RenderTemplateVars(vars ChangesetTemplateVars) {
+	d.Title = vars.Render(d.Title)
+	d.Body = vars.Render(d.Body)
+	for i := range d.Commits {
+		d.Commits[i].Message = vars.Render(d.Commits[i].Message)
+	}
+}
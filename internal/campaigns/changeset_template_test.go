@@ -0,0 +1,71 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChangesetTemplateVarsRender(t *testing.T) {
+	count := 3
+	vars := ChangesetTemplateVars{
+		RepositoryName:    "github.com/sourcegraph/sourcegraph",
+		SearchResultCount: &count,
+		StepOutputs:       []string{"first\n", "  second  "},
+	}
+
+	for _, tc := range []struct {
+		name string
+		vars ChangesetTemplateVars
+		in   string
+		want string
+	}{
+		{
+			name: "all variables",
+			vars: vars,
+			in:   "${{ repository.name }}: ${{repository.search_result_count}} results, ${{ steps.1.output }} and ${{ steps.2.output }}",
+			want: "github.com/sourcegraph/sourcegraph: 3 results, first and second",
+		},
+		{
+			name: "unknown variables",
+			vars: vars,
+			in:   "${{ steps.3.output }} ${{ parameters.name }} ${{ repository.owner }}",
+			want: "${{ steps.3.output }} ${{ parameters.name }} ${{ repository.owner }}",
+		},
+		{
+			name: "variables without value",
+			vars: ChangesetTemplateVars{RepositoryName: "github.com/sourcegraph/sourcegraph"},
+			in:   "${{ repository.name }} ${{ repository.search_result_count }} ${{ steps.1.output }}",
+			want: "github.com/sourcegraph/sourcegraph ${{ repository.search_result_count }} ${{ steps.1.output }}",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := tc.vars.Render(tc.in); have != tc.want {
+				t.Fatalf("wrong result.\nwant=%q\nhave=%q", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestChangesetTemplateRender(t *testing.T) {
+	tmpl := &ChangesetTemplate{
+		Title:  "Update ${{ repository.name }}",
+		Body:   "Found ${{ steps.1.output }}",
+		Branch: "update-${{ repository.name }}",
+		Commit: CommitTemplate{Message: "Update ${{ repository.name }}"},
+	}
+	vars := ChangesetTemplateVars{RepositoryName: "a/b", StepOutputs: []string{"2 files\n"}}
+
+	want := &ChangesetTemplate{
+		Title:  "Update a/b",
+		Body:   "Found 2 files",
+		Branch: "update-${{ repository.name }}",
+		Commit: CommitTemplate{Message: "Update a/b"},
+	}
+	if diff := cmp.Diff(want, tmpl.Render(vars)); diff != "" {
+		t.Fatal(diff)
+	}
+	if tmpl.Title != "Update ${{ repository.name }}" {
+		t.Fatal("template was modified")
+	}
+}
@@ -1,6 +1,8 @@
 package campaigns
 
 import (
+	"time"
+
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 )
 
@@ -12,3 +14,38 @@ func init() {
 		return
 	})
 }
+
+// CampaignSpecTTL returns the TTL of CampaignSpecs that haven't been applied
+// yet, as configured in the site configuration.
+func CampaignSpecTTL() time.Duration {
+	if e := conf.Get().CampaignsSpecExpiration; e != nil && e.CampaignSpecHours > 0 {
+		return time.Duration(e.CampaignSpecHours) * time.Hour
+	}
+	return DefaultCampaignSpecTTL
+}
+
+// ChangesetSpecTTL returns the TTL of ChangesetSpecs that haven't been
+// attached to a CampaignSpec, as configured in the site configuration.
+func ChangesetSpecTTL() time.Duration {
+	if e := conf.Get().CampaignsSpecExpiration; e != nil && e.ChangesetSpecHours > 0 {
+		return time.Duration(e.ChangesetSpecHours) * time.Hour
+	}
+	return DefaultChangesetSpecTTL
+}
+
+// SpecExpirationInterval returns the time between two runs of the job that
+// deletes expired specs, as configured in the site configuration.
+func SpecExpirationInterval() time.Duration {
+	if e := conf.Get().CampaignsSpecExpiration; e != nil && e.IntervalMinutes > 0 {
+		return time.Duration(e.IntervalMinutes) * time.Minute
+	}
+	return DefaultSpecExpirationInterval
+}
+
+// SpecExpirationDryRun returns whether the job that deletes expired specs
+// only reports what it would delete, as configured in the site
+// configuration.
+func SpecExpirationDryRun() bool {
+	e := conf.Get().CampaignsSpecExpiration
+	return e != nil && e.DryRun
+}
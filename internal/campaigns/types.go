@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,10 +20,12 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 	"github.com/xeipuuv/gojsonschema"
@@ -37,6 +41,7 @@ var SupportedExternalServices = map[string]struct{}{
 	extsvc.TypeGitHub:          {},
 	extsvc.TypeBitbucketServer: {},
 	extsvc.TypeGitLab:          {},
+	extsvc.TypeBitbucketCloud:  {},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
@@ -72,10 +77,52 @@ type Campaign struct {
 
 	ClosedAt time.Time
 
+	// AutoMerge is true if the campaign's changesets are merged automatically
+	// once their checks pass and they are approved.
+	AutoMerge bool
+
+	// AutoRebase is true if the campaign's changesets are rebased
+	// automatically when their base branch advances.
+	AutoRebase bool
+
+	// Visibility controls which users can see the campaign.
+	Visibility CampaignVisibility
+
+	// UpdatePropagation controls how re-applying the campaign changes the
+	// title and body of published changesets that were edited on the code
+	// host.
+	UpdatePropagation CampaignUpdatePropagation
+
+	// PausedAt is when the campaign was paused. The reconciler and syncer
+	// don't act on the changesets of paused campaigns.
+	PausedAt time.Time
+
+	// DiffStatAdded, DiffStatChanged and DiffStatDeleted are the totals of
+	// the diff stats of all changesets in the campaign. They're maintained by
+	// the database whenever a changeset's diff stat or campaigns change and
+	// are never written by the Store.
+	DiffStatAdded   int32
+	DiffStatChanged int32
+	DiffStatDeleted int32
+
+	// CampaignSpecUserID is the ID of the user that created the campaign's
+	// current campaign spec. It's only loaded by Store.ListCampaigns, so that
+	// lists of campaigns don't need to load the specs, and is never written.
+	CampaignSpecUserID int32
+
+	// DeletedAt is set when the campaign has been deleted. Deleted campaigns
+	// can be restored until CampaignDeletionRetention has passed, after
+	// which they're removed for good.
+	DeletedAt time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// CampaignDeletionRetention specifies how long deleted Campaigns can be
+// restored before they're removed.
+const CampaignDeletionRetention = 30 * 24 * time.Hour
+
 // Clone returns a clone of a Campaign.
 func (c *Campaign) Clone() *Campaign {
 	cc := *c
@@ -96,6 +143,27 @@ func (c *Campaign) RemoveChangesetID(id int64) {
 // Closed returns true when the ClosedAt timestamp has been set.
 func (c *Campaign) Closed() bool { return !c.ClosedAt.IsZero() }
 
+// Paused returns true when the PausedAt timestamp has been set.
+func (c *Campaign) Paused() bool { return !c.PausedAt.IsZero() }
+
+// Deleted returns true when the DeletedAt timestamp has been set.
+func (c *Campaign) Deleted() bool { return !c.DeletedAt.IsZero() }
+
+// RestorableUntil returns the time until which the deleted Campaign can be
+// restored.
+func (c *Campaign) RestorableUntil() time.Time {
+	return c.DeletedAt.Add(CampaignDeletionRetention)
+}
+
+// DiffStat returns the aggregate diff.Stat of all changesets in the campaign.
+func (c *Campaign) DiffStat() diff.Stat {
+	return diff.Stat{
+		Added:   c.DiffStatAdded,
+		Changed: c.DiffStatChanged,
+		Deleted: c.DiffStatDeleted,
+	}
+}
+
 // GenChangesetBody creates the markdown to be used as the body of a changeset.
 // It includes a URL back to the campaign on the Sourcegraph instance.
 func (c *Campaign) GenChangesetBody(externalURL string) string {
@@ -105,6 +173,560 @@ func (c *Campaign) GenChangesetBody(externalURL string) string {
 	return description
 }
 
+// CampaignVisibility defines the possible visibilities of a Campaign.
+type CampaignVisibility string
+
+// CampaignVisibility constants.
+const (
+	// CampaignVisibilityPublic campaigns are visible to all users with read
+	// access to campaigns.
+	CampaignVisibilityPublic CampaignVisibility = "PUBLIC"
+	// CampaignVisibilityNamespaceOnly campaigns are only visible to site
+	// admins, users with admin rights for the campaign and the members of
+	// the campaign's namespace.
+	CampaignVisibilityNamespaceOnly CampaignVisibility = "NAMESPACE_ONLY"
+)
+
+// Valid returns true if the given CampaignVisibility is valid.
+func (v CampaignVisibility) Valid() bool {
+	switch v {
+	case CampaignVisibilityPublic, CampaignVisibilityNamespaceOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// CampaignUpdatePropagation defines how re-applying a Campaign changes the
+// title and body of its published changesets that were edited on the code
+// host since they were last updated by Sourcegraph.
+type CampaignUpdatePropagation string
+
+// CampaignUpdatePropagation constants.
+const (
+	// CampaignUpdatePropagationOverwrite replaces edited titles and bodies
+	// with the ones from the new changeset spec.
+	CampaignUpdatePropagationOverwrite CampaignUpdatePropagation = "OVERWRITE"
+	// CampaignUpdatePropagationAppend keeps edited titles and appends the
+	// body from the new changeset spec to edited bodies.
+	CampaignUpdatePropagationAppend CampaignUpdatePropagation = "APPEND"
+	// CampaignUpdatePropagationPreserve keeps edited titles and bodies
+	// untouched.
+	CampaignUpdatePropagationPreserve CampaignUpdatePropagation = "PRESERVE"
+)
+
+// Valid returns true if the given CampaignUpdatePropagation is valid.
+func (p CampaignUpdatePropagation) Valid() bool {
+	switch p {
+	case CampaignUpdatePropagationOverwrite,
+		CampaignUpdatePropagationAppend,
+		CampaignUpdatePropagationPreserve:
+		return true
+	default:
+		return false
+	}
+}
+
+// CampaignActivityKind defines the kind of a CampaignActivity.
+type CampaignActivityKind string
+
+// Valid CampaignActivity kinds
+const (
+	CampaignActivityKindApplied             CampaignActivityKind = "APPLIED"
+	CampaignActivityKindClosed              CampaignActivityKind = "CLOSED"
+	CampaignActivityKindChangesetPublished  CampaignActivityKind = "CHANGESET_PUBLISHED"
+	CampaignActivityKindChangesetsClosed    CampaignActivityKind = "CHANGESETS_CLOSED"
+	CampaignActivityKindChangesetAutoMerged CampaignActivityKind = "CHANGESET_AUTO_MERGED"
+	CampaignActivityKindAutoMergeEnabled    CampaignActivityKind = "AUTO_MERGE_ENABLED"
+	CampaignActivityKindAutoMergeDisabled   CampaignActivityKind = "AUTO_MERGE_DISABLED"
+	CampaignActivityKindChangesetsImported  CampaignActivityKind = "CHANGESETS_IMPORTED"
+	CampaignActivityKindChangesetsDetached  CampaignActivityKind = "CHANGESETS_DETACHED"
+	CampaignActivityKindDeleted             CampaignActivityKind = "DELETED"
+	CampaignActivityKindRestored            CampaignActivityKind = "RESTORED"
+	CampaignActivityKindAutoRebaseEnabled   CampaignActivityKind = "AUTO_REBASE_ENABLED"
+	CampaignActivityKindAutoRebaseDisabled  CampaignActivityKind = "AUTO_REBASE_DISABLED"
+	CampaignActivityKindTriggered           CampaignActivityKind = "TRIGGERED"
+	CampaignActivityKindPaused              CampaignActivityKind = "PAUSED"
+	CampaignActivityKindResumed             CampaignActivityKind = "RESUMED"
+	CampaignActivityKindChangesetsArchived  CampaignActivityKind = "CHANGESETS_ARCHIVED"
+)
+
+// Valid returns true if the given CampaignActivityKind is valid.
+func (k CampaignActivityKind) Valid() bool {
+	switch k {
+	case CampaignActivityKindApplied,
+		CampaignActivityKindClosed,
+		CampaignActivityKindChangesetPublished,
+		CampaignActivityKindChangesetsClosed,
+		CampaignActivityKindChangesetAutoMerged,
+		CampaignActivityKindAutoMergeEnabled,
+		CampaignActivityKindAutoMergeDisabled,
+		CampaignActivityKindChangesetsImported,
+		CampaignActivityKindChangesetsDetached,
+		CampaignActivityKindDeleted,
+		CampaignActivityKindRestored,
+		CampaignActivityKindAutoRebaseEnabled,
+		CampaignActivityKindAutoRebaseDisabled,
+		CampaignActivityKindTriggered,
+		CampaignActivityKindPaused,
+		CampaignActivityKindResumed,
+		CampaignActivityKindChangesetsArchived:
+		return true
+	default:
+		return false
+	}
+}
+
+// CampaignTriggerAction defines what happens when an external trigger, such
+// as a code monitor, fires for a Campaign.
+type CampaignTriggerAction string
+
+// CampaignTriggerAction constants.
+const (
+	// CampaignTriggerActionReapply re-evaluates the current campaign spec of
+	// the campaign server-side and applies the result, so that changesets
+	// are created for new occurrences of what the campaign fixes.
+	CampaignTriggerActionReapply CampaignTriggerAction = "REAPPLY"
+	// CampaignTriggerActionNotify emails the author and the subscribers of
+	// the campaign.
+	CampaignTriggerActionNotify CampaignTriggerAction = "NOTIFY"
+)
+
+// Valid returns true if the given CampaignTriggerAction is valid.
+func (a CampaignTriggerAction) Valid() bool {
+	switch a {
+	case CampaignTriggerActionReapply, CampaignTriggerActionNotify:
+		return true
+	default:
+		return false
+	}
+}
+
+// A CampaignActivity is an entry in the activity log of a Campaign. It
+// records who did what to a Campaign and when.
+type CampaignActivity struct {
+	ID         int64
+	CampaignID int64
+
+	// UserID is the user that caused the activity. It's 0 if the activity
+	// was caused by Sourcegraph itself, e.g. the reconciler.
+	UserID int32
+
+	// ChangesetID is the Changeset the activity relates to, if any.
+	ChangesetID int64
+
+	Kind     CampaignActivityKind
+	Metadata map[string]interface{}
+
+	CreatedAt time.Time
+}
+
+// Clone returns a clone of a CampaignActivity.
+func (a *CampaignActivity) Clone() *CampaignActivity {
+	aa := *a
+	return &aa
+}
+
+// A UserCredential is a token of a user for a code host. Changesets of the
+// campaigns the user applied last are published with it, instead of with the
+// token of the code host's external service.
+type UserCredential struct {
+	ID     int64
+	UserID int32
+
+	// ExternalServiceType and ExternalServiceID identify the code host, just
+	// like in api.ExternalRepoSpec.
+	ExternalServiceType string
+	ExternalServiceID   string
+
+	// Username is only set for code hosts that need it in addition to the
+	// token, e.g. Bitbucket Server.
+	Username string
+	Token    string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a UserCredential.
+func (c *UserCredential) Clone() *UserCredential {
+	cc := *c
+	return &cc
+}
+
+// CampaignPermissionLevel defines the rights that a CampaignPermissionGrant
+// grants on a Campaign.
+type CampaignPermissionLevel string
+
+// CampaignPermissionLevel constants.
+const (
+	// CampaignPermissionLevelView grants the right to see the campaign,
+	// regardless of its visibility.
+	CampaignPermissionLevelView CampaignPermissionLevel = "VIEW"
+	// CampaignPermissionLevelAdmin grants admin rights for the campaign,
+	// which include the right to see it.
+	CampaignPermissionLevelAdmin CampaignPermissionLevel = "ADMIN"
+)
+
+// Valid returns true if the given CampaignPermissionLevel is valid.
+func (l CampaignPermissionLevel) Valid() bool {
+	switch l {
+	case CampaignPermissionLevelView, CampaignPermissionLevelAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// A CampaignPermissionGrant grants a user, or all members of an org, view or
+// admin rights on a single Campaign. Exactly one of UserID and OrgID is set.
+type CampaignPermissionGrant struct {
+	ID         int64
+	CampaignID int64
+
+	UserID int32
+	OrgID  int32
+
+	Level CampaignPermissionLevel
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a CampaignPermissionGrant.
+func (g *CampaignPermissionGrant) Clone() *CampaignPermissionGrant {
+	gg := *g
+	return &gg
+}
+
+// A CampaignComment is a comment that a user left on a Campaign to discuss
+// it.
+type CampaignComment struct {
+	ID         int64
+	CampaignID int64
+
+	// AuthorID is the user that wrote the comment. It's 0 if the user has
+	// been deleted.
+	AuthorID int32
+
+	Body string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a CampaignComment.
+func (c *CampaignComment) Clone() *CampaignComment {
+	cc := *c
+	return &cc
+}
+
+// A ChangesetDiffStatJob recomputes the diff stat of a Changeset from
+// gitserver after the head of the changeset changed on the code host.
+type ChangesetDiffStatJob struct {
+	ID          int64
+	ChangesetID int64
+
+	// BaseRefOid and HeadRefOid are the revisions between which the diff
+	// stat is computed.
+	BaseRefOid string
+	HeadRefOid string
+
+	State          ReconcilerState
+	FailureMessage *string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int64
+
+	// NumAttempts is the number of times the job was requeued because
+	// gitserver didn't have the head revision yet.
+	NumAttempts int64
+
+	CreatedAt time.Time
+}
+
+// RecordID is needed to implement the workerutil.Record interface.
+func (j *ChangesetDiffStatJob) RecordID() int { return int(j.ID) }
+
+// ChangesetJobType is the type of operation that a ChangesetJob runs on its
+// changeset.
+type ChangesetJobType string
+
+// ChangesetJobType constants.
+const (
+	ChangesetJobTypeMerge   ChangesetJobType = "MERGE"
+	ChangesetJobTypeClose   ChangesetJobType = "CLOSE"
+	ChangesetJobTypeComment ChangesetJobType = "COMMENT"
+	ChangesetJobTypeRetry   ChangesetJobType = "RETRY"
+)
+
+// Valid returns true if the given ChangesetJobType is valid.
+func (t ChangesetJobType) Valid() bool {
+	switch t {
+	case ChangesetJobTypeMerge,
+		ChangesetJobTypeClose,
+		ChangesetJobTypeComment,
+		ChangesetJobTypeRetry:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetJobPayload holds the arguments of a ChangesetJob that depend on
+// its type.
+type ChangesetJobPayload struct {
+	// Message is the Markdown body of the comment created by a
+	// ChangesetJobTypeComment job.
+	Message string `json:"message,omitempty"`
+}
+
+// A ChangesetJob runs an operation on a single Changeset on behalf of a user.
+// The jobs of a bulk operation share a BulkGroup.
+type ChangesetJob struct {
+	ID          int64
+	BulkGroup   string
+	UserID      int32
+	CampaignID  int64
+	ChangesetID int64
+	JobType     ChangesetJobType
+	Payload     ChangesetJobPayload
+
+	State          ReconcilerState
+	FailureMessage *string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RecordID is needed to implement the workerutil.Record interface.
+func (j *ChangesetJob) RecordID() int { return int(j.ID) }
+
+// BulkOperationState is the state of a BulkOperation, derived from the states
+// of its ChangesetJobs.
+type BulkOperationState string
+
+// BulkOperationState constants.
+const (
+	// BulkOperationStateProcessing means that some of the jobs haven't been
+	// processed yet.
+	BulkOperationStateProcessing BulkOperationState = "PROCESSING"
+	// BulkOperationStateFailed means that all jobs have been processed and
+	// at least one of them failed.
+	BulkOperationStateFailed BulkOperationState = "FAILED"
+	// BulkOperationStateCompleted means that all jobs completed successfully.
+	BulkOperationStateCompleted BulkOperationState = "COMPLETED"
+)
+
+// A BulkOperation is the group of ChangesetJobs created together to run the
+// same operation on many changesets of a campaign. It's not stored itself but
+// aggregated from its jobs.
+type BulkOperation struct {
+	// ID is the BulkGroup of the jobs.
+	ID         string
+	Type       ChangesetJobType
+	State      BulkOperationState
+	UserID     int32
+	CampaignID int64
+
+	// Progress is the share of jobs that have been processed, between 0
+	// and 1.
+	Progress       float64
+	ChangesetCount int32
+
+	CreatedAt time.Time
+	// FinishedAt is zero until all jobs have been processed.
+	FinishedAt time.Time
+}
+
+// A CampaignReapplySchedule is a cron schedule on which a Campaign is
+// re-applied, so that its spec is re-evaluated periodically.
+type CampaignReapplySchedule struct {
+	CampaignID int64
+
+	// Schedule is a cron expression with five fields, or one of the macros
+	// @hourly, @daily, @weekly and @monthly.
+	Schedule string
+
+	NextRunAt time.Time
+	LastRunAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a CampaignReapplySchedule.
+func (s *CampaignReapplySchedule) Clone() *CampaignReapplySchedule {
+	ss := *s
+	return &ss
+}
+
+// A CampaignReapplyJob re-evaluates the CampaignSpec of a Campaign and
+// applies the result. Jobs are enqueued when the campaign's
+// CampaignReapplySchedule is due.
+type CampaignReapplyJob struct {
+	ID             int64
+	CampaignID     int64
+	CampaignSpecID int64
+
+	State          ReconcilerState
+	FailureMessage *string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int64
+
+	CreatedAt time.Time
+}
+
+// RecordID is needed to implement the workerutil.Record interface.
+func (j *CampaignReapplyJob) RecordID() int { return int(j.ID) }
+
+// A CampaignSpecExecution runs the steps of a raw campaign spec server-side,
+// in every repository the spec is on, and creates a CampaignSpec with the
+// resulting ChangesetSpecs. If CampaignID is set, the CampaignSpec is applied
+// to that campaign once the execution completes.
+type CampaignSpecExecution struct {
+	ID      int64
+	RawSpec string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	UserID          int32
+
+	CampaignID     int64
+	CampaignSpecID int64
+
+	State          ReconcilerState
+	FailureMessage *string
+
+	RepositoriesTotal     int32
+	RepositoriesCompleted int32
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// CampaignNotificationEvent defines the events of a Campaign that users can
+// be notified about by email.
+type CampaignNotificationEvent string
+
+// CampaignNotificationEvent constants.
+const (
+	// CampaignNotificationEventAllPublished is sent once all changesets
+	// created by the campaign have been published.
+	CampaignNotificationEventAllPublished CampaignNotificationEvent = "ALL_PUBLISHED"
+	// CampaignNotificationEventPublicationFailed is sent for every changeset
+	// that couldn't be published.
+	CampaignNotificationEventPublicationFailed CampaignNotificationEvent = "PUBLICATION_FAILED"
+	// CampaignNotificationEventLastMerged is sent once the last open
+	// changeset of the campaign has been merged.
+	CampaignNotificationEventLastMerged CampaignNotificationEvent = "LAST_MERGED"
+)
+
+// Valid returns true if the given CampaignNotificationEvent is valid.
+func (e CampaignNotificationEvent) Valid() bool {
+	switch e {
+	case CampaignNotificationEventAllPublished,
+		CampaignNotificationEventPublicationFailed,
+		CampaignNotificationEventLastMerged:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultCampaignNotificationSettings returns the settings that apply to
+// campaigns without CampaignNotificationSettings: their author is notified
+// about all events.
+func DefaultCampaignNotificationSettings(campaignID int64) *CampaignNotificationSettings {
+	return &CampaignNotificationSettings{
+		CampaignID: campaignID,
+		Events: []CampaignNotificationEvent{
+			CampaignNotificationEventAllPublished,
+			CampaignNotificationEventPublicationFailed,
+			CampaignNotificationEventLastMerged,
+		},
+	}
+}
+
+// CampaignNotificationSettings configure which events of a Campaign are
+// emailed to the campaign's author and to its subscribers.
+type CampaignNotificationSettings struct {
+	CampaignID int64
+
+	Events        []CampaignNotificationEvent
+	SubscriberIDs []int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of CampaignNotificationSettings.
+func (s *CampaignNotificationSettings) Clone() *CampaignNotificationSettings {
+	ss := *s
+	ss.Events = append([]CampaignNotificationEvent(nil), s.Events...)
+	ss.SubscriberIDs = append([]int32(nil), s.SubscriberIDs...)
+	return &ss
+}
+
+// Notifies returns true if users are notified about the given event.
+func (s *CampaignNotificationSettings) Notifies(e CampaignNotificationEvent) bool {
+	for _, ev := range s.Events {
+		if ev == e {
+			return true
+		}
+	}
+	return false
+}
+
+// A CampaignNotification records that a notification about an event of a
+// Campaign has been sent, so that it isn't sent twice. ChangesetID is only
+// set for events that concern a single changeset.
+type CampaignNotification struct {
+	ID          int64
+	CampaignID  int64
+	ChangesetID int64
+	Event       CampaignNotificationEvent
+	CreatedAt   time.Time
+}
+
+// CampaignWebhookEvent defines the campaign and changeset lifecycle events
+// that are sent to the outgoing webhooks configured in campaigns.webhooks.
+type CampaignWebhookEvent string
+
+// CampaignWebhookEvent constants.
+const (
+	CampaignWebhookEventCampaignApplied       CampaignWebhookEvent = "campaign.applied"
+	CampaignWebhookEventCampaignClosed        CampaignWebhookEvent = "campaign.closed"
+	CampaignWebhookEventChangesetPublished    CampaignWebhookEvent = "changeset.published"
+	CampaignWebhookEventChangesetStateChanged CampaignWebhookEvent = "changeset.stateChanged"
+)
+
+// A CampaignWebhookDelivery is the payload of a CampaignWebhookEvent that's
+// delivered to a single webhook endpoint.
+type CampaignWebhookDelivery struct {
+	ID    int64
+	URL   string
+	Event CampaignWebhookEvent
+
+	Payload json.RawMessage
+
+	Attempts      int32
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredAt   time.Time
+
+	CreatedAt time.Time
+}
+
 // ChangesetPublicationState defines the possible publication states of a Changeset.
 type ChangesetPublicationState string
 
@@ -193,6 +815,38 @@ type ChangesetLabel struct {
 	Description string
 }
 
+// ChangesetCheck represents an individual check of the latest commit of a
+// changeset, e.g. a GitHub check run or commit status, a Bitbucket Server
+// build status or a GitLab pipeline.
+type ChangesetCheck struct {
+	Name  string
+	State ChangesetCheckState
+	// URL is the URL of the check on the CI system, if known.
+	URL string
+}
+
+// ChangesetReviewThread is a thread of review comments on a line of a file
+// of a changeset.
+type ChangesetReviewThread struct {
+	// Resolved is nil if the code host doesn't report whether the thread is
+	// resolved.
+	Resolved *bool
+	Path     string
+	// Line is the line the thread is attached to, or 0 if it's unknown. For
+	// GitHub, it's the line in the diff of the file.
+	Line     int
+	Comments []ChangesetReviewComment
+}
+
+// ChangesetReviewComment is a comment in a ChangesetReviewThread.
+type ChangesetReviewComment struct {
+	// Author is the username of the author on the code host.
+	Author    string
+	Body      string
+	URL       string
+	CreatedAt time.Time
+}
+
 // CampaignState defines the possible states of a Campaign
 type CampaignState string
 
@@ -202,6 +856,16 @@ const (
 	CampaignStateClosed CampaignState = "CLOSED"
 )
 
+// CampaignNamespaceType defines the possible types of the namespace of a
+// Campaign.
+type CampaignNamespaceType string
+
+const (
+	CampaignNamespaceTypeAny  CampaignNamespaceType = "ANY"
+	CampaignNamespaceTypeUser CampaignNamespaceType = "USER"
+	CampaignNamespaceTypeOrg  CampaignNamespaceType = "ORG"
+)
+
 // ChangesetReviewState defines the possible states of a Changeset's review.
 type ChangesetReviewState string
 
@@ -251,6 +915,158 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetMergeableState constants.
+type ChangesetMergeableState string
+
+const (
+	ChangesetMergeableStateUnknown     ChangesetMergeableState = "UNKNOWN"
+	ChangesetMergeableStateMergeable   ChangesetMergeableState = "MERGEABLE"
+	ChangesetMergeableStateConflicting ChangesetMergeableState = "CONFLICTING"
+)
+
+// Valid returns true if the given Changeset mergeable state is valid.
+func (s ChangesetMergeableState) Valid() bool {
+	switch s {
+	case ChangesetMergeableStateUnknown,
+		ChangesetMergeableStateMergeable,
+		ChangesetMergeableStateConflicting:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetWaitReason describes why a queued changeset hasn't been processed
+// by the reconciler yet.
+type ChangesetWaitReason string
+
+// ChangesetWaitReason constants.
+const (
+	// ChangesetWaitReasonQueue means that the changeset waits for the
+	// changesets that were queued before it.
+	ChangesetWaitReasonQueue ChangesetWaitReason = "QUEUE"
+	// ChangesetWaitReasonRateLimit means that the code host's rate limit was
+	// exceeded and the changeset won't be processed before ProcessAfter.
+	ChangesetWaitReasonRateLimit ChangesetWaitReason = "RATE_LIMIT"
+	// ChangesetWaitReasonDependency means that the changeset waits for
+	// another operation, such as the clone of its repository, to finish.
+	ChangesetWaitReasonDependency ChangesetWaitReason = "DEPENDENCY"
+	// ChangesetWaitReasonRolloutWindow means that the changeset isn't
+	// published before ProcessAfter because of the rollout windows
+	// configured by site admins.
+	ChangesetWaitReasonRolloutWindow ChangesetWaitReason = "ROLLOUT_WINDOW"
+	// ChangesetWaitReasonRetry means that processing the changeset failed
+	// with a transient error and it's retried at ProcessAfter.
+	ChangesetWaitReasonRetry ChangesetWaitReason = "RETRY"
+	// ChangesetWaitReasonPaused means that the campaign that owns the
+	// changeset is paused and the changeset isn't processed before it's
+	// resumed.
+	ChangesetWaitReasonPaused ChangesetWaitReason = "PAUSED"
+)
+
+// Valid returns true if the given ChangesetWaitReason is valid.
+func (r ChangesetWaitReason) Valid() bool {
+	switch r {
+	case ChangesetWaitReasonQueue,
+		ChangesetWaitReasonRateLimit,
+		ChangesetWaitReasonDependency,
+		ChangesetWaitReasonRolloutWindow,
+		ChangesetWaitReasonRetry,
+		ChangesetWaitReasonPaused:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReconcilerOperation is an operation that the reconciler performs on a
+// changeset to reconcile it with its ChangesetSpec.
+type ReconcilerOperation string
+
+// ReconcilerOperation constants.
+const (
+	// ReconcilerOperationPush means that the reconciler creates a commit from
+	// the diff of the ChangesetSpec and pushes it to the changeset's branch.
+	ReconcilerOperationPush ReconcilerOperation = "PUSH"
+	// ReconcilerOperationPublish means that the reconciler opens the
+	// changeset on the code host.
+	ReconcilerOperationPublish ReconcilerOperation = "PUBLISH"
+	// ReconcilerOperationUpdate means that the reconciler updates the
+	// attributes of the changeset on the code host, such as its title, body
+	// or labels.
+	ReconcilerOperationUpdate ReconcilerOperation = "UPDATE"
+)
+
+// ChangesetErrorCode is the category of an error that caused the reconciler
+// to fail processing a changeset. Unlike a failure.Class, it tells users what
+// they need to do to resolve the error.
+type ChangesetErrorCode string
+
+// ChangesetErrorCode constants.
+const (
+	// ChangesetErrorCodeAuth means that the credentials used to publish the
+	// changeset are missing, invalid, or lack the required permissions.
+	ChangesetErrorCodeAuth ChangesetErrorCode = "AUTH"
+	// ChangesetErrorCodeRateLimit means that the rate limit of the code host
+	// was exceeded.
+	ChangesetErrorCodeRateLimit ChangesetErrorCode = "RATE_LIMIT"
+	// ChangesetErrorCodeTransient means that a network error occurred that
+	// is likely to go away when retried.
+	ChangesetErrorCodeTransient ChangesetErrorCode = "TRANSIENT"
+	// ChangesetErrorCodeMergeConflict means that the diff of the changeset
+	// spec doesn't apply to the base branch.
+	ChangesetErrorCodeMergeConflict ChangesetErrorCode = "MERGE_CONFLICT"
+	// ChangesetErrorCodeRepoArchived means that the repository of the
+	// changeset is archived and thus read-only.
+	ChangesetErrorCodeRepoArchived ChangesetErrorCode = "REPO_ARCHIVED"
+	// ChangesetErrorCodeUnknown are all other errors.
+	ChangesetErrorCodeUnknown ChangesetErrorCode = "UNKNOWN"
+)
+
+// Valid returns true if the given ChangesetErrorCode is valid.
+func (c ChangesetErrorCode) Valid() bool {
+	switch c {
+	case ChangesetErrorCodeAuth,
+		ChangesetErrorCodeRateLimit,
+		ChangesetErrorCodeTransient,
+		ChangesetErrorCodeMergeConflict,
+		ChangesetErrorCodeRepoArchived,
+		ChangesetErrorCodeUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetURLState is the result of checking whether the external URL of a
+// changeset still resolves on the code host.
+type ChangesetURLState string
+
+// ChangesetURLState constants.
+const (
+	// ChangesetURLStateOK means that the URL resolves.
+	ChangesetURLStateOK ChangesetURLState = "OK"
+	// ChangesetURLStateMoved means that the URL redirects elsewhere or points
+	// to a repository with a different name, for example because the
+	// repository was renamed.
+	ChangesetURLStateMoved ChangesetURLState = "MOVED"
+	// ChangesetURLStateDead means that the changeset doesn't exist anymore on
+	// the code host, for example because it was deleted.
+	ChangesetURLStateDead ChangesetURLState = "DEAD"
+)
+
+// Valid returns true if the given ChangesetURLState is valid.
+func (s ChangesetURLState) Valid() bool {
+	switch s {
+	case ChangesetURLStateOK,
+		ChangesetURLStateMoved,
+		ChangesetURLStateDead:
+		return true
+	default:
+		return false
+	}
+}
+
 // A Changeset is a changeset on a code host belonging to a Repository and many
 // Campaigns.
 type Changeset struct {
@@ -286,13 +1102,49 @@ type Changeset struct {
 
 	PublicationState ChangesetPublicationState // "unpublished", "published"
 
+	// CustomMetadata is arbitrary key/value metadata attached to the
+	// Changeset on Sourcegraph, e.g. by integrations. It's never sent to the
+	// code host.
+	CustomMetadata map[string]string
+
 	// All of the following fields are used by workerutil.Worker.
 	ReconcilerState ReconcilerState
 	FailureMessage  *string
-	StartedAt       time.Time
-	FinishedAt      time.Time
-	ProcessAfter    time.Time
-	NumResets       int64
+	// FailureClass is the class of the error that caused FailureMessage.
+	FailureClass failure.Class
+	// FailureCode is the category of the error that caused FailureMessage.
+	FailureCode ChangesetErrorCode
+	// SkippedReason is why the reconciler didn't publish the changeset,
+	// because its repository didn't meet the PublicationPreconditions of
+	// the campaign spec. It's empty if the publication wasn't skipped.
+	SkippedReason string
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	ProcessAfter  time.Time
+	NumResets     int64
+	// ArchivedAt is when the changeset was archived in the campaign that
+	// owns it, because a newer campaign spec no longer contained it. Archived
+	// changesets stay in the campaign and are still synced, but they aren't
+	// counted as open.
+	ArchivedAt time.Time
+	// ExternalForkNamespace is the namespace of the fork that the changeset's
+	// branch was pushed to, if the changeset was published from a fork
+	// instead of from its repository.
+	ExternalForkNamespace string
+
+	// WaitReason is the reason why the reconciler requeued the changeset
+	// until ProcessAfter.
+	WaitReason ChangesetWaitReason
+	// NumFailures is the number of consecutive times the reconciler failed
+	// to process the changeset. It's reset once the changeset is processed
+	// successfully or retried manually.
+	NumFailures int64
+
+	// ExternalURLState is the result of the last check of the external URL
+	// of the changeset, at ExternalURLCheckedAt. It's empty if the URL was
+	// never checked. Both are only updated by SetChangesetURLState.
+	ExternalURLState     ChangesetURLState
+	ExternalURLCheckedAt time.Time
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -356,12 +1208,45 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeGitLab
 		c.ExternalBranch = pr.SourceBranch
 		c.ExternalUpdatedAt = pr.UpdatedAt.Time
+	case *bitbucketcloud.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.ID, 10)
+		c.ExternalServiceType = extsvc.TypeBitbucketCloud
+		c.ExternalBranch = pr.Source.Branch.Name
+		c.ExternalUpdatedAt = pr.UpdatedOn
 	default:
 		return errors.New("unknown changeset type")
 	}
 	return nil
 }
 
+// SetCustomMetadata sets all of the given key/value pairs in the Changeset's
+// CustomMetadata, overwriting existing values for the same keys.
+func (c *Changeset) SetCustomMetadata(m map[string]string) {
+	if len(m) == 0 {
+		return
+	}
+	if c.CustomMetadata == nil {
+		c.CustomMetadata = make(map[string]string, len(m))
+	}
+	for k, v := range m {
+		c.CustomMetadata[k] = v
+	}
+}
+
+// RemoveCustomMetadata removes the given key from the Changeset's
+// CustomMetadata.
+func (c *Changeset) RemoveCustomMetadata(key string) {
+	delete(c.CustomMetadata, key)
+	if len(c.CustomMetadata) == 0 {
+		c.CustomMetadata = nil
+	}
+}
+
+// Archived returns whether the changeset was archived in the campaign that
+// owns it.
+func (c *Changeset) Archived() bool { return !c.ArchivedAt.IsZero() }
+
 // RemoveCampaignID removes the given id from the Changesets CampaignIDs slice.
 // If the id is not in CampaignIDs calling this method doesn't have an effect.
 func (c *Changeset) RemoveCampaignID(id int64) {
@@ -381,6 +1266,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -397,6 +1284,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return unixMilliToTime(int64(m.CreatedDate))
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
+	case *bitbucketcloud.PullRequest:
+		return m.CreatedOn
 	default:
 		return time.Time{}
 	}
@@ -411,6 +1300,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -455,6 +1346,17 @@ func (c *Changeset) externalState() (s ChangesetExternalState, err error) {
 		default:
 			return "", errors.Errorf("unknown merge request state: %s", m.State)
 		}
+	case *bitbucketcloud.PullRequest:
+		switch m.State {
+		case bitbucketcloud.PullRequestStateOpen:
+			s = ChangesetExternalStateOpen
+		case bitbucketcloud.PullRequestStateDeclined, bitbucketcloud.PullRequestStateSuperseded:
+			s = ChangesetExternalStateClosed
+		case bitbucketcloud.PullRequestStateMerged:
+			s = ChangesetExternalStateMerged
+		default:
+			return "", errors.Errorf("unknown pull request state: %s", m.State)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -479,11 +1381,44 @@ func (c *Changeset) URL() (s string, err error) {
 		return selfLink.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Links.HTML.Href, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
 }
 
+// MergeableState returns whether the Changeset can be merged into its base
+// branch without conflicts, as last reported by the code host. Code hosts
+// compute this asynchronously, so it is
+// ChangesetMergeableStateUnknown until they have done so.
+func (c *Changeset) MergeableState() ChangesetMergeableState {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		switch m.Mergeable {
+		case "MERGEABLE":
+			return ChangesetMergeableStateMergeable
+		case "CONFLICTING":
+			return ChangesetMergeableStateConflicting
+		}
+	case *bitbucketserver.PullRequest:
+		switch m.Properties.MergeResult.Outcome {
+		case "CLEAN":
+			return ChangesetMergeableStateMergeable
+		case "CONFLICTED":
+			return ChangesetMergeableStateConflicting
+		}
+	case *gitlab.MergeRequest:
+		switch m.MergeStatus {
+		case "can_be_merged":
+			return ChangesetMergeableStateMergeable
+		case "cannot_be_merged":
+			return ChangesetMergeableStateConflicting
+		}
+	}
+	return ChangesetMergeableStateUnknown
+}
+
 // ChangesetSpecs is a slice of *ChangesetSpecs.
 type ChangesetSpecs []*ChangesetSpec
 
@@ -577,6 +1512,7 @@ func (c *Changeset) Events() (events []*ChangesetEvent) {
 			switch e := ti.Item.(type) {
 			case *github.PullRequestReviewThread:
 				for _, c := range e.Comments {
+					c.ThreadResolved = e.IsResolved
 					ev := ev
 					ev.Key = c.Key()
 					ev.Kind = ChangesetEventKindFor(c)
@@ -643,6 +1579,24 @@ func (c *Changeset) Events() (events []*ChangesetEvent) {
 				Metadata:    pipeline,
 			})
 		}
+
+	case *bitbucketcloud.PullRequest:
+		participantEvents := m.ParticipantStatusEvents()
+		events = make([]*ChangesetEvent, 0, len(participantEvents)+len(m.Statuses))
+		addEvent := func(e Keyer) {
+			events = append(events, &ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        ChangesetEventKindFor(e),
+				Metadata:    e,
+			})
+		}
+		for _, e := range participantEvents {
+			addEvent(e)
+		}
+		for _, s := range m.Statuses {
+			addEvent(s)
+		}
 	}
 	return events
 }
@@ -658,6 +1612,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Source.Commit.Hash, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -673,6 +1629,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.FromRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Source.Branch.Name, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -689,6 +1647,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Destination.Commit.Hash, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -704,6 +1664,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.ToRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Destination.Branch.Name, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -865,6 +1827,13 @@ func (e *ChangesetEvent) ReviewAuthor() (string, error) {
 		}
 		return username, nil
 
+	case *bitbucketcloud.ParticipantStatusEvent:
+		uuid := meta.User.UUID
+		if uuid == "" {
+			return "", errors.New("participant user is blank")
+		}
+		return uuid, nil
+
 	default:
 		return "", nil
 	}
@@ -874,12 +1843,18 @@ func (e *ChangesetEvent) ReviewAuthor() (string, error) {
 func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	switch e.Kind {
 	case ChangesetEventKindBitbucketServerApproved,
-		ChangesetEventKindGitLabApproved:
+		ChangesetEventKindBitbucketServerParticipantApproved,
+		ChangesetEventKindGitLabApproved,
+		ChangesetEventKindBitbucketCloudApproved:
 		return ChangesetReviewStateApproved, nil
 
+	case ChangesetEventKindBitbucketCloudChangesRequested:
+		return ChangesetReviewStateChangesRequested, nil
+
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
-	case ChangesetEventKindBitbucketServerReviewed:
+	case ChangesetEventKindBitbucketServerReviewed,
+		ChangesetEventKindBitbucketServerParticipantReviewed:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -899,7 +1874,9 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	case ChangesetEventKindGitHubReviewDismissed,
 		ChangesetEventKindBitbucketServerUnapproved,
 		ChangesetEventKindBitbucketServerDismissed,
-		ChangesetEventKindGitLabUnapproved:
+		ChangesetEventKindGitLabUnapproved,
+		ChangesetEventKindBitbucketCloudUnapproved,
+		ChangesetEventKindBitbucketCloudChangesRequestRemoved:
 		return ChangesetReviewStateDismissed, nil
 
 	default:
@@ -965,6 +1942,16 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		return ev.CreatedAt.Time
 	case *gitlab.ReviewUnapproved:
 		return ev.CreatedAt.Time
+	case *bitbucketcloud.ParticipantStatusEvent:
+		return ev.Date
+	case *bitbucketcloud.PullRequestStatus:
+		return ev.UpdatedOn
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		return ev.PullRequest.UpdatedOn
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		return ev.PullRequest.UpdatedOn
+	case *ChangesetRebase:
+		return ev.CreatedAt
 	case *gitlabwebhooks.MergeRequestCloseEvent,
 		*gitlabwebhooks.MergeRequestMergeEvent,
 		*gitlabwebhooks.MergeRequestReopenEvent,
@@ -1357,6 +2344,31 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *bitbucketcloud.ParticipantStatusEvent:
+		o := o.Metadata.(*bitbucketcloud.ParticipantStatusEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.PullRequestStatus:
+		o := o.Metadata.(*bitbucketcloud.PullRequestStatus)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		o := o.Metadata.(*bitbucketcloud.PullRequestFulfilledEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		o := o.Metadata.(*bitbucketcloud.PullRequestRejectedEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *ChangesetRebase:
+		o := o.Metadata.(*ChangesetRebase)
+		// Rebases are recorded by us in full, so safe to replace it
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
@@ -1514,6 +2526,14 @@ func ChangesetEventKindFor(e interface{}) ChangesetEventKind {
 		return ChangesetEventKindGitLabMerged
 	case *gitlabwebhooks.MergeRequestReopenEvent:
 		return ChangesetEventKindGitLabReopened
+	case *bitbucketcloud.ParticipantStatusEvent:
+		return ChangesetEventKind("bitbucketcloud:" + string(e.Action))
+	case *bitbucketcloud.PullRequestStatus:
+		return ChangesetEventKindBitbucketCloudCommitStatus
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		return ChangesetEventKindBitbucketCloudMerged
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		return ChangesetEventKindBitbucketCloudDeclined
 	default:
 		panic(errors.Errorf("unknown changeset event kind for %T", e))
 	}
@@ -1527,11 +2547,24 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		switch k {
 		case ChangesetEventKindBitbucketServerCommitStatus:
 			return new(bitbucketserver.CommitStatus), nil
-		case ChangesetEventKindBitbucketServerDismissed:
+		case ChangesetEventKindBitbucketServerDismissed,
+			ChangesetEventKindBitbucketServerParticipantApproved,
+			ChangesetEventKindBitbucketServerParticipantReviewed:
 			return new(bitbucketserver.ParticipantStatusEvent), nil
 		default:
 			return new(bitbucketserver.Activity), nil
 		}
+	case strings.HasPrefix(string(k), "bitbucketcloud"):
+		switch k {
+		case ChangesetEventKindBitbucketCloudCommitStatus:
+			return new(bitbucketcloud.PullRequestStatus), nil
+		case ChangesetEventKindBitbucketCloudMerged:
+			return new(bitbucketcloud.PullRequestFulfilledEvent), nil
+		case ChangesetEventKindBitbucketCloudDeclined:
+			return new(bitbucketcloud.PullRequestRejectedEvent), nil
+		default:
+			return new(bitbucketcloud.ParticipantStatusEvent), nil
+		}
 	case strings.HasPrefix(string(k), "github"):
 		switch k {
 		case ChangesetEventKindGitHubAssigned:
@@ -1571,6 +2604,8 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		case ChangesetEventKindCheckRun:
 			return new(github.CheckRun), nil
 		}
+	case k == ChangesetEventKindSourcegraphRebased:
+		return new(ChangesetRebase), nil
 	case strings.HasPrefix(string(k), "gitlab"):
 		switch k {
 		case ChangesetEventKindGitLabApproved:
@@ -1632,14 +2667,214 @@ const (
 	// clearly convey that it only occurs when a request for changes has been dismissed.
 	ChangesetEventKindBitbucketServerDismissed ChangesetEventKind = "bitbucketserver:participant_status:unapproved"
 
+	// Participant status events are also created for approvals and requests
+	// for changes received through native Bitbucket Server webhooks, since
+	// those don't include the activity that is created at the same time.
+	ChangesetEventKindBitbucketServerParticipantApproved ChangesetEventKind = "bitbucketserver:participant_status:approved"
+	ChangesetEventKindBitbucketServerParticipantReviewed ChangesetEventKind = "bitbucketserver:participant_status:reviewed"
+
 	ChangesetEventKindGitLabApproved   ChangesetEventKind = "gitlab:approved"
 	ChangesetEventKindGitLabClosed     ChangesetEventKind = "gitlab:closed"
 	ChangesetEventKindGitLabMerged     ChangesetEventKind = "gitlab:merged"
 	ChangesetEventKindGitLabPipeline   ChangesetEventKind = "gitlab:pipeline"
 	ChangesetEventKindGitLabReopened   ChangesetEventKind = "gitlab:reopened"
 	ChangesetEventKindGitLabUnapproved ChangesetEventKind = "gitlab:unapproved"
+
+	ChangesetEventKindBitbucketCloudApproved              ChangesetEventKind = "bitbucketcloud:approved"
+	ChangesetEventKindBitbucketCloudUnapproved            ChangesetEventKind = "bitbucketcloud:unapproved"
+	ChangesetEventKindBitbucketCloudChangesRequested      ChangesetEventKind = "bitbucketcloud:changes_requested"
+	ChangesetEventKindBitbucketCloudChangesRequestRemoved ChangesetEventKind = "bitbucketcloud:changes_request_removed"
+	ChangesetEventKindBitbucketCloudCommitStatus          ChangesetEventKind = "bitbucketcloud:commit_status"
+	ChangesetEventKindBitbucketCloudMerged                ChangesetEventKind = "bitbucketcloud:merged"
+	ChangesetEventKindBitbucketCloudDeclined              ChangesetEventKind = "bitbucketcloud:declined"
+
+	// ChangesetEventKindSourcegraphRebased events are recorded by Sourcegraph
+	// itself when it rebased the branch of a changeset.
+	ChangesetEventKindSourcegraphRebased ChangesetEventKind = "sourcegraph:rebased"
 )
 
+// ChangesetRebase is the metadata of a ChangesetEventKindSourcegraphRebased
+// event, recorded when Sourcegraph re-applied the diff of a changeset on top
+// of the current head of its base branch and force-pushed it.
+type ChangesetRebase struct {
+	// ChangesetSpecID is the ID of the ChangesetSpec whose diff was applied.
+	ChangesetSpecID int64
+	// FromBaseRev is the commit the branch was based on before the rebase.
+	FromBaseRev string
+	// ToBaseRev is the commit the branch is based on after the rebase.
+	ToBaseRev string
+	// Automatic is true if the rebase was triggered by the auto-rebase of a
+	// campaign, false if a user requested it.
+	Automatic bool
+	// UserID is the ID of the user that requested the rebase, if any.
+	UserID    int32
+	CreatedAt time.Time
+}
+
+// ChangesetEventCategory groups the ChangesetEventKinds of the different code
+// hosts by what happened, so that changeset events can be filtered by it.
+type ChangesetEventCategory string
+
+// ChangesetEventCategory constants.
+const (
+	ChangesetEventCategoryReview   ChangesetEventCategory = "REVIEW"
+	ChangesetEventCategoryComment  ChangesetEventCategory = "COMMENT"
+	ChangesetEventCategoryCommit   ChangesetEventCategory = "COMMIT"
+	ChangesetEventCategoryCheckRun ChangesetEventCategory = "CHECK_RUN"
+)
+
+// Valid returns true if the given ChangesetEventCategory is valid.
+func (c ChangesetEventCategory) Valid() bool {
+	return len(c.Kinds()) != 0
+}
+
+// Kinds returns the ChangesetEventKinds that belong to the category.
+func (c ChangesetEventCategory) Kinds() []ChangesetEventKind {
+	switch c {
+	case ChangesetEventCategoryReview:
+		return []ChangesetEventKind{
+			ChangesetEventKindGitHubReviewed,
+			ChangesetEventKindGitHubReviewDismissed,
+			ChangesetEventKindGitHubReviewRequested,
+			ChangesetEventKindGitHubReviewRequestRemoved,
+			ChangesetEventKindBitbucketServerApproved,
+			ChangesetEventKindBitbucketServerUnapproved,
+			ChangesetEventKindBitbucketServerReviewed,
+			ChangesetEventKindBitbucketServerDismissed,
+			ChangesetEventKindBitbucketServerParticipantApproved,
+			ChangesetEventKindBitbucketServerParticipantReviewed,
+			ChangesetEventKindGitLabApproved,
+			ChangesetEventKindGitLabUnapproved,
+			ChangesetEventKindBitbucketCloudApproved,
+			ChangesetEventKindBitbucketCloudUnapproved,
+			ChangesetEventKindBitbucketCloudChangesRequested,
+			ChangesetEventKindBitbucketCloudChangesRequestRemoved,
+		}
+	case ChangesetEventCategoryComment:
+		return []ChangesetEventKind{
+			ChangesetEventKindGitHubCommented,
+			ChangesetEventKindGitHubReviewCommented,
+			ChangesetEventKindBitbucketServerCommented,
+		}
+	case ChangesetEventCategoryCommit:
+		return []ChangesetEventKind{
+			ChangesetEventKindGitHubCommit,
+			ChangesetEventKindBitbucketServerRescoped,
+			ChangesetEventKindSourcegraphRebased,
+		}
+	case ChangesetEventCategoryCheckRun:
+		return []ChangesetEventKind{
+			ChangesetEventKindCommitStatus,
+			ChangesetEventKindCheckSuite,
+			ChangesetEventKindCheckRun,
+			ChangesetEventKindBitbucketServerCommitStatus,
+			ChangesetEventKindGitLabPipeline,
+			ChangesetEventKindBitbucketCloudCommitStatus,
+		}
+	default:
+		return nil
+	}
+}
+
+// ChangesetsStats are the counts of a set of changesets in each state.
+type ChangesetsStats struct {
+	Total       int32
+	Unpublished int32
+	Open        int32
+	Merged      int32
+	Closed      int32
+	// Archived counts the changesets that were archived in the campaign that
+	// owns them. They're also counted as merged or closed, but never as open.
+	Archived int32
+
+	// Conflicting, Unreviewed, Approved and ChangesRequested only count
+	// open changesets that aren't archived.
+	Conflicting      int32
+	Unreviewed       int32
+	Approved         int32
+	ChangesRequested int32
+}
+
+// Add adds the counts of other to s.
+func (s *ChangesetsStats) Add(other *ChangesetsStats) {
+	s.Total += other.Total
+	s.Unpublished += other.Unpublished
+	s.Open += other.Open
+	s.Merged += other.Merged
+	s.Closed += other.Closed
+	s.Archived += other.Archived
+	s.Conflicting += other.Conflicting
+	s.Unreviewed += other.Unreviewed
+	s.Approved += other.Approved
+	s.ChangesRequested += other.ChangesRequested
+}
+
+// CampaignsStatistics are site-wide statistics about the usage of campaigns.
+type CampaignsStatistics struct {
+	// CampaignsCount and OpenCampaignsCount don't include deleted campaigns.
+	CampaignsCount     int32
+	OpenCampaignsCount int32
+
+	// ActiveAuthorsCount is the number of distinct users that applied a
+	// campaign spec since the start of the first week.
+	ActiveAuthorsCount int32
+
+	// Weeks are the statistics of each week, oldest first.
+	Weeks []*CampaignsWeekStatistics
+	// CodeHosts are the code hosts with the most published changesets in
+	// campaigns, most first.
+	CodeHosts []*CampaignsCodeHostStatistics
+}
+
+// CampaignsWeekStatistics are the statistics of the changesets of all
+// campaigns in a single week.
+type CampaignsWeekStatistics struct {
+	// StartsAt is the start of the week, on Monday.
+	StartsAt time.Time
+
+	// ChangesetsPublished is the number of changesets that campaigns
+	// published in the week.
+	ChangesetsPublished int32
+	// ChangesetsMerged is the number of merged changesets in campaigns that
+	// were last updated on the code host in the week.
+	ChangesetsMerged int32
+}
+
+// CampaignsCodeHostStatistics are the statistics of the published changesets
+// of all campaigns on a single code host.
+type CampaignsCodeHostStatistics struct {
+	ExternalServiceType string
+	ExternalServiceID   string
+
+	ChangesetsCount       int32
+	MergedChangesetsCount int32
+}
+
+// ChangesetRepoGroup aggregates the changesets of a campaign that are in the
+// same repository.
+type ChangesetRepoGroup struct {
+	RepoID api.RepoID
+
+	Total       int32
+	Unpublished int32
+	Open        int32
+	Merged      int32
+	Closed      int32
+
+	DiffStatAdded   int32
+	DiffStatChanged int32
+	DiffStatDeleted int32
+}
+
+// DiffStat returns the summed diff stat of the changesets in the group.
+func (g *ChangesetRepoGroup) DiffStat() diff.Stat {
+	return diff.Stat{
+		Added:   g.DiffStatAdded,
+		Changed: g.DiffStatChanged,
+		Deleted: g.DiffStatDeleted,
+	}
+}
+
 // ChangesetSyncData represents data about the sync status of a changeset
 type ChangesetSyncData struct {
 	ChangesetID int64
@@ -1652,7 +2887,40 @@ type ChangesetSyncData struct {
 	// RepoExternalServiceID is the external_service_id in the repo table, usually
 	// represented by the code host URL
 	RepoExternalServiceID string
-}
+	// SyncErrorMessage is the error of the last sync of the changeset, or nil
+	// if it succeeded
+	SyncErrorMessage *string
+	// SyncPriority is derived from the recent activity in the campaigns of the
+	// changeset and determines how often it's synced
+	SyncPriority ChangesetSyncPriority
+	// WebhooksConfigured is true if an external service of the code host of
+	// the changeset's repository has webhooks configured. Such changesets
+	// are updated by webhooks and only polled by a slow safety-net pass
+	WebhooksConfigured bool
+	// MinSyncInterval and MaxSyncInterval are the bounds of the delay between
+	// two syncs configured for the code host of the changeset's repository.
+	// They're zero if the defaults apply
+	MinSyncInterval time.Duration
+	MaxSyncInterval time.Duration
+}
+
+// ChangesetSyncPriority determines how often a changeset is synced with the
+// code host.
+type ChangesetSyncPriority int
+
+// ChangesetSyncPriority constants.
+const (
+	// ChangesetSyncPriorityDormant is the priority of changesets that are no
+	// longer open and whose campaigns haven't been viewed or applied in a
+	// long time. They're synced much less often.
+	ChangesetSyncPriorityDormant ChangesetSyncPriority = -1
+	// ChangesetSyncPriorityNormal is the priority of all other changesets.
+	ChangesetSyncPriorityNormal ChangesetSyncPriority = 0
+	// ChangesetSyncPriorityActive is the priority of changesets of which at
+	// least one campaign was viewed or applied recently. They're synced more
+	// often.
+	ChangesetSyncPriorityActive ChangesetSyncPriority = 1
+)
 
 func MarshalCampaignID(id int64) graphql.ID {
 	return relay.MarshalID("Campaign", id)
@@ -1691,6 +2959,10 @@ type CampaignSpec struct {
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// PinnedAt is the time when the CampaignSpec was pinned, so that it
+	// doesn't expire. It's zero if it isn't pinned.
+	PinnedAt time.Time
 }
 
 // Clone returns a clone of a CampaignSpec.
@@ -1705,14 +2977,49 @@ func (cs *CampaignSpec) UnmarshalValidate() error {
 	return unmarshalValidate(schema.CampaignSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec)
 }
 
-// CampaignSpecTTL specifies the TTL of CampaignSpecs that haven't been applied
-// yet.
-const CampaignSpecTTL = 7 * 24 * time.Hour
+// DefaultCampaignSpecTTL specifies the TTL of CampaignSpecs that haven't been
+// applied yet, unless configured otherwise. See CampaignSpecTTL.
+const DefaultCampaignSpecTTL = 7 * 24 * time.Hour
+
+// Pinned returns whether the CampaignSpec is pinned, in which case it never
+// expires.
+func (cs *CampaignSpec) Pinned() bool {
+	return !cs.PinnedAt.IsZero()
+}
 
 // ExpiresAt returns the time when the CampaignSpec will be deleted if not
-// applied.
+// applied. It returns the zero time if the CampaignSpec is pinned.
 func (cs *CampaignSpec) ExpiresAt() time.Time {
-	return cs.CreatedAt.Add(CampaignSpecTTL)
+	if cs.Pinned() {
+		return time.Time{}
+	}
+	return cs.CreatedAt.Add(CampaignSpecTTL())
+}
+
+// DefaultSpecExpirationInterval specifies the time between two runs of the
+// job that deletes expired specs, unless configured otherwise. See
+// SpecExpirationInterval.
+const DefaultSpecExpirationInterval = 2 * time.Minute
+
+// A SpecExpirationRun is a single run of the job that deletes expired
+// ChangesetSpecs and CampaignSpecs, and deleted Campaigns that can no longer
+// be restored.
+type SpecExpirationRun struct {
+	ID int64
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	// DryRun is true if the run only determined what it would delete, in
+	// which case the counts are what would have been deleted.
+	DryRun bool
+
+	ChangesetSpecsDeleted int32
+	CampaignSpecsDeleted  int32
+	CampaignsDeleted      int32
+
+	// Error is the error that the run failed with, if any.
+	Error string
 }
 
 type CampaignSpecFields struct {
@@ -1721,11 +3028,42 @@ type CampaignSpecFields struct {
 	On                []CampaignSpecOn   `json:"on"`
 	Steps             []CampaignSpecStep `json:"steps"`
 	ChangesetTemplate ChangesetTemplate  `json:"changesetTemplate"`
+
+	ImportChangesets []CampaignSpecImportChangeset `json:"importChangesets,omitempty"`
+
+	PublicationPreconditions PublicationPreconditions `json:"publicationPreconditions,omitempty"`
+}
+
+// PublicationPreconditions are checked by the reconciler before it publishes
+// a changeset of a campaign. Changesets whose repository doesn't meet all of
+// them aren't published, and the reason is recorded on the changeset.
+type PublicationPreconditions struct {
+	// RepositoryNotArchived skips the publication of changesets in archived
+	// repositories instead of failing it.
+	RepositoryNotArchived bool `json:"repositoryNotArchived,omitempty"`
+	// BaseBranchPassing requires the latest commit of the base branch to
+	// have passing commit statuses on the code host.
+	BaseBranchPassing bool `json:"baseBranchPassing,omitempty"`
+	// SearchMatches are search queries that all need to have results in the
+	// repository at the base revision of the changeset.
+	SearchMatches []string `json:"searchMatches,omitempty"`
+}
+
+// Empty returns true if no preconditions are set.
+func (p PublicationPreconditions) Empty() bool {
+	return !p.RepositoryNotArchived && !p.BaseBranchPassing && len(p.SearchMatches) == 0
 }
 
 type CampaignSpecOn struct {
 	RepositoriesMatchingQuery string `json:"repositoriesMatchingQuery,omitempty"`
 	Repository                string `json:"repository,omitempty"`
+	Branch                    string `json:"branch,omitempty"`
+}
+
+type CampaignSpecImportChangeset struct {
+	Repository string `json:"repository"`
+	// ExternalIDs are either strings or numbers.
+	ExternalIDs []interface{} `json:"externalIDs"`
 }
 
 type CampaignSpecStep struct {
@@ -1735,17 +3073,159 @@ type CampaignSpecStep struct {
 }
 
 type ChangesetTemplate struct {
-	Title     string         `json:"title"`
-	Body      string         `json:"body"`
-	Branch    string         `json:"branch"`
-	Commit    CommitTemplate `json:"commit"`
-	Published bool           `json:"published"`
+	Title          string            `json:"title"`
+	Body           string            `json:"body"`
+	Branch         string            `json:"branch"`
+	Commit         CommitTemplate    `json:"commit"`
+	Published      bool              `json:"published"`
+	CustomMetadata map[string]string `json:"customMetadata,omitempty"`
+
+	RequestCodeOwnerReviews bool     `json:"requestCodeOwnerReviews,omitempty"`
+	Reviewers               []string `json:"reviewers,omitempty"`
+	Assignees               []string `json:"assignees,omitempty"`
+	Labels                  []string `json:"labels,omitempty"`
+	Milestone               string   `json:"milestone,omitempty"`
 }
 
 type CommitTemplate struct {
 	Message string `json:"message"`
 }
 
+// A CampaignTemplate is a reusable campaign spec in which values can be left
+// open as parameters. CampaignSpecs are created from it by substituting values
+// for the parameters.
+type CampaignTemplate struct {
+	ID int64
+
+	Name        string
+	Description string
+
+	// Template is the raw campaign spec, in which parameters are referenced
+	// as ${{ parameters.NAME }}.
+	Template   string
+	Parameters []CampaignTemplateParameter
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	// CreatorID is 0 if the user that created the template has been deleted.
+	CreatorID int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// A CampaignTemplateParameter is a value that's substituted into a
+// CampaignTemplate when it's instantiated. Parameters without a default value
+// are required.
+type CampaignTemplateParameter struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+// Required returns true if a value must be given for the parameter when
+// instantiating the template.
+func (p CampaignTemplateParameter) Required() bool { return p.Default == nil }
+
+var (
+	campaignTemplateParameterNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	campaignTemplateParameterRefRegex  = regexp.MustCompile(`\$\{\{\s*parameters\.([^\s}]*)\s*\}\}`)
+)
+
+// Clone returns a clone of a CampaignTemplate.
+func (t *CampaignTemplate) Clone() *CampaignTemplate {
+	tt := *t
+	tt.Parameters = append([]CampaignTemplateParameter(nil), t.Parameters...)
+	return &tt
+}
+
+// Validate checks that the names of the template's parameters are valid and
+// unique and that the template only references declared parameters.
+func (t *CampaignTemplate) Validate() error {
+	var errs *multierror.Error
+
+	if strings.TrimSpace(t.Name) == "" {
+		errs = multierror.Append(errs, errors.New("campaign template name cannot be blank"))
+	}
+
+	declared := make(map[string]struct{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !campaignTemplateParameterNameRegex.MatchString(p.Name) {
+			errs = multierror.Append(errs, fmt.Errorf("invalid parameter name %q", p.Name))
+		}
+		if _, ok := declared[p.Name]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("duplicate parameter %q", p.Name))
+		}
+		declared[p.Name] = struct{}{}
+	}
+
+	for _, name := range t.referencedParameters() {
+		if _, ok := declared[name]; !ok {
+			errs = multierror.Append(errs, fmt.Errorf("template references undeclared parameter %q", name))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// referencedParameters returns the names of the parameters referenced in the
+// template, in order of first occurrence.
+func (t *CampaignTemplate) referencedParameters() []string {
+	var names []string
+	seen := map[string]struct{}{}
+	for _, m := range campaignTemplateParameterRefRegex.FindAllStringSubmatch(t.Template, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// Instantiate returns the raw campaign spec that results from substituting
+// the given values, falling back to the defaults, for the parameters
+// referenced in the template. It returns an error if a value is given for an
+// unknown parameter or if no value is given for a required parameter.
+func (t *CampaignTemplate) Instantiate(values map[string]string) (string, error) {
+	var errs *multierror.Error
+
+	params := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if v, ok := values[p.Name]; ok {
+			params[p.Name] = v
+		} else if !p.Required() {
+			params[p.Name] = *p.Default
+		} else {
+			errs = multierror.Append(errs, fmt.Errorf("missing value for required parameter %q", p.Name))
+		}
+	}
+
+	var unknown []string
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = multierror.Append(errs, fmt.Errorf("unknown parameter %q", name))
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return "", err
+	}
+
+	return campaignTemplateParameterRefRegex.ReplaceAllStringFunc(t.Template, func(ref string) string {
+		name := campaignTemplateParameterRefRegex.FindStringSubmatch(ref)[1]
+		if v, ok := params[name]; ok {
+			return v
+		}
+		return ref
+	}), nil
+}
+
 func NewChangesetSpecFromRaw(rawSpec string) (*ChangesetSpec, error) {
 	c := &ChangesetSpec{RawSpec: rawSpec}
 
@@ -1845,18 +3325,19 @@ func (cs *ChangesetSpec) UnmarshalValidate() error {
 	return nil
 }
 
-// ChangesetSpecTTL specifies the TTL of ChangesetSpecs that haven't been
-// attached to a CampaignSpec.
-// It's lower than CampaignSpecTTL because ChangesetSpecs should be attached to
-// a CampaignSpec immediately after having been created, whereas a CampaignSpec
-// might take a while to be complete and might also go through a lengthy review
-// phase.
-const ChangesetSpecTTL = 2 * 24 * time.Hour
+// DefaultChangesetSpecTTL specifies the TTL of ChangesetSpecs that haven't
+// been attached to a CampaignSpec, unless configured otherwise. See
+// ChangesetSpecTTL.
+// It's lower than DefaultCampaignSpecTTL because ChangesetSpecs should be
+// attached to a CampaignSpec immediately after having been created, whereas a
+// CampaignSpec might take a while to be complete and might also go through a
+// lengthy review phase.
+const DefaultChangesetSpecTTL = 2 * 24 * time.Hour
 
 // ExpiresAt returns the time when the ChangesetSpec will be deleted if not
 // attached to a CampaignSpec.
 func (cs *ChangesetSpec) ExpiresAt() time.Time {
-	return cs.CreatedAt.Add(ChangesetSpecTTL)
+	return cs.CreatedAt.Add(ChangesetSpecTTL())
 }
 
 // ErrHeadBaseMismatch is returned by (*ChangesetSpec).UnmarshalValidate() if
@@ -1884,6 +3365,25 @@ type ChangesetSpecDescription struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published bool `json:"published,omitempty"`
+
+	// CustomMetadata is attached to the Changeset when the spec is applied.
+	CustomMetadata map[string]string `json:"customMetadata,omitempty"`
+
+	// RequestCodeOwnerReviews is whether reviews are requested from the
+	// owners of the changed files when the changeset is published.
+	RequestCodeOwnerReviews bool `json:"requestCodeOwnerReviews,omitempty"`
+
+	// Reviewers are the users (and, on GitHub, "{org}/{team}" teams) that
+	// reviews of the changeset are requested from.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// Assignees are the users that the changeset is assigned to.
+	Assignees []string `json:"assignees,omitempty"`
+
+	// Labels are added to the changeset on the code host.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone is the title of the milestone of the changeset on the code
+	// host.
+	Milestone string `json:"milestone,omitempty"`
 }
 
 // Type returns the ChangesetSpecDescriptionType of the ChangesetSpecDescription.
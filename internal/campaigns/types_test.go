@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -338,6 +339,59 @@ func TestChangesetEvents(t *testing.T) {
 		})
 	}
 
+	{ // Bitbucket Cloud
+		alice := bitbucketcloud.Account{UUID: "{alice}"}
+		bob := bitbucketcloud.Account{UUID: "{bob}"}
+		carol := bitbucketcloud.Account{UUID: "{carol}"}
+
+		status := &bitbucketcloud.PullRequestStatus{CommitStatus: bitbucketcloud.CommitStatus{Key: "build"}}
+
+		pr := &bitbucketcloud.PullRequest{
+			Participants: []bitbucketcloud.Participant{
+				{User: alice, State: bitbucketcloud.ParticipantStateApproved, ParticipatedOn: time.Unix(10, 0)},
+				{User: bob, State: bitbucketcloud.ParticipantStateChangesRequested, ParticipatedOn: time.Unix(20, 0)},
+				{User: carol, Role: "REVIEWER"},
+			},
+			Statuses: []*bitbucketcloud.PullRequestStatus{status},
+		}
+
+		cases = append(cases, testCase{
+			name: "bitbucketcloud",
+			changeset: Changeset{
+				ID:       42,
+				Metadata: pr,
+			},
+			events: []*ChangesetEvent{
+				{
+					ChangesetID: 42,
+					Kind:        ChangesetEventKindBitbucketCloudApproved,
+					Key:         "approved:{alice}",
+					Metadata: &bitbucketcloud.ParticipantStatusEvent{
+						User:   alice,
+						Action: bitbucketcloud.ParticipantStatusActionApproved,
+						Date:   time.Unix(10, 0),
+					},
+				},
+				{
+					ChangesetID: 42,
+					Kind:        ChangesetEventKindBitbucketCloudChangesRequested,
+					Key:         "changes_requested:{bob}",
+					Metadata: &bitbucketcloud.ParticipantStatusEvent{
+						User:   bob,
+						Action: bitbucketcloud.ParticipantStatusActionChangesRequested,
+						Date:   time.Unix(20, 0),
+					},
+				},
+				{
+					ChangesetID: 42,
+					Kind:        ChangesetEventKindBitbucketCloudCommitStatus,
+					Key:         "build",
+					Metadata:    status,
+				},
+			},
+		})
+	}
+
 	for _, tc := range cases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -509,6 +563,22 @@ func TestChangeset_SetMetadata(t *testing.T) {
 				ExternalUpdatedAt:   time.Unix(10, 0),
 			},
 		},
+		"Bitbucket Cloud": {
+			meta: func() *bitbucketcloud.PullRequest {
+				pr := &bitbucketcloud.PullRequest{
+					ID:        12345,
+					UpdatedOn: time.Unix(10, 0),
+				}
+				pr.Source.Branch.Name = "branch"
+				return pr
+			}(),
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeBitbucketCloud,
+				ExternalBranch:      "branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			have := &Changeset{}
@@ -537,6 +607,9 @@ func TestChangeset_Title(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			Title: want,
 		},
+		"Bitbucket Cloud": &bitbucketcloud.PullRequest{
+			Title: want,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -668,6 +741,30 @@ func TestChangeset_externalState(t *testing.T) {
 			},
 			want: ChangesetExternalStateMerged,
 		},
+		"Bitbucket Cloud: open": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateOpen,
+			},
+			want: ChangesetExternalStateOpen,
+		},
+		"Bitbucket Cloud: declined": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateDeclined,
+			},
+			want: ChangesetExternalStateClosed,
+		},
+		"Bitbucket Cloud: superseded": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateSuperseded,
+			},
+			want: ChangesetExternalStateClosed,
+		},
+		"Bitbucket Cloud: merged": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateMerged,
+			},
+			want: ChangesetExternalStateMerged,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -750,6 +847,37 @@ func TestChangeset_URL(t *testing.T) {
 	})
 }
 
+func TestChangeset_MergeableState(t *testing.T) {
+	bbsPR := func(outcome string) *bitbucketserver.PullRequest {
+		pr := &bitbucketserver.PullRequest{}
+		pr.Properties.MergeResult.Outcome = outcome
+		return pr
+	}
+
+	for name, tc := range map[string]struct {
+		meta interface{}
+		want ChangesetMergeableState
+	}{
+		"bitbucketserver clean":      {meta: bbsPR("CLEAN"), want: ChangesetMergeableStateMergeable},
+		"bitbucketserver conflicted": {meta: bbsPR("CONFLICTED"), want: ChangesetMergeableStateConflicting},
+		"bitbucketserver unknown":    {meta: bbsPR(""), want: ChangesetMergeableStateUnknown},
+		"GitHub mergeable":           {meta: &github.PullRequest{Mergeable: "MERGEABLE"}, want: ChangesetMergeableStateMergeable},
+		"GitHub conflicting":         {meta: &github.PullRequest{Mergeable: "CONFLICTING"}, want: ChangesetMergeableStateConflicting},
+		"GitHub unknown":             {meta: &github.PullRequest{Mergeable: "UNKNOWN"}, want: ChangesetMergeableStateUnknown},
+		"GitLab can be merged":       {meta: &gitlab.MergeRequest{MergeStatus: "can_be_merged"}, want: ChangesetMergeableStateMergeable},
+		"GitLab cannot be merged":    {meta: &gitlab.MergeRequest{MergeStatus: "cannot_be_merged"}, want: ChangesetMergeableStateConflicting},
+		"GitLab unchecked":           {meta: &gitlab.MergeRequest{MergeStatus: "unchecked"}, want: ChangesetMergeableStateUnknown},
+		"unknown changeset type":     {meta: nil, want: ChangesetMergeableStateUnknown},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			if have := c.MergeableState(); have != tc.want {
+				t.Errorf("unexpected mergeable state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_HeadRefOid(t *testing.T) {
 	for name, tc := range map[string]struct {
 		meta interface{}
@@ -1184,3 +1312,118 @@ changesetTemplate:
 		})
 	}
 }
+
+func TestCampaignTemplate(t *testing.T) {
+	defaultBranch := "main"
+	tmpl := &CampaignTemplate{
+		Name: "bump-dependency",
+		Template: `name: bump-${{ parameters.dependency }}
+on:
+  - repositoriesMatchingQuery: ${{parameters.dependency}} file:package.json
+changesetTemplate:
+  branch: ${{ parameters.branch }}
+  body: ${{ parameters.unknown }}`,
+		Parameters: []CampaignTemplateParameter{
+			{Name: "dependency", Description: "The dependency to bump"},
+			{Name: "branch", Default: &defaultBranch},
+		},
+	}
+
+	t.Run("Validate", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			mutate func(*CampaignTemplate)
+			err    string
+		}{
+			{
+				name:   "undeclared parameter",
+				mutate: func(t *CampaignTemplate) {},
+				err:    "1 error occurred:\n\t* template references undeclared parameter \"unknown\"\n\n",
+			},
+			{
+				name: "valid",
+				mutate: func(t *CampaignTemplate) {
+					t.Parameters = append(t.Parameters, CampaignTemplateParameter{Name: "unknown"})
+				},
+			},
+			{
+				name: "invalid and duplicate names",
+				mutate: func(t *CampaignTemplate) {
+					t.Name = " "
+					t.Parameters = append(t.Parameters,
+						CampaignTemplateParameter{Name: "unknown"},
+						CampaignTemplateParameter{Name: "branch"},
+						CampaignTemplateParameter{Name: "1st"},
+					)
+				},
+				err: "3 errors occurred:\n\t* campaign template name cannot be blank\n\t* duplicate parameter \"branch\"\n\t* invalid parameter name \"1st\"\n\n",
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				tt := tmpl.Clone()
+				tc.mutate(tt)
+
+				haveErr := fmt.Sprintf("%v", tt.Validate())
+				if haveErr == "<nil>" {
+					haveErr = ""
+				}
+				if diff := cmp.Diff(tc.err, haveErr); diff != "" {
+					t.Fatalf("unexpected error (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Instantiate", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			values map[string]string
+			want   string
+			err    string
+		}{
+			{
+				name:   "defaults",
+				values: map[string]string{"dependency": "lodash"},
+				want: `name: bump-lodash
+on:
+  - repositoriesMatchingQuery: lodash file:package.json
+changesetTemplate:
+  branch: main
+  body: ${{ parameters.unknown }}`,
+			},
+			{
+				name:   "override default",
+				values: map[string]string{"dependency": "lodash", "branch": "bump-lodash"},
+				want: `name: bump-lodash
+on:
+  - repositoriesMatchingQuery: lodash file:package.json
+changesetTemplate:
+  branch: bump-lodash
+  body: ${{ parameters.unknown }}`,
+			},
+			{
+				name:   "missing and unknown values",
+				values: map[string]string{"unknown": "foo", "other": "bar"},
+				err:    "3 errors occurred:\n\t* missing value for required parameter \"dependency\"\n\t* unknown parameter \"other\"\n\t* unknown parameter \"unknown\"\n\n",
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				have, err := tmpl.Instantiate(tc.values)
+				haveErr := fmt.Sprintf("%v", err)
+				if haveErr == "<nil>" {
+					haveErr = ""
+				}
+				if diff := cmp.Diff(tc.err, haveErr); diff != "" {
+					t.Fatalf("unexpected error (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatalf("unexpected spec (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package bitbucketcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const eventKeyHeader = "X-Event-Key"
+
+// WebhookEventKey returns the event key of the webhook request, such as
+// "pullrequest:approved".
+func WebhookEventKey(r *http.Request) string {
+	return r.Header.Get(eventKeyHeader)
+}
+
+// ParseWebhookEvent parses the payload of a webhook request with the given
+// event key.
+func ParseWebhookEvent(eventKey string, payload []byte) (e interface{}, err error) {
+	switch eventKey {
+	case "pullrequest:approved":
+		e = &PullRequestApprovedEvent{}
+	case "pullrequest:unapproved":
+		e = &PullRequestUnapprovedEvent{}
+	case "pullrequest:changes_request_created":
+		e = &PullRequestChangesRequestCreatedEvent{}
+	case "pullrequest:changes_request_removed":
+		e = &PullRequestChangesRequestRemovedEvent{}
+	case "pullrequest:fulfilled":
+		e = &PullRequestFulfilledEvent{}
+	case "pullrequest:rejected":
+		e = &PullRequestRejectedEvent{}
+	case "pullrequest:updated":
+		e = &PullRequestUpdatedEvent{}
+	case "repo:commit_status_created", "repo:commit_status_updated":
+		e = &RepoCommitStatusEvent{}
+	default:
+		return nil, fmt.Errorf("unknown webhook event key: %q", eventKey)
+	}
+	return e, json.Unmarshal(payload, e)
+}
+
+// PullRequestEvent contains the fields that all pull request webhook events
+// have in common.
+type PullRequestEvent struct {
+	Actor       Account     `json:"actor"`
+	PullRequest PullRequest `json:"pullrequest"`
+	Repository  Repo        `json:"repository"`
+}
+
+// Review is the approval or request for changes of a webhook event.
+type Review struct {
+	Date time.Time `json:"date"`
+	User Account   `json:"user"`
+}
+
+type PullRequestApprovedEvent struct {
+	PullRequestEvent
+	Approval Review `json:"approval"`
+}
+
+type PullRequestUnapprovedEvent struct {
+	PullRequestEvent
+	Approval Review `json:"approval"`
+}
+
+type PullRequestChangesRequestCreatedEvent struct {
+	PullRequestEvent
+	ChangesRequest Review `json:"changes_request"`
+}
+
+type PullRequestChangesRequestRemovedEvent struct {
+	PullRequestEvent
+	ChangesRequest Review `json:"changes_request"`
+}
+
+type PullRequestFulfilledEvent struct{ PullRequestEvent }
+
+// Key returns the key of the event. A pull request can only be merged once.
+func (e *PullRequestFulfilledEvent) Key() string {
+	return fmt.Sprintf("fulfilled:%d", e.PullRequest.ID)
+}
+
+type PullRequestRejectedEvent struct{ PullRequestEvent }
+
+// Key returns the key of the event. Declined pull requests can't be
+// reopened on Bitbucket Cloud.
+func (e *PullRequestRejectedEvent) Key() string {
+	return fmt.Sprintf("rejected:%d", e.PullRequest.ID)
+}
+
+type PullRequestUpdatedEvent struct{ PullRequestEvent }
+
+// RepoCommitStatusEvent is sent when a commit status in a repository is
+// created or updated. It doesn't contain the pull requests of the commit.
+type RepoCommitStatusEvent struct {
+	Repository   Repo              `json:"repository"`
+	CommitStatus PullRequestStatus `json:"commit_status"`
+}
+
+// ParticipantStatusAction is the change of the review status of a
+// participant of a pull request.
+type ParticipantStatusAction string
+
+// ParticipantStatusAction constants.
+const (
+	ParticipantStatusActionApproved              ParticipantStatusAction = "approved"
+	ParticipantStatusActionUnapproved            ParticipantStatusAction = "unapproved"
+	ParticipantStatusActionChangesRequested      ParticipantStatusAction = "changes_requested"
+	ParticipantStatusActionChangesRequestRemoved ParticipantStatusAction = "changes_request_removed"
+)
+
+// ParticipantStatusEvent is a change of the review status of a participant
+// of a pull request. It's derived from the participants of a synced pull
+// request or from the review webhook events.
+type ParticipantStatusEvent struct {
+	User   Account                 `json:"user"`
+	Action ParticipantStatusAction `json:"action"`
+	Date   time.Time               `json:"date"`
+}
+
+// Key returns the key of the event. Only the latest status change of each
+// kind is kept per user.
+func (e *ParticipantStatusEvent) Key() string {
+	return fmt.Sprintf("%s:%s", e.Action, e.User.UUID)
+}
+
+// ParticipantStatusEvents returns the ParticipantStatusEvents of the
+// participants of the pull request that have reviewed it.
+func (pr *PullRequest) ParticipantStatusEvents() []*ParticipantStatusEvent {
+	var events []*ParticipantStatusEvent
+	for _, p := range pr.Participants {
+		var action ParticipantStatusAction
+		switch p.State {
+		case ParticipantStateApproved:
+			action = ParticipantStatusActionApproved
+		case ParticipantStateChangesRequested:
+			action = ParticipantStatusActionChangesRequested
+		default:
+			continue
+		}
+		events = append(events, &ParticipantStatusEvent{
+			User:   p.User,
+			Action: action,
+			Date:   p.ParticipatedOn,
+		})
+	}
+	return events
+}
+
+// ToParticipantStatusEvent returns the ParticipantStatusEvent of the review
+// webhook event e, or nil if e isn't one.
+func ToParticipantStatusEvent(e interface{}) *ParticipantStatusEvent {
+	switch e := e.(type) {
+	case *PullRequestApprovedEvent:
+		return &ParticipantStatusEvent{User: e.Approval.User, Action: ParticipantStatusActionApproved, Date: e.Approval.Date}
+	case *PullRequestUnapprovedEvent:
+		return &ParticipantStatusEvent{User: e.Approval.User, Action: ParticipantStatusActionUnapproved, Date: e.Approval.Date}
+	case *PullRequestChangesRequestCreatedEvent:
+		return &ParticipantStatusEvent{User: e.ChangesRequest.User, Action: ParticipantStatusActionChangesRequested, Date: e.ChangesRequest.Date}
+	case *PullRequestChangesRequestRemovedEvent:
+		return &ParticipantStatusEvent{User: e.ChangesRequest.User, Action: ParticipantStatusActionChangesRequestRemoved, Date: e.ChangesRequest.Date}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package bitbucketcloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	payload := `{
+		"pullrequest": {"id": 1},
+		"repository": {"uuid": "{repo}"},
+		"approval": {"date": "2020-06-01T10:00:00Z", "user": {"uuid": "{alice}"}}
+	}`
+
+	e, err := ParseWebhookEvent("pullrequest:approved", []byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	approved, ok := e.(*PullRequestApprovedEvent)
+	if !ok {
+		t.Fatalf("unexpected event type %T", e)
+	}
+	if approved.PullRequest.ID != 1 || approved.Repository.UUID != "{repo}" {
+		t.Errorf("unexpected event: %+v", approved)
+	}
+
+	want := &ParticipantStatusEvent{
+		User:   Account{UUID: "{alice}"},
+		Action: ParticipantStatusActionApproved,
+		Date:   time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(want, ToParticipantStatusEvent(e)); diff != "" {
+		t.Errorf("unexpected participant status event: %s", diff)
+	}
+
+	if _, err := ParseWebhookEvent("repo:push", []byte(`{}`)); err == nil {
+		t.Error("unexpected nil error for unknown event key")
+	}
+}
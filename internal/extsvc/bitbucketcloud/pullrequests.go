@@ -0,0 +1,264 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrPullRequestNotFound is returned by FindOpenPullRequest if there is no
+// open pull request between the given branches.
+var ErrPullRequestNotFound = errors.New("pull request not found")
+
+// PullRequestState is the state of a Bitbucket Cloud pull request.
+type PullRequestState string
+
+// PullRequestState constants.
+const (
+	PullRequestStateOpen       PullRequestState = "OPEN"
+	PullRequestStateMerged     PullRequestState = "MERGED"
+	PullRequestStateDeclined   PullRequestState = "DECLINED"
+	PullRequestStateSuperseded PullRequestState = "SUPERSEDED"
+)
+
+// PullRequest is a Bitbucket Cloud pull request.
+type PullRequest struct {
+	ID                int64               `json:"id"`
+	Title             string              `json:"title"`
+	Description       string              `json:"description"`
+	State             PullRequestState    `json:"state"`
+	Author            Account             `json:"author"`
+	Source            PullRequestEndpoint `json:"source"`
+	Destination       PullRequestEndpoint `json:"destination"`
+	Participants      []Participant       `json:"participants"`
+	CloseSourceBranch bool                `json:"close_source_branch"`
+	Links             Links               `json:"links"`
+	CreatedOn         time.Time           `json:"created_on"`
+	UpdatedOn         time.Time           `json:"updated_on"`
+
+	// Statuses are the commit statuses of the source commit. They're not
+	// part of the pull request returned by the API and have to be loaded
+	// with GetPullRequestStatuses.
+	Statuses []*PullRequestStatus `json:"statuses,omitempty"`
+}
+
+// PullRequestEndpoint is the source or destination of a pull request.
+type PullRequestEndpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		UUID     string `json:"uuid"`
+	} `json:"repository"`
+}
+
+// Account is a Bitbucket Cloud user or team.
+type Account struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+}
+
+// ParticipantState is the review state of a participant of a pull request.
+type ParticipantState string
+
+// ParticipantState constants. Participants that haven't reviewed the pull
+// request have an empty state.
+const (
+	ParticipantStateApproved         ParticipantState = "approved"
+	ParticipantStateChangesRequested ParticipantState = "changes_requested"
+)
+
+// Participant is a reviewer or participant of a pull request.
+type Participant struct {
+	User           Account          `json:"user"`
+	Role           string           `json:"role"`
+	Approved       bool             `json:"approved"`
+	State          ParticipantState `json:"state"`
+	ParticipatedOn time.Time        `json:"participated_on"`
+}
+
+// CommitStatus is a commit status, such as a build result.
+type CommitStatus struct {
+	UUID        string    `json:"uuid"`
+	Key         string    `json:"key"`
+	RefName     string    `json:"refname"`
+	URL         string    `json:"url"`
+	State       string    `json:"state"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedOn   time.Time `json:"created_on"`
+	UpdatedOn   time.Time `json:"updated_on"`
+	Commit      struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+}
+
+// PullRequestStatus is a CommitStatus of the source commit of a pull
+// request.
+type PullRequestStatus struct {
+	CommitStatus
+}
+
+// Key returns the key of the commit status, which identifies the build
+// across commits.
+func (s *PullRequestStatus) Key() string {
+	return s.CommitStatus.Key
+}
+
+// PullRequestInput is the input to create or update a pull request.
+type PullRequestInput struct {
+	Title       string
+	Description string
+
+	// SourceBranch is the branch the pull request merges from.
+	SourceBranch string
+	// SourceRepo is the full name of the repository that contains
+	// SourceBranch, if it's a fork. If empty, SourceBranch is in the
+	// repository the pull request is opened in.
+	SourceRepo string
+	// DestinationBranch is the branch the pull request merges into.
+	DestinationBranch string
+}
+
+// MarshalJSON implements json.Marshaler and returns the request body that
+// the Bitbucket Cloud API expects.
+func (in PullRequestInput) MarshalJSON() ([]byte, error) {
+	type branch struct {
+		Name string `json:"name"`
+	}
+	type repository struct {
+		FullName string `json:"full_name"`
+	}
+	type endpoint struct {
+		Branch     branch      `json:"branch"`
+		Repository *repository `json:"repository,omitempty"`
+	}
+
+	body := struct {
+		Title       string    `json:"title"`
+		Description string    `json:"description"`
+		Source      *endpoint `json:"source,omitempty"`
+		Destination *endpoint `json:"destination,omitempty"`
+	}{
+		Title:       in.Title,
+		Description: in.Description,
+	}
+	if in.SourceBranch != "" {
+		body.Source = &endpoint{Branch: branch{Name: in.SourceBranch}}
+		if in.SourceRepo != "" {
+			body.Source.Repository = &repository{FullName: in.SourceRepo}
+		}
+	}
+	if in.DestinationBranch != "" {
+		body.Destination = &endpoint{Branch: branch{Name: in.DestinationBranch}}
+	}
+
+	return json.Marshal(body)
+}
+
+// CreatePullRequest opens a new pull request in the given repository.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repo, in PullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestsPath(repo), in, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequest returns the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "GET", pullRequestPath(repo, id), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// FindOpenPullRequest returns the open pull request in the given repository
+// that merges the source branch into the destination branch. If there is
+// none, ErrPullRequestNotFound is returned.
+func (c *Client) FindOpenPullRequest(ctx context.Context, repo *Repo, source, destination string) (*PullRequest, error) {
+	qry := url.Values{}
+	qry.Set("q", fmt.Sprintf(
+		"source.branch.name = %q AND destination.branch.name = %q AND state = %q",
+		source, destination, PullRequestStateOpen,
+	))
+
+	var prs []*PullRequest
+	if _, err := c.page(ctx, pullRequestsPath(repo), qry, nil, &prs); err != nil {
+		return nil, err
+	}
+
+	if len(prs) == 0 {
+		return nil, ErrPullRequestNotFound
+	}
+	return prs[0], nil
+}
+
+// UpdatePullRequest updates the title, description and destination branch
+// of the pull request with the given ID.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo *Repo, id int64, in PullRequestInput) (*PullRequest, error) {
+	// The source of a pull request can't be changed.
+	in.SourceBranch, in.SourceRepo = "", ""
+
+	var pr PullRequest
+	if err := c.send(ctx, "PUT", pullRequestPath(repo, id), in, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// DeclinePullRequest declines the pull request with the given ID.
+func (c *Client) DeclinePullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestPath(repo, id)+"/decline", nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges the pull request with the given ID into its
+// destination branch.
+func (c *Client) MergePullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestPath(repo, id)+"/merge", nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequestStatuses returns all commit statuses of the source commit of
+// the pull request with the given ID.
+func (c *Client) GetPullRequestStatuses(ctx context.Context, repo *Repo, id int64) ([]*PullRequestStatus, error) {
+	var statuses []*PullRequestStatus
+
+	page, err := c.page(ctx, pullRequestPath(repo, id)+"/statuses", nil, &PageToken{Pagelen: 100}, &statuses)
+	for err == nil && page.HasMore() {
+		var batch []*PullRequestStatus
+		page, err = c.reqPage(ctx, page.Next, &batch)
+		statuses = append(statuses, batch...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func pullRequestsPath(repo *Repo) string {
+	return fmt.Sprintf("/2.0/repositories/%s/pullrequests", repo.FullName)
+}
+
+func pullRequestPath(repo *Repo, id int64) string {
+	return fmt.Sprintf("%s/%d", pullRequestsPath(repo), id)
+}
@@ -0,0 +1,154 @@
+package bitbucketcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func newMockClient(t *testing.T, handle func(r *http.Request) (int, string)) *Client {
+	t.Helper()
+
+	doer := httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		code, body := handle(r)
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	return NewClient(&url.URL{Scheme: "https", Host: "api.bitbucket.example"}, doer)
+}
+
+func TestPullRequestInput_MarshalJSON(t *testing.T) {
+	in := PullRequestInput{
+		Title:             "title",
+		Description:       "description",
+		SourceBranch:      "feature",
+		SourceRepo:        "fork/repo",
+		DestinationBranch: "master",
+	}
+
+	have, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"title":"title","description":"description","source":{"branch":{"name":"feature"},"repository":{"full_name":"fork/repo"}},"destination":{"branch":{"name":"master"}}}`
+	if string(have) != want {
+		t.Errorf("unexpected body:\nhave %s\nwant %s", have, want)
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	repo := &Repo{FullName: "owner/repo"}
+
+	var body string
+	cli := newMockClient(t, func(r *http.Request) (int, string) {
+		if r.Method != "POST" || r.URL.Path != "/2.0/repositories/owner/repo/pullrequests" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		bs, _ := ioutil.ReadAll(r.Body)
+		body = string(bs)
+		return http.StatusCreated, `{"id": 7, "title": "title", "state": "OPEN", "source": {"branch": {"name": "feature"}}}`
+	})
+
+	pr, err := cli.CreatePullRequest(context.Background(), repo, PullRequestInput{
+		Title:             "title",
+		SourceBranch:      "feature",
+		DestinationBranch: "master",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"title":"title","description":"","source":{"branch":{"name":"feature"}},"destination":{"branch":{"name":"master"}}}`; body != want {
+		t.Errorf("unexpected body:\nhave %s\nwant %s", body, want)
+	}
+
+	want := &PullRequest{ID: 7, Title: "title", State: PullRequestStateOpen}
+	want.Source.Branch.Name = "feature"
+	if diff := cmp.Diff(want, pr); diff != "" {
+		t.Errorf("unexpected pull request: %s", diff)
+	}
+}
+
+func TestClient_FindOpenPullRequest(t *testing.T) {
+	repo := &Repo{FullName: "owner/repo"}
+
+	for name, tc := range map[string]struct {
+		body    string
+		wantID  int64
+		wantErr error
+	}{
+		"found": {
+			body:   `{"values": [{"id": 3}]}`,
+			wantID: 3,
+		},
+		"not found": {
+			body:    `{"values": []}`,
+			wantErr: ErrPullRequestNotFound,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cli := newMockClient(t, func(r *http.Request) (int, string) {
+				want := `source.branch.name = "feature" AND destination.branch.name = "master" AND state = "OPEN"`
+				if have := r.URL.Query().Get("q"); have != want {
+					t.Errorf("unexpected query: have %q, want %q", have, want)
+				}
+				return http.StatusOK, tc.body
+			})
+
+			pr, err := cli.FindOpenPullRequest(context.Background(), repo, "feature", "master")
+			if err != tc.wantErr {
+				t.Fatalf("unexpected error: have %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr == nil && pr.ID != tc.wantID {
+				t.Errorf("unexpected pull request: have %d, want %d", pr.ID, tc.wantID)
+			}
+		})
+	}
+}
+
+func TestClient_GetPullRequestStatuses(t *testing.T) {
+	repo := &Repo{FullName: "owner/repo"}
+
+	cli := newMockClient(t, func(r *http.Request) (int, string) {
+		if r.URL.Query().Get("page") == "2" {
+			return http.StatusOK, `{"values": [{"key": "b", "state": "FAILED"}]}`
+		}
+		return http.StatusOK, `{"values": [{"key": "a", "state": "SUCCESSFUL"}], "next": "https://api.bitbucket.example/2.0/repositories/owner/repo/pullrequests/1/statuses?page=2"}`
+	})
+
+	statuses, err := cli.GetPullRequestStatuses(context.Background(), repo, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, s := range statuses {
+		keys = append(keys, s.Key()+":"+s.State)
+	}
+	if diff := cmp.Diff([]string{"a:SUCCESSFUL", "b:FAILED"}, keys); diff != "" {
+		t.Errorf("unexpected statuses: %s", diff)
+	}
+}
+
+func TestClient_GetPullRequest_NotFound(t *testing.T) {
+	cli := newMockClient(t, func(r *http.Request) (int, string) {
+		return http.StatusNotFound, `{"type": "error"}`
+	})
+
+	_, err := cli.GetPullRequest(context.Background(), &Repo{FullName: "owner/repo"}, 1)
+	if !IsNotFound(err) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -550,6 +550,28 @@ func (c *Client) DeclinePullRequest(ctx context.Context, pr *PullRequest) error
 	return c.send(ctx, "POST", path, qry, nil, pr)
 }
 
+// MergePullRequest merges the given PullRequest, returning an error in case of failure.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	qry := url.Values{"version": {strconv.Itoa(pr.Version)}}
+
+	return c.send(ctx, "POST", path, qry, nil, pr)
+}
+
 // LoadPullRequestActivities loads the given PullRequest's timeline of activities,
 // returning an error in case of failure.
 func (c *Client) LoadPullRequestActivities(ctx context.Context, pr *PullRequest) (err error) {
@@ -1082,6 +1104,13 @@ type PullRequest struct {
 			Href string `json:"href"`
 		} `json:"self"`
 	} `json:"links"`
+	Properties struct {
+		MergeResult struct {
+			// Outcome is one of CLEAN, CONFLICTED, or UNKNOWN.
+			Outcome string `json:"outcome"`
+			Current bool   `json:"current"`
+		} `json:"mergeResult"`
+	} `json:"properties"`
 
 	Activities   []*Activity     `json:"activities,omitempty"`
 	Commits      []*Commit       `json:"commits,omitempty"`
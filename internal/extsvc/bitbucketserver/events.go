@@ -28,6 +28,19 @@ func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err err
 	case "pr:participant:status":
 		e = &PullRequestParticipantStatusEvent{}
 		return e, json.Unmarshal(payload, e)
+
+	// The event keys below are sent by the native webhooks of Bitbucket
+	// Server 5.4+, which don't require the Sourcegraph plugin.
+	case "diagnostics:ping":
+		return PingEvent{}, nil
+	case "pr:reviewer:approved", "pr:reviewer:unapproved", "pr:reviewer:needs_work":
+		e = &NativePullRequestReviewerEvent{}
+		return e, json.Unmarshal(payload, e)
+	case "pr:opened", "pr:from_ref_updated", "pr:modified", "pr:reviewer:updated",
+		"pr:merged", "pr:declined", "pr:deleted",
+		"pr:comment:added", "pr:comment:edited", "pr:comment:deleted":
+		e = &NativePullRequestEvent{}
+		return e, json.Unmarshal(payload, e)
 	default:
 		return nil, fmt.Errorf("unknown webhook event type: %q", eventType)
 	}
@@ -63,3 +76,74 @@ type BuildStatusEvent struct {
 	Status       BuildStatus   `json:"status"`
 	PullRequests []PullRequest `json:"pullRequests"`
 }
+
+// NativeTime is the timestamp format used in native webhook payloads, which
+// isn't RFC 3339 since the UTC offset doesn't contain a colon.
+type NativeTime struct{ time.Time }
+
+const nativeTimeLayout = "2006-01-02T15:04:05-0700"
+
+func (t *NativeTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	dec, err := time.Parse(nativeTimeLayout, s)
+	if err != nil {
+		// Fall back to RFC 3339, in case the format is ever fixed.
+		if dec, err = time.Parse(time.RFC3339, s); err != nil {
+			return err
+		}
+	}
+	t.Time = dec
+	return nil
+}
+
+func (t NativeTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Format(nativeTimeLayout))
+}
+
+// NativePullRequestEvent contains the fields that all native pull request
+// webhook events have in common.
+type NativePullRequestEvent struct {
+	EventKey    string      `json:"eventKey"`
+	Date        NativeTime  `json:"date"`
+	Actor       User        `json:"actor"`
+	PullRequest PullRequest `json:"pullRequest"`
+}
+
+// NativePullRequestReviewerEvent is sent when a reviewer approves, unapproves
+// or marks a pull request as needing work.
+type NativePullRequestReviewerEvent struct {
+	NativePullRequestEvent
+
+	Participant struct {
+		User     User   `json:"user"`
+		Role     string `json:"role"`
+		Approved bool   `json:"approved"`
+		Status   string `json:"status"`
+	} `json:"participant"`
+	PreviousStatus string `json:"previousStatus"`
+}
+
+// ParticipantStatusEvent returns the ParticipantStatusEvent equivalent to the
+// reviewer event, so that native and plugin webhooks produce the same
+// changeset events.
+func (e *NativePullRequestReviewerEvent) ParticipantStatusEvent() *ParticipantStatusEvent {
+	var action ActivityAction
+	switch e.EventKey {
+	case "pr:reviewer:approved":
+		action = ApprovedActivityAction
+	case "pr:reviewer:needs_work":
+		action = ReviewedActivityAction
+	default:
+		action = UnapprovedActivityAction
+	}
+
+	return &ParticipantStatusEvent{
+		CreatedDate: int(e.Date.UnixNano() / int64(time.Millisecond)),
+		User:        e.Participant.User,
+		Action:      action,
+	}
+}
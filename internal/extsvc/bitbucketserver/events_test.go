@@ -0,0 +1,86 @@
+package bitbucketserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWebhookEvent_Native(t *testing.T) {
+	payload := func(eventKey string) []byte {
+		return []byte(`{
+			"eventKey": "` + eventKey + `",
+			"date": "2020-05-05T10:38:11+0200",
+			"actor": {"id": 1, "name": "milton"},
+			"pullRequest": {"id": 69, "toRef": {"repository": {"id": 10070}}},
+			"participant": {"user": {"id": 2, "name": "thorsten"}, "status": "APPROVED"}
+		}`)
+	}
+
+	date := time.Date(2020, 5, 5, 8, 38, 11, 0, time.UTC)
+
+	for eventKey, want := range map[string]ActivityAction{
+		"pr:reviewer:approved":   ApprovedActivityAction,
+		"pr:reviewer:unapproved": UnapprovedActivityAction,
+		"pr:reviewer:needs_work": ReviewedActivityAction,
+	} {
+		t.Run(eventKey, func(t *testing.T) {
+			e, err := ParseWebhookEvent(eventKey, payload(eventKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ev, ok := e.(*NativePullRequestReviewerEvent)
+			if !ok {
+				t.Fatalf("unexpected event type %T", e)
+			}
+			if !ev.Date.Equal(date) {
+				t.Errorf("unexpected date: have %s; want %s", ev.Date, date)
+			}
+
+			have := ev.ParticipantStatusEvent()
+			if diff := cmp.Diff(&ParticipantStatusEvent{
+				CreatedDate: int(date.UnixNano() / int64(time.Millisecond)),
+				User:        User{ID: 2, Name: "thorsten"},
+				Action:      want,
+			}, have); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	for _, eventKey := range []string{
+		"pr:opened",
+		"pr:from_ref_updated",
+		"pr:modified",
+		"pr:merged",
+		"pr:declined",
+		"pr:comment:added",
+	} {
+		t.Run(eventKey, func(t *testing.T) {
+			e, err := ParseWebhookEvent(eventKey, payload(eventKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ev, ok := e.(*NativePullRequestEvent)
+			if !ok {
+				t.Fatalf("unexpected event type %T", e)
+			}
+			if ev.PullRequest.ID != 69 || ev.PullRequest.ToRef.Repository.ID != 10070 {
+				t.Errorf("unexpected pull request: %+v", ev.PullRequest)
+			}
+		})
+	}
+
+	t.Run("diagnostics:ping", func(t *testing.T) {
+		e, err := ParseWebhookEvent("diagnostics:ping", []byte(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := e.(PingEvent); !ok {
+			t.Fatalf("unexpected event type %T", e)
+		}
+	})
+}
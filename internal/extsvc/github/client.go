@@ -90,6 +90,15 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("request to %s returned status %d: %s", e.URL, e.Code, e.Message)
 }
 
+func (e *APIError) Unauthorized() bool {
+	return e.Code == http.StatusUnauthorized
+}
+
+// RateLimitExceeded returns true if the request failed because the API rate limit was exceeded.
+func (e *APIError) RateLimitExceeded() bool {
+	return strings.Contains(e.Message, "API rate limit exceeded") || strings.Contains(e.DocumentationURL, "#rate-limiting")
+}
+
 func urlIsGitHubDotCom(apiURL *url.URL) bool {
 	hostname := strings.ToLower(apiURL.Hostname())
 	return hostname == "api.github.com" || hostname == "github.com" || hostname == "www.github.com" || apiURL.String() == githubProxyURL.String()
@@ -268,6 +277,47 @@ func (c *Client) requestGet(ctx context.Context, requestURI string, result inter
 	return c.do(ctx, req, result)
 }
 
+func (c *Client) requestPost(ctx context.Context, requestURI string, payload, result interface{}) error {
+	return c.requestWithPayload(ctx, "POST", requestURI, payload, result)
+}
+
+func (c *Client) requestPatch(ctx context.Context, requestURI string, payload, result interface{}) error {
+	return c.requestWithPayload(ctx, "PATCH", requestURI, payload, result)
+}
+
+func (c *Client) requestDelete(ctx context.Context, requestURI string, result interface{}) error {
+	req, err := http.NewRequest("DELETE", requestURI, nil)
+	if err != nil {
+		return err
+	}
+
+	err = c.rateLimit.Wait(ctx)
+	if err != nil {
+		return errors.Wrap(err, "rate limit")
+	}
+
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) requestWithPayload(ctx context.Context, method, requestURI string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, requestURI, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	err = c.rateLimit.Wait(ctx)
+	if err != nil {
+		return errors.Wrap(err, "rate limit")
+	}
+
+	return c.do(ctx, req, result)
+}
+
 func (c *Client) requestGraphQL(ctx context.Context, query string, vars map[string]interface{}, result interface{}) (err error) {
 	reqBody, err := json.Marshal(struct {
 		Query     string                 `json:"query"`
@@ -486,7 +536,7 @@ func IsNotFound(err error) bool {
 // limit was exceeded.
 func IsRateLimitExceeded(err error) bool {
 	if e, ok := errors.Cause(err).(*APIError); ok {
-		return strings.Contains(e.Message, "API rate limit exceeded") || strings.Contains(e.DocumentationURL, "#rate-limiting")
+		return e.RateLimitExceeded()
 	}
 
 	errs, ok := err.(graphqlErrors)
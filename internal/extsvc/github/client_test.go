@@ -600,3 +600,25 @@ query{
 		})
 	}
 }
+
+func TestPullRequestReviewThreadUnmarshalJSON(t *testing.T) {
+	want := &PullRequestReviewThread{
+		IsResolved: true,
+		Comments:   []*PullRequestReviewComment{{DatabaseID: 1, Body: "nit"}},
+	}
+
+	for name, data := range map[string]string{
+		"graphql": `{"isResolved": true, "comments": {"nodes": [{"databaseId": 1, "body": "nit"}]}}`,
+		"json":    `{"IsResolved": true, "Comments": [{"DatabaseID": 1, "Body": "nit"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := &PullRequestReviewThread{}
+			if err := json.Unmarshal([]byte(data), have); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
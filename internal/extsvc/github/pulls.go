@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -64,11 +66,13 @@ func (c *CheckSuite) Key() string {
 
 // CheckRun represents the status of a checkrun
 type CheckRun struct {
-	ID string
+	ID   string
+	Name string
 	// One of COMPLETED, IN_PROGRESS, QUEUED, REQUESTED
 	Status string
 	// One of ACTION_REQUIRED, CANCELLED, FAILURE, NEUTRAL, SUCCESS, TIMED_OUT
 	Conclusion string
+	DetailsURL string
 	// When the run was received via a webhook
 	ReceivedAt time.Time
 }
@@ -101,6 +105,7 @@ type CommitStatus struct {
 	SHA        string
 	Context    string
 	State      string
+	TargetURL  string
 	ReceivedAt time.Time
 }
 
@@ -115,6 +120,7 @@ type Context struct {
 	Context     string
 	Description string
 	State       string
+	TargetURL   string
 }
 
 type Label struct {
@@ -136,6 +142,8 @@ type PullRequest struct {
 	BaseRefOid    string
 	HeadRefName   string
 	BaseRefName   string
+	// Mergeable is one of MERGEABLE, CONFLICTING, or UNKNOWN.
+	Mergeable     string
 	Number        int64
 	Author        Actor
 	Participants  []Actor
@@ -241,7 +249,38 @@ func (e PullRequestReview) Key() string {
 // of a ChangesetEvent, instead storing each contained comment as a separate ChangesetEvent.
 // That's why this type doesn't have a Key method like the others.
 type PullRequestReviewThread struct {
-	Comments []*PullRequestReviewComment
+	IsResolved bool
+	Comments   []*PullRequestReviewComment
+}
+
+// UnmarshalJSON knows how to unmarshal a PullRequestReviewThread as produced
+// by json.Marshal or as returned by the GitHub GraphQL API, which returns the
+// comments as a connection.
+func (t *PullRequestReviewThread) UnmarshalJSON(data []byte) error {
+	var v struct {
+		IsResolved bool
+		Comments   json.RawMessage
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	t.IsResolved = v.IsResolved
+	t.Comments = nil
+	if len(v.Comments) == 0 || v.Comments[0] == 'n' {
+		return nil
+	}
+
+	if v.Comments[0] == '{' {
+		var conn struct{ Nodes []*PullRequestReviewComment }
+		if err := json.Unmarshal(v.Comments, &conn); err != nil {
+			return err
+		}
+		t.Comments = conn.Nodes
+		return nil
+	}
+
+	return json.Unmarshal(v.Comments, &t.Comments)
 }
 
 type PullRequestCommit struct {
@@ -276,6 +315,17 @@ type PullRequestReviewComment struct {
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 	IncludesCreatedEdit bool
+	// Path is the path of the file the comment was made on.
+	Path string
+	// Position is the line of the diff of the file the comment was made on,
+	// or 0 if the comment is outdated.
+	Position int
+	// ReplyTo is the comment this comment replies to, which is the first
+	// comment of the thread.
+	ReplyTo struct{ DatabaseID int64 }
+	// ThreadResolved is whether the thread of the comment is resolved, as of
+	// the last sync.
+	ThreadResolved bool
 }
 
 // Key is a unique key identifying this event in the context of its pull request.
@@ -595,6 +645,146 @@ func (c *Client) ClosePullRequest(ctx context.Context, pr *PullRequest) error {
 	return nil
 }
 
+// MergePullRequest merges the PullRequest on Github using the repository's
+// default merge method.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
+	var q strings.Builder
+	q.WriteString(pullRequestFragments)
+	q.WriteString(`mutation	MergePullRequest($input:MergePullRequestInput!) {
+  mergePullRequest(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MergePullRequest struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"mergePullRequest"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID string `json:"pullRequestId"`
+	}{ID: pr.ID}}
+	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.MergePullRequest.PullRequest.PullRequest
+	pr.TimelineItems = result.MergePullRequest.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.MergePullRequest.PullRequest.Participants.Nodes
+
+	return nil
+}
+
+// RequestReviews requests reviews of the pull request with the given number
+// in the repository owner/name from the given users and teams. Teams are
+// given by their slug.
+func (c *Client) RequestReviews(ctx context.Context, owner, name string, number int64, reviewers, teamReviewers []string) error {
+	payload := struct {
+		Reviewers     []string `json:"reviewers,omitempty"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{
+		Reviewers:     reviewers,
+		TeamReviewers: teamReviewers,
+	}
+	var result struct{}
+	return c.requestPost(ctx, fmt.Sprintf("/repos/%s/%s/pulls/%d/requested_reviewers", owner, name, number), payload, &result)
+}
+
+// AddAssignees adds the given users to the assignees of the pull request with
+// the given number in the repository owner/name.
+func (c *Client) AddAssignees(ctx context.Context, owner, name string, number int64, assignees []string) error {
+	payload := struct {
+		Assignees []string `json:"assignees"`
+	}{Assignees: assignees}
+	var result struct{}
+	return c.requestPost(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/assignees", owner, name, number), payload, &result)
+}
+
+// AddLabels adds the given labels to the pull request with the given number
+// in the repository owner/name. GitHub creates labels that don't exist yet.
+func (c *Client) AddLabels(ctx context.Context, owner, name string, number int64, labels []string) error {
+	payload := struct {
+		Labels []string `json:"labels"`
+	}{Labels: labels}
+	var result json.RawMessage
+	return c.requestPost(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/labels", owner, name, number), payload, &result)
+}
+
+// CreateComment adds a comment with the given Markdown body to the pull
+// request with the given number in the repository owner/name.
+func (c *Client) CreateComment(ctx context.Context, owner, name string, number int64, body string) error {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+	var result json.RawMessage
+	return c.requestPost(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", owner, name, number), payload, &result)
+}
+
+// RemoveLabel removes the given label from the pull request with the given
+// number in the repository owner/name. Removing a label that the pull request
+// doesn't have is not an error.
+func (c *Client) RemoveLabel(ctx context.Context, owner, name string, number int64, label string) error {
+	var result json.RawMessage
+	err := c.requestDelete(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/labels/%s", owner, name, number, url.PathEscape(label)), &result)
+	if HTTPErrorCode(err) == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// Milestone is a milestone of a GitHub repository.
+type Milestone struct {
+	Number int64  `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// GetOrCreateMilestone returns the milestone of the repository owner/name with
+// the given title, creating it if it doesn't exist yet.
+func (c *Client) GetOrCreateMilestone(ctx context.Context, owner, name, title string) (*Milestone, error) {
+	for page := 1; ; page++ {
+		var milestones []*Milestone
+		if err := c.requestGet(ctx, fmt.Sprintf("/repos/%s/%s/milestones?state=all&per_page=100&page=%d", owner, name, page), &milestones); err != nil {
+			return nil, err
+		}
+		for _, m := range milestones {
+			if m.Title == title {
+				return m, nil
+			}
+		}
+		if len(milestones) < 100 {
+			break
+		}
+	}
+
+	var milestone Milestone
+	payload := struct {
+		Title string `json:"title"`
+	}{Title: title}
+	if err := c.requestPost(ctx, fmt.Sprintf("/repos/%s/%s/milestones", owner, name), payload, &milestone); err != nil {
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+// SetMilestone sets the milestone of the pull request with the given number
+// in the repository owner/name to the milestone with the given number.
+func (c *Client) SetMilestone(ctx context.Context, owner, name string, number, milestone int64) error {
+	payload := struct {
+		Milestone int64 `json:"milestone"`
+	}{Milestone: milestone}
+	var result struct{}
+	return c.requestPatch(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d", owner, name, number), payload, &result)
+}
+
 // LoadPullRequests loads a list of PullRequests from Github.
 func (c *Client) LoadPullRequests(ctx context.Context, prs ...*PullRequest) error {
 	const batchSize = 15
@@ -681,6 +871,94 @@ func (c *Client) loadPullRequests(ctx context.Context, prs ...*PullRequest) erro
 	return nil
 }
 
+// LoadPullRequestStates loads only the state of a list of PullRequests from
+// Github, which is considerably cheaper than loading them fully. The State of
+// PullRequests that could not be found is left empty.
+func (c *Client) LoadPullRequestStates(ctx context.Context, prs ...*PullRequest) error {
+	// Since only a single scalar field is requested per pull request, a
+	// batch can be much bigger than in LoadPullRequests.
+	const batchSize = 100
+	for i := 0; i < len(prs); i += batchSize {
+		j := i + batchSize
+		if j > len(prs) {
+			j = len(prs)
+		}
+		if err := c.loadPullRequestStates(ctx, prs[i:j]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) loadPullRequestStates(ctx context.Context, prs ...*PullRequest) error {
+	type repository struct {
+		Owner string
+		Name  string
+		PRs   map[string]*PullRequest
+	}
+
+	labeled := map[string]*repository{}
+	byNameWithOwner := map[string]string{}
+	for _, pr := range prs {
+		owner, repo, err := SplitRepositoryNameWithOwner(pr.RepoWithOwner)
+		if err != nil {
+			return err
+		}
+
+		repoLabel, ok := byNameWithOwner[pr.RepoWithOwner]
+		if !ok {
+			repoLabel = fmt.Sprintf("repo_%d", len(labeled))
+			byNameWithOwner[pr.RepoWithOwner] = repoLabel
+			labeled[repoLabel] = &repository{
+				Owner: owner,
+				Name:  repo,
+				PRs:   map[string]*PullRequest{},
+			}
+		}
+
+		prLabel := repoLabel + "_" + strconv.FormatInt(pr.Number, 10)
+		labeled[repoLabel].PRs[prLabel] = pr
+	}
+
+	var q strings.Builder
+	q.WriteString("query {\n")
+
+	for repoLabel, r := range labeled {
+		q.WriteString(fmt.Sprintf("%s: repository(owner: %q, name: %q) {\n",
+			repoLabel, r.Owner, r.Name))
+
+		for prLabel, pr := range r.PRs {
+			q.WriteString(fmt.Sprintf("%s: pullRequest(number: %d) { state }\n",
+				prLabel, pr.Number,
+			))
+		}
+
+		q.WriteString("}\n")
+	}
+
+	q.WriteString("}")
+
+	var results map[string]map[string]*struct{ State string }
+
+	// Pull requests or repositories that don't exist anymore are reported
+	// as NOT_FOUND errors, but don't keep us from using the other results.
+	err := c.requestGraphQL(ctx, q.String(), nil, &results)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+
+	for repoLabel, prs := range results {
+		for prLabel, pr := range prs {
+			if pr == nil {
+				continue
+			}
+			labeled[repoLabel].PRs[prLabel].State = pr.State
+		}
+	}
+
+	return nil
+}
+
 // GetOpenPullRequestByRefs fetches the the pull request associated with the supplied
 // refs. GitHub only allows one open PR by ref at a time.
 // If nothing is found an error is returned.
@@ -764,6 +1042,7 @@ fragment commitWithChecks on Commit {
       context
       state
       description
+      targetUrl
     }
   }
   checkSuites(last: 20){
@@ -774,8 +1053,10 @@ fragment commitWithChecks on Commit {
       checkRuns(last: 20){
         nodes{
           id
+          name
           status
           conclusion
+          detailsUrl
         }
       }
     }
@@ -819,6 +1100,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   author {
     ...actor
   }
@@ -895,6 +1177,7 @@ fragment pr on PullRequest {
         ...review
       }
       ... on PullRequestReviewThread {
+        isResolved
         comments(last: 100) {
           nodes {
             databaseId
@@ -914,6 +1197,11 @@ fragment pr on PullRequest {
             createdAt
             updatedAt
             includesCreatedEdit
+            path
+            position
+            replyTo {
+              databaseId
+            }
           }
         }
       }
@@ -246,6 +246,23 @@ func (c *Client) getRepositoryFromAPI(ctx context.Context, owner, name string) (
 	return convertRestRepo(result), nil
 }
 
+// Fork forks the given repository into the namespace of the authenticated
+// user and returns the fork. If the user already has a fork of the
+// repository, that fork is returned.
+//
+// GitHub creates forks asynchronously, so pushing to the returned fork can
+// fail for a short while after it's been created.
+func (c *Client) Fork(ctx context.Context, owner, name string) (*Repository, error) {
+	var result restRepository
+	if err := c.requestPost(ctx, fmt.Sprintf("/repos/%s/%s/forks", owner, name), struct{}{}, &result); err != nil {
+		if HTTPErrorCode(err) == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return convertRestRepo(result), nil
+}
+
 // convertRestRepo converts repo information returned by the rest API
 // to a standard format.
 func convertRestRepo(restRepo restRepository) *Repository {
@@ -549,3 +566,49 @@ func (c *Client) ListTopicsOnRepository(ctx context.Context, ownerAndName string
 	}
 	return result.Names, nil
 }
+
+// CombinedStatus is the combined state of the commit statuses of a commit.
+type CombinedStatus struct {
+	// State is one of failure, pending or success. It's pending if the commit
+	// has no statuses.
+	State      string `json:"state"`
+	TotalCount int    `json:"total_count"`
+}
+
+// GetCombinedStatus returns the combined state of the commit statuses of the
+// commit that the given ref in the repository owner/name points to.
+func (c *Client) GetCombinedStatus(ctx context.Context, owner, name, ref string) (*CombinedStatus, error) {
+	var status CombinedStatus
+	if err := c.requestGet(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, name, url.PathEscape(ref)), &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ListCheckRuns returns the first 100 check runs of the commit that the given
+// ref in the repository owner/name points to. Like in the GraphQL API, their
+// status and conclusion are upper-case.
+func (c *Client) ListCheckRuns(ctx context.Context, owner, name, ref string) ([]*CheckRun, error) {
+	var result struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			DetailsURL string `json:"details_url"`
+		} `json:"check_runs"`
+	}
+	if err := c.requestGet(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs?per_page=100", owner, name, url.PathEscape(ref)), &result); err != nil {
+		return nil, err
+	}
+
+	runs := make([]*CheckRun, 0, len(result.CheckRuns))
+	for _, r := range result.CheckRuns {
+		runs = append(runs, &CheckRun{
+			Name:       r.Name,
+			Status:     strings.ToUpper(r.Status),
+			Conclusion: strings.ToUpper(r.Conclusion),
+			DetailsURL: r.DetailsURL,
+		})
+	}
+	return runs, nil
+}
@@ -133,6 +133,47 @@ func TestClient_GetRepository(t *testing.T) {
 	}
 }
 
+func TestClient_Fork(t *testing.T) {
+	var req *http.Request
+	mock := mockHTTPResponseBody{
+		responseBody: `
+{
+	"node_id": "f",
+	"full_name": "alice/r",
+	"html_url": "https://github.example.com/alice/r",
+	"fork": true
+}
+`,
+		status: http.StatusAccepted,
+	}
+	c := newTestClient(t, httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		req = r
+		return mock.Do(r)
+	}))
+
+	fork, err := c.Fork(context.Background(), "o", "r")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := req.Method, "POST"; have != want {
+		t.Errorf("wrong method. want=%q, have=%q", want, have)
+	}
+	if have, want := req.URL.Path, "/repos/o/r/forks"; have != want {
+		t.Errorf("wrong path. want=%q, have=%q", want, have)
+	}
+
+	want := &Repository{
+		ID:            "f",
+		NameWithOwner: "alice/r",
+		URL:           "https://github.example.com/alice/r",
+		IsFork:        true,
+	}
+	if diff := cmp.Diff(want, fork); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func TestClient_GetRepositoriesByNodeFromAPI(t *testing.T) {
 	tests := []struct {
 		responseBody string
@@ -59,6 +59,21 @@ func (c *Client) GetAuthenticatedUserEmails(ctx context.Context) ([]*UserEmail,
 	return emails, nil
 }
 
+var MockGetAuthenticatedUser func(ctx context.Context) (*github.User, error)
+
+// GetAuthenticatedUser returns the currently authenticated user.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*github.User, error) {
+	if MockGetAuthenticatedUser != nil {
+		return MockGetAuthenticatedUser(ctx)
+	}
+
+	var u github.User
+	if err := c.requestGet(ctx, "/user", &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
 type Org struct {
 	Login string `json:"login,omitempty"`
 }
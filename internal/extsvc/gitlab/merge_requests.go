@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -38,6 +40,7 @@ type MergeRequest struct {
 	SourceBranch string            `json:"source_branch"`
 	TargetBranch string            `json:"target_branch"`
 	WebURL       string            `json:"web_url"`
+	MergeStatus  string            `json:"merge_status"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -157,6 +160,54 @@ func (c *Client) GetOpenMergeRequestByRefs(ctx context.Context, project *Project
 	return c.GetMergeRequest(ctx, project, resp[0].IID)
 }
 
+// listMergeRequestsByIIDsPageSize is the maximum number of merge requests
+// that GitLab returns per page, and therefore the maximum number of IIDs
+// requested at once.
+const listMergeRequestsByIIDsPageSize = 100
+
+// ListMergeRequestsByIIDs lists the merge requests of the project with the
+// given IIDs, regardless of their state. Only the fields returned by GitLab's
+// "simple" view, which include the state, are populated. Merge requests that
+// don't exist are omitted from the result.
+func (c *Client) ListMergeRequestsByIIDs(ctx context.Context, project *Project, iids []ID) ([]*MergeRequest, error) {
+	if MockListMergeRequestsByIIDs != nil {
+		return MockListMergeRequestsByIIDs(c, ctx, project, iids)
+	}
+
+	var mrs []*MergeRequest
+	for i := 0; i < len(iids); i += listMergeRequestsByIIDsPageSize {
+		j := i + listMergeRequestsByIIDsPageSize
+		if j > len(iids) {
+			j = len(iids)
+		}
+
+		values := make(url.Values)
+		values.Add("per_page", strconv.Itoa(listMergeRequestsByIIDsPageSize))
+		values.Add("state", "all")
+		values.Add("view", "simple")
+		for _, iid := range iids[i:j] {
+			values.Add("iids[]", strconv.FormatInt(int64(iid), 10))
+		}
+		u := &url.URL{
+			Path: fmt.Sprintf("projects/%d/merge_requests", project.ID), RawQuery: values.Encode(),
+		}
+
+		req, err := http.NewRequest("GET", u.String(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating request to list merge requests by IIDs")
+		}
+
+		resp := []*MergeRequest{}
+		if _, _, err := c.do(ctx, req, &resp); err != nil {
+			return nil, errors.Wrap(err, "sending request to list merge requests by IIDs")
+		}
+
+		mrs = append(mrs, resp...)
+	}
+
+	return mrs, nil
+}
+
 type UpdateMergeRequestOpts struct {
 	TargetBranch string                       `json:"target_branch"`
 	Title        string                       `json:"title"`
@@ -201,3 +252,109 @@ func (c *Client) UpdateMergeRequest(ctx context.Context, project *Project, mr *M
 
 	return resp, nil
 }
+
+// SetMergeRequestAssignees replaces the assignees of the given merge request
+// with the users with the given IDs.
+func (c *Client) SetMergeRequestAssignees(ctx context.Context, project *Project, mr *MergeRequest, assigneeIDs []int32) (*MergeRequest, error) {
+	if MockSetMergeRequestAssignees != nil {
+		return MockSetMergeRequestAssignees(c, ctx, project, mr, assigneeIDs)
+	}
+
+	data, err := json.Marshal(struct {
+		AssigneeIDs []int32 `json:"assignee_ids"`
+	}{AssigneeIDs: assigneeIDs})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to set merge request assignees")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to set merge request assignees")
+	}
+
+	return resp, nil
+}
+
+// SetMergeRequestLabels adds the given labels to the given merge request and
+// removes the given removed labels from it. GitLab creates labels that don't
+// exist yet.
+func (c *Client) SetMergeRequestLabels(ctx context.Context, project *Project, mr *MergeRequest, labels, removed []string) (*MergeRequest, error) {
+	if MockSetMergeRequestLabels != nil {
+		return MockSetMergeRequestLabels(c, ctx, project, mr, labels, removed)
+	}
+
+	data, err := json.Marshal(struct {
+		AddLabels    string `json:"add_labels,omitempty"`
+		RemoveLabels string `json:"remove_labels,omitempty"`
+	}{
+		AddLabels:    strings.Join(labels, ","),
+		RemoveLabels: strings.Join(removed, ","),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to set merge request labels")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to set merge request labels")
+	}
+
+	return resp, nil
+}
+
+// SetMergeRequestMilestone sets the milestone of the given merge request to
+// the milestone with the given ID.
+func (c *Client) SetMergeRequestMilestone(ctx context.Context, project *Project, mr *MergeRequest, milestoneID ID) (*MergeRequest, error) {
+	if MockSetMergeRequestMilestone != nil {
+		return MockSetMergeRequestMilestone(c, ctx, project, mr, milestoneID)
+	}
+
+	data, err := json.Marshal(struct {
+		MilestoneID ID `json:"milestone_id"`
+	}{MilestoneID: milestoneID})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to set merge request milestone")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to set merge request milestone")
+	}
+
+	return resp, nil
+}
+
+// MergeMergeRequest accepts the given merge request, merging it into its
+// target branch.
+func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error) {
+	if MockMergeMergeRequest != nil {
+		return MockMergeMergeRequest(c, ctx, project, mr)
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/merge", project.ID, mr.IID), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to merge a merge request")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to merge a merge request")
+	}
+
+	return resp, nil
+}
@@ -272,6 +272,72 @@ func TestGetOpenMergeRequestByRefs(t *testing.T) {
 	})
 }
 
+func TestListMergeRequestsByIIDs(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusInternalServerError}
+
+		mrs, err := client.ListMergeRequestsByIIDs(ctx, project, []ID{1})
+		if mrs != nil {
+			t.Errorf("unexpected non-nil merge requests: %+v", mrs)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("no IIDs", func(t *testing.T) {
+		client := newTestClient(t)
+		mock := &mockHTTPResponseBody{responseBody: `[]`}
+		client.httpClient = mock
+
+		mrs, err := client.ListMergeRequestsByIIDs(ctx, project, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mrs) != 0 {
+			t.Errorf("unexpected merge requests: %+v", mrs)
+		}
+		if mock.count != 0 {
+			t.Errorf("unexpected number of requests: have %d; want 0", mock.count)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		mock := &mockHTTPResponseBody{
+			responseBody: `[{"iid":42,"state":"merged"}]`,
+		}
+		client.httpClient = mock
+
+		iids := make([]ID, listMergeRequestsByIIDsPageSize+1)
+		for i := range iids {
+			iids[i] = ID(i + 1)
+		}
+
+		mrs, err := client.ListMergeRequestsByIIDs(ctx, project, iids)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// One request per page of IIDs.
+		if mock.count != 2 {
+			t.Errorf("unexpected number of requests: have %d; want 2", mock.count)
+		}
+
+		want := []*MergeRequest{
+			{IID: 42, State: MergeRequestStateMerged},
+			{IID: 42, State: MergeRequestStateMerged},
+		}
+		if diff := cmp.Diff(want, mrs); diff != "" {
+			t.Errorf("unexpected merge requests (-want +have):\n%s", diff)
+		}
+	})
+}
+
 func TestUpdateMergeRequest(t *testing.T) {
 	ctx := context.Background()
 	empty := &MergeRequest{}
@@ -339,3 +405,36 @@ func TestUpdateMergeRequest(t *testing.T) {
 	})
 
 }
+
+func TestMergeMergeRequest(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusMethodNotAllowed}
+
+		mr, err := client.MergeMergeRequest(ctx, project, &MergeRequest{})
+		if mr != nil {
+			t.Errorf("unexpected non-nil merge request: %+v", mr)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"iid":42,"state":"merged"}`,
+		}
+
+		mr, err := client.MergeMergeRequest(ctx, project, &MergeRequest{IID: 42})
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(mr, &MergeRequest{IID: 42, State: MergeRequestStateMerged}); diff != "" {
+			t.Errorf("unexpected merge request: %s", diff)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Milestone is a milestone of a GitLab project.
+type Milestone struct {
+	ID    ID     `json:"id"`
+	IID   ID     `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// GetOrCreateMilestone returns the milestone of the given project with the
+// given title, creating it if it doesn't exist yet.
+func (c *Client) GetOrCreateMilestone(ctx context.Context, project *Project, title string) (*Milestone, error) {
+	if MockGetOrCreateMilestone != nil {
+		return MockGetOrCreateMilestone(c, ctx, project, title)
+	}
+
+	values := make(url.Values)
+	values.Add("title", title)
+	u := &url.URL{Path: fmt.Sprintf("projects/%d/milestones", project.ID), RawQuery: values.Encode()}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to get milestone")
+	}
+
+	var milestones []*Milestone
+	if _, _, err := c.do(ctx, req, &milestones); err != nil {
+		return nil, errors.Wrap(err, "sending request to get milestone")
+	}
+	if len(milestones) > 0 {
+		return milestones[0], nil
+	}
+
+	data, err := json.Marshal(struct {
+		Title string `json:"title"`
+	}{Title: title})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling milestone")
+	}
+
+	req, err = http.NewRequest("POST", fmt.Sprintf("projects/%d/milestones", project.ID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create milestone")
+	}
+
+	milestone := &Milestone{}
+	if _, _, err := c.do(ctx, req, milestone); err != nil {
+		return nil, errors.Wrap(err, "sending request to create milestone")
+	}
+	return milestone, nil
+}
@@ -37,6 +37,30 @@ var MockGetMergeRequestPipelines func(c *Client, ctx context.Context, project *P
 // Client.GetOpenMergeRequestByRefs
 var MockGetOpenMergeRequestByRefs func(c *Client, ctx context.Context, project *Project, source, target string) (*MergeRequest, error)
 
+// MockListMergeRequestsByIIDs, if non-nil, will be called instead of
+// Client.ListMergeRequestsByIIDs
+var MockListMergeRequestsByIIDs func(c *Client, ctx context.Context, project *Project, iids []ID) ([]*MergeRequest, error)
+
 // MockUpdateMergeRequest, if non-nil, will be called instead of
 // Client.UpdateMergeRequest
 var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestOpts) (*MergeRequest, error)
+
+// MockSetMergeRequestAssignees, if non-nil, will be called instead of
+// Client.SetMergeRequestAssignees
+var MockSetMergeRequestAssignees func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, assigneeIDs []int32) (*MergeRequest, error)
+
+// MockSetMergeRequestLabels, if non-nil, will be called instead of
+// Client.SetMergeRequestLabels
+var MockSetMergeRequestLabels func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, labels, removed []string) (*MergeRequest, error)
+
+// MockSetMergeRequestMilestone, if non-nil, will be called instead of
+// Client.SetMergeRequestMilestone
+var MockSetMergeRequestMilestone func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, milestoneID ID) (*MergeRequest, error)
+
+// MockGetOrCreateMilestone, if non-nil, will be called instead of
+// Client.GetOrCreateMilestone
+var MockGetOrCreateMilestone func(c *Client, ctx context.Context, project *Project, title string) (*Milestone, error)
+
+// MockMergeMergeRequest, if non-nil, will be called instead of
+// Client.MergeMergeRequest
+var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error)
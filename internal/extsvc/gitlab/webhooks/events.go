@@ -31,7 +31,8 @@ type downcaster interface {
 }
 
 // UnmarshalEvent unmarshals the given JSON into an event type. Possible return
-// types are *MergeRequestEvent.
+// types are the merge request event types, *MergeRequestNoteEvent, and
+// *PipelineEvent.
 //
 // Errors caused by a valid payload being of an unknown type may be
 // distinguished from other errors by checking for ErrObjectKindUnknown in the
@@ -57,6 +58,8 @@ func UnmarshalEvent(data []byte) (interface{}, error) {
 	switch event.ObjectKind {
 	case "merge_request":
 		typedEvent = &mergeRequestEvent{}
+	case "note":
+		typedEvent = &noteEvent{}
 	case "pipeline":
 		typedEvent = &PipelineEvent{}
 	default:
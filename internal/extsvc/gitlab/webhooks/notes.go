@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// MergeRequestNoteEvent is sent when a note is added to a merge request. The
+// note is converted into the same gitlab.Note type that we get when syncing a
+// merge request's notes via the REST API.
+type MergeRequestNoteEvent struct {
+	EventCommon
+
+	User         *gitlab.User         `json:"user"`
+	MergeRequest *gitlab.MergeRequest `json:"merge_request"`
+	Note         *gitlab.Note         `json:"note"`
+}
+
+func (e *MergeRequestNoteEvent) ToEvent() *MergeRequestEventCommon {
+	return &MergeRequestEventCommon{
+		EventCommon:  e.EventCommon,
+		MergeRequest: e.MergeRequest,
+		User:         e.User,
+	}
+}
+
+// noteEvent is an internal type used for initially unmarshalling the typed
+// event before it is downcast into a more specific type later based on the
+// "noteable_type" field in the JSON.
+type noteEvent struct {
+	EventCommon
+
+	User         *gitlab.User         `json:"user"`
+	MergeRequest *gitlab.MergeRequest `json:"merge_request"`
+
+	ObjectAttributes noteEventObjectAttributes `json:"object_attributes"`
+}
+
+// noteEventObjectAttributes is the note as it appears in webhook payloads,
+// which differs from the REST API representation: the body is in the "note"
+// field, and only the ID of the author is included.
+type noteEventObjectAttributes struct {
+	ID           gitlab.ID   `json:"id"`
+	Note         string      `json:"note"`
+	NoteableType string      `json:"noteable_type"`
+	System       bool        `json:"system"`
+	CreatedAt    gitlab.Time `json:"created_at"`
+}
+
+func (ne *noteEvent) downcast() (interface{}, error) {
+	// Notes can be attached to commits, issues, and snippets as well, but only
+	// merge request notes are relevant to changesets.
+	if ne.ObjectAttributes.NoteableType != "MergeRequest" {
+		return nil, errors.Wrapf(ErrObjectKindUnknown, "unknown noteable type: %s", ne.ObjectAttributes.NoteableType)
+	}
+	if ne.MergeRequest == nil {
+		return nil, errors.New("merge request note event does not include a merge request")
+	}
+
+	note := &gitlab.Note{
+		ID:        ne.ObjectAttributes.ID,
+		Body:      ne.ObjectAttributes.Note,
+		CreatedAt: ne.ObjectAttributes.CreatedAt,
+		System:    ne.ObjectAttributes.System,
+	}
+	if ne.User != nil {
+		note.Author = *ne.User
+	}
+
+	return &MergeRequestNoteEvent{
+		EventCommon:  ne.EventCommon,
+		User:         ne.User,
+		MergeRequest: ne.MergeRequest,
+		Note:         note,
+	}, nil
+}
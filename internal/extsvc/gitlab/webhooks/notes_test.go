@@ -0,0 +1,90 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+func TestNoteDowncast(t *testing.T) {
+	t.Run("invalid noteable types", func(t *testing.T) {
+		for _, noteableType := range []string{
+			"",
+			"Commit",
+			"Issue",
+			"Snippet",
+		} {
+			t.Run(noteableType, func(t *testing.T) {
+				ne := &noteEvent{
+					ObjectAttributes: noteEventObjectAttributes{
+						NoteableType: noteableType,
+					},
+				}
+				dc, err := ne.downcast()
+				if !errors.Is(err, ErrObjectKindUnknown) {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				if dc != nil {
+					t.Errorf("unexpected non-nil value: %+v", dc)
+				}
+			})
+		}
+	})
+
+	t.Run("missing merge request", func(t *testing.T) {
+		ne := &noteEvent{
+			ObjectAttributes: noteEventObjectAttributes{
+				NoteableType: "MergeRequest",
+			},
+		}
+		dc, err := ne.downcast()
+		if err == nil || errors.Is(err, ErrObjectKindUnknown) {
+			t.Errorf("unexpected error: %+v", err)
+		}
+		if dc != nil {
+			t.Errorf("unexpected non-nil value: %+v", dc)
+		}
+	})
+
+	t.Run("merge request note", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`
+			{
+				"object_kind": "note",
+				"project": {"id": 1},
+				"user": {"id": 2, "username": "alice"},
+				"merge_request": {"iid": 42},
+				"object_attributes": {
+					"id": 1244,
+					"note": "looks good",
+					"noteable_type": "MergeRequest",
+					"system": false,
+					"created_at": "2020-06-26 23:11:17 UTC"
+				}
+			}
+		`))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		ne, ok := event.(*MergeRequestNoteEvent)
+		if !ok {
+			t.Fatalf("unexpected event type: %T", event)
+		}
+
+		want := &gitlab.Note{
+			ID:        1244,
+			Body:      "looks good",
+			Author:    gitlab.User{ID: 2, Username: "alice"},
+			CreatedAt: gitlab.Time{Time: time.Date(2020, 6, 26, 23, 11, 17, 0, time.UTC)},
+		}
+		if diff := cmp.Diff(want, ne.Note); diff != "" {
+			t.Errorf("unexpected note: %s", diff)
+		}
+		if have, want := ne.ToEvent().MergeRequest.IID, gitlab.ID(42); have != want {
+			t.Errorf("unexpected merge request IID: have %d; want %d", have, want)
+		}
+	})
+}
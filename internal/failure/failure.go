@@ -0,0 +1,201 @@
+// Package failure classifies the errors that cause background jobs to fail
+// into a small, fixed set of classes. The class of a failure is persisted on
+// the failed record next to its free-text failure message, so that failures
+// can be aggregated and acted upon without parsing error messages.
+package failure
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// Class is the class of an error that caused a job to fail.
+type Class string
+
+// Class constants.
+const (
+	// ClassTransientNetwork are network errors that are likely to go away
+	// when the job is retried, such as timeouts or reset connections.
+	ClassTransientNetwork Class = "TRANSIENT_NETWORK"
+	// ClassAuth are errors caused by missing, invalid, or insufficient
+	// credentials.
+	ClassAuth Class = "AUTH"
+	// ClassRateLimit are errors caused by exceeding the rate limit of an
+	// external service.
+	ClassRateLimit Class = "RATE_LIMIT"
+	// ClassUserConfig are errors caused by user input or configuration, such
+	// as a repository or revision that doesn't exist.
+	ClassUserConfig Class = "USER_CONFIG"
+	// ClassInternal are all other errors.
+	ClassInternal Class = "INTERNAL"
+)
+
+// Classes contains all valid classes.
+var Classes = []Class{
+	ClassTransientNetwork,
+	ClassAuth,
+	ClassRateLimit,
+	ClassUserConfig,
+	ClassInternal,
+}
+
+// Valid returns true if the given Class is valid.
+func (c Class) Valid() bool {
+	for _, class := range Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Retryable returns true if errors of the given Class are likely to go away
+// when the failed job is retried without changes.
+func (c Class) Retryable() bool {
+	return c == ClassTransientNetwork || c == ClassRateLimit
+}
+
+// classifiedError is an error with an explicitly assigned Class.
+type classifiedError struct {
+	error
+	class Class
+}
+
+func (e *classifiedError) Cause() error        { return e.error }
+func (e *classifiedError) Unwrap() error       { return e.error }
+func (e *classifiedError) FailureClass() Class { return e.class }
+
+// WithClass annotates err with the given Class, which takes precedence over
+// the class Classify would otherwise infer. If err is nil, WithClass returns
+// nil.
+func WithClass(err error, class Class) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{error: err, class: class}
+}
+
+// Classify returns the Class of the given error. Errors that have been
+// annotated with WithClass, or that implement a `FailureClass() Class`
+// method, are returned as-is. Otherwise the class is inferred from the error
+// and its causes. Classify returns the empty Class for a nil error.
+func Classify(err error) Class {
+	if err == nil {
+		return ""
+	}
+
+	var class Class
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ FailureClass() Class }); ok && e.FailureClass().Valid() {
+			class = e.FailureClass()
+			return true
+		}
+		return false
+	})
+	if class != "" {
+		return class
+	}
+
+	switch {
+	case isRateLimit(err):
+		return ClassRateLimit
+	case isAuth(err):
+		return ClassAuth
+	case isTransientNetwork(err):
+		return ClassTransientNetwork
+	case isUserConfig(err):
+		return ClassUserConfig
+	}
+
+	return ClassInternal
+}
+
+func isRateLimit(err error) bool {
+	return walk(err, func(err error) bool {
+		if e, ok := err.(interface{ RateLimitExceeded() bool }); ok && e.RateLimitExceeded() {
+			return true
+		}
+		return httpStatusCode(err) == http.StatusTooManyRequests
+	})
+}
+
+func isAuth(err error) bool {
+	if errcode.IsUnauthorized(err) {
+		return true
+	}
+
+	return walk(err, func(err error) bool {
+		switch httpStatusCode(err) {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return true
+		}
+		return false
+	})
+}
+
+func isTransientNetwork(err error) bool {
+	if errcode.IsTemporary(err) || errcode.IsTimeout(err) || vcs.IsCloneInProgress(errors.Cause(err)) {
+		return true
+	}
+
+	return walk(err, func(err error) bool {
+		switch err {
+		case context.DeadlineExceeded, io.ErrUnexpectedEOF:
+			return true
+		}
+
+		switch httpStatusCode(err) {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	})
+}
+
+func isUserConfig(err error) bool {
+	if errcode.IsNotFound(err) || errcode.IsBadRequest(err) {
+		return true
+	}
+
+	return walk(err, func(err error) bool {
+		switch httpStatusCode(err) {
+		case http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity:
+			return true
+		}
+		return false
+	})
+}
+
+// httpStatusCode returns the HTTP status code of err, if it has one, and 0
+// otherwise.
+func httpStatusCode(err error) int {
+	if e, ok := err.(interface{ HTTPStatusCode() int }); ok {
+		return e.HTTPStatusCode()
+	}
+	return 0
+}
+
+// walk calls p with err and each error it wraps, both through `Cause` and
+// `Unwrap`, until p returns true. It returns whether p returned true.
+func walk(err error, p func(err error) bool) bool {
+	for err != nil {
+		if p(err) {
+			return true
+		}
+
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package failure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+type rateLimitError struct{}
+
+func (rateLimitError) Error() string           { return "rate limit exceeded" }
+func (rateLimitError) RateLimitExceeded() bool { return true }
+
+type unauthorizedError struct{}
+
+func (unauthorizedError) Error() string      { return "unauthorized" }
+func (unauthorizedError) Unauthorized() bool { return true }
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "temporary" }
+func (temporaryError) Temporary() bool { return true }
+
+func TestClassify(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want Class
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "plain", err: errors.New("oops"), want: ClassInternal},
+
+		{name: "rate limit", err: rateLimitError{}, want: ClassRateLimit},
+		{name: "http 429", err: &errcode.HTTPErr{Status: http.StatusTooManyRequests}, want: ClassRateLimit},
+		{name: "wrapped rate limit", err: errors.Wrap(rateLimitError{}, "fetching"), want: ClassRateLimit},
+
+		{name: "unauthorized", err: unauthorizedError{}, want: ClassAuth},
+		{name: "http 401", err: &errcode.HTTPErr{Status: http.StatusUnauthorized}, want: ClassAuth},
+		{name: "http 403", err: &errcode.HTTPErr{Status: http.StatusForbidden}, want: ClassAuth},
+
+		{name: "temporary", err: temporaryError{}, want: ClassTransientNetwork},
+		{name: "deadline", err: errors.Wrap(context.DeadlineExceeded, "fetching"), want: ClassTransientNetwork},
+		{name: "std wrapped deadline", err: fmt.Errorf("fetching: %w", context.DeadlineExceeded), want: ClassTransientNetwork},
+		{name: "http 503", err: &errcode.HTTPErr{Status: http.StatusServiceUnavailable}, want: ClassTransientNetwork},
+		{name: "clone in progress", err: &vcs.RepoNotExistError{Repo: "r", CloneInProgress: true}, want: ClassTransientNetwork},
+
+		{name: "repo not exist", err: &vcs.RepoNotExistError{Repo: "r"}, want: ClassUserConfig},
+		{name: "http 422", err: &errcode.HTTPErr{Status: http.StatusUnprocessableEntity}, want: ClassUserConfig},
+
+		{name: "explicit class", err: WithClass(temporaryError{}, ClassUserConfig), want: ClassUserConfig},
+		{name: "wrapped explicit class", err: errors.Wrap(WithClass(errors.New("oops"), ClassAuth), "fetching"), want: ClassAuth},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := Classify(tc.err); have != tc.want {
+				t.Fatalf("wrong class. want=%q have=%q", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestWithClassNil(t *testing.T) {
+	if err := WithClass(nil, ClassInternal); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
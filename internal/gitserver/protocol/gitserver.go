@@ -167,6 +167,15 @@ type CreateCommitFromPatchRequest struct {
 	CommitInfo PatchCommitInfo
 	// Push specifies whether the target ref will be pushed to the code host
 	Push bool
+	// PushRemotePath is the path of the repository on the code host of Repo
+	// that the target ref is pushed to, e.g. "alice/sourcegraph" to push to a
+	// fork. If empty, the target ref is pushed to Repo itself.
+	PushRemotePath string
+	// PushUsername and PushPassword, if PushUsername is set, replace the
+	// credentials in the remote URL of Repo when pushing the target ref, so
+	// that it's pushed as a different user than the one Repo is cloned as.
+	PushUsername string
+	PushPassword string
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
@@ -348,6 +348,40 @@ func (c *Client) StatusMessages(ctx context.Context) (*protocol.StatusMessagesRe
 	return &res, nil
 }
 
+// MockCampaignsPublicationBudgets mocks (*Client).CampaignsPublicationBudgets
+// for tests.
+var MockCampaignsPublicationBudgets func(context.Context) ([]protocol.CampaignsPublicationBudget, error)
+
+// CampaignsPublicationBudgets returns the budgets that the publication of
+// campaign changesets has left on each code host.
+func (c *Client) CampaignsPublicationBudgets(ctx context.Context) ([]protocol.CampaignsPublicationBudget, error) {
+	if MockCampaignsPublicationBudgets != nil {
+		return MockCampaignsPublicationBudgets(ctx)
+	}
+
+	resp, err := c.httpGet(ctx, "campaigns-publication-budgets")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	var res protocol.CampaignsPublicationBudgetsResponse
+	if err = json.Unmarshal(bs, &res); err == nil && res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.New(string(bs))
+	} else if err != nil {
+		return nil, err
+	}
+	return res.Budgets, nil
+}
+
 func (c *Client) httpPost(ctx context.Context, method string, payload interface{}) (resp *http.Response, err error) {
 	reqBody, err := json.Marshal(payload)
 	if err != nil {
@@ -167,6 +167,31 @@ type ChangesetSyncResponse struct {
 	Error string
 }
 
+// CampaignsPublicationBudget is the share of a code host's rate limit that the
+// publication of campaign changesets may use.
+type CampaignsPublicationBudget struct {
+	// BaseURL is the normalized base URL of the code host.
+	BaseURL string
+	// DisplayName is the display name of a code host connection to the code
+	// host.
+	DisplayName string
+	// Unlimited is true if the code host's rate limit is disabled, in which
+	// case the publication of changesets isn't limited either.
+	Unlimited bool
+	// RequestsPerHour is the number of API requests per hour that the
+	// publication of changesets may use.
+	RequestsPerHour float64
+	// Remaining is the number of API requests currently left in the budget.
+	Remaining int
+}
+
+// CampaignsPublicationBudgetsResponse is a response to list the campaigns
+// publication budgets of all code hosts.
+type CampaignsPublicationBudgetsResponse struct {
+	Budgets []CampaignsPublicationBudget
+	Error   string
+}
+
 // PermsSyncRequest is a request to sync permissions.
 type PermsSyncRequest struct {
 	UserIDs []int32      `json:"user_ids"`
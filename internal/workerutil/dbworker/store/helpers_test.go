@@ -90,6 +90,7 @@ func setupStoreTest(t *testing.T) {
 			id              integer NOT NULL,
 			state           text NOT NULL,
 			failure_message text,
+			failure_class   text,
 			started_at      timestamp with time zone,
 			finished_at     timestamp with time zone,
 			process_after   timestamp with time zone,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
@@ -75,6 +76,7 @@ type StoreOptions struct {
 	//   - id: integer primary key
 	//   - state: an enum type containing at least `queued`, `processing`, and `errored`
 	//   - failure_message: text
+	//   - failure_class: text
 	//   - started_at: timestamp with time zone
 	//   - finished_at: timestamp with time zone
 	//   - process_after: timestamp with time zone
@@ -176,6 +178,7 @@ var columnNames = []string{
 	"id",
 	"state",
 	"failure_message",
+	"failure_class",
 	"started_at",
 	"finished_at",
 	"process_after",
@@ -368,14 +371,23 @@ RETURNING {id}
 // if the current state of the record is processing or completed. A requeued record or a record already marked
 // with an error will not be updated. This method returns a boolean flag indicating if the record was updated.
 func (s *store) MarkErrored(ctx context.Context, id int, failureMessage string) (bool, error) {
-	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(markErroredQuery, quote(s.options.TableName), failureMessage, id)))
+	return s.markErrored(ctx, id, failureMessage, nil)
+}
+
+// MarkErroredWithClass is like MarkErrored, but also records the class of the failure.
+func (s *store) MarkErroredWithClass(ctx context.Context, id int, failureMessage string, class failure.Class) (bool, error) {
+	return s.markErrored(ctx, id, failureMessage, &class)
+}
+
+func (s *store) markErrored(ctx context.Context, id int, failureMessage string, class *failure.Class) (bool, error) {
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(markErroredQuery, quote(s.options.TableName), failureMessage, class, id)))
 	return ok, err
 }
 
 const markErroredQuery = `
 -- source: internal/workerutil/store.go:MarkErrored
 UPDATE %s
-SET {state} = 'errored', {finished_at} = clock_timestamp(), {failure_message} = %s
+SET {state} = 'errored', {finished_at} = clock_timestamp(), {failure_message} = %s, {failure_class} = %s
 WHERE {id} = %s AND ({state} = 'processing' OR {state} = 'completed')
 RETURNING {id}
 `
@@ -444,7 +456,8 @@ UPDATE %s
 SET
 	{state} = 'errored',
 	{finished_at} = clock_timestamp(),
-	{failure_message} = 'failed to process'
+	{failure_message} = 'failed to process',
+	{failure_class} = 'INTERNAL'
 WHERE {id} IN (SELECT {id} FROM stalled)
 RETURNING {id}
 `
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 )
 
 func TestStoreDequeueState(t *testing.T) {
@@ -354,6 +355,55 @@ func TestStoreMarkErrored(t *testing.T) {
 	}
 }
 
+func TestStoreMarkErroredWithClass(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore(defaultTestStoreOptions)
+
+	if _, err := dbconn.Global.Exec(`
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'processing')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	marked, err := store.MarkErroredWithClass(context.Background(), 1, "new message", failure.ClassRateLimit)
+	if err != nil {
+		t.Fatalf("unexpected error marking upload as errored: %s", err)
+	}
+	if !marked {
+		t.Fatalf("expected record to be marked")
+	}
+
+	rows, err := dbconn.Global.Query(`SELECT state, failure_message, failure_class FROM workerutil_test WHERE id = 1`)
+	if err != nil {
+		t.Fatalf("unexpected error querying record: %s", err)
+	}
+	defer func() { _ = basestore.CloseRows(rows, nil) }()
+
+	if !rows.Next() {
+		t.Fatal("expected record to exist")
+	}
+
+	var state string
+	var failureMessage, failureClass *string
+	if err := rows.Scan(&state, &failureMessage, &failureClass); err != nil {
+		t.Fatalf("unexpected error scanning record: %s", err)
+	}
+	if state != "errored" {
+		t.Errorf("unexpected state. want=%q have=%q", "errored", state)
+	}
+	if failureMessage == nil || *failureMessage != "new message" {
+		t.Errorf("unexpected failure message. want=%v have=%v", "new message", failureMessage)
+	}
+	if failureClass == nil || *failureClass != string(failure.ClassRateLimit) {
+		t.Errorf("unexpected failure class. want=%v have=%v", failure.ClassRateLimit, failureClass)
+	}
+}
+
 func TestStoreMarkErroredAlreadyCompleted(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
@@ -15,6 +16,7 @@ type storeShim struct {
 }
 
 var _ workerutil.Store = &storeShim{}
+var _ workerutil.WithFailureClass = &storeShim{}
 
 // newStoreShim wraps the given store in a shim.
 func newStoreShim(store store.Store) workerutil.Store {
@@ -36,6 +38,16 @@ func (s *storeShim) Dequeue(ctx context.Context, extraArguments interface{}) (wo
 	return record, newStoreShim(tx), dequeued, err
 }
 
+// MarkErroredWithClass calls into the inner store if it supports failure classes, and falls
+// back to MarkErrored otherwise.
+func (s *storeShim) MarkErroredWithClass(ctx context.Context, id int, failureMessage string, class failure.Class) (bool, error) {
+	if inner, ok := s.Store.(workerutil.WithFailureClass); ok {
+		return inner.MarkErroredWithClass(ctx, id, failureMessage, class)
+	}
+
+	return s.Store.MarkErrored(ctx, id, failureMessage)
+}
+
 // ErrNotConditions occurs when a PreDequeue handler returns non-sql query extra arguments.
 var ErrNotConditions = errors.New("expected slice of *sqlf.Query values")
 
@@ -1,6 +1,10 @@
 package workerutil
 
-import "context"
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/failure"
+)
 
 // Record is a generic interface for record conforming to the requirements of the store.
 type Record interface {
@@ -29,3 +33,11 @@ type Store interface {
 	// that occurs during finalization to the error argument.
 	Done(err error) error
 }
+
+// WithFailureClass is an extension of the Store interface.
+type WithFailureClass interface {
+	// MarkErroredWithClass is called, if implemented, instead of MarkErrored when a handler fails. It
+	// behaves like MarkErrored, but additionally persists the class of the failure so that failures
+	// can be aggregated by their cause rather than by their free-text message.
+	MarkErroredWithClass(ctx context.Context, id int, failureMessage string, class failure.Class) (bool, error)
+}
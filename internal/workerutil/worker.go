@@ -8,6 +8,9 @@ import (
 	"github.com/efritz/glock"
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 )
@@ -24,6 +27,11 @@ type Worker struct {
 	finished         chan struct{}   // signals that Start has finished
 }
 
+var failuresCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_workerutil_failures_total",
+	Help: "Total number of records marked as errored, by worker name and failure class.",
+}, []string{"name", "class"})
+
 type WorkerOptions struct {
 	Name        string
 	Handler     Handler
@@ -188,10 +196,12 @@ func (w *Worker) handle(tx Store, record Record) (err error) {
 	}()
 
 	if handleErr := w.options.Handler.Handle(ctx, tx, record); handleErr != nil {
-		if marked, markErr := tx.MarkErrored(ctx, record.RecordID(), handleErr.Error()); markErr != nil {
+		class := failure.Classify(handleErr)
+		if marked, markErr := w.markErrored(ctx, tx, record, handleErr, class); markErr != nil {
 			return errors.Wrap(markErr, "store.MarkErrored")
 		} else if marked {
-			log15.Warn("Marked record as errored", "name", w.options.Name, "id", record.RecordID(), "err", handleErr)
+			failuresCounter.WithLabelValues(w.options.Name, string(class)).Inc()
+			log15.Warn("Marked record as errored", "name", w.options.Name, "id", record.RecordID(), "class", class, "err", handleErr)
 		}
 	} else {
 		if marked, markErr := tx.MarkComplete(ctx, record.RecordID()); markErr != nil {
@@ -205,6 +215,16 @@ func (w *Worker) handle(tx Store, record Record) (err error) {
 	return nil
 }
 
+// markErrored marks the given record as errored. The class of the failure is persisted along with
+// the failure message if the store supports it.
+func (w *Worker) markErrored(ctx context.Context, tx Store, record Record, handleErr error, class failure.Class) (bool, error) {
+	if s, ok := tx.(WithFailureClass); ok {
+		return s.MarkErroredWithClass(ctx, record.RecordID(), handleErr.Error(), class)
+	}
+
+	return tx.MarkErrored(ctx, record.RecordID(), handleErr.Error())
+}
+
 // preDequeueHook invokes the handler's pre-dequeue hook if it exists.
 func (w *Worker) preDequeueHook() (dequeueable bool, extraDequeueArguments interface{}, err error) {
 	if o, ok := w.options.Handler.(WithPreDequeue); ok {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/efritz/glock"
+	"github.com/sourcegraph/sourcegraph/internal/failure"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -105,6 +106,47 @@ func TestWorkerHandlerFailure(t *testing.T) {
 	}
 }
 
+type failureClassStore struct {
+	*MockStore
+	classes []failure.Class
+}
+
+func (s *failureClassStore) MarkErroredWithClass(ctx context.Context, id int, failureMessage string, class failure.Class) (bool, error) {
+	s.classes = append(s.classes, class)
+	return true, nil
+}
+
+func TestWorkerHandlerFailureWithClass(t *testing.T) {
+	store := &failureClassStore{MockStore: NewMockStore()}
+	handler := NewMockHandler()
+	clock := glock.NewMockClock()
+	options := WorkerOptions{
+		Handler:     handler,
+		NumHandlers: 1,
+		Interval:    time.Second,
+		Metrics: WorkerMetrics{
+			HandleOperation: observation.TestContext.Operation(observation.Op{}),
+		},
+	}
+
+	store.DequeueFunc.PushReturn(TestRecord{ID: 42}, store, true, nil)
+	store.DequeueFunc.SetDefaultReturn(nil, nil, false, nil)
+	handler.HandleFunc.SetDefaultReturn(failure.WithClass(fmt.Errorf("oops"), failure.ClassUserConfig))
+
+	worker := newWorker(context.Background(), store, options, clock)
+	go func() { worker.Start() }()
+	clock.BlockingAdvance(time.Second)
+	worker.Stop()
+
+	if callCount := len(store.MarkErroredFunc.History()); callCount != 0 {
+		t.Errorf("unexpected mark errored call count. want=%d have=%d", 0, callCount)
+	}
+	if len(store.classes) != 1 {
+		t.Errorf("unexpected mark errored with class call count. want=%d have=%d", 1, len(store.classes))
+	} else if class := store.classes[0]; class != failure.ClassUserConfig {
+		t.Errorf("unexpected failure class. want=%q have=%q", failure.ClassUserConfig, class)
+	}
+}
 func TestWorkerConcurrent(t *testing.T) {
 	NumTestRecords := 50
 
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_activities (
+  id bigserial PRIMARY KEY,
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+  changeset_id bigint REFERENCES changesets(id) ON DELETE SET NULL DEFERRABLE,
+  kind text NOT NULL,
+  metadata jsonb NOT NULL DEFAULT '{}'::jsonb,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS campaign_activities_campaign_id ON campaign_activities(campaign_id);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS changesets_custom_metadata_gin_idx;
+
+ALTER TABLE changesets DROP COLUMN IF EXISTS custom_metadata;
+
+COMMIT;
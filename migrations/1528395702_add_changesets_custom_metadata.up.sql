@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS custom_metadata jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS changesets_custom_metadata_gin_idx ON changesets USING gin (custom_metadata);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+DROP VIEW lsif_uploads_with_repository_name;
+DROP VIEW lsif_indexes_with_repository_name;
+
+ALTER TABLE changesets DROP COLUMN IF EXISTS failure_class;
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS failure_class;
+ALTER TABLE lsif_indexes DROP COLUMN IF EXISTS failure_class;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+    SELECT u.*, r.name as repository_name FROM lsif_uploads u
+    JOIN repo r ON r.id = u.repository_id
+    WHERE r.deleted_at IS NULL;
+
+CREATE VIEW lsif_indexes_with_repository_name AS
+    SELECT u.*, r.name as repository_name FROM lsif_indexes u
+    JOIN repo r ON r.id = u.repository_id
+    WHERE r.deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS failure_class text;
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS failure_class text;
+ALTER TABLE lsif_indexes ADD COLUMN IF NOT EXISTS failure_class text;
+
+-- Recreate views so that they include the new column
+DROP VIEW lsif_uploads_with_repository_name;
+DROP VIEW lsif_indexes_with_repository_name;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+    SELECT u.*, r.name as repository_name FROM lsif_uploads u
+    JOIN repo r ON r.id = u.repository_id
+    WHERE r.deleted_at IS NULL;
+
+CREATE VIEW lsif_indexes_with_repository_name AS
+    SELECT u.*, r.name as repository_name FROM lsif_indexes u
+    JOIN repo r ON r.id = u.repository_id
+    WHERE r.deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS wait_reason text;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS changeset_diff_stat_jobs;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+-- Jobs that recompute the diff stat of a changeset from gitserver after its
+-- head OID changed on the code host, for example due to a force-push. The
+-- columns state through num_resets are required by the workerutil package.
+CREATE TABLE IF NOT EXISTS changeset_diff_stat_jobs (
+  id bigserial PRIMARY KEY,
+  changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+  base_ref_oid text NOT NULL,
+  head_ref_oid text NOT NULL,
+  state text NOT NULL DEFAULT 'queued',
+  failure_message text,
+  failure_class text,
+  started_at timestamp with time zone,
+  finished_at timestamp with time zone,
+  process_after timestamp with time zone,
+  num_resets integer NOT NULL DEFAULT 0,
+  num_attempts integer NOT NULL DEFAULT 0,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_diff_stat_jobs_changeset_id ON changeset_diff_stat_jobs(changeset_id);
+CREATE INDEX IF NOT EXISTS changeset_diff_stat_jobs_state ON changeset_diff_stat_jobs(state);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_templates (
+  id bigserial PRIMARY KEY,
+  name text NOT NULL,
+  description text NOT NULL DEFAULT '',
+  template text NOT NULL,
+  parameters jsonb NOT NULL DEFAULT '[]'::jsonb,
+  namespace_user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+
+  CONSTRAINT campaign_templates_has_1_namespace CHECK ((namespace_user_id IS NULL) <> (namespace_org_id IS NULL)),
+  CONSTRAINT campaign_templates_name_not_blank CHECK (name <> ''),
+  CONSTRAINT campaign_templates_parameters_check CHECK (jsonb_typeof(parameters) = 'array')
+);
+
+CREATE INDEX IF NOT EXISTS campaign_templates_namespace_user_id ON campaign_templates(namespace_user_id);
+CREATE INDEX IF NOT EXISTS campaign_templates_namespace_org_id ON campaign_templates(namespace_org_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS campaign_reapply_jobs;
+DROP TABLE IF EXISTS campaign_reapply_schedules;
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+-- Cron schedules on which campaigns are re-applied: their campaign spec is
+-- re-evaluated server-side and the resulting changeset specs applied.
+CREATE TABLE IF NOT EXISTS campaign_reapply_schedules (
+  campaign_id bigint PRIMARY KEY REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  schedule text NOT NULL,
+  next_run_at timestamp with time zone NOT NULL,
+  last_run_at timestamp with time zone,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS campaign_reapply_schedules_next_run_at ON campaign_reapply_schedules(next_run_at);
+
+-- Jobs that re-evaluate the campaign spec of a campaign and apply the result,
+-- enqueued when a schedule is due. The columns state through num_resets are
+-- required by the workerutil package.
+CREATE TABLE IF NOT EXISTS campaign_reapply_jobs (
+  id bigserial PRIMARY KEY,
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  campaign_spec_id bigint NOT NULL REFERENCES campaign_specs(id) ON DELETE CASCADE DEFERRABLE,
+  state text NOT NULL DEFAULT 'queued',
+  failure_message text,
+  failure_class text,
+  started_at timestamp with time zone,
+  finished_at timestamp with time zone,
+  process_after timestamp with time zone,
+  num_resets integer NOT NULL DEFAULT 0,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS campaign_reapply_jobs_campaign_id ON campaign_reapply_jobs(campaign_id);
+CREATE INDEX IF NOT EXISTS campaign_reapply_jobs_state ON campaign_reapply_jobs(state);
+
+COMMIT;
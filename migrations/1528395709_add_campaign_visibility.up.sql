@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Campaigns with NAMESPACE_ONLY visibility are only visible to site admins,
+-- their authors and the members of their namespace.
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS visibility text NOT NULL DEFAULT 'PUBLIC';
+
+COMMIT;
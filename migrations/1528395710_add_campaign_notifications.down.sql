@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS campaign_notifications;
+DROP TABLE IF EXISTS campaign_notification_settings;
+
+COMMIT;
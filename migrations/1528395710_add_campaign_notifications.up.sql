@@ -0,0 +1,61 @@
+BEGIN;
+
+-- The events of a campaign that are emailed to its author and subscribers.
+-- Campaigns without settings notify their author about all events.
+CREATE TABLE IF NOT EXISTS campaign_notification_settings (
+  campaign_id bigint PRIMARY KEY REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  events jsonb NOT NULL DEFAULT '[]'::jsonb,
+  subscriber_ids jsonb NOT NULL DEFAULT '[]'::jsonb,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- The notifications that have been sent, so that every event is only
+-- notified about once. changeset_id is 0 for events that concern the whole
+-- campaign.
+CREATE TABLE IF NOT EXISTS campaign_notifications (
+  id bigserial PRIMARY KEY,
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  changeset_id bigint NOT NULL DEFAULT 0,
+  event text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS campaign_notifications_unique ON campaign_notifications(campaign_id, changeset_id, event);
+
+-- Record the events that happened before notifications existed as notified,
+-- so that their authors aren't flooded with emails after the upgrade.
+INSERT INTO campaign_notifications (campaign_id, changeset_id, event)
+SELECT owned_by_campaign_id, id, 'PUBLICATION_FAILED' FROM changesets
+WHERE
+  owned_by_campaign_id IS NOT NULL AND
+  reconciler_state = 'errored' AND
+  publication_state = 'UNPUBLISHED'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO campaign_notifications (campaign_id, event)
+SELECT campaigns.id, 'ALL_PUBLISHED' FROM campaigns
+WHERE
+  EXISTS (SELECT 1 FROM changesets WHERE owned_by_campaign_id = campaigns.id) AND
+  NOT EXISTS (
+    SELECT 1 FROM changesets
+    WHERE owned_by_campaign_id = campaigns.id AND publication_state = 'UNPUBLISHED'
+  )
+ON CONFLICT DO NOTHING;
+
+INSERT INTO campaign_notifications (campaign_id, event)
+SELECT campaigns.id, 'LAST_MERGED' FROM campaigns
+WHERE
+  EXISTS (
+    SELECT 1 FROM changesets
+    WHERE campaign_ids ? campaigns.id::text AND external_state = 'MERGED'
+  ) AND
+  NOT EXISTS (
+    SELECT 1 FROM changesets
+    WHERE
+      campaign_ids ? campaigns.id::text AND
+      (external_state IS NULL OR external_state NOT IN ('MERGED', 'CLOSED', 'DELETED'))
+  )
+ON CONFLICT DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Outgoing webhook payloads about campaign and changeset lifecycle events,
+-- one per configured endpoint. The endpoint's secret isn't stored: payloads
+-- are signed with the secret that's configured when they're delivered.
+CREATE TABLE IF NOT EXISTS campaign_webhook_deliveries (
+  id bigserial PRIMARY KEY,
+  url text NOT NULL,
+  event text NOT NULL,
+  payload jsonb NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+  last_error text,
+  delivered_at timestamp with time zone,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS campaign_webhook_deliveries_next_attempt_at ON campaign_webhook_deliveries(next_attempt_at) WHERE delivered_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_spec_executions (
+  id bigserial PRIMARY KEY,
+  raw_spec text NOT NULL,
+  namespace_user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  campaign_id bigint REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  campaign_spec_id bigint REFERENCES campaign_specs(id) ON DELETE SET NULL DEFERRABLE,
+  state text NOT NULL DEFAULT 'queued',
+  failure_message text,
+  repositories_total integer NOT NULL DEFAULT 0,
+  repositories_completed integer NOT NULL DEFAULT 0,
+  started_at timestamp with time zone,
+  finished_at timestamp with time zone,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  CONSTRAINT campaign_spec_executions_has_1_namespace CHECK ((namespace_user_id IS NULL) <> (namespace_org_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS campaign_spec_executions_state ON campaign_spec_executions(state);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS sync_error_message text;
+
+COMMIT;
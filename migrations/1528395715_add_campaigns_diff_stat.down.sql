@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_changesets_update_campaign_diff_stats ON changesets;
+DROP TRIGGER IF EXISTS trig_changesets_insert_delete_campaign_diff_stats ON changesets;
+DROP FUNCTION IF EXISTS changesets_update_campaign_diff_stats();
+
+ALTER TABLE campaigns
+  DROP COLUMN IF EXISTS diff_stat_added,
+  DROP COLUMN IF EXISTS diff_stat_changed,
+  DROP COLUMN IF EXISTS diff_stat_deleted;
+
+COMMIT;
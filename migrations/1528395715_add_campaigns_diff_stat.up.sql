@@ -0,0 +1,74 @@
+BEGIN;
+
+ALTER TABLE campaigns
+  ADD COLUMN IF NOT EXISTS diff_stat_added integer NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS diff_stat_changed integer NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS diff_stat_deleted integer NOT NULL DEFAULT 0;
+
+UPDATE
+  campaigns
+SET
+  diff_stat_added = stats.added,
+  diff_stat_changed = stats.changed,
+  diff_stat_deleted = stats.deleted
+FROM (
+  SELECT
+    campaigns.id AS campaign_id,
+    COALESCE(SUM(changesets.diff_stat_added), 0) AS added,
+    COALESCE(SUM(changesets.diff_stat_changed), 0) AS changed,
+    COALESCE(SUM(changesets.diff_stat_deleted), 0) AS deleted
+  FROM campaigns
+  JOIN changesets ON changesets.campaign_ids ? campaigns.id::text
+  GROUP BY campaigns.id
+) AS stats
+WHERE
+  campaigns.id = stats.campaign_id;
+
+CREATE OR REPLACE FUNCTION changesets_update_campaign_diff_stats() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+    BEGIN
+        IF TG_OP IN ('UPDATE', 'DELETE') THEN
+            UPDATE
+              campaigns
+            SET
+              diff_stat_added = campaigns.diff_stat_added - COALESCE(OLD.diff_stat_added, 0),
+              diff_stat_changed = campaigns.diff_stat_changed - COALESCE(OLD.diff_stat_changed, 0),
+              diff_stat_deleted = campaigns.diff_stat_deleted - COALESCE(OLD.diff_stat_deleted, 0)
+            WHERE
+              OLD.campaign_ids ? campaigns.id::text;
+        END IF;
+
+        IF TG_OP IN ('INSERT', 'UPDATE') THEN
+            UPDATE
+              campaigns
+            SET
+              diff_stat_added = campaigns.diff_stat_added + COALESCE(NEW.diff_stat_added, 0),
+              diff_stat_changed = campaigns.diff_stat_changed + COALESCE(NEW.diff_stat_changed, 0),
+              diff_stat_deleted = campaigns.diff_stat_deleted + COALESCE(NEW.diff_stat_deleted, 0)
+            WHERE
+              NEW.campaign_ids ? campaigns.id::text;
+        END IF;
+
+        RETURN NULL;
+    END;
+$$;
+
+DROP TRIGGER IF EXISTS trig_changesets_insert_delete_campaign_diff_stats ON changesets;
+CREATE TRIGGER trig_changesets_insert_delete_campaign_diff_stats
+  AFTER INSERT OR DELETE ON changesets
+  FOR EACH ROW EXECUTE PROCEDURE changesets_update_campaign_diff_stats();
+
+DROP TRIGGER IF EXISTS trig_changesets_update_campaign_diff_stats ON changesets;
+CREATE TRIGGER trig_changesets_update_campaign_diff_stats
+  AFTER UPDATE ON changesets
+  FOR EACH ROW
+  WHEN (
+    OLD.diff_stat_added IS DISTINCT FROM NEW.diff_stat_added OR
+    OLD.diff_stat_changed IS DISTINCT FROM NEW.diff_stat_changed OR
+    OLD.diff_stat_deleted IS DISTINCT FROM NEW.diff_stat_deleted OR
+    OLD.campaign_ids IS DISTINCT FROM NEW.campaign_ids
+  )
+  EXECUTE PROCEDURE changesets_update_campaign_diff_stats();
+
+COMMIT;
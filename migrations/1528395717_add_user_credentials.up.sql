@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Per-user code host credentials that changesets are published with, instead
+-- of the token of the external service. external_service_id is the ServiceID
+-- of the code host, e.g. "https://github.com/". username is only required by
+-- code hosts that can't authenticate with the token alone.
+CREATE TABLE IF NOT EXISTS user_credentials (
+  id bigserial PRIMARY KEY,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  external_service_type text NOT NULL,
+  external_service_id text NOT NULL,
+  username text,
+  token text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_credentials_user_id_external_service ON user_credentials(user_id, external_service_type, external_service_id);
+
+COMMIT;
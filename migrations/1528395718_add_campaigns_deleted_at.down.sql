@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS campaigns_deleted_at;
+
+ALTER TABLE campaigns DROP COLUMN IF EXISTS deleted_at;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS campaigns_deleted_at ON campaigns (deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Explicit grants of view or admin rights on a single campaign to a user or
+-- to all members of an org, in addition to the rights that follow from the
+-- campaign's author, namespace and visibility. Exactly one of user_id and
+-- org_id is set.
+CREATE TABLE IF NOT EXISTS campaign_permission_grants (
+  id bigserial PRIMARY KEY,
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  level text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  CONSTRAINT campaign_permission_grants_has_one_grantee CHECK ((user_id IS NULL) <> (org_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS campaign_permission_grants_campaign_id_user_id ON campaign_permission_grants(campaign_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS campaign_permission_grants_campaign_id_org_id ON campaign_permission_grants(campaign_id, org_id) WHERE org_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS changesets_external_url_checked_at;
+
+ALTER TABLE changesets DROP COLUMN IF EXISTS external_url_checked_at;
+ALTER TABLE changesets DROP COLUMN IF EXISTS external_url_state;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS external_url_state text;
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS external_url_checked_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS changesets_external_url_checked_at ON changesets (external_url_checked_at NULLS FIRST) WHERE publication_state = 'PUBLISHED';
+
+COMMIT;
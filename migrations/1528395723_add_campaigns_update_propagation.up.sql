@@ -0,0 +1,7 @@
+BEGIN;
+
+-- How re-applied campaign specs change the title and body of published
+-- changesets that were edited on the code host.
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS update_propagation text NOT NULL DEFAULT 'OVERWRITE';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- When the campaign was paused. The reconciler and syncer don't act on the
+-- changesets of paused campaigns.
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS paused_at timestamp with time zone;
+
+COMMIT;
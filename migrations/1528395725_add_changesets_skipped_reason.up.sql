@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Why the reconciler skipped the publication of the changeset because its
+-- repository didn't meet the publication preconditions of its campaign.
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS skipped_reason text;
+
+COMMIT;
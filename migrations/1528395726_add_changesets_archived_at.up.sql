@@ -0,0 +1,7 @@
+BEGIN;
+
+-- When the changeset was archived in the campaign that owns it, because a
+-- newer campaign spec no longer contained it.
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS archived_at timestamp with time zone;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS campaigns_namespace_user_id ON campaigns(namespace_user_id);
+CREATE INDEX IF NOT EXISTS campaigns_namespace_org_id ON campaigns(namespace_org_id);
+
+DROP INDEX IF EXISTS campaigns_namespace_user_id_id;
+DROP INDEX IF EXISTS campaigns_namespace_org_id_id;
+DROP INDEX IF EXISTS campaigns_open_id;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+-- ListCampaigns pages through campaigns ordered by ID. These indexes cover
+-- the most common filters together with that order, so that deep pages don't
+-- have to scan and filter all campaigns before the cursor.
+CREATE INDEX IF NOT EXISTS campaigns_namespace_user_id_id ON campaigns(namespace_user_id, id);
+CREATE INDEX IF NOT EXISTS campaigns_namespace_org_id_id ON campaigns(namespace_org_id, id);
+CREATE INDEX IF NOT EXISTS campaigns_open_id ON campaigns(id) WHERE closed_at IS NULL AND deleted_at IS NULL;
+
+-- Superseded by the indexes above.
+DROP INDEX IF EXISTS campaigns_namespace_user_id;
+DROP INDEX IF EXISTS campaigns_namespace_org_id;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS campaigns_name_trgm;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Used to filter campaigns by a substring of their name.
+CREATE INDEX IF NOT EXISTS campaigns_name_trgm ON campaigns USING gin (lower(name) gin_trgm_ops);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS changesets_failed;
+
+COMMIT;
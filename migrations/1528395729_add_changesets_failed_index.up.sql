@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Used to list the changesets whose reconciliation failed.
+CREATE INDEX IF NOT EXISTS changesets_failed ON changesets (id) WHERE failure_message IS NOT NULL;
+
+COMMIT;
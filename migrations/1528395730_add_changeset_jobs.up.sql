@@ -0,0 +1,30 @@
+BEGIN;
+
+-- Jobs that run an operation on a single changeset on behalf of a user, for
+-- example closing or commenting on it. The jobs created together for a bulk
+-- operation share a bulk_group. The columns state through num_resets are
+-- required by the workerutil package.
+CREATE TABLE IF NOT EXISTS changeset_jobs (
+  id bigserial PRIMARY KEY,
+  bulk_group text NOT NULL,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+  job_type text NOT NULL,
+  payload jsonb NOT NULL DEFAULT '{}'::jsonb,
+  state text NOT NULL DEFAULT 'queued',
+  failure_message text,
+  failure_class text,
+  started_at timestamp with time zone,
+  finished_at timestamp with time zone,
+  process_after timestamp with time zone,
+  num_resets integer NOT NULL DEFAULT 0,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_jobs_bulk_group ON changeset_jobs(bulk_group);
+CREATE INDEX IF NOT EXISTS changeset_jobs_changeset_id ON changeset_jobs(changeset_id);
+CREATE INDEX IF NOT EXISTS changeset_jobs_state ON changeset_jobs(state);
+
+COMMIT;
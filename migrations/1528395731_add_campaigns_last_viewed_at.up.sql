@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Used by the changeset syncer to sync the changesets of campaigns that are
+-- looked at more often.
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS last_viewed_at timestamp with time zone;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Daily changeset counts of campaigns, stored before the events they're
+-- computed from are compacted, so that burndown charts stay accurate.
+CREATE TABLE IF NOT EXISTS campaign_changeset_counts (
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  time timestamp with time zone NOT NULL,
+  total integer NOT NULL,
+  merged integer NOT NULL,
+  closed integer NOT NULL,
+  open integer NOT NULL,
+  open_approved integer NOT NULL,
+  open_changes_requested integer NOT NULL,
+  open_pending integer NOT NULL,
+  PRIMARY KEY (campaign_id, time)
+);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS campaigns_namespace_user_id_name;
+DROP INDEX IF EXISTS campaigns_namespace_org_id_name;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Campaigns are looked up by namespace and name when campaign specs are
+-- applied and when campaign URLs are resolved.
+CREATE INDEX IF NOT EXISTS campaigns_namespace_user_id_name ON campaigns (namespace_user_id, name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS campaigns_namespace_org_id_name ON campaigns (namespace_org_id, name) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS num_failures integer DEFAULT 0 NOT NULL;
+
+COMMIT;
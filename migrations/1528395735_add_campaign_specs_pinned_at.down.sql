@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE campaign_specs DROP COLUMN IF EXISTS pinned_at;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE campaign_specs ADD COLUMN IF NOT EXISTS pinned_at timestamp with time zone;
+
+COMMIT;
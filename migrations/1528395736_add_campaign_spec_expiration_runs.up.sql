@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Runs of the job that deletes expired campaign specs, changeset specs and
+-- deleted campaigns, so that site admins can see what it removed.
+CREATE TABLE IF NOT EXISTS campaign_spec_expiration_runs (
+  id bigserial PRIMARY KEY,
+  started_at timestamp with time zone NOT NULL,
+  finished_at timestamp with time zone NOT NULL,
+  dry_run boolean NOT NULL DEFAULT false,
+  changeset_specs_deleted integer NOT NULL DEFAULT 0,
+  campaign_specs_deleted integer NOT NULL DEFAULT 0,
+  campaigns_deleted integer NOT NULL DEFAULT 0,
+  error text
+);
+
+CREATE INDEX IF NOT EXISTS campaign_spec_expiration_runs_started_at ON campaign_spec_expiration_runs(started_at);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS campaign_repo_changeset_counts;
+
+COMMIT;
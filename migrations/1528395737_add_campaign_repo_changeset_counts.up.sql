@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Daily changeset counts of campaigns per repository, stored alongside
+-- campaign_changeset_counts before events are compacted, so that burndown
+-- charts grouped by repository stay accurate too.
+CREATE TABLE IF NOT EXISTS campaign_repo_changeset_counts (
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+  time timestamp with time zone NOT NULL,
+  total integer NOT NULL,
+  merged integer NOT NULL,
+  closed integer NOT NULL,
+  open integer NOT NULL,
+  open_approved integer NOT NULL,
+  open_changes_requested integer NOT NULL,
+  open_pending integer NOT NULL,
+  PRIMARY KEY (campaign_id, repo_id, time)
+);
+
+COMMIT;
@@ -102,6 +102,80 @@
 // 1528395699_campaign_remove_branch.up.sql (69B)
 // 1528395700_add_apply_data_to_campaign.down.sql (209B)
 // 1528395700_add_apply_data_to_campaign.up.sql (279B)
+// 1528395701_add_campaign_activities.down.sql (59B)
+// 1528395701_add_campaign_activities.up.sql (558B)
+// 1528395702_add_changesets_custom_metadata.down.sql (137B)
+// 1528395702_add_changesets_custom_metadata.up.sql (223B)
+// 1528395703_add_failure_class.down.sql (664B)
+// 1528395703_add_failure_class.up.sql (742B)
+// 1528395704_add_changeset_wait_reason.down.sql (75B)
+// 1528395704_add_changeset_wait_reason.up.sql (83B)
+// 1528395705_add_campaign_auto_merge.down.sql (73B)
+// 1528395705_add_campaign_auto_merge.up.sql (107B)
+// 1528395706_add_changeset_diff_stat_jobs.down.sql (64B)
+// 1528395706_add_changeset_diff_stat_jobs.up.sql (1.032kB)
+// 1528395707_add_campaign_templates.down.sql (58B)
+// 1528395707_add_campaign_templates.up.sql (1.072kB)
+// 1528395708_add_campaign_reapply_schedules.down.sql (110B)
+// 1528395708_add_campaign_reapply_schedules.up.sql (1.616kB)
+// 1528395709_add_campaign_visibility.down.sql (73B)
+// 1528395709_add_campaign_visibility.up.sql (237B)
+// 1528395710_add_campaign_notifications.down.sql (115B)
+// 1528395710_add_campaign_notifications.up.sql (2.344kB)
+// 1528395711_add_campaign_webhook_deliveries.down.sql (67B)
+// 1528395711_add_campaign_webhook_deliveries.up.sql (775B)
+// 1528395712_add_campaign_spec_executions.down.sql (64B)
+// 1528395712_add_campaign_spec_executions.up.sql (1.109kB)
+// 1528395713_add_changesets_sync_error_message.down.sql (82B)
+// 1528395713_add_changesets_sync_error_message.up.sql (90B)
+// 1528395714_add_campaign_comments.down.sql (57B)
+// 1528395714_add_campaign_comments.up.sql (490B)
+// 1528395715_add_campaigns_diff_stat.down.sql (401B)
+// 1528395715_add_campaigns_diff_stat.up.sql (2.69kB)
+// 1528395716_add_changeset_external_fork_namespace.down.sql (87B)
+// 1528395716_add_changeset_external_fork_namespace.up.sql (95B)
+// 1528395717_add_user_credentials.down.sql (56B)
+// 1528395717_add_user_credentials.up.sql (856B)
+// 1528395718_add_campaigns_deleted_at.down.sql (117B)
+// 1528395718_add_campaigns_deleted_at.up.sql (206B)
+// 1528395719_add_campaign_permission_grants.down.sql (66B)
+// 1528395719_add_campaign_permission_grants.up.sql (1.138kB)
+// 1528395720_add_campaign_auto_rebase.down.sql (74B)
+// 1528395720_add_campaign_auto_rebase.up.sql (108B)
+// 1528395721_add_changeset_external_url_state.down.sql (210B)
+// 1528395721_add_changeset_external_url_state.up.sql (342B)
+// 1528395722_add_changesets_failure_code.down.sql (76B)
+// 1528395722_add_changesets_failure_code.up.sql (84B)
+// 1528395723_add_campaigns_update_propagation.down.sql (81B)
+// 1528395723_add_campaigns_update_propagation.up.sql (239B)
+// 1528395724_add_campaigns_paused_at.down.sql (72B)
+// 1528395724_add_campaigns_paused_at.up.sql (211B)
+// 1528395725_add_changesets_skipped_reason.down.sql (78B)
+// 1528395725_add_changesets_skipped_reason.up.sql (234B)
+// 1528395726_add_changesets_archived_at.down.sql (75B)
+// 1528395726_add_changesets_archived_at.up.sql (225B)
+// 1528395727_add_campaigns_keyset_indexes.down.sql (337B)
+// 1528395727_add_campaigns_keyset_indexes.up.sql (665B)
+// 1528395728_add_campaigns_name_trgm_index.down.sql (59B)
+// 1528395728_add_campaigns_name_trgm_index.up.sql (173B)
+// 1528395729_add_changesets_failed_index.down.sql (57B)
+// 1528395729_add_changesets_failed_index.up.sql (176B)
+// 1528395730_add_changeset_jobs.down.sql (54B)
+// 1528395730_add_changeset_jobs.up.sql (1.327kB)
+// 1528395731_add_campaigns_last_viewed_at.down.sql (77B)
+// 1528395731_add_campaigns_last_viewed_at.up.sql (207B)
+// 1528395732_add_campaign_changeset_counts.down.sql (65B)
+// 1528395732_add_campaign_changeset_counts.up.sql (595B)
+// 1528395733_add_campaigns_namespace_name_index.down.sql (126B)
+// 1528395733_add_campaigns_namespace_name_index.up.sql (386B)
+// 1528395734_add_changeset_num_failures.down.sql (76B)
+// 1528395734_add_changeset_num_failures.up.sql (106B)
+// 1528395735_add_campaign_specs_pinned_at.down.sql (77B)
+// 1528395735_add_campaign_specs_pinned_at.up.sql (105B)
+// 1528395736_add_campaign_spec_expiration_runs.down.sql (69B)
+// 1528395736_add_campaign_spec_expiration_runs.up.sql (672B)
+// 1528395737_add_campaign_repo_changeset_counts.down.sql (70B)
+// 1528395737_add_campaign_repo_changeset_counts.up.sql (740B)
 
 package migrations
 
@@ -2210,6 +2284,1486 @@ func _1528395700_add_apply_data_to_campaignUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395701_add_campaign_activitiesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3b\x00\xc4\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x61\x63\x74\x69\x76\x69\x74\x69\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xa4\xf2\x31\x37\x3b\x00\x00\x00")
+
+func _1528395701_add_campaign_activitiesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395701_add_campaign_activitiesDownSql,
+		"1528395701_add_campaign_activities.down.sql",
+	)
+}
+
+func _1528395701_add_campaign_activitiesDownSql() (*asset, error) {
+	bytes, err := _1528395701_add_campaign_activitiesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395701_add_campaign_activities.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4f, 0x26, 0xc9, 0xeb, 0xcb, 0x51, 0x9e, 0xdc, 0x64, 0x1c, 0x4b, 0xbb, 0x99, 0x76, 0x0, 0x8e, 0xb3, 0x9f, 0x25, 0xb5, 0xdd, 0xec, 0x15, 0x97, 0xa1, 0x57, 0xe6, 0x91, 0xd7, 0x9, 0x61, 0x81}}
+	return a, nil
+}
+
+var __1528395701_add_campaign_activitiesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xcd\x4e\xc3\x30\x10\x84\xef\x79\x8a\xbd\x35\x95\x78\x82\xf6\x94\x26\x5b\x14\x91\xa6\x28\x71\xa5\xf6\x14\xb9\xf1\x2a\x5d\x20\x6e\x15\x2f\x14\x81\x78\x77\xe4\xf0\x93\xf0\x23\xc1\xd1\x9e\xf1\x37\xb3\xde\x05\x5e\xa6\xf9\x3c\x08\xe2\x02\x23\x85\xa0\xa2\x45\x86\x90\x2e\x21\x5f\x2b\xc0\x6d\x5a\xaa\x12\x6a\xdd\x9e\x34\x37\xb6\xd2\xb5\xf0\x03\x0b\x93\x83\x30\x00\x60\x03\x7b\x6e\x1c\x75\xac\xef\xe0\xba\x48\x57\x51\xb1\x83\x2b\xdc\x5d\x04\x30\xbc\x79\x33\xb1\x95\x9e\x98\x6f\xb2\x0c\x0a\x5c\x62\x81\x79\x8c\x03\xda\x85\x6c\xa6\xb0\xce\x21\xc1\x0c\x15\x42\x1c\x95\x71\x94\x20\x24\xde\x5a\xf8\x4e\x1e\x7a\xef\xa8\xab\xd8\x00\x5b\xa1\x86\xba\x31\xc8\x4b\xdf\x21\x25\xbe\x07\x7e\xa5\xd4\x07\x6d\x1b\x72\x24\xa3\x6e\xe3\x4a\x1f\xf2\x3f\x71\xb7\x6c\x0d\x08\x3d\x0e\x03\xfa\x90\x96\x44\x1b\x2d\x1a\x6e\xdc\xd1\xee\x87\xd9\x13\x5c\x46\x9b\x4c\xc1\xe4\xf9\x65\x32\x9b\xf5\xa2\xb7\xd7\x1d\x69\x21\x53\x69\x01\xe1\x96\x9c\xe8\xf6\x04\x67\x96\x43\x7f\x84\xa7\xa3\xa5\x9f\x0c\x7b\x3c\x87\xd3\x60\x3a\x2c\x2f\xcd\x13\xdc\xfe\xbd\xbc\xea\xf3\x8e\x8d\xff\xf3\x5f\x2c\xe1\xc8\xd2\x07\xac\x57\xab\x54\xcd\x83\xd7\x01\x00\xf1\xbe\x40\xf6\x2e\x02\x00\x00")
+
+func _1528395701_add_campaign_activitiesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395701_add_campaign_activitiesUpSql,
+		"1528395701_add_campaign_activities.up.sql",
+	)
+}
+
+func _1528395701_add_campaign_activitiesUpSql() (*asset, error) {
+	bytes, err := _1528395701_add_campaign_activitiesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395701_add_campaign_activities.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbc, 0x82, 0x8f, 0x8f, 0x6b, 0xdc, 0x59, 0x60, 0x46, 0xde, 0x96, 0x7a, 0x99, 0x8, 0xa, 0xc, 0x62, 0x79, 0xe1, 0x9b, 0x43, 0xf0, 0x40, 0x7, 0x19, 0x8c, 0x31, 0xde, 0x3, 0xc7, 0x51, 0x42}}
+	return a, nil
+}
+
+var __1528395702_add_changesets_custom_metadataDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xce\x48\xcc\x4b\x4f\x2d\x4e\x2d\x29\x8e\x4f\x2e\x2d\x2e\xc9\xcf\x8d\xcf\x4d\x2d\x49\x4c\x49\x2c\x49\x8c\x4f\xcf\xcc\x8b\xcf\x4c\xa9\xb0\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x45\xd2\xa0\x00\x36\xd0\xd9\xdf\x27\xd4\xd7\x0f\xd9\x44\x54\x63\xac\xb9\xb8\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x03\x00\xf9\x64\xc6\x61\x89\x00\x00\x00")
+
+func _1528395702_add_changesets_custom_metadataDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395702_add_changesets_custom_metadataDownSql,
+		"1528395702_add_changesets_custom_metadata.down.sql",
+	)
+}
+
+func _1528395702_add_changesets_custom_metadataDownSql() (*asset, error) {
+	bytes, err := _1528395702_add_changesets_custom_metadataDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395702_add_changesets_custom_metadata.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3, 0xe4, 0x1f, 0x1e, 0xef, 0xdc, 0x3, 0xfb, 0x8d, 0x38, 0x2c, 0x43, 0x38, 0x34, 0x7b, 0x41, 0x3c, 0xbf, 0x25, 0x7, 0x4d, 0xc5, 0x27, 0x22, 0x6b, 0x14, 0xa2, 0x3a, 0x27, 0x18, 0x3a, 0xec}}
+	return a, nil
+}
+
+var __1528395702_add_changesets_custom_metadataUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8e\xb1\x6a\x80\x30\x18\x84\xf7\x3c\xc5\x6d\xb6\xaf\x60\xa6\x68\x7e\x25\x10\x13\xd0\x04\xdc\x42\xaa\x62\x2d\x18\x87\xa4\x50\x28\x7d\xf7\x82\x43\x29\xce\x77\xf7\xdd\xd7\x50\xaf\x0c\x67\x4c\x68\x47\x23\x9c\x68\x34\x61\x79\x8f\x69\xdf\xf2\x56\x32\x84\x94\x68\xad\xf6\x83\x81\xea\x60\xac\x03\xcd\x6a\x72\x13\x96\xcf\x5c\xae\x33\x9c\x5b\x89\x6b\x2c\x11\x1f\xf9\x4a\x6f\x77\xc1\x78\xad\x21\xa9\x13\x5e\x3b\x54\xdf\x3f\x55\x5d\xdf\x21\x67\xac\x1d\x49\x38\x82\x32\x92\xe6\x27\xef\xef\x33\x3c\xd0\x61\x3f\x52\x38\xd6\x2f\x58\xf3\xdf\xcc\x4f\xca\xf4\xd8\x8f\x84\x97\xc7\xe0\x95\x33\xd6\xda\x61\x50\x8e\xb3\xdf\x01\x00\x80\x7b\xdb\xca\xdf\x00\x00\x00")
+
+func _1528395702_add_changesets_custom_metadataUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395702_add_changesets_custom_metadataUpSql,
+		"1528395702_add_changesets_custom_metadata.up.sql",
+	)
+}
+
+func _1528395702_add_changesets_custom_metadataUpSql() (*asset, error) {
+	bytes, err := _1528395702_add_changesets_custom_metadataUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395702_add_changesets_custom_metadata.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4, 0xcc, 0x5f, 0x1d, 0xc7, 0x70, 0x54, 0x2, 0x4a, 0x0, 0x7f, 0x4, 0x3d, 0xbc, 0x6f, 0xbb, 0x29, 0x9b, 0x73, 0x3d, 0x5d, 0xe2, 0x97, 0xba, 0xca, 0xfc, 0xf3, 0x7a, 0x70, 0x7f, 0x20, 0x71}}
+	return a, nil
+}
+
+var __1528395703_add_failure_classDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x91\x51\x4b\xf3\x30\x14\x86\xef\xf3\x2b\xde\xeb\x8f\x8f\xfe\x81\xe2\x45\x57\xcf\x34\x92\x36\xd2\x66\xce\xbb\x10\x96\x33\x17\xa8\xed\x48\x5a\xd4\x7f\x2f\x16\x85\x6d\xa8\x8c\xe1\xfd\xf3\x1c\x1e\xde\xb3\xa0\x1b\x59\xe7\x42\x5c\x37\xfa\x1e\x0f\x92\xd6\xe8\x52\xd8\xda\x69\xdf\x0d\xce\x27\xfb\x12\xc6\x9d\x8d\xbc\x1f\x52\x18\x87\xf8\x66\x7b\xf7\xcc\xf9\x29\x1c\x7a\xcf\xaf\xfc\x13\x2c\x0a\x65\xa8\x81\x29\x16\x8a\xb0\xd9\xb9\xfe\x89\x13\x8f\x09\xf3\x91\x52\xab\x55\x55\x43\x2e\x41\x8f\xb2\x35\x2d\xb6\x2e\x74\x53\x64\xbb\xe9\x5c\x4a\xf9\x91\x7b\x18\x76\xa1\xfd\x59\x7a\x9e\x2d\xca\x86\x0a\x43\x67\xae\x82\xa2\x15\x00\xd0\x92\xa2\xd2\x60\xca\xfe\xfd\x47\xcc\x3e\x26\x80\x4b\x38\x85\x97\x8d\xae\x8e\x6e\x62\x9a\xed\x3b\x2d\xeb\x19\x46\x84\xae\x11\xb3\xe0\x71\x85\x29\x3b\xf0\x83\x9f\xc9\xf5\x2d\x35\x84\x98\x79\xee\x78\x64\x6f\xdd\x08\xd9\xa2\x5e\x29\xf5\x5d\xf9\x6f\x2f\xba\xb4\xfc\x6b\xcc\xbf\x2d\xd7\x55\x25\x4d\x2e\xde\x07\x00\x67\x2d\xfd\x5d\x98\x02\x00\x00")
+
+func _1528395703_add_failure_classDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395703_add_failure_classDownSql,
+		"1528395703_add_failure_class.down.sql",
+	)
+}
+
+func _1528395703_add_failure_classDownSql() (*asset, error) {
+	bytes, err := _1528395703_add_failure_classDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395703_add_failure_class.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8f, 0xdb, 0x4, 0xe8, 0x83, 0xf7, 0xca, 0x16, 0x4a, 0xc7, 0xdb, 0xae, 0x4, 0x32, 0xd7, 0x1c, 0x56, 0xbb, 0x54, 0x69, 0x98, 0x5b, 0xaa, 0xd5, 0xb5, 0xfa, 0x15, 0x31, 0xb3, 0x5f, 0x23, 0x90}}
+	return a, nil
+}
+
+var __1528395703_add_failure_classUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x8f\xcb\x6e\xea\x30\x14\x45\xe7\xf9\x8a\x3d\xbe\xba\xe4\x07\xa2\x0e\x02\x98\xd6\x55\x12\x57\x89\x29\x9d\x45\x56\x7c\x68\x2c\x99\x04\xf9\x51\xe0\xef\x2b\x10\x83\x82\xda\xaa\xaf\xa1\xa5\x75\x96\xd7\x9e\xb2\x5b\x5e\x65\x49\x92\x17\x92\xd5\x90\xf9\xb4\x60\xe8\x7a\x35\x3c\x93\xa7\xe0\x91\xcf\xe7\x98\x89\x62\x59\x56\xe0\x0b\x54\x42\x82\x3d\xf1\x46\x36\x58\x2b\x63\xa3\xa3\xb6\xb3\xca\x7b\x04\xda\x87\xec\xc2\x61\xbd\x59\xb7\x71\x6b\x47\xa5\x7f\x6b\x31\x83\xa6\x3d\x7d\xd3\x92\x4c\x26\xa8\xa9\x73\xa4\x02\xe1\xc5\xd0\xce\xc3\x8f\x08\xbd\x0a\x08\x3d\x1d\x60\x86\xce\x46\x4d\xc7\x07\x06\xda\xa1\x1b\x6d\xdc\x0c\xc9\xbc\x16\x0f\x78\xe4\x6c\x75\x31\xa0\xdd\x99\xd0\xb7\x8e\xb6\xa3\x37\x61\x74\x87\x76\x50\x1b\xca\xae\xe1\x73\xe7\x07\x70\x32\xab\x59\x2e\xd9\x17\xe5\xc8\x9b\x04\x00\x1a\x56\xb0\x99\x44\x4c\xff\xfd\x87\x4b\x8f\xdf\x42\x79\x5c\xc3\x8b\x5a\x94\x17\x4e\xc4\xd3\xf5\xbd\xe0\xd5\x09\x86\x83\xa8\xe0\x52\xa3\x71\x83\x98\xbe\xb9\x37\xfa\x44\xae\xee\x58\xcd\xe0\x52\x4d\x96\x02\xe9\x56\x05\xf0\x06\xd5\xb2\x28\xde\x2b\xff\x6c\xe9\x4f\xcb\xcf\xce\x3f\x2e\x17\x65\xc9\x65\x96\xbc\x0e\x00\x72\x58\xc2\xa4\xe6\x02\x00\x00")
+
+func _1528395703_add_failure_classUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395703_add_failure_classUpSql,
+		"1528395703_add_failure_class.up.sql",
+	)
+}
+
+func _1528395703_add_failure_classUpSql() (*asset, error) {
+	bytes, err := _1528395703_add_failure_classUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395703_add_failure_class.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x92, 0xfa, 0xc, 0x12, 0x3c, 0x5e, 0xde, 0xa, 0x34, 0xdd, 0x59, 0x23, 0xa5, 0x4, 0x41, 0x20, 0x1, 0x4c, 0x3a, 0x22, 0x2c, 0x53, 0xe4, 0x10, 0x66, 0xa1, 0xd3, 0x1f, 0xe7, 0xfb, 0xef, 0x1e}}
+	return a, nil
+}
+
+var __1528395704_add_changeset_wait_reasonDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4b\x00\xb4\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x77\x61\x69\x74\x5f\x72\x65\x61\x73\x6f\x6e\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x76\x60\x2b\x6c\x4b\x00\x00\x00")
+
+func _1528395704_add_changeset_wait_reasonDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395704_add_changeset_wait_reasonDownSql,
+		"1528395704_add_changeset_wait_reason.down.sql",
+	)
+}
+
+func _1528395704_add_changeset_wait_reasonDownSql() (*asset, error) {
+	bytes, err := _1528395704_add_changeset_wait_reasonDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395704_add_changeset_wait_reason.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xac, 0x4a, 0xa9, 0x5e, 0xd8, 0x7, 0xc9, 0x32, 0x1c, 0xa3, 0xfd, 0x89, 0xc0, 0x79, 0x33, 0x94, 0x1d, 0x34, 0x16, 0x76, 0xfb, 0x87, 0x70, 0xa, 0x80, 0x65, 0x9b, 0x2e, 0x1c, 0x92, 0xf6, 0x7a}}
+	return a, nil
+}
+
+var __1528395704_add_changeset_wait_reasonUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x53\x00\xac\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x77\x61\x69\x74\x5f\x72\x65\x61\x73\x6f\x6e\x20\x74\x65\x78\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x5c\xa8\x34\xca\x53\x00\x00\x00")
+
+func _1528395704_add_changeset_wait_reasonUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395704_add_changeset_wait_reasonUpSql,
+		"1528395704_add_changeset_wait_reason.up.sql",
+	)
+}
+
+func _1528395704_add_changeset_wait_reasonUpSql() (*asset, error) {
+	bytes, err := _1528395704_add_changeset_wait_reasonUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395704_add_changeset_wait_reason.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0x6a, 0x9d, 0x17, 0x7, 0x0, 0xbf, 0xe1, 0xca, 0xa6, 0xa4, 0xf3, 0xb7, 0xc8, 0x51, 0x7a, 0xa, 0x5e, 0xa2, 0x34, 0xea, 0x61, 0xe, 0x52, 0x2b, 0xa7, 0x3c, 0xab, 0xfa, 0x91, 0x7a, 0x8c}}
+	return a, nil
+}
+
+var __1528395705_add_campaign_auto_mergeDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x49\x00\xb6\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x74\x6f\x5f\x6d\x65\x72\x67\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x98\x25\x68\x98\x49\x00\x00\x00")
+
+func _1528395705_add_campaign_auto_mergeDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395705_add_campaign_auto_mergeDownSql,
+		"1528395705_add_campaign_auto_merge.down.sql",
+	)
+}
+
+func _1528395705_add_campaign_auto_mergeDownSql() (*asset, error) {
+	bytes, err := _1528395705_add_campaign_auto_mergeDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395705_add_campaign_auto_merge.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb5, 0x4e, 0x4, 0x62, 0x10, 0xda, 0x54, 0x76, 0x35, 0x26, 0x1a, 0xef, 0xa3, 0x91, 0xdc, 0x68, 0xe2, 0x5d, 0xeb, 0x4a, 0xb2, 0xc7, 0xe2, 0xc, 0xae, 0x5b, 0xd9, 0x90, 0x7c, 0x76, 0x54, 0xa0}}
+	return a, nil
+}
+
+var __1528395705_add_campaign_auto_mergeUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6b\x00\x94\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x74\x6f\x5f\x6d\x65\x72\x67\x65\x20\x62\x6f\x6f\x6c\x65\x61\x6e\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x66\x61\x6c\x73\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x68\xda\x7a\xca\x6b\x00\x00\x00")
+
+func _1528395705_add_campaign_auto_mergeUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395705_add_campaign_auto_mergeUpSql,
+		"1528395705_add_campaign_auto_merge.up.sql",
+	)
+}
+
+func _1528395705_add_campaign_auto_mergeUpSql() (*asset, error) {
+	bytes, err := _1528395705_add_campaign_auto_mergeUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395705_add_campaign_auto_merge.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4, 0x3c, 0x4b, 0x10, 0xb1, 0x51, 0xe1, 0x9e, 0x97, 0xf1, 0x49, 0xc4, 0x28, 0x79, 0xf3, 0x40, 0x6, 0x6c, 0xe2, 0x96, 0x4a, 0x92, 0xae, 0x8, 0x4d, 0x14, 0x51, 0x6f, 0xa8, 0xb5, 0xf9, 0xbf}}
+	return a, nil
+}
+
+var __1528395706_add_changeset_diff_stat_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x40\x00\xbf\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x64\x69\x66\x66\x5f\x73\x74\x61\x74\x5f\x6a\x6f\x62\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xfe\xb3\x2d\xfa\x40\x00\x00\x00")
+
+func _1528395706_add_changeset_diff_stat_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395706_add_changeset_diff_stat_jobsDownSql,
+		"1528395706_add_changeset_diff_stat_jobs.down.sql",
+	)
+}
+
+func _1528395706_add_changeset_diff_stat_jobsDownSql() (*asset, error) {
+	bytes, err := _1528395706_add_changeset_diff_stat_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395706_add_changeset_diff_stat_jobs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe2, 0x5c, 0x23, 0x52, 0x9d, 0x94, 0x21, 0x1a, 0x8, 0xb2, 0xfd, 0x60, 0x3e, 0x37, 0x9b, 0x8a, 0xd6, 0x28, 0x14, 0xca, 0xbe, 0x78, 0x26, 0xce, 0x32, 0xb1, 0xc7, 0x5c, 0x6c, 0xeb, 0x20, 0xef}}
+	return a, nil
+}
+
+var __1528395706_add_changeset_diff_stat_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\x41\x6f\xdb\x3e\x0c\xc5\xef\xfe\x14\xef\xd6\x04\x68\x8a\xff\x3d\x27\x37\x51\xff\xf0\x96\x38\x83\xe3\x02\xed\xc9\x50\x2c\xda\xd6\x6a\x5b\xae\x44\x2d\xdd\x3e\xfd\x20\xa7\x5d\x0c\x14\x6d\x86\x1d\x45\xfe\xf8\x1e\x49\xf1\x56\xfc\x9f\xa4\xcb\x28\x5a\x2c\xf0\xc5\x1c\x1c\xb8\x91\x0c\x4b\xa5\xe9\x06\xcf\x04\x6e\x08\x4a\x57\x15\x1c\x4b\x86\xa9\x20\x51\x36\xb2\xaf\xc9\x11\xa3\xb2\xa6\x43\xad\xd9\x91\xfd\x41\x16\xb2\x62\xb2\xd0\xec\x82\x58\x43\x52\x61\x97\xac\x5f\x71\x05\xd3\x8f\x62\xa5\x51\x84\xc6\x38\xbe\x46\x65\x2c\xe8\x45\x76\x43\x4b\x50\x9e\xc0\x06\x32\x04\x4b\x5a\x0c\xde\x35\x37\xc8\x1b\x0a\x52\xa5\x69\x7d\xd7\xbb\xb1\x85\xd0\x91\x35\xbe\x6e\xd0\xfb\xae\xb0\xa1\x0d\x07\x69\x09\x96\x9e\xbd\xb6\xa4\x70\xf8\x39\xfa\x1c\x8d\x7d\x22\xeb\x59\xb7\x18\x64\xf9\x24\x6b\xba\x89\x56\x99\x88\x73\x81\x3c\xbe\xdd\x08\x24\x77\x48\x77\x39\xc4\x43\xb2\xcf\xf7\xe7\xa1\x8a\x30\x6d\x11\xac\x8a\xef\x61\x1f\xb3\x08\xd0\x0a\x07\x5d\x3b\xb2\x5a\xb6\xf8\x96\x25\xdb\x38\x7b\xc4\x57\xf1\x78\x1d\x61\x52\x78\xa2\x74\xcf\xa3\x6e\x7a\xbf\xd9\x20\x13\x77\x22\x13\xe9\x4a\x4c\x0c\xdc\x4c\xab\x39\x76\x29\xd6\x62\x23\x72\x81\x55\xbc\x5f\xc5\x6b\x81\x75\x60\xb3\xd0\x5a\x90\x3d\x48\x47\x85\xa5\xaa\x30\x5a\x81\xe9\xe5\x2c\x1a\xb2\x61\xb9\x1f\x67\x5f\xf7\x34\x0d\x07\xf5\xf8\x7e\x93\xe3\xea\xd9\x93\x27\x75\x15\xb8\x4a\xea\xd6\x5b\x2a\x3a\x72\x4e\xd6\xa7\x8a\x69\xbc\x6c\xa5\x73\x7f\xa2\x8e\xa5\x65\x52\x85\x64\xb0\xee\xc8\xb1\xec\x06\x1c\x35\x37\xe3\x13\xbf\x4c\x4f\x63\xb1\xee\xb5\x6b\x2e\x73\x83\x35\x25\x39\x57\x9c\x8e\xe6\x33\x72\xf2\xd1\xba\x67\xaa\xc9\xbe\x1f\xeb\xbf\x37\x50\x32\x53\x37\x5c\x44\x4b\x4b\xf2\xc2\x30\xef\x2b\x7b\x73\x9c\xcd\xa3\xf9\x32\x7a\x3b\xa4\x24\x5d\x8b\x87\xbf\x3c\xa4\xe2\x9c\xd0\x2a\x7c\xff\x47\xe0\x6c\x0a\xce\x97\xff\xe4\x15\x5c\xe9\x53\x93\x91\x18\x47\xd9\x6d\xb7\x49\xbe\x8c\x7e\x0f\x00\xbd\x98\xf7\x10\x08\x04\x00\x00")
+
+func _1528395706_add_changeset_diff_stat_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395706_add_changeset_diff_stat_jobsUpSql,
+		"1528395706_add_changeset_diff_stat_jobs.up.sql",
+	)
+}
+
+func _1528395706_add_changeset_diff_stat_jobsUpSql() (*asset, error) {
+	bytes, err := _1528395706_add_changeset_diff_stat_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395706_add_changeset_diff_stat_jobs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc4, 0xbe, 0xbf, 0x81, 0x9d, 0xb7, 0xa, 0x57, 0x2c, 0x2f, 0x3c, 0x32, 0x77, 0xf, 0x45, 0x1e, 0x2b, 0xed, 0xdd, 0x7f, 0x44, 0x91, 0xa8, 0xf9, 0x68, 0x80, 0x93, 0xfd, 0x7a, 0x43, 0xa4, 0xa7}}
+	return a, nil
+}
+
+var __1528395707_add_campaign_templatesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3a\x00\xc5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x74\x65\x6d\x70\x6c\x61\x74\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x5a\x67\xd1\xcb\x3a\x00\x00\x00")
+
+func _1528395707_add_campaign_templatesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395707_add_campaign_templatesDownSql,
+		"1528395707_add_campaign_templates.down.sql",
+	)
+}
+
+func _1528395707_add_campaign_templatesDownSql() (*asset, error) {
+	bytes, err := _1528395707_add_campaign_templatesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395707_add_campaign_templates.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf0, 0xd4, 0x19, 0x2d, 0x53, 0x42, 0xb2, 0x82, 0xf4, 0xb3, 0xd0, 0xb2, 0x64, 0x2b, 0x79, 0xb1, 0x9f, 0xe7, 0xbd, 0xf1, 0xbc, 0x83, 0xf, 0xc8, 0xc7, 0x75, 0xb5, 0xbe, 0x56, 0x3d, 0x88, 0xa1}}
+	return a, nil
+}
+
+var __1528395707_add_campaign_templatesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\x41\x6f\x9c\x30\x10\x85\xef\xfc\x8a\xb9\x01\x52\x2e\xbd\x66\xdb\x4a\x84\x9d\x6d\x51\x58\x53\x81\x23\x25\xaa\x2a\xcb\x0b\x53\xd6\xed\x62\x23\xdb\x51\x9a\xfe\xfa\xca\xb4\x1b\x94\xb2\xd5\xa6\x39\xe2\x19\x7f\xef\xf9\x31\x73\x85\x1f\x0a\xb6\x8a\xa2\xbc\xc6\x8c\x23\xf0\xec\xaa\x44\x28\x36\xc0\x2a\x0e\x78\x5b\x34\xbc\x81\x56\x0e\xa3\x54\xbd\x16\x9e\x86\xf1\x20\x3d\x39\x48\x22\x00\xd5\xc1\x4e\xf5\x8e\xac\x92\x07\xf8\x54\x17\xdb\xac\xbe\x83\x6b\xbc\xbb\x88\x00\xb4\x1c\x08\x3c\xfd\xf0\x13\x87\xdd\x94\x65\x38\xed\xc8\xb5\x56\x8d\x5e\x19\xfd\xbc\x08\x6b\xdc\x64\x37\x25\x87\x38\x0e\x7d\x47\x9d\x25\x61\x94\x56\x0e\xe4\xc9\x3a\xf8\xe6\x8c\xde\x9d\x20\x7c\xfe\x12\x5f\x5e\x4e\xc5\xa3\x11\x37\xca\x96\xc4\xbd\x23\x2b\x54\x07\x4a\x7b\xea\xc9\x42\x8d\x1b\xac\x91\xe5\xd8\x40\x28\xb9\x44\x75\x29\x54\x0c\xd6\x58\x22\x47\xc8\xb3\x26\xcf\xd6\x18\x9c\x61\x5d\x87\x54\x9e\xe3\x8c\xed\xff\x41\x33\xb6\x7f\x19\xac\xb5\x24\xbd\xf9\x1f\x53\x0d\xce\xaf\xfd\x1b\x44\x9d\x90\x1e\xbc\x1a\xc8\x79\x39\x8c\xf0\xa0\xfc\x7e\xfa\x84\x9f\x46\xd3\x32\x28\x6d\x1e\x92\x34\xd8\xb8\x1f\xbb\x57\xdf\x8e\x00\xf2\x8a\x35\xbc\xce\x0a\xc6\x4f\x0c\x8a\xd8\x4b\x27\xde\x88\xa7\xd8\x20\xff\x88\xf9\x35\x24\xc9\xf2\xbf\x14\xcd\xa4\x90\xc2\xdb\xf7\x90\x2c\x72\x3e\x56\xd3\x8b\xb3\x92\xe1\xae\xd0\xc6\x8b\xdd\x41\xea\xef\x47\xc5\x70\x1a\xd0\x71\xfc\x02\xc4\x3c\x66\xa2\xdd\x53\xfb\x04\x99\xc6\x4a\xf8\xc7\x91\xcc\xd7\x64\x6e\x4a\xe1\x1d\xc4\xd2\x5a\xf9\x18\xa7\x51\x3a\x2f\x53\xc1\xd6\x78\x7b\x76\x99\xc4\x32\x8b\x8a\x9d\xe8\x5b\x66\x96\xae\x5e\xad\xf4\x27\xd6\x73\x42\xbf\xdb\xa6\x27\x55\xdb\x6d\xc1\x57\xd1\xaf\x01\x00\x02\xa9\x08\xc4\x30\x04\x00\x00")
+
+func _1528395707_add_campaign_templatesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395707_add_campaign_templatesUpSql,
+		"1528395707_add_campaign_templates.up.sql",
+	)
+}
+
+func _1528395707_add_campaign_templatesUpSql() (*asset, error) {
+	bytes, err := _1528395707_add_campaign_templatesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395707_add_campaign_templates.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe4, 0x5e, 0xf6, 0xe4, 0x68, 0xde, 0xba, 0x4c, 0x57, 0x43, 0x3c, 0xf1, 0xe4, 0xee, 0xcd, 0xa3, 0x27, 0x96, 0x57, 0xa9, 0xdc, 0x28, 0x30, 0x58, 0x50, 0xf6, 0xd3, 0x35, 0xee, 0xe8, 0x6f, 0xd2}}
+	return a, nil
+}
+
+var __1528395708_add_campaign_reapply_schedulesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6e\x00\x91\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x72\x65\x61\x70\x70\x6c\x79\x5f\x6a\x6f\x62\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x72\x65\x61\x70\x70\x6c\x79\x5f\x73\x63\x68\x65\x64\x75\x6c\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x8e\xb7\xf8\xde\x6e\x00\x00\x00")
+
+func _1528395708_add_campaign_reapply_schedulesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395708_add_campaign_reapply_schedulesDownSql,
+		"1528395708_add_campaign_reapply_schedules.down.sql",
+	)
+}
+
+func _1528395708_add_campaign_reapply_schedulesDownSql() (*asset, error) {
+	bytes, err := _1528395708_add_campaign_reapply_schedulesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395708_add_campaign_reapply_schedules.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbe, 0x5d, 0x30, 0xe0, 0xd0, 0x2, 0x84, 0x8c, 0x5e, 0xc3, 0x97, 0xe8, 0x5e, 0xc6, 0x9d, 0xef, 0xce, 0xb2, 0x34, 0xb6, 0xf5, 0x94, 0xb1, 0x38, 0xae, 0xff, 0x51, 0xb7, 0x2f, 0x3e, 0x8b, 0xb3}}
+	return a, nil
+}
+
+var __1528395708_add_campaign_reapply_schedulesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x41\x73\x9b\x3c\x10\x86\xef\xfc\x8a\xbd\xc5\x9e\x31\x99\xef\xfc\xf9\x44\x6c\xa5\x43\x6b\xe3\x0e\x26\x33\xc9\x89\x91\xd1\x1a\xd4\x80\x20\x5a\x29\x4e\xfb\xeb\x3b\x12\x0d\xa6\xd3\x34\xb6\xd3\x23\xda\x77\xdf\x5d\x76\x9f\xbd\x61\x9f\xe2\x64\x1e\x04\x61\x08\x0b\xdd\x2a\xa0\xa2\x42\x61\x6b\x24\x68\x15\x1c\x2a\x59\x54\x50\xf0\xa6\xe3\xb2\x54\x04\x5c\x23\x68\x0c\x79\xd7\xd5\x12\xc5\xff\x60\x2a\x94\x7a\x88\x03\x75\x58\x80\x24\xe7\xa5\x31\xc4\x67\x5e\x5b\x6e\x50\x00\xa1\x7e\x46\x1d\x92\x14\x08\x5c\x09\x97\x06\x1a\xc9\xd6\x46\xaa\x12\x8a\x8a\xab\x12\x09\x8d\xcf\x27\xf8\xe5\x7e\x1d\x2c\x52\x16\x65\x0c\xb2\xe8\x66\xc5\x20\xbe\x85\x64\x93\x01\xbb\x8f\xb7\xd9\x76\x28\x99\x6b\x74\xf2\xef\xf9\xb1\xed\x49\x00\xc7\xb0\x14\xb0\x93\xa5\x54\x06\xbe\xa6\xf1\x3a\x4a\x1f\xe0\x0b\x7b\x80\x94\xdd\xb2\x94\x25\x0b\x76\x34\xa2\x89\x14\x53\xd8\x24\xb0\x64\x2b\x96\x31\x58\x44\xdb\x45\xb4\x64\xb0\x74\xd2\xd4\x75\x30\x0b\x60\x18\x0e\x18\x7c\x31\xbe\x9f\xe4\x6e\xb5\x72\x11\x85\x2f\x26\xd7\x56\xe5\xdc\x80\x91\x0d\x92\xe1\x4d\x07\x07\x69\x2a\xff\x09\x3f\x5a\x85\xbf\x25\xd4\x9c\x4e\x26\x38\xe3\x42\xa3\x9b\xe1\x59\xbe\xae\xdb\xe8\x6e\x95\x81\x6a\x0f\x93\xa9\xcb\xb6\x9d\xf8\x60\x76\x30\x9d\x07\xaf\x1b\x88\x93\x25\xbb\x3f\x7b\x03\xf9\x78\x16\x9b\xe4\x1d\xe5\x64\xa4\x74\xe5\xc2\x10\x3e\xb7\x3b\x02\x53\x71\x33\x26\xc8\x03\xf3\x6a\xe3\x29\x81\x76\x0f\x7c\x70\xf6\x50\x79\xef\x11\x5a\x33\x87\x21\xaa\x27\x8b\x16\x05\x1c\x2a\x54\xc0\x8f\x0b\x94\x04\xc2\xe2\x35\x64\xce\xb9\xad\x6d\xa3\x08\xc8\xf4\xb5\x74\x6b\xcb\x0a\x94\x6d\x72\xed\xb0\xf4\xd8\x3b\x33\x8d\x4f\x56\x6a\x14\xb0\xeb\xeb\x1c\x5a\xfd\x88\xda\x1a\x59\x43\xc7\x8b\x47\x5e\xe2\x65\xcc\x7e\x73\xff\xea\x70\xed\x29\x25\xd4\x92\xd7\x63\x50\x67\x6f\xa3\x3c\x2c\xec\xc3\x1c\x0f\xa6\x6e\x96\x67\x3a\x7b\xed\x99\x67\xd2\x0f\x72\x7c\x23\x03\x5c\x57\xfd\x42\xae\x9c\x6e\xcf\x65\x6d\x35\xe6\x0d\x12\xf1\xb2\xcf\x18\xbf\x17\x35\x27\x1a\x5e\xc9\x70\x7d\x82\x65\x9f\x2c\x95\xa4\xea\xb4\xae\xd3\x6d\x81\x44\x39\xdf\x1b\xd4\xef\x2a\x47\x24\x48\x65\xb0\x44\xfd\xe7\x6f\xfd\xf7\x6f\xb7\x7a\xf1\xb5\x39\x76\xf2\xe1\x55\x8a\x37\x0f\xcd\x89\x26\x23\xd1\x74\x7e\x79\x8d\xfe\x28\xfe\xea\xee\xc3\xbe\xf9\xcd\x7a\x1d\x67\xf3\xe0\xe7\x00\xc9\x72\x4a\x90\x50\x06\x00\x00")
+
+func _1528395708_add_campaign_reapply_schedulesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395708_add_campaign_reapply_schedulesUpSql,
+		"1528395708_add_campaign_reapply_schedules.up.sql",
+	)
+}
+
+func _1528395708_add_campaign_reapply_schedulesUpSql() (*asset, error) {
+	bytes, err := _1528395708_add_campaign_reapply_schedulesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395708_add_campaign_reapply_schedules.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc9, 0x6b, 0x26, 0x88, 0x7f, 0x95, 0x7f, 0x6f, 0x11, 0x53, 0x2e, 0xac, 0xc5, 0xa0, 0x5e, 0xea, 0x82, 0x85, 0x8a, 0x8f, 0x92, 0x9f, 0x2, 0x1e, 0xaa, 0x43, 0x9c, 0x12, 0x92, 0xe, 0x6b, 0x6}}
+	return a, nil
+}
+
+var __1528395709_add_campaign_visibilityDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x49\x00\xb6\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x76\x69\x73\x69\x62\x69\x6c\x69\x74\x79\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb9\x2e\x83\x6d\x49\x00\x00\x00")
+
+func _1528395709_add_campaign_visibilityDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395709_add_campaign_visibilityDownSql,
+		"1528395709_add_campaign_visibility.down.sql",
+	)
+}
+
+func _1528395709_add_campaign_visibilityDownSql() (*asset, error) {
+	bytes, err := _1528395709_add_campaign_visibilityDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395709_add_campaign_visibility.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7, 0xfd, 0xa9, 0x7, 0xcb, 0x33, 0x5b, 0xd4, 0x97, 0x96, 0xba, 0xe9, 0xe3, 0xb8, 0x8e, 0xc, 0x7d, 0x42, 0x87, 0x5a, 0xb8, 0x8, 0x95, 0x5, 0x65, 0xde, 0x99, 0x3c, 0x96, 0xfa, 0x91, 0x94}}
+	return a, nil
+}
+
+var __1528395709_add_campaign_visibilityUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xce\x4d\x6a\xc3\x30\x14\x04\xe0\xbd\x4e\x31\xbb\x6c\x9a\x5e\x20\x2b\x59\x56\x8a\x40\x96\x43\x2d\x43\xbb\x2a\x4a\xf2\x5a\x3f\xb0\xe4\x60\xbd\xfe\xe4\xf6\xa5\x3f\x94\x6e\x67\x86\xe1\x6b\xec\x9d\x0b\x3b\xa5\xb6\x5b\x98\x94\x2f\x89\x5f\x4a\xc5\x3b\xcb\x84\xa0\x3b\x3b\x1c\xb4\xb1\x4f\x7d\xf0\x8f\x78\xe3\xca\x47\x9e\x59\xae\x48\x2b\x61\x29\xf3\xf5\x27\x9b\x09\xb2\xa0\xb2\x10\xd2\x39\x73\xa9\x37\x5f\x67\x32\x11\xaf\x48\xaf\x32\x2d\x6b\x45\x2a\x67\xc8\x44\xc8\x94\x8f\xb4\x56\x2c\xcf\xbf\x83\x92\x32\xd5\x4b\x3a\xd1\xad\xd2\x3e\xda\x7b\x44\xdd\x78\x8b\xd3\x1f\x45\xb7\x2d\x4c\xef\xc7\x2e\xc0\xed\x11\xfa\x08\xfb\xe0\x86\x38\xfc\xf7\x08\x7d\xc8\x77\x15\x46\xef\xd1\xda\xbd\x1e\x7d\xc4\xe6\x30\x36\xde\x99\xcd\x4e\x29\xd3\x77\x9d\x8b\x3b\xf5\x39\x00\x9a\xc8\x8f\xcc\xed\x00\x00\x00")
+
+func _1528395709_add_campaign_visibilityUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395709_add_campaign_visibilityUpSql,
+		"1528395709_add_campaign_visibility.up.sql",
+	)
+}
+
+func _1528395709_add_campaign_visibilityUpSql() (*asset, error) {
+	bytes, err := _1528395709_add_campaign_visibilityUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395709_add_campaign_visibility.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbb, 0x24, 0xbe, 0xaf, 0x45, 0x29, 0xe9, 0xe4, 0x64, 0xbc, 0x5c, 0xef, 0x2d, 0x8b, 0x66, 0xa7, 0x2e, 0xa2, 0xa5, 0x2a, 0xc4, 0xff, 0x1b, 0xdb, 0x71, 0xbc, 0x31, 0xed, 0xe8, 0x19, 0x21, 0x47}}
+	return a, nil
+}
+
+var __1528395710_add_campaign_notificationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x73\x00\x8c\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x6e\x6f\x74\x69\x66\x69\x63\x61\x74\x69\x6f\x6e\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x6e\x6f\x74\x69\x66\x69\x63\x61\x74\x69\x6f\x6e\x5f\x73\x65\x74\x74\x69\x6e\x67\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x94\xf5\x0c\xb7\x73\x00\x00\x00")
+
+func _1528395710_add_campaign_notificationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395710_add_campaign_notificationsDownSql,
+		"1528395710_add_campaign_notifications.down.sql",
+	)
+}
+
+func _1528395710_add_campaign_notificationsDownSql() (*asset, error) {
+	bytes, err := _1528395710_add_campaign_notificationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395710_add_campaign_notifications.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x92, 0xe5, 0x2b, 0xf9, 0x75, 0x4b, 0xa9, 0x6c, 0x6f, 0x39, 0x55, 0xff, 0x9a, 0xfb, 0x74, 0x1a, 0x12, 0xa0, 0xa, 0xe, 0x8f, 0x48, 0x7b, 0xb, 0x7f, 0x71, 0x97, 0x24, 0xbf, 0x5d, 0x41, 0x66}}
+	return a, nil
+}
+
+var __1528395710_add_campaign_notificationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\x51\x6f\xda\x30\x14\x85\xdf\xf3\x2b\xce\x5b\x41\xa2\xa8\xcf\xed\x53\x0a\xee\x14\x0d\xc2\x16\x82\xd4\x6a\x9a\x22\x27\xb9\x10\x4f\xc1\x66\xf6\x0d\x8c\xfd\xfa\xc9\x09\x84\x76\xeb\x43\xd7\x3e\xc6\x39\xf7\xf3\xf5\x39\xe7\x5e\x7c\x8a\xe2\xbb\x20\xb8\xbe\x46\x5a\x11\x68\x4f\x9a\x1d\xcc\x1a\x12\x85\xdc\xee\xa4\xda\x68\x70\x25\x19\xd2\x12\x68\x2b\x55\x4d\x25\xd8\x40\xb1\x83\x6c\xb8\x32\x16\x52\x97\x70\x4d\xee\x0a\xab\x72\xb2\x6e\xec\x59\x93\xd3\xac\xc3\x41\x71\x65\x1a\x86\x23\x66\xa5\x37\x0e\xda\xb0\x5a\x1f\xc1\x15\x29\xdb\x23\x72\x2f\x91\x75\x7d\x5a\x60\x1c\x4c\x12\x11\xa6\x02\x69\x78\x3f\x13\x88\x1e\x10\x2f\x52\x88\xc7\x68\x99\x2e\xfb\xbd\xb2\x96\xa4\x0a\xc9\xca\xe8\xac\xe7\x0f\x02\x5c\x24\xaa\x44\xae\x36\x4a\x33\xbe\x24\xd1\x3c\x4c\x9e\xf0\x59\x3c\x21\x11\x0f\x22\x11\xf1\x44\x5c\x60\x6e\xa0\xca\x21\x16\x31\xa6\x62\x26\x52\x81\x49\xb8\x9c\x84\x53\x81\xa9\x97\x26\x7e\x8b\x51\x80\xb3\x3d\x3f\x9c\xd1\x79\xbb\x52\xbc\x9a\xcd\xbc\x26\x5c\xcd\x52\x5c\x7d\xfb\x7e\x75\x7b\xdb\xfe\xf4\xe2\x8b\x27\x99\x2a\xdf\x3a\x54\x58\x92\x4c\x65\x26\x19\xac\xb6\xe4\x58\x6e\x77\xad\x87\xed\x27\x7e\x1b\x4d\xff\x32\xb4\x39\x0c\x86\x7e\xba\xd9\x95\xef\x9c\x0e\x86\x97\x12\x3c\xf7\xd5\x75\xe9\x57\x72\x4f\xc8\x89\x34\x1c\x69\x1e\xc1\x99\xee\x9c\xf6\x64\x8f\x9d\x2d\x50\x0e\x46\xd7\x47\x4f\xe9\x08\x54\x9e\x82\x35\xba\xa0\x31\x8a\x4a\xea\x0d\x39\xe2\x4c\x95\x5e\x7c\x83\xb5\xb1\x67\x4b\x5b\x5a\xe1\x85\xd6\x17\x8e\x70\xa8\x4c\x4d\x9e\x75\x4e\xe8\xff\x3b\xd1\x75\xa1\xab\x80\x23\xab\x64\xfd\xbc\x05\xa3\xd7\x7b\xd2\xdb\xf3\xee\x92\xbc\x78\xe7\xdf\xd4\xb3\xe9\x37\x7d\x9d\xc0\xf4\xeb\x22\xf8\x58\x07\xda\x14\x4f\x36\xad\xe2\xe8\xeb\x4a\x20\x8a\xa7\xe2\xf1\x4d\x6e\x65\x8d\x56\x3f\x1b\xf2\xef\x7b\x5d\x30\xe8\x8f\x55\x39\x7a\x11\xe7\xa8\x8b\xb1\xbd\x7d\x31\x9f\x47\xe9\x5d\xf0\x67\x00\x19\x30\x1a\x36\x59\x04\x00\x00")
+
+func _1528395710_add_campaign_notificationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395710_add_campaign_notificationsUpSql,
+		"1528395710_add_campaign_notifications.up.sql",
+	)
+}
+
+func _1528395710_add_campaign_notificationsUpSql() (*asset, error) {
+	bytes, err := _1528395710_add_campaign_notificationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395710_add_campaign_notifications.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x58, 0x34, 0x7e, 0x5f, 0x41, 0x4f, 0xc0, 0x2f, 0xa2, 0x95, 0x6b, 0xa1, 0x32, 0x7c, 0x64, 0xd7, 0xa0, 0xe0, 0x92, 0x7c, 0x7c, 0x9a, 0x91, 0x33, 0xc1, 0x5f, 0x1f, 0xe0, 0xe9, 0x30, 0xe7, 0xcb}}
+	return a, nil
+}
+
+var __1528395711_add_campaign_webhook_deliveriesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x43\x00\xbc\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x64\x65\x6c\x69\x76\x65\x72\x69\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x5b\x74\xa6\x81\x43\x00\x00\x00")
+
+func _1528395711_add_campaign_webhook_deliveriesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395711_add_campaign_webhook_deliveriesDownSql,
+		"1528395711_add_campaign_webhook_deliveries.down.sql",
+	)
+}
+
+func _1528395711_add_campaign_webhook_deliveriesDownSql() (*asset, error) {
+	bytes, err := _1528395711_add_campaign_webhook_deliveriesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395711_add_campaign_webhook_deliveries.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x43, 0x87, 0xf3, 0x21, 0x31, 0x63, 0x7e, 0xf3, 0x97, 0x26, 0x5a, 0xcb, 0xcb, 0x53, 0x3a, 0x1a, 0xec, 0xe8, 0x6b, 0x27, 0x7b, 0xc6, 0xc7, 0xef, 0x61, 0x64, 0x3, 0x12, 0xd1, 0x22, 0x68, 0xb0}}
+	return a, nil
+}
+
+var __1528395711_add_campaign_webhook_deliveriesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\x41\x6f\xdb\x30\x0c\x85\xef\xfa\x15\xef\x96\x04\x68\x8b\x9d\x97\x53\xda\xaa\x9b\xb1\xc4\x19\x12\x17\x6b\x4f\x86\x62\xb1\xb6\x36\x9b\x32\x24\xa6\x69\xf6\xeb\x07\x05\xae\xb7\xb5\x40\x30\xec\x68\xea\xf1\x23\xdf\xa3\xaf\xf5\xa7\x2c\x9f\x2b\x75\x79\x89\xf5\x5e\x6a\xef\xb8\xc6\x81\x76\x8d\xf7\x3f\xd0\x9b\x63\xeb\x8d\x8d\x30\x3b\xbf\x17\x54\xa6\xeb\x8d\xab\x19\x86\x2d\xaa\xc6\x70\x4d\x91\x04\xad\x7b\xa2\xea\x58\xb5\x04\x7a\x26\x96\x78\x91\x58\x9e\x09\x3d\x05\x54\x9e\x9f\x5c\xbd\x0f\x64\x41\x6c\x7b\xef\x58\xae\x50\x34\x34\x7e\x4d\x22\x22\x55\x81\x04\x2e\xf2\x44\x10\xc5\x07\xb2\x1f\xc7\xd9\x09\x66\x02\x21\xba\x9a\xc9\xe2\xe0\xa4\x81\x34\xf4\xda\x24\x8d\x91\x49\xfc\x73\xcc\xa1\x21\x4e\x8a\xe3\x24\x10\x2c\xb5\xee\x99\x02\xd9\x2b\x75\xb3\xd1\x8b\x42\xa3\x58\x5c\x2f\x35\xb2\x3b\xe4\xeb\x02\xfa\x21\xdb\x16\xdb\xd1\x58\x39\x18\x2f\x87\x36\x47\x11\x53\x05\x38\x8b\x9d\xab\x23\x05\x67\x5a\x7c\xdd\x64\xab\xc5\xe6\x11\x5f\xf4\xe3\x85\x02\xf6\xa1\x85\xd0\x8b\x9c\x78\xf9\xfd\x72\x99\x8a\xa7\x20\xde\x97\x07\x4f\xf8\x1e\x3d\xef\xfe\x7a\x31\x22\xd4\xf5\x12\xe1\x58\xa8\xa6\x30\x3e\xe2\x56\xdf\x2d\xee\x97\x05\x3e\x24\x00\xd3\x8b\x94\x83\xb6\x34\x02\x71\x1d\x45\x31\x5d\x3f\xe4\xe2\x3a\xc2\xcf\x14\xfd\xbb\x76\xf6\x87\xe9\x2c\x21\x5a\x13\xa5\xa4\x10\x7c\x38\xed\x97\x4a\x63\x48\xe7\x90\x49\x58\x05\x32\x42\xf6\x3f\x26\xab\xd9\x5c\xbd\x5e\x20\xcb\x6f\xf5\xc3\xbf\x5f\xa0\x7c\x6b\x7a\x9d\x9f\x93\x4f\xdf\xc8\x67\xf8\xf6\x59\x6f\xf4\xef\x3f\x21\x21\xb2\xed\x69\xc7\xb9\x52\x37\xeb\xd5\x2a\x2b\xe6\xea\xd7\x00\x61\x18\xf8\x66\x07\x03\x00\x00")
+
+func _1528395711_add_campaign_webhook_deliveriesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395711_add_campaign_webhook_deliveriesUpSql,
+		"1528395711_add_campaign_webhook_deliveries.up.sql",
+	)
+}
+
+func _1528395711_add_campaign_webhook_deliveriesUpSql() (*asset, error) {
+	bytes, err := _1528395711_add_campaign_webhook_deliveriesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395711_add_campaign_webhook_deliveries.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdd, 0xe3, 0x85, 0xd5, 0x73, 0x4d, 0xa7, 0x66, 0xae, 0xc8, 0x42, 0x78, 0x29, 0x9a, 0xc9, 0x65, 0xcb, 0x42, 0x46, 0xef, 0x3b, 0xb6, 0xfe, 0x8f, 0x44, 0x25, 0xd7, 0xbc, 0x1e, 0xbf, 0x54, 0xd5}}
+	return a, nil
+}
+
+var __1528395712_add_campaign_spec_executionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x40\x00\xbf\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x70\x65\x63\x5f\x65\x78\x65\x63\x75\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xa4\x86\x10\x8e\x40\x00\x00\x00")
+
+func _1528395712_add_campaign_spec_executionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395712_add_campaign_spec_executionsDownSql,
+		"1528395712_add_campaign_spec_executions.down.sql",
+	)
+}
+
+func _1528395712_add_campaign_spec_executionsDownSql() (*asset, error) {
+	bytes, err := _1528395712_add_campaign_spec_executionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395712_add_campaign_spec_executions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd4, 0x95, 0x50, 0x42, 0x73, 0xcc, 0xe3, 0xee, 0x28, 0x46, 0xf9, 0x20, 0x59, 0x42, 0x66, 0xfc, 0xd, 0x89, 0xa9, 0x8b, 0xd8, 0x50, 0xef, 0x81, 0x58, 0xeb, 0x2c, 0xff, 0xc5, 0x38, 0x83, 0x94}}
+	return a, nil
+}
+
+var __1528395712_add_campaign_spec_executionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x41\x6f\x9b\x40\x10\x85\xef\xfc\x8a\xb9\x05\xa4\x1e\xda\x73\xaa\x4a\x04\x4f\x5a\x14\xbc\x54\xb0\x91\x92\xd3\x6a\x0b\x13\xbc\x92\x61\xe9\xee\x20\x47\xfd\xf5\xd5\xe2\x3a\xad\x83\x5c\xbb\xcd\x11\xe6\xbd\x8f\xe1\xed\xbe\x1b\xfc\x9c\x8b\xeb\x28\xca\x2a\x4c\x25\x82\x4c\x6f\x0a\x84\xfc\x16\x44\x29\x01\x1f\xf2\x5a\xd6\xd0\xe8\x7e\xd4\xa6\x1b\x94\x1f\xa9\x51\xf4\x4c\xcd\xc4\xc6\x0e\x1e\xe2\x08\xc0\xb4\xf0\xcd\x74\x9e\x9c\xd1\x5b\xf8\x5a\xe5\xeb\xb4\x7a\x84\x3b\x7c\x7c\x17\x01\x38\xbd\x9b\x3d\xc0\xf4\xcc\x33\x51\xdc\x17\x45\x98\x0c\xba\x27\x3f\xea\x86\xd4\xe4\xc9\x29\xd3\x82\x19\x98\x3a\x72\x50\xe1\x2d\x56\x28\x32\xac\x21\x8c\x7c\x6c\xda\x04\x4a\x01\x2b\x2c\x50\x22\x64\x69\x9d\xa5\x2b\x84\x55\x90\x55\x61\xd9\x63\x9c\x75\xdd\x09\x9a\x75\xdd\x65\xb0\xd7\x1b\x1d\xf6\xfe\xbf\xd5\x5e\xc2\xdb\x07\x65\x06\xfe\x93\x73\x98\xfe\x23\x2b\x84\xfa\x77\xe0\x9c\xfb\x6b\x6a\x8d\xbf\xfe\xe4\x18\xeb\x59\x33\x1d\x9f\x51\xc8\x37\xbd\x2f\x24\x5c\x7d\x9f\x68\xa2\xf6\x2a\xe8\x9e\xb4\xd9\x4e\x8e\x54\x4f\xde\xeb\x6e\xef\x08\xef\x1d\x8d\xd6\x1b\xb6\xce\x90\x57\x6c\x59\x6f\x97\xd9\x1d\x78\xef\x17\x86\xc6\xf6\xe3\x96\x98\xda\x33\x26\xcf\xda\x31\xb5\x4a\x33\xb0\xe9\xc9\xb3\xee\x47\xd8\x19\xde\xcc\x8f\xf0\xc3\x0e\x14\x64\x4f\x66\x30\x7e\x73\x5e\xd7\x38\xd2\x67\x70\xcb\x45\x06\xbb\x8b\x93\xe0\x9e\xc6\xf6\x0d\xee\xac\x14\xb5\xac\xd2\x5c\xc8\x93\xdd\x52\x1b\xed\xd5\x07\xf5\x72\xb1\x21\xfb\x82\xd9\x1d\xc4\xf1\xb2\x39\x79\x3d\x7f\x26\x81\x8f\x9f\x20\x5e\x34\xe1\x30\x4d\xa2\xe4\x77\xcb\x73\xb1\xc2\x87\x0b\x5b\xae\xf6\xd7\xa3\x14\x27\x15\xf1\xac\x98\xf1\xe5\x7a\x9d\xcb\xeb\xe8\xe7\x00\xc6\xff\x29\xa8\x55\x04\x00\x00")
+
+func _1528395712_add_campaign_spec_executionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395712_add_campaign_spec_executionsUpSql,
+		"1528395712_add_campaign_spec_executions.up.sql",
+	)
+}
+
+func _1528395712_add_campaign_spec_executionsUpSql() (*asset, error) {
+	bytes, err := _1528395712_add_campaign_spec_executionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395712_add_campaign_spec_executions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2a, 0x70, 0x9c, 0xa7, 0x15, 0xd1, 0xb0, 0x9, 0xd2, 0xc2, 0xed, 0xbe, 0x46, 0x77, 0x6a, 0x54, 0xca, 0x3a, 0x2a, 0x5e, 0xdf, 0x80, 0xea, 0xac, 0x14, 0x9f, 0x85, 0x9a, 0x71, 0xc6, 0x7c, 0x4c}}
+	return a, nil
+}
+
+var __1528395713_add_changesets_sync_error_messageDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x52\x00\xad\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x79\x6e\x63\x5f\x65\x72\x72\x6f\x72\x5f\x6d\x65\x73\x73\x61\x67\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xe8\x92\x3f\xd3\x52\x00\x00\x00")
+
+func _1528395713_add_changesets_sync_error_messageDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395713_add_changesets_sync_error_messageDownSql,
+		"1528395713_add_changesets_sync_error_message.down.sql",
+	)
+}
+
+func _1528395713_add_changesets_sync_error_messageDownSql() (*asset, error) {
+	bytes, err := _1528395713_add_changesets_sync_error_messageDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395713_add_changesets_sync_error_message.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x93, 0x8, 0x53, 0x4d, 0x68, 0xae, 0x69, 0xfc, 0x80, 0xcf, 0x9e, 0x8, 0x73, 0xde, 0xbf, 0x66, 0x86, 0xab, 0x93, 0x21, 0x9d, 0xbe, 0x4c, 0x45, 0x21, 0x8, 0x89, 0x19, 0x40, 0xa9, 0xe6, 0x8b}}
+	return a, nil
+}
+
+var __1528395713_add_changesets_sync_error_messageUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5a\x00\xa5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x73\x79\x6e\x63\x5f\x65\x72\x72\x6f\x72\x5f\x6d\x65\x73\x73\x61\x67\x65\x20\x74\x65\x78\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb2\xba\xe5\x98\x5a\x00\x00\x00")
+
+func _1528395713_add_changesets_sync_error_messageUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395713_add_changesets_sync_error_messageUpSql,
+		"1528395713_add_changesets_sync_error_message.up.sql",
+	)
+}
+
+func _1528395713_add_changesets_sync_error_messageUpSql() (*asset, error) {
+	bytes, err := _1528395713_add_changesets_sync_error_messageUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395713_add_changesets_sync_error_message.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7a, 0x43, 0x5e, 0xe3, 0x4e, 0x77, 0x49, 0xa3, 0x8c, 0x6d, 0x2c, 0x88, 0xf3, 0x1d, 0x88, 0x24, 0x8, 0x69, 0x59, 0x6, 0x7, 0x37, 0x64, 0x8, 0x3f, 0xe4, 0xa0, 0xb2, 0x25, 0xd8, 0x78, 0xad}}
+	return a, nil
+}
+
+var __1528395714_add_campaign_commentsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x39\x00\xc6\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x23\x97\xce\xa7\x39\x00\x00\x00")
+
+func _1528395714_add_campaign_commentsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395714_add_campaign_commentsDownSql,
+		"1528395714_add_campaign_comments.down.sql",
+	)
+}
+
+func _1528395714_add_campaign_commentsDownSql() (*asset, error) {
+	bytes, err := _1528395714_add_campaign_commentsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395714_add_campaign_comments.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2, 0xeb, 0x81, 0x19, 0x11, 0xb2, 0x91, 0x2d, 0xc5, 0x5c, 0xa, 0x48, 0x30, 0x88, 0xde, 0xa, 0xb0, 0xc5, 0xdf, 0xff, 0x83, 0x55, 0xa8, 0xf7, 0x5c, 0xa5, 0xed, 0xc6, 0x82, 0x78, 0xb4, 0x2d}}
+	return a, nil
+}
+
+var __1528395714_add_campaign_commentsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xc1\x6a\xeb\x30\x10\x45\xf7\xfa\x8a\xbb\x74\xe0\xfd\x41\x56\x8a\x3d\x79\x88\x3a\x4a\x91\x15\x48\x56\x46\x89\x84\x23\xa8\x65\x63\xcb\xa4\xed\xd7\x17\xb9\xa5\x4e\xdb\x45\xa1\x4b\x0d\xe7\x1e\xcd\xdc\x0d\xfd\x17\x72\xcd\x58\xae\x88\x6b\x82\xe6\x9b\x92\x20\xb6\x90\x7b\x0d\x3a\x8a\x4a\x57\xb8\x98\xb6\x37\xbe\x09\xf5\xa5\x6b\x5b\x17\xe2\x88\x8c\x01\xde\xe2\xec\x9b\xd1\x0d\xde\x3c\xe1\x51\x89\x1d\x57\x27\x3c\xd0\xe9\x1f\xc3\x92\x78\x87\x7c\x88\xb3\x4f\x1e\xca\x12\x8a\xb6\xa4\x48\xe6\xb4\x88\xc7\xcc\xdb\x15\xf6\x12\x05\x95\xa4\x09\x39\xaf\x72\x5e\x10\x8a\x84\xaa\xb4\x51\x92\x9a\x29\x5e\xbb\xa1\xf6\x16\x3e\x44\xd7\xb8\xe1\x5e\x35\x8d\x6e\xf8\xae\xa9\xe8\xe3\xcb\xaf\x9e\x73\x67\x5f\x10\xdd\xf3\xb2\x53\x9a\x5e\x06\x67\xa2\xb3\xb5\x89\x88\xbe\x75\x63\x34\x6d\x8f\x9b\x8f\xd7\xf9\x89\xd7\x2e\xb8\xe5\x86\x82\xb6\xfc\x50\x6a\x84\xee\x96\xad\x52\x7a\xea\xed\x1f\xd3\x6c\xb5\x94\x2f\x64\x41\xc7\xdf\xca\xaf\x3f\x27\xde\xa6\xce\x7e\x00\xd9\x1d\x30\xcb\xf7\xbb\x9d\xd0\x6b\xf6\x36\x00\x25\xaf\xfb\x7d\xea\x01\x00\x00")
+
+func _1528395714_add_campaign_commentsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395714_add_campaign_commentsUpSql,
+		"1528395714_add_campaign_comments.up.sql",
+	)
+}
+
+func _1528395714_add_campaign_commentsUpSql() (*asset, error) {
+	bytes, err := _1528395714_add_campaign_commentsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395714_add_campaign_comments.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa7, 0xd8, 0xe9, 0x3b, 0xbf, 0xf0, 0x3d, 0x33, 0x66, 0x2c, 0x82, 0x99, 0x25, 0xd7, 0x77, 0x16, 0xd0, 0xb8, 0x1c, 0x3a, 0x15, 0x43, 0x71, 0xf2, 0x0, 0x4, 0x27, 0xd6, 0xb0, 0xe6, 0x8a, 0xae}}
+	return a, nil
+}
+
+var __1528395715_add_campaigns_diff_statDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcf\xc1\x8a\x83\x30\x10\xc6\xf1\xfb\x3c\xc5\x1c\x77\x61\xdf\x20\x27\x75\xa3\x04\x34\x29\x31\x42\x6f\x21\x98\xd1\x06\x5a\x11\x93\xbe\x7f\xa1\xd2\xd6\x63\x7a\xff\xcf\x8f\x6f\x4a\xde\x08\xc9\x00\xfe\xb5\x3a\xa1\xd1\xa2\x69\xb8\x46\x51\x23\x3f\x8b\xde\xf4\x98\xb6\x30\xdb\xf1\xe2\x96\x99\x22\xa5\x68\xef\xab\x77\x89\xec\xe8\x6e\xab\x0b\xf3\x62\x7d\x98\x26\x1b\x93\x4b\x11\x95\xc4\x4f\xc8\x72\xc1\xb0\x44\xda\x92\xf5\x74\xa5\x2f\xdc\x7a\x90\x95\x11\x4a\x1e\xe0\xac\x91\x3f\xbf\x0c\xa0\x68\x0d\xd7\x68\x8a\xb2\xe5\xf8\x6a\x22\x20\x3e\xe5\x4a\xb5\x43\x77\x74\xdf\xc7\xd6\x79\x4f\xfe\x2f\x23\xdc\xa7\x64\xa5\xfb\xdf\x9e\x01\x54\xaa\xeb\x84\x61\xf0\x18\x00\x07\xa0\xbb\x63\x91\x01\x00\x00")
+
+func _1528395715_add_campaigns_diff_statDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395715_add_campaigns_diff_statDownSql,
+		"1528395715_add_campaigns_diff_stat.down.sql",
+	)
+}
+
+func _1528395715_add_campaigns_diff_statDownSql() (*asset, error) {
+	bytes, err := _1528395715_add_campaigns_diff_statDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395715_add_campaigns_diff_stat.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0x5a, 0x43, 0xba, 0x21, 0xf8, 0x95, 0x40, 0xcf, 0x7b, 0x68, 0xaf, 0x9b, 0xd6, 0xeb, 0x9c, 0xb9, 0x14, 0x27, 0x6, 0x61, 0xdc, 0x19, 0x9f, 0xc6, 0xc5, 0x1d, 0xe7, 0x90, 0xeb, 0x33, 0x2c}}
+	return a, nil
+}
+
+var __1528395715_add_campaigns_diff_statUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x56\x5f\x6f\x9b\x3e\x14\x7d\xf7\xa7\x38\x0f\x91\x92\xe8\x97\x5f\xd4\xe7\xa2\x69\xa2\x70\x49\x3d\x11\x3b\x32\x46\xed\x9e\x50\x54\x68\x86\xd4\x45\x59\x60\xd2\x3e\xfe\x64\x83\x09\x74\xa1\x4d\xd5\x4e\x4b\x79\xc0\xf8\xde\x73\xff\x9c\xe3\xeb\xde\xd0\x8a\x0b\x8f\x31\x3f\xd6\xa4\xa0\xfd\x9b\x98\xf0\xb0\xfd\x7e\xd8\x96\xbb\x7d\xc5\x00\x3f\x0c\x11\xc8\x38\x5d\x0b\xf0\x08\x42\x6a\xd0\x3d\x4f\x74\x82\xbc\x7c\x7c\xcc\xaa\x7a\x5b\x67\xdb\x3c\x2f\x72\x94\xfb\xba\xd8\x15\x47\x6b\x22\xd2\x38\x46\x48\x91\x9f\xc6\x1a\x57\x8b\xcb\x60\x1e\xbe\x6d\xf7\xbb\x8f\x00\xca\x8b\xa7\xa2\x7e\x11\xc8\x63\x2c\xdd\x84\xbe\x26\x86\x5e\xb1\x09\x69\x86\x1e\x4e\x53\xd7\x27\x98\x45\xb5\xb4\xab\x05\xc3\x99\x8c\x9d\x49\xbb\x1e\x1a\xb9\x6c\x9c\x51\xbb\x66\x91\x92\x6b\xcc\x18\x90\x50\x4c\x81\x89\xdc\xcb\x65\x59\xe6\xf0\x93\x6e\x9d\x95\x16\x14\x08\xa4\x1f\x53\x12\xd0\x2c\x49\xd7\xb3\x26\x5c\x55\xd4\xd5\xf2\x14\xce\xa6\x39\x5f\xe0\x6a\x6e\x00\xba\xa4\x2f\x71\x6d\xd3\xef\x9c\x7b\xe5\x5c\xe2\xde\x16\xd6\xb9\xbb\x42\x01\x5b\x6a\x57\x1b\x03\xbe\x48\x2e\x70\x02\x81\xec\xaf\x96\xbd\xaa\x2b\x7c\x1e\x34\xe5\xfa\xba\x2e\x7e\xd5\x0c\x58\x29\x99\x6e\x70\xf3\x75\xb0\xcb\x6c\x5c\xdb\x66\x76\x77\x4b\x6a\x40\xef\xb2\x3c\x71\xe0\x3e\x66\x65\xee\x31\x16\x28\xf2\x35\x41\x2a\x28\xda\xc4\x7e\x40\x88\x52\x11\x68\x3e\xc8\x2a\xfb\x79\xc8\xb7\x75\x91\x75\xae\x5d\xe1\xd5\x6c\x0e\x45\x3a\x55\x22\x41\x7d\x2c\x77\xbb\xe2\x68\x3b\x16\xfb\x62\x95\xfa\x2b\xc2\xe1\xe9\xb0\xab\x7e\x3c\xd9\x8f\x7e\x82\xc9\xc4\xbe\xd9\x83\x67\xdf\xcc\xc3\x23\xe8\x55\x26\x37\xe0\x02\xb3\x69\x23\xce\xe9\x02\xd3\x90\x62\xd2\x34\x9d\x43\xdf\xd2\xc9\xda\x3c\x9d\x80\xfb\x7f\x2e\xbb\x6a\xf0\xbd\x11\x76\xff\xf7\xa7\xc8\x3b\xcf\x1e\xa3\xcd\xde\xff\x27\xee\x65\x1c\x3e\xdf\x36\x6c\x2f\x46\xd1\x5b\x09\x8d\xe0\xbb\xdd\xd1\x08\xad\xc1\xcb\x31\x5a\x9d\x8d\xc4\x70\xbb\xa3\x31\x5a\x03\x13\x63\x10\xc2\x09\xa8\xff\x33\xf5\xbf\xaa\x4e\xaf\x73\x22\x11\x82\x47\x1e\x1b\x61\x99\x8b\x84\x94\x36\x2c\xb7\x7c\xff\x4b\x96\xff\x3b\xf5\x47\xd0\xdd\xdf\x60\x79\x34\xc2\x07\xb2\x3c\x1a\xe3\x2d\x2c\x9b\xfa\xdf\xc3\x72\x33\x0c\xec\x95\xd3\x68\x81\x44\xe8\xb1\xc9\xc4\x63\x2c\x54\x72\x03\xad\xf8\x6a\x45\xca\xa8\xa1\xbd\xbb\xcc\xd8\x68\xfb\x64\x26\x60\x56\xee\xab\xe2\xe8\x92\x3e\x37\x72\x86\x13\xd3\x73\x23\xcc\x21\xbf\x19\xcf\x5c\xac\x91\xf9\x07\xa0\xd1\xa4\x99\x85\xcd\xe4\x19\x06\x62\x40\x24\x15\xc8\x0f\x6e\xa1\xe4\x1d\xe8\x9e\x82\x54\x13\x36\x4a\x06\x14\xa6\x8a\x2e\x1d\x98\x97\xb7\x62\x1c\xe5\x8d\x3d\x18\x07\xea\x8a\x6f\x8e\xe1\x8b\x25\x33\x2b\x19\x61\x2f\xee\x66\x1e\x3c\x3f\x48\x3c\x41\xc8\x13\xcd\x45\xa0\x9b\x8b\x6f\x28\xc4\xc6\x48\xaa\x33\xfe\xee\xa0\xbc\x82\xe0\xcc\xce\x62\xb8\x83\xf0\x0a\x86\x33\xeb\x61\x74\x8d\x29\xf3\xea\xbc\x7b\xdf\x82\x01\xe6\x20\xbd\x47\x00\x81\x5c\xaf\xb9\xf6\xd8\xef\x01\x00\x5f\x16\x6e\x1c\x82\x0a\x00\x00")
+
+func _1528395715_add_campaigns_diff_statUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395715_add_campaigns_diff_statUpSql,
+		"1528395715_add_campaigns_diff_stat.up.sql",
+	)
+}
+
+func _1528395715_add_campaigns_diff_statUpSql() (*asset, error) {
+	bytes, err := _1528395715_add_campaigns_diff_statUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395715_add_campaigns_diff_stat.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x90, 0x4d, 0x75, 0xbd, 0x26, 0xb7, 0x3b, 0x8d, 0x6, 0x16, 0xd4, 0xc, 0xbc, 0x9c, 0xef, 0xfa, 0x15, 0xaa, 0x1a, 0x16, 0x37, 0x22, 0xa2, 0x24, 0xac, 0xc6, 0x63, 0xf4, 0x73, 0x3f, 0x9, 0x97}}
+	return a, nil
+}
+
+var __1528395716_add_changeset_external_fork_namespaceDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x57\x00\xa8\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x65\x78\x74\x65\x72\x6e\x61\x6c\x5f\x66\x6f\x72\x6b\x5f\x6e\x61\x6d\x65\x73\x70\x61\x63\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x61\x5f\xae\xa3\x57\x00\x00\x00")
+
+func _1528395716_add_changeset_external_fork_namespaceDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395716_add_changeset_external_fork_namespaceDownSql,
+		"1528395716_add_changeset_external_fork_namespace.down.sql",
+	)
+}
+
+func _1528395716_add_changeset_external_fork_namespaceDownSql() (*asset, error) {
+	bytes, err := _1528395716_add_changeset_external_fork_namespaceDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395716_add_changeset_external_fork_namespace.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf9, 0x96, 0x9c, 0x7d, 0x42, 0x8e, 0x47, 0x7d, 0x76, 0xeb, 0x49, 0x25, 0xa, 0xb1, 0xc1, 0x45, 0xa, 0xec, 0x15, 0xb1, 0xfe, 0xa5, 0x88, 0xa4, 0x8, 0xe3, 0x9c, 0x57, 0xc2, 0xbb, 0x54, 0x47}}
+	return a, nil
+}
+
+var __1528395716_add_changeset_external_fork_namespaceUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5f\x00\xa0\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x65\x78\x74\x65\x72\x6e\x61\x6c\x5f\x66\x6f\x72\x6b\x5f\x6e\x61\x6d\x65\x73\x70\x61\x63\x65\x20\x74\x65\x78\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x6f\x95\x56\x59\x5f\x00\x00\x00")
+
+func _1528395716_add_changeset_external_fork_namespaceUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395716_add_changeset_external_fork_namespaceUpSql,
+		"1528395716_add_changeset_external_fork_namespace.up.sql",
+	)
+}
+
+func _1528395716_add_changeset_external_fork_namespaceUpSql() (*asset, error) {
+	bytes, err := _1528395716_add_changeset_external_fork_namespaceUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395716_add_changeset_external_fork_namespace.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9c, 0x73, 0x8c, 0x7c, 0xc0, 0xc9, 0x94, 0x1b, 0x4f, 0xcb, 0x8f, 0x7, 0xf1, 0x2b, 0x17, 0x36, 0x71, 0x7e, 0x81, 0x9f, 0x87, 0x62, 0x37, 0x42, 0xd6, 0x5c, 0xc9, 0xb6, 0xd7, 0xa1, 0x72, 0x81}}
+	return a, nil
+}
+
+var __1528395717_add_user_credentialsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x38\x00\xc7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x73\x65\x72\x5f\x63\x72\x65\x64\x65\x6e\x74\x69\x61\x6c\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x77\x25\x18\x56\x38\x00\x00\x00")
+
+func _1528395717_add_user_credentialsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395717_add_user_credentialsDownSql,
+		"1528395717_add_user_credentials.down.sql",
+	)
+}
+
+func _1528395717_add_user_credentialsDownSql() (*asset, error) {
+	bytes, err := _1528395717_add_user_credentialsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395717_add_user_credentials.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xec, 0xee, 0xa4, 0x33, 0x3c, 0x8e, 0xa3, 0xc7, 0xc6, 0x31, 0x9e, 0x9e, 0x58, 0x8e, 0xf0, 0xe1, 0x4d, 0x68, 0x22, 0x24, 0x1e, 0x18, 0xe0, 0xdf, 0x7f, 0xfa, 0x60, 0x8, 0x8a, 0xca, 0xd4, 0x2}}
+	return a, nil
+}
+
+var __1528395717_add_user_credentialsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x4f\x6f\xda\x40\x10\xc5\xef\xfe\x14\x4f\xb9\x14\x24\x30\xf7\x72\x72\x60\x53\x59\x05\x93\x1a\x23\x25\x27\x6b\xf1\x4e\xed\x55\xed\xb5\xbb\x3b\x6e\x42\x3f\x7d\xb5\xfc\xb1\x50\x40\xaa\x94\xa3\x9f\xdf\xfe\x66\xde\x9b\x47\xf1\x2d\x4e\xe6\x41\x30\x9d\xe2\x99\xec\xb4\x77\x64\x51\xb4\x8a\x50\xb5\x8e\x51\x58\x52\x64\x58\xcb\xda\x81\x2b\xc9\x28\x2a\x69\x4a\x72\xc4\x0e\xd2\x12\xba\x7e\x5f\x6b\x57\x91\xc2\x9b\xe6\x6a\x02\x6d\x1c\x93\x54\x9e\xd6\xfe\x04\x57\x04\x6e\x7f\x91\xb9\x7c\xd0\x3b\x93\x35\xb2\x86\x23\xfb\x47\x17\x14\x0e\x4a\x7e\x56\x72\xad\xa0\xfd\x2c\xc2\xf6\xa4\xc4\xcb\x2b\xda\xb0\xd9\x04\x14\x96\x21\x1e\x2a\xe6\xce\x7d\x9d\xcd\x4a\xcd\x55\xbf\x0f\x8b\xb6\x99\x3d\x84\xf0\x29\x8c\x6c\xc8\xa3\x5a\x53\x1f\x60\xe9\x77\xaf\x2d\x29\xec\x0f\x9e\x36\x60\x2e\xa9\xa4\xf9\xc2\x90\x3d\x57\x3e\x6c\x21\x99\x8e\x79\xae\x02\xc8\xba\x35\x14\x06\x8b\x54\x44\x99\x40\x16\x3d\xae\x04\xe2\x27\x24\x9b\x0c\xe2\x25\xde\x66\xdb\xe3\xcc\xfc\xba\xaf\x51\x00\x68\x85\xbd\x2e\x1d\x59\x2d\x6b\x3c\xa7\xf1\x3a\x4a\x5f\xf1\x5d\xbc\x4e\x02\x9c\x1e\xf8\xb8\x86\xa9\x24\x7b\x64\x25\xbb\xd5\x0a\xa9\x78\x12\xa9\x48\x16\xe2\x04\x75\x23\xad\xc6\xd8\x24\x58\x8a\x95\xc8\x04\x16\xd1\x76\x11\x2d\x05\x96\xde\x96\xfa\x4d\x3c\xed\xa6\x48\x3e\x74\x04\xa6\x77\x1e\xc0\x77\x6d\x5a\xdd\x9a\x86\xfa\xfc\x1f\x2f\x9c\x8e\x78\xe3\x2b\x2c\x49\x26\x95\x4b\x06\xeb\x86\x1c\xcb\xa6\x3b\x17\xa7\x1b\xc2\xdf\xd6\xd0\xe0\xf7\xeb\x46\xbb\x55\x06\xd3\xbe\x8d\xc6\x1e\xda\x77\xea\x93\xaf\x83\xf1\x3c\xb8\x9c\x62\x97\xc4\x3f\x76\x02\x71\xb2\x14\x2f\xff\xb9\x48\x7e\x6e\x3c\xff\x58\x82\x2f\xf7\xa3\x79\x74\x36\x4f\xee\x37\x7b\x47\xd6\xea\xb8\xd6\x66\xbd\x8e\xb3\x79\xf0\x6f\x00\xce\x27\xb1\xda\x58\x03\x00\x00")
+
+func _1528395717_add_user_credentialsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395717_add_user_credentialsUpSql,
+		"1528395717_add_user_credentials.up.sql",
+	)
+}
+
+func _1528395717_add_user_credentialsUpSql() (*asset, error) {
+	bytes, err := _1528395717_add_user_credentialsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395717_add_user_credentials.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd8, 0x5a, 0xc7, 0x80, 0x90, 0x89, 0x7, 0xdb, 0xc3, 0xea, 0xfd, 0xeb, 0x3c, 0x2f, 0xd, 0xd1, 0x1a, 0x1d, 0x51, 0xc6, 0xc4, 0x8a, 0x9a, 0xad, 0x5b, 0x7f, 0xec, 0xf8, 0x58, 0xf, 0x6c, 0xee}}
+	return a, nil
+}
+
+var __1528395718_add_campaigns_deleted_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x75\x00\x8a\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x5f\x64\x65\x6c\x65\x74\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x65\x6c\x65\x74\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x6d\x99\x4a\x8e\x75\x00\x00\x00")
+
+func _1528395718_add_campaigns_deleted_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395718_add_campaigns_deleted_atDownSql,
+		"1528395718_add_campaigns_deleted_at.down.sql",
+	)
+}
+
+func _1528395718_add_campaigns_deleted_atDownSql() (*asset, error) {
+	bytes, err := _1528395718_add_campaigns_deleted_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395718_add_campaigns_deleted_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xef, 0x64, 0x73, 0x56, 0x2e, 0xc0, 0x4d, 0x94, 0xaa, 0x19, 0x9e, 0xf7, 0xb, 0x6c, 0xc3, 0x4d, 0x75, 0x96, 0xbe, 0x26, 0x49, 0xaa, 0x1a, 0xaf, 0x8f, 0x8b, 0x9c, 0xff, 0x90, 0xf0, 0x27, 0xaa}}
+	return a, nil
+}
+
+var __1528395718_add_campaigns_deleted_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\xce\xc1\xaa\x83\x30\x10\x85\xe1\xfd\x3c\xc5\x59\xde\xfb\x0c\x59\x45\x9d\xb6\x81\x98\x80\x8e\xd4\x9d\x84\x1a\x5a\xa1\x5a\xc1\x40\xa1\x4f\x5f\x70\xd1\x86\x2e\x87\x81\xff\x3b\x05\x1f\x8d\x53\x44\xda\x0a\x37\x10\x5d\x58\xc6\x25\xcc\x6b\x98\xae\xcb\x06\x5d\x55\x28\xbd\xed\x6a\x07\x73\x80\xf3\x02\xee\x4d\x2b\x2d\xc6\x78\x8f\x29\x8e\x43\x48\x48\xd3\x1c\xb7\x14\xe6\x15\xcf\x29\xdd\xf6\x13\xaf\xc7\x12\x15\x51\xd9\xb0\x16\x86\x71\x15\xf7\x3f\x81\x8f\x31\x64\x29\xef\x32\xfb\xef\xfb\xf8\xc7\xf9\xc4\x0d\xe7\xaa\x69\xf7\x39\xae\xb3\x56\x11\x95\xbe\xae\x8d\x28\x7a\x0f\x00\x81\xae\xbe\xb8\xce\x00\x00\x00")
+
+func _1528395718_add_campaigns_deleted_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395718_add_campaigns_deleted_atUpSql,
+		"1528395718_add_campaigns_deleted_at.up.sql",
+	)
+}
+
+func _1528395718_add_campaigns_deleted_atUpSql() (*asset, error) {
+	bytes, err := _1528395718_add_campaigns_deleted_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395718_add_campaigns_deleted_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfb, 0xc7, 0x69, 0x52, 0xf6, 0xbd, 0x6c, 0xf0, 0xd0, 0x39, 0x65, 0xee, 0xde, 0xd3, 0xce, 0xcc, 0xc9, 0xf8, 0x1, 0x4f, 0x12, 0x1a, 0x29, 0x74, 0x67, 0x9f, 0x1d, 0x6b, 0xb2, 0x3e, 0xf8, 0xf1}}
+	return a, nil
+}
+
+var __1528395719_add_campaign_permission_grantsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x42\x00\xbd\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x5f\x67\x72\x61\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x9a\xe4\x8b\x51\x42\x00\x00\x00")
+
+func _1528395719_add_campaign_permission_grantsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395719_add_campaign_permission_grantsDownSql,
+		"1528395719_add_campaign_permission_grants.down.sql",
+	)
+}
+
+func _1528395719_add_campaign_permission_grantsDownSql() (*asset, error) {
+	bytes, err := _1528395719_add_campaign_permission_grantsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395719_add_campaign_permission_grants.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf5, 0xee, 0x4a, 0xf8, 0xd3, 0xb5, 0xc0, 0xbc, 0xcc, 0x75, 0x19, 0x5a, 0xaf, 0x1d, 0x9d, 0x2a, 0x7d, 0x0, 0xec, 0x95, 0x1b, 0xe2, 0xaa, 0xc9, 0xb3, 0xaf, 0x2a, 0xa4, 0xd6, 0x2d, 0x43, 0xaa}}
+	return a, nil
+}
+
+var __1528395719_add_campaign_permission_grantsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xd1\x6e\xa3\x3c\x10\x85\xef\x79\x8a\x73\xf7\x83\x94\xf6\x05\xf2\x6b\x25\x4a\xdc\x2d\x6a\x4a\x76\x81\x68\xdb\x2b\xe4\x86\x29\x8c\x04\x36\xb2\xdd\x24\xdd\xa7\x5f\x39\x09\x24\xd2\x4a\x55\x56\xbb\x97\x63\xfb\x7c\x73\xe6\x78\xee\xc4\xd7\x34\x9b\x07\xc1\xcd\x0d\xc4\x7e\xe8\x78\xc3\x0e\x8d\x91\xca\x59\xe8\x37\x6c\x99\x76\xd0\x06\xb2\xee\x59\xc1\x70\xd3\xfa\x73\x05\x09\xcb\xaa\xe9\x08\x1b\xd9\x0f\x92\x1b\x05\xa7\x21\xf1\x6e\xc9\x40\x1b\x0f\xf3\x75\xd7\xa1\xa7\xfe\x95\xcc\x81\x25\x15\xb4\x69\x66\x60\x05\x59\xd7\xec\x58\x1f\x54\xae\xa5\x11\xec\x5a\xe9\xf0\xa6\xbb\x4e\xef\xf0\x66\x74\x0f\xd7\x92\x67\x8d\x4d\xfe\xb3\x90\xef\xae\xd5\x66\x06\x25\x7b\xb2\x83\xdc\x10\xa4\xaa\xb1\x65\xcb\xaf\xdc\xb1\xfb\xb8\x85\xd8\xcb\x8d\xeb\x3e\xa0\x15\xf9\xae\xde\x52\xc5\xb5\x7f\xe6\x51\xda\x34\xbe\x62\x0b\x4b\xee\x36\x48\x72\x11\x97\x02\x65\x7c\xb7\x14\x48\xef\x91\xad\x4a\x88\xe7\xb4\x28\x8b\xa9\x69\x35\x90\xe9\xd9\x5a\xd6\xaa\x3a\x05\x13\x06\x00\xd7\x78\xe5\xc6\x92\x61\xd9\xe1\x5b\x9e\x3e\xc5\xf9\x0b\x1e\xc5\xcb\x2c\xc0\x59\x7a\x7c\xc4\xca\x1d\xc0\xd9\x7a\xb9\x44\x2e\xee\x45\x2e\xb2\x44\x9c\x3b\xd8\x90\xeb\x08\xab\x0c\x0b\xb1\x14\xa5\x40\x12\x17\x49\xbc\x10\x58\xf8\xa7\xb9\xb7\xe6\xa1\xe3\x20\xac\x1c\x35\x64\x2e\x41\xfe\xea\x3a\xc8\x38\xfe\xef\x0c\x6d\x9a\xeb\x10\x1d\x6d\xa9\x83\xa3\xfd\x79\x28\x7f\xbc\x31\x24\x1d\xd5\x95\x74\x70\xdc\x93\x75\xb2\x1f\xb0\x63\xd7\x1e\x4a\xfc\xf4\xdf\x31\x85\xb0\x10\xf7\xf1\x7a\x59\x42\xe9\x5d\x18\x79\xf5\xfb\x50\xff\x85\x3a\x59\x65\x45\x99\xc7\x69\x56\x7e\xf2\x6b\x55\x2b\x6d\xa5\x15\x1d\x4b\x22\x24\x0f\x22\x79\x44\x18\x8e\xc1\xa6\xc5\x01\x1f\xe1\xff\x2f\x08\x4f\x41\x8d\x67\x51\x10\xcd\x83\x71\x5b\xd6\x59\xfa\x7d\x2d\x90\x66\x0b\xf1\x7c\xf5\xd2\x54\xd3\x15\xd7\xd5\xd8\x72\x95\x7d\xa2\x08\x2f\x14\xb3\xf1\xfb\x23\xfc\x78\x10\xb9\x98\xb6\x21\x2d\xa6\x5c\xe6\xff\xce\xdf\x69\xfa\x3f\xb0\x77\x54\x8c\xee\x2e\xd2\x9b\xcc\x05\xc9\xea\xe9\x29\x2d\xe7\xc1\xaf\x01\x00\xb0\x0a\x73\xaa\x72\x04\x00\x00")
+
+func _1528395719_add_campaign_permission_grantsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395719_add_campaign_permission_grantsUpSql,
+		"1528395719_add_campaign_permission_grants.up.sql",
+	)
+}
+
+func _1528395719_add_campaign_permission_grantsUpSql() (*asset, error) {
+	bytes, err := _1528395719_add_campaign_permission_grantsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395719_add_campaign_permission_grants.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe1, 0xd0, 0x7e, 0x53, 0xac, 0x24, 0x2e, 0xf3, 0xf0, 0xf3, 0xc2, 0x60, 0x4d, 0xe2, 0xfb, 0xe0, 0x26, 0xbe, 0xa8, 0x37, 0x46, 0xcf, 0xbd, 0xe9, 0xb3, 0xfd, 0x8b, 0x8b, 0xdf, 0x6e, 0x18, 0x41}}
+	return a, nil
+}
+
+var __1528395720_add_campaign_auto_rebaseDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4a\x00\xb5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x74\x6f\x5f\x72\x65\x62\x61\x73\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x4f\x7c\x80\xe9\x4a\x00\x00\x00")
+
+func _1528395720_add_campaign_auto_rebaseDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395720_add_campaign_auto_rebaseDownSql,
+		"1528395720_add_campaign_auto_rebase.down.sql",
+	)
+}
+
+func _1528395720_add_campaign_auto_rebaseDownSql() (*asset, error) {
+	bytes, err := _1528395720_add_campaign_auto_rebaseDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395720_add_campaign_auto_rebase.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7f, 0x4d, 0x29, 0xe5, 0xf1, 0x68, 0xf3, 0xaa, 0x30, 0xa4, 0xdf, 0x24, 0x10, 0x1b, 0x58, 0x59, 0xef, 0x7c, 0x2e, 0x2e, 0x0, 0xf1, 0xeb, 0xbc, 0x20, 0xac, 0x67, 0x23, 0xff, 0x72, 0xc9, 0x48}}
+	return a, nil
+}
+
+var __1528395720_add_campaign_auto_rebaseUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x74\x6f\x5f\x72\x65\x62\x61\x73\x65\x20\x62\x6f\x6f\x6c\x65\x61\x6e\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x66\x61\x6c\x73\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x23\x0a\x41\xf6\x6c\x00\x00\x00")
+
+func _1528395720_add_campaign_auto_rebaseUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395720_add_campaign_auto_rebaseUpSql,
+		"1528395720_add_campaign_auto_rebase.up.sql",
+	)
+}
+
+func _1528395720_add_campaign_auto_rebaseUpSql() (*asset, error) {
+	bytes, err := _1528395720_add_campaign_auto_rebaseUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395720_add_campaign_auto_rebase.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x45, 0xe4, 0x18, 0x88, 0x47, 0x71, 0x17, 0xd9, 0x54, 0xaf, 0x93, 0x82, 0xe, 0xee, 0xe1, 0x57, 0xd4, 0x67, 0xdb, 0xaa, 0x4, 0xc2, 0xcf, 0xee, 0x63, 0xdb, 0xa8, 0xa7, 0xe, 0xd5, 0xbe, 0x2e}}
+	return a, nil
+}
+
+var __1528395721_add_changeset_external_url_stateDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcc\x3b\x0a\xc3\x30\x0c\x00\xd0\x5d\xa7\xd0\x3d\x3c\xe5\xa3\x16\x81\x3f\x25\x71\x21\x9b\x31\xae\x68\xa0\x21\x43\xac\x42\x8f\x5f\xe8\xe4\xa5\x4b\x0e\xf0\x5e\x4f\x57\xf6\x06\x60\x9c\xc2\x0d\xd9\x8f\xb4\x20\x5f\x90\x16\x9e\xe3\x8c\x65\xcd\xfb\x53\xaa\x68\x4d\xf2\x51\x39\xf6\xbc\xa5\xf7\xb1\xa5\xb2\x4a\x79\xc9\x23\x65\x35\x00\x9d\x8d\x34\x61\xec\x7a\x4b\x0d\xc0\x5f\x38\x04\x7b\x77\xbe\x19\xff\x36\xe7\x97\xaa\x59\xc5\x00\x0c\xc1\x39\x8e\x06\xbe\x03\x00\xde\xf9\xf2\x3f\xd2\x00\x00\x00")
+
+func _1528395721_add_changeset_external_url_stateDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395721_add_changeset_external_url_stateDownSql,
+		"1528395721_add_changeset_external_url_state.down.sql",
+	)
+}
+
+func _1528395721_add_changeset_external_url_stateDownSql() (*asset, error) {
+	bytes, err := _1528395721_add_changeset_external_url_stateDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395721_add_changeset_external_url_state.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc6, 0x98, 0x8, 0x2e, 0x3, 0x4b, 0x16, 0x33, 0xc1, 0xb6, 0x9b, 0xf9, 0xb0, 0x92, 0x12, 0x5f, 0x19, 0x85, 0xe0, 0x56, 0xe6, 0x73, 0x9, 0x6, 0x6b, 0xee, 0xb9, 0xf8, 0xf6, 0xd3, 0x79, 0x50}}
+	return a, nil
+}
+
+var __1528395721_add_changeset_external_url_stateUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xcf\xcd\x6a\xc3\x30\x10\x04\xe0\xbb\x9e\x62\x6e\x69\x9f\x41\xf4\xe0\x9f\x4d\x23\x90\xe5\x62\xc9\x34\x37\xa3\xba\x4b\x6d\xea\x28\x21\xda\xd0\xd0\xa7\x2f\x94\x42\x4b\xc1\xa7\x1c\x17\x96\x6f\x66\x4a\x7a\x34\x4e\x2b\x55\xd8\x40\x1d\x42\x51\x5a\xc2\x38\xc5\xf4\xc6\x99\x25\xa3\xa8\x6b\x54\xad\xed\x1b\x07\xb3\x85\x6b\x03\x68\x6f\x7c\xf0\xe0\xab\xf0\x39\xc5\x65\xb8\x9c\x97\x21\x4b\x14\x86\xf0\x55\xf4\x6d\xd0\x38\xf1\xf8\xce\xaf\x43\x14\xc8\x7c\xe0\x2c\xf1\x70\xc2\xc7\x2c\xd3\xf7\x89\xcf\x63\x62\xad\x54\xd5\x51\x11\x08\xc6\xd5\xb4\xff\xa7\xfd\x26\x0e\x6b\x70\xeb\xfe\xf6\xba\x5b\x7b\x73\xbd\xb5\x1e\x5b\xd3\xf9\x70\x8f\xe7\x1d\x75\x84\xd3\xe5\x65\x99\xc7\x28\xf3\x31\xfd\x4c\x7e\xc0\xe6\xa9\x2f\xad\xf1\x3b\xaa\x37\x5a\xa9\xaa\x6d\x1a\x13\xb4\xfa\x1a\x00\x43\xf3\xed\xc2\x56\x01\x00\x00")
+
+func _1528395721_add_changeset_external_url_stateUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395721_add_changeset_external_url_stateUpSql,
+		"1528395721_add_changeset_external_url_state.up.sql",
+	)
+}
+
+func _1528395721_add_changeset_external_url_stateUpSql() (*asset, error) {
+	bytes, err := _1528395721_add_changeset_external_url_stateUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395721_add_changeset_external_url_state.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9e, 0x53, 0xc6, 0x98, 0xb8, 0xef, 0x4e, 0xd6, 0xa1, 0xe6, 0xb5, 0xc, 0x41, 0x21, 0xc5, 0x74, 0xdb, 0xfa, 0x84, 0x1b, 0xbb, 0x6f, 0x24, 0x98, 0x0, 0x39, 0x6c, 0x86, 0xa4, 0xdf, 0x9c, 0x65}}
+	return a, nil
+}
+
+var __1528395722_add_changesets_failure_codeDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4c\x00\xb3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x66\x61\x69\x6c\x75\x72\x65\x5f\x63\x6f\x64\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x6d\xc1\x7d\xc4\x4c\x00\x00\x00")
+
+func _1528395722_add_changesets_failure_codeDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395722_add_changesets_failure_codeDownSql,
+		"1528395722_add_changesets_failure_code.down.sql",
+	)
+}
+
+func _1528395722_add_changesets_failure_codeDownSql() (*asset, error) {
+	bytes, err := _1528395722_add_changesets_failure_codeDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395722_add_changesets_failure_code.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0xf9, 0xc4, 0xa4, 0x7b, 0x96, 0x59, 0xce, 0x2e, 0xc8, 0x58, 0xaa, 0x5c, 0x94, 0x5d, 0x64, 0xb9, 0x83, 0x82, 0xb2, 0x26, 0x18, 0x5d, 0x66, 0xfc, 0xeb, 0x1a, 0xd2, 0x58, 0x4d, 0x39, 0x89}}
+	return a, nil
+}
+
+var __1528395722_add_changesets_failure_codeUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x54\x00\xab\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x66\x61\x69\x6c\x75\x72\x65\x5f\x63\x6f\x64\x65\x20\x74\x65\x78\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xaf\xff\xaa\xfe\x54\x00\x00\x00")
+
+func _1528395722_add_changesets_failure_codeUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395722_add_changesets_failure_codeUpSql,
+		"1528395722_add_changesets_failure_code.up.sql",
+	)
+}
+
+func _1528395722_add_changesets_failure_codeUpSql() (*asset, error) {
+	bytes, err := _1528395722_add_changesets_failure_codeUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395722_add_changesets_failure_code.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa, 0x85, 0x68, 0x57, 0xf9, 0x58, 0x3f, 0x46, 0xaa, 0x57, 0xef, 0x48, 0x27, 0x9b, 0x96, 0xb3, 0x5d, 0xd2, 0x3c, 0x8d, 0x36, 0xc6, 0x50, 0xd8, 0x28, 0xbd, 0xa9, 0x0, 0xe9, 0x79, 0xec, 0x35}}
+	return a, nil
+}
+
+var __1528395723_add_campaigns_update_propagationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x51\x00\xae\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x70\x64\x61\x74\x65\x5f\x70\x72\x6f\x70\x61\x67\x61\x74\x69\x6f\x6e\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x33\x88\xbb\xaf\x51\x00\x00\x00")
+
+func _1528395723_add_campaigns_update_propagationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395723_add_campaigns_update_propagationDownSql,
+		"1528395723_add_campaigns_update_propagation.down.sql",
+	)
+}
+
+func _1528395723_add_campaigns_update_propagationDownSql() (*asset, error) {
+	bytes, err := _1528395723_add_campaigns_update_propagationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395723_add_campaigns_update_propagation.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x64, 0xdd, 0xb8, 0x84, 0xa, 0xa3, 0xb, 0xf7, 0x54, 0xe9, 0xf3, 0xa2, 0x9a, 0xf5, 0xdd, 0x68, 0x4e, 0xc0, 0x14, 0x43, 0x71, 0xc, 0xa9, 0x60, 0x55, 0x99, 0x40, 0xe6, 0xe8, 0x13, 0x3d, 0x32}}
+	return a, nil
+}
+
+var __1528395723_add_campaigns_update_propagationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\xcd\xb1\x4e\xc3\x30\x14\x46\xe1\xdd\x4f\xf1\x6f\x9d\xca\x0b\x64\x4a\x1b\x17\x2c\x39\x89\x94\x3a\xc0\x86\x6e\xe3\x4b\x6c\x29\xc4\x56\x7c\xab\xc2\xdb\xa3\x16\x89\xfd\xe8\x3b\x07\xfd\x6c\xba\x4a\xa9\xfd\x1e\x2f\xe9\x86\x8d\xf7\x94\xf3\x12\xd9\x63\xa2\xaf\x4c\x71\x5e\x51\x32\x4f\x05\x53\xa0\x75\x66\x48\x60\x48\x94\x85\x41\xab\xc7\x25\xf9\x1f\xa4\x4f\xe4\xeb\x65\x89\x25\xb0\xbf\x3b\x7f\x65\x61\x29\x90\x40\x82\x1b\x6f\x0c\xf6\x51\xd8\x23\xad\x0f\x61\x4a\x9e\x11\x52\x91\x27\x55\x5b\xa7\x07\xb8\xfa\x60\xf5\xff\xb2\xa0\x6e\x1a\x1c\x7b\x3b\xb6\x1d\xcc\x09\x5d\xef\xa0\xdf\xcd\xd9\x9d\x71\xcd\x9e\x84\x3f\xf2\x96\x32\xcd\x24\xf1\x0e\xf2\xb7\x3c\x92\x6e\xb4\x16\x8d\x3e\xd5\xa3\x75\xd8\xf5\xaf\x7a\x78\x1b\x8c\xd3\xbb\x4a\xa9\x63\xdf\xb6\xc6\x55\xea\x77\x00\xd5\x5d\xd0\x1c\xef\x00\x00\x00")
+
+func _1528395723_add_campaigns_update_propagationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395723_add_campaigns_update_propagationUpSql,
+		"1528395723_add_campaigns_update_propagation.up.sql",
+	)
+}
+
+func _1528395723_add_campaigns_update_propagationUpSql() (*asset, error) {
+	bytes, err := _1528395723_add_campaigns_update_propagationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395723_add_campaigns_update_propagation.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbc, 0x3e, 0x94, 0x40, 0x77, 0xe3, 0x8c, 0xd5, 0x6c, 0x82, 0xec, 0xf6, 0x4d, 0x1f, 0x32, 0x47, 0xd3, 0xf5, 0xe4, 0x1d, 0x1b, 0x8e, 0x6e, 0x84, 0xc7, 0xcf, 0xd5, 0x3a, 0x5, 0x6e, 0xf3, 0xa9}}
+	return a, nil
+}
+
+var __1528395724_add_campaigns_paused_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x48\x00\xb7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x61\x75\x73\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x04\xeb\xe8\xe6\x48\x00\x00\x00")
+
+func _1528395724_add_campaigns_paused_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395724_add_campaigns_paused_atDownSql,
+		"1528395724_add_campaigns_paused_at.down.sql",
+	)
+}
+
+func _1528395724_add_campaigns_paused_atDownSql() (*asset, error) {
+	bytes, err := _1528395724_add_campaigns_paused_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395724_add_campaigns_paused_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x76, 0x61, 0x22, 0x15, 0x88, 0xfd, 0xa5, 0x83, 0xfe, 0x88, 0x75, 0x62, 0x28, 0xf8, 0x6c, 0x67, 0xb3, 0x8f, 0x5b, 0x1c, 0x45, 0x7f, 0xf7, 0x97, 0xc5, 0x61, 0x33, 0x8a, 0xf2, 0x27, 0x9d, 0xcb}}
+	return a, nil
+}
+
+var __1528395724_add_campaigns_paused_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\xcc\xcd\x4a\xc4\x30\x14\xc5\xf1\x7d\x9e\xe2\xec\x5c\xcd\xbc\x40\x57\x9d\x99\x28\x81\x7e\x80\x8d\xe8\x4e\x2e\xe9\xb5\x09\xd8\x9b\xd2\x5c\x29\xfa\xf4\x62\x29\xce\xf2\x1c\xf8\xff\x2e\xf6\xc9\x75\x95\x31\xa7\x13\x5e\x23\x0b\x34\x32\x02\xcd\x0b\xa5\x49\xb0\x51\xc1\x42\x5f\x85\xc7\x33\x7c\x64\xac\x1c\xb2\x84\xf4\xc9\x2b\x48\x46\x94\x6f\x09\xbc\x62\xcc\xf2\xa0\xa0\xa0\xc8\x7b\xff\x67\x85\x48\x32\x71\x61\x2d\xc8\x1f\x87\xf1\xef\x96\xb3\xa9\x1b\x6f\x9f\xe1\xeb\x4b\x63\xef\x37\xea\xdb\x0d\xd7\xbe\x79\x69\x3b\xb8\x47\x74\xbd\x87\x7d\x73\x83\x1f\x0e\xe0\x9d\x14\x9a\x66\x2e\x4a\xf3\x82\x2d\x69\xdc\x27\x7e\xb2\x70\x65\xcc\xb5\x6f\x5b\xe7\x2b\xf3\x3b\x00\x9d\xce\x3d\x3b\xd3\x00\x00\x00")
+
+func _1528395724_add_campaigns_paused_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395724_add_campaigns_paused_atUpSql,
+		"1528395724_add_campaigns_paused_at.up.sql",
+	)
+}
+
+func _1528395724_add_campaigns_paused_atUpSql() (*asset, error) {
+	bytes, err := _1528395724_add_campaigns_paused_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395724_add_campaigns_paused_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb7, 0xa5, 0x63, 0xda, 0x5f, 0x3, 0x23, 0xb, 0xfb, 0x34, 0xe4, 0x48, 0x6c, 0xd9, 0x65, 0xf5, 0x24, 0x54, 0xe9, 0x65, 0xd1, 0xe7, 0x6e, 0x41, 0xa5, 0x8e, 0x25, 0x9a, 0x54, 0x6, 0xcf, 0x18}}
+	return a, nil
+}
+
+var __1528395725_add_changesets_skipped_reasonDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4e\x00\xb1\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x6b\x69\x70\x70\x65\x64\x5f\x72\x65\x61\x73\x6f\x6e\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb6\xc5\x35\x5c\x4e\x00\x00\x00")
+
+func _1528395725_add_changesets_skipped_reasonDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395725_add_changesets_skipped_reasonDownSql,
+		"1528395725_add_changesets_skipped_reason.down.sql",
+	)
+}
+
+func _1528395725_add_changesets_skipped_reasonDownSql() (*asset, error) {
+	bytes, err := _1528395725_add_changesets_skipped_reasonDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395725_add_changesets_skipped_reason.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3e, 0x2c, 0x41, 0x4e, 0xeb, 0x67, 0xf9, 0xe3, 0xa, 0x41, 0x1e, 0x67, 0x3e, 0xc4, 0x50, 0x20, 0xeb, 0x97, 0x25, 0x45, 0xe4, 0xb8, 0x81, 0xbd, 0xe8, 0xc6, 0x4a, 0x4c, 0x8f, 0x82, 0x18, 0xa6}}
+	return a, nil
+}
+
+var __1528395725_add_changesets_skipped_reasonUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x8e\xbd\x4e\xc3\x30\x14\x85\x77\x3f\xc5\xd9\x98\xca\x0b\x64\x4a\xdb\x80\x2c\xe5\x47\xa2\x46\xb0\x21\xd7\xbe\x34\x57\xb4\xb6\xe5\x7b\x2b\x91\xb7\x47\x89\x84\x18\x18\xcf\x37\x7c\xdf\xd9\x77\xcf\x76\x6c\x8c\xd9\xed\xf0\x36\x2f\xd0\x99\x50\x29\xe4\x14\xf8\x4a\x15\xf2\xc5\xa5\x50\xdc\x70\xb9\x9f\xaf\x1c\xbc\x72\x4e\xc8\x9f\x1b\x0a\xb3\x4f\x17\x12\x52\x9c\x29\xf8\xbb\x10\x58\x65\x55\x55\x2a\x59\x58\x73\x5d\x10\x39\xa6\x07\xc5\x8d\x48\xff\x69\xca\x96\x8a\xbc\x0e\x59\xa5\xac\x82\xe0\x6f\xc5\xf3\x25\x3d\x9a\xb6\x77\xdd\x0b\x5c\xbb\xef\xbb\xbf\x94\xa0\x3d\x1e\x71\x98\xfa\xd7\x61\x84\x7d\xc2\x38\x39\x74\xef\xf6\xe4\x4e\xbf\x6f\x3f\x2a\x79\xc9\x09\x4a\xdf\xda\x18\x73\x98\x86\xc1\xba\xc6\xfc\x0c\x00\x94\x37\x19\xd3\xea\x00\x00\x00")
+
+func _1528395725_add_changesets_skipped_reasonUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395725_add_changesets_skipped_reasonUpSql,
+		"1528395725_add_changesets_skipped_reason.up.sql",
+	)
+}
+
+func _1528395725_add_changesets_skipped_reasonUpSql() (*asset, error) {
+	bytes, err := _1528395725_add_changesets_skipped_reasonUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395725_add_changesets_skipped_reason.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x37, 0x9b, 0x36, 0x4e, 0x8d, 0xc4, 0x9d, 0xcf, 0xaa, 0xff, 0x14, 0xb1, 0xeb, 0x59, 0xce, 0xf5, 0xfc, 0xd2, 0x72, 0x63, 0x71, 0x74, 0x10, 0x32, 0x14, 0x2b, 0xee, 0x35, 0xb6, 0x2b, 0x74, 0x41}}
+	return a, nil
+}
+
+var __1528395726_add_changesets_archived_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4b\x00\xb4\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x72\x63\x68\x69\x76\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xda\x4a\x07\xe5\x4b\x00\x00\x00")
+
+func _1528395726_add_changesets_archived_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395726_add_changesets_archived_atDownSql,
+		"1528395726_add_changesets_archived_at.down.sql",
+	)
+}
+
+func _1528395726_add_changesets_archived_atDownSql() (*asset, error) {
+	bytes, err := _1528395726_add_changesets_archived_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395726_add_changesets_archived_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xec, 0xdf, 0x41, 0x5c, 0xf0, 0x12, 0x88, 0xb7, 0x41, 0x5b, 0x27, 0x2, 0xbc, 0x81, 0xbc, 0x26, 0x1b, 0xe9, 0x96, 0xf1, 0xf2, 0x88, 0x6b, 0x49, 0x93, 0xa6, 0x33, 0x22, 0x92, 0xd, 0x81, 0x75}}
+	return a, nil
+}
+
+var __1528395726_add_changesets_archived_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8e\x4d\x4a\x04\x31\x10\x85\xf7\x39\xc5\x3b\x80\xe3\x05\x7a\xd5\x33\x13\x25\xd0\x3f\xe0\x44\x74\x27\x65\x2c\x3a\x01\xbb\xd2\x4c\x4a\x03\x9e\x5e\x22\x32\xbd\xfc\xaa\x1e\xdf\x7b\x47\xfb\xe8\xa6\xce\x98\xc3\x01\x2f\x91\x05\x1a\x19\x21\x92\x2c\x5c\x58\x51\xa9\x80\xae\x21\xa6\x6f\xfe\x40\xfa\xff\xd2\xba\x51\x5a\x1a\x90\x22\x57\x29\x48\x7a\x87\x77\x0e\xf4\x55\x18\xd4\x54\xc2\x95\xaf\x7b\xb2\x6c\x1c\x20\x19\x9f\x59\x96\x76\xcf\xa2\x94\xa4\x29\xf5\xde\xf4\x83\xb7\x4f\xf0\xfd\x71\xb0\x7b\x73\x41\x7f\x3e\xe3\x34\x0f\xcf\xe3\x04\xf7\x80\x69\xf6\xb0\xaf\xee\xe2\x2f\xb7\x3d\x6f\xa4\xd0\xb4\x72\x51\x5a\x37\xd4\xa4\xf1\x0f\xf1\x93\x85\x3b\x63\x4e\xf3\x38\x3a\xdf\x99\xdf\x01\x00\xf1\xaa\x6e\xd9\xe1\x00\x00\x00")
+
+func _1528395726_add_changesets_archived_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395726_add_changesets_archived_atUpSql,
+		"1528395726_add_changesets_archived_at.up.sql",
+	)
+}
+
+func _1528395726_add_changesets_archived_atUpSql() (*asset, error) {
+	bytes, err := _1528395726_add_changesets_archived_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395726_add_changesets_archived_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x16, 0x84, 0x41, 0x29, 0xf4, 0x5d, 0xed, 0xa8, 0x39, 0x15, 0x19, 0x22, 0x7c, 0x70, 0xbc, 0xba, 0xd0, 0xd3, 0x36, 0x8c, 0x66, 0xbb, 0x11, 0x76, 0xa6, 0x5c, 0xf8, 0x61, 0xca, 0xdb, 0xb8, 0xe3}}
+	return a, nil
+}
+
+var __1528395727_add_campaigns_keyset_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4e\xcc\x2d\x48\xcc\x4c\xcf\x2b\x8e\xcf\x4b\xcc\x4d\x2d\x2e\x48\x4c\x4e\x8d\x2f\x2d\x4e\x2d\x8a\xcf\x4c\x51\xf0\xf7\x43\x48\x6b\x60\x48\x6b\x5a\x93\x6a\x6e\x7e\x51\x3a\x1e\x63\x21\xb2\x9a\xd6\x5c\x5c\x2e\x41\xfe\x01\x08\x43\x09\x3b\x34\x3e\x33\xc5\x9a\x78\x4d\x10\x7b\x88\xd1\x93\x5f\x90\x9a\x07\x56\xc7\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x18\x00\xa1\x68\x31\x0b\x51\x01\x00\x00")
+
+func _1528395727_add_campaigns_keyset_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395727_add_campaigns_keyset_indexesDownSql,
+		"1528395727_add_campaigns_keyset_indexes.down.sql",
+	)
+}
+
+func _1528395727_add_campaigns_keyset_indexesDownSql() (*asset, error) {
+	bytes, err := _1528395727_add_campaigns_keyset_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395727_add_campaigns_keyset_indexes.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc8, 0x69, 0x53, 0xa9, 0xbf, 0xd1, 0x18, 0xdb, 0xa0, 0xd7, 0x31, 0x9a, 0xcc, 0xc9, 0xd9, 0xf8, 0xd5, 0x91, 0x50, 0x85, 0x22, 0xe3, 0x67, 0xac, 0x96, 0x5e, 0xe4, 0x17, 0x25, 0x73, 0xc8, 0xd1}}
+	return a, nil
+}
+
+var __1528395727_add_campaigns_keyset_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\x41\x8f\x9b\x30\x10\x85\xef\xfc\x8a\x77\x6b\x23\x25\xf9\x03\x9c\xd2\x40\x5b\xa4\x04\xaa\x40\xd5\xdc\x90\x83\x27\xd8\x12\x78\x90\x3d\xa4\xed\xbf\x5f\x65\x89\x96\x5d\x45\xda\xdd\x1c\x3d\x7a\xf3\xbd\x67\x3f\x7f\x4b\x7f\x64\x79\x1c\x45\xab\x15\x76\x36\xc8\x56\xf5\x83\xb2\xad\x0b\x18\x54\x4b\x01\x62\x3c\x8f\xad\x41\xf3\x32\x67\xaf\xc9\x93\xc6\xe9\x3f\xb2\x64\x8d\xca\x50\x20\x58\xa7\xe9\x1f\x05\x34\x7c\x21\x7f\x65\x89\x21\xf4\x1c\x04\x0d\xf7\x3d\x3b\x9c\x6d\x27\xe4\x03\x84\x5b\x12\x43\x1e\x7f\xad\x18\x88\x51\x32\x01\x97\x08\x3c\x1d\x35\xd1\x70\x33\xd7\xec\xbe\xc8\x95\x66\xd4\x85\x20\x8c\xd0\x28\x07\xe5\xf4\x0d\x07\xd5\x75\xaf\x92\x9d\xe8\xcc\x9e\x9e\xad\x9b\xd1\x07\xf6\xeb\x68\x7b\x48\x37\x55\x8a\x2c\x4f\xd2\x23\xb2\xef\xc8\x8b\x0a\xe9\x31\x2b\xab\x72\xde\xab\x9d\xea\x29\x0c\xaa\xa1\x7a\x0c\xe4\x6b\xab\x6b\xab\x51\xe4\xb3\xe2\xeb\x9d\x62\x09\xab\x17\xf1\xa3\x78\xf6\xed\xfb\xf4\x49\xf0\x08\x9c\x07\x72\x77\x40\xab\x17\xf8\xf3\x33\x3d\xa4\x68\x3a\x0e\xa4\x6b\x25\xc8\x4a\xe4\xbf\x77\x3b\x6c\xf2\x04\x9a\x3a\x92\x37\xe3\xe9\x03\x94\xe3\x40\x3e\x90\x9e\xda\x15\x33\xf7\xaa\x4e\x7c\xa1\x75\x94\x1c\x8a\x5f\x73\xa0\x8f\x1f\x32\xfe\xfc\xc6\x74\xf5\x38\x8a\xb6\xc5\x7e\x9f\x55\x71\xf4\x34\x00\xea\x8d\xbe\x07\x99\x02\x00\x00")
+
+func _1528395727_add_campaigns_keyset_indexesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395727_add_campaigns_keyset_indexesUpSql,
+		"1528395727_add_campaigns_keyset_indexes.up.sql",
+	)
+}
+
+func _1528395727_add_campaigns_keyset_indexesUpSql() (*asset, error) {
+	bytes, err := _1528395727_add_campaigns_keyset_indexesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395727_add_campaigns_keyset_indexes.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc8, 0x94, 0xb0, 0xd9, 0x2b, 0x88, 0x65, 0x4a, 0x19, 0x3e, 0x17, 0x36, 0xfb, 0x18, 0xfc, 0xe8, 0x68, 0xd8, 0xad, 0xa1, 0x7, 0x81, 0xc8, 0xa, 0xb7, 0xf6, 0x7e, 0x46, 0x52, 0x5a, 0xa0, 0xd5}}
+	return a, nil
+}
+
+var __1528395728_add_campaigns_name_trgm_indexDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3b\x00\xc4\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x5f\x6e\x61\x6d\x65\x5f\x74\x72\x67\x6d\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xbf\xf6\xa6\x71\x3b\x00\x00\x00")
+
+func _1528395728_add_campaigns_name_trgm_indexDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395728_add_campaigns_name_trgm_indexDownSql,
+		"1528395728_add_campaigns_name_trgm_index.down.sql",
+	)
+}
+
+func _1528395728_add_campaigns_name_trgm_indexDownSql() (*asset, error) {
+	bytes, err := _1528395728_add_campaigns_name_trgm_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395728_add_campaigns_name_trgm_index.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0xb4, 0xeb, 0x66, 0x3b, 0x94, 0x98, 0x60, 0x40, 0xcb, 0x6e, 0x97, 0x22, 0x29, 0x1d, 0xbf, 0xaa, 0x69, 0x15, 0xac, 0x94, 0xe5, 0xc7, 0x2c, 0x31, 0xb0, 0xda, 0x54, 0x8f, 0xa0, 0xf, 0x3f}}
+	return a, nil
+}
+
+var __1528395728_add_campaigns_name_trgm_indexUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcc\x31\x0e\x82\x30\x18\x47\xf1\xbd\xa7\xf8\x8f\x30\xe0\x05\x98\x14\x2b\xf9\x06\x4a\x22\x25\x61\x23\x45\x4b\x6d\x02\x2d\x69\x6b\x8c\xb7\x37\xb8\xe8\xfa\xf2\xcb\x3b\xf1\x9a\x44\xc9\x58\x51\xa0\x8f\xfa\x8e\xe4\x31\xdb\x25\xe9\x80\x9b\x5a\x37\x65\x8d\x8b\x98\xde\x50\x88\xcf\x29\xa6\x60\x9d\x81\x9f\x91\x1e\xda\x06\x38\xb5\xea\x03\xab\xae\xfc\x28\x39\x48\x9c\xf9\x00\xba\x40\xb4\x12\x7c\xa0\x4e\x76\xbf\xc5\xb8\xd3\x31\x05\xb3\xa2\x15\x7f\xe7\xbe\x23\x51\xc3\x58\x87\x6c\xf1\x2f\x1d\xb2\xdd\xe5\x7b\xf8\xe2\xd1\x6f\x31\x2f\x19\xab\xda\xa6\x21\x59\xb2\xcf\x00\x00\xe9\xed\xcd\xad\x00\x00\x00")
+
+func _1528395728_add_campaigns_name_trgm_indexUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395728_add_campaigns_name_trgm_indexUpSql,
+		"1528395728_add_campaigns_name_trgm_index.up.sql",
+	)
+}
+
+func _1528395728_add_campaigns_name_trgm_indexUpSql() (*asset, error) {
+	bytes, err := _1528395728_add_campaigns_name_trgm_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395728_add_campaigns_name_trgm_index.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x13, 0xb8, 0x2e, 0x3f, 0xeb, 0xe3, 0x8c, 0x32, 0x4c, 0x2b, 0xdc, 0xc5, 0x42, 0x55, 0x7d, 0xe9, 0x8e, 0x6, 0x20, 0xd5, 0xc0, 0x86, 0xa4, 0x8d, 0xac, 0x1f, 0x0, 0x75, 0x1c, 0x9c, 0x86, 0x88}}
+	return a, nil
+}
+
+var __1528395729_add_changesets_failed_indexDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x39\x00\xc6\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x5f\x66\x61\x69\x6c\x65\x64\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x13\xbc\x34\x47\x39\x00\x00\x00")
+
+func _1528395729_add_changesets_failed_indexDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395729_add_changesets_failed_indexDownSql,
+		"1528395729_add_changesets_failed_index.down.sql",
+	)
+}
+
+func _1528395729_add_changesets_failed_indexDownSql() (*asset, error) {
+	bytes, err := _1528395729_add_changesets_failed_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395729_add_changesets_failed_index.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x33, 0xfb, 0xb8, 0xf6, 0x86, 0xd1, 0x14, 0x84, 0x72, 0x5d, 0xbc, 0xb4, 0xb8, 0xbf, 0x17, 0xce, 0xd9, 0x2f, 0xee, 0x3f, 0x44, 0x34, 0x48, 0xd9, 0x70, 0xde, 0x94, 0x25, 0x46, 0x1e, 0x43, 0x12}}
+	return a, nil
+}
+
+var __1528395729_add_changesets_failed_indexUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcc\x41\x0a\x82\x40\x14\x06\xe0\xfd\x9c\xe2\x5f\xd6\xc2\x2e\xe0\xaa\xec\x55\x03\x3a\x82\x8e\xe4\x4e\x06\x7d\xe9\x80\x39\xe0\x9b\xe8\xfa\x81\x6d\xdc\x7f\x7c\x17\xba\x6b\x93\x2a\x95\x24\x68\x84\x07\xc4\x80\xd9\x4b\x44\x9c\x18\xfd\xe4\x96\x91\x85\xa3\xe0\x3b\x05\x61\xac\xdc\x87\xa5\xf7\xb3\x77\xd1\x87\x05\x2f\xe7\x67\x1e\x4e\x2a\xab\xe8\x6c\x09\xda\x5c\xa9\x85\xbe\xc1\x94\x16\xd4\xea\xda\xd6\xbb\xa2\xfb\x6b\x94\x66\xff\x1e\xfc\x70\xc4\xf3\x41\x15\x6d\xdb\x67\xe5\xee\xcd\x22\x6e\x64\xe8\x7a\x8b\x4c\x93\xe7\xa9\x52\x59\x59\x14\xda\xa6\xea\x37\x00\x5e\xfc\xc9\x18\xb0\x00\x00\x00")
+
+func _1528395729_add_changesets_failed_indexUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395729_add_changesets_failed_indexUpSql,
+		"1528395729_add_changesets_failed_index.up.sql",
+	)
+}
+
+func _1528395729_add_changesets_failed_indexUpSql() (*asset, error) {
+	bytes, err := _1528395729_add_changesets_failed_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395729_add_changesets_failed_index.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5, 0x1d, 0xbf, 0xb3, 0x88, 0x89, 0xbe, 0x9b, 0xca, 0x30, 0x17, 0xf1, 0x71, 0x66, 0xc8, 0xef, 0xaf, 0x25, 0x39, 0x1c, 0x82, 0xe2, 0x35, 0xb, 0x12, 0xb, 0x21, 0xf6, 0xc, 0x4c, 0x0, 0x8}}
+	return a, nil
+}
+
+var __1528395730_add_changeset_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x36\x00\xc9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x6a\x6f\x62\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xd6\x7b\x79\xcc\x36\x00\x00\x00")
+
+func _1528395730_add_changeset_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395730_add_changeset_jobsDownSql,
+		"1528395730_add_changeset_jobs.down.sql",
+	)
+}
+
+func _1528395730_add_changeset_jobsDownSql() (*asset, error) {
+	bytes, err := _1528395730_add_changeset_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395730_add_changeset_jobs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1c, 0x97, 0x6f, 0xe4, 0x29, 0xbe, 0xbb, 0x13, 0x25, 0xa, 0x67, 0xe, 0x67, 0xd5, 0x39, 0xc1, 0x4b, 0x1a, 0x58, 0x3b, 0x7d, 0x1f, 0x6d, 0x7a, 0x4a, 0xa1, 0x1c, 0xd7, 0x6c, 0x21, 0xb0, 0xe7}}
+	return a, nil
+}
+
+var __1528395730_add_changeset_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\x41\x4f\xe3\x3e\x10\xc5\xef\xf9\x14\xef\x46\x2b\x01\xfa\x9f\xe9\xa9\xb4\xe6\xaf\xee\x96\x74\xd5\x06\x09\x4e\xd1\x24\x99\x26\x86\xc4\x0e\xf6\x44\xc0\xae\xf6\xbb\xaf\x1c\x5a\x5a\x6d\x51\x81\x3d\xda\xef\xbd\x9f\x67\x6c\xcf\xa5\xfa\x7f\x16\x8f\xa2\xe8\xec\x0c\xdf\x6c\xe6\x21\x15\x09\x5c\x67\x40\x06\xb6\x65\x47\xa2\xad\x81\x35\x20\x78\x6d\xca\x9a\x91\x57\x64\x4a\xf6\x2c\x61\x37\xe3\x8a\xea\x35\xec\x1a\x84\xce\xb3\x3b\xc5\xda\xba\x00\xe3\x67\x6a\xda\xe0\xae\x6d\xc8\xc1\x3a\xe4\xb6\x69\xd8\x48\xbf\x32\xd0\x72\x8e\xa4\x62\xdc\x87\x53\x73\xc7\x24\x5c\x40\x6c\xc9\x52\xb1\x0b\x14\x10\xb2\xae\x7e\x08\xb0\x5d\x21\xbe\x22\xc7\x1b\x25\x2d\x9d\xed\xda\x57\x4a\x6e\xeb\xae\x31\x1e\x5e\x48\x18\x52\x39\xdb\x95\x15\x4c\xd7\xa4\x2e\x94\xea\x41\x8e\x03\xc9\xf1\x63\xa7\x1d\x17\xc8\x5e\x20\x15\xe3\xc9\xba\x07\x76\x9d\xe8\x1a\x2d\xe5\x0f\x54\xf2\x79\x34\x59\xaa\x71\xa2\x90\x8c\x2f\xe7\x0a\xb3\x2b\xc4\x8b\x04\xea\x76\xb6\x4a\x56\xbb\xde\xd3\xbe\xec\x41\x04\xe8\x02\x99\x2e\x3d\x3b\x4d\x35\x7e\x2c\x67\xd7\xe3\xe5\x1d\xbe\xab\xbb\xd3\x08\x7b\x55\x42\xf8\x59\x7a\x52\x7c\x33\x9f\x07\x2d\xdc\x56\xaa\x0b\x68\x23\x5c\xb2\x7b\xd3\xb0\x54\x57\x6a\xa9\xe2\x89\x5a\xf5\x37\xea\x07\xba\x18\x62\x11\x63\xaa\xe6\x2a\x51\x98\x8c\x57\x93\xf1\x54\x61\x1a\x6c\xcb\x50\x63\xa0\xe5\xd4\xb4\xa4\x4b\x13\x88\x99\x2e\xb5\x91\x77\x81\x5b\xdb\x27\xa1\x6f\xdd\x7e\x40\xdd\xfa\x3e\x87\xbd\xb7\x59\x2a\x2f\x2d\x1f\xde\x49\x4b\x2f\xb5\xa5\x02\xf7\xde\x9a\x6c\x77\xd4\x54\x5d\x8d\x6f\xe6\x09\x4e\x7e\xfd\x3e\xb9\xb8\xe8\xc5\xe0\xde\xbc\xf5\x3e\x64\x67\x7d\xec\xb8\xe3\xe2\x24\xf8\xd6\xa4\xeb\xce\x71\xda\xb0\xf7\x54\xbe\x26\x36\x79\x27\x5c\xa4\x24\x10\xdd\xb0\x17\x6a\x5a\x3c\x69\xa9\xfa\x25\x7e\x5a\xc3\xc1\xb6\xd6\x46\xfb\xea\x63\x5f\xeb\x6c\xce\xde\xa7\xb4\x16\x76\x47\x9d\x7b\xdf\xf2\xe0\xf5\xb7\x0d\xfc\x17\x90\x9b\xb1\x38\x76\xf2\x61\xd2\xd8\xa7\xc1\x30\xa4\xbb\xb6\xf8\xc7\x74\x34\x1c\x45\xdb\x31\x98\xc5\x53\x75\x7b\x74\x0c\xd2\xbd\x6f\xbe\x88\xff\x12\x07\x3b\x71\x38\xfa\x02\x73\xb7\xd4\xc5\x3b\xd4\x7d\xf9\x4b\xdc\xd7\x4f\x73\x08\xec\xf7\xfb\xb6\x17\xd7\xd7\xb3\x64\x14\xfd\x19\x00\x02\x9f\x9b\x9e\x19\x05\x00\x00")
+
+func _1528395730_add_changeset_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395730_add_changeset_jobsUpSql,
+		"1528395730_add_changeset_jobs.up.sql",
+	)
+}
+
+func _1528395730_add_changeset_jobsUpSql() (*asset, error) {
+	bytes, err := _1528395730_add_changeset_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395730_add_changeset_jobs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1e, 0xa6, 0x3e, 0xa2, 0xac, 0xbd, 0x90, 0x38, 0x49, 0x7b, 0x85, 0xdf, 0x92, 0xb2, 0xc2, 0xf1, 0xa7, 0x5f, 0x3f, 0x19, 0x0, 0xfe, 0x6c, 0x92, 0x8c, 0x5c, 0x9a, 0xee, 0x83, 0x9d, 0x98, 0x3b}}
+	return a, nil
+}
+
+var __1528395731_add_campaigns_last_viewed_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4d\x00\xb2\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x61\x73\x74\x5f\x76\x69\x65\x77\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xfa\x34\x61\x0f\x4d\x00\x00\x00")
+
+func _1528395731_add_campaigns_last_viewed_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395731_add_campaigns_last_viewed_atDownSql,
+		"1528395731_add_campaigns_last_viewed_at.down.sql",
+	)
+}
+
+func _1528395731_add_campaigns_last_viewed_atDownSql() (*asset, error) {
+	bytes, err := _1528395731_add_campaigns_last_viewed_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395731_add_campaigns_last_viewed_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd7, 0x2b, 0x87, 0x1, 0xe4, 0x84, 0xff, 0xf4, 0x20, 0xa2, 0x1f, 0x9f, 0xb0, 0x6a, 0xc1, 0xdf, 0xc6, 0x8e, 0xa2, 0x4f, 0x91, 0x33, 0xcb, 0x7b, 0xcb, 0x86, 0xa4, 0x35, 0xe2, 0xca, 0xa7, 0x53}}
+	return a, nil
+}
+
+var __1528395731_add_campaigns_last_viewed_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x8c\xcd\x6a\x84\x30\x14\x85\xf7\x79\x8a\xf3\x02\xd3\x17\x70\xe5\xcc\xa4\x25\xe0\x0f\x74\x22\x74\x27\xb7\x7a\x35\xa1\x26\x11\x73\xa9\xd8\xa7\x2f\x75\xd5\xd9\x9d\xc3\xc7\xf7\x5d\xf5\x9b\x69\x0a\xa5\x2e\x17\x74\x99\x47\x7c\x1e\x10\xc7\x18\x1c\xc5\x99\x33\x0b\xf2\x11\x07\xde\x20\xe9\x5c\xcf\x30\x23\x4d\x18\x28\xac\xe4\xe7\x98\x21\x8e\x04\xb4\xf1\x5f\x6c\x49\xe9\x8b\x47\x90\x20\xa4\x8d\x91\x26\xe1\xf8\xa2\xca\xca\xea\x77\xd8\xf2\x5a\xe9\x7f\x5e\x79\xbf\xe3\xd6\x56\x5d\xdd\xc0\xbc\xa2\x69\x2d\xf4\x87\x79\xd8\x07\x16\xca\xd2\x7f\x7b\xde\x79\xec\x49\x20\x3e\x70\x16\x0a\x2b\x76\x2f\xee\xbc\xf8\x49\x91\x0b\xa5\x6e\x6d\x5d\x1b\x5b\xa8\xdf\x01\x00\xad\x04\xde\xf0\xcf\x00\x00\x00")
+
+func _1528395731_add_campaigns_last_viewed_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395731_add_campaigns_last_viewed_atUpSql,
+		"1528395731_add_campaigns_last_viewed_at.up.sql",
+	)
+}
+
+func _1528395731_add_campaigns_last_viewed_atUpSql() (*asset, error) {
+	bytes, err := _1528395731_add_campaigns_last_viewed_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395731_add_campaigns_last_viewed_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x46, 0x2a, 0x26, 0xb9, 0x73, 0x6d, 0x38, 0xbc, 0xda, 0xfa, 0xfa, 0x75, 0x62, 0x28, 0x19, 0xcb, 0x53, 0xe2, 0x61, 0x85, 0x9d, 0x72, 0xa9, 0x1c, 0xd4, 0xfb, 0x3f, 0xb6, 0x50, 0xba, 0xaa, 0xf3}}
+	return a, nil
+}
+
+var __1528395732_add_campaign_changeset_countsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x41\x00\xbe\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x63\x6f\x75\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xc7\x80\xe0\x4d\x41\x00\x00\x00")
+
+func _1528395732_add_campaign_changeset_countsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395732_add_campaign_changeset_countsDownSql,
+		"1528395732_add_campaign_changeset_counts.down.sql",
+	)
+}
+
+func _1528395732_add_campaign_changeset_countsDownSql() (*asset, error) {
+	bytes, err := _1528395732_add_campaign_changeset_countsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395732_add_campaign_changeset_counts.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9b, 0xd2, 0x4a, 0x84, 0xf6, 0x2d, 0x58, 0x4c, 0x93, 0x6f, 0xcf, 0x8f, 0x5b, 0xf9, 0x52, 0xf0, 0xfd, 0xd6, 0xff, 0x83, 0x11, 0xdb, 0xf7, 0x29, 0xee, 0x59, 0x77, 0x3d, 0x8f, 0x8f, 0xfd, 0x65}}
+	return a, nil
+}
+
+var __1528395732_add_campaign_changeset_countsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x41\x8b\xdb\x30\x10\x85\xef\xfa\x15\xef\xd6\x04\xbc\xfd\x03\x39\x79\x6d\x6d\x31\x75\x9c\xe2\xb8\xd0\x3d\x19\x45\x9a\xd8\x82\x58\x72\xa5\xf1\x86\xf4\xd7\x17\x9b\x36\x69\x21\xc9\x45\xa0\x79\xdf\xbc\x79\xbc\x57\xf9\xa5\xa8\x36\x42\xbc\xbc\x20\x57\xf6\x74\x81\xee\x95\xeb\x28\x12\x43\xfb\xc9\x71\x84\x3f\x42\xab\x61\x54\xb6\x73\x31\x41\x64\x1f\xc8\xe0\x40\x47\x1f\x08\xdc\x13\xe8\x83\x66\x8c\x7b\xba\x7c\x0a\x34\x1b\x69\x3f\x8c\x13\x93\xc1\x31\xf8\x01\x2a\xd0\x32\x51\x9a\xc9\x24\x88\x1e\xdc\x2b\xc6\x61\x0a\xce\xf8\xb3\x9b\x0f\x06\x8e\x88\xac\x2e\x50\x5a\x4f\x41\x31\x7d\x16\x59\x2d\xd3\x46\xa2\x49\x5f\x4b\x89\xe2\x0d\xd5\xae\x81\xfc\x51\xec\x9b\xfd\x35\x4d\x7b\x8d\xda\xfe\x89\xba\x12\xb8\xa9\xd6\xe0\x60\x3b\xeb\x78\xd9\xad\xbe\x97\x25\x6a\xf9\x26\x6b\x59\x65\xf2\x66\x12\x57\xd6\xac\xb1\xab\x90\xcb\x52\x36\x12\x59\xba\xcf\xd2\x5c\x22\x9f\xd1\x7a\xbe\x9e\x08\x80\xed\x40\xcb\x13\x59\x0d\x23\xce\x96\xfb\xe5\x8b\x5f\xde\xd1\xd5\x7f\x21\x3d\xab\x13\xac\x63\xea\x28\xfc\xa7\x0c\x14\x3a\x32\x77\x25\x7d\xf2\xf1\x81\xe4\x47\x72\x0f\x85\x56\x8d\x63\xf0\x1f\x4f\x56\xff\x76\xd4\x06\xfa\x39\x51\xe4\x67\xe8\x48\xce\x58\xd7\xdd\x05\xbe\xd5\xc5\x36\xad\xdf\xf1\x55\xbe\x63\xf5\x4f\xc5\xc9\x52\xc3\x5a\xac\x37\x42\x64\xbb\xed\xb6\x68\x36\xe2\xf7\x00\x56\xd7\x61\x21\x53\x02\x00\x00")
+
+func _1528395732_add_campaign_changeset_countsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395732_add_campaign_changeset_countsUpSql,
+		"1528395732_add_campaign_changeset_counts.up.sql",
+	)
+}
+
+func _1528395732_add_campaign_changeset_countsUpSql() (*asset, error) {
+	bytes, err := _1528395732_add_campaign_changeset_countsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395732_add_campaign_changeset_counts.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x53, 0x93, 0x99, 0x78, 0x29, 0x9e, 0xea, 0xf0, 0xee, 0x88, 0x8a, 0x66, 0xa8, 0x94, 0x50, 0x8d, 0xa7, 0x4d, 0x8e, 0x16, 0x7b, 0x9b, 0xc8, 0xeb, 0x18, 0xbd, 0x5e, 0x84, 0x2a, 0x6e, 0x9f, 0xf4}}
+	return a, nil
+}
+
+var __1528395733_add_campaigns_namespace_name_indexDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7e\x00\x81\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x5f\x6e\x61\x6d\x65\x73\x70\x61\x63\x65\x5f\x75\x73\x65\x72\x5f\x69\x64\x5f\x6e\x61\x6d\x65\x3b\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x5f\x6e\x61\x6d\x65\x73\x70\x61\x63\x65\x5f\x6f\x72\x67\x5f\x69\x64\x5f\x6e\x61\x6d\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x02\x4e\x46\xfc\x7e\x00\x00\x00")
+
+func _1528395733_add_campaigns_namespace_name_indexDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395733_add_campaigns_namespace_name_indexDownSql,
+		"1528395733_add_campaigns_namespace_name_index.down.sql",
+	)
+}
+
+func _1528395733_add_campaigns_namespace_name_indexDownSql() (*asset, error) {
+	bytes, err := _1528395733_add_campaigns_namespace_name_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395733_add_campaigns_namespace_name_index.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x31, 0x8d, 0x3d, 0x5, 0xa5, 0x7, 0xd8, 0x70, 0x56, 0xa8, 0x92, 0xd4, 0x67, 0xda, 0x91, 0xf4, 0x2f, 0xcc, 0x27, 0x4a, 0x64, 0x9, 0xa0, 0x92, 0xd8, 0xb, 0x1d, 0xf9, 0xf8, 0xec, 0xb7, 0xef}}
+	return a, nil
+}
+
+var __1528395733_add_campaigns_namespace_name_indexUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x8e\xc1\x4a\x03\x31\x10\x86\xef\x79\x8a\xff\xa8\x60\x7d\x81\x3d\xe9\x1a\x35\xb0\xcd\xc2\x6e\x8a\xbd\x85\x71\x33\xd4\xc5\x34\x09\x49\xab\xf8\xf6\x62\x94\x8a\x78\x58\x7a\x9c\xe1\xfb\xe6\x9b\x5b\xf9\xa0\x74\x23\xc4\x6a\x85\x96\xf6\x89\xe6\x5d\x28\xa0\xcc\xf0\x31\xbe\xb2\xc3\x31\xe1\xf9\x03\x81\xf6\x5c\x12\x4d\x0c\x0a\xae\x4e\x78\x7f\xe1\x80\xe9\x47\x41\x49\x3c\x55\xef\xeb\x10\xa5\xe4\x67\x76\x95\xfd\x8b\x6d\x86\xae\x52\xc8\x5c\xa2\x7f\x63\x77\x2d\xda\x41\xde\x18\x09\xa5\xef\xe4\x16\xea\x1e\xba\x37\x90\x5b\x35\x9a\xf1\xa4\x15\x7b\xea\xdb\x63\xe1\x6c\x67\x57\x37\xe8\xf5\x2f\x83\x8b\x7f\xd0\x55\xfd\xf4\x12\x4f\x8f\x72\x90\x70\xec\xf9\xc0\xce\xd2\x01\x6a\x84\xde\x74\x5d\x73\x6e\x3c\xe6\xdd\x62\xfb\x9b\x59\x4e\x8b\xb6\x5f\xaf\x95\x69\xc4\xe7\x00\x15\x2c\xbc\x7c\x82\x01\x00\x00")
+
+func _1528395733_add_campaigns_namespace_name_indexUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395733_add_campaigns_namespace_name_indexUpSql,
+		"1528395733_add_campaigns_namespace_name_index.up.sql",
+	)
+}
+
+func _1528395733_add_campaigns_namespace_name_indexUpSql() (*asset, error) {
+	bytes, err := _1528395733_add_campaigns_namespace_name_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395733_add_campaigns_namespace_name_index.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0x8e, 0xf1, 0x34, 0xd5, 0x54, 0xa7, 0x5f, 0xc2, 0x3e, 0xa, 0x14, 0xa6, 0x57, 0xa9, 0xd8, 0xb1, 0x46, 0x8, 0xad, 0xb0, 0x5b, 0xb, 0xbe, 0xef, 0xab, 0x9e, 0x28, 0x47, 0x4, 0xa6, 0x7}}
+	return a, nil
+}
+
+var __1528395734_add_changeset_num_failuresDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4c\x00\xb3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6e\x75\x6d\x5f\x66\x61\x69\x6c\x75\x72\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xdf\xda\x62\xc6\x4c\x00\x00\x00")
+
+func _1528395734_add_changeset_num_failuresDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395734_add_changeset_num_failuresDownSql,
+		"1528395734_add_changeset_num_failures.down.sql",
+	)
+}
+
+func _1528395734_add_changeset_num_failuresDownSql() (*asset, error) {
+	bytes, err := _1528395734_add_changeset_num_failuresDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395734_add_changeset_num_failures.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x95, 0x62, 0xac, 0x36, 0x12, 0x11, 0x56, 0xd, 0xbb, 0x9, 0xf9, 0xd4, 0x5a, 0xfc, 0x9, 0xc5, 0xbe, 0x54, 0x16, 0x22, 0xb2, 0xff, 0x92, 0x4f, 0x34, 0xc2, 0x2a, 0xc0, 0xc2, 0x0, 0x9d, 0xc7}}
+	return a, nil
+}
+
+var __1528395734_add_changeset_num_failuresUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6a\x00\x95\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6e\x75\x6d\x5f\x66\x61\x69\x6c\x75\x72\x65\x73\x20\x69\x6e\x74\x65\x67\x65\x72\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x30\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x1a\xc7\xed\xbf\x6a\x00\x00\x00")
+
+func _1528395734_add_changeset_num_failuresUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395734_add_changeset_num_failuresUpSql,
+		"1528395734_add_changeset_num_failures.up.sql",
+	)
+}
+
+func _1528395734_add_changeset_num_failuresUpSql() (*asset, error) {
+	bytes, err := _1528395734_add_changeset_num_failuresUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395734_add_changeset_num_failures.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf9, 0xd5, 0x68, 0x67, 0x17, 0x34, 0x2b, 0xfa, 0x71, 0xdd, 0xe3, 0xb, 0x2a, 0xd8, 0x43, 0xd0, 0x18, 0xe7, 0x3, 0xab, 0xc8, 0xe7, 0x38, 0xea, 0x8d, 0xc, 0x24, 0xd9, 0xc2, 0x34, 0x29, 0xd5}}
+	return a, nil
+}
+
+var __1528395735_add_campaign_specs_pinned_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4d\x00\xb2\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x70\x65\x63\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x69\x6e\x6e\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb9\x4b\xd3\xdc\x4d\x00\x00\x00")
+
+func _1528395735_add_campaign_specs_pinned_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395735_add_campaign_specs_pinned_atDownSql,
+		"1528395735_add_campaign_specs_pinned_at.down.sql",
+	)
+}
+
+func _1528395735_add_campaign_specs_pinned_atDownSql() (*asset, error) {
+	bytes, err := _1528395735_add_campaign_specs_pinned_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395735_add_campaign_specs_pinned_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa1, 0xfe, 0xa, 0x5a, 0x23, 0x9b, 0x98, 0x62, 0xe1, 0xbc, 0xe0, 0xcf, 0x53, 0x92, 0x7b, 0xe4, 0x6d, 0xc1, 0x7d, 0x3d, 0xe9, 0x2f, 0xe, 0x4b, 0x22, 0x16, 0x74, 0x26, 0x6a, 0xaa, 0xe, 0xfc}}
+	return a, nil
+}
+
+var __1528395735_add_campaign_specs_pinned_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x69\x00\x96\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x70\x65\x63\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x70\x69\x6e\x6e\x65\x64\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x77\x69\x74\x68\x20\x74\x69\x6d\x65\x20\x7a\x6f\x6e\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xf7\xd5\xca\xee\x69\x00\x00\x00")
+
+func _1528395735_add_campaign_specs_pinned_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395735_add_campaign_specs_pinned_atUpSql,
+		"1528395735_add_campaign_specs_pinned_at.up.sql",
+	)
+}
+
+func _1528395735_add_campaign_specs_pinned_atUpSql() (*asset, error) {
+	bytes, err := _1528395735_add_campaign_specs_pinned_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395735_add_campaign_specs_pinned_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x66, 0x4, 0xac, 0x2a, 0x68, 0xeb, 0x86, 0xfd, 0x54, 0x5e, 0x7d, 0x9e, 0x50, 0xcb, 0x27, 0x5a, 0x3e, 0x6b, 0xa9, 0xe6, 0x74, 0x1a, 0xd8, 0x97, 0x23, 0x16, 0xf4, 0x30, 0xe7, 0x2, 0xe3, 0xe6}}
+	return a, nil
+}
+
+var __1528395736_add_campaign_spec_expiration_runsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x45\x00\xba\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x70\x65\x63\x5f\x65\x78\x70\x69\x72\x61\x74\x69\x6f\x6e\x5f\x72\x75\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xe9\x62\x76\xb7\x45\x00\x00\x00")
+
+func _1528395736_add_campaign_spec_expiration_runsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395736_add_campaign_spec_expiration_runsDownSql,
+		"1528395736_add_campaign_spec_expiration_runs.down.sql",
+	)
+}
+
+func _1528395736_add_campaign_spec_expiration_runsDownSql() (*asset, error) {
+	bytes, err := _1528395736_add_campaign_spec_expiration_runsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395736_add_campaign_spec_expiration_runs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe8, 0xff, 0x3d, 0x1b, 0x12, 0xa8, 0xc7, 0x8e, 0x2, 0x22, 0xbe, 0xa8, 0xfc, 0x3c, 0xfb, 0x33, 0xa8, 0x2a, 0x44, 0x18, 0x67, 0xba, 0x93, 0x33, 0xbf, 0x98, 0x29, 0x6d, 0xb, 0x7d, 0xd5, 0x73}}
+	return a, nil
+}
+
+var __1528395736_add_campaign_spec_expiration_runsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x51\xcb\xae\xda\x30\x10\xdd\xfb\x2b\xce\xf2\x56\xe2\x56\xdd\xb3\x0a\xc5\x54\x51\x43\xa8\x42\x90\x60\x15\x99\x78\x48\x5c\x25\x76\xe4\x99\x16\xda\xaf\xaf\x12\xc4\x43\xaa\x54\x95\xa5\x3d\xe7\x35\x67\x16\xfa\x4b\x9a\xcf\x95\x7a\x7f\x47\xf1\xc3\x33\xc2\x09\xd2\x12\xbe\x87\x23\xa4\x35\x02\x4b\x1d\x09\x31\xe8\x32\xb8\x48\x16\xb5\xe9\x07\xe3\x1a\x0f\x1e\xa8\xe6\x19\xea\xd6\xf8\x86\x98\xe4\xfa\x01\xe3\xed\xa8\x75\xa5\x3d\xe0\x3c\x03\x87\xab\x22\x3b\x21\x18\xdb\x3b\xcf\xa8\x8d\x07\x13\xe1\x3c\x0e\x9c\x20\x52\x1f\x7e\x92\xfd\xa8\x3e\x17\x3a\x29\x35\xca\x64\x91\x69\xa4\x2b\xe4\x9b\x12\x7a\x9f\x6e\xcb\xed\x5d\xb2\x1a\x0d\xab\x29\x97\x11\x17\x7c\x15\xc7\xfc\x6f\x0a\x70\x16\x47\xd7\x30\x45\x67\x3a\x7c\x2b\xd2\x75\x52\x1c\xf0\x55\x1f\x66\x0a\x60\x31\x51\xc8\x56\x46\x20\xae\x27\x16\xd3\x0f\x38\x3b\x69\xa7\x27\x7e\x07\x4f\x93\x59\xbe\xcb\xb2\x11\x7f\x72\xde\x71\xfb\x02\xc1\xc6\x5f\x63\x12\x1c\x43\xe8\xc8\xf8\xfb\x0c\x4b\xbd\x4a\x76\x59\x89\x93\xe9\x98\x46\xe9\x7b\x75\xd3\x26\x5c\xdd\x3a\x73\x5e\xa8\xa1\xf8\x37\xf3\xd3\xc4\x7a\x5e\xff\x55\xd2\xff\xe2\x29\xc6\x10\x21\x74\x11\xf5\x61\xae\x6e\xd7\x48\xf3\xa5\xde\xbf\x72\x8d\xea\xa9\xed\x4d\xfe\x6f\xec\xdb\x03\x3b\x59\x6e\xd6\xeb\xb4\x9c\xab\x3f\x03\x00\xc7\xa3\xca\xa6\xa0\x02\x00\x00")
+
+func _1528395736_add_campaign_spec_expiration_runsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395736_add_campaign_spec_expiration_runsUpSql,
+		"1528395736_add_campaign_spec_expiration_runs.up.sql",
+	)
+}
+
+func _1528395736_add_campaign_spec_expiration_runsUpSql() (*asset, error) {
+	bytes, err := _1528395736_add_campaign_spec_expiration_runsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395736_add_campaign_spec_expiration_runs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe3, 0x24, 0x6b, 0x15, 0x5b, 0xbc, 0x23, 0x67, 0x4c, 0xfa, 0xc3, 0x32, 0x38, 0x4, 0x9f, 0x3b, 0x3b, 0x84, 0x84, 0xa1, 0xde, 0x32, 0x54, 0xd4, 0x5, 0xc, 0x88, 0x88, 0x81, 0xd, 0x58, 0x7d}}
+	return a, nil
+}
+
+var __1528395737_add_campaign_repo_changeset_countsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x72\x65\x70\x6f\x5f\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x63\x6f\x75\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x28\x73\x2c\x87\x46\x00\x00\x00")
+
+func _1528395737_add_campaign_repo_changeset_countsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395737_add_campaign_repo_changeset_countsDownSql,
+		"1528395737_add_campaign_repo_changeset_counts.down.sql",
+	)
+}
+
+func _1528395737_add_campaign_repo_changeset_countsDownSql() (*asset, error) {
+	bytes, err := _1528395737_add_campaign_repo_changeset_countsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395737_add_campaign_repo_changeset_counts.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8a, 0xb9, 0x25, 0x55, 0xea, 0xd8, 0x9e, 0x66, 0x31, 0xc1, 0x48, 0xc3, 0x79, 0x61, 0xf8, 0x3, 0x58, 0xc9, 0x3d, 0xe0, 0x3c, 0xe6, 0x8e, 0x78, 0xc3, 0xe7, 0x86, 0x57, 0x51, 0x61, 0x5, 0x5f}}
+	return a, nil
+}
+
+var __1528395737_add_campaign_repo_changeset_countsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xd1\x6a\xdb\x30\x14\x86\xef\xfd\x14\xff\x65\x02\xee\x5e\x20\x57\xae\xa3\x0e\xb3\xc4\x19\x8e\x07\xeb\x95\x51\xac\x53\x47\x10\xeb\x68\xd2\x71\x4b\xf6\xf4\x43\x66\x69\x0a\x69\x4b\x6f\x04\xe2\xff\xf4\x9d\x9f\xa3\x7b\xf5\xbd\xaa\x57\x59\x76\x77\x87\xb5\xb6\xa7\x33\xfa\xa3\x76\x03\x45\x12\xf4\x3c\x39\x89\xe0\x27\xf4\x7a\xf4\xda\x0e\x2e\xc2\x53\x40\x20\xcf\xd1\x0a\x87\x73\x8e\x28\x1c\xc8\x40\x9f\xd8\x0d\xd1\x1a\x4a\x9e\x0b\xdd\xbd\xaa\xba\xff\xaa\x03\x3d\x71\x20\xd0\x33\x25\xb1\x0e\x84\x9e\x47\xaf\x7b\x21\x93\x23\x32\xe4\xa8\x05\x87\x29\x38\xc3\x2f\x6e\x56\x1d\x75\x90\x88\x21\xf0\xe4\xc9\xe0\x70\x7e\x33\x1c\x51\xf4\x19\xba\xef\xa7\xa0\x85\x20\xcc\xdf\xb2\xb2\x51\x45\xab\xd0\x16\xf7\x1b\x85\xea\x01\xf5\xae\x85\xfa\x5d\xed\xdb\xfd\xb5\x55\x32\xdc\x56\x5b\x64\xb8\x22\xd6\xe0\x60\x07\xeb\x64\x16\xd4\xbf\x36\x1b\x34\xea\x41\x35\xaa\x2e\xd5\xd5\x14\x17\xd6\x2c\xb1\xab\xb1\x56\x1b\xd5\x2a\x94\xc5\xbe\x2c\xd6\x0a\xeb\x84\x36\xa9\x42\x9e\x61\x2e\xdc\x59\x03\xeb\x84\x06\x0a\xef\x1a\x13\xf3\x25\x99\xd8\x91\xe6\x23\x8a\x1e\x3d\x5e\xac\x1c\xe7\x2b\xfe\xb2\xa3\x57\x75\x1a\x2b\x2c\xfa\x74\x33\x34\x25\x23\x85\x81\x6e\xfb\xa4\xa8\x3f\x71\xfc\x20\x62\x4f\xee\xc3\xa0\xd3\xde\x07\x7e\xfe\xe4\xe9\x65\xe1\x5d\xa0\x3f\x13\x45\xf9\x0c\xf5\xe4\x8c\x75\xc3\xbb\xc0\xcf\xa6\xda\x16\xcd\x23\x7e\xa8\x47\x2c\xde\xfc\x57\x7e\xd9\x73\x3e\xef\x63\x99\x2d\x57\x59\x56\xee\xb6\xdb\xaa\x5d\x65\xff\x06\x00\x33\x25\xb5\x77\xe4\x02\x00\x00")
+
+func _1528395737_add_campaign_repo_changeset_countsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395737_add_campaign_repo_changeset_countsUpSql,
+		"1528395737_add_campaign_repo_changeset_counts.up.sql",
+	)
+}
+
+func _1528395737_add_campaign_repo_changeset_countsUpSql() (*asset, error) {
+	bytes, err := _1528395737_add_campaign_repo_changeset_countsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395737_add_campaign_repo_changeset_counts.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa7, 0x43, 0xe1, 0xe8, 0x52, 0xb9, 0x59, 0xd, 0x9a, 0x26, 0xb3, 0x25, 0x6, 0x24, 0xcd, 0x5, 0x71, 0xe0, 0x83, 0xf, 0x11, 0xf0, 0x62, 0x8e, 0xe7, 0x16, 0xd8, 0xc7, 0xf9, 0x5, 0xe3, 0x3d}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -2403,6 +3957,80 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395699_campaign_remove_branch.up.sql":                                _1528395699_campaign_remove_branchUpSql,
 	"1528395700_add_apply_data_to_campaign.down.sql":                          _1528395700_add_apply_data_to_campaignDownSql,
 	"1528395700_add_apply_data_to_campaign.up.sql":                            _1528395700_add_apply_data_to_campaignUpSql,
+	"1528395701_add_campaign_activities.down.sql":                             _1528395701_add_campaign_activitiesDownSql,
+	"1528395701_add_campaign_activities.up.sql":                               _1528395701_add_campaign_activitiesUpSql,
+	"1528395702_add_changesets_custom_metadata.down.sql":                      _1528395702_add_changesets_custom_metadataDownSql,
+	"1528395702_add_changesets_custom_metadata.up.sql":                        _1528395702_add_changesets_custom_metadataUpSql,
+	"1528395703_add_failure_class.down.sql":                                   _1528395703_add_failure_classDownSql,
+	"1528395703_add_failure_class.up.sql":                                     _1528395703_add_failure_classUpSql,
+	"1528395704_add_changeset_wait_reason.down.sql":                           _1528395704_add_changeset_wait_reasonDownSql,
+	"1528395704_add_changeset_wait_reason.up.sql":                             _1528395704_add_changeset_wait_reasonUpSql,
+	"1528395705_add_campaign_auto_merge.down.sql":                             _1528395705_add_campaign_auto_mergeDownSql,
+	"1528395705_add_campaign_auto_merge.up.sql":                               _1528395705_add_campaign_auto_mergeUpSql,
+	"1528395706_add_changeset_diff_stat_jobs.down.sql":                        _1528395706_add_changeset_diff_stat_jobsDownSql,
+	"1528395706_add_changeset_diff_stat_jobs.up.sql":                          _1528395706_add_changeset_diff_stat_jobsUpSql,
+	"1528395707_add_campaign_templates.down.sql":                              _1528395707_add_campaign_templatesDownSql,
+	"1528395707_add_campaign_templates.up.sql":                                _1528395707_add_campaign_templatesUpSql,
+	"1528395708_add_campaign_reapply_schedules.down.sql":                      _1528395708_add_campaign_reapply_schedulesDownSql,
+	"1528395708_add_campaign_reapply_schedules.up.sql":                        _1528395708_add_campaign_reapply_schedulesUpSql,
+	"1528395709_add_campaign_visibility.down.sql":                             _1528395709_add_campaign_visibilityDownSql,
+	"1528395709_add_campaign_visibility.up.sql":                               _1528395709_add_campaign_visibilityUpSql,
+	"1528395710_add_campaign_notifications.down.sql":                          _1528395710_add_campaign_notificationsDownSql,
+	"1528395710_add_campaign_notifications.up.sql":                            _1528395710_add_campaign_notificationsUpSql,
+	"1528395711_add_campaign_webhook_deliveries.down.sql":                     _1528395711_add_campaign_webhook_deliveriesDownSql,
+	"1528395711_add_campaign_webhook_deliveries.up.sql":                       _1528395711_add_campaign_webhook_deliveriesUpSql,
+	"1528395712_add_campaign_spec_executions.down.sql":                        _1528395712_add_campaign_spec_executionsDownSql,
+	"1528395712_add_campaign_spec_executions.up.sql":                          _1528395712_add_campaign_spec_executionsUpSql,
+	"1528395713_add_changesets_sync_error_message.down.sql":                   _1528395713_add_changesets_sync_error_messageDownSql,
+	"1528395713_add_changesets_sync_error_message.up.sql":                     _1528395713_add_changesets_sync_error_messageUpSql,
+	"1528395714_add_campaign_comments.down.sql":                               _1528395714_add_campaign_commentsDownSql,
+	"1528395714_add_campaign_comments.up.sql":                                 _1528395714_add_campaign_commentsUpSql,
+	"1528395715_add_campaigns_diff_stat.down.sql":                             _1528395715_add_campaigns_diff_statDownSql,
+	"1528395715_add_campaigns_diff_stat.up.sql":                               _1528395715_add_campaigns_diff_statUpSql,
+	"1528395716_add_changeset_external_fork_namespace.down.sql":               _1528395716_add_changeset_external_fork_namespaceDownSql,
+	"1528395716_add_changeset_external_fork_namespace.up.sql":                 _1528395716_add_changeset_external_fork_namespaceUpSql,
+	"1528395717_add_user_credentials.down.sql":                                _1528395717_add_user_credentialsDownSql,
+	"1528395717_add_user_credentials.up.sql":                                  _1528395717_add_user_credentialsUpSql,
+	"1528395718_add_campaigns_deleted_at.down.sql":                            _1528395718_add_campaigns_deleted_atDownSql,
+	"1528395718_add_campaigns_deleted_at.up.sql":                              _1528395718_add_campaigns_deleted_atUpSql,
+	"1528395719_add_campaign_permission_grants.down.sql":                      _1528395719_add_campaign_permission_grantsDownSql,
+	"1528395719_add_campaign_permission_grants.up.sql":                        _1528395719_add_campaign_permission_grantsUpSql,
+	"1528395720_add_campaign_auto_rebase.down.sql":                            _1528395720_add_campaign_auto_rebaseDownSql,
+	"1528395720_add_campaign_auto_rebase.up.sql":                              _1528395720_add_campaign_auto_rebaseUpSql,
+	"1528395721_add_changeset_external_url_state.down.sql":                    _1528395721_add_changeset_external_url_stateDownSql,
+	"1528395721_add_changeset_external_url_state.up.sql":                      _1528395721_add_changeset_external_url_stateUpSql,
+	"1528395722_add_changesets_failure_code.down.sql":                         _1528395722_add_changesets_failure_codeDownSql,
+	"1528395722_add_changesets_failure_code.up.sql":                           _1528395722_add_changesets_failure_codeUpSql,
+	"1528395723_add_campaigns_update_propagation.down.sql":                    _1528395723_add_campaigns_update_propagationDownSql,
+	"1528395723_add_campaigns_update_propagation.up.sql":                      _1528395723_add_campaigns_update_propagationUpSql,
+	"1528395724_add_campaigns_paused_at.down.sql":                             _1528395724_add_campaigns_paused_atDownSql,
+	"1528395724_add_campaigns_paused_at.up.sql":                               _1528395724_add_campaigns_paused_atUpSql,
+	"1528395725_add_changesets_skipped_reason.down.sql":                       _1528395725_add_changesets_skipped_reasonDownSql,
+	"1528395725_add_changesets_skipped_reason.up.sql":                         _1528395725_add_changesets_skipped_reasonUpSql,
+	"1528395726_add_changesets_archived_at.down.sql":                          _1528395726_add_changesets_archived_atDownSql,
+	"1528395726_add_changesets_archived_at.up.sql":                            _1528395726_add_changesets_archived_atUpSql,
+	"1528395727_add_campaigns_keyset_indexes.down.sql":                        _1528395727_add_campaigns_keyset_indexesDownSql,
+	"1528395727_add_campaigns_keyset_indexes.up.sql":                          _1528395727_add_campaigns_keyset_indexesUpSql,
+	"1528395728_add_campaigns_name_trgm_index.down.sql":                       _1528395728_add_campaigns_name_trgm_indexDownSql,
+	"1528395728_add_campaigns_name_trgm_index.up.sql":                         _1528395728_add_campaigns_name_trgm_indexUpSql,
+	"1528395729_add_changesets_failed_index.down.sql":                         _1528395729_add_changesets_failed_indexDownSql,
+	"1528395729_add_changesets_failed_index.up.sql":                           _1528395729_add_changesets_failed_indexUpSql,
+	"1528395730_add_changeset_jobs.down.sql":                                  _1528395730_add_changeset_jobsDownSql,
+	"1528395730_add_changeset_jobs.up.sql":                                    _1528395730_add_changeset_jobsUpSql,
+	"1528395731_add_campaigns_last_viewed_at.down.sql":                        _1528395731_add_campaigns_last_viewed_atDownSql,
+	"1528395731_add_campaigns_last_viewed_at.up.sql":                          _1528395731_add_campaigns_last_viewed_atUpSql,
+	"1528395732_add_campaign_changeset_counts.down.sql":                       _1528395732_add_campaign_changeset_countsDownSql,
+	"1528395732_add_campaign_changeset_counts.up.sql":                         _1528395732_add_campaign_changeset_countsUpSql,
+	"1528395733_add_campaigns_namespace_name_index.down.sql":                  _1528395733_add_campaigns_namespace_name_indexDownSql,
+	"1528395733_add_campaigns_namespace_name_index.up.sql":                    _1528395733_add_campaigns_namespace_name_indexUpSql,
+	"1528395734_add_changeset_num_failures.down.sql":                          _1528395734_add_changeset_num_failuresDownSql,
+	"1528395734_add_changeset_num_failures.up.sql":                            _1528395734_add_changeset_num_failuresUpSql,
+	"1528395735_add_campaign_specs_pinned_at.down.sql":                        _1528395735_add_campaign_specs_pinned_atDownSql,
+	"1528395735_add_campaign_specs_pinned_at.up.sql":                          _1528395735_add_campaign_specs_pinned_atUpSql,
+	"1528395736_add_campaign_spec_expiration_runs.down.sql":                   _1528395736_add_campaign_spec_expiration_runsDownSql,
+	"1528395736_add_campaign_spec_expiration_runs.up.sql":                     _1528395736_add_campaign_spec_expiration_runsUpSql,
+	"1528395737_add_campaign_repo_changeset_counts.down.sql":                  _1528395737_add_campaign_repo_changeset_countsDownSql,
+	"1528395737_add_campaign_repo_changeset_counts.up.sql":                    _1528395737_add_campaign_repo_changeset_countsUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -2551,6 +4179,80 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395699_campaign_remove_branch.up.sql":                                {_1528395699_campaign_remove_branchUpSql, map[string]*bintree{}},
 	"1528395700_add_apply_data_to_campaign.down.sql":                          {_1528395700_add_apply_data_to_campaignDownSql, map[string]*bintree{}},
 	"1528395700_add_apply_data_to_campaign.up.sql":                            {_1528395700_add_apply_data_to_campaignUpSql, map[string]*bintree{}},
+	"1528395701_add_campaign_activities.down.sql":                             {_1528395701_add_campaign_activitiesDownSql, map[string]*bintree{}},
+	"1528395701_add_campaign_activities.up.sql":                               {_1528395701_add_campaign_activitiesUpSql, map[string]*bintree{}},
+	"1528395702_add_changesets_custom_metadata.down.sql":                      {_1528395702_add_changesets_custom_metadataDownSql, map[string]*bintree{}},
+	"1528395702_add_changesets_custom_metadata.up.sql":                        {_1528395702_add_changesets_custom_metadataUpSql, map[string]*bintree{}},
+	"1528395703_add_failure_class.down.sql":                                   {_1528395703_add_failure_classDownSql, map[string]*bintree{}},
+	"1528395703_add_failure_class.up.sql":                                     {_1528395703_add_failure_classUpSql, map[string]*bintree{}},
+	"1528395704_add_changeset_wait_reason.down.sql":                           {_1528395704_add_changeset_wait_reasonDownSql, map[string]*bintree{}},
+	"1528395704_add_changeset_wait_reason.up.sql":                             {_1528395704_add_changeset_wait_reasonUpSql, map[string]*bintree{}},
+	"1528395705_add_campaign_auto_merge.down.sql":                             {_1528395705_add_campaign_auto_mergeDownSql, map[string]*bintree{}},
+	"1528395705_add_campaign_auto_merge.up.sql":                               {_1528395705_add_campaign_auto_mergeUpSql, map[string]*bintree{}},
+	"1528395706_add_changeset_diff_stat_jobs.down.sql":                        {_1528395706_add_changeset_diff_stat_jobsDownSql, map[string]*bintree{}},
+	"1528395706_add_changeset_diff_stat_jobs.up.sql":                          {_1528395706_add_changeset_diff_stat_jobsUpSql, map[string]*bintree{}},
+	"1528395707_add_campaign_templates.down.sql":                              {_1528395707_add_campaign_templatesDownSql, map[string]*bintree{}},
+	"1528395707_add_campaign_templates.up.sql":                                {_1528395707_add_campaign_templatesUpSql, map[string]*bintree{}},
+	"1528395708_add_campaign_reapply_schedules.down.sql":                      {_1528395708_add_campaign_reapply_schedulesDownSql, map[string]*bintree{}},
+	"1528395708_add_campaign_reapply_schedules.up.sql":                        {_1528395708_add_campaign_reapply_schedulesUpSql, map[string]*bintree{}},
+	"1528395709_add_campaign_visibility.down.sql":                             {_1528395709_add_campaign_visibilityDownSql, map[string]*bintree{}},
+	"1528395709_add_campaign_visibility.up.sql":                               {_1528395709_add_campaign_visibilityUpSql, map[string]*bintree{}},
+	"1528395710_add_campaign_notifications.down.sql":                          {_1528395710_add_campaign_notificationsDownSql, map[string]*bintree{}},
+	"1528395710_add_campaign_notifications.up.sql":                            {_1528395710_add_campaign_notificationsUpSql, map[string]*bintree{}},
+	"1528395711_add_campaign_webhook_deliveries.down.sql":                     {_1528395711_add_campaign_webhook_deliveriesDownSql, map[string]*bintree{}},
+	"1528395711_add_campaign_webhook_deliveries.up.sql":                       {_1528395711_add_campaign_webhook_deliveriesUpSql, map[string]*bintree{}},
+	"1528395712_add_campaign_spec_executions.down.sql":                        {_1528395712_add_campaign_spec_executionsDownSql, map[string]*bintree{}},
+	"1528395712_add_campaign_spec_executions.up.sql":                          {_1528395712_add_campaign_spec_executionsUpSql, map[string]*bintree{}},
+	"1528395713_add_changesets_sync_error_message.down.sql":                   {_1528395713_add_changesets_sync_error_messageDownSql, map[string]*bintree{}},
+	"1528395713_add_changesets_sync_error_message.up.sql":                     {_1528395713_add_changesets_sync_error_messageUpSql, map[string]*bintree{}},
+	"1528395714_add_campaign_comments.down.sql":                               {_1528395714_add_campaign_commentsDownSql, map[string]*bintree{}},
+	"1528395714_add_campaign_comments.up.sql":                                 {_1528395714_add_campaign_commentsUpSql, map[string]*bintree{}},
+	"1528395715_add_campaigns_diff_stat.down.sql":                             {_1528395715_add_campaigns_diff_statDownSql, map[string]*bintree{}},
+	"1528395715_add_campaigns_diff_stat.up.sql":                               {_1528395715_add_campaigns_diff_statUpSql, map[string]*bintree{}},
+	"1528395716_add_changeset_external_fork_namespace.down.sql":               {_1528395716_add_changeset_external_fork_namespaceDownSql, map[string]*bintree{}},
+	"1528395716_add_changeset_external_fork_namespace.up.sql":                 {_1528395716_add_changeset_external_fork_namespaceUpSql, map[string]*bintree{}},
+	"1528395717_add_user_credentials.down.sql":                                {_1528395717_add_user_credentialsDownSql, map[string]*bintree{}},
+	"1528395717_add_user_credentials.up.sql":                                  {_1528395717_add_user_credentialsUpSql, map[string]*bintree{}},
+	"1528395718_add_campaigns_deleted_at.down.sql":                            {_1528395718_add_campaigns_deleted_atDownSql, map[string]*bintree{}},
+	"1528395718_add_campaigns_deleted_at.up.sql":                              {_1528395718_add_campaigns_deleted_atUpSql, map[string]*bintree{}},
+	"1528395719_add_campaign_permission_grants.down.sql":                      {_1528395719_add_campaign_permission_grantsDownSql, map[string]*bintree{}},
+	"1528395719_add_campaign_permission_grants.up.sql":                        {_1528395719_add_campaign_permission_grantsUpSql, map[string]*bintree{}},
+	"1528395720_add_campaign_auto_rebase.down.sql":                            {_1528395720_add_campaign_auto_rebaseDownSql, map[string]*bintree{}},
+	"1528395720_add_campaign_auto_rebase.up.sql":                              {_1528395720_add_campaign_auto_rebaseUpSql, map[string]*bintree{}},
+	"1528395721_add_changeset_external_url_state.down.sql":                    {_1528395721_add_changeset_external_url_stateDownSql, map[string]*bintree{}},
+	"1528395721_add_changeset_external_url_state.up.sql":                      {_1528395721_add_changeset_external_url_stateUpSql, map[string]*bintree{}},
+	"1528395722_add_changesets_failure_code.down.sql":                         {_1528395722_add_changesets_failure_codeDownSql, map[string]*bintree{}},
+	"1528395722_add_changesets_failure_code.up.sql":                           {_1528395722_add_changesets_failure_codeUpSql, map[string]*bintree{}},
+	"1528395723_add_campaigns_update_propagation.down.sql":                    {_1528395723_add_campaigns_update_propagationDownSql, map[string]*bintree{}},
+	"1528395723_add_campaigns_update_propagation.up.sql":                      {_1528395723_add_campaigns_update_propagationUpSql, map[string]*bintree{}},
+	"1528395724_add_campaigns_paused_at.down.sql":                             {_1528395724_add_campaigns_paused_atDownSql, map[string]*bintree{}},
+	"1528395724_add_campaigns_paused_at.up.sql":                               {_1528395724_add_campaigns_paused_atUpSql, map[string]*bintree{}},
+	"1528395725_add_changesets_skipped_reason.down.sql":                       {_1528395725_add_changesets_skipped_reasonDownSql, map[string]*bintree{}},
+	"1528395725_add_changesets_skipped_reason.up.sql":                         {_1528395725_add_changesets_skipped_reasonUpSql, map[string]*bintree{}},
+	"1528395726_add_changesets_archived_at.down.sql":                          {_1528395726_add_changesets_archived_atDownSql, map[string]*bintree{}},
+	"1528395726_add_changesets_archived_at.up.sql":                            {_1528395726_add_changesets_archived_atUpSql, map[string]*bintree{}},
+	"1528395727_add_campaigns_keyset_indexes.down.sql":                        {_1528395727_add_campaigns_keyset_indexesDownSql, map[string]*bintree{}},
+	"1528395727_add_campaigns_keyset_indexes.up.sql":                          {_1528395727_add_campaigns_keyset_indexesUpSql, map[string]*bintree{}},
+	"1528395728_add_campaigns_name_trgm_index.down.sql":                       {_1528395728_add_campaigns_name_trgm_indexDownSql, map[string]*bintree{}},
+	"1528395728_add_campaigns_name_trgm_index.up.sql":                         {_1528395728_add_campaigns_name_trgm_indexUpSql, map[string]*bintree{}},
+	"1528395729_add_changesets_failed_index.down.sql":                         {_1528395729_add_changesets_failed_indexDownSql, map[string]*bintree{}},
+	"1528395729_add_changesets_failed_index.up.sql":                           {_1528395729_add_changesets_failed_indexUpSql, map[string]*bintree{}},
+	"1528395730_add_changeset_jobs.down.sql":                                  {_1528395730_add_changeset_jobsDownSql, map[string]*bintree{}},
+	"1528395730_add_changeset_jobs.up.sql":                                    {_1528395730_add_changeset_jobsUpSql, map[string]*bintree{}},
+	"1528395731_add_campaigns_last_viewed_at.down.sql":                        {_1528395731_add_campaigns_last_viewed_atDownSql, map[string]*bintree{}},
+	"1528395731_add_campaigns_last_viewed_at.up.sql":                          {_1528395731_add_campaigns_last_viewed_atUpSql, map[string]*bintree{}},
+	"1528395732_add_campaign_changeset_counts.down.sql":                       {_1528395732_add_campaign_changeset_countsDownSql, map[string]*bintree{}},
+	"1528395732_add_campaign_changeset_counts.up.sql":                         {_1528395732_add_campaign_changeset_countsUpSql, map[string]*bintree{}},
+	"1528395733_add_campaigns_namespace_name_index.down.sql":                  {_1528395733_add_campaigns_namespace_name_indexDownSql, map[string]*bintree{}},
+	"1528395733_add_campaigns_namespace_name_index.up.sql":                    {_1528395733_add_campaigns_namespace_name_indexUpSql, map[string]*bintree{}},
+	"1528395734_add_changeset_num_failures.down.sql":                          {_1528395734_add_changeset_num_failuresDownSql, map[string]*bintree{}},
+	"1528395734_add_changeset_num_failures.up.sql":                            {_1528395734_add_changeset_num_failuresUpSql, map[string]*bintree{}},
+	"1528395735_add_campaign_specs_pinned_at.down.sql":                        {_1528395735_add_campaign_specs_pinned_atDownSql, map[string]*bintree{}},
+	"1528395735_add_campaign_specs_pinned_at.up.sql":                          {_1528395735_add_campaign_specs_pinned_atUpSql, map[string]*bintree{}},
+	"1528395736_add_campaign_spec_expiration_runs.down.sql":                   {_1528395736_add_campaign_spec_expiration_runsDownSql, map[string]*bintree{}},
+	"1528395736_add_campaign_spec_expiration_runs.up.sql":                     {_1528395736_add_campaign_spec_expiration_runsUpSql, map[string]*bintree{}},
+	"1528395737_add_campaign_repo_changeset_counts.down.sql":                  {_1528395737_add_campaign_repo_changeset_countsDownSql, map[string]*bintree{}},
+	"1528395737_add_campaign_repo_changeset_counts.up.sql":                    {_1528395737_add_campaign_repo_changeset_countsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
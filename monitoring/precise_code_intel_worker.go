@@ -42,6 +42,18 @@ func PreciseCodeIntelWorker() *Container {
 							PossibleSolutions: "none",
 						},
 					},
+					{
+						{
+							Name:              "upload_process_errors_by_class",
+							Description:       "upload process errors every 5m by failure class",
+							Query:             `sum by (class)(increase(src_workerutil_failures_total{name="precise_code_intel_upload_worker"}[5m]))`,
+							DataMayNotExist:   true,
+							Warning:           Alert{GreaterOrEqual: 20},
+							PanelOptions:      PanelOptions().LegendFormat("{{class}}"),
+							Owner:             ObservableOwnerCodeIntel,
+							PossibleSolutions: "Check the failure messages of recently errored uploads. `USER_CONFIG` errors usually indicate malformed uploads, while `INTERNAL` errors indicate a problem with the worker itself.",
+						},
+					},
 					{
 						{
 							Name:        "99th_percentile_store_duration",
@@ -14,6 +14,23 @@ func RepoUpdater() *Container {
 					},
 				},
 			},
+			{
+				Title: "Campaigns",
+				Rows: []Row{
+					{
+						{
+							Name:              "changeset_reconciler_errors_by_class",
+							Description:       "changeset reconciler errors every 5m by failure class",
+							Query:             `sum by (class)(increase(src_workerutil_failures_total{name="campaigns_reconciler"}[5m]))`,
+							DataMayNotExist:   true,
+							Warning:           Alert{GreaterOrEqual: 20},
+							PanelOptions:      PanelOptions().LegendFormat("{{class}}"),
+							Owner:             ObservableOwnerCampaigns,
+							PossibleSolutions: "Check the errors of recently errored changesets. `AUTH` and `RATE_LIMIT` errors indicate a problem with the code host token, `USER_CONFIG` errors usually indicate invalid campaign specs, and `INTERNAL` errors indicate a problem with the reconciler itself.",
+						},
+					},
+				},
+			},
 			{
 				Title:  "Container monitoring (not available on server)",
 				Hidden: true,
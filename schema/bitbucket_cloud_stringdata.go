@@ -58,6 +58,26 @@ const BitbucketCloudSchemaJSON = `{
         "requestsPerHour": 7200
       }
     },
+    "campaignsSyncIntervals": {
+      "description": "The minimum and maximum delay between two syncs of the changesets of campaigns on this Bitbucket Cloud instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to Bitbucket Cloud. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).",
+      "title": "BitbucketCloudCampaignsSyncIntervals",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "min": {
+          "description": "The minimum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "2m",
+          "examples": ["10m"]
+        },
+        "max": {
+          "description": "The maximum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "8h",
+          "examples": ["24h"]
+        }
+      }
+    },
     "username": {
       "description": "The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding \"appPassword\" field.",
       "type": "string"
@@ -114,6 +134,23 @@ const BitbucketCloudSchemaJSON = `{
         [{ "name": "myorg/myrepo" }, { "uuid": "{fceb73c7-cef6-4abe-956d-e471281126bc}" }],
         [{ "name": "myorg/myrepo" }, { "name": "myorg/myotherrepo" }, { "pattern": "^topsecretproject/.*" }]
       ]
+    },
+    "webhooks": {
+      "description": "An array of webhook configurations. Bitbucket Cloud doesn't sign webhook payloads, so the secret has to be passed in the \"secret\" query parameter of the webhook URL.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "title": "BitbucketCloudWebhook",
+        "required": ["secret"],
+        "additionalProperties": false,
+        "properties": {
+          "secret": {
+            "description": "The secret used to authenticate incoming webhook requests",
+            "type": "string",
+            "minLength": 1
+          }
+        }
+      }
     }
   }
 }
@@ -50,6 +50,26 @@ const BitbucketServerSchemaJSON = `{
         "requestsPerHour": 28800
       }
     },
+    "campaignsSyncIntervals": {
+      "description": "The minimum and maximum delay between two syncs of the changesets of campaigns on this Bitbucket Server instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to Bitbucket Server. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).",
+      "title": "BitbucketServerCampaignsSyncIntervals",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "min": {
+          "description": "The minimum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "2m",
+          "examples": ["10m"]
+        },
+        "max": {
+          "description": "The maximum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "8h",
+          "examples": ["24h"]
+        }
+      }
+    },
     "url": {
       "description": "URL of a Bitbucket Server instance, such as https://bitbucket.example.com.",
       "type": "string",
@@ -98,6 +118,19 @@ const BitbucketServerSchemaJSON = `{
         }
       }
     },
+    "nativeWebhooks": {
+      "title": "BitbucketServerNativeWebhooks",
+      "description": "Configuration for the native webhooks of Bitbucket Server 5.4+, which can be used instead of the Sourcegraph plugin webhooks. The webhook must be created manually in Bitbucket Server with the same secret. For details, see our docs: https://docs.sourcegraph.com/admin/external_service/bitbucket_server#webhooks",
+      "type": "object",
+      "required": ["secret"],
+      "properties": {
+        "secret": {
+          "description": "Secret for authenticating incoming webhook payloads",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
     "plugin": {
       "title": "BitbucketServerPlugin",
       "description": "Configuration for Bitbucket Server Sourcegraph plugin",
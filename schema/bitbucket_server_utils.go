@@ -15,6 +15,19 @@ func (c *BitbucketServerConnection) WebhookSecret() string {
 	}
 }
 
+// WebhookSecrets returns the secrets of the plugin and native webhooks, in
+// that order. Empty secrets are omitted.
+func (c *BitbucketServerConnection) WebhookSecrets() []string {
+	var secrets []string
+	if secret := c.WebhookSecret(); secret != "" {
+		secrets = append(secrets, secret)
+	}
+	if c != nil && c.NativeWebhooks != nil && c.NativeWebhooks.Secret != "" {
+		secrets = append(secrets, c.NativeWebhooks.Secret)
+	}
+	return secrets
+}
+
 // WebhookSyncDisabled returns true if no webhooks are configured or when webhook syncing is explicitly disabled.
 func (c *BitbucketServerConnection) WebhookSyncDisabled() bool {
 	if c == nil {
@@ -114,6 +114,29 @@ const CampaignSpecSchemaJSON = `{
         }
       }
     },
+    "publicationPreconditions": {
+      "type": "object",
+      "description": "Conditions that are checked on the server before each changeset is published. Changesets whose repository doesn't meet all of them aren't published, and the reason is reported on the changeset.",
+      "additionalProperties": false,
+      "properties": {
+        "repositoryNotArchived": {
+          "type": "boolean",
+          "description": "Skip changesets in archived repositories instead of failing their publication."
+        },
+        "baseBranchPassing": {
+          "type": "boolean",
+          "description": "Only publish changesets whose base branch has passing commit statuses on the code host. Only supported on GitHub."
+        },
+        "searchMatches": {
+          "type": "array",
+          "description": "Search queries that all need to have results in the repository, at the revision the changeset is based on.",
+          "items": {
+            "type": "string"
+          },
+          "examples": [["file:^go\\.mod$"]]
+        }
+      }
+    },
     "changesetTemplate": {
       "type": "object",
       "description": "A template describing how to create (and update) changesets with the file changes produced by the command steps.",
@@ -143,6 +166,34 @@ const CampaignSpecSchemaJSON = `{
           "type": "boolean",
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.",
           "$comment": "TODO(sqs): Come up with a way to specify that only a subset of changesets should be published. For example, making ` + "`" + `published` + "`" + ` an array with some include/exclude syntax items."
+        },
+        "customMetadata": {
+          "type": "object",
+          "description": "Arbitrary key/value metadata to attach to each changeset on Sourcegraph. It is not sent to the code host.",
+          "additionalProperties": { "type": "string" }
+        },
+        "requestCodeOwnerReviews": {
+          "type": "boolean",
+          "description": "Whether to request reviews from the owners of the changed files, as listed in the CODEOWNERS file of each repository, when the changesets are published. Only supported on GitHub."
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request reviews of the changesets from. On GitHub, teams can be given as \"org/team\".",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign the changesets to. Only supported on GitHub and GitLab.",
+          "items": { "type": "string" }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changesets. Labels that don't exist yet are created. Only supported on GitHub and GitLab.",
+          "items": { "type": "string" }
+        },
+        "milestone": {
+          "type": "string",
+          "description": "The title of the milestone to add the changesets to. The milestone is created if it doesn't exist yet. Only supported on GitHub and GitLab."
         }
       }
     }
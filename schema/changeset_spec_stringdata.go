@@ -80,6 +80,39 @@ const ChangesetSpecSchemaJSON = `{
         "published": {
           "type": "boolean",
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+        },
+        "customMetadata": {
+          "type": "object",
+          "description": "Arbitrary key/value metadata that is attached to the changeset on Sourcegraph. It is not sent to the code host.",
+          "additionalProperties": { "type": "string" },
+          "examples": [{ "ticket": "ENG-1234", "risk": "low" }]
+        },
+        "requestCodeOwnerReviews": {
+          "type": "boolean",
+          "description": "Whether to request reviews from the owners of the changed files, as listed in the CODEOWNERS file of the base repository, when the changeset is published. Only supported on GitHub."
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request reviews of the changeset from. On GitHub, teams can be given as \"org/team\".",
+          "items": { "type": "string" },
+          "examples": [["alice", "sourcegraph/campaigns"]]
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign the changeset to. Only supported on GitHub and GitLab.",
+          "items": { "type": "string" },
+          "examples": [["bob"]]
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset. Labels that don't exist yet are created. Only supported on GitHub and GitLab.",
+          "items": { "type": "string" },
+          "examples": [["automation", "dependencies"]]
+        },
+        "milestone": {
+          "type": "string",
+          "description": "The title of the milestone to add the changeset to. The milestone is created if it doesn't exist yet. Only supported on GitHub and GitLab.",
+          "examples": ["v3.21"]
         }
       },
       "required": [
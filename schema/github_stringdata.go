@@ -58,6 +58,26 @@ const GitHubSchemaJSON = `{
         "requestsPerHour": 5000
       }
     },
+    "campaignsSyncIntervals": {
+      "description": "The minimum and maximum delay between two syncs of the changesets of campaigns on this GitHub instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to GitHub. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).",
+      "title": "GitHubCampaignsSyncIntervals",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "min": {
+          "description": "The minimum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "2m",
+          "examples": ["10m"]
+        },
+        "max": {
+          "description": "The maximum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "8h",
+          "examples": ["24h"]
+        }
+      }
+    },
     "certificate": {
       "description": "TLS certificate of the GitHub Enterprise instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run ` + "`" + `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM` + "`" + `. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.",
       "type": "string",
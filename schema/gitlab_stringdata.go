@@ -52,6 +52,26 @@ const GitLabSchemaJSON = `{
         "requestsPerHour": 36000
       }
     },
+    "campaignsSyncIntervals": {
+      "description": "The minimum and maximum delay between two syncs of the changesets of campaigns on this GitLab instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to GitLab. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).",
+      "title": "GitLabCampaignsSyncIntervals",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "min": {
+          "description": "The minimum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "2m",
+          "examples": ["10m"]
+        },
+        "max": {
+          "description": "The maximum delay between two syncs of a changeset.",
+          "type": "string",
+          "default": "8h",
+          "examples": ["24h"]
+        }
+      }
+    },
     "gitURLType": {
       "description": "The type of Git URLs to use for cloning and fetching Git repositories on this GitLab instance.\n\nIf \"http\", Sourcegraph will access GitLab repositories using Git URLs of the form http(s)://gitlab.example.com/myteam/myproject.git (using https: if the GitLab instance uses HTTPS).\n\nIf \"ssh\", Sourcegraph will access GitLab repositories using Git URLs of the form git@example.gitlab.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.",
       "type": "string",
@@ -111,12 +111,22 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
 }
 
+// BitbucketCloudCampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this Bitbucket Cloud instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to Bitbucket Cloud. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+type BitbucketCloudCampaignsSyncIntervals struct {
+	// Max description: The maximum delay between two syncs of a changeset.
+	Max string `json:"max,omitempty"`
+	// Min description: The minimum delay between two syncs of a changeset.
+	Min string `json:"min,omitempty"`
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// CampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this Bitbucket Cloud instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to Bitbucket Cloud. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+	CampaignsSyncIntervals *BitbucketCloudCampaignsSyncIntervals `json:"campaignsSyncIntervals,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -143,6 +153,8 @@ type BitbucketCloudConnection struct {
 	Url string `json:"url"`
 	// Username description: The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding "appPassword" field.
 	Username string `json:"username"`
+	// Webhooks description: An array of webhook configurations. Bitbucket Cloud doesn't sign webhook payloads, so the secret has to be passed in the "secret" query parameter of the webhook URL.
+	Webhooks []*BitbucketCloudWebhook `json:"webhooks,omitempty"`
 }
 
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
@@ -152,6 +164,10 @@ type BitbucketCloudRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+type BitbucketCloudWebhook struct {
+	// Secret description: The secret used to authenticate incoming webhook requests
+	Secret string `json:"secret"`
+}
 
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server repository permissions.
 type BitbucketServerAuthorization struct {
@@ -169,10 +185,20 @@ type BitbucketServerAuthorization struct {
 	Ttl string `json:"ttl,omitempty"`
 }
 
+// BitbucketServerCampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this Bitbucket Server instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to Bitbucket Server. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+type BitbucketServerCampaignsSyncIntervals struct {
+	// Max description: The maximum delay between two syncs of a changeset.
+	Max string `json:"max,omitempty"`
+	// Min description: The minimum delay between two syncs of a changeset.
+	Min string `json:"min,omitempty"`
+}
+
 // BitbucketServerConnection description: Configuration for a connection to Bitbucket Server.
 type BitbucketServerConnection struct {
 	// Authorization description: If non-null, enforces Bitbucket Server repository permissions.
 	Authorization *BitbucketServerAuthorization `json:"authorization,omitempty"`
+	// CampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this Bitbucket Server instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to Bitbucket Server. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+	CampaignsSyncIntervals *BitbucketServerCampaignsSyncIntervals `json:"campaignsSyncIntervals,omitempty"`
 	// Certificate description: TLS certificate of the Bitbucket Server instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
 	// Exclude description: A list of repositories to never mirror from this Bitbucket Server instance. Takes precedence over "repos" and "repositoryQuery".
@@ -189,6 +215,8 @@ type BitbucketServerConnection struct {
 	GitURLType string `json:"gitURLType,omitempty"`
 	// InitialRepositoryEnablement description: Defines whether repositories from this Bitbucket Server instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Bitbucket Server repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by Bitbucket Server); site admins can still disable them explicitly, and they'll remain disabled.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// NativeWebhooks description: Configuration for the native webhooks of Bitbucket Server 5.4+, which can be used instead of the Sourcegraph plugin webhooks. The webhook must be created manually in Bitbucket Server with the same secret. For details, see our docs: https://docs.sourcegraph.com/admin/external_service/bitbucket_server#webhooks
+	NativeWebhooks *BitbucketServerNativeWebhooks `json:"nativeWebhooks,omitempty"`
 	// Password description: The password to use when authenticating to the Bitbucket Server instance. Also set the corresponding "username" field.
 	//
 	// For Bitbucket Server instances that support personal access tokens (Bitbucket Server version 5.5 and newer), it is recommended to provide a token instead (in the "token" field).
@@ -250,6 +278,12 @@ func (v *BitbucketServerIdentityProvider) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
 }
 
+// BitbucketServerNativeWebhooks description: Configuration for the native webhooks of Bitbucket Server 5.4+, which can be used instead of the Sourcegraph plugin webhooks. The webhook must be created manually in Bitbucket Server with the same secret. For details, see our docs: https://docs.sourcegraph.com/admin/external_service/bitbucket_server#webhooks
+type BitbucketServerNativeWebhooks struct {
+	// Secret description: Secret for authenticating incoming webhook payloads
+	Secret string `json:"secret"`
+}
+
 // BitbucketServerOAuth description: OAuth configuration specified when creating the Bitbucket Server Application Link with incoming authentication. Two Legged OAuth with 'ExecuteAs=admin' must be enabled as well as user impersonation.
 type BitbucketServerOAuth struct {
 	// ConsumerKey description: The OAuth consumer key specified when creating the Bitbucket Server Application Link with incoming authentication.
@@ -323,20 +357,114 @@ type CampaignSpec struct {
 	Name string `json:"name"`
 	// On description: The set of repositories (and branches) to run the campaign on, specified as a list of search queries (that match repositories) and/or specific repositories.
 	On []interface{} `json:"on,omitempty"`
+	// PublicationPreconditions description: Conditions that are checked on the server before each changeset is published. Changesets whose repository doesn't meet all of them aren't published, and the reason is reported on the changeset.
+	PublicationPreconditions *PublicationPreconditions `json:"publicationPreconditions,omitempty"`
 	// Steps description: The sequence of commands to run (for each repository branch matched in the `on` property) to produce the campaign's changes.
 	Steps []*Step `json:"steps,omitempty"`
 }
 
+// CampaignsExecutor description: Configures the server-side execution of campaign specs, in which repo-updater runs the steps of a campaign spec in Docker containers and creates the resulting changeset specs. Required by the executeCampaignSpec mutation and by campaign reapply schedules. repo-updater must have access to a Docker daemon.
+type CampaignsExecutor struct {
+	// Cpus description: The number of CPUs available to each step container (passed to `docker run --cpus`). 0 means no limit.
+	Cpus float64 `json:"cpus,omitempty"`
+	// Enabled description: Enables server-side execution of campaign specs.
+	Enabled bool `json:"enabled,omitempty"`
+	// ImageAllowlist description: If set, steps can only use the listed Docker images.
+	ImageAllowlist []string `json:"imageAllowlist,omitempty"`
+	// Memory description: The memory limit of each step container (passed to `docker run --memory`), e.g. "2g". Empty means no limit.
+	Memory string `json:"memory,omitempty"`
+	// Parallelism description: The number of repositories in which the steps of a campaign spec are run in parallel.
+	Parallelism int `json:"parallelism,omitempty"`
+}
+
+// CampaignsNamespaceRule description: A user or organization namespace. Exactly one of user and org must be set.
+type CampaignsNamespaceRule struct {
+	// MaxChangesets description: Overrides the maximum number of changesets a single campaign in this namespace may contain. Only used in the allowlist. 0 means no limit.
+	MaxChangesets *int `json:"maxChangesets,omitempty"`
+	// MaxOpenCampaigns description: Overrides the maximum number of open campaigns this namespace may contain. Only used in the allowlist. 0 means no limit.
+	MaxOpenCampaigns *int `json:"maxOpenCampaigns,omitempty"`
+	// Org description: The name of the organization namespace.
+	Org string `json:"org,omitempty"`
+	// User description: The username of the user namespace.
+	User string `json:"user,omitempty"`
+}
+
+// CampaignsNamespaces description: Restricts the user and organization namespaces in which campaigns can be created and applied, e.g. to pilot campaigns with a single team before enabling them for the whole instance. Enforced when creating and applying campaign specs.
+type CampaignsNamespaces struct {
+	// Allowlist description: If set, campaigns can only be created in the listed namespaces.
+	Allowlist []*CampaignsNamespaceRule `json:"allowlist,omitempty"`
+	// Denylist description: Campaigns cannot be created in the listed namespaces. Takes precedence over the allowlist.
+	Denylist []*CampaignsNamespaceRule `json:"denylist,omitempty"`
+	// MaxChangesets description: The maximum number of changesets a single campaign may contain, unless overridden by the allowlist entry matching the campaign's namespace. 0 means no limit.
+	MaxChangesets int `json:"maxChangesets,omitempty"`
+	// MaxOpenCampaigns description: The maximum number of open campaigns a single namespace may contain, unless overridden by the allowlist entry matching the namespace. Enforced when a campaign is created or moved into the namespace. 0 means no limit.
+	MaxOpenCampaigns int `json:"maxOpenCampaigns,omitempty"`
+}
+
+// CampaignsReconcilerConcurrency description: Limits how many changesets of campaigns are published or updated in parallel, in total and on each code host. Raise the limits to publish large campaigns faster on big instances, or lower the limit per code host if publishing triggers the abuse detection of the code host, such as GitHub's secondary rate limits. Changes apply without a restart.
+type CampaignsReconcilerConcurrency struct {
+	// PerCodeHost description: The maximum number of changesets on the same code host, such as github.com, that are processed in parallel. Defaults to total.
+	PerCodeHost int `json:"perCodeHost,omitempty"`
+	// Total description: The maximum number of changesets that are processed in parallel.
+	Total int `json:"total,omitempty"`
+}
+
+// CampaignsRolloutWindow description: A window of time in which changesets are published at a limited rate.
+type CampaignsRolloutWindow struct {
+	// Days description: The days of the week on which the window applies. Applies on every day if not set.
+	Days []string `json:"days,omitempty"`
+	// End description: The time of day in UTC at which the window ends, in 24-hour HH:MM format. Must be after start. Defaults to the end of the day.
+	End string `json:"end,omitempty"`
+	// Rate description: The maximum number of changesets published in the window, e.g. "50/hour", or "unlimited". A rate of 0 pauses publication during the window.
+	Rate string `json:"rate"`
+	// Start description: The time of day in UTC at which the window starts, in 24-hour HH:MM format. Defaults to the start of the day.
+	Start string `json:"start,omitempty"`
+}
+
+// CampaignsSpecExpiration description: Configures after how long campaign specs and changeset specs that haven't been applied yet expire and are deleted. Raise the limits if the review of campaign specs before they are applied regularly takes longer, or pin individual campaign specs so that they never expire while they are being reviewed. Campaign specs that have been applied, and the changeset specs attached to them, never expire.
+type CampaignsSpecExpiration struct {
+	// CampaignSpecHours description: The number of hours after which a campaign spec that hasn't been applied expires.
+	CampaignSpecHours int `json:"campaignSpecHours,omitempty"`
+	// ChangesetSpecHours description: The number of hours after which a changeset spec that hasn't been attached to a campaign spec, or whose campaign spec hasn't been applied, expires. It's usually lower than campaignSpecHours, because changeset specs are attached to a campaign spec right after they have been created.
+	ChangesetSpecHours int `json:"changesetSpecHours,omitempty"`
+	// DryRun description: If true, the job only logs and reports what it would delete, without deleting anything. Use it to check the effect of new expiration limits before they take effect.
+	DryRun bool `json:"dryRun,omitempty"`
+	// IntervalMinutes description: The number of minutes between two runs of the job that deletes expired campaign specs, changeset specs and deleted campaigns.
+	IntervalMinutes int `json:"intervalMinutes,omitempty"`
+}
+
+// CampaignsWebhook description: An endpoint that receives campaign and changeset lifecycle events.
+type CampaignsWebhook struct {
+	// Events description: The events sent to the endpoint. All events are sent if not set.
+	Events []string `json:"events,omitempty"`
+	// Secret description: The secret with which the payloads are signed. The hex-encoded HMAC-SHA256 of the request body is sent in the X-Sourcegraph-Signature header.
+	Secret string `json:"secret"`
+	// Url description: The URL to which events are POSTed.
+	Url string `json:"url"`
+}
+
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users to assign the changesets to. Only supported on GitHub and GitLab.
+	Assignees []string `json:"assignees,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// CustomMetadata description: Arbitrary key/value metadata to attach to each changeset on Sourcegraph. It is not sent to the code host.
+	CustomMetadata map[string]string `json:"customMetadata,omitempty"`
+	// Labels description: The labels to add to the changesets. Labels that don't exist yet are created. Only supported on GitHub and GitLab.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to add the changesets to. The milestone is created if it doesn't exist yet. Only supported on GitHub and GitLab.
+	Milestone string `json:"milestone,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published bool `json:"published"`
+	// RequestCodeOwnerReviews description: Whether to request reviews from the owners of the changed files, as listed in the CODEOWNERS file of each repository, when the changesets are published. Only supported on GitHub.
+	RequestCodeOwnerReviews bool `json:"requestCodeOwnerReviews,omitempty"`
+	// Reviewers description: The usernames of the users to request reviews of the changesets from. On GitHub, teams can be given as "org/team".
+	Reviewers []string `json:"reviewers,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -349,6 +477,22 @@ type CloneURLToRepositoryName struct {
 	To string `json:"to"`
 }
 
+// CodeIntelIndexer description: Configuration served to precise-code-intel-indexer-vm executors. Executors poll this configuration and apply changes between index jobs, without restarting. Values set here override the executor's environment and configuration file.
+type CodeIntelIndexer struct {
+	// Cpus description: The number of CPUs available to each indexer container (passed to `docker run --cpus`). 0 means no limit.
+	Cpus float64 `json:"cpus,omitempty"`
+	// Image description: The Docker image used to index repositories.
+	Image string `json:"image,omitempty"`
+	// ImageAllowlist description: If set, executors refuse to run index jobs with an image not in this list.
+	ImageAllowlist []string `json:"imageAllowlist,omitempty"`
+	// MaxContainers description: The maximum number of index jobs that may run in parallel on an executor.
+	MaxContainers int `json:"maxContainers,omitempty"`
+	// Memory description: The memory limit of each indexer container (passed to `docker run --memory`), e.g. "4g". Empty means no limit.
+	Memory string `json:"memory,omitempty"`
+	// MinContainers description: The minimum number of index jobs that may run in parallel on an executor.
+	MinContainers int `json:"minContainers,omitempty"`
+}
+
 // CustomGitFetchMapping description: Mapping from Git clone URl domain/path to git fetch command. The `domainPath` field contains the Git clone URL domain/path part. The `fetch` field contains the custom git fetch command.
 type CustomGitFetchMapping struct {
 	// DomainPath description: Git clone URL domain/path
@@ -504,10 +648,20 @@ type GitHubAuthorization struct {
 	Ttl string `json:"ttl,omitempty"`
 }
 
+// GitHubCampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this GitHub instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to GitHub. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+type GitHubCampaignsSyncIntervals struct {
+	// Max description: The maximum delay between two syncs of a changeset.
+	Max string `json:"max,omitempty"`
+	// Min description: The minimum delay between two syncs of a changeset.
+	Min string `json:"min,omitempty"`
+}
+
 // GitHubConnection description: Configuration for a connection to GitHub or GitHub Enterprise.
 type GitHubConnection struct {
 	// Authorization description: If non-null, enforces GitHub repository permissions. This requires that there is an item in the `auth.providers` field of type "github" with the same `url` field as specified in this `GitHubConnection`.
 	Authorization *GitHubAuthorization `json:"authorization,omitempty"`
+	// CampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this GitHub instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to GitHub. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+	CampaignsSyncIntervals *GitHubCampaignsSyncIntervals `json:"campaignsSyncIntervals,omitempty"`
 	// Certificate description: TLS certificate of the GitHub Enterprise instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
 	// Exclude description: A list of repositories to never mirror from this GitHub instance. Takes precedence over "orgs", "repos", and "repositoryQuery" configuration.
@@ -601,10 +755,20 @@ type GitLabAuthorization struct {
 	Ttl string `json:"ttl,omitempty"`
 }
 
+// GitLabCampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this GitLab instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to GitLab. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+type GitLabCampaignsSyncIntervals struct {
+	// Max description: The maximum delay between two syncs of a changeset.
+	Max string `json:"max,omitempty"`
+	// Min description: The minimum delay between two syncs of a changeset.
+	Min string `json:"min,omitempty"`
+}
+
 // GitLabConnection description: Configuration for a connection to GitLab (GitLab.com or GitLab self-managed).
 type GitLabConnection struct {
 	// Authorization description: If non-null, enforces GitLab repository permissions. This requires that there be an item in the `auth.providers` field of type "gitlab" with the same `url` field as specified in this `GitLabConnection`.
 	Authorization *GitLabAuthorization `json:"authorization,omitempty"`
+	// CampaignsSyncIntervals description: The minimum and maximum delay between two syncs of the changesets of campaigns on this GitLab instance. Changesets that don't change are synced less and less often, within these bounds. Increase them to make fewer requests to GitLab. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration).
+	CampaignsSyncIntervals *GitLabCampaignsSyncIntervals `json:"campaignsSyncIntervals,omitempty"`
 	// Certificate description: TLS certificate of the GitLab instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
 	// Exclude description: A list of projects to never mirror from this GitLab instance. Takes precedence over "projects" and "projectQuery" configuration. Supports excluding by name ({"name": "group/name"}) or by ID ({"id": 42}).
@@ -954,6 +1118,16 @@ type PhabricatorConnection struct {
 	// Url description: URL of a Phabricator instance, such as https://phabricator.example.com
 	Url string `json:"url,omitempty"`
 }
+
+// PublicationPreconditions description: Conditions that are checked on the server before each changeset is published. Changesets whose repository doesn't meet all of them aren't published, and the reason is reported on the changeset.
+type PublicationPreconditions struct {
+	// BaseBranchPassing description: Only publish changesets whose base branch has passing commit statuses on the code host. Only supported on GitHub.
+	BaseBranchPassing bool `json:"baseBranchPassing,omitempty"`
+	// RepositoryNotArchived description: Skip changesets in archived repositories instead of failing their publication.
+	RepositoryNotArchived bool `json:"repositoryNotArchived,omitempty"`
+	// SearchMatches description: Search queries that all need to have results in the repository, at the revision the changeset is based on.
+	SearchMatches []string `json:"searchMatches,omitempty"`
+}
 type QuickLink struct {
 	// Description description: A description for this quick link
 	Description string `json:"description,omitempty"`
@@ -1153,8 +1327,36 @@ type SiteConfiguration struct {
 	//
 	// Only available in Sourcegraph Enterprise.
 	Branding *Branding `json:"branding,omitempty"`
+	// CampaignsChangesetEventsRetentionMonths description: The number of months after which the events of merged and closed changesets of campaigns are compacted, since the table storing them grows without bound on active instances. Compaction deletes all events of a changeset that was merged or closed and hasn't changed on its code host for this long, except for the events that changed its state. Before that, the daily changeset counts shown in the burndown charts of its campaigns are stored, so that the charts stay accurate. The timelines and analytics of compacted changesets only show the remaining events. 0 disables compaction.
+	CampaignsChangesetEventsRetentionMonths int `json:"campaigns.changesetEventsRetentionMonths,omitempty"`
+	// CampaignsCodeIntelIndexOnMerge description: Controls whether a precise code intelligence index job is enqueued for the merge commit when a changeset of a campaign is merged, so that code navigation is accurate right after the changes of a campaign land. `never` disables it, `preciseRepositories` only enqueues jobs for repositories that already have precise code intelligence data, and `always` enqueues jobs for all repositories. Only supported for code hosts that report the merge commit (GitHub and Bitbucket Server).
+	CampaignsCodeIntelIndexOnMerge string `json:"campaigns.codeIntelIndexOnMerge,omitempty"`
+	// CampaignsExecutor description: Configures the server-side execution of campaign specs, in which repo-updater runs the steps of a campaign spec in Docker containers and creates the resulting changeset specs. Required by the executeCampaignSpec mutation and by campaign reapply schedules. repo-updater must have access to a Docker daemon.
+	CampaignsExecutor *CampaignsExecutor `json:"campaigns.executor,omitempty"`
+	// CampaignsNamespaces description: Restricts the user and organization namespaces in which campaigns can be created and applied, e.g. to pilot campaigns with a single team before enabling them for the whole instance. Enforced when creating and applying campaign specs.
+	CampaignsNamespaces *CampaignsNamespaces `json:"campaigns.namespaces,omitempty"`
+	// CampaignsOrgMembersCanAdminister description: Gives all members of an organization admin rights for the campaigns in the organization's namespace, so that they can update, close and delete them. Organizations don't distinguish admins from members, so this applies to all members. If disabled, only site admins and the author of a campaign have admin rights for it.
+	CampaignsOrgMembersCanAdminister bool `json:"campaigns.orgMembersCanAdminister,omitempty"`
+	// CampaignsPublicationBudget description: The percentage of each code host's API rate limit that the publication of campaign changesets may use, so that publishing large campaigns doesn't exhaust the rate limit and break repository syncing. The rate limit is the one configured in the code host connection, or the code host's default. Changesets that exceed the budget are published once it has been replenished.
+	CampaignsPublicationBudget int `json:"campaigns.publicationBudget,omitempty"`
+	// CampaignsPushToFork description: Publishes the changesets of campaigns from a fork of their repository in the namespace of the code host user whose token is configured for the code host connection, instead of pushing branches to the repository itself. Use this for code hosts where that user lacks push access to the repositories. Forks are created as needed. Only supported for GitHub; changesets on other code hosts are published from their repository. Changesets that are already published keep being updated where they were published.
+	CampaignsPushToFork bool `json:"campaigns.pushToFork,omitempty"`
 	// CampaignsReadAccessEnabled description: Enables read-only access to campaigns for non-site-admin users. This is a setting for the experimental campaigns feature. These will only have an effect when campaigns is enabled with `{"experimentalFeatures": {"automation": "enabled"}}`.
 	CampaignsReadAccessEnabled *bool `json:"campaigns.readAccess.enabled,omitempty"`
+	// CampaignsReadReplicaDataSource description: The connection string or URI of a Postgres read replica of the main database (e.g. "postgres://sourcegraph@replica.example.com:5432/sourcegraph?sslmode=disable"). If set, the frontend runs the read-only queries that list and count campaigns, changesets and changeset events, and that compute campaign statistics and burndown charts, against the replica, to take load off the main database on large instances. Writes and queries in transactions always run against the main database. Results of queries against the replica can lag behind recent changes by the replication delay. Changes require a restart of the frontend.
+	CampaignsReadReplicaDataSource string `json:"campaigns.readReplicaDataSource,omitempty"`
+	// CampaignsReconcilerConcurrency description: Limits how many changesets of campaigns are published or updated in parallel, in total and on each code host. Raise the limits to publish large campaigns faster on big instances, or lower the limit per code host if publishing triggers the abuse detection of the code host, such as GitHub's secondary rate limits. Changes apply without a restart.
+	CampaignsReconcilerConcurrency *CampaignsReconcilerConcurrency `json:"campaigns.reconcilerConcurrency,omitempty"`
+	// CampaignsReconcilerMaxAttempts description: The number of times the reconciler attempts to publish or update a changeset of a campaign before it gives up and marks the changeset as errored, when processing it keeps failing with a transient or unknown error. Failed attempts are retried automatically after a backoff that starts at 1 minute and doubles with every consecutive failure, up to 1 hour. Changesets that exceed the code host's rate limit are retried until the rate limit is replenished, and errors that require user action, such as invalid credentials or merge conflicts, are never retried automatically. Errored changesets can still be retried manually.
+	CampaignsReconcilerMaxAttempts int `json:"campaigns.reconcilerMaxAttempts,omitempty"`
+	// CampaignsRolloutWindows description: Configures when and how fast the changesets of campaigns are published on code hosts, to avoid overwhelming code hosts and reviewers. At any time, the first window that matches the current day and time applies. Outside of all windows, no changesets are published. If not set, changesets are published as fast as possible at any time. Only the creation of changesets is affected; updates to published changesets aren't delayed.
+	CampaignsRolloutWindows []*CampaignsRolloutWindow `json:"campaigns.rolloutWindows,omitempty"`
+	// CampaignsSpecExpiration description: Configures after how long campaign specs and changeset specs that haven't been applied yet expire and are deleted. Raise the limits if the review of campaign specs before they are applied regularly takes longer, or pin individual campaign specs so that they never expire while they are being reviewed. Campaign specs that have been applied, and the changeset specs attached to them, never expire.
+	CampaignsSpecExpiration *CampaignsSpecExpiration `json:"campaigns.specExpiration,omitempty"`
+	// CampaignsWebhooks description: Endpoints that receive a JSON payload, signed with the endpoint's secret, whenever a campaign is applied or closed and whenever a changeset of a campaign is published or changes its state on the code host.
+	CampaignsWebhooks []*CampaignsWebhook `json:"campaigns.webhooks,omitempty"`
+	// CodeIntelIndexer description: Configuration served to precise-code-intel-indexer-vm executors. Executors poll this configuration and apply changes between index jobs, without restarting. Values set here override the executor's environment and configuration file.
+	CodeIntelIndexer *CodeIntelIndexer `json:"codeIntel.indexer,omitempty"`
 	// CorsOrigin description: Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.
 	CorsOrigin string `json:"corsOrigin,omitempty"`
 	// DebugSearchSymbolsParallelism description: (debug) controls the amount of symbol search parallelism. Defaults to 20. It is not recommended to change this outside of debugging scenarios. This option will be removed in a future version.
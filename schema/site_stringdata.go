@@ -264,6 +264,214 @@ const SiteSchemaJSON = `{
       "!go": { "pointer": true },
       "group": "Campaigns"
     },
+    "campaigns.namespaces": {
+      "description": "Restricts the user and organization namespaces in which campaigns can be created and applied, e.g. to pilot campaigns with a single team before enabling them for the whole instance. Enforced when creating and applying campaign specs.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "allowlist": {
+          "description": "If set, campaigns can only be created in the listed namespaces.",
+          "type": "array",
+          "items": { "$ref": "#/definitions/CampaignsNamespaceRule" }
+        },
+        "denylist": {
+          "description": "Campaigns cannot be created in the listed namespaces. Takes precedence over the allowlist.",
+          "type": "array",
+          "items": { "$ref": "#/definitions/CampaignsNamespaceRule" }
+        },
+        "maxChangesets": {
+          "description": "The maximum number of changesets a single campaign may contain, unless overridden by the allowlist entry matching the campaign's namespace. 0 means no limit.",
+          "type": "integer",
+          "minimum": 0
+        },
+        "maxOpenCampaigns": {
+          "description": "The maximum number of open campaigns a single namespace may contain, unless overridden by the allowlist entry matching the namespace. Enforced when a campaign is created or moved into the namespace. 0 means no limit.",
+          "type": "integer",
+          "minimum": 0
+        }
+      },
+      "examples": [
+        {
+          "allowlist": [{ "org": "pilot-team", "maxChangesets": 100, "maxOpenCampaigns": 10 }, { "user": "alice" }],
+          "maxChangesets": 20,
+          "maxOpenCampaigns": 3
+        }
+      ],
+      "group": "Campaigns"
+    },
+    "campaigns.rolloutWindows": {
+      "description": "Configures when and how fast the changesets of campaigns are published on code hosts, to avoid overwhelming code hosts and reviewers. At any time, the first window that matches the current day and time applies. Outside of all windows, no changesets are published. If not set, changesets are published as fast as possible at any time. Only the creation of changesets is affected; updates to published changesets aren't delayed.",
+      "type": "array",
+      "items": { "$ref": "#/definitions/CampaignsRolloutWindow" },
+      "examples": [
+        [
+          {
+            "rate": "50/hour",
+            "days": ["monday", "tuesday", "wednesday", "thursday", "friday"],
+            "start": "09:00",
+            "end": "17:00"
+          }
+        ]
+      ],
+      "group": "Campaigns"
+    },
+    "campaigns.publicationBudget": {
+      "description": "The percentage of each code host's API rate limit that the publication of campaign changesets may use, so that publishing large campaigns doesn't exhaust the rate limit and break repository syncing. The rate limit is the one configured in the code host connection, or the code host's default. Changesets that exceed the budget are published once it has been replenished.",
+      "type": "integer",
+      "minimum": 1,
+      "maximum": 100,
+      "default": 50,
+      "group": "Campaigns"
+    },
+    "campaigns.orgMembersCanAdminister": {
+      "description": "Gives all members of an organization admin rights for the campaigns in the organization's namespace, so that they can update, close and delete them. Organizations don't distinguish admins from members, so this applies to all members. If disabled, only site admins and the author of a campaign have admin rights for it.",
+      "type": "boolean",
+      "default": false,
+      "group": "Campaigns"
+    },
+    "campaigns.pushToFork": {
+      "description": "Publishes the changesets of campaigns from a fork of their repository in the namespace of the code host user whose token is configured for the code host connection, instead of pushing branches to the repository itself. Use this for code hosts where that user lacks push access to the repositories. Forks are created as needed. Only supported for GitHub; changesets on other code hosts are published from their repository. Changesets that are already published keep being updated where they were published.",
+      "type": "boolean",
+      "default": false,
+      "group": "Campaigns"
+    },
+    "campaigns.codeIntelIndexOnMerge": {
+      "description": "Controls whether a precise code intelligence index job is enqueued for the merge commit when a changeset of a campaign is merged, so that code navigation is accurate right after the changes of a campaign land. ` + "`" + `never` + "`" + ` disables it, ` + "`" + `preciseRepositories` + "`" + ` only enqueues jobs for repositories that already have precise code intelligence data, and ` + "`" + `always` + "`" + ` enqueues jobs for all repositories. Only supported for code hosts that report the merge commit (GitHub and Bitbucket Server).",
+      "type": "string",
+      "enum": ["never", "preciseRepositories", "always"],
+      "default": "never",
+      "group": "Campaigns"
+    },
+    "campaigns.webhooks": {
+      "description": "Endpoints that receive a JSON payload, signed with the endpoint's secret, whenever a campaign is applied or closed and whenever a changeset of a campaign is published or changes its state on the code host.",
+      "type": "array",
+      "items": { "$ref": "#/definitions/CampaignsWebhook" },
+      "examples": [
+        [
+          {
+            "url": "https://deploy-bot.example.com/sourcegraph",
+            "secret": "a-long-random-secret",
+            "events": ["campaign.applied", "changeset.stateChanged"]
+          }
+        ]
+      ],
+      "group": "Campaigns"
+    },
+    "campaigns.readReplicaDataSource": {
+      "description": "The connection string or URI of a Postgres read replica of the main database (e.g. \"postgres://sourcegraph@replica.example.com:5432/sourcegraph?sslmode=disable\"). If set, the frontend runs the read-only queries that list and count campaigns, changesets and changeset events, and that compute campaign statistics and burndown charts, against the replica, to take load off the main database on large instances. Writes and queries in transactions always run against the main database. Results of queries against the replica can lag behind recent changes by the replication delay. Changes require a restart of the frontend.",
+      "type": "string",
+      "group": "Campaigns"
+    },
+    "campaigns.changesetEventsRetentionMonths": {
+      "description": "The number of months after which the events of merged and closed changesets of campaigns are compacted, since the table storing them grows without bound on active instances. Compaction deletes all events of a changeset that was merged or closed and hasn't changed on its code host for this long, except for the events that changed its state. Before that, the daily changeset counts shown in the burndown charts of its campaigns are stored, so that the charts stay accurate. The timelines and analytics of compacted changesets only show the remaining events. 0 disables compaction.",
+      "type": "integer",
+      "minimum": 0,
+      "default": 0,
+      "group": "Campaigns"
+    },
+    "campaigns.reconcilerMaxAttempts": {
+      "description": "The number of times the reconciler attempts to publish or update a changeset of a campaign before it gives up and marks the changeset as errored, when processing it keeps failing with a transient or unknown error. Failed attempts are retried automatically after a backoff that starts at 1 minute and doubles with every consecutive failure, up to 1 hour. Changesets that exceed the code host's rate limit are retried until the rate limit is replenished, and errors that require user action, such as invalid credentials or merge conflicts, are never retried automatically. Errored changesets can still be retried manually.",
+      "type": "integer",
+      "minimum": 1,
+      "default": 5,
+      "group": "Campaigns"
+    },
+    "campaigns.reconcilerConcurrency": {
+      "description": "Limits how many changesets of campaigns are published or updated in parallel, in total and on each code host. Raise the limits to publish large campaigns faster on big instances, or lower the limit per code host if publishing triggers the abuse detection of the code host, such as GitHub's secondary rate limits. Changes apply without a restart.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "total": {
+          "description": "The maximum number of changesets that are processed in parallel.",
+          "type": "integer",
+          "minimum": 1,
+          "maximum": 50,
+          "default": 5
+        },
+        "perCodeHost": {
+          "description": "The maximum number of changesets on the same code host, such as github.com, that are processed in parallel. Defaults to total.",
+          "type": "integer",
+          "minimum": 1,
+          "maximum": 50
+        }
+      },
+      "examples": [{ "total": 20, "perCodeHost": 5 }],
+      "group": "Campaigns"
+    },
+    "campaigns.specExpiration": {
+      "description": "Configures after how long campaign specs and changeset specs that haven't been applied yet expire and are deleted. Raise the limits if the review of campaign specs before they are applied regularly takes longer, or pin individual campaign specs so that they never expire while they are being reviewed. Campaign specs that have been applied, and the changeset specs attached to them, never expire.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "campaignSpecHours": {
+          "description": "The number of hours after which a campaign spec that hasn't been applied expires.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 168
+        },
+        "changesetSpecHours": {
+          "description": "The number of hours after which a changeset spec that hasn't been attached to a campaign spec, or whose campaign spec hasn't been applied, expires. It's usually lower than campaignSpecHours, because changeset specs are attached to a campaign spec right after they have been created.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 48
+        },
+        "intervalMinutes": {
+          "description": "The number of minutes between two runs of the job that deletes expired campaign specs, changeset specs and deleted campaigns.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 2
+        },
+        "dryRun": {
+          "description": "If true, the job only logs and reports what it would delete, without deleting anything. Use it to check the effect of new expiration limits before they take effect.",
+          "type": "boolean",
+          "default": false
+        }
+      },
+      "examples": [{ "campaignSpecHours": 720, "changesetSpecHours": 720 }, { "intervalMinutes": 60, "dryRun": true }],
+      "group": "Campaigns"
+    },
+    "campaigns.executor": {
+      "description": "Configures the server-side execution of campaign specs, in which repo-updater runs the steps of a campaign spec in Docker containers and creates the resulting changeset specs. Required by the executeCampaignSpec mutation and by campaign reapply schedules. repo-updater must have access to a Docker daemon.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {
+          "description": "Enables server-side execution of campaign specs.",
+          "type": "boolean",
+          "default": false
+        },
+        "parallelism": {
+          "description": "The number of repositories in which the steps of a campaign spec are run in parallel.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 1
+        },
+        "imageAllowlist": {
+          "description": "If set, steps can only use the listed Docker images.",
+          "type": "array",
+          "items": { "type": "string" }
+        },
+        "cpus": {
+          "description": "The number of CPUs available to each step container (passed to ` + "`" + `docker run --cpus` + "`" + `). 0 means no limit.",
+          "type": "number",
+          "minimum": 0
+        },
+        "memory": {
+          "description": "The memory limit of each step container (passed to ` + "`" + `docker run --memory` + "`" + `), e.g. \"2g\". Empty means no limit.",
+          "type": "string"
+        }
+      },
+      "examples": [
+        {
+          "enabled": true,
+          "parallelism": 4,
+          "imageAllowlist": ["alpine:3", "comby/comby"],
+          "cpus": 1,
+          "memory": "2g"
+        }
+      ],
+      "group": "Campaigns"
+    },
     "corsOrigin": {
       "description": "Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.",
       "type": "string",
@@ -277,6 +485,52 @@ const SiteSchemaJSON = `{
       "default": false,
       "group": "Security"
     },
+    "codeIntel.indexer": {
+      "description": "Configuration served to precise-code-intel-indexer-vm executors. Executors poll this configuration and apply changes between index jobs, without restarting. Values set here override the executor's environment and configuration file.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "minContainers": {
+          "description": "The minimum number of index jobs that may run in parallel on an executor.",
+          "type": "integer",
+          "minimum": 1
+        },
+        "maxContainers": {
+          "description": "The maximum number of index jobs that may run in parallel on an executor.",
+          "type": "integer",
+          "minimum": 1
+        },
+        "image": {
+          "description": "The Docker image used to index repositories.",
+          "type": "string",
+          "examples": ["sourcegraph/lsif-go:latest"]
+        },
+        "imageAllowlist": {
+          "description": "If set, executors refuse to run index jobs with an image not in this list.",
+          "type": "array",
+          "items": { "type": "string" }
+        },
+        "cpus": {
+          "description": "The number of CPUs available to each indexer container (passed to ` + "`" + `docker run --cpus` + "`" + `). 0 means no limit.",
+          "type": "number",
+          "minimum": 0
+        },
+        "memory": {
+          "description": "The memory limit of each indexer container (passed to ` + "`" + `docker run --memory` + "`" + `), e.g. \"4g\". Empty means no limit.",
+          "type": "string"
+        }
+      },
+      "examples": [
+        {
+          "maxContainers": 4,
+          "image": "sourcegraph/lsif-go:latest",
+          "imageAllowlist": ["sourcegraph/lsif-go:latest"],
+          "cpus": 2,
+          "memory": "4g"
+        }
+      ],
+      "group": "Misc."
+    },
     "disableNonCriticalTelemetry": {
       "description": "Disable aggregated event counts from being sent to Sourcegraph.com via pings.",
       "type": "boolean",
@@ -800,6 +1054,92 @@ const SiteSchemaJSON = `{
         }
       }
     },
+    "CampaignsNamespaceRule": {
+      "description": "A user or organization namespace. Exactly one of user and org must be set.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "user": {
+          "description": "The username of the user namespace.",
+          "type": "string"
+        },
+        "org": {
+          "description": "The name of the organization namespace.",
+          "type": "string"
+        },
+        "maxChangesets": {
+          "description": "Overrides the maximum number of changesets a single campaign in this namespace may contain. Only used in the allowlist. 0 means no limit.",
+          "type": "integer",
+          "minimum": 0,
+          "!go": { "pointer": true }
+        },
+        "maxOpenCampaigns": {
+          "description": "Overrides the maximum number of open campaigns this namespace may contain. Only used in the allowlist. 0 means no limit.",
+          "type": "integer",
+          "minimum": 0,
+          "!go": { "pointer": true }
+        }
+      },
+      "oneOf": [{ "required": ["user"] }, { "required": ["org"] }]
+    },
+    "CampaignsRolloutWindow": {
+      "description": "A window of time in which changesets are published at a limited rate.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["rate"],
+      "properties": {
+        "rate": {
+          "description": "The maximum number of changesets published in the window, e.g. \"50/hour\", or \"unlimited\". A rate of 0 pauses publication during the window.",
+          "type": "string",
+          "pattern": "^(unlimited|[0-9]+/(second|minute|hour|day))$"
+        },
+        "days": {
+          "description": "The days of the week on which the window applies. Applies on every day if not set.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": ["monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"]
+          }
+        },
+        "start": {
+          "description": "The time of day in UTC at which the window starts, in 24-hour HH:MM format. Defaults to the start of the day.",
+          "type": "string",
+          "pattern": "^([01][0-9]|2[0-3]):[0-5][0-9]$"
+        },
+        "end": {
+          "description": "The time of day in UTC at which the window ends, in 24-hour HH:MM format. Must be after start. Defaults to the end of the day.",
+          "type": "string",
+          "pattern": "^([01][0-9]|2[0-3]):[0-5][0-9]$"
+        }
+      }
+    },
+    "CampaignsWebhook": {
+      "description": "An endpoint that receives campaign and changeset lifecycle events.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["url", "secret"],
+      "properties": {
+        "url": {
+          "description": "The URL to which events are POSTed.",
+          "type": "string",
+          "format": "uri",
+          "pattern": "^https?://"
+        },
+        "secret": {
+          "description": "The secret with which the payloads are signed. The hex-encoded HMAC-SHA256 of the request body is sent in the X-Sourcegraph-Signature header.",
+          "type": "string",
+          "minLength": 1
+        },
+        "events": {
+          "description": "The events sent to the endpoint. All events are sent if not set.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": ["campaign.applied", "campaign.closed", "changeset.published", "changeset.stateChanged"]
+          }
+        }
+      }
+    },
     "BuiltinAuthProvider": {
       "description": "Configures the builtin username-password authentication provider.",
       "type": "object",
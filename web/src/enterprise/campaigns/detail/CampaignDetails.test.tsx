@@ -26,6 +26,7 @@ describe('CampaignDetails', () => {
             extensionsController={undefined as any}
             platformContext={undefined as any}
             telemetryService={NOOP_TELEMETRY_SERVICE}
+            _recordCampaignView={() => Promise.resolve()}
             _fetchCampaignById={() =>
                 of({
                     __typename: 'Campaign',
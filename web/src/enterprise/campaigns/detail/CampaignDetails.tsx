@@ -4,7 +4,7 @@ import React, { useState, useEffect, useMemo } from 'react'
 import { HeroPage } from '../../../components/HeroPage'
 import { PageTitle } from '../../../components/PageTitle'
 import { isEqual } from 'lodash'
-import { fetchCampaignById } from './backend'
+import { fetchCampaignById, recordCampaignView } from './backend'
 import { useError } from '../../../../../shared/src/util/useObservable'
 import * as H from 'history'
 import { CampaignBurndownChart } from './BurndownChart'
@@ -30,6 +30,8 @@ interface Props extends ThemeProps, ExtensionsControllerProps, PlatformContextPr
 
     /** For testing only. */
     _fetchCampaignById?: typeof fetchCampaignById
+    /** For testing only. */
+    _recordCampaignView?: typeof recordCampaignView
 }
 
 /**
@@ -44,6 +46,7 @@ export const CampaignDetails: React.FunctionComponent<Props> = ({
     platformContext,
     telemetryService,
     _fetchCampaignById = fetchCampaignById,
+    _recordCampaignView = recordCampaignView,
 }) => {
     // For errors during fetching
     const triggerError = useError()
@@ -59,6 +62,16 @@ export const CampaignDetails: React.FunctionComponent<Props> = ({
         telemetryService.logViewEvent(campaignID ? 'CampaignDetailsPage' : 'NewCampaignPage')
     }, [campaignID, telemetryService])
 
+    // Recording the view makes the backend sync the campaign's changesets right away. This is
+    // done once per page load, not on every poll of the campaign below.
+    useEffect(() => {
+        if (!campaignID) {
+            return
+        }
+        // Failing to record the view doesn't affect the page.
+        _recordCampaignView(campaignID).catch(() => undefined)
+    }, [campaignID, _recordCampaignView])
+
     useEffect(() => {
         if (!campaignID) {
             return
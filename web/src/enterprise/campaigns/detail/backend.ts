@@ -13,6 +13,8 @@ import {
     ExternalChangesetFileDiffsFields,
     SyncChangesetResult,
     SyncChangesetVariables,
+    RecordCampaignViewResult,
+    RecordCampaignViewVariables,
     Scalars,
 } from '../../../graphql-operations'
 
@@ -207,7 +209,7 @@ export async function syncChangeset(changeset: Scalars['ID']): Promise<void> {
         request: gql`
             mutation SyncChangeset($changeset: ID!) {
                 syncChangeset(changeset: $changeset) {
-                    alwaysNil
+                    id
                 }
             }
         `,
@@ -216,6 +218,20 @@ export async function syncChangeset(changeset: Scalars['ID']): Promise<void> {
     dataOrThrowErrors(result)
 }
 
+export async function recordCampaignView(campaign: Scalars['ID']): Promise<void> {
+    const result = await requestGraphQL<RecordCampaignViewResult, RecordCampaignViewVariables>({
+        request: gql`
+            mutation RecordCampaignView($campaign: ID!) {
+                recordCampaignView(campaign: $campaign) {
+                    alwaysNil
+                }
+            }
+        `,
+        variables: { campaign },
+    }).toPromise()
+    dataOrThrowErrors(result)
+}
+
 // Because thats the name in the API:
 // eslint-disable-next-line unicorn/prevent-abbreviations
 export const gitRefSpecFields = gql`